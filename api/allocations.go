@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -37,6 +38,27 @@ const (
 	AllocRestartReasonWithinPolicy = "Restart within policy"
 )
 
+const (
+	// LastRescheduleOOMKillLimit is the LastReschedule of the reschedule
+	// tracker of an allocation that isn't rescheduled because its job version
+	// was OOM-killed the max_oom_kills of its reschedule policy in a row
+	LastRescheduleOOMKillLimit = "oom kill limit"
+)
+
+const (
+	// QoSClassGuaranteed is the QoS class of allocations whose tasks are
+	// limited to the memory they reserve
+	QoSClassGuaranteed = "guaranteed"
+
+	// QoSClassBurstable is the QoS class of allocations with tasks that may
+	// use memory beyond their reservation, up to a limit
+	QoSClassBurstable = "burstable"
+
+	// QoSClassBestEffort is the QoS class of allocations with tasks without
+	// a memory limit
+	QoSClassBestEffort = "best-effort"
+)
+
 // Allocations is used to query the alloc-related endpoints.
 type Allocations struct {
 	client *Client
@@ -62,6 +84,35 @@ func (a *Allocations) PrefixList(prefix string) ([]*AllocationListStub, *QueryMe
 	return a.List(&QueryOptions{Prefix: prefix})
 }
 
+// BillingRecords lists the billing records the clients reported for
+// allocations when they terminated, in the namespace of the query options or
+// of jobID if set. A zero since or until leaves that end of the range of end
+// times open.
+func (a *Allocations) BillingRecords(jobID string, since, until time.Time, q *QueryOptions) ([]*AllocBillingRecord, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	if jobID != "" {
+		q.Params["job"] = jobID
+	}
+	if !since.IsZero() {
+		q.Params["since"] = since.Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		q.Params["until"] = until.Format(time.RFC3339)
+	}
+
+	var resp []*AllocBillingRecord
+	qm, err := a.client.query("/v1/allocations/billing-records", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Info is used to retrieve a single allocation.
 func (a *Allocations) Info(allocID string, q *QueryOptions) (*Allocation, *QueryMeta, error) {
 	var resp Allocation
@@ -123,6 +174,83 @@ func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceU
 	return &resp, err
 }
 
+// AllocStatsSample is a sample of the resource usage of an allocation polled
+// by StatsStream, or the error polling it.
+type AllocStatsSample struct {
+	Stats *AllocResourceUsage
+	Err   error
+}
+
+// StatsStream polls the stats of an allocation every interval until ctx is
+// done, and sends each new sample on the returned channel, which is closed
+// once ctx is done. Samples the client hasn't updated since the previous poll
+// are skipped. Errors polling the stats are sent with Err set, and polling
+// carries on, so consumers decide whether to give up.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) StatsStream(ctx context.Context, alloc *Allocation, interval time.Duration, q *QueryOptions) (<-chan *AllocStatsSample, error) {
+	if interval <= 0 {
+		return nil, errors.New("stats stream interval must be positive")
+	}
+	q = q.WithContext(ctx)
+
+	samplesCh := make(chan *AllocStatsSample, 1)
+	go func() {
+		defer close(samplesCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last int64
+		for {
+			stats, err := a.Stats(alloc, q)
+			var sample *AllocStatsSample
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				sample = &AllocStatsSample{Err: err}
+			case stats.Timestamp != last:
+				last = stats.Timestamp
+				sample = &AllocStatsSample{Stats: stats}
+			}
+
+			if sample != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case samplesCh <- sample:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return samplesCh, nil
+}
+
+// Processes lists the processes running in the tasks of an allocation, keyed
+// by task name. If task is set, only the processes of that task are listed.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) Processes(allocID, task string, q *QueryOptions) (map[string][]*TaskProcess, error) {
+	var resp map[string][]*TaskProcess
+	path := "/v1/client/allocation/" + allocID + "/processes"
+	if task != "" {
+		path += "?task=" + url.QueryEscape(task)
+	}
+	_, err := a.client.query(path, &resp, q)
+	return resp, err
+}
+
 // Checks gets status information for nomad service checks that exist in the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -235,6 +363,26 @@ func (a *Allocations) Signal(alloc *Allocation, q *QueryOptions, task, signal st
 	return err
 }
 
+// Capture records what the main process of a task is doing and returns the
+// path, relative to the allocation directory, of the file the output was
+// written to. Mode is one of "stack", "sigquit", or "profile", and duration
+// bounds how long stacks are sampled or profiled for.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) Capture(alloc *Allocation, q *QueryOptions, task, mode string, duration time.Duration) (string, error) {
+	req := AllocCaptureRequest{
+		Task:     task,
+		Mode:     mode,
+		Duration: duration,
+	}
+
+	var resp AllocCaptureResponse
+	_, err := a.client.putQuery("/v1/client/allocation/"+alloc.ID+"/capture", &req, &resp, q)
+	return resp.Path, err
+}
+
 // SetPauseState sets the schedule behavior of one task in the allocation.
 func (a *Allocations) SetPauseState(alloc *Allocation, q *QueryOptions, task, state string) error {
 	req := AllocPauseRequest{
@@ -293,8 +441,10 @@ type Allocation struct {
 	NextAllocation        string
 	RescheduleTracker     *RescheduleTracker
 	NetworkStatus         *AllocNetworkStatus
+	UsageSummary          *AllocUsageSummary
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	TraceParent           string
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	AllocModifyIndex      uint64
@@ -388,6 +538,30 @@ func (a *Allocation) ClientTerminalStatus() bool {
 	}
 }
 
+// QoSClass returns the QoS class of the allocation, which is the lowest class
+// of its tasks, derived from their memory reservation and memory_max.
+// Allocations of groups with shared resources are at most burstable.
+func (a *Allocation) QoSClass() string {
+	class := QoSClassGuaranteed
+	if a.AllocatedResources == nil {
+		return class
+	}
+	for _, task := range a.AllocatedResources.Tasks {
+		switch max := task.Memory.MemoryMaxMB; {
+		case max == -1:
+			return QoSClassBestEffort
+		case max != 0 && max != task.Memory.MemoryMB:
+			class = QoSClassBurstable
+		}
+	}
+	if class == QoSClassGuaranteed && a.Job != nil {
+		if tg := a.Job.LookupTaskGroup(a.TaskGroup); tg != nil && tg.SharedResources != nil {
+			class = QoSClassBurstable
+		}
+	}
+	return class
+}
+
 // AllocationListStub is used to return a subset of an allocation
 // during list operations.
 type AllocationListStub struct {
@@ -417,6 +591,65 @@ type AllocationListStub struct {
 	ModifyIndex           uint64
 	CreateTime            int64
 	ModifyTime            int64
+	Usage                 *AllocStubUsage `json:",omitempty"`
+}
+
+// AllocStubUsage is the resource usage of a running allocation as last
+// reported by its client. CPUPercent is in percent of the allocated CPU.
+type AllocStubUsage struct {
+	CPUPercent float64
+	MemoryMB   int
+	Timestamp  int64
+}
+
+// AllocUsageSummary is the resource usage of an allocation's tasks as last
+// reported by its client. CPU is in MHz. Partial is set while the stats
+// collection of a task is paused and its last sample is reported.
+type AllocUsageSummary struct {
+	CPU       int
+	MemoryMB  int
+	Timestamp int64
+	Partial   bool
+	Tasks     map[string]*TaskUsageSummary
+}
+
+// TaskUsageSummary is the resource usage of a single task in an
+// AllocUsageSummary. CPU is in MHz.
+type TaskUsageSummary struct {
+	CPU      int
+	MemoryMB int
+}
+
+// AllocBillingRecord is the cumulative resource usage of an allocation over
+// its lifetime, reported by its client when it terminated. StartTime and
+// EndTime are in Unix nanoseconds.
+type AllocBillingRecord struct {
+	AllocID       string
+	Namespace     string
+	JobID         string
+	TaskGroup     string
+	NodeID        string
+	ClientStatus  string
+	StartTime     int64
+	EndTime       int64
+	CPUSeconds    float64
+	MemoryGBHours float64
+	EgressGB      float64
+	PeakMemoryMB  int
+	Partial       bool
+	Tasks         map[string]*TaskBillingRecord
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// TaskBillingRecord is the cumulative resource usage of a task in an
+// AllocBillingRecord.
+type TaskBillingRecord struct {
+	CPUSeconds    float64
+	MemoryGBHours float64
+	EgressGB      float64
+	PeakMemoryMB  int
+	Partial       bool
 }
 
 // AllocDeploymentStatus captures the status of the allocation as part of the
@@ -452,9 +685,11 @@ type AllocatedTaskResources struct {
 }
 
 type AllocatedSharedResources struct {
-	DiskMB   int64
-	Networks []*NetworkResource
-	Ports    []PortMapping
+	DiskMB    int64
+	Networks  []*NetworkResource
+	Ports     []PortMapping
+	MemoryMB  int64
+	CpuShares int64
 }
 
 type PortMapping struct {
@@ -539,6 +774,16 @@ type AllocSignalRequest struct {
 	Signal string
 }
 
+type AllocCaptureRequest struct {
+	Task     string
+	Mode     string
+	Duration time.Duration
+}
+
+type AllocCaptureResponse struct {
+	Path string
+}
+
 type AllocPauseRequest struct {
 	Task string
 
@@ -564,6 +809,10 @@ type GenericResponse struct {
 type RescheduleTracker struct {
 	Events         []*RescheduleEvent
 	LastReschedule string
+
+	// OOMKills is the number of consecutive previous allocations of the job
+	// version of the latest event that were OOM-killed
+	OOMKills int
 }
 
 // RescheduleEvent is used to keep track of previous attempts at rescheduling an allocation
@@ -576,6 +825,13 @@ type RescheduleEvent struct {
 
 	// PrevNodeID is the node ID of the previous allocation
 	PrevNodeID string
+
+	// JobVersion is the job version of the previous allocation
+	JobVersion uint64
+
+	// OOMKilled is true if the previous allocation failed because a task was
+	// OOM-killed
+	OOMKilled bool
 }
 
 // DesiredTransition is used to mark an allocation as having a desired state
@@ -5,9 +5,13 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -472,6 +476,42 @@ func TestAllocation_ClientTerminalStatus(t *testing.T) {
 	}
 }
 
+func TestAllocation_QoSClass(t *testing.T) {
+	testutil.Parallel(t)
+
+	withTasks := func(memory ...AllocatedMemoryResources) *Allocation {
+		alloc := &Allocation{AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{},
+		}}
+		for i, m := range memory {
+			alloc.AllocatedResources.Tasks[fmt.Sprintf("task%d", i)] = &AllocatedTaskResources{Memory: m}
+		}
+		return alloc
+	}
+
+	must.Eq(t, QoSClassGuaranteed, withTasks(
+		AllocatedMemoryResources{MemoryMB: 256},
+		AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: 128},
+	).QoSClass())
+	must.Eq(t, QoSClassBurstable, withTasks(
+		AllocatedMemoryResources{MemoryMB: 256},
+		AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: 512},
+	).QoSClass())
+	must.Eq(t, QoSClassBestEffort, withTasks(
+		AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: 512},
+		AllocatedMemoryResources{MemoryMB: 128, MemoryMaxMB: -1},
+	).QoSClass())
+
+	// Allocations of groups with shared resources are at most burstable
+	shared := withTasks(AllocatedMemoryResources{MemoryMB: 256})
+	shared.TaskGroup = "web"
+	shared.Job = &Job{TaskGroups: []*TaskGroup{{
+		Name:            pointerOf("web"),
+		SharedResources: &SharedResources{},
+	}}}
+	must.Eq(t, QoSClassBurstable, shared.QoSClass())
+}
+
 func TestAllocations_ShouldMigrate(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -484,3 +524,49 @@ func TestAllocations_Services(t *testing.T) {
 	t.Skip("needs to be implemented")
 	// TODO(jrasell) add tests once registration process is in place.
 }
+
+func TestAllocations_StatsStream(t *testing.T) {
+	testutil.Parallel(t)
+
+	// The client updates the stats every other poll, and fails the third
+	var polls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		n := polls.Add(1)
+		if n == 3 {
+			http.Error(rw, "unreachable", http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(&AllocResourceUsage{Timestamp: (n + 1) / 2})
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{Address: server.URL})
+	must.NoError(t, err)
+
+	_, err = c.Allocations().StatsStream(context.Background(), &Allocation{ID: "a"}, 0, nil)
+	must.ErrorContains(t, err, "interval must be positive")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	samples, err := c.Allocations().StatsStream(ctx, &Allocation{ID: "a"}, time.Millisecond, nil)
+	must.NoError(t, err)
+
+	next := func() *AllocStatsSample {
+		select {
+		case sample := <-samples:
+			return sample
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a sample")
+			return nil
+		}
+	}
+	must.Eq(t, 1, next().Stats.Timestamp)
+	must.Error(t, next().Err)
+	must.Eq(t, 2, next().Stats.Timestamp)
+	must.Eq(t, 3, next().Stats.Timestamp)
+
+	// The stream is closed once the context is done
+	cancel()
+	for range samples {
+	}
+}
@@ -122,6 +122,7 @@ type Evaluation struct {
 	AnnotatePlan         bool
 	QueuedAllocations    map[string]int
 	SnapshotIndex        uint64
+	TraceParent          string
 	CreateIndex          uint64
 	ModifyIndex          uint64
 	CreateTime           int64
@@ -347,6 +347,42 @@ func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*Al
 	return resp, qm, nil
 }
 
+// Utilization is used to roll up the resource usage the clients reported for
+// the running allocations of a job.
+func (j *Jobs) Utilization(jobID string, q *QueryOptions) (*JobUtilization, *QueryMeta, error) {
+	var resp JobUtilization
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/utilization", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// UsageHistory is used to read the usage samples the clients reported for the
+// allocations of a job, including those clients backfilled after being
+// disconnected. A zero since or until leaves that end of the range open.
+func (j *Jobs) UsageHistory(jobID string, since, until time.Time, q *QueryOptions) ([]*UsageSample, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	if !since.IsZero() {
+		q.Params["since"] = since.Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		q.Params["until"] = until.Format(time.RFC3339)
+	}
+
+	var resp []*UsageSample
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/usage-history", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Deployments is used to query the deployments associated with the given job
 // ID.
 func (j *Jobs) Deployments(jobID string, all bool, q *QueryOptions) ([]*Deployment, *QueryMeta, error) {
@@ -520,6 +556,32 @@ func (j *Jobs) PlanOpts(job *Job, opts *PlanOptions, q *WriteOptions) (*JobPlanR
 	return &resp, wm, nil
 }
 
+// WhatIf simulates the placement of the job, such as one with modified
+// resources, against the current state of the cluster without registering it,
+// and projects the usage of the nodes it would be placed on.
+func (j *Jobs) WhatIf(job *Job, opts *PlanOptions, q *WriteOptions) (*JobWhatIfResponse, *WriteMeta, error) {
+	if job == nil {
+		return nil, nil, errors.New("must pass non-nil job")
+	}
+	if job.ID == nil {
+		return nil, nil, errors.New("job is missing ID")
+	}
+
+	req := &JobWhatIfRequest{
+		Job: job,
+	}
+	if opts != nil {
+		req.PolicyOverride = opts.PolicyOverride
+	}
+
+	var resp JobWhatIfResponse
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(*job.ID)+"/what-if", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta, error) {
 	var resp JobSummary
 	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/summary", &resp, q)
@@ -607,6 +669,9 @@ type UpdateStrategy struct {
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+
+	CanaryMaxCPURatio    *float64 `mapstructure:"canary_max_cpu_ratio" hcl:"canary_max_cpu_ratio,optional"`
+	CanaryMaxMemoryRatio *float64 `mapstructure:"canary_max_memory_ratio" hcl:"canary_max_memory_ratio,optional"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -668,6 +733,14 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.CanaryMaxCPURatio != nil {
+		copy.CanaryMaxCPURatio = pointerOf(*u.CanaryMaxCPURatio)
+	}
+
+	if u.CanaryMaxMemoryRatio != nil {
+		copy.CanaryMaxMemoryRatio = pointerOf(*u.CanaryMaxMemoryRatio)
+	}
+
 	return copy
 }
 
@@ -711,6 +784,14 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.CanaryMaxCPURatio != nil {
+		u.CanaryMaxCPURatio = pointerOf(*o.CanaryMaxCPURatio)
+	}
+
+	if o.CanaryMaxMemoryRatio != nil {
+		u.CanaryMaxMemoryRatio = pointerOf(*o.CanaryMaxMemoryRatio)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -795,6 +876,14 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.CanaryMaxCPURatio != nil && *u.CanaryMaxCPURatio != 0 {
+		return false
+	}
+
+	if u.CanaryMaxMemoryRatio != nil && *u.CanaryMaxMemoryRatio != 0 {
+		return false
+	}
+
 	return true
 }
 
@@ -1286,6 +1375,54 @@ type TaskGroupSummary struct {
 	Unknown  int
 }
 
+// JobUtilization is a rollup of the resource usage the clients reported for
+// the running allocations of a job, keyed by task group.
+type JobUtilization struct {
+	Namespace  string
+	JobID      string
+	TaskGroups map[string]*TaskGroupUtilization
+}
+
+// TaskGroupUtilization is the utilization of the tasks of a task group across
+// its running allocations, keyed by task name.
+type TaskGroupUtilization struct {
+	Allocations int
+	Tasks       map[string]*TaskUtilization
+}
+
+// TaskUtilization compares the usage of a task against its reserved
+// resources. CPU is in MHz.
+type TaskUtilization struct {
+	ReservedCPU         int
+	ReservedMemoryMB    int
+	ReservedMemoryMaxMB int
+	CPU                 UsagePercentiles
+	MemoryMB            UsagePercentiles
+}
+
+// UsagePercentiles is the distribution of a usage value across allocations.
+type UsagePercentiles struct {
+	P50 int
+	P95 int
+	Max int
+}
+
+// UsageSample is the usage summary of an allocation at a point in time. CPU
+// is in MHz and Timestamp in Unix nanoseconds.
+type UsageSample struct {
+	Namespace   string
+	JobID       string
+	TaskGroup   string
+	AllocID     string
+	NodeID      string
+	CPU         int
+	MemoryMB    int
+	Timestamp   int64
+	Tasks       map[string]*TaskUsageSummary
+	Backfilled  bool
+	CreateIndex uint64
+}
+
 // JobListStub is used to return a subset of information about
 // jobs during list operations.
 type JobListStub struct {
@@ -1528,11 +1665,58 @@ type JobPlanResponse struct {
 	FailedTGAllocs     map[string]*AllocationMetric
 	NextPeriodicLaunch time.Time
 
+	// NodeImpacts is the observed and projected usage of the nodes the plan
+	// places allocations on or stops allocations from, highest projected
+	// pressure first.
+	NodeImpacts []*NodeWhatIfImpact
+
 	// Warnings contains any warnings about the given job. These may include
 	// deprecation warnings.
 	Warnings string
 }
 
+type JobWhatIfRequest struct {
+	Job            *Job
+	PolicyOverride bool
+	WriteRequest
+}
+
+type JobWhatIfResponse struct {
+	// Feasible is whether every allocation of the job could be placed
+	Feasible       bool
+	FailedTGAllocs map[string]*AllocationMetric
+
+	// Nodes is the projected impact on each node the job would be placed on
+	// or stopped from, ordered from the highest projected pressure
+	Nodes []*NodeWhatIfImpact
+
+	Warnings string
+}
+
+// NodeWhatIfImpact is the observed and projected usage of a node's
+// allocations. Allocations that haven't reported usage, including new
+// placements, are counted at their reservation.
+type NodeWhatIfImpact struct {
+	NodeID     string
+	NodeName   string
+	Datacenter string
+	Placed     int
+	Stopped    int
+
+	CPUCapacity      int64
+	MemoryCapacityMB int64
+
+	CurrentCPU        int64
+	CurrentMemoryMB   int64
+	ProjectedCPU      int64
+	ProjectedMemoryMB int64
+
+	// CurrentPressure and ProjectedPressure are the percentage of the
+	// busier of the CPU and memory capacity of the node that is used
+	CurrentPressure   float64
+	ProjectedPressure float64
+}
+
 type JobDiff struct {
 	Type       string
 	ID         string
@@ -1593,6 +1777,7 @@ type JobDispatchRequest struct {
 	Payload          []byte
 	Meta             map[string]string
 	IdPrefixTemplate string
+	TraceParent      string
 }
 
 type JobDispatchResponse struct {
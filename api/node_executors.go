@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// ExecutorsResponse contains the executors running on a Node that none of its
+// tasks own, and the task handles that reattach to executors that are gone.
+type ExecutorsResponse struct {
+	Orphans      []*OrphanedExecutor
+	StaleHandles []*StaleTaskHandle
+}
+
+// OrphanedExecutor is an executor process left running for a task the Node no
+// longer runs.
+type OrphanedExecutor struct {
+	Pid         int
+	AllocID     string
+	Task        string
+	Cgroup      string
+	Pids        []int
+	MemoryBytes uint64
+	Terminated  bool
+}
+
+// StaleTaskHandle is the persisted handle of a dead task that still refers to
+// the plugin process that ran it.
+type StaleTaskHandle struct {
+	AllocID string
+	Task    string
+	Pid     int
+	Cleared bool
+}
+
+// NodeExecutors is a client for finding and cleaning up the orphaned
+// executors of a Node.
+type NodeExecutors struct {
+	client *Client
+}
+
+// Executors returns a NodeExecutors client.
+func (n *Nodes) Executors() *NodeExecutors {
+	return &NodeExecutors{client: n.client}
+}
+
+// List the orphaned executors and stale task handles of a Node. If nodeID is
+// empty then those of the Node receiving the request are returned.
+func (n *NodeExecutors) List(nodeID string, q *QueryOptions) (*ExecutorsResponse, error) {
+	var out ExecutorsResponse
+	_, err := n.client.query("/v1/client/executors", &out, nodeQuery(nodeID, q))
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GarbageCollect terminates the orphaned executors of a Node along with their
+// tasks, and clears its stale task handles. The response lists what was
+// cleaned up.
+func (n *NodeExecutors) GarbageCollect(nodeID string, q *QueryOptions) (*ExecutorsResponse, error) {
+	var out ExecutorsResponse
+	_, err := n.client.putQuery("/v1/client/executors/gc", nil, &out, nodeQuery(nodeID, q))
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// nodeQuery returns a copy of the query options targeting the Node.
+func nodeQuery(nodeID string, q *QueryOptions) *QueryOptions {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	qo := *q
+	qo.Params = make(map[string]string, len(q.Params)+1)
+	for k, v := range q.Params {
+		qo.Params[k] = v
+	}
+	if nodeID != "" {
+		qo.Params["node_id"] = nodeID
+	}
+	return &qo
+}
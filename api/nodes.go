@@ -783,10 +783,13 @@ type HostStats struct {
 	Memory           *HostMemoryStats
 	CPU              []*HostCPUStats
 	DiskStats        []*HostDiskStats
+	DiskIOStats      []*HostDiskIOStats
 	AllocDirStats    *HostDiskStats
 	DeviceStats      []*DeviceGroupStats
 	Uptime           uint64
 	CPUTicksConsumed float64
+	Power            *HostPowerStats
+	SoftIRQs         []*HostSoftIRQStats
 }
 
 type HostMemoryStats struct {
@@ -797,10 +800,12 @@ type HostMemoryStats struct {
 }
 
 type HostCPUStats struct {
-	CPU    string
-	User   float64
-	System float64
-	Idle   float64
+	CPU     string
+	User    float64
+	System  float64
+	Idle    float64
+	Irq     float64
+	SoftIrq float64
 }
 
 type HostDiskStats struct {
@@ -813,6 +818,44 @@ type HostDiskStats struct {
 	InodesUsedPercent float64
 }
 
+// HostDiskIOStats contains the latency and saturation of a block device since
+// the previous sample.
+type HostDiskIOStats struct {
+	Device             string
+	ReadsPerSecond     float64
+	WritesPerSecond    float64
+	AwaitMillis        float64
+	UtilizationPercent float64
+	QueueDepth         float64
+	InFlight           uint64
+}
+
+// HostPowerStats contains the power consumption and CPU temperature of the
+// host. It is only set on hardware exposing energy counters or temperature
+// sensors.
+type HostPowerStats struct {
+	Zones        []*HostPowerZoneStats
+	Watts        float64
+	Temperatures []*HostTemperatureStats
+}
+
+type HostPowerZoneStats struct {
+	Name  string
+	Watts float64
+}
+
+type HostTemperatureStats struct {
+	Sensor  string
+	Celsius float64
+}
+
+// HostSoftIRQStats is the rate per second at which the host handles software
+// interrupts of a type, such as net_rx or block.
+type HostSoftIRQStats struct {
+	Type string
+	Rate float64
+}
+
 // DeviceGroupStats contains statistics for each device of a particular
 // device group, identified by the vendor, type and name of the device.
 type DeviceGroupStats struct {
@@ -219,6 +219,7 @@ type PreemptionConfig struct {
 	SysBatchSchedulerEnabled bool
 	BatchSchedulerEnabled    bool
 	ServiceSchedulerEnabled  bool
+	IgnoreObservedUsage      bool
 }
 
 // SchedulerGetConfiguration is used to query the current Scheduler configuration.
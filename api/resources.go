@@ -21,6 +21,10 @@ type Resources struct {
 	NUMA        *NUMAResource      `hcl:"numa,block"`
 	SecretsMB   *int               `mapstructure:"secrets" hcl:"secrets,optional"`
 
+	// ResizeInPlace updates the cpu and memory of the running allocations of
+	// the task in place when they change, instead of replacing them.
+	ResizeInPlace *bool `mapstructure:"resize_in_place" hcl:"resize_in_place,optional"`
+
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
@@ -108,6 +112,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != nil {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.ResizeInPlace != nil {
+		r.ResizeInPlace = other.ResizeInPlace
+	}
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
@@ -120,6 +127,11 @@ type NUMAResource struct {
 	// Devices is the subset of devices requested by the task that must share
 	// the same numa node, along with the tasks reserved cpu cores.
 	Devices []string `hcl:"devices,optional"`
+
+	// MemoryPolicy is the memory policy applied to the task over the numa
+	// nodes of its reserved cpu cores. One of "bind", "interleave",
+	// "preferred".
+	MemoryPolicy string `hcl:"memory_policy,optional"`
 }
 
 func (n *NUMAResource) Copy() *NUMAResource {
@@ -127,8 +139,9 @@ func (n *NUMAResource) Copy() *NUMAResource {
 		return nil
 	}
 	return &NUMAResource{
-		Affinity: n.Affinity,
-		Devices:  slices.Clone(n.Devices),
+		Affinity:     n.Affinity,
+		Devices:      slices.Clone(n.Devices),
+		MemoryPolicy: n.MemoryPolicy,
 	}
 }
 
@@ -243,6 +256,10 @@ type NodeDevice struct {
 	// Locality stores HW locality information for the node to optionally be
 	// used when making placement decisions.
 	Locality *NodeDeviceLocality
+
+	// ParentID is the ID of the device this device is a partition of, such as
+	// the GPU of an NVIDIA MIG slice.
+	ParentID string
 }
 
 // Attribute is used to describe the value of an attribute, optionally
@@ -3,6 +3,8 @@
 
 package api
 
+import "net/url"
+
 const (
 	// ScalingPolicyTypeHorizontal indicates a policy that does horizontal scaling.
 	ScalingPolicyTypeHorizontal = "horizontal"
@@ -125,3 +127,32 @@ type ScalingEvent struct {
 	Time          uint64
 	CreateIndex   uint64
 }
+
+// ScalingMetric is the value of a scaling policy query computed by Nomad from
+// the resource usage of a task group's running allocations.
+type ScalingMetric struct {
+	// Query is the query the metric was computed for.
+	Query string
+
+	// Value is the average utilization of the allocations, in percent of
+	// their allocated resources.
+	Value float64
+
+	// Timestamp is the time the metric was computed, in Unix nanoseconds.
+	Timestamp int64
+
+	// Allocations is the number of allocations the value averages.
+	Allocations int
+}
+
+// GetMetric computes a scaling metric from the resource usage of a task
+// group's running allocations. Queries have the format used by the Nomad APM
+// plugin of the Nomad Autoscaler, such as "taskgroup_avg_cpu/<group>/<job>".
+func (s *Scaling) GetMetric(query string, q *QueryOptions) (*ScalingMetric, *QueryMeta, error) {
+	var metric ScalingMetric
+	qm, err := s.client.query("/v1/client/scaling/metric?query="+url.QueryEscape(query), &metric, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &metric, qm, nil
+}
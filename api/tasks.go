@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -40,6 +42,7 @@ type MemoryStats struct {
 	MaxUsage       uint64
 	KernelUsage    uint64
 	KernelMaxUsage uint64
+	Shared         uint64
 	Measured       []string
 }
 
@@ -51,14 +54,91 @@ type CpuStats struct {
 	ThrottledPeriods uint64
 	ThrottledTime    uint64
 	Percent          float64
+	AllocatedPercent float64
+	NodePercent      float64
+	TotalCpuSeconds  float64
+	BorrowedTicks    float64
+	Cores            []*CoreStats
 	Measured         []string
 }
 
+// CoreStats holds the utilization of a core reserved for a task
+type CoreStats struct {
+	ID      uint16
+	Percent float64
+}
+
+// PerfStats holds hardware performance counter stats
+type PerfStats struct {
+	Cycles               uint64
+	Instructions         uint64
+	CacheReferences      uint64
+	CacheMisses          uint64
+	BranchInstructions   uint64
+	BranchMisses         uint64
+	InstructionsPerCycle float64
+	CacheMissRate        float64
+	BranchMissRate       float64
+}
+
+// EnergyStats holds the estimated power drawn on behalf of a task
+type EnergyStats struct {
+	Watts float64
+}
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*DeviceGroupStats
+	PerfStats   *PerfStats
+	EnergyStats *EnergyStats
+	Gauges      map[string]*Gauge
+	Egress      map[string]*EgressStats
+	Resctrl     *ResctrlStats
+	DiskIO      *DiskIOStats
+	Pressure    *PressureStats
+
+	// Unreadable is set on the usage of a process of a task whose stats
+	// could not be read in time. Its stats are then those it was last read
+	// with, or unset if it never was.
+	Unreadable bool
+}
+
+// ResctrlStats is the cache occupancy and memory bandwidth of the resctrl
+// class a task is assigned to, shared by the tasks of the class
+type ResctrlStats struct {
+	LLCOccupancy  uint64
+	MBMTotalBytes uint64
+	MBMLocalBytes uint64
+}
+
+// EgressStats is the traffic a task sent to a class of destinations
+type EgressStats struct {
+	Bytes   uint64
+	Packets uint64
+}
+
+// DiskIOStats is the block I/O a task did since it started
+type DiskIOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+}
+
+// PressureStats is the percentage of time some of a task's processes stalled
+// waiting on CPU, memory or I/O over the last minute
+type PressureStats struct {
+	CPU    float64
+	Memory float64
+	IO     float64
+}
+
+// Gauge is a driver-specific measurement of a task's resource usage
+type Gauge struct {
+	Value float64
+	Unit  string
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -67,6 +147,160 @@ type TaskResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Timestamp     int64
 	Pids          map[string]*ResourceUsage
+	CgroupPath    string
+	CgroupID      uint64
+	ExecutorPID   int
+	Capabilities  *StatsCapabilities
+	Limits        *ResourceLimits
+
+	// Allocated are the resources allocated to the task when the sample was
+	// collected.
+	Allocated *TaskAllocatedResources
+
+	// KilledResource is the resource the usage of which the task is being
+	// killed for, such as "memory" or "cpu", if any.
+	KilledResource string
+
+	// Sequence increases by one with each sample of the task, and
+	// CollectionDuration is the time elapsed since the previous sample as
+	// measured by a monotonic clock.
+	Sequence           uint64
+	CollectionDuration time.Duration
+
+	// Window is only set when requesting stats with the window parameter.
+	Window *TaskUsageWindow
+
+	// Logs is the volume of output the task wrote to its log files. It is
+	// nil if the client doesn't collect the task's logs.
+	Logs *TaskLogStats
+
+	// Stale is set on the sample the client restored from before it was
+	// restarted, until the task driver sends a new one.
+	Stale bool
+}
+
+// PidUsage is the resource usage of a process of a task.
+type PidUsage struct {
+	Pid   int
+	Usage *ResourceUsage
+}
+
+// PidUsages returns the resource usage of the processes of the task from its
+// Pids map, sorted by PID. Processes whose key isn't a PID are skipped.
+func (t *TaskResourceUsage) PidUsages() []*PidUsage {
+	usages := make([]*PidUsage, 0, len(t.Pids))
+	for key, usage := range t.Pids {
+		pid, err := strconv.Atoi(key)
+		if err != nil || usage == nil {
+			continue
+		}
+		usages = append(usages, &PidUsage{Pid: pid, Usage: usage})
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Pid < usages[j].Pid })
+	return usages
+}
+
+// TopPidsByMemory returns the n processes of the task using the most memory,
+// by RSS, from the most. All processes are returned if n isn't positive.
+func (t *TaskResourceUsage) TopPidsByMemory(n int) []*PidUsage {
+	return topPids(t.PidUsages(), n, func(ru *ResourceUsage) float64 {
+		if ru.MemoryStats == nil {
+			return 0
+		}
+		return float64(ru.MemoryStats.RSS)
+	})
+}
+
+// TopPidsByCPU returns the n processes of the task using the most CPU, by
+// percent, from the most. All processes are returned if n isn't positive.
+func (t *TaskResourceUsage) TopPidsByCPU(n int) []*PidUsage {
+	return topPids(t.PidUsages(), n, func(ru *ResourceUsage) float64 {
+		if ru.CpuStats == nil {
+			return 0
+		}
+		return ru.CpuStats.Percent
+	})
+}
+
+func topPids(usages []*PidUsage, n int, value func(*ResourceUsage) float64) []*PidUsage {
+	sort.SliceStable(usages, func(i, j int) bool {
+		return value(usages[i].Usage) > value(usages[j].Usage)
+	})
+	if n > 0 && n < len(usages) {
+		usages = usages[:n]
+	}
+	return usages
+}
+
+// TaskLogStats is the volume of output a task wrote to its stdout and stderr.
+type TaskLogStats struct {
+	Stdout *LogStreamStats
+	Stderr *LogStreamStats
+}
+
+// LogStreamStats counts the output written to a log file since the task
+// started, and its rates since the previous sample. Dropped counts the output
+// dropped for exceeding the task's log rate limits.
+type LogStreamStats struct {
+	Bytes          uint64
+	Lines          uint64
+	BytesPerSecond float64
+	LinesPerSecond float64
+	DroppedBytes   uint64
+	DroppedLines   uint64
+}
+
+// TaskUsageWindow summarizes the usage of a task over the recent samples its
+// client retains. CPU is in MHz. Partial is set if stats collection paused
+// within the window.
+type TaskUsageWindow struct {
+	Start    int64
+	End      int64
+	Samples  int
+	Partial  bool
+	CPU      UsagePercentiles
+	MemoryMB UsagePercentiles
+}
+
+// TaskAllocatedResources are the CPU and memory allocated to a task. CPU is
+// in MHz, and MemoryMaxMB is zero for tasks without memory oversubscription.
+type TaskAllocatedResources struct {
+	CpuShares     int64
+	ReservedCores []uint16
+	MemoryMB      int64
+	MemoryMaxMB   int64
+}
+
+// ResourceLimits are the limits enforced on a task's resource usage. MemoryMax
+// is in bytes and CpuMax in CPUs; zero means not limited.
+type ResourceLimits struct {
+	MemoryMax uint64
+	CpuMax    float64
+}
+
+// StatsCapabilities describes which optional stats the task driver reports.
+// It is nil when the driver has not declared its stats.
+type StatsCapabilities struct {
+	Network  bool
+	DiskIO   bool
+	Devices  bool
+	Pressure bool
+	Gauges   []string
+
+	// Schema describes the driver-specific gauges the driver reports
+	Schema []*StatSchema
+
+	// MemoryMeasured and CpuMeasured are the memory and CPU stats the driver
+	// measures, if it declared them
+	MemoryMeasured []string
+	CpuMeasured    []string
+}
+
+// StatSchema describes a driver-specific gauge.
+type StatSchema struct {
+	Name        string
+	Unit        string
+	Description string
 }
 
 // AllocResourceUsage holds the aggregated task resource usage of the
@@ -75,6 +309,87 @@ type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
 	Timestamp     int64
+
+	// Networks holds the counters of each interface in the network namespace
+	// of the allocation, keyed by interface name. It is only set for
+	// allocations with a network namespace of their own.
+	Networks map[string]*NetworkInterfaceStats
+
+	// Connections counts the connections of the network namespace of the
+	// allocation. It is set along with Networks.
+	Connections *NetworkConnectionStats
+
+	// Disk is the usage of the alloc dir, which holds the ephemeral disk of
+	// the allocation. It is measured less often than the other stats.
+	Disk *AllocDiskStats
+}
+
+// AllocDiskStats is the disk space and inodes used by the alloc dir of an
+// allocation, along with the free inodes of the filesystem shared with the
+// other allocations of the client.
+type AllocDiskStats struct {
+	UsedBytes                   uint64
+	Inodes                      uint64
+	FilesystemInodesFree        uint64
+	FilesystemInodesUsedPercent float64
+	Timestamp                   int64
+}
+
+// NetworkConnectionStats counts the connections of a network namespace.
+type NetworkConnectionStats struct {
+	Established    uint64
+	Conntrack      uint64
+	ConntrackLimit uint64
+	Measured       []string
+}
+
+// NetworkTotals returns the counters of all the interfaces in the network
+// namespace of the allocation summed, or nil if it has none.
+func (a *AllocResourceUsage) NetworkTotals() *NetworkInterfaceStats {
+	if len(a.Networks) == 0 {
+		return nil
+	}
+	total := new(NetworkInterfaceStats)
+	for _, iface := range a.Networks {
+		if iface != nil {
+			total.Add(iface)
+		}
+	}
+	return total
+}
+
+// NetworkInterfaceStats holds the counters of a network interface since it
+// was created.
+type NetworkInterfaceStats struct {
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+}
+
+// Add adds the counters of other to the counters.
+func (n *NetworkInterfaceStats) Add(other *NetworkInterfaceStats) {
+	n.RxBytes += other.RxBytes
+	n.RxPackets += other.RxPackets
+	n.RxErrors += other.RxErrors
+	n.RxDropped += other.RxDropped
+	n.TxBytes += other.TxBytes
+	n.TxPackets += other.TxPackets
+	n.TxErrors += other.TxErrors
+	n.TxDropped += other.TxDropped
+}
+
+// TaskProcess describes a process running as part of a task.
+type TaskProcess struct {
+	Pid       int
+	Ppid      int
+	Cmdline   []string
+	State     string
+	StartTime int64
 }
 
 // AllocCheckStatus contains the current status of a nomad service discovery check.
@@ -103,6 +418,35 @@ type RestartPolicy struct {
 	Delay           *time.Duration `hcl:"delay,optional"`
 	Mode            *string        `hcl:"mode,optional"`
 	RenderTemplates *bool          `mapstructure:"render_templates" hcl:"render_templates,optional"`
+
+	ResourceTrigger *RestartResourceTrigger `mapstructure:"resource_trigger" hcl:"resource_trigger,block"`
+
+	// OOMMemoryStepMB is the memory in MB by which the memory limit of a task
+	// is raised each time it restarts after an OOM kill, up to its memory_max.
+	OOMMemoryStepMB *int `mapstructure:"oom_memory_step" hcl:"oom_memory_step,optional"`
+}
+
+// RestartResourceTrigger restarts a task when its resource usage stays beyond
+// any of its thresholds for Duration.
+type RestartResourceTrigger struct {
+	MemoryRSSMB       *int           `mapstructure:"memory_rss_mb" hcl:"memory_rss_mb,optional"`
+	CPUThrottledRatio *float64       `mapstructure:"cpu_throttled_ratio" hcl:"cpu_throttled_ratio,optional"`
+	Duration          *time.Duration `hcl:"duration,optional"`
+}
+
+func (r *RestartResourceTrigger) Canonicalize() {
+	if r == nil {
+		return
+	}
+	if r.MemoryRSSMB == nil {
+		r.MemoryRSSMB = pointerOf(0)
+	}
+	if r.CPUThrottledRatio == nil {
+		r.CPUThrottledRatio = pointerOf(0.0)
+	}
+	if r.Duration == nil {
+		r.Duration = pointerOf(time.Duration(0))
+	}
 }
 
 func (r *RestartPolicy) Merge(rp *RestartPolicy) {
@@ -121,6 +465,12 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	if rp.RenderTemplates != nil {
 		r.RenderTemplates = rp.RenderTemplates
 	}
+	if rp.OOMMemoryStepMB != nil {
+		r.OOMMemoryStepMB = rp.OOMMemoryStepMB
+	}
+	if rp.ResourceTrigger != nil {
+		r.ResourceTrigger = rp.ResourceTrigger
+	}
 }
 
 // Disconnect strategy defines how both clients and server should behave in case of
@@ -175,6 +525,10 @@ type ReschedulePolicy struct {
 
 	// Unlimited allows rescheduling attempts until they succeed
 	Unlimited *bool `mapstructure:"unlimited" hcl:"unlimited,optional"`
+
+	// MaxOOMKills is the number of times the allocations of a job version can
+	// be OOM-killed in a row before they are no longer rescheduled.
+	MaxOOMKills *int `mapstructure:"max_oom_kills" hcl:"max_oom_kills,optional"`
 }
 
 func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
@@ -199,6 +553,9 @@ func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
 	if rp.Unlimited != nil {
 		r.Unlimited = rp.Unlimited
 	}
+	if rp.MaxOOMKills != nil {
+		r.MaxOOMKills = rp.MaxOOMKills
+	}
 }
 
 func (r *ReschedulePolicy) Canonicalize(jobType string) {
@@ -514,13 +871,23 @@ type TaskGroup struct {
 	// Deprecated: StopAfterClientDisconnect is deprecated in Nomad 1.8. Use Disconnect.StopOnClientAfter instead.
 	StopAfterClientDisconnect *time.Duration `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.LostAfter
-	MaxClientDisconnect *time.Duration `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
-	Scaling             *ScalingPolicy `hcl:"scaling,block"`
-	Consul              *Consul        `hcl:"consul,block"`
+	MaxClientDisconnect *time.Duration   `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
+	Scaling             *ScalingPolicy   `hcl:"scaling,block"`
+	Consul              *Consul          `hcl:"consul,block"`
+	SharedResources     *SharedResources `hcl:"shared_resources,block"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.Replace
 	PreventRescheduleOnLost *bool `hcl:"prevent_reschedule_on_lost,optional"`
 }
 
+// SharedResources configures an alloc-level cgroup the tasks of a group run
+// under, so they share headroom within the sum of their resources plus an
+// overhead.
+type SharedResources struct {
+	MemoryOverheadMB int `mapstructure:"memory_overhead" hcl:"memory_overhead,optional"`
+	CPUOverhead      int `mapstructure:"cpu_overhead" hcl:"cpu_overhead,optional"`
+	CPUCap           int `mapstructure:"cpu_cap" hcl:"cpu_cap,optional"`
+}
+
 // NewTaskGroup creates a new TaskGroup.
 func NewTaskGroup(name string, count int) *TaskGroup {
 	return &TaskGroup{
@@ -621,6 +988,7 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		defaultRestartPolicy.Merge(g.RestartPolicy)
 	}
 	g.RestartPolicy = defaultRestartPolicy
+	g.RestartPolicy.ResourceTrigger.Canonicalize()
 
 	for _, t := range g.Tasks {
 		t.Canonicalize(g, job)
@@ -721,6 +1089,20 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	// MaxBytesPerSecond and MaxLinesPerSecond limit the rate of output of
+	// each of the task's streams written to its log files. Unset or zero is
+	// unlimited.
+	MaxBytesPerSecond *int `mapstructure:"max_bytes_per_second" hcl:"max_bytes_per_second,optional"`
+	MaxLinesPerSecond *int `mapstructure:"max_lines_per_second" hcl:"max_lines_per_second,optional"`
+
+	// RateLimitMode is what happens to output over the rate limits: "drop"
+	// (the default) drops it, "block" blocks the task's writes.
+	RateLimitMode *string `mapstructure:"rate_limit_mode" hcl:"rate_limit_mode,optional"`
+
+	// MultilinePattern is a regular expression matching the first line of
+	// each event of a task that writes multiline events, such as stack traces.
+	MultilinePattern *string `mapstructure:"multiline_pattern" hcl:"multiline_pattern,optional"`
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -764,12 +1146,29 @@ func (l *TaskLifecycle) Empty() bool {
 	return l == nil || (l.Hook == "")
 }
 
+// TaskWarmup delays a task being considered healthy until its CPU usage stays
+// below CPUPercent for Duration.
+type TaskWarmup struct {
+	CPUPercent *float64       `mapstructure:"cpu_percent" hcl:"cpu_percent,optional"`
+	Duration   *time.Duration `hcl:"duration,optional"`
+}
+
+func (w *TaskWarmup) Canonicalize() {
+	if w.CPUPercent == nil {
+		w.CPUPercent = pointerOf(10.0)
+	}
+	if w.Duration == nil {
+		w.Duration = pointerOf(10 * time.Second)
+	}
+}
+
 // Task is a single process in a task group.
 type Task struct {
 	Name            string                 `hcl:"name,label"`
 	Driver          string                 `hcl:"driver,optional"`
 	User            string                 `hcl:"user,optional"`
 	Lifecycle       *TaskLifecycle         `hcl:"lifecycle,block"`
+	Warmup          *TaskWarmup            `hcl:"warmup,block"`
 	Config          map[string]interface{} `hcl:"config,block"`
 	Constraints     []*Constraint          `hcl:"constraint,block"`
 	Affinities      []*Affinity            `hcl:"affinity,block"`
@@ -843,6 +1242,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	if t.Lifecycle.Empty() {
 		t.Lifecycle = nil
 	}
+	if t.Warmup != nil {
+		t.Warmup.Canonicalize()
+	}
 	if t.CSIPluginConfig != nil {
 		t.CSIPluginConfig.Canonicalize()
 	}
@@ -853,6 +1255,7 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 		*tgrp = *tg.RestartPolicy
 		tgrp.Merge(t.RestartPolicy)
 		t.RestartPolicy = tgrp
+		t.RestartPolicy.ResourceTrigger.Canonicalize()
 	}
 }
 
@@ -1112,6 +1515,20 @@ type TaskState struct {
 	FinishedAt  time.Time
 	Events      []*TaskEvent
 
+	// CgroupPath, CgroupID, and ExecutorPID identify the cgroup and executor
+	// process of the task as last reported by its driver.
+	CgroupPath  string
+	CgroupID    uint64
+	ExecutorPID int
+
+	// ExitReason classifies why the task last exited, as one of the
+	// TaskExitReason constants.
+	ExitReason string
+
+	// WarmedUpAt is the time the task finished warming up, for tasks with a
+	// warmup block.
+	WarmedUpAt time.Time
+
 	// Experimental -  TaskHandle is based on drivers.TaskHandle and used
 	// by remote task drivers to migrate task handles between allocations.
 	TaskHandle *TaskHandle
@@ -1145,6 +1562,20 @@ const (
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskWarmedUp               = "Warmed Up"
+	TaskResized                = "Resized"
+	TaskUsageAnomaly           = "Usage Anomaly"
+)
+
+// The reasons a task may exit for, reported in TaskState.ExitReason and the
+// "exit_reason" detail of Terminated task events.
+const (
+	TaskExitReasonCompleted       = "completed"
+	TaskExitReasonNonZeroExit     = "nonzero-exit"
+	TaskExitReasonSignaled        = "signaled"
+	TaskExitReasonOOMKilled       = "oom-killed"
+	TaskExitReasonExecutorFailure = "executor-failure"
+	TaskExitReasonNodeShutdown    = "node-shutdown"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -364,6 +364,15 @@ func TestTask_Canonicalize_TaskLifecycle(t *testing.T) {
 	}
 }
 
+func TestTask_Canonicalize_Warmup(t *testing.T) {
+	testutil.Parallel(t)
+
+	task := &Task{Warmup: &TaskWarmup{}}
+	task.Canonicalize(&TaskGroup{Name: pointerOf("foo")}, &Job{ID: pointerOf("test")})
+	must.Eq(t, 10.0, *task.Warmup.CPUPercent)
+	must.Eq(t, 10*time.Second, *task.Warmup.Duration)
+}
+
 func TestTask_Template_WaitConfig_Canonicalize_and_Copy(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -926,3 +935,45 @@ func TestTaskGroup_Canonicalize_Consul(t *testing.T) {
 		must.Eq(t, "ns2", tg.Consul.Namespace)
 	})
 }
+
+func TestTaskResourceUsage_PidUsages(t *testing.T) {
+	testutil.Parallel(t)
+
+	usage := &TaskResourceUsage{Pids: map[string]*ResourceUsage{
+		"42": {
+			MemoryStats: &MemoryStats{RSS: 100},
+			CpuStats:    &CpuStats{Percent: 80},
+		},
+		"7": {
+			MemoryStats: &MemoryStats{RSS: 300},
+			CpuStats:    &CpuStats{Percent: 10},
+		},
+		"1000": {
+			MemoryStats: &MemoryStats{RSS: 200},
+		},
+		"not-a-pid": {},
+	}}
+
+	pids := func(usages []*PidUsage) []int {
+		var pids []int
+		for _, u := range usages {
+			pids = append(pids, u.Pid)
+		}
+		return pids
+	}
+	must.Eq(t, []int{7, 42, 1000}, pids(usage.PidUsages()))
+	must.Eq(t, []int{7, 1000}, pids(usage.TopPidsByMemory(2)))
+	must.Eq(t, []int{42, 7, 1000}, pids(usage.TopPidsByCPU(0)))
+}
+
+func TestAllocResourceUsage_NetworkTotals(t *testing.T) {
+	testutil.Parallel(t)
+
+	must.Nil(t, (&AllocResourceUsage{}).NetworkTotals())
+
+	usage := &AllocResourceUsage{Networks: map[string]*NetworkInterfaceStats{
+		"eth0": {RxBytes: 100, TxBytes: 10, RxDropped: 1},
+		"lo":   {RxBytes: 5, TxBytes: 5},
+	}}
+	must.Eq(t, &NetworkInterfaceStats{RxBytes: 105, TxBytes: 15, RxDropped: 1}, usage.NetworkTotals())
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// authorizeAudited resolves the token of a request to an endpoint exposing
+// what the tasks or the agent run, checks it with allow, and audits the
+// access.
+func (c *Client) authorizeAudited(endpoint, token string, level hclog.Level,
+	allow func(*acl.ACL) bool, args ...any) error {
+
+	aclObj, ident, err := c.resolveTokenAndACL(token)
+	if err == nil && !allow(aclObj) {
+		err = structs.ErrPermissionDenied
+	}
+	c.auditAccess(endpoint, ident, err, level, args...)
+	return err
+}
+
+// auditAccess records an access to an endpoint of the client in the audit
+// log of the client, with the identity that requested it and whether it was
+// allowed. Denied accesses are recorded too, since they may be probes. The
+// level only marks the entry, since the audit log isn't filtered by the log
+// level of the agent.
+func (c *Client) auditAccess(endpoint string, ident *structs.AuthenticatedIdentity, err error,
+	level hclog.Level, args ...any) {
+
+	logArgs := []any{"endpoint", endpoint, "identity", ident.String(), "allowed", err == nil}
+	if token := ident.GetACLToken(); token != nil && token != structs.AnonymousACLToken {
+		logArgs = append(logArgs, "access_token_name", token.Name)
+	}
+	if err != nil {
+		logArgs = append(logArgs, "error", err)
+	}
+	c.auditLogger.Log(level, "access", append(logArgs, args...)...)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_authorizeAudited(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.Logger.SetLevel(hclog.Warn)
+		c.AuditLogger = hclog.New(&hclog.LoggerOptions{
+			Output: &buf,
+			Level:  hclog.Trace,
+		})
+	})
+	defer cleanup()
+
+	err := client.authorizeAudited("Allocations.Processes", "", hclog.Info,
+		func(*acl.ACL) bool { return false }, "alloc_id", "a1")
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	must.StrContains(t, buf.String(), "client.audit: access: endpoint=Allocations.Processes identity=unauthenticated allowed=false")
+	must.StrContains(t, buf.String(), "alloc_id=a1")
+
+	buf.Reset()
+	must.NoError(t, client.authorizeAudited("Agent.Profile", "", hclog.Info,
+		func(*acl.ACL) bool { return true }))
+	must.StrContains(t, buf.String(), "endpoint=Agent.Profile identity=unauthenticated allowed=true")
+
+	// Accesses are audited whatever the log level
+	buf.Reset()
+	must.NoError(t, client.authorizeAudited("Allocations.Stats", "", hclog.Debug,
+		func(*acl.ACL) bool { return true }))
+	must.StrContains(t, buf.String(), "[DEBUG] client.audit: access: endpoint=Allocations.Stats")
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/go-msgpack/v2/codec"
 
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/command/agent/host"
 	"github.com/hashicorp/nomad/command/agent/monitor"
 	"github.com/hashicorp/nomad/command/agent/pprof"
@@ -36,16 +37,13 @@ func NewAgentEndpoint(c *Client) *Agent {
 }
 
 func (a *Agent) Profile(args *structs.AgentPprofRequest, reply *structs.AgentPprofResponse) error {
-	// Check ACL for agent write
-	aclObj, err := a.c.ResolveToken(args.AuthToken)
+	// Check ACL for agent write and debug
+	enableDebug := a.c.GetConfig().EnableDebug
+	err := a.c.authorizeAudited("Agent.Profile", args.AuthToken, log.Info, func(aclObj *acl.ACL) bool {
+		return aclObj.AllowAgentWrite() && aclObj.AllowAgentDebug(enableDebug)
+	}, "type", args.ReqType, "profile", args.Profile)
 	if err != nil {
 		return err
-	} else if !aclObj.AllowAgentWrite() {
-		return structs.ErrPermissionDenied
-	}
-
-	if !aclObj.AllowAgentDebug(a.c.GetConfig().EnableDebug) {
-		return structs.ErrPermissionDenied
 	}
 
 	var resp []byte
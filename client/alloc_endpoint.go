@@ -13,9 +13,13 @@ import (
 	"time"
 
 	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/hoststats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	nstructs "github.com/hashicorp/nomad/nomad/structs"
@@ -23,6 +27,10 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
 )
 
+// statsWaitInterval is how often the stats of an allocation are checked for a
+// new sample while a request waits for one
+const statsWaitInterval = 50 * time.Millisecond
+
 // Allocations endpoint is used for interacting with client allocations
 type Allocations struct {
 	c *Client
@@ -159,11 +167,14 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 		return err
 	}
 
-	// Check read-job permission.
-	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+	// Check read-job or read-stats permission. Stats are polled, so their
+	// accesses are only audited at debug level.
+	if err := a.c.authorizeAudited("Allocations.Stats", args.AuthToken, hclog.Debug, func(aclObj *acl.ACL) bool {
+		hasReadJob := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob)
+		hasReadStats := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadStats)
+		return hasReadJob || hasReadStats
+	}, "alloc_id", alloc.ID, "task", args.Task); err != nil {
 		return err
-	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
-		return nstructs.ErrPermissionDenied
 	}
 
 	clientStats := a.c.StatsReporter()
@@ -176,11 +187,153 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 	if err != nil {
 		return err
 	}
+	if args.After > 0 {
+		if stats, err = a.waitForStats(aStats, args, stats); err != nil {
+			return err
+		}
+	}
+
+	if a.c.GetConfig().TaskEnergyStats {
+		apportionTaskEnergy(stats, a.c.LatestHostStats())
+	}
+
+	if args.Window {
+		for name, w := range aStats.UsageWindows(args.Task) {
+			// The task map is built for this request, but the usage is
+			// shared with the task runner
+			if ts, ok := stats.Tasks[name]; ok {
+				withWindow := *ts
+				withWindow.Window = w
+				stats.Tasks[name] = &withWindow
+			}
+		}
+	}
 
 	reply.Stats = stats
 	return nil
 }
 
+// waitForStats waits for a sample of the allocation newer than args.After,
+// up to the MaxQueryTime of the request, and returns the latest sample once
+// there is one or the wait elapsed.
+func (a *Allocations) waitForStats(aStats interfaces.AllocStatsReporter, args *cstructs.AllocStatsRequest,
+	stats *cstructs.AllocResourceUsage) (*cstructs.AllocResourceUsage, error) {
+
+	wait := args.MaxQueryTime
+	if wait <= 0 {
+		wait = nstructs.DefaultBlockingRPCQueryTime
+	}
+	timer, stop := helper.NewSafeTimer(min(wait, nstructs.MaxBlockingRPCQueryTime))
+	defer stop()
+	ticker := time.NewTicker(statsWaitInterval)
+	defer ticker.Stop()
+
+	var err error
+	for stats.Timestamp <= args.After {
+		select {
+		case <-a.c.shutdownCh:
+			return stats, nil
+		case <-timer.C:
+			return stats, nil
+		case <-ticker.C:
+		}
+		if stats, err = aStats.LatestAllocStats(args.Task); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// Processes is used to list the processes running in the tasks of an
+// allocation
+func (a *Allocations) Processes(args *cstructs.AllocProcessesRequest, reply *cstructs.AllocProcessesResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "processes"}, time.Now())
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check read-processes permission, since the command lines of the
+	// processes can be more sensitive than the job.
+	if err := a.c.authorizeAudited("Allocations.Processes", args.AuthToken, hclog.Info, func(aclObj *acl.ACL) bool {
+		return aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadProcesses)
+	}, "alloc_id", alloc.ID, "task", args.Task); err != nil {
+		return err
+	}
+
+	ar, err := a.c.getAllocRunner(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := ar.TaskProcesses(args.Task)
+	if err != nil {
+		return err
+	}
+
+	reply.Tasks = tasks
+	return nil
+}
+
+// Capture is used to record what the main process of a task is doing.
+func (a *Allocations) Capture(args *cstructs.AllocCaptureRequest, reply *cstructs.AllocCaptureResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "capture"}, time.Now())
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace alloc-lifecycle permission.
+	if err := a.c.authorizeAudited("Allocations.Capture", args.AuthToken, hclog.Info, func(aclObj *acl.ACL) bool {
+		return aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocLifecycle)
+	}, "alloc_id", alloc.ID, "task", args.Task, "mode", args.Mode); err != nil {
+		return err
+	}
+
+	ar, err := a.c.getAllocRunner(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	path, err := ar.CaptureTask(context.Background(), args.Task, args.Mode, args.Duration)
+	if err != nil {
+		return err
+	}
+
+	reply.Path = path
+	return nil
+}
+
+// apportionTaskEnergy sets the estimated power drawn by each task of the
+// allocation by attributing the host power to tasks in proportion to the host
+// CPU ticks they consumed. The task samples are shared with the task runners,
+// so they are copied rather than modified in place.
+func apportionTaskEnergy(stats *cstructs.AllocResourceUsage, host *hoststats.HostStats) {
+	if host == nil || host.Power == nil || len(host.Power.Zones) == 0 || host.CPUTicksConsumed <= 0 {
+		return
+	}
+
+	total := new(cstructs.EnergyStats)
+	for name, usage := range stats.Tasks {
+		if usage == nil || usage.ResourceUsage == nil || usage.ResourceUsage.CpuStats == nil {
+			continue
+		}
+
+		share := min(usage.ResourceUsage.CpuStats.TotalTicks/host.CPUTicksConsumed, 1)
+
+		ru := *usage.ResourceUsage
+		ru.EnergyStats = &cstructs.EnergyStats{Watts: host.Power.Watts * share}
+		task := *usage
+		task.ResourceUsage = &ru
+		stats.Tasks[name] = &task
+
+		total.Add(ru.EnergyStats)
+	}
+	stats.ResourceUsage.EnergyStats = total
+}
+
 // Checks is used to retrieve nomad service discovery check status information.
 func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.AllocChecksResponse) error {
 	defer metrics.MeasureSince([]string{"client", "allocations", "checks"}, time.Now())
@@ -277,11 +430,17 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 
 		a.c.logger.Info("task exec session starting", logArgs...)
 	}
+	audit := func(err error) {
+		a.c.auditAccess("Allocations.Exec", ident, err, hclog.Info,
+			"exec_id", execID, "alloc_id", req.AllocID, "task", req.Task)
+	}
 
 	// Check alloc-exec permission.
 	if err != nil {
+		audit(err)
 		return pointer.Of(int64(400)), err
 	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocExec) {
+		audit(nstructs.ErrPermissionDenied)
 		return nil, nstructs.ErrPermissionDenied
 	}
 
@@ -330,9 +489,11 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	if capabilities.FSIsolation == fsisolation.None {
 		exec := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocNodeExec)
 		if !exec {
+			audit(nstructs.ErrPermissionDenied)
 			return nil, nstructs.ErrPermissionDenied
 		}
 	}
+	audit(nil)
 
 	allocState, err := a.c.GetAllocState(req.AllocID)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/hoststats"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pluginutils/catalog"
@@ -507,6 +508,124 @@ func TestAllocations_Stats(t *testing.T) {
 	})
 }
 
+func TestAllocations_Stats_After(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	a := mock.Alloc()
+	must.NoError(t, client.addAlloc(a, ""))
+
+	// Requests for samples newer than the client has wait for the max query
+	// time, and then return the latest sample
+	req := &cstructs.AllocStatsRequest{
+		AllocID: a.ID,
+		After:   time.Now().Add(time.Hour).UnixNano(),
+	}
+	req.MaxQueryTime = 200 * time.Millisecond
+	var resp cstructs.AllocStatsResponse
+	start := time.Now()
+	must.NoError(t, client.ClientRPC("Allocations.Stats", req, &resp))
+	must.GreaterEq(t, 200*time.Millisecond, time.Since(start))
+	must.NotNil(t, resp.Stats)
+}
+
+func TestAllocations_Processes(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	task := job.TaskGroups[0].Tasks[0].Name
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	// Try with bad task
+	req := &cstructs.AllocProcessesRequest{AllocID: alloc.ID, Task: "missing"}
+	var resp cstructs.AllocProcessesResponse
+	must.Error(t, client.ClientRPC("Allocations.Processes", req, &resp))
+
+	// Try with all tasks
+	req.Task = ""
+	testutil.WaitForResult(func() (bool, error) {
+		var resp2 cstructs.AllocProcessesResponse
+		if err := client.ClientRPC("Allocations.Processes", req, &resp2); err != nil {
+			return false, err
+		}
+		procs := resp2.Tasks[task]
+		if len(procs) != 1 {
+			return false, fmt.Errorf("expected 1 process for task %q, got %v", task, resp2.Tasks)
+		}
+		if procs[0].Cmdline[0] != "mock" {
+			return false, fmt.Errorf("unexpected process %#v", procs[0])
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
+func TestAllocations_Capture(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	task := job.TaskGroups[0].Tasks[0].Name
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	// Try with bad task
+	req := &cstructs.AllocCaptureRequest{
+		AllocID: alloc.ID,
+		Task:    "missing",
+		Mode:    cstructs.CaptureModeSignal,
+	}
+	var resp cstructs.AllocCaptureResponse
+	must.Error(t, client.ClientRPC("Allocations.Capture", req, &resp))
+
+	req.Task = task
+	testutil.WaitForResult(func() (bool, error) {
+		var resp2 cstructs.AllocCaptureResponse
+		if err := client.ClientRPC("Allocations.Capture", req, &resp2); err != nil {
+			return false, err
+		}
+		if !strings.HasPrefix(resp2.Path, "alloc/captures/"+task+".") {
+			return false, fmt.Errorf("unexpected capture path %q", resp2.Path)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 func TestAllocations_Stats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -567,6 +686,20 @@ func TestAllocations_Stats_ACL(t *testing.T) {
 		require.NoError(err)
 	}
 
+	// Try request with a token that can only read stats
+	{
+		token := mock.CreatePolicyAndToken(t, server.State(), 1007, "test-read-stats",
+			mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadStats}))
+		req := &cstructs.AllocStatsRequest{}
+		req.AllocID = alloc.ID
+		req.AuthToken = token.SecretID
+		req.Namespace = nstructs.DefaultNamespace
+
+		var resp cstructs.AllocStatsResponse
+		err := client.ClientRPC("Allocations.Stats", &req, &resp)
+		require.NoError(err)
+	}
+
 	// Try request with a management token
 	{
 		req := &cstructs.AllocStatsRequest{}
@@ -579,6 +712,91 @@ func TestAllocations_Stats_ACL(t *testing.T) {
 	}
 }
 
+func TestAllocations_Processes_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunningWithToken(t, server.RPC, job, root.SecretID)[0]
+
+	request := func(token string) error {
+		req := &cstructs.AllocProcessesRequest{AllocID: alloc.ID}
+		req.AuthToken = token
+		req.Namespace = nstructs.DefaultNamespace
+		var resp cstructs.AllocProcessesResponse
+		return client.ClientRPC("Allocations.Processes", req, &resp)
+	}
+
+	// Reading the job doesn't allow reading its processes
+	token := mock.CreatePolicyAndToken(t, server.State(), 1005, "test-read-job",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	must.EqError(t, request(token.SecretID), nstructs.ErrPermissionDenied.Error())
+
+	// Nor does the read policy
+	token = mock.CreatePolicyAndToken(t, server.State(), 1007, "test-read",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, acl.PolicyRead, nil))
+	must.EqError(t, request(token.SecretID), nstructs.ErrPermissionDenied.Error())
+
+	token = mock.CreatePolicyAndToken(t, server.State(), 1009, "test-read-processes",
+		mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadProcesses}))
+	must.NoError(t, request(token.SecretID))
+
+	must.NoError(t, request(root.SecretID))
+}
+
+func TestAllocations_apportionTaskEnergy(t *testing.T) {
+	ci.Parallel(t)
+
+	web := &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{CpuStats: &cstructs.CpuStats{TotalTicks: 500}},
+	}
+	sidecar := &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{CpuStats: &cstructs.CpuStats{TotalTicks: 100}},
+	}
+	stats := &cstructs.AllocResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{},
+		Tasks: map[string]*cstructs.TaskResourceUsage{
+			"web":     web,
+			"sidecar": sidecar,
+		},
+	}
+	host := &hoststats.HostStats{
+		CPUTicksConsumed: 1000,
+		Power: &hoststats.PowerStats{
+			Zones: []*hoststats.PowerZoneStats{{Name: "package-0", Watts: 80}},
+			Watts: 80,
+		},
+	}
+
+	apportionTaskEnergy(stats, host)
+	must.Eq(t, 40, stats.Tasks["web"].ResourceUsage.EnergyStats.Watts)
+	must.Eq(t, 8, stats.Tasks["sidecar"].ResourceUsage.EnergyStats.Watts)
+	must.Eq(t, 48, stats.ResourceUsage.EnergyStats.Watts)
+
+	// the samples owned by the task runners are left untouched
+	must.Nil(t, web.ResourceUsage.EnergyStats)
+	must.Nil(t, sidecar.ResourceUsage.EnergyStats)
+
+	// nothing is attributed without host energy counters
+	stats.ResourceUsage.EnergyStats = nil
+	apportionTaskEnergy(stats, &hoststats.HostStats{CPUTicksConsumed: 1000})
+	must.Nil(t, stats.ResourceUsage.EnergyStats)
+}
+
 func TestAlloc_Checks(t *testing.T) {
 	ci.Parallel(t)
 
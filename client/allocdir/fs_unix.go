@@ -107,5 +107,26 @@ func getOwner(fi os.FileInfo) (int, int) {
 	if !ok {
 		return -1, -1
 	}
+
+	// An unprivileged client can't give files away, so the files it copies
+	// into a chroot are owned by its own user
+	if unix.Geteuid() != 0 {
+		return idUnsupported, idUnsupported
+	}
 	return int(stat.Uid), int(stat.Gid)
 }
+
+func fileUsage(fi os.FileInfo) (entryUsage, bool) {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return entryUsage{}, false
+	}
+
+	// Blocks are counted in 512-byte units regardless of the block size of
+	// the filesystem
+	return entryUsage{
+		dev:   uint64(stat.Dev),
+		ino:   uint64(stat.Ino),
+		bytes: uint64(stat.Blocks) * 512,
+	}, true
+}
@@ -62,3 +62,9 @@ func MountSpecialDirs(taskDir string) error {
 func getOwner(os.FileInfo) (int, int) {
 	return idUnsupported, idUnsupported
 }
+
+// fileUsage doesn't work on Windows as os.FileInfo doesn't expose inode
+// numbers there
+func fileUsage(os.FileInfo) (entryUsage, bool) {
+	return entryUsage{}, false
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocdir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiskUsage is the disk space and the inodes used by the files of a
+// directory tree.
+type DiskUsage struct {
+	// UsedBytes is the space allocated on disk to the files, which may be
+	// less than their size for sparse files
+	UsedBytes uint64
+
+	// Inodes is the number of files, directories, and other entries of the
+	// tree, each of which uses an inode
+	Inodes uint64
+}
+
+// Usage walks the directory tree at path and returns the disk space and the
+// inodes its entries use. Hard links to a file are counted once, and
+// directories on other filesystems than path, such as the tmpfs of the
+// secrets dir, are skipped since their usage isn't taken from the disk.
+// Mountpoints under path, such as the shared alloc dir bind mounted into each
+// task dir, and the entries at the skip paths, such as the files embedded into
+// the chroot of a task, are skipped too since the tasks didn't write them.
+func Usage(path string, skip []string) (*DiskUsage, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	root, rootOk := fileUsage(info)
+
+	skipped, err := mountpoints(path)
+	if err != nil {
+		return nil, err
+	}
+	if skipped == nil {
+		skipped = make(map[string]struct{}, len(skip))
+	}
+	for _, p := range skip {
+		skipped[filepath.Clean(p)] = struct{}{}
+	}
+	delete(skipped, filepath.Clean(path))
+
+	usage := &DiskUsage{}
+	seen := make(map[uint64]struct{})
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Entries may be removed by the tasks during the walk
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if _, ok := skipped[p]; ok {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		entry, ok := fileUsage(info)
+		if !rootOk || !ok {
+			// Without inode numbers every entry is counted
+			usage.Inodes++
+			usage.UsedBytes += uint64(info.Size())
+			return nil
+		}
+
+		if entry.dev != root.dev {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := seen[entry.ino]; ok {
+			return nil
+		}
+		seen[entry.ino] = struct{}{}
+		usage.Inodes++
+		usage.UsedBytes += entry.bytes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// entryUsage is the device and inode numbers of a directory entry and the
+// disk space allocated to it
+type entryUsage struct {
+	dev   uint64
+	ino   uint64
+	bytes uint64
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package allocdir
+
+// mountpoints returns the mountpoints under path. Mounts are only found on
+// Linux, elsewhere directories on other filesystems are skipped by device.
+func mountpoints(string) (map[string]struct{}, error) {
+	return nil, nil
+}
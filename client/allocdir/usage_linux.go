@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocdir
+
+import (
+	"github.com/moby/sys/mountinfo"
+)
+
+// mountpoints returns the mountpoints under path, such as the bind mounted
+// shared alloc dir of each task and its secrets dir.
+func mountpoints(path string) (map[string]struct{}, error) {
+	mounts, err := mountinfo.GetMounts(mountinfo.PrefixFilter(path))
+	if err != nil {
+		return nil, err
+	}
+	points := make(map[string]struct{}, len(mounts))
+	for _, m := range mounts {
+		points[m.Mountpoint] = struct{}{}
+	}
+	return points, nil
+}
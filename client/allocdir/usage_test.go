@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package allocdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "a", "one"), make([]byte, 8192), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "two"), []byte("two"), 0o644))
+
+	// A hard link shares the inode and blocks of its file
+	must.NoError(t, os.Link(filepath.Join(dir, "a", "one"), filepath.Join(dir, "one")))
+
+	usage, err := Usage(dir, nil)
+	must.NoError(t, err)
+
+	// The root, two directories, and two files
+	must.Eq(t, 5, usage.Inodes)
+	must.Greater(t, 8192, usage.UsedBytes)
+}
+
+func TestUsage_skip(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "task", "bin"), 0o755))
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "task", "local"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "task", "bin", "sh"), make([]byte, 8192), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "task", "ld.so.cache"), make([]byte, 8192), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "task", "local", "out"), []byte("out"), 0o644))
+
+	full, err := Usage(dir, nil)
+	must.NoError(t, err)
+	usage, err := Usage(dir, []string{
+		filepath.Join(dir, "task", "bin"),
+		filepath.Join(dir, "task", "ld.so.cache"),
+	})
+	must.NoError(t, err)
+
+	// The root, the task and local directories, and the file of the task
+	must.Eq(t, 4, usage.Inodes)
+	must.GreaterEq(t, 2*8192, full.UsedBytes-usage.UsedBytes)
+}
+
+func TestUsage_missing(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := Usage(filepath.Join(t.TempDir(), "missing"), nil)
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -359,13 +359,19 @@ func (t *Tracker) watchTaskEvents() {
 				return
 			}
 
-			if state.State == structs.TaskStatePending {
+			if state.State == structs.TaskStatePending || t.warmingUp(taskName, state) {
 				latestStartTime = time.Time{}
 				break
 			} else if state.StartedAt.After(latestStartTime) {
 				// task is either running or exited successfully
 				latestStartTime = state.StartedAt
 			}
+
+			// min_healthy_time of tasks with a warmup block counts from the
+			// end of their warm-up
+			if state.WarmedUpAt.After(latestStartTime) {
+				latestStartTime = state.WarmedUpAt
+			}
 		}
 
 		// If the alloc is marked as failed by the client but none of the
@@ -408,6 +414,16 @@ func (t *Tracker) watchTaskEvents() {
 	}
 }
 
+// warmingUp returns whether the task is running but has not finished warming
+// up since it last started.
+func (t *Tracker) warmingUp(taskName string, state *structs.TaskState) bool {
+	th, ok := t.taskHealth[taskName]
+	if !ok || th.task.Warmup == nil {
+		return false
+	}
+	return state.State == structs.TaskStateRunning && !state.WarmedUpAt.After(state.StartedAt)
+}
+
 // healthyFuture is used to fire after checks have been healthy for MinHealthyTime
 type healthyFuture struct {
 	timer *time.Timer
@@ -734,6 +750,15 @@ func (t *taskHealthState) event(deadline time.Time, healthyDeadline, minHealthyT
 				return "Unhealthy because of dead task", true
 			}
 		case structs.TaskStateRunning:
+			if t.task.Warmup != nil {
+				if !t.state.WarmedUpAt.After(t.state.StartedAt) {
+					return fmt.Sprintf("Task not warmed up by healthy_deadline of %v", healthyDeadline), true
+				}
+				if t.state.WarmedUpAt.Add(minHealthyTime).After(deadline) {
+					return fmt.Sprintf("Task not warmed up for min_healthy_time of %v by healthy_deadline of %v", minHealthyTime, healthyDeadline), true
+				}
+			}
+
 			// We are running so check if we have been running long enough
 			if t.state.StartedAt.Add(minHealthyTime).After(deadline) {
 				return fmt.Sprintf("Task not running for min_healthy_time of %v by healthy_deadline of %v", minHealthyTime, healthyDeadline), true
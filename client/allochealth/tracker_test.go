@@ -493,6 +493,60 @@ func TestTracker_Succeeded_PostStart_Healthy(t *testing.T) {
 	}
 }
 
+func TestTracker_Warmup(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services = nil
+	task.Warmup = &structs.TaskWarmup{CPUPercent: 10, Duration: time.Second}
+
+	// Synthesize a running task which has not warmed up yet
+	started := time.Now()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		task.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: started,
+		},
+	}
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	consul := regmock.NewServiceRegistrationHandler(logger)
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	checks := checkstore.NewStore(logger, state.NewMemDB(logger))
+	taskEnvBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), taskEnvBuilder, consul, checks, time.Millisecond, false)
+	tracker.Start()
+
+	select {
+	case <-time.After(50 * time.Millisecond):
+	case h := <-tracker.HealthyCh():
+		t.Fatalf("unexpected health %v before the task warmed up", h)
+	}
+
+	event, unhealthy := tracker.taskHealth[task.Name].event(time.Now(), time.Minute, time.Millisecond, false)
+	must.True(t, unhealthy)
+	must.StrContains(t, event, "not warmed up")
+
+	warmedUp := alloc.Copy()
+	warmedUp.TaskStates[task.Name].WarmedUpAt = started.Add(time.Millisecond)
+	must.NoError(t, b.Send(warmedUp))
+
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out while waiting for health")
+	case h := <-tracker.HealthyCh():
+		must.True(t, h)
+	}
+}
+
 func TestTracker_ConsulChecks_Unhealthy(t *testing.T) {
 	ci.Parallel(t)
 
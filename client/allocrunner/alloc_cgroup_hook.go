@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	allocCgroupHookName = "alloc_cgroup"
+)
+
+// allocCgroupHook creates the alloc-level cgroup of allocs whose group has
+// shared_resources, which their tasks run under so they share headroom within
+// the ceiling of the alloc. It's only supported with cgroups v2.
+type allocCgroupHook struct {
+	logger   hclog.Logger
+	topology *numalib.Topology
+
+	alloc     *structs.Allocation
+	allocLock sync.Mutex
+}
+
+func newAllocCgroupHook(logger hclog.Logger, alloc *structs.Allocation, topology *numalib.Topology) *allocCgroupHook {
+	return &allocCgroupHook{
+		logger:   logger.Named(allocCgroupHookName),
+		topology: topology,
+		alloc:    alloc,
+	}
+}
+
+func (*allocCgroupHook) Name() string {
+	return allocCgroupHookName
+}
+
+// sharedResources returns the shared resources of the group of the alloc, or
+// nil if its tasks don't share an alloc-level cgroup.
+func sharedResources(alloc *structs.Allocation) *structs.SharedResources {
+	if alloc.Job == nil || alloc.AllocatedResources == nil {
+		return nil
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+	return tg.SharedResources
+}
+
+func (h *allocCgroupHook) Prerun() error {
+	h.allocLock.Lock()
+	defer h.allocLock.Unlock()
+
+	shared := sharedResources(h.alloc)
+	if shared == nil {
+		return nil
+	}
+	if cgroupslib.GetMode() != cgroupslib.CG2 {
+		h.logger.Warn("shared_resources requires cgroups v2; tasks are limited individually")
+		return nil
+	}
+	return h.apply(shared)
+}
+
+func (h *allocCgroupHook) Update(req *interfaces.RunnerUpdateRequest) error {
+	h.allocLock.Lock()
+	defer h.allocLock.Unlock()
+
+	h.alloc = req.Alloc
+	shared := sharedResources(h.alloc)
+	if shared == nil || cgroupslib.GetMode() != cgroupslib.CG2 {
+		return nil
+	}
+	return h.apply(shared)
+}
+
+// apply creates the alloc-level cgroup, or updates its ceiling to the
+// resources of the tasks of the alloc.
+func (h *allocCgroupHook) apply(shared *structs.SharedResources) error {
+	memoryMB, cpuShares := shared.Ceiling(h.alloc.AllocatedResources.Tasks)
+	memoryMax := memoryMB
+	if memoryMB > 0 {
+		memoryMax = memoryMB * 1024 * 1024
+	}
+	cpuCores := capCores(shared.CPUCap, h.topology)
+	h.logger.Trace("setting up alloc cgroup", "memory_mb", memoryMB, "cpu_shares", cpuShares, "cpu_cores", cpuCores)
+	return cgroupslib.CreateAllocSliceCG2(h.alloc.ID, memoryMax, cpuShares, cpuCores)
+}
+
+// capCores converts a CPU cap in MHz to the number of the usable cores of the
+// node it amounts to, which is 0 without a cap.
+func capCores(capMHz int, topology *numalib.Topology) float64 {
+	if capMHz <= 0 || topology == nil {
+		return 0
+	}
+	compute := topology.UsableCompute()
+	if compute == 0 {
+		return 0
+	}
+	cores := float64(topology.UsableCores().Size())
+	return float64(capMHz) / float64(compute) * cores
+}
+
+func (h *allocCgroupHook) Postrun() error {
+	h.allocLock.Lock()
+	defer h.allocLock.Unlock()
+
+	// Allocs without shared resources are removed too, so whether they have
+	// an alloc-level cgroup is forgotten
+	if cgroupslib.GetMode() != cgroupslib.CG2 {
+		return nil
+	}
+	if err := cgroupslib.RemoveAllocSliceCG2(h.alloc.ID); err != nil {
+		h.logger.Warn("failed to remove alloc cgroup", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+var (
+	_ interfaces.RunnerPrerunHook  = (*allocCgroupHook)(nil)
+	_ interfaces.RunnerUpdateHook  = (*allocCgroupHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*allocCgroupHook)(nil)
+)
+
+func TestAllocCgroupHook_sharedResources(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	must.Nil(t, sharedResources(alloc))
+
+	// The hook does nothing for allocs without shared resources
+	h := newAllocCgroupHook(testlog.HCLogger(t), alloc, nil)
+	must.NoError(t, h.Prerun())
+	must.NoError(t, h.Postrun())
+
+	shared := &structs.SharedResources{MemoryOverheadMB: 64}
+	alloc = alloc.Copy()
+	alloc.Job.TaskGroups[0].SharedResources = shared
+	must.Eq(t, shared, sharedResources(alloc))
+
+	alloc.AllocatedResources = nil
+	must.Nil(t, sharedResources(alloc))
+}
+
+func TestAllocCgroupHook_capCores(t *testing.T) {
+	ci.Parallel(t)
+
+	// 4 cores of 3500 MHz
+	topology := structs.MockBasicTopology()
+	must.Eq(t, 0, capCores(0, topology))
+	must.Eq(t, 1, capCores(3500, topology))
+	must.Eq(t, 2.5, capCores(8750, topology))
+	must.Eq(t, 0, capCores(3500, nil))
+}
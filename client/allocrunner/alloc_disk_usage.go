@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	cconfig "github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// diskUsageInterval is the minimum time between two measurements of the
+// alloc dir, since walking a directory tree of many small files is costly
+const diskUsageInterval = time.Minute
+
+// diskUsageTracker measures the disk space and inodes used by the alloc dir.
+// The alloc dir is walked in the background when its last measurement is
+// older than diskUsageInterval, so that reading the stats of an allocation
+// never waits on the walk.
+type diskUsageTracker struct {
+	logger hclog.Logger
+	path   string
+
+	// skip are the paths under the alloc dir the walk skips
+	skip []string
+
+	lock    sync.Mutex
+	last    *cstructs.AllocDiskStats
+	walking bool
+
+	// usage and now are overridden by tests
+	usage func(string, []string) (*allocdir.DiskUsage, error)
+	now   func() time.Time
+}
+
+func newDiskUsageTracker(logger hclog.Logger, path string, skip []string) *diskUsageTracker {
+	return &diskUsageTracker{
+		logger: logger,
+		path:   path,
+		skip:   skip,
+		usage:  allocdir.Usage,
+		now:    time.Now,
+	}
+}
+
+// chrootPaths returns the paths in the task dirs of an alloc dir that the
+// chroot of each task is embedded at. The files of a chroot are hard linked
+// or copied from the client, so they don't count towards the disk usage of
+// the allocation.
+func chrootPaths(allocDir string, tasks []*structs.Task, chroot map[string]string) []string {
+	if len(chroot) == 0 {
+		chroot = cconfig.DefaultChrootEnv
+	}
+	paths := make([]string, 0, len(tasks)*len(chroot))
+	for _, task := range tasks {
+		for _, dest := range chroot {
+			paths = append(paths, filepath.Join(allocDir, task.Name, dest))
+		}
+	}
+	return paths
+}
+
+// Stats returns the last measurement of the alloc dir, or nil if it hasn't
+// been measured yet, and starts a new measurement if it is stale.
+func (d *diskUsageTracker) Stats() *cstructs.AllocDiskStats {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	stale := d.last == nil || d.now().Sub(time.Unix(0, d.last.Timestamp)) >= diskUsageInterval
+	if stale && !d.walking {
+		d.walking = true
+		go d.measure()
+	}
+	return d.last
+}
+
+func (d *diskUsageTracker) measure() {
+	stats, err := d.collect()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.walking = false
+	if err != nil {
+		d.logger.Debug("failed to measure alloc dir disk usage", "path", d.path, "error", err)
+		return
+	}
+	d.last = stats
+}
+
+func (d *diskUsageTracker) collect() (*cstructs.AllocDiskStats, error) {
+	usage, err := d.usage(d.path, d.skip)
+	if err != nil {
+		return nil, err
+	}
+	stats := &cstructs.AllocDiskStats{
+		UsedBytes: usage.UsedBytes,
+		Inodes:    usage.Inodes,
+		Timestamp: d.now().UnixNano(),
+	}
+
+	// Not every filesystem counts inodes, in which case only the usage of
+	// the alloc dir is reported
+	if fs, err := disk.Usage(d.path); err == nil && fs.InodesTotal > 0 {
+		stats.FilesystemInodesFree = fs.InodesFree
+		if !math.IsNaN(fs.InodesUsedPercent) {
+			stats.FilesystemInodesUsedPercent = fs.InodesUsedPercent
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestDiskUsageTracker_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	var walks atomic.Int32
+	var now atomic.Int64
+	now.Store(time.Now().UnixNano())
+
+	d := newDiskUsageTracker(testlog.HCLogger(t), t.TempDir(), nil)
+	d.now = func() time.Time { return time.Unix(0, now.Load()) }
+	d.usage = func(string, []string) (*allocdir.DiskUsage, error) {
+		n := walks.Add(1)
+		return &allocdir.DiskUsage{UsedBytes: 4096, Inodes: uint64(n) * 10}, nil
+	}
+
+	// The first call starts measuring the alloc dir without waiting on it
+	must.Nil(t, d.Stats())
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return d.Stats() != nil }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	stats := d.Stats()
+	must.Eq(t, 4096, stats.UsedBytes)
+	must.Eq(t, 10, stats.Inodes)
+	must.Eq(t, 1, walks.Load())
+
+	// Once stale the last measurement is returned while it is refreshed
+	now.Add(int64(diskUsageInterval))
+	must.Eq(t, 10, d.Stats().Inodes)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return d.Stats().Inodes == 20 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, 2, walks.Load())
+}
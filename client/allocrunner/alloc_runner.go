@@ -5,8 +5,11 @@ package allocrunner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/netstats"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
@@ -136,6 +140,9 @@ type allocRunner struct {
 	// allocDir is used to build the allocations directory structure.
 	allocDir allocdir.Interface
 
+	// diskUsage measures the disk space and inodes used by allocDir
+	diskUsage *diskUsageTracker
+
 	// runnerHooks are alloc runner lifecycle hooks that should be run on state
 	// transitions.
 	runnerHooks []interfaces.RunnerHook
@@ -147,9 +154,20 @@ type allocRunner struct {
 	// tasks are the set of task runners
 	tasks map[string]*taskrunner.TaskRunner
 
+	// netNSPath is the path of the network namespace of the allocation, if it
+	// has one of its own, which its network stats are read from
+	netNSPath     string
+	netNSPathLock sync.RWMutex
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// statsSink receives every resource usage sample of the tasks
+	statsSink cinterfaces.TaskStatsSink
+
+	// statsInterval returns the effective stats collection interval
+	statsInterval cinterfaces.StatsIntervalReporter
+
 	// allocBroadcaster sends client allocation updates to all listeners
 	allocBroadcaster *cstructs.AllocBroadcaster
 
@@ -245,6 +263,8 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 		taskStateUpdateHandlerCh: make(chan struct{}),
 		allocUpdatedCh:           make(chan *structs.Allocation, 1),
 		deviceStatsReporter:      config.DeviceStatsReporter,
+		statsSink:                config.StatsSink,
+		statsInterval:            config.StatsInterval,
 		prevAllocWatcher:         config.PrevAllocWatcher,
 		prevAllocMigrator:        config.PrevAllocMigrator,
 		dynamicRegistry:          config.DynamicRegistry,
@@ -276,6 +296,8 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 		config.ClientConfig.AllocMountsDir,
 		alloc.ID,
 	)
+	ar.diskUsage = newDiskUsageTracker(ar.logger, ar.allocDir.AllocDirPath(),
+		chrootPaths(ar.allocDir.AllocDirPath(), tg.Tasks, config.ClientConfig.ChrootEnv))
 
 	ar.taskCoordinator = tasklifecycle.NewCoordinator(ar.logger, tg.Tasks, ar.waitCh)
 
@@ -311,6 +333,20 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 
 // initTaskRunners creates task runners but does *not* run them.
 func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
+	// Tasks share one stats stream per driver rather than each opening
+	// their own
+	var allocStats *taskrunner.AllocStatsBatcher
+	if len(tasks) > 1 {
+		allocStats = taskrunner.NewAllocStatsBatcher(ar.logger)
+	}
+
+	// Tasks of groups with shared resources borrow the CPU their siblings
+	// don't use
+	var sharedCPU *taskrunner.SharedCPU
+	if sharedResources(ar.alloc) != nil {
+		sharedCPU = taskrunner.NewSharedCPU()
+	}
+
 	for _, task := range tasks {
 		trConfig := &taskrunner.Config{
 			Alloc:               ar.alloc,
@@ -326,6 +362,10 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			ConsulSI:            ar.sidsClient,
 			VaultFunc:           ar.vaultClientFunc,
 			DeviceStatsReporter: ar.deviceStatsReporter,
+			StatsSink:           ar.statsSink,
+			StatsInterval:       ar.statsInterval,
+			AllocStats:          allocStats,
+			SharedCPU:           sharedCPU,
 			CSIManager:          ar.csiManager,
 			DeviceManager:       ar.devicemanager,
 			DriverManager:       ar.driverManager,
@@ -367,6 +407,9 @@ func (ar *allocRunner) Run() {
 	// Start the alloc update handler
 	go ar.handleAllocUpdates()
 
+	// Start reporting changes in resource usage
+	go ar.handleUsageUpdates()
+
 	// If task update chan has been closed, that means we've been shutdown.
 	select {
 	case <-ar.taskStateUpdateHandlerCh:
@@ -805,6 +848,8 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 // Client specific fields. Note: this mutates the allocRunner's state to store
 // the taskStates!
 func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *structs.Allocation {
+	usage := ar.usageSummary()
+
 	ar.stateLock.Lock()
 	defer ar.stateLock.Unlock()
 
@@ -812,8 +857,9 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	ar.state.TaskStates = taskStates
 
 	a := &structs.Allocation{
-		ID:         ar.id,
-		TaskStates: taskStates,
+		ID:           ar.id,
+		TaskStates:   taskStates,
+		UsageSummary: usage,
 	}
 
 	if d := ar.state.DeploymentStatus; d != nil {
@@ -856,6 +902,8 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 				ts.FinishedAt = now
 			}
 		}
+
+		a.BillingRecord = ar.billingRecord(a.TaskStates)
 	}
 
 	// Set the NetworkStatus and default DNSConfig if one is not returned from the client
@@ -1282,6 +1330,7 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		},
 	}
 
+	var shared []uint64
 	for name, tr := range ar.tasks {
 		if taskFilter != "" && taskFilter != name {
 			// Getting stats for a particular task and its not this one!
@@ -1294,15 +1343,98 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 			if usage.Timestamp > astat.Timestamp {
 				astat.Timestamp = usage.Timestamp
 			}
+			if ms := usage.ResourceUsage.MemoryStats; ms != nil && slices.Contains(ms.Measured, "Shared") {
+				shared = append(shared, ms.Shared)
+			}
 		}
 	}
 
+	// Tasks of an allocation commonly share memory, such as through /dev/shm
+	// in the shared alloc dir, which each task counts in its RSS
+	if len(shared) > 0 {
+		astat.ResourceUsage.MemoryStats.DedupShared(shared)
+	}
+
+	// The traffic of tasks in a network namespace of their own isn't visible
+	// to their cgroups, but the interfaces of the namespace count it
+	ar.netNSPathLock.RLock()
+	netNSPath := ar.netNSPath
+	ar.netNSPathLock.RUnlock()
+	if netNSPath != "" {
+		networks, err := netstats.Read(netNSPath)
+		if err != nil {
+			ar.logger.Debug("failed to read network namespace stats", "path", netNSPath, "error", err)
+		}
+		astat.Networks = networks
+
+		connections, err := netstats.ReadConnections(netNSPath)
+		if err != nil {
+			ar.logger.Debug("failed to count network namespace connections", "path", netNSPath, "error", err)
+		} else {
+			connections.ConntrackLimit = ar.conntrackLimit()
+			astat.Connections = connections
+		}
+	}
+
+	// Small files can exhaust the inodes of the filesystem long before its
+	// space, so both are measured
+	astat.Disk = ar.diskUsage.Stats()
+
 	return astat, nil
 }
 
+// conntrackLimit returns the limit of the connections the network namespace
+// of the allocation tracks, which the client only enforces in bridge mode.
+func (ar *allocRunner) conntrackLimit() uint64 {
+	alloc := ar.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || len(tg.Networks) == 0 || strings.ToLower(tg.Networks[0].Mode) != "bridge" {
+		return 0
+	}
+	return uint64(ar.clientConfig.BridgeNetworkConntrackLimit)
+}
+
+// UsageWindows summarizes the usage of each task over the samples retained
+// for it. If taskFilter is set, only the window of that task is returned.
+func (ar *allocRunner) UsageWindows(taskFilter string) map[string]*cstructs.TaskUsageWindow {
+	windows := make(map[string]*cstructs.TaskUsageWindow, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		if taskFilter != "" && taskFilter != name {
+			continue
+		}
+		if w := tr.UsageWindow(); w != nil {
+			windows[name] = w
+		}
+	}
+	return windows
+}
+
+// ReattachPids returns the pid of the plugin process the persisted handle of
+// each task reattaches to, keyed by task name. Tasks without one are omitted.
+func (ar *allocRunner) ReattachPids() map[string]int {
+	pids := make(map[string]int, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		if pid := tr.ReattachPid(); pid != 0 {
+			pids[name] = pid
+		}
+	}
+	return pids
+}
+
+// ClearStaleTaskHandle removes the persisted handle of a dead task. It
+// returns whether a handle was removed.
+func (ar *allocRunner) ClearStaleTaskHandle(taskName string) (bool, error) {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return false, fmt.Errorf("Failed to clear handle: task %q not found", taskName)
+	}
+	return tr.ClearStaleHandle()
+}
+
 func (ar *allocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	if tr, ok := ar.tasks[taskName]; ok {
 		return func(ev *drivers.TaskEvent) {
+			tr.AnnotateKill(ev.Annotations[drivers.TaskEventKilledResource])
 			tr.EmitEvent(&structs.TaskEvent{
 				Type:          structs.TaskDriverMessage,
 				Time:          ev.Timestamp.UnixNano(),
@@ -1330,6 +1462,19 @@ func (ar *allocRunner) RestartTask(taskName string, event *structs.TaskEvent) er
 	return tr.Restart(context.TODO(), event, false)
 }
 
+// FailTask kills the provided task with an event that fails it, which fails
+// the allocation so it is replaced according to its reschedule policy, unlike
+// Destroy which stops it for good. It blocks until the task exits.
+func (ar *allocRunner) FailTask(taskName string, event *structs.TaskEvent) error {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return fmt.Errorf("Could not find task runner for task: %s", taskName)
+	}
+
+	tr.AnnotateKill(event.Details["killed_resource"])
+	return tr.Kill(context.TODO(), event.SetFailsTask())
+}
+
 // RestartRunning restarts all tasks that are currently running.
 func (ar *allocRunner) RestartRunning(event *structs.TaskEvent) error {
 	return ar.restartTasks(context.TODO(), event, false, false)
@@ -1473,6 +1618,48 @@ func (ar *allocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capa
 	return tr.DriverCapabilities()
 }
 
+// TaskProcesses returns a snapshot of the processes running in each of the
+// allocation's tasks, or only in the given task if taskFilter is set. Tasks
+// that are not running or whose driver cannot list processes are omitted
+// unless they are requested explicitly.
+func (ar *allocRunner) TaskProcesses(taskFilter string) (map[string][]*cstructs.TaskProcess, error) {
+	if taskFilter != "" {
+		tr, ok := ar.tasks[taskFilter]
+		if !ok {
+			return nil, fmt.Errorf("task %q not found in allocation", taskFilter)
+		}
+		procs, err := tr.Processes()
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]*cstructs.TaskProcess{taskFilter: procs}, nil
+	}
+
+	result := make(map[string][]*cstructs.TaskProcess, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		procs, err := tr.Processes()
+		switch {
+		case errors.Is(err, taskrunner.ErrTaskNotRunning),
+			errors.Is(err, taskrunner.ErrProcessesNotSupported):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to list processes of task %q: %w", name, err)
+		}
+		result[name] = procs
+	}
+	return result, nil
+}
+
+// CaptureTask records what the main process of the given task is doing and
+// returns the path of the output relative to the alloc dir.
+func (ar *allocRunner) CaptureTask(ctx context.Context, taskName, mode string, duration time.Duration) (string, error) {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return "", fmt.Errorf("task %q not found in allocation", taskName)
+	}
+	return tr.Capture(ctx, mode, duration)
+}
+
 // AcknowledgeState is called by the client's alloc sync when a given client
 // state has been acknowledged by the server
 func (ar *allocRunner) AcknowledgeState(a *state.State) {
@@ -1521,6 +1708,8 @@ func (ar *allocRunner) GetUpdatePriority(a *structs.Allocation) cstructs.AllocUp
 		return cstructs.AllocUpdatePriorityTypical
 	case !last.NetworkStatus.Equal(a.NetworkStatus):
 		return cstructs.AllocUpdatePriorityTypical
+	case usageChanged(last.UsageSummary, a.UsageSummary):
+		return cstructs.AllocUpdatePriorityTypical
 	}
 
 	if !maps.EqualFunc(last.TaskStates, a.TaskStates, func(st, o *structs.TaskState) bool {
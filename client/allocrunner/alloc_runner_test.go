@@ -1802,6 +1802,61 @@ func TestAllocRunner_HandlesArtifactFailure(t *testing.T) {
 }
 
 // Test that alloc runner kills tasks in task group when another task fails
+// TestAllocRunner_FailTask asserts failing a task fails it and its allocation,
+// so the allocation is rescheduled rather than stopped.
+func TestAllocRunner_FailTask(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.KillTimeout = 10 * time.Millisecond
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+	upd := conf.StateUpdater.(*MockStateUpdater)
+
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil || last.ClientStatus != structs.AllocClientStatusRunning {
+			return false, fmt.Errorf("alloc not running yet")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	event := structs.NewTaskEvent(structs.TaskKilling).SetDisplayMessage("cores removed")
+	must.NoError(t, ar.FailTask(task.Name, event))
+	must.ErrorContains(t, ar.FailTask("missing", event), "Could not find task runner")
+
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last.ClientStatus != structs.AllocClientStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusFailed)
+		}
+		state := last.TaskStates[task.Name]
+		if !state.Failed {
+			return false, fmt.Errorf("task not failed")
+		}
+		for _, e := range state.Events {
+			if e.DisplayMessage == "cores removed" {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("missing event in %v", state.Events)
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
 func TestAllocRunner_TaskFailed_KillTG(t *testing.T) {
 	ci.Parallel(t)
 
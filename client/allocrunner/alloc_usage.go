@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// usageSummaryInterval is how often the usage summary of an allocation
+	// is checked for changes worth reporting to the servers
+	usageSummaryInterval = time.Minute
+
+	// usageSummaryDeadband is the relative change of CPU or memory usage below
+	// which an updated usage summary is not reported, so allocation updates
+	// aren't sent for usage that only fluctuates
+	usageSummaryDeadband = 0.1
+
+	// usageSummaryMinCPU and usageSummaryMinMemoryMB are the absolute changes
+	// below which an updated usage summary is not reported, so small tasks
+	// don't report every fluctuation
+	usageSummaryMinCPU      = 10
+	usageSummaryMinMemoryMB = 8
+)
+
+// usageSummary summarizes the latest resource usage of the allocation's
+// tasks. It returns nil if none of the tasks reported usage yet.
+//
+// The summary is polled, so it doesn't count as a read of the task stats:
+// while lazy_task_stats pauses collection, the summary keeps reporting the
+// last sample collected and is marked partial.
+func (ar *allocRunner) usageSummary() *structs.AllocUsageSummary {
+	var summary *structs.AllocUsageSummary
+	var cpu float64
+	var memory uint64
+
+	for name, tr := range ar.tasks {
+		ru := tr.PeekResourceUsage()
+		if ru == nil || ru.ResourceUsage == nil {
+			continue
+		}
+		if summary == nil {
+			summary = &structs.AllocUsageSummary{
+				Tasks: make(map[string]*structs.TaskUsageSummary, len(ar.tasks)),
+			}
+		}
+		summary.Timestamp = max(summary.Timestamp, ru.Timestamp)
+		summary.Partial = summary.Partial || tr.StatsPaused()
+
+		var taskCPU float64
+		var taskMemory uint64
+		if cs := ru.ResourceUsage.CpuStats; cs != nil {
+			taskCPU = cs.TotalTicks
+		}
+		if ms := ru.ResourceUsage.MemoryStats; ms != nil {
+			taskMemory = ms.Used()
+		}
+		summary.Tasks[name] = &structs.TaskUsageSummary{
+			CPU:      int(math.Round(taskCPU)),
+			MemoryMB: int(taskMemory / 1024 / 1024),
+		}
+		cpu += taskCPU
+		memory += taskMemory
+	}
+
+	if summary != nil {
+		summary.CPU = int(math.Round(cpu))
+		summary.MemoryMB = int(memory / 1024 / 1024)
+	}
+	return summary
+}
+
+// billingRecord returns the usage of the allocation's tasks over their
+// lifetime, spanning from when the first task started to when the last one
+// finished. It returns nil if no task ever started.
+func (ar *allocRunner) billingRecord(taskStates map[string]*structs.TaskState) *structs.AllocBillingRecord {
+	var start, end time.Time
+	for _, ts := range taskStates {
+		if !ts.StartedAt.IsZero() && (start.IsZero() || ts.StartedAt.Before(start)) {
+			start = ts.StartedAt
+		}
+		if ts.FinishedAt.After(end) {
+			end = ts.FinishedAt
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+
+	tasks := make(map[string]*structs.TaskBillingRecord, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		tasks[name] = tr.BillingRecord()
+	}
+	record := structs.NewAllocBillingRecord(tasks)
+	record.StartTime = start.UnixNano()
+	record.EndTime = end.UnixNano()
+	return record
+}
+
+// usageChanged returns true if the usage summary changed by enough since the
+// last reported one to be worth reporting, or collection paused or resumed.
+func usageChanged(last, cur *structs.AllocUsageSummary) bool {
+	switch {
+	case cur == nil:
+		return false
+	case last == nil, last.Partial != cur.Partial:
+		return true
+	}
+	return significantChange(last.CPU, cur.CPU, usageSummaryMinCPU) ||
+		significantChange(last.MemoryMB, cur.MemoryMB, usageSummaryMinMemoryMB)
+}
+
+func significantChange(last, cur, minDelta int) bool {
+	delta := math.Abs(float64(cur - last))
+	return delta >= float64(minDelta) && delta >= usageSummaryDeadband*float64(last)
+}
+
+// handleUsageUpdates periodically checks the usage summary of the allocation
+// and triggers an allocation update when it changed significantly. It
+// returns when Run exits.
+func (ar *allocRunner) handleUsageUpdates() {
+	ticker := time.NewTicker(usageSummaryInterval)
+	defer ticker.Stop()
+
+	var reported *structs.AllocUsageSummary
+	for {
+		select {
+		case <-ticker.C:
+		case <-ar.waitCh:
+			return
+		}
+
+		if cur := ar.usageSummary(); usageChanged(reported, cur) {
+			reported = cur
+			ar.TaskStateUpdated()
+		}
+	}
+}
+
+// AllocUpdateDelta returns the allocation update with the task states and
+// usage summary that did not change since the last update acknowledged by
+// the servers removed. The servers fill them back in from their copy of the
+// allocation. It must be called from the goroutine that calls
+// AcknowledgeState, so the acknowledged state can't change under it.
+//
+// Usage summaries which changed by less than the deadband are still sent
+// along with other changes, so the acknowledged summary is always the one
+// the servers have.
+func (ar *allocRunner) AllocUpdateDelta(a *structs.Allocation) *structs.Allocation {
+	ar.stateLock.RLock()
+	defer ar.stateLock.RUnlock()
+
+	last := ar.lastAcknowledgedState
+	if last == nil {
+		return a
+	}
+
+	delta := *a
+	delta.TaskStates = maps.Clone(a.TaskStates)
+	maps.DeleteFunc(delta.TaskStates, func(name string, ts *structs.TaskState) bool {
+		acked, ok := last.TaskStates[name]
+		return ok && ts.Equal(acked)
+	})
+	if a.UsageSummary != nil && a.UsageSummary.Equal(last.UsageSummary) {
+		delta.UsageSummary = nil
+	}
+	return &delta
+}
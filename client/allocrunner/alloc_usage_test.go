@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsageChanged(t *testing.T) {
+	ci.Parallel(t)
+
+	last := &structs.AllocUsageSummary{CPU: 500, MemoryMB: 256}
+
+	must.False(t, usageChanged(last, nil))
+	must.True(t, usageChanged(nil, last))
+
+	// changes within the deadband are not reported
+	must.False(t, usageChanged(last, &structs.AllocUsageSummary{CPU: 540, MemoryMB: 270}))
+	must.True(t, usageChanged(last, &structs.AllocUsageSummary{CPU: 560, MemoryMB: 256}))
+	must.True(t, usageChanged(last, &structs.AllocUsageSummary{CPU: 500, MemoryMB: 200}))
+
+	// pausing collection is reported
+	must.True(t, usageChanged(last, &structs.AllocUsageSummary{CPU: 500, MemoryMB: 256, Partial: true}))
+
+	// nor are small absolute changes of small allocations
+	small := &structs.AllocUsageSummary{CPU: 20, MemoryMB: 10}
+	must.False(t, usageChanged(small, &structs.AllocUsageSummary{CPU: 28, MemoryMB: 16}))
+}
+
+func TestAllocRunner_AllocUpdateDelta(t *testing.T) {
+	ci.Parallel(t)
+
+	running := &structs.TaskState{State: structs.TaskStateRunning}
+	usage := &structs.AllocUsageSummary{
+		CPU:       500,
+		MemoryMB:  256,
+		Timestamp: 1,
+		Tasks: map[string]*structs.TaskUsageSummary{
+			"web": {CPU: 500, MemoryMB: 256},
+		},
+	}
+	update := &structs.Allocation{
+		ID:           "alloc",
+		ClientStatus: structs.AllocClientStatusRunning,
+		TaskStates: map[string]*structs.TaskState{
+			"web":     running,
+			"sidecar": {State: structs.TaskStateRunning, Restarts: 1},
+			"new":     running,
+		},
+		UsageSummary: usage,
+	}
+
+	// without an acknowledged state everything is sent
+	ar := &allocRunner{}
+	must.Eq(t, update, ar.AllocUpdateDelta(update))
+
+	ar.lastAcknowledgedState = &state.State{
+		TaskStates: map[string]*structs.TaskState{
+			"web":     running.Copy(),
+			"sidecar": running.Copy(),
+		},
+		UsageSummary: usage.Copy(),
+	}
+
+	delta := ar.AllocUpdateDelta(update)
+	must.Eq(t, structs.AllocClientStatusRunning, delta.ClientStatus)
+	must.MapLen(t, 2, delta.TaskStates)
+	must.MapContainsKeys(t, delta.TaskStates, []string{"sidecar", "new"})
+	must.Nil(t, delta.UsageSummary)
+
+	// the same usage collected later is omitted too
+	update.UsageSummary = usage.Copy()
+	update.UsageSummary.Timestamp = 5
+	must.Nil(t, ar.AllocUpdateDelta(update).UsageSummary)
+	update.UsageSummary = usage
+
+	// the full update is left as is to be acknowledged
+	must.MapLen(t, 3, update.TaskStates)
+	must.NotNil(t, update.UsageSummary)
+
+	// summaries are compared task by task
+	update.UsageSummary = usage.Copy()
+	update.UsageSummary.Tasks["web"].CPU = 400
+	must.NotNil(t, ar.AllocUpdateDelta(update).UsageSummary)
+
+	update.UsageSummary = &structs.AllocUsageSummary{CPU: 510, MemoryMB: 256, Timestamp: 2}
+	must.NotNil(t, ar.AllocUpdateDelta(update).UsageSummary)
+}
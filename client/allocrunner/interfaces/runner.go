@@ -4,6 +4,9 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/allocrunner/state"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
@@ -36,16 +39,22 @@ type AllocRunner interface {
 	PersistState() error
 	AcknowledgeState(*state.State)
 	GetUpdatePriority(*structs.Allocation) cstructs.AllocUpdatePriority
+	AllocUpdateDelta(*structs.Allocation) *structs.Allocation
 	SetClientStatus(string)
 
 	Signal(taskName, signal string) error
 	RestartTask(taskName string, taskEvent *structs.TaskEvent) error
 	RestartRunning(taskEvent *structs.TaskEvent) error
 	RestartAll(taskEvent *structs.TaskEvent) error
+	FailTask(taskName string, taskEvent *structs.TaskEvent) error
 
 	GetTaskEventHandler(taskName string) drivermanager.EventHandler
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
 	GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error)
+	TaskProcesses(taskFilter string) (map[string][]*cstructs.TaskProcess, error)
+	ReattachPids() map[string]int
+	ClearStaleTaskHandle(taskName string) (bool, error)
+	CaptureTask(ctx context.Context, taskName, mode string, duration time.Duration) (string, error)
 	StatsReporter() AllocStatsReporter
 	Listener() *cstructs.AllocListener
 	GetAllocDir() allocdir.Interface
@@ -64,6 +73,11 @@ type TaskStateHandler interface {
 // allocation
 type AllocStatsReporter interface {
 	LatestAllocStats(taskFilter string) (*cstructs.AllocResourceUsage, error)
+
+	// UsageWindows summarizes the usage of each task over the samples the
+	// client retains, keyed by task name. If taskFilter is set, only the
+	// window of that task is returned.
+	UsageWindows(taskFilter string) map[string]*cstructs.TaskUsageWindow
 }
 
 // HookResourceSetter is used to communicate between alloc hooks and task hooks
@@ -40,6 +40,13 @@ type allocNetworkIsolationSetter struct {
 }
 
 func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
+	a.ar.netNSPathLock.Lock()
+	a.ar.netNSPath = ""
+	if n != nil && n.Mode == drivers.NetIsolationModeGroup {
+		a.ar.netNSPath = n.Path
+	}
+	a.ar.netNSPathLock.Unlock()
+
 	for _, tr := range a.ar.tasks {
 		tr.SetNetworkIsolation(n)
 	}
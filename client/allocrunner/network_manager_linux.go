@@ -197,6 +197,7 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		if err != nil {
 			return nil, err
 		}
+		c.conntrackLimit = config.BridgeNetworkConntrackLimit
 		return &synchronizedNetworkConfigurator{c}, nil
 	case strings.HasPrefix(netMode, "cni/"):
 		c, err := newCNINetworkConfigurator(log, config.CNIPath, config.CNIInterfacePrefix, config.CNIConfigDir, netMode[4:], ignorePortMappingHostIP, config.Node)
@@ -9,6 +9,7 @@ import (
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/cni"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -36,6 +37,7 @@ type bridgeNetworkConfigurator struct {
 	allocSubnetIPv4 string
 	bridgeName      string
 	hairpinMode     bool
+	conntrackLimit  int
 
 	newIPTables func(structs.NodeNetworkAF) (IPTablesChain, error)
 
@@ -125,7 +127,42 @@ func (b *bridgeNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Al
 		return nil, fmt.Errorf("failed to initialize table forwarding rules: %v", err)
 	}
 
-	return b.cni.Setup(ctx, alloc, spec)
+	status, err := b.cni.Setup(ctx, alloc, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.conntrackLimit > 0 {
+		err := nsutil.WithNetNSPath(spec.Path, func(nsutil.NetNS) error {
+			return b.ensureConntrackLimit()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to limit connections of the alloc network: %v", err)
+		}
+	}
+	return status, nil
+}
+
+// ensureConntrackLimit adds the rules rejecting new connections once the
+// connections tracked in the network namespace it is called from reach the
+// limit. The rules are part of the namespace, so they are removed along with
+// it.
+func (b *bridgeNetworkConfigurator) ensureConntrackLimit() error {
+	families := []structs.NodeNetworkAF{structs.NodeNetworkAF_IPv4}
+	if b.allocSubnetIPv6 != "" {
+		families = append(families, structs.NodeNetworkAF_IPv6)
+	}
+
+	for _, family := range families {
+		ipt, err := b.newIPTables(family)
+		if err != nil {
+			return err
+		}
+		if err := ensureConntrackLimitRules(ipt, b.conntrackLimit); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Teardown calls the CNI plugins with the delete action
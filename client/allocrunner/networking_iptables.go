@@ -7,6 +7,7 @@ package allocrunner
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/coreos/go-iptables/iptables"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -16,6 +17,10 @@ const (
 	// cniAdminChainName is the name of the admin iptables chain used to allow
 	// forwarding traffic to allocations
 	cniAdminChainName = "NOMAD-ADMIN"
+
+	// conntrackLimitChainName is the name of the iptables chain of an alloc
+	// network namespace which limits the connections it tracks
+	conntrackLimitChainName = "NOMAD-CONNTRACK-LIMIT"
 )
 
 // newIPTables provides an *iptables.IPTables for the requested address family
@@ -95,6 +100,35 @@ func appendChainRule(ipt IPTablesChain, chain string, rule []string) error {
 	return err
 }
 
+// ensureConntrackLimitRules ensures that new connections in or out of the
+// network namespace of ipt are rejected once it tracks limit connections.
+// Connections over the loopback interface are not counted, since they stay
+// within the allocation. Both directions jump to the same rule so that they
+// share a single count.
+func ensureConntrackLimitRules(ipt IPTablesChain, limit int) error {
+	if err := ensureChain(ipt, "filter", conntrackLimitChainName); err != nil {
+		return err
+	}
+	if err := appendChainRule(ipt, conntrackLimitChainName, generateConntrackLimitRule(limit)); err != nil {
+		return err
+	}
+	if err := appendChainRule(ipt, "INPUT", []string{"!", "-i", "lo", "-j", conntrackLimitChainName}); err != nil {
+		return err
+	}
+	return appendChainRule(ipt, "OUTPUT", []string{"!", "-o", "lo", "-j", conntrackLimitChainName})
+}
+
+// generateConntrackLimitRule builds the iptables rule rejecting new
+// connections above limit. A mask of 0 counts the connections of every
+// address together.
+func generateConntrackLimitRule(limit int) []string {
+	return []string{
+		"-m", "conntrack", "--ctstate", "NEW",
+		"-m", "connlimit", "--connlimit-above", strconv.Itoa(limit), "--connlimit-mask", "0",
+		"-j", "REJECT",
+	}
+}
+
 // generateAdminChainRule builds the iptables rule that is inserted into the
 // CNI admin chain to ensure traffic forwarding to the bridge network
 func generateAdminChainRule(bridgeName, subnet string) []string {
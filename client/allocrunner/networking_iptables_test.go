@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/coreos/go-iptables/iptables"
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
 )
@@ -49,6 +50,56 @@ func TestIPTables_ensureChainRule(t *testing.T) {
 	must.Eq(t, ipt.rules, []string{"-o", "test-bridge", "-d", "1.1.1.1/1", "-j", "ACCEPT"})
 }
 
+func TestIPTables_ensureConntrackLimitRules(t *testing.T) {
+	ci.Parallel(t)
+
+	ipt := &memIPTablesChain{chains: map[string][][]string{"INPUT": nil, "OUTPUT": nil}}
+
+	// ensuring twice, such as when the client restores the alloc, doesn't
+	// add the rules again
+	for range 2 {
+		must.NoError(t, ensureConntrackLimitRules(ipt, 4096))
+	}
+	must.Eq(t, map[string][][]string{
+		"INPUT":  {{"!", "-i", "lo", "-j", conntrackLimitChainName}},
+		"OUTPUT": {{"!", "-o", "lo", "-j", conntrackLimitChainName}},
+		conntrackLimitChainName: {{
+			"-m", "conntrack", "--ctstate", "NEW",
+			"-m", "connlimit", "--connlimit-above", "4096", "--connlimit-mask", "0",
+			"-j", "REJECT",
+		}},
+	}, ipt.chains)
+}
+
+// memIPTablesChain keeps the rules of each chain of the filter table.
+type memIPTablesChain struct {
+	chains map[string][][]string
+}
+
+func (ipt *memIPTablesChain) ListChains(string) ([]string, error) {
+	var chains []string
+	for chain := range ipt.chains {
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+func (ipt *memIPTablesChain) NewChain(_ string, chain string) error {
+	ipt.chains[chain] = nil
+	return nil
+}
+
+func (ipt *memIPTablesChain) Exists(_ string, chain string, rulespec ...string) (bool, error) {
+	return slices.ContainsFunc(ipt.chains[chain], func(rule []string) bool {
+		return slices.Equal(rule, rulespec)
+	}), nil
+}
+
+func (ipt *memIPTablesChain) Append(_ string, chain string, rulespec ...string) error {
+	ipt.chains[chain] = append(ipt.chains[chain], rulespec)
+	return nil
+}
+
 type mockIPTablesCleanup struct {
 	listCall  [2]string
 	listRules []string
@@ -27,6 +27,10 @@ type State struct {
 
 	// NetworkStatus captures network details not known until runtime
 	NetworkStatus *structs.AllocNetworkStatus
+
+	// UsageSummary is the summary of the allocation's resource usage. It is
+	// only set on the state acknowledged by the servers.
+	UsageSummary *structs.AllocUsageSummary
 }
 
 // SetDeploymentStatus is a helper for updating the client-controlled
@@ -65,6 +69,7 @@ func (s *State) Copy() *State {
 		DeploymentStatus:  s.DeploymentStatus.Copy(),
 		TaskStates:        taskStates,
 		NetworkStatus:     s.NetworkStatus.Copy(),
+		UsageSummary:      s.UsageSummary.Copy(),
 	}
 }
 
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// AllocStatsBatcher multiplexes the stats streams of an allocation's tasks
+// over a single AllocStats RPC per driver, instead of one TaskStats RPC per
+// task. It is shared by the task runners of an allocation.
+type AllocStatsBatcher struct {
+	streams map[string]*allocStatsStream
+	mu      sync.Mutex
+
+	logger hclog.Logger
+}
+
+// allocStatsStream is an AllocStats stream of one driver. Each driver has a
+// stream shared by its tasks, and tasks which restarted their subscription
+// while the shared stream still collected them get a stream of their own.
+type allocStatsStream struct {
+	key        string
+	driverName string
+	driver     drivers.AllocStatsDriver
+	subs       map[string]*allocStatsSub
+
+	// taskIDs are the tasks the driver's stream collects, which may include
+	// tasks that unsubscribed since it was started
+	taskIDs map[string]struct{}
+
+	// gen is incremented every time the stream is restarted, so a stream
+	// goroutine can tell whether it is still current
+	gen    uint64
+	cancel context.CancelFunc
+}
+
+// allocStatsSub is a single task's subscription to its driver's stream.
+type allocStatsSub struct {
+	interval time.Duration
+	ch       chan *cstructs.TaskResourceUsage
+}
+
+// NewAllocStatsBatcher returns an AllocStatsBatcher for an allocation.
+func NewAllocStatsBatcher(logger hclog.Logger) *AllocStatsBatcher {
+	return &AllocStatsBatcher{
+		streams: map[string]*allocStatsStream{},
+		logger:  logger.Named("alloc_stats"),
+	}
+}
+
+// Subscribe returns a channel of the task's resource usage, with the same
+// semantics as the driver's TaskStats: the channel is closed when ctx is
+// canceled or the driver's stream fails, and the caller is expected to
+// subscribe again. The driver's shared stream is restarted to include a new
+// task and runs at the shortest interval of any subscribed task. A task
+// which subscribes again while the shared stream still collects it, such as
+// after its stream stalled, gets a stream of its own so the other tasks'
+// streams are not interrupted.
+func (b *AllocStatsBatcher) Subscribe(ctx context.Context, driverName string,
+	driver drivers.AllocStatsDriver, taskID string, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := driverName
+	own := driverName + "/" + taskID
+	if _, ok := b.streams[own]; ok {
+		key = own
+	} else if shared, ok := b.streams[driverName]; ok {
+		if _, ok := shared.taskIDs[taskID]; ok {
+			// Move the task to a stream of its own rather than restarting
+			// the shared one
+			if old, ok := shared.subs[taskID]; ok {
+				delete(shared.subs, taskID)
+				close(old.ch)
+			}
+			if len(shared.subs) == 0 {
+				b.closeLocked(shared)
+			} else {
+				key = own
+			}
+		}
+	}
+
+	s, ok := b.streams[key]
+	if !ok {
+		s = &allocStatsStream{
+			key:        key,
+			driverName: driverName,
+			subs:       map[string]*allocStatsSub{},
+		}
+		b.streams[key] = s
+	}
+	// The driver may have been redispensed since the stream was started
+	s.driver = driver
+
+	if old, ok := s.subs[taskID]; ok {
+		close(old.ch)
+	}
+	sub := &allocStatsSub{
+		interval: interval,
+		ch:       make(chan *cstructs.TaskResourceUsage, 1),
+	}
+	s.subs[taskID] = sub
+
+	if err := b.restartLocked(s); err != nil {
+		delete(s.subs, taskID)
+		b.closeLocked(s)
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(s, taskID, sub)
+	}()
+
+	return sub.ch, nil
+}
+
+// unsubscribe removes a task's subscription. The stream keeps running for
+// the remaining tasks, and drops the task the next time it is restarted.
+func (b *AllocStatsBatcher) unsubscribe(s *allocStatsStream, taskID string, sub *allocStatsSub) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if s.subs[taskID] != sub {
+		// Already closed by a failed stream or replaced
+		return
+	}
+	delete(s.subs, taskID)
+	close(sub.ch)
+
+	if len(s.subs) == 0 {
+		b.closeLocked(s)
+	}
+}
+
+// restartLocked stops the driver's current stream, if any, and starts a new
+// one for the subscribed tasks. b.mu must be held.
+func (b *AllocStatsBatcher) restartLocked(s *allocStatsStream) error {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.gen++
+	s.taskIDs = nil
+
+	taskIDs := make([]string, 0, len(s.subs))
+	var interval time.Duration
+	for id, sub := range s.subs {
+		taskIDs = append(taskIDs, id)
+		if interval == 0 || sub.interval < interval {
+			interval = sub.interval
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := s.driver.AllocStats(ctx, taskIDs, interval)
+	if err != nil {
+		cancel()
+		return err
+	}
+	s.cancel = cancel
+	s.taskIDs = make(map[string]struct{}, len(taskIDs))
+	for _, id := range taskIDs {
+		s.taskIDs[id] = struct{}{}
+	}
+
+	go b.forward(s, s.gen, ch)
+	return nil
+}
+
+// forward delivers the batches of a driver stream to the subscribed tasks
+// until the stream ends. If the stream ends without having been restarted,
+// every subscription is closed so the tasks' stats hooks retry.
+func (b *AllocStatsBatcher) forward(s *allocStatsStream, gen uint64, ch <-chan map[string]*cstructs.TaskResourceUsage) {
+	for batch := range ch {
+		b.mu.Lock()
+		if s.gen != gen {
+			b.mu.Unlock()
+			continue
+		}
+		for id, ru := range batch {
+			sub, ok := s.subs[id]
+			if !ok {
+				continue
+			}
+			// Never block the stream on a slow task; drop its older sample
+			select {
+			case sub.ch <- ru:
+			default:
+				select {
+				case <-sub.ch:
+				default:
+				}
+				sub.ch <- ru
+			}
+		}
+		b.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s.gen == gen {
+		b.closeLocked(s)
+	}
+}
+
+// closeLocked stops the driver's stream and closes every subscription to
+// it. b.mu must be held.
+func (b *AllocStatsBatcher) closeLocked(s *allocStatsStream) {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.gen++
+	for id, sub := range s.subs {
+		close(sub.ch)
+		delete(s.subs, id)
+	}
+	s.taskIDs = nil
+	if b.streams[s.key] == s {
+		delete(b.streams, s.key)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// mockAllocStatsDriver records the AllocStats streams opened against it.
+type mockAllocStatsDriver struct {
+	mu      sync.Mutex
+	streams []*mockAllocStatsCall
+}
+
+type mockAllocStatsCall struct {
+	taskIDs  []string
+	interval time.Duration
+	ch       chan map[string]*cstructs.TaskResourceUsage
+	ctx      context.Context
+}
+
+func (m *mockAllocStatsDriver) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*cstructs.TaskResourceUsage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := slices.Clone(taskIDs)
+	slices.Sort(ids)
+	call := &mockAllocStatsCall{
+		taskIDs:  ids,
+		interval: interval,
+		ch:       make(chan map[string]*cstructs.TaskResourceUsage),
+		ctx:      ctx,
+	}
+	m.streams = append(m.streams, call)
+	return call.ch, nil
+}
+
+func (m *mockAllocStatsDriver) last() *mockAllocStatsCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.streams[len(m.streams)-1]
+}
+
+func TestAllocStatsBatcher(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &mockAllocStatsDriver{}
+	b := NewAllocStatsBatcher(testlog.HCLogger(t))
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	chA, err := b.Subscribe(ctxA, "mock", d, "a", time.Second)
+	must.NoError(t, err)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	chB, err := b.Subscribe(ctxB, "mock", d, "b", 500*time.Millisecond)
+	must.NoError(t, err)
+
+	// Subscribing restarts the stream for every task at the shortest interval
+	call := d.last()
+	must.Eq(t, []string{"a", "b"}, call.taskIDs)
+	must.Eq(t, 500*time.Millisecond, call.interval)
+	must.Len(t, 2, d.streams)
+	must.Error(t, d.streams[0].ctx.Err())
+
+	call.ch <- map[string]*cstructs.TaskResourceUsage{
+		"a": {Timestamp: 1},
+		"b": {Timestamp: 2},
+	}
+	must.Eq(t, int64(1), (<-chA).Timestamp)
+	must.Eq(t, int64(2), (<-chB).Timestamp)
+
+	// A task subscribing again while the shared stream collects it, such as
+	// after its stream stalled, gets its own stream without interrupting
+	// the others
+	cancelB()
+	_, ok := <-chB
+	must.False(t, ok)
+	ctxB, cancelB = context.WithCancel(context.Background())
+	defer cancelB()
+	chB, err = b.Subscribe(ctxB, "mock", d, "b", 500*time.Millisecond)
+	must.NoError(t, err)
+	must.Len(t, 3, d.streams)
+	must.NoError(t, call.ctx.Err())
+	own := d.last()
+	must.Eq(t, []string{"b"}, own.taskIDs)
+
+	call.ch <- map[string]*cstructs.TaskResourceUsage{
+		"a": {Timestamp: 3},
+		"b": {Timestamp: 3},
+	}
+	must.Eq(t, int64(3), (<-chA).Timestamp)
+	own.ch <- map[string]*cstructs.TaskResourceUsage{"b": {Timestamp: 4}}
+	must.Eq(t, int64(4), (<-chB).Timestamp)
+
+	// Unsubscribing a task leaves the stream running for the others
+	cancelB()
+	_, ok = <-chB
+	must.False(t, ok)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return own.ctx.Err() != nil }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.NoError(t, call.ctx.Err())
+	must.Len(t, 3, d.streams)
+
+	// A new task restarts the shared stream, which drops departed tasks
+	ctxC, cancelC := context.WithCancel(context.Background())
+	defer cancelC()
+	_, err = b.Subscribe(ctxC, "mock", d, "c", time.Second)
+	must.NoError(t, err)
+	must.Error(t, call.ctx.Err())
+	call = d.last()
+	must.Eq(t, []string{"a", "c"}, call.taskIDs)
+	must.Eq(t, time.Second, call.interval)
+
+	// A failed stream closes every subscription
+	close(call.ch)
+	_, ok = <-chA
+	must.False(t, ok)
+}
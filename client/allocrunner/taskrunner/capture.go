@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/stacksample"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// captureDirName is the directory of the shared alloc dir captures are
+	// written to
+	captureDirName = "captures"
+
+	// defaultCaptureDuration and maxCaptureDuration bound how long a stack
+	// or profile capture samples for
+	defaultCaptureDuration = 5 * time.Second
+	maxCaptureDuration     = time.Minute
+
+	// captureSampleInterval is how often stacks are sampled during a capture
+	captureSampleInterval = 50 * time.Millisecond
+)
+
+// Capture records what the task's main process is doing and writes the
+// result to a file in the shared alloc dir. It returns the path of the file
+// relative to the alloc dir.
+func (tr *TaskRunner) Capture(ctx context.Context, mode string, duration time.Duration) (string, error) {
+	if mode == "" {
+		mode = cstructs.CaptureModeStack
+	}
+	switch {
+	case duration == 0:
+		duration = defaultCaptureDuration
+	case duration < 0 || duration > maxCaptureDuration:
+		return "", fmt.Errorf("capture duration must be between 0 and %s", maxCaptureDuration)
+	}
+
+	ext := "txt"
+	var report func(*os.File) error
+	switch mode {
+	case cstructs.CaptureModeStack:
+		procs, err := tr.Processes()
+		if err != nil {
+			return "", err
+		}
+		pid, ok := mainProcess(procs)
+		if !ok {
+			return "", fmt.Errorf("task %q has no processes", tr.taskName)
+		}
+
+		profile, err := stacksample.Sample(ctx, pid, duration, captureSampleInterval)
+		if err != nil {
+			return "", err
+		}
+		report = func(f *os.File) error {
+			_, err := profile.WriteTo(f)
+			return err
+		}
+
+	case cstructs.CaptureModeSignal:
+		event := structs.NewTaskEvent(structs.TaskSignaling).
+			SetSignalText("SIGQUIT").
+			SetDisplayMessage("Capture requested, sending SIGQUIT")
+		if err := tr.Signal(event, "SIGQUIT"); err != nil {
+			return "", err
+		}
+		sentAt := time.Now()
+		report = func(f *os.File) error {
+			_, err := fmt.Fprintf(f, "SIGQUIT sent to task %q at %s.\n"+
+				"Go and JVM runtimes write a dump of their threads to stderr, "+
+				"which is collected in %s.\n",
+				tr.taskName, sentAt.UTC().Format(time.RFC3339),
+				filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName, tr.taskName+".stderr.*"))
+			return err
+		}
+
+	case cstructs.CaptureModeProfile:
+		handle := tr.getDriverHandle()
+		if handle == nil {
+			return "", ErrTaskNotRunning
+		}
+		if tr.driverCapabilities == nil || !tr.driverCapabilities.Profile {
+			return "", ErrProfileNotSupported
+		}
+
+		folded, err := handle.Profile(ctx, duration)
+		if err != nil {
+			return "", err
+		}
+		ext = "folded"
+		report = func(f *os.File) error {
+			_, err := f.Write(folded)
+			return err
+		}
+
+	default:
+		return "", fmt.Errorf("unknown capture mode %q", mode)
+	}
+
+	name := fmt.Sprintf("%s.%s.%s.%s", tr.taskName, mode, time.Now().UTC().Format("20060102T150405Z"), ext)
+	dir := filepath.Join(tr.taskDir.SharedAllocDir, captureDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to create capture file: %w", err)
+	}
+	defer f.Close()
+
+	if err := report(f); err != nil {
+		return "", fmt.Errorf("failed to write capture file: %w", err)
+	}
+
+	return filepath.Join(allocdir.SharedAllocName, captureDirName, name), nil
+}
+
+// mainProcess returns the root of the task's process tree: the process whose
+// parent is not part of the task. If there are several, the one with the
+// lowest pid is returned.
+func mainProcess(procs []*cstructs.TaskProcess) (int, bool) {
+	pids := make(map[int]struct{}, len(procs))
+	for _, p := range procs {
+		pids[p.Pid] = struct{}{}
+	}
+
+	main, found := 0, false
+	for _, p := range procs {
+		if _, ok := pids[p.Ppid]; ok {
+			continue
+		}
+		if !found || p.Pid < main {
+			main, found = p.Pid, true
+		}
+	}
+	return main, found
+}
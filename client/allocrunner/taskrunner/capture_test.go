@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/shoenig/test/must"
+)
+
+func TestTaskRunner_Capture(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+
+	tr, conf, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForTaskToStart(t, tr)
+
+	_, err := tr.Capture(context.Background(), "bogus", 0)
+	must.ErrorContains(t, err, "unknown capture mode")
+
+	_, err = tr.Capture(context.Background(), cstructs.CaptureModeSignal, time.Hour)
+	must.ErrorContains(t, err, "capture duration")
+
+	path, err := tr.Capture(context.Background(), cstructs.CaptureModeSignal, 0)
+	must.NoError(t, err)
+	must.StrHasPrefix(t, "alloc/captures/"+task.Name+".sigquit.", path)
+
+	data, err := os.ReadFile(filepath.Join(conf.ClientConfig.AllocDir, alloc.ID, path))
+	must.NoError(t, err)
+	must.True(t, strings.Contains(string(data), "SIGQUIT sent"))
+
+	path, err = tr.Capture(context.Background(), cstructs.CaptureModeProfile, time.Second)
+	must.NoError(t, err)
+	must.StrHasSuffix(t, ".folded", path)
+
+	data, err = os.ReadFile(filepath.Join(conf.ClientConfig.AllocDir, alloc.ID, path))
+	must.NoError(t, err)
+	must.Eq(t, "mock;"+task.Name+" 1\n", string(data))
+}
+
+func TestMainProcess(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ok := mainProcess(nil)
+	must.False(t, ok)
+
+	pid, ok := mainProcess([]*cstructs.TaskProcess{
+		{Pid: 12, Ppid: 10},
+		{Pid: 10, Ppid: 1},
+		{Pid: 11, Ppid: 10},
+	})
+	must.True(t, ok)
+	must.Eq(t, 10, pid)
+
+	// several roots, such as processes reparented after their parent exited
+	pid, ok = mainProcess([]*cstructs.TaskProcess{
+		{Pid: 30, Ppid: 1},
+		{Pid: 20, Ppid: 1},
+	})
+	must.True(t, ok)
+	must.Eq(t, 20, pid)
+}
@@ -37,6 +37,11 @@ type DriverHandle struct {
 	taskID      string
 	killSignal  string
 	killTimeout time.Duration
+
+	// allocStats batches the stats of the allocation's tasks when the driver
+	// supports it. If nil, stats are streamed per task.
+	allocStats *AllocStatsBatcher
+	driverName string
 }
 
 func (h *DriverHandle) ID() string {
@@ -57,9 +62,33 @@ func (h *DriverHandle) Kill() error {
 }
 
 func (h *DriverHandle) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+	if h.allocStats != nil {
+		if d, ok := h.driver.(drivers.AllocStatsDriver); ok {
+			return h.allocStats.Subscribe(ctx, h.driverName, d, h.taskID, interval)
+		}
+	}
 	return h.driver.TaskStats(ctx, h.taskID, interval)
 }
 
+// Processes returns a snapshot of the processes running in the task.
+func (h *DriverHandle) Processes() ([]*cstructs.TaskProcess, error) {
+	d, ok := h.driver.(drivers.ProcessListDriver)
+	if !ok {
+		return nil, ErrProcessesNotSupported
+	}
+	return d.TaskProcesses(h.taskID)
+}
+
+// Profile samples the call stacks of the task's processes for the duration
+// and returns them folded.
+func (h *DriverHandle) Profile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	d, ok := h.driver.(drivers.ProfileDriver)
+	if !ok {
+		return nil, ErrProfileNotSupported
+	}
+	return d.ProfileTask(ctx, h.taskID, duration)
+}
+
 func (h *DriverHandle) Signal(s string) error {
 	return h.driver.SignalTask(h.taskID, s)
 }
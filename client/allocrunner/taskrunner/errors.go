@@ -10,11 +10,15 @@ import (
 )
 
 const (
-	errTaskNotRunning = "Task not running"
+	errTaskNotRunning        = "Task not running"
+	errProcessesNotSupported = "Task driver does not support listing processes"
+	errProfileNotSupported   = "Task driver does not support profiling"
 )
 
 var (
-	ErrTaskNotRunning = errors.New(errTaskNotRunning)
+	ErrTaskNotRunning        = errors.New(errTaskNotRunning)
+	ErrProcessesNotSupported = errors.New(errProcessesNotSupported)
+	ErrProfileNotSupported   = errors.New(errProfileNotSupported)
 )
 
 // NewHookError contains an underlying err and a pre-formatted task event.
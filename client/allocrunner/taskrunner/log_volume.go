@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/logmon"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// logVolumeInterval is the minimum interval between reads of the counters of
+// logmon, which are an RPC to the logmon process. Stats samples taken in
+// between report the last log volume read.
+const logVolumeInterval = 10 * time.Second
+
+// logVolume computes the volume of output of a task from the counters of its
+// logmon process, which the logmon hook sets when it launches or reattaches
+// to logmon. The zero value is ready to use and reports nothing until logmon
+// is set.
+type logVolume struct {
+	mu     sync.Mutex
+	logmon logmon.LogMon
+
+	// prev is the last sample of the counters of logmon, taken at the
+	// timestamp of prevTimestamp
+	prev          *logmon.LogStats
+	prevTimestamp int64
+
+	// read is the timestamp of the sample logmon was last read for, and last
+	// the log volume computed then, which samples taken less than
+	// logVolumeInterval later report. last is never mutated so it can be
+	// shared between samples.
+	read int64
+	last *cstructs.TaskLogStats
+
+	// offset is the output counted by logmon processes replaced since, such
+	// as after logmon crashed and was relaunched
+	offset logmon.LogStats
+}
+
+// setLogMon sets the logmon process to read the counters from. The counters
+// of a newly launched process start from zero, so the output counted by the
+// previous one is carried over, while a process reattached to keeps counting
+// from where it was.
+func (v *logVolume) setLogMon(l logmon.LogMon, reattached bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.logmon != nil && v.prev != nil && !reattached {
+		v.offset.Stdout = addLogStreamStats(v.offset.Stdout, v.prev.Stdout)
+		v.offset.Stderr = addLogStreamStats(v.offset.Stderr, v.prev.Stderr)
+		v.prev = &logmon.LogStats{}
+	}
+	v.logmon = l
+	v.read, v.last = 0, nil
+}
+
+// record sets the log volume of the resource usage sample, if logmon can be
+// read.
+func (v *logVolume) record(ru *cstructs.TaskResourceUsage) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.logmon == nil {
+		return
+	}
+	if v.read != 0 && time.Duration(ru.Timestamp-v.read) < logVolumeInterval {
+		ru.Logs = v.last
+		return
+	}
+	v.read = ru.Timestamp
+
+	cur, err := v.logmon.Stats()
+	if err != nil {
+		// logmon processes launched by older clients don't count their
+		// output
+		v.last = nil
+		return
+	}
+
+	var elapsed float64
+	if v.prev != nil && ru.Timestamp > v.prevTimestamp {
+		elapsed = time.Duration(ru.Timestamp - v.prevTimestamp).Seconds()
+	}
+	var prev logmon.LogStats
+	if v.prev != nil {
+		prev = *v.prev
+	}
+
+	// Counters going backwards come from a logmon process that was
+	// relaunched without the hook noticing
+	if cur.Stdout.Bytes < prev.Stdout.Bytes || cur.Stderr.Bytes < prev.Stderr.Bytes {
+		v.offset.Stdout = addLogStreamStats(v.offset.Stdout, prev.Stdout)
+		v.offset.Stderr = addLogStreamStats(v.offset.Stderr, prev.Stderr)
+		prev = logmon.LogStats{}
+	}
+
+	ru.Logs = &cstructs.TaskLogStats{
+		Stdout: logStreamStats(v.offset.Stdout, prev.Stdout, cur.Stdout, elapsed),
+		Stderr: logStreamStats(v.offset.Stderr, prev.Stderr, cur.Stderr, elapsed),
+	}
+	v.prev, v.prevTimestamp, v.last = cur, ru.Timestamp, ru.Logs
+}
+
+func logStreamStats(offset, prev, cur logmon.LogStreamStats, elapsed float64) *cstructs.LogStreamStats {
+	total := addLogStreamStats(offset, cur)
+	stats := &cstructs.LogStreamStats{
+		Bytes:        total.Bytes,
+		Lines:        total.Lines,
+		DroppedBytes: total.DroppedBytes,
+		DroppedLines: total.DroppedLines,
+	}
+	if elapsed > 0 {
+		stats.BytesPerSecond = float64(cur.Bytes-prev.Bytes) / elapsed
+		stats.LinesPerSecond = float64(cur.Lines-prev.Lines) / elapsed
+	}
+	return stats
+}
+
+func addLogStreamStats(a, b logmon.LogStreamStats) logmon.LogStreamStats {
+	return logmon.LogStreamStats{
+		Bytes:        a.Bytes + b.Bytes,
+		Lines:        a.Lines + b.Lines,
+		DroppedBytes: a.DroppedBytes + b.DroppedBytes,
+		DroppedLines: a.DroppedLines + b.DroppedLines,
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/logmon"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+// fakeLogMon is a logmon.LogMon reporting the stats it is set to
+type fakeLogMon struct {
+	stats *logmon.LogStats
+	err   error
+}
+
+func (f *fakeLogMon) Start(*logmon.LogConfig) error { return nil }
+func (f *fakeLogMon) Stop() error                   { return nil }
+func (f *fakeLogMon) FramedLogs() bool              { return false }
+func (f *fakeLogMon) Stats() (*logmon.LogStats, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	stats := *f.stats
+	return &stats, nil
+}
+
+func TestLogVolume_record(t *testing.T) {
+	ci.Parallel(t)
+
+	var v logVolume
+	start := time.Now()
+	sample := func(offset time.Duration) *cstructs.TaskResourceUsage {
+		ru := &cstructs.TaskResourceUsage{Timestamp: start.Add(offset).UnixNano()}
+		v.record(ru)
+		return ru
+	}
+
+	// Nothing is reported until logmon is launched
+	must.Nil(t, sample(0).Logs)
+
+	lm := &fakeLogMon{stats: &logmon.LogStats{
+		Stdout: logmon.LogStreamStats{Bytes: 1000, Lines: 10},
+	}}
+	v.setLogMon(lm, false)
+	must.Eq(t, &cstructs.TaskLogStats{
+		Stdout: &cstructs.LogStreamStats{Bytes: 1000, Lines: 10},
+		Stderr: &cstructs.LogStreamStats{},
+	}, sample(0).Logs)
+
+	lm.stats.Stdout = logmon.LogStreamStats{Bytes: 21000, Lines: 210}
+	lm.stats.Stderr = logmon.LogStreamStats{Bytes: 1000, Lines: 10}
+	expected := &cstructs.TaskLogStats{
+		Stdout: &cstructs.LogStreamStats{Bytes: 21000, Lines: 210, BytesPerSecond: 2000, LinesPerSecond: 20},
+		Stderr: &cstructs.LogStreamStats{Bytes: 1000, Lines: 10, BytesPerSecond: 100, LinesPerSecond: 1},
+	}
+	must.Eq(t, expected, sample(10*time.Second).Logs)
+
+	// Samples taken within logVolumeInterval of the last read report it
+	// without reading logmon again
+	lm.stats.Stdout = logmon.LogStreamStats{Bytes: 50000, Lines: 500}
+	must.Eq(t, expected, sample(15*time.Second).Logs)
+
+	// A relaunched logmon counts from zero, and the output counted by the
+	// previous one is carried over
+	v.setLogMon(&fakeLogMon{stats: &logmon.LogStats{
+		Stdout: logmon.LogStreamStats{Bytes: 4000, Lines: 40},
+	}}, false)
+	must.Eq(t, &cstructs.TaskLogStats{
+		Stdout: &cstructs.LogStreamStats{Bytes: 25000, Lines: 250, BytesPerSecond: 400, LinesPerSecond: 4},
+		Stderr: &cstructs.LogStreamStats{Bytes: 1000, Lines: 10},
+	}, sample(20*time.Second).Logs)
+
+	// Errors reading logmon leave the sample without log stats
+	v.setLogMon(&fakeLogMon{err: errors.New("unimplemented")}, false)
+	must.Nil(t, sample(30*time.Second).Logs)
+}
+
+func TestLogVolume_record_reattach(t *testing.T) {
+	ci.Parallel(t)
+
+	var v logVolume
+	start := time.Now()
+	sample := func(offset time.Duration) *cstructs.TaskResourceUsage {
+		ru := &cstructs.TaskResourceUsage{Timestamp: start.Add(offset).UnixNano()}
+		v.record(ru)
+		return ru
+	}
+
+	lm := &fakeLogMon{stats: &logmon.LogStats{
+		Stdout: logmon.LogStreamStats{Bytes: 1000, Lines: 10},
+	}}
+	v.setLogMon(lm, false)
+	sample(0)
+
+	// A reattached logmon process keeps its counters, so they are neither
+	// carried over nor reset
+	v.setLogMon(&fakeLogMon{stats: &logmon.LogStats{
+		Stdout: logmon.LogStreamStats{Bytes: 3000, Lines: 30},
+	}}, true)
+	must.Eq(t, &cstructs.TaskLogStats{
+		Stdout: &cstructs.LogStreamStats{Bytes: 3000, Lines: 30, BytesPerSecond: 200, LinesPerSecond: 2},
+		Stderr: &cstructs.LogStreamStats{},
+	}, sample(10*time.Second).Logs)
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
@@ -28,6 +29,11 @@ const (
 	// logmonReattachKey is the HookData key where logmon's reattach config
 	// is stored.
 	logmonReattachKey = "reattach_config"
+
+	// logmonFramedKey is the HookData key where whether the task's output is
+	// framed is stored, so a logmon relaunched while the task runs decodes it
+	// the same way.
+	logmonFramedKey = "framed_logs"
 )
 
 // logmonHook launches logmon and manages task logging
@@ -48,6 +54,10 @@ type logmonHookConfig struct {
 	disabled   bool
 	stdoutFifo string
 	stderrFifo string
+
+	// framedLogs is true if the executor frames the task's output, which is
+	// only known once logmon is started
+	framedLogs bool
 }
 
 func newLogMonHook(tr *TaskRunner, logger hclog.Logger) *logmonHook {
@@ -96,6 +106,7 @@ func (h *logmonHook) launchLogMon(reattachConfig *plugin.ReattachConfig) error {
 
 	h.logmon = l
 	h.logmonPluginClient = c
+	h.runner.logVolume.setLogMon(l, reattachConfig != nil)
 	return nil
 }
 
@@ -113,6 +124,16 @@ func reattachConfigFromHookData(data map[string]string) (*plugin.ReattachConfig,
 	return pstructs.ReattachConfigToGoPlugin(&cfg)
 }
 
+// framedFromHookData returns true if the task's output is to be framed. Once a
+// task was started its framing is kept, since its executor may still be
+// running. Tasks started by older clients aren't framed.
+func framedFromHookData(data map[string]string) bool {
+	if data == nil || data[logmonReattachKey] == "" {
+		return true
+	}
+	return data[logmonFramedKey] == "true"
+}
+
 func (h *logmonHook) Prestart(ctx context.Context,
 	req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
 	if h.isLoggingDisabled() {
@@ -145,7 +166,10 @@ func (h *logmonHook) Prestart(ctx context.Context,
 		if err != nil {
 			return err
 		}
-		resp.State = map[string]string{logmonReattachKey: string(jsonCfg)}
+		resp.State = map[string]string{
+			logmonReattachKey: string(jsonCfg),
+			logmonFramedKey:   strconv.FormatBool(h.config.framedLogs),
+		}
 		return nil
 	}
 }
@@ -192,6 +216,7 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
+	framed := framedFromHookData(req.PreviousState)
 	err := h.logmon.Start(&logmon.LogConfig{
 		LogDir:        h.config.logDir,
 		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
@@ -200,11 +225,19 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		StderrFifo:    h.config.stderrFifo,
 		MaxFiles:      req.Task.LogConfig.MaxFiles,
 		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
+		RateLimit: logmon.RateLimit{
+			BytesPerSecond: uint64(req.Task.LogConfig.MaxBytesPerSecond),
+			LinesPerSecond: uint64(req.Task.LogConfig.MaxLinesPerSecond),
+			Mode:           req.Task.LogConfig.RateLimitMode,
+		},
+		MultilinePattern: req.Task.LogConfig.MultilinePattern,
+		Framed:           framed,
 	})
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
 		return err
 	}
+	h.config.framedLogs = framed && h.logmon.FramedLogs()
 
 	return nil
 }
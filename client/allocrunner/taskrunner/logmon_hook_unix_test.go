@@ -11,16 +11,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logframe"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shirou/gopsutil/v3/process"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -174,3 +180,128 @@ func TestTaskRunner_LogmonHook_ShutdownMidStart(t *testing.T) {
 	require.True(t, initLogmon != hook.logmon)
 	require.True(t, initClient != hook.logmonPluginClient)
 }
+
+// TestTaskRunner_LogmonHook_Reattach_LogVolume asserts the log volume of a
+// task keeps counting the output logged by a logmon process reattached to
+// after the Nomad client restarts.
+func TestTaskRunner_LogmonHook_Reattach_LogVolume(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	dir := t.TempDir()
+
+	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
+	runner := &TaskRunner{logmonHookConfig: hookConf}
+	hook := newLogMonHook(runner, testlog.HCLogger(t))
+
+	req := interfaces.TaskPrestartRequest{
+		Task: task,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	must.NoError(t, hook.Prestart(context.Background(), &req, &resp))
+	defer hook.Stop(context.Background(), nil, nil)
+
+	stdout, err := fifo.OpenWriter(hookConf.stdoutFifo)
+	must.NoError(t, err)
+	defer stdout.Close()
+	must.True(t, hookConf.framedLogs)
+	_, err = logframe.NewWriter(stdout, logframe.StreamStdout).Write([]byte("hello\nworld\n"))
+	must.NoError(t, err)
+
+	// Simulate the client restarting by reattaching a new task runner to the
+	// running logmon
+	restarted := &TaskRunner{logmonHookConfig: hookConf}
+	req.PreviousState = resp.State
+	resp = interfaces.TaskPrestartResponse{}
+	must.NoError(t, newLogMonHook(restarted, testlog.HCLogger(t)).Prestart(context.Background(), &req, &resp))
+	must.Eq(t, req.PreviousState, resp.State)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			// Sample far enough apart to read logmon every time
+			ru := &cstructs.TaskResourceUsage{Timestamp: time.Now().Add(time.Hour).UnixNano()}
+			restarted.logVolume.read = 0
+			restarted.logVolume.record(ru)
+			if ru.Logs == nil {
+				return fmt.Errorf("no log volume reported")
+			}
+			if lines := ru.Logs.Stdout.Lines; lines != 2 {
+				return fmt.Errorf("expected 2 lines, got %d", lines)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(100*time.Millisecond),
+	))
+}
+
+// TestTaskRunner_LogmonHook_Relaunch_Framed asserts a logmon relaunched while
+// the task runs decodes its framed output, even though the executor doesn't
+// start it with a preamble again.
+func TestTaskRunner_LogmonHook_Relaunch_Framed(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	dir := t.TempDir()
+
+	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
+	runner := &TaskRunner{logmonHookConfig: hookConf}
+	hook := newLogMonHook(runner, testlog.HCLogger(t))
+
+	req := interfaces.TaskPrestartRequest{
+		Task: task,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	must.NoError(t, hook.Prestart(context.Background(), &req, &resp))
+	defer hook.Stop(context.Background(), nil, nil)
+	must.True(t, hookConf.framedLogs)
+	must.Eq(t, "true", resp.State[logmonFramedKey])
+
+	stdout, err := fifo.OpenWriter(hookConf.stdoutFifo)
+	must.NoError(t, err)
+	defer stdout.Close()
+	w := logframe.NewWriter(stdout, logframe.StreamStdout)
+
+	logPath := filepath.Join(dir, task.Name+".stdout.0")
+	waitForLog := func(expected string) {
+		must.Wait(t, wait.InitialSuccess(
+			wait.ErrorFunc(func() error {
+				b, err := os.ReadFile(logPath)
+				if err != nil {
+					return err
+				}
+				if string(b) != expected {
+					return fmt.Errorf("unexpected log output %q", b)
+				}
+				return nil
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(100*time.Millisecond),
+		))
+	}
+
+	_, err = w.Write([]byte("hello\n"))
+	must.NoError(t, err)
+	waitForLog("hello\n")
+
+	// Crash logmon and relaunch it while the task keeps writing frames
+	hook.logmonPluginClient.Kill()
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(hook.logmonPluginClient.Exited),
+		wait.Timeout(5*time.Second),
+		wait.Gap(100*time.Millisecond),
+	))
+
+	req.PreviousState = resp.State
+	resp = interfaces.TaskPrestartResponse{}
+	must.NoError(t, hook.Prestart(context.Background(), &req, &resp))
+	must.True(t, hookConf.framedLogs)
+
+	_, err = w.Write([]byte("world\n"))
+	must.NoError(t, err)
+	waitForLog("hello\nworld\n")
+}
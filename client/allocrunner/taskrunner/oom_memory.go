@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// oomMemoryResources returns the resources of the task with the memory limit
+// its OOM kills raised it to, if the restart policy of the task escalates its
+// memory. Such tasks with a memory_max are limited to their memory until they
+// are first OOM-killed, and then to their memory plus a step per OOM kill, up
+// to their memory_max, until they run for the interval of their restart
+// policy without being OOM-killed.
+func (tr *TaskRunner) oomMemoryResources(resources *structs.AllocatedTaskResources) *structs.AllocatedTaskResources {
+	if tr.oomMemoryStepMB == 0 || resources.Memory.MemoryMaxMB == 0 {
+		return resources
+	}
+
+	tr.taskResourcesLock.RLock()
+	limit := resources.Memory.MemoryMB + tr.oomMemoryRaised()
+	tr.taskResourcesLock.RUnlock()
+	if memoryMax := resources.Memory.MemoryMaxMB; memoryMax > 0 && limit > memoryMax {
+		limit = memoryMax
+	}
+
+	escalated := resources.Copy()
+	escalated.Memory.MemoryMaxMB = limit
+	return escalated
+}
+
+// oomMemoryRaised returns how much the memory limit of the task is raised,
+// which is reset once the interval of the restart policy passed since the
+// last OOM kill. It must be called with taskResourcesLock held.
+func (tr *TaskRunner) oomMemoryRaised() int64 {
+	if tr.oomMemoryInterval > 0 && time.Since(tr.oomKilledAt) >= tr.oomMemoryInterval {
+		return 0
+	}
+	return tr.oomMemoryRaisedMB
+}
+
+// escalateOOMMemory raises the memory limit the task restarts with by the
+// step of its restart policy if it exited because it was OOM-killed, unless
+// the limit already is the memory_max of the task. The raise is persisted, so
+// it is restored along with the task.
+func (tr *TaskRunner) escalateOOMMemory(result *drivers.ExitResult) {
+	if tr.oomMemoryStepMB == 0 || result == nil || !result.OOMKilled {
+		return
+	}
+	resources := tr.sharedMemoryResources(tr.getTaskResources())
+	memoryMax := resources.Memory.MemoryMaxMB
+	if memoryMax == 0 {
+		return
+	}
+	before := tr.oomMemoryResources(resources).Memory.MemoryMaxMB
+
+	// The raise is kept while the task keeps being OOM-killed at its
+	// memory_max
+	tr.taskResourcesLock.Lock()
+	raised := tr.oomMemoryRaised()
+	if before != memoryMax {
+		raised += tr.oomMemoryStepMB
+	}
+	tr.oomMemoryRaisedMB = raised
+	tr.oomKilledAt = time.Now()
+	killedAt := tr.oomKilledAt
+	tr.taskResourcesLock.Unlock()
+
+	tr.stateLock.Lock()
+	tr.localState.OOMMemoryRaisedMB = raised
+	tr.localState.OOMKilledAt = killedAt
+	tr.stateLock.Unlock()
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("failed to persist raised memory limit", "error", err)
+	}
+
+	after := tr.oomMemoryResources(resources).Memory.MemoryMaxMB
+	if after == before {
+		return
+	}
+	tr.logger.Info("raising memory limit after OOM kill", "from", before, "to", after)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskResized).
+		SetMessage(fmt.Sprintf("Raised memory limit from %d MB to %d MB after OOM kill", before, after)))
+}
+
+// restoreOOMMemory restores how much the memory limit of the task was raised
+// after OOM kills by the previous agent.
+func (tr *TaskRunner) restoreOOMMemory() {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+
+	tr.oomMemoryRaisedMB = tr.localState.OOMMemoryRaisedMB
+	tr.oomKilledAt = tr.localState.OOMKilledAt
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+// reattachPid returns the pid of the plugin process, such as an executor, the
+// driver state of a task handle reattaches to. It is 0 for handles of drivers
+// that don't run the task through a plugin process.
+func reattachPid(h *drivers.TaskHandle) int {
+	if h == nil || len(h.DriverState) == 0 {
+		return 0
+	}
+
+	// The drivers that reattach to a plugin process all persist its reattach
+	// config in the same field, and decoding ignores the other fields
+	var state struct {
+		ReattachConfig *pstructs.ReattachConfig
+	}
+	if err := h.GetDriverState(&state); err != nil || state.ReattachConfig == nil {
+		return 0
+	}
+	return state.ReattachConfig.Pid
+}
+
+// ReattachPid returns the pid of the plugin process the persisted handle of
+// the task reattaches to, or 0 if there is none.
+func (tr *TaskRunner) ReattachPid() int {
+	tr.stateLock.RLock()
+	defer tr.stateLock.RUnlock()
+	return reattachPid(tr.localState.TaskHandle)
+}
+
+// ClearStaleHandle removes the persisted handle of the task if the task is
+// dead, so the client never reattaches to the pid it refers to. It returns
+// whether a handle was removed.
+func (tr *TaskRunner) ClearStaleHandle() (bool, error) {
+	tr.stateLock.Lock()
+	if tr.state.State != structs.TaskStateDead || tr.localState.TaskHandle == nil {
+		tr.stateLock.Unlock()
+		return false, nil
+	}
+	tr.localState.TaskHandle = nil
+	tr.stateLock.Unlock()
+
+	if err := tr.persistLocalState(); err != nil {
+		return false, err
+	}
+	tr.logger.Debug("cleared stale task handle")
+	return true, nil
+}
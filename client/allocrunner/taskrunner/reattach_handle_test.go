@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestReattachPid(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Zero(t, reattachPid(nil))
+	must.Zero(t, reattachPid(drivers.NewTaskHandle(1)))
+
+	// the driver state of drivers using an executor
+	h := drivers.NewTaskHandle(1)
+	must.NoError(t, h.SetDriverState(&struct {
+		ReattachConfig *pstructs.ReattachConfig
+		TaskConfig     *drivers.TaskConfig
+	}{
+		ReattachConfig: &pstructs.ReattachConfig{Network: "unix", Addr: "/tmp/plugin", Pid: 4242},
+		TaskConfig:     &drivers.TaskConfig{ID: "task"},
+	}))
+	must.Eq(t, 4242, reattachPid(h))
+
+	// the driver state of drivers without one
+	h = drivers.NewTaskHandle(1)
+	must.NoError(t, h.SetDriverState(&struct{ ContainerID string }{ContainerID: "abc"}))
+	must.Zero(t, reattachPid(h))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// resize applies the cpu and memory of the task in the updated allocation, if
+// the scheduler resized them in place. The limits of the cgroup of a running
+// task are updated live if its driver runs it in the cgroup the client
+// manages, and otherwise the task is restarted to run with them.
+func (tr *TaskRunner) resize(update *structs.Allocation, task *structs.Task) {
+	if update.AllocatedResources == nil || task.Resources == nil {
+		return
+	}
+	updated, ok := update.AllocatedResources.Tasks[tr.taskName]
+	current := tr.getTaskResources()
+	if !ok || current == nil {
+		return
+	}
+
+	// like when the task runner is created, the memory of the secrets
+	// directory isn't part of the limits of the task
+	memoryMB := updated.Memory.MemoryMB - int64(task.Resources.SecretsMB)
+	if current.Cpu.CpuShares == updated.Cpu.CpuShares &&
+		current.Memory.MemoryMB == memoryMB &&
+		current.Memory.MemoryMaxMB == updated.Memory.MemoryMaxMB {
+		return
+	}
+
+	resized := current.Copy()
+	resized.Cpu.CpuShares = updated.Cpu.CpuShares
+	resized.Memory.MemoryMB = memoryMB
+	resized.Memory.MemoryMaxMB = updated.Memory.MemoryMaxMB
+	tr.setTaskResources(resized)
+
+	// tasks that aren't running start with the resized resources
+	if tr.getDriverHandle() == nil {
+		return
+	}
+
+	err := tr.resizeCgroup(resized)
+	if err == nil {
+		tr.logger.Debug("resized task in place", "cpu", resized.Cpu.CpuShares,
+			"memory", resized.Memory.MemoryMB, "memory_max", resized.Memory.MemoryMaxMB)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskResized).
+			SetMessage(fmt.Sprintf("Resized to %d MHz of CPU and %d MB of memory",
+				resized.Cpu.CpuShares, resized.Memory.MemoryMB)))
+		return
+	}
+
+	tr.logger.Info("restarting task to apply resized resources", "reason", err)
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason("Restarting task to apply resized resources")
+	go func() {
+		if err := tr.Restart(tr.killCtx, event, false); err != nil && !errors.Is(err, ErrTaskNotRunning) {
+			tr.logger.Warn("failed to restart task to apply resized resources", "error", err)
+		}
+	}()
+}
+
+// resizeCgroup updates the limits of the cgroup of the running task to the
+// resized resources, if its driver runs it in the cgroup the client manages.
+func (tr *TaskRunner) resizeCgroup(resources *structs.AllocatedTaskResources) error {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.SharedCgroup {
+		return fmt.Errorf("driver %q can't resize tasks in place", tr.Task().Driver)
+	}
+	resources = tr.oomMemoryResources(tr.sharedMemoryResources(resources))
+	hard, soft := memoryLimits(resources.Memory, tr.clientConfig.QoSClasses)
+	reserveCores := len(resources.Cpu.ReservedCores) > 0
+	return cgroupslib.ResizeTask(tr.allocID, tr.taskName, reserveCores, hard, soft, resources.Cpu.CpuShares)
+}
+
+// memoryLimits returns the hard limit, or -1 for none, and the soft limit in
+// bytes of the cgroup of a task with the memory resources, the way executors
+// set them when the task starts.
+func memoryLimits(mem structs.AllocatedMemoryResources, qosClasses bool) (int64, int64) {
+	const mb = structs.BytesInMegabyte
+	switch mem.MemoryMaxMB {
+	case 0:
+		if qosClasses {
+			return mem.MemoryMB * mb, mem.MemoryMB * mb
+		}
+		return mem.MemoryMB * mb, 0
+	case -1:
+		if qosClasses {
+			return -1, 0
+		}
+		return -1, mem.MemoryMB * mb
+	default:
+		return mem.MemoryMaxMB * mb, mem.MemoryMB * mb
+	}
+}
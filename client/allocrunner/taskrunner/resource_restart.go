@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// resourceRestartTimeout bounds how long a restart triggered by the task's
+// resource usage may block.
+const resourceRestartTimeout = 10 * time.Second
+
+// resourceRestarter tracks how long a task's resource usage has been beyond
+// the thresholds of its restart policy's resource trigger. The zero value is
+// ready to use.
+type resourceRestarter struct {
+	mu sync.Mutex
+
+	// exceededSince is the time of the first sample of the current run of
+	// samples beyond a threshold, or zero if the last sample was within them
+	exceededSince time.Time
+
+	// prev is the previous sample, used to compute rates
+	prev *cstructs.TaskResourceUsage
+
+	// restarting is set while a triggered restart is in progress, so samples
+	// of the processes being stopped are ignored
+	restarting bool
+
+	// resource is the resource whose threshold triggered the restart in
+	// progress
+	resource string
+}
+
+// check records a resource usage sample and returns why the task should be
+// restarted, or an empty string if it should keep running.
+func (r *resourceRestarter) check(trigger *structs.RestartResourceTrigger, ru *cstructs.TaskResourceUsage) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.restarting {
+		return ""
+	}
+
+	prev := r.prev
+	r.prev = ru
+
+	resource, exceeded := exceededResource(trigger, prev, ru)
+	if exceeded == "" {
+		r.exceededSince = time.Time{}
+		return ""
+	}
+
+	now := time.Unix(0, ru.Timestamp)
+	if r.exceededSince.IsZero() {
+		r.exceededSince = now
+	}
+	if now.Sub(r.exceededSince) < trigger.Duration {
+		return ""
+	}
+
+	r.restarting = true
+	r.resource = resource
+	if trigger.Duration == 0 {
+		return "resource trigger: " + exceeded
+	}
+	return fmt.Sprintf("resource trigger: %s for %s", exceeded, trigger.Duration)
+}
+
+// reset clears the state of the restarter once a triggered restart is done.
+func (r *resourceRestarter) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exceededSince = time.Time{}
+	r.prev = nil
+	r.restarting = false
+	r.resource = ""
+}
+
+// triggeredResource returns the resource whose threshold triggered the
+// restart in progress.
+func (r *resourceRestarter) triggeredResource() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resource
+}
+
+// exceededResource returns the resource and a description of the first
+// threshold of the trigger the sample is beyond, or empty strings if it is
+// within all of them.
+// The CPU throttled ratio is the share of the time between the previous sample
+// and this one the task's processes spent throttled.
+func exceededResource(trigger *structs.RestartResourceTrigger, prev, ru *cstructs.TaskResourceUsage) (string, string) {
+	if ru == nil || ru.ResourceUsage == nil {
+		return "", ""
+	}
+
+	if ms := ru.ResourceUsage.MemoryStats; trigger.MemoryRSSMB > 0 && ms != nil {
+		limit := uint64(trigger.MemoryRSSMB) * 1024 * 1024
+		if ms.RSS > limit {
+			return structs.TaskKilledResourceMemory,
+				fmt.Sprintf("memory RSS %d MiB above %d MiB", ms.RSS/1024/1024, trigger.MemoryRSSMB)
+		}
+	}
+
+	if trigger.CPUThrottledRatio > 0 && prev != nil && prev.ResourceUsage != nil {
+		cur, last := ru.ResourceUsage.CpuStats, prev.ResourceUsage.CpuStats
+		elapsed := ru.Timestamp - prev.Timestamp
+		if cur != nil && last != nil && elapsed > 0 && cur.ThrottledTime >= last.ThrottledTime {
+			ratio := float64(cur.ThrottledTime-last.ThrottledTime) / float64(elapsed)
+			if ratio > trigger.CPUThrottledRatio {
+				return structs.TaskKilledResourceCPU, fmt.Sprintf("CPU throttled %.0f%% of the time, above %.0f%%",
+					ratio*100, trigger.CPUThrottledRatio*100)
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// checkResourceTrigger restarts the task if its resource usage has stayed
+// beyond the thresholds of its restart policy's resource trigger. Restarts
+// count as failures against the restart policy, like check_restart.
+func (tr *TaskRunner) checkResourceTrigger(ru *cstructs.TaskResourceUsage) {
+	rp := tr.Task().RestartPolicy
+	if rp == nil || rp.ResourceTrigger == nil {
+		return
+	}
+
+	reason := tr.resourceRestarter.check(rp.ResourceTrigger, ru)
+	if reason == "" {
+		return
+	}
+
+	tr.logger.Info("restarting task due to resource usage", "reason", reason)
+	resource := tr.resourceRestarter.triggeredResource()
+	tr.AnnotateKill(resource)
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason(reason).
+		SetKilledResource(resource)
+	go func() {
+		defer tr.resourceRestarter.reset()
+
+		ctx, cancel := context.WithTimeout(tr.killCtx, resourceRestartTimeout)
+		defer cancel()
+
+		if err := tr.Restart(ctx, event, true); err != nil {
+			tr.logger.Debug("failed to restart task", "error", err, "event_time", event.Time, "event_type", event.Type)
+		}
+	}()
+}
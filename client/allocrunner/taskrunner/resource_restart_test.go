@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func resourceSample(at time.Duration, rssMB, throttled uint64) *cstructs.TaskResourceUsage {
+	return &cstructs.TaskResourceUsage{
+		Timestamp: int64(at),
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{RSS: rssMB * 1024 * 1024},
+			CpuStats:    &cstructs.CpuStats{ThrottledTime: throttled},
+		},
+	}
+}
+
+func TestResourceRestarter_Memory(t *testing.T) {
+	ci.Parallel(t)
+
+	trigger := &structs.RestartResourceTrigger{
+		MemoryRSSMB: 100,
+		Duration:    2 * time.Second,
+	}
+
+	var r resourceRestarter
+	must.Eq(t, "", r.check(trigger, resourceSample(0, 150, 0)))
+	must.Eq(t, "", r.check(trigger, resourceSample(time.Second, 150, 0)))
+
+	// dropping below the threshold resets the duration
+	must.Eq(t, "", r.check(trigger, resourceSample(2*time.Second, 50, 0)))
+	must.Eq(t, "", r.check(trigger, resourceSample(3*time.Second, 150, 0)))
+	must.Eq(t, "", r.check(trigger, resourceSample(4*time.Second, 150, 0)))
+
+	reason := r.check(trigger, resourceSample(5*time.Second, 150, 0))
+	must.Eq(t, "resource trigger: memory RSS 150 MiB above 100 MiB for 2s", reason)
+	must.Eq(t, structs.TaskKilledResourceMemory, r.triggeredResource())
+
+	// samples are ignored until the restart is done
+	must.Eq(t, "", r.check(trigger, resourceSample(10*time.Second, 150, 0)))
+	r.reset()
+	must.Eq(t, "", r.triggeredResource())
+	must.Eq(t, "", r.check(trigger, resourceSample(11*time.Second, 150, 0)))
+}
+
+func TestResourceRestarter_CPUThrottled(t *testing.T) {
+	ci.Parallel(t)
+
+	trigger := &structs.RestartResourceTrigger{
+		CPUThrottledRatio: 0.5,
+	}
+
+	var r resourceRestarter
+
+	// the ratio needs two samples
+	must.Eq(t, "", r.check(trigger, resourceSample(0, 0, 0)))
+
+	// throttled 25% of the time
+	must.Eq(t, "", r.check(trigger, resourceSample(time.Second, 0, uint64(250*time.Millisecond))))
+
+	// throttled 75% of the time
+	reason := r.check(trigger, resourceSample(2*time.Second, 0, uint64(time.Second)))
+	must.Eq(t, "resource trigger: CPU throttled 75% of the time, above 50%", reason)
+	must.Eq(t, structs.TaskKilledResourceCPU, r.triggeredResource())
+}
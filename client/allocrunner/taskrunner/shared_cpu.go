@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import "sync"
+
+// SharedCPU tracks the CPU the tasks of an allocation with shared resources
+// reserve and use, so the CPU a task uses beyond its own reservation is only
+// counted as borrowed out of what its siblings reserved but left idle, and
+// not out of the idle CPU of the node. It is shared by the task runners of an
+// allocation.
+type SharedCPU struct {
+	tasks map[string]sharedCPUTask
+	mu    sync.Mutex
+}
+
+// sharedCPUTask is the CPU in MHz a task reserves and used in its latest
+// sample.
+type sharedCPUTask struct {
+	reserved float64
+	used     float64
+}
+
+// NewSharedCPU returns a SharedCPU for an allocation.
+func NewSharedCPU() *SharedCPU {
+	return &SharedCPU{
+		tasks: map[string]sharedCPUTask{},
+	}
+}
+
+// Borrowed records the CPU in MHz the task reserves and used in its latest
+// sample, and returns the CPU it used beyond its reservation that its
+// siblings reserved but didn't use in their latest samples. Siblings that
+// haven't reported usage yet don't lend any CPU.
+func (s *SharedCPU) Borrowed(task string, reserved, used float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[task] = sharedCPUTask{reserved: reserved, used: used}
+
+	idle := 0.0
+	for name, t := range s.tasks {
+		if name != task {
+			idle += max(0, t.reserved-t.used)
+		}
+	}
+	return min(max(0, used-reserved), idle)
+}
+
+// Exited records that the task exited, which leaves all the CPU it reserves
+// idle for its siblings until it reports usage again.
+func (s *SharedCPU) Exited(task string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.tasks[task]; ok {
+		t.used = 0
+		s.tasks[task] = t
+	}
+}
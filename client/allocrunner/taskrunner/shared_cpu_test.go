@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSharedCPU_Borrowed(t *testing.T) {
+	ci.Parallel(t)
+
+	shared := NewSharedCPU()
+
+	// Siblings that haven't reported usage don't lend CPU
+	must.Zero(t, shared.Borrowed("main", 500, 900))
+
+	must.Zero(t, shared.Borrowed("sidecar", 300, 100))
+	must.Eq(t, 200, shared.Borrowed("main", 500, 900))
+
+	// The CPU of a sibling that exited is idle
+	shared.Exited("sidecar")
+	must.Eq(t, 300, shared.Borrowed("main", 500, 900))
+	must.Eq(t, 100, shared.Borrowed("main", 500, 600))
+
+	// Siblings using more than their own CPU don't lend any
+	must.Zero(t, shared.Borrowed("sidecar", 300, 400))
+	must.Zero(t, shared.Borrowed("main", 500, 900))
+}
@@ -5,8 +5,11 @@ package state
 
 import (
 	"maps"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/mitchellh/copystructure"
 )
 
 // LocalState is Task state which is persisted for use when restarting Nomad
@@ -25,6 +28,17 @@ type LocalState struct {
 	// It is used to distinguish between a dead task that could be restarted
 	// and one that will never run again.
 	RunComplete bool
+
+	// Stats is the state of the task's resource usage stats when the agent
+	// last shut down. It is restored so the samples and counters of a task
+	// carry on across in-place upgrades of the client instead of resetting.
+	Stats *StatsState
+
+	// OOMMemoryRaisedMB is how much the restart policy of the task raised its
+	// memory limit after it was OOM-killed, last at OOMKilledAt. It is
+	// restored so the limit of the task matches the one it runs with.
+	OOMMemoryRaisedMB int64
+	OOMKilledAt       time.Time
 }
 
 func NewLocalState() *LocalState {
@@ -62,6 +76,10 @@ func (s *LocalState) Copy() *LocalState {
 		DriverNetwork: s.DriverNetwork.Copy(),
 		TaskHandle:    s.TaskHandle.Copy(),
 		RunComplete:   s.RunComplete,
+		Stats:         s.Stats.Copy(),
+
+		OOMMemoryRaisedMB: s.OOMMemoryRaisedMB,
+		OOMKilledAt:       s.OOMKilledAt,
 	}
 
 	// Copy the hook state
@@ -72,6 +90,81 @@ func (s *LocalState) Copy() *LocalState {
 	return c
 }
 
+// StatsState is the state of a task's resource usage stats which is needed to
+// keep them continuous across agent restarts.
+type StatsState struct {
+	// Sequence is the sequence number of the latest sample and
+	// DriverSequence the one the driver numbered it with
+	Sequence       uint64
+	DriverSequence uint64
+
+	// CpuSeconds is the latest TotalCpuSeconds reported by the driver and
+	// CpuSecondsOffset the CPU time counted before the driver's counter last
+	// reset, which is added to the reported counter so it never decreases
+	CpuSeconds       float64
+	CpuSecondsOffset float64
+
+	// Window is the samples retained to summarize recent usage
+	Window []UsageSample
+
+	// Meter is the usage of the task accumulated for its billing record
+	Meter UsageMeter
+
+	// Latest is the latest sample of the task, without the usage of each of
+	// its processes. It is served until the task sends a fresh sample, so
+	// the usage of the task isn't blank while the agent restarts.
+	Latest *drivers.TaskResourceUsage
+
+	// Shutdown is set if the state was checkpointed when the agent shut
+	// down, rather than periodically while the task ran
+	Shutdown bool
+}
+
+// UsageSample is a retained sample of a task's resource usage.
+type UsageSample struct {
+	Timestamp int64
+	CPU       int
+	MemoryMB  int
+}
+
+// UsageMeter is the cumulative resource usage of a task over its lifetime.
+type UsageMeter struct {
+	// Timestamp is the time of the latest sample
+	Timestamp int64
+
+	CPUSeconds        float64
+	MemoryByteSeconds float64
+	PeakMemory        uint64
+
+	// EgressBytes is the latest total of the task's egress counters and
+	// EgressOffset the traffic counted before they last reset
+	EgressBytes  uint64
+	EgressOffset uint64
+
+	// Partial is set once usage went unmetered because of a gap between
+	// samples
+	Partial bool
+}
+
+// Copy StatsState. Returns nil if nil.
+func (s *StatsState) Copy() *StatsState {
+	if s == nil {
+		return nil
+	}
+
+	c := new(StatsState)
+	*c = *s
+	c.Window = slices.Clone(s.Window)
+	if s.Latest != nil {
+		if i, err := copystructure.Copy(s.Latest); err != nil {
+			panic(err.Error())
+		} else {
+			c.Latest = i.(*drivers.TaskResourceUsage)
+		}
+	}
+	return c
+}
+
 type HookState struct {
 	// Prestart is true if the hook has run Prestart successfully and does
 	// not need to run again
@@ -5,11 +5,14 @@ package taskrunner
 
 import (
 	"context"
+	"runtime/debug"
 	"sync"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -22,11 +25,107 @@ type StatsUpdater interface {
 	UpdateStats(*cstructs.TaskResourceUsage)
 }
 
+// statsIdleTimeout is how long after the last read of a task's stats lazy
+// collection is paused.
+const statsIdleTimeout = time.Minute
+
+const (
+	// statsStallIntervals is how many intervals a stats stream may go
+	// without a sample before it is considered stalled, such as by a driver
+	// blocked reading /proc files of a process on a hung NFS mount.
+	statsStallIntervals = 5
+
+	// statsMinStallTimeout is the least time a stats stream may go without a
+	// sample before it is considered stalled, so a driver which is only slow
+	// on a loaded node isn't restarted.
+	statsMinStallTimeout = 10 * time.Second
+
+	// statsBreakerThreshold is how many consecutive stats streams of a task
+	// may fail before its collection is suspended.
+	statsBreakerThreshold = 3
+
+	// statsBreakerBase and statsBreakerMax bound how long the collection of
+	// a task is suspended each time its streams keep failing.
+	statsBreakerBase = 30 * time.Second
+	statsBreakerMax  = 10 * time.Minute
+)
+
+// statsDemand tracks when a task's stats were last read so collection can be
+// paused while nothing consumes them.
+type statsDemand struct {
+	mu       sync.Mutex
+	lastRead time.Time
+
+	// paused is set while collection is paused, during which the latest
+	// sample of the task gets older
+	paused bool
+
+	// wakeCh is sent to when stats are read, to resume paused collection
+	wakeCh chan struct{}
+}
+
+func newStatsDemand() *statsDemand {
+	return &statsDemand{
+		lastRead: time.Now(),
+		wakeCh:   make(chan struct{}, 1),
+	}
+}
+
+// Touch records that the stats were read and resumes paused collection.
+func (d *statsDemand) Touch() {
+	d.mu.Lock()
+	d.lastRead = time.Now()
+	d.mu.Unlock()
+
+	select {
+	case d.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// idle returns whether the stats have not been read within timeout.
+func (d *statsDemand) idle(timeout time.Duration) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return time.Since(d.lastRead) > timeout
+}
+
+func (d *statsDemand) setPaused(paused bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.paused = paused
+}
+
+// Paused returns whether collection is paused.
+func (d *statsDemand) Paused() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.paused
+}
+
 // statsHook manages the task stats collection goroutine.
 type statsHook struct {
 	updater  StatsUpdater
 	interval time.Duration
 
+	// intervalReporter returns the effective collection interval. If nil,
+	// interval is used.
+	intervalReporter cinterfaces.StatsIntervalReporter
+
+	// demand is used to pause collection while the stats are not being
+	// read. If nil, stats are always collected.
+	demand      *statsDemand
+	idleTimeout time.Duration
+
+	// minStallTimeout and breakerBase are statsMinStallTimeout and
+	// statsBreakerBase, overridden by tests
+	minStallTimeout time.Duration
+	breakerBase     time.Duration
+
+	// labels are the labels of the telemetry of the stats pipeline, which
+	// is emitted per driver
+	labels []metrics.Label
+
 	// cancel is called by Exited
 	cancel context.CancelFunc
 
@@ -35,10 +134,17 @@ type statsHook struct {
 	logger hclog.Logger
 }
 
-func newStatsHook(su StatsUpdater, interval time.Duration, logger hclog.Logger) *statsHook {
+func newStatsHook(su StatsUpdater, driver string, interval time.Duration, intervalReporter cinterfaces.StatsIntervalReporter,
+	demand *statsDemand, logger hclog.Logger) *statsHook {
 	h := &statsHook{
-		updater:  su,
-		interval: interval,
+		updater:          su,
+		labels:           []metrics.Label{{Name: "driver", Value: driver}},
+		interval:         interval,
+		intervalReporter: intervalReporter,
+		demand:           demand,
+		idleTimeout:      statsIdleTimeout,
+		minStallTimeout:  statsMinStallTimeout,
+		breakerBase:      statsBreakerBase,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -65,7 +171,7 @@ func (h *statsHook) Poststart(_ context.Context, req *interfaces.TaskPoststartRe
 	// canceled on the Exited hook.
 	ctx, cancel := context.WithCancel(context.Background())
 	h.cancel = cancel
-	go h.collectResourceUsageStats(ctx, req.DriverStats)
+	go h.collectResourceUsageStats(ctx, req.DriverStats, time.Now())
 
 	return nil
 }
@@ -88,18 +194,186 @@ func (h *statsHook) Exited(context.Context, *interfaces.TaskExitedRequest, *inte
 	return nil
 }
 
-// collectResourceUsageStats starts collecting resource usage stats of a Task.
-// Collection ends when the passed channel is closed
-func (h *statsHook) collectResourceUsageStats(ctx context.Context, handle interfaces.DriverStats) {
+// streamResult is the reason collecting from a driver stats stream stopped
+type streamResult int
 
-MAIN:
-	ch, err := h.callStatsWithRetry(ctx, handle)
-	if err != nil {
+const (
+	// streamDone means the context was canceled or the driver can't
+	// provide stats
+	streamDone streamResult = iota
+
+	// streamIdle means the stats are not being read
+	streamIdle
+
+	// streamRestart means the stream should be re-established
+	streamRestart
+
+	// streamFailed means the stream stalled or collecting from it panicked,
+	// and it should be re-established unless the breaker trips
+	streamFailed
+)
+
+// statsBreaker suspends the stats collection of a task whose streams keep
+// failing, so a driver repeatedly stuck on the processes of one task doesn't
+// pile up more stuck reads with every restart. Each trip suspends collection
+// for longer.
+type statsBreaker struct {
+	failures int
+	trips    uint64
+}
+
+// succeeded resets the breaker once a stream delivers a sample.
+func (b *statsBreaker) succeeded() {
+	b.failures = 0
+	b.trips = 0
+}
+
+// failed records a failed stream, and returns how long collection must be
+// suspended for if the breaker tripped.
+func (b *statsBreaker) failed(base time.Duration) (time.Duration, bool) {
+	b.failures++
+	if b.failures < statsBreakerThreshold {
+		return 0, false
+	}
+	wait := helper.Backoff(base, statsBreakerMax, b.trips)
+	b.failures = 0
+	b.trips++
+	return wait, true
+}
+
+// firstSampleWatch measures the time from the start of a task to its first
+// stats sample. Drivers whose stats break only fail to deliver samples, so a
+// task without a sample within the timeout is counted as well, since it would
+// never be measured.
+type firstSampleWatch struct {
+	started time.Time
+	labels  []metrics.Label
+	timer   *time.Timer
+}
+
+func newFirstSampleWatch(started time.Time, timeout time.Duration, labels []metrics.Label,
+	logger hclog.Logger) *firstSampleWatch {
+	w := &firstSampleWatch{
+		started: started,
+		labels:  labels,
+	}
+	w.timer = time.AfterFunc(timeout, func() {
+		logger.Warn("no stats sample received since task started", "timeout", timeout)
+		metrics.IncrCounterWithLabels([]string{"client", "stats", "first_sample_timeouts"}, 1, labels)
+	})
+	return w
+}
+
+// received records a sample, and measures the time to it if it is the first.
+func (w *firstSampleWatch) received() {
+	if w.started.IsZero() {
 		return
 	}
+	w.timer.Stop()
+	metrics.MeasureSinceWithLabels([]string{"client", "stats", "first_sample"}, w.started, w.labels)
+	w.started = time.Time{}
+}
+
+// stop stops waiting for the first sample, once collection ends.
+func (w *firstSampleWatch) stop() {
+	w.timer.Stop()
+}
+
+// collectResourceUsageStats starts collecting resource usage stats of a Task
+// which was started or restored at started. Collection ends when the passed
+// context is canceled
+func (h *statsHook) collectResourceUsageStats(ctx context.Context, handle interfaces.DriverStats, started time.Time) {
+	first := newFirstSampleWatch(started, h.stallTimeout(h.currentInterval()), h.labels, h.logger)
+	defer first.stop()
+
+	var breaker statsBreaker
+	for {
+		switch h.recoverStream(ctx, handle, first, &breaker) {
+		case streamDone:
+			return
+		case streamFailed:
+			wait, tripped := breaker.failed(h.breakerBase)
+			if !tripped {
+				continue
+			}
+			h.logger.Warn("suspending stats collection after repeated failures",
+				"failures", statsBreakerThreshold, "duration", wait)
+			metrics.IncrCounterWithLabels([]string{"client", "stats", "breaker_trips"}, 1, h.labels)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+			h.logger.Info("resuming stats collection")
+		case streamIdle:
+			h.logger.Debug("pausing stats collection while stats are not read")
+
+			// Discard reads that raced with the idle check before waiting
+			select {
+			case <-h.demand.wakeCh:
+			default:
+			}
+			if h.demand.idle(h.idleTimeout) {
+				h.demand.setPaused(true)
+				select {
+				case <-h.demand.wakeCh:
+				case <-ctx.Done():
+					return
+				}
+				h.demand.setPaused(false)
+			}
+			h.logger.Debug("resuming stats collection")
+		}
+	}
+}
+
+// recoverStream collects a stats stream as collectStream, but recovers from
+// panics in the driver's stats or the updater, which would otherwise take
+// down the client and the stats of every task with it.
+func (h *statsHook) recoverStream(ctx context.Context, handle interfaces.DriverStats, first *firstSampleWatch,
+	breaker *statsBreaker) (result streamResult) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error("panic collecting stats", "panic", r, "stack", string(debug.Stack()))
+			h.countRestart("panic")
+			result = streamFailed
+		}
+	}()
+	return h.collectStream(ctx, handle, first, breaker)
+}
+
+// collectStream streams stats from the driver to the updater at the current
+// interval until the stream should stop, and returns why it stopped. Samples
+// are recorded on the first sample watch, and the breaker is reset once a
+// sample is received.
+func (h *statsHook) collectStream(ctx context.Context, handle interfaces.DriverStats, first *firstSampleWatch,
+	breaker *statsBreaker) streamResult {
+
+	// Canceling the context stops the driver's stats stream
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	interval := h.currentInterval()
+	ch, err := h.callStatsWithRetry(ctx, handle, interval)
+	if err != nil {
+		return streamDone
+	}
+
+	// A stream which stops delivering samples without closing is restarted,
+	// and only holds back the stats of this task
+	stallTimeout := h.stallTimeout(interval)
+	stall := time.NewTimer(stallTimeout)
+	defer stall.Stop()
 
+	var lastReceived time.Time
 	for {
 		select {
+		case <-stall.C:
+			h.logger.Warn("stats stream stalled, restarting", "timeout", stallTimeout)
+			h.countRestart("stalled")
+			return streamFailed
+
 		case ru, ok := <-ch:
 			// if channel closes, re-establish a new one
 			if !ok {
@@ -107,27 +381,90 @@ MAIN:
 				// because task shutdown or because driver
 				// doesn't implement channel interval checking
 				select {
-				case <-time.After(h.interval):
-					goto MAIN
+				case <-time.After(interval):
+					h.countRestart("closed")
+					return streamRestart
 				case <-ctx.Done():
-					return
+					return streamDone
+				}
+			}
+
+			received := time.Now()
+			if !lastReceived.IsZero() {
+				if dropped := droppedSamples(received.Sub(lastReceived), interval); dropped > 0 {
+					metrics.IncrCounterWithLabels([]string{"client", "stats", "dropped_samples"}, float32(dropped), h.labels)
 				}
 			}
+			lastReceived = received
+			stall.Reset(stallTimeout)
+
+			if ru != nil {
+				first.received()
+			}
 
-			// Update stats on TaskRunner and emit them
+			// Update stats on TaskRunner and emit them. The driver can't
+			// send the next sample until the update returns, so an update
+			// slower than the interval holds back the stream.
 			h.updater.UpdateStats(ru)
+			if ru != nil {
+				breaker.succeeded()
+			}
+			if time.Since(received) > interval {
+				metrics.IncrCounterWithLabels([]string{"client", "stats", "backpressure"}, 1, h.labels)
+			}
+
+			if h.demand != nil && h.demand.idle(h.idleTimeout) {
+				return streamIdle
+			}
+			if next := h.currentInterval(); next != interval {
+				h.logger.Debug("restarting stats collection at new interval", "interval", next)
+				h.countRestart("interval")
+				return streamRestart
+			}
 
 		case <-ctx.Done():
-			return
+			return streamDone
 		}
 	}
 }
 
+// stallTimeout returns how long a stream collected at the interval may go
+// without a sample before it is considered stalled.
+func (h *statsHook) stallTimeout(interval time.Duration) time.Duration {
+	return max(statsStallIntervals*interval, h.minStallTimeout)
+}
+
+// countRestart counts a restart of the driver's stats stream for the reason.
+func (h *statsHook) countRestart(reason string) {
+	labels := append([]metrics.Label{{Name: "reason", Value: reason}}, h.labels...)
+	metrics.IncrCounterWithLabels([]string{"client", "stats", "stream_restarts"}, 1, labels)
+}
+
+// droppedSamples returns how many samples were missed between two samples
+// received elapsed apart, when the driver sends one every interval. Samples
+// are only counted as dropped once a whole interval passed without one, so
+// jitter in the driver's collection isn't counted.
+func droppedSamples(elapsed, interval time.Duration) int {
+	if interval <= 0 || elapsed < 2*interval {
+		return 0
+	}
+	return int(elapsed/interval) - 1
+}
+
+// currentInterval returns the effective stats collection interval, which
+// may be backed off from the configured interval on a loaded node.
+func (h *statsHook) currentInterval() time.Duration {
+	if h.intervalReporter != nil {
+		return h.intervalReporter.StatsInterval()
+	}
+	return h.interval
+}
+
 // callStatsWithRetry invokes handle driver Stats() functions and retries until channel is established
 // successfully.  Returns an error if it encounters a permanent error.
 //
 // It logs the errors with appropriate log levels; don't log returned error
-func (h *statsHook) callStatsWithRetry(ctx context.Context, handle interfaces.DriverStats) (<-chan *cstructs.TaskResourceUsage, error) {
+func (h *statsHook) callStatsWithRetry(ctx context.Context, handle interfaces.DriverStats, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	var retry uint64
 	var backoff time.Duration
 	limit := time.Second * 5
@@ -137,7 +474,7 @@ MAIN:
 		return nil, ctx.Err()
 	}
 
-	ch, err := handle.Stats(ctx, h.interval)
+	ch, err := handle.Stats(ctx, interval)
 	if err == nil {
 		return ch, nil
 	}
@@ -164,6 +501,7 @@ MAIN:
 	} else {
 		h.logger.Error("failed to start stats collection for task", "error", err)
 	}
+	metrics.IncrCounterWithLabels([]string{"client", "stats", "stream_errors"}, 1, h.labels)
 
 	backoff = helper.Backoff(time.Second, limit, retry)
 	retry++
@@ -9,10 +9,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,12 +46,16 @@ func (m *mockStatsUpdater) UpdateStats(ru *cstructs.TaskResourceUsage) {
 type mockDriverStats struct {
 	called uint32
 
+	// interval is the interval passed to the last Stats call
+	interval int64
+
 	// err is returned by Stats if it is non-nil
 	err error
 }
 
 func (m *mockDriverStats) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	atomic.AddUint32(&m.called, 1)
+	atomic.StoreInt64(&m.interval, int64(interval))
 
 	if m.err != nil {
 		return nil, m.err
@@ -96,7 +103,7 @@ func TestTaskRunner_StatsHook_PoststartExited(t *testing.T) {
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
 	// Create hook
-	h := newStatsHook(su, time.Minute, logger)
+	h := newStatsHook(su, "mock_driver", time.Minute, nil, nil, logger)
 
 	// Always call Exited to cleanup goroutines
 	defer h.Exited(context.Background(), nil, nil)
@@ -131,7 +138,7 @@ func TestTaskRunner_StatsHook_Periodic(t *testing.T) {
 	// Exited() can complete within the interval.
 	const interval = 500 * time.Millisecond
 
-	h := newStatsHook(su, interval, logger)
+	h := newStatsHook(su, "mock_driver", interval, nil, nil, logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -180,6 +187,98 @@ WAITING:
 	}
 }
 
+// TestTaskRunner_StatsHook_Lazy asserts lazy stats collection pauses when
+// the stats are not read and resumes when they are.
+func TestTaskRunner_StatsHook_Lazy(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	su := newMockStatsUpdater()
+	ds := new(mockDriverStats)
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
+
+	demand := newStatsDemand()
+	h := newStatsHook(su, "mock_driver", 10*time.Millisecond, nil, demand, logger)
+	h.idleTimeout = 100 * time.Millisecond
+	defer h.Exited(context.Background(), nil, nil)
+
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	select {
+	case <-su.Ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for initial stats collection")
+	}
+
+	// Drain updates until collection pauses
+	deadline := time.After(10 * time.Second)
+PAUSING:
+	for {
+		select {
+		case <-su.Ch:
+		case <-time.After(500 * time.Millisecond):
+			break PAUSING
+		case <-deadline:
+			t.Fatal("stats collection did not pause")
+		}
+	}
+	called := atomic.LoadUint32(&ds.called)
+	must.True(t, demand.Paused())
+
+	// Reading the stats resumes collection
+	demand.Touch()
+	select {
+	case <-su.Ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for stats collection to resume")
+	}
+	must.Greater(t, called, atomic.LoadUint32(&ds.called))
+	must.False(t, demand.Paused())
+}
+
+type mockStatsInterval struct {
+	interval int64
+}
+
+func (m *mockStatsInterval) StatsInterval() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.interval))
+}
+
+// TestTaskRunner_StatsHook_AdaptiveInterval asserts stats are collected at
+// the effective interval reported by the client.
+func TestTaskRunner_StatsHook_AdaptiveInterval(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	su := newMockStatsUpdater()
+	ds := new(mockDriverStats)
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
+
+	reporter := &mockStatsInterval{interval: int64(10 * time.Millisecond)}
+	h := newStatsHook(su, "mock_driver", time.Minute, reporter, nil, logger)
+	defer h.Exited(context.Background(), nil, nil)
+
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	select {
+	case <-su.Ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for initial stats collection")
+	}
+	must.Eq(t, int64(10*time.Millisecond), atomic.LoadInt64(&ds.interval))
+
+	// Backing off the interval restarts collection at the new interval
+	atomic.StoreInt64(&reporter.interval, int64(20*time.Millisecond))
+	deadline := time.After(10 * time.Second)
+	for atomic.LoadInt64(&ds.interval) != int64(20*time.Millisecond) {
+		select {
+		case <-su.Ch:
+		case <-deadline:
+			t.Fatal("timeout waiting for stats collection at new interval")
+		}
+	}
+}
+
 // TestTaskRunner_StatsHook_NotImplemented asserts the stats hook stops if the
 // driver returns NotImplemented.
 func TestTaskRunner_StatsHook_NotImplemented(t *testing.T) {
@@ -194,7 +293,7 @@ func TestTaskRunner_StatsHook_NotImplemented(t *testing.T) {
 
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
-	h := newStatsHook(su, 1, logger)
+	h := newStatsHook(su, "mock_driver", 1, nil, nil, logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -220,7 +319,7 @@ func TestTaskRunner_StatsHook_Backoff(t *testing.T) {
 
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
-	h := newStatsHook(su, time.Minute, logger)
+	h := newStatsHook(su, "mock_driver", time.Minute, nil, nil, logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -239,3 +338,166 @@ DRAIN:
 
 	require.Equal(t, ds.Called(), 1)
 }
+
+// stallingDriverStats is a DriverStats whose streams never deliver a sample,
+// as a driver blocked reading the processes of a task.
+type stallingDriverStats struct {
+	called uint32
+}
+
+func (m *stallingDriverStats) Stats(ctx context.Context, _ time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+	atomic.AddUint32(&m.called, 1)
+	return make(chan *cstructs.TaskResourceUsage), nil
+}
+
+func (m *stallingDriverStats) Called() int {
+	return int(atomic.LoadUint32(&m.called))
+}
+
+// TestTaskRunner_StatsHook_Stalled asserts stalled streams are restarted,
+// and that collection is suspended once they keep stalling.
+func TestTaskRunner_StatsHook_Stalled(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	su := newMockStatsUpdater()
+	ds := &stallingDriverStats{}
+
+	h := newStatsHook(su, "mock_driver", time.Millisecond, nil, nil, logger)
+	h.minStallTimeout = 20 * time.Millisecond
+	h.breakerBase = time.Hour
+	defer h.Exited(context.Background(), nil, nil)
+
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return ds.Called() == statsBreakerThreshold }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// The breaker tripped, so the stream isn't restarted again
+	time.Sleep(200 * time.Millisecond)
+	must.Eq(t, statsBreakerThreshold, ds.Called())
+}
+
+// panickingStatsUpdater panics on every update.
+type panickingStatsUpdater struct{}
+
+func (panickingStatsUpdater) UpdateStats(*cstructs.TaskResourceUsage) {
+	panic("bad stats")
+}
+
+// TestTaskRunner_StatsHook_Panic asserts a panic collecting the stats of a
+// task is recovered from, and the stream restarted.
+func TestTaskRunner_StatsHook_Panic(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	ds := &mockDriverStats{}
+
+	h := newStatsHook(panickingStatsUpdater{}, "mock_driver", time.Millisecond, nil, nil, logger)
+	h.breakerBase = time.Hour
+	defer h.Exited(context.Background(), nil, nil)
+
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return ds.Called() == statsBreakerThreshold }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	time.Sleep(100 * time.Millisecond)
+	must.Eq(t, statsBreakerThreshold, ds.Called())
+}
+
+// logLines is an hclog output sending each logged line on a channel.
+type logLines chan string
+
+func (l logLines) Write(p []byte) (int, error) {
+	l <- string(p)
+	return len(p), nil
+}
+
+// TestStatsHook_firstSampleWatch asserts tasks without a first sample within
+// the timeout are caught.
+func TestStatsHook_firstSampleWatch(t *testing.T) {
+	ci.Parallel(t)
+
+	lines := make(logLines, 10)
+	logger := hclog.New(&hclog.LoggerOptions{Output: lines})
+
+	missed := func(timeout time.Duration) bool {
+		select {
+		case line := <-lines:
+			must.StrContains(t, line, "no stats sample received since task started")
+			return true
+		case <-time.After(timeout):
+			return false
+		}
+	}
+
+	w := newFirstSampleWatch(time.Now(), 10*time.Millisecond, nil, logger)
+	defer w.stop()
+	must.True(t, missed(5*time.Second))
+
+	// A late first sample is still measured
+	w.received()
+	must.True(t, w.started.IsZero())
+
+	// Tasks whose first sample is on time aren't counted
+	w = newFirstSampleWatch(time.Now(), 50*time.Millisecond, nil, logger)
+	w.received()
+	must.False(t, missed(100*time.Millisecond))
+
+	// Tasks exiting before their first sample aren't counted either
+	w = newFirstSampleWatch(time.Now(), 50*time.Millisecond, nil, logger)
+	w.stop()
+	must.False(t, missed(100*time.Millisecond))
+}
+
+func TestStatsHook_statsBreaker(t *testing.T) {
+	ci.Parallel(t)
+
+	var b statsBreaker
+	for i := 1; i < statsBreakerThreshold; i++ {
+		_, tripped := b.failed(time.Second)
+		must.False(t, tripped)
+	}
+	wait, tripped := b.failed(time.Second)
+	must.True(t, tripped)
+	must.Eq(t, time.Second, wait)
+
+	// Each trip suspends collection for longer
+	for i := 1; i < statsBreakerThreshold; i++ {
+		b.failed(time.Second)
+	}
+	wait, tripped = b.failed(time.Second)
+	must.True(t, tripped)
+	must.Greater(t, time.Second, wait)
+
+	// A sample resets the breaker
+	b.succeeded()
+	_, tripped = b.failed(time.Second)
+	must.False(t, tripped)
+}
+
+func TestStatsHook_droppedSamples(t *testing.T) {
+	ci.Parallel(t)
+
+	const interval = time.Second
+	for _, tc := range []struct {
+		elapsed time.Duration
+		exp     int
+	}{
+		{elapsed: interval, exp: 0},
+		{elapsed: 1900 * time.Millisecond, exp: 0},
+		{elapsed: 2 * interval, exp: 1},
+		{elapsed: 5500 * time.Millisecond, exp: 4},
+	} {
+		must.Eq(t, tc.exp, droppedSamples(tc.elapsed, interval), must.Sprintf("elapsed %s", tc.elapsed))
+	}
+	must.Zero(t, droppedSamples(time.Minute, 0))
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// stitchSequence numbers the sample of the task from the sequence number and
+// collection duration the driver stamped it with, keeping the sequence
+// increasing when the driver's numbering restarts, such as when the task is
+// restarted or its executor replaced. Samples of drivers that don't number
+// them are numbered and timed as they are received. Must be called with
+// resourceUsageLock held.
+func (tr *TaskRunner) stitchSequence(ru *cstructs.TaskResourceUsage) {
+	now := time.Now()
+	if ru.Sequence == 0 {
+		ru.Sequence = tr.statsDriverSequence + 1
+		if !tr.statsReceivedAt.IsZero() {
+			ru.CollectionDuration = now.Sub(tr.statsReceivedAt)
+		}
+	}
+	tr.statsReceivedAt = now
+
+	offset := tr.statsSequence - tr.statsDriverSequence
+	if ru.Sequence <= tr.statsDriverSequence {
+		offset = tr.statsSequence
+	}
+	tr.statsDriverSequence = ru.Sequence
+	ru.Sequence += offset
+	tr.statsSequence = ru.Sequence
+}
+
+// stitchCpuSeconds keeps the TotalCpuSeconds of the task from decreasing
+// when the counter reported by the driver resets, such as when the task is
+// restarted or its executor replaced, by adding the CPU time counted before
+// the reset. Must be called with resourceUsageLock held.
+func (tr *TaskRunner) stitchCpuSeconds(ru *cstructs.TaskResourceUsage) {
+	if ru.ResourceUsage == nil || ru.ResourceUsage.CpuStats == nil {
+		return
+	}
+	cs := ru.ResourceUsage.CpuStats
+	if !slices.Contains(cs.Measured, "Total CPU Seconds") {
+		return
+	}
+
+	if cs.TotalCpuSeconds < tr.cpuSeconds {
+		tr.cpuSecondsOffset += tr.cpuSeconds
+	}
+	tr.cpuSeconds = cs.TotalCpuSeconds
+	cs.TotalCpuSeconds += tr.cpuSecondsOffset
+}
+
+// statsCheckpointInterval is how often the state of a task's stats is
+// persisted while the task runs, so an agent that exits without shutting down
+// restores it as of at most this long before.
+const statsCheckpointInterval = time.Minute
+
+// statsCheckpointDue returns whether the state of the task's stats is due to
+// be persisted, and if so records that it is. Must be called with
+// resourceUsageLock held.
+func (tr *TaskRunner) statsCheckpointDue() bool {
+	now := time.Now()
+	if now.Sub(tr.statsCheckpointedAt) < statsCheckpointInterval {
+		return false
+	}
+	tr.statsCheckpointedAt = now
+	return true
+}
+
+// persistStats checkpoints the state of the task's stats and persists it
+// along with the rest of the local state of the task.
+func (tr *TaskRunner) persistStats() {
+	tr.checkpointStats(false)
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("failed to persist task stats", "error", err)
+	}
+}
+
+// checkpointStats records the state of the task's stats in its local state,
+// so that when the agent is restarted, for example to upgrade it, the samples
+// of the task carry on from where they stopped. Shutdown is set for the
+// checkpoint taken when the agent shuts down, after which the task sends no
+// more samples.
+func (tr *TaskRunner) checkpointStats(shutdown bool) {
+	stats := tr.statsState(shutdown)
+
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+	tr.localState.Stats = stats
+}
+
+// statsState returns the state of the task's stats to checkpoint.
+func (tr *TaskRunner) statsState(shutdown bool) *state.StatsState {
+	tr.resourceUsageLock.Lock()
+	defer tr.resourceUsageLock.Unlock()
+
+	stats := &state.StatsState{
+		Sequence:         tr.statsSequence,
+		DriverSequence:   tr.statsDriverSequence,
+		CpuSeconds:       tr.cpuSeconds,
+		CpuSecondsOffset: tr.cpuSecondsOffset,
+		Window:           tr.usageWindow.snapshot(),
+		Meter:            tr.usageMeter.snapshot(),
+		Shutdown:         shutdown,
+	}
+	if tr.resourceUsage != nil {
+		// The usage of each process is left out since it is large and goes
+		// stale the fastest
+		latest := *tr.resourceUsage
+		latest.Pids = nil
+		latest.Window = nil
+		stats.Latest = &latest
+	}
+	return stats
+}
+
+// restoreStats restores the state of the task's stats checkpointed by the
+// previous agent. The checkpoint is cleared once restored, since the stats
+// it describes are stale if the agent exits without checkpointing again. The
+// restored sample is flagged as stale until the task sends a fresh one.
+func (tr *TaskRunner) restoreStats() {
+	stats := tr.localState.Stats
+	if stats == nil {
+		return
+	}
+	tr.localState.Stats = nil
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("failed to clear restored task stats", "error", err)
+	}
+
+	// The previous agent may have sent samples after a periodic checkpoint,
+	// so the sequence skips the most samples it could have sent since, and
+	// never repeats
+	sequence := stats.Sequence
+	if interval := tr.effectiveStatsInterval(); !stats.Shutdown && stats.Latest != nil && interval > 0 {
+		elapsed := time.Since(time.Unix(0, stats.Latest.Timestamp))
+		sequence += uint64(max(elapsed, 0) / interval)
+	}
+	if stats.Latest != nil {
+		stats.Latest.Stale = true
+	}
+
+	tr.resourceUsageLock.Lock()
+	tr.statsSequence = sequence
+	tr.statsDriverSequence = stats.DriverSequence
+	tr.cpuSeconds = stats.CpuSeconds
+	tr.cpuSecondsOffset = stats.CpuSecondsOffset
+	tr.resourceUsage = stats.Latest
+	tr.resourceUsageLock.Unlock()
+
+	tr.usageWindow.restore(stats.Window)
+	tr.usageMeter.restore(stats.Meter)
+	tr.logger.Trace("restored task stats", "sequence", stats.Sequence, "samples", len(stats.Window))
+}
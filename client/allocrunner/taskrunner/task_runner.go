@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -26,6 +27,10 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
@@ -75,12 +80,27 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// taskResources are the resources of the task, which change when the
+	// task is resized in place, and must be accessed with the lock held
+	taskResources     *structs.AllocatedTaskResources
+	taskResourcesLock sync.RWMutex
+
+	// oomMemoryStepMB is the step by which the restart policy of the task
+	// raises its memory limit after each OOM kill, and oomMemoryInterval the
+	// interval of the policy, after which the raise is reset unless the task
+	// is OOM-killed again. oomMemoryRaisedMB is how much the limit was
+	// raised, last at oomKilledAt, which must be accessed with
+	// taskResourcesLock held.
+	oomMemoryStepMB   int64
+	oomMemoryInterval time.Duration
+	oomMemoryRaisedMB int64
+	oomKilledAt       time.Time
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -222,9 +242,69 @@ type TaskRunner struct {
 	resourceUsage     *cstructs.TaskResourceUsage
 	resourceUsageLock sync.Mutex
 
+	// statsSequence is the sequence number of the latest resource usage
+	// sample, statsDriverSequence the one the driver numbered it with, and
+	// statsReceivedAt the monotonic time it was received at. Guarded by
+	// resourceUsageLock.
+	statsSequence       uint64
+	statsDriverSequence uint64
+	statsReceivedAt     time.Time
+
+	// cpuSeconds is the latest TotalCpuSeconds reported by the driver and
+	// cpuSecondsOffset the CPU time counted before that counter last reset.
+	// Guarded by resourceUsageLock.
+	cpuSeconds       float64
+	cpuSecondsOffset float64
+
+	// statsCheckpointedAt is when the state of the task's stats was last
+	// persisted. Guarded by resourceUsageLock.
+	statsCheckpointedAt time.Time
+
+	// killedResource is the resource the usage of which the task is being
+	// killed for, which annotates its samples until it starts again.
+	// Guarded by resourceUsageLock.
+	killedResource string
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// statsSink receives every resource usage sample of the task
+	statsSink cinterfaces.TaskStatsSink
+
+	// resourceRestarter evaluates the resource trigger of the task's restart
+	// policy against its resource usage samples
+	resourceRestarter resourceRestarter
+
+	// warmup tracks the warm-up of tasks with a warmup block
+	warmup warmupWatcher
+
+	// usageWindow retains recent samples of the task's resource usage
+	usageWindow usageWindow
+
+	// usageAnomaly flags abrupt changes of the task's resource usage
+	usageAnomaly usageAnomalyDetector
+
+	// usageMeter accumulates the task's resource usage for its billing record
+	usageMeter usageMeter
+
+	// logVolume reads the volume of the task's output from logmon
+	logVolume logVolume
+
+	// statsInterval returns the effective stats collection interval
+	statsInterval cinterfaces.StatsIntervalReporter
+
+	// allocStats batches the stats of the allocation's tasks for drivers
+	// that support it
+	allocStats *AllocStatsBatcher
+
+	// sharedCPU tracks the CPU the tasks of an allocation with shared
+	// resources use, to bound the CPU the task borrows
+	sharedCPU *SharedCPU
+
+	// statsDemand tracks reads of the task's stats when lazy stats
+	// collection is in effect. It is nil if stats are always collected.
+	statsDemand *statsDemand
+
 	// csiManager is used to manage the mounting of CSI volumes into tasks
 	csiManager csimanager.Manager
 
@@ -318,6 +398,20 @@ type Config struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	DeviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// StatsSink receives every resource usage sample of the task
+	StatsSink cinterfaces.TaskStatsSink
+
+	// StatsInterval returns the effective stats collection interval
+	StatsInterval cinterfaces.StatsIntervalReporter
+
+	// AllocStats batches the stats of the allocation's tasks for drivers
+	// that support it. If nil, stats are streamed per task.
+	AllocStats *AllocStatsBatcher
+
+	// SharedCPU tracks the CPU the tasks of an allocation with shared
+	// resources use. If nil, the group doesn't share resources.
+	SharedCPU *SharedCPU
+
 	// CSIManager is used to manage the mounting of CSI volumes into tasks
 	CSIManager csimanager.Manager
 
@@ -405,6 +499,10 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		stateDB:                 config.StateDB,
 		stateUpdater:            config.StateUpdater,
 		deviceStatsReporter:     config.DeviceStatsReporter,
+		statsSink:               config.StatsSink,
+		statsInterval:           config.StatsInterval,
+		allocStats:              config.AllocStats,
+		sharedCPU:               config.SharedCPU,
 		killCtx:                 killCtx,
 		killCtxCancel:           killCancel,
 		shutdownCtx:             trCtx,
@@ -448,7 +546,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 	// and tracking on the node, but now that we're creating the task driver
 	// config we only care about the memory without the secrets.
 	tres.Memory.MemoryMB -= int64(tr.task.Resources.SecretsMB)
-	tr.taskResources = tres
+	tr.setTaskResources(tres)
 
 	// Build the restart tracker.
 	rp := config.Task.RestartPolicy
@@ -461,6 +559,10 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		rp = tg.RestartPolicy
 	}
 	tr.restartTracker = restarts.NewRestartTracker(rp, tr.alloc.Job.Type, config.Task.Lifecycle)
+	if rp != nil {
+		tr.oomMemoryStepMB = int64(rp.OOMMemoryStepMB)
+		tr.oomMemoryInterval = rp.Interval
+	}
 
 	// Get the driver
 	if err := tr.initDriver(); err != nil {
@@ -472,6 +574,14 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 	// update this with a workload identity if one is available
 	tr.setNomadToken(config.ClientConfig.Node.SecretID)
 
+	// Stats are only collected lazily when no other consumer needs them
+	if tr.clientConfig.LazyTaskStats && !tr.clientConfig.PublishAllocationMetrics &&
+		(tr.statsSink == nil || !tr.statsSink.Enabled()) &&
+		(tr.task.RestartPolicy == nil || tr.task.RestartPolicy.ResourceTrigger == nil) &&
+		tr.task.Warmup == nil {
+		tr.statsDemand = newStatsDemand()
+	}
+
 	// Initialize the runners hooks. Must come after initDriver so hooks
 	// can use tr.driverCapabilities
 	tr.initHooks()
@@ -708,6 +818,7 @@ MAIN:
 
 		// Store the wait result on the restart tracker
 		tr.restartTracker.SetExitResult(result)
+		tr.escalateOOMMemory(result)
 
 		if err := tr.exited(); err != nil {
 			tr.logger.Error("exited hooks failed", "error", err)
@@ -850,7 +961,12 @@ func (tr *TaskRunner) emitExitResultEvent(result *drivers.ExitResult) {
 		SetExitCode(result.ExitCode).
 		SetSignal(result.Signal).
 		SetOOMKilled(result.OOMKilled).
+		SetExitReason(result.Reason()).
 		SetExitMessage(result.Err)
+	if result.OOMKilled {
+		event.SetKilledResource(structs.TaskKilledResourceMemory)
+		tr.AnnotateKill(structs.TaskKilledResourceMemory)
+	}
 
 	tr.EmitEvent(event)
 
@@ -902,8 +1018,31 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 	}
 }
 
+// sharedMemoryResources returns the resources of the task with the memory
+// ceiling of its alloc as its memory_max, if it has none and runs under the
+// alloc-level cgroup of an alloc whose group has shared_resources, so it may
+// use the memory the other tasks of the alloc don't.
+func (tr *TaskRunner) sharedMemoryResources(taskResources *structs.AllocatedTaskResources) *structs.AllocatedTaskResources {
+	if taskResources.Memory.MemoryMaxMB != 0 || len(taskResources.Cpu.ReservedCores) > 0 {
+		return taskResources
+	}
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.SharedCgroup || cgroupslib.GetMode() != cgroupslib.CG2 {
+		return taskResources
+	}
+	alloc := tr.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.SharedResources == nil || alloc.AllocatedResources == nil {
+		return taskResources
+	}
+
+	memoryMB, _ := tg.SharedResources.Ceiling(alloc.AllocatedResources.Tasks)
+	shared := taskResources.Copy()
+	shared.Memory.MemoryMaxMB = memoryMB
+	return shared
+}
+
 func (tr *TaskRunner) assignCgroup(taskConfig *drivers.TaskConfig) {
-	reserveCores := len(tr.taskResources.Cpu.ReservedCores) > 0
+	reserveCores := len(tr.getTaskResources().Cpu.ReservedCores) > 0
 	p := cgroupslib.LinuxResourcesPath(taskConfig.AllocID, taskConfig.Name, reserveCores)
 	taskConfig.Resources.LinuxResources.CpusetCgroupPath = p
 }
@@ -999,10 +1138,17 @@ func (tr *TaskRunner) runDriver() error {
 	}
 	tr.stateLock.Unlock()
 
-	tr.setDriverHandle(NewDriverHandle(tr.driver, taskConfig.ID, tr.Task(), tr.clientConfig.MaxKillTimeout, net))
+	tr.setDriverHandle(tr.newDriverHandle(taskConfig.ID, net))
 
 	// Emit an event that we started
-	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(structs.TaskStarted))
+	event := structs.NewTaskEvent(structs.TaskStarted)
+	if tr.clientConfig.AuditTaskStarts {
+		record := taskStartRecord(taskConfig)
+		event.SetTaskStartRecord(record)
+		tr.logger.Info("task started", "command", record.Command, "args_sha256", record.ArgsSHA256,
+			"image", record.Image, "user", record.User, "cgroup", record.Cgroup, "env_sha256", record.EnvSHA256)
+	}
+	tr.UpdateState(structs.TaskStateRunning, event)
 	return nil
 }
 
@@ -1028,6 +1174,18 @@ func (tr *TaskRunner) initDriver() error {
 	if err != nil {
 		return err
 	}
+
+	// Stats the driver didn't describe properly are dropped rather than
+	// failing the task, since they are only informational
+	if caps.Stats != nil {
+		stats, err := drivers.SanitizeStatsCapabilities(caps.Stats)
+		if err != nil {
+			tr.logger.Warn("driver declared an invalid stats schema", "error", err)
+		}
+		sanitized := *caps
+		sanitized.Stats = stats
+		caps = &sanitized
+	}
 	tr.driverCapabilities = caps
 
 	return nil
@@ -1165,7 +1323,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Short()
-	taskResources := tr.taskResources
+	taskResources := tr.getTaskResources()
 	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
@@ -1196,6 +1354,8 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
+	taskResources = tr.oomMemoryResources(tr.sharedMemoryResources(taskResources))
+
 	memoryLimit := taskResources.Memory.MemoryMB
 	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
 		memoryLimit = max
@@ -1206,6 +1366,9 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		cpusetCpus[i] = fmt.Sprintf("%d", v)
 	}
 
+	memoryPolicy, memoryNodes := numaMemoryPolicy(task.Resources,
+		tr.clientConfig.Node.NodeResources.Processors.Topology, taskResources.Cpu.ReservedCores)
+
 	return &drivers.TaskConfig{
 		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
 		Name:          task.Name,
@@ -1222,6 +1385,8 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 				MemoryLimitBytes: memoryLimit * 1024 * 1024,
 				CPUShares:        taskResources.Cpu.CpuShares,
 				CpusetCpus:       strings.Join(cpusetCpus, ","),
+				MemoryPolicy:     memoryPolicy,
+				MemoryNodes:      memoryNodes,
 				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
 			},
 			Ports: &ports,
@@ -1234,12 +1399,27 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		AllocDir:         tr.taskDir.AllocDir,
 		StdoutPath:       tr.logmonHookConfig.stdoutFifo,
 		StderrPath:       tr.logmonHookConfig.stderrFifo,
+		FramedLogs:       tr.logmonHookConfig.framedLogs,
+		QoSClasses:       tr.clientConfig.QoSClasses,
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
 		DNS:              dns,
 	}
 }
 
+// numaMemoryPolicy returns the numa memory policy of a task, and the numa
+// nodes of its reserved cores the policy applies to in cpuset list notation.
+func numaMemoryPolicy(resources *structs.Resources, top *numalib.Topology, cores []uint16) (string, string) {
+	if resources == nil || resources.NUMA == nil || resources.NUMA.MemoryPolicy == "" || top == nil {
+		return "", ""
+	}
+	nodes := top.CoreNodes(idset.From[hw.CoreID](cores))
+	if nodes.Empty() {
+		return "", ""
+	}
+	return resources.NUMA.MemoryPolicy, nodes.String()
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1251,6 +1431,8 @@ func (tr *TaskRunner) Restore() error {
 	if ls != nil {
 		ls.Canonicalize()
 		tr.localState = ls
+		tr.restoreStats()
+		tr.restoreOOMMemory()
 	}
 
 	if ts != nil {
@@ -1321,10 +1503,21 @@ func (tr *TaskRunner) restoreHandle(taskHandle *drivers.TaskHandle, net *drivers
 	}
 
 	// Update driver handle on task runner
-	tr.setDriverHandle(NewDriverHandle(tr.driver, taskHandle.Config.ID, tr.Task(), tr.clientConfig.MaxKillTimeout, net))
+	tr.setDriverHandle(tr.newDriverHandle(taskHandle.Config.ID, net))
 	return true
 }
 
+// newDriverHandle returns a handle for the task, batching its stats with the
+// allocation's other tasks if the driver supports it.
+func (tr *TaskRunner) newDriverHandle(taskID string, net *drivers.DriverNetwork) *DriverHandle {
+	handle := NewDriverHandle(tr.driver, taskID, tr.Task(), tr.clientConfig.MaxKillTimeout, net)
+	if tr.allocStats != nil && tr.driverCapabilities != nil && tr.driverCapabilities.AllocStats {
+		handle.allocStats = tr.allocStats
+		handle.driverName = tr.Task().Driver
+	}
+	return handle
+}
+
 // UpdateState sets the task runners allocation state and triggers a server
 // update.
 func (tr *TaskRunner) UpdateState(state string, event *structs.TaskEvent) {
@@ -1446,8 +1639,35 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 		tr.state.LastRestart = time.Unix(0, event.Time)
 	}
 
+	// Restart the warm-up when the task starts, and record when it ends
+	switch event.Type {
+	case structs.TaskStarted:
+		tr.warmup.reset()
+		tr.usageAnomaly.reset()
+		tr.resourceUsageLock.Lock()
+		tr.killedResource = ""
+		tr.resourceUsageLock.Unlock()
+	case structs.TaskWarmedUp:
+		tr.state.WarmedUpAt = time.Unix(0, event.Time)
+	}
+
+	// Propagate the exit reason from event to task state
+	if event.Type == structs.TaskTerminated {
+		if reason := event.Details["exit_reason"]; reason != "" {
+			tr.state.ExitReason = reason
+		}
+	}
+
 	tr.logger.Info("Task event", "type", event.Type, "msg", event.DisplayMessage, "failed", event.FailsTask)
 
+	// Only the latest usage anomaly is kept, so anomalies don't push the
+	// other events out
+	if event.Type == structs.TaskUsageAnomaly {
+		tr.state.Events = slices.DeleteFunc(slices.Clone(tr.state.Events), func(e *structs.TaskEvent) bool {
+			return e.Type == structs.TaskUsageAnomaly
+		})
+	}
+
 	// Append event to slice
 	appendTaskEvent(tr.state, event, tr.maxEvents)
 
@@ -1481,6 +1701,11 @@ func (tr *TaskRunner) Update(update *structs.Allocation) {
 	// Update tr.alloc
 	tr.setAlloc(update, task)
 
+	// Apply the cpu and memory of tasks resized in place
+	if !update.TerminalStatus() {
+		tr.resize(update, task)
+	}
+
 	// Trigger update hooks if not terminal
 	if !update.TerminalStatus() {
 		tr.triggerUpdateHooks()
@@ -1532,7 +1757,9 @@ func (tr *TaskRunner) Shutdown() {
 	// Run shutdown hooks to cleanup
 	tr.shutdownHooks()
 
-	// Persist once more
+	// Persist once more, along with the stats so the next agent carries on
+	// from them
+	tr.checkpointStats(true)
 	tr.persistLocalState()
 }
 
@@ -1540,25 +1767,317 @@ func (tr *TaskRunner) Shutdown() {
 // collected. May return nil if the task is not running or no resource
 // utilization has been collected yet.
 func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
-	tr.resourceUsageLock.Lock()
-	ru := tr.resourceUsage
-	tr.resourceUsageLock.Unlock()
+	if tr.statsDemand != nil {
+		tr.statsDemand.Touch()
+	}
 
-	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
-	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
-		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
+	ru := tr.PeekResourceUsage()
+	if ru == nil || ru.ResourceUsage == nil || tr.deviceStatsReporter == nil || !tr.reportsDeviceStats() {
+		return ru
 	}
-	return ru
+
+	// Look up device statistics lazily when fetched, as currently we do not
+	// emit any stats for them yet. The sample is shared with other readers,
+	// so they are set on a copy.
+	deviceResources := tr.getTaskResources().Devices
+	usage := *ru.ResourceUsage
+	usage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
+	out := *ru
+	out.ResourceUsage = &usage
+	return &out
+}
+
+// reportsDeviceStats returns whether the task's driver reports the usage of
+// devices attached to the task. Drivers that don't declare their stats are
+// assumed to.
+func (tr *TaskRunner) reportsDeviceStats() bool {
+	caps := tr.driverCapabilities
+	return caps == nil || caps.Stats == nil || caps.Stats.Devices
+}
+
+// PeekResourceUsage returns the last resource utilization datapoint collected
+// like LatestResourceUsage, but without counting as a read of the task's
+// stats, so that the client's own periodic consumers of the stats don't keep
+// lazy collection from pausing. Device statistics are not looked up.
+func (tr *TaskRunner) PeekResourceUsage() *cstructs.TaskResourceUsage {
+	tr.resourceUsageLock.Lock()
+	defer tr.resourceUsageLock.Unlock()
+	return tr.resourceUsage
+}
+
+// StatsPaused returns whether lazy collection of the task's stats is paused,
+// in which case its last sample may be arbitrarily old.
+func (tr *TaskRunner) StatsPaused() bool {
+	return tr.statsDemand != nil && tr.statsDemand.Paused()
 }
 
 // UpdateStats updates and emits the latest stats from the driver.
 func (tr *TaskRunner) UpdateStats(ru *cstructs.TaskResourceUsage) {
+	if ru != nil && tr.driverCapabilities != nil {
+		applyStatsCapabilities(ru, tr.driverCapabilities.Stats)
+	}
+	if ru != nil && tr.sharesCPU() {
+		applyBorrowedTicks(ru, tr.taskName, tr.getTaskResources().Cpu.CpuShares, tr.sharedCPU)
+	}
+	if ru != nil {
+		res := tr.getTaskResources()
+		applyCPUPercentNormalization(ru, res, tr.nodeCompute())
+		ru.Allocated = newTaskAllocatedResources(res)
+	}
+	if ru != nil {
+		// Reading logmon is an RPC, so it isn't done with the lock held
+		tr.logVolume.record(ru)
+	}
+
+	if tr.setResourceUsage(ru) {
+		tr.persistStats()
+	}
+	if ru != nil {
+		tr.updateTaskIdentity(ru)
+		tr.emitStats(ru)
+		if tr.statsSink != nil {
+			tr.statsSink.EmitTaskStats(tr.Alloc(), tr.taskName, ru)
+		}
+		tr.checkResourceTrigger(ru)
+		tr.checkWarmup(ru)
+		tr.checkUsageAnomaly(ru)
+		tr.usageWindow.record(ru, tr.effectiveStatsInterval())
+		tr.usageMeter.record(ru, tr.effectiveStatsInterval())
+	}
+}
+
+// setResourceUsage numbers the sample and stitches its counters, and stores
+// it as the latest sample of the task. It returns whether the state of the
+// task's stats is due to be persisted. The lock is released on a deferred
+// call, since the stats hook recovers from panics while updating the stats.
+func (tr *TaskRunner) setResourceUsage(ru *cstructs.TaskResourceUsage) bool {
 	tr.resourceUsageLock.Lock()
+	defer tr.resourceUsageLock.Unlock()
+
 	tr.resourceUsage = ru
+	if ru == nil {
+		return false
+	}
+	tr.stitchSequence(ru)
+	tr.stitchCpuSeconds(ru)
+	ru.KilledResource = tr.killedResource
+	return tr.statsCheckpointDue()
+}
+
+// AnnotateKill records that the task is being killed for its usage of the
+// resource, such as "memory" or "cpu". The latest sample of the task and the
+// samples it sends until it starts again are annotated with the resource,
+// and the annotated sample is sent to the stats sinks again, so consumers of
+// the stats see why the task stopped.
+func (tr *TaskRunner) AnnotateKill(resource string) {
+	if resource == "" {
+		return
+	}
+
+	tr.resourceUsageLock.Lock()
+	tr.killedResource = resource
+	var annotated *cstructs.TaskResourceUsage
+	if tr.resourceUsage != nil {
+		// Samples are shared with their readers, so the latest one is
+		// replaced rather than modified
+		latest := *tr.resourceUsage
+		latest.KilledResource = resource
+		tr.resourceUsage = &latest
+		annotated = &latest
+	}
 	tr.resourceUsageLock.Unlock()
-	if ru != nil {
-		tr.emitStats(ru)
+
+	if annotated != nil && tr.statsSink != nil {
+		tr.statsSink.EmitTaskStats(tr.Alloc(), tr.taskName, annotated)
+	}
+}
+
+// updateTaskIdentity copies the cgroup and executor process the driver reported
+// with the stats into the task state, notifying the alloc runner so that they
+// are published in the allocation when they change, such as after the task
+// restarts.
+func (tr *TaskRunner) updateTaskIdentity(ru *cstructs.TaskResourceUsage) {
+	if ru.CgroupPath == "" && ru.CgroupID == 0 && ru.ExecutorPID == 0 {
+		return
+	}
+	if tr.setTaskIdentity(ru) {
+		tr.stateUpdater.TaskStateUpdated()
+	}
+}
+
+// setTaskIdentity copies the cgroup and executor process of the sample into
+// the task state and persists it, and returns whether they changed.
+func (tr *TaskRunner) setTaskIdentity(ru *cstructs.TaskResourceUsage) bool {
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+
+	state := tr.state
+	if state.CgroupPath == ru.CgroupPath && state.CgroupID == ru.CgroupID && state.ExecutorPID == ru.ExecutorPID {
+		return false
+	}
+	state.CgroupPath, state.CgroupID, state.ExecutorPID = ru.CgroupPath, ru.CgroupID, ru.ExecutorPID
+	if err := tr.stateDB.PutTaskState(tr.allocID, tr.taskName, state); err != nil {
+		// Only a warning because the next state transition will try to
+		// persist it again
+		tr.logger.Warn("error persisting task identity", "error", err)
+	}
+	return true
+}
+
+// effectiveStatsInterval returns the interval stats are currently collected
+// at, which the client may have backed off from the configured one.
+func (tr *TaskRunner) effectiveStatsInterval() time.Duration {
+	if tr.statsInterval != nil {
+		return tr.statsInterval.StatsInterval()
+	}
+	return tr.clientConfig.StatsCollectionInterval
+}
+
+// applyStatsCapabilities records the stats the driver declared it reports on
+// the usage and drops any it populated but did not declare, so consumers
+// don't render unsupported stats as zero values. Gauges take the unit of their
+// schema, and the measured memory and CPU stats are limited to the ones the
+// driver declared, if it did.
+func applyStatsCapabilities(ru *cstructs.TaskResourceUsage, caps *cstructs.StatsCapabilities) {
+	if caps == nil {
+		return
+	}
+	ru.Capabilities = caps
+	if ru.ResourceUsage == nil {
+		return
+	}
+	if !caps.Devices {
+		ru.ResourceUsage.DeviceStats = nil
+	}
+	if !caps.Network {
+		ru.ResourceUsage.Egress = nil
+	}
+	if !caps.DiskIO {
+		ru.ResourceUsage.DiskIO = nil
+	}
+	if !caps.Pressure {
+		ru.ResourceUsage.Pressure = nil
+	}
+	for name, g := range ru.ResourceUsage.Gauges {
+		schema := caps.GaugeSchema(name)
+		switch {
+		case schema == nil && !slices.Contains(caps.Gauges, name):
+			delete(ru.ResourceUsage.Gauges, name)
+		case schema == nil || schema.Unit == "":
+		case g.Unit == "":
+			g.Unit = schema.Unit
+		case g.Unit != schema.Unit:
+			// Gauges in another unit than described would be mislabeled
+			delete(ru.ResourceUsage.Gauges, name)
+		}
+	}
+	if ms := ru.ResourceUsage.MemoryStats; ms != nil && len(caps.MemoryMeasured) > 0 {
+		ms.Measured = declaredMeasured(ms.Measured, caps.MemoryMeasured)
+	}
+	if cs := ru.ResourceUsage.CpuStats; cs != nil && len(caps.CpuMeasured) > 0 {
+		cs.Measured = declaredMeasured(cs.Measured, caps.CpuMeasured)
+	}
+}
+
+// declaredMeasured returns the stats of a sample that are also declared, as a
+// sample that failed to read a declared stat must not claim to have measured it.
+func declaredMeasured(measured, declared []string) []string {
+	var out []string
+	for _, m := range measured {
+		if slices.Contains(declared, m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// sharesCPU returns whether the task runs under the alloc-level cgroup of an
+// alloc whose group shares resources, so it may borrow the CPU its siblings
+// don't use.
+func (tr *TaskRunner) sharesCPU() bool {
+	if tr.sharedCPU == nil || tr.driverCapabilities == nil || !tr.driverCapabilities.SharedCgroup ||
+		cgroupslib.GetMode() != cgroupslib.CG2 {
+		return false
+	}
+	alloc := tr.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	return tg != nil && tg.SharedResources != nil
+}
+
+// applyBorrowedTicks records the CPU in MHz the task used beyond its own
+// cpuShares which the other tasks of its alloc reserved but don't use. CPU
+// beyond that comes from the idle CPU of the node and isn't borrowed.
+func applyBorrowedTicks(ru *cstructs.TaskResourceUsage, task string, cpuShares int64, shared *SharedCPU) {
+	if ru.ResourceUsage == nil || ru.ResourceUsage.CpuStats == nil {
+		return
+	}
+	cs := ru.ResourceUsage.CpuStats
+	cs.BorrowedTicks = shared.Borrowed(task, float64(cpuShares), cs.TotalTicks)
+	if !slices.Contains(cs.Measured, "Borrowed Ticks") {
+		cs.Measured = append(cs.Measured, "Borrowed Ticks")
+	}
+}
+
+// nodeCompute returns the CPU of the node the task runs on.
+func (tr *TaskRunner) nodeCompute() cpustats.Compute {
+	node := tr.clientConfig.Node
+	if node == nil || node.NodeResources == nil || node.NodeResources.Processors.Topology == nil {
+		return cpustats.Compute{}
+	}
+	return node.NodeResources.Processors.Topology.Compute()
+}
+
+// applyCPUPercentNormalization records the CPU percent of the task, where
+// 100% is one core, normalized to the CPU allocated to the task and to all
+// the cores of the node too. Tasks with reserved cores are allocated whole
+// cores, and other tasks are allocated a share of the MHz of the node,
+// converted to cores.
+func applyCPUPercentNormalization(ru *cstructs.TaskResourceUsage, res *structs.AllocatedTaskResources, compute cpustats.Compute) {
+	if ru.ResourceUsage == nil || ru.ResourceUsage.CpuStats == nil ||
+		compute.NumCores <= 0 || compute.TotalCompute <= 0 {
+		return
+	}
+	cs := ru.ResourceUsage.CpuStats
+	cs.NodePercent = cs.Percent / float64(compute.NumCores)
+
+	var allocatedCores float64
+	if res != nil {
+		if n := len(res.Cpu.ReservedCores); n > 0 {
+			allocatedCores = float64(n)
+		} else {
+			mhzPerCore := float64(compute.TotalCompute) / float64(compute.NumCores)
+			allocatedCores = float64(res.Cpu.CpuShares) / mhzPerCore
+		}
+	}
+	if allocatedCores > 0 {
+		cs.AllocatedPercent = cs.Percent / allocatedCores
+	}
+}
+
+// newTaskAllocatedResources returns the CPU and memory of the resources
+// allocated to a task, or nil if they aren't known.
+func newTaskAllocatedResources(res *structs.AllocatedTaskResources) *cstructs.TaskAllocatedResources {
+	if res == nil {
+		return nil
+	}
+	return &cstructs.TaskAllocatedResources{
+		CpuShares:     res.Cpu.CpuShares,
+		ReservedCores: slices.Clone(res.Cpu.ReservedCores),
+		MemoryMB:      res.Memory.MemoryMB,
+		MemoryMaxMB:   res.Memory.MemoryMaxMB,
+	}
+}
+
+// cpuPercent returns the CPU percent of the task in the convention the
+// client is configured to publish.
+func (tr *TaskRunner) cpuPercent(cs *cstructs.CpuStats) float64 {
+	switch tr.clientConfig.CPUPercentNormalization {
+	case config.CPUPercentAllocated:
+		return cs.AllocatedPercent
+	case config.CPUPercentNode:
+		return cs.NodePercent
+	default:
+		return cs.Percent
 	}
 }
 
@@ -1586,6 +2105,7 @@ func (tr *TaskRunner) setGaugeForMemory(ru *cstructs.TaskResourceUsage) {
 	publishMetric(ms.Cache, "cache", "Cache")
 	publishMetric(ms.Swap, "swap", "Swap")
 	publishMetric(ms.MappedFile, "mapped_file", "Mapped File")
+	publishMetric(ms.Shared, "shared", "Shared")
 	publishMetric(ms.Usage, "usage", "Usage")
 	publishMetric(ms.MaxUsage, "max_usage", "Max Usage")
 	publishMetric(ms.KernelUsage, "kernel_usage", "Kernel Usage")
@@ -1609,7 +2129,7 @@ func (tr *TaskRunner) setGaugeForCPU(ru *cstructs.TaskResourceUsage) {
 	}
 
 	metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "total_percent"},
-		float32(ru.ResourceUsage.CpuStats.Percent), tr.baseLabels)
+		float32(tr.cpuPercent(ru.ResourceUsage.CpuStats)), tr.baseLabels)
 	metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "system"},
 		float32(ru.ResourceUsage.CpuStats.SystemMode), tr.baseLabels)
 	metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "user"},
@@ -1622,10 +2142,50 @@ func (tr *TaskRunner) setGaugeForCPU(ru *cstructs.TaskResourceUsage) {
 		float32(ru.ResourceUsage.CpuStats.TotalTicks), tr.baseLabels)
 	metrics.IncrCounterWithLabels([]string{"client", "allocs", "cpu", "total_ticks_count"},
 		float32(ru.ResourceUsage.CpuStats.TotalTicks), tr.baseLabels)
+	if slices.Contains(ru.ResourceUsage.CpuStats.Measured, "Total CPU Seconds") {
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "total_cpu_seconds"},
+			float32(ru.ResourceUsage.CpuStats.TotalCpuSeconds), tr.baseLabels)
+	}
+	if slices.Contains(ru.ResourceUsage.CpuStats.Measured, "Borrowed Ticks") {
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "borrowed_ticks"},
+			float32(ru.ResourceUsage.CpuStats.BorrowedTicks), tr.baseLabels)
+	}
 	if allocatedCPU > 0 {
 		metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "allocated"},
 			allocatedCPU, tr.baseLabels)
 	}
+	for _, core := range ru.ResourceUsage.CpuStats.Cores {
+		labels := append(slices.Clone(tr.baseLabels),
+			metrics.Label{Name: "core", Value: strconv.Itoa(int(core.ID))})
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "cpu", "core_percent"},
+			float32(core.Percent), labels)
+	}
+}
+
+func (tr *TaskRunner) setGaugeForPerf(ru *cstructs.TaskResourceUsage) {
+	ps := ru.ResourceUsage.PerfStats
+
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "perf", "instructions_per_cycle"},
+		float32(ps.InstructionsPerCycle), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "perf", "cache_misses"},
+		float32(ps.CacheMisses), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "perf", "cache_miss_rate"},
+		float32(ps.CacheMissRate), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "perf", "branch_misses"},
+		float32(ps.BranchMisses), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "perf", "branch_miss_rate"},
+		float32(ps.BranchMissRate), tr.baseLabels)
+}
+
+func (tr *TaskRunner) setGaugeForResctrl(ru *cstructs.TaskResourceUsage) {
+	rs := ru.ResourceUsage.Resctrl
+
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "resctrl", "llc_occupancy"},
+		float32(rs.LLCOccupancy), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "resctrl", "mbm_total_bytes"},
+		float32(rs.MBMTotalBytes), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "resctrl", "mbm_local_bytes"},
+		float32(rs.MBMLocalBytes), tr.baseLabels)
 }
 
 // emitStats emits resource usage stats of tasks to remote metrics collector
@@ -1646,6 +2206,55 @@ func (tr *TaskRunner) emitStats(ru *cstructs.TaskResourceUsage) {
 	} else {
 		tr.logger.Debug("Skipping cpu stats for allocation", "reason", "CpuStats is nil")
 	}
+
+	// perf event stats are opt-in, so there is nothing to log when missing
+	if ru.ResourceUsage.PerfStats != nil {
+		tr.setGaugeForPerf(ru)
+	}
+
+	if ru.ResourceUsage.Resctrl != nil {
+		tr.setGaugeForResctrl(ru)
+	}
+
+	if ru.Logs != nil {
+		tr.setGaugeForLogs(ru)
+	}
+
+	for name, g := range ru.ResourceUsage.Gauges {
+		labels := tr.baseLabels
+		if g.Unit != "" {
+			labels = append(slices.Clip(labels), metrics.Label{Name: "unit", Value: g.Unit})
+		}
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "driver", gaugeMetricName(name)},
+			float32(g.Value), labels)
+	}
+}
+
+func (tr *TaskRunner) setGaugeForLogs(ru *cstructs.TaskResourceUsage) {
+	for stream, ls := range map[string]*cstructs.LogStreamStats{
+		"stdout": ru.Logs.Stdout,
+		"stderr": ru.Logs.Stderr,
+	} {
+		if ls == nil {
+			continue
+		}
+		labels := append(slices.Clone(tr.baseLabels), metrics.Label{Name: "stream", Value: stream})
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "logs", "bytes_per_second"},
+			float32(ls.BytesPerSecond), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "logs", "lines_per_second"},
+			float32(ls.LinesPerSecond), labels)
+	}
+}
+
+// gaugeMetricName returns the name of the metric of a driver-specific gauge,
+// replacing the characters metrics sinks don't accept in names.
+func gaugeMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
 }
 
 // appendTaskEvent updates the task status by appending the new event.
@@ -1679,6 +2288,18 @@ func (tr *TaskRunner) DriverCapabilities() (*drivers.Capabilities, error) {
 	return tr.driver.Capabilities()
 }
 
+// Processes returns a snapshot of the processes running in the task.
+func (tr *TaskRunner) Processes() ([]*cstructs.TaskProcess, error) {
+	handle := tr.getDriverHandle()
+	if handle == nil {
+		return nil, ErrTaskNotRunning
+	}
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Processes {
+		return nil, ErrProcessesNotSupported
+	}
+	return handle.Processes()
+}
+
 // shutdownDelayCancel is used for testing only and cancels the
 // shutdownDelayCtx
 func (tr *TaskRunner) shutdownDelayCancel() {
@@ -95,6 +95,21 @@ func (tr *TaskRunner) setNomadToken(token string) {
 	}
 }
 
+// getTaskResources returns the resources of the task.
+func (tr *TaskRunner) getTaskResources() *structs.AllocatedTaskResources {
+	tr.taskResourcesLock.RLock()
+	defer tr.taskResourcesLock.RUnlock()
+	return tr.taskResources
+}
+
+// setTaskResources sets the resources of the task, when it's created or
+// resized in place.
+func (tr *TaskRunner) setTaskResources(resources *structs.AllocatedTaskResources) {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	tr.taskResources = resources
+}
+
 // getDriverHandle returns a driver handle.
 func (tr *TaskRunner) getDriverHandle() *DriverHandle {
 	tr.handleLock.Lock()
@@ -70,7 +70,7 @@ func (tr *TaskRunner) initHooks() {
 		newDispatchHook(alloc, hookLogger),
 		newVolumeHook(tr, hookLogger),
 		newArtifactHook(tr, tr.getter, hookLogger),
-		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
+		newStatsHook(tr, task.Driver, tr.clientConfig.StatsCollectionInterval, tr.statsInterval, tr.statsDemand, hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
 		newAPIHook(tr.shutdownCtx, tr.clientConfig.APIListenerRegistrar, hookLogger),
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
@@ -250,7 +250,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.getTaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
@@ -390,6 +390,11 @@ func (tr *TaskRunner) poststart() error {
 
 // exited is used to run the exited hooks before a task is stopped.
 func (tr *TaskRunner) exited() error {
+	// The CPU the task reserves is idle for its siblings once it exited
+	if tr.sharedCPU != nil {
+		tr.sharedCPU.Exited(tr.taskName)
+	}
+
 	if tr.logger.IsTrace() {
 		start := time.Now()
 		tr.logger.Trace("running exited hooks", "start", start)
@@ -30,6 +30,10 @@ import (
 	consulclient "github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	regMock "github.com/hashicorp/nomad/client/serviceregistration/mock"
@@ -276,6 +280,29 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 	}
 }
 
+func TestTaskRunner_numaMemoryPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	top := numalib.MockTopology(idset.From[hw.NodeID]([]hw.NodeID{0, 1, 2}), numalib.SLIT{}, []numalib.Core{
+		{ID: 0, NodeID: 0}, {ID: 1, NodeID: 0},
+		{ID: 2, NodeID: 1}, {ID: 3, NodeID: 1},
+		{ID: 4, NodeID: 2}, {ID: 5, NodeID: 2},
+	})
+	resources := &structs.Resources{
+		Cores: 3,
+		NUMA:  &structs.NUMA{Affinity: "prefer", MemoryPolicy: "interleave"},
+	}
+
+	// The policy applies to the nodes of the reserved cores only
+	policy, nodes := numaMemoryPolicy(resources, top, []uint16{1, 4, 5})
+	must.Eq(t, "interleave", policy)
+	must.Eq(t, "0,2", nodes)
+
+	policy, nodes = numaMemoryPolicy(&structs.Resources{NUMA: &structs.NUMA{Affinity: "none"}}, top, []uint16{1})
+	must.Eq(t, "", policy)
+	must.Eq(t, "", nodes)
+}
+
 // TestTaskRunner_Stop_ExitCode asserts that the exit code is captured on a task, even if it's stopped
 func TestTaskRunner_Stop_ExitCode(t *testing.T) {
 	ctestutil.ExecCompatible(t)
@@ -2765,6 +2792,41 @@ func TestTaskRunner_VaultManager_Signal(t *testing.T) {
 
 }
 
+// TestTaskRunner_ExitReason asserts the reason a task exited for is set on
+// the Terminated event and the task state.
+func TestTaskRunner_ExitReason(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	rp := &structs.RestartPolicy{Attempts: 0, Mode: structs.RestartPolicyModeFail}
+	alloc.Job.TaskGroups[0].RestartPolicy = rp
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.RestartPolicy = rp
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"exit_code": "3",
+		"run_for":   "1ns",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForTaskToDie(t, tr)
+
+	state := tr.TaskState()
+	must.Eq(t, structs.TaskStateDead, state.State)
+	must.Eq(t, structs.TaskExitReasonNonZeroExit, state.ExitReason)
+
+	var terminated *structs.TaskEvent
+	for _, e := range state.Events {
+		if e.Type == structs.TaskTerminated {
+			terminated = e
+		}
+	}
+	must.NotNil(t, terminated)
+	must.Eq(t, structs.TaskExitReasonNonZeroExit, terminated.Details["exit_reason"])
+}
+
 // TestTaskRunner_UnregisterConsul_Retries asserts a task is unregistered from
 // Consul when waiting to be retried.
 func TestTaskRunner_UnregisterConsul_Retries(t *testing.T) {
@@ -3101,3 +3163,623 @@ func TestTaskRunner_AllocNetworkStatus(t *testing.T) {
 		})
 	}
 }
+
+// TestTaskRunner_UpdateStats_Identity asserts the cgroup and executor process
+// reported with the stats are published in the task state when they change.
+func TestTaskRunner_UpdateStats_Identity(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+	updater := conf.StateUpdater.(*MockTaskStateUpdater)
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	sample := func(executorPID int) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+				CpuStats:    &cstructs.CpuStats{},
+			},
+			Timestamp:   time.Now().UnixNano(),
+			CgroupPath:  "/sys/fs/cgroup/nomad.slice/share.slice/abc.web.scope",
+			CgroupID:    4026,
+			ExecutorPID: executorPID,
+		}
+	}
+
+	tr.UpdateStats(sample(3417))
+	must.Eq(t, 1, len(updater.ch))
+	<-updater.ch
+	state := tr.TaskState()
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/share.slice/abc.web.scope", state.CgroupPath)
+	must.Eq(t, 4026, state.CgroupID)
+	must.Eq(t, 3417, state.ExecutorPID)
+
+	// Unchanged identities don't update the task state
+	tr.UpdateStats(sample(3417))
+	must.Eq(t, 0, len(updater.ch))
+
+	// A restarted task has a new executor
+	tr.UpdateStats(sample(3500))
+	must.Eq(t, 1, len(updater.ch))
+	must.Eq(t, 3500, tr.TaskState().ExecutorPID)
+}
+
+func TestTaskRunner_applyStatsCapabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	newUsage := func() *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				DeviceStats: []*device.DeviceGroupStats{{Name: "gpu"}},
+				Gauges: map[string]*cstructs.Gauge{
+					"queue_depth": {Value: 3},
+					"undeclared":  {Value: 1},
+				},
+				Egress:   map[string]*cstructs.EgressStats{"internet": {Bytes: 1024}},
+				DiskIO:   &cstructs.DiskIOStats{ReadBytes: 4096},
+				Pressure: &cstructs.PressureStats{IO: 1.5},
+			},
+		}
+	}
+
+	// drivers that don't declare their stats are passed through
+	ru := newUsage()
+	applyStatsCapabilities(ru, nil)
+	must.Nil(t, ru.Capabilities)
+	must.Len(t, 1, ru.ResourceUsage.DeviceStats)
+	must.MapLen(t, 2, ru.ResourceUsage.Gauges)
+
+	caps := &cstructs.StatsCapabilities{Network: true}
+	ru = newUsage()
+	applyStatsCapabilities(ru, caps)
+	must.Eq(t, caps, ru.Capabilities)
+	must.Nil(t, ru.ResourceUsage.DeviceStats)
+	must.MapEmpty(t, ru.ResourceUsage.Gauges)
+	must.MapLen(t, 1, ru.ResourceUsage.Egress)
+	must.Nil(t, ru.ResourceUsage.DiskIO)
+	must.Nil(t, ru.ResourceUsage.Pressure)
+
+	caps = &cstructs.StatsCapabilities{DiskIO: true, Pressure: true}
+	ru = newUsage()
+	applyStatsCapabilities(ru, caps)
+	must.Nil(t, ru.ResourceUsage.Egress)
+	must.NotNil(t, ru.ResourceUsage.DiskIO)
+	must.NotNil(t, ru.ResourceUsage.Pressure)
+
+	caps = &cstructs.StatsCapabilities{Devices: true, Gauges: []string{"queue_depth"}}
+	ru = newUsage()
+	applyStatsCapabilities(ru, caps)
+	must.Len(t, 1, ru.ResourceUsage.DeviceStats)
+	must.MapContainsKeys(t, ru.ResourceUsage.Gauges, []string{"queue_depth"})
+	must.MapLen(t, 1, ru.ResourceUsage.Gauges)
+
+	// gauges take the unit of their schema, and are dropped if reported in
+	// another unit
+	caps = &cstructs.StatsCapabilities{
+		Schema: []*cstructs.StatSchema{
+			{Name: "queue_depth", Unit: "messages"},
+			{Name: "undeclared", Unit: "bytes"},
+		},
+		MemoryMeasured: []string{"RSS"},
+		CpuMeasured:    []string{"System Mode", "User Mode"},
+	}
+	ru = newUsage()
+	ru.ResourceUsage.Gauges["undeclared"].Unit = "requests"
+	ru.ResourceUsage.MemoryStats = &cstructs.MemoryStats{Measured: []string{"RSS", "Swap"}}
+	ru.ResourceUsage.CpuStats = &cstructs.CpuStats{Measured: []string{"User Mode", "Percent"}}
+	applyStatsCapabilities(ru, caps)
+	must.Eq(t, map[string]*cstructs.Gauge{
+		"queue_depth": {Value: 3, Unit: "messages"},
+	}, ru.ResourceUsage.Gauges)
+	must.Eq(t, []string{"RSS"}, ru.ResourceUsage.MemoryStats.Measured)
+
+	// declared stats a sample didn't measure aren't claimed
+	must.Eq(t, []string{"User Mode"}, ru.ResourceUsage.CpuStats.Measured)
+}
+
+type deviceStatsReporter []*device.DeviceGroupStats
+
+func (r deviceStatsReporter) LatestDeviceResourceStats([]*structs.AllocatedDeviceResource) []*device.DeviceGroupStats {
+	return r
+}
+
+// TestTaskRunner_LatestResourceUsage_devices asserts device stats are only
+// looked up for drivers that report them, and without changing the sample
+// shared with other readers.
+func TestTaskRunner_LatestResourceUsage_devices(t *testing.T) {
+	ci.Parallel(t)
+
+	ru := &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{CpuStats: &cstructs.CpuStats{Percent: 50}},
+	}
+	tr := &TaskRunner{
+		deviceStatsReporter: deviceStatsReporter{{Name: "gpu"}},
+		taskResources:       &structs.AllocatedTaskResources{},
+		resourceUsage:       ru,
+	}
+
+	// drivers that don't declare their stats are assumed to report devices
+	latest := tr.LatestResourceUsage()
+	must.Len(t, 1, latest.ResourceUsage.DeviceStats)
+	must.Eq(t, 50, latest.ResourceUsage.CpuStats.Percent)
+	must.Nil(t, ru.ResourceUsage.DeviceStats)
+
+	tr.driverCapabilities = &drivers.Capabilities{Stats: &drivers.StatsCapabilities{Network: true}}
+	must.Nil(t, tr.LatestResourceUsage().ResourceUsage.DeviceStats)
+
+	tr.driverCapabilities.Stats.Devices = true
+	must.Len(t, 1, tr.LatestResourceUsage().ResourceUsage.DeviceStats)
+}
+
+func TestTaskRunner_applyBorrowedTicks(t *testing.T) {
+	ci.Parallel(t)
+
+	shared := NewSharedCPU()
+	must.Zero(t, shared.Borrowed("sidecar", 500, 200))
+
+	ru := &cstructs.TaskResourceUsage{ResourceUsage: &cstructs.ResourceUsage{
+		CpuStats: &cstructs.CpuStats{TotalTicks: 750, Measured: []string{"Percent"}},
+	}}
+	applyBorrowedTicks(ru, "main", 500, shared)
+	must.Eq(t, 250, ru.ResourceUsage.CpuStats.BorrowedTicks)
+	must.Eq(t, []string{"Percent", "Borrowed Ticks"}, ru.ResourceUsage.CpuStats.Measured)
+
+	// Tasks using less than their own CPU don't borrow
+	ru.ResourceUsage.CpuStats.TotalTicks = 100
+	applyBorrowedTicks(ru, "main", 500, shared)
+	must.Zero(t, ru.ResourceUsage.CpuStats.BorrowedTicks)
+	must.Eq(t, []string{"Percent", "Borrowed Ticks"}, ru.ResourceUsage.CpuStats.Measured)
+
+	// CPU beyond what the siblings leave idle comes from the node
+	ru.ResourceUsage.CpuStats.TotalTicks = 1500
+	applyBorrowedTicks(ru, "main", 500, shared)
+	must.Eq(t, 300, ru.ResourceUsage.CpuStats.BorrowedTicks)
+}
+
+func TestTaskRunner_applyCPUPercentNormalization(t *testing.T) {
+	ci.Parallel(t)
+
+	compute := cpustats.Compute{TotalCompute: 8000, NumCores: 4}
+	ru := &cstructs.TaskResourceUsage{ResourceUsage: &cstructs.ResourceUsage{
+		CpuStats: &cstructs.CpuStats{Percent: 100},
+	}}
+
+	// One core of a task allocated 1000 MHz, half a core of the node
+	res := &structs.AllocatedTaskResources{Cpu: structs.AllocatedCpuResources{CpuShares: 1000}}
+	applyCPUPercentNormalization(ru, res, compute)
+	must.Eq(t, 200, ru.ResourceUsage.CpuStats.AllocatedPercent)
+	must.Eq(t, 25, ru.ResourceUsage.CpuStats.NodePercent)
+
+	// Tasks with reserved cores are allocated whole cores
+	res.Cpu.ReservedCores = []uint16{0, 1}
+	applyCPUPercentNormalization(ru, res, compute)
+	must.Eq(t, 50, ru.ResourceUsage.CpuStats.AllocatedPercent)
+
+	// Nodes without a known topology are left alone
+	ru.ResourceUsage.CpuStats = &cstructs.CpuStats{Percent: 100}
+	applyCPUPercentNormalization(ru, res, cpustats.Compute{})
+	must.Zero(t, ru.ResourceUsage.CpuStats.AllocatedPercent)
+	must.Zero(t, ru.ResourceUsage.CpuStats.NodePercent)
+}
+
+func TestTaskRunner_newTaskAllocatedResources(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, newTaskAllocatedResources(nil))
+
+	res := &structs.AllocatedTaskResources{
+		Cpu:    structs.AllocatedCpuResources{CpuShares: 500, ReservedCores: []uint16{2}},
+		Memory: structs.AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 512},
+	}
+	allocated := newTaskAllocatedResources(res)
+	must.Eq(t, &cstructs.TaskAllocatedResources{
+		CpuShares:     500,
+		ReservedCores: []uint16{2},
+		MemoryMB:      256,
+		MemoryMaxMB:   512,
+	}, allocated)
+
+	// The sample doesn't share the cores of the task's resources
+	allocated.ReservedCores[0] = 3
+	must.Eq(t, []uint16{2}, res.Cpu.ReservedCores)
+}
+
+// TestTaskRunner_Resize asserts tasks of drivers that can't resize them in
+// place are restarted with their resized resources.
+func TestTaskRunner_Resize(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	// Updates that don't resize the task leave its resources alone
+	current := tr.getTaskResources()
+	tr.Update(alloc.Copy())
+	must.True(t, current == tr.getTaskResources())
+
+	update := alloc.Copy()
+	resources := update.AllocatedResources.Tasks[task.Name]
+	resources.Cpu.CpuShares += 100
+	resources.Memory.MemoryMB += 256
+	tr.Update(update)
+
+	must.Eq(t, resources.Memory.MemoryMB, tr.getTaskResources().Memory.MemoryMB)
+	must.Eq(t, resources.Cpu.CpuShares, tr.getTaskResources().Cpu.CpuShares)
+	testutil.WaitForResult(func() (bool, error) {
+		for _, e := range tr.TaskState().Events {
+			if e.Type == structs.TaskRestartSignal {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("expected task to be restarted")
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
+func TestTaskRunner_memoryLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	const mb = structs.BytesInMegabyte
+	hard, soft := memoryLimits(structs.AllocatedMemoryResources{MemoryMB: 256}, false)
+	must.Eq(t, 256*mb, hard)
+	must.Zero(t, soft)
+
+	hard, soft = memoryLimits(structs.AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 512}, false)
+	must.Eq(t, 512*mb, hard)
+	must.Eq(t, 256*mb, soft)
+
+	hard, soft = memoryLimits(structs.AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: -1}, false)
+	must.Eq(t, -1, hard)
+	must.Eq(t, 256*mb, soft)
+
+	// With QoS classes enforced, the memory of guaranteed tasks is protected
+	// and the one of best-effort tasks isn't
+	hard, soft = memoryLimits(structs.AllocatedMemoryResources{MemoryMB: 256}, true)
+	must.Eq(t, 256*mb, hard)
+	must.Eq(t, 256*mb, soft)
+
+	hard, soft = memoryLimits(structs.AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: -1}, true)
+	must.Eq(t, -1, hard)
+	must.Zero(t, soft)
+}
+
+// TestTaskRunner_escalateOOMMemory asserts the memory limit of tasks
+// escalating their memory is raised by a step per OOM kill, up to their
+// memory_max, until they run for the interval of their restart policy without
+// being OOM-killed, and that the raise is restored with the task.
+func TestTaskRunner_escalateOOMMemory(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.Job.TaskGroups[0].RestartPolicy.OOMMemoryStepMB = 200
+	alloc.Job.TaskGroups[0].RestartPolicy.Interval = time.Hour
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	resources := alloc.AllocatedResources.Tasks[task.Name]
+	resources.Memory.MemoryMB = 256
+	resources.Memory.MemoryMaxMB = 512
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	conf.StateDB = cstate.NewMemDB(conf.Logger) // "persist" state between task runners
+	defer cleanup()
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	limit := func() int64 {
+		return tr.oomMemoryResources(tr.getTaskResources()).Memory.MemoryMaxMB
+	}
+	must.Eq(t, 256, limit())
+
+	// Exits that aren't OOM kills leave the limit alone
+	tr.escalateOOMMemory(&drivers.ExitResult{ExitCode: 1})
+	must.Eq(t, 256, limit())
+
+	tr.escalateOOMMemory(&drivers.ExitResult{OOMKilled: true})
+	must.Eq(t, 456, limit())
+	tr.escalateOOMMemory(&drivers.ExitResult{OOMKilled: true})
+	must.Eq(t, 512, limit())
+	tr.escalateOOMMemory(&drivers.ExitResult{OOMKilled: true})
+	must.Eq(t, 512, limit())
+
+	var resized int
+	for _, e := range tr.TaskState().Events {
+		if e.Type == structs.TaskResized {
+			resized++
+		}
+	}
+	must.Eq(t, 2, resized)
+
+	// The raise is restored with the task
+	restoredTR, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	must.NoError(t, restoredTR.Restore())
+	must.Eq(t, 512, restoredTR.oomMemoryResources(restoredTR.getTaskResources()).Memory.MemoryMaxMB)
+
+	// The raise is reset once the task ran for the interval of its restart
+	// policy without being OOM-killed
+	tr.taskResourcesLock.Lock()
+	tr.oomKilledAt = time.Now().Add(-time.Hour)
+	tr.taskResourcesLock.Unlock()
+	must.Eq(t, 256, limit())
+
+	tr.escalateOOMMemory(&drivers.ExitResult{OOMKilled: true})
+	must.Eq(t, 456, limit())
+}
+
+func TestTaskRunner_gaugeMetricName(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, "queue_depth", gaugeMetricName("queue_depth"))
+	must.Eq(t, "http_requests_5xx", gaugeMetricName("http.requests/5xx"))
+}
+
+// TestTaskRunner_StatsSequence asserts resource usage samples are numbered and
+// record the time elapsed since the previous sample.
+func TestTaskRunner_StatsSequence(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "1ns",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToDie(t, tr)
+
+	sample := func() *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+				CpuStats:    &cstructs.CpuStats{},
+			},
+			Timestamp: time.Now().UnixNano(),
+		}
+	}
+
+	// Samples the driver doesn't number are numbered and timed as received
+	tr.UpdateStats(sample())
+	first := tr.LatestResourceUsage()
+	time.Sleep(10 * time.Millisecond)
+	tr.UpdateStats(sample())
+	second := tr.LatestResourceUsage()
+
+	must.Eq(t, first.Sequence+1, second.Sequence)
+	must.Between(t, 10*time.Millisecond, second.CollectionDuration, time.Minute)
+
+	// Samples the driver numbers keep its numbering and timing, so samples
+	// lost on the way leave a gap
+	stamped := func(seq uint64, d time.Duration) *cstructs.TaskResourceUsage {
+		ru := sample()
+		ru.Sequence, ru.CollectionDuration = seq, d
+		return ru
+	}
+	must.Less(t, 7, second.Sequence)
+	tr.UpdateStats(stamped(7, 0))
+	must.Eq(t, 7, tr.LatestResourceUsage().Sequence)
+	tr.UpdateStats(stamped(9, 2*time.Second))
+	third := tr.LatestResourceUsage()
+	must.Eq(t, 9, third.Sequence)
+	must.Eq(t, 2*time.Second, third.CollectionDuration)
+
+	// The sequence keeps increasing when the driver's numbering restarts
+	tr.UpdateStats(stamped(1, 0))
+	must.Eq(t, 10, tr.LatestResourceUsage().Sequence)
+	tr.UpdateStats(stamped(2, time.Second))
+	must.Eq(t, 11, tr.LatestResourceUsage().Sequence)
+}
+
+// TestTaskRunner_PeekResourceUsage asserts only reads of the task stats by
+// their consumers count as demand for lazy collection.
+func TestTaskRunner_PeekResourceUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+	conf.ClientConfig.LazyTaskStats = true
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	must.NotNil(t, tr.statsDemand)
+
+	ru := &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{},
+			CpuStats:    &cstructs.CpuStats{},
+		},
+		Timestamp: time.Now().UnixNano(),
+	}
+	tr.UpdateStats(ru)
+
+	lastRead := func() time.Time {
+		tr.statsDemand.mu.Lock()
+		defer tr.statsDemand.mu.Unlock()
+		return tr.statsDemand.lastRead
+	}
+
+	before := lastRead()
+	must.Eq(t, ru, tr.PeekResourceUsage())
+	must.Eq(t, before, lastRead())
+
+	time.Sleep(time.Millisecond)
+	must.Eq(t, ru, tr.LatestResourceUsage())
+	must.True(t, lastRead().After(before))
+}
+
+// TestTaskRunner_Restore_Stats asserts the samples and counters of a task's
+// stats carry on across agent restarts, and that TotalCpuSeconds does not
+// decrease when the driver's counter resets.
+func TestTaskRunner_Restore_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	conf.StateDB = cstate.NewMemDB(conf.Logger) // "persist" state between task runners
+	defer cleanup()
+
+	start := time.Now()
+	sample := func(offset time.Duration, cpuSeconds float64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+				CpuStats: &cstructs.CpuStats{
+					TotalCpuSeconds: cpuSeconds,
+					Measured:        []string{"Total CPU Seconds"},
+				},
+			},
+			Timestamp: start.Add(offset).UnixNano(),
+		}
+	}
+
+	origTR, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	origTR.UpdateStats(sample(0, 5))
+	origTR.UpdateStats(sample(time.Minute, 7))
+	origTR.checkpointStats(true)
+	must.NoError(t, origTR.persistLocalState())
+
+	newTR, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	must.NoError(t, newTR.Restore())
+	must.Nil(t, newTR.localState.Stats)
+	must.Eq(t, 2, newTR.UsageWindow().Samples)
+
+	// The cleared checkpoint is persisted, so it isn't restored again
+	ls, _, err := conf.StateDB.GetTaskRunnerState(alloc.ID, task.Name)
+	must.NoError(t, err)
+	must.Nil(t, ls.Stats)
+
+	// The latest sample is served until the task sends a new one
+	restored := newTR.LatestResourceUsage()
+	must.NotNil(t, restored)
+	must.Eq(t, 2, restored.Sequence)
+	must.Eq(t, 7, restored.ResourceUsage.CpuStats.TotalCpuSeconds)
+	must.True(t, restored.Stale)
+
+	// The counter reset, so the CPU time counted before is added to it
+	newTR.UpdateStats(sample(2*time.Minute, 1))
+	ru := newTR.LatestResourceUsage()
+	must.False(t, ru.Stale)
+	must.Eq(t, 3, ru.Sequence)
+	must.Eq(t, 8, ru.ResourceUsage.CpuStats.TotalCpuSeconds)
+	must.Eq(t, 3, newTR.UsageWindow().Samples)
+
+	newTR.UpdateStats(sample(3*time.Minute, 2))
+	must.Eq(t, 9, newTR.LatestResourceUsage().ResourceUsage.CpuStats.TotalCpuSeconds)
+}
+
+// TestTaskRunner_Restore_StatsCheckpoint asserts the stats of a task are
+// persisted periodically, so they are restored when the agent exits without
+// shutting down, and that the sequence then never repeats.
+func TestTaskRunner_Restore_StatsCheckpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	conf.StateDB = cstate.NewMemDB(conf.Logger) // "persist" state between task runners
+	conf.ClientConfig.StatsCollectionInterval = time.Minute
+	defer cleanup()
+
+	start := time.Now().Add(-time.Hour)
+	sample := func(offset time.Duration, cpuSeconds float64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+				CpuStats: &cstructs.CpuStats{
+					TotalCpuSeconds: cpuSeconds,
+					Measured:        []string{"Total CPU Seconds"},
+				},
+			},
+			Timestamp: start.Add(offset).UnixNano(),
+		}
+	}
+
+	// Only the first sample is checkpointed, since the second one is taken
+	// within the checkpoint interval
+	origTR, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	origTR.UpdateStats(sample(0, 5))
+	origTR.UpdateStats(sample(time.Second, 6))
+
+	ls, _, err := conf.StateDB.GetTaskRunnerState(alloc.ID, task.Name)
+	must.NoError(t, err)
+	must.NotNil(t, ls.Stats)
+	must.False(t, ls.Stats.Shutdown)
+	must.Eq(t, 1, ls.Stats.Latest.Sequence)
+
+	// Copies don't share the sample
+	c := ls.Stats.Copy()
+	c.Latest.ResourceUsage.CpuStats.TotalCpuSeconds = 0
+	must.Eq(t, 5, ls.Stats.Latest.ResourceUsage.CpuStats.TotalCpuSeconds)
+
+	newTR, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	must.NoError(t, newTR.Restore())
+
+	restored := newTR.LatestResourceUsage()
+	must.True(t, restored.Stale)
+	must.Eq(t, 5, restored.ResourceUsage.CpuStats.TotalCpuSeconds)
+
+	// The sequence skips the samples the previous agent may have sent after
+	// the checkpoint
+	newTR.UpdateStats(sample(time.Hour, 7))
+	must.Eq(t, 62, newTR.LatestResourceUsage().Sequence)
+}
+
+// TestTaskRunner_AnnotateKill asserts the samples of a task killed for its
+// resource usage record the resource until the task starts again.
+func TestTaskRunner_AnnotateKill(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	sample := func() *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+				CpuStats:    &cstructs.CpuStats{},
+			},
+			Timestamp: time.Now().UnixNano(),
+		}
+	}
+
+	tr.UpdateStats(sample())
+	before := tr.LatestResourceUsage()
+	tr.AnnotateKill(structs.TaskKilledResourceMemory)
+
+	// The latest sample is annotated without modifying the one already read
+	must.Eq(t, structs.TaskKilledResourceMemory, tr.LatestResourceUsage().KilledResource)
+	must.Eq(t, "", before.KilledResource)
+
+	tr.UpdateStats(sample())
+	must.Eq(t, structs.TaskKilledResourceMemory, tr.LatestResourceUsage().KilledResource)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskStarted))
+	tr.UpdateStats(sample())
+	must.Eq(t, "", tr.LatestResourceUsage().KilledResource)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// taskStartDriverConfig holds the fields of a driver config that say what a
+// task runs. Drivers without them leave them empty.
+type taskStartDriverConfig struct {
+	Image   string   `codec:"image"`
+	Command string   `codec:"command"`
+	Args    []string `codec:"args"`
+}
+
+// taskStartRecord returns the audit record of starting the task with
+// taskConfig, once its driver config was interpolated and encoded.
+func taskStartRecord(taskConfig *drivers.TaskConfig) *structs.TaskStartRecord {
+	var driverConfig taskStartDriverConfig
+	_ = taskConfig.DecodeDriverConfig(&driverConfig)
+
+	record := &structs.TaskStartRecord{
+		Image:     driverConfig.Image,
+		Command:   driverConfig.Command,
+		User:      taskConfig.User,
+		EnvSHA256: envDigest(taskConfig.Env),
+	}
+	if len(driverConfig.Args) > 0 {
+		record.ArgsSHA256 = argsDigest(driverConfig.Args)
+	}
+	if r := taskConfig.Resources; r != nil && r.LinuxResources != nil {
+		record.Cgroup = r.LinuxResources.CpusetCgroupPath
+	}
+	return record
+}
+
+// argsDigest returns the hex encoded SHA-256 digest of args.
+func argsDigest(args []string) string {
+	h := sha256.New()
+	for _, arg := range args {
+		h.Write([]byte(arg))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// envDigest returns the hex encoded SHA-256 digest of env, which doesn't
+// depend on the order of the map.
+func envDigest(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{'='})
+		h.Write([]byte(env[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
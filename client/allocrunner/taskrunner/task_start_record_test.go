@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestTaskRunner_taskStartRecord(t *testing.T) {
+	ci.Parallel(t)
+
+	taskConfig := &drivers.TaskConfig{
+		User: "nobody",
+		Env:  map[string]string{"A": "1", "SECRET": "hunter2"},
+		Resources: &drivers.Resources{
+			LinuxResources: &drivers.LinuxResources{CpusetCgroupPath: "/sys/fs/cgroup/nomad.slice/share.slice/web"},
+		},
+	}
+	must.NoError(t, taskConfig.EncodeConcreteDriverConfig(map[string]any{
+		"command": "/bin/server",
+		"args":    []string{"-port", "8080"},
+	}))
+
+	record := taskStartRecord(taskConfig)
+	must.Eq(t, "/bin/server", record.Command)
+	must.Eq(t, argsDigest([]string{"-port", "8080"}), record.ArgsSHA256)
+	must.NotEq(t, argsDigest([]string{"-port8080"}), record.ArgsSHA256)
+	must.Eq(t, "nobody", record.User)
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/share.slice/web", record.Cgroup)
+	must.Eq(t, envDigest(map[string]string{"SECRET": "hunter2", "A": "1"}), record.EnvSHA256)
+	must.NotEq(t, envDigest(map[string]string{"A": "1", "SECRET": "hunter3"}), record.EnvSHA256)
+
+	event := structs.NewTaskEvent(structs.TaskStarted).SetTaskStartRecord(record)
+	must.Eq(t, record.ArgsSHA256, event.Details["args_sha256"])
+	must.MapNotContainsKey(t, event.Details, "args")
+	must.Eq(t, record.EnvSHA256, event.Details["env_sha256"])
+	must.MapNotContainsKey(t, event.Details, "image")
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// usageAnomalyAlpha is the weight of each sample in the moving average
+	// and variance of a measurement, so they follow roughly the last 20
+	// samples
+	usageAnomalyAlpha = 0.1
+
+	// usageAnomalyWarmup is the number of samples of a measurement observed
+	// before its changes are flagged, so its average settles after the task
+	// starts
+	usageAnomalyWarmup = 30
+
+	// usageAnomalyMinRelStdDev is the smallest standard deviation changes
+	// are scored against, relative to the average, so measurements which
+	// barely change don't flag small changes
+	usageAnomalyMinRelStdDev = 0.05
+
+	// usageAnomalyCooldown is the minimum time between two anomalies flagged
+	// for the same measurement, so a sustained change is flagged once
+	usageAnomalyCooldown = 10 * time.Minute
+)
+
+// usageMeasure is a measurement of a task's usage the detector watches.
+type usageMeasure struct {
+	name string
+	unit string
+
+	// minStdDev is the smallest standard deviation changes are scored
+	// against, in the unit of the measurement
+	minStdDev float64
+
+	// value returns the measurement of a sample, or false if the sample
+	// doesn't have it
+	value func(*usageAnomalyDetector, *cstructs.TaskResourceUsage) (float64, bool)
+}
+
+func (m *usageMeasure) format(v float64) string {
+	if m.unit == "" {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.0f %s", v, m.unit)
+}
+
+var usageMeasures = []*usageMeasure{
+	{
+		name:      "CPU usage",
+		unit:      "MHz",
+		minStdDev: 25,
+		value: func(_ *usageAnomalyDetector, ru *cstructs.TaskResourceUsage) (float64, bool) {
+			cs := ru.ResourceUsage.CpuStats
+			if cs == nil || !slices.Contains(cs.Measured, "Percent") {
+				return 0, false
+			}
+			return cs.TotalTicks, true
+		},
+	},
+	{
+		name:      "Memory RSS",
+		unit:      "MiB",
+		minStdDev: 8,
+		value: func(_ *usageAnomalyDetector, ru *cstructs.TaskResourceUsage) (float64, bool) {
+			ms := ru.ResourceUsage.MemoryStats
+			if ms == nil || !slices.Contains(ms.Measured, "RSS") {
+				return 0, false
+			}
+			return float64(ms.RSS) / 1024 / 1024, true
+		},
+	},
+	{
+		name:      "Open file descriptors",
+		minStdDev: 4,
+		value: func(d *usageAnomalyDetector, ru *cstructs.TaskResourceUsage) (float64, bool) {
+			return d.fds.latest(ru.Pids)
+		},
+	},
+}
+
+// usageSeries is the exponentially weighted moving average and variance of
+// a measurement of a task's usage.
+type usageSeries struct {
+	mean     float64
+	variance float64
+	samples  int
+
+	// flaggedAt is the time of the sample last flagged as an anomaly
+	flaggedAt time.Time
+}
+
+// observe records a value of the measurement and returns how many standard
+// deviations it is from the average of the previous values, which is 0 until
+// the series warmed up, along with that average.
+func (s *usageSeries) observe(x, minStdDev float64) (score, mean float64) {
+	mean = s.mean
+	if s.samples >= usageAnomalyWarmup {
+		stddev := max(math.Sqrt(s.variance), minStdDev, usageAnomalyMinRelStdDev*math.Abs(mean))
+		score = (x - mean) / stddev
+	}
+
+	if s.samples == 0 {
+		s.mean = x
+	} else {
+		diff := x - s.mean
+		incr := usageAnomalyAlpha * diff
+		s.mean += incr
+		s.variance = (1 - usageAnomalyAlpha) * (s.variance + diff*incr)
+	}
+	s.samples++
+	return score, mean
+}
+
+// openFDCounter counts the file descriptors the processes of a task have open
+// in the background, since reading /proc for each process is too slow for
+// the stats path. The zero value is ready to use.
+type openFDCounter struct {
+	mu       sync.Mutex
+	count    float64
+	ok       bool
+	counting bool
+}
+
+// latest starts counting the file descriptors of the processes, unless a
+// count is already running, and returns the last completed count, so the
+// count lags the samples by one. It returns false if there is no count yet
+// or the processes couldn't be read.
+func (c *openFDCounter) latest(pids map[string]*cstructs.ResourceUsage) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.counting && len(pids) > 0 {
+		c.counting = true
+		go c.countOpenFDs(maps.Clone(pids))
+	}
+	return c.count, c.ok
+}
+
+func (c *openFDCounter) countOpenFDs(pids map[string]*cstructs.ResourceUsage) {
+	count, ok := countOpenFDs(pids)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count, c.ok, c.counting = count, ok, false
+}
+
+// reset drops the last count, since it counted the previous processes of the
+// task.
+func (c *openFDCounter) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count, c.ok = 0, false
+}
+
+// usageAnomalyDetector flags abrupt changes of a task's CPU usage, memory RSS
+// and open file descriptors, which are early signs of leaks and runaway
+// loops. A change is abrupt when the sample is more standard deviations from
+// the moving average of the measurement than the threshold. The zero value is
+// ready to use.
+type usageAnomalyDetector struct {
+	mu     sync.Mutex
+	series map[string]*usageSeries
+
+	// last is the timestamp of the latest sample
+	last int64
+
+	fds openFDCounter
+}
+
+// check records a resource usage sample taken at the collection interval and
+// returns a description of each of its measurements that changed abruptly.
+// A sample taken more than two intervals after the previous one, such as
+// after lazy collection of the stats was paused, clears the averages, since
+// the usage may have changed gradually during the gap.
+func (d *usageAnomalyDetector) check(threshold float64, ru *cstructs.TaskResourceUsage, interval time.Duration) []string {
+	if ru == nil || ru.ResourceUsage == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.last > 0 && interval > 0 && ru.Timestamp-d.last > int64(2*interval) {
+		d.series = nil
+	}
+	d.last = ru.Timestamp
+
+	if d.series == nil {
+		d.series = make(map[string]*usageSeries, len(usageMeasures))
+	}
+
+	var anomalies []string
+	now := time.Unix(0, ru.Timestamp)
+	for _, m := range usageMeasures {
+		x, ok := m.value(d, ru)
+		if !ok {
+			continue
+		}
+		s := d.series[m.name]
+		if s == nil {
+			s = new(usageSeries)
+			d.series[m.name] = s
+		}
+
+		score, mean := s.observe(x, m.minStdDev)
+		if math.Abs(score) < threshold || now.Sub(s.flaggedAt) < usageAnomalyCooldown {
+			continue
+		}
+		s.flaggedAt = now
+
+		change := "rose"
+		if score < 0 {
+			change = "fell"
+		}
+		anomalies = append(anomalies, fmt.Sprintf("%s %s to %s, %.1f standard deviations from its average of %s",
+			m.name, change, m.format(x), math.Abs(score), m.format(mean)))
+	}
+	return anomalies
+}
+
+// reset clears the averages of the detector when the task starts, since the
+// usage of the previous processes of the task isn't representative.
+func (d *usageAnomalyDetector) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.series = nil
+	d.last = 0
+	d.fds.reset()
+}
+
+// checkUsageAnomaly emits a TaskUsageAnomaly event describing the
+// measurements of the task's usage that changed abruptly, if the client
+// detects anomalies. The task keeps only its latest TaskUsageAnomaly event,
+// so anomalies don't push its other events out.
+func (tr *TaskRunner) checkUsageAnomaly(ru *cstructs.TaskResourceUsage) {
+	threshold := tr.clientConfig.UsageAnomalyThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	anomalies := tr.usageAnomaly.check(threshold, ru, tr.effectiveStatsInterval())
+	if len(anomalies) == 0 {
+		return
+	}
+	for _, anomaly := range anomalies {
+		tr.logger.Info("task usage changed abruptly", "anomaly", anomaly)
+	}
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskUsageAnomaly).SetMessage(strings.Join(anomalies, "; ")))
+}
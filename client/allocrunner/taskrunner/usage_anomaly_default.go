@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package taskrunner
+
+import (
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// countOpenFDs is only supported on Linux.
+func countOpenFDs(map[string]*cstructs.ResourceUsage) (float64, bool) {
+	return 0, false
+}
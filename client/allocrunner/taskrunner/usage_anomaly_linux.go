@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package taskrunner
+
+import (
+	"os"
+	"path/filepath"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// countOpenFDs returns the number of file descriptors the processes of a
+// task have open, or false if the driver didn't report the processes or one
+// of them couldn't be read, such as one that just exited.
+func countOpenFDs(pids map[string]*cstructs.ResourceUsage) (float64, bool) {
+	if len(pids) == 0 {
+		return 0, false
+	}
+
+	var count int
+	for pid := range pids {
+		f, err := os.Open(filepath.Join("/proc", pid, "fd"))
+		if err != nil {
+			return 0, false
+		}
+		names, err := f.Readdirnames(-1)
+		f.Close()
+		if err != nil {
+			return 0, false
+		}
+		count += len(names)
+	}
+	return float64(count), true
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func anomalySample(at time.Duration, rssMB uint64, cpuMHz float64) *cstructs.TaskResourceUsage {
+	return &cstructs.TaskResourceUsage{
+		Timestamp: int64(at),
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{RSS: rssMB * 1024 * 1024, Measured: []string{"RSS"}},
+			CpuStats:    &cstructs.CpuStats{TotalTicks: cpuMHz, Measured: []string{"Percent"}},
+		},
+	}
+}
+
+func TestUsageAnomalyDetector(t *testing.T) {
+	ci.Parallel(t)
+
+	var d usageAnomalyDetector
+	at := time.Duration(0)
+	for i := 0; i < usageAnomalyWarmup; i++ {
+		at += time.Second
+		rss := uint64(98 + 4*(i%2))
+		must.SliceEmpty(t, d.check(6, anomalySample(at, rss, 500), time.Second))
+	}
+
+	// Changes within the noise of the usage aren't flagged
+	at += time.Second
+	must.SliceEmpty(t, d.check(6, anomalySample(at, 110, 520), time.Second))
+
+	at += time.Second
+	anomalies := d.check(6, anomalySample(at, 300, 500), time.Second)
+	must.Len(t, 1, anomalies)
+	must.Eq(t, "Memory RSS rose to 300 MiB, 24.9 standard deviations from its average of 101 MiB", anomalies[0])
+
+	// A sustained change is flagged once
+	at += time.Second
+	must.SliceEmpty(t, d.check(6, anomalySample(at, 300, 500), time.Second))
+
+	at += time.Second
+	anomalies = d.check(6, anomalySample(at, 300, 50), time.Second)
+	must.Len(t, 1, anomalies)
+	must.StrContains(t, anomalies[0], "CPU usage fell to 50 MHz")
+
+	// Restarted tasks warm up again
+	d.reset()
+	must.SliceEmpty(t, d.check(6, anomalySample(at, 1000, 500), time.Second))
+}
+
+func TestUsageAnomalyDetector_Gap(t *testing.T) {
+	ci.Parallel(t)
+
+	var d usageAnomalyDetector
+	at := time.Duration(0)
+	for i := 0; i < usageAnomalyWarmup; i++ {
+		at += time.Second
+		must.SliceEmpty(t, d.check(6, anomalySample(at, 100, 500), time.Second))
+	}
+
+	// The usage after a gap in the samples isn't compared against the
+	// averages from before the gap, and the averages warm up again
+	at += time.Hour
+	must.SliceEmpty(t, d.check(6, anomalySample(at, 300, 500), time.Second))
+	for i := 1; i < usageAnomalyWarmup; i++ {
+		at += time.Second
+		must.SliceEmpty(t, d.check(6, anomalySample(at, 300, 500), time.Second))
+	}
+
+	at += time.Second
+	must.Len(t, 1, d.check(6, anomalySample(at, 900, 500), time.Second))
+}
+
+func TestOpenFDCounter(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("open file descriptors are only counted on Linux")
+	}
+
+	// The file descriptors are counted in the background, so the first
+	// sample has no count
+	var c openFDCounter
+	pids := map[string]*cstructs.ResourceUsage{strconv.Itoa(os.Getpid()): {}}
+	_, ok := c.latest(pids)
+	must.False(t, ok)
+
+	must.Wait(t, wait.InitialSuccess(wait.BoolFunc(func() bool {
+		count, ok := c.latest(pids)
+		return ok && count > 0
+	}), wait.Timeout(5*time.Second), wait.Gap(10*time.Millisecond)))
+
+	c.reset()
+	_, ok = c.latest(nil)
+	must.False(t, ok)
+}
+
+func TestTaskRunner_UsageAnomalyEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	tr := &TaskRunner{
+		state:     structs.NewTaskState(),
+		logger:    testlog.HCLogger(t),
+		maxEvents: 3,
+	}
+	tr.appendEvent(structs.NewTaskEvent(structs.TaskReceived))
+	tr.appendEvent(structs.NewTaskEvent(structs.TaskUsageAnomaly).SetMessage("first"))
+	tr.appendEvent(structs.NewTaskEvent(structs.TaskSetup))
+	tr.appendEvent(structs.NewTaskEvent(structs.TaskUsageAnomaly).SetMessage("second"))
+
+	// Only the latest anomaly is kept, so the other events aren't pushed out
+	must.Len(t, 3, tr.state.Events)
+	must.Eq(t, structs.TaskReceived, tr.state.Events[0].Type)
+	must.Eq(t, structs.TaskSetup, tr.state.Events[1].Type)
+	must.Eq(t, "second", tr.state.Events[2].Message)
+}
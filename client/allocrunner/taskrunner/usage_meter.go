@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// bytesPerGB is the size of the GB the billing record reports memory and
+// traffic in, which is a binary gigabyte as cloud providers bill in
+const bytesPerGB = 1 << 30
+
+// usageMeter accumulates the resource usage of a task over its lifetime for
+// its billing record. The zero value is ready to use.
+type usageMeter struct {
+	mu    sync.Mutex
+	meter state.UsageMeter
+}
+
+// record adds a resource usage sample to the accumulated usage. Memory is
+// integrated over the time since the previous sample, and so is CPU unless
+// the driver reports the task's total CPU time.
+//
+// The interval is the stats collection interval. A sample taken more than two
+// intervals after the previous one, such as after collection was paused or
+// backed off, only counts for one interval, so that the usage of one sample
+// isn't billed across the whole gap. The billing record is then marked
+// partial.
+func (m *usageMeter) record(ru *cstructs.TaskResourceUsage, interval time.Duration) {
+	if ru == nil || ru.ResourceUsage == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var elapsed float64
+	if m.meter.Timestamp > 0 && ru.Timestamp > m.meter.Timestamp {
+		gap := time.Duration(ru.Timestamp - m.meter.Timestamp)
+		if interval > 0 && gap > 2*interval {
+			gap = interval
+			m.meter.Partial = true
+		}
+		elapsed = gap.Seconds()
+	}
+	if ru.Timestamp > m.meter.Timestamp {
+		m.meter.Timestamp = ru.Timestamp
+	}
+
+	if cs := ru.ResourceUsage.CpuStats; cs != nil {
+		if slices.Contains(cs.Measured, "Total CPU Seconds") {
+			m.meter.CPUSeconds = max(m.meter.CPUSeconds, cs.TotalCpuSeconds)
+		} else {
+			m.meter.CPUSeconds += cs.Percent / 100 * elapsed
+		}
+	}
+
+	if ms := ru.ResourceUsage.MemoryStats; ms != nil {
+		memory := ms.Used()
+		m.meter.MemoryByteSeconds += float64(memory) * elapsed
+		m.meter.PeakMemory = max(m.meter.PeakMemory, memory)
+	}
+
+	if len(ru.ResourceUsage.Egress) > 0 {
+		var egress uint64
+		for _, e := range ru.ResourceUsage.Egress {
+			egress += e.Bytes
+		}
+		// The counters start over when the task's cgroup is recreated
+		if egress < m.meter.EgressBytes {
+			m.meter.EgressOffset += m.meter.EgressBytes
+		}
+		m.meter.EgressBytes = egress
+	}
+}
+
+// billingRecord returns the accumulated usage of the task.
+func (m *usageMeter) billingRecord() *structs.TaskBillingRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return &structs.TaskBillingRecord{
+		CPUSeconds:    m.meter.CPUSeconds,
+		MemoryGBHours: m.meter.MemoryByteSeconds / bytesPerGB / time.Hour.Seconds(),
+		EgressGB:      float64(m.meter.EgressOffset+m.meter.EgressBytes) / bytesPerGB,
+		PeakMemoryMB:  int(m.meter.PeakMemory / 1024 / 1024),
+		Partial:       m.meter.Partial,
+	}
+}
+
+// snapshot returns a copy of the accumulated usage.
+func (m *usageMeter) snapshot() state.UsageMeter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.meter
+}
+
+// restore replaces the accumulated usage with that of a snapshot.
+func (m *usageMeter) restore(meter state.UsageMeter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.meter = meter
+}
+
+// BillingRecord returns the resource usage of the task over its lifetime.
+func (tr *TaskRunner) BillingRecord() *structs.TaskBillingRecord {
+	return tr.usageMeter.billingRecord()
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsageMeter(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	sample := func(at time.Duration, percent float64, rssMB, egress uint64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			Timestamp: start.Add(at).UnixNano(),
+			ResourceUsage: &cstructs.ResourceUsage{
+				CpuStats: &cstructs.CpuStats{Percent: percent},
+				MemoryStats: &cstructs.MemoryStats{
+					RSS:      rssMB * 1024 * 1024,
+					Measured: []string{"RSS"},
+				},
+				Egress: map[string]*cstructs.EgressStats{
+					"internet": {Bytes: egress},
+				},
+			},
+		}
+	}
+
+	var m usageMeter
+	m.record(sample(0, 100, 1024, 0), 0)
+	// Each sample counts for the time since the previous one: half an hour
+	// of one core and 1GB, then half an hour of two cores peaking at 3GB
+	m.record(sample(30*time.Minute, 100, 1024, bytesPerGB), 0)
+	m.record(sample(45*time.Minute, 200, 3072, 2*bytesPerGB), 0)
+	// The egress counter starts over when the task is restarted
+	m.record(sample(time.Hour, 200, 1024, bytesPerGB), 0)
+
+	record := m.billingRecord()
+	must.Eq(t, 5400, record.CPUSeconds)
+	must.Eq(t, 1.5, record.MemoryGBHours)
+	must.Eq(t, 3, record.EgressGB)
+	must.Eq(t, 3072, record.PeakMemoryMB)
+
+	// A driver reporting the total CPU time is not integrated
+	var total usageMeter
+	ru := sample(0, 100, 0, 0)
+	ru.ResourceUsage.CpuStats.TotalCpuSeconds = 42
+	ru.ResourceUsage.CpuStats.Measured = []string{"Total CPU Seconds"}
+	total.record(ru, 0)
+	ru = sample(time.Hour, 100, 0, 0)
+	ru.ResourceUsage.CpuStats.TotalCpuSeconds = 84
+	ru.ResourceUsage.CpuStats.Measured = []string{"Total CPU Seconds"}
+	total.record(ru, 0)
+	must.Eq(t, 84, total.billingRecord().CPUSeconds)
+
+	// The meter carries on from a snapshot
+	var restored usageMeter
+	restored.restore(m.snapshot())
+	must.Eq(t, record, restored.billingRecord())
+}
+
+func TestUsageMeter_gap(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Now()
+	sample := func(at time.Duration, rssMB uint64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			Timestamp: start.Add(at).UnixNano(),
+			ResourceUsage: &cstructs.ResourceUsage{
+				CpuStats: &cstructs.CpuStats{Percent: 100},
+				MemoryStats: &cstructs.MemoryStats{
+					RSS:      rssMB * 1024 * 1024,
+					Measured: []string{"RSS"},
+				},
+			},
+		}
+	}
+
+	const interval = time.Minute
+	var m usageMeter
+	m.record(sample(0, 1024), interval)
+	// A late sample is still contiguous
+	m.record(sample(90*time.Second, 1024), interval)
+	must.False(t, m.billingRecord().Partial)
+	// Collection paused for an hour, so the next sample only counts for one
+	// interval
+	m.record(sample(90*time.Second+time.Hour, 1024), interval)
+
+	record := m.billingRecord()
+	must.Eq(t, 150, record.CPUSeconds)
+	must.Eq(t, 150.0/3600, record.MemoryGBHours)
+	must.True(t, record.Partial)
+}
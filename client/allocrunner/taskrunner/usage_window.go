@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// usageWindowDuration is how long the samples of a task's usage are
+	// retained for
+	usageWindowDuration = time.Hour
+
+	// usageWindowResolution is the minimum time between retained samples,
+	// which bounds the memory used for the window to a few hundred samples
+	// regardless of the stats collection interval
+	usageWindowResolution = 10 * time.Second
+)
+
+// usageWindow retains samples of a task's resource usage over the last
+// usageWindowDuration. The zero value is ready to use.
+type usageWindow struct {
+	mu      sync.Mutex
+	samples []state.UsageSample
+
+	// last is the timestamp of the latest sample recorded, retained or not,
+	// and resumed the timestamp of the first sample after the latest gap in
+	// collection
+	last    int64
+	resumed int64
+}
+
+// record retains a resource usage sample, unless the last retained sample is
+// more recent than the window's resolution, and evicts expired samples. A
+// sample taken more than two collection intervals after the previous one,
+// such as after collection was paused, marks the window partial until the
+// samples from before the gap expire.
+func (w *usageWindow) record(ru *cstructs.TaskResourceUsage, interval time.Duration) {
+	if ru == nil || ru.ResourceUsage == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.last > 0 && interval > 0 && ru.Timestamp-w.last > int64(2*interval) {
+		w.resumed = ru.Timestamp
+	}
+	w.last = max(w.last, ru.Timestamp)
+
+	if n := len(w.samples); n > 0 && ru.Timestamp-w.samples[n-1].Timestamp < int64(usageWindowResolution) {
+		return
+	}
+
+	sample := state.UsageSample{Timestamp: ru.Timestamp}
+	if cs := ru.ResourceUsage.CpuStats; cs != nil {
+		sample.CPU = int(math.Round(cs.TotalTicks))
+	}
+	if ms := ru.ResourceUsage.MemoryStats; ms != nil {
+		sample.MemoryMB = int(ms.Used() / 1024 / 1024)
+	}
+
+	expired := ru.Timestamp - int64(usageWindowDuration)
+	i, _ := slices.BinarySearchFunc(w.samples, expired, func(s state.UsageSample, t int64) int {
+		return cmp.Compare(s.Timestamp, t)
+	})
+	w.samples = append(w.samples[i:], sample)
+}
+
+// summary returns the distribution of the retained samples, or nil if there
+// are none.
+func (w *usageWindow) summary() *cstructs.TaskUsageWindow {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.samples) == 0 {
+		return nil
+	}
+
+	cpu := make([]int, len(w.samples))
+	memory := make([]int, len(w.samples))
+	for i, s := range w.samples {
+		cpu[i] = s.CPU
+		memory[i] = s.MemoryMB
+	}
+	return &cstructs.TaskUsageWindow{
+		Start:    w.samples[0].Timestamp,
+		End:      w.samples[len(w.samples)-1].Timestamp,
+		Samples:  len(w.samples),
+		Partial:  w.resumed > w.samples[0].Timestamp,
+		CPU:      structs.NewUsagePercentiles(cpu),
+		MemoryMB: structs.NewUsagePercentiles(memory),
+	}
+}
+
+// snapshot returns a copy of the retained samples.
+func (w *usageWindow) snapshot() []state.UsageSample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.samples)
+}
+
+// restore replaces the retained samples with those of a snapshot.
+func (w *usageWindow) restore(samples []state.UsageSample) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.samples = slices.Clone(samples)
+
+	// The client wasn't collecting while it restarted
+	if n := len(samples); n > 0 {
+		w.last = samples[n-1].Timestamp
+	}
+}
+
+// UsageWindow summarizes the resource usage of the task over the samples
+// retained for it.
+func (tr *TaskRunner) UsageWindow() *cstructs.TaskUsageWindow {
+	return tr.usageWindow.summary()
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsageWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	sample := func(at time.Duration, cpu float64, rssMB uint64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			Timestamp: int64(at),
+			ResourceUsage: &cstructs.ResourceUsage{
+				CpuStats: &cstructs.CpuStats{TotalTicks: cpu},
+				MemoryStats: &cstructs.MemoryStats{
+					RSS:      rssMB * 1024 * 1024,
+					Measured: []string{"RSS"},
+				},
+			},
+		}
+	}
+
+	var w usageWindow
+	must.Nil(t, w.summary())
+
+	w.record(sample(0, 100, 64), usageWindowResolution)
+
+	// samples within the resolution of the last one are not retained
+	w.record(sample(time.Second, 900, 900), usageWindowResolution)
+	must.Eq(t, 1, w.summary().Samples)
+
+	for i := 1; i <= 4; i++ {
+		at := time.Duration(i) * usageWindowResolution
+		w.record(sample(at, float64(100*(i+1)), uint64(64*(i+1))), usageWindowResolution)
+	}
+
+	summary := w.summary()
+	must.Eq(t, 5, summary.Samples)
+	must.Eq(t, 0, summary.Start)
+	must.False(t, summary.Partial)
+	must.Eq(t, structs.UsagePercentiles{P50: 300, P95: 500, Max: 500}, summary.CPU)
+	must.Eq(t, structs.UsagePercentiles{P50: 192, P95: 320, Max: 320}, summary.MemoryMB)
+
+	// samples older than the window are evicted, and the window is partial
+	// since collection paused in between
+	w.record(sample(usageWindowDuration+2*usageWindowResolution, 50, 32), usageWindowResolution)
+	summary = w.summary()
+	must.Eq(t, 4, summary.Samples)
+	must.Eq(t, int64(2*usageWindowResolution), summary.Start)
+	must.Eq(t, structs.UsagePercentiles{P50: 300, P95: 500, Max: 500}, summary.CPU)
+	must.True(t, summary.Partial)
+
+	// the window is complete again once the samples before the gap expire
+	for i := 3; i <= 5; i++ {
+		w.record(sample(usageWindowDuration+time.Duration(i)*usageWindowResolution, 50, 32), usageWindowResolution)
+	}
+	summary = w.summary()
+	must.Eq(t, 4, summary.Samples)
+	must.Eq(t, int64(usageWindowDuration+2*usageWindowResolution), summary.Start)
+	must.False(t, summary.Partial)
+}
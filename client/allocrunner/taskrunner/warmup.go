@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// warmupWatcher tracks how long a task's CPU usage has stayed below the
+// threshold of its warmup block since the task started. The zero value is
+// ready to use.
+type warmupWatcher struct {
+	mu sync.Mutex
+
+	// samples is the number of samples seen since the task started. The CPU
+	// percent of the first sample is always zero, so it is ignored.
+	samples int
+
+	// belowSince is the time of the first sample of the current run of
+	// samples below the threshold, or zero if the last sample was above it
+	belowSince time.Time
+
+	// done is set once the task warmed up
+	done bool
+}
+
+// check records a resource usage sample and returns true once, when the CPU
+// usage of the task has stayed below the threshold for the warmup's duration.
+func (w *warmupWatcher) check(warmup *structs.TaskWarmup, ru *cstructs.TaskResourceUsage) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.done || ru == nil || ru.ResourceUsage == nil || ru.ResourceUsage.CpuStats == nil {
+		return false
+	}
+
+	w.samples++
+	if w.samples == 1 {
+		return false
+	}
+
+	if ru.ResourceUsage.CpuStats.Percent >= warmup.CPUPercent {
+		w.belowSince = time.Time{}
+		return false
+	}
+
+	now := time.Unix(0, ru.Timestamp)
+	if w.belowSince.IsZero() {
+		w.belowSince = now
+	}
+	if now.Sub(w.belowSince) < warmup.Duration {
+		return false
+	}
+
+	w.done = true
+	return true
+}
+
+// reset clears the state of the watcher when the task starts.
+func (w *warmupWatcher) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples = 0
+	w.belowSince = time.Time{}
+	w.done = false
+}
+
+// checkWarmup emits a TaskWarmedUp event once the CPU usage of a task with a
+// warmup block settles, which allows the task to be considered healthy.
+func (tr *TaskRunner) checkWarmup(ru *cstructs.TaskResourceUsage) {
+	warmup := tr.Task().Warmup
+	if warmup == nil || !tr.warmup.check(warmup, ru) {
+		return
+	}
+
+	// Tasks restored after a client restart may have warmed up already
+	tr.stateLock.RLock()
+	warmedUp := tr.state.WarmedUpAt.After(tr.state.StartedAt)
+	tr.stateLock.RUnlock()
+	if warmedUp {
+		return
+	}
+
+	tr.logger.Debug("task warmed up", "cpu_percent", warmup.CPUPercent, "duration", warmup.Duration)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskWarmedUp).
+		SetMessage(fmt.Sprintf("CPU usage below %v%% for %v", warmup.CPUPercent, warmup.Duration)))
+}
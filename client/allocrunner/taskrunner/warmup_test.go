@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func cpuSample(at time.Duration, percent float64) *cstructs.TaskResourceUsage {
+	return &cstructs.TaskResourceUsage{
+		Timestamp: int64(at),
+		ResourceUsage: &cstructs.ResourceUsage{
+			CpuStats: &cstructs.CpuStats{Percent: percent},
+		},
+	}
+}
+
+func TestWarmupWatcher(t *testing.T) {
+	ci.Parallel(t)
+
+	warmup := &structs.TaskWarmup{CPUPercent: 20, Duration: 2 * time.Second}
+
+	var w warmupWatcher
+
+	// the first sample is ignored
+	must.False(t, w.check(warmup, cpuSample(0, 0)))
+	must.False(t, w.check(warmup, cpuSample(time.Second, 180)))
+	must.False(t, w.check(warmup, cpuSample(2*time.Second, 10)))
+	must.False(t, w.check(warmup, cpuSample(3*time.Second, 10)))
+
+	// going back above the threshold restarts the duration
+	must.False(t, w.check(warmup, cpuSample(4*time.Second, 50)))
+	must.False(t, w.check(warmup, cpuSample(5*time.Second, 10)))
+	must.False(t, w.check(warmup, cpuSample(6*time.Second, 10)))
+	must.True(t, w.check(warmup, cpuSample(7*time.Second, 10)))
+
+	// warm-up is only signaled once per start
+	must.False(t, w.check(warmup, cpuSample(8*time.Second, 10)))
+
+	w.reset()
+	must.False(t, w.check(warmup, cpuSample(9*time.Second, 0)))
+	must.False(t, w.check(warmup, cpuSample(10*time.Second, 10)))
+	must.True(t, w.check(warmup, cpuSample(12*time.Second, 10)))
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -36,11 +37,13 @@ import (
 	"github.com/hashicorp/nomad/client/hoststats"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/hotplug"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/pluginmanager/statssinkmanager"
 	"github.com/hashicorp/nomad/client/servers"
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
@@ -164,6 +167,10 @@ type Client struct {
 	logger    hclog.InterceptLogger
 	rpcLogger hclog.Logger
 
+	// auditLogger records the accesses to the endpoints exposing what the
+	// tasks run
+	auditLogger hclog.Logger
+
 	connPool *pool.ConnPool
 
 	// tlsWrap is used to wrap outbound connections using TLS. It should be
@@ -187,6 +194,10 @@ type Client struct {
 	// update it.
 	triggerNodeUpdate chan struct{}
 
+	// triggerUtilization triggers a heartbeat to send the changed live
+	// utilization of the node to the servers
+	triggerUtilization chan struct{}
+
 	// triggerEmitNodeEvent sends an event and triggers the client to update the
 	// server for the node event
 	triggerEmitNodeEvent chan *structs.NodeEvent
@@ -211,9 +222,31 @@ type Client struct {
 	invalidAllocs     map[string]struct{}
 	invalidAllocsLock sync.Mutex
 
+	// orphanedExecutors are the executors running on the node that none of
+	// the client's tasks own, as of the last scan
+	orphanedExecutors     []*cstructs.OrphanedExecutor
+	orphanedExecutorsLock sync.Mutex
+
 	// pendingUpdates stores allocations that need to be synced to the server.
 	pendingUpdates *pendingClientUpdates
 
+	// serversAcceptAllocDeltas is set when the servers reported in the last
+	// heartbeat that they fill in unchanged fields of allocation updates.
+	serversAcceptAllocDeltas atomic.Bool
+
+	// usageBackfill buffers the usage summaries the client could not send
+	// while disconnected, and serversAcceptUsageBackfill is set when the
+	// servers reported in the last heartbeat that they accept them.
+	usageBackfill              *usageBackfill
+	serversAcceptUsageBackfill atomic.Bool
+	triggerUsageBackfillCh     chan struct{}
+
+	// pendingUsage stores the usage summaries to send with the next
+	// heartbeat, and serversAcceptUsageHeartbeats is set when the servers
+	// reported in the last heartbeat that they accept them.
+	pendingUsage                 *pendingUsage
+	serversAcceptUsageHeartbeats atomic.Bool
+
 	// consulServices gets a Consul handler implementation for managing
 	// services and checks.
 	consulServices serviceregistration.Handler
@@ -242,6 +275,18 @@ type Client struct {
 	// HostStatsCollector collects host resource usage stats
 	hostStatsCollector *hoststats.HostStatsCollector
 
+	// gcTuner adjusts the agent's GC to the memory pressure of the node, if
+	// enabled
+	gcTuner *gcTuner
+
+	// lastMemoryEviction is when an allocation was last evicted since the
+	// node was running out of memory, only accessed by emitStats
+	lastMemoryEviction time.Time
+
+	// statsInterval is the effective stats collection interval, backed off
+	// while collecting host stats is slow
+	statsInterval *hoststats.AdaptiveInterval
+
 	// shutdown is true when the Client has been shutdown. Must hold
 	// shutdownLock to access.
 	shutdown bool
@@ -289,6 +334,9 @@ type Client struct {
 	// drivermanager is responsible for managing driver plugins
 	drivermanager drivermanager.Manager
 
+	// statssinkmanager is responsible for managing stats sink plugins
+	statssinkmanager statssinkmanager.Manager
+
 	// baseLabels are used when emitting tagged metrics. All client metrics will
 	// have these tags, and optionally more.
 	baseLabels []metrics.Label
@@ -371,32 +419,41 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	logger := cfg.Logger.ResetNamedIntercept("client")
 
 	// Create the client
+	backfill := newUsageBackfill(usageBackfillLimit)
 	c := &Client{
-		config:               cfg,
-		consulCatalog:        consulCatalog,
-		consulProxiesFunc:    consulProxiesFunc,
-		consulServices:       consulServices,
-		start:                time.Now(),
-		connPool:             pool.NewPool(logger, clientRPCCache, clientMaxStreams, tlsWrap),
-		tlsWrap:              tlsWrap,
-		streamingRpcs:        structs.NewStreamingRpcRegistry(),
-		logger:               logger,
-		rpcLogger:            logger.Named("rpc"),
-		allocs:               make(map[string]interfaces.AllocRunner),
-		pendingUpdates:       newPendingClientUpdates(),
-		shutdownCh:           make(chan struct{}),
-		triggerDiscoveryCh:   make(chan struct{}),
-		triggerNodeUpdate:    make(chan struct{}, 8),
-		triggerEmitNodeEvent: make(chan *structs.NodeEvent, 8),
-		fpInitialized:        make(chan struct{}),
-		invalidAllocs:        make(map[string]struct{}),
-		serversContactedCh:   make(chan struct{}),
-		serversContactedOnce: sync.Once{},
-		registeredCh:         make(chan struct{}),
-		registeredOnce:       sync.Once{},
-		getter:               getter.New(cfg.Artifact, logger),
-		EnterpriseClient:     newEnterpriseClient(logger),
-		allocrunnerFactory:   cfg.AllocRunnerFactory,
+		config:                 cfg,
+		consulCatalog:          consulCatalog,
+		consulProxiesFunc:      consulProxiesFunc,
+		consulServices:         consulServices,
+		start:                  time.Now(),
+		connPool:               pool.NewPool(logger, clientRPCCache, clientMaxStreams, tlsWrap),
+		tlsWrap:                tlsWrap,
+		streamingRpcs:          structs.NewStreamingRpcRegistry(),
+		logger:                 logger,
+		rpcLogger:              logger.Named("rpc"),
+		auditLogger:            logger.Named("audit"),
+		allocs:                 make(map[string]interfaces.AllocRunner),
+		pendingUpdates:         newPendingClientUpdates(backfill),
+		usageBackfill:          backfill,
+		triggerUsageBackfillCh: make(chan struct{}, 1),
+		pendingUsage:           newPendingUsage(backfill),
+		shutdownCh:             make(chan struct{}),
+		triggerDiscoveryCh:     make(chan struct{}),
+		triggerNodeUpdate:      make(chan struct{}, 8),
+		triggerUtilization:     make(chan struct{}, 1),
+		triggerEmitNodeEvent:   make(chan *structs.NodeEvent, 8),
+		fpInitialized:          make(chan struct{}),
+		invalidAllocs:          make(map[string]struct{}),
+		serversContactedCh:     make(chan struct{}),
+		serversContactedOnce:   sync.Once{},
+		registeredCh:           make(chan struct{}),
+		registeredOnce:         sync.Once{},
+		getter:                 getter.New(cfg.Artifact, logger),
+		EnterpriseClient:       newEnterpriseClient(logger),
+		allocrunnerFactory:     cfg.AllocRunnerFactory,
+	}
+	if cfg.AuditLogger != nil {
+		c.auditLogger = cfg.AuditLogger.Named("client").Named("audit")
 	}
 
 	// we can't have this set in the default Config because of import cycles
@@ -504,6 +561,17 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.csimanager = csiManager
 	c.pluginManagers.RegisterAndRun(csiManager.PluginManager())
 
+	// Setup watching for devices being attached or removed, so the drivers
+	// and devices plugins fingerprint them again, and for CPU cores being
+	// removed from under the tasks that reserved them
+	var hotplugNotifier *hotplug.Notifier
+	if cfg.Hotplug != nil {
+		hotplugNotifier = hotplug.NewNotifier(c.logger, cfg.Hotplug.Subsystems, cfg.Hotplug.Quiescence)
+		hotplugCh := hotplugNotifier.Subscribe()
+		c.shutdownGroup.Go(func() { hotplugNotifier.Run(c.shutdownCh) })
+		c.shutdownGroup.Go(func() { c.watchCoreHotplug(hotplugCh) })
+	}
+
 	// Setup the driver manager
 	driverConfig := &drivermanager.Config{
 		Logger:              c.logger,
@@ -514,6 +582,7 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		State:               c.stateDB,
 		AllowedDrivers:      allowlistDrivers,
 		BlockedDrivers:      blocklistDrivers,
+		Hotplug:             hotplugNotifier,
 	}
 	drvManager := drivermanager.New(driverConfig)
 	c.drivermanager = drvManager
@@ -527,11 +596,23 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		Updater:       c.batchNodeUpdates.updateNodeFromDevices,
 		StatsInterval: cfg.StatsCollectionInterval,
 		State:         c.stateDB,
+		Hotplug:       hotplugNotifier,
 	}
 	devManager := devicemanager.New(devConfig)
 	c.devicemanager = devManager
 	c.pluginManagers.RegisterAndRun(devManager)
 
+	// Setup the stats sink manager
+	statsSinkConfig := &statssinkmanager.Config{
+		Logger:       c.logger,
+		Loader:       cfg.PluginSingletonLoader,
+		PluginConfig: cfg.NomadPluginConfig(c.topology),
+		NodeID:       cfg.Node.ID,
+	}
+	statsSinkManager := statssinkmanager.New(statsSinkConfig)
+	c.statssinkmanager = statsSinkManager
+	c.pluginManagers.RegisterAndRun(statsSinkManager)
+
 	// Set up the service registration wrapper using the Consul and Nomad
 	// implementations. The Nomad implementation is only ever used on the
 	// client, so we do that here rather than within the agent.
@@ -553,6 +634,10 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Add the stats collector
 	statsCollector := hoststats.NewHostStatsCollector(c.logger, c.topology, c.GetConfig().AllocDir, c.devicemanager.AllStats)
 	c.hostStatsCollector = statsCollector
+	c.statsInterval = hoststats.NewAdaptiveInterval(cfg.StatsCollectionInterval)
+	if cfg.GCAutoTune {
+		c.gcTuner = newGCTuner(c.logger)
+	}
 
 	// Add the garbage collector
 	gcConfig := &GCConfig{
@@ -621,6 +706,9 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Begin syncing allocations to the server
 	c.shutdownGroup.Go(c.allocSync)
 
+	// Begin uploading the usage history buffered while disconnected
+	c.shutdownGroup.Go(c.watchUsageBackfill)
+
 	// Start the client! Don't use the shutdownGroup as run handles
 	// shutdowns manually to prevent updates from being applied during
 	// shutdown.
@@ -629,6 +717,15 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Start collecting stats
 	c.shutdownGroup.Go(c.emitStats)
 
+	// Start watching the usage of the agent itself
+	c.shutdownGroup.Go(c.watchSelfProfiling)
+
+	// Start watching for executors left running by a previous client
+	c.shutdownGroup.Go(c.watchOrphanedExecutors)
+
+	// Start fencing the tasks of drivers that stay unhealthy
+	c.shutdownGroup.Go(c.watchDriverFencing)
+
 	c.logger.Info("started client", "node_id", c.NodeID())
 	return c, nil
 }
@@ -1060,6 +1157,37 @@ func (c *Client) GetAllocStats(allocID string) (interfaces.AllocStatsReporter, e
 	return ar.StatsReporter(), nil
 }
 
+// AllocUsage is the latest resource usage of an allocation
+type AllocUsage struct {
+	Alloc *structs.Allocation
+	Usage *cstructs.AllocResourceUsage
+}
+
+// NamespaceAllocUsage returns the latest resource usage of the running
+// allocations of a namespace, sorted by allocation ID. Allocations that
+// haven't reported stats yet are left out.
+func (c *Client) NamespaceAllocUsage(namespace string) []*AllocUsage {
+	var usages []*AllocUsage
+	for _, ar := range c.getAllocRunners() {
+		if ar.IsDestroyed() {
+			continue
+		}
+		alloc := ar.Alloc()
+		if alloc.Namespace != namespace || alloc.ClientTerminalStatus() {
+			continue
+		}
+		usage, err := ar.StatsReporter().LatestAllocStats("")
+		if err != nil || usage == nil {
+			continue
+		}
+		usages = append(usages, &AllocUsage{Alloc: alloc, Usage: usage})
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Alloc.ID < usages[j].Alloc.ID
+	})
+	return usages
+}
+
 // LatestHostStats returns all the stats related to a Nomad client.
 func (c *Client) LatestHostStats() *hoststats.HostStats {
 	return c.hostStatsCollector.Stats()
@@ -1075,46 +1203,64 @@ func (c *Client) computeAllocatedDeviceGroupStats(devices []*structs.AllocatedDe
 		return nil
 	}
 
-	// Build an index of allocated devices
-	adIdx := map[structs.DeviceIdTuple][]string{}
-
-	total := 0
-	for _, ds := range devices {
-		adIdx[*ds.ID()] = ds.DeviceIDs
-		total += len(ds.DeviceIDs)
-	}
-
-	// Collect allocated device stats from host stats
-	result := make([]*device.DeviceGroupStats, 0, len(adIdx))
-
+	// Build an index of the host device groups, and of the instances of each
+	// vendor and type, since plugins may report the stats of the partitions
+	// of a device under the group of the device
+	groupIdx := make(map[structs.DeviceIdTuple]*device.DeviceGroupStats, len(hostDeviceGroupStats))
+	instanceIdx := map[structs.DeviceIdTuple]map[string]*device.DeviceStats{}
 	for _, dg := range hostDeviceGroupStats {
 		k := structs.DeviceIdTuple{
 			Vendor: dg.Vendor,
 			Type:   dg.Type,
 			Name:   dg.Name,
 		}
+		groupIdx[k] = dg
 
-		allocatedDeviceIDs, ok := adIdx[k]
-		if !ok {
-			continue
+		vt := structs.DeviceIdTuple{Vendor: dg.Vendor, Type: dg.Type}
+		if instanceIdx[vt] == nil {
+			instanceIdx[vt] = map[string]*device.DeviceStats{}
 		}
+		for id, stats := range dg.InstanceStats {
+			instanceIdx[vt][id] = stats
+		}
+	}
+
+	// Collect allocated device stats from host stats
+	result := make([]*device.DeviceGroupStats, 0, len(devices))
+
+	for _, ad := range devices {
+		k := *ad.ID()
+		dg, ok := groupIdx[k]
 
 		rdgStats := &device.DeviceGroupStats{
-			Vendor:        dg.Vendor,
-			Type:          dg.Type,
-			Name:          dg.Name,
+			Vendor:        k.Vendor,
+			Type:          k.Type,
+			Name:          k.Name,
 			InstanceStats: map[string]*device.DeviceStats{},
 		}
 
-		for _, adID := range allocatedDeviceIDs {
-			deviceStats, ok := dg.InstanceStats[adID]
-			if !ok || deviceStats == nil {
-				c.logger.Warn("device not found in stats", "device_id", adID, "device_group_id", k)
+		for _, adID := range ad.DeviceIDs {
+			var deviceStats *device.DeviceStats
+			if ok {
+				deviceStats = dg.InstanceStats[adID]
+			}
+			if deviceStats == nil {
+				deviceStats = instanceIdx[structs.DeviceIdTuple{Vendor: k.Vendor, Type: k.Type}][adID]
+			}
+			if deviceStats == nil {
+				if ok {
+					c.logger.Warn("device not found in stats", "device_id", adID, "device_group_id", k)
+				}
 				continue
 			}
 
 			rdgStats.InstanceStats[adID] = deviceStats
 		}
+
+		// Skip groups the host doesn't report the stats of at all
+		if !ok && len(rdgStats.InstanceStats) == 0 {
+			continue
+		}
 		result = append(result, rdgStats)
 	}
 
@@ -1238,6 +1384,13 @@ func (c *Client) restoreState() error {
 		return nil
 	}
 
+	// Restore the usage history buffered before the client restarted
+	backfill, err := c.stateDB.GetUsageBackfill()
+	if err != nil {
+		c.logger.Error("error restoring usage backfill", "error", err)
+	}
+	c.usageBackfill.load(backfill)
+
 	// Restore allocations
 	allocs, allocErrs, err := c.stateDB.GetAllAllocations()
 	if err != nil {
@@ -1397,6 +1550,11 @@ func (c *Client) saveState() error {
 	}
 
 	wg.Wait()
+
+	if err := c.persistUsageBackfill(); err != nil {
+		c.logger.Error("error saving usage backfill", "error", err)
+		_ = multierror.Append(&mErr, err)
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -1642,6 +1800,7 @@ func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResp
 	defer c.configLock.Unlock()
 
 	nodeHasChanged := false
+	utilizationHasChanged := false
 	newConfig := c.config.Copy()
 
 	for name, newVal := range response.Attributes {
@@ -1650,7 +1809,14 @@ func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResp
 			continue
 		}
 
-		nodeHasChanged = true
+		// the live utilization of the node changes on every refresh, so it
+		// is sent to the servers with the heartbeats instead of updating the
+		// node
+		if structs.IsLiveUtilizationAttr(name) {
+			utilizationHasChanged = true
+		} else {
+			nodeHasChanged = true
+		}
 		if newVal == "" {
 			delete(newConfig.Node.Attributes, name)
 		} else {
@@ -1699,6 +1865,12 @@ func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResp
 	if nodeHasChanged {
 		c.config = newConfig
 		c.updateNode()
+	} else if utilizationHasChanged {
+		c.config = newConfig
+		select {
+		case c.triggerUtilization <- struct{}{}:
+		default:
+		}
 	}
 
 	return newConfig.Node
@@ -1774,6 +1946,7 @@ func (c *Client) registerAndHeartbeat() {
 		select {
 		case <-c.rpcRetryWatcher():
 		case <-heartbeat:
+		case <-c.triggerUtilization:
 		case <-c.shutdownCh:
 			return
 		}
@@ -2088,8 +2261,10 @@ func (c *Client) registerNode(authToken string) error {
 func (c *Client) updateNodeStatus() error {
 	start := time.Now()
 	req := structs.NodeUpdateStatusRequest{
-		NodeID: c.NodeID(),
-		Status: structs.NodeStatusReady,
+		NodeID:      c.NodeID(),
+		Status:      structs.NodeStatusReady,
+		Utilization: structs.LiveUtilizationAttrs(c.GetConfig().Node.Attributes),
+		AllocUsage:  c.pendingUsage.take(),
 		WriteRequest: structs.WriteRequest{
 			Region:    c.Region(),
 			AuthToken: c.secretNodeID(),
@@ -2097,9 +2272,11 @@ func (c *Client) updateNodeStatus() error {
 	}
 	var resp structs.NodeUpdateResponse
 	if err := c.RPC("Node.UpdateStatus", &req, &resp); err != nil {
+		c.pendingUsage.restore(req.AllocUsage)
 		c.triggerDiscovery()
 		return fmt.Errorf("failed to update status: %v", err)
 	}
+	c.pendingUsage.synced()
 	end := time.Now()
 
 	if len(resp.EvalIDs) != 0 {
@@ -2148,6 +2325,12 @@ func (c *Client) updateNodeStatus() error {
 	}
 
 	c.EnterpriseClient.SetFeatures(resp.Features)
+	c.serversAcceptAllocDeltas.Store(resp.AllocUpdateDeltas)
+	c.serversAcceptUsageBackfill.Store(resp.UsageBackfill)
+	c.serversAcceptUsageHeartbeats.Store(resp.UsageHeartbeats)
+	if resp.UsageBackfill && c.usageBackfill.len() > 0 {
+		c.triggerUsageBackfill()
+	}
 	return nil
 }
 
@@ -2202,6 +2385,16 @@ func (c *Client) AllocStateUpdated(alloc *structs.Allocation) {
 	stripped.ClientDescription = alloc.ClientDescription
 	stripped.DeploymentStatus = alloc.DeploymentStatus
 	stripped.NetworkStatus = alloc.NetworkStatus
+	stripped.BillingRecord = alloc.BillingRecord
+
+	// Servers which accept usage summaries with heartbeats don't need them in
+	// allocation updates, so an update that only changed the usage is dropped
+	// instead of rewriting the allocation
+	if c.serversAcceptUsageHeartbeats.Load() && alloc.UsageSummary != nil {
+		c.pendingUsage.add(alloc.ID, alloc.UsageSummary)
+	} else {
+		stripped.UsageSummary = alloc.UsageSummary
+	}
 
 	c.pendingUpdates.add(stripped)
 }
@@ -2235,12 +2428,13 @@ func (c *Client) allocSync() {
 
 			// Send to server.
 			args := structs.AllocUpdateRequest{
-				Alloc: toSync,
+				Alloc: c.allocUpdateDeltas(toSync),
 				WriteRequest: structs.WriteRequest{
 					Region:    c.Region(),
 					AuthToken: c.secretNodeID(),
 				},
 			}
+			c.compressAllocUpdates(&args)
 
 			var resp structs.GenericResponse
 			err := c.RPC("Node.UpdateAlloc", &args, &resp)
@@ -2266,10 +2460,12 @@ func (c *Client) allocSync() {
 						DeploymentStatus:  update.DeploymentStatus,
 						TaskStates:        update.TaskStates,
 						NetworkStatus:     update.NetworkStatus,
+						UsageSummary:      update.UsageSummary,
 					})
 				}
 			}
 			c.allocLock.RUnlock()
+			c.pendingUpdates.synced()
 
 			// Successfully updated allocs. Reset ticker to give loop time to
 			// receive new alloc updates. Otherwise if the RPC took the ticker
@@ -2280,6 +2476,44 @@ func (c *Client) allocSync() {
 	}
 }
 
+// allocUpdateDeltas returns the allocation updates with the task states and
+// usage summaries the servers already have removed, if all servers support
+// filling them back in. The full updates are kept to acknowledge or retry.
+func (c *Client) allocUpdateDeltas(updates []*structs.Allocation) []*structs.Allocation {
+	if !c.serversAcceptAllocDeltas.Load() {
+		return updates
+	}
+
+	c.allocLock.RLock()
+	defer c.allocLock.RUnlock()
+
+	deltas := make([]*structs.Allocation, 0, len(updates))
+	for _, update := range updates {
+		if ar, ok := c.allocs[update.ID]; ok {
+			update = ar.AllocUpdateDelta(update)
+		}
+		deltas = append(deltas, update)
+	}
+	return deltas
+}
+
+// compressAllocUpdates replaces the allocation updates of the request with
+// their compressed encoding if all servers support it and the updates are
+// large enough for compression to pay off.
+func (c *Client) compressAllocUpdates(args *structs.AllocUpdateRequest) {
+	if !c.serversAcceptAllocDeltas.Load() {
+		return
+	}
+	compressed, err := structs.EncodeAllocUpdates(args.Alloc)
+	if err != nil {
+		c.logger.Warn("failed to compress allocation updates", "error", err)
+		return
+	}
+	if compressed != nil {
+		args.Alloc, args.CompressedAllocs = nil, compressed
+	}
+}
+
 // allocUpdates holds the results of receiving updated allocations from the
 // servers.
 type allocUpdates struct {
@@ -2801,6 +3035,8 @@ func (c *Client) newAllocRunnerConfig(
 		ServiceRegWrapper:   c.serviceRegWrapper,
 		StateDB:             c.stateDB,
 		StateUpdater:        c,
+		StatsSink:           c.statssinkmanager,
+		StatsInterval:       c,
 		VaultFunc:           c.VaultClient,
 		WIDSigner:           c.widsigner,
 		Wranglers:           c.wranglers,
@@ -3208,13 +3444,34 @@ func (c *Client) emitStats() {
 		config := c.GetConfig()
 		select {
 		case <-next.C:
+			start := time.Now()
 			err := c.hostStatsCollector.Collect()
-			next.Reset(config.StatsCollectionInterval)
+			took := time.Since(start)
+
+			// Back off the interval if collecting is taking too long
+			interval, changed := c.statsInterval.Observe(took)
+			if changed {
+				c.logger.Info("adjusted stats collection interval", "interval", interval,
+					"configured", config.StatsCollectionInterval, "collection_time", took)
+			}
+			next.Reset(interval)
+			metrics.MeasureSinceWithLabels([]string{"client", "stats", "collection_time"}, start, c.baseLabels)
+			metrics.SetGaugeWithLabels([]string{"client", "stats", "interval"},
+				float32(interval.Milliseconds()), c.baseLabels)
+
 			if err != nil {
 				c.logger.Warn("error fetching host resource usage stats", "error", err)
-			} else if config.PublishNodeMetrics {
-				// Publish Node metrics if operator has opted in
-				c.emitHostStats()
+			} else {
+				if c.gcTuner != nil {
+					c.gcTuner.tune(c.hostStatsCollector.Stats().Memory, c.baseLabels)
+				}
+				if config.QoSClasses && config.MemoryEvictionThreshold > 0 {
+					c.evictForMemoryPressure(c.hostStatsCollector.Stats().Memory, config.MemoryEvictionThreshold)
+				}
+				if config.PublishNodeMetrics {
+					// Publish Node metrics if operator has opted in
+					c.emitHostStats()
+				}
 			}
 
 			c.emitClientMetrics()
@@ -3224,6 +3481,12 @@ func (c *Client) emitStats() {
 	}
 }
 
+// StatsInterval returns the effective stats collection interval, which is
+// backed off from the configured interval while collection is slow.
+func (c *Client) StatsInterval() time.Duration {
+	return c.statsInterval.Interval()
+}
+
 // setGaugeForMemoryStats proxies metrics for memory specific statistics
 func (c *Client) setGaugeForMemoryStats(nodeID string, hStats *hoststats.HostStats, baseLabels []metrics.Label) {
 	metrics.SetGaugeWithLabels([]string{"client", "host", "memory", "total"}, float32(hStats.Memory.Total), baseLabels)
@@ -3252,6 +3515,24 @@ func (c *Client) setGaugeForCPUStats(nodeID string, hStats *hoststats.HostStats,
 		metrics.SetGaugeWithLabels([]string{"client", "host", "cpu", "user"}, float32(cpu.User), labels)
 		metrics.SetGaugeWithLabels([]string{"client", "host", "cpu", "idle"}, float32(cpu.Idle), labels)
 		metrics.SetGaugeWithLabels([]string{"client", "host", "cpu", "system"}, float32(cpu.System), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "cpu", "irq"}, float32(cpu.Irq), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "cpu", "softirq"}, float32(cpu.SoftIrq), labels)
+	}
+}
+
+// setGaugeForSoftIRQStats proxies metrics for the rates of the types of
+// software interrupts handled by the host
+func (c *Client) setGaugeForSoftIRQStats(hStats *hoststats.HostStats, baseLabels []metrics.Label) {
+	labels := make([]metrics.Label, len(baseLabels))
+	copy(labels, baseLabels)
+
+	for _, softirq := range hStats.SoftIRQs {
+		labels := append(labels, metrics.Label{
+			Name:  "type",
+			Value: softirq.Type,
+		})
+
+		metrics.SetGaugeWithLabels([]string{"client", "host", "softirq", "rate"}, float32(softirq.Rate), labels)
 	}
 }
 
@@ -3275,6 +3556,60 @@ func (c *Client) setGaugeForDiskStats(nodeID string, hStats *hoststats.HostStats
 	}
 }
 
+// setGaugeForDiskIOStats proxies metrics for the latency and saturation of
+// the block devices of the host
+func (c *Client) setGaugeForDiskIOStats(hStats *hoststats.HostStats, baseLabels []metrics.Label) {
+	labels := make([]metrics.Label, len(baseLabels))
+	copy(labels, baseLabels)
+
+	for _, disk := range hStats.DiskIOStats {
+		labels := append(labels, metrics.Label{
+			Name:  "device",
+			Value: disk.Device,
+		})
+
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "reads"}, float32(disk.ReadsPerSecond), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "writes"}, float32(disk.WritesPerSecond), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "await"}, float32(disk.AwaitMillis), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "utilization"}, float32(disk.UtilizationPercent), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "queue_depth"}, float32(disk.QueueDepth), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "diskio", "in_flight"}, float32(disk.InFlight), labels)
+	}
+}
+
+// setGaugeForPowerStats proxies metrics for host energy consumption and CPU
+// temperature, which are only collected on supported hardware
+func (c *Client) setGaugeForPowerStats(hStats *hoststats.HostStats, baseLabels []metrics.Label) {
+	if hStats.Power == nil {
+		return
+	}
+
+	labels := make([]metrics.Label, len(baseLabels))
+	copy(labels, baseLabels)
+
+	if len(hStats.Power.Zones) > 0 {
+		metrics.SetGaugeWithLabels([]string{"client", "host", "power", "watts"}, float32(hStats.Power.Watts), labels)
+	}
+
+	for _, zone := range hStats.Power.Zones {
+		labels := append(labels, metrics.Label{
+			Name:  "zone",
+			Value: zone.Name,
+		})
+
+		metrics.SetGaugeWithLabels([]string{"client", "host", "power", "zone", "watts"}, float32(zone.Watts), labels)
+	}
+
+	for _, temp := range hStats.Power.Temperatures {
+		labels := append(labels, metrics.Label{
+			Name:  "sensor",
+			Value: temp.Sensor,
+		})
+
+		metrics.SetGaugeWithLabels([]string{"client", "host", "temperature"}, float32(temp.Celsius), labels)
+	}
+}
+
 // setGaugeForAllocationStats proxies metrics for allocation specific statistics
 func (c *Client) setGaugeForAllocationStats(nodeID string, baseLabels []metrics.Label) {
 	node := c.GetConfig().Node
@@ -3342,6 +3677,9 @@ func (c *Client) emitHostStats() {
 	c.setGaugeForUptime(hStats, labels)
 	c.setGaugeForCPUStats(nodeID, hStats, labels)
 	c.setGaugeForDiskStats(nodeID, hStats, labels)
+	c.setGaugeForDiskIOStats(hStats, labels)
+	c.setGaugeForPowerStats(hStats, labels)
+	c.setGaugeForSoftIRQStats(hStats, labels)
 }
 
 // emitClientMetrics emits lower volume client metrics
@@ -3350,6 +3688,7 @@ func (c *Client) emitClientMetrics() {
 	labels := c.labels()
 
 	c.setGaugeForAllocationStats(nodeID, labels)
+	c.emitOrphanedExecutorMetrics(labels)
 
 	// Emit allocation metrics
 	blocked, migrating, pending, running, terminal := 0, 0, 0, 0, 0
@@ -3483,12 +3822,20 @@ func (c *Client) GetTaskEventHandler(allocID, taskName string) drivermanager.Eve
 // waiting to send
 type pendingClientUpdates struct {
 	updates map[string]*structs.Allocation
-	lock    sync.Mutex
+
+	// backfill buffers the usage summaries of updates superseded while the
+	// client fails to send updates, which is set when the last attempt to
+	// send them failed
+	backfill *usageBackfill
+	failing  bool
+
+	lock sync.Mutex
 }
 
-func newPendingClientUpdates() *pendingClientUpdates {
+func newPendingClientUpdates(backfill *usageBackfill) *pendingClientUpdates {
 	return &pendingClientUpdates{
-		updates: make(map[string]*structs.Allocation, 64),
+		updates:  make(map[string]*structs.Allocation, 64),
+		backfill: backfill,
 	}
 }
 
@@ -3496,24 +3843,51 @@ func newPendingClientUpdates() *pendingClientUpdates {
 // lightweight copies of its *structs.Allocation (i.e. just the client state),
 // serialized with an internal lock. So the latest update is always the
 // authoritative one, and the server only cares about that one.
+//
+// While the client fails to send updates, the usage summary of an overwritten
+// update is buffered for backfill, so the servers don't lose the usage history
+// of the time the client was disconnected.
 func (p *pendingClientUpdates) add(alloc *structs.Allocation) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	if prev, ok := p.updates[alloc.ID]; ok && p.failing {
+		p.supersedeLocked(prev, alloc)
+	}
 	p.updates[alloc.ID] = alloc
 }
 
-// restore refills the pending updates map, but only if a newer update hasn't come in
+// restore refills the pending updates map, but only if a newer update hasn't
+// come in. It is called when sending the updates failed.
 func (p *pendingClientUpdates) restore(toRestore []*structs.Allocation) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	p.failing = true
 	for _, alloc := range toRestore {
-		if _, ok := p.updates[alloc.ID]; !ok {
+		if newer, ok := p.updates[alloc.ID]; ok {
+			p.supersedeLocked(alloc, newer)
+		} else {
 			p.updates[alloc.ID] = alloc
 		}
 	}
 }
 
+// synced records that the client sent its updates successfully.
+func (p *pendingClientUpdates) synced() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.failing = false
+}
+
+// supersedeLocked buffers the usage summary of an update that will not be
+// sent because of a newer one, if the newer one reports different usage.
+func (p *pendingClientUpdates) supersedeLocked(prev, newer *structs.Allocation) {
+	if p.backfill == nil || prev.UsageSummary == nil || prev.UsageSummary.Equal(newer.UsageSummary) {
+		return
+	}
+	p.backfill.add(prev.ID, prev.UsageSummary)
+}
+
 // nextBatch returns a list of client allocation updates we need to make in this
 // tick of the allocSync. It returns nil if there's no updates to make yet. The
 // caller is responsible for calling restore() if it can't successfully send the
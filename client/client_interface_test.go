@@ -4,8 +4,10 @@
 package client
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -126,6 +128,10 @@ func (ar *emptyAllocRunner) GetUpdatePriority(*structs.Allocation) cstructs.Allo
 	return cstructs.AllocUpdatePriorityUrgent
 }
 
+func (ar *emptyAllocRunner) AllocUpdateDelta(a *structs.Allocation) *structs.Allocation {
+	return a
+}
+
 func (ar *emptyAllocRunner) SetClientStatus(status string) {
 	ar.allocLock.Lock()
 	defer ar.allocLock.Unlock()
@@ -138,6 +144,9 @@ func (ar *emptyAllocRunner) RestartTask(taskName string, taskEvent *structs.Task
 }
 func (ar *emptyAllocRunner) RestartRunning(taskEvent *structs.TaskEvent) error { return nil }
 func (ar *emptyAllocRunner) RestartAll(taskEvent *structs.TaskEvent) error     { return nil }
+func (ar *emptyAllocRunner) FailTask(taskName string, taskEvent *structs.TaskEvent) error {
+	return nil
+}
 
 func (ar *emptyAllocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	return nil
@@ -148,11 +157,25 @@ func (ar *emptyAllocRunner) GetTaskExecHandler(taskName string) drivermanager.Ta
 func (ar *emptyAllocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error) {
 	return nil, nil
 }
+func (ar *emptyAllocRunner) TaskProcesses(taskFilter string) (map[string][]*cstructs.TaskProcess, error) {
+	return nil, nil
+}
+func (ar *emptyAllocRunner) ReattachPids() map[string]int { return nil }
+func (ar *emptyAllocRunner) ClearStaleTaskHandle(taskName string) (bool, error) {
+	return false, nil
+}
+func (ar *emptyAllocRunner) CaptureTask(ctx context.Context, taskName, mode string, duration time.Duration) (string, error) {
+	return "", nil
+}
 
 func (ar *emptyAllocRunner) StatsReporter() interfaces.AllocStatsReporter { return ar }
 func (ar *emptyAllocRunner) Listener() *cstructs.AllocListener            { return nil }
 func (ar *emptyAllocRunner) GetAllocDir() allocdir.Interface              { return nil }
 
+func (ar *emptyAllocRunner) UsageWindows(taskFilter string) map[string]*cstructs.TaskUsageWindow {
+	return nil
+}
+
 // LatestAllocStats lets this empty runner implement AllocStatsReporter
 func (ar *emptyAllocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResourceUsage, error) {
 	return &cstructs.AllocResourceUsage{
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -1387,6 +1388,28 @@ func TestClient_ServerList(t *testing.T) {
 	}
 }
 
+// TestClient_UpdateNodeFromFingerprint_Utilization asserts changes of the
+// live utilization of the node trigger a heartbeat rather than a node update.
+func TestClient_UpdateNodeFromFingerprint_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {})
+	defer cleanup()
+
+	updates := len(client.triggerNodeUpdate)
+	client.updateNodeFromFingerprint(&fingerprint.FingerprintResponse{
+		Attributes: map[string]string{"unique.utilization.cpu.percent": "42"},
+	})
+
+	must.Eq(t, "42", client.GetConfig().Node.Attributes["unique.utilization.cpu.percent"])
+	must.Eq(t, updates, len(client.triggerNodeUpdate))
+	select {
+	case <-client.triggerUtilization:
+	default:
+		t.Fatal("expected a heartbeat to be triggered")
+	}
+}
+
 func TestClient_UpdateNodeFromDevicesAccumulates(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1695,6 +1718,29 @@ func TestClient_computeAllocatedDeviceStats(t *testing.T) {
 	}
 
 	assert.EqualValues(t, expected, result)
+
+	// partitions reported under the group of their device
+	partitionDevices := []*structs.AllocatedDeviceResource{
+		{
+			Vendor:    "vendor",
+			Type:      "type",
+			Name:      "partition",
+			DeviceIDs: []string{"p1"},
+		},
+	}
+	hostDeviceGroupStats[0].InstanceStats["p1"] = newDeviceStats("p1")
+
+	result = c.computeAllocatedDeviceGroupStats(partitionDevices, hostDeviceGroupStats)
+	must.Eq(t, []*device.DeviceGroupStats{
+		{
+			Vendor: "vendor",
+			Type:   "type",
+			Name:   "partition",
+			InstanceStats: map[string]*device.DeviceStats{
+				"p1": newDeviceStats("p1"),
+			},
+		},
+	}, result)
 }
 
 func TestClient_getAllocatedResources(t *testing.T) {
@@ -2184,3 +2230,34 @@ func TestClient_AllocPrerunErrorDuringRestore(t *testing.T) {
 	must.Eq(t, expectEvents, actual)
 	test.StrContains(t, ts.Events[3].DisplayMessage, allocrunner.ErrFailHookError.Error())
 }
+
+func TestClient_compressAllocUpdates(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &Client{logger: testlog.HCLogger(t)}
+	update := func(description string) *structs.AllocUpdateRequest {
+		return &structs.AllocUpdateRequest{
+			Alloc: []*structs.Allocation{{ID: "alloc", ClientDescription: description}},
+		}
+	}
+	large := strings.Repeat("still running ", 500)
+
+	// Nothing is compressed until all servers support it
+	args := update(large)
+	c.compressAllocUpdates(args)
+	must.Len(t, 1, args.Alloc)
+	must.Nil(t, args.CompressedAllocs)
+
+	c.serversAcceptAllocDeltas.Store(true)
+	args = update("running")
+	c.compressAllocUpdates(args)
+	must.Len(t, 1, args.Alloc)
+	must.Nil(t, args.CompressedAllocs)
+
+	args = update(large)
+	c.compressAllocUpdates(args)
+	must.Nil(t, args.Alloc)
+	allocs, err := structs.DecodeAllocUpdates(args.CompressedAllocs)
+	must.NoError(t, err)
+	must.Eq(t, large, allocs[0].ClientDescription)
+}
@@ -70,6 +70,12 @@ type AllocRunnerConfig struct {
 	// DeviceStatsReporter is used to lookup resource usage for alloc devices
 	DeviceStatsReporter interfaces.DeviceStatsReporter
 
+	// StatsSink receives every resource usage sample of the tasks
+	StatsSink interfaces.TaskStatsSink
+
+	// StatsInterval returns the effective stats collection interval
+	StatsInterval interfaces.StatsIntervalReporter
+
 	// PrevAllocWatcher handles waiting on previous or preempted allocations
 	PrevAllocWatcher PrevAllocWatcher
 
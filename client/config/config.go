@@ -32,6 +32,29 @@ import (
 	"github.com/hashicorp/nomad/version"
 )
 
+const (
+	// CPUPercentPerCore reports CPU percent where 100% is one core
+	CPUPercentPerCore = "core"
+
+	// CPUPercentAllocated reports CPU percent where 100% is the CPU allocated
+	// to the task
+	CPUPercentAllocated = "allocated"
+
+	// CPUPercentNode reports CPU percent where 100% is all the cores of the
+	// node
+	CPUPercentNode = "node"
+)
+
+const (
+	// ExecutorStatsModeFull makes executors read the CPU times and memory of
+	// the processes of tasks every 5 seconds
+	ExecutorStatsModeFull = "full"
+
+	// ExecutorStatsModeJiffies makes executors read only the CPU times and
+	// RSS of the processes of tasks from /proc/<pid>/stat, every second
+	ExecutorStatsModeJiffies = "jiffies"
+)
+
 var (
 	// DefaultEnvDenylist is the default set of environment variables that are
 	// filtered when passing the environment variables of the host to a task.
@@ -93,6 +116,10 @@ type Config struct {
 	// in the absence of ACLs
 	EnableDebug bool
 
+	// DataDir is the data_dir of the agent, which holds the client's state
+	// and allocation directories unless they are set on their own
+	DataDir string
+
 	// StateDir is where we store our state
 	StateDir string
 
@@ -110,6 +137,11 @@ type Config struct {
 	// Logger provides a logger to the client
 	Logger log.InterceptLogger
 
+	// AuditLogger records the accesses to the endpoints exposing what the
+	// tasks run. It must not be filtered by the log level, since these
+	// accesses may be regulated. Logger is used if it is not set.
+	AuditLogger log.Logger
+
 	// Region is the clients region
 	Region string
 
@@ -254,6 +286,59 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// TaskEnergyStats enables apportioning the measured host power to tasks
+	// by their share of the host CPU usage in allocation stats.
+	TaskEnergyStats bool
+
+	// LazyTaskStats pauses task stats collection while nothing consumes the
+	// samples: allocation metrics are not published, no stats sink plugins
+	// are running, and the task's stats haven't been read recently. Usage
+	// covering a pause is marked partial.
+	LazyTaskStats bool
+
+	// RecordExecutorStats makes executors record the stats samples they send
+	// to a file in the task directory, for debugging wrong usage numbers.
+	RecordExecutorStats bool
+
+	// ExecutorStatsMode is how executors read the processes of tasks: either
+	// ExecutorStatsModeFull or ExecutorStatsModeJiffies. Empty is the
+	// executor's default of ExecutorStatsModeFull.
+	ExecutorStatsMode string
+
+	// UsageAnomalyThreshold is how many standard deviations from its moving
+	// average a sample of a task's CPU usage, memory RSS or open file
+	// descriptors must be to be flagged as an anomaly in a task event. Zero
+	// disables the detection.
+	UsageAnomalyThreshold float64
+
+	// CPUPercentNormalization is the convention of the CPU percent the client
+	// publishes in the nomad.client.allocs.cpu.total_percent metric. The
+	// stats of the API report the percent in each convention.
+	CPUPercentNormalization string
+
+	// AuditTaskStarts records what the client started for each task start in
+	// the task's Started event, and logs it, for compliance auditing.
+	AuditTaskStarts bool
+
+	// GCAutoTune makes the agent lower its GOGC and set a GOMEMLIMIT as the
+	// memory available on the node shrinks, so it competes less with tasks.
+	GCAutoTune bool
+
+	// QoSClasses enforces the QoS classes of tasks: their oom_score_adj and
+	// memory protected from reclaim follow their class, and allocations may
+	// be evicted by class under memory pressure.
+	QoSClasses bool
+
+	// MemoryEvictionThreshold is the percent of the memory of the node
+	// available below which the client evicts allocations, from the lowest
+	// QoS class, to relieve the pressure. Zero disables eviction. It requires
+	// QoSClasses.
+	MemoryEvictionThreshold float64
+
+	// UtilizationAttributesInterval is how often the node attributes that
+	// expose its smoothed utilization are refreshed
+	UtilizationAttributesInterval time.Duration
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -297,6 +382,11 @@ type Config struct {
 	// internal bridge network
 	BridgeNetworkHairpinMode bool
 
+	// BridgeNetworkConntrackLimit is the number of connections each
+	// allocation in bridge networking mode may track at once, beyond which
+	// new connections are rejected. Zero means unlimited.
+	BridgeNetworkConntrackLimit int
+
 	// BridgeNetworkAllocSubnet is the IP subnet to use for address allocation
 	// for allocations in bridge networking mode. Subnet must be in CIDR
 	// notation and must be an IPv4 address.
@@ -352,6 +442,18 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// SelfProfiling configures the client to profile itself under pressure.
+	// If nil, self profiling is disabled.
+	SelfProfiling *SelfProfilingConfig
+
+	// Hotplug configures the client to fingerprint devices again when they are
+	// attached or removed. If nil, devices are only fingerprinted by plugins.
+	Hotplug *HotplugConfig
+
+	// DriverFencing configures the client to fail the tasks of drivers that
+	// stayed unhealthy. If nil, they are left running.
+	DriverFencing *DriverFencingConfig
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
@@ -1024,10 +1126,26 @@ func (c *Config) NomadPluginConfig(topology *numalib.Topology) *base.AgentConfig
 			ClientMinPort: c.ClientMinPort,
 			ClientMaxPort: c.ClientMaxPort,
 			Topology:      topology,
+
+			RecordExecutorStats: c.RecordExecutorStats,
+			ExecutorStatsMode:   c.ExecutorStatsMode,
+			DataDirs:            c.dataDirs(),
 		},
 	}
 }
 
+// dataDirs returns the directories the client keeps its own data and the data
+// of allocations in.
+func (c *Config) dataDirs() []string {
+	var dirs []string
+	for _, dir := range []string{c.DataDir, c.StateDir, c.AllocDir} {
+		if dir != "" && !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
 func (c *Config) GetDefaultConsul() *structsc.ConsulConfig {
 	return c.ConsulConfigs[structs.ConsulDefaultCluster]
 }
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// DriverFencingNone leaves the tasks of unhealthy drivers running
+	DriverFencingNone = "none"
+
+	// DriverFencingStop fails the tasks of a driver that stayed unhealthy, so
+	// their allocations are rescheduled on other nodes
+	DriverFencingStop = "stop"
+)
+
+// DriverFencingConfig is the internal readonly copy of the client agent's
+// DriverFencingConfig.
+type DriverFencingConfig struct {
+	// Grace is how long a driver must have been unhealthy for before the
+	// tasks are failed.
+	Grace time.Duration
+}
+
+// DriverFencingConfigFromAgent creates the internal readonly copy of the
+// client agent's DriverFencingConfig. It returns nil if tasks aren't fenced.
+func DriverFencingConfigFromAgent(c *config.DriverFencingConfig) (*DriverFencingConfig, error) {
+	if c == nil || c.Policy == nil {
+		return nil, nil
+	}
+
+	switch *c.Policy {
+	case DriverFencingNone:
+		return nil, nil
+	case DriverFencingStop:
+	default:
+		return nil, fmt.Errorf("policy must be %q or %q: %q", DriverFencingNone, DriverFencingStop, *c.Policy)
+	}
+
+	d := &DriverFencingConfig{
+		Grace: 5 * time.Minute,
+	}
+	if c.Grace != nil {
+		grace, err := time.ParseDuration(*c.Grace)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing grace: %w", err)
+		}
+		if grace < 0 {
+			return nil, errors.New("grace must not be negative")
+		}
+		d.Grace = grace
+	}
+	return d, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestDriverFencingConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	d, err := DriverFencingConfigFromAgent(&config.DriverFencingConfig{})
+	must.NoError(t, err)
+	must.Nil(t, d)
+
+	d, err = DriverFencingConfigFromAgent(&config.DriverFencingConfig{Policy: pointer.Of("none")})
+	must.NoError(t, err)
+	must.Nil(t, d)
+
+	d, err = DriverFencingConfigFromAgent(&config.DriverFencingConfig{Policy: pointer.Of("stop")})
+	must.NoError(t, err)
+	must.Eq(t, &DriverFencingConfig{Grace: 5 * time.Minute}, d)
+
+	d, err = DriverFencingConfigFromAgent(&config.DriverFencingConfig{
+		Policy: pointer.Of("stop"),
+		Grace:  pointer.Of("30s"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &DriverFencingConfig{Grace: 30 * time.Second}, d)
+
+	_, err = DriverFencingConfigFromAgent(&config.DriverFencingConfig{Policy: pointer.Of("kill")})
+	must.ErrorContains(t, err, "policy must be")
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/nomad/client/lib/hotplug"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// HotplugConfig is the internal readonly copy of the client agent's
+// HotplugConfig.
+type HotplugConfig struct {
+	// Subsystems are the kernel subsystems of the devices watched.
+	Subsystems []string
+
+	// Quiescence is how long the events of devices must have stopped for
+	// before fingerprinting.
+	Quiescence time.Duration
+}
+
+// HotplugConfigFromAgent creates the internal readonly copy of the client
+// agent's HotplugConfig. It returns nil if hotplug isn't enabled.
+func HotplugConfigFromAgent(c *config.HotplugConfig) (*HotplugConfig, error) {
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+
+	h := &HotplugConfig{
+		Subsystems: slices.Clone(hotplug.DefaultSubsystems),
+		Quiescence: hotplug.DefaultQuiescence,
+	}
+	if len(c.Subsystems) > 0 {
+		h.Subsystems = slices.Clone(c.Subsystems)
+	}
+	if c.Quiescence != nil {
+		d, err := time.ParseDuration(*c.Quiescence)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing quiescence: %w", err)
+		}
+		if d < 0 {
+			return nil, errors.New("quiescence must not be negative")
+		}
+		h.Quiescence = d
+	}
+	return h, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/hotplug"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestHotplugConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	h, err := HotplugConfigFromAgent(&config.HotplugConfig{})
+	must.NoError(t, err)
+	must.Nil(t, h)
+
+	h, err = HotplugConfigFromAgent(&config.HotplugConfig{Enabled: pointer.Of(true)})
+	must.NoError(t, err)
+	must.Eq(t, &HotplugConfig{
+		Subsystems: hotplug.DefaultSubsystems,
+		Quiescence: hotplug.DefaultQuiescence,
+	}, h)
+
+	h, err = HotplugConfigFromAgent(&config.HotplugConfig{
+		Enabled:    pointer.Of(true),
+		Subsystems: []string{"usb"},
+		Quiescence: pointer.Of("500ms"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &HotplugConfig{
+		Subsystems: []string{"usb"},
+		Quiescence: 500 * time.Millisecond,
+	}, h)
+
+	_, err = HotplugConfigFromAgent(&config.HotplugConfig{
+		Enabled:    pointer.Of(true),
+		Quiescence: pointer.Of("-1s"),
+	})
+	must.ErrorContains(t, err, "quiescence must not be negative")
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// SelfProfilingConfig is the internal readonly copy of the client agent's
+// SelfProfilingConfig.
+type SelfProfilingConfig struct {
+	// CPUThreshold is the CPU usage of the agent, as a percentage of one
+	// core, above which a profile is captured. Zero disables the check.
+	CPUThreshold float64
+
+	// MemoryThreshold is the RSS of the agent in bytes above which a
+	// profile is captured. Zero disables the check.
+	MemoryThreshold uint64
+
+	// CPUProfileDuration is how long the CPU profile of a capture runs.
+	CPUProfileDuration time.Duration
+
+	// Cooldown is the minimum time between two captures.
+	Cooldown time.Duration
+
+	// Retain is the number of captures kept.
+	Retain int
+}
+
+// SelfProfilingConfigFromAgent creates the internal readonly copy of the
+// client agent's SelfProfilingConfig. It returns nil if self profiling isn't
+// enabled.
+func SelfProfilingConfigFromAgent(c *config.SelfProfilingConfig) (*SelfProfilingConfig, error) {
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+
+	sp := &SelfProfilingConfig{
+		CPUThreshold:       200,
+		MemoryThreshold:    2 * 1024 * 1024 * 1024,
+		CPUProfileDuration: 30 * time.Second,
+		Cooldown:           30 * time.Minute,
+		Retain:             5,
+	}
+	if c.CPUThreshold != nil {
+		if *c.CPUThreshold < 0 {
+			return nil, errors.New("cpu_threshold must not be negative")
+		}
+		sp.CPUThreshold = float64(*c.CPUThreshold)
+	}
+	if c.MemoryThreshold != nil {
+		v, err := humanize.ParseBytes(*c.MemoryThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing memory_threshold: %w", err)
+		}
+		sp.MemoryThreshold = v
+	}
+	if c.CPUProfileDuration != nil {
+		d, err := time.ParseDuration(*c.CPUProfileDuration)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cpu_profile_duration: %w", err)
+		}
+		if d <= 0 {
+			return nil, errors.New("cpu_profile_duration must be positive")
+		}
+		sp.CPUProfileDuration = d
+	}
+	if c.Cooldown != nil {
+		d, err := time.ParseDuration(*c.Cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cooldown: %w", err)
+		}
+		sp.Cooldown = d
+	}
+	if c.Retain != nil {
+		if *c.Retain < 1 {
+			return nil, errors.New("retain must be at least 1")
+		}
+		sp.Retain = *c.Retain
+	}
+	if sp.CPUThreshold == 0 && sp.MemoryThreshold == 0 {
+		return nil, errors.New("at least one of cpu_threshold and memory_threshold must be set")
+	}
+	return sp, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestSelfProfilingConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	sp, err := SelfProfilingConfigFromAgent(&config.SelfProfilingConfig{})
+	must.NoError(t, err)
+	must.Nil(t, sp)
+
+	sp, err = SelfProfilingConfigFromAgent(&config.SelfProfilingConfig{
+		Enabled:         pointer.Of(true),
+		CPUThreshold:    pointer.Of(0),
+		MemoryThreshold: pointer.Of("512MiB"),
+		Cooldown:        pointer.Of("1h"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &SelfProfilingConfig{
+		MemoryThreshold:    512 * 1024 * 1024,
+		CPUProfileDuration: 30 * time.Second,
+		Cooldown:           time.Hour,
+		Retain:             5,
+	}, sp)
+
+	_, err = SelfProfilingConfigFromAgent(&config.SelfProfilingConfig{
+		Enabled:         pointer.Of(true),
+		CPUThreshold:    pointer.Of(0),
+		MemoryThreshold: pointer.Of("0"),
+	})
+	must.ErrorContains(t, err, "at least one of")
+
+	_, err = SelfProfilingConfigFromAgent(&config.SelfProfilingConfig{
+		Enabled: pointer.Of(true),
+		Retain:  pointer.Of(0),
+	})
+	must.ErrorContains(t, err, "retain must be at least 1")
+}
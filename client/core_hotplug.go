@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// watchCoreHotplug fingerprints the CPU cores of the node again when devices
+// are hotplugged, since the cpu fingerprinter otherwise only runs when the
+// client starts, and fails the tasks that reserved cores removed from the
+// node so their allocations are replaced on cores that exist.
+func (c *Client) watchCoreHotplug(hotplugCh <-chan struct{}) {
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-hotplugCh:
+		}
+
+		c.fingerprintManager.Refingerprint("cpu")
+		c.drainRemovedCores(onlineCores())
+	}
+}
+
+// onlineCores returns the CPU cores of the node that are online.
+func onlineCores() *idset.Set[hw.CoreID] {
+	top := numalib.Scan(numalib.PlatformScanners())
+	cores := idset.Empty[hw.CoreID]()
+	for _, core := range top.Cores {
+		cores.Insert(core.ID)
+	}
+	return cores
+}
+
+// drainRemovedCores fails the running tasks that reserved cores which aren't
+// online anymore.
+func (c *Client) drainRemovedCores(online *idset.Set[hw.CoreID]) {
+	if online.Empty() {
+		// the cores couldn't be detected, which doesn't mean they were removed
+		return
+	}
+
+	for id, ar := range c.getAllocRunners() {
+		tasks, removed := removedCoreTasks(ar, online)
+		if len(tasks) == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("Failing task since its reserved cores %s were removed from the node", removed)
+		c.logger.Warn("failing tasks with reserved cores removed from the node",
+			"alloc_id", id, "tasks", tasks, "cores", removed.String())
+		c.failTasks(ar, tasks, structs.NewTaskEvent(structs.TaskKilling).SetDisplayMessage(message))
+	}
+}
+
+// removedCoreTasks returns the names of the tasks of the allocation of the
+// runner which reserved cores that aren't online, sorted, and those cores, if
+// the allocation is still running.
+func removedCoreTasks(ar interfaces.AllocRunner, online *idset.Set[hw.CoreID]) ([]string, *idset.Set[hw.CoreID]) {
+	removed := idset.Empty[hw.CoreID]()
+	if ar.IsDestroyed() {
+		return nil, removed
+	}
+	alloc := ar.Alloc()
+	if alloc.ClientTerminalStatus() || alloc.ServerTerminalStatus() || alloc.AllocatedResources == nil {
+		return nil, removed
+	}
+
+	var tasks []string
+	for name, res := range alloc.AllocatedResources.Tasks {
+		reserved := idset.From[hw.CoreID](res.Cpu.ReservedCores)
+		if gone := reserved.Difference(online); !gone.Empty() {
+			tasks = append(tasks, name)
+			removed.InsertSet(gone)
+		}
+	}
+	sort.Strings(tasks)
+	return tasks, removed
+}
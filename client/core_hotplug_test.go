@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_removedCoreTasks(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	web := alloc.AllocatedResources.Tasks["web"]
+	web.Cpu.ReservedCores = []uint16{2, 3}
+	alloc.AllocatedResources.Tasks["sidecar"] = &structs.AllocatedTaskResources{
+		Cpu: structs.AllocatedCpuResources{ReservedCores: []uint16{4}},
+	}
+	alloc.AllocatedResources.Tasks["shared"] = &structs.AllocatedTaskResources{}
+	ar := &emptyAllocRunner{alloc: alloc}
+
+	// Tasks on online cores keep running
+	tasks, removed := removedCoreTasks(ar, idset.Parse[hw.CoreID]("0-7"))
+	must.SliceEmpty(t, tasks)
+	must.True(t, removed.Empty())
+
+	// Tasks that reserved any removed core fail
+	tasks, removed = removedCoreTasks(ar, idset.Parse[hw.CoreID]("0-2,5-7"))
+	must.Eq(t, []string{"sidecar", "web"}, tasks)
+	must.Eq(t, "3-4", removed.String())
+
+	// Terminal allocations are left alone
+	alloc.ClientStatus = structs.AllocClientStatusFailed
+	tasks, _ = removedCoreTasks(ar, idset.Parse[hw.CoreID]("0-2,5-7"))
+	must.SliceEmpty(t, tasks)
+}
@@ -55,6 +55,10 @@ type instanceManagerConfig struct {
 
 	// StatsInterval is the interval at which we collect statistics.
 	StatsInterval time.Duration
+
+	// RefingerprintCh receives when devices were attached to or removed from
+	// the node, so the plugin fingerprints them again.
+	RefingerprintCh <-chan struct{}
 }
 
 // instanceManager is used to manage a single device plugin
@@ -83,6 +87,9 @@ type instanceManager struct {
 	// fingerprintOutCh is used to emit new fingerprinted devices
 	fingerprintOutCh chan<- struct{}
 
+	// refingerprintCh receives when the plugin should fingerprint again
+	refingerprintCh <-chan struct{}
+
 	// plugin is the plugin instance being managed
 	plugin loader.PluginInstance
 
@@ -127,6 +134,7 @@ func newInstanceManager(c *instanceManagerConfig) *instanceManager {
 		pluginConfig:       c.PluginConfig,
 		id:                 c.Id,
 		fingerprintOutCh:   c.FingerprintOutCh,
+		refingerprintCh:    c.RefingerprintCh,
 		statsInterval:      c.StatsInterval,
 		firstFingerprintCh: make(chan struct{}),
 	}
@@ -329,25 +337,35 @@ func (i *instanceManager) cleanup() {
 
 // fingerprint is a long lived routine used to fingerprint the device
 func (i *instanceManager) fingerprint() {
-START:
+	for i.fingerprintUntilRestart() {
+	}
+}
+
+// fingerprintUntilRestart fingerprints the device until the fingerprinting
+// must start over, in which case it returns true. Fingerprinting starts over
+// when the plugin exited, or when devices were attached or removed since
+// plugins only fingerprint periodically.
+func (i *instanceManager) fingerprintUntilRestart() bool {
 	// Get a device plugin
 	devicePlugin, err := i.dispense()
 	if err != nil {
 		i.logger.Error("dispensing plugin failed", "error", err)
 		i.cancel()
-		return
+		return false
 	}
 
 	// Start fingerprinting
-	fingerprintCh, err := devicePlugin.Fingerprint(i.ctx)
+	ctx, cancel := context.WithCancel(i.ctx)
+	defer cancel()
+	fingerprintCh, err := devicePlugin.Fingerprint(ctx)
 	if err == device.ErrPluginDisabled {
 		i.logger.Info("fingerprinting failed: plugin is not enabled")
 		i.handleFingerprintError()
-		return
+		return false
 	} else if err != nil {
 		i.logger.Error("fingerprinting failed", "error", err)
 		i.handleFingerprintError()
-		return
+		return false
 	}
 
 	var fresp *device.FingerprintResponse
@@ -355,14 +373,17 @@ START:
 	for {
 		select {
 		case <-i.ctx.Done():
-			return
+			return false
+		case <-i.refingerprintCh:
+			i.logger.Debug("fingerprinting again after devices were attached or removed")
+			return true
 		case fresp, ok = <-fingerprintCh:
 		}
 
 		if !ok {
 			i.logger.Trace("exiting since fingerprinting gracefully shutdown")
 			i.handleFingerprintError()
-			return
+			return false
 		}
 
 		// Guard against error by the plugin
@@ -374,12 +395,12 @@ START:
 		if fresp.Error != nil {
 			if fresp.Error == bstructs.ErrPluginShutdown {
 				i.logger.Error("plugin exited unexpectedly")
-				goto START
+				return true
 			}
 
 			i.logger.Error("fingerprinting returned an error", "error", fresp.Error)
 			i.handleFingerprintError()
-			return
+			return false
 		}
 
 		if err := i.handleFingerprint(fresp); err != nil {
@@ -14,6 +14,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/devicemanager/state"
+	"github.com/hashicorp/nomad/client/lib/hotplug"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -73,6 +74,10 @@ type Config struct {
 
 	// State is used to manage the device managers state
 	State StateStorage
+
+	// Hotplug notifies when devices are attached to or removed from the
+	// node, so the plugins fingerprint them again. It may be nil.
+	Hotplug *hotplug.Notifier
 }
 
 // manager is used to manage a set of device plugins
@@ -102,6 +107,9 @@ type manager struct {
 	// fingerprintResCh is used to be triggered that there are new devices
 	fingerprintResCh chan struct{}
 
+	// hotplug notifies when devices are attached to or removed from the node
+	hotplug *hotplug.Notifier
+
 	// instances is the list of managed devices
 	instances map[loader.PluginID]*instanceManager
 
@@ -122,6 +130,7 @@ func New(c *Config) *manager {
 		pluginConfig:     c.PluginConfig,
 		updater:          c.Updater,
 		statsInterval:    c.StatsInterval,
+		hotplug:          c.Hotplug,
 		instances:        make(map[loader.PluginID]*instanceManager),
 		reattachConfigs:  make(map[loader.PluginID]*pstructs.ReattachConfig),
 		fingerprintResCh: make(chan struct{}, 1),
@@ -162,6 +171,7 @@ func (m *manager) Run() {
 			Id:               &id,
 			FingerprintOutCh: m.fingerprintResCh,
 			StatsInterval:    m.statsInterval,
+			RefingerprintCh:  m.hotplug.Subscribe(),
 		})
 	}
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -493,3 +494,61 @@ func TestManager_Run_ShutdownOld(t *testing.T) {
 		t.Fatal(err)
 	})
 }
+
+// Test that the devices are fingerprinted again when devices are attached
+func TestInstanceManager_Refingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	config, _, catalog := baseTestConfig(t)
+
+	// Each fingerprint detects one more device
+	var attached atomic.Int32
+	info := pluginInfoResponse("nvidia")
+	dev := &device.MockDevicePlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF:   base.StaticInfo(info),
+			ConfigSchemaF: base.TestConfigSchema(),
+			SetConfigF:    base.NoopSetConfig(),
+		},
+		FingerprintF: func(ctx context.Context) (<-chan *device.FingerprintResponse, error) {
+			group := *nvidiaDeviceGroup
+			group.Devices = group.Devices[:attached.Add(1)]
+			return device.StaticFingerprinter([]*device.DeviceGroup{&group})(ctx)
+		},
+		StatsF: device.StaticStats(nil),
+	}
+	configureCatalogWith(catalog, map[*base.PluginInfoResponse]loader.PluginInstance{
+		info: loader.MockBasicExternalPlugin(dev, device.ApiVersion010),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	refingerprintCh := make(chan struct{}, 1)
+	i := newInstanceManager(&instanceManagerConfig{
+		Logger:           config.Logger,
+		Ctx:              ctx,
+		Loader:           catalog,
+		StoreReattach:    func(*plugin.ReattachConfig) error { return nil },
+		PluginConfig:     config.PluginConfig,
+		Id:               &loader.PluginID{Name: info.Name, PluginType: info.Type},
+		FingerprintOutCh: make(chan struct{}, 1),
+		StatsInterval:    config.StatsInterval,
+		RefingerprintCh:  refingerprintCh,
+	})
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+	i.WaitForFirstFingerprint(waitCtx)
+	require.NoError(t, waitCtx.Err())
+	require.Len(t, i.Devices()[0].Devices, 1)
+
+	refingerprintCh <- struct{}{}
+	testutil.WaitForResult(func() (bool, error) {
+		if l := len(i.Devices()[0].Devices); l != 2 {
+			return false, fmt.Errorf("expected 2 devices; got %d", l)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// driverFencingInterval is the interval the health of drivers is checked at
+// for fencing their tasks
+const driverFencingInterval = 10 * time.Second
+
+// watchDriverFencing fails the tasks of drivers that stayed unhealthy for the
+// grace of the driver fencing config, so their allocations are replaced on
+// other nodes instead of running on a driver that can't manage them.
+func (c *Client) watchDriverFencing() {
+	conf := c.GetConfig()
+	if conf.DriverFencing == nil {
+		return
+	}
+	grace := conf.DriverFencing.Grace
+
+	// unhealthySince is when each unhealthy driver was first seen unhealthy
+	unhealthySince := map[string]time.Time{}
+
+	ticker := time.NewTicker(driverFencingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-ticker.C:
+		}
+
+		due := driversToFence(c.Node().Drivers, unhealthySince, time.Now(), grace)
+		for _, driver := range due {
+			c.fenceDriver(driver, unhealthySince[driver])
+		}
+	}
+}
+
+// driversToFence updates when each driver was first seen unhealthy, and
+// returns the drivers that have been unhealthy for the grace, sorted.
+func driversToFence(infos map[string]*structs.DriverInfo, unhealthySince map[string]time.Time, now time.Time, grace time.Duration) []string {
+	for driver := range unhealthySince {
+		if info, ok := infos[driver]; !ok || info.Healthy || !info.Detected {
+			delete(unhealthySince, driver)
+		}
+	}
+
+	var due []string
+	for driver, info := range infos {
+		if info == nil || info.Healthy || !info.Detected {
+			continue
+		}
+		since, ok := unhealthySince[driver]
+		if !ok {
+			unhealthySince[driver] = now
+			since = now
+		}
+		if now.Sub(since) >= grace {
+			due = append(due, driver)
+		}
+	}
+	sort.Strings(due)
+	return due
+}
+
+// fenceDriver fails the running tasks of the driver, which has been unhealthy
+// since the given time, so their allocations are rescheduled.
+func (c *Client) fenceDriver(driver string, since time.Time) {
+	message := fmt.Sprintf("Failing task since driver %q was unhealthy since %s",
+		driver, since.Format(time.RFC3339))
+
+	var fenced []string
+	for id, ar := range c.getAllocRunners() {
+		tasks := driverTasks(ar, driver)
+		if len(tasks) == 0 {
+			continue
+		}
+
+		c.logger.Warn("failing tasks of unhealthy driver",
+			"alloc_id", id, "driver", driver, "tasks", tasks, "unhealthy_since", since)
+		c.failTasks(ar, tasks, structs.NewTaskEvent(structs.TaskKilling).SetDisplayMessage(message))
+		fenced = append(fenced, id)
+	}
+	if len(fenced) == 0 {
+		return
+	}
+
+	sort.Strings(fenced)
+	c.triggerNodeEvent(structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemDriver).
+		SetMessage(fmt.Sprintf("Failed %d allocations with tasks of unhealthy driver", len(fenced))).
+		AddDetail("driver", driver).
+		AddDetail("allocs", fmt.Sprintf("%v", fenced)))
+}
+
+// driverTasks returns the names of the tasks of the allocation of the runner
+// run by the driver, if the allocation is still running.
+func driverTasks(ar interfaces.AllocRunner, driver string) []string {
+	if ar.IsDestroyed() {
+		return nil
+	}
+	alloc := ar.Alloc()
+	if alloc.ClientTerminalStatus() || alloc.ServerTerminalStatus() {
+		return nil
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+
+	var tasks []string
+	for _, task := range tg.Tasks {
+		if task.Driver == driver {
+			tasks = append(tasks, task.Name)
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_driversToFence(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	grace := time.Minute
+	unhealthySince := map[string]time.Time{}
+	infos := map[string]*structs.DriverInfo{
+		"exec":   {Detected: true, Healthy: false},
+		"docker": {Detected: true, Healthy: true},
+		"java":   {Detected: false, Healthy: false},
+	}
+
+	// Drivers are only fenced once unhealthy for the grace
+	must.SliceEmpty(t, driversToFence(infos, unhealthySince, now, grace))
+	must.MapLen(t, 1, unhealthySince)
+	must.Eq(t, now, unhealthySince["exec"])
+
+	must.SliceEmpty(t, driversToFence(infos, unhealthySince, now.Add(30*time.Second), grace))
+	must.Eq(t, []string{"exec"}, driversToFence(infos, unhealthySince, now.Add(grace), grace))
+
+	// Recovering resets the grace
+	infos["exec"] = &structs.DriverInfo{Detected: true, Healthy: true}
+	must.SliceEmpty(t, driversToFence(infos, unhealthySince, now.Add(2*grace), grace))
+	must.MapEmpty(t, unhealthySince)
+
+	infos["exec"] = &structs.DriverInfo{Detected: true, Healthy: false}
+	must.SliceEmpty(t, driversToFence(infos, unhealthySince, now.Add(3*grace), grace))
+	must.Eq(t, now.Add(3*grace), unhealthySince["exec"])
+}
@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	multierror "github.com/hashicorp/go-multierror"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// orphanedExecutorInterval is how often the client scans for executors
+	// none of its tasks own
+	orphanedExecutorInterval = 5 * time.Minute
+
+	// orphanedExecutorGrace is how long an executor must have been running
+	// before it can be orphaned, since the handle of a task only refers to
+	// its executor once the executor started the task
+	orphanedExecutorGrace = time.Minute
+)
+
+// executorProcess is an executor process running a task of the client.
+type executorProcess struct {
+	pid     int
+	allocID string
+	task    string
+	created time.Time
+}
+
+// parseExecutorCmdline returns the allocation and task an executor process
+// runs from its command line, whose config includes the path of the
+// executor's log file in the task directory. It returns false for processes
+// that aren't executors and for the executors of other clients.
+func parseExecutorCmdline(allocDir string, cmdline []string) (string, string, bool) {
+	if len(cmdline) != 3 || cmdline[1] != "executor" {
+		return "", "", false
+	}
+
+	var config struct {
+		LogFile string
+	}
+	if err := json.Unmarshal([]byte(cmdline[2]), &config); err != nil || config.LogFile == "" {
+		return "", "", false
+	}
+
+	rel, err := filepath.Rel(allocDir, config.LogFile)
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) != 3 || parts[0] == ".." {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// scanExecutors returns the executor processes running tasks in the alloc
+// dir of the client.
+func scanExecutors(allocDir string) ([]*executorProcess, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	var executors []*executorProcess
+	for _, p := range procs {
+		// Processes may exit while scanning
+		cmdline, err := p.CmdlineSlice()
+		if err != nil {
+			continue
+		}
+		allocID, task, ok := parseExecutorCmdline(allocDir, cmdline)
+		if !ok {
+			continue
+		}
+		created, err := p.CreateTime()
+		if err != nil {
+			continue
+		}
+		executors = append(executors, &executorProcess{
+			pid:     int(p.Pid),
+			allocID: allocID,
+			task:    task,
+			created: time.UnixMilli(created),
+		})
+	}
+	return executors, nil
+}
+
+// orphanedExecutors returns the executors of tasks the client doesn't know
+// of. The executors of known tasks are left to their alloc runner, which
+// owns the cgroup of the task even if the executor is not the one in the
+// handle of the task.
+func orphanedExecutors(executors []*executorProcess, known map[string]map[string]bool, now time.Time) []*cstructs.OrphanedExecutor {
+	var orphans []*cstructs.OrphanedExecutor
+	for _, e := range executors {
+		if known[e.allocID][e.task] || now.Sub(e.created) < orphanedExecutorGrace {
+			continue
+		}
+		orphans = append(orphans, &cstructs.OrphanedExecutor{
+			Pid:     e.pid,
+			AllocID: e.allocID,
+			Task:    e.task,
+		})
+	}
+	slices.SortFunc(orphans, func(a, b *cstructs.OrphanedExecutor) int {
+		return cmp.Compare(a.Pid, b.Pid)
+	})
+	return orphans
+}
+
+// findOrphanedExecutors returns the executors running on the client that
+// none of its live tasks own, with the usage of their task, and the handles
+// of dead tasks that still refer to a plugin process.
+func (c *Client) findOrphanedExecutors() ([]*cstructs.OrphanedExecutor, []*cstructs.StaleTaskHandle, error) {
+	executors, err := scanExecutors(c.GetConfig().AllocDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	known, err := c.knownTasks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stale []*cstructs.StaleTaskHandle
+	for allocID, ar := range c.getAllocRunners() {
+		states := ar.AllocState().TaskStates
+		for task, pid := range ar.ReattachPids() {
+			if ts := states[task]; ts != nil && ts.State == structs.TaskStateDead {
+				stale = append(stale, &cstructs.StaleTaskHandle{AllocID: allocID, Task: task, Pid: pid})
+			}
+		}
+	}
+	slices.SortFunc(stale, func(a, b *cstructs.StaleTaskHandle) int {
+		return cmp.Or(cmp.Compare(a.AllocID, b.AllocID), cmp.Compare(a.Task, b.Task))
+	})
+
+	orphans := orphanedExecutors(executors, known, time.Now())
+	for _, o := range orphans {
+		adoptCgroup(o)
+	}
+	return orphans, stale, nil
+}
+
+// knownTasks returns the tasks of the allocations the client runs or has in
+// its state DB, keyed by allocation ID and task name.
+func (c *Client) knownTasks() (map[string]map[string]bool, error) {
+	known := make(map[string]map[string]bool)
+	add := func(alloc *structs.Allocation) {
+		if known[alloc.ID] == nil {
+			known[alloc.ID] = make(map[string]bool)
+		}
+		if alloc.Job == nil {
+			return
+		}
+		if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+			for _, task := range tg.Tasks {
+				known[alloc.ID][task.Name] = true
+			}
+		}
+	}
+
+	for _, ar := range c.getAllocRunners() {
+		add(ar.Alloc())
+	}
+	allocs, _, err := c.stateDB.GetAllAllocations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allocations from state: %w", err)
+	}
+	for _, alloc := range allocs {
+		add(alloc)
+	}
+	return known, nil
+}
+
+// gcOrphanedExecutors terminates the orphaned executors and their tasks, and
+// clears the stale handles of dead tasks.
+func (c *Client) gcOrphanedExecutors() ([]*cstructs.OrphanedExecutor, []*cstructs.StaleTaskHandle, error) {
+	orphans, stale, err := c.findOrphanedExecutors()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mErr multierror.Error
+	var remaining []*cstructs.OrphanedExecutor
+	for _, o := range orphans {
+		if err := terminateExecutor(o); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("failed to terminate executor %d: %w", o.Pid, err))
+			remaining = append(remaining, o)
+			continue
+		}
+		o.Terminated = true
+		c.logger.Info("terminated orphaned executor", "pid", o.Pid, "alloc_id", o.AllocID, "task", o.Task)
+	}
+
+	for _, h := range stale {
+		ar, err := c.getAllocRunner(h.AllocID)
+		if err != nil {
+			// The allocation was garbage collected along with the handle
+			continue
+		}
+		if h.Cleared, err = ar.ClearStaleTaskHandle(h.Task); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("failed to clear handle of task %q in alloc %s: %w", h.Task, h.AllocID, err))
+		}
+	}
+
+	c.setOrphanedExecutors(remaining)
+	return orphans, stale, mErr.ErrorOrNil()
+}
+
+// terminateExecutor kills the processes of an orphaned executor's task
+// before the executor itself, since they outlive the executor, and removes
+// the cgroup of the task.
+func terminateExecutor(o *cstructs.OrphanedExecutor) error {
+	p, err := process.NewProcess(int32(o.Pid))
+	if err == nil {
+		if err := killProcessTree(p); err != nil {
+			return err
+		}
+	}
+	return removeCgroup(o)
+}
+
+func killProcessTree(p *process.Process) error {
+	// Children fails when there are none
+	children, _ := p.Children()
+	for _, child := range children {
+		_ = killProcessTree(child)
+	}
+	if err := p.Kill(); err != nil {
+		if running, _ := p.IsRunning(); running {
+			return err
+		}
+	}
+	return nil
+}
+
+// setOrphanedExecutors stores the orphaned executors found by the last scan,
+// whose usage is accounted for until they are terminated.
+func (c *Client) setOrphanedExecutors(orphans []*cstructs.OrphanedExecutor) {
+	c.orphanedExecutorsLock.Lock()
+	defer c.orphanedExecutorsLock.Unlock()
+	c.orphanedExecutors = orphans
+}
+
+// emitOrphanedExecutorMetrics emits the number of orphaned executors and the
+// memory their tasks use, refreshed from the cgroups adopted for them.
+func (c *Client) emitOrphanedExecutorMetrics(labels []metrics.Label) {
+	c.orphanedExecutorsLock.Lock()
+	defer c.orphanedExecutorsLock.Unlock()
+
+	var memory uint64
+	for _, o := range c.orphanedExecutors {
+		adoptCgroup(o)
+		memory += o.MemoryBytes
+	}
+	metrics.SetGaugeWithLabels([]string{"client", "executors", "orphaned"}, float32(len(c.orphanedExecutors)), labels)
+	metrics.SetGaugeWithLabels([]string{"client", "executors", "orphaned_memory"}, float32(memory), labels)
+}
+
+// watchOrphanedExecutors periodically scans for orphaned executors, such as
+// those left running after the client crashed or lost its state, and logs
+// the new ones. They are left running until an operator garbage collects
+// them, since their tasks may still be doing useful work.
+func (c *Client) watchOrphanedExecutors() {
+	known := make(map[int]bool)
+
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-c.shutdownCh:
+			return
+		}
+		timer.Reset(orphanedExecutorInterval)
+
+		orphans, stale, err := c.findOrphanedExecutors()
+		if err != nil {
+			c.logger.Warn("failed to scan for orphaned executors", "error", err)
+			continue
+		}
+		c.setOrphanedExecutors(orphans)
+
+		seen := make(map[int]bool, len(orphans))
+		for _, o := range orphans {
+			seen[o.Pid] = true
+			if !known[o.Pid] {
+				c.logger.Warn("found orphaned executor; run 'nomad system gc -executors' to terminate it",
+					"pid", o.Pid, "alloc_id", o.AllocID, "task", o.Task)
+			}
+		}
+		known = seen
+
+		if len(stale) > 0 {
+			c.logger.Debug("found stale task handles", "count", len(stale))
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package client
+
+import (
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// adoptCgroup is a no-op on platforms without cgroups.
+func adoptCgroup(*cstructs.OrphanedExecutor) {}
+
+// removeCgroup is a no-op on platforms without cgroups.
+func removeCgroup(*cstructs.OrphanedExecutor) error { return nil }
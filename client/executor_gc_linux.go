@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package client
+
+import (
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// orphanCgroup returns the cgroup the task of an orphaned executor runs in,
+// the cgroup its memory usage is read from, and whether it reserves cores. It
+// returns false if the task isn't in a cgroup the client manages.
+func orphanCgroup(o *cstructs.OrphanedExecutor) (string, string, bool, bool) {
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		dir := cgroupslib.PathCG1(o.AllocID, o.Task, "freezer")
+		if _, err := os.Stat(dir); err != nil {
+			return "", "", false, false
+		}
+		_, err := os.Stat(cgroupslib.LinuxResourcesPath(o.AllocID, o.Task, true))
+		return dir, cgroupslib.PathCG1(o.AllocID, o.Task, "memory"), err == nil, true
+	case cgroupslib.CG2:
+		for _, cores := range []bool{false, true} {
+			dir := cgroupslib.LinuxResourcesPath(o.AllocID, o.Task, cores)
+			if _, err := os.Stat(dir); err == nil {
+				return dir, dir, cores, true
+			}
+		}
+	}
+	return "", "", false, false
+}
+
+// adoptCgroup sets the cgroup of the task of an orphaned executor, and the
+// processes and memory usage of the task read from it.
+func adoptCgroup(o *cstructs.OrphanedExecutor) {
+	dir, memoryDir, _, ok := orphanCgroup(o)
+	if !ok {
+		return
+	}
+	o.Cgroup = dir
+
+	if pids, err := cgroupslib.OpenPath(dir).PIDs(); err == nil {
+		o.Pids = pids.Slice()
+		slices.Sort(o.Pids)
+	}
+
+	file := "memory.current"
+	if cgroupslib.GetMode() == cgroupslib.CG1 {
+		file = "memory.usage_in_bytes"
+	}
+	if s, err := cgroupslib.OpenPath(memoryDir).Read(file); err == nil {
+		o.MemoryBytes, _ = strconv.ParseUint(s, 10, 64)
+	}
+}
+
+// removeCgroup kills any process left in the cgroup adopted for the task of
+// an orphaned executor and removes the cgroup.
+func removeCgroup(o *cstructs.OrphanedExecutor) error {
+	_, _, cores, ok := orphanCgroup(o)
+	if !ok {
+		return nil
+	}
+
+	cg := cgroupslib.Factory(o.AllocID, o.Task, cores)
+	if err := cg.Kill(); err != nil {
+		return err
+	}
+	return cg.Teardown()
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseExecutorCmdline(t *testing.T) {
+	ci.Parallel(t)
+
+	allocDir := filepath.FromSlash("/var/nomad/alloc")
+	config := func(logFile string) string {
+		return `{"LogFile":"` + filepath.ToSlash(logFile) + `","LogLevel":"debug","FSIsolation":true}`
+	}
+
+	cases := []struct {
+		name    string
+		cmdline []string
+		allocID string
+		task    string
+		ok      bool
+	}{
+		{
+			name:    "executor",
+			cmdline: []string{"/usr/bin/nomad", "executor", config("/var/nomad/alloc/0f8ac7a5/web/executor.out")},
+			allocID: "0f8ac7a5",
+			task:    "web",
+			ok:      true,
+		},
+		{
+			name:    "other client",
+			cmdline: []string{"/usr/bin/nomad", "executor", config("/opt/nomad/alloc/0f8ac7a5/web/executor.out")},
+		},
+		{
+			name:    "not in task dir",
+			cmdline: []string{"/usr/bin/nomad", "executor", config("/var/nomad/alloc/executor.out")},
+		},
+		{
+			name:    "agent",
+			cmdline: []string{"/usr/bin/nomad", "agent", "-config", "/etc/nomad.d"},
+		},
+		{
+			name:    "invalid config",
+			cmdline: []string{"/usr/bin/nomad", "executor", "{"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			allocID, task, ok := parseExecutorCmdline(allocDir, tc.cmdline)
+			must.Eq(t, tc.ok, ok)
+			must.Eq(t, tc.allocID, allocID)
+			must.Eq(t, tc.task, task)
+		})
+	}
+}
+
+func TestOrphanedExecutors(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	executors := []*executorProcess{
+		{pid: 30, allocID: "a2", task: "db", created: now.Add(-time.Hour)},
+		{pid: 10, allocID: "a1", task: "web", created: now.Add(-time.Hour)},
+
+		// of a task the client knows, even if not in the handle of the task
+		{pid: 20, allocID: "a1", task: "api", created: now.Add(-time.Hour)},
+		{pid: 21, allocID: "a1", task: "api", created: now.Add(-time.Hour)},
+
+		// may not be persisted in the handle of its task yet
+		{pid: 40, allocID: "a3", task: "web", created: now.Add(-time.Second)},
+	}
+
+	known := map[string]map[string]bool{"a1": {"api": true}}
+	orphans := orphanedExecutors(executors, known, now)
+	must.Eq(t, []*cstructs.OrphanedExecutor{
+		{Pid: 10, AllocID: "a1", Task: "web"},
+		{Pid: 30, AllocID: "a2", Task: "db"},
+	}, orphans)
+}
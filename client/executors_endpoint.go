@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Executors endpoint is used for finding and cleaning up the executors left
+// running on a client for tasks it no longer runs
+type Executors struct {
+	c *Client
+}
+
+// List returns the orphaned executors and stale task handles of the client.
+func (e *Executors) List(args *structs.NodeSpecificRequest, reply *cstructs.ExecutorsResponse) error {
+	defer metrics.MeasureSince([]string{"client", "executors", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := e.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	orphans, stale, err := e.c.findOrphanedExecutors()
+	if err != nil {
+		return err
+	}
+	reply.Orphans = orphans
+	reply.StaleHandles = stale
+	return nil
+}
+
+// GarbageCollect terminates the orphaned executors of the client along with
+// their tasks, and clears its stale task handles.
+func (e *Executors) GarbageCollect(args *structs.NodeSpecificRequest, reply *cstructs.ExecutorsResponse) error {
+	defer metrics.MeasureSince([]string{"client", "executors", "garbage_collect"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := e.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	orphans, stale, err := e.c.gcOrphanedExecutors()
+	reply.Orphans = orphans
+	reply.StaleHandles = stale
+	return err
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"maps"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// failTasks fails the tasks of the allocation of the runner by killing them
+// with a copy of the event, so the allocation fails and the scheduler replaces
+// it according to its reschedule policy rather than the client stopping it
+// for good. The other tasks of the allocation are killed as their sibling
+// failed. The tasks are killed in the background, since killing them waits
+// for their kill timeout.
+func (c *Client) failTasks(ar interfaces.AllocRunner, tasks []string, event *structs.TaskEvent) {
+	allocID := ar.Alloc().ID
+	for _, task := range tasks {
+		// each task gets details of its own, which failing it sets
+		taskEvent := event.Copy()
+		taskEvent.Details = maps.Clone(event.Details)
+
+		go func(task string, event *structs.TaskEvent) {
+			if err := ar.FailTask(task, event); err != nil {
+				c.logger.Warn("failed to fail task", "alloc_id", allocID, "task", task, "error", err)
+			}
+		}(task, taskEvent)
+	}
+}
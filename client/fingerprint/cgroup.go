@@ -29,5 +29,18 @@ func (f *CgroupFingerprint) Fingerprint(request *FingerprintRequest, response *F
 		response.AddAttribute(versionKey, "2")
 		f.logger.Debug("detected cgroups", "version", "2")
 	}
+
+	if cgroupslib.IsHybrid() {
+		response.AddAttribute("os.cgroups.hybrid", "true")
+	}
+
+	// record which resources of tasks the node can limit, and which it only
+	// accounts for, e.g. os.cgroups.controller.memory = accounting
+	for controller, enforcement := range cgroupslib.GetEnforcement() {
+		response.AddAttribute("os.cgroups.controller."+controller, string(enforcement))
+		if enforcement != cgroupslib.Enforced {
+			f.logger.Warn("cgroup controller not available; task usage is accounted for but not limited", "controller", controller)
+		}
+	}
 	return nil
 }
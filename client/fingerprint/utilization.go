@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	// defaultUtilizationInterval is how often the utilization attributes
+	// are refreshed if the client doesn't configure it
+	defaultUtilizationInterval = 5 * time.Minute
+
+	// minUtilizationInterval bounds how often the utilization attributes
+	// may be refreshed, since every change is written to the servers
+	minUtilizationInterval = 10 * time.Second
+
+	// utilizationSmoothing is the weight of the latest interval in the
+	// moving average of each utilization attribute
+	utilizationSmoothing = 0.5
+
+	// utilizationBucketMargin is how far past a bucket threshold the
+	// utilization must move before the bucket attribute changes, so that a
+	// node hovering around a threshold doesn't flap between buckets
+	utilizationBucketMargin = 5.0
+)
+
+// The utilization buckets, by the busiest of the CPU and memory utilization
+const (
+	UtilizationBucketLow    = "low"
+	UtilizationBucketMedium = "medium"
+	UtilizationBucketHigh   = "high"
+)
+
+// UtilizationFingerprint exposes the smoothed CPU, memory, and pressure
+// utilization of the node as attributes, so jobs can constrain or prefer
+// nodes by how busy they are. The attributes are unique so that their changes
+// don't change the computed class of the node, and are rounded to whole
+// percents so that they only change when the utilization does materially.
+type UtilizationFingerprint struct {
+	logger log.Logger
+
+	// lock guards the fields below, since Periodic is called by the
+	// fingerprint manager outside of Fingerprint
+	lock     sync.Mutex
+	period   time.Duration
+	lastCPU  cpu.TimesStat
+	lastDisk map[string]disk.IOCountersStat
+	lastTime time.Time
+	averages map[string]float64
+	bucket   string
+}
+
+func NewUtilizationFingerprint(logger log.Logger) Fingerprint {
+	return &UtilizationFingerprint{
+		logger:   logger.Named("utilization"),
+		averages: make(map[string]float64),
+	}
+}
+
+func (f *UtilizationFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.period = defaultUtilizationInterval
+	if req.Config != nil && req.Config.UtilizationAttributesInterval > 0 {
+		f.period = max(req.Config.UtilizationAttributesInterval, minUtilizationInterval)
+	}
+
+	samples := make(map[string]float64)
+
+	// The first interval is measured since boot, when the times are zero
+	times, err := cpu.Times(false)
+	if err != nil || len(times) == 0 {
+		return fmt.Errorf("failed to read CPU times: %v", err)
+	}
+	if busy, steal, ok := cpuUtilization(f.lastCPU, times[0]); ok {
+		samples["cpu.percent"] = busy
+		samples["cpu.steal"] = steal
+	}
+	f.lastCPU = times[0]
+
+	// Disk I/O counters aren't available on every platform
+	now := time.Now()
+	if counters, err := disk.IOCounters(); err == nil {
+		if util, ok := diskUtilization(f.lastDisk, counters, now.Sub(f.lastTime)); ok {
+			samples["disk.percent"] = util
+		}
+		f.lastDisk = counters
+	}
+	f.lastTime = now
+
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return fmt.Errorf("failed to read memory stats: %v", err)
+	}
+	if vm.Total > 0 {
+		samples["memory.percent"] = float64(vm.Total-vm.Available) / float64(vm.Total) * 100
+	}
+
+	// Pressure is already averaged over a minute by the kernel
+	for _, resource := range []string{"cpu", "memory", "io"} {
+		if pressure, ok := readPressure(resource); ok {
+			samples["pressure."+resource] = pressure
+		}
+	}
+
+	for name, sample := range samples {
+		avg, ok := f.averages[name]
+		if !ok {
+			avg = sample
+		} else {
+			avg += utilizationSmoothing * (sample - avg)
+		}
+		f.averages[name] = avg
+		resp.AddAttribute(structs.NodeUtilizationAttrPrefix+name, strconv.Itoa(int(math.Round(avg))))
+	}
+
+	if busiest, ok := f.busiest(); ok {
+		f.bucket = utilizationBucket(f.bucket, busiest)
+		resp.AddAttribute(structs.NodeUtilizationBucketAttr, f.bucket)
+	}
+
+	resp.Detected = true
+	return nil
+}
+
+func (f *UtilizationFingerprint) Periodic() (bool, time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return true, f.period
+}
+
+// busiest returns the higher of the smoothed CPU and memory utilization.
+func (f *UtilizationFingerprint) busiest() (float64, bool) {
+	cpuPercent, cpuOk := f.averages["cpu.percent"]
+	memPercent, memOk := f.averages["memory.percent"]
+	if !cpuOk && !memOk {
+		return 0, false
+	}
+	return max(cpuPercent, memPercent), true
+}
+
+// utilizationBucket returns the bucket of the utilization, given the previous
+// bucket of the node. The bucket only changes once the utilization is past the
+// threshold by utilizationBucketMargin.
+func utilizationBucket(prev string, util float64) string {
+	bucket := bucketFor(util)
+	switch {
+	case prev == "" || bucket == prev:
+		return bucket
+	case bucketRank(bucket) > bucketRank(prev):
+		return bucketFor(util - utilizationBucketMargin)
+	default:
+		return bucketFor(util + utilizationBucketMargin)
+	}
+}
+
+func bucketFor(util float64) string {
+	switch {
+	case util < 50:
+		return UtilizationBucketLow
+	case util < 80:
+		return UtilizationBucketMedium
+	default:
+		return UtilizationBucketHigh
+	}
+}
+
+func bucketRank(bucket string) int {
+	switch bucket {
+	case UtilizationBucketLow:
+		return 0
+	case UtilizationBucketMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// cpuUtilization returns the percentages of the CPU time between two samples
+// that was busy and that was stolen by the hypervisor. Guest time is already
+// counted in user time.
+func cpuUtilization(prev, cur cpu.TimesStat) (busy, steal float64, ok bool) {
+	total := func(t cpu.TimesStat) float64 {
+		return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+	}
+	elapsed := total(cur) - total(prev)
+	if elapsed <= 0 {
+		return 0, 0, false
+	}
+
+	idle := (cur.Idle + cur.Iowait) - (prev.Idle + prev.Iowait)
+	busy = clampPercent((elapsed - idle) / elapsed * 100)
+	steal = clampPercent((cur.Steal - prev.Steal) / elapsed * 100)
+	return busy, steal, true
+}
+
+// diskUtilization returns the percentage of the time between two samples that
+// the busiest block device was busy with I/O, ignoring loop and RAM devices.
+func diskUtilization(prev, cur map[string]disk.IOCountersStat, elapsed time.Duration) (float64, bool) {
+	if len(prev) == 0 || elapsed <= 0 {
+		return 0, false
+	}
+
+	var busiest float64
+	var ok bool
+	for name, c := range cur {
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+			continue
+		}
+		p, found := prev[name]
+		if !found || c.IoTime < p.IoTime {
+			continue
+		}
+		util := float64(c.IoTime-p.IoTime) / float64(elapsed.Milliseconds()) * 100
+		busiest, ok = max(busiest, clampPercent(util)), true
+	}
+	return busiest, ok
+}
+
+func clampPercent(v float64) float64 {
+	return min(max(v, 0), 100)
+}
@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package fingerprint
+
+// readPressure is only supported on Linux, which exposes pressure stall
+// information.
+func readPressure(string) (float64, bool) { return 0, false }
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package fingerprint
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// pressureDir is where the kernel exposes pressure stall information, if it
+// was built with CONFIG_PSI and not booted with psi=0
+const pressureDir = "/proc/pressure"
+
+// readPressure returns the percentage of the last minute in which some tasks
+// of the node stalled waiting for the given resource.
+func readPressure(resource string) (float64, bool) {
+	f, err := os.Open(filepath.Join(pressureDir, resource))
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	return parsePressure(f)
+}
+
+// parsePressure returns the avg60 of the "some" line of a pressure file.
+func parsePressure(r io.Reader) (float64, bool) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			value, ok := strings.CutPrefix(field, "avg60=")
+			if !ok {
+				continue
+			}
+			avg, err := strconv.ParseFloat(value, 64)
+			return avg, err == nil
+		}
+	}
+	return 0, false
+}
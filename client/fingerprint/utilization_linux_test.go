@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package fingerprint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestUtilizationFingerprint_parsePressure(t *testing.T) {
+	ci.Parallel(t)
+
+	avg, ok := parsePressure(strings.NewReader(
+		"some avg10=1.50 avg60=4.25 avg300=2.00 total=123456\n" +
+			"full avg10=0.50 avg60=1.00 avg300=0.75 total=65432\n"))
+	must.True(t, ok)
+	must.Eq(t, 4.25, avg)
+
+	_, ok = parsePressure(strings.NewReader("full avg10=0.50 avg60=1.00 avg300=0.75 total=65432\n"))
+	must.False(t, ok)
+}
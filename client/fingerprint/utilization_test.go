@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shoenig/test/must"
+)
+
+func TestUtilizationFingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewUtilizationFingerprint(testlog.HCLogger(t))
+	node := &structs.Node{Attributes: make(map[string]string)}
+
+	// The interval is bounded so clients don't flood the servers
+	request := &FingerprintRequest{
+		Config: &config.Config{UtilizationAttributesInterval: time.Second},
+		Node:   node,
+	}
+	var response FingerprintResponse
+	must.NoError(t, f.Fingerprint(request, &response))
+	must.True(t, response.Detected)
+
+	periodic, period := f.Periodic()
+	must.True(t, periodic)
+	must.Eq(t, minUtilizationInterval, period)
+
+	for _, attr := range []string{
+		"unique.utilization.cpu.percent",
+		"unique.utilization.cpu.steal",
+		"unique.utilization.memory.percent",
+	} {
+		assertNodeAttributeContains(t, response.Attributes, attr)
+		percent, err := strconv.Atoi(response.Attributes[attr])
+		must.NoError(t, err)
+		must.Between(t, 0, percent, 100)
+	}
+	must.SliceContains(t, []string{UtilizationBucketLow, UtilizationBucketMedium, UtilizationBucketHigh},
+		response.Attributes["unique.utilization.bucket"])
+
+	request.Config = &config.Config{}
+	must.NoError(t, f.Fingerprint(request, &response))
+	_, period = f.Periodic()
+	must.Eq(t, defaultUtilizationInterval, period)
+}
+
+func TestUtilizationFingerprint_cpuUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := cpu.TimesStat{User: 100, System: 50, Idle: 800, Iowait: 40, Steal: 10}
+	cur := cpu.TimesStat{User: 160, System: 70, Idle: 880, Iowait: 60, Steal: 30}
+
+	// 200 seconds elapsed, of which 100 were idle and 20 stolen
+	busy, steal, ok := cpuUtilization(prev, cur)
+	must.True(t, ok)
+	must.Eq(t, 50, busy)
+	must.Eq(t, 10, steal)
+
+	_, _, ok = cpuUtilization(cur, cur)
+	must.False(t, ok)
+}
+
+func TestUtilizationFingerprint_diskUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := map[string]disk.IOCountersStat{
+		"sda":   {IoTime: 1000},
+		"sdb":   {IoTime: 5000},
+		"loop0": {IoTime: 0},
+	}
+	cur := map[string]disk.IOCountersStat{
+		"sda":   {IoTime: 1500},
+		"sdb":   {IoTime: 6500},
+		"loop0": {IoTime: 2000},
+		"sdc":   {IoTime: 2000},
+	}
+
+	// sdb was busy for 1.5 of the 2 seconds, and loop0 and the new sdc
+	// are ignored
+	util, ok := diskUtilization(prev, cur, 2*time.Second)
+	must.True(t, ok)
+	must.Eq(t, 75, util)
+
+	_, ok = diskUtilization(nil, cur, 2*time.Second)
+	must.False(t, ok)
+}
+
+func TestUtilizationFingerprint_utilizationBucket(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		prev string
+		util float64
+		exp  string
+	}{
+		{"", 10, UtilizationBucketLow},
+		{"", 50, UtilizationBucketMedium},
+		{"", 95, UtilizationBucketHigh},
+
+		// The bucket must be left by the margin to change
+		{UtilizationBucketLow, 52, UtilizationBucketLow},
+		{UtilizationBucketLow, 56, UtilizationBucketMedium},
+		{UtilizationBucketLow, 90, UtilizationBucketHigh},
+		{UtilizationBucketMedium, 47, UtilizationBucketMedium},
+		{UtilizationBucketMedium, 44, UtilizationBucketLow},
+		{UtilizationBucketHigh, 76, UtilizationBucketHigh},
+		{UtilizationBucketHigh, 74, UtilizationBucketMedium},
+		{UtilizationBucketHigh, 20, UtilizationBucketLow},
+	}
+	for _, tc := range cases {
+		must.Eq(t, tc.exp, utilizationBucket(tc.prev, tc.util),
+			must.Sprintf("prev %q at %v", tc.prev, tc.util))
+	}
+}
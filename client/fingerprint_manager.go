@@ -29,6 +29,9 @@ type FingerprintManager struct {
 
 	reloadableFps map[string]fingerprint.ReloadableFingerprint
 
+	// fingerprinters are the fingerprinters set up, by name
+	fingerprinters map[string]fingerprint.Fingerprint
+
 	// initialResult is used to pass information detected during the first pass
 	// of fingerprinting back to the client
 	initialResult *fingerprint.InitialResult
@@ -54,6 +57,7 @@ func NewFingerprintManager(
 		shutdownCh:           shutdownCh,
 		logger:               logger.Named("fingerprint_mgr"),
 		reloadableFps:        make(map[string]fingerprint.ReloadableFingerprint),
+		fingerprinters:       make(map[string]fingerprint.Fingerprint),
 		initialResult:        new(fingerprint.InitialResult),
 	}
 }
@@ -127,6 +131,18 @@ func (fm *FingerprintManager) Reload() {
 	}
 }
 
+// Refingerprint runs the named fingerprinter again, if it was set up, such as
+// when the hardware it fingerprints changed.
+func (fm *FingerprintManager) Refingerprint(name string) {
+	f, ok := fm.fingerprinters[name]
+	if !ok {
+		return
+	}
+	if _, err := fm.fingerprint(name, f); err != nil {
+		fm.logger.Warn("error fingerprinting again", "fingerprinter", name, "error", err)
+	}
+}
+
 // setupFingerprints is used to fingerprint the node to see if these attributes are
 // supported
 func (fm *FingerprintManager) setupFingerprinters(fingerprints []string) error {
@@ -158,6 +174,7 @@ func (fm *FingerprintManager) setupFingerprinters(fingerprints []string) error {
 		if rfp, ok := f.(fingerprint.ReloadableFingerprint); ok {
 			fm.reloadableFps[name] = rfp
 		}
+		fm.fingerprinters[name] = f
 	}
 
 	fm.logger.Debug("detected fingerprints", "node_attrs", appliedFingerprints)
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -266,7 +267,7 @@ func (f *FileSystem) stream(conn io.ReadWriteCloser) {
 
 	// Start streaming
 	go func() {
-		if err := f.streamFile(ctx, req.Offset, req.Path, req.Limit, fs, framer, nil, cancelAfterFirstEof); err != nil {
+		if err := f.streamFile(ctx, req.Offset, req.Path, req.Limit, fs, framer, nil, cancelAfterFirstEof, nil); err != nil {
 			select {
 			case errCh <- err:
 			case <-ctx.Done():
@@ -412,6 +413,18 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		return
 	}
 
+	lineOpts := logLineOptions{
+		timestamps: req.Timestamps,
+		since:      req.Since,
+		until:      req.Until,
+	}
+	if req.Filter != "" {
+		if lineOpts.filter, err = regexp.Compile(req.Filter); err != nil {
+			handleStreamResultError(fmt.Errorf("invalid filter: %v", err), pointer.Of(int64(http.StatusBadRequest)), encoder)
+			return
+		}
+	}
+
 	fs, err := f.c.GetAllocFS(req.AllocID)
 	if err != nil {
 		code := pointer.Of(int64(http.StatusInternalServerError))
@@ -460,7 +473,7 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 
 	// Start streaming
 	go func() {
-		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
+		if err := f.logsImpl(ctx, req.Follow, req.PlainText, lineOpts,
 			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
 			select {
 			case errCh <- err:
@@ -544,8 +557,9 @@ OUTER:
 
 // logsImpl is used to stream the logs of a the given task. Output is sent on
 // the passed frames channel and the method will return on EOF if follow is not
-// true otherwise when the context is cancelled or on an error.
-func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset int64,
+// true otherwise when the context is cancelled or on an error. The lines of
+// the logs are selected and annotated according to lineOpts.
+func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, lineOpts logLineOptions, offset int64,
 	origin, task, logType string,
 	fs allocdir.AllocDirFS, frames chan<- *sframer.StreamFrame) error {
 
@@ -554,6 +568,18 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 	framer.Run()
 	defer framer.Destroy()
 
+	var lines *logLines
+	if lineOpts.enabled() {
+		lines = newLogLines(lineOpts, fs)
+
+		// Stream the last line even if it didn't end
+		defer func() {
+			if data := lines.flush(); len(data) > 0 {
+				framer.Send(lines.path, "", data, lines.offset)
+			}
+		}()
+	}
+
 	// Path to the logs
 	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
 
@@ -612,7 +638,7 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 		}
 
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof, lines)
 
 		// Check if the context is cancelled
 		select {
@@ -636,7 +662,7 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			return fmt.Errorf("failed to stream %q: %v", p, err)
 		}
 
-		if exitAfter {
+		if exitAfter || (lines != nil && lines.done) {
 			return nil
 		}
 
@@ -658,10 +684,12 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 // streamFile is the internal method to stream the content of a file. If limit
 // is greater than zero, the stream will end once that many bytes have been
 // read. If eofCancelCh is triggered while at EOF, read one more frame and
-// cancel the stream on the next EOF. If the connection is broken an EPIPE
-// error is returned.
+// cancel the stream on the next EOF. If lines is set, the lines of the log
+// file are selected and annotated by it, and the stream ends once it is done.
+// If the connection is broken an EPIPE error is returned.
 func (f *FileSystem) streamFile(ctx context.Context, offset int64, path string, limit int64,
-	fs allocdir.AllocDirFS, framer *sframer.StreamFramer, eofCancelCh chan error, cancelAfterFirstEof bool) error {
+	fs allocdir.AllocDirFS, framer *sframer.StreamFramer, eofCancelCh chan error, cancelAfterFirstEof bool,
+	lines *logLines) error {
 
 	// Get the reader
 	file, err := fs.ReadAt(path, offset)
@@ -697,11 +725,26 @@ func (f *FileSystem) streamFile(ctx context.Context, offset int64, path string,
 		bufSize = limit
 	}
 	data := make([]byte, bufSize)
+
+	if lines != nil {
+		if last := lines.open(path, offset); len(last) > 0 {
+			if err := framer.Send(path, "", last, offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+	}
 OUTER:
 	for {
 		// Read up to the max frame size
 		n, readErr := fileReader.Read(data)
 
+		frameData := data[:n]
+		if lines != nil {
+			if frameData, err = lines.process(frameData, offset); err != nil {
+				return err
+			}
+		}
+
 		// Update the offset
 		offset += int64(n)
 
@@ -712,10 +755,13 @@ OUTER:
 
 		// Send the frame
 		if n != 0 || lastEvent != "" {
-			if err := framer.Send(path, lastEvent, data[:n], offset); err != nil {
+			if err := framer.Send(path, lastEvent, frameData, offset); err != nil {
 				return parseFramerErr(err)
 			}
 		}
+		if lines != nil && lines.done {
+			return nil
+		}
 
 		// Clear the last event
 		if lastEvent != "" {
@@ -736,6 +782,15 @@ OUTER:
 			return nil
 		}
 
+		// Stream the last event before waiting for more of it
+		if lines != nil {
+			if data := lines.idle(); len(data) > 0 {
+				if err := framer.Send(path, "", data, offset); err != nil {
+					return parseFramerErr(err)
+				}
+			}
+		}
+
 		// If EOF is hit, wait for a change to the file
 		if changes == nil {
 			changes, err = fs.ChangeEvents(waitCtx, path, offset)
@@ -764,6 +819,9 @@ OUTER:
 					return err
 				}
 				defer file.Close()
+				if lines != nil {
+					lines.open(path, offset)
+				}
 
 				if limit <= 0 {
 					fileReader = file
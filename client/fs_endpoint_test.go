@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -25,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/config"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -1756,7 +1758,7 @@ func TestFS_streamFile_NoFile(t *testing.T) {
 	defer framer.Destroy()
 
 	err := c.endpoints.FileSystem.streamFile(
-		context.Background(), 0, "foo", 0, ad, framer, nil, false)
+		context.Background(), 0, "foo", 0, ad, framer, nil, false, nil)
 	require.Error(t, err)
 	if runtime.GOOS == "windows" {
 		require.Contains(t, err.Error(), "cannot find the file")
@@ -1817,7 +1819,7 @@ func TestFS_streamFile_Modify(t *testing.T) {
 	// Start streaming
 	go func() {
 		if err := c.endpoints.FileSystem.streamFile(
-			context.Background(), 0, streamFile, 0, ad, framer, nil, false); err != nil {
+			context.Background(), 0, streamFile, 0, ad, framer, nil, false, nil); err != nil {
 			t.Fatalf("stream() failed: %v", err)
 		}
 	}()
@@ -1893,7 +1895,7 @@ func TestFS_streamFile_Truncate(t *testing.T) {
 	// Start streaming
 	go func() {
 		if err := c.endpoints.FileSystem.streamFile(
-			context.Background(), 0, streamFile, 0, ad, framer, nil, false); err != nil {
+			context.Background(), 0, streamFile, 0, ad, framer, nil, false, nil); err != nil {
 			t.Fatalf("stream() failed: %v", err)
 		}
 	}()
@@ -1997,7 +1999,7 @@ func TestFS_streamImpl_Delete(t *testing.T) {
 	// Start streaming
 	go func() {
 		if err := c.endpoints.FileSystem.streamFile(
-			context.Background(), 0, streamFile, 0, ad, framer, nil, false); err != nil {
+			context.Background(), 0, streamFile, 0, ad, framer, nil, false, nil); err != nil {
 			t.Fatalf("stream() failed: %v", err)
 		}
 	}()
@@ -2073,7 +2075,7 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 	defer cancel()
 
 	if err := c.endpoints.FileSystem.logsImpl(
-		ctx, false, false, 0,
+		ctx, false, false, logLineOptions{}, 0,
 		OriginStart, task, logType, ad, frames); err != nil {
 		t.Fatalf("logsImpl failed: %v", err)
 	}
@@ -2085,6 +2087,202 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 	}
 }
 
+func TestFS_logsImpl_Timestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Write output the way logmon does for framed output, with a line split
+	// across frames
+	rotator, err := logging.NewFileRotator(logDir, "foo.stdout", 2, 1024, testlog.HCLogger(t))
+	must.NoError(t, err)
+	first := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	rotator.SetTimestamp(first)
+	_, err = rotator.Write([]byte("one\ntw"))
+	must.NoError(t, err)
+	rotator.SetTimestamp(first.Add(time.Second))
+	_, err = rotator.Write([]byte("o\nthree\n"))
+	must.NoError(t, err)
+	must.NoError(t, rotator.Close())
+
+	frames := make(chan *sframer.StreamFrame, 32)
+	must.NoError(t, c.endpoints.FileSystem.logsImpl(
+		context.Background(), false, false, logLineOptions{timestamps: true}, 0,
+		OriginStart, "foo", "stdout", ad, frames))
+
+	var received []byte
+	timeout := time.After(10 * time.Duration(testutil.TestMultiplier()) * streamBatchWindow)
+	expected := "2024-01-02T03:04:05.000000006Z one\n" +
+		"2024-01-02T03:04:05.000000006Z two\n" +
+		"2024-01-02T03:04:06.000000006Z three\n"
+	for string(received) != expected {
+		select {
+		case frame := <-frames:
+			received = append(received, frame.Data...)
+		case <-timeout:
+			t.Fatalf("did not receive timestamped logs: got %q", received)
+		}
+	}
+}
+
+func TestFS_logsImpl_SelectLines(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Write a line a second, rotating across files
+	start := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	rotator, err := logging.NewFileRotator(logDir, "foo.stdout", 10, 16, testlog.HCLogger(t))
+	must.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		rotator.SetTimestamp(start.Add(time.Duration(i) * time.Second))
+		_, err = fmt.Fprintf(rotator, "line %d\n", i)
+		must.NoError(t, err)
+	}
+	must.NoError(t, rotator.Close())
+
+	read := func(opts logLineOptions) string {
+		frames := make(chan *sframer.StreamFrame, 32)
+		must.NoError(t, c.endpoints.FileSystem.logsImpl(
+			context.Background(), false, false, opts, 0,
+			OriginStart, "foo", "stdout", ad, frames))
+
+		var received []byte
+		for {
+			select {
+			case frame, ok := <-frames:
+				if !ok {
+					return string(received)
+				}
+				received = append(received, frame.Data...)
+			case <-time.After(10 * time.Duration(testutil.TestMultiplier()) * streamBatchWindow):
+				return string(received)
+			}
+		}
+	}
+
+	must.Eq(t, "line 2\nline 3\nline 4\n", read(logLineOptions{
+		since: start.Add(2 * time.Second),
+		until: start.Add(4 * time.Second),
+	}))
+	must.Eq(t, "line 1\nline 5\n", read(logLineOptions{
+		filter: regexp.MustCompile(`[15]$`),
+	}))
+	must.Eq(t, "2024-01-02T03:04:03.000000000Z line 3\n", read(logLineOptions{
+		timestamps: true,
+		filter:     regexp.MustCompile(`3`),
+	}))
+}
+
+func TestFS_logsImpl_SelectLines_NoTimestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	// Write a log file without a timestamp index
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0"), []byte("line 0\n"), 0666))
+
+	frames := make(chan *sframer.StreamFrame, 32)
+	err := c.endpoints.FileSystem.logsImpl(
+		context.Background(), false, false, logLineOptions{since: time.Now().Add(-time.Hour)}, 0,
+		OriginStart, "foo", "stdout", ad, frames)
+	must.ErrorContains(t, err, "has no timestamps")
+
+	// Other selections still stream the lines
+	frames = make(chan *sframer.StreamFrame, 32)
+	must.NoError(t, c.endpoints.FileSystem.logsImpl(
+		context.Background(), false, false, logLineOptions{filter: regexp.MustCompile(`0`)}, 0,
+		OriginStart, "foo", "stdout", ad, frames))
+	frame := <-frames
+	must.Eq(t, "line 0\n", string(frame.Data))
+}
+
+func TestFS_logsImpl_GroupEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Write events rotating across files, so the stack trace and the event
+	// after it carry on in the next files
+	start := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	rotator, err := logging.NewFileRotator(logDir, "foo.stdout", 10, 20, testlog.HCLogger(t))
+	must.NoError(t, err)
+	rotator.EventPattern = regexp.MustCompile(`^\S`)
+	for i, event := range []string{
+		"info ok\n",
+		"error boom\n  at a\n  at b\n",
+		"info ok again\n",
+	} {
+		rotator.SetTimestamp(start.Add(time.Duration(i) * time.Second))
+		_, err = io.WriteString(rotator, event)
+		must.NoError(t, err)
+	}
+	must.NoError(t, rotator.Close())
+
+	read := func(opts logLineOptions) string {
+		frames := make(chan *sframer.StreamFrame, 32)
+		must.NoError(t, c.endpoints.FileSystem.logsImpl(
+			context.Background(), false, false, opts, 0,
+			OriginStart, "foo", "stdout", ad, frames))
+
+		var received []byte
+		for {
+			select {
+			case frame, ok := <-frames:
+				if !ok {
+					return string(received)
+				}
+				received = append(received, frame.Data...)
+			case <-time.After(10 * time.Duration(testutil.TestMultiplier()) * streamBatchWindow):
+				return string(received)
+			}
+		}
+	}
+
+	must.Eq(t, "error boom\n  at a\n  at b\n", read(logLineOptions{
+		filter: regexp.MustCompile(`boom`),
+	}))
+	must.Eq(t, "info ok\ninfo ok again\n", read(logLineOptions{
+		filter: regexp.MustCompile(`^info`),
+	}))
+	must.Eq(t, "2024-01-02T03:04:01.000000000Z error boom\n  at a\n  at b\n", read(logLineOptions{
+		timestamps: true,
+		since:      start.Add(time.Second),
+		until:      start.Add(time.Second),
+	}))
+}
+
 func TestFS_logsImpl_Follow(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2163,7 +2361,7 @@ func TestFS_logsImpl_Follow(t *testing.T) {
 
 	// Start streaming logs
 	go c.endpoints.FileSystem.logsImpl(
-		context.Background(), true, false, 0,
+		context.Background(), true, false, logLineOptions{}, 0,
 		OriginStart, task, logType, ad, frames)
 
 	select {
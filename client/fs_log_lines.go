@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/logmon/logging"
+)
+
+const (
+	// logTimestampFormat is the format of the timestamps lines are prefixed
+	// with. It is fixed width so the output stays aligned.
+	logTimestampFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+	// maxSelectedLineSize is the size past which a line that hasn't ended yet
+	// is selected on its own, so a task that never writes a new line can't make
+	// the client buffer its output.
+	maxSelectedLineSize = 64 * 1024
+)
+
+// logLineOptions are the options of a logs request that work on the lines of
+// the logs rather than their bytes.
+type logLineOptions struct {
+	// timestamps prefixes each line with the time it was produced at
+	timestamps bool
+
+	// since and until select the lines produced in a time range, and are
+	// ignored if zero
+	since time.Time
+	until time.Time
+
+	// filter selects lines matching it, if set
+	filter *regexp.Regexp
+}
+
+// enabled returns true if any option is set.
+func (o logLineOptions) enabled() bool {
+	return o.timestamps || o.selects()
+}
+
+// selects returns true if lines are selected, so they have to be streamed
+// whole.
+func (o logLineOptions) selects() bool {
+	return !o.since.IsZero() || !o.until.IsZero() || o.filter != nil
+}
+
+// logLines selects and annotates the lines streamed from the log files of a
+// task, using the timestamps recorded in the log files' timestamp indexes.
+// Lines without a recorded timestamp aren't prefixed with one and aren't
+// selected by a time range. Selecting a time range of a log file without a
+// timestamp index, such as those of tasks whose executor doesn't frame their
+// output, is an error rather than selecting none of its lines.
+//
+// Once a log file with an event index is read, the lines are grouped into the
+// events recorded in it: an event is only prefixed with a timestamp on its
+// first line, and is selected as a whole.
+type logLines struct {
+	opts logLineOptions
+	fs   allocdir.AllocDirFS
+
+	// path is the log file being streamed and offset the offset in it after
+	// the output processed so far
+	path   string
+	offset int64
+
+	// indexed is true once the timestamp index of the log file was read
+	indexed   bool
+	indexPath string
+	index     logging.TimestampIndex
+
+	eventsPath string
+	events     logging.EventIndex
+
+	// grouped is true once a log file with an event index was read
+	grouped bool
+
+	// lineStart is true if the next byte streamed starts a line
+	lineStart bool
+
+	// pending is the part of a line, or of an event if grouped, read so far
+	// when lines are selected, and pendingTS is when it was produced if known
+	pending   []byte
+	pendingTS time.Time
+
+	// flushed is true if the pending line or event was selected or dropped
+	// before it ended, and flushedSelected is true if it was selected, so the
+	// rest of it is streamed as it is read
+	flushed         bool
+	flushedSelected bool
+
+	// done is true once a line past the until time was streamed, so none of
+	// the following lines can be selected
+	done bool
+}
+
+func newLogLines(opts logLineOptions, fs allocdir.AllocDirFS) *logLines {
+	return &logLines{
+		opts: opts,
+		fs:   fs,
+	}
+}
+
+// open starts processing the log file at path from offset. It returns the
+// output to stream for the last line of the previous file, if the file doesn't
+// carry on with it.
+func (l *logLines) open(path string, offset int64) []byte {
+	l.path = path
+	l.offset = offset
+	l.indexPath = filepath.Join(filepath.Dir(path), logging.TimestampIndexName(filepath.Base(path)))
+	l.index = logging.TimestampIndex{}
+	l.indexed = false
+	l.eventsPath = filepath.Join(filepath.Dir(path), logging.EventIndexName(filepath.Base(path)))
+	l.events = logging.EventIndex{}
+	l.update()
+
+	l.lineStart = false
+	if offset == 0 {
+		l.lineStart = !l.index.Continued
+	} else if r, err := l.fs.ReadAt(path, offset-1); err == nil {
+		var prev [1]byte
+		if _, err := io.ReadFull(r, prev[:]); err == nil {
+			l.lineStart = prev[0] == '\n'
+		}
+		r.Close()
+	}
+
+	// The event a grouped file starts with may carry on with the last one of
+	// the previous file
+	if l.lineStart && !l.grouped {
+		return l.flush()
+	}
+	return nil
+}
+
+// update reads the entries added to the indexes since they were last read. The
+// indexes are best effort, so they are skipped if they can't be read.
+func (l *logLines) update() {
+	if r, err := l.fs.ReadAt(l.indexPath, l.index.Size()); err == nil {
+		l.index.Update(r)
+		l.indexed = true
+		r.Close()
+	}
+	if r, err := l.fs.ReadAt(l.eventsPath, l.events.Size()); err == nil {
+		if l.events.Update(r) == nil {
+			l.grouped = true
+		}
+		r.Close()
+	}
+}
+
+// startsEvent returns true if the line at offset starts an event. Each line is
+// an event of its own unless the lines are grouped.
+func (l *logLines) startsEvent(offset int64) bool {
+	return !l.grouped || l.events.IsStart(offset)
+}
+
+// process returns the output to stream for data read from offset of the log
+// file. It returns an error if a time range is selected but the log file has
+// no timestamp index. The index is written before the output it indexes, so
+// a log file with output but no index was written without timestamps.
+func (l *logLines) process(data []byte, offset int64) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	l.offset = offset + int64(len(data))
+	if !l.index.Covers(l.offset-1) || !l.events.Covers(l.offset-1) {
+		l.update()
+	}
+	if (!l.opts.since.IsZero() || !l.opts.until.IsZero()) && !l.indexed {
+		return nil, fmt.Errorf("log file %q has no timestamps to select lines by time", filepath.Base(l.path))
+	}
+
+	out := make([]byte, 0, len(data)+len(logTimestampFormat)+1)
+	for len(data) > 0 {
+		var ts time.Time
+		start := l.lineStart && l.startsEvent(offset)
+		if start {
+			ts, _ = l.index.Lookup(offset)
+		}
+
+		end := len(data)
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			end = i + 1
+		}
+		segment := data[:end]
+		data = data[end:]
+		offset += int64(end)
+		ended := segment[len(segment)-1] == '\n'
+
+		if !l.opts.selects() {
+			if start {
+				out = l.appendTimestamp(out, ts)
+			}
+			out = append(out, segment...)
+			l.lineStart = ended
+			continue
+		}
+
+		if start {
+			out = l.finish(out)
+			l.pendingTS = ts
+		}
+		switch {
+		case l.flushed:
+			if l.flushedSelected {
+				out = append(out, segment...)
+			}
+		case len(l.pending)+len(segment) >= maxSelectedLineSize:
+			l.pending = append(l.pending, segment...)
+			out = l.flushPartial(out)
+		default:
+			l.pending = append(l.pending, segment...)
+		}
+		if ended && !l.grouped {
+			out = l.finish(out)
+		}
+		l.lineStart = ended
+	}
+	return out, nil
+}
+
+// idle returns the output to stream for the pending event once the end of the
+// log file is reached while following it, if the event ended a line. Otherwise
+// the last event would only be streamed once the next one starts. The lines
+// carrying on with the event later are streamed if it was selected.
+func (l *logLines) idle() []byte {
+	if !l.grouped || len(l.pending) == 0 || l.pending[len(l.pending)-1] != '\n' {
+		return nil
+	}
+	return l.flushPartial(nil)
+}
+
+// flush returns the output to stream for the line or event read so far, once
+// no more of it will be read.
+func (l *logLines) flush() []byte {
+	return l.finish(nil)
+}
+
+// finish appends the pending line or event to out if it is selected, once it
+// ended.
+func (l *logLines) finish(out []byte) []byte {
+	if l.flushed {
+		l.flushed, l.flushedSelected = false, false
+		return out
+	}
+	if len(l.pending) == 0 {
+		return out
+	}
+	return l.appendSelected(out)
+}
+
+// flushPartial appends the pending line or event to out if it is selected
+// before it ended, and records whether it was so the rest of it follows.
+func (l *logLines) flushPartial(out []byte) []byte {
+	n := len(out)
+	out = l.appendSelected(out)
+	l.flushed, l.flushedSelected = true, len(out) > n
+	return out
+}
+
+// appendSelected appends the pending line or event to out if it is selected.
+func (l *logLines) appendSelected(out []byte) []byte {
+	line, ts := l.pending, l.pendingTS
+	l.pending, l.pendingTS = l.pending[:0], time.Time{}
+
+	if !l.opts.since.IsZero() || !l.opts.until.IsZero() {
+		if ts.IsZero() {
+			return out
+		}
+		if !l.opts.since.IsZero() && ts.Before(l.opts.since) {
+			return out
+		}
+		if !l.opts.until.IsZero() && ts.After(l.opts.until) {
+			l.done = true
+			return out
+		}
+	}
+	if l.opts.filter != nil && !l.opts.filter.Match(bytes.TrimSuffix(line, []byte{'\n'})) {
+		return out
+	}
+
+	out = l.appendTimestamp(out, ts)
+	return append(out, line...)
+}
+
+func (l *logLines) appendTimestamp(out []byte, ts time.Time) []byte {
+	if !l.opts.timestamps || ts.IsZero() {
+		return out
+	}
+	out = ts.UTC().AppendFormat(out, logTimestampFormat)
+	return append(out, ' ')
+}
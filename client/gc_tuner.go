@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"math"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+
+	gometrics "github.com/armon/go-metrics"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/hoststats"
+)
+
+const (
+	// gcRelaxedAvailable is the fraction of the node's memory available
+	// above which the agent runs with its default GC settings
+	gcRelaxedAvailable = 0.5
+
+	// gcPressuredAvailable is the fraction of the node's memory available
+	// at or below which the agent collects as aggressively as it may
+	gcPressuredAvailable = 0.1
+
+	// gcMinPercent is the lowest GOGC the tuner sets, since collecting more
+	// often costs CPU the tasks could use instead
+	gcMinPercent = 25
+
+	// gcGrowthShare is the share of the node's available memory the Go
+	// runtime may grow into under pressure before collecting harder
+	gcGrowthShare = 4
+
+	// gcMinLimit is the lowest GOMEMLIMIT the tuner sets, below which the
+	// runtime would spend most of its time collecting
+	gcMinLimit = 128 * 1024 * 1024
+
+	// gcLimitHysteresis is the fraction by which the GOMEMLIMIT must move
+	// before the tuner changes it. The limit is derived from the memory of
+	// the runtime, which the limit itself drives, so following every small
+	// change makes it oscillate.
+	gcLimitHysteresis = 0.1
+)
+
+// gcTuner adjusts the GOGC and GOMEMLIMIT of the agent to the memory pressure
+// of the node. Settings configured with the GOGC and GOMEMLIMIT environment
+// variables are left alone.
+type gcTuner struct {
+	logger hclog.Logger
+
+	tunePercent bool
+	tuneLimit   bool
+
+	// basePercent is the GOGC of the agent when the node isn't under
+	// pressure
+	basePercent int
+
+	percent int
+	limit   int64
+
+	sample []metrics.Sample
+}
+
+func newGCTuner(logger hclog.Logger) *gcTuner {
+	basePercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(basePercent)
+
+	t := &gcTuner{
+		logger:      logger.Named("gc_tuner"),
+		tunePercent: os.Getenv("GOGC") == "" && basePercent > 0,
+		tuneLimit:   os.Getenv("GOMEMLIMIT") == "",
+		basePercent: basePercent,
+		percent:     basePercent,
+		limit:       debug.SetMemoryLimit(-1),
+		sample:      []metrics.Sample{{Name: "/memory/classes/total:bytes"}},
+	}
+	if !t.tunePercent || !t.tuneLimit {
+		t.logger.Info("leaving GC settings configured by the environment alone",
+			"tune_gogc", t.tunePercent, "tune_gomemlimit", t.tuneLimit)
+	}
+	return t
+}
+
+// tune applies the GC settings for the memory of the node.
+func (t *gcTuner) tune(mem *hoststats.MemoryStats, labels []gometrics.Label) {
+	if mem == nil || mem.Total == 0 {
+		return
+	}
+
+	metrics.Read(t.sample)
+	var runtimeMem uint64
+	if t.sample[0].Value.Kind() == metrics.KindUint64 {
+		runtimeMem = t.sample[0].Value.Uint64()
+	}
+
+	percent, limit := gcSettings(t.basePercent, mem.Total, mem.Available, runtimeMem)
+	if t.tunePercent && percent != t.percent {
+		t.logger.Debug("adjusting GOGC to node memory pressure", "gogc", percent,
+			"available", mem.Available, "total", mem.Total)
+		debug.SetGCPercent(percent)
+		t.percent = percent
+	}
+	if t.tuneLimit && limitChanged(t.limit, limit) {
+		debug.SetMemoryLimit(limit)
+		t.limit = limit
+	}
+
+	gometrics.SetGaugeWithLabels([]string{"client", "gc", "gogc"}, float32(t.percent), labels)
+	var limitGauge float32
+	if t.limit != math.MaxInt64 {
+		limitGauge = float32(t.limit)
+	}
+	gometrics.SetGaugeWithLabels([]string{"client", "gc", "memory_limit"}, limitGauge, labels)
+}
+
+// gcSettings returns the GOGC and GOMEMLIMIT of an agent whose default GOGC
+// is basePercent and whose runtime uses runtimeMem bytes, when available of
+// the total memory of the node is available. GOGC is lowered linearly to
+// gcMinPercent as the available memory shrinks, and the memory limit lets the
+// runtime grow into a share of the available memory before collecting harder,
+// but never below gcMinLimit.
+func gcSettings(basePercent int, total, available, runtimeMem uint64) (int, int64) {
+	ratio := float64(available) / float64(total)
+	if ratio >= gcRelaxedAvailable {
+		return basePercent, math.MaxInt64
+	}
+
+	pressure := min((gcRelaxedAvailable-ratio)/(gcRelaxedAvailable-gcPressuredAvailable), 1)
+	percent := basePercent
+	if basePercent > gcMinPercent {
+		percent = basePercent - int(pressure*float64(basePercent-gcMinPercent))
+	}
+
+	limit := max(runtimeMem+available/gcGrowthShare, gcMinLimit)
+	if limit > math.MaxInt64 {
+		return percent, math.MaxInt64
+	}
+	return percent, int64(limit)
+}
+
+// limitChanged returns whether the memory limit should be changed from
+// current to next. Limits within gcLimitHysteresis of the current one are
+// ignored, but setting or lifting the limit always applies.
+func limitChanged(current, next int64) bool {
+	if current == next {
+		return false
+	}
+	if current == math.MaxInt64 || next == math.MaxInt64 {
+		return true
+	}
+	delta := next - current
+	if delta < 0 {
+		delta = -delta
+	}
+	return float64(delta) > float64(current)*gcLimitHysteresis
+}
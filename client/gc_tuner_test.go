@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestGCTuner_gcSettings(t *testing.T) {
+	ci.Parallel(t)
+
+	const mib = 1024 * 1024
+	const total = 1000 * mib
+	for _, tc := range []struct {
+		name       string
+		base       int
+		available  uint64
+		runtime    uint64
+		expPercent int
+		expLimit   int64
+	}{
+		{name: "relaxed", base: 100, available: 600 * mib, runtime: 200 * mib, expPercent: 100, expLimit: math.MaxInt64},
+		{name: "at relaxed threshold", base: 100, available: 500 * mib, runtime: 200 * mib, expPercent: 100, expLimit: math.MaxInt64},
+		{name: "halfway", base: 100, available: 300 * mib, runtime: 200 * mib, expPercent: 63, expLimit: (200 + 75) * mib},
+		{name: "pressured", base: 100, available: 100 * mib, runtime: 200 * mib, expPercent: gcMinPercent, expLimit: (200 + 25) * mib},
+		{name: "beyond pressured", base: 100, available: 20 * mib, runtime: 200 * mib, expPercent: gcMinPercent, expLimit: (200 + 5) * mib},
+		{name: "floor", base: 100, available: 20 * mib, runtime: 100 * mib, expPercent: gcMinPercent, expLimit: gcMinLimit},
+		{name: "low base", base: 20, available: 100 * mib, runtime: 200 * mib, expPercent: 20, expLimit: (200 + 25) * mib},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			percent, limit := gcSettings(tc.base, total, tc.available, tc.runtime)
+			must.Eq(t, tc.expPercent, percent)
+			must.Eq(t, tc.expLimit, limit)
+		})
+	}
+}
+
+func TestGCTuner_limitChanged(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		name          string
+		current, next int64
+		exp           bool
+	}{
+		{name: "same", current: 1000, next: 1000, exp: false},
+		{name: "set", current: math.MaxInt64, next: 1000, exp: true},
+		{name: "lifted", current: 1000, next: math.MaxInt64, exp: true},
+		{name: "small increase", current: 1000, next: 1100, exp: false},
+		{name: "small decrease", current: 1000, next: 900, exp: false},
+		{name: "large increase", current: 1000, next: 1101, exp: true},
+		{name: "large decrease", current: 1000, next: 899, exp: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, limitChanged(tc.current, tc.next))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// DiskIOStats represents the latency and saturation of a block device since
+// the previous sample, as reported by /proc/diskstats on Linux
+type DiskIOStats struct {
+	Device string
+
+	// ReadsPerSecond and WritesPerSecond are the rates of completed reads
+	// and writes
+	ReadsPerSecond  float64
+	WritesPerSecond float64
+
+	// AwaitMillis is the average time an I/O took to complete, including the
+	// time it spent queued
+	AwaitMillis float64
+
+	// UtilizationPercent is the time the device was busy with at least one
+	// I/O. Devices serving requests in parallel, such as SSDs, can be
+	// saturated well after it reaches 100%.
+	UtilizationPercent float64
+
+	// QueueDepth is the average number of I/Os queued or in flight, and
+	// InFlight the number in flight when the sample was taken
+	QueueDepth float64
+	InFlight   uint64
+}
+
+// ignoredDiskPrefixes are the names of virtual block devices reported by the
+// kernel that aren't backed by a disk.
+var ignoredDiskPrefixes = []string{"loop", "ram", "zram"}
+
+// diskIOCollector computes the latency and saturation of each block device
+// from the difference between consecutive readings of its I/O counters.
+type diskIOCollector struct {
+	prev     map[string]disk.IOCountersStat
+	prevTime time.Time
+}
+
+func newDiskIOCollector() *diskIOCollector {
+	return &diskIOCollector{}
+}
+
+// collect returns the stats of every device since the previous call, ordered
+// by device name. The first call only records the counters and returns nil.
+func (d *diskIOCollector) collect(now time.Time) ([]*DiskIOStats, error) {
+	counters, err := disk.IOCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	prev, elapsed := d.prev, now.Sub(d.prevTime)
+	d.prev, d.prevTime = counters, now
+	if prev == nil {
+		return nil, nil
+	}
+
+	var stats []*DiskIOStats
+	for name, cur := range counters {
+		if isIgnoredDisk(name) {
+			continue
+		}
+		p, ok := prev[name]
+		if !ok {
+			continue
+		}
+		if s := diskIODelta(p, cur, elapsed); s != nil {
+			stats = append(stats, s)
+		}
+	}
+	slices.SortFunc(stats, func(a, b *DiskIOStats) int {
+		return strings.Compare(a.Device, b.Device)
+	})
+	return stats, nil
+}
+
+// diskIODelta computes the stats of a device from two readings of its
+// counters taken elapsed apart. It returns nil if the counters went backwards,
+// as they do when a device is replaced by one with the same name.
+func diskIODelta(prev, cur disk.IOCountersStat, elapsed time.Duration) *DiskIOStats {
+	if elapsed <= 0 || cur.ReadCount < prev.ReadCount || cur.WriteCount < prev.WriteCount ||
+		cur.ReadTime < prev.ReadTime || cur.WriteTime < prev.WriteTime ||
+		cur.IoTime < prev.IoTime || cur.WeightedIO < prev.WeightedIO {
+		return nil
+	}
+
+	// The times are counted by the kernel in milliseconds
+	millis := float64(elapsed.Milliseconds())
+	seconds := elapsed.Seconds()
+	reads := cur.ReadCount - prev.ReadCount
+	writes := cur.WriteCount - prev.WriteCount
+
+	s := &DiskIOStats{
+		Device:             cur.Name,
+		ReadsPerSecond:     float64(reads) / seconds,
+		WritesPerSecond:    float64(writes) / seconds,
+		UtilizationPercent: min(float64(cur.IoTime-prev.IoTime)/millis*100, 100),
+		QueueDepth:         float64(cur.WeightedIO-prev.WeightedIO) / millis,
+		InFlight:           cur.IopsInProgress,
+	}
+	if ios := reads + writes; ios > 0 {
+		waited := (cur.ReadTime - prev.ReadTime) + (cur.WriteTime - prev.WriteTime)
+		s.AwaitMillis = float64(waited) / float64(ios)
+	}
+	return s
+}
+
+func isIgnoredDisk(name string) bool {
+	for _, prefix := range ignoredDiskPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shoenig/test/must"
+)
+
+func TestDiskIODelta(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := disk.IOCountersStat{
+		Name:       "sda",
+		ReadCount:  100,
+		WriteCount: 200,
+		ReadTime:   1000,
+		WriteTime:  4000,
+		IoTime:     3000,
+		WeightedIO: 5000,
+	}
+	cur := disk.IOCountersStat{
+		Name:           "sda",
+		ReadCount:      300,
+		WriteCount:     400,
+		ReadTime:       1400,
+		WriteTime:      6000,
+		IoTime:         4000,
+		WeightedIO:     9000,
+		IopsInProgress: 3,
+	}
+
+	must.Eq(t, &DiskIOStats{
+		Device:             "sda",
+		ReadsPerSecond:     100,
+		WritesPerSecond:    100,
+		AwaitMillis:        6,
+		UtilizationPercent: 50,
+		QueueDepth:         2,
+		InFlight:           3,
+	}, diskIODelta(prev, cur, 2*time.Second))
+
+	// Idle devices have no latency
+	must.Eq(t, &DiskIOStats{Device: "sda"}, diskIODelta(prev, prev, time.Second))
+
+	// Counters going backwards are a different device
+	must.Nil(t, diskIODelta(cur, prev, time.Second))
+}
@@ -22,11 +22,14 @@ type HostStats struct {
 	Memory           *MemoryStats
 	CPU              []*CPUStats
 	DiskStats        []*DiskStats
+	DiskIOStats      []*DiskIOStats
 	AllocDirStats    *DiskStats
 	DeviceStats      []*DeviceGroupStats
 	Uptime           uint64
 	Timestamp        int64
 	CPUTicksConsumed float64
+	Power            *PowerStats
+	SoftIRQs         []*SoftIRQStats
 }
 
 // MemoryStats represents stats related to virtual memory usage
@@ -43,6 +46,8 @@ type CPUStats struct {
 	User         float64
 	System       float64
 	Idle         float64
+	Irq          float64
+	SoftIrq      float64
 	TotalPercent float64
 	TotalTicks   float64
 }
@@ -79,6 +84,9 @@ type HostStatsCollector struct {
 	hostStatsLock        sync.RWMutex
 	allocDir             string
 	deviceStatsCollector DeviceStatsCollector
+	energyCollector      *energyCollector
+	softIRQCollector     *softIRQCollector
+	diskIOCollector      *diskIOCollector
 
 	// badParts is a set of partitions whose usage cannot be read; used to
 	// squelch logspam.
@@ -98,6 +106,9 @@ func NewHostStatsCollector(logger hclog.Logger, top *numalib.Topology, allocDir
 		allocDir:             allocDir,
 		badParts:             make(map[string]struct{}),
 		deviceStatsCollector: deviceStatsCollector,
+		energyCollector:      newEnergyCollector(),
+		softIRQCollector:     newSoftIRQCollector(),
+		diskIOCollector:      newDiskIOCollector(),
 	}
 }
 
@@ -111,7 +122,8 @@ func (h *HostStatsCollector) Collect() error {
 // collectLocked collects stats related to resource usage of the host but should
 // be called with the lock held.
 func (h *HostStatsCollector) collectLocked() error {
-	hs := &HostStats{Timestamp: time.Now().UTC().UnixNano()}
+	now := time.Now().UTC()
+	hs := &HostStats{Timestamp: now.UnixNano()}
 
 	// Determine up-time
 	uptime, err := host.Uptime()
@@ -147,6 +159,14 @@ func (h *HostStatsCollector) collectLocked() error {
 	}
 	hs.DiskStats = diskStats
 
+	// Collect the latency and saturation of the block devices. The counters
+	// aren't available on every platform, so failures aren't logged loudly
+	diskIOStats, err := h.diskIOCollector.collect(now)
+	if err != nil {
+		h.logger.Debug("failed to collect disk I/O stats", "error", err)
+	}
+	hs.DiskIOStats = diskIOStats
+
 	// Getting the disk stats for the allocation directory
 	usage, err := disk.Usage(h.allocDir)
 	if err != nil {
@@ -159,6 +179,13 @@ func (h *HostStatsCollector) collectLocked() error {
 	deviceStats := h.collectDeviceGroupStats()
 	hs.DeviceStats = deviceStats
 
+	// Collect energy and temperature stats, where supported by the hardware
+	hs.Power = h.collectPowerStats(now)
+
+	// Collect the rates of software interrupts, to break down the softirq
+	// time of the CPUs
+	hs.SoftIRQs = h.softIRQCollector.collect(now)
+
 	// Update the collected status object.
 	h.hostStats = hs
 
@@ -261,6 +288,11 @@ type HostCpuStatsCalculator struct {
 	prevSystem float64
 	prevBusy   float64
 	prevTotal  float64
+
+	prevIrq     float64
+	prevSoftirq float64
+	irq         float64
+	softirq     float64
 }
 
 // NewHostCpuStatsCalculator returns a HostCpuStatsCalculator
@@ -282,6 +314,8 @@ func (h *HostCpuStatsCalculator) Calculate(times cpu.TimesStat) (idle float64, u
 	user = ((currentUser - h.prevUser) / deltaTotal) * 100
 	system = ((currentSystem - h.prevSystem) / deltaTotal) * 100
 	total = ((currentBusy - h.prevBusy) / deltaTotal) * 100
+	irq := ((times.Irq - h.prevIrq) / deltaTotal) * 100
+	softirq := ((times.Softirq - h.prevSoftirq) / deltaTotal) * 100
 
 	// Protect against any invalid values
 	if math.IsNaN(idle) || math.IsInf(idle, 0) || idle < 0.0 {
@@ -296,15 +330,31 @@ func (h *HostCpuStatsCalculator) Calculate(times cpu.TimesStat) (idle float64, u
 	if math.IsNaN(total) || math.IsInf(total, 0) || total < 0.0 {
 		total = 0.0
 	}
+	if math.IsNaN(irq) || math.IsInf(irq, 0) || irq < 0.0 {
+		irq = 0.0
+	}
+	if math.IsNaN(softirq) || math.IsInf(softirq, 0) || softirq < 0.0 {
+		softirq = 0.0
+	}
 
 	h.prevIdle = currentIdle
 	h.prevUser = currentUser
 	h.prevSystem = currentSystem
 	h.prevTotal = currentTotal
 	h.prevBusy = currentBusy
+	h.prevIrq = times.Irq
+	h.prevSoftirq = times.Softirq
+	h.irq = irq
+	h.softirq = softirq
 	return
 }
 
+// Interrupts returns the percentages of CPU time spent handling hardware and
+// software interrupts, as computed by the last call to Calculate
+func (h *HostCpuStatsCalculator) Interrupts() (irq float64, softirq float64) {
+	return h.irq, h.softirq
+}
+
 func (h *HostStatsCollector) collectCPUStats() (cpus []*CPUStats, totalTicks float64, err error) {
 	ticksConsumed := 0.0
 	cpuStats, err := cpu.Times(true)
@@ -319,6 +369,7 @@ func (h *HostStatsCollector) collectCPUStats() (cpus []*CPUStats, totalTicks flo
 			h.statsCalculator[cpuStat.CPU] = percentCalculator
 		}
 		idle, user, system, total := percentCalculator.Calculate(cpuStat)
+		irq, softirq := percentCalculator.Interrupts()
 		totalCompute := h.top.TotalCompute()
 		ticks := (total / 100.0) * (float64(totalCompute) / float64(len(cpuStats)))
 		cs[idx] = &CPUStats{
@@ -326,6 +377,8 @@ func (h *HostStatsCollector) collectCPUStats() (cpus []*CPUStats, totalTicks flo
 			User:         user,
 			System:       system,
 			Idle:         idle,
+			Irq:          irq,
+			SoftIrq:      softirq,
 			TotalPercent: total,
 			TotalTicks:   ticks,
 		}
@@ -52,3 +52,32 @@ func TestHostCpuStatsCalculator_DecreasedIOWait(t *testing.T) {
 	_, _, _, total := calculator.Calculate(times)
 	must.GreaterEq(t, 0.0, total, must.Sprint("total must never be negative"))
 }
+
+func TestHostCpuStatsCalculator_Interrupts(t *testing.T) {
+	times := cpu.TimesStat{
+		CPU:     "cpu0",
+		User:    100,
+		System:  100,
+		Idle:    700,
+		Irq:     20,
+		Softirq: 80,
+	}
+
+	calculator := NewHostCpuStatsCalculator()
+	calculator.Calculate(times)
+
+	times = cpu.TimesStat{
+		CPU:     "cpu0",
+		User:    150,
+		System:  150,
+		Idle:    750,
+		Irq:     30,
+		Softirq: 120,
+	}
+
+	_, _, _, total := calculator.Calculate(times)
+	irq, softirq := calculator.Interrupts()
+	must.Eq(t, 75.0, total)
+	must.Eq(t, 5.0, irq)
+	must.Eq(t, 20.0, softirq)
+}
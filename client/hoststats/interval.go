@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// adaptiveThreshold is the fraction of the interval a collection cycle
+	// may take before the interval is backed off.
+	adaptiveThreshold = 0.1
+
+	// adaptiveMaxFactor bounds how far the interval is backed off from the
+	// configured interval.
+	adaptiveMaxFactor = 16
+)
+
+// AdaptiveInterval backs off a stats collection interval when collecting
+// takes more than a tenth of it, so an overloaded node doesn't spend its
+// remaining capacity monitoring itself. The interval is doubled each time a
+// cycle is too slow and halved back towards the configured interval once
+// cycles are fast again.
+type AdaptiveInterval struct {
+	base    time.Duration
+	max     time.Duration
+	current time.Duration
+	mu      sync.RWMutex
+}
+
+// NewAdaptiveInterval returns an AdaptiveInterval starting at the configured
+// interval.
+func NewAdaptiveInterval(base time.Duration) *AdaptiveInterval {
+	return &AdaptiveInterval{
+		base:    base,
+		max:     base * adaptiveMaxFactor,
+		current: base,
+	}
+}
+
+// Interval returns the effective collection interval.
+func (a *AdaptiveInterval) Interval() time.Duration {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.current
+}
+
+// Observe records how long a collection cycle took and returns the effective
+// interval for the next cycle, and whether it changed.
+func (a *AdaptiveInterval) Observe(took time.Duration) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prev := a.current
+	limit := time.Duration(float64(a.current) * adaptiveThreshold)
+	switch {
+	case took > limit && a.current < a.max:
+		a.current = min(a.current*2, a.max)
+	case took < limit/4 && a.current > a.base:
+		// Only step down once the cycle would also be well within the
+		// threshold of the shorter interval, to avoid flapping
+		a.current = max(a.current/2, a.base)
+	}
+	return a.current, a.current != prev
+}
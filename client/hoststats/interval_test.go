@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	ci.Parallel(t)
+
+	a := NewAdaptiveInterval(time.Second)
+	must.Eq(t, time.Second, a.Interval())
+
+	// fast cycles keep the configured interval
+	interval, changed := a.Observe(10 * time.Millisecond)
+	must.Eq(t, time.Second, interval)
+	must.False(t, changed)
+
+	// slow cycles back off
+	interval, changed = a.Observe(200 * time.Millisecond)
+	must.Eq(t, 2*time.Second, interval)
+	must.True(t, changed)
+
+	// within the threshold of the backed off interval, but not of the
+	// configured one
+	interval, changed = a.Observe(150 * time.Millisecond)
+	must.Eq(t, 2*time.Second, interval)
+	must.False(t, changed)
+
+	// backing off is bounded
+	for i := 0; i < 10; i++ {
+		a.Observe(time.Minute)
+	}
+	must.Eq(t, 16*time.Second, a.Interval())
+
+	// fast cycles step back down to the configured interval
+	for i := 0; i < 10; i++ {
+		a.Observe(time.Millisecond)
+	}
+	must.Eq(t, time.Second, a.Interval())
+}
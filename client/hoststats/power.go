@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// PowerStats represents stats related to the power consumption and
+// temperature of the host. It is only populated on hardware that exposes
+// energy counters or CPU temperature sensors.
+type PowerStats struct {
+	// Zones is the power drawn by each energy zone (e.g. RAPL package or
+	// dram domains) since the previous sample.
+	Zones []*PowerZoneStats
+
+	// Watts is the combined power of the top-level energy zones.
+	Watts float64
+
+	// Temperatures contains the readings of the CPU temperature sensors.
+	Temperatures []*TemperatureStats
+}
+
+// PowerZoneStats represents the power drawn by a single energy zone
+type PowerZoneStats struct {
+	Name  string
+	Watts float64
+}
+
+// TemperatureStats represents the reading of a temperature sensor
+type TemperatureStats struct {
+	Sensor  string
+	Celsius float64
+}
+
+// cpuSensorPrefixes are the hwmon sensor names reporting CPU temperatures.
+var cpuSensorPrefixes = []string{
+	"coretemp",
+	"k10temp",
+	"zenpower",
+	"cpu_thermal",
+	"x86_pkg_temp",
+}
+
+func (h *HostStatsCollector) collectPowerStats(now time.Time) *PowerStats {
+	zones, watts := h.energyCollector.collect(now)
+	temps := collectTemperatureStats()
+	if len(zones) == 0 && len(temps) == 0 {
+		return nil
+	}
+
+	return &PowerStats{
+		Zones:        zones,
+		Watts:        watts,
+		Temperatures: temps,
+	}
+}
+
+func collectTemperatureStats() []*TemperatureStats {
+	// gopsutil returns the sensors it could read alongside an error for the
+	// ones it could not, so the error is only fatal without any readings
+	sensors, _ := host.SensorsTemperatures()
+
+	var temps []*TemperatureStats
+	for _, sensor := range sensors {
+		if !isCPUSensor(sensor.SensorKey) {
+			continue
+		}
+		temps = append(temps, &TemperatureStats{
+			Sensor:  sensor.SensorKey,
+			Celsius: sensor.Temperature,
+		})
+	}
+	return temps
+}
+
+func isCPUSensor(key string) bool {
+	for _, prefix := range cpuSensorPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
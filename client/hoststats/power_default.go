@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hoststats
+
+import "time"
+
+// energyCollector is a no-op on platforms without RAPL energy counters
+type energyCollector struct{}
+
+func newEnergyCollector() *energyCollector {
+	return &energyCollector{}
+}
+
+func (e *energyCollector) collect(time.Time) ([]*PowerZoneStats, float64) {
+	return nil, 0
+}
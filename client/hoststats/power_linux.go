@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hoststats
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// powercapRoot is where the kernel exposes the RAPL energy counters of
+	// Intel and AMD processors.
+	powercapRoot = "/sys/class/powercap"
+
+	// raplZonePrefix is the name prefix of the RAPL powercap zones; top-level
+	// zones are named intel-rapl:N and their subzones intel-rapl:N:M.
+	raplZonePrefix = "intel-rapl:"
+)
+
+type energySample struct {
+	microJoules uint64
+	time        time.Time
+}
+
+// energyCollector computes the power drawn by each RAPL zone from the
+// difference between consecutive readings of its energy counter.
+type energyCollector struct {
+	root string
+	prev map[string]energySample
+}
+
+func newEnergyCollector() *energyCollector {
+	return &energyCollector{
+		root: powercapRoot,
+		prev: make(map[string]energySample),
+	}
+}
+
+// collect returns the power drawn by every readable zone and the combined
+// power of the top-level zones. The platform (psys) zone already includes the
+// package zones and is excluded from the total.
+func (e *energyCollector) collect(now time.Time) ([]*PowerZoneStats, float64) {
+	// Glob sorts its results so parent zones come before their subzones
+	paths, _ := filepath.Glob(filepath.Join(e.root, raplZonePrefix+"*"))
+
+	var (
+		zones []*PowerZoneStats
+		total float64
+		names = make(map[string]string)
+	)
+	for _, path := range paths {
+		id := strings.TrimPrefix(filepath.Base(path), raplZonePrefix)
+		name, err := readZoneString(filepath.Join(path, "name"))
+		if err != nil {
+			continue
+		}
+		energy, err := readZoneUint(filepath.Join(path, "energy_uj"))
+		if err != nil {
+			continue
+		}
+
+		parent, _, isSubzone := strings.Cut(id, ":")
+		if isSubzone {
+			name = names[parent] + "/" + name
+		} else {
+			names[id] = name
+		}
+
+		zone := &PowerZoneStats{Name: name}
+		if prev, ok := e.prev[path]; ok {
+			delta := energy - prev.microJoules
+			if energy < prev.microJoules {
+				// the counter wrapped around
+				maxRange, err := readZoneUint(filepath.Join(path, "max_energy_range_uj"))
+				if err != nil || maxRange < prev.microJoules {
+					delta = 0
+				} else {
+					delta = maxRange - prev.microJoules + energy
+				}
+			}
+			if elapsed := now.Sub(prev.time).Seconds(); elapsed > 0 {
+				zone.Watts = float64(delta) / 1e6 / elapsed
+			}
+		}
+		e.prev[path] = energySample{microJoules: energy, time: now}
+
+		if !isSubzone && name != "psys" {
+			total += zone.Watts
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, total
+}
+
+func readZoneString(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func readZoneUint(path string) (uint64, error) {
+	s, err := readZoneString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hoststats
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func writeRAPLZone(t *testing.T, root, zone, name string, energy, maxRange uint64) {
+	dir := filepath.Join(root, zone)
+	must.NoError(t, os.MkdirAll(dir, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "name"), []byte(name+"\n"), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "energy_uj"), []byte(strconv.FormatUint(energy, 10)+"\n"), 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "max_energy_range_uj"), []byte(strconv.FormatUint(maxRange, 10)+"\n"), 0o644))
+}
+
+func TestEnergyCollector_collect(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	e := newEnergyCollector()
+	e.root = root
+
+	writeRAPLZone(t, root, "intel-rapl:0", "package-0", 1_000_000, 10_000_000)
+	writeRAPLZone(t, root, "intel-rapl:0:0", "dram", 500_000, 10_000_000)
+	writeRAPLZone(t, root, "intel-rapl:1", "psys", 2_000_000, 10_000_000)
+
+	// the first sample has nothing to compare against
+	start := time.Now()
+	zones, watts := e.collect(start)
+	must.Len(t, 3, zones)
+	must.Eq(t, 0, watts)
+
+	// package-0 draws 20W, its dram subzone 5W and the counter of the
+	// platform zone wraps around
+	writeRAPLZone(t, root, "intel-rapl:0", "package-0", 41_000_000, 100_000_000)
+	writeRAPLZone(t, root, "intel-rapl:0:0", "dram", 10_500_000, 100_000_000)
+	writeRAPLZone(t, root, "intel-rapl:1", "psys", 1_000_000, 10_000_000)
+
+	zones, watts = e.collect(start.Add(2 * time.Second))
+	must.Eq(t, []*PowerZoneStats{
+		{Name: "package-0", Watts: 20},
+		{Name: "package-0/dram", Watts: 5},
+		{Name: "psys", Watts: 4.5},
+	}, zones)
+	must.Eq(t, 20, watts)
+}
+
+func TestEnergyCollector_unsupported(t *testing.T) {
+	ci.Parallel(t)
+
+	e := newEnergyCollector()
+	e.root = t.TempDir()
+
+	zones, watts := e.collect(time.Now())
+	must.SliceEmpty(t, zones)
+	must.Eq(t, 0, watts)
+}
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+// SoftIRQStats represents the rate at which the host handles software
+// interrupts of a single type, such as net_rx for received packets or block
+// for completed block I/O. The kernel doesn't account the CPU time of each
+// type, so together with the SoftIrq time of the CPUs the rates show which
+// kernel work the softirq time is spent on.
+type SoftIRQStats struct {
+	Type string
+
+	// Rate is the number of interrupts handled per second across all CPUs
+	// since the previous sample
+	Rate float64
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hoststats
+
+import "time"
+
+// softIRQCollector is a no-op on platforms without /proc/softirqs
+type softIRQCollector struct{}
+
+func newSoftIRQCollector() *softIRQCollector {
+	return &softIRQCollector{}
+}
+
+func (s *softIRQCollector) collect(time.Time) []*SoftIRQStats {
+	return nil
+}
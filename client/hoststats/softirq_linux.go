@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hoststats
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// procSoftIRQs is where the kernel counts the software interrupts each CPU
+// has handled since boot, by type.
+const procSoftIRQs = "/proc/softirqs"
+
+// softIRQCollector computes the rate of each type of software interrupt from
+// the difference between consecutive readings of /proc/softirqs.
+type softIRQCollector struct {
+	path     string
+	prev     map[string]uint64
+	prevTime time.Time
+}
+
+func newSoftIRQCollector() *softIRQCollector {
+	return &softIRQCollector{path: procSoftIRQs}
+}
+
+// collect returns the rate of every type of software interrupt, in the order
+// the kernel lists them. The rates of the first sample are zero.
+func (s *softIRQCollector) collect(now time.Time) []*SoftIRQStats {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil
+	}
+	types, counts := parseSoftIRQs(string(b))
+
+	elapsed := now.Sub(s.prevTime).Seconds()
+	stats := make([]*SoftIRQStats, 0, len(types))
+	for _, typ := range types {
+		stat := &SoftIRQStats{Type: typ}
+		if prev, ok := s.prev[typ]; ok && counts[typ] >= prev && elapsed > 0 {
+			stat.Rate = float64(counts[typ]-prev) / elapsed
+		}
+		stats = append(stats, stat)
+	}
+	s.prev, s.prevTime = counts, now
+
+	return stats
+}
+
+// parseSoftIRQs returns the lowercased types listed in the content of
+// /proc/softirqs and the number of interrupts of each type summed across all
+// CPUs. The header line naming the CPUs is skipped.
+func parseSoftIRQs(content string) ([]string, map[string]uint64) {
+	var types []string
+	counts := make(map[string]uint64)
+	for _, line := range strings.Split(content, "\n") {
+		name, values, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		typ := strings.ToLower(strings.TrimSpace(name))
+
+		var total uint64
+		for _, field := range strings.Fields(values) {
+			n, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				continue
+			}
+			total += n
+		}
+		types = append(types, typ)
+		counts[typ] = total
+	}
+	return types, counts
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hoststats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSoftIRQCollector_collect(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "softirqs")
+	s := newSoftIRQCollector()
+	s.path = path
+
+	must.NoError(t, os.WriteFile(path, []byte(`                    CPU0       CPU1
+          HI:          1          0
+      NET_RX:       1000       3000
+       BLOCK:        200        100
+`), 0o644))
+
+	// the first sample has nothing to compare against
+	start := time.Now()
+	must.Eq(t, []*SoftIRQStats{
+		{Type: "hi"},
+		{Type: "net_rx"},
+		{Type: "block"},
+	}, s.collect(start))
+
+	must.NoError(t, os.WriteFile(path, []byte(`                    CPU0       CPU1
+          HI:          1          0
+      NET_RX:       1500       4500
+       BLOCK:        300        100
+`), 0o644))
+
+	must.Eq(t, []*SoftIRQStats{
+		{Type: "hi", Rate: 0},
+		{Type: "net_rx", Rate: 1000},
+		{Type: "block", Rate: 50},
+	}, s.collect(start.Add(2*time.Second)))
+}
+
+func TestSoftIRQCollector_missing(t *testing.T) {
+	ci.Parallel(t)
+
+	s := newSoftIRQCollector()
+	s.path = filepath.Join(t.TempDir(), "softirqs")
+	must.Nil(t, s.collect(time.Now()))
+}
@@ -4,9 +4,12 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/device"
 )
@@ -31,6 +34,20 @@ type DeviceStatsReporter interface {
 	LatestDeviceResourceStats([]*structs.AllocatedDeviceResource) []*device.DeviceGroupStats
 }
 
+// TaskStatsSink receives every resource usage sample collected for a task
+type TaskStatsSink interface {
+	EmitTaskStats(alloc *structs.Allocation, task string, usage *cstructs.TaskResourceUsage)
+
+	// Enabled returns whether any sink is consuming samples
+	Enabled() bool
+}
+
+// StatsIntervalReporter returns the effective stats collection interval,
+// which may be backed off from the configured interval on a loaded node
+type StatsIntervalReporter interface {
+	StatsInterval() time.Duration
+}
+
 // EnvReplacer is an interface which can interpolate environment variables and
 // is usually satisfied by taskenv.TaskEnv.
 type EnvReplacer interface {
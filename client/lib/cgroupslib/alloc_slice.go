@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// AllocSliceCG2 returns the path of the alloc-level cgroup the tasks of an
+// alloc with shared resources run under, in the share partition.
+func AllocSliceCG2(allocID string) string {
+	return filepath.Join(root, NomadCgroupParent, SharePartition(), allocID+".slice")
+}
+
+// allocSlices caches whether allocs have an alloc-level cgroup, since the path
+// of the cgroup of a task is looked up whenever the cgroup is used.
+var allocSlices sync.Map // alloc ID -> bool
+
+// allocCPUPeriod is the period in microseconds of the CPU bandwidth of
+// alloc-level cgroups
+const allocCPUPeriod = 100_000
+
+// CreateAllocSliceCG2 creates the alloc-level cgroup of an alloc, with a
+// ceiling of memoryMax bytes of memory, or none if negative, the CPU weight of
+// cpuShares, and a CPU bandwidth of cpuCores cores, or none if not positive.
+// The cgroups of the tasks of the alloc are created under it from then on.
+func CreateAllocSliceCG2(allocID string, memoryMax, cpuShares int64, cpuCores float64) error {
+	dir := AllocSliceCG2(allocID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create alloc cgroup: %w", err)
+	}
+	allocSlices.Store(allocID, true)
+	ed := OpenPath(dir)
+
+	available, err := ed.Read("cgroup.controllers")
+	if err != nil {
+		return fmt.Errorf("failed to read controllers of alloc cgroup: %w", err)
+	}
+	if err := ed.Write("cgroup.subtree_control", activationCG2(strings.Fields(available))); err != nil {
+		return fmt.Errorf("failed to set subtree control on alloc cgroup: %w", err)
+	}
+
+	if Enforces("memory") {
+		limit := "max"
+		if memoryMax >= 0 {
+			limit = strconv.FormatInt(memoryMax, 10)
+		}
+		if err := ed.Write("memory.max", limit); err != nil {
+			return fmt.Errorf("failed to set memory ceiling of alloc cgroup: %w", err)
+		}
+	}
+
+	if Enforces("cpu") {
+		weight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+		if err := ed.Write("cpu.weight", strconv.FormatUint(weight, 10)); err != nil {
+			return fmt.Errorf("failed to set cpu weight of alloc cgroup: %w", err)
+		}
+		if err := ed.Write("cpu.max", cpuMaxCG2(cpuCores)); err != nil {
+			return fmt.Errorf("failed to set cpu cap of alloc cgroup: %w", err)
+		}
+	}
+	return nil
+}
+
+// cpuMaxCG2 returns the content of cpu.max for a CPU bandwidth of cores
+// cores, or none if not positive.
+func cpuMaxCG2(cores float64) string {
+	if cores <= 0 {
+		return fmt.Sprintf("max %d", allocCPUPeriod)
+	}
+	quota := max(int64(cores*allocCPUPeriod), 1000)
+	return fmt.Sprintf("%d %d", quota, allocCPUPeriod)
+}
+
+// RemoveAllocSliceCG2 removes the alloc-level cgroup of an alloc, which fails
+// while the cgroups of its tasks still exist, and forgets whether the alloc
+// has one.
+func RemoveAllocSliceCG2(allocID string) error {
+	err := os.Remove(AllocSliceCG2(allocID))
+	if err == nil || errors.Is(err, fs.ErrNotExist) {
+		allocSlices.Delete(allocID)
+		return nil
+	}
+	return err
+}
+
+// allocSliceExists returns whether the alloc has an alloc-level cgroup its
+// tasks are created under.
+func allocSliceExists(allocID string) bool {
+	if exists, ok := allocSlices.Load(allocID); ok {
+		return exists.(bool)
+	}
+	_, err := os.Stat(AllocSliceCG2(allocID))
+	exists := err == nil
+	allocSlices.Store(allocID, exists)
+	return exists
+}
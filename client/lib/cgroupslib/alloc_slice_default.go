@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package cgroupslib
+
+// AllocSliceCG2 returns empty string on non-Linux systems
+func AllocSliceCG2(string) string {
+	return ""
+}
+
+// CreateAllocSliceCG2 does nothing on non-Linux systems
+func CreateAllocSliceCG2(string, int64, int64, float64) error {
+	return nil
+}
+
+// RemoveAllocSliceCG2 does nothing on non-Linux systems
+func RemoveAllocSliceCG2(string) error {
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocSliceCG2(t *testing.T) {
+	if GetMode() != CG2 {
+		t.Skip("requires cgroups v2")
+	}
+
+	allocID := "0dc9b99a-5bd2-4ab1-bd36-1f4a6b9a8a7d"
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/share.slice/"+allocID+".slice", AllocSliceCG2(allocID))
+
+	// Tasks of allocs without an alloc-level cgroup are in the partition
+	must.False(t, allocSliceExists(allocID))
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/share.slice/"+allocID+".web.scope", pathCG2(allocID, "web", false))
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/reserve.slice/"+allocID+".web.scope", pathCG2(allocID, "web", true))
+
+	// Whether an alloc has an alloc-level cgroup is cached until removed
+	allocSlices.Store(allocID, true)
+	must.Eq(t, "/sys/fs/cgroup/nomad.slice/share.slice/"+allocID+".slice/"+allocID+".web.scope", pathCG2(allocID, "web", false))
+	must.NoError(t, RemoveAllocSliceCG2(allocID))
+	must.False(t, allocSliceExists(allocID))
+}
+
+func TestAllocSlice_cpuMaxCG2(t *testing.T) {
+	must.Eq(t, "max 100000", cpuMaxCG2(0))
+	must.Eq(t, "150000 100000", cpuMaxCG2(1.5))
+
+	// The kernel requires a quota of at least 1ms
+	must.Eq(t, "1000 100000", cpuMaxCG2(0.001))
+}
@@ -63,6 +63,10 @@ type Interface interface {
 	//
 	// https://www.kernel.org/doc/html/latest/admin-guide/cgroup-v1/cgroups.html
 	PIDs() (*set.Set[int], error)
+
+	// ID returns the inode number of the cgroup directory, which is the ID the
+	// kernel uses to identify the cgroup (e.g. bpf_get_current_cgroup_id).
+	ID() (uint64, error)
 }
 
 type editor struct {
@@ -83,6 +87,14 @@ func (e *editor) PIDs() (*set.Set[int], error) {
 	return getPIDs(path)
 }
 
+func (e *editor) ID() (uint64, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(e.dpath, &st); err != nil {
+		return 0, err
+	}
+	return st.Ino, nil
+}
+
 func (e *editor) Write(filename, content string) error {
 	path := filepath.Join(e.dpath, filename)
 	return os.WriteFile(path, []byte(content), 0644)
@@ -210,11 +222,18 @@ func (l *lifeCG1) paths() []string {
 	ifaces := []string{"freezer", "cpu", "memory"}
 	paths := make([]string, 0, len(ifaces)+1)
 	for _, iface := range ifaces {
+		if iface != "freezer" && !Enforces(iface) {
+			continue
+		}
 		paths = append(paths, filepath.Join(
 			root, iface, NomadCgroupParent, scope,
 		))
 	}
 
+	if !Enforces("cpuset") {
+		return paths
+	}
+
 	switch partition := GetPartitionFromBool(l.reservedCores); partition {
 	case "reserve":
 		paths = append(paths, filepath.Join(root, "cpuset", NomadCgroupParent, partition, scope))
@@ -273,7 +292,12 @@ func scopeCG2(allocID, task string) string {
 	return fmt.Sprintf("%s.%s.scope", allocID, task)
 }
 
+// pathCG2 returns the path of the cgroup of a task, which is under the
+// alloc-level cgroup of the alloc if it has one.
 func pathCG2(allocID, task string, cores bool) string {
+	if !cores && allocSliceExists(allocID) {
+		return filepath.Join(AllocSliceCG2(allocID), scopeCG2(allocID, task))
+	}
 	partition := GetPartitionFromBool(cores)
 	return filepath.Join(root, NomadCgroupParent, partition, scopeCG2(allocID, task))
 }
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-set/v3"
+)
+
+var (
+	enforcementLock sync.Mutex
+	detected        bool
+	hybrid          bool
+	enforcement     map[string]Enforcement
+)
+
+// GetEnforcement returns whether the limits of each of the Controllers are
+// enforced on this node. On cgroups v1 a controller is enforced if it is bound
+// to a v1 hierarchy, even in hybrid mode where systemd also mounts the v2
+// unified hierarchy. On cgroups v2 a controller is enforced if it is enabled
+// for the Nomad parent cgroup, which clients with delegated cgroups may not
+// control. It returns nil if cgroups are not enabled.
+func GetEnforcement() map[string]Enforcement {
+	enforcementLock.Lock()
+	defer enforcementLock.Unlock()
+
+	detectEnforcement()
+	return enforcement
+}
+
+// Enforces returns whether the limits of the given controller are enforced.
+func Enforces(controller string) bool {
+	return GetEnforcement()[controller] == Enforced
+}
+
+// IsHybrid returns whether the node uses cgroups v1 with the cgroups v2
+// unified hierarchy mounted alongside, such as in the hybrid mode of systemd.
+func IsHybrid() bool {
+	enforcementLock.Lock()
+	defer enforcementLock.Unlock()
+
+	detectEnforcement()
+	return hybrid
+}
+
+// detectEnforcement sets the enforcement of the controllers unless it was
+// detected already. It must be called with the enforcementLock held.
+func detectEnforcement() {
+	if detected {
+		return
+	}
+
+	switch GetMode() {
+	case CG1:
+		f, err := os.Open("/proc/self/mountinfo")
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		var bound *set.Set[string]
+		bound, hybrid = scanV1(f)
+		enforcement = enforcementOf(bound)
+		detected = true
+	case CG2:
+		content, err := ReadNomadCG2("cgroup.controllers")
+		if err == nil {
+			enforcement = enforcementOf(set.From(strings.Fields(content)))
+			detected = true
+			return
+		}
+
+		// the nomad parent cgroup is created when the client starts, which
+		// may be after its fingerprint, and gets the controllers its own
+		// parent enables for its children. Those aren't cached, so they are
+		// read again until the nomad parent cgroup exists.
+		content, err = readRootCG2(filepath.Join(filepath.Dir(NomadCgroupParent), "cgroup.subtree_control"))
+		if err != nil {
+			return
+		}
+		enforcement = enforcementOf(set.From(strings.Fields(content)))
+	}
+}
+
+// scanV1 returns the controllers bound to a cgroups v1 hierarchy according to
+// the given mountinfo, and whether the cgroups v2 unified hierarchy is mounted
+// alongside them.
+func scanV1(in io.Reader) (*set.Set[string], bool) {
+	bound := set.New[string](len(Controllers))
+	unified := false
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		// the optional fields of a mount end with a "-", followed by the
+		// filesystem type, the source, and the super block options
+		mount, super, ok := strings.Cut(scanner.Text(), " - ")
+		if !ok {
+			continue
+		}
+		mountFields, superFields := strings.Fields(mount), strings.Fields(super)
+		if len(mountFields) < 5 || len(superFields) < 3 {
+			continue
+		}
+		if filepath.Dir(mountFields[4]) != root {
+			continue
+		}
+
+		switch superFields[0] {
+		case "cgroup":
+			// the options include the controllers, e.g. "rw,cpu,cpuacct"
+			bound.InsertSlice(strings.Split(superFields[2], ","))
+		case "cgroup2":
+			unified = true
+		}
+	}
+	return bound, unified
+}
+
+func enforcementOf(available *set.Set[string]) map[string]Enforcement {
+	result := make(map[string]Enforcement, len(Controllers))
+	for _, controller := range Controllers {
+		if available.Contains(controller) {
+			result[controller] = Enforced
+		} else {
+			result[controller] = Accounting
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/shoenig/test/must"
+)
+
+const (
+	hybridCG1 = `
+34 25 0:28 / /sys/fs/cgroup ro,nosuid,nodev,noexec shared:9 - tmpfs tmpfs ro,mode=755
+35 34 0:29 / /sys/fs/cgroup/unified rw,nosuid,nodev,noexec,relatime shared:10 - cgroup2 cgroup2 rw,nsdelegate
+36 34 0:30 / /sys/fs/cgroup/systemd rw,nosuid,nodev,noexec,relatime shared:11 - cgroup cgroup rw,xattr,name=systemd
+38 34 0:32 / /sys/fs/cgroup/cpu,cpuacct rw,nosuid,nodev,noexec,relatime shared:14 - cgroup cgroup rw,cpu,cpuacct
+39 34 0:33 / /sys/fs/cgroup/freezer rw,nosuid,nodev,noexec,relatime shared:15 - cgroup cgroup rw,freezer
+40 34 0:34 / /sys/fs/cgroup/cpuset rw,nosuid,nodev,noexec,relatime shared:16 - cgroup cgroup rw,cpuset
+`
+
+	plainCG1 = `
+34 25 0:28 / /sys/fs/cgroup ro,nosuid,nodev,noexec shared:9 - tmpfs tmpfs ro,mode=755
+38 34 0:32 / /sys/fs/cgroup/cpu,cpuacct rw,nosuid,nodev,noexec,relatime shared:14 - cgroup cgroup rw,cpu,cpuacct
+39 34 0:33 / /sys/fs/cgroup/memory rw,nosuid,nodev,noexec,relatime shared:15 - cgroup cgroup rw,memory
+40 34 0:34 / /sys/fs/cgroup/cpuset rw,nosuid,nodev,noexec,relatime shared:16 - cgroup cgroup rw,cpuset
+`
+)
+
+func Test_scanV1(t *testing.T) {
+	bound, unified := scanV1(strings.NewReader(hybridCG1))
+	must.True(t, unified)
+	must.Eq(t, map[string]Enforcement{
+		"cpu":    Enforced,
+		"cpuset": Enforced,
+		"memory": Accounting,
+	}, enforcementOf(bound))
+
+	bound, unified = scanV1(strings.NewReader(plainCG1))
+	must.False(t, unified)
+	must.Eq(t, map[string]Enforcement{
+		"cpu":    Enforced,
+		"cpuset": Enforced,
+		"memory": Enforced,
+	}, enforcementOf(bound))
+
+	// only the v1 hierarchies and unified hierarchy under the cgroup root count
+	_, unified = scanV1(strings.NewReader(cg2))
+	must.False(t, unified)
+}
+
+func Test_enforcementOf(t *testing.T) {
+	// e.g. the controllers a delegated nomad.slice may have enabled
+	result := enforcementOf(set.From([]string{"cpu", "pids"}))
+	must.Eq(t, map[string]Enforcement{
+		"cpu":    Enforced,
+		"cpuset": Accounting,
+		"memory": Accounting,
+	}, result)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
@@ -41,12 +42,21 @@ func Init(log hclog.Logger, cores string) error {
 		// for each cgroup controller we are going to use
 		controllers := []string{"freezer", "memory", "cpu", "cpuset"}
 		for _, ctrl := range controllers {
+			// the fingerprint warns about controllers the node doesn't mount
+			if ctrl != "freezer" && !Enforces(ctrl) {
+				continue
+			}
 			p := filepath.Join(root, ctrl, NomadCgroupParent)
 			if err := os.MkdirAll(p, 0755); err != nil {
 				return fmt.Errorf("failed to create nomad cgroup %s: %w", ctrl, err)
 			}
 		}
 
+		// without the cpuset controller there are no partitions to configure
+		if !Enforces("cpuset") {
+			return nil
+		}
+
 		// determine the memset that will be set on the cgroup for each task
 		//
 		// nominally this will be all available but we have to read the root
@@ -124,8 +134,8 @@ func Init(log hclog.Logger, cores string) error {
 		log.Debug("nomad cpuset partitions initialized", "cores", cores)
 
 	case CG2:
-		// the cgroup controllers we need to activate at the root and on the nomad slice
-		const activation = "+cpuset +cpu +io +memory +pids"
+		// the cgroup controllers we need to activate at the root
+		const rootActivation = "+cpuset +cpu +io +memory +pids"
 
 		// the name of the cgroup subtree interface file
 		const subtreeFile = "cgroup.subtree_control"
@@ -136,8 +146,8 @@ func Init(log hclog.Logger, cores string) error {
 		// clients with delegated cgroups typically won't be able to write to
 		// the subtree file, but that's ok so long as the required controllers
 		// are activated
-		if !functionalCgroups2(subtreeFile) {
-			if err := writeCG(activation, subtreeFile); err != nil {
+		if os.Geteuid() == 0 && !functionalCgroups2(subtreeFile) {
+			if err := writeCG(rootActivation, subtreeFile); err != nil {
 				return fmt.Errorf("failed to create nomad cgroup: %w", err)
 			}
 		}
@@ -150,12 +160,25 @@ func Init(log hclog.Logger, cores string) error {
 			return fmt.Errorf("failed to create nomad cgroup: %w", err)
 		}
 
+		// activate the controllers available to nomad.slice, which is all of
+		// them unless the client runs in a delegated subtree
+		available, err := ReadNomadCG2("cgroup.controllers")
+		if err != nil {
+			return fmt.Errorf("failed to read controllers of nomad cgroup: %w", err)
+		}
+		activation := activationCG2(strings.Fields(available))
+		if activation != rootActivation {
+			log.Warn("not all cgroup controllers are delegated to the nomad cgroup", "parent", NomadCgroupParent, "controllers", available)
+		}
+
 		if err := writeCG(activation, NomadCgroupParent, subtreeFile); err != nil {
 			return fmt.Errorf("failed to set subtree control on nomad cgroup: %w", err)
 		}
 
-		if err := writeCG(cores, NomadCgroupParent, cpusetFile); err != nil {
-			return fmt.Errorf("failed to write root partition cpuset: %w", err)
+		if Enforces("cpuset") {
+			if err := writeCG(cores, NomadCgroupParent, cpusetFile); err != nil {
+				return fmt.Errorf("failed to write root partition cpuset: %w", err)
+			}
 		}
 
 		log.Debug("top level partition root nomad.slice cgroup initialized")
@@ -192,6 +215,18 @@ func Init(log hclog.Logger, cores string) error {
 	return nil
 }
 
+// activationCG2 returns the content of cgroup.subtree_control which activates
+// the required controllers that are among the available ones.
+func activationCG2(available []string) string {
+	var activation []string
+	for _, controller := range requiredCgroup2Controllers {
+		if slices.Contains(available, controller) {
+			activation = append(activation, "+"+controller)
+		}
+	}
+	return strings.Join(activation, " ")
+}
+
 // detectMemsCG1 will determine the cpuset.mems value to use for
 // Nomad managed cgroups.
 //
@@ -288,7 +323,7 @@ func LinuxResourcesPath(allocID, task string, reserveCores bool) string {
 	case mode == CG1 && !reserveCores:
 		return filepath.Join(root, "cpuset", NomadCgroupParent, partition)
 	default:
-		return filepath.Join(root, NomadCgroupParent, partition, scopeCG2(allocID, task))
+		return pathCG2(allocID, task, reserveCores)
 	}
 }
 
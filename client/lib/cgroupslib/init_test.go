@@ -43,3 +43,12 @@ func TestCustomPathCG2(t *testing.T) {
 		must.Eq(t, result, exp)
 	})
 }
+
+func Test_activationCG2(t *testing.T) {
+	all := []string{"cpuset", "cpu", "io", "memory", "hugetlb", "pids", "rdma"}
+	must.Eq(t, "+cpuset +cpu +io +memory +pids", activationCG2(all))
+
+	// systemd delegates these to user managers by default
+	delegated := []string{"cpu", "memory", "pids"}
+	must.Eq(t, "+cpu +memory +pids", activationCG2(delegated))
+}
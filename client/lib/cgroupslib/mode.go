@@ -12,3 +12,18 @@ const (
 	CG1
 	CG2
 )
+
+// Enforcement indicates whether the limits Nomad sets on a resource of tasks
+// are enforced by a cgroup controller, or whether the usage of the resource is
+// only accounted for because the controller is not available in the cgroup
+// hierarchy Nomad uses.
+type Enforcement string
+
+const (
+	Enforced   Enforcement = "enforced"
+	Accounting Enforcement = "accounting"
+)
+
+// Controllers are the cgroup controllers with which Nomad limits the
+// resources of tasks.
+var Controllers = []string{"cpu", "cpuset", "memory"}
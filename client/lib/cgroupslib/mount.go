@@ -7,6 +7,7 @@ package cgroupslib
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -43,12 +44,7 @@ func detect() Mode {
 		uid := os.Geteuid()
 		if uid > 0 {
 			// allow for cgroup delegation if we own the slice
-			cgPath := filepathCG("nomad.slice")
-			fi, err := os.Stat(cgPath)
-			if err != nil {
-				return OFF
-			}
-			if uid != int(fi.Sys().(*syscall.Stat_t).Uid) {
+			if _, ok := delegatedParent(uid); !ok {
 				return OFF
 			}
 		}
@@ -57,9 +53,34 @@ func detect() Mode {
 	return mode
 }
 
-func functionalCgroups2(controllersFile string) bool {
-	requiredCgroup2Controllers := []string{"cpuset", "cpu", "io", "memory", "pids"}
+// delegatedParent returns the parent cgroup, relative to the cgroup root, of
+// the tasks of a client running as the given unprivileged user. That is the
+// nomad.slice cgroup if the user owns it, or else a nomad.slice cgroup under
+// the user's systemd user manager, whose subtree systemd delegates to the user.
+func delegatedParent(uid int) (string, bool) {
+	if ownsCG2(uid, "nomad.slice") {
+		return "nomad.slice", true
+	}
+	manager := fmt.Sprintf("user.slice/user-%d.slice/user@%d.service", uid, uid)
+	if ownsCG2(uid, manager) {
+		return filepath.Join(manager, "nomad.slice"), true
+	}
+	return "", false
+}
 
+func ownsCG2(uid int, cgroup string) bool {
+	fi, err := os.Stat(filepathCG(cgroup))
+	if err != nil {
+		return false
+	}
+	return uid == int(fi.Sys().(*syscall.Stat_t).Uid)
+}
+
+// requiredCgroup2Controllers are the controllers Nomad activates for the
+// cgroups of tasks on cgroups v2.
+var requiredCgroup2Controllers = []string{"cpuset", "cpu", "io", "memory", "pids"}
+
+func functionalCgroups2(controllersFile string) bool {
 	controllersRootPath := filepath.Join(root, controllersFile)
 	content, err := os.ReadFile(controllersRootPath)
 	if err != nil {
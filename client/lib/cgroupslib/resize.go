@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// ResizeTask updates the limits of the cgroup of a running task to a hard
+// limit of memoryHard bytes of memory, or none if negative, memorySoft bytes
+// of memory protected from reclaim, and the CPU weight of cpuShares.
+func ResizeTask(allocID, task string, cores bool, memoryHard, memorySoft, cpuShares int64) error {
+	switch GetMode() {
+	case CG1:
+		return resizeCG1(allocID, task, memoryHard, memorySoft, cpuShares)
+	case CG2:
+		return resizeCG2(allocID, task, cores, memoryHard, memorySoft, cpuShares)
+	}
+	return fmt.Errorf("cgroups are not enabled")
+}
+
+func resizeCG1(allocID, task string, memoryHard, memorySoft, cpuShares int64) error {
+	if Enforces("memory") {
+		ed := OpenPath(PathCG1(allocID, task, "memory"))
+		if err := ed.Write("memory.limit_in_bytes", strconv.FormatInt(max(memoryHard, -1), 10)); err != nil {
+			return fmt.Errorf("failed to set memory limit: %w", err)
+		}
+		if err := ed.Write("memory.soft_limit_in_bytes", strconv.FormatInt(memorySoft, 10)); err != nil {
+			return fmt.Errorf("failed to set memory soft limit: %w", err)
+		}
+	}
+	if Enforces("cpu") {
+		ed := OpenPath(PathCG1(allocID, task, "cpu"))
+		if err := ed.Write("cpu.shares", strconv.FormatInt(cpuShares, 10)); err != nil {
+			return fmt.Errorf("failed to set cpu shares: %w", err)
+		}
+	}
+	return nil
+}
+
+func resizeCG2(allocID, task string, cores bool, memoryHard, memorySoft, cpuShares int64) error {
+	ed := OpenPath(pathCG2(allocID, task, cores))
+	if Enforces("memory") {
+		limit := "max"
+		if memoryHard >= 0 {
+			limit = strconv.FormatInt(memoryHard, 10)
+		}
+		if err := ed.Write("memory.max", limit); err != nil {
+			return fmt.Errorf("failed to set memory limit: %w", err)
+		}
+		if err := ed.Write("memory.low", strconv.FormatInt(memorySoft, 10)); err != nil {
+			return fmt.Errorf("failed to set memory protection: %w", err)
+		}
+	}
+	if Enforces("cpu") {
+		weight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+		if err := ed.Write("cpu.weight", strconv.FormatUint(weight, 10)); err != nil {
+			return fmt.Errorf("failed to set cpu weight: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package cgroupslib
+
+import "errors"
+
+// ResizeTask returns an error on non-Linux systems, where tasks can't be
+// resized in place
+func ResizeTask(string, string, bool, int64, int64, int64) error {
+	return errors.New("resizing tasks in place requires cgroups")
+}
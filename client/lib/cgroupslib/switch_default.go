@@ -9,3 +9,18 @@ package cgroupslib
 func GetMode() Mode {
 	return OFF
 }
+
+// GetEnforcement returns nil on non-Linux systems.
+func GetEnforcement() map[string]Enforcement {
+	return nil
+}
+
+// Enforces returns false on non-Linux systems.
+func Enforces(controller string) bool {
+	return false
+}
+
+// IsHybrid returns false on non-Linux systems.
+func IsHybrid() bool {
+	return false
+}
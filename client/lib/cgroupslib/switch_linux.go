@@ -6,6 +6,7 @@
 package cgroupslib
 
 import (
+	"os"
 	"sync"
 )
 
@@ -21,6 +22,14 @@ func defaultParent() string {
 	switch GetMode() {
 	case CG1:
 		return "/nomad"
+	case CG2:
+		// unprivileged clients use the cgroup subtree delegated to them
+		if uid := os.Geteuid(); uid > 0 {
+			if parent, ok := delegatedParent(uid); ok {
+				return parent
+			}
+		}
+		return "nomad.slice"
 	default:
 		return "nomad.slice"
 	}
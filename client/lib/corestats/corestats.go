@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package corestats provides utilities for breaking down the CPU usage of a
+// cgroup by the cores it is pinned to, so that an imbalance between the cores
+// reserved for a task is visible.
+package corestats
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// ErrNotSupported is returned by Open on platforms where the CPU usage of a
+// cgroup cannot be broken down by core.
+var ErrNotSupported = errors.New("per-core CPU stats are not supported")
+
+// A Collector reads the CPU usage of a cgroup on each core.
+type Collector interface {
+	// Read returns the cumulative CPU time in nanoseconds the processes of
+	// the cgroup have used on each core. Cores without usage may be omitted.
+	Read() (map[hw.CoreID]uint64, error)
+}
+
+// A Tracker converts the cumulative usage read from a Collector into the
+// utilization of each of a set of cores since the previous call to Stats.
+type Tracker struct {
+	lock      sync.Mutex
+	collector Collector
+	cores     []hw.CoreID
+
+	prev     map[hw.CoreID]uint64
+	prevTime time.Time
+
+	// now is overridden by tests
+	now func() time.Time
+}
+
+// NewTracker creates a Tracker reporting the utilization of the cores read
+// from c.
+func NewTracker(c Collector, cores []hw.CoreID) *Tracker {
+	cores = slices.Clone(cores)
+	slices.Sort(cores)
+	return &Tracker{
+		collector: c,
+		cores:     cores,
+		now:       time.Now,
+	}
+}
+
+// Stats returns the utilization of each core since the previous call, ordered
+// by core ID, or nil if this is the first sample or the usage could not be
+// read.
+func (t *Tracker) Stats() []*cstructs.CoreStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	current, err := t.collector.Read()
+	if err != nil {
+		return nil
+	}
+	now := t.now()
+
+	prev, prevTime := t.prev, t.prevTime
+	t.prev, t.prevTime = current, now
+	if prev == nil {
+		return nil
+	}
+	return Delta(t.cores, prev, current, now.Sub(prevTime))
+}
+
+// Delta computes the utilization of each of the cores between two readings
+// of cumulative usage taken elapsed apart. Usage that went backwards (e.g.
+// because a process exited) is reported as zero.
+func Delta(cores []hw.CoreID, prev, current map[hw.CoreID]uint64, elapsed time.Duration) []*cstructs.CoreStats {
+	if elapsed <= 0 {
+		return nil
+	}
+
+	stats := make([]*cstructs.CoreStats, 0, len(cores))
+	for _, core := range cores {
+		var used uint64
+		if current[core] > prev[core] {
+			used = current[core] - prev[core]
+		}
+		percent := float64(used) / float64(elapsed.Nanoseconds()) * 100
+		stats = append(stats, &cstructs.CoreStats{
+			ID:      uint16(core),
+			Percent: min(percent, 100),
+		})
+	}
+	return stats
+}
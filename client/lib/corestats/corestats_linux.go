@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package corestats
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+)
+
+// userHZ is the unit of the CPU times in /proc, which the kernel reports in
+// 1/100ths of a second regardless of its tick rate
+const userHZ = 100
+
+// Open creates a Collector reading the CPU usage of the processes in the
+// cgroup at the given path, which on cgroups v1 is the freezer cgroup.
+func Open(cgroup string) (Collector, error) {
+	if cgroup == "" {
+		return nil, ErrNotSupported
+	}
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		return &cpuacctCollector{cg: cgroupslib.OpenFromFreezerCG1(cgroup, "cpuacct")}, nil
+	case cgroupslib.CG2:
+		return newThreadCollector(cgroupslib.OpenPath(cgroup), "/proc"), nil
+	default:
+		return nil, ErrNotSupported
+	}
+}
+
+// cpuacctCollector reads the per-CPU usage the cpuacct controller of cgroups
+// v1 accounts.
+type cpuacctCollector struct {
+	cg cgroupslib.Interface
+}
+
+func (c *cpuacctCollector) Read() (map[hw.CoreID]uint64, error) {
+	content, err := c.cg.Read("cpuacct.usage_percpu")
+	if err != nil {
+		return nil, err
+	}
+	return parseUsagePercpu(content)
+}
+
+// parseUsagePercpu parses the usage in nanoseconds listed for each CPU by
+// cpuacct.usage_percpu.
+func parseUsagePercpu(content string) (map[hw.CoreID]uint64, error) {
+	fields := strings.Fields(content)
+	usage := make(map[hw.CoreID]uint64, len(fields))
+	for i, field := range fields {
+		ns, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse usage of CPU %d: %w", i, err)
+		}
+		if ns > 0 {
+			usage[hw.CoreID(i)] = ns
+		}
+	}
+	return usage, nil
+}
+
+// threadCollector approximates the per-CPU usage of a cgroups v2 cgroup,
+// which the kernel doesn't account, by attributing the CPU time each thread
+// used between two reads to the CPU it last ran on. The approximation is
+// exact for threads that stay on one CPU, as threads pinned to their own
+// core do.
+type threadCollector struct {
+	cg       cgroupslib.Interface
+	procRoot string
+
+	lock    sync.Mutex
+	threads map[int]uint64
+	usage   map[hw.CoreID]uint64
+}
+
+func newThreadCollector(cg cgroupslib.Interface, procRoot string) *threadCollector {
+	return &threadCollector{
+		cg:       cg,
+		procRoot: procRoot,
+		threads:  make(map[int]uint64),
+		usage:    make(map[hw.CoreID]uint64),
+	}
+}
+
+func (c *threadCollector) Read() (map[hw.CoreID]uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	content, err := c.cg.Read("cgroup.threads")
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make(map[int]uint64, len(c.threads))
+	for _, field := range strings.Fields(content) {
+		tid, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		stat, err := os.ReadFile(filepath.Join(c.procRoot, field, "stat"))
+		if err != nil {
+			// The thread exited since the cgroup was read
+			continue
+		}
+		ticks, cpu, err := parseThreadStat(string(stat))
+		if err != nil {
+			return nil, err
+		}
+		threads[tid] = ticks
+
+		// Threads not seen before have their CPU time since they started
+		// attributed to the CPU they are on now
+		if ticks > c.threads[tid] {
+			c.usage[cpu] += (ticks - c.threads[tid]) * (1e9 / userHZ)
+		}
+	}
+	c.threads = threads
+
+	return maps.Clone(c.usage), nil
+}
+
+// parseThreadStat returns the user and system CPU time in ticks and the CPU
+// the thread last ran on from the content of /proc/<tid>/stat.
+func parseThreadStat(content string) (uint64, hw.CoreID, error) {
+	// The command name may contain spaces and parentheses, so the fields
+	// are counted from the last closing parenthesis, after field 2
+	i := strings.LastIndexByte(content, ')')
+	if i < 0 {
+		return 0, 0, errors.New("malformed thread stat")
+	}
+	fields := strings.Fields(content[i+1:])
+
+	// utime and stime are fields 14 and 15, and processor is field 39
+	const utime, stime, processor = 14 - 3, 15 - 3, 39 - 3
+	if len(fields) <= processor {
+		return 0, 0, errors.New("truncated thread stat")
+	}
+	user, err := strconv.ParseUint(fields[utime], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse utime: %w", err)
+	}
+	system, err := strconv.ParseUint(fields[stime], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse stime: %w", err)
+	}
+	cpu, err := strconv.ParseUint(fields[processor], 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse processor: %w", err)
+	}
+	return user + system, hw.CoreID(cpu), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package corestats
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseUsagePercpu(t *testing.T) {
+	ci.Parallel(t)
+
+	usage, err := parseUsagePercpu("1200 0 3400 5600 \n")
+	must.NoError(t, err)
+	must.Eq(t, map[hw.CoreID]uint64{0: 1200, 2: 3400, 3: 5600}, usage)
+
+	_, err = parseUsagePercpu("1200 abc")
+	must.ErrorContains(t, err, "usage of CPU 1")
+}
+
+func TestParseThreadStat(t *testing.T) {
+	ci.Parallel(t)
+
+	// The command name contains spaces and parentheses
+	stat := "1234 (my (odd) cmd) S 1 1234 1234 0 -1 4194560 500 0 0 0 " +
+		"150 25 0 0 20 0 4 0 12345 10000000 500 18446744073709551615 " +
+		"1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n"
+	ticks, cpu, err := parseThreadStat(stat)
+	must.NoError(t, err)
+	must.Eq(t, 175, ticks)
+	must.Eq(t, 3, cpu)
+
+	_, _, err = parseThreadStat("1234 (cmd) S 1 2 3")
+	must.ErrorContains(t, err, "truncated")
+}
+
+func TestThreadCollector(t *testing.T) {
+	ci.Parallel(t)
+
+	cgroup := t.TempDir()
+	proc := t.TempDir()
+	writeThread := func(tid string, ticks, cpu int) {
+		t.Helper()
+		stat := "1 (task) R 1 1 1 0 -1 0 0 0 0 0 " +
+			strconv.Itoa(ticks) + " 0 0 0 20 0 1 0 0 0 0 0 1 1 0 0 0 0 0 0 0 0 0 0 17 " +
+			strconv.Itoa(cpu) + " 0 0 0 0 0\n"
+		must.NoError(t, os.MkdirAll(filepath.Join(proc, tid), 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(proc, tid, "stat"), []byte(stat), 0o644))
+	}
+	writeThreads := func(content string) {
+		t.Helper()
+		must.NoError(t, os.WriteFile(filepath.Join(cgroup, "cgroup.threads"), []byte(content), 0o644))
+	}
+
+	c := newThreadCollector(cgroupslib.OpenPath(cgroup), proc)
+
+	writeThreads("10\n11\n")
+	writeThread("10", 100, 2)
+	writeThread("11", 50, 3)
+	usage, err := c.Read()
+	must.NoError(t, err)
+	must.Eq(t, map[hw.CoreID]uint64{2: 1_000_000_000, 3: 500_000_000}, usage)
+
+	// Thread 11 moved cores and thread 12 started, still listed after
+	// thread 13 exited before its stat could be read
+	writeThreads("10\n11\n12\n13\n")
+	writeThread("10", 150, 2)
+	writeThread("11", 60, 2)
+	writeThread("12", 5, 3)
+	usage, err = c.Read()
+	must.NoError(t, err)
+	must.Eq(t, map[hw.CoreID]uint64{2: 1_600_000_000, 3: 550_000_000}, usage)
+}
+
+// FuzzParseUsagePercpu asserts malformed cpuacct.usage_percpu files can't
+// panic the parser.
+func FuzzParseUsagePercpu(f *testing.F) {
+	f.Add("1200 0 3400 5600 \n")
+	f.Add("")
+	f.Add("18446744073709551616")
+	f.Add("-1 2")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		usage, err := parseUsagePercpu(content)
+		if err == nil {
+			for _, ns := range usage {
+				must.Positive(t, ns)
+			}
+		}
+	})
+}
+
+// FuzzParseThreadStat asserts malformed /proc/<tid>/stat files, such as
+// those of kernels with fewer fields or with odd command names, can't panic
+// the parser.
+func FuzzParseThreadStat(f *testing.F) {
+	f.Add("1234 (my (odd) cmd) S 1 1234 1234 0 -1 4194560 500 0 0 0 " +
+		"150 25 0 0 20 0 4 0 12345 10000000 500 18446744073709551615 " +
+		"1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n")
+	f.Add("1234 (cmd) S 1 2 3")
+	f.Add("1234 cmd")
+	f.Add(")")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		_, _, _ = parseThreadStat(content)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package corestats
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+type mockCollector struct {
+	samples []map[hw.CoreID]uint64
+	err     error
+}
+
+func (m *mockCollector) Read() (map[hw.CoreID]uint64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	next := m.samples[0]
+	m.samples = m.samples[1:]
+	return next, nil
+}
+
+func TestDelta(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := map[hw.CoreID]uint64{2: 1_000_000_000, 3: 500_000_000, 4: 100}
+	current := map[hw.CoreID]uint64{2: 1_900_000_000, 3: 600_000_000, 4: 50, 5: 1_000_000_000}
+
+	// Cores that aren't reserved are ignored, and usage that went backwards
+	// is zero
+	stats := Delta([]hw.CoreID{2, 3, 4}, prev, current, time.Second)
+	must.Eq(t, []*cstructs.CoreStats{
+		{ID: 2, Percent: 90},
+		{ID: 3, Percent: 10},
+		{ID: 4, Percent: 0},
+	}, stats)
+
+	// Utilization is capped at the whole core
+	stats = Delta([]hw.CoreID{5}, prev, current, 500*time.Millisecond)
+	must.Eq(t, []*cstructs.CoreStats{{ID: 5, Percent: 100}}, stats)
+
+	must.Nil(t, Delta([]hw.CoreID{2}, prev, current, 0))
+}
+
+func TestTracker_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &mockCollector{
+		samples: []map[hw.CoreID]uint64{
+			{1: 0, 3: 0},
+			{1: 250_000_000, 3: 1_000_000_000},
+		},
+	}
+	tracker := NewTracker(c, []hw.CoreID{3, 1})
+
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+
+	// The first sample has nothing to compare against
+	must.Nil(t, tracker.Stats())
+
+	now = now.Add(time.Second)
+	must.Eq(t, []*cstructs.CoreStats{
+		{ID: 1, Percent: 25},
+		{ID: 3, Percent: 100},
+	}, tracker.Stats())
+
+	c.err = errors.New("cgroup removed")
+	must.Nil(t, tracker.Stats())
+}
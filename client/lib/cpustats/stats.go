@@ -5,8 +5,10 @@
 package cpustats
 
 import (
+	"math"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"oss.indeed.com/go/libtime"
 )
@@ -30,7 +32,8 @@ type Tracker struct {
 	totalCompute hw.MHz
 	numCPUs      int
 
-	clock libtime.Clock
+	clock  libtime.Clock
+	logger hclog.Logger
 }
 
 // New creates a fresh Tracker with no data.
@@ -39,9 +42,16 @@ func New(c Compute) *Tracker {
 		totalCompute: c.TotalCompute,
 		numCPUs:      c.NumCores,
 		clock:        libtime.SystemClock(),
+		logger:       hclog.NewNullLogger(),
 	}
 }
 
+// SetLogger sets the logger the Tracker reports discontinuities of the CPU
+// time it tracks to.
+func (t *Tracker) SetLogger(logger hclog.Logger) {
+	t.logger = logger
+}
+
 // Percent calculates the CPU usage percentage based on the current CPU usage
 // and the previous CPU usage where usage is given as a time in nanoseconds
 // spent using the CPU.
@@ -62,16 +72,46 @@ func (t *Tracker) Percent(cpuTime float64) float64 {
 }
 
 func (t *Tracker) calculatePercent(t1, t2 float64, timeDelta int64) float64 {
+	if timeDelta <= 0 {
+		return 0.0
+	}
+
+	// The CPU time in nanoseconds doesn't wrap, so it only goes backwards
+	// when the counter was reset, such as after restoring a checkpoint of the
+	// process or migrating its VM
 	vDelta := t2 - t1
-	if timeDelta <= 0 || vDelta <= 0 {
+	if vDelta < 0 {
+		t.logger.Warn("CPU time went backwards, assuming the counter was reset",
+			"previous", t1, "current", t2)
+		return 0.0
+	}
+	if vDelta == 0 {
 		return 0.0
 	}
-	return (vDelta / float64(timeDelta)) * 100.0
+
+	// A process can't use more than all of the cores for the interval, so a
+	// larger delta is the counter jumping rather than usage
+	percent := (vDelta / float64(timeDelta)) * 100.0
+	if limit := t.maxPercent(); limit > 0 && percent > limit {
+		t.logger.Debug("CPU time grew faster than the cores allow, clamping usage",
+			"percent", percent, "max_percent", limit)
+		return limit
+	}
+	return percent
+}
+
+// maxPercent returns the largest percent of a core the tracked CPU time can
+// grow by, or 0 if the number of cores is unknown.
+func (t *Tracker) maxPercent() float64 {
+	return float64(t.numCPUs) * 100.0
 }
 
 // TicksConsumed calculates the total bandwidth consumed by the process across
 // all system CPU cores (not just the ones available to Nomad or this particular
 // process.
 func (t *Tracker) TicksConsumed(percent float64) float64 {
+	if t.numCPUs <= 0 || percent <= 0 || math.IsNaN(percent) || math.IsInf(percent, 0) {
+		return 0.0
+	}
 	return (percent / 100) * float64(t.totalCompute) / float64(t.numCPUs)
 }
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cpustats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestTracker_calculatePercent(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := New(Compute{TotalCompute: 8000, NumCores: 4})
+	second := time.Second.Nanoseconds()
+
+	cases := []struct {
+		name   string
+		t1, t2 float64
+		delta  int64
+		exp    float64
+	}{
+		{name: "half a core", t1: 1e9, t2: 1.5e9, delta: second, exp: 50},
+		{name: "idle", t1: 1e9, t2: 1e9, delta: second, exp: 0},
+		{name: "no time passed", t1: 1e9, t2: 2e9, delta: 0, exp: 0},
+		{name: "reset", t1: 100e9, t2: 1e9, delta: second, exp: 0},
+		{name: "reset near 32 bits", t1: math.MaxUint32 - 0.25e9, t2: 0.25e9, delta: second, exp: 0},
+		{name: "jump", t1: 1e9, t2: 100e9, delta: second, exp: 400},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, tracker.calculatePercent(tc.t1, tc.t2, tc.delta))
+		})
+	}
+}
+
+func TestTracker_TicksConsumed(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := New(Compute{TotalCompute: 8000, NumCores: 4})
+	must.Eq(t, 1000, tracker.TicksConsumed(50))
+	must.Eq(t, 0, tracker.TicksConsumed(math.Inf(1)))
+
+	// Trackers without the compute of the node don't divide by zero
+	must.Eq(t, 0, New(Compute{}).TicksConsumed(50))
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package egressstats accounts the traffic a task sends by classes of
+// destinations, such as the subnets of other availability zones or the
+// internet, for chargeback of egress traffic.
+package egressstats
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// Unclassified is the name of the class of traffic to destinations no class
+// matches.
+const Unclassified = "unclassified"
+
+// ErrNotSupported is returned by Open on platforms or cgroup configurations
+// where egress traffic cannot be accounted per cgroup.
+var ErrNotSupported = errors.New("egress stats are not supported")
+
+// Class is a class of destinations whose traffic is accounted together.
+type Class struct {
+	// Name identifies the class in the stats of the task
+	Name string `codec:"name"`
+
+	// CIDRs are the destination subnets of the class
+	CIDRs []string `codec:"cidrs"`
+
+	// Ports restricts the class to traffic to these TCP or UDP ports, if set
+	Ports []int `codec:"ports"`
+}
+
+// Validate returns an error if the classes are invalid.
+func Validate(classes []*Class) error {
+	names := make(map[string]bool, len(classes))
+	for _, c := range classes {
+		switch {
+		case c.Name == "":
+			return errors.New("egress class name must not be empty")
+		case c.Name == Unclassified:
+			return fmt.Errorf("egress class name %q is reserved", Unclassified)
+		case names[c.Name]:
+			return fmt.Errorf("duplicate egress class %q", c.Name)
+		case len(c.CIDRs) == 0:
+			return fmt.Errorf("egress class %q must have at least one CIDR", c.Name)
+		}
+		names[c.Name] = true
+
+		for _, cidr := range c.CIDRs {
+			if _, err := netip.ParsePrefix(cidr); err != nil {
+				return fmt.Errorf("egress class %q has invalid CIDR %q: %w", c.Name, cidr, err)
+			}
+		}
+		for _, port := range c.Ports {
+			if port < 1 || port > 65535 {
+				return fmt.Errorf("egress class %q has invalid port %d", c.Name, port)
+			}
+		}
+	}
+	return nil
+}
+
+// keySize is the size of the keys of the trie matching destinations to
+// classes: the prefix length followed by the destination port and the IPv6
+// address of the destination, with IPv4 addresses mapped to IPv6. Traffic to
+// a port is matched with the port first, and then with port 0, which is how
+// classes without ports are keyed.
+const keySize = 4 + 2 + 16
+
+// classKeys returns the trie keys of a class.
+func classKeys(c *Class) ([][]byte, error) {
+	ports := c.Ports
+	if len(ports) == 0 {
+		ports = []int{0}
+	}
+
+	var keys [][]byte
+	for _, cidr := range c.CIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		prefix = prefix.Masked()
+
+		bits := prefix.Bits()
+		if prefix.Addr().Is4() {
+			bits += 96
+		}
+		addr := prefix.Addr().As16()
+		for _, port := range ports {
+			key := make([]byte, keySize)
+			binary.NativeEndian.PutUint32(key, uint32(16+bits))
+			binary.BigEndian.PutUint16(key[4:], uint16(port))
+			copy(key[6:], addr[:])
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// A Collector accounts the traffic a cgroup sends.
+type Collector interface {
+	// Stats returns the traffic sent to each class since the collector was
+	// opened, keyed by class name.
+	Stats() (map[string]*cstructs.EgressStats, error)
+
+	// Close stops accounting and releases the resources of the Collector.
+	Close() error
+}
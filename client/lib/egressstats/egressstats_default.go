@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package egressstats
+
+// Open is not supported on non-Linux systems.
+func Open(string, []*Class) (Collector, error) {
+	return nil, ErrNotSupported
+}
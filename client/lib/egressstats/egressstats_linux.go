@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package egressstats
+
+import (
+	"fmt"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"golang.org/x/sys/unix"
+)
+
+// counter is the value of the per-CPU counters of each class, whose index is
+// the value the trie matches destinations to. Index 0 counts unclassified
+// traffic.
+type counter struct {
+	Bytes   uint64
+	Packets uint64
+}
+
+type collector struct {
+	names    []string
+	classes  *ebpf.Map
+	counters *ebpf.Map
+	prog     *ebpf.Program
+	link     link.Link
+}
+
+// Open attaches a program to the cgroup at path which accounts the traffic
+// its processes send to each class. It requires cgroups v2.
+func Open(path string, classes []*Class) (Collector, error) {
+	if cgroupslib.GetMode() != cgroupslib.CG2 || path == "" {
+		return nil, ErrNotSupported
+	}
+	if err := Validate(classes); err != nil {
+		return nil, err
+	}
+
+	// Kernels before 5.11 charge eBPF maps to the memlock rlimit. This is
+	// best effort, since creating the maps reports if the limit is too low.
+	_ = rlimit.RemoveMemlock()
+
+	c := &collector{names: []string{Unclassified}}
+	var keys [][]byte
+	var values []uint32
+	for i, class := range classes {
+		ks, err := classKeys(class)
+		if err != nil {
+			return nil, err
+		}
+		for range ks {
+			values = append(values, uint32(i+1))
+		}
+		keys = append(keys, ks...)
+		c.names = append(c.names, class.Name)
+	}
+
+	var err error
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	c.classes, err = ebpf.NewMap(&ebpf.MapSpec{
+		Name:       "egress_classes",
+		Type:       ebpf.LPMTrie,
+		KeySize:    keySize,
+		ValueSize:  4,
+		MaxEntries: uint32(max(len(keys), 1)),
+		Flags:      unix.BPF_F_NO_PREALLOC,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create classes map: %w", err)
+	}
+	for i, key := range keys {
+		if err = c.classes.Put(key, values[i]); err != nil {
+			return nil, fmt.Errorf("failed to add class %q: %w", c.names[values[i]], err)
+		}
+	}
+
+	c.counters, err = ebpf.NewMap(&ebpf.MapSpec{
+		Name:       "egress_counts",
+		Type:       ebpf.PerCPUArray,
+		KeySize:    4,
+		ValueSize:  16,
+		MaxEntries: uint32(len(c.names)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create counters map: %w", err)
+	}
+
+	c.prog, err = ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name:         "nomad_egress",
+		Type:         ebpf.CGroupSKB,
+		AttachType:   ebpf.AttachCGroupInetEgress,
+		Instructions: program(c.classes.FD(), c.counters.FD()),
+		License:      "MPL-2.0",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load egress program: %w", err)
+	}
+
+	c.link, err = link.AttachCgroup(link.CgroupOptions{
+		Path:    path,
+		Attach:  ebpf.AttachCGroupInetEgress,
+		Program: c.prog,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach egress program: %w", err)
+	}
+	return c, nil
+}
+
+func (c *collector) Stats() (map[string]*cstructs.EgressStats, error) {
+	stats := make(map[string]*cstructs.EgressStats, len(c.names))
+	for i, name := range c.names {
+		var perCPU []counter
+		if err := c.counters.Lookup(uint32(i), &perCPU); err != nil {
+			return nil, fmt.Errorf("failed to read counters of class %q: %w", name, err)
+		}
+		es := new(cstructs.EgressStats)
+		for _, v := range perCPU {
+			es.Bytes += v.Bytes
+			es.Packets += v.Packets
+		}
+		stats[name] = es
+	}
+	return stats, nil
+}
+
+func (c *collector) Close() error {
+	var err error
+	if c.link != nil {
+		err = c.link.Close()
+	}
+	for _, closer := range []interface{ Close() error }{c.prog, c.counters, c.classes} {
+		if closer != nil {
+			_ = closer.Close()
+		}
+	}
+	return err
+}
+
+const (
+	ipProtoTCP = 6
+	ipProtoUDP = 17
+)
+
+// Offsets in the stack of the program, which keeps the version and protocol
+// of the packet, the index of its counter, and the trie key looked up
+const (
+	offVersion  = -32
+	offProtocol = -31
+	offIndex    = -28
+	offKey      = -24
+	offPort     = offKey + 4
+	offAddr     = offPort + 2
+)
+
+// program returns the instructions of the cgroup skb program, which looks up
+// the class of the destination of each packet and adds it to the counter of
+// the class. The packets of a cgroup skb program start at the IP header.
+// Only the fixed IPv6 header is parsed, so the port of IPv6 packets with
+// extension headers isn't matched.
+func program(classesFD, countersFD int) asm.Instructions {
+	// loadBytes copies size bytes of the packet at the offset in R2 to the
+	// stack at off, jumping to onErr if the packet is too short
+	loadBytes := func(off int16, size int32, onErr string) asm.Instructions {
+		return asm.Instructions{
+			asm.Mov.Reg(asm.R1, asm.R6),
+			asm.Mov.Reg(asm.R3, asm.RFP),
+			asm.Add.Imm(asm.R3, int32(off)),
+			asm.Mov.Imm(asm.R4, size),
+			asm.FnSkbLoadBytes.Call(),
+			asm.JNE.Imm(asm.R0, 0, onErr),
+		}
+	}
+	lookupClass := asm.Instructions{
+		asm.StoreImm(asm.RFP, offKey, 16+128, asm.Word),
+		asm.LoadMapPtr(asm.R1, classesFD),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, offKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JNE.Imm(asm.R0, 0, "found"),
+	}
+
+	var insns asm.Instructions
+	insns = append(insns,
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.StoreImm(asm.RFP, offKey, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, offKey+8, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, offKey+16, 0, asm.DWord),
+		asm.Mov.Imm(asm.R2, 0),
+	)
+	insns = append(insns, loadBytes(offVersion, 1, "unclassified")...)
+	insns = append(insns,
+		asm.LoadMem(asm.R7, asm.RFP, offVersion, asm.Byte),
+		asm.RSh.Imm(asm.R7, 4),
+		asm.JEq.Imm(asm.R7, 6, "ipv6"),
+		asm.JNE.Imm(asm.R7, 4, "unclassified"),
+
+		// IPv4: the header length is in the low nibble of the first byte,
+		// and the address is mapped to IPv6
+		asm.LoadMem(asm.R8, asm.RFP, offVersion, asm.Byte),
+		asm.And.Imm(asm.R8, 0xf),
+		asm.LSh.Imm(asm.R8, 2),
+		asm.StoreImm(asm.RFP, offAddr+10, 0xffff, asm.Half),
+		asm.Mov.Imm(asm.R2, 9),
+	)
+	insns = append(insns, loadBytes(offProtocol, 1, "unclassified")...)
+	insns = append(insns, asm.Mov.Imm(asm.R2, 16))
+	insns = append(insns, loadBytes(offAddr+12, 4, "unclassified")...)
+	insns = append(insns, asm.Ja.Label("ports"))
+
+	insns = append(insns,
+		asm.Mov.Imm(asm.R8, 40).Sym("ipv6"),
+		asm.Mov.Imm(asm.R2, 6),
+	)
+	insns = append(insns, loadBytes(offProtocol, 1, "unclassified")...)
+	insns = append(insns, asm.Mov.Imm(asm.R2, 24))
+	insns = append(insns, loadBytes(offAddr, 16, "unclassified")...)
+
+	// The destination port follows the source port in both TCP and UDP
+	insns = append(insns,
+		asm.LoadMem(asm.R9, asm.RFP, offProtocol, asm.Byte).Sym("ports"),
+		asm.JEq.Imm(asm.R9, ipProtoTCP, "port"),
+		asm.JNE.Imm(asm.R9, ipProtoUDP, "portless"),
+		asm.Mov.Reg(asm.R2, asm.R8).Sym("port"),
+		asm.Add.Imm(asm.R2, 2),
+	)
+	insns = append(insns, loadBytes(offPort, 2, "portless")...)
+	insns = append(insns, lookupClass...)
+	insns = append(insns, asm.StoreImm(asm.RFP, offPort, 0, asm.Half).Sym("portless"))
+	insns = append(insns, lookupClass...)
+
+	insns = append(insns,
+		asm.Mov.Imm(asm.R7, 0).Sym("unclassified"),
+		asm.Ja.Label("count"),
+		asm.LoadMem(asm.R7, asm.R0, 0, asm.Word).Sym("found"),
+
+		asm.StoreMem(asm.RFP, offIndex, asm.R7, asm.Word).Sym("count"),
+		asm.LoadMapPtr(asm.R1, countersFD),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, offIndex),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "allow"),
+
+		// The counters are per CPU, so they are updated without atomics.
+		// skb->len is the first field of the context.
+		asm.LoadMem(asm.R1, asm.R6, 0, asm.Word),
+		asm.LoadMem(asm.R2, asm.R0, 0, asm.DWord),
+		asm.Add.Reg(asm.R2, asm.R1),
+		asm.StoreMem(asm.R0, 0, asm.R2, asm.DWord),
+		asm.LoadMem(asm.R2, asm.R0, 8, asm.DWord),
+		asm.Add.Imm(asm.R2, 1),
+		asm.StoreMem(asm.R0, 8, asm.R2, asm.DWord),
+
+		// The program only accounts traffic, so every packet is allowed
+		asm.Mov.Imm(asm.R0, 1).Sym("allow"),
+		asm.Return(),
+	)
+	return insns
+}
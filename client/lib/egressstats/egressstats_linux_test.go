@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package egressstats
+
+import (
+	"testing"
+
+	"github.com/cilium/ebpf"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+// TestProgram_verifier ensures the kernel's verifier accepts the program.
+func TestProgram_verifier(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	classes, err := ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.LPMTrie,
+		KeySize:    keySize,
+		ValueSize:  4,
+		MaxEntries: 1,
+		Flags:      unix.BPF_F_NO_PREALLOC,
+	})
+	must.NoError(t, err)
+	defer classes.Close()
+
+	counters, err := ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.PerCPUArray,
+		KeySize:    4,
+		ValueSize:  16,
+		MaxEntries: 2,
+	})
+	must.NoError(t, err)
+	defer counters.Close()
+
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Type:         ebpf.CGroupSKB,
+		AttachType:   ebpf.AttachCGroupInetEgress,
+		Instructions: program(classes.FD(), counters.FD()),
+		License:      "MPL-2.0",
+	})
+	must.NoError(t, err)
+	must.NoError(t, prog.Close())
+}
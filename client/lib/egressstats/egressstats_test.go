@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package egressstats
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestValidate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, Validate([]*Class{
+		{Name: "cross-az", CIDRs: []string{"10.1.0.0/16", "fd00:1::/64"}},
+		{Name: "https", CIDRs: []string{"0.0.0.0/0"}, Ports: []int{443}},
+	}))
+
+	cases := []struct {
+		name    string
+		classes []*Class
+		err     string
+	}{
+		{"empty name", []*Class{{CIDRs: []string{"10.0.0.0/8"}}}, "name must not be empty"},
+		{"reserved name", []*Class{{Name: Unclassified, CIDRs: []string{"10.0.0.0/8"}}}, "is reserved"},
+		{"duplicate", []*Class{
+			{Name: "a", CIDRs: []string{"10.0.0.0/8"}},
+			{Name: "a", CIDRs: []string{"10.1.0.0/16"}},
+		}, `duplicate egress class "a"`},
+		{"no cidrs", []*Class{{Name: "a"}}, "at least one CIDR"},
+		{"bad cidr", []*Class{{Name: "a", CIDRs: []string{"10.0.0.0"}}}, `invalid CIDR "10.0.0.0"`},
+		{"bad port", []*Class{{Name: "a", CIDRs: []string{"10.0.0.0/8"}, Ports: []int{70000}}}, "invalid port 70000"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.ErrorContains(t, Validate(tc.classes), tc.err)
+		})
+	}
+}
+
+func Test_classKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	keys, err := classKeys(&Class{Name: "a", CIDRs: []string{"10.1.2.3/16", "fd00::/8"}, Ports: []int{53, 443}})
+	must.NoError(t, err)
+	must.SliceLen(t, 4, keys)
+
+	// IPv4 subnets are masked and mapped to IPv6, after the port
+	must.Eq(t, 16+96+16, binary.NativeEndian.Uint32(keys[0]))
+	must.Eq(t, []byte{0, 53, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 10, 1, 0, 0}, keys[0][4:])
+	must.Eq(t, []byte{1, 187}, keys[1][4:6])
+
+	must.Eq(t, 16+8, binary.NativeEndian.Uint32(keys[2]))
+	must.Eq(t, 0xfd, keys[2][6])
+
+	// Classes without ports are keyed with port 0
+	keys, err = classKeys(&Class{Name: "b", CIDRs: []string{"192.168.0.0/24"}})
+	must.NoError(t, err)
+	must.SliceLen(t, 1, keys)
+	must.Eq(t, []byte{0, 0}, keys[0][4:6])
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package hotplug watches the kernel for devices being attached to and removed
+// from the node, so devices can be fingerprinted again without restarting the
+// client.
+package hotplug
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// ErrNotSupported is returned when hotplug events can't be watched on this
+// system.
+var ErrNotSupported = errors.New("hotplug events are not supported on this system")
+
+// DefaultSubsystems are the subsystems of the devices whose events trigger a
+// fingerprint by default.
+var DefaultSubsystems = []string{"pci", "usb", "drm", "nvidia", "vfio", "cpu"}
+
+// DefaultQuiescence is how long the events of the devices being attached or
+// removed must have stopped for before fingerprinting by default, since a
+// single device generates bursts of events.
+const DefaultQuiescence = 2 * time.Second
+
+// Event is a kernel uevent for a device.
+type Event struct {
+	// Action is the action of the event, such as "add" or "remove"
+	Action string
+
+	// Subsystem is the subsystem of the device, such as "pci" or "usb"
+	Subsystem string
+
+	// DevPath is the path of the device in sysfs
+	DevPath string
+}
+
+// parseEvent parses a kernel uevent, which is a header followed by NUL
+// separated KEY=value pairs, and returns false if it isn't one.
+func parseEvent(b []byte) (*Event, bool) {
+	fields := bytes.Split(b, []byte{0})
+	if len(fields) < 2 || !bytes.Contains(fields[0], []byte{'@'}) {
+		return nil, false
+	}
+
+	e := new(Event)
+	for _, field := range fields[1:] {
+		key, value, ok := bytes.Cut(field, []byte{'='})
+		if !ok {
+			continue
+		}
+		switch string(key) {
+		case "ACTION":
+			e.Action = string(value)
+		case "SUBSYSTEM":
+			e.Subsystem = string(value)
+		case "DEVPATH":
+			e.DevPath = string(value)
+		}
+	}
+	return e, e.Action != ""
+}
+
+// triggers returns true if the event may change the devices a plugin
+// fingerprints: a device of one of the subsystems being attached, removed,
+// bound to or unbound from a kernel driver, or brought online or offline as
+// CPU cores are.
+func (e *Event) triggers(subsystems []string) bool {
+	switch e.Action {
+	case "add", "remove", "bind", "unbind", "online", "offline":
+	default:
+		return false
+	}
+	return slices.Contains(subsystems, e.Subsystem)
+}
+
+// Notifier notifies its subscribers when devices were attached to or removed
+// from the node.
+type Notifier struct {
+	logger     hclog.Logger
+	subsystems []string
+	quiescence time.Duration
+
+	// watch is overridden by tests
+	watch func(<-chan struct{}) (<-chan *Event, error)
+
+	l    sync.Mutex
+	subs []chan struct{}
+}
+
+// NewNotifier returns a notifier for the events of the devices of the
+// subsystems, which notifies once they stopped for the quiescence.
+func NewNotifier(logger hclog.Logger, subsystems []string, quiescence time.Duration) *Notifier {
+	return &Notifier{
+		logger:     logger.Named("hotplug"),
+		subsystems: subsystems,
+		quiescence: quiescence,
+		watch:      watch,
+	}
+}
+
+// Subscribe returns a channel which receives a value when devices were
+// attached or removed. Notifications pending on the channel are coalesced. A
+// nil Notifier returns a nil channel, which never receives.
+func (n *Notifier) Subscribe() <-chan struct{} {
+	if n == nil {
+		return nil
+	}
+	ch := make(chan struct{}, 1)
+	n.l.Lock()
+	n.subs = append(n.subs, ch)
+	n.l.Unlock()
+	return ch
+}
+
+// Run watches for the events of devices until shutdownCh is closed.
+func (n *Notifier) Run(shutdownCh <-chan struct{}) {
+	events, err := n.watch(shutdownCh)
+	if err != nil {
+		n.logger.Warn("failed to watch device hotplug events; devices are only fingerprinted by plugins", "error", err)
+		return
+	}
+	n.logger.Debug("watching device hotplug events", "subsystems", n.subsystems)
+
+	// fire receives once the events stopped for the quiescence
+	var timer *time.Timer
+	var fire <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.triggers(n.subsystems) {
+				n.logger.Trace("device hotplug event", "action", e.Action, "subsystem", e.Subsystem, "devpath", e.DevPath)
+				if timer == nil {
+					timer = time.NewTimer(n.quiescence)
+				} else {
+					timer.Reset(n.quiescence)
+				}
+				fire = timer.C
+			}
+		case <-fire:
+			fire = nil
+			n.notify()
+		}
+	}
+}
+
+func (n *Notifier) notify() {
+	n.l.Lock()
+	defer n.l.Unlock()
+	for _, ch := range n.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hotplug
+
+// watch is not supported on non-Linux systems.
+func watch(<-chan struct{}) (<-chan *Event, error) {
+	return nil, ErrNotSupported
+}
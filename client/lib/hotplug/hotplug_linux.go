@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hotplug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// ueventBufferSize is the size of the buffer a uevent is read into, which is
+// larger than the largest uevent the kernel sends
+const ueventBufferSize = 64 * 1024
+
+// watch returns the kernel uevents until shutdownCh is closed, read from the
+// netlink multicast group of the kernel so it works without udev running.
+func watch(shutdownCh <-chan struct{}) (<-chan *Event, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uevent socket: %w", err)
+	}
+	addr := &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Pid:    0,
+		Groups: 1,
+	}
+	if err := unix.Bind(fd, addr); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind uevent socket: %w", err)
+	}
+
+	// The file reads through the runtime poller, so closing it unblocks the
+	// read
+	f := os.NewFile(uintptr(fd), "uevent")
+	go func() {
+		<-shutdownCh
+		f.Close()
+	}()
+
+	events := make(chan *Event)
+	go func() {
+		defer close(events)
+		buf := make([]byte, ueventBufferSize)
+		for {
+			n, err := f.Read(buf)
+			if errors.Is(err, unix.ENOBUFS) {
+				// The socket overflowed during a burst of events, which
+				// carries on after the events that were dropped
+				continue
+			} else if err != nil {
+				return
+			}
+			e, ok := parseEvent(buf[:n])
+			if !ok {
+				continue
+			}
+			select {
+			case events <- e:
+			case <-shutdownCh:
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hotplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestHotplug_watch(t *testing.T) {
+	ci.Parallel(t)
+
+	shutdownCh := make(chan struct{})
+	events, err := watch(shutdownCh)
+	must.NoError(t, err)
+
+	// Shutting down stops the watch
+	close(shutdownCh)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected the watch to stop")
+		}
+	}
+}
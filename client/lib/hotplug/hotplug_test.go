@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hotplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+func TestHotplug_parseEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	e, ok := parseEvent([]byte("add@/devices/pci0000:00/0000:00:14.0/usb1/1-1\x00" +
+		"ACTION=add\x00DEVPATH=/devices/pci0000:00/0000:00:14.0/usb1/1-1\x00SUBSYSTEM=usb\x00SEQNUM=4242\x00"))
+	must.True(t, ok)
+	must.Eq(t, &Event{
+		Action:    "add",
+		Subsystem: "usb",
+		DevPath:   "/devices/pci0000:00/0000:00:14.0/usb1/1-1",
+	}, e)
+	must.True(t, e.triggers(DefaultSubsystems))
+	must.True(t, (&Event{Action: "offline", Subsystem: "cpu"}).triggers(DefaultSubsystems))
+
+	// Events of other subsystems or actions don't trigger
+	must.False(t, (&Event{Action: "add", Subsystem: "net"}).triggers(DefaultSubsystems))
+	must.False(t, (&Event{Action: "change", Subsystem: "pci"}).triggers(DefaultSubsystems))
+
+	// Messages of udev aren't kernel uevents
+	_, ok = parseEvent([]byte("libudev\x00\xfe\xed\xca\xfe"))
+	must.False(t, ok)
+}
+
+func TestHotplug_Notifier(t *testing.T) {
+	ci.Parallel(t)
+
+	events := make(chan *Event)
+	n := NewNotifier(testlog.HCLogger(t), DefaultSubsystems, 10*time.Millisecond)
+	n.watch = func(<-chan struct{}) (<-chan *Event, error) { return events, nil }
+
+	sub1, sub2 := n.Subscribe(), n.Subscribe()
+	shutdownCh := make(chan struct{})
+	defer close(shutdownCh)
+	go n.Run(shutdownCh)
+
+	// A burst of events notifies once
+	for i := 0; i < 3; i++ {
+		events <- &Event{Action: "add", Subsystem: "pci"}
+	}
+	events <- &Event{Action: "add", Subsystem: "net"}
+	for _, sub := range []<-chan struct{}{sub1, sub2} {
+		select {
+		case <-sub:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a notification")
+		}
+	}
+	select {
+	case <-sub1:
+		t.Fatal("expected the burst of events to be coalesced")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// A nil notifier never notifies
+	var nilNotifier *Notifier
+	must.Nil(t, nilNotifier.Subscribe())
+}
@@ -83,8 +83,13 @@ func Parse[T ID](list string) *Set[T] {
 		case spanRe.MatchString(s):
 			values := spanRe.FindStringSubmatch(s)
 			low, high := order(atoi[T](values[1]), atoi[T](values[2]))
-			for i := low; i <= high; i++ {
+			for i := low; ; i++ {
 				result.items.Insert(i)
+				// Breaking before the increment keeps spans ending at
+				// the largest ID from wrapping around forever
+				if i == high {
+					break
+				}
 			}
 		}
 	}
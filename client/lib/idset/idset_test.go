@@ -38,6 +38,10 @@ func Test_Parse(t *testing.T) {
 			input: " 4-2 , 9-9 , 11-7\n",
 			exp:   []uint16{2, 3, 4, 7, 8, 9, 10, 11},
 		},
+		{
+			input: "65533-65535",
+			exp:   []uint16{65533, 65534, 65535},
+		},
 	}
 
 	for _, tc := range cases {
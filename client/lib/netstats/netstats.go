@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package netstats reads the counters of the network interfaces inside the
+// network namespace of an allocation.
+package netstats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// ErrNotSupported is returned by Read on platforms without network
+// namespaces.
+var ErrNotSupported = errors.New("network namespace stats are not supported")
+
+// parseNetDev parses the interface counters in the format of /proc/net/dev,
+// keyed by interface name. The loopback interface is skipped, since traffic
+// between the tasks of an allocation isn't network usage.
+func parseNetDev(r io.Reader) (map[string]*cstructs.NetworkInterfaceStats, error) {
+	stats := make(map[string]*cstructs.NetworkInterfaceStats)
+
+	scanner := bufio.NewScanner(r)
+	for line := 0; scanner.Scan(); line++ {
+		// The first two lines are headers
+		if line < 2 {
+			continue
+		}
+
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid interface line %q", scanner.Text())
+		}
+		name = strings.TrimSpace(name)
+		if name == "lo" {
+			continue
+		}
+
+		// The receive counters are bytes, packets, errs, drop, fifo, frame,
+		// compressed and multicast, followed by the transmit counters bytes,
+		// packets, errs, drop, fifo, colls, carrier and compressed
+		fields := strings.Fields(counters)
+		if len(fields) != 16 {
+			return nil, fmt.Errorf("invalid counters of interface %q", name)
+		}
+		values := make([]uint64, len(fields))
+		for i, f := range fields {
+			v, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid counters of interface %q: %w", name, err)
+			}
+			values[i] = v
+		}
+
+		stats[name] = &cstructs.NetworkInterfaceStats{
+			RxBytes:   values[0],
+			RxPackets: values[1],
+			RxErrors:  values[2],
+			RxDropped: values[3],
+			TxBytes:   values[8],
+			TxPackets: values[9],
+			TxErrors:  values[10],
+			TxDropped: values[11],
+		}
+	}
+	return stats, scanner.Err()
+}
+
+// tcpEstablished is the state of established connections in /proc/net/tcp
+const tcpEstablished = "01"
+
+// countEstablished counts the established connections in the format of
+// /proc/net/tcp.
+func countEstablished(r io.Reader) (uint64, error) {
+	var n uint64
+
+	scanner := bufio.NewScanner(r)
+	for line := 0; scanner.Scan(); line++ {
+		// The first line is a header
+		if line == 0 {
+			continue
+		}
+		// The fields are sl, local_address, rem_address, st, ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			return 0, fmt.Errorf("invalid connection line %q", scanner.Text())
+		}
+		if fields[3] == tcpEstablished {
+			n++
+		}
+	}
+	return n, scanner.Err()
+}
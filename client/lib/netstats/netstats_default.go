@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package netstats
+
+import (
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// Read is not supported on non-Linux systems.
+func Read(string) (map[string]*cstructs.NetworkInterfaceStats, error) {
+	return nil, ErrNotSupported
+}
+
+// ReadConnections is not supported on non-Linux systems.
+func ReadConnections(string) (*cstructs.NetworkConnectionStats, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package netstats
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// Read returns the counters of the interfaces in the network namespace at
+// nsPath, keyed by interface name.
+func Read(nsPath string) (map[string]*cstructs.NetworkInterfaceStats, error) {
+	var stats map[string]*cstructs.NetworkInterfaceStats
+	err := nsutil.WithNetNSPath(nsPath, func(nsutil.NetNS) error {
+		// /proc/net follows the namespace of the main thread of the client,
+		// rather than the one of the thread that entered the namespace
+		f, err := os.Open("/proc/thread-self/net/dev")
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		stats, err = parseNetDev(f)
+		return err
+	})
+	return stats, err
+}
+
+// ReadConnections counts the connections of the network namespace at nsPath.
+// The conntrack count is only measured if the client has loaded the
+// nf_conntrack module.
+func ReadConnections(nsPath string) (*cstructs.NetworkConnectionStats, error) {
+	stats := new(cstructs.NetworkConnectionStats)
+	err := nsutil.WithNetNSPath(nsPath, func(nsutil.NetNS) error {
+		for _, file := range []string{"/proc/thread-self/net/tcp", "/proc/thread-self/net/tcp6"} {
+			n, err := readEstablished(file)
+			if errors.Is(err, fs.ErrNotExist) {
+				// tcp6 is missing if IPv6 is disabled
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			stats.Established += n
+		}
+		stats.Measured = append(stats.Measured, "Established")
+
+		// The sysctls of the net namespace of the thread are visible
+		b, err := os.ReadFile("/proc/sys/net/netfilter/nf_conntrack_count")
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if stats.Conntrack, err = strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64); err != nil {
+			return fmt.Errorf("invalid conntrack count: %w", err)
+		}
+		stats.Measured = append(stats.Measured, "Conntrack")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func readEstablished(file string) (uint64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return countEstablished(f)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package netstats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func Test_parseNetDev(t *testing.T) {
+	ci.Parallel(t)
+
+	const netDev = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    4312      52    0    0    0     0          0         0     4312      52    0    0    0     0       0          0
+  eth0: 1094133    8265    1    2    0     0          0         0   552876    5113    3    4    0     0       0          0
+`
+
+	stats, err := parseNetDev(strings.NewReader(netDev))
+	must.NoError(t, err)
+	must.Eq(t, map[string]*cstructs.NetworkInterfaceStats{
+		"eth0": {
+			RxBytes:   1094133,
+			RxPackets: 8265,
+			RxErrors:  1,
+			RxDropped: 2,
+			TxBytes:   552876,
+			TxPackets: 5113,
+			TxErrors:  3,
+			TxDropped: 4,
+		},
+	}, stats)
+
+	_, err = parseNetDev(strings.NewReader("header\nheader\n  eth0: 1 2 3\n"))
+	must.ErrorContains(t, err, `invalid counters of interface "eth0"`)
+}
+
+func Test_countEstablished(t *testing.T) {
+	ci.Parallel(t)
+
+	const tcp = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 31245 1 0000000000000000 100 0 0 10 0
+   1: 0A00020F:1F90 0A000201:C3A2 01 00000000:00000000 00:00000000 00000000     0        0 31301 1 0000000000000000 20 4 30 10 -1
+   2: 0A00020F:8E3C 0A000235:0035 01 00000000:00000000 00:00000000 00000000     0        0 31302 1 0000000000000000 20 4 30 10 -1
+   3: 0A00020F:8E3E 0A000235:0035 06 00000000:00000000 03:00000a5c 00000000     0        0 0 3 0000000000000000
+`
+
+	n, err := countEstablished(strings.NewReader(tcp))
+	must.NoError(t, err)
+	must.Eq(t, 2, n)
+}
@@ -190,6 +190,17 @@ func (st *Topology) NodeCores(node hw.NodeID) *idset.Set[hw.CoreID] {
 	return result
 }
 
+// CoreNodes returns the set of NUMA Node IDs of the given Core IDs.
+func (st *Topology) CoreNodes(cores *idset.Set[hw.CoreID]) *idset.Set[hw.NodeID] {
+	result := idset.Empty[hw.NodeID]()
+	for _, cpu := range st.Cores {
+		if cores.Contains(cpu.ID) {
+			result.Insert(cpu.NodeID)
+		}
+	}
+	return result
+}
+
 func (st *Topology) insert(node hw.NodeID, socket hw.SocketID, core hw.CoreID, grade CoreGrade, max, base hw.KHz) {
 	st.Cores[core] = Core{
 		NodeID:    node,
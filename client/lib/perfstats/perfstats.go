@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package perfstats provides utilities for collecting hardware performance
+// counters (cycles, instructions, cache and branch misses) for the processes of
+// a cgroup.
+package perfstats
+
+import (
+	"errors"
+	"sync"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// ErrNotSupported is returned by Open on platforms or cgroup configurations
+// where perf event counters cannot be collected per cgroup.
+var ErrNotSupported = errors.New("perf event stats are not supported")
+
+// Counters is a snapshot of the cumulative values of each hardware counter,
+// summed across all CPUs.
+type Counters struct {
+	Cycles             uint64
+	Instructions       uint64
+	CacheReferences    uint64
+	CacheMisses        uint64
+	BranchInstructions uint64
+	BranchMisses       uint64
+}
+
+// A Collector reads the hardware counters of a cgroup.
+type Collector interface {
+	// Read returns the current cumulative value of each counter.
+	Read() (*Counters, error)
+
+	// Close releases the perf events held by the Collector.
+	Close() error
+}
+
+// A Tracker converts the cumulative counters read from a Collector into the
+// usage observed since the previous call to Stats.
+type Tracker struct {
+	lock      sync.Mutex
+	collector Collector
+	prev      *Counters
+}
+
+// NewTracker creates a Tracker reading from c.
+func NewTracker(c Collector) *Tracker {
+	return &Tracker{collector: c}
+}
+
+// Stats returns the counters accumulated since the previous call, or nil if
+// this is the first sample or the counters could not be read.
+func (t *Tracker) Stats() *cstructs.PerfStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	current, err := t.collector.Read()
+	if err != nil {
+		return nil
+	}
+
+	prev := t.prev
+	t.prev = current
+	if prev == nil {
+		return nil
+	}
+
+	return Delta(prev, current)
+}
+
+// Close releases the underlying Collector.
+func (t *Tracker) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.collector.Close()
+}
+
+// Delta computes the PerfStats for the interval between two snapshots of
+// counters. Counters that went backwards (e.g. because they were reset) are
+// reported as zero.
+func Delta(prev, current *Counters) *cstructs.PerfStats {
+	sub := func(a, b uint64) uint64 {
+		if b < a {
+			return 0
+		}
+		return b - a
+	}
+
+	ps := &cstructs.PerfStats{
+		Cycles:             sub(prev.Cycles, current.Cycles),
+		Instructions:       sub(prev.Instructions, current.Instructions),
+		CacheReferences:    sub(prev.CacheReferences, current.CacheReferences),
+		CacheMisses:        sub(prev.CacheMisses, current.CacheMisses),
+		BranchInstructions: sub(prev.BranchInstructions, current.BranchInstructions),
+		BranchMisses:       sub(prev.BranchMisses, current.BranchMisses),
+	}
+	ps.ComputeRatios()
+	return ps
+}
@@ -0,0 +1,11 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package perfstats
+
+// Open is not supported on non-Linux systems.
+func Open(string) (Collector, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package perfstats
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	cpuOnline = "/sys/devices/system/cpu/online"
+
+	// readFormat requests the enabled and running times be reported alongside
+	// each counter, so values can be scaled when the kernel multiplexes more
+	// events than there are hardware counters
+	readFormat = unix.PERF_FORMAT_TOTAL_TIME_ENABLED | unix.PERF_FORMAT_TOTAL_TIME_RUNNING
+)
+
+// hardwareEvents are the generic hardware events opened for each CPU, in the
+// order their values are stored in Counters.
+var hardwareEvents = []uint64{
+	unix.PERF_COUNT_HW_CPU_CYCLES,
+	unix.PERF_COUNT_HW_INSTRUCTIONS,
+	unix.PERF_COUNT_HW_CACHE_REFERENCES,
+	unix.PERF_COUNT_HW_CACHE_MISSES,
+	unix.PERF_COUNT_HW_BRANCH_INSTRUCTIONS,
+	unix.PERF_COUNT_HW_BRANCH_MISSES,
+}
+
+type collector struct {
+	// fds contains one slice per hardware event, each with one perf event
+	// file descriptor per online CPU
+	fds [][]int
+}
+
+// Open creates a Collector counting hardware events for every process in the
+// cgroup at the given path. The cgroup must be part of the unified (v2)
+// hierarchy, which implicitly enables the perf_event controller.
+func Open(cgroup string) (Collector, error) {
+	if cgroupslib.GetMode() != cgroupslib.CG2 || cgroup == "" {
+		return nil, ErrNotSupported
+	}
+
+	cpus, err := onlineCPUs()
+	if err != nil {
+		return nil, err
+	}
+
+	cgfd, err := unix.Open(cgroup, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cgroup: %w", err)
+	}
+	defer unix.Close(cgfd)
+
+	c := &collector{fds: make([][]int, len(hardwareEvents))}
+	for i, config := range hardwareEvents {
+		for _, cpu := range cpus {
+			attr := &unix.PerfEventAttr{
+				Type:        unix.PERF_TYPE_HARDWARE,
+				Config:      config,
+				Size:        uint32(unsafe.Sizeof(unix.PerfEventAttr{})),
+				Read_format: readFormat,
+				Bits:        unix.PerfBitExcludeHv,
+			}
+			fd, err := unix.PerfEventOpen(attr, cgfd, cpu, -1, unix.PERF_FLAG_PID_CGROUP|unix.PERF_FLAG_FD_CLOEXEC)
+			if err != nil {
+				_ = c.Close()
+				return nil, fmt.Errorf("failed to open perf event: %w", err)
+			}
+			c.fds[i] = append(c.fds[i], fd)
+		}
+	}
+	return c, nil
+}
+
+func (c *collector) Read() (*Counters, error) {
+	values := make([]uint64, len(hardwareEvents))
+	buf := make([]byte, 24)
+	for i, fds := range c.fds {
+		for _, fd := range fds {
+			if _, err := unix.Read(fd, buf); err != nil {
+				return nil, err
+			}
+			values[i] += scale(
+				binary.NativeEndian.Uint64(buf[0:8]),
+				binary.NativeEndian.Uint64(buf[8:16]),
+				binary.NativeEndian.Uint64(buf[16:24]),
+			)
+		}
+	}
+	return &Counters{
+		Cycles:             values[0],
+		Instructions:       values[1],
+		CacheReferences:    values[2],
+		CacheMisses:        values[3],
+		BranchInstructions: values[4],
+		BranchMisses:       values[5],
+	}, nil
+}
+
+func (c *collector) Close() error {
+	var errs error
+	for _, fds := range c.fds {
+		for _, fd := range fds {
+			errs = errors.Join(errs, unix.Close(fd))
+		}
+	}
+	c.fds = nil
+	return errs
+}
+
+// scale estimates the full value of a counter that was only scheduled on the
+// hardware for part of the time it was enabled.
+func scale(value, enabled, running uint64) uint64 {
+	if running == 0 || running >= enabled {
+		return value
+	}
+	return uint64(float64(value) * float64(enabled) / float64(running))
+}
+
+func onlineCPUs() ([]int, error) {
+	b, err := os.ReadFile(cpuOnline)
+	if err != nil {
+		return nil, err
+	}
+	ids := idset.Parse[uint16](strings.TrimSpace(string(b))).Slice()
+	cpus := make([]int, 0, len(ids))
+	for _, id := range ids {
+		cpus = append(cpus, int(id))
+	}
+	return cpus, nil
+}
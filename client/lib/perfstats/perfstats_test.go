@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package perfstats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+type mockCollector struct {
+	samples []*Counters
+	err     error
+}
+
+func (m *mockCollector) Read() (*Counters, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	next := m.samples[0]
+	m.samples = m.samples[1:]
+	return next, nil
+}
+
+func (m *mockCollector) Close() error {
+	return nil
+}
+
+func TestDelta(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := &Counters{
+		Cycles:             1000,
+		Instructions:       1500,
+		CacheReferences:    100,
+		CacheMisses:        10,
+		BranchInstructions: 400,
+		BranchMisses:       4,
+	}
+	current := &Counters{
+		Cycles:             3000,
+		Instructions:       5500,
+		CacheReferences:    200,
+		CacheMisses:        35,
+		BranchInstructions: 800,
+		BranchMisses:       12,
+	}
+
+	must.Eq(t, &cstructs.PerfStats{
+		Cycles:               2000,
+		Instructions:         4000,
+		CacheReferences:      100,
+		CacheMisses:          25,
+		BranchInstructions:   400,
+		BranchMisses:         8,
+		InstructionsPerCycle: 2,
+		CacheMissRate:        25,
+		BranchMissRate:       2,
+	}, Delta(prev, current))
+}
+
+func TestDelta_reset(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := &Counters{Cycles: 5000, Instructions: 5000}
+	current := &Counters{Cycles: 100, Instructions: 50}
+
+	result := Delta(prev, current)
+	must.Zero(t, result.Cycles)
+	must.Zero(t, result.Instructions)
+	must.Zero(t, result.InstructionsPerCycle)
+}
+
+func TestTracker_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &mockCollector{samples: []*Counters{
+		{Cycles: 100, Instructions: 100},
+		{Cycles: 200, Instructions: 250},
+	}}
+	tracker := NewTracker(c)
+
+	// the first sample establishes the baseline
+	must.Nil(t, tracker.Stats())
+
+	stats := tracker.Stats()
+	must.NotNil(t, stats)
+	must.Eq(t, 1.5, stats.InstructionsPerCycle)
+
+	c.err = errors.New("read failed")
+	must.Nil(t, tracker.Stats())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package resctrl assigns tasks to classes of L3 cache and memory bandwidth
+// allocation of the resctrl filesystem, for Intel RDT and AMD QoS, and
+// monitors the cache occupancy and memory bandwidth of each task.
+package resctrl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// ErrNotSupported is returned by Open on platforms or systems where the
+// resctrl filesystem is not mounted.
+var ErrNotSupported = errors.New("resctrl is not supported")
+
+// GroupPrefix prefixes the names of the resctrl control groups of classes, so
+// they don't collide with groups managed by other tools.
+const GroupPrefix = "nomad-"
+
+var (
+	validName     = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	validSchemata = regexp.MustCompile(`^[A-Z0-9]+:\s*[0-9]+=[0-9a-fA-F]+(;\s*[0-9]+=[0-9a-fA-F]+)*$`)
+)
+
+// Class is a class of L3 cache and memory bandwidth allocation tasks can be
+// assigned to. Tasks of the same class share its allocation.
+type Class struct {
+	// Name identifies the class in the configuration of tasks
+	Name string `codec:"name"`
+
+	// Schemata are the lines of the schemata of the class, such as
+	// "L3:0=ff;1=ff" for cache ways or "MB:0=50;1=50" for memory bandwidth
+	Schemata []string `codec:"schemata"`
+}
+
+// Validate returns an error if the classes are invalid.
+func Validate(classes []*Class) error {
+	names := make(map[string]bool, len(classes))
+	for _, c := range classes {
+		switch {
+		case !validName.MatchString(c.Name):
+			return fmt.Errorf("resctrl class name %q must only contain letters, digits, '_' and '-'", c.Name)
+		case names[c.Name]:
+			return fmt.Errorf("duplicate resctrl class %q", c.Name)
+		case len(c.Schemata) == 0:
+			return fmt.Errorf("resctrl class %q must have at least one schemata line", c.Name)
+		}
+		names[c.Name] = true
+
+		for _, line := range c.Schemata {
+			if !validSchemata.MatchString(line) {
+				return fmt.Errorf("resctrl class %q has invalid schemata line %q", c.Name, line)
+			}
+		}
+	}
+	return nil
+}
+
+// Find returns the class named name, or an error if there is none.
+func Find(classes []*Class, name string) (*Class, error) {
+	for _, c := range classes {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("resctrl class %q is not configured", name)
+}
+
+// GroupName returns the name of the control group of the class in the resctrl
+// filesystem.
+func (c *Class) GroupName() string {
+	return GroupPrefix + c.Name
+}
+
+// Schema returns the L3 cache and memory bandwidth lines of the schemata of
+// the class, joined by newlines, as runc configures them.
+func (c *Class) Schema() (l3, mb string) {
+	var l3Lines, mbLines []string
+	for _, line := range c.Schemata {
+		if strings.HasPrefix(line, "MB:") {
+			mbLines = append(mbLines, line)
+		} else {
+			l3Lines = append(l3Lines, line)
+		}
+	}
+	return strings.Join(l3Lines, "\n"), strings.Join(mbLines, "\n")
+}
+
+// A Monitor monitors the cache occupancy and memory bandwidth of the control
+// group of a class, which its tasks share.
+type Monitor interface {
+	// Stats returns the cache occupancy of the tasks of the class and the
+	// memory traffic they caused since its group was created.
+	Stats() (*cstructs.ResctrlStats, error)
+}
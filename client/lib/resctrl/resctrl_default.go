@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package resctrl
+
+// Assign is not supported on non-Linux systems.
+func Assign(*Class, int) error {
+	return ErrNotSupported
+}
+
+// NewMonitor is not supported on non-Linux systems.
+func NewMonitor(*Class) Monitor {
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package resctrl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// root is the mount point of the resctrl filesystem, overridden by tests
+var root = "/sys/fs/resctrl"
+
+// procRoot is the mount point of procfs, overridden by tests
+var procRoot = "/proc"
+
+// Assign assigns the process pid, and its threads, to the control group of
+// class, which is created if it doesn't exist yet. Processes and threads it
+// starts later inherit the group, so the executor of a task assigns itself
+// before starting the task.
+func Assign(class *Class, pid int) error {
+	if _, err := os.Stat(filepath.Join(root, "info")); err != nil {
+		return ErrNotSupported
+	}
+
+	group := filepath.Join(root, class.GroupName())
+	if err := os.Mkdir(group, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("failed to create resctrl group: %w", err)
+	}
+	// the kernel parses schemata line by line, so each is written on its own
+	// to report which one it rejected
+	for _, line := range class.Schemata {
+		if err := os.WriteFile(filepath.Join(group, "schemata"), []byte(line+"\n"), 0o644); err != nil {
+			return fmt.Errorf("failed to write resctrl schemata %q: %w", line, err)
+		}
+	}
+
+	return assign(filepath.Join(group, "tasks"), pid)
+}
+
+// assign writes the process pid and its threads to the tasks file of a
+// resctrl group, which only moves the thread written to it.
+func assign(tasks string, pid int) error {
+	if err := os.WriteFile(tasks, []byte(strconv.Itoa(pid)), 0o644); err != nil {
+		return fmt.Errorf("failed to assign process to resctrl group: %w", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(procRoot, strconv.Itoa(pid), "task"))
+	for _, e := range entries {
+		if e.Name() == strconv.Itoa(pid) {
+			continue
+		}
+		// threads exiting meanwhile can't be assigned, which is fine
+		_ = os.WriteFile(tasks, []byte(e.Name()), 0o644)
+	}
+	return nil
+}
+
+// NewMonitor returns a Monitor of the usage of the control group of class, or
+// nil if the system doesn't support monitoring.
+func NewMonitor(class *Class) Monitor {
+	if _, err := os.Stat(filepath.Join(root, "info", "L3_MON")); err != nil {
+		return nil
+	}
+	return &monitor{path: filepath.Join(root, class.GroupName())}
+}
+
+// monitor reads the usage of a resctrl control group, summed over the domains
+// of the system.
+type monitor struct {
+	path string
+}
+
+func (m *monitor) Stats() (*cstructs.ResctrlStats, error) {
+	domains, err := os.ReadDir(filepath.Join(m.path, "mon_data"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := new(cstructs.ResctrlStats)
+	for _, d := range domains {
+		dir := filepath.Join(m.path, "mon_data", d.Name())
+		stats.LLCOccupancy += readCounter(filepath.Join(dir, "llc_occupancy"))
+		stats.MBMTotalBytes += readCounter(filepath.Join(dir, "mbm_total_bytes"))
+		stats.MBMLocalBytes += readCounter(filepath.Join(dir, "mbm_local_bytes"))
+	}
+	return stats, nil
+}
+
+// readCounter returns the value of a monitoring file, or zero if the system
+// doesn't support the event or its value is unavailable.
+func readCounter(path string) uint64 {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package resctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+// testRoot fakes a resctrl filesystem supporting monitoring, which the
+// kernel would populate with the files of each group it creates.
+func testRoot(t *testing.T) string {
+	dir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "info", "L3_MON"), 0o755))
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, GroupPrefix+"gold"), 0o755))
+
+	oldRoot, oldProc := root, procRoot
+	root, procRoot = dir, filepath.Join(dir, "proc")
+	t.Cleanup(func() { root, procRoot = oldRoot, oldProc })
+	return dir
+}
+
+func TestAssign(t *testing.T) {
+	dir := testRoot(t)
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "proc", "42", "task", "43"), 0o755))
+
+	class := &Class{Name: "gold", Schemata: []string{"L3:0=ff0", "MB:0=70"}}
+	must.NoError(t, Assign(class, 42))
+
+	group := filepath.Join(dir, GroupPrefix+"gold")
+	schemata, err := os.ReadFile(filepath.Join(group, "schemata"))
+	must.NoError(t, err)
+	must.Eq(t, "MB:0=70\n", string(schemata))
+
+	// the fake tasks file keeps the last thread written to it
+	tasks, err := os.ReadFile(filepath.Join(group, "tasks"))
+	must.NoError(t, err)
+	must.Eq(t, "43", string(tasks))
+}
+
+func TestNewMonitor(t *testing.T) {
+	dir := testRoot(t)
+
+	m := NewMonitor(&Class{Name: "gold", Schemata: []string{"L3:0=ff0"}})
+	must.NotNil(t, m)
+
+	for domain, values := range map[string][3]string{
+		"mon_L3_00": {"1048576\n", "4096\n", "1024\n"},
+		"mon_L3_01": {"2097152\n", "8192\n", "Unavailable\n"},
+	} {
+		d := filepath.Join(dir, GroupPrefix+"gold", "mon_data", domain)
+		must.NoError(t, os.MkdirAll(d, 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(d, "llc_occupancy"), []byte(values[0]), 0o644))
+		must.NoError(t, os.WriteFile(filepath.Join(d, "mbm_total_bytes"), []byte(values[1]), 0o644))
+		must.NoError(t, os.WriteFile(filepath.Join(d, "mbm_local_bytes"), []byte(values[2]), 0o644))
+	}
+
+	stats, err := m.Stats()
+	must.NoError(t, err)
+	must.Eq(t, &structs.ResctrlStats{
+		LLCOccupancy:  3145728,
+		MBMTotalBytes: 12288,
+		MBMLocalBytes: 1024,
+	}, stats)
+
+	// without monitoring support there is nothing to monitor
+	must.NoError(t, os.Remove(filepath.Join(dir, "info", "L3_MON")))
+	must.Nil(t, NewMonitor(&Class{Name: "gold"}))
+}
+
+func TestAssign_NotSupported(t *testing.T) {
+	old := root
+	root = t.TempDir()
+	t.Cleanup(func() { root = old })
+
+	err := Assign(&Class{Name: "gold", Schemata: []string{"L3:0=f"}}, 42)
+	must.ErrorIs(t, err, ErrNotSupported)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package resctrl
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestValidate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, Validate([]*Class{
+		{Name: "gold", Schemata: []string{"L3:0=ff0;1=ff0", "MB:0=70;1=70"}},
+		{Name: "bronze", Schemata: []string{"L3:0=00f"}},
+	}))
+
+	cases := []struct {
+		name    string
+		classes []*Class
+		err     string
+	}{
+		{
+			name:    "invalid name",
+			classes: []*Class{{Name: "../info", Schemata: []string{"L3:0=f"}}},
+			err:     "must only contain letters",
+		},
+		{
+			name: "duplicate name",
+			classes: []*Class{
+				{Name: "gold", Schemata: []string{"L3:0=f"}},
+				{Name: "gold", Schemata: []string{"L3:0=f0"}},
+			},
+			err: `duplicate resctrl class "gold"`,
+		},
+		{
+			name:    "no schemata",
+			classes: []*Class{{Name: "gold"}},
+			err:     "at least one schemata line",
+		},
+		{
+			name:    "invalid schemata",
+			classes: []*Class{{Name: "gold", Schemata: []string{"L3:0=ff\nMB:0=10"}}},
+			err:     "invalid schemata line",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.ErrorContains(t, Validate(tc.classes), tc.err)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	ci.Parallel(t)
+
+	classes := []*Class{{Name: "gold", Schemata: []string{"L3:0=ff0"}}}
+
+	c, err := Find(classes, "gold")
+	must.NoError(t, err)
+	must.Eq(t, classes[0], c)
+
+	_, err = Find(classes, "silver")
+	must.EqError(t, err, `resctrl class "silver" is not configured`)
+}
+
+func TestClass_Schema(t *testing.T) {
+	ci.Parallel(t)
+
+	class := &Class{Name: "gold", Schemata: []string{"L3:0=ff0;1=ff0", "MB:0=70;1=70", "L3CODE:0=f"}}
+	l3, mb := class.Schema()
+	must.Eq(t, "L3:0=ff0;1=ff0\nL3CODE:0=f", l3)
+	must.Eq(t, "MB:0=70;1=70", mb)
+	must.Eq(t, "nomad-gold", class.GroupName())
+}
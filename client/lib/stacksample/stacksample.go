@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package stacksample samples the kernel stacks of a process's threads over a
+// period of time, to show where a process is blocked or spinning without
+// attaching a debugger to it.
+package stacksample
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNotSupported is returned by Sample on platforms where kernel stacks
+// cannot be read.
+var ErrNotSupported = errors.New("stack sampling is not supported")
+
+// Stack is a distinct thread state and kernel stack observed while sampling.
+type Stack struct {
+	// Comm is the name of the thread
+	Comm string
+
+	// State is the scheduler state of the thread, such as "R" or "D"
+	State string
+
+	// Frames are the kernel stack frames, innermost first
+	Frames []string
+
+	// Count is the number of samples the stack was observed in
+	Count int
+}
+
+func (s *Stack) key() string {
+	return s.Comm + "\x00" + s.State + "\x00" + strings.Join(s.Frames, "\n")
+}
+
+// Profile is the result of sampling a process.
+type Profile struct {
+	Pid      int
+	Start    time.Time
+	Duration time.Duration
+
+	// Samples is the number of times the process's threads were sampled
+	Samples int
+
+	// Stacks are the distinct stacks observed, most frequent first
+	Stacks []*Stack
+}
+
+// add records a sample of one thread.
+func (p *Profile) add(seen map[string]*Stack, s *Stack) {
+	k := s.key()
+	if existing, ok := seen[k]; ok {
+		existing.Count++
+		return
+	}
+	s.Count = 1
+	seen[k] = s
+	p.Stacks = append(p.Stacks, s)
+}
+
+// sort orders the stacks by how often they were observed.
+func (p *Profile) sort() {
+	slices.SortStableFunc(p.Stacks, func(a, b *Stack) int {
+		return b.Count - a.Count
+	})
+}
+
+// WriteTo writes the profile as a human readable report.
+func (p *Profile) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "pid %d: %d samples over %s starting %s\n",
+		p.Pid, p.Samples, p.Duration.Round(time.Millisecond), p.Start.UTC().Format(time.RFC3339))
+	for _, s := range p.Stacks {
+		fmt.Fprintf(&buf, "\n%d/%d %s (%s)\n", s.Count, p.Samples, s.Comm, s.State)
+		for _, f := range s.Frames {
+			fmt.Fprintf(&buf, "    %s\n", f)
+		}
+	}
+	return buf.WriteTo(w)
+}
+
+// parseStack parses the contents of /proc/<pid>/task/<tid>/stack, whose lines
+// look like "[<0>] do_select+0x6a8/0x7e0".
+func parseStack(b []byte) []string {
+	var frames []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, "] "); strings.HasPrefix(line, "[") && i >= 0 {
+			line = line[i+2:]
+		}
+		if line != "" {
+			frames = append(frames, line)
+		}
+	}
+	return frames
+}
+
+// parseStat returns the command name and state from the contents of
+// /proc/<pid>/task/<tid>/stat. The command name is enclosed in parentheses
+// and may itself contain spaces and parentheses.
+func parseStat(b []byte) (string, string, error) {
+	s := string(b)
+	open, end := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if open < 0 || end < open {
+		return "", "", fmt.Errorf("malformed stat %q", s)
+	}
+	fields := strings.Fields(s[end+1:])
+	if len(fields) == 0 {
+		return "", "", fmt.Errorf("malformed stat %q", s)
+	}
+	return s[open+1 : end], fields[0], nil
+}
@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package stacksample
+
+import (
+	"context"
+	"time"
+)
+
+// Sample is not supported on non-Linux systems.
+func Sample(context.Context, int, time.Duration, time.Duration) (*Profile, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package stacksample
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Sample reads the kernel stack of every thread of the process pid once per
+// interval until duration has elapsed or ctx is canceled. Reading kernel
+// stacks requires CAP_SYS_ADMIN.
+func Sample(ctx context.Context, pid int, duration, interval time.Duration) (*Profile, error) {
+	profile := &Profile{Pid: pid, Start: time.Now()}
+	seen := map[string]*Stack{}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.After(duration)
+
+	for {
+		if err := sampleOnce(profile, seen); err != nil {
+			if profile.Samples == 0 {
+				return nil, err
+			}
+			// The process exited while being sampled; report what was seen
+			break
+		}
+
+		done := false
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			done = true
+		case <-ctx.Done():
+			done = true
+		}
+		if done {
+			break
+		}
+	}
+
+	profile.Duration = time.Since(profile.Start)
+	profile.sort()
+	return profile, nil
+}
+
+func sampleOnce(p *Profile, seen map[string]*Stack) error {
+	taskDir := filepath.Join("/proc", fmt.Sprint(p.Pid), "task")
+	tids, err := os.ReadDir(taskDir)
+	if err != nil {
+		return fmt.Errorf("failed to list threads of process %d: %w", p.Pid, err)
+	}
+
+	sampled := false
+	for _, tid := range tids {
+		stat, err := os.ReadFile(filepath.Join(taskDir, tid.Name(), "stat"))
+		if err != nil {
+			// The thread exited
+			continue
+		}
+		comm, state, err := parseStat(stat)
+		if err != nil {
+			continue
+		}
+
+		stack, err := os.ReadFile(filepath.Join(taskDir, tid.Name(), "stack"))
+		if err != nil {
+			if os.IsPermission(err) {
+				return fmt.Errorf("failed to read kernel stack of process %d: %w", p.Pid, err)
+			}
+			continue
+		}
+
+		p.add(seen, &Stack{Comm: comm, State: state, Frames: parseStack(stack)})
+		sampled = true
+	}
+	if !sampled {
+		return fmt.Errorf("no threads of process %d could be sampled", p.Pid)
+	}
+
+	p.Samples++
+	return nil
+}
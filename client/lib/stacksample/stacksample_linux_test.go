@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package stacksample
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSample(t *testing.T) {
+	ci.Parallel(t)
+
+	p, err := Sample(context.Background(), os.Getpid(), 50*time.Millisecond, 10*time.Millisecond)
+	if errors.Is(err, os.ErrPermission) {
+		t.Skip("reading kernel stacks requires CAP_SYS_ADMIN")
+	}
+	must.NoError(t, err)
+	must.Eq(t, os.Getpid(), p.Pid)
+	must.Greater(t, 1, p.Samples)
+	must.SliceNotEmpty(t, p.Stacks)
+
+	_, err = Sample(context.Background(), 1<<30, time.Millisecond, time.Millisecond)
+	must.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stacksample
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseStack(t *testing.T) {
+	ci.Parallel(t)
+
+	frames := parseStack([]byte("[<0>] do_select+0x6a8/0x7e0\n[<0>] core_sys_select+0x1d8/0x3f0\n\n"))
+	must.Eq(t, []string{"do_select+0x6a8/0x7e0", "core_sys_select+0x1d8/0x3f0"}, frames)
+
+	must.SliceEmpty(t, parseStack([]byte("")))
+}
+
+func TestParseStat(t *testing.T) {
+	ci.Parallel(t)
+
+	comm, state, err := parseStat([]byte("1234 (my (weird) proc) D 1 1234 1234 0 -1"))
+	must.NoError(t, err)
+	must.Eq(t, "my (weird) proc", comm)
+	must.Eq(t, "D", state)
+
+	_, _, err = parseStat([]byte("1234 no parens"))
+	must.Error(t, err)
+}
+
+func TestProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &Profile{Pid: 42, Start: time.Unix(0, 0), Duration: time.Second, Samples: 3}
+	seen := map[string]*Stack{}
+	p.add(seen, &Stack{Comm: "app", State: "S", Frames: []string{"futex_wait"}})
+	p.add(seen, &Stack{Comm: "app", State: "D", Frames: []string{"io_schedule"}})
+	p.add(seen, &Stack{Comm: "app", State: "D", Frames: []string{"io_schedule"}})
+	p.sort()
+
+	must.Len(t, 2, p.Stacks)
+	must.Eq(t, 2, p.Stacks[0].Count)
+	must.Eq(t, "D", p.Stacks[0].State)
+
+	var out strings.Builder
+	_, err := p.WriteTo(&out)
+	must.NoError(t, err)
+	must.StrContains(t, out.String(), "pid 42: 3 samples over 1s")
+	must.StrContains(t, out.String(), "2/3 app (D)\n    io_schedule\n")
+}
@@ -5,6 +5,7 @@ package logmon
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/nomad/client/logmon/proto"
@@ -16,10 +17,18 @@ type logmonClient struct {
 
 	// doneCtx is closed when the plugin exits
 	doneCtx context.Context
+
+	// framing is true if the last Start reported that logmon decodes framed
+	// output. Logmon from older clients don't report it.
+	framing atomic.Bool
 }
 
 const logmonRPCTimeout = 1 * time.Minute
 
+// logmonStatsTimeout bounds reading the stats of logmon, which is done on
+// every collection of the task's stats
+const logmonStatsTimeout = 5 * time.Second
+
 func (c *logmonClient) Start(cfg *LogConfig) error {
 	req := &proto.StartRequest{
 		LogDir:         cfg.LogDir,
@@ -29,12 +38,53 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
+
+		RateLimitBytesPerSecond: cfg.RateLimit.BytesPerSecond,
+		RateLimitLinesPerSecond: cfg.RateLimit.LinesPerSecond,
+		RateLimitMode:           cfg.RateLimit.Mode,
+		MultilinePattern:        cfg.MultilinePattern,
+		Framed:                  cfg.Framed,
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
 
-	_, err := c.client.Start(ctx, req)
-	return grpcutils.HandleGrpcErr(err, c.doneCtx)
+	resp, err := c.client.Start(ctx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, c.doneCtx)
+	}
+	c.framing.Store(resp.Framing)
+	return nil
+}
+
+func (c *logmonClient) FramedLogs() bool {
+	return c.framing.Load()
+}
+
+func (c *logmonClient) Stats() (*LogStats, error) {
+	req := &proto.StatsRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), logmonStatsTimeout)
+	defer cancel()
+
+	resp, err := c.client.Stats(ctx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, c.doneCtx)
+	}
+	return &LogStats{
+		Stdout: logStreamStatsFromProto(resp.Stdout),
+		Stderr: logStreamStatsFromProto(resp.Stderr),
+	}, nil
+}
+
+func logStreamStatsFromProto(pb *proto.LogStreamStats) LogStreamStats {
+	if pb == nil {
+		return LogStreamStats{}
+	}
+	return LogStreamStats{
+		Bytes:        pb.Bytes,
+		Lines:        pb.Lines,
+		DroppedBytes: pb.DroppedBytes,
+		DroppedLines: pb.DroppedLines,
+	}
 }
 
 func (c *logmonClient) Stop() error {
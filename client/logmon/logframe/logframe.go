@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package logframe implements the framed protocol executors use to ship task
+// output to logmon. Each frame carries the stream it was read from and the time
+// it was read at, so the timestamps logmon records for the output are exact and
+// don't depend on how the output is later split across log files.
+//
+// Logmon is told whether the output is framed rather than detecting it, since
+// a logmon relaunched while the task runs starts reading in the middle of the
+// stream. Writers start with a preamble, and again after a write fails, and
+// readers skip output until the next preamble or plausible frame header to
+// resynchronize.
+package logframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxPayload is the largest payload of a single frame. Larger writes are
+	// split across frames.
+	MaxPayload = 64 * 1024
+
+	// headerSize is the size of a frame's stream ID, timestamp and payload
+	// length
+	headerSize = 1 + 8 + 4
+)
+
+// Stream identifies the stream a frame's output was read from.
+type Stream byte
+
+const (
+	StreamStdout Stream = 1
+	StreamStderr Stream = 2
+)
+
+func (s Stream) String() string {
+	switch s {
+	case StreamStdout:
+		return "stdout"
+	case StreamStderr:
+		return "stderr"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(s))
+	}
+}
+
+// preamble starts every framed stream. Its last byte is the protocol version.
+var preamble = []byte{0, 'N', 'L', 'F', 0, 0, 0, 1}
+
+// Frame is a chunk of a task's output.
+type Frame struct {
+	Stream    Stream
+	Timestamp time.Time
+	Payload   []byte
+}
+
+// Writer frames the output written to it. It is safe for concurrent use.
+type Writer struct {
+	w      io.Writer
+	stream Stream
+
+	// now is overridden by tests
+	now func() time.Time
+
+	lock          sync.Mutex
+	wrotePreamble bool
+	buf           []byte
+}
+
+// NewWriter returns a Writer that frames output from stream and writes it to
+// w. The preamble is written along with the first frame.
+func NewWriter(w io.Writer, stream Stream) *Writer {
+	return &Writer{
+		w:      w,
+		stream: stream,
+		now:    time.Now,
+	}
+}
+
+// Write writes p as one or more frames timestamped with the current time.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ts := w.now()
+	n := 0
+	for n < len(p) {
+		chunk := p[n:min(len(p), n+MaxPayload)]
+
+		w.buf = w.buf[:0]
+		if !w.wrotePreamble {
+			w.buf = append(w.buf, preamble...)
+		}
+		w.buf = append(w.buf, byte(w.stream))
+		w.buf = binary.BigEndian.AppendUint64(w.buf, uint64(ts.UnixNano()))
+		w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(len(chunk)))
+		w.buf = append(w.buf, chunk...)
+
+		// The whole frame is written at once so a reader never sees a partial
+		// frame from a short write to a pipe. The reader may have gone away
+		// mid-frame, so the next frame starts with a preamble again.
+		if _, err := w.w.Write(w.buf); err != nil {
+			w.wrotePreamble = false
+			return n, err
+		}
+		w.wrotePreamble = true
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// resyncWindow bounds how far from the reader's clock the timestamp of a
+// frame header found while resynchronizing may be
+const resyncWindow = 7 * 24 * time.Hour
+
+// Reader reads the frames of a framed stream. It skips preambles, and output
+// that doesn't start with a frame header, such as when it starts reading in
+// the middle of a frame.
+type Reader struct {
+	r *bufio.Reader
+
+	// skipped counts the bytes skipped to resynchronize
+	skipped uint64
+}
+
+// NewReader returns a Reader reading frames from r.
+func NewReader(r io.Reader) *Reader {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Reader{r: br}
+}
+
+// Skipped returns the number of bytes skipped to resynchronize on a frame.
+func (r *Reader) Skipped() uint64 {
+	return r.skipped
+}
+
+// Next returns the next frame, or io.EOF once the stream ends between frames.
+func (r *Reader) Next() (*Frame, error) {
+	syncing := false
+	var header []byte
+	for {
+		var err error
+		header, err = r.r.Peek(headerSize)
+		if err != nil {
+			if err == io.EOF && len(header) == 0 {
+				return nil, io.EOF
+			}
+			if err == io.EOF && !syncing && !bytes.HasPrefix(preamble, header) {
+				return nil, fmt.Errorf("truncated frame header: %w", io.ErrUnexpectedEOF)
+			}
+			if err != io.EOF {
+				return nil, err
+			}
+		}
+
+		// Writers start with a preamble, including executors restarted with
+		// the task and those whose previous reader went away
+		if bytes.HasPrefix(header, preamble) {
+			if _, err := r.r.Discard(len(preamble)); err != nil {
+				return nil, err
+			}
+			syncing = false
+			continue
+		}
+		if err == io.EOF {
+			// The stream ended in the middle of a preamble or of the output
+			// skipped while resynchronizing
+			r.skipped += uint64(len(header))
+			r.r.Discard(len(header))
+			return nil, io.EOF
+		}
+
+		if r.validHeader(header, syncing) {
+			break
+		}
+
+		// Skip a byte at a time until a preamble or frame header is found.
+		// The header's timestamp must be close to the reader's clock, so
+		// output that happens to look like one is unlikely to be mistaken
+		// for it.
+		syncing = true
+		r.skipped++
+		if _, err := r.r.Discard(1); err != nil {
+			return nil, err
+		}
+	}
+
+	stream := Stream(header[0])
+	ts := time.Unix(0, int64(binary.BigEndian.Uint64(header[1:9])))
+	length := binary.BigEndian.Uint32(header[9:])
+	if _, err := r.r.Discard(headerSize); err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("truncated frame payload: %w", err)
+	}
+
+	return &Frame{
+		Stream:    stream,
+		Timestamp: ts,
+		Payload:   payload,
+	}, nil
+}
+
+// validHeader returns true if header is a frame header. While resynchronizing
+// the timestamp must be plausible too.
+func (r *Reader) validHeader(header []byte, syncing bool) bool {
+	switch Stream(header[0]) {
+	case StreamStdout, StreamStderr:
+	default:
+		return false
+	}
+	if binary.BigEndian.Uint32(header[9:]) > MaxPayload {
+		return false
+	}
+	if !syncing {
+		return true
+	}
+
+	ts := time.Unix(0, int64(binary.BigEndian.Uint64(header[1:9])))
+	now := time.Now()
+	return ts.After(now.Add(-resyncWindow)) && ts.Before(now.Add(resyncWindow))
+}
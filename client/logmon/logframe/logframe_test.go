@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logframe
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestLogFrame_RoundTrip(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, StreamStderr)
+	now := time.Unix(1700000000, 123456789)
+	w.now = func() time.Time { return now }
+
+	_, err := w.Write([]byte("first line\nsecond "))
+	must.NoError(t, err)
+	now = now.Add(time.Second)
+	_, err = w.Write([]byte("line\n"))
+	must.NoError(t, err)
+
+	// Writes larger than a frame are split
+	big := bytes.Repeat([]byte("x"), MaxPayload+10)
+	n, err := w.Write(big)
+	must.NoError(t, err)
+	must.Eq(t, len(big), n)
+
+	r := NewReader(&buf)
+	frame, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, StreamStderr, frame.Stream)
+	must.Eq(t, "first line\nsecond ", string(frame.Payload))
+	must.True(t, frame.Timestamp.Equal(time.Unix(1700000000, 123456789)))
+
+	frame, err = r.Next()
+	must.NoError(t, err)
+	must.Eq(t, "line\n", string(frame.Payload))
+	must.True(t, frame.Timestamp.Equal(now))
+
+	frame, err = r.Next()
+	must.NoError(t, err)
+	must.Len(t, MaxPayload, frame.Payload)
+	frame, err = r.Next()
+	must.NoError(t, err)
+	must.Len(t, 10, frame.Payload)
+
+	// A new writer on the same stream starts with another preamble
+	_, err = NewWriter(&buf, StreamStderr).Write([]byte("restarted\n"))
+	must.NoError(t, err)
+	frame, err = r.Next()
+	must.NoError(t, err)
+	must.Eq(t, "restarted\n", string(frame.Payload))
+
+	_, err = r.Next()
+	must.ErrorIs(t, err, io.EOF)
+}
+
+// TestLogFrame_Resync asserts a reader that starts in the middle of a frame,
+// like a logmon relaunched while the task writes, skips to the next frame.
+func TestLogFrame_Resync(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, StreamStdout)
+	w.now = func() time.Time { return now }
+
+	_, err := w.Write([]byte("lost output\n"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	// Start reading after the preamble and part of the first frame
+	buf.Next(len(preamble) + 5)
+
+	r := NewReader(&buf)
+	frame, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, "hello\n", string(frame.Payload))
+	must.Eq(t, headerSize-5+len("lost output\n"), int(r.Skipped()))
+
+	_, err = r.Next()
+	must.ErrorIs(t, err, io.EOF)
+}
+
+// TestLogFrame_ResyncPreamble asserts the writer starts with a preamble again
+// after a failed write, which a reader resynchronizes on.
+func TestLogFrame_ResyncPreamble(t *testing.T) {
+	ci.Parallel(t)
+
+	var out failingWriter
+	w := NewWriter(&out, StreamStderr)
+
+	_, err := w.Write([]byte("first\n"))
+	must.NoError(t, err)
+	out.fail = true
+	_, err = w.Write([]byte("lost\n"))
+	must.Error(t, err)
+	out.fail = false
+	_, err = w.Write([]byte("second\n"))
+	must.NoError(t, err)
+
+	// Only the preamble of the first frame was read
+	data := out.buf.Bytes()[len(preamble)+2:]
+	must.True(t, bytes.Contains(data, preamble))
+
+	r := NewReader(bytes.NewReader(data))
+	frame, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, StreamStderr, frame.Stream)
+	must.Eq(t, "second\n", string(frame.Payload))
+}
+
+type failingWriter struct {
+	buf  bytes.Buffer
+	fail bool
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, io.ErrClosedPipe
+	}
+	return w.buf.Write(p)
+}
+
+func TestLogFrame_Truncated(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	_, err := NewWriter(&buf, StreamStdout).Write([]byte("hello"))
+	must.NoError(t, err)
+	buf.Truncate(buf.Len() - 2)
+
+	_, err = NewReader(&buf).Next()
+	must.ErrorContains(t, err, "truncated frame payload")
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	// eventIndexHeaderSize is the size of the header of an event index
+	eventIndexHeaderSize = 8
+
+	// eventEntrySize is the size of an event index entry: the offset in the
+	// log file of the first line of an event
+	eventEntrySize = 8
+
+	// maxEventMatchSize is how much of a line is matched against the event
+	// pattern, so a task that never writes a new line can't make logmon
+	// buffer its output
+	maxEventMatchSize = 4 * 1024
+)
+
+var eventIndexMagic = []byte{'N', 'L', 'E', 'V', 1}
+
+// EventIndexName returns the name of the event index of a log file. Like
+// timestamp indexes, event indexes are hidden files next to their log files.
+func EventIndexName(logFile string) string {
+	return "." + logFile + ".events"
+}
+
+func eventIndexHeader() []byte {
+	header := make([]byte, eventIndexHeaderSize)
+	copy(header, eventIndexMagic)
+	return header
+}
+
+// EventIndex holds the offsets of a log file where the events of a task that
+// writes multiline events start, such as the first line of a stack trace. The
+// lines between two offsets are one event. The lines at the start of a file
+// before the first offset carry on with the last event of the previous file.
+type EventIndex struct {
+	offsets    []int64
+	size       int64
+	readHeader bool
+	partial    []byte
+}
+
+// Size returns how much of the index file has been read, so the next Update
+// can carry on from there.
+func (idx *EventIndex) Size() int64 {
+	return idx.size
+}
+
+// Update reads the entries added to an index file since the last Update. r must
+// read the index file from Size.
+func (idx *EventIndex) Update(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	idx.size += int64(len(b))
+	if len(idx.partial) > 0 {
+		b = append(idx.partial, b...)
+		idx.partial = nil
+	}
+
+	if !idx.readHeader {
+		if len(b) < eventIndexHeaderSize {
+			idx.partial = bytes.Clone(b)
+			return nil
+		}
+		if !bytes.Equal(b[:len(eventIndexMagic)], eventIndexMagic) {
+			return fmt.Errorf("invalid event index header")
+		}
+		idx.readHeader = true
+		b = b[eventIndexHeaderSize:]
+	}
+
+	for len(b) >= eventEntrySize {
+		idx.offsets = append(idx.offsets, int64(binary.BigEndian.Uint64(b)))
+		b = b[eventEntrySize:]
+	}
+	if len(b) > 0 {
+		idx.partial = bytes.Clone(b)
+	}
+	return nil
+}
+
+// IsStart returns true if an event starts at offset.
+func (idx *EventIndex) IsStart(offset int64) bool {
+	i := sort.Search(len(idx.offsets), func(i int) bool {
+		return idx.offsets[i] >= offset
+	})
+	return i < len(idx.offsets) && idx.offsets[i] == offset
+}
+
+// Covers returns true if the index has entries past offset, so it doesn't need
+// to be updated to look it up.
+func (idx *EventIndex) Covers(offset int64) bool {
+	return len(idx.offsets) > 0 && idx.offsets[len(idx.offsets)-1] >= offset
+}
@@ -6,9 +6,11 @@ package logging
 import (
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -40,6 +42,11 @@ type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	// EventPattern matches the first line of each event of a task that writes
+	// multiline events. If set, the offsets of the events are recorded in the
+	// event index of each log file. It must be set before the first Write.
+	EventPattern *regexp.Regexp
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
@@ -53,6 +60,32 @@ type FileRotator struct {
 	bufw        *bufio.Writer
 	bufLock     sync.Mutex
 
+	// timestamp is the time the output written next was produced at, set by
+	// SetTimestamp, and timestampPending is true until it is recorded in the
+	// timestamp index of the current file
+	timestamp        time.Time
+	timestampPending bool
+
+	// indexFile is the timestamp index of the current file, opened once the
+	// first timestamp is recorded for it, and indexBuf buffers its entries
+	// until the log file is flushed. continued is true if the current file
+	// starts in the middle of a line, and lastByte is the last byte written.
+	indexFile *os.File
+	indexBuf  []byte
+	continued bool
+	lastByte  byte
+
+	// eventFile is the event index of the current file and eventBuf buffers
+	// its entries like indexBuf. line holds the start of the line being
+	// written, which starts at lineOffset in the current file if lineInFile is
+	// true, and midLine is true while a line is being written.
+	eventFile  *os.File
+	eventBuf   []byte
+	line       []byte
+	lineOffset int64
+	lineInFile bool
+	midLine    bool
+
 	flushTicker *time.Ticker
 	logger      hclog.Logger
 	purgeCh     chan struct{}
@@ -96,12 +129,18 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 		if forceRotate || f.currentWr >= f.FileSize {
 			forceRotate = false
 			f.flushBuffer()
-			f.currentFile.Close()
+			f.closeCurrentFile()
 			if err := f.nextFile(); err != nil {
 				f.logger.Error("error creating next file", "error", err)
 				return 0, err
 			}
 		}
+
+		// Record when the output written next was produced at, including
+		// again at the start of each new file
+		if f.timestampPending {
+			f.recordTimestamp()
+		}
 		// Calculate the remaining size on this file and how much we have left
 		// to write
 		remainingSpace := f.FileSize - f.currentWr
@@ -144,7 +183,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 		n += nw
 
 		// Increment the total number of bytes in the file
-		f.currentWr += int64(n)
+		f.currentWr += int64(nw)
 		if err != nil {
 			f.logger.Error("error writing to file", "error", err)
 
@@ -232,10 +271,143 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+
+	// A new file continues the last line of the previous file unless it ended
+	// with a new line
+	f.continued = f.currentWr == 0 && f.lastByte != 0 && f.lastByte != newLineDelimiter
+	f.lineInFile = false
+	if !f.timestamp.IsZero() {
+		f.timestampPending = true
+	}
 	f.createOrResetBuffer()
 	return nil
 }
 
+// SetTimestamp sets the time the output written next was produced at, which is
+// recorded in the timestamp index of the log file it is written to.
+func (f *FileRotator) SetTimestamp(ts time.Time) {
+	f.timestamp = ts
+	f.timestampPending = true
+}
+
+// recordTimestamp adds the pending timestamp for the current offset to the
+// timestamp index of the current file.
+func (f *FileRotator) recordTimestamp() {
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+
+	f.timestampPending = false
+	if f.indexFile == nil {
+		name := filepath.Join(f.path, TimestampIndexName(filepath.Base(f.currentFile.Name())))
+		indexFile, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			f.logger.Error("error opening timestamp index", "error", err)
+			return
+		}
+		fi, err := indexFile.Stat()
+		if err != nil {
+			indexFile.Close()
+			f.logger.Error("error opening timestamp index", "error", err)
+			return
+		}
+		f.indexFile = indexFile
+		if fi.Size() == 0 {
+			f.indexBuf = append(f.indexBuf, timestampIndexHeader(f.continued)...)
+		}
+	}
+	f.indexBuf = appendTimestampEntry(f.indexBuf, f.currentWr, f.timestamp)
+}
+
+// scanEvents records the events that start in p, which is about to be written
+// at the current offset. It must be called with the bufLock held.
+func (f *FileRotator) scanEvents(p []byte) {
+	if f.eventFile == nil {
+		name := filepath.Join(f.path, EventIndexName(filepath.Base(f.currentFile.Name())))
+		eventFile, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			f.logger.Error("error opening event index", "error", err)
+			return
+		}
+		fi, err := eventFile.Stat()
+		if err != nil {
+			eventFile.Close()
+			f.logger.Error("error opening event index", "error", err)
+			return
+		}
+		f.eventFile = eventFile
+		if fi.Size() == 0 {
+			f.eventBuf = append(f.eventBuf, eventIndexHeader()...)
+		}
+	}
+
+	offset := f.currentWr
+	for len(p) > 0 {
+		if !f.midLine {
+			f.midLine = true
+			f.line = f.line[:0]
+			f.lineOffset = offset
+			f.lineInFile = true
+		}
+
+		end := bytes.IndexByte(p, newLineDelimiter)
+		segment := p
+		if end >= 0 {
+			segment = p[:end]
+		}
+		if room := maxEventMatchSize - len(f.line); room > 0 {
+			f.line = append(f.line, segment[:min(len(segment), room)]...)
+		}
+		if end < 0 {
+			return
+		}
+
+		// Events that start in the previous file are left out of its index
+		// rather than recorded at the wrong offset
+		if f.lineInFile && f.EventPattern.Match(f.line) {
+			f.eventBuf = binary.BigEndian.AppendUint64(f.eventBuf, uint64(f.lineOffset))
+		}
+		f.midLine = false
+		p = p[end+1:]
+		offset += int64(end + 1)
+	}
+}
+
+// flushIndex writes the buffered timestamp and event index entries. It must be
+// called with the bufLock held, before the log output they index is written,
+// so readers never see output without its timestamp or event.
+func (f *FileRotator) flushIndex() {
+	if f.indexFile != nil && len(f.indexBuf) > 0 {
+		if _, err := f.indexFile.Write(f.indexBuf); err != nil {
+			f.logger.Error("error writing timestamp index", "error", err)
+		}
+		f.indexBuf = f.indexBuf[:0]
+	}
+	if f.eventFile != nil && len(f.eventBuf) > 0 {
+		if _, err := f.eventFile.Write(f.eventBuf); err != nil {
+			f.logger.Error("error writing event index", "error", err)
+		}
+		f.eventBuf = f.eventBuf[:0]
+	}
+}
+
+// closeCurrentFile closes the current file and its timestamp index.
+func (f *FileRotator) closeCurrentFile() {
+	f.currentFile.Close()
+
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+	if f.indexFile != nil {
+		f.indexFile.Close()
+		f.indexFile = nil
+	}
+	f.indexBuf = f.indexBuf[:0]
+	if f.eventFile != nil {
+		f.eventFile.Close()
+		f.eventFile = nil
+	}
+	f.eventBuf = f.eventBuf[:0]
+}
+
 // flushPeriodically flushes the buffered writer every 100ms to the underlying
 // file
 func (f *FileRotator) flushPeriodically() {
@@ -264,7 +436,7 @@ func (f *FileRotator) Close() error {
 		close(f.doneCh)
 		close(f.purgeCh)
 		f.closed = true
-		f.currentFile.Close()
+		f.closeCurrentFile()
 	}
 
 	return nil
@@ -311,6 +483,14 @@ func (f *FileRotator) purgeOldFiles() {
 				if err != nil {
 					f.logger.Error("error removing file", "filename", fname, "error", err)
 				}
+				for _, index := range []string{
+					filepath.Join(f.path, TimestampIndexName(filepath.Base(fname))),
+					filepath.Join(f.path, EventIndexName(filepath.Base(fname))),
+				} {
+					if err := os.Remove(index); err != nil && !os.IsNotExist(err) {
+						f.logger.Error("error removing file", "filename", index, "error", err)
+					}
+				}
 			}
 
 			f.fileLock.Lock()
@@ -327,6 +507,7 @@ func (f *FileRotator) flushBuffer() error {
 	f.bufLock.Lock()
 	defer f.bufLock.Unlock()
 	if f.bufw != nil {
+		f.flushIndex()
 		return f.bufw.Flush()
 	}
 	return nil
@@ -336,7 +517,21 @@ func (f *FileRotator) flushBuffer() error {
 func (f *FileRotator) writeToBuffer(p []byte) (int, error) {
 	f.bufLock.Lock()
 	defer f.bufLock.Unlock()
-	return f.bufw.Write(p)
+
+	if f.EventPattern != nil {
+		f.scanEvents(p)
+	}
+
+	// The buffer writes through to the file once it fills up, so the indexes
+	// have to be written first
+	if len(p) > f.bufw.Available() {
+		f.flushIndex()
+	}
+	n, err := f.bufw.Write(p)
+	if n > 0 {
+		f.lastByte = p[n-1]
+	}
+	return n, err
 }
 
 // createOrResetBuffer creates a new buffer if we don't have one otherwise
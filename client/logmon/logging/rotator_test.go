@@ -4,11 +4,14 @@
 package logging
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -311,3 +314,101 @@ func benchmarkRotatorWithInputSize(size int, b *testing.B) {
 		must.NoError(b, err)
 	}
 }
+
+func TestFileRotator_TimestampIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	// Output without a timestamp isn't indexed
+	_, err = fr.Write([]byte("plain\n"))
+	must.NoError(t, err)
+
+	first := time.Unix(1700000000, 0)
+	fr.SetTimestamp(first)
+	_, err = fr.Write([]byte("abc"))
+	must.NoError(t, err)
+
+	// The line carries over to the next file, which is indexed from its start
+	second := first.Add(time.Second)
+	fr.SetTimestamp(second)
+	_, err = fr.Write([]byte("defg\n"))
+	must.NoError(t, err)
+	must.NoError(t, fr.Close())
+
+	readIndex := func(name string) *TimestampIndex {
+		f, err := os.Open(filepath.Join(path, TimestampIndexName(name)))
+		must.NoError(t, err)
+		defer f.Close()
+		var idx TimestampIndex
+		must.NoError(t, idx.Update(f))
+		return &idx
+	}
+
+	idx := readIndex("redis.stdout.0")
+	must.False(t, idx.Continued)
+	_, ok := idx.Lookup(0)
+	must.False(t, ok)
+	ts, ok := idx.Lookup(6)
+	must.True(t, ok)
+	must.Eq(t, first, ts)
+	ts, ok = idx.Lookup(9)
+	must.True(t, ok)
+	must.Eq(t, second, ts)
+
+	idx = readIndex("redis.stdout.1")
+	must.True(t, idx.Continued)
+	ts, ok = idx.Lookup(0)
+	must.True(t, ok)
+	must.Eq(t, second, ts)
+}
+
+func TestTimestampIndex_PartialUpdate(t *testing.T) {
+	header := timestampIndexHeader(false)
+	b := appendTimestampEntry(header, 10, time.Unix(10, 0))
+	b = appendTimestampEntry(b, 20, time.Unix(20, 0))
+
+	// Reading the index as it is written only uses whole entries
+	var idx TimestampIndex
+	must.NoError(t, idx.Update(bytes.NewReader(b[:4])))
+	must.NoError(t, idx.Update(bytes.NewReader(b[4:30])))
+	must.True(t, idx.Covers(10))
+	must.False(t, idx.Covers(20))
+	must.NoError(t, idx.Update(bytes.NewReader(b[30:])))
+	must.Eq(t, int64(len(b)), idx.Size())
+
+	ts, ok := idx.Lookup(25)
+	must.True(t, ok)
+	must.Eq(t, time.Unix(20, 0), ts)
+}
+
+func TestFileRotator_EventIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 1024, testlog.HCLogger(t))
+	must.NoError(t, err)
+	fr.EventPattern = regexp.MustCompile(`^\S`)
+
+	// A stack trace written over several writes is one event
+	for _, p := range []string{"Exception in main\n", "\tat foo\n\tat", " bar\n", "next event\n"} {
+		_, err = fr.Write([]byte(p))
+		must.NoError(t, err)
+	}
+	must.NoError(t, fr.Close())
+
+	f, err := os.Open(filepath.Join(path, EventIndexName("redis.stdout.0")))
+	must.NoError(t, err)
+	defer f.Close()
+
+	var idx EventIndex
+	must.NoError(t, idx.Update(f))
+	must.True(t, idx.IsStart(0))
+	must.False(t, idx.IsStart(18))
+	must.False(t, idx.IsStart(26))
+	must.True(t, idx.IsStart(34))
+}
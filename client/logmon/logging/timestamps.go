@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	// timestampIndexHeaderSize is the size of the header of a timestamp index:
+	// its magic, version and flags
+	timestampIndexHeaderSize = 8
+
+	// timestampEntrySize is the size of a timestamp index entry: the offset in
+	// the log file and the time the output there was produced at
+	timestampEntrySize = 16
+
+	// timestampFlagContinued is set in a timestamp index's flags when its log
+	// file starts in the middle of a line carried over from the previous file
+	timestampFlagContinued = 1 << 0
+)
+
+var timestampIndexMagic = []byte{'N', 'L', 'T', 'I', 1}
+
+// TimestampIndexName returns the name of the timestamp index of a log file.
+// Indexes are hidden files next to their log files so they are never mistaken
+// for rotated log files.
+func TimestampIndexName(logFile string) string {
+	return "." + logFile + ".ts"
+}
+
+// timestampIndexHeader returns the header of a new timestamp index.
+func timestampIndexHeader(continued bool) []byte {
+	header := make([]byte, timestampIndexHeaderSize)
+	copy(header, timestampIndexMagic)
+	if continued {
+		header[len(timestampIndexMagic)] = timestampFlagContinued
+	}
+	return header
+}
+
+// appendTimestampEntry appends an entry for output at offset in the log file
+// to an index.
+func appendTimestampEntry(b []byte, offset int64, ts time.Time) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(offset))
+	return binary.BigEndian.AppendUint64(b, uint64(ts.UnixNano()))
+}
+
+type timestampEntry struct {
+	offset int64
+	ts     int64
+}
+
+// TimestampIndex maps the offsets of a log file to the time the output there
+// was produced at. It is only written for the output of executors that ship
+// it framed with timestamps.
+type TimestampIndex struct {
+	// Continued is true if the log file starts in the middle of a line carried
+	// over from the previous file
+	Continued bool
+
+	entries    []timestampEntry
+	size       int64
+	readHeader bool
+	partial    []byte
+}
+
+// Size returns how much of the index file has been read, so the next Update
+// can carry on from there.
+func (idx *TimestampIndex) Size() int64 {
+	return idx.size
+}
+
+// Update reads the entries added to an index file since the last Update. r must
+// read the index file from Size.
+func (idx *TimestampIndex) Update(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	idx.size += int64(len(b))
+	if len(idx.partial) > 0 {
+		b = append(idx.partial, b...)
+		idx.partial = nil
+	}
+
+	if !idx.readHeader {
+		if len(b) < timestampIndexHeaderSize {
+			idx.partial = bytes.Clone(b)
+			return nil
+		}
+		if !bytes.Equal(b[:len(timestampIndexMagic)], timestampIndexMagic) {
+			return fmt.Errorf("invalid timestamp index header")
+		}
+		idx.Continued = b[len(timestampIndexMagic)]&timestampFlagContinued != 0
+		idx.readHeader = true
+		b = b[timestampIndexHeaderSize:]
+	}
+
+	for len(b) >= timestampEntrySize {
+		idx.entries = append(idx.entries, timestampEntry{
+			offset: int64(binary.BigEndian.Uint64(b)),
+			ts:     int64(binary.BigEndian.Uint64(b[8:])),
+		})
+		b = b[timestampEntrySize:]
+	}
+	if len(b) > 0 {
+		// Keep the rest of an entry that wasn't fully written yet
+		idx.partial = bytes.Clone(b)
+	}
+	return nil
+}
+
+// Lookup returns the time the output at offset in the log file was produced
+// at, or false if it isn't indexed.
+func (idx *TimestampIndex) Lookup(offset int64) (time.Time, bool) {
+	i := sort.Search(len(idx.entries), func(i int) bool {
+		return idx.entries[i].offset > offset
+	})
+	if i == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, idx.entries[i-1].ts), true
+}
+
+// Covers returns true if the index has entries past offset, so it doesn't need
+// to be updated to look it up.
+func (idx *TimestampIndex) Covers(offset int64) bool {
+	return len(idx.entries) > 0 && idx.entries[len(idx.entries)-1].offset >= offset
+}
@@ -4,15 +4,20 @@
 package logmon
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logframe"
 	"github.com/hashicorp/nomad/client/logmon/logging"
 )
 
@@ -44,11 +49,61 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// RateLimit limits the rate of output written to each of the log files
+	RateLimit RateLimit
+
+	// MultilinePattern matches the first line of each event of a task that
+	// writes multiline events, which are recorded in the log files' event
+	// indexes
+	MultilinePattern string
+
+	// Framed is true if the executor frames the output it writes to the
+	// fifos. It is passed explicitly since a logmon relaunched while the task
+	// runs can't tell the output is framed from where it starts reading.
+	Framed bool
 }
 
 type LogMon interface {
 	Start(*LogConfig) error
 	Stop() error
+
+	// Stats returns the volume of output written to the log files since
+	// logmon was launched, including before the task was restarted.
+	Stats() (*LogStats, error)
+
+	// FramedLogs returns true if logmon decodes the framed output of
+	// executors when Start is configured with Framed, as reported by the last
+	// call to Start. Executors must only frame their output if it does, since
+	// a logmon reattached from an older client would write the frames to the
+	// log files as they are.
+	FramedLogs() bool
+}
+
+// LogStats counts the output of a task written to its stdout and stderr log
+// files.
+type LogStats struct {
+	Stdout LogStreamStats
+	Stderr LogStreamStats
+}
+
+// LogStreamStats counts the bytes and lines of output written to a log file,
+// and dropped for exceeding the rate limit.
+type LogStreamStats struct {
+	Bytes uint64
+	Lines uint64
+
+	DroppedBytes uint64
+	DroppedLines uint64
+}
+
+func (s LogStreamStats) add(o LogStreamStats) LogStreamStats {
+	return LogStreamStats{
+		Bytes:        s.Bytes + o.Bytes,
+		Lines:        s.Lines + o.Lines,
+		DroppedBytes: s.DroppedBytes + o.DroppedBytes,
+		DroppedLines: s.DroppedLines + o.DroppedLines,
+	}
 }
 
 func NewLogMon(logger hclog.Logger) LogMon {
@@ -61,6 +116,10 @@ type logmonImpl struct {
 	logger hclog.Logger
 	tl     *TaskLogger
 	lock   sync.Mutex
+
+	// closed counts the output of the TaskLoggers closed when the task
+	// restarted
+	closed LogStats
 }
 
 func (l *logmonImpl) Start(cfg *LogConfig) error {
@@ -76,6 +135,9 @@ func (l *logmonImpl) Start(cfg *LogConfig) error {
 	// restart the TaskLogger
 	if !l.tl.IsRunning() {
 		l.tl.Close()
+		stats := l.tl.Stats()
+		l.closed.Stdout = l.closed.Stdout.add(stats.Stdout)
+		l.closed.Stderr = l.closed.Stderr.add(stats.Stderr)
 		return l.start(cfg)
 	}
 
@@ -92,6 +154,10 @@ func (l *logmonImpl) start(cfg *LogConfig) error {
 	return nil
 }
 
+func (l *logmonImpl) FramedLogs() bool {
+	return true
+}
+
 func (l *logmonImpl) Stop() error {
 	l.lock.Lock()
 	defer l.lock.Unlock()
@@ -101,6 +167,19 @@ func (l *logmonImpl) Stop() error {
 	return nil
 }
 
+func (l *logmonImpl) Stats() (*LogStats, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	stats := l.closed
+	if l.tl != nil {
+		current := l.tl.Stats()
+		stats.Stdout = stats.Stdout.add(current.Stdout)
+		stats.Stderr = stats.Stderr.add(current.Stderr)
+	}
+	return &stats, nil
+}
+
 type TaskLogger struct {
 	config *LogConfig
 
@@ -119,6 +198,18 @@ func (tl *TaskLogger) IsRunning() bool {
 	return lroRunning && lreRunning
 }
 
+// Stats returns the output written to the log files by the TaskLogger
+func (tl *TaskLogger) Stats() LogStats {
+	var stats LogStats
+	if tl.lro != nil {
+		stats.Stdout = tl.lro.stats()
+	}
+	if tl.lre != nil {
+		stats.Stderr = tl.lre.stats()
+	}
+	return stats
+}
+
 func (tl *TaskLogger) Close() {
 	var wg sync.WaitGroup
 	if tl.lro != nil {
@@ -141,14 +232,23 @@ func (tl *TaskLogger) Close() {
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
+	var eventPattern *regexp.Regexp
+	if cfg.MultilinePattern != "" {
+		var err error
+		if eventPattern, err = regexp.Compile(cfg.MultilinePattern); err != nil {
+			return nil, fmt.Errorf("invalid multiline pattern: %v", err)
+		}
+	}
+
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
 	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
 		cfg.MaxFiles, logFileSize, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
+	lro.EventPattern = eventPattern
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro, cfg.RateLimit, logframe.StreamStdout, cfg.Framed)
 	if err != nil {
 		return nil, err
 	}
@@ -160,8 +260,9 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
+	lre.EventPattern = eventPattern
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre, cfg.RateLimit, logframe.StreamStderr, cfg.Framed)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +278,11 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 // data will be copied from the reader to the rotator.
 type logRotatorWrapper struct {
 	fifoPath          string
+	stream            logframe.Stream
+	framed            bool
 	rotatorWriter     io.WriteCloser
+	counter           *logCounter
+	limiter           *rateLimiter
 	hasFinishedCopied chan struct{}
 	logger            hclog.Logger
 
@@ -196,8 +301,10 @@ func (l *logRotatorWrapper) isRunning() bool {
 }
 
 // newLogRotatorWrapper takes a rotator and returns a wrapper that has the
-// processOutWriter to attach to the stdout or stderr of a process.
-func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteCloser) (*logRotatorWrapper, error) {
+// processOutWriter to attach to the stdout or stderr of a process, and limits
+// the rate of output written to the rotator. The output of stream is decoded
+// if the executor frames it.
+func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteCloser, limit RateLimit, stream logframe.Stream, framed bool) (*logRotatorWrapper, error) {
 	logger.Debug("opening fifo", "path", path)
 
 	var openFn func() (io.ReadCloser, error)
@@ -219,11 +326,17 @@ func newLogRotatorWrapper(path string, logger hclog.Logger, rotator io.WriteClos
 
 	wrap := &logRotatorWrapper{
 		fifoPath:          path,
+		stream:            stream,
+		framed:            framed,
 		rotatorWriter:     rotator,
+		counter:           &logCounter{w: rotator},
 		hasFinishedCopied: make(chan struct{}),
 		openCompleted:     make(chan struct{}),
 		logger:            logger,
 	}
+	if limit.enabled() {
+		wrap.limiter = newRateLimiter(wrap.counter, limit)
+	}
 
 	wrap.start(openFn)
 	return wrap, nil
@@ -243,7 +356,11 @@ func (l *logRotatorWrapper) start(openFn func() (io.ReadCloser, error)) {
 		l.processOutReader = reader
 		close(l.openCompleted)
 
-		_, err = io.Copy(l.rotatorWriter, reader)
+		var w io.Writer = l.counter
+		if l.limiter != nil {
+			w = l.limiter
+		}
+		err = l.copy(w, reader)
 		if err != nil {
 			l.logger.Warn("failed to read from log fifo", "error", err)
 			// Close reader to propagate io error across pipe.
@@ -258,6 +375,52 @@ func (l *logRotatorWrapper) start(openFn func() (io.ReadCloser, error)) {
 	}()
 }
 
+// copy copies the output read from the fifo to w, decoding it first if the
+// executor frames it.
+func (l *logRotatorWrapper) copy(w io.Writer, reader io.Reader) error {
+	if !l.framed {
+		_, err := io.Copy(w, reader)
+		return err
+	}
+
+	timestamper, _ := l.rotatorWriter.(interface{ SetTimestamp(time.Time) })
+	frames := logframe.NewReader(bufio.NewReaderSize(reader, logframe.MaxPayload))
+	var skipped uint64
+	for {
+		frame, err := frames.Next()
+		if n := frames.Skipped(); n > skipped {
+			// Reading started in the middle of a frame, such as after logmon
+			// was relaunched while the task was writing
+			l.logger.Warn("skipped output to resynchronize with the framed stream", "bytes", n-skipped)
+			skipped = n
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if frame.Stream != l.stream {
+			l.logger.Warn("received output for another stream", "expected", l.stream, "received", frame.Stream)
+		}
+		if timestamper != nil {
+			timestamper.SetTimestamp(frame.Timestamp)
+		}
+		if _, err := w.Write(frame.Payload); err != nil {
+			return err
+		}
+	}
+}
+
+// stats returns the output written to the rotator and dropped by the rate
+// limiter.
+func (l *logRotatorWrapper) stats() LogStreamStats {
+	stats := l.counter.stats()
+	if l.limiter != nil {
+		stats.DroppedBytes, stats.DroppedLines = l.limiter.dropped()
+	}
+	return stats
+}
+
 // Close closes the rotator and the process writer to ensure that the Wait
 // command exits.
 func (l *logRotatorWrapper) Close() {
@@ -297,3 +460,21 @@ func (l *logRotatorWrapper) Close() {
 
 	l.rotatorWriter.Close()
 }
+
+// logCounter counts the bytes and lines written through it to the rotator.
+type logCounter struct {
+	w     io.Writer
+	bytes atomic.Uint64
+	lines atomic.Uint64
+}
+
+func (c *logCounter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.bytes.Add(uint64(n))
+	c.lines.Add(uint64(bytes.Count(p[:n], []byte{'\n'})))
+	return n, err
+}
+
+func (c *logCounter) stats() LogStreamStats {
+	return LogStreamStats{Bytes: c.bytes.Load(), Lines: c.lines.Load()}
+}
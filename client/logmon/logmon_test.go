@@ -4,6 +4,7 @@
 package logmon
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"os"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logframe"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/testutil"
@@ -76,6 +79,77 @@ func TestLogmon_Start_rotate(t *testing.T) {
 	must.NoError(t, lm.Stop())
 }
 
+// TestLogmon_Start_framed asserts that output framed by the executor is
+// decoded and its timestamps are indexed.
+func TestLogmon_Start_framed(t *testing.T) {
+	ci.Parallel(t)
+
+	var stdoutFifoPath, stderrFifoPath string
+
+	dir := t.TempDir()
+
+	if runtime.GOOS == "windows" {
+		stdoutFifoPath = "//./pipe/test-framed.stdout"
+		stderrFifoPath = "//./pipe/test-framed.stderr"
+	} else {
+		stdoutFifoPath = filepath.Join(dir, "stdout.fifo")
+		stderrFifoPath = filepath.Join(dir, "stderr.fifo")
+	}
+
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    stdoutFifoPath,
+		StderrLogFile: "stderr",
+		StderrFifo:    stderrFifoPath,
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Framed:        true,
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+	must.True(t, lm.FramedLogs())
+
+	stdout, err := fifo.OpenWriter(stdoutFifoPath)
+	must.NoError(t, err)
+	defer stdout.Close()
+
+	// Only the second frame is written, which has no preamble
+	var buf bytes.Buffer
+	w := logframe.NewWriter(&buf, logframe.StreamStdout)
+	_, err = w.Write([]byte("lost\n"))
+	must.NoError(t, err)
+	buf.Reset()
+	_, err = w.Write([]byte("hello\n"))
+	must.NoError(t, err)
+	_, err = stdout.Write(buf.Bytes())
+	must.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(filepath.Join(dir, "stdout.0"))
+		if err != nil {
+			return false, err
+		}
+		if string(b) != "hello\n" {
+			return false, fmt.Errorf("unexpected log output %q", b)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+	must.NoError(t, lm.Stop())
+
+	f, err := os.Open(filepath.Join(dir, logging.TimestampIndexName("stdout.0")))
+	must.NoError(t, err)
+	defer f.Close()
+
+	var idx logging.TimestampIndex
+	must.NoError(t, idx.Update(f))
+	_, ok := idx.Lookup(0)
+	must.True(t, ok)
+}
+
 // asserts that calling Start twice restarts the log rotator and that any logs
 // published while the listener was unavailable are received.
 func TestLogmon_Start_restart_flusheslogs(t *testing.T) {
@@ -181,6 +255,11 @@ func TestLogmon_Start_restart_flusheslogs(t *testing.T) {
 	}, func(err error) {
 		must.NoError(t, err)
 	})
+
+	// The output is counted across the restart
+	stats, err := lm.Stats()
+	must.NoError(t, err)
+	must.Eq(t, &LogStats{Stdout: LogStreamStats{Bytes: 10, Lines: 2}}, stats)
 }
 
 // asserts that calling Start twice restarts the log rotator
@@ -247,6 +326,10 @@ func TestLogmon_Start_restart(t *testing.T) {
 		must.NoError(t, err)
 	})
 
+	// Closing stdout also keeps it open until now, rather than until it's
+	// garbage collected
+	must.NoError(t, stdout.Close())
+
 	// Start logmon again and assert that it can receive logs again
 	must.NoError(t, lm.Start(cfg))
 
@@ -300,7 +383,7 @@ func TestLogmon_NewError(t *testing.T) {
 	// No code that uses the writer should get hit
 	rotator := panicWriter{}
 
-	w, err := newLogRotatorWrapper(path, logger, rotator)
+	w, err := newLogRotatorWrapper(path, logger, rotator, RateLimit{}, logframe.StreamStdout, false)
 	must.Error(t, err)
 	must.Nil(t, w)
 }
@@ -25,16 +25,21 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir                  string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName          string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName          string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles                uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb           uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo              string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo              string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	RateLimitBytesPerSecond uint64   `protobuf:"varint,8,opt,name=rate_limit_bytes_per_second,json=rateLimitBytesPerSecond,proto3" json:"rate_limit_bytes_per_second,omitempty"`
+	RateLimitLinesPerSecond uint64   `protobuf:"varint,9,opt,name=rate_limit_lines_per_second,json=rateLimitLinesPerSecond,proto3" json:"rate_limit_lines_per_second,omitempty"`
+	RateLimitMode           string   `protobuf:"bytes,10,opt,name=rate_limit_mode,json=rateLimitMode,proto3" json:"rate_limit_mode,omitempty"`
+	MultilinePattern        string   `protobuf:"bytes,11,opt,name=multiline_pattern,json=multilinePattern,proto3" json:"multiline_pattern,omitempty"`
+	Framed                  bool     `protobuf:"varint,12,opt,name=framed,proto3" json:"framed,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,7 +116,44 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetRateLimitBytesPerSecond() uint64 {
+	if m != nil {
+		return m.RateLimitBytesPerSecond
+	}
+	return 0
+}
+
+func (m *StartRequest) GetRateLimitLinesPerSecond() uint64 {
+	if m != nil {
+		return m.RateLimitLinesPerSecond
+	}
+	return 0
+}
+
+func (m *StartRequest) GetRateLimitMode() string {
+	if m != nil {
+		return m.RateLimitMode
+	}
+	return ""
+}
+
+func (m *StartRequest) GetMultilinePattern() string {
+	if m != nil {
+		return m.MultilinePattern
+	}
+	return ""
+}
+
+func (m *StartRequest) GetFramed() bool {
+	if m != nil {
+		return m.Framed
+	}
+	return false
+}
+
 type StartResponse struct {
+	// framing is true if logmon decodes framed output from the executor
+	Framing              bool     `protobuf:"varint,1,opt,name=framing,proto3" json:"framing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -142,6 +184,13 @@ func (m *StartResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StartResponse proto.InternalMessageInfo
 
+func (m *StartResponse) GetFraming() bool {
+	if m != nil {
+		return m.Framing
+	}
+	return false
+}
+
 type StopRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -204,11 +253,155 @@ func (m *StopResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
+type StatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
+func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()    {}
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
+}
+
+func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsRequest.Unmarshal(m, b)
+}
+func (m *StatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsRequest.Marshal(b, m, deterministic)
+}
+func (m *StatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsRequest.Merge(m, src)
+}
+func (m *StatsRequest) XXX_Size() int {
+	return xxx_messageInfo_StatsRequest.Size(m)
+}
+func (m *StatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsRequest proto.InternalMessageInfo
+
+type StatsResponse struct {
+	Stdout               *LogStreamStats `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr               *LogStreamStats `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{5}
+}
+
+func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsResponse.Unmarshal(m, b)
+}
+func (m *StatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsResponse.Marshal(b, m, deterministic)
+}
+func (m *StatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsResponse.Merge(m, src)
+}
+func (m *StatsResponse) XXX_Size() int {
+	return xxx_messageInfo_StatsResponse.Size(m)
+}
+func (m *StatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsResponse proto.InternalMessageInfo
+
+func (m *StatsResponse) GetStdout() *LogStreamStats {
+	if m != nil {
+		return m.Stdout
+	}
+	return nil
+}
+
+func (m *StatsResponse) GetStderr() *LogStreamStats {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+type LogStreamStats struct {
+	Bytes                uint64   `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Lines                uint64   `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	DroppedBytes         uint64   `protobuf:"varint,3,opt,name=dropped_bytes,json=droppedBytes,proto3" json:"dropped_bytes,omitempty"`
+	DroppedLines         uint64   `protobuf:"varint,4,opt,name=dropped_lines,json=droppedLines,proto3" json:"dropped_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogStreamStats) Reset()         { *m = LogStreamStats{} }
+func (m *LogStreamStats) String() string { return proto.CompactTextString(m) }
+func (*LogStreamStats) ProtoMessage()    {}
+func (*LogStreamStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{6}
+}
+
+func (m *LogStreamStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogStreamStats.Unmarshal(m, b)
+}
+func (m *LogStreamStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogStreamStats.Marshal(b, m, deterministic)
+}
+func (m *LogStreamStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogStreamStats.Merge(m, src)
+}
+func (m *LogStreamStats) XXX_Size() int {
+	return xxx_messageInfo_LogStreamStats.Size(m)
+}
+func (m *LogStreamStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogStreamStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogStreamStats proto.InternalMessageInfo
+
+func (m *LogStreamStats) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *LogStreamStats) GetLines() uint64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *LogStreamStats) GetDroppedBytes() uint64 {
+	if m != nil {
+		return m.DroppedBytes
+	}
+	return 0
+}
+
+func (m *LogStreamStats) GetDroppedLines() uint64 {
+	if m != nil {
+		return m.DroppedLines
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
+	proto.RegisterType((*StatsRequest)(nil), "hashicorp.nomad.client.logmon.proto.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "hashicorp.nomad.client.logmon.proto.StatsResponse")
+	proto.RegisterType((*LogStreamStats)(nil), "hashicorp.nomad.client.logmon.proto.LogStreamStats")
 }
 
 func init() {
@@ -216,27 +409,42 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 559 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xcf, 0x4e, 0xdc, 0x3e,
+	0x10, 0xc7, 0x09, 0x64, 0xff, 0x30, 0xbb, 0xe1, 0xc7, 0xcf, 0xaa, 0x8a, 0x05, 0x87, 0xae, 0x82,
+	0xd4, 0x6e, 0x55, 0x29, 0x14, 0xb8, 0xf6, 0x84, 0xaa, 0x5e, 0xba, 0x54, 0x28, 0x7b, 0xeb, 0x25,
+	0xf2, 0x92, 0x49, 0xb0, 0x14, 0xc7, 0xa9, 0x6d, 0x24, 0xca, 0x03, 0xf4, 0x5d, 0xfa, 0x4c, 0x7d,
+	0x85, 0x3e, 0x44, 0x15, 0xdb, 0x9b, 0x06, 0x4e, 0xbb, 0xea, 0x29, 0x9a, 0x99, 0xcf, 0x77, 0x3c,
+	0xa3, 0xf9, 0x06, 0x66, 0xb7, 0x15, 0xc7, 0xda, 0x9c, 0x55, 0xb2, 0x14, 0xb2, 0x3e, 0x6b, 0x94,
+	0x34, 0xd2, 0x07, 0x89, 0x0d, 0xc8, 0xe9, 0x1d, 0xd3, 0x77, 0xfc, 0x56, 0xaa, 0x26, 0xa9, 0xa5,
+	0x60, 0x79, 0xe2, 0x14, 0x49, 0x1f, 0x8a, 0x7f, 0xef, 0xc1, 0x74, 0x69, 0x98, 0x32, 0x29, 0x7e,
+	0xbb, 0x47, 0x6d, 0xc8, 0x11, 0x8c, 0x2a, 0x59, 0x66, 0x39, 0x57, 0x34, 0x98, 0x05, 0xf3, 0xfd,
+	0x74, 0x58, 0xc9, 0xf2, 0x23, 0x57, 0x64, 0x0e, 0x87, 0xda, 0xe4, 0xf2, 0xde, 0x64, 0x05, 0xaf,
+	0x30, 0xab, 0x99, 0x40, 0xba, 0x6b, 0x89, 0x03, 0x97, 0xff, 0xc4, 0x2b, 0xfc, 0xc2, 0x04, 0x7a,
+	0x12, 0x95, 0xea, 0x91, 0x7b, 0x1d, 0x89, 0x4a, 0x75, 0xe4, 0x09, 0xec, 0x0b, 0xf6, 0x60, 0x31,
+	0x4d, 0xc3, 0x59, 0x30, 0x8f, 0xd2, 0xb1, 0x60, 0x0f, 0x6d, 0x5d, 0x93, 0x37, 0x70, 0xb8, 0x2e,
+	0x66, 0x9a, 0x3f, 0x62, 0x26, 0x56, 0x74, 0x60, 0x99, 0xc8, 0x33, 0x4b, 0xfe, 0x88, 0xd7, 0x2b,
+	0xf2, 0x0a, 0x26, 0xdd, 0x64, 0x85, 0xa4, 0x43, 0xfb, 0x14, 0xac, 0x87, 0x2a, 0xa4, 0x07, 0xdc,
+	0x40, 0x85, 0xa4, 0xa3, 0x0e, 0xb0, 0xb3, 0x14, 0x92, 0x7c, 0x80, 0x13, 0xc5, 0x0c, 0x66, 0x15,
+	0x17, 0xdc, 0x64, 0xab, 0xef, 0x06, 0x75, 0xd6, 0xa0, 0xca, 0x34, 0xde, 0xca, 0x3a, 0xa7, 0xe3,
+	0x59, 0x30, 0x0f, 0xd3, 0xa3, 0x16, 0x59, 0xb4, 0xc4, 0x55, 0x0b, 0xdc, 0xa0, 0x5a, 0xda, 0xf2,
+	0x33, 0x75, 0xc5, 0xeb, 0xa7, 0xea, 0xfd, 0x67, 0xea, 0x05, 0xaf, 0xfb, 0xea, 0xd7, 0xf0, 0x5f,
+	0x4f, 0x2d, 0x64, 0x8e, 0x14, 0xec, 0x80, 0x51, 0xa7, 0xb8, 0x96, 0x39, 0x92, 0x77, 0xf0, 0xbf,
+	0xb8, 0xaf, 0x0c, 0x6f, 0xfb, 0x67, 0x0d, 0x33, 0x06, 0x55, 0x4d, 0x27, 0x96, 0x3c, 0xec, 0x0a,
+	0x37, 0x2e, 0x4f, 0x5e, 0xc2, 0xb0, 0x50, 0x4c, 0x60, 0x4e, 0xa7, 0xb3, 0x60, 0x3e, 0x4e, 0x7d,
+	0x14, 0xbf, 0x85, 0xc8, 0x5f, 0x5b, 0x37, 0xb2, 0xd6, 0x48, 0x28, 0x8c, 0xda, 0x12, 0xaf, 0x4b,
+	0x7b, 0xee, 0x71, 0xba, 0x0e, 0xe3, 0x08, 0x26, 0x4b, 0x23, 0x1b, 0xef, 0x8b, 0xf8, 0x00, 0xa6,
+	0x2e, 0x74, 0x42, 0x17, 0x33, 0xa3, 0xd7, 0xf5, 0x9f, 0x01, 0x44, 0x3e, 0xe1, 0x5b, 0x7f, 0x86,
+	0xa1, 0xbb, 0x81, 0xed, 0x3c, 0xb9, 0xb8, 0x4c, 0x36, 0x30, 0x64, 0xb2, 0x90, 0xe5, 0xd2, 0x28,
+	0x64, 0xc2, 0x35, 0xf3, 0x2d, 0x7c, 0x33, 0x54, 0x8a, 0xee, 0xfe, 0x5b, 0x33, 0x54, 0x2a, 0xfe,
+	0x11, 0xc0, 0xc1, 0xd3, 0x12, 0x79, 0x01, 0x03, 0x7b, 0x76, 0x3b, 0x6b, 0x98, 0xba, 0xa0, 0xcd,
+	0xda, 0x73, 0xda, 0x47, 0xc3, 0xd4, 0x05, 0xe4, 0x14, 0xa2, 0x5c, 0xc9, 0xa6, 0xc1, 0xdc, 0x59,
+	0xc5, 0x9a, 0x3b, 0x4c, 0xa7, 0x3e, 0x69, 0xdd, 0xd1, 0x87, 0x5c, 0x8b, 0xf0, 0x09, 0x64, 0x4d,
+	0x70, 0xf1, 0x6b, 0x17, 0x86, 0x0b, 0x59, 0x5e, 0xcb, 0x9a, 0x34, 0x30, 0xb0, 0x97, 0x21, 0xe7,
+	0x1b, 0x6d, 0xd6, 0xff, 0x67, 0x8f, 0x2f, 0xb6, 0x91, 0xf8, 0xfb, 0xed, 0x10, 0x01, 0x61, 0x7b,
+	0x51, 0xf2, 0x7e, 0x43, 0x75, 0xe7, 0x85, 0xe3, 0xf3, 0x2d, 0x14, 0xdd, 0x73, 0x6e, 0x41, 0xa3,
+	0x37, 0x5f, 0xd0, 0xe8, 0xad, 0x17, 0xfc, 0x6b, 0xbf, 0x78, 0xe7, 0x6a, 0xf4, 0x75, 0x60, 0x0b,
+	0xab, 0xa1, 0xfd, 0x5c, 0xfe, 0x19, 0x00, 0x9c, 0x3b, 0x12, 0xdd, 0x34, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -253,6 +461,7 @@ const _ = grpc.SupportPackageIsVersion6
 type LogMonClient interface {
 	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
 	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 }
 
 type logMonClient struct {
@@ -281,10 +490,20 @@ func (c *logMonClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *logMonClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.client.logmon.proto.LogMon/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogMonServer is the server API for LogMon service.
 type LogMonServer interface {
 	Start(context.Context, *StartRequest) (*StartResponse, error)
 	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
 }
 
 // UnimplementedLogMonServer can be embedded to have forward compatible implementations.
@@ -297,6 +516,9 @@ func (*UnimplementedLogMonServer) Start(ctx context.Context, req *StartRequest)
 func (*UnimplementedLogMonServer) Stop(ctx context.Context, req *StopRequest) (*StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (*UnimplementedLogMonServer) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
 
 func RegisterLogMonServer(s *grpc.Server, srv LogMonServer) {
 	s.RegisterService(&_LogMon_serviceDesc, srv)
@@ -338,6 +560,24 @@ func _LogMon_Stop_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogMon_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogMonServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.client.logmon.proto.LogMon/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogMonServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _LogMon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.client.logmon.proto.LogMon",
 	HandlerType: (*LogMonServer)(nil),
@@ -350,6 +590,10 @@ var _LogMon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _LogMon_Stop_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _LogMon_Stats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "client/logmon/proto/logmon.proto",
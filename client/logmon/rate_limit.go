@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// RateLimitModeDrop drops the output of a task over its log rate limits
+	// and annotates the log with how much was dropped
+	RateLimitModeDrop = "drop"
+
+	// RateLimitModeBlock blocks reading the output of a task over its log rate
+	// limits, so the task blocks writing to stdout or stderr once the pipe
+	// buffer fills up
+	RateLimitModeBlock = "block"
+
+	// rateLimitWindow is the window the rate limits are enforced over
+	rateLimitWindow = time.Second
+)
+
+// RateLimit limits the rate of output of a task written to a log file.
+type RateLimit struct {
+	// BytesPerSecond and LinesPerSecond are the maximum rates of output. Zero
+	// is unlimited.
+	BytesPerSecond uint64
+	LinesPerSecond uint64
+
+	// Mode is RateLimitModeDrop or RateLimitModeBlock, and defaults to
+	// RateLimitModeDrop
+	Mode string
+}
+
+// enabled returns true if the rate limit limits any rate.
+func (r *RateLimit) enabled() bool {
+	return r != nil && (r.BytesPerSecond > 0 || r.LinesPerSecond > 0)
+}
+
+// rateLimiter enforces a RateLimit on the output copied from a task's fifo to
+// its log file. The limits apply to fixed windows of a second: output past the
+// limits of the current window is either dropped, or written once the next
+// window starts.
+type rateLimiter struct {
+	w     io.Writer
+	limit RateLimit
+
+	// now and sleep are overridden by tests
+	now   func() time.Time
+	sleep func(time.Duration)
+
+	// windowStart is the start of the current window, and bytes and lines
+	// count the output written in it
+	windowStart time.Time
+	bytes       uint64
+	lines       uint64
+
+	// pendingBytes and pendingLines count the output dropped since the last
+	// annotation, and needNewline is true if the last output written didn't
+	// end a line
+	pendingBytes uint64
+	pendingLines uint64
+	needNewline  bool
+
+	droppedBytes atomic.Uint64
+	droppedLines atomic.Uint64
+}
+
+func newRateLimiter(w io.Writer, limit RateLimit) *rateLimiter {
+	return &rateLimiter{
+		w:     w,
+		limit: limit,
+		now:   time.Now,
+		sleep: time.Sleep,
+	}
+}
+
+// Write writes as much of p as the rate limits allow, and either drops or
+// waits to write the rest. It always consumes all of p so the copy from the
+// fifo carries on.
+func (r *rateLimiter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if err := r.roll(); err != nil {
+			return 0, err
+		}
+
+		allowed := r.allowed(p)
+		if allowed > 0 {
+			if err := r.write(p[:allowed]); err != nil {
+				return 0, err
+			}
+			r.bytes += uint64(allowed)
+			r.lines += uint64(bytes.Count(p[:allowed], []byte{'\n'}))
+			p = p[allowed:]
+		}
+		if len(p) == 0 {
+			break
+		}
+
+		if r.limit.Mode == RateLimitModeBlock {
+			r.sleep(r.windowStart.Add(rateLimitWindow).Sub(r.now()))
+			continue
+		}
+
+		lines := uint64(bytes.Count(p, []byte{'\n'}))
+		r.pendingBytes += uint64(len(p))
+		r.pendingLines += lines
+		r.droppedBytes.Add(uint64(len(p)))
+		r.droppedLines.Add(lines)
+		break
+	}
+	return n, nil
+}
+
+// roll starts a new window once the current one ended, annotating the log
+// with the output dropped in the previous windows.
+func (r *rateLimiter) roll() error {
+	now := r.now()
+	if now.Before(r.windowStart.Add(rateLimitWindow)) {
+		return nil
+	}
+	r.windowStart = now
+	r.bytes, r.lines = 0, 0
+
+	if r.pendingBytes == 0 {
+		return nil
+	}
+	prefix := ""
+	if r.needNewline {
+		prefix = "\n"
+	}
+	note := fmt.Sprintf("%s[nomad: dropped %d bytes (%d lines) of output over the log rate limit]\n",
+		prefix, r.pendingBytes, r.pendingLines)
+	r.pendingBytes, r.pendingLines = 0, 0
+	return r.write([]byte(note))
+}
+
+// allowed returns how many bytes of p the current window has room for.
+func (r *rateLimiter) allowed(p []byte) int {
+	allowed := len(p)
+	if limit := r.limit.BytesPerSecond; limit > 0 {
+		allowed = int(min(uint64(allowed), limit-min(limit, r.bytes)))
+	}
+	if limit := r.limit.LinesPerSecond; limit > 0 {
+		// Output that doesn't end a line is free, so the limit cuts at the
+		// start of the first line that doesn't fit
+		remaining := limit - min(limit, r.lines)
+		start := 0
+		for i, b := range p[:allowed] {
+			if b != '\n' {
+				continue
+			}
+			if remaining == 0 {
+				return start
+			}
+			remaining--
+			start = i + 1
+		}
+	}
+	return allowed
+}
+
+func (r *rateLimiter) write(p []byte) error {
+	if _, err := r.w.Write(p); err != nil {
+		return err
+	}
+	r.needNewline = p[len(p)-1] != '\n'
+	return nil
+}
+
+// dropped returns the output dropped since the limiter was created.
+func (r *rateLimiter) dropped() (uint64, uint64) {
+	return r.droppedBytes.Load(), r.droppedLines.Load()
+}
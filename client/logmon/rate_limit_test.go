@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// testRateLimiter returns a rate limiter writing to buf, with a clock that
+// only moves when it sleeps or the test advances it.
+func testRateLimiter(buf *bytes.Buffer, limit RateLimit) (*rateLimiter, *time.Time) {
+	now := time.Unix(1000, 0)
+	r := newRateLimiter(buf, limit)
+	r.now = func() time.Time { return now }
+	r.sleep = func(d time.Duration) { now = now.Add(d) }
+	return r, &now
+}
+
+func TestRateLimiter_drop(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	r, now := testRateLimiter(&buf, RateLimit{BytesPerSecond: 10})
+
+	n, err := r.Write([]byte("0123456789abcdef\n"))
+	must.NoError(t, err)
+	must.Eq(t, 17, n, must.Sprint("expected dropped output to be consumed"))
+	must.Eq(t, "0123456789", buf.String())
+
+	// Output dropped in a window is annotated once the next one starts,
+	// on its own line
+	n, err = r.Write([]byte("more"))
+	must.NoError(t, err)
+	must.Eq(t, 4, n)
+	must.Eq(t, "0123456789", buf.String())
+
+	*now = now.Add(time.Second)
+	_, err = r.Write([]byte("next\n"))
+	must.NoError(t, err)
+	must.Eq(t, "0123456789\n[nomad: dropped 11 bytes (1 lines) of output over the log rate limit]\nnext\n",
+		buf.String())
+
+	droppedBytes, droppedLines := r.dropped()
+	must.Eq(t, 11, droppedBytes)
+	must.Eq(t, 1, droppedLines)
+}
+
+func TestRateLimiter_lines(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	r, now := testRateLimiter(&buf, RateLimit{LinesPerSecond: 2})
+
+	// The limit cuts at the start of the first line over it
+	_, err := r.Write([]byte("a\nb\nc\nd"))
+	must.NoError(t, err)
+	must.Eq(t, "a\nb\n", buf.String())
+
+	// Output that doesn't end a line doesn't count against the limit
+	*now = now.Add(time.Second)
+	buf.Reset()
+	_, err = r.Write([]byte("e\nf"))
+	must.NoError(t, err)
+	_, err = r.Write([]byte("g"))
+	must.NoError(t, err)
+	must.Eq(t, "[nomad: dropped 3 bytes (1 lines) of output over the log rate limit]\ne\nfg", buf.String())
+}
+
+func TestRateLimiter_block(t *testing.T) {
+	ci.Parallel(t)
+
+	var buf bytes.Buffer
+	r, now := testRateLimiter(&buf, RateLimit{BytesPerSecond: 4, Mode: RateLimitModeBlock})
+	start := *now
+
+	n, err := r.Write([]byte("0123456789"))
+	must.NoError(t, err)
+	must.Eq(t, 10, n)
+	must.Eq(t, "0123456789", buf.String(), must.Sprint("expected no output to be dropped"))
+	must.Eq(t, 2*time.Second, now.Sub(start), must.Sprint("expected writes to wait for the rate limit"))
+
+	droppedBytes, _ := r.dropped()
+	must.Zero(t, droppedBytes)
+}
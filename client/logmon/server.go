@@ -24,16 +24,45 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 		MaxFileSizeMB: int(req.MaxFileSizeMb),
 		StdoutFifo:    req.StdoutFifo,
 		StderrFifo:    req.StderrFifo,
+		RateLimit: RateLimit{
+			BytesPerSecond: req.RateLimitBytesPerSecond,
+			LinesPerSecond: req.RateLimitLinesPerSecond,
+			Mode:           req.RateLimitMode,
+		},
+		MultilinePattern: req.MultilinePattern,
+		Framed:           req.Framed,
 	}
 
 	err := s.impl.Start(cfg)
 	if err != nil {
 		return nil, err
 	}
-	resp := &proto.StartResponse{}
+	resp := &proto.StartResponse{
+		Framing: s.impl.FramedLogs(),
+	}
 	return resp, nil
 }
 
+func (s *logmonServer) Stats(ctx context.Context, req *proto.StatsRequest) (*proto.StatsResponse, error) {
+	stats, err := s.impl.Stats()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.StatsResponse{
+		Stdout: logStreamStatsToProto(stats.Stdout),
+		Stderr: logStreamStatsToProto(stats.Stderr),
+	}, nil
+}
+
+func logStreamStatsToProto(stats LogStreamStats) *proto.LogStreamStats {
+	return &proto.LogStreamStats{
+		Bytes:        stats.Bytes,
+		Lines:        stats.Lines,
+		DroppedBytes: stats.DroppedBytes,
+		DroppedLines: stats.DroppedLines,
+	}
+}
+
 func (s *logmonServer) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
 	return &proto.StopResponse{}, s.impl.Stop()
 }
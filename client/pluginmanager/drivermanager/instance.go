@@ -29,6 +29,16 @@ const (
 	driverFPBackoffLimit = 2 * time.Minute
 )
 
+var (
+	// driverReprobeBaseline is the baseline time for exponential backoff while
+	// probing an unhealthy driver again. It is overridden by tests.
+	driverReprobeBaseline = 10 * time.Second
+
+	// driverReprobeLimit is the limit of the exponential backoff for probing
+	// an unhealthy driver again. It is overridden by tests.
+	driverReprobeLimit = 5 * time.Minute
+)
+
 // instanceManagerConfig configures a driver instance manager
 type instanceManagerConfig struct {
 	// Logger is the logger used by the driver instance manager
@@ -57,6 +67,10 @@ type instanceManagerConfig struct {
 
 	// EventHandlerFactory is used to fetch a task event handler
 	EventHandlerFactory TaskEventHandlerFactory
+
+	// RefingerprintCh receives when devices were attached to or removed from
+	// the node, so the driver fingerprints again.
+	RefingerprintCh <-chan struct{}
 }
 
 // instanceManager is used to manage a single driver plugin
@@ -103,6 +117,9 @@ type instanceManager struct {
 	// eventHandlerFactory is used to fetch a handler for a task event
 	eventHandlerFactory TaskEventHandlerFactory
 
+	// refingerprintCh receives when the driver should fingerprint again
+	refingerprintCh <-chan struct{}
+
 	// firstFingerprintCh is used to trigger that we have successfully
 	// fingerprinted once. It is used to gate launching the stats collection.
 	firstFingerprintCh chan struct{}
@@ -130,6 +147,7 @@ func newInstanceManager(c *instanceManagerConfig) *instanceManager {
 		id:                   c.ID,
 		updateNodeFromDriver: c.UpdateNodeFromDriver,
 		eventHandlerFactory:  c.EventHandlerFactory,
+		refingerprintCh:      c.RefingerprintCh,
 		firstFingerprintCh:   make(chan struct{}),
 	}
 
@@ -287,11 +305,31 @@ func (i *instanceManager) fingerprint() {
 	fpChan, cancel, err := i.dispenseFingerprintCh()
 	if err != nil {
 		i.logger.Error("failed to dispense driver plugin", "error", err)
+		cancel = func() {}
+	}
+
+	// restart starts fingerprinting over, which probes the driver again
+	restart := func(reason string) {
+		newFpChan, newCancel, err := i.dispenseFingerprintCh()
+		if err != nil {
+			i.logger.Warn("error fingerprinting driver again", "reason", reason, "error", err)
+			return
+		}
+		i.logger.Debug("fingerprinting driver again", "reason", reason)
+		cancel()
+		fpChan = newFpChan
+		cancel = newCancel
 	}
 
 	// backoff and retry used if the RPC is closed by the other end
 	var backoff time.Duration
 	var retry uint64
+
+	// reprobe fires to probe an unhealthy driver again, backing off while it
+	// stays unhealthy, since drivers may only fingerprint periodically or
+	// fail to recover on their own
+	var reprobe <-chan time.Time
+	var reprobes uint64
 	for {
 		if backoff > 0 {
 			select {
@@ -306,6 +344,13 @@ func (i *instanceManager) fingerprint() {
 		case <-i.ctx.Done():
 			cancel()
 			return
+		case <-i.refingerprintCh:
+			// Drivers only fingerprint periodically, so start over to
+			// fingerprint the devices that were attached or removed
+			restart("devices were attached or removed")
+		case <-reprobe:
+			reprobe = nil
+			restart("driver is unhealthy")
 		case fp, ok := <-fpChan:
 			if ok {
 				if fp.Err == nil {
@@ -314,6 +359,13 @@ func (i *instanceManager) fingerprint() {
 					i.logger.Warn("received fingerprint error from driver", "error", fp.Err)
 					i.handleFingerprintError()
 				}
+
+				if fp.Err == nil && fp.Health != drivers.HealthStateUnhealthy {
+					reprobe, reprobes = nil, 0
+				} else if reprobe == nil {
+					reprobe = time.After(helper.Backoff(driverReprobeBaseline, driverReprobeLimit, reprobes))
+					reprobes++
+				}
 				continue
 			}
 
@@ -10,6 +10,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/lib/hotplug"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
@@ -95,6 +96,10 @@ type Config struct {
 
 	// BlockedDrivers if set will not allow the given driver plugins to start
 	BlockedDrivers map[string]struct{}
+
+	// Hotplug notifies when devices are attached to or removed from the
+	// node, so the drivers fingerprint them again. It may be nil.
+	Hotplug *hotplug.Notifier
 }
 
 // manager is used to manage a set of driver plugins
@@ -134,6 +139,9 @@ type manager struct {
 	allowedDrivers map[string]struct{}
 	blockedDrivers map[string]struct{}
 
+	// hotplug notifies when devices are attached to or removed from the node
+	hotplug *hotplug.Notifier
+
 	// readyCh is ticked once at the end of Run()
 	readyCh chan struct{}
 }
@@ -154,6 +162,7 @@ func New(c *Config) *manager {
 		reattachConfigs:     make(map[loader.PluginID]*pstructs.ReattachConfig),
 		allowedDrivers:      c.AllowedDrivers,
 		blockedDrivers:      c.BlockedDrivers,
+		hotplug:             c.Hotplug,
 		readyCh:             make(chan struct{}),
 	}
 }
@@ -203,6 +212,7 @@ func (m *manager) Run() {
 			ID:                   &id,
 			UpdateNodeFromDriver: m.updater,
 			EventHandlerFactory:  m.eventHandlerFactory,
+			RefingerprintCh:      m.hotplug.Subscribe(),
 		})
 
 		m.instancesMu.Lock()
@@ -171,6 +171,77 @@ func TestManager_Fingerprint(t *testing.T) {
 	require.False(infos[2].Detected)
 }
 
+// Test that an unhealthy driver is probed again until it is healthy. It
+// overrides the reprobe backoff, so it isn't run in parallel.
+func TestManager_Fingerprint_Reprobe(t *testing.T) {
+	baseline, limit := driverReprobeBaseline, driverReprobeLimit
+	driverReprobeBaseline, driverReprobeLimit = 10*time.Millisecond, 20*time.Millisecond
+	defer func() {
+		driverReprobeBaseline, driverReprobeLimit = baseline, limit
+	}()
+
+	var l sync.Mutex
+	var probes int
+	health := drivers.HealthStateUnhealthy
+	drv := &dtu.MockDriver{
+		FingerprintF: func(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+			l.Lock()
+			defer l.Unlock()
+			probes++
+			ch := make(chan *drivers.Fingerprint, 1)
+			ch <- &drivers.Fingerprint{Health: health}
+			return ch, nil
+		},
+		TaskEventsF: func(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+			return make(chan *drivers.TaskEvent), nil
+		},
+	}
+	mgr := New(&Config{
+		Logger:              testlog.HCLogger(t),
+		Loader:              mockCatalog(map[string]drivers.DriverPlugin{"mock": drv}),
+		PluginConfig:        &base.AgentConfig{},
+		Updater:             noopUpdater,
+		EventHandlerFactory: noopEventHandlerFactory,
+		State:               state.NoopDB{},
+		AllowedDrivers:      make(map[string]struct{}),
+		BlockedDrivers:      make(map[string]struct{}),
+	})
+	go mgr.Run()
+	defer mgr.Shutdown()
+
+	getProbes := func() int {
+		l.Lock()
+		defer l.Unlock()
+		return probes
+	}
+	testutil.WaitForResult(func() (bool, error) {
+		if n := getProbes(); n < 3 {
+			return false, fmt.Errorf("expected the unhealthy driver to be probed again; probed %d times", n)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Once healthy it isn't probed anymore
+	l.Lock()
+	health = drivers.HealthStateHealthy
+	l.Unlock()
+	testutil.WaitForResult(func() (bool, error) {
+		mgr.instancesMu.Lock()
+		defer mgr.instancesMu.Unlock()
+		if mgr.instances["mock"].getLastHealth() != drivers.HealthStateHealthy {
+			return false, fmt.Errorf("mock instance should be healthy")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+	n := getProbes()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, n, getProbes())
+}
+
 func TestManager_TaskEvents(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
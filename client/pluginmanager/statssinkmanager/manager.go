@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package statssinkmanager is used to manage the stats sink plugins, which
+// receive the resource usage samples of the tasks running on the client.
+package statssinkmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/statssink"
+)
+
+const (
+	// sampleBufferSize is the number of samples buffered for each sink before
+	// new samples are dropped.
+	sampleBufferSize = 256
+
+	// dispenseBackoffBaseline is the baseline time for exponential backoff
+	// while dispensing a stats sink plugin that failed to start or exited.
+	dispenseBackoffBaseline = 5 * time.Second
+
+	// dispenseBackoffLimit is the limit of the exponential backoff for
+	// dispensing a stats sink plugin.
+	dispenseBackoffLimit = 5 * time.Minute
+)
+
+// errNotStatsSink is returned when dispensing a plugin that isn't a stats
+// sink, which dispensing it again won't fix.
+var errNotStatsSink = errors.New("plugin is not a stats sink plugin")
+
+// Manager is the interface used to manage stats sink plugins
+type Manager interface {
+	pluginmanager.PluginManager
+	interfaces.TaskStatsSink
+}
+
+// Config is used to configure a stats sink manager
+type Config struct {
+	// Logger is the logger used by the stats sink manager
+	Logger log.Logger
+
+	// Loader is the plugin loader
+	Loader loader.PluginCatalog
+
+	// PluginConfig is the config passed to the launched plugins
+	PluginConfig *base.AgentConfig
+
+	// NodeID is the ID of the client node, passed along with every sample
+	NodeID string
+}
+
+// manager is used to manage a set of stats sink plugins
+type manager struct {
+	// logger is the logger used by the stats sink manager
+	logger log.Logger
+
+	// ctx is used to shutdown the stats sink manager
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// loader is the plugin loader
+	loader loader.PluginCatalog
+
+	// pluginConfig is the config passed to the launched plugins
+	pluginConfig *base.AgentConfig
+
+	nodeID string
+
+	// sinks is the list of managed sinks, access is serialized by sinksMu
+	sinks   []*sink
+	sinksMu sync.RWMutex
+
+	// wg tracks the sink goroutines so Shutdown can wait on them
+	wg sync.WaitGroup
+
+	// backoffBaseline is the baseline time for exponential backoff while
+	// dispensing the plugins
+	backoffBaseline time.Duration
+}
+
+// New returns a new stats sink manager
+func New(c *Config) *manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &manager{
+		logger:       c.Logger.Named("stats_sink_mgr"),
+		ctx:          ctx,
+		cancel:       cancel,
+		loader:       c.Loader,
+		pluginConfig: c.PluginConfig,
+		nodeID:       c.NodeID,
+
+		backoffBaseline: dispenseBackoffBaseline,
+	}
+}
+
+// PluginType returns the type of plugin this manager mananges
+func (*manager) PluginType() string { return base.PluginTypeStatsSink }
+
+// Run starts a goroutine for each stats sink plugin in the catalog, which
+// dispenses the plugin and forwards samples to it, dispensing it again if it
+// fails to start or exits.
+func (m *manager) Run() {
+	plugins := m.loader.Catalog()[base.PluginTypeStatsSink]
+	if len(plugins) == 0 {
+		m.logger.Debug("exiting since there are no stats sink plugins")
+		return
+	}
+
+	m.sinksMu.Lock()
+	defer m.sinksMu.Unlock()
+	for _, p := range plugins {
+		s := &sink{
+			id:      loader.PluginInfoID(p),
+			logger:  m.logger.With("plugin", p.Name),
+			samples: make(chan *statssink.TaskStatsSample, sampleBufferSize),
+		}
+		m.sinks = append(m.sinks, s)
+
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.runSink(s)
+		}()
+	}
+}
+
+// Shutdown stops forwarding samples and kills the stats sink plugins
+func (m *manager) Shutdown() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// Enabled returns whether any stats sink plugins are running.
+func (m *manager) Enabled() bool {
+	m.sinksMu.RLock()
+	defer m.sinksMu.RUnlock()
+	return len(m.sinks) > 0
+}
+
+// EmitTaskStats queues the sample for every stats sink plugin. It never
+// blocks: samples are dropped for sinks that are not keeping up.
+func (m *manager) EmitTaskStats(alloc *structs.Allocation, task string, usage *cstructs.TaskResourceUsage) {
+	m.sinksMu.RLock()
+	defer m.sinksMu.RUnlock()
+	if len(m.sinks) == 0 {
+		return
+	}
+
+	sample := &statssink.TaskStatsSample{
+		AllocID:   alloc.ID,
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		TaskGroup: alloc.TaskGroup,
+		TaskName:  task,
+		NodeID:    m.nodeID,
+		Stats:     usage,
+	}
+
+	for _, s := range m.sinks {
+		select {
+		case s.samples <- sample:
+		default:
+			metrics.IncrCounterWithLabels([]string{"client", "stats_sink", "dropped"}, 1,
+				[]metrics.Label{{Name: "plugin", Value: s.id.Name}})
+		}
+	}
+}
+
+// runSink dispenses the plugin of the sink and forwards samples to it until
+// the manager is shutdown. Plugins that fail to start or exit are dispensed
+// again with backoff, and samples are dropped meanwhile once the buffer of
+// the sink is full. Plugins that aren't stats sinks are removed.
+func (m *manager) runSink(s *sink) {
+	var attempt uint64
+	for {
+		instance, plugin, err := s.dispense(m.loader, m.pluginConfig)
+		switch {
+		case errors.Is(err, errNotStatsSink):
+			s.logger.Error("removing stats sink", "error", err)
+			m.removeSink(s)
+			return
+		case err != nil:
+			s.logger.Error("failed to dispense stats sink plugin", "error", err)
+		default:
+			if s.forward(m.ctx, instance, plugin) {
+				attempt = 0
+			}
+		}
+
+		if m.ctx.Err() != nil {
+			return
+		}
+		backoff := helper.Backoff(m.backoffBaseline, dispenseBackoffLimit, attempt)
+		attempt++
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// removeSink stops queuing samples for the sink.
+func (m *manager) removeSink(s *sink) {
+	m.sinksMu.Lock()
+	defer m.sinksMu.Unlock()
+	for i, other := range m.sinks {
+		if other == s {
+			m.sinks = append(m.sinks[:i:i], m.sinks[i+1:]...)
+			return
+		}
+	}
+}
+
+// sink forwards samples to a single stats sink plugin
+type sink struct {
+	id      loader.PluginID
+	logger  log.Logger
+	samples chan *statssink.TaskStatsSample
+}
+
+// dispense returns an instance of the plugin of the sink.
+func (s *sink) dispense(catalog loader.PluginCatalog, config *base.AgentConfig) (
+	loader.PluginInstance, statssink.StatsSinkPlugin, error) {
+
+	instance, err := catalog.Dispense(s.id.Name, s.id.PluginType, config, s.logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	plugin, ok := instance.Plugin().(statssink.StatsSinkPlugin)
+	if !ok {
+		instance.Kill()
+		return nil, nil, errNotStatsSink
+	}
+	return instance, plugin, nil
+}
+
+// forward forwards samples to the plugin until the context is canceled or the
+// plugin exits, and returns whether any sample was forwarded.
+func (s *sink) forward(ctx context.Context, instance loader.PluginInstance, plugin statssink.StatsSinkPlugin) bool {
+	defer instance.Kill()
+
+	forwarded := false
+	for {
+		select {
+		case <-ctx.Done():
+			return forwarded
+		case sample := <-s.samples:
+			if err := plugin.EmitTaskStats(ctx, sample); err != nil {
+				if instance.Exited() {
+					s.logger.Error("stats sink plugin exited", "error", err)
+					return forwarded
+				}
+				s.logger.Warn("failed to emit task stats", "error", err,
+					"alloc_id", sample.AllocID, "task", sample.TaskName)
+				continue
+			}
+			forwarded = true
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package statssinkmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/statssink"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestManager_EmitTaskStats(t *testing.T) {
+	ci.Parallel(t)
+
+	var (
+		lock    sync.Mutex
+		samples []*statssink.TaskStatsSample
+	)
+	sink := &statssink.MockStatsSinkPlugin{
+		MockPlugin: &base.MockPlugin{},
+		EmitTaskStatsF: func(_ context.Context, sample *statssink.TaskStatsSample) error {
+			lock.Lock()
+			defer lock.Unlock()
+			samples = append(samples, sample)
+			return nil
+		},
+	}
+	instance := loader.MockBasicExternalPlugin(sink, statssink.ApiVersion010)
+
+	catalog := &loader.MockCatalog{
+		CatalogF: func() map[string][]*base.PluginInfoResponse {
+			return map[string][]*base.PluginInfoResponse{
+				base.PluginTypeStatsSink: {{
+					Name:              "mock_sink",
+					Type:              base.PluginTypeStatsSink,
+					PluginApiVersions: []string{statssink.ApiVersion010},
+				}},
+			}
+		},
+		DispenseF: func(name, _ string, _ *base.AgentConfig, _ log.Logger) (loader.PluginInstance, error) {
+			must.Eq(t, "mock_sink", name)
+			return instance, nil
+		},
+	}
+
+	m := New(&Config{
+		Logger: testlog.HCLogger(t),
+		Loader: catalog,
+		NodeID: "node-1",
+	})
+	m.Run()
+	must.True(t, m.Enabled())
+
+	alloc := mock.Alloc()
+	usage := &cstructs.TaskResourceUsage{Timestamp: 1}
+	m.EmitTaskStats(alloc, "web", usage)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(samples) == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	lock.Lock()
+	sample := samples[0]
+	lock.Unlock()
+	must.Eq(t, alloc.ID, sample.AllocID)
+	must.Eq(t, alloc.JobID, sample.JobID)
+	must.Eq(t, alloc.TaskGroup, sample.TaskGroup)
+	must.Eq(t, "web", sample.TaskName)
+	must.Eq(t, "node-1", sample.NodeID)
+	must.Eq(t, usage, sample.Stats)
+
+	m.Shutdown()
+	must.True(t, instance.Exited())
+}
+
+func TestManager_NoPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	catalog := &loader.MockCatalog{
+		CatalogF: func() map[string][]*base.PluginInfoResponse { return nil },
+	}
+	m := New(&Config{
+		Logger: testlog.HCLogger(t),
+		Loader: catalog,
+	})
+	m.Run()
+	must.False(t, m.Enabled())
+
+	// emitting without any sinks is a no-op
+	m.EmitTaskStats(mock.Alloc(), "web", &cstructs.TaskResourceUsage{})
+	m.Shutdown()
+}
+
+func TestManager_Redispense(t *testing.T) {
+	ci.Parallel(t)
+
+	var (
+		lock       sync.Mutex
+		dispensed  int
+		samples    int
+		instance   *loader.MockInstance
+		sinkPlugin *statssink.MockStatsSinkPlugin
+	)
+	sinkPlugin = &statssink.MockStatsSinkPlugin{
+		MockPlugin: &base.MockPlugin{},
+		EmitTaskStatsF: func(context.Context, *statssink.TaskStatsSample) error {
+			lock.Lock()
+			defer lock.Unlock()
+			samples++
+
+			// the first instance exits on its first sample
+			if dispensed == 2 {
+				instance.Kill()
+				return errors.New("plugin exited")
+			}
+			return nil
+		},
+	}
+	catalog := &loader.MockCatalog{
+		CatalogF: func() map[string][]*base.PluginInfoResponse {
+			return map[string][]*base.PluginInfoResponse{
+				base.PluginTypeStatsSink: {{
+					Name:              "mock_sink",
+					Type:              base.PluginTypeStatsSink,
+					PluginApiVersions: []string{statssink.ApiVersion010},
+				}},
+			}
+		},
+		DispenseF: func(string, string, *base.AgentConfig, log.Logger) (loader.PluginInstance, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			dispensed++
+
+			// the plugin fails to start the first time
+			if dispensed == 1 {
+				return nil, errors.New("failed to start")
+			}
+			instance = loader.MockBasicExternalPlugin(sinkPlugin, statssink.ApiVersion010)
+			return instance, nil
+		},
+	}
+
+	m := New(&Config{
+		Logger: testlog.HCLogger(t),
+		Loader: catalog,
+	})
+	m.backoffBaseline = 10 * time.Millisecond
+	m.Run()
+	defer m.Shutdown()
+
+	alloc := mock.Alloc()
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			m.EmitTaskStats(alloc, "web", &cstructs.TaskResourceUsage{})
+
+			lock.Lock()
+			defer lock.Unlock()
+			return dispensed == 3 && samples > 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.True(t, m.Enabled())
+}
+
+func TestManager_NotStatsSink(t *testing.T) {
+	ci.Parallel(t)
+
+	catalog := &loader.MockCatalog{
+		CatalogF: func() map[string][]*base.PluginInfoResponse {
+			return map[string][]*base.PluginInfoResponse{
+				base.PluginTypeStatsSink: {{
+					Name:              "mock_sink",
+					Type:              base.PluginTypeStatsSink,
+					PluginApiVersions: []string{statssink.ApiVersion010},
+				}},
+			}
+		},
+		DispenseF: func(string, string, *base.AgentConfig, log.Logger) (loader.PluginInstance, error) {
+			return loader.MockBasicExternalPlugin(&base.MockPlugin{}, statssink.ApiVersion010), nil
+		},
+	}
+
+	m := New(&Config{
+		Logger: testlog.HCLogger(t),
+		Loader: catalog,
+	})
+	m.Run()
+	defer m.Shutdown()
+
+	// the sink is removed, rather than counting its samples as dropped
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return !m.Enabled() }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/hoststats"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// memoryEvictionCooldown is how long the client waits after evicting an
+// allocation for its memory to be released before evicting another
+const memoryEvictionCooldown = 30 * time.Second
+
+// evictionCandidate is an allocation the client may evict when the node runs
+// out of memory.
+type evictionCandidate struct {
+	allocID string
+	class   string
+
+	// overMB is the memory the allocation uses beyond its reservation
+	overMB int64
+}
+
+// evictionOrder returns the candidates in the order they are evicted: from
+// the lowest QoS class, and within a class from the one using the most memory
+// beyond its reservation. Guaranteed allocations are never evicted.
+func evictionOrder(candidates []evictionCandidate) []evictionCandidate {
+	ordered := make([]evictionCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.class != structs.QoSClassGuaranteed {
+			ordered = append(ordered, c)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if ra, rb := structs.QoSRank(a.class), structs.QoSRank(b.class); ra != rb {
+			return ra < rb
+		}
+		if a.overMB != b.overMB {
+			return a.overMB > b.overMB
+		}
+		return a.allocID < b.allocID
+	})
+	return ordered
+}
+
+// evictForMemoryPressure evicts the first allocation in eviction order if the
+// memory available on the node is below the threshold, in percent of its
+// total memory. A single allocation is evicted per cooldown, so the memory it
+// releases is seen before evicting more.
+func (c *Client) evictForMemoryPressure(mem *hoststats.MemoryStats, threshold float64) {
+	if mem == nil || mem.Total == 0 {
+		return
+	}
+	available := float64(mem.Available) / float64(mem.Total) * 100
+	if available >= threshold || time.Since(c.lastMemoryEviction) < memoryEvictionCooldown {
+		return
+	}
+
+	runners := c.getAllocRunners()
+	var candidates []evictionCandidate
+	for id, ar := range runners {
+		if candidate, ok := newEvictionCandidate(id, ar); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+	ordered := evictionOrder(candidates)
+	if len(ordered) == 0 {
+		return
+	}
+	victim := ordered[0]
+	ar := runners[victim.allocID]
+
+	message := fmt.Sprintf("Evicting %s allocation since only %.1f%% of the memory of the node is available",
+		victim.class, available)
+	var tasks []string
+	for _, task := range ar.Alloc().Job.LookupTaskGroup(ar.Alloc().TaskGroup).Tasks {
+		tasks = append(tasks, task.Name)
+	}
+	c.logger.Warn("evicting allocation since the node is running out of memory",
+		"alloc_id", victim.allocID, "qos_class", victim.class, "over_reservation_mb", victim.overMB,
+		"available_percent", available, "threshold_percent", threshold)
+	c.failTasks(ar, tasks, structs.NewTaskEvent(structs.TaskKilling).
+		SetDisplayMessage(message).
+		SetKilledResource(structs.TaskKilledResourceMemory))
+	c.lastMemoryEviction = time.Now()
+
+	c.triggerNodeEvent(structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetMessage("Evicted allocation since the node is running out of memory").
+		AddDetail("alloc_id", victim.allocID).
+		AddDetail("qos_class", victim.class))
+}
+
+// newEvictionCandidate returns the allocation of the runner as an eviction
+// candidate, if it is still running.
+func newEvictionCandidate(id string, ar interfaces.AllocRunner) (evictionCandidate, bool) {
+	if ar.IsDestroyed() {
+		return evictionCandidate{}, false
+	}
+	alloc := ar.Alloc()
+	if alloc.ClientTerminalStatus() || alloc.ServerTerminalStatus() ||
+		alloc.Job.LookupTaskGroup(alloc.TaskGroup) == nil {
+		return evictionCandidate{}, false
+	}
+
+	candidate := evictionCandidate{allocID: id, class: alloc.QoSClass()}
+	if alloc.AllocatedResources == nil {
+		return candidate, true
+	}
+	var reservedMB int64
+	for _, task := range alloc.AllocatedResources.Tasks {
+		reservedMB += task.Memory.MemoryMB
+	}
+	usage, err := ar.StatsReporter().LatestAllocStats("")
+	if err == nil && usage != nil && usage.ResourceUsage != nil && usage.ResourceUsage.MemoryStats != nil {
+		usedMB := int64(usage.ResourceUsage.MemoryStats.Used() / 1024 / 1024)
+		candidate.overMB = max(0, usedMB-reservedMB)
+	}
+	return candidate, true
+}
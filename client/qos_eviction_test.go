@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_evictionOrder(t *testing.T) {
+	ci.Parallel(t)
+
+	ordered := evictionOrder([]evictionCandidate{
+		{allocID: "guaranteed", class: structs.QoSClassGuaranteed, overMB: 4096},
+		{allocID: "burstable-small", class: structs.QoSClassBurstable, overMB: 10},
+		{allocID: "burstable-large", class: structs.QoSClassBurstable, overMB: 500},
+		{allocID: "best-effort-b", class: structs.QoSClassBestEffort},
+		{allocID: "best-effort-a", class: structs.QoSClassBestEffort},
+	})
+
+	var ids []string
+	for _, c := range ordered {
+		ids = append(ids, c.allocID)
+	}
+	must.Eq(t, []string{"best-effort-a", "best-effort-b", "burstable-large", "burstable-small"}, ids)
+
+	// Guaranteed allocations alone are never evicted
+	must.SliceEmpty(t, evictionOrder([]evictionCandidate{
+		{allocID: "guaranteed", class: structs.QoSClassGuaranteed},
+	}))
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// selfProfileInterval is how often the client checks its own usage
+	// against the self profiling thresholds
+	selfProfileInterval = 10 * time.Second
+
+	// selfProfileDir is the directory of the state dir captures are written
+	// to, one directory per capture
+	selfProfileDir = "profiles"
+)
+
+// selfUsage is the resource usage of the agent process.
+type selfUsage struct {
+	cpuPercent float64
+	rss        uint64
+}
+
+// selfProfileReason returns why a profile should be captured for the usage,
+// or an empty string if it is within the thresholds.
+func selfProfileReason(conf *config.SelfProfilingConfig, usage selfUsage) string {
+	var reasons []string
+	if conf.CPUThreshold > 0 && usage.cpuPercent > conf.CPUThreshold {
+		reasons = append(reasons, "cpu")
+	}
+	if conf.MemoryThreshold > 0 && usage.rss > conf.MemoryThreshold {
+		reasons = append(reasons, "memory")
+	}
+	return strings.Join(reasons, "-")
+}
+
+// watchSelfProfiling periodically checks the usage of the agent process, and
+// captures a CPU profile and a heap snapshot to the state dir when it exceeds
+// the self profiling thresholds. A node event records each capture, so it can
+// be correlated with the pressure the node was under.
+func (c *Client) watchSelfProfiling() {
+	conf := c.GetConfig()
+	if conf.SelfProfiling == nil {
+		return
+	}
+	sp := conf.SelfProfiling
+	dir := filepath.Join(conf.StateDir, selfProfileDir)
+
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		c.logger.Warn("failed to watch agent usage; self profiling disabled", "error", err)
+		return
+	}
+	// The first measurement of the CPU percent is since the process started
+	_, _ = proc.Percent(0)
+
+	var lastCapture time.Time
+	timer, stop := helper.NewSafeTimer(selfProfileInterval)
+	defer stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-c.shutdownCh:
+			return
+		}
+		timer.Reset(selfProfileInterval)
+
+		cpu, err := proc.Percent(0)
+		if err != nil {
+			c.logger.Debug("failed to read agent CPU usage", "error", err)
+			continue
+		}
+		mem, err := proc.MemoryInfo()
+		if err != nil {
+			c.logger.Debug("failed to read agent memory usage", "error", err)
+			continue
+		}
+		usage := selfUsage{cpuPercent: cpu, rss: mem.RSS}
+
+		reason := selfProfileReason(sp, usage)
+		if reason == "" || time.Since(lastCapture) < sp.Cooldown {
+			continue
+		}
+		lastCapture = time.Now()
+
+		c.logger.Warn("agent resource usage exceeded self profiling thresholds; capturing profile",
+			"cpu_percent", usage.cpuPercent, "rss", humanize.IBytes(usage.rss))
+		path, err := c.captureSelfProfile(sp, dir, reason)
+		if err != nil {
+			c.logger.Error("failed to capture self profile", "error", err)
+			continue
+		}
+		metrics.IncrCounterWithLabels([]string{"client", "self_profile", "captured"}, 1,
+			append([]metrics.Label{{Name: "reason", Value: reason}}, c.baseLabels...))
+		c.triggerNodeEvent(c.selfProfileEvent(usage, path))
+
+		if err := pruneSelfProfiles(dir, sp.Retain); err != nil {
+			c.logger.Warn("failed to remove old self profiles", "error", err)
+		}
+	}
+}
+
+// captureSelfProfile writes a CPU profile and a heap snapshot of the agent to
+// a new directory of dir, and returns its path. The CPU profile is skipped if
+// one is already running, such as one requested through the pprof endpoint.
+func (c *Client) captureSelfProfile(sp *config.SelfProfilingConfig, dir, reason string) (string, error) {
+	path := filepath.Join(dir, time.Now().UTC().Format("20060102T150405Z")+"-"+reason)
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return "", err
+	}
+
+	heap, err := os.Create(filepath.Join(path, "heap.pprof"))
+	if err != nil {
+		return "", err
+	}
+	err = pprof.Lookup("heap").WriteTo(heap, 0)
+	heap.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to write heap profile: %w", err)
+	}
+
+	cpu, err := os.Create(filepath.Join(path, "cpu.pprof"))
+	if err != nil {
+		return "", err
+	}
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		c.logger.Warn("skipping CPU profile of self profile", "error", err)
+		cpu.Close()
+		_ = os.Remove(cpu.Name())
+		return path, nil
+	}
+
+	select {
+	case <-time.After(sp.CPUProfileDuration):
+	case <-c.shutdownCh:
+	}
+	pprof.StopCPUProfile()
+	return path, cpu.Close()
+}
+
+// selfProfileEvent returns the node event recording a capture, along with the
+// usage of the host at the time.
+func (c *Client) selfProfileEvent(usage selfUsage, path string) *structs.NodeEvent {
+	event := structs.NewNodeEvent().
+		SetSubsystem("Agent").
+		SetMessage("Agent resource usage exceeded self profiling thresholds").
+		AddDetail("cpu_percent", fmt.Sprintf("%.1f", usage.cpuPercent)).
+		AddDetail("rss", humanize.IBytes(usage.rss)).
+		AddDetail("path", path)
+
+	if host := c.hostStatsCollector.Stats(); host != nil {
+		if host.Memory != nil && host.Memory.Total > 0 {
+			used := float64(host.Memory.Total-host.Memory.Available) / float64(host.Memory.Total) * 100
+			event.AddDetail("host_memory_used_percent", fmt.Sprintf("%.1f", used))
+		}
+		if len(host.CPU) > 0 {
+			var total float64
+			for _, cpu := range host.CPU {
+				total += cpu.TotalPercent
+			}
+			event.AddDetail("host_cpu_percent", fmt.Sprintf("%.1f", total/float64(len(host.CPU))))
+		}
+	}
+	return event
+}
+
+// pruneSelfProfiles removes the oldest captures of dir so at most retain are
+// kept. Captures are named after their time, so they sort oldest first.
+func pruneSelfProfiles(dir string, retain int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var captures []string
+	for _, e := range entries {
+		if e.IsDir() {
+			captures = append(captures, e.Name())
+		}
+	}
+	slices.Sort(captures)
+
+	for len(captures) > retain {
+		if err := os.RemoveAll(filepath.Join(dir, captures[0])); err != nil {
+			return err
+		}
+		captures = captures[1:]
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestSelfProfile_reason(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := &config.SelfProfilingConfig{CPUThreshold: 200, MemoryThreshold: 1024}
+	must.Eq(t, "", selfProfileReason(conf, selfUsage{cpuPercent: 150, rss: 512}))
+	must.Eq(t, "cpu", selfProfileReason(conf, selfUsage{cpuPercent: 250, rss: 512}))
+	must.Eq(t, "memory", selfProfileReason(conf, selfUsage{cpuPercent: 150, rss: 2048}))
+	must.Eq(t, "cpu-memory", selfProfileReason(conf, selfUsage{cpuPercent: 250, rss: 2048}))
+
+	conf.MemoryThreshold = 0
+	must.Eq(t, "", selfProfileReason(conf, selfUsage{rss: 1 << 40}))
+}
+
+func TestSelfProfile_prune(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"20260101T000000Z-cpu",
+		"20260102T000000Z-memory",
+		"20260103T000000Z-cpu",
+	} {
+		must.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o700))
+	}
+
+	must.NoError(t, pruneSelfProfiles(dir, 2))
+
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	must.SliceLen(t, 2, entries)
+	must.Eq(t, "20260102T000000Z-memory", entries[0].Name())
+	must.Eq(t, "20260103T000000Z-cpu", entries[1].Name())
+}
@@ -57,6 +57,7 @@ nodemeta/
 
 node/
 |--> registration -> *cstructs.NodeRegistration
+|--> usage_backfill -> *cstructs.UsageBackfill
 */
 
 var (
@@ -138,6 +139,10 @@ var (
 
 	// nodeRegistrationKey is the key at which node registration data is stored.
 	nodeRegistrationKey = []byte("node_registration")
+
+	// usageBackfillKey is the key at which the usage summaries buffered for
+	// backfill are stored.
+	usageBackfillKey = []byte("usage_backfill")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -1048,6 +1053,39 @@ func (s *BoltStateDB) GetNodeRegistration() (*cstructs.NodeRegistration, error)
 	return &reg, err
 }
 
+func (s *BoltStateDB) PutUsageBackfill(backfill *cstructs.UsageBackfill) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(nodeBucket)
+		if err != nil {
+			return err
+		}
+
+		return b.Put(usageBackfillKey, backfill)
+	})
+}
+
+func (s *BoltStateDB) GetUsageBackfill() (*cstructs.UsageBackfill, error) {
+	var backfill *cstructs.UsageBackfill
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		b := tx.Bucket(nodeBucket)
+		if b == nil {
+			return nil
+		}
+		var stored cstructs.UsageBackfill
+		if err := b.Get(usageBackfillKey, &stored); err != nil {
+			return err
+		}
+		backfill = &stored
+		return nil
+	})
+
+	if boltdd.IsErrNotFound(err) {
+		return nil, nil
+	}
+
+	return backfill, err
+}
+
 // init initializes metadata entries in a newly created state database.
 func (s *BoltStateDB) init() error {
 	return s.db.Update(func(tx *boltdd.Tx) error {
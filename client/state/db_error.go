@@ -154,6 +154,14 @@ func (m *ErrDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return nil, fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) PutUsageBackfill(backfill *cstructs.UsageBackfill) error {
+	return fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) GetUsageBackfill() (*cstructs.UsageBackfill, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) Close() error {
 	return fmt.Errorf("Error!")
 }
@@ -60,6 +60,8 @@ type MemDB struct {
 
 	nodeRegistration *cstructs.NodeRegistration
 
+	usageBackfill *cstructs.UsageBackfill
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -354,6 +356,19 @@ func (m *MemDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return m.nodeRegistration, nil
 }
 
+func (m *MemDB) PutUsageBackfill(backfill *cstructs.UsageBackfill) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usageBackfill = backfill
+	return nil
+}
+
+func (m *MemDB) GetUsageBackfill() (*cstructs.UsageBackfill, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usageBackfill, nil
+}
+
 func (m *MemDB) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -145,6 +145,14 @@ func (n NoopDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return nil, nil
 }
 
+func (n NoopDB) PutUsageBackfill(backfill *cstructs.UsageBackfill) error {
+	return nil
+}
+
+func (n NoopDB) GetUsageBackfill() (*cstructs.UsageBackfill, error) {
+	return nil, nil
+}
+
 func (n NoopDB) Close() error {
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -384,6 +385,31 @@ func TestStateDB_DynamicRegistry(t *testing.T) {
 	})
 }
 
+// TestStateDB_UsageBackfill asserts the behavior of usage backfill related
+// StateDB methods.
+func TestStateDB_UsageBackfill(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		// Getting nonexistent state should return nils
+		backfill, err := db.GetUsageBackfill()
+		must.NoError(t, err)
+		must.Nil(t, backfill)
+
+		state := &cstructs.UsageBackfill{
+			Entries: []*cstructs.UsageBackfillEntry{{
+				AllocID: "alloc1",
+				Summary: &structs.AllocUsageSummary{CPU: 100, MemoryMB: 64},
+			}},
+		}
+		must.NoError(t, db.PutUsageBackfill(state))
+
+		backfill, err = db.GetUsageBackfill()
+		must.NoError(t, err)
+		must.Eq(t, state, backfill)
+	})
+}
+
 func TestStateDB_CheckResult_keyForCheck(t *testing.T) {
 	ci.Parallel(t)
 
@@ -137,6 +137,14 @@ type StateDB interface {
 	PutNodeRegistration(*cstructs.NodeRegistration) error
 	GetNodeRegistration() (*cstructs.NodeRegistration, error)
 
+	// PutUsageBackfill stores the usage summaries the client buffered for
+	// backfill, replacing any stored before.
+	PutUsageBackfill(*cstructs.UsageBackfill) error
+
+	// GetUsageBackfill retrieves the usage summaries the client buffered for
+	// backfill, or nil if none were stored.
+	GetUsageBackfill() (*cstructs.UsageBackfill, error)
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
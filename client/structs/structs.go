@@ -7,6 +7,7 @@ package structs
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/nomad/client/hoststats"
@@ -161,6 +162,18 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Timestamps prefixes each line with the time it was produced at, if the
+	// task's executor recorded it.
+	Timestamps bool
+
+	// Since and Until select the lines produced in a time range, and are
+	// ignored if zero. Lines without a recorded timestamp are never selected.
+	Since time.Time
+	Until time.Time
+
+	// Filter is a regular expression selecting the lines matching it.
+	Filter string
+
 	structs.QueryOptions
 }
 
@@ -219,6 +232,15 @@ type AllocStatsRequest struct {
 	// Task is an optional filter to only request stats for the task.
 	Task string
 
+	// Window requests the summary of the usage of each task over the samples
+	// the client retains.
+	Window bool
+
+	// After blocks the request until the client has a sample newer than the
+	// timestamp, in UnixNano, or the MaxQueryTime elapsed, so consumers are
+	// sent each sample as soon as it's collected.
+	After int64
+
 	structs.QueryOptions
 }
 
@@ -229,6 +251,110 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+const (
+	// CaptureModeStack samples the kernel stacks of the threads of a task's
+	// main process.
+	CaptureModeStack = "stack"
+
+	// CaptureModeSignal sends SIGQUIT to a task's main process, which makes Go
+	// and JVM runtimes write a dump of their threads to stderr.
+	CaptureModeSignal = "sigquit"
+
+	// CaptureModeProfile samples the call stacks of a task's processes with
+	// perf and writes them folded, for rendering as a flamegraph.
+	CaptureModeProfile = "profile"
+)
+
+// AllocCaptureRequest is used to capture what the main process of a task is
+// doing.
+type AllocCaptureRequest struct {
+	// AllocID is the allocation of the task
+	AllocID string
+
+	// Task is the task to capture
+	Task string
+
+	// Mode is how the capture is taken. Defaults to CaptureModeStack.
+	Mode string
+
+	// Duration is how long stacks are sampled or profiled for. Defaults to
+	// five seconds.
+	Duration time.Duration
+
+	structs.QueryOptions
+}
+
+// AllocCaptureResponse is used to return where the output of a capture was
+// written.
+type AllocCaptureResponse struct {
+	// Path is the capture output file, relative to the allocation directory
+	Path string
+	structs.QueryMeta
+}
+
+// AllocProcessesRequest is used to request the processes running in the
+// tasks of a given allocation, potentially filtering by task
+type AllocProcessesRequest struct {
+	// AllocID is the allocation to list the processes of
+	AllocID string
+
+	// Task is an optional filter to only list the processes of the task.
+	Task string
+
+	structs.QueryOptions
+}
+
+// AllocProcessesResponse is used to return the processes running in the
+// tasks of a given allocation.
+type AllocProcessesResponse struct {
+	// Tasks maps the name of each running task to its processes
+	Tasks map[string][]*TaskProcess
+	structs.QueryMeta
+}
+
+// ExecutorsResponse is used to return the executors running on a client that
+// none of its tasks own, and the task handles that reattach to executors that
+// are gone. When returned by a garbage collection, they have been cleaned up.
+type ExecutorsResponse struct {
+	Orphans      []*OrphanedExecutor
+	StaleHandles []*StaleTaskHandle
+	structs.QueryMeta
+}
+
+// OrphanedExecutor is an executor process left running for a task the client
+// no longer runs, such as after the client crashed or its state was lost.
+type OrphanedExecutor struct {
+	Pid     int
+	AllocID string
+	Task    string
+
+	// Cgroup is the cgroup of the executor's task, which is adopted for
+	// accounting until the executor is terminated. It is empty when the task
+	// isn't in a cgroup the client manages.
+	Cgroup string
+
+	// Pids are the processes of the task
+	Pids []int
+
+	// MemoryBytes is the memory used by the task
+	MemoryBytes uint64
+
+	// Terminated is set once the executor and its task were killed
+	Terminated bool
+}
+
+// StaleTaskHandle is the persisted handle of a dead task, whose reattach
+// config still refers to the plugin process that ran it. The process may
+// have exited and its pid be reused by an unrelated process.
+type StaleTaskHandle struct {
+	AllocID string
+	Task    string
+	Pid     int
+
+	// Cleared is set once the handle was removed from the client state
+	Cleared bool
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -240,6 +366,12 @@ type MemoryStats struct {
 	KernelUsage    uint64
 	KernelMaxUsage uint64
 
+	// Shared is the shared memory, such as files in /dev/shm, included in
+	// RSS. Processes mapping the same shared memory all include it in their
+	// RSS, so it is counted once when aggregating the usage of several
+	// processes or tasks.
+	Shared uint64
+
 	// A list of fields whose values were actually sampled
 	Measured []string
 }
@@ -257,9 +389,38 @@ func (ms *MemoryStats) Add(other *MemoryStats) {
 	ms.MaxUsage += other.MaxUsage
 	ms.KernelUsage += other.KernelUsage
 	ms.KernelMaxUsage += other.KernelMaxUsage
+	ms.Shared += other.Shared
 	ms.Measured = joinStringSet(ms.Measured, other.Measured)
 }
 
+// Used returns the memory used, as the RSS if it was measured. Not every
+// driver reports RSS, but they all report usage.
+func (ms *MemoryStats) Used() uint64 {
+	if slices.Contains(ms.Measured, "RSS") {
+		return ms.RSS
+	}
+	return ms.Usage
+}
+
+// DedupShared corrects the RSS of memory stats aggregated from several
+// processes or tasks, which counts the shared memory mapped by each of them,
+// so the shared memory is counted once. Without knowing which pages are
+// shared by whom, it assumes they all map the same memory, and counts it as
+// the most shared memory any of them maps.
+func (ms *MemoryStats) DedupShared(shared []uint64) {
+	var total, most uint64
+	for _, s := range shared {
+		total += s
+		most = max(most, s)
+	}
+	if excess := total - most; excess <= ms.RSS {
+		ms.RSS -= excess
+	} else {
+		ms.RSS = 0
+	}
+	ms.Shared = most
+}
+
 // CpuStats holds cpu usage related stats
 type CpuStats struct {
 	SystemMode       float64
@@ -269,10 +430,45 @@ type CpuStats struct {
 	ThrottledTime    uint64
 	Percent          float64
 
+	// AllocatedPercent is Percent normalized to the CPU allocated to the
+	// task, so 100% is all of its allocated CPU. It's set by the client from
+	// the task's resources, and isn't aggregated for allocations, since the
+	// tasks of an allocation don't share their allocated CPU.
+	AllocatedPercent float64
+
+	// NodePercent is Percent normalized to all the cores of the node, so 100%
+	// is the whole node. It's set by the client.
+	NodePercent float64
+
+	// TotalCpuSeconds is the CPU time in seconds the task has used in user
+	// and system mode since it started. Unlike the other fields it only ever
+	// increases, so external systems can compute rates from it.
+	TotalCpuSeconds float64
+
+	// BorrowedTicks is the CPU in MHz the task used beyond its own out of
+	// the CPU the other tasks of its alloc reserved but don't use. It's only
+	// measured for tasks of groups with shared resources.
+	BorrowedTicks float64
+
+	// Cores is the utilization of each of the cores reserved for the task
+	// with resources.cores, ordered by core ID. It is empty for tasks that
+	// share cores.
+	Cores []*CoreStats
+
 	// A list of fields whose values were actually sampled
 	Measured []string
 }
 
+// CoreStats is the utilization of one of the cores reserved for a task.
+type CoreStats struct {
+	// ID is the ID of the core
+	ID uint16
+
+	// Percent is the percentage of the time of the core the task used since
+	// the previous sample
+	Percent float64
+}
+
 func (cs *CpuStats) Add(other *CpuStats) {
 	if other == nil {
 		return
@@ -284,20 +480,296 @@ func (cs *CpuStats) Add(other *CpuStats) {
 	cs.ThrottledPeriods += other.ThrottledPeriods
 	cs.ThrottledTime += other.ThrottledTime
 	cs.Percent += other.Percent
+	cs.NodePercent += other.NodePercent
+	cs.TotalCpuSeconds += other.TotalCpuSeconds
+	cs.BorrowedTicks += other.BorrowedTicks
+	cs.Cores = append(cs.Cores, other.Cores...)
 	cs.Measured = joinStringSet(cs.Measured, other.Measured)
 }
 
+// PerfStats holds hardware performance counter stats observed during the
+// most recent collection interval. It is only collected when enabled in the
+// driver configuration.
+type PerfStats struct {
+	Cycles             uint64
+	Instructions       uint64
+	CacheReferences    uint64
+	CacheMisses        uint64
+	BranchInstructions uint64
+	BranchMisses       uint64
+
+	// InstructionsPerCycle is the ratio of Instructions to Cycles
+	InstructionsPerCycle float64
+
+	// CacheMissRate is the percentage of CacheReferences that missed the last
+	// level cache
+	CacheMissRate float64
+
+	// BranchMissRate is the percentage of BranchInstructions that were
+	// mispredicted
+	BranchMissRate float64
+}
+
+func (ps *PerfStats) Add(other *PerfStats) {
+	if other == nil {
+		return
+	}
+
+	ps.Cycles += other.Cycles
+	ps.Instructions += other.Instructions
+	ps.CacheReferences += other.CacheReferences
+	ps.CacheMisses += other.CacheMisses
+	ps.BranchInstructions += other.BranchInstructions
+	ps.BranchMisses += other.BranchMisses
+	ps.ComputeRatios()
+}
+
+// ComputeRatios sets the derived ratio fields from the raw counters.
+func (ps *PerfStats) ComputeRatios() {
+	ratio := func(n, d uint64) float64 {
+		if d == 0 {
+			return 0
+		}
+		return float64(n) / float64(d)
+	}
+
+	ps.InstructionsPerCycle = ratio(ps.Instructions, ps.Cycles)
+	ps.CacheMissRate = ratio(ps.CacheMisses, ps.CacheReferences) * 100
+	ps.BranchMissRate = ratio(ps.BranchMisses, ps.BranchInstructions) * 100
+}
+
+// EnergyStats holds the estimated power drawn on behalf of a task. It is
+// apportioned from the power of the host by the share of the host CPU ticks
+// consumed by the task, and is only set when enabled in the client
+// configuration on hardware exposing energy counters.
+type EnergyStats struct {
+	Watts float64
+}
+
+func (es *EnergyStats) Add(other *EnergyStats) {
+	es.Watts += other.Watts
+}
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*device.DeviceGroupStats
+	PerfStats   *PerfStats
+	EnergyStats *EnergyStats
+
+	// Gauges are driver-specific measurements, keyed by name, which let
+	// drivers report usage Nomad has no field for
+	Gauges map[string]*Gauge
+
+	// Egress is the traffic sent to each class of destinations configured
+	// for egress accounting, keyed by class name
+	Egress map[string]*EgressStats
+
+	// Resctrl is the cache occupancy and memory bandwidth of the resctrl
+	// class a task is assigned to, on systems supporting monitoring. It is
+	// shared by the tasks of the class, so it isn't summed across tasks.
+	Resctrl *ResctrlStats
+
+	// DiskIO is the block I/O the task did since it started
+	DiskIO *DiskIOStats
+
+	// Pressure is the share of time the task stalled on CPU, memory and I/O,
+	// from its pressure stall information
+	Pressure *PressureStats
+
+	// Unreadable is set on the usage of a process of a task whose stats
+	// could not be read in time, such as a process in uninterruptible sleep
+	// on a hung NFS mount. Its stats are then those it was last read with,
+	// or unset if it never was.
+	Unreadable bool
 }
 
 func (ru *ResourceUsage) Add(other *ResourceUsage) {
 	ru.MemoryStats.Add(other.MemoryStats)
 	ru.CpuStats.Add(other.CpuStats)
 	ru.DeviceStats = append(ru.DeviceStats, other.DeviceStats...)
+	if other.PerfStats != nil {
+		if ru.PerfStats == nil {
+			ru.PerfStats = new(PerfStats)
+		}
+		ru.PerfStats.Add(other.PerfStats)
+	}
+	if other.EnergyStats != nil {
+		if ru.EnergyStats == nil {
+			ru.EnergyStats = new(EnergyStats)
+		}
+		ru.EnergyStats.Add(other.EnergyStats)
+	}
+	for name, g := range other.Gauges {
+		if g == nil {
+			continue
+		}
+		if ru.Gauges == nil {
+			ru.Gauges = make(map[string]*Gauge, len(other.Gauges))
+		}
+		ru.Gauges[name] = ru.Gauges[name].add(g)
+	}
+	for name, e := range other.Egress {
+		if e == nil {
+			continue
+		}
+		if ru.Egress == nil {
+			ru.Egress = make(map[string]*EgressStats, len(other.Egress))
+		}
+		if ru.Egress[name] == nil {
+			ru.Egress[name] = new(EgressStats)
+		}
+		ru.Egress[name].Add(e)
+	}
+	if other.DiskIO != nil {
+		if ru.DiskIO == nil {
+			ru.DiskIO = new(DiskIOStats)
+		}
+		ru.DiskIO.Add(other.DiskIO)
+	}
+	if other.Pressure != nil {
+		if ru.Pressure == nil {
+			ru.Pressure = new(PressureStats)
+		}
+		ru.Pressure.Add(other.Pressure)
+	}
+}
+
+// ResctrlStats is the L3 cache occupancy of the tasks of a resctrl class and
+// the memory traffic they caused since its control group was created, as
+// monitored by resctrl.
+type ResctrlStats struct {
+	// LLCOccupancy is the last level cache the tasks occupy, in bytes
+	LLCOccupancy uint64
+
+	// MBMTotalBytes and MBMLocalBytes are the memory traffic of the tasks, to
+	// any NUMA node and to the local one
+	MBMTotalBytes uint64
+	MBMLocalBytes uint64
+}
+
+// EgressStats is the traffic a task sent to a class of destinations since
+// it started.
+type EgressStats struct {
+	Bytes   uint64
+	Packets uint64
+}
+
+func (es *EgressStats) Add(other *EgressStats) {
+	es.Bytes += other.Bytes
+	es.Packets += other.Packets
+}
+
+// DiskIOStats is the block I/O a task did since it started.
+type DiskIOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+}
+
+func (ds *DiskIOStats) Add(other *DiskIOStats) {
+	ds.ReadBytes += other.ReadBytes
+	ds.WriteBytes += other.WriteBytes
+	ds.ReadOps += other.ReadOps
+	ds.WriteOps += other.WriteOps
+}
+
+// PressureStats is the percentage of time some of a task's processes stalled
+// waiting on CPU, memory or I/O over the last minute.
+type PressureStats struct {
+	CPU    float64
+	Memory float64
+	IO     float64
+}
+
+// Add keeps the highest pressure of each resource, as the stall time of
+// different tasks overlaps and can't be summed.
+func (ps *PressureStats) Add(other *PressureStats) {
+	ps.CPU = max(ps.CPU, other.CPU)
+	ps.Memory = max(ps.Memory, other.Memory)
+	ps.IO = max(ps.IO, other.IO)
+}
+
+// Gauge is a driver-specific measurement of a task's resource usage.
+type Gauge struct {
+	Value float64
+
+	// Unit is the unit of the value, such as "bytes" or "requests"
+	Unit string
+}
+
+// add returns the sum of two gauges of the same name. Gauges in different
+// units can't be summed, so the first one is kept.
+func (g *Gauge) add(other *Gauge) *Gauge {
+	if g == nil {
+		return &Gauge{Value: other.Value, Unit: other.Unit}
+	}
+	if g.Unit == other.Unit {
+		g.Value += other.Value
+	}
+	return g
+}
+
+// StatsCapabilities describes which optional resource usage stats a driver
+// reports for its tasks, so consumers can tell an unsupported stat from one
+// that is zero. Memory and CPU stats describe themselves through their
+// Measured fields instead.
+type StatsCapabilities struct {
+	Network  bool
+	DiskIO   bool
+	Devices  bool
+	Pressure bool
+
+	// Gauges are the names of the driver-specific gauges the driver reports
+	Gauges []string
+
+	// Schema describes the driver-specific gauges the driver reports, which
+	// declares them like their names in Gauges
+	Schema []*StatSchema
+
+	// MemoryMeasured and CpuMeasured are the memory and CPU stats the driver
+	// measures. When set, the Measured fields of the stats are limited to them.
+	MemoryMeasured []string
+	CpuMeasured    []string
+}
+
+// GaugeSchema returns the schema of the named gauge, or nil if the driver
+// didn't describe it.
+func (s *StatsCapabilities) GaugeSchema(name string) *StatSchema {
+	for _, schema := range s.Schema {
+		if schema.Name == name {
+			return schema
+		}
+	}
+	return nil
+}
+
+// StatSchema describes a driver-specific gauge.
+type StatSchema struct {
+	// Name is the name the gauge is reported under
+	Name string
+
+	// Unit is the unit of the gauge, such as "bytes" or "requests"
+	Unit string
+
+	// Description is a human readable description of the gauge
+	Description string
+}
+
+// TaskProcess describes a process running as part of a task.
+type TaskProcess struct {
+	Pid     int
+	Ppid    int
+	Cmdline []string
+
+	// State is the process state as reported by the operating system, such
+	// as "running", "sleep", or "zombie".
+	State string
+
+	// StartTime is when the process started, in UnixNano
+	StartTime int64
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -306,6 +778,137 @@ type TaskResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Timestamp     int64 // UnixNano
 	Pids          map[string]*ResourceUsage
+
+	// CgroupPath is the path of the cgroup the task processes are members
+	// of, if the driver places the task in a cgroup.
+	CgroupPath string
+
+	// CgroupID is the inode number of CgroupPath, which is how the kernel
+	// identifies the cgroup to tools such as eBPF programs.
+	CgroupID uint64
+
+	// ExecutorPID is the PID of the executor supervising the task, if the
+	// driver uses one.
+	ExecutorPID int
+
+	// Capabilities are the optional stats the task driver declared it
+	// reports. It is nil if the driver has not declared its stats.
+	Capabilities *StatsCapabilities
+
+	// Limits are the limits the task driver enforces on the task's resource
+	// usage. It is nil if the driver does not report them.
+	Limits *ResourceLimits
+
+	// Allocated are the resources allocated to the task when the sample was
+	// collected, so consumers can compute its utilization without fetching
+	// the allocation. It's set by the client.
+	Allocated *TaskAllocatedResources
+
+	// KilledResource is the resource the usage of which the task is being
+	// killed for, such as "memory" or "cpu". It's set on the samples from
+	// when the kill is decided until the task stops, so the final sample of
+	// the task records why it stopped.
+	KilledResource string
+
+	// Sequence numbers the samples of a task, starting at 1. It increases by
+	// one for every sample the task driver collects, so consumers can detect
+	// samples that were missed. Drivers that don't number their samples have
+	// them numbered by the client as it receives them.
+	Sequence uint64
+
+	// CollectionDuration is the time elapsed between the collection of the
+	// previous sample of the task and this one, measured with a monotonic
+	// clock so it is not affected by the node clock stepping. It is zero for
+	// the first sample.
+	CollectionDuration time.Duration
+
+	// Window summarizes the usage of the task over the samples the client
+	// retains. It is only set when requested.
+	Window *TaskUsageWindow
+
+	// Logs is the volume of the output of the task written to its log
+	// files. It is nil when the client doesn't collect the task's logs.
+	Logs *TaskLogStats
+
+	// Stale is set on the sample the client restored from before it was
+	// restarted, which it serves until the task driver sends a new one.
+	// It's set by the client.
+	Stale bool
+}
+
+// TaskLogStats is the volume of the output of a task written to its stdout
+// and stderr log files.
+type TaskLogStats struct {
+	Stdout *LogStreamStats
+	Stderr *LogStreamStats
+}
+
+// LogStreamStats is the volume of output written to a log file of a task.
+type LogStreamStats struct {
+	// Bytes and Lines count the output since the task started, including
+	// before it was restarted
+	Bytes uint64
+	Lines uint64
+
+	// BytesPerSecond and LinesPerSecond are the rates of output since the
+	// previous sample, and zero for the first sample
+	BytesPerSecond float64
+	LinesPerSecond float64
+
+	// DroppedBytes and DroppedLines count the output since the task started
+	// that was dropped for exceeding the task's log rate limits
+	DroppedBytes uint64
+	DroppedLines uint64
+}
+
+// TaskUsageWindow summarizes the resource usage of a task over the recent
+// samples the client retains for it.
+type TaskUsageWindow struct {
+	// Start and End are the timestamps of the oldest and latest retained
+	// samples, in UnixNano
+	Start int64
+	End   int64
+
+	// Samples is the number of retained samples
+	Samples int
+
+	// Partial is set if stats collection paused between retained samples,
+	// so the window lacks the usage of the paused time
+	Partial bool
+
+	// CPU is the distribution of the CPU used by the task in MHz
+	CPU structs.UsagePercentiles
+
+	// MemoryMB is the distribution of the memory used by the task in MB
+	MemoryMB structs.UsagePercentiles
+}
+
+// ResourceLimits are the limits enforced on a task's resource usage, such as
+// by its cgroup on Linux or its Job Object on Windows.
+type ResourceLimits struct {
+	// MemoryMax is the hard memory limit in bytes, or zero if memory is not
+	// limited.
+	MemoryMax uint64
+
+	// CpuMax is how many CPUs worth of time the task may use, or zero if CPU
+	// time is not limited.
+	CpuMax float64
+}
+
+// TaskAllocatedResources are the CPU and memory allocated to a task.
+type TaskAllocatedResources struct {
+	// CpuShares is the CPU allocated to the task in MHz
+	CpuShares int64
+
+	// ReservedCores are the IDs of the cores reserved for the task with
+	// resources.cores. It is empty for tasks that share cores.
+	ReservedCores []uint16
+
+	// MemoryMB is the memory allocated to the task, and MemoryMaxMB is the
+	// memory it may use with memory oversubscription, or zero if it can't
+	// use more than MemoryMB.
+	MemoryMB    int64
+	MemoryMaxMB int64
 }
 
 // AllocResourceUsage holds the aggregated task resource usage of the
@@ -319,6 +922,68 @@ type AllocResourceUsage struct {
 
 	// The max timestamp of all the Tasks
 	Timestamp int64
+
+	// Networks holds the counters of each interface in the network namespace
+	// of the allocation, keyed by interface name. It is only set for
+	// allocations with a network namespace of their own, such as in bridge
+	// mode.
+	Networks map[string]*NetworkInterfaceStats
+
+	// Connections counts the connections of the network namespace of the
+	// allocation. It is set along with Networks.
+	Connections *NetworkConnectionStats
+
+	// Disk is the usage of the alloc dir, or nil until the alloc dir has
+	// been measured
+	Disk *AllocDiskStats
+}
+
+// AllocDiskStats is the disk space and inodes used by the alloc dir of an
+// allocation, which holds its ephemeral disk.
+type AllocDiskStats struct {
+	// UsedBytes and Inodes are used by the files of the alloc dir
+	UsedBytes uint64
+	Inodes    uint64
+
+	// FilesystemInodesFree and FilesystemInodesUsedPercent are of the
+	// filesystem holding the alloc dir, which is shared with the other
+	// allocations of the client
+	FilesystemInodesFree        uint64
+	FilesystemInodesUsedPercent float64
+
+	// Timestamp is when the alloc dir was measured, which is less often
+	// than the other stats are collected (UnixNano)
+	Timestamp int64
+}
+
+// NetworkConnectionStats counts the connections of a network namespace.
+type NetworkConnectionStats struct {
+	// Established is the number of TCP connections in the established state
+	Established uint64
+
+	// Conntrack is the number of connections the kernel's connection
+	// tracking table holds for the namespace
+	Conntrack uint64
+
+	// ConntrackLimit is the number of tracked connections beyond which new
+	// connections are rejected, or 0 if there is no limit
+	ConntrackLimit uint64
+
+	// Measured lists which of the counts above were measured
+	Measured []string
+}
+
+// NetworkInterfaceStats holds the counters of a network interface since it
+// was created.
+type NetworkInterfaceStats struct {
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
 }
 
 // joinStringSet takes two slices of strings and joins them
@@ -378,3 +1043,15 @@ var DriverStatsNotImplemented = errors.New("stats not implemented for driver")
 type NodeRegistration struct {
 	HasRegistered bool
 }
+
+// UsageBackfill stores the usage summaries the client buffered while it could
+// not send them to the servers, oldest first.
+type UsageBackfill struct {
+	Entries []*UsageBackfillEntry
+}
+
+// UsageBackfillEntry is a buffered usage summary of an allocation.
+type UsageBackfillEntry struct {
+	AllocID string
+	Summary *structs.AllocUsageSummary
+}
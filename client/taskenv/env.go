@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/nomad/helper"
 	hargs "github.com/hashicorp/nomad/helper/args"
 	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/zclconf/go-cty/cty"
@@ -58,6 +59,10 @@ const (
 	// AllocName is the environment variable for passing the allocation name.
 	AllocName = "NOMAD_ALLOC_NAME"
 
+	// TraceParent is the environment variable for passing the W3C trace
+	// context of the task start, when the allocation carries one.
+	TraceParent = tracecontext.EnvName
+
 	// TaskName is the environment variable for passing the task name.
 	TaskName = "NOMAD_TASK_NAME"
 
@@ -439,6 +444,8 @@ type Builder struct {
 	jobID                string
 	jobName              string
 	jobParentID          string
+	allocTraceParent     string
+	traceParent          string
 
 	// otherPorts for tasks in the same alloc
 	otherPorts map[string]string
@@ -562,6 +569,9 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	if b.region != "" {
 		envMap[Region] = b.region
 	}
+	if b.traceParent != "" {
+		envMap[TraceParent] = b.traceParent
+	}
 
 	// Build the network related env vars
 	buildNetworkEnv(envMap, b.networks, b.driverNetwork)
@@ -754,6 +764,13 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 	b.jobParentID = alloc.Job.ParentID
 	b.namespace = alloc.Namespace
 
+	// Each task gets its own span within the trace of the placement. Keep
+	// the span stable across alloc updates so the environment doesn't change.
+	if alloc.TraceParent != b.allocTraceParent {
+		b.allocTraceParent = alloc.TraceParent
+		b.traceParent = tracecontext.ChildIfSet(alloc.TraceParent)
+	}
+
 	// Set meta
 	combined := alloc.Job.CombinedTaskMeta(alloc.TaskGroup, b.taskName)
 	// taskMetaSize is double to total meta keys to account for given and upper
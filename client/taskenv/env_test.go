@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -570,6 +571,33 @@ func TestEnvironment_Envvars(t *testing.T) {
 	}
 }
 
+// TestEnvironment_TraceParent asserts tasks receive a child of the
+// allocation's trace context that is stable across alloc updates.
+func TestEnvironment_TraceParent(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	task := a.Job.TaskGroups[0].Tasks[0]
+
+	act := NewBuilder(n, a, task, "global").Build().All()
+	require.NotContains(t, act, TraceParent)
+
+	a.TraceParent = tracecontext.New()
+	parent, err := tracecontext.Parse(a.TraceParent)
+	require.NoError(t, err)
+
+	builder := NewBuilder(n, a, task, "global")
+	act = builder.Build().All()
+	child, err := tracecontext.Parse(act[TraceParent])
+	require.NoError(t, err)
+	require.Equal(t, parent.TraceID, child.TraceID)
+	require.NotEqual(t, parent.SpanID, child.SpanID)
+
+	builder.UpdateTask(a, task)
+	require.Equal(t, act[TraceParent], builder.Build().All()[TraceParent])
+}
+
 // TestEnvironment_HookVars asserts hook env vars are LWW and deletes of later
 // writes allow earlier hook's values to be visible.
 func TestEnvironment_HookVars(t *testing.T) {
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// usageBackfillLimit is the maximum number of usage summaries the client
+	// buffers while disconnected. Allocations report at most one summary a
+	// minute, so this holds days of history for a handful of allocations.
+	usageBackfillLimit = 20000
+
+	// usageBackfillInterval is how often the client uploads the usage
+	// summaries it buffered while disconnected, and persists the rest.
+	usageBackfillInterval = 30 * time.Second
+)
+
+// usageBackfillEntry is a usage summary of an allocation the servers didn't
+// receive.
+type usageBackfillEntry struct {
+	allocID string
+	summary *structs.AllocUsageSummary
+}
+
+// usageBackfill buffers the usage summaries of allocation updates which were
+// superseded before the client could send them, so the client can upload them
+// once it reaches the servers again. When the buffer is full the oldest
+// summaries are dropped.
+type usageBackfill struct {
+	entries []usageBackfillEntry
+	limit   int
+
+	// dirty is set when the entries changed since they were last persisted
+	dirty bool
+
+	lock sync.Mutex
+}
+
+func newUsageBackfill(limit int) *usageBackfill {
+	return &usageBackfill{limit: limit}
+}
+
+// add buffers the usage summary of an allocation.
+func (b *usageBackfill) add(allocID string, summary *structs.AllocUsageSummary) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.entries = append(b.entries, usageBackfillEntry{allocID: allocID, summary: summary})
+	b.dirty = true
+	b.trimLocked()
+}
+
+// take removes and returns up to n of the oldest buffered summaries.
+func (b *usageBackfill) take(n int) []usageBackfillEntry {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	n = min(n, len(b.entries))
+	if n == 0 {
+		return nil
+	}
+	taken := make([]usageBackfillEntry, n)
+	copy(taken, b.entries)
+	b.entries = b.entries[n:]
+	b.dirty = true
+	return taken
+}
+
+// restore returns summaries which failed to upload to the front of the
+// buffer, ahead of any buffered since they were taken.
+func (b *usageBackfill) restore(entries []usageBackfillEntry) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.entries = append(entries, b.entries...)
+	b.dirty = true
+	b.trimLocked()
+}
+
+// snapshot returns the buffered summaries to persist, or nil if they didn't
+// change since the last snapshot. The caller must call markDirty if it fails
+// to persist them.
+func (b *usageBackfill) snapshot() *cstructs.UsageBackfill {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.dirty {
+		return nil
+	}
+	b.dirty = false
+
+	snap := &cstructs.UsageBackfill{Entries: make([]*cstructs.UsageBackfillEntry, len(b.entries))}
+	for i, e := range b.entries {
+		snap.Entries[i] = &cstructs.UsageBackfillEntry{AllocID: e.allocID, Summary: e.summary}
+	}
+	return snap
+}
+
+func (b *usageBackfill) markDirty() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.dirty = true
+}
+
+// load buffers summaries persisted by a previous client ahead of any buffered
+// since it started.
+func (b *usageBackfill) load(persisted *cstructs.UsageBackfill) {
+	if persisted == nil || len(persisted.Entries) == 0 {
+		return
+	}
+	entries := make([]usageBackfillEntry, 0, len(persisted.Entries))
+	for _, e := range persisted.Entries {
+		if e != nil && e.Summary != nil {
+			entries = append(entries, usageBackfillEntry{allocID: e.AllocID, summary: e.Summary})
+		}
+	}
+	b.restore(entries)
+}
+
+func (b *usageBackfill) len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.entries)
+}
+
+func (b *usageBackfill) trimLocked() {
+	if dropped := len(b.entries) - b.limit; dropped > 0 {
+		b.entries = b.entries[dropped:]
+		metrics.IncrCounter([]string{"client", "usage_backfill", "dropped"}, float32(dropped))
+	}
+}
+
+// groupUsageBackfill groups buffered summaries by allocation for a backfill
+// request.
+func groupUsageBackfill(entries []usageBackfillEntry) []*structs.AllocUsageBackfill {
+	var backfills []*structs.AllocUsageBackfill
+	byAlloc := make(map[string]*structs.AllocUsageBackfill)
+	for _, e := range entries {
+		backfill, ok := byAlloc[e.allocID]
+		if !ok {
+			backfill = &structs.AllocUsageBackfill{AllocID: e.allocID}
+			byAlloc[e.allocID] = backfill
+			backfills = append(backfills, backfill)
+		}
+		backfill.Summaries = append(backfill.Summaries, e.summary)
+	}
+	return backfills
+}
+
+// triggerUsageBackfill makes the client upload the usage summaries it
+// buffered without waiting for the next interval.
+func (c *Client) triggerUsageBackfill() {
+	select {
+	case c.triggerUsageBackfillCh <- struct{}{}:
+	default:
+	}
+}
+
+// watchUsageBackfill is a long lived goroutine which uploads the usage
+// summaries buffered while the client was disconnected, periodically and once
+// it reaches the servers again, and persists those left so they survive a
+// restart of the client.
+func (c *Client) watchUsageBackfill() {
+	timer, stop := helper.NewSafeTimer(usageBackfillInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-c.triggerUsageBackfillCh:
+		case <-c.shutdownCh:
+			return
+		}
+
+		for c.backfillUsage() {
+		}
+		if err := c.persistUsageBackfill(); err != nil {
+			c.logger.Error("failed to persist usage backfill", "error", err)
+		}
+		timer.Reset(usageBackfillInterval)
+	}
+}
+
+// persistUsageBackfill stores the buffered usage summaries in the state DB if
+// they changed since they were last stored.
+func (c *Client) persistUsageBackfill() error {
+	snap := c.usageBackfill.snapshot()
+	if snap == nil {
+		return nil
+	}
+	if err := c.stateDB.PutUsageBackfill(snap); err != nil {
+		c.usageBackfill.markDirty()
+		return err
+	}
+	return nil
+}
+
+// backfillUsage uploads a batch of the usage summaries buffered while the
+// client was disconnected, if the servers accept them. Summaries which fail to
+// upload are kept for the next attempt. It returns true if a batch was
+// uploaded and more summaries remain.
+func (c *Client) backfillUsage() bool {
+	if !c.serversAcceptUsageBackfill.Load() {
+		return false
+	}
+	entries := c.usageBackfill.take(structs.MaxUsageBackfillSamples)
+	if len(entries) == 0 {
+		return false
+	}
+
+	payload, err := structs.EncodeUsageBackfill(groupUsageBackfill(entries))
+	if err != nil {
+		c.logger.Error("failed to encode usage backfill", "error", err)
+		return false
+	}
+
+	args := structs.NodeUsageBackfillRequest{
+		NodeID:   c.NodeID(),
+		Backfill: payload,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.Region(),
+			AuthToken: c.secretNodeID(),
+		},
+	}
+	var resp structs.GenericResponse
+	if err := c.RPC(structs.NodeBackfillUsageRPCMethod, &args, &resp); err != nil {
+		c.logger.Warn("failed to backfill usage history", "error", err)
+		c.usageBackfill.restore(entries)
+		return false
+	}
+
+	remaining := c.usageBackfill.len()
+	metrics.IncrCounter([]string{"client", "usage_backfill", "uploaded"}, float32(len(entries)))
+	c.logger.Debug("backfilled usage history", "samples", len(entries),
+		"remaining", remaining)
+	return remaining > 0
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsageBackfill_Buffer(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newUsageBackfill(3)
+	for i := range 4 {
+		b.add("a", &structs.AllocUsageSummary{CPU: i})
+	}
+
+	// The oldest summary is dropped when the buffer is full
+	entries := b.take(2)
+	must.Len(t, 2, entries)
+	must.Eq(t, 1, entries[0].summary.CPU)
+	must.Eq(t, 2, entries[1].summary.CPU)
+	must.Eq(t, 1, b.len())
+
+	// Summaries that failed to upload go back ahead of newer ones
+	b.add("b", &structs.AllocUsageSummary{CPU: 4})
+	b.restore(entries)
+	must.Eq(t, 3, b.len())
+	entries = b.take(10)
+	must.Eq(t, []int{2, 3, 4}, []int{entries[0].summary.CPU, entries[1].summary.CPU, entries[2].summary.CPU})
+
+	backfills := groupUsageBackfill(entries)
+	must.Len(t, 2, backfills)
+	must.Eq(t, "a", backfills[0].AllocID)
+	must.Len(t, 2, backfills[0].Summaries)
+	must.Eq(t, "b", backfills[1].AllocID)
+}
+
+func TestUsageBackfill_Persist(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newUsageBackfill(10)
+	must.Nil(t, b.snapshot())
+
+	b.add("a", &structs.AllocUsageSummary{CPU: 1})
+	b.add("b", &structs.AllocUsageSummary{CPU: 2})
+	snap := b.snapshot()
+	must.Len(t, 2, snap.Entries)
+	must.Eq(t, "a", snap.Entries[0].AllocID)
+	must.Eq(t, 2, snap.Entries[1].Summary.CPU)
+
+	// Nothing to persist until the buffer changes again
+	must.Nil(t, b.snapshot())
+	b.markDirty()
+	must.NotNil(t, b.snapshot())
+
+	// A restarted client buffers the persisted summaries ahead of new ones
+	restored := newUsageBackfill(10)
+	restored.add("c", &structs.AllocUsageSummary{CPU: 3})
+	restored.load(snap)
+	entries := restored.take(10)
+	must.Eq(t, []int{1, 2, 3}, []int{entries[0].summary.CPU, entries[1].summary.CPU, entries[2].summary.CPU})
+}
+
+func TestPendingClientUpdates_UsageBackfill(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newUsageBackfill(usageBackfillLimit)
+	p := newPendingClientUpdates(b)
+	update := func(cpu int) *structs.Allocation {
+		return &structs.Allocation{
+			ID:           "a",
+			UsageSummary: &structs.AllocUsageSummary{CPU: cpu, Timestamp: int64(cpu)},
+		}
+	}
+
+	// While connected, superseded updates are dropped
+	p.add(update(100))
+	p.add(update(200))
+	must.Eq(t, 0, b.len())
+
+	// Failing to send buffers the summaries of updates superseded while
+	// in flight and since
+	toSync := []*structs.Allocation{p.updates["a"]}
+	clear(p.updates)
+	p.add(update(300))
+	p.restore(toSync)
+	must.Eq(t, 1, b.len())
+	p.add(update(400))
+	p.add(update(400))
+	must.Eq(t, 2, b.len())
+
+	p.synced()
+	p.add(update(500))
+	must.Eq(t, 2, b.len())
+
+	entries := b.take(10)
+	must.Eq(t, 200, entries[0].summary.CPU)
+	must.Eq(t, 300, entries[1].summary.CPU)
+}
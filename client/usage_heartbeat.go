@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// pendingUsage holds the latest usage summary of each allocation the client
+// is waiting to send with its next heartbeat. Servers which accept usage
+// summaries with heartbeats record them apart from allocation updates, so an
+// allocation whose usage drifted isn't rewritten.
+type pendingUsage struct {
+	summaries map[string]*structs.AllocUsageSummary
+
+	// backfill buffers the summaries superseded while the client fails to
+	// heartbeat, which is set when the last heartbeat failed
+	backfill *usageBackfill
+	failing  bool
+
+	lock sync.Mutex
+}
+
+func newPendingUsage(backfill *usageBackfill) *pendingUsage {
+	return &pendingUsage{
+		summaries: make(map[string]*structs.AllocUsageSummary),
+		backfill:  backfill,
+	}
+}
+
+// add overwrites the pending usage summary of an allocation. While the client
+// fails to heartbeat, an overwritten summary is buffered for backfill.
+func (p *pendingUsage) add(allocID string, summary *structs.AllocUsageSummary) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if prev, ok := p.summaries[allocID]; ok && p.failing {
+		p.supersedeLocked(allocID, prev, summary)
+	}
+	p.summaries[allocID] = summary
+}
+
+// take removes and returns the pending usage summaries, or nil if there are
+// none. The caller is responsible for calling restore if it can't send them.
+func (p *pendingUsage) take() map[string]*structs.AllocUsageSummary {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.summaries) == 0 {
+		return nil
+	}
+	taken := p.summaries
+	p.summaries = make(map[string]*structs.AllocUsageSummary, len(taken))
+	return taken
+}
+
+// restore refills the pending usage summaries, but only for allocations which
+// don't have a newer one. It is called when the heartbeat failed.
+func (p *pendingUsage) restore(taken map[string]*structs.AllocUsageSummary) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.failing = true
+	for allocID, summary := range taken {
+		if newer, ok := p.summaries[allocID]; ok {
+			p.supersedeLocked(allocID, summary, newer)
+		} else {
+			p.summaries[allocID] = summary
+		}
+	}
+}
+
+// synced records that the client heartbeated successfully.
+func (p *pendingUsage) synced() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.failing = false
+}
+
+// supersedeLocked buffers a usage summary that will not be sent because of a
+// newer one, if the newer one reports different usage.
+func (p *pendingUsage) supersedeLocked(allocID string, prev, newer *structs.AllocUsageSummary) {
+	if p.backfill == nil || prev.Equal(newer) {
+		return
+	}
+	p.backfill.add(allocID, prev)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestPendingUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newUsageBackfill(10)
+	p := newPendingUsage(b)
+	must.Nil(t, p.take())
+
+	// The latest summary of an allocation wins while heartbeats succeed
+	p.add("a", &structs.AllocUsageSummary{CPU: 1})
+	p.add("a", &structs.AllocUsageSummary{CPU: 2})
+	p.add("b", &structs.AllocUsageSummary{CPU: 3})
+	taken := p.take()
+	must.MapLen(t, 2, taken)
+	must.Eq(t, 2, taken["a"].CPU)
+	must.Nil(t, p.take())
+	must.Eq(t, 0, b.len())
+
+	// Summaries of a failed heartbeat are restored unless a newer one came
+	// in, and superseded ones are buffered for backfill while failing
+	p.add("a", &structs.AllocUsageSummary{CPU: 4})
+	p.restore(taken)
+	must.Eq(t, 1, b.len())
+	p.add("b", &structs.AllocUsageSummary{CPU: 5})
+	must.Eq(t, 2, b.len())
+
+	taken = p.take()
+	must.Eq(t, 4, taken["a"].CPU)
+	must.Eq(t, 5, taken["b"].CPU)
+	entries := b.take(10)
+	must.Eq(t, 2, entries[0].summary.CPU)
+	must.Eq(t, 3, entries[1].summary.CPU)
+
+	// Nothing is buffered once heartbeats succeed again
+	p.synced()
+	p.add("a", &structs.AllocUsageSummary{CPU: 6})
+	p.add("a", &structs.AllocUsageSummary{CPU: 7})
+	must.Eq(t, 0, b.len())
+}
+
+func TestClient_AllocStateUpdated_UsageHeartbeats(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newUsageBackfill(10)
+	c := &Client{
+		config:         config.DefaultConfig(),
+		pendingUpdates: newPendingClientUpdates(b),
+		pendingUsage:   newPendingUsage(b),
+	}
+	c.config.Node = mock.Node()
+
+	alloc := mock.Alloc()
+	alloc.UsageSummary = &structs.AllocUsageSummary{CPU: 100}
+
+	// The usage summary goes with allocation updates until the servers
+	// accept it with heartbeats
+	c.AllocStateUpdated(alloc)
+	must.Eq(t, alloc.UsageSummary, c.pendingUpdates.updates[alloc.ID].UsageSummary)
+	must.Nil(t, c.pendingUsage.take())
+
+	c.serversAcceptUsageHeartbeats.Store(true)
+	c.AllocStateUpdated(alloc)
+	must.Nil(t, c.pendingUpdates.updates[alloc.ID].UsageSummary)
+	must.Eq(t, map[string]*structs.AllocUsageSummary{alloc.ID: alloc.UsageSummary},
+		c.pendingUsage.take())
+}
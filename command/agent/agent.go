@@ -77,6 +77,10 @@ type Agent struct {
 	httpLogger log.Logger
 	logOutput  io.Writer
 
+	// accessLogger records the accesses to the endpoints exposing what the
+	// tasks run at every log level
+	accessLogger log.Logger
+
 	// EnterpriseAgent holds information and methods for enterprise functionality
 	EnterpriseAgent *EnterpriseAgent
 
@@ -146,6 +150,12 @@ func NewAgent(config *Config, logger log.InterceptLogger, logOutput io.Writer, i
 	// Create the loggers
 	a.logger = logger
 	a.httpLogger = a.logger.ResetNamed("http")
+	a.accessLogger = log.New(&log.LoggerOptions{
+		Level:           log.Trace,
+		Output:          logOutput,
+		JSONFormat:      config.LogJson,
+		IncludeLocation: config.LogIncludeLocation,
+	})
 
 	// Global logger should match internal logger as much as possible
 	golog.SetFlags(golog.LstdFlags | golog.Lmicroseconds)
@@ -493,6 +503,37 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.RootKeyRotationThreshold = dur
 	}
+	if retention := agentConfig.Server.UsageHistoryRetention; retention != "" {
+		dur, err := time.ParseDuration(retention)
+		if err != nil {
+			return nil, err
+		}
+		conf.UsageHistoryRetention = dur
+	}
+	if interval := agentConfig.Server.UsageHistoryInterval; interval != "" {
+		dur, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, err
+		}
+		conf.UsageHistoryInterval = dur
+	}
+	if gcInterval := agentConfig.Server.UsageHistoryGCInterval; gcInterval != "" {
+		dur, err := time.ParseDuration(gcInterval)
+		if err != nil {
+			return nil, err
+		}
+		conf.UsageHistoryGCInterval = dur
+	}
+	if retention := agentConfig.Server.BillingRecordRetention; retention != "" {
+		dur, err := time.ParseDuration(retention)
+		if err != nil {
+			return nil, err
+		}
+		if dur <= 0 {
+			return nil, fmt.Errorf("billing_record_retention must be greater than 0")
+		}
+		conf.BillingRecordRetention = dur
+	}
 
 	if heartbeatGrace := agentConfig.Server.HeartbeatGrace; heartbeatGrace != 0 {
 		conf.HeartbeatGrace = heartbeatGrace
@@ -579,6 +620,27 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set usage admission configuration.
+	if usageConf := agentConfig.Server.UsageAdmission; usageConf != nil {
+		switch usageConf.Mode {
+		case "", nomad.UsageAdmissionModeWarn, nomad.UsageAdmissionModeDeny:
+			conf.UsageAdmissionMode = usageConf.Mode
+		default:
+			return nil, fmt.Errorf("usage_admission.mode must be %q or %q, got %q",
+				nomad.UsageAdmissionModeWarn, nomad.UsageAdmissionModeDeny, usageConf.Mode)
+		}
+		if usageConf.MaxRatio < 0 || (usageConf.MaxRatio > 0 && usageConf.MaxRatio <= 1) {
+			return nil, fmt.Errorf("usage_admission.max_ratio must be greater than 1")
+		} else if usageConf.MaxRatio > 0 {
+			conf.UsageAdmissionMaxRatio = usageConf.MaxRatio
+		}
+		if usageConf.MinAllocations < 0 {
+			return nil, fmt.Errorf("usage_admission.min_allocations must not be negative")
+		} else if usageConf.MinAllocations > 0 {
+			conf.UsageAdmissionMinAllocations = usageConf.MinAllocations
+		}
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseConfig = &nomad.LicenseConfig{
 		BuildDate:         agentConfig.Version.BuildDate,
@@ -636,6 +698,7 @@ func (a *Agent) serverConfig() (*nomad.Config, error) {
 func (a *Agent) finalizeServerConfig(c *nomad.Config) {
 	// Setup the logging
 	c.Logger = a.logger
+	c.AuditLogger = a.accessLogger
 	c.LogOutput = a.logOutput
 	c.AgentShutdown = func() error { return a.Shutdown() }
 }
@@ -660,6 +723,7 @@ func (a *Agent) clientConfig() (*clientconfig.Config, error) {
 func (a *Agent) finalizeClientConfig(c *clientconfig.Config) error {
 	// Setup the logging
 	c.Logger = a.logger
+	c.AuditLogger = a.accessLogger
 
 	// If we are running a server, append both its bind and advertise address so
 	// we are able to at least talk to the local server even if that isn't
@@ -722,6 +786,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.Region = agentConfig.Region
 	}
 	if agentConfig.DataDir != "" {
+		conf.DataDir = agentConfig.DataDir
 		conf.StateDir = filepath.Join(agentConfig.DataDir, "client")
 		conf.AllocDir = filepath.Join(agentConfig.DataDir, "alloc")
 		dataParent := filepath.Dir(agentConfig.DataDir)
@@ -771,6 +836,41 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.MaxDynamicPort = agentConfig.Client.MaxDynamicPort
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
+	conf.TaskEnergyStats = agentConfig.Client.TaskEnergyStats
+	conf.LazyTaskStats = agentConfig.Client.LazyTaskStats
+	conf.RecordExecutorStats = agentConfig.Client.RecordExecutorStats
+	switch m := agentConfig.Client.ExecutorStatsMode; m {
+	case "", clientconfig.ExecutorStatsModeFull, clientconfig.ExecutorStatsModeJiffies:
+		conf.ExecutorStatsMode = m
+	default:
+		return nil, fmt.Errorf("invalid executor_stats_mode: must be %q or %q: %q",
+			clientconfig.ExecutorStatsModeFull, clientconfig.ExecutorStatsModeJiffies, m)
+	}
+	conf.AuditTaskStarts = agentConfig.Client.AuditTaskStarts
+	if t := agentConfig.Client.UsageAnomalyThreshold; t < 0 {
+		return nil, fmt.Errorf("invalid usage_anomaly_threshold: must not be negative: %v", t)
+	}
+	conf.UsageAnomalyThreshold = agentConfig.Client.UsageAnomalyThreshold
+	switch n := agentConfig.Client.CPUPercentNormalization; n {
+	case "":
+		conf.CPUPercentNormalization = clientconfig.CPUPercentPerCore
+	case clientconfig.CPUPercentPerCore, clientconfig.CPUPercentAllocated, clientconfig.CPUPercentNode:
+		conf.CPUPercentNormalization = n
+	default:
+		return nil, fmt.Errorf("invalid cpu_percent_normalization: must be one of %q, %q, or %q: %q",
+			clientconfig.CPUPercentPerCore, clientconfig.CPUPercentAllocated, clientconfig.CPUPercentNode, n)
+	}
+	conf.GCAutoTune = agentConfig.Client.GCAutoTune
+	conf.QoSClasses = agentConfig.Client.QoSClasses
+	if t := agentConfig.Client.MemoryEvictionThreshold; t < 0 || t >= 100 {
+		return nil, fmt.Errorf("invalid memory_eviction_threshold: must be between 0 and 100: %v", t)
+	} else if t > 0 && !conf.QoSClasses {
+		return nil, fmt.Errorf("invalid memory_eviction_threshold: requires qos_classes to be enabled")
+	}
+	conf.MemoryEvictionThreshold = agentConfig.Client.MemoryEvictionThreshold
+	if agentConfig.Client.UtilizationAttributesInterval != 0 {
+		conf.UtilizationAttributesInterval = agentConfig.Client.UtilizationAttributesInterval
+	}
 
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = conf.TemplateConfig.Merge(agentConfig.Client.TemplateConfig)
@@ -916,6 +1016,10 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.BridgeNetworkAllocSubnetIPv6 = ipv6Subnet
 	}
 	conf.BridgeNetworkHairpinMode = agentConfig.Client.BridgeNetworkHairpinMode
+	if agentConfig.Client.BridgeNetworkConntrackLimit < 0 {
+		return nil, fmt.Errorf("invalid bridge_network_conntrack_limit: must not be negative")
+	}
+	conf.BridgeNetworkConntrackLimit = agentConfig.Client.BridgeNetworkConntrackLimit
 
 	for _, hn := range agentConfig.Client.HostNetworks {
 		conf.HostNetworks[hn.Name] = hn
@@ -939,6 +1043,24 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.Drain = drainConfig
 
+	selfProfiling, err := clientconfig.SelfProfilingConfigFromAgent(agentConfig.Client.SelfProfiling)
+	if err != nil {
+		return nil, fmt.Errorf("invalid self_profiling config: %v", err)
+	}
+	conf.SelfProfiling = selfProfiling
+
+	hotplug, err := clientconfig.HotplugConfigFromAgent(agentConfig.Client.Hotplug)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hotplug config: %v", err)
+	}
+	conf.Hotplug = hotplug
+
+	driverFencing, err := clientconfig.DriverFencingConfigFromAgent(agentConfig.Client.DriverFencing)
+	if err != nil {
+		return nil, fmt.Errorf("invalid driver_fencing config: %v", err)
+	}
+	conf.DriverFencing = driverFencing
+
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
 
 	return conf, nil
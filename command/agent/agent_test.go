@@ -740,6 +740,24 @@ func TestConvertClientConfig(t *testing.T) {
 			},
 			expectErr: "invalid bridge_network_subnet_ipv6: not an IPv6 address: 10.0.0.1/24",
 		},
+		{
+			name: "memory eviction with qos classes",
+			modConfig: func(c *Config) {
+				c.Client.QoSClasses = true
+				c.Client.MemoryEvictionThreshold = 10
+			},
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.True(t, cc.QoSClasses)
+				must.Eq(t, 10, cc.MemoryEvictionThreshold)
+			},
+		},
+		{
+			name: "memory eviction without qos classes",
+			modConfig: func(c *Config) {
+				c.Client.MemoryEvictionThreshold = 10
+			},
+			expectErr: "invalid memory_eviction_threshold: requires qos_classes to be enabled",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
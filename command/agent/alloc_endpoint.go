@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,7 +15,9 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
@@ -73,6 +76,45 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 	return out.Allocations, nil
 }
 
+func (s *HTTPServer) AllocBillingRecordsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.AllocBillingRecordsRequest{
+		JobID: req.URL.Query().Get("job"),
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	since, err := parseTime(req, "since")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if since != nil {
+		args.Since = since.UnixNano()
+	}
+	until, err := parseTime(req, "until")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if until != nil {
+		args.Until = until.UnixNano()
+	}
+
+	var out structs.AllocBillingRecordsResponse
+	if err := s.agent.RPC(structs.AllocBillingRecordsRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Records == nil {
+		out.Records = make([]*structs.AllocBillingRecord, 0)
+	}
+	return out.Records, nil
+}
+
 func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	reqSuffix := strings.TrimPrefix(req.URL.Path, "/v1/allocation/")
 
@@ -215,6 +257,9 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 	// tokenize the suffix of the path to get the alloc id and find the action
 	// invoked on the alloc id
 	tokens := strings.Split(reqSuffix, "/")
+	if len(tokens) == 3 && tokens[1] == "stats" && tokens[2] == "stream" {
+		return s.allocStatsStream(tokens[0], resp, req)
+	}
 	if len(tokens) != 2 {
 		return nil, CodedError(404, resourceNotFoundErr)
 	}
@@ -224,6 +269,10 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 		return s.allocChecks(allocID, resp, req)
 	case "stats":
 		return s.allocStats(allocID, resp, req)
+	case "processes":
+		return s.allocProcesses(allocID, resp, req)
+	case "capture":
+		return s.allocCapture(allocID, resp, req)
 	case "exec":
 		return s.allocExec(allocID, resp, req)
 	case "snapshot":
@@ -520,20 +569,140 @@ func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *h
 		AllocID: allocID,
 		Task:    task,
 	}
+	if window := req.URL.Query().Get("window"); window != "" {
+		var err error
+		args.Window, err = strconv.ParseBool(window)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse window value %q: %v", window, err))
+		}
+	}
+	if after := req.URL.Query().Get("after"); after != "" {
+		var err error
+		args.After, err = strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse after value %q: %v", after, err))
+		}
+	}
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
+	return s.allocStatsRPC(&args)
+}
+
+// statsStreamWait is how long each request of a stats stream waits for a new
+// sample, which bounds how long a stream outlives its connection
+const statsStreamWait = 10 * time.Second
+
+// allocStatsStream streams each new sample of the stats of an allocation as
+// it's collected, over a websocket if the request is a websocket upgrade and
+// otherwise as newline delimited JSON.
+func (s *HTTPServer) allocStatsStream(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := cstructs.AllocStatsRequest{
+		AllocID: allocID,
+		Task:    req.URL.Query().Get("task"),
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+	args.MaxQueryTime = statsStreamWait
+
+	// Get the current sample before streaming, so errors such as unknown
+	// allocations are returned as responses
+	stats, err := s.allocStatsRPC(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	var send func(*cstructs.AllocResourceUsage) error
+	var finish func(error)
+	if websocket.IsWebSocketUpgrade(req) {
+		conn, err := s.wsUpgrader.Upgrade(resp, req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade connection: %v", err)
+		}
+		defer conn.Close()
+
+		// Reading detects the consumer closing the connection
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		send = func(stats *cstructs.AllocResourceUsage) error {
+			var buf bytes.Buffer
+			if err := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions).Encode(stats); err != nil {
+				return err
+			}
+			return conn.WriteMessage(websocket.TextMessage, buf.Bytes())
+		}
+		finish = func(err error) {
+			msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			if err != nil {
+				msg = websocket.FormatCloseMessage(toWsCode(500), err.Error())
+			}
+			conn.WriteMessage(websocket.CloseMessage, msg)
+		}
+	} else {
+		resp.Header().Set("Content-Type", "application/json")
+		resp.Header().Set("Cache-Control", "no-cache")
+		output := ioutils.NewWriteFlusher(resp)
+		enc := codec.NewEncoder(output, structs.JsonHandleWithExtensions)
+
+		send = func(stats *cstructs.AllocResourceUsage) error {
+			if err := enc.Encode(stats); err != nil {
+				return err
+			}
+			// Each sample is its own line according to https://github.com/ndjson/ndjson-spec
+			_, err := fmt.Fprint(output, "\n")
+			return err
+		}
+		finish = func(error) {}
+	}
+
+	for ctx.Err() == nil {
+		if stats.Timestamp > args.After {
+			if err := send(stats); err != nil {
+				return nil, nil
+			}
+			args.After = stats.Timestamp
+		}
+
+		// The stream ends once the allocation is gone or its client
+		// becomes unreachable
+		if stats, err = s.allocStatsRPC(&args); err != nil {
+			s.logger.Debug("stopped streaming alloc stats", "alloc_id", allocID, "error", err)
+			finish(err)
+			return nil, nil
+		}
+	}
+	finish(nil)
+	return nil, nil
+}
+
+// allocStatsRPC gets the resource usage of an allocation from the local client
+// if it runs the allocation, or else from the client running it through the
+// servers.
+func (s *HTTPServer) allocStatsRPC(args *cstructs.AllocStatsRequest) (*cstructs.AllocResourceUsage, error) {
 	// Determine the handler to use
-	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(args.AllocID)
 
 	// Make the RPC
 	var reply cstructs.AllocStatsResponse
 	var rpcErr error
 	if useLocalClient {
-		rpcErr = s.agent.Client().ClientRPC("Allocations.Stats", &args, &reply)
+		rpcErr = s.agent.Client().ClientRPC("Allocations.Stats", args, &reply)
 	} else if useClientRPC {
-		rpcErr = s.agent.Client().RPC("ClientAllocations.Stats", &args, &reply)
+		rpcErr = s.agent.Client().RPC("ClientAllocations.Stats", args, &reply)
 	} else if useServerRPC {
-		rpcErr = s.agent.Server().RPC("ClientAllocations.Stats", &args, &reply)
+		rpcErr = s.agent.Server().RPC("ClientAllocations.Stats", args, &reply)
 	} else {
 		rpcErr = CodedError(400, "No local Node and node_id not provided")
 	}
@@ -547,6 +716,84 @@ func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *h
 	return reply.Stats, rpcErr
 }
 
+func (s *HTTPServer) allocProcesses(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	// Build the request and parse the ACL token
+	args := cstructs.AllocProcessesRequest{
+		AllocID: allocID,
+		Task:    req.URL.Query().Get("task"),
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocProcessesResponse
+	var rpcErr error
+	switch {
+	case useLocalClient:
+		rpcErr = s.agent.Client().ClientRPC("Allocations.Processes", &args, &reply)
+	case useClientRPC:
+		rpcErr = s.agent.Client().RPC("ClientAllocations.Processes", &args, &reply)
+	case useServerRPC:
+		rpcErr = s.agent.Server().RPC("ClientAllocations.Processes", &args, &reply)
+	default:
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+
+	return reply.Tasks, rpcErr
+}
+
+func (s *HTTPServer) allocCapture(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	// Build the request and parse the ACL token
+	args := cstructs.AllocCaptureRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, fmt.Sprintf("Failed to decode body: %v", err))
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+	args.AllocID = allocID
+
+	if args.Task == "" {
+		return nil, CodedError(400, "Task must be specified")
+	}
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocCaptureResponse
+	var rpcErr error
+	switch {
+	case useLocalClient:
+		rpcErr = s.agent.Client().ClientRPC("Allocations.Capture", &args, &reply)
+	case useClientRPC:
+		rpcErr = s.agent.Client().RPC("ClientAllocations.Capture", &args, &reply)
+	case useServerRPC:
+		rpcErr = s.agent.Server().RPC("ClientAllocations.Capture", &args, &reply)
+	default:
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+
+	return reply, rpcErr
+}
+
 func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
 	// Build the request and parse the ACL token
 	args := cstructs.AllocChecksRequest{
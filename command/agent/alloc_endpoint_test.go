@@ -620,6 +620,24 @@ func TestHTTP_AllocStats(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocStatsStream(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Streams of unknown allocations fail before streaming
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/client/allocation/%s/stats/stream", uuid.Generate()), nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.True(t, structs.IsErrUnknownAllocation(err))
+
+		// Long polls need a valid timestamp
+		req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/client/allocation/%s/stats?after=soon", uuid.Generate()), nil)
+		must.NoError(t, err)
+		_, err = s.Server.ClientAllocRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Failed to parse after value")
+	})
+}
+
 func TestHTTP_AllocStats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
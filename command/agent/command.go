@@ -32,6 +32,7 @@ import (
 	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	gatedwriter "github.com/hashicorp/nomad/helper/gated-writer"
 	"github.com/hashicorp/nomad/helper/logging"
+	"github.com/hashicorp/nomad/helper/otlpmetrics"
 	"github.com/hashicorp/nomad/helper/winsvc"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -861,6 +862,9 @@ func (c *Command) Run(args []string) int {
 	defer func() {
 		c.agent.Shutdown()
 
+		// Flush any metrics buffered by the telemetry sinks
+		metrics.Shutdown()
+
 		// Shutdown the http server at the end, to ease debugging if
 		// the agent takes long to shutdown
 		if len(c.httpServers) > 0 {
@@ -1304,6 +1308,33 @@ func (c *Command) setupTelemetry(config *Config) (*metrics.InmemSink, error) {
 		fanout = append(fanout, sink)
 	}
 
+	// Configure the OTLP sink
+	if telConfig.OTLPEndpoint != "" {
+		resourceAttrs := map[string]string{
+			"service.name":     "nomad",
+			"service.version":  version.GetVersion().VersionNumber(),
+			"nomad.region":     config.Region,
+			"nomad.datacenter": config.Datacenter,
+		}
+		if config.NodeName != "" {
+			resourceAttrs["service.instance.id"] = config.NodeName
+		}
+		if hostname, err := os.Hostname(); err == nil {
+			resourceAttrs["host.name"] = hostname
+		}
+
+		sink, err := otlpmetrics.NewSink(&otlpmetrics.Config{
+			Endpoint:           telConfig.OTLPEndpoint,
+			Headers:            telConfig.OTLPHeaders,
+			ExportInterval:     telConfig.otlpExportInterval,
+			ResourceAttributes: resourceAttrs,
+		})
+		if err != nil {
+			return inm, err
+		}
+		fanout = append(fanout, sink)
+	}
+
 	// Initialize the global sink
 	if len(fanout) > 0 {
 		fanout = append(fanout, inm)
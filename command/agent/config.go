@@ -335,6 +335,51 @@ type ClientConfig struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool `hcl:"disable_remote_exec"`
 
+	// TaskEnergyStats enables apportioning the measured host power to tasks
+	// by their share of the host CPU usage in allocation stats.
+	TaskEnergyStats bool `hcl:"task_energy_stats"`
+
+	// LazyTaskStats pauses task stats collection while nothing consumes the
+	// samples.
+	LazyTaskStats bool `hcl:"lazy_task_stats"`
+
+	// RecordExecutorStats makes executors record the stats samples they send
+	// to a file in the task directory, for debugging.
+	RecordExecutorStats bool `hcl:"record_executor_stats"`
+
+	// ExecutorStatsMode is how executors read the processes of tasks: "full"
+	// or "jiffies".
+	ExecutorStatsMode string `hcl:"executor_stats_mode"`
+
+	// UsageAnomalyThreshold is how many standard deviations from its moving
+	// average a sample of a task's usage must be to be flagged in a task event.
+	UsageAnomalyThreshold float64 `hcl:"usage_anomaly_threshold"`
+
+	// CPUPercentNormalization is the convention of the CPU percent published
+	// in the allocation metrics: "core", "allocated", or "node".
+	CPUPercentNormalization string `hcl:"cpu_percent_normalization"`
+
+	// AuditTaskStarts records the resolved command, user, cgroup and hashes
+	// of the arguments and environment of each task start in its Started
+	// event.
+	AuditTaskStarts bool `hcl:"audit_task_starts"`
+
+	// GCAutoTune makes the agent adjust its garbage collector to the memory
+	// pressure of the node.
+	GCAutoTune bool `hcl:"gc_autotune"`
+
+	// QoSClasses enforces the QoS classes of the tasks of the client.
+	QoSClasses bool `hcl:"qos_classes"`
+
+	// MemoryEvictionThreshold is the percent of the memory of the node
+	// available below which allocations are evicted by QoS class.
+	MemoryEvictionThreshold float64 `hcl:"memory_eviction_threshold"`
+
+	// UtilizationAttributesInterval is how often the utilization attributes
+	// of the node are refreshed
+	UtilizationAttributesInterval    time.Duration
+	UtilizationAttributesIntervalHCL string `hcl:"utilization_attributes_interval" json:"-"`
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *client.ClientTemplateConfig `hcl:"template"`
 
@@ -371,6 +416,11 @@ type ClientConfig struct {
 	// internal bridge network
 	BridgeNetworkHairpinMode bool `hcl:"bridge_network_hairpin_mode"`
 
+	// BridgeNetworkConntrackLimit is the number of connections each
+	// allocation in bridge networking mode may track at once. Zero means
+	// unlimited.
+	BridgeNetworkConntrackLimit int `hcl:"bridge_network_conntrack_limit"`
+
 	// HostNetworks describes the different host networks available to the host
 	// if the host uses multiple interfaces
 	HostNetworks []*structs.ClientHostNetworkConfig `hcl:"host_network"`
@@ -399,6 +449,18 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// SelfProfiling configures the client to capture profiles of itself when
+	// its resource usage exceeds thresholds.
+	SelfProfiling *config.SelfProfilingConfig `hcl:"self_profiling"`
+
+	// Hotplug configures the client to fingerprint devices again when they
+	// are attached to or removed from the node.
+	Hotplug *config.HotplugConfig `hcl:"hotplug"`
+
+	// DriverFencing configures the client to fail the tasks of drivers that
+	// stayed unhealthy.
+	DriverFencing *config.DriverFencingConfig `hcl:"driver_fencing"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -423,6 +485,9 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
 	nc.Users = c.Users.Copy()
+	nc.SelfProfiling = c.SelfProfiling.Copy()
+	nc.Hotplug = c.Hotplug.Copy()
+	nc.DriverFencing = c.DriverFencing.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -581,6 +646,22 @@ type ServerConfig struct {
 	// collection interval.
 	RootKeyRotationThreshold string `hcl:"root_key_rotation_threshold"`
 
+	// UsageHistoryRetention is how long the usage samples of allocations are
+	// kept. "0" disables recording usage history.
+	UsageHistoryRetention string `hcl:"usage_history_retention"`
+
+	// UsageHistoryInterval is the minimum time between the recorded usage
+	// samples of an allocation.
+	UsageHistoryInterval string `hcl:"usage_history_interval"`
+
+	// UsageHistoryGCInterval is how often we dispatch a job to GC expired
+	// usage samples.
+	UsageHistoryGCInterval string `hcl:"usage_history_gc_interval"`
+
+	// BillingRecordRetention is how long the billing records of allocations
+	// are kept after they terminated.
+	BillingRecordRetention string `hcl:"billing_record_retention"`
+
 	// HeartbeatGrace is the grace period beyond the TTL to account for network,
 	// processing delays and clock skew before marking a node as "down".
 	HeartbeatGrace    time.Duration
@@ -657,6 +738,10 @@ type ServerConfig struct {
 	// detects potentially bad nodes.
 	PlanRejectionTracker *PlanRejectionTracker `hcl:"plan_rejection_tracker"`
 
+	// UsageAdmission configures comparing the resources requested by job
+	// submissions against the usage observed for the job.
+	UsageAdmission *UsageAdmission `hcl:"usage_admission"`
+
 	// EnableEventBroker configures whether this server's state store
 	// will generate events for its event stream.
 	EnableEventBroker *bool `hcl:"enable_event_broker"`
@@ -743,6 +828,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.ServerJoin = s.ServerJoin.Copy()
 	ns.DefaultSchedulerConfig = s.DefaultSchedulerConfig.Copy()
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
+	ns.UsageAdmission = s.UsageAdmission.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
 	ns.JobMaxSourceSize = pointer.Copy(s.JobMaxSourceSize)
@@ -759,6 +845,59 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	return &ns
 }
 
+// UsageAdmission is used in servers to configure comparing the resources
+// requested by job submissions against the usage observed for the job.
+type UsageAdmission struct {
+	// Mode is "warn" to warn about job submissions requesting resources that
+	// deviate from the observed usage, or "deny" to reject them. Requests
+	// aren't compared to the usage if empty.
+	Mode string `hcl:"mode"`
+
+	// MaxRatio is the ratio between the requested resources and the observed
+	// usage of a task over which the request deviates, in either direction.
+	MaxRatio float64 `hcl:"max_ratio"`
+
+	// MinAllocations is the number of running allocations of a task group
+	// that must report usage before requests are compared to it.
+	MinAllocations int `hcl:"min_allocations"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (u *UsageAdmission) Copy() *UsageAdmission {
+	if u == nil {
+		return nil
+	}
+
+	nu := *u
+	nu.ExtraKeysHCL = slices.Clone(u.ExtraKeysHCL)
+	return &nu
+}
+
+func (u *UsageAdmission) Merge(b *UsageAdmission) *UsageAdmission {
+	if u == nil {
+		return b
+	}
+
+	result := *u
+
+	if b == nil {
+		return &result
+	}
+
+	if b.Mode != "" {
+		result.Mode = b.Mode
+	}
+	if b.MaxRatio != 0 {
+		result.MaxRatio = b.MaxRatio
+	}
+	if b.MinAllocations != 0 {
+		result.MinAllocations = b.MinAllocations
+	}
+	return &result
+}
+
 // RaftBoltConfig is used in servers to configure parameters of the boltdb
 // used for raft consensus.
 type RaftBoltConfig struct {
@@ -983,6 +1122,18 @@ type Telemetry struct {
 	IncludeAllocMetadataInMetrics bool          `hcl:"include_alloc_metadata_in_metrics"`
 	AllowedMetadataKeysInMetrics  []string      `hcl:"allowed_metadata_keys_in_metrics"`
 
+	// OTLPEndpoint is the URL of the OTLP/HTTP metrics endpoint of an
+	// OpenTelemetry collector. Metrics are exported over OTLP when it is set.
+	OTLPEndpoint string `hcl:"otlp_endpoint"`
+
+	// OTLPHeaders are sent with every OTLP export request, for example to
+	// authenticate with the collector.
+	OTLPHeaders map[string]string `hcl:"otlp_headers"`
+
+	// OTLPExportInterval is how often metrics are exported over OTLP
+	OTLPExportInterval string        `hcl:"otlp_export_interval"`
+	otlpExportInterval time.Duration `hcl:"-"`
+
 	// PrefixFilter allows for filtering out metrics from being collected
 	PrefixFilter []string `hcl:"prefix_filter"`
 
@@ -1088,6 +1239,7 @@ func (t *Telemetry) Copy() *Telemetry {
 
 	nt := *t
 	nt.DataDogTags = slices.Clone(t.DataDogTags)
+	nt.OTLPHeaders = maps.Clone(t.OTLPHeaders)
 	nt.PrefixFilter = slices.Clone(t.PrefixFilter)
 	nt.FilterDefault = pointer.Copy(t.FilterDefault)
 	nt.ExtraKeysHCL = slices.Clone(t.ExtraKeysHCL)
@@ -2165,6 +2317,18 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.RootKeyRotationThreshold != "" {
 		result.RootKeyRotationThreshold = b.RootKeyRotationThreshold
 	}
+	if b.UsageHistoryRetention != "" {
+		result.UsageHistoryRetention = b.UsageHistoryRetention
+	}
+	if b.UsageHistoryInterval != "" {
+		result.UsageHistoryInterval = b.UsageHistoryInterval
+	}
+	if b.UsageHistoryGCInterval != "" {
+		result.UsageHistoryGCInterval = b.UsageHistoryGCInterval
+	}
+	if b.BillingRecordRetention != "" {
+		result.BillingRecordRetention = b.BillingRecordRetention
+	}
 	if b.HeartbeatGrace != 0 {
 		result.HeartbeatGrace = b.HeartbeatGrace
 	}
@@ -2231,6 +2395,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.PlanRejectionTracker = result.PlanRejectionTracker.Merge(b.PlanRejectionTracker)
 	}
 
+	if b.UsageAdmission != nil {
+		result.UsageAdmission = result.UsageAdmission.Merge(b.UsageAdmission)
+	}
+
 	if b.DefaultSchedulerConfig != nil {
 		c := *b.DefaultSchedulerConfig
 		result.DefaultSchedulerConfig = &c
@@ -2391,6 +2559,53 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.DisableRemoteExec = b.DisableRemoteExec
 	}
 
+	if b.TaskEnergyStats {
+		result.TaskEnergyStats = b.TaskEnergyStats
+	}
+
+	if b.LazyTaskStats {
+		result.LazyTaskStats = b.LazyTaskStats
+	}
+
+	if b.RecordExecutorStats {
+		result.RecordExecutorStats = b.RecordExecutorStats
+	}
+
+	if b.ExecutorStatsMode != "" {
+		result.ExecutorStatsMode = b.ExecutorStatsMode
+	}
+
+	if b.UsageAnomalyThreshold != 0 {
+		result.UsageAnomalyThreshold = b.UsageAnomalyThreshold
+	}
+
+	if b.CPUPercentNormalization != "" {
+		result.CPUPercentNormalization = b.CPUPercentNormalization
+	}
+
+	if b.AuditTaskStarts {
+		result.AuditTaskStarts = b.AuditTaskStarts
+	}
+
+	if b.GCAutoTune {
+		result.GCAutoTune = b.GCAutoTune
+	}
+
+	if b.QoSClasses {
+		result.QoSClasses = true
+	}
+
+	if b.MemoryEvictionThreshold != 0 {
+		result.MemoryEvictionThreshold = b.MemoryEvictionThreshold
+	}
+
+	if b.UtilizationAttributesInterval != 0 {
+		result.UtilizationAttributesInterval = b.UtilizationAttributesInterval
+	}
+	if b.UtilizationAttributesIntervalHCL != "" {
+		result.UtilizationAttributesIntervalHCL = b.UtilizationAttributesIntervalHCL
+	}
+
 	if b.TemplateConfig != nil {
 		result.TemplateConfig = result.TemplateConfig.Merge(b.TemplateConfig)
 	}
@@ -2450,6 +2665,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.BridgeNetworkHairpinMode {
 		result.BridgeNetworkHairpinMode = true
 	}
+	if b.BridgeNetworkConntrackLimit != 0 {
+		result.BridgeNetworkConntrackLimit = b.BridgeNetworkConntrackLimit
+	}
 
 	result.HostNetworks = a.HostNetworks
 
@@ -2474,6 +2692,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Artifact = a.Artifact.Merge(b.Artifact)
 	result.Drain = a.Drain.Merge(b.Drain)
 	result.Users = a.Users.Merge(b.Users)
+	result.SelfProfiling = a.SelfProfiling.Merge(b.SelfProfiling)
+	result.Hotplug = a.Hotplug.Merge(b.Hotplug)
+	result.DriverFencing = a.DriverFencing.Merge(b.DriverFencing)
 
 	return &result
 }
@@ -2532,6 +2753,18 @@ func (t *Telemetry) Merge(b *Telemetry) *Telemetry {
 		result.IncludeAllocMetadataInMetrics = true
 	}
 	result.AllowedMetadataKeysInMetrics = append(result.AllowedMetadataKeysInMetrics, b.AllowedMetadataKeysInMetrics...)
+	if b.OTLPEndpoint != "" {
+		result.OTLPEndpoint = b.OTLPEndpoint
+	}
+	if b.OTLPHeaders != nil {
+		result.OTLPHeaders = b.OTLPHeaders
+	}
+	if b.OTLPExportInterval != "" {
+		result.OTLPExportInterval = b.OTLPExportInterval
+	}
+	if b.otlpExportInterval != 0 {
+		result.otlpExportInterval = b.otlpExportInterval
+	}
 	if b.CirconusAPIToken != "" {
 		result.CirconusAPIToken = b.CirconusAPIToken
 	}
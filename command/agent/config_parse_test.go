@@ -89,6 +89,8 @@ var basicConfig = &Config{
 		GCMaxAllocs:           50,
 		NoHostUUID:            pointer.Of(false),
 		DisableRemoteExec:     true,
+		TaskEnergyStats:       true,
+		LazyTaskStats:         true,
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -211,6 +213,10 @@ var basicConfig = &Config{
 		collectionInterval:         3 * time.Second,
 		PublishAllocationMetrics:   true,
 		PublishNodeMetrics:         true,
+		OTLPEndpoint:               "http://127.0.0.1:4318",
+		OTLPHeaders:                map[string]string{"Authorization": "Bearer token"},
+		OTLPExportInterval:         "15s",
+		otlpExportInterval:         15 * time.Second,
 	},
 	LeaveOnInt:                true,
 	LeaveOnTerm:               true,
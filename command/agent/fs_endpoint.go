@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/v2/codec"
@@ -264,9 +265,14 @@ func (s *HTTPServer) Stream(resp http.ResponseWriter, req *http.Request) (interf
 //   - offset: The offset to start streaming data at, defaults to zero.
 //   - origin: Either "start" or "end" and defines from where the offset is
 //     applied. Defaults to "start".
+//   - timestamps: A boolean of whether to prefix lines with the time they were
+//     produced at.
+//   - since, until: RFC 3339 times selecting the lines produced in a time
+//     range.
+//   - filter: A regular expression selecting the lines matching it.
 func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var allocID, task, logType string
-	var plain, follow bool
+	var plain, follow, timestamps bool
 	var err error
 
 	q := req.URL.Query()
@@ -290,6 +296,24 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		}
 	}
 
+	if timestampsStr := q.Get("timestamps"); timestampsStr != "" {
+		if timestamps, err = strconv.ParseBool(timestampsStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse timestamps field to boolean: %v", err))
+		}
+	}
+
+	var since, until time.Time
+	if sinceStr := q.Get("since"); sinceStr != "" {
+		if since, err = time.Parse(time.RFC3339Nano, sinceStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse since field to a time: %v", err))
+		}
+	}
+	if untilStr := q.Get("until"); untilStr != "" {
+		if until, err = time.Parse(time.RFC3339Nano, untilStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse until field to a time: %v", err))
+		}
+	}
+
 	logType = q.Get("type")
 	switch logType {
 	case "stdout", "stderr":
@@ -317,13 +341,17 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 
 	// Create the request arguments
 	fsReq := &cstructs.FsLogsRequest{
-		AllocID:   allocID,
-		Task:      task,
-		LogType:   logType,
-		Offset:    offset,
-		Origin:    origin,
-		PlainText: plain,
-		Follow:    follow,
+		AllocID:    allocID,
+		Task:       task,
+		LogType:    logType,
+		Offset:     offset,
+		Origin:     origin,
+		PlainText:  plain,
+		Follow:     follow,
+		Timestamps: timestamps,
+		Since:      since,
+		Until:      until,
+		Filter:     q.Get("filter"),
 	}
 	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
 
@@ -110,6 +110,9 @@ type HTTPServer struct {
 	Addr       string
 
 	wsUpgrader *websocket.Upgrader
+
+	// nsMetricsLimiter rate limits the namespace metrics requests
+	nsMetricsLimiter *namespaceLimiter
 }
 
 // NewHTTPServers starts an HTTP server for every address.http configured in
@@ -145,6 +148,9 @@ func NewHTTPServers(agent *Agent, config *Config) ([]*HTTPServer, error) {
 		WriteBufferSize: 2048,
 	}
 
+	// The namespace metrics are limited per agent, not per listener
+	nsMetricsLimiter := newNamespaceLimiter()
+
 	// Start the listener
 	for _, addr := range config.normalizedAddrs.HTTP {
 		lnAddr, err := net.ResolveTCPAddr("tcp", addr)
@@ -178,6 +184,8 @@ func NewHTTPServers(agent *Agent, config *Config) ([]*HTTPServer, error) {
 			logger:       agent.httpLogger,
 			Addr:         ln.Addr().String(),
 			wsUpgrader:   wsUpgrader,
+
+			nsMetricsLimiter: nsMetricsLimiter,
 		}
 		srv.registerHandlers(config.EnableDebug)
 
@@ -218,6 +226,8 @@ func NewHTTPServers(agent *Agent, config *Config) ([]*HTTPServer, error) {
 			logger:       agent.httpLogger,
 			Addr:         "builtin",
 			wsUpgrader:   wsUpgrader,
+
+			nsMetricsLimiter: nsMetricsLimiter,
 		}
 
 		srv.registerHandlers(config.EnableDebug)
@@ -395,6 +405,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/node/pool/", s.wrap(s.NodePoolSpecificRequest))
 
 	s.mux.HandleFunc("/v1/allocations", s.wrap(s.AllocsRequest))
+	s.mux.HandleFunc("/v1/allocations/billing-records", s.wrap(s.AllocBillingRecordsRequest))
 	s.mux.HandleFunc("/v1/allocation/", s.wrap(s.AllocSpecificRequest))
 
 	s.mux.HandleFunc("/v1/evaluations", s.wrap(s.EvalsRequest))
@@ -446,6 +457,10 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
 	s.mux.Handle("/v1/client/metadata", wrapCORS(s.wrap(s.NodeMetaRequest)))
+	s.mux.HandleFunc("/v1/client/executors", s.wrap(s.ClientExecutorsRequest))
+	s.mux.HandleFunc("/v1/client/executors/gc", s.wrap(s.ClientExecutorsGCRequest))
+	s.mux.Handle("/v1/client/scaling/metric", wrapCORS(s.wrap(s.ClientScalingMetricRequest)))
+	s.mux.Handle("/v1/client/metrics", wrapCORS(s.wrap(s.ClientNamespaceMetricsRequest)))
 
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
 	s.mux.HandleFunc("/v1/agent/join", s.wrap(s.AgentJoinRequest))
@@ -989,6 +1004,19 @@ func parseInt(req *http.Request, field string) (*int, error) {
 	return nil, nil
 }
 
+// parseTime parses an RFC3339 query parameter to a time or returns (nil, nil)
+// if the parameter is not present.
+func parseTime(req *http.Request, field string) (*time.Time, error) {
+	if str := req.URL.Query().Get(field); str != "" {
+		param, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse value of %q (%v) as an RFC3339 time: %v", field, str, err)
+		}
+		return &param, nil
+	}
+	return nil, nil
+}
+
 // parseToken is used to parse the X-Nomad-Token param
 func (s *HTTPServer) parseToken(req *http.Request, token *string) {
 	if other := req.Header.Get("X-Nomad-Token"); other != "" {
@@ -19,6 +19,7 @@ import (
 	api "github.com/hashicorp/nomad/api"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -78,12 +79,21 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/evaluations"):
 		jobID := strings.TrimSuffix(path, "/evaluations")
 		return s.jobEvaluations(resp, req, jobID)
+	case strings.HasSuffix(path, "/utilization"):
+		jobID := strings.TrimSuffix(path, "/utilization")
+		return s.jobUtilization(resp, req, jobID)
+	case strings.HasSuffix(path, "/usage-history"):
+		jobID := strings.TrimSuffix(path, "/usage-history")
+		return s.jobUsageHistory(resp, req, jobID)
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobID := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/plan"):
 		jobID := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobID)
+	case strings.HasSuffix(path, "/what-if"):
+		jobID := strings.TrimSuffix(path, "/what-if")
+		return s.jobWhatIf(resp, req, jobID)
 	case strings.HasSuffix(path, "/summary"):
 		jobID := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobID)
@@ -206,6 +216,41 @@ func (s *HTTPServer) jobPlan(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobWhatIf(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.JobWhatIfRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.Job == nil {
+		return nil, CodedError(400, "Job must be specified")
+	}
+	if args.Job.ID == nil {
+		return nil, CodedError(400, "Job must have a valid ID")
+	}
+	if jobName != "" && *args.Job.ID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	sJob, writeReq := s.apiJobAndRequestToStructs(args.Job, req, args.WriteRequest)
+	whatIfReq := structs.JobWhatIfRequest{
+		Job:            sJob,
+		PolicyOverride: args.PolicyOverride,
+		WriteRequest:   *writeReq,
+	}
+
+	var out structs.JobWhatIfResponse
+	if err := s.agent.RPC(structs.JobWhatIfRPCMethod, &whatIfReq, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) ValidateJobRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Ensure request method is POST or PUT
 	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
@@ -286,6 +331,64 @@ func (s *HTTPServer) jobAllocations(resp http.ResponseWriter, req *http.Request,
 	return out.Allocations, nil
 }
 
+func (s *HTTPServer) jobUtilization(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobUtilizationResponse
+	if err := s.agent.RPC(structs.JobUtilizationRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Utilization, nil
+}
+
+func (s *HTTPServer) jobUsageHistory(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobUsageHistoryRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	since, err := parseTime(req, "since")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if since != nil {
+		args.Since = since.UnixNano()
+	}
+	until, err := parseTime(req, "until")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if until != nil {
+		args.Until = until.UnixNano()
+	}
+
+	var out structs.JobUsageHistoryResponse
+	if err := s.agent.RPC(structs.JobUsageHistoryRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Samples == nil {
+		out.Samples = make([]*structs.UsageSample, 0)
+	}
+	return out.Samples, nil
+}
+
 func (s *HTTPServer) jobEvaluations(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -898,6 +1001,9 @@ func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Requ
 	if args.JobID == "" {
 		args.JobID = jobID
 	}
+	if args.TraceParent == "" {
+		args.TraceParent = req.Header.Get(tracecontext.HeaderName)
+	}
 
 	s.parseWriteRequest(req, &args.WriteRequest)
 
@@ -922,6 +1028,8 @@ func (s *HTTPServer) jobDispatchPayloadRequest(resp http.ResponseWriter, req *ht
 		return nil, CodedError(400, err.Error())
 	}
 
+	args.TraceParent = req.Header.Get(tracecontext.HeaderName)
+
 	// this only parses query args and headers (not request body)
 	s.parseWriteRequest(req, &args.WriteRequest)
 
@@ -1242,12 +1350,24 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
 
+	if taskGroup.SharedResources != nil {
+		tg.SharedResources = &structs.SharedResources{
+			MemoryOverheadMB: taskGroup.SharedResources.MemoryOverheadMB,
+			CPUOverhead:      taskGroup.SharedResources.CPUOverhead,
+			CPUCap:           taskGroup.SharedResources.CPUCap,
+		}
+	}
+
 	tg.RestartPolicy = &structs.RestartPolicy{
 		Attempts:        *taskGroup.RestartPolicy.Attempts,
 		Interval:        *taskGroup.RestartPolicy.Interval,
 		Delay:           *taskGroup.RestartPolicy.Delay,
 		Mode:            *taskGroup.RestartPolicy.Mode,
 		RenderTemplates: *taskGroup.RestartPolicy.RenderTemplates,
+		ResourceTrigger: apiRestartResourceTriggerToStructs(taskGroup.RestartPolicy.ResourceTrigger),
+	}
+	if taskGroup.RestartPolicy.OOMMemoryStepMB != nil {
+		tg.RestartPolicy.OOMMemoryStepMB = *taskGroup.RestartPolicy.OOMMemoryStepMB
 	}
 
 	if taskGroup.PreventRescheduleOnLost == nil {
@@ -1277,6 +1397,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			MaxDelay:      *taskGroup.ReschedulePolicy.MaxDelay,
 			Unlimited:     *taskGroup.ReschedulePolicy.Unlimited,
 		}
+
+		if taskGroup.ReschedulePolicy.MaxOOMKills != nil {
+			tg.ReschedulePolicy.MaxOOMKills = *taskGroup.ReschedulePolicy.MaxOOMKills
+		}
 	}
 
 	if taskGroup.Disconnect != nil {
@@ -1370,6 +1494,14 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.CanaryMaxCPURatio != nil {
+			tg.Update.CanaryMaxCPURatio = *taskGroup.Update.CanaryMaxCPURatio
+		}
+
+		if taskGroup.Update.CanaryMaxMemoryRatio != nil {
+			tg.Update.CanaryMaxMemoryRatio = *taskGroup.Update.CanaryMaxMemoryRatio
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1432,6 +1564,10 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			Delay:           *apiTask.RestartPolicy.Delay,
 			Mode:            *apiTask.RestartPolicy.Mode,
 			RenderTemplates: *apiTask.RestartPolicy.RenderTemplates,
+			ResourceTrigger: apiRestartResourceTriggerToStructs(apiTask.RestartPolicy.ResourceTrigger),
+		}
+		if apiTask.RestartPolicy.OOMMemoryStepMB != nil {
+			structsTask.RestartPolicy.OOMMemoryStepMB = *apiTask.RestartPolicy.OOMMemoryStepMB
 		}
 	}
 
@@ -1524,6 +1660,13 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 		}
 	}
 
+	if apiTask.Warmup != nil {
+		structsTask.Warmup = &structs.TaskWarmup{
+			CPUPercent: *apiTask.Warmup.CPUPercent,
+			Duration:   *apiTask.Warmup.Duration,
+		}
+	}
+
 	for _, action := range apiTask.Actions {
 		act := ApiActionToStructsAction(job, action)
 		structsTask.Actions = append(structsTask.Actions, act)
@@ -1639,7 +1782,8 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 
 	if in.NUMA != nil {
 		out.NUMA = &structs.NUMA{
-			Affinity: in.NUMA.Affinity,
+			Affinity:     in.NUMA.Affinity,
+			MemoryPolicy: in.NUMA.MemoryPolicy,
 		}
 	}
 
@@ -1647,6 +1791,10 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		out.SecretsMB = *in.SecretsMB
 	}
 
+	if in.ResizeInPlace != nil {
+		out.ResizeInPlace = *in.ResizeInPlace
+	}
+
 	return out
 }
 
@@ -1821,6 +1969,17 @@ func apiWorkloadWeightsToStructs(in *api.ServiceWeights) *structs.ServiceWeights
 	}
 }
 
+func apiRestartResourceTriggerToStructs(in *api.RestartResourceTrigger) *structs.RestartResourceTrigger {
+	if in == nil {
+		return nil
+	}
+	return &structs.RestartResourceTrigger{
+		MemoryRSSMB:       *in.MemoryRSSMB,
+		CPUThrottledRatio: *in.CPUThrottledRatio,
+		Duration:          *in.Duration,
+	}
+}
+
 func ApiConsulConnectToStructs(in *api.ConsulConnect) *structs.ConsulConnect {
 	if in == nil {
 		return nil
@@ -2190,9 +2349,13 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 	}
 
 	return &structs.LogConfig{
-		Disabled:      dereferenceBool(in.Disabled),
-		MaxFiles:      dereferenceInt(in.MaxFiles),
-		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+		Disabled:          dereferenceBool(in.Disabled),
+		MaxFiles:          dereferenceInt(in.MaxFiles),
+		MaxFileSizeMB:     dereferenceInt(in.MaxFileSizeMB),
+		MaxBytesPerSecond: dereferenceInt(in.MaxBytesPerSecond),
+		MaxLinesPerSecond: dereferenceInt(in.MaxLinesPerSecond),
+		RateLimitMode:     dereferenceString(in.RateLimitMode),
+		MultilinePattern:  dereferenceString(in.MultilinePattern),
 	}
 }
 
@@ -2210,6 +2373,13 @@ func dereferenceInt(in *int) int {
 	return *in
 }
 
+func dereferenceString(in *string) string {
+	if in == nil {
+		return ""
+	}
+	return *in
+}
+
 func ApiConstraintsToStructs(in []*api.Constraint) []*structs.Constraint {
 	if in == nil {
 		return nil
@@ -1874,6 +1874,35 @@ func TestHTTP_JobPlan(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobWhatIf(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := MockJob()
+		args := api.JobWhatIfRequest{
+			Job: job,
+			WriteRequest: api.WriteRequest{
+				Region:    "global",
+				Namespace: api.DefaultNamespace,
+			},
+		}
+
+		req, err := http.NewRequest(http.MethodPut, "/v1/job/"+*job.ID+"/what-if", encodeReq(args))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+		whatIf := obj.(structs.JobWhatIfResponse)
+		must.Eq(t, len(whatIf.FailedTGAllocs) == 0, whatIf.Feasible)
+
+		// The job ID must match the path
+		req, err = http.NewRequest(http.MethodPut, "/v1/job/other/what-if", encodeReq(args))
+		must.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(respW, req)
+		must.ErrorContains(t, err, "Job ID does not match")
+	})
+}
+
 func TestHTTP_JobPlanRegion(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2734,6 +2763,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				Consul: &api.Consul{
 					Namespace: "team-foo",
 				},
+				SharedResources: &api.SharedResources{
+					MemoryOverheadMB: 64,
+					CPUOverhead:      100,
+					CPUCap:           2000,
+				},
 				Services: []*api.Service{
 					{
 						Name:              "groupserviceA",
@@ -3179,6 +3213,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Namespace: "team-foo",
 					Cluster:   structs.ConsulDefaultCluster,
 				},
+				SharedResources: &structs.SharedResources{
+					MemoryOverheadMB: 64,
+					CPUOverhead:      100,
+					CPUCap:           2000,
+				},
 				Services: []*structs.Service{
 					{
 						Name:              "groupserviceA",
@@ -3971,6 +4010,26 @@ func TestConversion_apiResourcesToStructs(t *testing.T) {
 				},
 			},
 		},
+		{
+			"with numa memory policy",
+			&api.Resources{
+				CPU:      pointer.Of(0),
+				Cores:    pointer.Of(2),
+				MemoryMB: pointer.Of(200),
+				NUMA: &api.NUMAResource{
+					Affinity:     "require",
+					MemoryPolicy: "bind",
+				},
+			},
+			&structs.Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA: &structs.NUMA{
+					Affinity:     "require",
+					MemoryPolicy: "bind",
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// namespaceMetricsRate is the number of requests per second each
+	// namespace may make for its metrics
+	namespaceMetricsRate = 1
+
+	// namespaceMetricsBurst is the number of requests each namespace may make
+	// for its metrics in a burst
+	namespaceMetricsBurst = 5
+
+	// namespaceLimitersSize is the number of namespaces whose rate limiters
+	// are kept, so requests for arbitrary namespaces can't grow them without
+	// bound. The least recently requested namespaces are forgotten first.
+	namespaceLimitersSize = 1024
+)
+
+// namespaceMetricLabels are the labels of namespace metrics, in the order
+// they are set on the Prometheus gauges
+var namespaceMetricLabels = []string{"namespace", "job", "task_group", "alloc_id", "task"}
+
+// namespaceLimiter rate limits requests per namespace, so scrapes of one
+// namespace can't starve the others or load the client.
+type namespaceLimiter struct {
+	l        sync.Mutex
+	limiters *lru.Cache[string, *rate.Limiter]
+}
+
+func newNamespaceLimiter() *namespaceLimiter {
+	return newNamespaceLimiterSize(namespaceLimitersSize)
+}
+
+func newNamespaceLimiterSize(size int) *namespaceLimiter {
+	limiters, err := lru.New[string, *rate.Limiter](size)
+	if err != nil {
+		// only returned for sizes that aren't positive
+		panic(err)
+	}
+	return &namespaceLimiter{limiters: limiters}
+}
+
+// allow returns whether a request of the namespace may be served now.
+func (n *namespaceLimiter) allow(namespace string) bool {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	limiter, ok := n.limiters.Get(namespace)
+	if !ok {
+		limiter = rate.NewLimiter(namespaceMetricsRate, namespaceMetricsBurst)
+		n.limiters.Add(namespace, limiter)
+	}
+	return limiter.Allow()
+}
+
+// namespaceMetric is a task metric of a namespace
+type namespaceMetric struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// ClientNamespaceMetricsRequest returns the resource usage metrics of the
+// tasks of a namespace running on the client. Unlike the agent metrics, it
+// only requires read-job or read-stats on the namespace, so teams can scrape the usage of
+// their own jobs. Metrics are JSON by default but Prometheus is an optional
+// format.
+func (s *HTTPServer) ClientNamespaceMetricsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	c := s.agent.Client()
+	if c == nil {
+		return nil, clientNotRunning
+	}
+
+	var namespace string
+	parseNamespace(req, &namespace)
+
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+	hasReadJob := aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob)
+	hasReadStats := aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadStats)
+	if !(hasReadJob || hasReadStats) {
+		return nil, structs.ErrPermissionDenied
+	}
+
+	if s.nsMetricsLimiter != nil && !s.nsMetricsLimiter.allow(namespace) {
+		return nil, CodedError(http.StatusTooManyRequests,
+			fmt.Sprintf("too many metrics requests for namespace %q", namespace))
+	}
+
+	out := namespaceMetrics(c.NamespaceAllocUsage(namespace))
+
+	if format := req.URL.Query().Get("format"); format == "prometheus" {
+		namespacePrometheusHandler(out).ServeHTTP(resp, req)
+		return nil, nil
+	}
+	return out, nil
+}
+
+// namespaceMetrics returns the metrics of the tasks of the allocations, named
+// like the allocation gauges of the client telemetry.
+func namespaceMetrics(usages []*client.AllocUsage) []*namespaceMetric {
+	out := []*namespaceMetric{}
+	for _, u := range usages {
+		tasks := make([]string, 0, len(u.Usage.Tasks))
+		for task := range u.Usage.Tasks {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+
+		for _, task := range tasks {
+			ru := u.Usage.Tasks[task].ResourceUsage
+			if ru == nil {
+				continue
+			}
+			labels := map[string]string{
+				"namespace":  u.Alloc.Namespace,
+				"job":        u.Alloc.JobID,
+				"task_group": u.Alloc.TaskGroup,
+				"alloc_id":   u.Alloc.ID,
+				"task":       task,
+			}
+			add := func(name string, v float64) {
+				out = append(out, &namespaceMetric{
+					Name:   "nomad.client.allocs." + name,
+					Labels: labels,
+					Value:  v,
+				})
+			}
+
+			if ms := ru.MemoryStats; ms != nil {
+				add("memory.rss", float64(ms.RSS))
+				add("memory.cache", float64(ms.Cache))
+				add("memory.swap", float64(ms.Swap))
+				add("memory.usage", float64(ms.Usage))
+				add("memory.max_usage", float64(ms.MaxUsage))
+			}
+			if cs := ru.CpuStats; cs != nil {
+				add("cpu.total_percent", cs.Percent)
+				add("cpu.system", cs.SystemMode)
+				add("cpu.user", cs.UserMode)
+				add("cpu.total_ticks", cs.TotalTicks)
+				add("cpu.throttled_time", float64(cs.ThrottledTime))
+				add("cpu.throttled_periods", float64(cs.ThrottledPeriods))
+			}
+		}
+	}
+	return out
+}
+
+// namespacePrometheusHandler returns a handler serving the metrics in the
+// Prometheus format. The metrics are registered in their own registry so
+// none of the other metrics of the agent are exposed.
+func namespacePrometheusHandler(metrics []*namespaceMetric) http.Handler {
+	registry := prometheus.NewRegistry()
+	gauges := map[string]*prometheus.GaugeVec{}
+	for _, m := range metrics {
+		name := strings.ReplaceAll(m.Name, ".", "_")
+		gauge, ok := gauges[name]
+		if !ok {
+			gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name}, namespaceMetricLabels)
+			registry.MustRegister(gauge)
+			gauges[name] = gauge
+		}
+		gauge.With(prometheus.Labels(m.Labels)).Set(m.Value)
+	}
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorHandling:      promhttp.ContinueOnError,
+		DisableCompression: true,
+	})
+}
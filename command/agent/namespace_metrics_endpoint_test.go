@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_ClientNamespaceMetrics_ACL(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/client/metrics?namespace=default", nil)
+		must.NoError(t, err)
+
+		// Requests without a token are denied
+		_, err = s.Server.ClientNamespaceMetricsRequest(httptest.NewRecorder(), req)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+		// Tokens of other namespaces are denied
+		policy := mock.NamespacePolicy("other", "", []string{acl.NamespaceCapabilityReadJob})
+		token := mock.CreatePolicyAndToken(t, state, 1005, "other", policy)
+		setToken(req, token)
+		_, err = s.Server.ClientNamespaceMetricsRequest(httptest.NewRecorder(), req)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+		// Tokens that can read the jobs of the namespace are allowed
+		policy = mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+		token = mock.CreatePolicyAndToken(t, state, 1007, "valid", policy)
+		setToken(req, token)
+		obj, err := s.Server.ClientNamespaceMetricsRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.SliceEmpty(t, obj.([]*namespaceMetric))
+	})
+}
+
+func TestNamespaceLimiter(t *testing.T) {
+	ci.Parallel(t)
+
+	l := newNamespaceLimiter()
+	for i := 0; i < namespaceMetricsBurst; i++ {
+		must.True(t, l.allow("web"))
+	}
+	must.False(t, l.allow("web"))
+
+	// Namespaces are limited independently
+	must.True(t, l.allow("batch"))
+
+	// The limiters of the least recently requested namespaces are forgotten
+	l = newNamespaceLimiterSize(2)
+	for i := 0; i < namespaceMetricsBurst; i++ {
+		must.True(t, l.allow("web"))
+	}
+	must.True(t, l.allow("batch"))
+	must.True(t, l.allow("system"))
+	must.Eq(t, 2, l.limiters.Len())
+	must.True(t, l.allow("web"))
+}
+
+func TestNamespaceMetrics(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	usages := []*client.AllocUsage{{
+		Alloc: alloc,
+		Usage: &cstructs.AllocResourceUsage{
+			Tasks: map[string]*cstructs.TaskResourceUsage{
+				"web": {
+					ResourceUsage: &cstructs.ResourceUsage{
+						MemoryStats: &cstructs.MemoryStats{RSS: 1024},
+						CpuStats:    &cstructs.CpuStats{Percent: 12.5},
+					},
+				},
+				"idle": {},
+			},
+		},
+	}}
+
+	out := namespaceMetrics(usages)
+	must.Len(t, 11, out)
+	must.Eq(t, "nomad.client.allocs.memory.rss", out[0].Name)
+	must.Eq(t, 1024, out[0].Value)
+	must.Eq(t, map[string]string{
+		"namespace":  alloc.Namespace,
+		"job":        alloc.JobID,
+		"task_group": alloc.TaskGroup,
+		"alloc_id":   alloc.ID,
+		"task":       "web",
+	}, out[0].Labels)
+
+	// The Prometheus format only has the metrics of the namespace
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/client/metrics?format=prometheus", nil)
+	namespacePrometheusHandler(out).ServeHTTP(resp, req)
+	body := resp.Body.String()
+	must.StrContains(t, body, `nomad_client_allocs_cpu_total_percent{alloc_id="`+alloc.ID+`"`)
+	must.False(t, strings.Contains(body, "go_goroutines"))
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// scalingMetricAvgCPU is the average CPU utilization of a task group's
+	// allocations, in percent of their allocated CPU
+	scalingMetricAvgCPU = "taskgroup_avg_cpu"
+
+	// scalingMetricAvgMemory is the average memory utilization of a task
+	// group's allocations, in percent of their allocated memory
+	scalingMetricAvgMemory = "taskgroup_avg_memory"
+)
+
+// ClientScalingMetricRequest computes a scaling metric from the resource usage
+// of the running allocations of a task group, so horizontal scaling policies
+// can use Nomad as their metrics source. Queries have the format of the Nomad
+// APM plugin of the autoscaler: <metric>/<group>/<job>.
+func (s *HTTPServer) ClientScalingMetricRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	query := req.URL.Query().Get("query")
+	metric, group, job, err := parseScalingMetricQuery(query)
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	var q structs.QueryOptions
+	if s.parse(resp, req, &q.Region, &q) {
+		return nil, nil
+	}
+
+	listArgs := structs.JobSpecificRequest{JobID: job, QueryOptions: q}
+	var list structs.JobAllocationsResponse
+	if err := s.agent.RPC("Job.Allocations", &listArgs, &list); err != nil {
+		return nil, err
+	}
+
+	var sum float64
+	var n, running int
+	for _, stub := range list.Allocations {
+		if stub.TaskGroup != group || stub.ClientStatus != structs.AllocClientStatusRunning {
+			continue
+		}
+
+		// The allocated resources are only on the full allocation
+		allocArgs := structs.AllocSpecificRequest{AllocID: stub.ID, QueryOptions: q}
+		var alloc structs.SingleAllocResponse
+		if err := s.agent.RPC("Alloc.GetAlloc", &allocArgs, &alloc); err != nil {
+			return nil, err
+		}
+		if alloc.Alloc == nil {
+			continue
+		}
+		running++
+
+		statsArgs := cstructs.AllocStatsRequest{AllocID: stub.ID, QueryOptions: q}
+		stats, err := s.allocStatsRPC(&statsArgs)
+		if err != nil {
+			// Allocations may stop or their client may be unreachable, so
+			// the metric is computed from the allocations that report stats
+			s.logger.Debug("failed to get allocation stats for scaling metric", "alloc_id", stub.ID, "error", err)
+			continue
+		}
+		if v, ok := allocUtilization(metric, alloc.Alloc, stats); ok {
+			sum += v
+			n++
+		}
+	}
+	if running == 0 {
+		return nil, CodedError(404, fmt.Sprintf("no running allocations for group %q of job %q", group, job))
+	}
+	if n == 0 {
+		return nil, CodedError(404, fmt.Sprintf("no resource usage reported for group %q of job %q", group, job))
+	}
+
+	return &api.ScalingMetric{
+		Query:       query,
+		Value:       sum / float64(n),
+		Timestamp:   time.Now().UnixNano(),
+		Allocations: n,
+	}, nil
+}
+
+// parseScalingMetricQuery parses a scaling metric query. Job IDs may contain
+// slashes, so the job is everything after the group.
+func parseScalingMetricQuery(query string) (metric, group, job string, err error) {
+	parts := strings.SplitN(query, "/", 3)
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid query %q, expected <metric>/<group>/<job>", query)
+	}
+
+	switch parts[0] {
+	case scalingMetricAvgCPU, scalingMetricAvgMemory:
+	default:
+		return "", "", "", fmt.Errorf("unsupported metric %q, expected %s or %s",
+			parts[0], scalingMetricAvgCPU, scalingMetricAvgMemory)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// allocUtilization returns the utilization of an allocation's resources for
+// a metric, in percent. Tasks without stats are left out, and false is
+// returned if none of the allocation's tasks reported stats.
+func allocUtilization(metric string, alloc *structs.Allocation, stats *cstructs.AllocResourceUsage) (float64, bool) {
+	if alloc.AllocatedResources == nil || stats == nil {
+		return 0, false
+	}
+
+	var used, allocated float64
+	for name, res := range alloc.AllocatedResources.Tasks {
+		ts, ok := stats.Tasks[name]
+		if !ok || ts.ResourceUsage == nil {
+			continue
+		}
+
+		switch metric {
+		case scalingMetricAvgCPU:
+			if cs := ts.ResourceUsage.CpuStats; cs != nil {
+				used += cs.TotalTicks
+				allocated += float64(res.Cpu.CpuShares)
+			}
+		case scalingMetricAvgMemory:
+			if ms := ts.ResourceUsage.MemoryStats; ms != nil {
+				used += float64(ms.Used())
+				allocated += float64(res.Memory.MemoryMB * 1024 * 1024)
+			}
+		}
+	}
+
+	if allocated == 0 {
+		return 0, false
+	}
+	return used / allocated * 100, true
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_ClientScalingMetric(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		request := func(query string) (interface{}, error) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/client/scaling/metric?query="+url.QueryEscape(query), nil)
+			must.NoError(t, err)
+			return s.Server.ClientScalingMetricRequest(httptest.NewRecorder(), req)
+		}
+
+		_, err := request("taskgroup_avg_disk/web/" + alloc.JobID)
+		must.ErrorContains(t, err, "unsupported metric")
+
+		_, err = request("taskgroup_avg_cpu/other/" + alloc.JobID)
+		must.ErrorContains(t, err, "no running allocations")
+
+		// the node of the allocation is not connected, so it has no stats
+		_, err = request("taskgroup_avg_cpu/" + alloc.TaskGroup + "/" + alloc.JobID)
+		must.ErrorContains(t, err, "no resource usage reported")
+	})
+}
+
+func TestParseScalingMetricQuery(t *testing.T) {
+	ci.Parallel(t)
+
+	metric, group, job, err := parseScalingMetricQuery("taskgroup_avg_memory/cache/parent/dispatch-1")
+	must.NoError(t, err)
+	must.Eq(t, scalingMetricAvgMemory, metric)
+	must.Eq(t, "cache", group)
+	must.Eq(t, "parent/dispatch-1", job)
+
+	for _, query := range []string{"", "taskgroup_avg_cpu", "taskgroup_avg_cpu/cache", "taskgroup_avg_cpu//example"} {
+		_, _, _, err := parseScalingMetricQuery(query)
+		must.ErrorContains(t, err, "invalid query")
+	}
+}
+
+func TestAllocUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+		"web": {
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 500},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 256},
+		},
+		"sidecar": {
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 500},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 256},
+		},
+	}
+
+	stats := &cstructs.AllocResourceUsage{
+		Tasks: map[string]*cstructs.TaskResourceUsage{
+			"web": {
+				ResourceUsage: &cstructs.ResourceUsage{
+					CpuStats: &cstructs.CpuStats{TotalTicks: 250},
+					MemoryStats: &cstructs.MemoryStats{
+						RSS:      64 * 1024 * 1024,
+						Usage:    128 * 1024 * 1024,
+						Measured: []string{"RSS", "Usage"},
+					},
+				},
+			},
+		},
+	}
+
+	// tasks without stats are left out
+	cpu, ok := allocUtilization(scalingMetricAvgCPU, alloc, stats)
+	must.True(t, ok)
+	must.Eq(t, 50, cpu)
+
+	memory, ok := allocUtilization(scalingMetricAvgMemory, alloc, stats)
+	must.True(t, ok)
+	must.Eq(t, 25, memory)
+
+	_, ok = allocUtilization(scalingMetricAvgCPU, alloc, &cstructs.AllocResourceUsage{})
+	must.False(t, ok)
+}
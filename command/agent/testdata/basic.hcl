@@ -97,6 +97,8 @@ client {
   gc_max_allocs            = 50
   no_host_uuid             = false
   disable_remote_exec      = true
+  task_energy_stats        = true
+  lazy_task_stats          = true
 
   host_volume "tmp" {
     path = "/tmp"
@@ -210,6 +212,12 @@ telemetry {
   collection_interval           = "3s"
   publish_allocation_metrics    = true
   publish_node_metrics          = true
+  otlp_endpoint                 = "http://127.0.0.1:4318"
+  otlp_export_interval          = "15s"
+
+  otlp_headers {
+    Authorization = "Bearer token"
+  }
 }
 
 leave_on_interrupt = true
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -24,9 +25,11 @@ type AllocLogsCommand struct {
 
 	// The fields below represent the commands flags.
 	verbose, job, tail, stderr, stdout, follow bool
+	timestamps                                 bool
 	numLines                                   int64
 	numBytes                                   int64
 	task                                       string
+	since, until, filter                       string
 }
 
 func (l *AllocLogsCommand) Help() string {
@@ -80,6 +83,23 @@ Logs Specific Options:
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
 
+  -timestamps
+    Prefix each line with the time it was written at. Only the output of tasks
+    run by the exec, raw_exec, and java drivers is timestamped.
+
+  -since <time>
+    Only show the lines written at or after the given time, either as an RFC
+    3339 timestamp or as a duration before now such as "10m". Lines without a
+    recorded timestamp are not shown.
+
+  -until <time>
+    Only show the lines written at or before the given time, in the same
+    formats as -since.
+
+  -filter <regex>
+    Only show the lines matching the given regular expression. The lines are
+    selected by the client agent, so the rest of the logs are not downloaded.
+
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -95,15 +115,19 @@ func (l *AllocLogsCommand) Synopsis() string {
 func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-stderr":  complete.PredictNothing,
-			"-stdout":  complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
-			"-task":    complete.PredictAnything,
-			"-job":     complete.PredictAnything,
-			"-f":       complete.PredictNothing,
-			"-tail":    complete.PredictAnything,
-			"-n":       complete.PredictAnything,
-			"-c":       complete.PredictAnything,
+			"-stderr":     complete.PredictNothing,
+			"-stdout":     complete.PredictNothing,
+			"-verbose":    complete.PredictNothing,
+			"-task":       complete.PredictAnything,
+			"-job":        complete.PredictAnything,
+			"-f":          complete.PredictNothing,
+			"-tail":       complete.PredictAnything,
+			"-n":          complete.PredictAnything,
+			"-c":          complete.PredictAnything,
+			"-timestamps": complete.PredictNothing,
+			"-since":      complete.PredictAnything,
+			"-until":      complete.PredictAnything,
+			"-filter":     complete.PredictAnything,
 		})
 }
 
@@ -134,6 +158,10 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.BoolVar(&l.follow, "f", false, "")
 	flags.BoolVar(&l.stderr, "stderr", false, "")
 	flags.BoolVar(&l.stdout, "stdout", false, "")
+	flags.BoolVar(&l.timestamps, "timestamps", false, "")
+	flags.StringVar(&l.since, "since", "", "")
+	flags.StringVar(&l.until, "until", "", "")
+	flags.StringVar(&l.filter, "filter", "", "")
 	flags.Int64Var(&l.numLines, "n", -1, "")
 	flags.Int64Var(&l.numBytes, "c", -1, "")
 	flags.StringVar(&l.task, "task", "", "")
@@ -158,6 +186,23 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
+	now := time.Now()
+	var err error
+	if l.since, err = parseLogsTime(l.since, now); err != nil {
+		l.Ui.Error(fmt.Sprintf("Invalid -since: %v", err))
+		return 1
+	}
+	if l.until, err = parseLogsTime(l.until, now); err != nil {
+		l.Ui.Error(fmt.Sprintf("Invalid -until: %v", err))
+		return 1
+	}
+	if l.filter != "" {
+		if _, err := regexp.Compile(l.filter); err != nil {
+			l.Ui.Error(fmt.Sprintf("Invalid -filter: %v", err))
+			return 1
+		}
+	}
+
 	client, err := l.Meta.Client()
 	if err != nil {
 		l.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
@@ -314,7 +359,7 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 	logType, origin string, offset int64) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, l.follow, l.task, logType, origin, offset, cancel, nil)
+	frames, errCh := client.AllocFS().Logs(alloc, l.follow, l.task, logType, origin, offset, cancel, l.queryOptions())
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -344,6 +389,44 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 	return r, nil
 }
 
+// queryOptions returns the query options of the logs requests.
+func (l *AllocLogsCommand) queryOptions() *api.QueryOptions {
+	params := map[string]string{}
+	if l.timestamps {
+		params["timestamps"] = "true"
+	}
+	if l.since != "" {
+		params["since"] = l.since
+	}
+	if l.until != "" {
+		params["until"] = l.until
+	}
+	if l.filter != "" {
+		params["filter"] = l.filter
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return &api.QueryOptions{Params: params}
+}
+
+// parseLogsTime parses the time of the -since and -until flags, which is
+// either an RFC 3339 timestamp or a duration before now, into an RFC 3339
+// timestamp.
+func parseLogsTime(s string, now time.Time) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d).UTC().Format(time.RFC3339Nano), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return "", fmt.Errorf("%q is neither an RFC 3339 timestamp nor a duration", s)
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
 // tailMultipleFiles will follow both stdout and stderr log files of the passed
 // allocation. Each stream will be output to the users console via stout and
 // stderr until the user cancels it.
@@ -358,7 +441,7 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	defer close(cancel)
 
 	stdoutFrames, stdoutErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, cancel, nil)
+		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, cancel, l.queryOptions())
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -369,7 +452,7 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	}
 
 	stderrFrames, stderrErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, cancel, nil)
+		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, cancel, l.queryOptions())
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -5,6 +5,7 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -90,3 +91,24 @@ func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	must.Len(t, 1, res)
 	must.Eq(t, a.ID, res[0])
 }
+
+func TestLogsCommand_parseLogsTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ts, err := parseLogsTime("10m", now)
+	must.NoError(t, err)
+	must.Eq(t, "2024-01-02T02:54:05Z", ts)
+
+	ts, err = parseLogsTime("2024-01-02T01:00:00.5+01:00", now)
+	must.NoError(t, err)
+	must.Eq(t, "2024-01-02T01:00:00.5+01:00", ts)
+
+	ts, err = parseLogsTime("", now)
+	must.NoError(t, err)
+	must.Eq(t, "", ts)
+
+	_, err = parseLogsTime("yesterday", now)
+	must.ErrorContains(t, err, "neither an RFC 3339 timestamp nor a duration")
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocProfileCommand struct {
+	Meta
+}
+
+func (c *AllocProfileCommand) Help() string {
+	helpText := `
+Usage: nomad alloc profile [options] <allocation> <task>
+
+  Profile the processes of a running task. The client running the allocation
+  samples the call stacks of the task's processes with 'perf record' for the
+  duration of the profile, and writes them in the folded format read by
+  flamegraph tools to the allocation's 'alloc/captures' directory. The path of
+  the file is printed once the profile completes.
+
+  Profiling requires 'perf' to be installed on the client, and a task driver
+  that supports profiling, such as exec, raw_exec, or java.
+
+  When ACLs are enabled, this command requires a token with the
+  'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the
+  allocation's namespace. Downloading the profile with -o also requires the
+  'read-fs' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Profile Specific Options:
+
+  -duration <duration>
+    How long to profile the task for, at most one minute. Defaults to 10s.
+
+  -o <path>
+    Download the profile to the given path once it completes.
+
+  -verbose
+    Show full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocProfileCommand) Name() string { return "alloc profile" }
+
+func (c *AllocProfileCommand) Run(args []string) int {
+	var verbose bool
+	var duration time.Duration
+	var output string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.DurationVar(&duration, "duration", 10*time.Second, "")
+	flags.StringVar(&output, "o", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got an alloc and a task
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <alloc-id> <task>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if duration <= 0 || duration > time.Minute {
+		c.Ui.Error("Duration must be greater than zero and at most one minute")
+		return 1
+	}
+
+	allocID, task := args[0], args[1]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	// Prefix lookup matched a single allocation
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	if err := validateTaskExistsInAllocation(task, alloc); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Profiling task %q for %s...", task, duration))
+	path, err := client.Allocations().Capture(alloc, q, task, "profile", duration)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error profiling task: %s", err))
+		return 1
+	}
+
+	if output == "" {
+		c.Ui.Output(fmt.Sprintf("Profile written to %s", path))
+		return 0
+	}
+
+	r, err := client.AllocFS().Cat(alloc, path, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading profile %s: %s", path, err))
+		return 1
+	}
+	defer r.Close()
+
+	f, err := os.Create(output)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating %s: %s", output, err))
+		return 1
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error downloading profile %s: %s", path, err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Profile downloaded to %s", output))
+	return 0
+}
+
+func (c *AllocProfileCommand) Synopsis() string {
+	return "Profile the processes of a running task"
+}
+
+func (c *AllocProfileCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-duration": complete.PredictAnything,
+			"-o":        complete.PredictFiles("*"),
+			"-verbose":  complete.PredictNothing,
+		})
+}
+
+func (c *AllocProfileCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
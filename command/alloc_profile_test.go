@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocProfileCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &AllocProfileCommand{}
+}
+
+func TestAllocProfileCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocProfileCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on lack of task
+	code := cmd.Run([]string{"foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes two arguments")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on bad duration
+	code = cmd.Run([]string{"-duration=2m", "foobar", "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "at most one minute")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foobar", "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying allocation")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C", "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+}
@@ -228,6 +228,10 @@ func (c *AllocStatusCommand) Run(args []string) int {
 				c.Ui.Output("Omitting resource statistics since the node is down.")
 			}
 		}
+		if stats != nil && stats.Disk != nil {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Ephemeral Disk Usage[reset]"))
+			c.Ui.Output(formatAllocDiskUsage(alloc, stats.Disk))
+		}
 		c.outputTaskDetails(alloc, stats, displayStats, verbose)
 	}
 
@@ -277,6 +281,7 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 		fmt.Sprintf("Client Description|%s", alloc.ClientDescription),
 		fmt.Sprintf("Desired Status|%s", alloc.DesiredStatus),
 		fmt.Sprintf("Desired Description|%s", alloc.DesiredDescription),
+		fmt.Sprintf("QoS Class|%s", alloc.QoSClass()),
 		fmt.Sprintf("Created|%s", formattedCreateTime),
 		fmt.Sprintf("Modified|%s", formattedModifyTime),
 	}
@@ -312,6 +317,9 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 			basic = append(basic, reschedInfo)
 		}
 	}
+	if alloc.RescheduleTracker != nil && alloc.RescheduleTracker.LastReschedule == api.LastRescheduleOOMKillLimit {
+		basic = append(basic, "Reschedule Eligibility|not rescheduled, its job version was repeatedly OOM-killed")
+	}
 	if alloc.NextAllocation != "" {
 		basic = append(basic,
 			fmt.Sprintf("Replacement Alloc ID|%s", limit(alloc.NextAllocation, uuidLength)))
@@ -336,6 +344,27 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 	return formatKV(basic), nil
 }
 
+// formatAllocDiskUsage formats the usage of the alloc dir against the size of
+// the ephemeral disk of the allocation
+func formatAllocDiskUsage(alloc *api.Allocation, disk *api.AllocDiskStats) string {
+	used := humanize.IBytes(disk.UsedBytes)
+	if alloc.AllocatedResources != nil && alloc.AllocatedResources.Shared.DiskMB > 0 {
+		used = fmt.Sprintf("%s/%s", used, humanize.IBytes(uint64(alloc.AllocatedResources.Shared.DiskMB)*1024*1024))
+	}
+
+	filesystem := "-"
+	if disk.FilesystemInodesFree > 0 {
+		filesystem = fmt.Sprintf("%v%% (%s free)",
+			humanize.FormatFloat(floatFormat, disk.FilesystemInodesUsedPercent),
+			humanize.Comma(int64(disk.FilesystemInodesFree)))
+	}
+
+	return formatList([]string{
+		"Disk|Inodes|Filesystem Inodes Used",
+		fmt.Sprintf("%s|%s|%s", used, humanize.Comma(int64(disk.Inodes)), filesystem),
+	})
+}
+
 func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	nw := alloc.AllocatedResources.Shared.Networks[0]
 	addrs := []string{"Label|Dynamic|Address"}
@@ -722,6 +751,11 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 			case "System Mode":
 				percent := strconv.FormatFloat(cpuStats.SystemMode, 'f', 2, 64)
 				measuredStats = append(measuredStats, fmt.Sprintf("%v%%", percent))
+			case "Total CPU Seconds":
+				measuredStats = append(measuredStats,
+					time.Duration(cpuStats.TotalCpuSeconds*float64(time.Second)).Round(time.Millisecond).String())
+			case "Borrowed Ticks":
+				measuredStats = append(measuredStats, fmt.Sprintf("%.0f MHz", cpuStats.BorrowedTicks))
 			}
 		}
 
@@ -731,6 +765,18 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 		c.Ui.Output(formatList(out))
 	}
 
+	if cpuStats != nil && len(cpuStats.Cores) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output("Reserved Core Stats")
+
+		out := make([]string, 0, len(cpuStats.Cores)+1)
+		out = append(out, "Core|Percent")
+		for _, core := range cpuStats.Cores {
+			out = append(out, fmt.Sprintf("%d|%s%%", core.ID, strconv.FormatFloat(core.Percent, 'f', 2, 64)))
+		}
+		c.Ui.Output(formatList(out))
+	}
+
 	if len(deviceStats) > 0 {
 		c.Ui.Output("")
 		c.Ui.Output("Device Stats")
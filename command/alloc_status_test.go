@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -484,3 +485,23 @@ func TestAllocStatusCommand_NSD_Checks(t *testing.T) {
 	must.RegexMatch(t, regexp.MustCompile(`Service\s+Task\s+Name\s+Mode\s+Status`), out)
 	must.RegexMatch(t, regexp.MustCompile(`service1\s+\(group\)\s+check1\s+healthiness\s+(pending|failure)`), out)
 }
+
+func TestAllocStatusCommand_formatAllocDiskUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &api.Allocation{
+		AllocatedResources: &api.AllocatedResources{
+			Shared: api.AllocatedSharedResources{DiskMB: 300},
+		},
+	}
+	out := formatAllocDiskUsage(alloc, &api.AllocDiskStats{
+		UsedBytes:                   12 * 1024 * 1024,
+		Inodes:                      123456,
+		FilesystemInodesFree:        1000,
+		FilesystemInodesUsedPercent: 99.9,
+	})
+	must.RegexMatch(t, regexp.MustCompile(`12 MiB/300 MiB\s+123,456\s+99\.90% \(1,000 free\)`), out)
+
+	out = formatAllocDiskUsage(&api.Allocation{}, &api.AllocDiskStats{UsedBytes: 1024, Inodes: 3})
+	must.RegexMatch(t, regexp.MustCompile(`1\.0 KiB\s+3\s+-`), out)
+}
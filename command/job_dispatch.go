@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/nomad/api"
 	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/posener/complete"
 )
 
@@ -35,6 +36,11 @@ Usage: nomad job dispatch [options] <parameterized job> [input source]
   triggered evaluation will be monitored. This can be disabled by supplying the
   detach flag.
 
+  If the TRACEPARENT environment variable contains a W3C trace context, the
+  dispatch joins that trace. Otherwise a new trace is started. The trace
+  context is carried through the evaluation and allocations to the dispatched
+  tasks.
+
   When ACLs are enabled, this command requires a token with the 'dispatch-job'
   capability for the job's namespace. The 'list-jobs' capability is required to
   run the command with a job prefix instead of the exact job ID. The 'read-job'
@@ -195,6 +201,9 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		IdempotencyToken: idempotencyToken,
 		Namespace:        namespace,
 	}
+	if traceParent := os.Getenv(tracecontext.EnvName); traceParent != "" {
+		w.Headers = map[string]string{tracecontext.HeaderName: traceParent}
+	}
 	resp, _, err := client.Jobs().Dispatch(jobID, metaMap, payload, idPrefixTemplate, w)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to dispatch job: %s", err))
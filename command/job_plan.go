@@ -29,6 +29,10 @@ potentially invalid.`
 	// preemptionDisplayThreshold is an upper bound used to limit and summarize
 	// the details of preempted jobs in the output
 	preemptionDisplayThreshold = 10
+
+	// nodeImpactDisplayThreshold is the number of nodes with the highest
+	// projected pressure shown in the output, unless verbose
+	nodeImpactDisplayThreshold = 10
 )
 
 type JobPlanCommand struct {
@@ -57,7 +61,11 @@ Alias: nomad plan
   Multiregion jobs do not return a job modify index.
 
   A structured diff between the local and remote job is displayed to
-  give insight into what the scheduler will attempt to do and why.
+  give insight into what the scheduler will attempt to do and why. For each
+  node the plan places allocations on or stops allocations from, the usage
+  its allocations were observed to have and the projected usage once the plan
+  is applied are displayed. Allocations that haven't reported usage, including
+  new placements, are counted at their reservation.
 
   If the job has specified the region, the -region flag and NOMAD_REGION
   environment variable are overridden and the job's region is used.
@@ -120,7 +128,8 @@ Plan Options:
     Path to HCL2 file containing user variables.
 
   -verbose
-    Increase diff verbosity.
+    Increase diff verbosity, and show the full node IDs and every node in the
+    node pressure output.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -326,9 +335,45 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 		c.addPreemptions(resp)
 	}
 
+	// Print the projected pressure of the nodes the plan changes
+	if len(resp.NodeImpacts) > 0 {
+		c.Ui.Output(c.Colorize().Color("[bold]Node Pressure:[reset]"))
+		c.Ui.Output(formatNodeImpacts(resp.NodeImpacts, verbose))
+		c.Ui.Output("")
+	}
+
 	return getExitCode(resp)
 }
 
+// formatNodeImpacts formats the observed and projected usage of the nodes the
+// plan changes, highest projected pressure first. Only the nodes with the
+// highest pressure are shown unless verbose.
+func formatNodeImpacts(impacts []*api.NodeWhatIfImpact, verbose bool) string {
+	length := shortId
+	shown := impacts
+	if verbose {
+		length = fullId
+	} else if len(shown) > nodeImpactDisplayThreshold {
+		shown = shown[:nodeImpactDisplayThreshold]
+	}
+
+	out := make([]string, 0, len(shown)+1)
+	out = append(out, "Node ID|Node Name|Placed|Stopped|CPU (MHz)|Memory (MiB)|Pressure")
+	for _, n := range shown {
+		out = append(out, fmt.Sprintf("%s|%s|%d|%d|%d -> %d / %d|%d -> %d / %d|%.0f%% -> %.0f%%",
+			limit(n.NodeID, length), n.NodeName, n.Placed, n.Stopped,
+			n.CurrentCPU, n.ProjectedCPU, n.CPUCapacity,
+			n.CurrentMemoryMB, n.ProjectedMemoryMB, n.MemoryCapacityMB,
+			n.CurrentPressure, n.ProjectedPressure))
+	}
+
+	formatted := formatList(out)
+	if hidden := len(impacts) - len(shown); hidden > 0 {
+		formatted += fmt.Sprintf("\n(%d more nodes with lower pressure, use -verbose to show them)", hidden)
+	}
+	return formatted
+}
+
 // addPreemptions shows details about preempted allocations
 func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
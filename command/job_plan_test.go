@@ -4,6 +4,7 @@
 package command
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -326,3 +327,37 @@ func TestPlanCommand_JSON(t *testing.T) {
 	must.Eq(t, 255, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "Error during plan: Put")
 }
+
+func TestPlanCommand_formatNodeImpacts(t *testing.T) {
+	ci.Parallel(t)
+
+	var impacts []*api.NodeWhatIfImpact
+	for i := 0; i < nodeImpactDisplayThreshold+2; i++ {
+		impacts = append(impacts, &api.NodeWhatIfImpact{
+			NodeID:            fmt.Sprintf("%08d-6c45-4b6f-8d5c-2c1e6b0d8f4a", i),
+			NodeName:          fmt.Sprintf("node-%d", i),
+			Placed:            1,
+			CPUCapacity:       4000,
+			MemoryCapacityMB:  8192,
+			CurrentCPU:        1000,
+			CurrentMemoryMB:   2048,
+			ProjectedCPU:      1500,
+			ProjectedMemoryMB: 4096,
+			CurrentPressure:   25,
+			ProjectedPressure: 50,
+		})
+	}
+
+	out := formatNodeImpacts(impacts, false)
+	must.StrContains(t, out, "00000000  node-0")
+	must.StrContains(t, out, "1000 -> 1500 / 4000")
+	must.StrContains(t, out, "2048 -> 4096 / 8192")
+	must.StrContains(t, out, "25% -> 50%")
+	must.StrNotContains(t, out, "node-11")
+	must.StrContains(t, out, "(2 more nodes with lower pressure, use -verbose to show them)")
+
+	out = formatNodeImpacts(impacts, true)
+	must.StrContains(t, out, impacts[0].NodeID)
+	must.StrContains(t, out, "node-11")
+	must.StrNotContains(t, out, "more nodes")
+}
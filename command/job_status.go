@@ -5,10 +5,12 @@ package command
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
 	"github.com/posener/complete"
@@ -532,9 +534,26 @@ func formatAllocListStubs(stubs []*api.AllocationListStub, verbose bool, uuidLen
 		}
 	}
 
+	// Show the usage reported by clients if there is any
+	if slices.ContainsFunc(stubs, func(s *api.AllocationListStub) bool { return s.Usage != nil }) {
+		allocs[0] += "|CPU|Memory"
+		for i, alloc := range stubs {
+			allocs[i+1] += "|" + formatStubUsage(alloc.Usage)
+		}
+	}
+
 	return formatList(allocs)
 }
 
+// formatStubUsage formats the CPU and memory columns of the usage of an
+// allocation list stub.
+func formatStubUsage(usage *api.AllocStubUsage) string {
+	if usage == nil {
+		return "-|-"
+	}
+	return fmt.Sprintf("%.0f%%|%s", usage.CPUPercent, humanize.IBytes(uint64(usage.MemoryMB)*1024*1024))
+}
+
 func formatAllocList(allocations []*api.Allocation, verbose bool, uuidLength int) string {
 	if len(allocations) == 0 {
 		return "No allocations placed"
@@ -227,6 +227,23 @@ func TestJobStatusCommand_Run(t *testing.T) {
 	}
 }
 
+func TestFormatAllocListStubs_Usage(t *testing.T) {
+	ci.Parallel(t)
+
+	stubs := []*api.AllocationListStub{
+		{ID: "a", ClientStatus: "running", Usage: &api.AllocStubUsage{CPUPercent: 42.4, MemoryMB: 128}},
+		{ID: "b", ClientStatus: "pending"},
+	}
+	out := formatAllocListStubs(stubs, false, 8)
+	must.StrContains(t, out, "CPU")
+	must.RegexMatch(t, regexp.MustCompile(`a .*42% +128 MiB`), out)
+	must.RegexMatch(t, regexp.MustCompile(`b .*- +-`), out)
+
+	// the columns are only shown if clients reported usage
+	stubs[0].Usage = nil
+	must.StrNotContains(t, formatAllocListStubs(stubs, false, 8), "CPU")
+}
+
 func TestJobStatusCommand_Fails(t *testing.T) {
 	ci.Parallel(t)
 	ui := cli.NewMockUi()
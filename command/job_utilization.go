@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+// jobUtilizationExitThreshold is the exit code when the usage of a task
+// crosses one of the thresholds, so CI can tell it apart from errors
+const jobUtilizationExitThreshold = 2
+
+type JobUtilizationCommand struct {
+	Meta
+}
+
+func (c *JobUtilizationCommand) Help() string {
+	helpText := `
+Usage: nomad job utilization [options] <job>
+
+  Display the CPU and memory reserved by each task of a job next to the p50,
+  p95, and max usage observed over the samples the clients of its running
+  allocations retain, which span up to the last hour. When a task runs in
+  several allocations, the highest usage across them is displayed.
+
+  The -fail-above and -fail-below options make the command exit with code 2
+  when the p95 usage of a task crosses a share of its reservation, which can
+  be used in CI to catch resource requests that regressed.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Utilization Options:
+
+  -fail-above=<percent>
+    Exit with code 2 if the p95 CPU or memory usage of a task is above this
+    percentage of its reservation.
+
+  -fail-below=<percent>
+    Exit with code 2 if the p95 CPU or memory usage of a task is below this
+    percentage of its reservation.
+
+  -json
+    Output the utilization in a JSON format.
+
+  -t
+    Format and display the utilization using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobUtilizationCommand) Synopsis() string {
+	return "Display the resource usage of a job's tasks against their reservations"
+}
+
+func (c *JobUtilizationCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-fail-above": complete.PredictAnything,
+			"-fail-below": complete.PredictAnything,
+			"-json":       complete.PredictNothing,
+			"-t":          complete.PredictAnything,
+		})
+}
+
+func (c *JobUtilizationCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobUtilizationCommand) Name() string { return "job utilization" }
+
+// taskUtilization is the usage of a task across the running allocations of a
+// job, compared against its reserved resources.
+type taskUtilization struct {
+	TaskGroup        string
+	Task             string
+	Allocations      int
+	Samples          int
+	ReservedCPU      int
+	ReservedMemoryMB int
+	CPU              api.UsagePercentiles
+	MemoryMB         api.UsagePercentiles
+
+	createIndex uint64
+}
+
+func (c *JobUtilizationCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+	var failAbove, failBelow int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.IntVar(&failAbove, "fail-above", 0, "")
+	flags.IntVar(&failBelow, "fail-below", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if failAbove < 0 || failBelow < 0 {
+		c.Ui.Error("The -fail-above and -fail-below thresholds must be positive")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	q := &api.QueryOptions{Namespace: namespace}
+	stubs, _, err := client.Jobs().Allocations(jobID, false, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving allocations: %s", err))
+		return 1
+	}
+
+	tasks := make(map[string]*taskUtilization)
+	for _, stub := range stubs {
+		if stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+
+		alloc, _, err := client.Allocations().Info(stub.ID, q)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving allocation %s: %s", limit(stub.ID, shortId), err))
+			return 1
+		}
+
+		// The clients of some allocations may be unreachable, which should
+		// not prevent reporting the usage of the others
+		statsQuery := &api.QueryOptions{
+			Namespace: namespace,
+			Params:    map[string]string{"window": "true"},
+		}
+		stats, err := client.Allocations().Stats(alloc, statsQuery)
+		if err != nil {
+			c.Ui.Warn(fmt.Sprintf("Skipping allocation %s: %s", limit(stub.ID, shortId), err))
+			continue
+		}
+		addTaskUtilization(tasks, alloc, stats)
+	}
+
+	list := make([]*taskUtilization, 0, len(tasks))
+	for _, tu := range tasks {
+		list = append(list, tu)
+	}
+	slices.SortFunc(list, func(a, b *taskUtilization) int {
+		return cmp.Or(cmp.Compare(a.TaskGroup, b.TaskGroup), cmp.Compare(a.Task, b.Task))
+	})
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, list)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+	} else if len(list) > 0 {
+		c.Ui.Output(formatTaskUtilization(list))
+	} else {
+		c.Ui.Output("No usage reported by the running allocations of the job")
+	}
+
+	if failAbove == 0 && failBelow == 0 {
+		return 0
+	}
+	if len(list) == 0 {
+		c.Ui.Error("Cannot check the utilization thresholds without usage")
+		return 1
+	}
+
+	var violations []string
+	for _, tu := range list {
+		violations = append(violations, checkUtilization(tu, "CPU", tu.CPU.P95, tu.ReservedCPU, failAbove, failBelow)...)
+		violations = append(violations, checkUtilization(tu, "memory", tu.MemoryMB.P95, tu.ReservedMemoryMB, failAbove, failBelow)...)
+	}
+	if len(violations) > 0 {
+		c.Ui.Error(strings.Join(violations, "\n"))
+		return jobUtilizationExitThreshold
+	}
+	return 0
+}
+
+// addTaskUtilization merges the usage windows of an allocation's tasks into
+// the utilization of the job's tasks, keeping the highest usage.
+func addTaskUtilization(tasks map[string]*taskUtilization, alloc *api.Allocation, stats *api.AllocResourceUsage) {
+	for name, ts := range stats.Tasks {
+		if ts.Window == nil {
+			continue
+		}
+
+		key := alloc.TaskGroup + "/" + name
+		tu, ok := tasks[key]
+		if !ok {
+			tu = &taskUtilization{TaskGroup: alloc.TaskGroup, Task: name}
+			tasks[key] = tu
+		}
+		tu.Allocations++
+		tu.Samples += ts.Window.Samples
+		tu.CPU = maxPercentiles(tu.CPU, ts.Window.CPU)
+		tu.MemoryMB = maxPercentiles(tu.MemoryMB, ts.Window.MemoryMB)
+
+		// Take the reservation of the newest allocation, which runs the
+		// latest version of the job
+		if alloc.CreateIndex < tu.createIndex || alloc.AllocatedResources == nil {
+			continue
+		}
+		if res, ok := alloc.AllocatedResources.Tasks[name]; ok {
+			tu.createIndex = alloc.CreateIndex
+			tu.ReservedCPU = int(res.Cpu.CpuShares)
+			tu.ReservedMemoryMB = int(res.Memory.MemoryMB)
+		}
+	}
+}
+
+func maxPercentiles(a, b api.UsagePercentiles) api.UsagePercentiles {
+	return api.UsagePercentiles{
+		P50: max(a.P50, b.P50),
+		P95: max(a.P95, b.P95),
+		Max: max(a.Max, b.Max),
+	}
+}
+
+// checkUtilization returns the thresholds the p95 usage of a task crosses.
+func checkUtilization(tu *taskUtilization, resource string, p95, reserved, failAbove, failBelow int) []string {
+	if reserved == 0 {
+		return nil
+	}
+
+	percent := p95 * 100 / reserved
+	var violations []string
+	if failAbove > 0 && percent > failAbove {
+		violations = append(violations, fmt.Sprintf("Task %q in group %q: p95 %s usage is %d%% of its reservation, above %d%%",
+			tu.Task, tu.TaskGroup, resource, percent, failAbove))
+	}
+	if failBelow > 0 && percent < failBelow {
+		violations = append(violations, fmt.Sprintf("Task %q in group %q: p95 %s usage is %d%% of its reservation, below %d%%",
+			tu.Task, tu.TaskGroup, resource, percent, failBelow))
+	}
+	return violations
+}
+
+func formatTaskUtilization(list []*taskUtilization) string {
+	rows := make([]string, 0, len(list)+1)
+	rows = append(rows, "Task Group|Task|Allocs|CPU Reserved|CPU p50|CPU p95|CPU Max|Memory Reserved|Memory p50|Memory p95|Memory Max")
+	for _, tu := range list {
+		rows = append(rows, fmt.Sprintf("%s|%s|%d|%d MHz|%d MHz|%d MHz|%d MHz|%d MiB|%d MiB|%d MiB|%d MiB",
+			tu.TaskGroup, tu.Task, tu.Allocations,
+			tu.ReservedCPU, tu.CPU.P50, tu.CPU.P95, tu.CPU.Max,
+			tu.ReservedMemoryMB, tu.MemoryMB.P50, tu.MemoryMB.P95, tu.MemoryMB.Max))
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobUtilizationCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobUtilizationCommand{}
+}
+
+func TestJobUtilizationCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobUtilizationCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"some", "bad", "args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	job := mock.Job()
+	state := srv.Agent.Server().State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	must.Zero(t, cmd.Run([]string{"-address=" + url, job.ID}))
+	must.StrContains(t, ui.OutputWriter.String(), "No usage reported")
+
+	// Thresholds can't be checked without usage
+	must.One(t, cmd.Run([]string{"-address=" + url, "-fail-above=90", job.ID}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Cannot check the utilization thresholds")
+}
+
+func TestJobUtilizationCommand_Thresholds(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := func(index uint64, cpu, memoryMB int) (*api.Allocation, *api.AllocResourceUsage) {
+		a := &api.Allocation{
+			TaskGroup:   "web",
+			CreateIndex: index,
+			AllocatedResources: &api.AllocatedResources{
+				Tasks: map[string]*api.AllocatedTaskResources{
+					"server": {
+						Cpu:    api.AllocatedCpuResources{CpuShares: 500},
+						Memory: api.AllocatedMemoryResources{MemoryMB: 256},
+					},
+				},
+			},
+		}
+		stats := &api.AllocResourceUsage{
+			Tasks: map[string]*api.TaskResourceUsage{
+				"server": {
+					Window: &api.TaskUsageWindow{
+						Samples:  10,
+						CPU:      api.UsagePercentiles{P50: cpu / 2, P95: cpu, Max: cpu},
+						MemoryMB: api.UsagePercentiles{P50: memoryMB, P95: memoryMB, Max: memoryMB},
+					},
+				},
+				// older clients don't report windows
+				"sidecar": {},
+			},
+		}
+		return a, stats
+	}
+
+	tasks := make(map[string]*taskUtilization)
+	for _, usage := range [][3]int{{10, 100, 200}, {20, 480, 64}} {
+		a, stats := alloc(uint64(usage[0]), usage[1], usage[2])
+		addTaskUtilization(tasks, a, stats)
+	}
+	must.MapLen(t, 1, tasks)
+
+	// the highest usage across allocations is kept
+	tu := tasks["web/server"]
+	must.Eq(t, 2, tu.Allocations)
+	must.Eq(t, 20, tu.Samples)
+	must.Eq(t, api.UsagePercentiles{P50: 240, P95: 480, Max: 480}, tu.CPU)
+	must.Eq(t, 200, tu.MemoryMB.P95)
+	must.Eq(t, 500, tu.ReservedCPU)
+
+	// CPU is at 96% and memory at 78% of their reservation
+	must.Len(t, 1, checkUtilization(tu, "CPU", tu.CPU.P95, tu.ReservedCPU, 90, 0))
+	must.Len(t, 0, checkUtilization(tu, "memory", tu.MemoryMB.P95, tu.ReservedMemoryMB, 90, 0))
+	must.Eq(t, []string{`Task "server" in group "web": p95 memory usage is 78% of its reservation, below 80%`},
+		checkUtilization(tu, "memory", tu.MemoryMB.P95, tu.ReservedMemoryMB, 0, 80))
+}
@@ -571,10 +571,18 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 	if hostStats != nil && c.stats {
 		c.Ui.Output(c.Colorize().Color("\n[bold]CPU Stats[reset]"))
 		c.printCpuStats(hostStats)
+		if len(hostStats.SoftIRQs) > 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]SoftIRQ Stats[reset]"))
+			c.printSoftIRQStats(hostStats)
+		}
 		c.Ui.Output(c.Colorize().Color("\n[bold]Memory Stats[reset]"))
 		c.printMemoryStats(hostStats)
 		c.Ui.Output(c.Colorize().Color("\n[bold]Disk Stats[reset]"))
 		c.printDiskStats(hostStats)
+		if len(hostStats.DiskIOStats) > 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Disk I/O Stats[reset]"))
+			c.printDiskIOStats(hostStats)
+		}
 		if len(hostStats.DeviceStats) > 0 {
 			c.Ui.Output(c.Colorize().Color("\n[bold]Device Stats[reset]"))
 			printDeviceStats(c.Ui, hostStats.DeviceStats)
@@ -845,11 +853,13 @@ func (c *NodeStatusCommand) formatMeta(node *api.Node) {
 func (c *NodeStatusCommand) printCpuStats(hostStats *api.HostStats) {
 	l := len(hostStats.CPU)
 	for i, cpuStat := range hostStats.CPU {
-		cpuStatsAttr := make([]string, 4)
+		cpuStatsAttr := make([]string, 6)
 		cpuStatsAttr[0] = fmt.Sprintf("CPU|%v", cpuStat.CPU)
 		cpuStatsAttr[1] = fmt.Sprintf("User|%v%%", humanize.FormatFloat(floatFormat, cpuStat.User))
 		cpuStatsAttr[2] = fmt.Sprintf("System|%v%%", humanize.FormatFloat(floatFormat, cpuStat.System))
-		cpuStatsAttr[3] = fmt.Sprintf("Idle|%v%%", humanize.FormatFloat(floatFormat, cpuStat.Idle))
+		cpuStatsAttr[3] = fmt.Sprintf("Irq|%v%%", humanize.FormatFloat(floatFormat, cpuStat.Irq))
+		cpuStatsAttr[4] = fmt.Sprintf("SoftIrq|%v%%", humanize.FormatFloat(floatFormat, cpuStat.SoftIrq))
+		cpuStatsAttr[5] = fmt.Sprintf("Idle|%v%%", humanize.FormatFloat(floatFormat, cpuStat.Idle))
 		c.Ui.Output(formatKV(cpuStatsAttr))
 		if i+1 < l {
 			c.Ui.Output("")
@@ -857,6 +867,16 @@ func (c *NodeStatusCommand) printCpuStats(hostStats *api.HostStats) {
 	}
 }
 
+func (c *NodeStatusCommand) printSoftIRQStats(hostStats *api.HostStats) {
+	softIRQStats := make([]string, 0, len(hostStats.SoftIRQs)+1)
+	softIRQStats = append(softIRQStats, "Type|Rate")
+	for _, softirq := range hostStats.SoftIRQs {
+		softIRQStats = append(softIRQStats, fmt.Sprintf("%s|%v/s",
+			softirq.Type, humanize.FormatFloat(floatFormat, softirq.Rate)))
+	}
+	c.Ui.Output(formatList(softIRQStats))
+}
+
 func (c *NodeStatusCommand) printMemoryStats(hostStats *api.HostStats) {
 	memoryStat := hostStats.Memory
 	memStatsAttr := make([]string, 4)
@@ -885,6 +905,21 @@ func (c *NodeStatusCommand) printDiskStats(hostStats *api.HostStats) {
 	}
 }
 
+func (c *NodeStatusCommand) printDiskIOStats(hostStats *api.HostStats) {
+	diskIOStats := make([]string, 0, len(hostStats.DiskIOStats)+1)
+	diskIOStats = append(diskIOStats, "Device|Reads|Writes|Await|Utilization|Queue Depth")
+	for _, disk := range hostStats.DiskIOStats {
+		diskIOStats = append(diskIOStats, fmt.Sprintf("%s|%v/s|%v/s|%vms|%v%%|%v",
+			disk.Device,
+			humanize.FormatFloat(floatFormat, disk.ReadsPerSecond),
+			humanize.FormatFloat(floatFormat, disk.WritesPerSecond),
+			humanize.FormatFloat(floatFormat, disk.AwaitMillis),
+			humanize.FormatFloat(floatFormat, disk.UtilizationPercent),
+			humanize.FormatFloat(floatFormat, disk.QueueDepth)))
+	}
+	c.Ui.Output(formatList(diskIOStats))
+}
+
 // getRunningAllocs returns a slice of allocation id's running on the node
 func getRunningAllocs(client *api.Client, nodeID string) ([]*api.Allocation, error) {
 	var allocs []*api.Allocation
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/posener/complete"
+)
+
+type OperatorReplayStatsCommand struct {
+	Meta
+}
+
+func (c *OperatorReplayStatsCommand) Help() string {
+	helpText := `
+Usage: nomad operator replay-stats [options] <path>
+
+  Replays the stats samples an executor recorded to the given file, in the
+  order the executor sent them to the client. Executors record their samples
+  to the executor.stats file in the task directory when the client is
+  configured with record_executor_stats. Samples where the CPU time or the
+  timestamp went backwards are flagged, which helps report wrong usage numbers.
+
+  This command doesn't contact the Nomad agent and can be run on a copy of the
+  file.
+
+Replay Options:
+
+  -json
+    Output the samples in a JSON format.
+
+  -t
+    Format and display the samples using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorReplayStatsCommand) Synopsis() string {
+	return "Replay the stats samples recorded by an executor"
+}
+
+func (c *OperatorReplayStatsCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-json": complete.PredictNothing,
+		"-t":    complete.PredictAnything,
+	}
+}
+
+func (c *OperatorReplayStatsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.stats")
+}
+
+func (c *OperatorReplayStatsCommand) Name() string { return "operator replay-stats" }
+
+func (c *OperatorReplayStatsCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening stats record file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	samples, err := readStatsRecord(f)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		c.Ui.Error(fmt.Sprintf("Error reading stats record file: %s", err))
+		return 1
+	}
+	if err != nil {
+		c.Ui.Warn("The last sample of the file is incomplete and was skipped")
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, samples)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(samples) == 0 {
+		c.Ui.Output("No samples recorded")
+		return 0
+	}
+	c.Ui.Output(formatStatsRecord(samples))
+	return 0
+}
+
+// readStatsRecord returns the samples of a stats record file. If the last
+// sample is incomplete, the samples before it are returned along with
+// io.ErrUnexpectedEOF.
+func readStatsRecord(r io.Reader) ([]*drivers.TaskResourceUsage, error) {
+	reader, err := executor.NewStatsRecordReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []*drivers.TaskResourceUsage
+	for {
+		sample, err := reader.Next()
+		if err == io.EOF {
+			return samples, nil
+		}
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, sample)
+	}
+}
+
+func formatStatsRecord(samples []*drivers.TaskResourceUsage) string {
+	rows := make([]string, 0, len(samples)+1)
+	rows = append(rows, "Time|Interval|CPU Percent|CPU Ticks|CPU Seconds|Memory Usage|RSS|Processes|Notes")
+
+	var prev *drivers.TaskResourceUsage
+	for _, s := range samples {
+		var interval string
+		var notes []string
+		if prev != nil {
+			interval = time.Duration(s.Timestamp - prev.Timestamp).String()
+			if s.Timestamp < prev.Timestamp {
+				notes = append(notes, "timestamp went backwards")
+			}
+		}
+
+		var percent, ticks, seconds float64
+		if cs := s.ResourceUsage.CpuStats; cs != nil {
+			percent, ticks, seconds = cs.Percent, cs.TotalTicks, cs.TotalCpuSeconds
+			if prev != nil && prev.ResourceUsage.CpuStats != nil && seconds < prev.ResourceUsage.CpuStats.TotalCpuSeconds {
+				notes = append(notes, "CPU seconds went backwards")
+			}
+		}
+		var usage, rss uint64
+		if ms := s.ResourceUsage.MemoryStats; ms != nil {
+			usage, rss = ms.Usage, ms.RSS
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%s|%.2f%%|%.2f MHz|%.3f|%s|%s|%d|%s",
+			formatUnixNanoTime(s.Timestamp), interval, percent, ticks, seconds,
+			humanize.IBytes(usage), humanize.IBytes(rss), len(s.Pids), strings.Join(notes, ", ")))
+		prev = s
+	}
+	return formatList(rows)
+}
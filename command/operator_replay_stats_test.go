@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorReplayStatsCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorReplayStatsCommand{}
+}
+
+func TestOperatorReplayStatsCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorReplayStatsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"some", "bad", "args"}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on files that aren't stats records
+	path := filepath.Join(t.TempDir(), "executor.stats")
+	must.NoError(t, os.WriteFile(path, []byte("not stats"), 0644))
+	must.One(t, cmd.Run([]string{path}))
+	must.StrContains(t, ui.ErrorWriter.String(), "not an executor stats record file")
+}
+
+func TestOperatorReplayStatsCommand_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	sample := func(offset time.Duration, cpuSeconds float64) *drivers.TaskResourceUsage {
+		return &drivers.TaskResourceUsage{
+			ResourceUsage: &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{},
+				CpuStats:    &drivers.CpuStats{TotalCpuSeconds: cpuSeconds},
+			},
+			Timestamp: now.Add(offset).UnixNano(),
+		}
+	}
+
+	out := formatStatsRecord([]*drivers.TaskResourceUsage{
+		sample(0, 2),
+		sample(time.Second, 3),
+		sample(2*time.Second, 1),
+	})
+	lines := strings.Split(out, "\n")
+	must.Len(t, 4, lines)
+	must.StrNotContains(t, lines[2], "went backwards")
+	must.StrContains(t, lines[3], "CPU seconds went backwards")
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorUsageCommand struct {
+	Meta
+}
+
+func (c *OperatorUsageCommand) Help() string {
+	helpText := `
+Usage: nomad operator usage <subcommand> [options]
+
+  This command groups subcommands for working with the resource usage the
+  Nomad servers keep for allocations, such as the usage samples clients report
+  and the billing records of terminated allocations.
+
+  Export the usage samples of all jobs to a CSV file:
+
+      $ nomad operator usage export -namespace=* usage.csv
+
+  Export the billing records of the last day to a Parquet file:
+
+      $ nomad operator usage export -type=billing -format=parquet -since=24h billing.parquet
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorUsageCommand) Synopsis() string {
+	return "Exports the resource usage kept by the Nomad servers"
+}
+
+func (c *OperatorUsageCommand) Name() string { return "operator usage" }
+
+func (c *OperatorUsageCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
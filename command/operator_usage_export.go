@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/parquet-go/parquet-go"
+	"github.com/posener/complete"
+)
+
+const (
+	usageExportTypeSamples = "samples"
+	usageExportTypeBilling = "billing"
+
+	usageExportFormatCSV     = "csv"
+	usageExportFormatParquet = "parquet"
+)
+
+type OperatorUsageExportCommand struct {
+	Meta
+}
+
+func (c *OperatorUsageExportCommand) Help() string {
+	helpText := `
+Usage: nomad operator usage export [options] [<filename>]
+
+  Exports the resource usage the Nomad servers keep for allocations to a file,
+  for analysis with other tools. By default the usage samples clients reported
+  for the allocations of jobs are exported, one row per allocation and sample.
+  With -type=billing the billing records of terminated allocations are
+  exported instead, one row per allocation.
+
+  The servers keep usage samples and billing records for the retention periods
+  of their configuration. Usage samples are exported for the jobs known to the
+  servers, so samples of jobs that were garbage collected are left out, while
+  billing records outlive their jobs.
+
+  If no filename is given, the export is written to a file named after the
+  type, the current date, and the format. The file must not exist.
+
+  If ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the exported namespaces. The -namespace=* option exports the
+  usage of all the namespaces the token can read.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Export Options:
+
+  -type=<samples|billing>
+    The kind of usage to export. Defaults to samples.
+
+  -format=<csv|parquet>
+    The format of the file. CSV files have a header row, and Parquet files
+    store timestamps as nanoseconds since the Unix epoch. Defaults to csv.
+
+  -job=<id>
+    Only export the usage of the allocations of the given job.
+
+  -since=<time>
+    Only export samples taken, or records of allocations that ended, at or
+    after the given time. The time is either in RFC 3339 format or a duration
+    before now, such as 24h.
+
+  -until=<time>
+    Only export samples taken, or records of allocations that ended, at or
+    before the given time, in the same format as -since.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorUsageExportCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-type":   complete.PredictSet(usageExportTypeSamples, usageExportTypeBilling),
+			"-format": complete.PredictSet(usageExportFormatCSV, usageExportFormatParquet),
+			"-job":    complete.PredictAnything,
+			"-since":  complete.PredictAnything,
+			"-until":  complete.PredictAnything,
+		})
+}
+
+func (c *OperatorUsageExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorUsageExportCommand) Synopsis() string {
+	return "Exports usage samples or billing records to CSV or Parquet"
+}
+
+func (c *OperatorUsageExportCommand) Name() string { return "operator usage export" }
+
+func (c *OperatorUsageExportCommand) Run(args []string) int {
+	var exportType, format, jobID, sinceStr, untilStr string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&exportType, "type", usageExportTypeSamples, "")
+	flags.StringVar(&format, "format", usageExportFormatCSV, "")
+	flags.StringVar(&jobID, "job", "", "")
+	flags.StringVar(&sinceStr, "since", "", "")
+	flags.StringVar(&untilStr, "until", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes either no arguments or one: <filename>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if exportType != usageExportTypeSamples && exportType != usageExportTypeBilling {
+		c.Ui.Error(fmt.Sprintf("Invalid -type %q: must be %q or %q",
+			exportType, usageExportTypeSamples, usageExportTypeBilling))
+		return 1
+	}
+	if format != usageExportFormatCSV && format != usageExportFormatParquet {
+		c.Ui.Error(fmt.Sprintf("Invalid -format %q: must be %q or %q",
+			format, usageExportFormatCSV, usageExportFormatParquet))
+		return 1
+	}
+
+	now := time.Now()
+	since, err := parseUsageExportTime(sinceStr, now)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid -since: %v", err))
+		return 1
+	}
+	until, err := parseUsageExportTime(untilStr, now)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid -until: %v", err))
+		return 1
+	}
+
+	filename := fmt.Sprintf("nomad-usage-%s-%04d%02d%02d-%d.%s",
+		exportType, now.Year(), now.Month(), now.Day(), now.Unix(), format)
+	if len(args) == 1 {
+		filename = args[0]
+	}
+	if _, err := os.Lstat(filename); err == nil {
+		c.Ui.Error(fmt.Sprintf("Destination file already exists: %q", filename))
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	} else if !os.IsNotExist(err) {
+		c.Ui.Error(fmt.Sprintf("Unexpected failure checking %q: %v", filename, err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch everything before creating the file, so a failed export doesn't
+	// leave a partial file behind
+	var write func(io.Writer) error
+	var count int
+	switch exportType {
+	case usageExportTypeSamples:
+		rows, err := c.usageSampleRows(client, jobID, since, until)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading usage samples: %s", err))
+			return 1
+		}
+		count = len(rows)
+		write = func(w io.Writer) error { return writeUsageExport(w, format, rows) }
+	case usageExportTypeBilling:
+		records, _, err := client.Allocations().BillingRecords(jobID, since, until, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading billing records: %s", err))
+			return 1
+		}
+		rows := make([]billingRecordRow, 0, len(records))
+		for _, r := range records {
+			rows = append(rows, newBillingRecordRow(r))
+		}
+		count = len(rows)
+		write = func(w io.Writer) error { return writeUsageExport(w, format, rows) }
+	}
+
+	tmpFile, err := os.Create(filename + ".tmp")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to create file: %v", err))
+		return 1
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if err := write(tmpFile); err != nil {
+		tmpFile.Close()
+		c.Ui.Error(fmt.Sprintf("Failed to write file: %v", err))
+		return 1
+	}
+	if err := tmpFile.Close(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write file: %v", err))
+		return 1
+	}
+	if err := os.Rename(tmpFile.Name(), filename); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to finalize file: %v", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Exported %d rows to %s", count, filename))
+	return 0
+}
+
+// usageSampleRows reads the usage samples of a job, or of every job of the
+// namespace of the client when jobID is empty.
+func (c *OperatorUsageExportCommand) usageSampleRows(client *api.Client, jobID string, since, until time.Time) ([]usageSampleRow, error) {
+	var jobs []*api.JobListStub
+	if jobID != "" {
+		jobs = []*api.JobListStub{{ID: jobID}}
+	} else {
+		var err error
+		jobs, _, err = client.Jobs().List(nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []usageSampleRow
+	for _, job := range jobs {
+		// The stubs of a listing across namespaces carry their namespace
+		samples, _, err := client.Jobs().UsageHistory(job.ID, since, until,
+			&api.QueryOptions{Namespace: job.Namespace})
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", job.ID, err)
+		}
+		for _, s := range samples {
+			rows = append(rows, newUsageSampleRow(s))
+		}
+	}
+	return rows, nil
+}
+
+// parseUsageExportTime parses a time in RFC 3339 format or as a duration
+// before now. An empty value is the zero time, which leaves that end of the
+// range open.
+func parseUsageExportTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 time nor a duration", value)
+	}
+	return t, nil
+}
+
+// usageSampleRow is the exported form of a usage sample. The parquet tags
+// name the columns of both formats.
+type usageSampleRow struct {
+	Namespace  string `parquet:"namespace,dict"`
+	JobID      string `parquet:"job_id,dict"`
+	TaskGroup  string `parquet:"task_group,dict"`
+	AllocID    string `parquet:"alloc_id"`
+	NodeID     string `parquet:"node_id,dict"`
+	Timestamp  int64  `parquet:"timestamp,timestamp(nanosecond)"`
+	CPU        int64  `parquet:"cpu_mhz"`
+	MemoryMB   int64  `parquet:"memory_mb"`
+	Backfilled bool   `parquet:"backfilled"`
+}
+
+func newUsageSampleRow(s *api.UsageSample) usageSampleRow {
+	return usageSampleRow{
+		Namespace:  s.Namespace,
+		JobID:      s.JobID,
+		TaskGroup:  s.TaskGroup,
+		AllocID:    s.AllocID,
+		NodeID:     s.NodeID,
+		Timestamp:  s.Timestamp,
+		CPU:        int64(s.CPU),
+		MemoryMB:   int64(s.MemoryMB),
+		Backfilled: s.Backfilled,
+	}
+}
+
+// billingRecordRow is the exported form of a billing record.
+type billingRecordRow struct {
+	Namespace     string  `parquet:"namespace,dict"`
+	JobID         string  `parquet:"job_id,dict"`
+	TaskGroup     string  `parquet:"task_group,dict"`
+	AllocID       string  `parquet:"alloc_id"`
+	NodeID        string  `parquet:"node_id,dict"`
+	ClientStatus  string  `parquet:"client_status,dict"`
+	StartTime     int64   `parquet:"start_time,timestamp(nanosecond)"`
+	EndTime       int64   `parquet:"end_time,timestamp(nanosecond)"`
+	CPUSeconds    float64 `parquet:"cpu_seconds"`
+	MemoryGBHours float64 `parquet:"memory_gb_hours"`
+	EgressGB      float64 `parquet:"egress_gb"`
+	PeakMemoryMB  int64   `parquet:"peak_memory_mb"`
+}
+
+func newBillingRecordRow(r *api.AllocBillingRecord) billingRecordRow {
+	return billingRecordRow{
+		Namespace:     r.Namespace,
+		JobID:         r.JobID,
+		TaskGroup:     r.TaskGroup,
+		AllocID:       r.AllocID,
+		NodeID:        r.NodeID,
+		ClientStatus:  r.ClientStatus,
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+		CPUSeconds:    r.CPUSeconds,
+		MemoryGBHours: r.MemoryGBHours,
+		EgressGB:      r.EgressGB,
+		PeakMemoryMB:  int64(r.PeakMemoryMB),
+	}
+}
+
+// writeUsageExport writes rows in the given format.
+func writeUsageExport[T any](w io.Writer, format string, rows []T) error {
+	if format == usageExportFormatParquet {
+		pw := parquet.NewGenericWriter[T](w, parquet.Compression(&parquet.Zstd))
+		if _, err := pw.Write(rows); err != nil {
+			return err
+		}
+		return pw.Close()
+	}
+	return writeUsageCSV(w, rows)
+}
+
+// writeUsageCSV writes rows as CSV, with a header of the column names of
+// their parquet tags. Timestamps are written in RFC 3339 format.
+func writeUsageCSV[T any](w io.Writer, rows []T) error {
+	typ := reflect.TypeFor[T]()
+	header := make([]string, typ.NumField())
+	timestamps := make([]bool, typ.NumField())
+	for i := range typ.NumField() {
+		name, opts, _ := strings.Cut(typ.Field(i).Tag.Get("parquet"), ",")
+		header[i] = name
+		timestamps[i] = strings.HasPrefix(opts, "timestamp")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i := range v.NumField() {
+			field := v.Field(i)
+			switch {
+			case timestamps[i]:
+				record[i] = ""
+				if ns := field.Int(); ns != 0 {
+					record[i] = time.Unix(0, ns).UTC().Format(time.RFC3339Nano)
+				}
+			case field.Kind() == reflect.Float64:
+				record[i] = strconv.FormatFloat(field.Float(), 'f', -1, 64)
+			default:
+				record[i] = fmt.Sprint(field.Interface())
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/parquet-go/parquet-go"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorUsageExportCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorUsageExportCommand{}
+}
+
+func TestOperatorUsageExportCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorUsageExportCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-format=json"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid -format "json"`)
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-since=yesterday"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "neither an RFC 3339 time nor a duration")
+	ui.ErrorWriter.Reset()
+
+	dest := filepath.Join(t.TempDir(), "usage.csv")
+	must.NoError(t, os.WriteFile(dest, nil, 0o600))
+	code = cmd.Run([]string{dest})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Destination file already exists")
+}
+
+func TestOperatorUsageExportCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	state := srv.Agent.Server().State()
+
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1010, []*structs.Allocation{alloc}))
+
+	now := time.Now().Truncate(time.Second)
+	sample := structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+		CPU:       250,
+		MemoryMB:  64,
+		Timestamp: now.UnixNano(),
+	})
+	must.NoError(t, state.UpsertUsageSamples(structs.MsgTypeTestSetup, 1020, []*structs.UsageSample{sample}, 0))
+
+	billing := structs.NewAllocBillingRecord(map[string]*structs.TaskBillingRecord{
+		"web": {CPUSeconds: 1.5, MemoryGBHours: 0.25, PeakMemoryMB: 128},
+	})
+	billing.StartTime = now.Add(-time.Hour).UnixNano()
+	billing.EndTime = now.UnixNano()
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1030, []*structs.Allocation{{
+		ID:            alloc.ID,
+		NodeID:        alloc.NodeID,
+		ClientStatus:  structs.AllocClientStatusComplete,
+		ModifyTime:    now.UnixNano(),
+		BillingRecord: billing,
+	}}))
+
+	tmpDir := t.TempDir()
+
+	t.Run("samples csv", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorUsageExportCommand{Meta: Meta{Ui: ui}}
+
+		dest := filepath.Join(tmpDir, "samples.csv")
+		code := cmd.Run([]string{"-address=" + url, "-since=1h", dest})
+		must.Zero(t, code)
+		must.StrContains(t, ui.OutputWriter.String(), "Exported 1 rows to "+dest)
+
+		out, err := os.ReadFile(dest)
+		must.NoError(t, err)
+		must.Eq(t, "namespace,job_id,task_group,alloc_id,node_id,timestamp,cpu_mhz,memory_mb,backfilled\n"+
+			"default,"+alloc.JobID+",web,"+alloc.ID+","+alloc.NodeID+","+now.UTC().Format(time.RFC3339Nano)+",250,64,false\n",
+			string(out))
+	})
+
+	t.Run("samples out of range", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorUsageExportCommand{Meta: Meta{Ui: ui}}
+
+		dest := filepath.Join(tmpDir, "empty.csv")
+		code := cmd.Run([]string{"-address=" + url, "-job=" + alloc.JobID, "-until=2h", dest})
+		must.Zero(t, code)
+		must.StrContains(t, ui.OutputWriter.String(), "Exported 0 rows")
+	})
+
+	t.Run("billing parquet", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorUsageExportCommand{Meta: Meta{Ui: ui}}
+
+		dest := filepath.Join(tmpDir, "billing.parquet")
+		code := cmd.Run([]string{"-address=" + url, "-type=billing", "-format=parquet", dest})
+		must.Zero(t, code)
+
+		rows, err := parquet.ReadFile[billingRecordRow](dest)
+		must.NoError(t, err)
+		must.Eq(t, []billingRecordRow{{
+			Namespace:     alloc.Namespace,
+			JobID:         alloc.JobID,
+			TaskGroup:     alloc.TaskGroup,
+			AllocID:       alloc.ID,
+			NodeID:        alloc.NodeID,
+			ClientStatus:  structs.AllocClientStatusComplete,
+			StartTime:     billing.StartTime,
+			EndTime:       billing.EndTime,
+			CPUSeconds:    1.5,
+			MemoryGBHours: 0.25,
+			PeakMemoryMB:  128,
+		}}, rows)
+	})
+}
+
+func TestParseUsageExportTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 11, 4, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseUsageExportTime("", now)
+	must.NoError(t, err)
+	must.True(t, got.IsZero())
+
+	got, err = parseUsageExportTime("36h", now)
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, 11, 3, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseUsageExportTime("2024-11-01T08:30:00Z", now)
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, 11, 1, 8, 30, 0, 0, time.UTC), got)
+
+	_, err = parseUsageExportTime("last week", now)
+	must.Error(t, err)
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -20,11 +22,35 @@ Usage: nomad system gc [options]
 
   Initializes a garbage collection of jobs, evaluations, allocations, and nodes.
 
-  If ACLs are enabled, this option requires a management token.
+  With the -executors option, the clients instead terminate the executors left
+  running for tasks they no longer run, such as after a client crashed or lost
+  its state, along with the processes of those tasks. They also clear the
+  persisted handles of dead tasks, so they never reattach to a pid that may
+  have been reused by an unrelated process.
+
+  If ACLs are enabled, this option requires a management token. The -executors
+  option instead requires a token with the 'node:write' capability, or
+  'node:read' with the -dry-run option.
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+GC Options:
+
+  -executors
+    Garbage collect the orphaned executors and stale task handles of clients
+    instead of the cluster state.
+
+  -node-id=<id>
+    Only garbage collect the executors of the client with the given node ID.
+    By default, the executors of every client that isn't down are garbage
+    collected.
+
+  -dry-run
+    List the orphaned executors and stale task handles that would be garbage
+    collected, without terminating or clearing them.
+`
 	return strings.TrimSpace(helpText)
 }
 
@@ -33,7 +59,12 @@ func (c *SystemGCCommand) Synopsis() string {
 }
 
 func (c *SystemGCCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-executors": complete.PredictNothing,
+			"-node-id":   complete.PredictAnything,
+			"-dry-run":   complete.PredictNothing,
+		})
 }
 
 func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
@@ -43,8 +74,14 @@ func (c *SystemGCCommand) AutocompleteArgs() complete.Predictor {
 func (c *SystemGCCommand) Name() string { return "system gc" }
 
 func (c *SystemGCCommand) Run(args []string) int {
+	var executors, dryRun bool
+	var nodeID string
+
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&executors, "executors", false, "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+	flags.StringVar(&nodeID, "node-id", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -53,6 +90,11 @@ func (c *SystemGCCommand) Run(args []string) int {
 	if args = flags.Args(); len(args) > 0 {
 		c.Ui.Error("This command takes no arguments")
 		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !executors && (nodeID != "" || dryRun) {
+		c.Ui.Error("The -node-id and -dry-run options require -executors")
+		return 1
 	}
 
 	// Get the HTTP client
@@ -62,9 +104,79 @@ func (c *SystemGCCommand) Run(args []string) int {
 		return 1
 	}
 
+	if executors {
+		return c.gcExecutors(client, nodeID, dryRun)
+	}
+
 	if err := client.System().GarbageCollect(); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error running system garbage-collection: %s", err))
 		return 1
 	}
 	return 0
 }
+
+// gcExecutors garbage collects the orphaned executors and stale task handles
+// of the client with the node ID, or of every client that isn't down.
+func (c *SystemGCCommand) gcExecutors(client *api.Client, nodeID string, dryRun bool) int {
+	nodeIDs := []string{nodeID}
+	if nodeID == "" {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying nodes: %s", err))
+			return 1
+		}
+		nodeIDs = nodeIDs[:0]
+		for _, node := range nodes {
+			if node.Status != api.NodeStatusDown {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+		}
+	}
+
+	code := 0
+	found := false
+	for _, id := range nodeIDs {
+		var resp *api.ExecutorsResponse
+		var err error
+		if dryRun {
+			resp, err = client.Nodes().Executors().List(id, nil)
+		} else {
+			resp, err = client.Nodes().Executors().GarbageCollect(id, nil)
+		}
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error garbage collecting executors of node %s: %s", limit(id, shortId), err))
+			code = 1
+		}
+		if resp == nil {
+			continue
+		}
+
+		for _, o := range resp.Orphans {
+			found = true
+			action := "Terminated"
+			if dryRun {
+				action = "Would terminate"
+			} else if !o.Terminated {
+				action = "Failed to terminate"
+			}
+			c.Ui.Output(fmt.Sprintf("%s executor %d of task %q in allocation %s on node %s (%s used)",
+				action, o.Pid, o.Task, limit(o.AllocID, shortId), limit(id, shortId), humanize.IBytes(o.MemoryBytes)))
+		}
+		for _, h := range resp.StaleHandles {
+			found = true
+			action := "Cleared"
+			if dryRun {
+				action = "Would clear"
+			} else if !h.Cleared {
+				action = "Failed to clear"
+			}
+			c.Ui.Output(fmt.Sprintf("%s stale handle of task %q in allocation %s on node %s (pid %d)",
+				action, h.Task, limit(h.AllocID, shortId), limit(id, shortId), h.Pid))
+		}
+	}
+
+	if !found && code == 0 {
+		c.Ui.Output("No orphaned executors or stale task handles found")
+	}
+	return code
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
 )
 
 func TestSystemGCCommand_Implements(t *testing.T) {
@@ -29,3 +30,23 @@ func TestSystemGCCommand_Good(t *testing.T) {
 		t.Fatalf("expected exit 0, got: %d; %v", code, ui.ErrorWriter.String())
 	}
 }
+
+func TestSystemGCCommand_Executors(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server running a client
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &SystemGCCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{"-address=" + url, "-dry-run"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "require -executors")
+	ui.ErrorWriter.Reset()
+
+	nodeID := srv.Agent.Client().NodeID()
+	must.Zero(t, cmd.Run([]string{"-address=" + url, "-executors", "-dry-run", "-node-id=" + nodeID}))
+	must.StrContains(t, ui.OutputWriter.String(), "No orphaned executors or stale task handles found")
+}
@@ -446,6 +446,11 @@ var (
 		},
 		MustInitiateNetwork: true,
 		MountConfigs:        drivers.MountConfigSupportAll,
+		AllocStats:          true,
+		Stats: &drivers.StatsCapabilities{
+			DiskIO:  true,
+			Devices: true,
+		},
 	}
 )
 
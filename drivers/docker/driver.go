@@ -1740,6 +1740,12 @@ func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Dur
 	return h.Stats(ctx, interval, d.compute)
 }
 
+// AllocStats returns a single stream of resource usage for the given tasks
+// of an allocation.
+func (d *Driver) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+	return drivers.MergeTaskStats(ctx, d, taskIDs, interval)
+}
+
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.eventer.TaskEvents(ctx)
 }
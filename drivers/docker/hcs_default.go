@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package docker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+)
+
+// collectHCSStats returns false as the Host Compute Service only exists on
+// Windows.
+func (h *taskHandle) collectHCSStats(context.Context, *usageSender, time.Duration, cpustats.Compute) bool {
+	return false
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build windows
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/drivers/docker/util"
+	"github.com/hashicorp/nomad/helper"
+	"golang.org/x/sys/windows"
+)
+
+// The Host Compute Service (HCS) runs Windows containers, and knows the
+// working set, commit charge, and CPU time of a container, which the Docker
+// stats API does not report for every isolation mode. Docker names the HCS
+// compute system of a container after the container's ID.
+// Ref: https://learn.microsoft.com/en-us/virtualization/api/hcs/overview
+var (
+	modvmcompute = windows.NewLazySystemDLL("vmcompute.dll")
+
+	procHcsOpenComputeSystem          = modvmcompute.NewProc("HcsOpenComputeSystem")
+	procHcsCloseComputeSystem         = modvmcompute.NewProc("HcsCloseComputeSystem")
+	procHcsGetComputeSystemProperties = modvmcompute.NewProc("HcsGetComputeSystemProperties")
+)
+
+// hcsStatisticsQuery is the v1 schema property query for the statistics of
+// a compute system
+const hcsStatisticsQuery = `{"PropertyTypes":["Statistics"]}`
+
+// hcsSystem is a handle to an HCS compute system.
+type hcsSystem uintptr
+
+// openHCSSystem opens the compute system with the given ID.
+func openHCSSystem(id string) (hcsSystem, error) {
+	if err := procHcsOpenComputeSystem.Find(); err != nil {
+		return 0, err
+	}
+
+	idPtr, err := windows.UTF16PtrFromString(id)
+	if err != nil {
+		return 0, err
+	}
+
+	var system hcsSystem
+	var result *uint16
+	r0, _, _ := syscall.SyscallN(procHcsOpenComputeSystem.Addr(),
+		uintptr(unsafe.Pointer(idPtr)),
+		uintptr(unsafe.Pointer(&system)),
+		uintptr(unsafe.Pointer(&result)))
+	if err := hcsError("HcsOpenComputeSystem", r0, result); err != nil {
+		return 0, err
+	}
+	return system, nil
+}
+
+// statistics returns the current statistics of the compute system.
+func (s hcsSystem) statistics() (*util.HCSStatistics, error) {
+	query, err := windows.UTF16PtrFromString(hcsStatisticsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var properties, result *uint16
+	r0, _, _ := syscall.SyscallN(procHcsGetComputeSystemProperties.Addr(),
+		uintptr(s),
+		uintptr(unsafe.Pointer(query)),
+		uintptr(unsafe.Pointer(&properties)),
+		uintptr(unsafe.Pointer(&result)))
+	if err := hcsError("HcsGetComputeSystemProperties", r0, result); err != nil {
+		return nil, err
+	}
+	if properties == nil {
+		return nil, fmt.Errorf("HcsGetComputeSystemProperties returned no properties")
+	}
+	defer windows.CoTaskMemFree(unsafe.Pointer(properties))
+
+	var props struct {
+		Statistics *util.HCSStatistics
+	}
+	if err := json.Unmarshal([]byte(windows.UTF16PtrToString(properties)), &props); err != nil {
+		return nil, fmt.Errorf("failed to decode compute system properties: %w", err)
+	}
+	if props.Statistics == nil {
+		return nil, fmt.Errorf("compute system properties have no statistics")
+	}
+	return props.Statistics, nil
+}
+
+// close releases the handle to the compute system.
+func (s hcsSystem) close() {
+	syscall.SyscallN(procHcsCloseComputeSystem.Addr(), uintptr(s))
+}
+
+// hcsError returns an error for a failed HCS call, including the error
+// details HCS returned in result, and frees result.
+func hcsError(call string, hr uintptr, result *uint16) error {
+	var details string
+	if result != nil {
+		details = windows.UTF16PtrToString(result)
+		windows.CoTaskMemFree(unsafe.Pointer(result))
+	}
+	if int32(hr) >= 0 {
+		return nil
+	}
+	if details != "" {
+		return fmt.Errorf("%s failed: %w: %s", call, windows.Errno(hr), details)
+	}
+	return fmt.Errorf("%s failed: %w", call, windows.Errno(hr))
+}
+
+// collectHCSStats collects resource usage stats of the container from HCS
+// until the context is done or the container stops. It returns false without
+// collecting any stats if the container's compute system cannot be opened,
+// such as when the client lacks the privileges to use HCS, so that stats
+// can be collected from the Docker API instead.
+func (h *taskHandle) collectHCSStats(ctx context.Context, destCh *usageSender, interval time.Duration, compute cpustats.Compute) bool {
+	system, err := openHCSSystem(h.containerID)
+	if err != nil {
+		h.logger.Debug("unable to collect stats from HCS, falling back to docker stats API", "error", err)
+		return false
+	}
+	defer system.close()
+
+	ticker, cancel := helper.NewSafeTicker(interval)
+	defer cancel()
+	var prev *util.HCSStatistics
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-h.doneCh:
+			return true
+		case <-ticker.C:
+			stats, err := system.statistics()
+			if err != nil {
+				h.logger.Debug("error collecting stats from HCS", "error", err)
+				return true
+			}
+
+			destCh.send(util.HCSStatsToTaskResourceUsage(stats, prev, compute))
+			prev = stats
+		}
+	}
+}
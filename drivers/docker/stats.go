@@ -93,6 +93,12 @@ func (h *taskHandle) Stats(ctx context.Context, interval time.Duration, compute
 func (h *taskHandle) collectStats(ctx context.Context, destCh *usageSender, interval time.Duration, compute cpustats.Compute) {
 	defer destCh.close()
 
+	// on Windows, prefer stats from the Host Compute Service, which reports
+	// memory and CPU usage the Docker API leaves at zero
+	if h.collectHCSStats(ctx, destCh, interval, compute) {
+		return
+	}
+
 	ticker, cancel := helper.NewSafeTicker(interval)
 	defer cancel()
 	var stats *containerapi.Stats
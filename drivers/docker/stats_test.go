@@ -32,6 +32,18 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 	stats.MemoryStats.CommitPeak = 321323
 	stats.MemoryStats.PrivateWorkingSet = 62222
 
+	stats.BlkioStats.IoServiceBytesRecursive = []containerapi.BlkioStatEntry{
+		{Major: 8, Op: "read", Value: 4096},
+		{Major: 8, Op: "write", Value: 8192},
+		{Major: 9, Op: "Read", Value: 1024},
+	}
+	stats.BlkioStats.IoServicedRecursive = []containerapi.BlkioStatEntry{
+		{Major: 8, Op: "read", Value: 2},
+		{Major: 8, Op: "write", Value: 3},
+	}
+	stats.StorageStats.ReadSizeBytes = 4096
+	stats.StorageStats.WriteCountNormalized = 3
+
 	ru := util.DockerStatsToTaskResourceUsage(stats, cpustats.Compute{})
 
 	if runtime.GOOS != "windows" {
@@ -40,13 +52,14 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 		must.Eq(t, stats.MemoryStats.MaxUsage, ru.ResourceUsage.MemoryStats.MaxUsage)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledPeriods, ru.ResourceUsage.CpuStats.ThrottledPeriods)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledTime, ru.ResourceUsage.CpuStats.ThrottledTime)
+		must.Eq(t, &cstructs.DiskIOStats{ReadBytes: 5120, WriteBytes: 8192, ReadOps: 2, WriteOps: 3}, ru.ResourceUsage.DiskIO)
 	} else {
 		must.Eq(t, stats.MemoryStats.PrivateWorkingSet, ru.ResourceUsage.MemoryStats.RSS)
 		must.Eq(t, stats.MemoryStats.Commit, ru.ResourceUsage.MemoryStats.Usage)
 		must.Eq(t, stats.MemoryStats.CommitPeak, ru.ResourceUsage.MemoryStats.MaxUsage)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledPeriods, ru.ResourceUsage.CpuStats.ThrottledPeriods)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledTime, ru.ResourceUsage.CpuStats.ThrottledTime)
-
+		must.Eq(t, &cstructs.DiskIOStats{ReadBytes: 4096, WriteOps: 3}, ru.ResourceUsage.DiskIO)
 	}
 }
 
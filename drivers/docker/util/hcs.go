@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package util
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+var (
+	// The statistics the Docker driver exposes when reading them from the
+	// Host Compute Service rather than the Docker API
+	HCSMeasuredCPUStats = []string{"Percent", "User Mode", "System Mode", "Total CPU Seconds"}
+	HCSMeasuredMemStats = []string{"RSS", "Usage", "Max Usage"}
+)
+
+// HCSStatistics are the statistics of a Windows container as reported by the
+// Host Compute Service (HCS), in the v1 schema of its Statistics property.
+type HCSStatistics struct {
+	Timestamp time.Time `json:",omitempty"`
+	Memory    HCSMemoryStats
+	Processor HCSProcessorStats
+}
+
+// HCSMemoryStats are the memory statistics of a Windows container.
+type HCSMemoryStats struct {
+	UsageCommitBytes            uint64 `json:"MemoryUsageCommitBytes,omitempty"`
+	UsageCommitPeakBytes        uint64 `json:"MemoryUsageCommitPeakBytes,omitempty"`
+	UsagePrivateWorkingSetBytes uint64 `json:"MemoryUsagePrivateWorkingSetBytes,omitempty"`
+}
+
+// HCSProcessorStats are the CPU statistics of a Windows container, as
+// cumulative CPU time in 100ns intervals.
+type HCSProcessorStats struct {
+	TotalRuntime100ns  uint64 `json:",omitempty"`
+	RuntimeUser100ns   uint64 `json:",omitempty"`
+	RuntimeKernel100ns uint64 `json:",omitempty"`
+}
+
+// HCSStatsToTaskResourceUsage converts the statistics of a Windows container
+// to resource usage. CPU usage is computed from the CPU time used between
+// the previous statistics and the current ones, so it is zero if prev is nil.
+func HCSStatsToTaskResourceUsage(cur, prev *HCSStatistics, compute cpustats.Compute) *cstructs.TaskResourceUsage {
+	ms := &cstructs.MemoryStats{
+		RSS:      cur.Memory.UsagePrivateWorkingSetBytes,
+		Usage:    cur.Memory.UsageCommitBytes,
+		MaxUsage: cur.Memory.UsageCommitPeakBytes,
+		Measured: HCSMeasuredMemStats,
+	}
+
+	cs := &cstructs.CpuStats{
+		TotalCpuSeconds: float64(cur.Processor.TotalRuntime100ns*100) / float64(time.Second),
+		Measured:        HCSMeasuredCPUStats,
+	}
+	if prev != nil {
+		elapsed := cur.Timestamp.Sub(prev.Timestamp)
+		cs.Percent = runtimePercent(prev.Processor.TotalRuntime100ns, cur.Processor.TotalRuntime100ns, elapsed)
+		cs.UserMode = runtimePercent(prev.Processor.RuntimeUser100ns, cur.Processor.RuntimeUser100ns, elapsed)
+		cs.SystemMode = runtimePercent(prev.Processor.RuntimeKernel100ns, cur.Processor.RuntimeKernel100ns, elapsed)
+		if compute.NumCores > 0 {
+			cs.TotalTicks = (cs.Percent / 100) * float64(compute.TotalCompute) / float64(compute.NumCores)
+		}
+	}
+
+	return &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: ms,
+			CpuStats:    cs,
+		},
+		Timestamp: cur.Timestamp.UTC().UnixNano(),
+	}
+}
+
+// runtimePercent returns the CPU time used between two readings of a runtime
+// counter as a percentage of elapsed wall time, so that fully using one core
+// is 100%.
+func runtimePercent(prev, cur uint64, elapsed time.Duration) float64 {
+	if elapsed <= 0 || cur <= prev {
+		return 0
+	}
+	used := time.Duration(cur-prev) * 100
+	return float64(used) / float64(elapsed) * 100
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/shoenig/test/must"
+)
+
+func TestHCSStatsToTaskResourceUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	var prev, cur HCSStatistics
+	must.NoError(t, json.Unmarshal([]byte(`{
+		"Timestamp": "2024-01-02T03:04:05Z",
+		"Memory": {
+			"MemoryUsageCommitBytes": 1000,
+			"MemoryUsageCommitPeakBytes": 2000,
+			"MemoryUsagePrivateWorkingSetBytes": 500
+		},
+		"Processor": {
+			"TotalRuntime100ns": 10000000,
+			"RuntimeUser100ns": 8000000,
+			"RuntimeKernel100ns": 2000000
+		}
+	}`), &prev))
+	cur = prev
+	cur.Timestamp = prev.Timestamp.Add(time.Second)
+	cur.Memory.UsageCommitBytes = 1200
+	// one and a half cores used for the second, mostly in user mode
+	cur.Processor.TotalRuntime100ns += 15000000
+	cur.Processor.RuntimeUser100ns += 12000000
+	cur.Processor.RuntimeKernel100ns += 3000000
+
+	compute := cpustats.Compute{TotalCompute: 8000, NumCores: 4}
+
+	usage := HCSStatsToTaskResourceUsage(&prev, nil, compute)
+	must.Eq(t, 500, usage.ResourceUsage.MemoryStats.RSS)
+	must.Eq(t, 1000, usage.ResourceUsage.MemoryStats.Usage)
+	must.Eq(t, 2000, usage.ResourceUsage.MemoryStats.MaxUsage)
+	must.Eq(t, 0, usage.ResourceUsage.CpuStats.Percent)
+	must.Eq(t, 1, usage.ResourceUsage.CpuStats.TotalCpuSeconds)
+
+	usage = HCSStatsToTaskResourceUsage(&cur, &prev, compute)
+	must.Eq(t, 1200, usage.ResourceUsage.MemoryStats.Usage)
+	must.Eq(t, 150, usage.ResourceUsage.CpuStats.Percent)
+	must.Eq(t, 120, usage.ResourceUsage.CpuStats.UserMode)
+	must.Eq(t, 30, usage.ResourceUsage.CpuStats.SystemMode)
+	must.Eq(t, 3000, usage.ResourceUsage.CpuStats.TotalTicks)
+	must.Eq(t, 2.5, usage.ResourceUsage.CpuStats.TotalCpuSeconds)
+	must.Eq(t, cur.Timestamp.UnixNano(), usage.Timestamp)
+}
@@ -6,13 +6,16 @@
 package util
 
 import (
+	"strings"
+	"time"
+
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 )
 
 var (
-	DockerMeasuredCPUStats = []string{"Throttled Periods", "Throttled Time", "Percent"}
+	DockerMeasuredCPUStats = []string{"Throttled Periods", "Throttled Time", "Percent", "Total CPU Seconds"}
 
 	// cgroup-v2 only exposes a subset of memory stats
 	DockerCgroupV1MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage"}
@@ -43,6 +46,7 @@ func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Comp
 	cs := &cstructs.CpuStats{
 		ThrottledPeriods: s.CPUStats.ThrottlingData.ThrottledPeriods,
 		ThrottledTime:    s.CPUStats.ThrottlingData.ThrottledTime,
+		TotalCpuSeconds:  float64(s.CPUStats.CPUUsage.TotalUsage) / float64(time.Second),
 		Measured:         DockerMeasuredCPUStats,
 	}
 
@@ -63,7 +67,31 @@ func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Comp
 		ResourceUsage: &cstructs.ResourceUsage{
 			MemoryStats: ms,
 			CpuStats:    cs,
+			DiskIO:      blkioStats(s.BlkioStats),
 		},
 		Timestamp: s.Read.UTC().UnixNano(),
 	}
 }
+
+// blkioStats sums the block I/O of the container over its devices. Docker
+// reports the operation in lower case on cgroups v2 and capitalized on v1.
+func blkioStats(s containerapi.BlkioStats) *cstructs.DiskIOStats {
+	ds := new(cstructs.DiskIOStats)
+	for _, e := range s.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			ds.ReadBytes += e.Value
+		case "write":
+			ds.WriteBytes += e.Value
+		}
+	}
+	for _, e := range s.IoServicedRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			ds.ReadOps += e.Value
+		case "write":
+			ds.WriteOps += e.Value
+		}
+	}
+	return ds
+}
@@ -6,6 +6,8 @@
 package util
 
 import (
+	"time"
+
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -13,7 +15,7 @@ import (
 
 var (
 	// The statistics the Docker driver exposes
-	DockerMeasuredCPUStats = []string{"Throttled Periods", "Throttled Time", "Percent"}
+	DockerMeasuredCPUStats = []string{"Throttled Periods", "Throttled Time", "Percent", "Total CPU Seconds"}
 	DockerMeasuredMemStats = []string{"RSS", "Usage", "Max Usage"}
 )
 
@@ -51,6 +53,7 @@ func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Comp
 		ThrottledTime:    s.CPUStats.ThrottlingData.ThrottledTime,
 		Percent:          cpuPercent,
 		TotalTicks:       (cpuPercent / 100) * float64(totalCompute) / float64(totalCores),
+		TotalCpuSeconds:  float64(s.CPUStats.CPUUsage.TotalUsage*100) / float64(time.Second),
 		Measured:         DockerMeasuredCPUStats,
 	}
 
@@ -58,6 +61,12 @@ func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Comp
 		ResourceUsage: &cstructs.ResourceUsage{
 			MemoryStats: ms,
 			CpuStats:    cs,
+			DiskIO: &cstructs.DiskIOStats{
+				ReadBytes:  s.StorageStats.ReadSizeBytes,
+				WriteBytes: s.StorageStats.WriteSizeBytes,
+				ReadOps:    s.StorageStats.ReadCountNormalized,
+				WriteOps:   s.StorageStats.WriteCountNormalized,
+			},
 		},
 		Timestamp: s.Read.UTC().UnixNano(),
 	}
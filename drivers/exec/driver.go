@@ -7,8 +7,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +20,8 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
@@ -86,18 +92,47 @@ var (
 		),
 		"denied_host_uids": hclspec.NewAttr("denied_host_uids", "string", false),
 		"denied_host_gids": hclspec.NewAttr("denied_host_gids", "string", false),
+		"perf_event_stats": hclspec.NewDefault(
+			hclspec.NewAttr("perf_event_stats", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
+		"rootless": hclspec.NewDefault(
+			hclspec.NewAttr("rootless", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
+		"egress_class": hclspec.NewBlockList("egress_class", hclspec.NewObject(map[string]*hclspec.Spec{
+			"name":  hclspec.NewAttr("name", "string", true),
+			"cidrs": hclspec.NewAttr("cidrs", "list(string)", true),
+			"ports": hclspec.NewAttr("ports", "list(number)", false),
+		})),
+		"resctrl_class": hclspec.NewBlockList("resctrl_class", hclspec.NewObject(map[string]*hclspec.Spec{
+			"name":     hclspec.NewAttr("name", "string", true),
+			"schemata": hclspec.NewAttr("schemata", "list(string)", true),
+		})),
+		"provenance":              hclspec.NewAttr("provenance", "string", false),
+		"allow_selinux_labels":    hclspec.NewAttr("allow_selinux_labels", "list(string)", false),
+		"allow_apparmor_profiles": hclspec.NewAttr("allow_apparmor_profiles", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
-		"work_dir": hclspec.NewAttr("work_dir", "string", false),
+		"command":            hclspec.NewAttr("command", "string", true),
+		"args":               hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":           hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":           hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":            hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":           hclspec.NewAttr("cap_drop", "list(string)", false),
+		"work_dir":           hclspec.NewAttr("work_dir", "string", false),
+		"runtime_hints":      hclspec.NewAttr("runtime_hints", "bool", false),
+		"selinux_label":      hclspec.NewAttr("selinux_label", "string", false),
+		"apparmor_profile":   hclspec.NewAttr("apparmor_profile", "string", false),
+		"masked_paths":       hclspec.NewAttr("masked_paths", "list(string)", false),
+		"readonly_paths":     hclspec.NewAttr("readonly_paths", "list(string)", false),
+		"dns_servers":        hclspec.NewAttr("dns_servers", "list(string)", false),
+		"dns_search_domains": hclspec.NewAttr("dns_search_domains", "list(string)", false),
+		"dns_options":        hclspec.NewAttr("dns_options", "list(string)", false),
+		"resctrl_class":      hclspec.NewAttr("resctrl_class", "string", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -111,6 +146,11 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
+		AllocStats:   true,
+		Processes:    true,
+		Profile:      true,
+		SharedCgroup: true,
+		Stats:        executor.StatsCapabilities(),
 	}
 )
 
@@ -168,6 +208,37 @@ type Config struct {
 
 	DeniedHostUids string `codec:"denied_host_uids"`
 	DeniedHostGids string `codec:"denied_host_gids"`
+
+	// PerfEventStats enables collection of hardware performance counters
+	// (cycles, instructions, cache and branch misses) for each task.
+	PerfEventStats bool `codec:"perf_event_stats"`
+
+	// Rootless enables the driver when the client runs as an unprivileged
+	// user with a delegated cgroup subtree. Tasks then run in a user
+	// namespace as the user of the client.
+	Rootless bool `codec:"rootless"`
+
+	// EgressClasses are the classes of destinations the traffic each task
+	// sends is accounted by, using an eBPF program attached to the task's
+	// cgroup.
+	EgressClasses []*egressstats.Class `codec:"egress_class"`
+
+	// Provenance is "binary" or "libraries" to record the digests of the
+	// binary each task executes, and of the shared libraries it links, in a
+	// task event when it starts.
+	Provenance string `codec:"provenance"`
+
+	// ResctrlClasses are the classes of L3 cache and memory bandwidth
+	// allocation tasks can be assigned to with resctrl_class.
+	ResctrlClasses []*resctrl.Class `codec:"resctrl_class"`
+
+	// AllowSELinuxLabels are the SELinux labels tasks can run with using
+	// selinux_label. Tasks cannot set a label if empty.
+	AllowSELinuxLabels []string `codec:"allow_selinux_labels"`
+
+	// AllowAppArmorProfiles are the AppArmor profiles tasks can be confined
+	// by using apparmor_profile. Tasks cannot set a profile if empty.
+	AllowAppArmorProfiles []string `codec:"allow_apparmor_profiles"`
 }
 
 func (c *Config) validate() error {
@@ -188,6 +259,24 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := egressstats.Validate(c.EgressClasses); err != nil {
+		return err
+	}
+	if err := resctrl.Validate(c.ResctrlClasses); err != nil {
+		return err
+	}
+	return executor.ValidateProvenance(c.Provenance)
+}
+
+// validateSecurityLabels checks the SELinux label and AppArmor profile of the
+// task are allowed by the plugin configuration.
+func (c *Config) validateSecurityLabels(tc *TaskConfig) error {
+	if tc.SELinuxLabel != "" && !slices.Contains(c.AllowSELinuxLabels, tc.SELinuxLabel) {
+		return fmt.Errorf("selinux_label %q is not allowed by allow_selinux_labels", tc.SELinuxLabel)
+	}
+	if tc.AppArmorProfile != "" && !slices.Contains(c.AllowAppArmorProfiles, tc.AppArmorProfile) {
+		return fmt.Errorf("apparmor_profile %q is not allowed by allow_apparmor_profiles", tc.AppArmorProfile)
+	}
 	return nil
 }
 
@@ -215,6 +304,32 @@ type TaskConfig struct {
 
 	// WorkDir is the working directory inside the chroot
 	WorkDir string `codec:"work_dir"`
+
+	// RuntimeHints sets GOMAXPROCS and the JVM's active processor count from
+	// the CPUs the task can use
+	RuntimeHints bool `codec:"runtime_hints"`
+
+	// SELinuxLabel is the SELinux process label the task runs with
+	SELinuxLabel string `codec:"selinux_label"`
+
+	// AppArmorProfile is the AppArmor profile the task is confined by
+	AppArmorProfile string `codec:"apparmor_profile"`
+
+	// MaskedPaths are masked inside the task in addition to the defaults
+	MaskedPaths []string `codec:"masked_paths"`
+
+	// ReadonlyPaths are read-only inside the task in addition to the defaults
+	ReadonlyPaths []string `codec:"readonly_paths"`
+
+	// DNSServers, DNSSearchDomains and DNSOptions override the DNS
+	// configuration of the group network for the task
+	DNSServers       []string `codec:"dns_servers"`
+	DNSSearchDomains []string `codec:"dns_search_domains"`
+	DNSOptions       []string `codec:"dns_options"`
+
+	// ResctrlClass assigns the task to a resctrl class of the plugin
+	// configuration, which allocates it L3 cache and memory bandwidth
+	ResctrlClass string `codec:"resctrl_class"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -245,6 +360,24 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("work_dir must be absolute but got relative path %q", tc.WorkDir)
 	}
 
+	// a label is user:role:type followed by an optional level, which may
+	// itself contain colons
+	if tc.SELinuxLabel != "" && len(strings.SplitN(tc.SELinuxLabel, ":", 4)) < 3 {
+		return fmt.Errorf("selinux_label must be of the form user:role:type[:level], got %q", tc.SELinuxLabel)
+	}
+
+	for _, p := range tc.MaskedPaths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("masked_paths must be absolute but got relative path %q", p)
+		}
+	}
+
+	for _, p := range tc.ReadonlyPaths {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("readonly_paths must be absolute but got relative path %q", p)
+		}
+	}
+
 	return nil
 }
 
@@ -382,7 +515,8 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		HealthDescription: drivers.DriverHealthy,
 	}
 
-	if !utils.IsUnixRoot() {
+	rootless := !utils.IsUnixRoot()
+	if rootless && !d.config.Rootless {
 		fp.Health = drivers.HealthStateUndetected
 		fp.HealthDescription = drivers.DriverRequiresRootMessage
 		d.setFingerprintFailure()
@@ -396,6 +530,16 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		return fp
 	}
 
+	if rootless {
+		if !userNamespacesEnabled() {
+			fp.Health = drivers.HealthStateUnhealthy
+			fp.HealthDescription = "Rootless exec requires unprivileged user namespaces"
+			d.setFingerprintFailure()
+			return fp
+		}
+		fp.Attributes["driver.exec.rootless"] = pstructs.NewBoolAttribute(true)
+	}
+
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
 	d.setFingerprintSuccess()
 	return fp
@@ -470,14 +614,34 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
-	if cfg.User == "" {
-		cfg.User = "nobody"
+	if err := d.config.validateSecurityLabels(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
+	var resctrlClass *resctrl.Class
+	if driverConfig.ResctrlClass != "" {
+		if resctrlClass, err = resctrl.Find(d.config.ResctrlClasses, driverConfig.ResctrlClass); err != nil {
+			return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+		}
 	}
 
-	d.logger.Debug("setting up user", "user", cfg.User)
+	// the tasks of a rootless client run as its user, which is root in the
+	// user namespace of the task
+	rootless := !utils.IsUnixRoot()
+	if rootless {
+		if err := validateRootlessUser(cfg.User); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if cfg.User == "" {
+			cfg.User = "nobody"
+		}
+
+		d.logger.Debug("setting up user", "user", cfg.User)
 
-	if err := d.userIDValidator.HasValidIDs(cfg.User); err != nil {
-		return nil, nil, fmt.Errorf("failed host user validation: %v", err)
+		if err := d.userIDValidator.HasValidIDs(cfg.User); err != nil {
+			return nil, nil, fmt.Errorf("failed host user validation: %v", err)
+		}
 	}
 
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
@@ -493,14 +657,9 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	}
 
 	user := cfg.User
-	if cfg.DNS != nil {
-		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
-		}
-		cfg.Mounts = append(cfg.Mounts, dnsMount)
+	if rootless {
+		user = ""
 	}
-
 	caps, err := capabilities.Calculate(
 		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
 	)
@@ -534,12 +693,24 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		WorkDir:          driverConfig.WorkDir,
 		StdoutPath:       cfg.StdoutPath,
 		StderrPath:       cfg.StderrPath,
+		FramedLogs:       cfg.FramedLogs,
+		QoSClasses:       cfg.QoSClasses,
 		Mounts:           cfg.Mounts,
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		PerfEventStats:   d.config.PerfEventStats,
+		RuntimeHints:     driverConfig.RuntimeHints,
+		SELinuxLabel:     driverConfig.SELinuxLabel,
+		AppArmorProfile:  driverConfig.AppArmorProfile,
+		MaskedPaths:      driverConfig.MaskedPaths,
+		ReadonlyPaths:    driverConfig.ReadonlyPaths,
+		EgressClasses:    d.config.EgressClasses,
+		ResctrlClass:     resctrlClass,
+		Provenance:       d.config.Provenance,
+		DNS:              resolvconf.TaskDNS(cfg.DNS, driverConfig.DNSServers, driverConfig.DNSSearchDomains, driverConfig.DNSOptions),
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -547,6 +718,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
+	if ps.Provenance != nil {
+		d.eventer.EmitEvent(executor.ProvenanceEvent(cfg, ps.Provenance))
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          ps.Pid,
@@ -558,7 +733,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	}
 
 	driverState := TaskState{
-		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(executor.ReattachConfig(pluginClient)),
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
@@ -597,9 +772,10 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode:  ps.ExitCode,
-			Signal:    ps.Signal,
-			OOMKilled: ps.OOMKilled,
+			ExitCode:   ps.ExitCode,
+			Signal:     ps.Signal,
+			OOMKilled:  ps.OOMKilled,
+			ExitReason: ps.ExitReason,
 		}
 	}
 
@@ -668,6 +844,33 @@ func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Dur
 	return handle.exec.Stats(ctx, interval)
 }
 
+// AllocStats returns a single stream of resource usage for the given tasks
+// of an allocation.
+func (d *Driver) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+	return drivers.MergeTaskStats(ctx, d, taskIDs, interval)
+}
+
+// TaskProcesses returns a snapshot of the processes running in the task.
+func (d *Driver) TaskProcesses(taskID string) ([]*drivers.TaskProcess, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Processes()
+}
+
+// ProfileTask samples the call stacks of the task's processes with perf for
+// the duration and returns them folded.
+func (d *Driver) ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Profile(ctx, duration)
+}
+
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.eventer.TaskEvents(ctx)
 }
@@ -733,3 +936,33 @@ func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 
 	return handle.exec.ExecStreaming(ctx, command, tty, stream)
 }
+
+// userNamespacesEnabled returns whether unprivileged users can create user
+// namespaces, which some distributions disable with a sysctl.
+func userNamespacesEnabled() bool {
+	if b, err := os.ReadFile("/proc/sys/kernel/unprivileged_userns_clone"); err == nil && strings.TrimSpace(string(b)) == "0" {
+		return false
+	}
+	b, err := os.ReadFile("/proc/sys/user/max_user_namespaces")
+	if err != nil {
+		return false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	return err == nil && n > 0
+}
+
+// validateRootlessUser returns an error if a task of a rootless client asks
+// to run as another user than the client's, which it can't switch to.
+func validateRootlessUser(taskUser string) error {
+	if taskUser == "" {
+		return nil
+	}
+	current, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("failed to look up client user: %w", err)
+	}
+	if taskUser != current.Username && taskUser != current.Uid {
+		return fmt.Errorf("task user %q is not supported by a rootless client, whose tasks run as %q", taskUser, current.Username)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -21,7 +22,9 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
 	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
 	ctestutils "github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
@@ -129,6 +132,18 @@ func TestExecDriver_Fingerprint(t *testing.T) {
 	}
 }
 
+func TestExecDriver_validateRootlessUser(t *testing.T) {
+	ci.Parallel(t)
+
+	current, err := user.Current()
+	must.NoError(t, err)
+
+	must.NoError(t, validateRootlessUser(""))
+	must.NoError(t, validateRootlessUser(current.Username))
+	must.NoError(t, validateRootlessUser(current.Uid))
+	must.ErrorContains(t, validateRootlessUser("nomad-rootless-other"), "not supported by a rootless client")
+}
+
 func TestExecDriver_WorkDir(t *testing.T) {
 	ci.Parallel(t)
 
@@ -843,12 +858,20 @@ config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
   work_dir = "/root"
+  runtime_hints = true
+  dns_servers = ["10.0.0.53"]
+  dns_search_domains = ["service.consul"]
+  dns_options = ["ndots:2"]
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
-		WorkDir: "/root",
+		Command:          "/bin/bash",
+		Args:             []string{"-c", "echo hello"},
+		WorkDir:          "/root",
+		RuntimeHints:     true,
+		DNSServers:       []string{"10.0.0.53"},
+		DNSSearchDomains: []string{"service.consul"},
+		DNSOptions:       []string{"ndots:2"},
 	}
 
 	var tc *TaskConfig
@@ -1062,6 +1085,80 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("egress_class", func(t *testing.T) {
+		for _, tc := range []struct {
+			classes []*egressstats.Class
+			err     string
+		}{
+			{classes: nil},
+			{classes: []*egressstats.Class{{Name: "db", CIDRs: []string{"10.0.0.0/8"}, Ports: []int{5432}}}},
+			{classes: []*egressstats.Class{{Name: "db", CIDRs: []string{"10.0.0.0"}}}, err: "invalid CIDR"},
+			{classes: []*egressstats.Class{{Name: egressstats.Unclassified, CIDRs: []string{"10.0.0.0/8"}}}, err: "reserved"},
+		} {
+			err := (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				EgressClasses:  tc.classes,
+			}).validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		}
+	})
+
+	t.Run("resctrl_class", func(t *testing.T) {
+		for _, tc := range []struct {
+			classes []*resctrl.Class
+			err     string
+		}{
+			{classes: nil},
+			{classes: []*resctrl.Class{{Name: "gold", Schemata: []string{"L3:0=ff0", "MB:0=70"}}}},
+			{classes: []*resctrl.Class{{Name: "gold", Schemata: []string{"L3=ff0"}}}, err: "invalid schemata line"},
+		} {
+			err := (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				ResctrlClasses: tc.classes,
+			}).validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		}
+	})
+}
+
+func TestDriver_Config_validateSecurityLabels(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &Config{
+		AllowSELinuxLabels:    []string{"system_u:system_r:container_t:s0"},
+		AllowAppArmorProfiles: []string{"nomad-task"},
+	}
+
+	for _, tc := range []struct {
+		label, profile string
+		exp            error
+	}{
+		{exp: nil},
+		{label: "system_u:system_r:container_t:s0", profile: "nomad-task", exp: nil},
+		{label: "system_u:system_r:spc_t:s0", exp: errors.New(`selinux_label "system_u:system_r:spc_t:s0" is not allowed by allow_selinux_labels`)},
+		{profile: "unconfined", exp: errors.New(`apparmor_profile "unconfined" is not allowed by allow_apparmor_profiles`)},
+	} {
+		must.Eq(t, tc.exp, config.validateSecurityLabels(&TaskConfig{
+			SELinuxLabel:    tc.label,
+			AppArmorProfile: tc.profile,
+		}))
+	}
+
+	// nothing is allowed by default
+	must.Error(t, (&Config{}).validateSecurityLabels(&TaskConfig{
+		AppArmorProfile: "nomad-task",
+	}))
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -1136,4 +1233,28 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("selinux_label", func(t *testing.T) {
+		for _, tc := range []struct {
+			label string
+			exp   error
+		}{
+			{label: "", exp: nil},
+			{label: "system_u:system_r:container_t", exp: nil},
+			{label: "system_u:system_r:container_t:s0:c1,c2", exp: nil},
+			{label: "container_t", exp: errors.New(`selinux_label must be of the form user:role:type[:level], got "container_t"`)},
+		} {
+			must.Eq(t, tc.exp, (&TaskConfig{
+				SELinuxLabel: tc.label,
+			}).validate())
+		}
+	})
+
+	t.Run("masked_paths", func(t *testing.T) {
+		must.NoError(t, (&TaskConfig{MaskedPaths: []string{"/proc/meminfo"}}).validate())
+		must.Eq(t, errors.New(`masked_paths must be absolute but got relative path "proc/meminfo"`),
+			(&TaskConfig{MaskedPaths: []string{"proc/meminfo"}}).validate())
+		must.Eq(t, errors.New(`readonly_paths must be absolute but got relative path "sys"`),
+			(&TaskConfig{ReadonlyPaths: []string{"sys"}}).validate())
+	})
 }
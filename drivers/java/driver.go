@@ -89,16 +89,17 @@ var (
 		// It's required for either `class` or `jar_path` to be set,
 		// but that's not expressable in hclspec.  Marking both as optional
 		// and setting checking explicitly later
-		"class":       hclspec.NewAttr("class", "string", false),
-		"class_path":  hclspec.NewAttr("class_path", "string", false),
-		"jar_path":    hclspec.NewAttr("jar_path", "string", false),
-		"jvm_options": hclspec.NewAttr("jvm_options", "list(string)", false),
-		"args":        hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
-		"work_dir":    hclspec.NewAttr("work_dir", "string", false),
+		"class":         hclspec.NewAttr("class", "string", false),
+		"class_path":    hclspec.NewAttr("class_path", "string", false),
+		"jar_path":      hclspec.NewAttr("jar_path", "string", false),
+		"jvm_options":   hclspec.NewAttr("jvm_options", "list(string)", false),
+		"args":          hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":      hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":      hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":       hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":      hclspec.NewAttr("cap_drop", "list(string)", false),
+		"work_dir":      hclspec.NewAttr("work_dir", "string", false),
+		"runtime_hints": hclspec.NewAttr("runtime_hints", "bool", false),
 	})
 
 	// driverCapabilities is returned by the Capabilities RPC and indicates what
@@ -112,6 +113,11 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportNone,
+		AllocStats:   true,
+		Processes:    true,
+		Profile:      true,
+		SharedCgroup: true,
+		Stats:        executor.StatsCapabilities(),
 	}
 
 	_ drivers.DriverPlugin = (*Driver)(nil)
@@ -193,6 +199,10 @@ type TaskConfig struct {
 
 	// WorkDir is the working directory for the task
 	WorkDir string `coded:"work_dir"`
+
+	// RuntimeHints sets the JVM's active processor count from the CPUs the
+	// task can use
+	RuntimeHints bool `codec:"runtime_hints"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -512,12 +522,15 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		WorkDir:          driverConfig.WorkDir,
 		StdoutPath:       cfg.StdoutPath,
 		StderrPath:       cfg.StderrPath,
+		FramedLogs:       cfg.FramedLogs,
+		QoSClasses:       cfg.QoSClasses,
 		Mounts:           cfg.Mounts,
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		RuntimeHints:     driverConfig.RuntimeHints,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -536,7 +549,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	}
 
 	driverState := TaskState{
-		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(executor.ReattachConfig(pluginClient)),
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
@@ -606,9 +619,10 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode:  ps.ExitCode,
-			Signal:    ps.Signal,
-			OOMKilled: ps.OOMKilled,
+			ExitCode:   ps.ExitCode,
+			Signal:     ps.Signal,
+			OOMKilled:  ps.OOMKilled,
+			ExitReason: ps.ExitReason,
 		}
 	}
 
@@ -677,6 +691,33 @@ func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Dur
 	return handle.exec.Stats(ctx, interval)
 }
 
+// AllocStats returns a single stream of resource usage for the given tasks
+// of an allocation.
+func (d *Driver) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+	return drivers.MergeTaskStats(ctx, d, taskIDs, interval)
+}
+
+// TaskProcesses returns a snapshot of the processes running in the task.
+func (d *Driver) TaskProcesses(taskID string) ([]*drivers.TaskProcess, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Processes()
+}
+
+// ProfileTask samples the call stacks of the task's processes with perf for
+// the duration and returns them folded.
+func (d *Driver) ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Profile(ctx, duration)
+}
+
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.eventer.TaskEvents(ctx)
 }
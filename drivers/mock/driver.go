@@ -15,6 +15,7 @@ import (
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -84,6 +85,7 @@ var (
 		"driver_ip":               hclspec.NewAttr("driver_ip", "string", false),
 		"driver_advertise":        hclspec.NewAttr("driver_advertise", "bool", false),
 		"driver_port_map":         hclspec.NewAttr("driver_port_map", "string", false),
+		"stats":                   hclspec.NewBlock("stats", false, statsSpec),
 
 		"run_for":                hclspec.NewAttr("run_for", "string", false),
 		"exit_code":              hclspec.NewAttr("exit_code", "number", false),
@@ -144,6 +146,10 @@ type Driver struct {
 	// lastMu guards access to last[Driver]TaskConfig
 	lastMu sync.Mutex
 
+	// compute is the CPU of the node, which synthetic CPU usage is reported
+	// against
+	compute cpustats.Compute
+
 	// logger will log to the Nomad agent
 	logger hclog.Logger
 }
@@ -157,6 +163,8 @@ func NewMockDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugi
 		Exec:         true,
 		FSIsolation:  drivers.FSIsolationNone,
 		MountConfigs: drivers.MountConfigSupportNone,
+		Processes:    true,
+		Profile:      true,
 	}
 
 	return &Driver{
@@ -263,6 +271,10 @@ type TaskConfig struct {
 	// DriverPortMap will parse a label:number pair and return it in
 	// DriverNetwork.PortMap from Start().
 	DriverPortMap string `codec:"driver_port_map"`
+
+	// Stats makes TaskStats report synthetic resource usage following
+	// configurable patterns, instead of a random memory usage.
+	Stats *StatsConfig `codec:"stats"`
 }
 
 type MockTaskState struct {
@@ -275,6 +287,7 @@ type MockTaskState struct {
 	ExecCommand     *Command
 	PluginExitAfter time.Duration
 	KillAfter       time.Duration
+	Stats           *StatsConfig
 	ProcState       drivers.TaskState
 }
 
@@ -304,6 +317,10 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 		d.capabilities.FSIsolation = drivers.FSIsolation(isolation)
 	}
 
+	if cfg.AgentConfig != nil {
+		d.compute = cfg.AgentConfig.Compute()
+	}
+
 	return nil
 }
 
@@ -372,6 +389,9 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	if taskState.ExecCommand != nil {
 		taskState.ExecCommand.parseDurations()
 	}
+	if taskState.Stats != nil {
+		taskState.Stats.parse()
+	}
 
 	// Correct the run_for time based on how long it has already been running
 	now := time.Now()
@@ -394,6 +414,7 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		taskConfig:      handle.Config,
 		command:         taskState.Command,
 		execCommand:     taskState.ExecCommand,
+		stats:           taskState.Stats,
 		procState:       drivers.TaskStateRunning,
 		startedAt:       taskState.StartedAt,
 		kill:            killCancel,
@@ -448,6 +469,12 @@ func parseDriverConfig(cfg *drivers.TaskConfig) (*TaskConfig, error) {
 		}
 	}
 
+	if driverConfig.Stats != nil {
+		if err = driverConfig.Stats.parse(); err != nil {
+			return nil, fmt.Errorf("stats: %v", err)
+		}
+	}
+
 	return &driverConfig, nil
 }
 
@@ -493,6 +520,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		taskConfig:      cfg,
 		command:         driverConfig.Command,
 		execCommand:     driverConfig.ExecCommand,
+		stats:           driverConfig.Stats,
 		pluginExitAfter: driverConfig.pluginExitAfterDuration,
 		killAfter:       driverConfig.killAfterDuration,
 		logger:          d.logger.With("task_name", cfg.Name),
@@ -508,6 +536,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ExecCommand:     driverConfig.ExecCommand,
 		PluginExitAfter: driverConfig.pluginExitAfterDuration,
 		KillAfter:       driverConfig.killAfterDuration,
+		Stats:           driverConfig.Stats,
 	}
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -592,6 +621,10 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 
 func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
 	ch := make(chan *drivers.TaskResourceUsage)
+	if h, ok := d.tasks.Get(taskID); ok && h.stats != nil {
+		go d.handleSyntheticStats(ctx, h, interval, ch)
+		return ch, nil
+	}
 	go d.handleStats(ctx, ch)
 	return ch, nil
 }
@@ -623,6 +656,35 @@ func (d *Driver) handleStats(ctx context.Context, ch chan<- *drivers.TaskResourc
 	}
 }
 
+// TaskProcesses returns a single fake process for the task, as mock tasks do
+// not run any processes.
+func (d *Driver) TaskProcesses(taskID string) ([]*drivers.TaskProcess, error) {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+
+	return []*drivers.TaskProcess{{
+		Pid:       1,
+		Cmdline:   []string{"mock", h.taskConfig.Name},
+		State:     "running",
+		StartTime: h.startedAt.UnixNano(),
+	}}, nil
+}
+
+// ProfileTask returns a single fake stack for the task's fake process.
+func (d *Driver) ProfileTask(_ context.Context, taskID string, _ time.Duration) ([]byte, error) {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return []byte(fmt.Sprintf("mock;%s 1\n", h.taskConfig.Name)), nil
+}
+
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.eventer.TaskEvents(ctx)
 }
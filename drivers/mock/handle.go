@@ -25,6 +25,9 @@ type taskHandle struct {
 	command     Command
 	execCommand *Command
 
+	// stats is the synthetic usage reported for the task, if any
+	stats *StatsConfig
+
 	// stateLock guards the procState field
 	stateLock sync.RWMutex
 	procState drivers.TaskState
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+)
+
+const (
+	// UsagePatternConstant reports the base usage
+	UsagePatternConstant = "constant"
+
+	// UsagePatternRamp climbs from the base usage to the peak usage over the
+	// period, and then holds at the peak
+	UsagePatternRamp = "ramp"
+
+	// UsagePatternSpike reports the peak usage for spike_for at the start of
+	// every period, and the base usage otherwise
+	UsagePatternSpike = "spike"
+
+	// UsagePatternLeak grows from the base usage by the difference between
+	// the peak and the base usage every period, without bound
+	UsagePatternLeak = "leak"
+)
+
+// usagePatternSpec is the hcl specification of a synthetic usage pattern
+var usagePatternSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+	"pattern":   hclspec.NewAttr("pattern", "string", false),
+	"base":      hclspec.NewAttr("base", "number", false),
+	"peak":      hclspec.NewAttr("peak", "number", false),
+	"period":    hclspec.NewAttr("period", "string", false),
+	"spike_for": hclspec.NewAttr("spike_for", "string", false),
+})
+
+// statsSpec is the hcl specification of the synthetic stats of a task
+var statsSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+	"cpu":    hclspec.NewBlock("cpu", false, usagePatternSpec),
+	"memory": hclspec.NewBlock("memory", false, usagePatternSpec),
+})
+
+// StatsConfig makes the mock driver report synthetic resource usage for a
+// task, which follows the configured patterns over the time since the task
+// started. The CPU usage is in MHz and the memory usage in MiB.
+type StatsConfig struct {
+	CPU    *UsagePattern `codec:"cpu"`
+	Memory *UsagePattern `codec:"memory"`
+}
+
+// UsagePattern is a synthetic pattern of a resource usage.
+type UsagePattern struct {
+	// Pattern is the shape of the usage over time, one of constant, ramp,
+	// spike and leak
+	Pattern string `codec:"pattern"`
+
+	// Base is the usage outside of spikes and at the start of ramps and
+	// leaks, and Peak the usage of spikes and at the end of ramps
+	Base float64 `codec:"base"`
+	Peak float64 `codec:"peak"`
+
+	// Period is the duration of ramps, between spikes, and over which leaks
+	// grow by the difference between Peak and Base
+	Period string `codec:"period"`
+	period time.Duration
+
+	// SpikeFor is how long spikes last
+	SpikeFor string `codec:"spike_for"`
+	spikeFor time.Duration
+}
+
+func (s *StatsConfig) parse() error {
+	if err := s.CPU.parse(); err != nil {
+		return fmt.Errorf("cpu: %v", err)
+	}
+	if err := s.Memory.parse(); err != nil {
+		return fmt.Errorf("memory: %v", err)
+	}
+	return nil
+}
+
+func (p *UsagePattern) parse() error {
+	if p == nil {
+		return nil
+	}
+
+	var err error
+	if p.period, err = parseDuration(p.Period); err != nil {
+		return fmt.Errorf("period %v not a valid duration: %v", p.Period, err)
+	}
+	if p.spikeFor, err = parseDuration(p.SpikeFor); err != nil {
+		return fmt.Errorf("spike_for %v not a valid duration: %v", p.SpikeFor, err)
+	}
+
+	switch p.Pattern {
+	case "", UsagePatternConstant:
+	case UsagePatternRamp, UsagePatternLeak:
+		if p.period <= 0 {
+			return fmt.Errorf("%s pattern requires a period", p.Pattern)
+		}
+	case UsagePatternSpike:
+		if p.period <= 0 || p.spikeFor <= 0 {
+			return fmt.Errorf("spike pattern requires a period and spike_for")
+		}
+	default:
+		return fmt.Errorf("unknown pattern %q", p.Pattern)
+	}
+	return nil
+}
+
+// value returns the usage of the pattern at elapsed since the task started.
+func (p *UsagePattern) value(elapsed time.Duration) float64 {
+	var v float64
+	switch p.Pattern {
+	case UsagePatternRamp:
+		progress := min(float64(elapsed)/float64(p.period), 1)
+		v = p.Base + (p.Peak-p.Base)*progress
+	case UsagePatternSpike:
+		v = p.Base
+		if elapsed%p.period < p.spikeFor {
+			v = p.Peak
+		}
+	case UsagePatternLeak:
+		v = p.Base + (p.Peak-p.Base)*float64(elapsed)/float64(p.period)
+	default:
+		v = p.Base
+	}
+	return max(v, 0)
+}
+
+// syntheticUsage returns the usage the stats config describes at elapsed
+// since the task started. The CPU percent is only reported when the compute
+// of the node is known.
+func syntheticUsage(s *StatsConfig, compute cpustats.Compute, elapsed time.Duration, now time.Time) *drivers.TaskResourceUsage {
+	ru := &drivers.ResourceUsage{}
+	if s.CPU != nil {
+		mhz := s.CPU.value(elapsed)
+		ru.CpuStats = &drivers.CpuStats{TotalTicks: mhz}
+		if compute.TotalCompute > 0 && compute.NumCores > 0 {
+			coreMHz := float64(compute.TotalCompute) / float64(compute.NumCores)
+			ru.CpuStats.Percent = mhz / coreMHz * 100
+			ru.CpuStats.TotalCpuSeconds = s.CPU.integral(elapsed) / coreMHz
+			ru.CpuStats.Measured = []string{"Percent", "Total CPU Seconds"}
+		}
+	}
+	if s.Memory != nil {
+		bytes := uint64(math.Round(s.Memory.value(elapsed) * 1024 * 1024))
+		ru.MemoryStats = &drivers.MemoryStats{
+			RSS:      bytes,
+			Usage:    bytes,
+			Measured: []string{"RSS", "Usage"},
+		}
+	}
+
+	return &drivers.TaskResourceUsage{
+		ResourceUsage: ru,
+		Timestamp:     now.UTC().UnixNano(),
+	}
+}
+
+// integral returns the usage of the pattern integrated over the seconds up to
+// elapsed since the task started, such as the MHz seconds used by a CPU
+// pattern.
+func (p *UsagePattern) integral(elapsed time.Duration) float64 {
+	t := elapsed.Seconds()
+	rise := p.Peak - p.Base
+
+	var total float64
+	switch p.Pattern {
+	case UsagePatternRamp:
+		period := p.period.Seconds()
+		if t <= period {
+			total = p.Base*t + rise*t*t/(2*period)
+		} else {
+			total = p.Base*period + rise*period/2 + p.Peak*(t-period)
+		}
+	case UsagePatternSpike:
+		period, spike := p.period.Seconds(), min(p.spikeFor, p.period).Seconds()
+		n := math.Floor(t / period)
+		spiking := n*spike + min(t-n*period, spike)
+		total = p.Peak*spiking + p.Base*(t-spiking)
+	case UsagePatternLeak:
+		total = p.Base*t + rise*t*t/(2*p.period.Seconds())
+	default:
+		total = p.Base * t
+	}
+	return max(total, 0)
+}
+
+// handleSyntheticStats sends the synthetic usage of a task every interval.
+func (d *Driver) handleSyntheticStats(ctx context.Context, h *taskHandle, interval time.Duration, ch chan<- *drivers.TaskResourceUsage) {
+	defer close(ch)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case now := <-timer.C:
+			select {
+			case ch <- syntheticUsage(h.stats, d.compute, now.Sub(h.startedAt), now):
+			case <-ctx.Done():
+				return
+			}
+			timer.Reset(interval)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/testtask"
+	"github.com/hashicorp/nomad/helper/uuid"
+	basePlug "github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsagePattern(t *testing.T) {
+	ci.Parallel(t)
+
+	parse := func(p *UsagePattern) *UsagePattern {
+		must.NoError(t, p.parse())
+		return p
+	}
+
+	constant := parse(&UsagePattern{Base: 100})
+	must.Eq(t, 100, constant.value(time.Hour))
+	must.Eq(t, 6000, constant.integral(time.Minute))
+
+	ramp := parse(&UsagePattern{Pattern: UsagePatternRamp, Base: 100, Peak: 300, Period: "10s"})
+	must.Eq(t, 100, ramp.value(0))
+	must.Eq(t, 200, ramp.value(5*time.Second))
+	must.Eq(t, 300, ramp.value(time.Minute))
+	must.Eq(t, 2000, ramp.integral(10*time.Second))
+	must.Eq(t, 5000, ramp.integral(20*time.Second))
+
+	spike := parse(&UsagePattern{Pattern: UsagePatternSpike, Base: 100, Peak: 900, Period: "10s", SpikeFor: "2s"})
+	must.Eq(t, 900, spike.value(21*time.Second))
+	must.Eq(t, 100, spike.value(25*time.Second))
+	must.Eq(t, 2*(900*2+100*8), spike.integral(20*time.Second))
+
+	leak := parse(&UsagePattern{Pattern: UsagePatternLeak, Base: 64, Peak: 128, Period: "1m"})
+	must.Eq(t, 64, leak.value(0))
+	must.Eq(t, 256, leak.value(3*time.Minute))
+
+	must.ErrorContains(t, (&UsagePattern{Pattern: UsagePatternRamp}).parse(), "requires a period")
+	must.ErrorContains(t, (&UsagePattern{Pattern: "sine"}).parse(), "unknown pattern")
+}
+
+func TestMockDriver_SyntheticStats(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	logger := testlog.HCLogger(t)
+	d := NewMockDriver(ctx, logger).(*Driver)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	var data []byte
+	must.NoError(t, basePlug.MsgPackEncode(&data, &Config{}))
+	must.NoError(t, harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+	d.compute = cpustats.Compute{TotalCompute: 8000, NumCores: 4}
+
+	task := &drivers.TaskConfig{
+		AllocID: uuid.Generate(),
+		ID:      uuid.Generate(),
+		Name:    "synthetic",
+		Env:     map[string]string{},
+	}
+	tc := &TaskConfig{
+		Command: Command{RunFor: "10s"},
+		Stats: &StatsConfig{
+			CPU:    &UsagePattern{Base: 1000},
+			Memory: &UsagePattern{Pattern: UsagePatternRamp, Base: 64, Peak: 128, Period: "1h"},
+		},
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	testtask.SetTaskConfigEnv(task)
+	cleanup := mkTestAllocDir(t, harness, logger, task)
+	t.Cleanup(cleanup)
+
+	_, _, err := harness.StartTask(task)
+	must.NoError(t, err)
+
+	ch, err := harness.TaskStats(ctx, task.ID, 10*time.Millisecond)
+	must.NoError(t, err)
+
+	// Samples keep coming at the interval
+	for i := 0; i < 3; i++ {
+		select {
+		case ru := <-ch:
+			must.Eq(t, 1000, ru.ResourceUsage.CpuStats.TotalTicks)
+			must.Eq(t, 50, ru.ResourceUsage.CpuStats.Percent)
+			must.Between(t, 64*1024*1024, ru.ResourceUsage.MemoryStats.RSS, 65*1024*1024)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for stats")
+		}
+	}
+}
@@ -606,7 +606,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 
 	qemuDriverState := TaskState{
-		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(executor.ReattachConfig(pluginClient)),
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
@@ -745,9 +745,10 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode:  ps.ExitCode,
-			Signal:    ps.Signal,
-			OOMKilled: ps.OOMKilled,
+			ExitCode:   ps.ExitCode,
+			Signal:     ps.Signal,
+			OOMKilled:  ps.OOMKilled,
+			ExitReason: ps.ExitReason,
 		}
 	}
 
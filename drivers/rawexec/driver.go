@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"time"
 
@@ -16,8 +17,11 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
 	"github.com/hashicorp/nomad/drivers/shared/validators"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
@@ -84,6 +88,20 @@ var (
 		),
 		"denied_host_uids": hclspec.NewAttr("denied_host_uids", "string", false),
 		"denied_host_gids": hclspec.NewAttr("denied_host_gids", "string", false),
+		"perf_event_stats": hclspec.NewDefault(
+			hclspec.NewAttr("perf_event_stats", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
+		"egress_class": hclspec.NewBlockList("egress_class", hclspec.NewObject(map[string]*hclspec.Spec{
+			"name":  hclspec.NewAttr("name", "string", true),
+			"cidrs": hclspec.NewAttr("cidrs", "list(string)", true),
+			"ports": hclspec.NewAttr("ports", "list(number)", false),
+		})),
+		"resctrl_class": hclspec.NewBlockList("resctrl_class", hclspec.NewObject(map[string]*hclspec.Spec{
+			"name":     hclspec.NewAttr("name", "string", true),
+			"schemata": hclspec.NewAttr("schemata", "list(string)", true),
+		})),
+		"provenance": hclspec.NewAttr("provenance", "string", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -95,6 +113,15 @@ var (
 		"cgroup_v1_override": hclspec.NewAttr("cgroup_v1_override", "list(map(string))", false),
 		"oom_score_adj":      hclspec.NewAttr("oom_score_adj", "number", false),
 		"work_dir":           hclspec.NewAttr("work_dir", "string", false),
+		"runtime_hints":      hclspec.NewAttr("runtime_hints", "bool", false),
+		"dns_servers":        hclspec.NewAttr("dns_servers", "list(string)", false),
+		"dns_search_domains": hclspec.NewAttr("dns_search_domains", "list(string)", false),
+		"dns_options":        hclspec.NewAttr("dns_options", "list(string)", false),
+		"resctrl_class":      hclspec.NewAttr("resctrl_class", "string", false),
+		"windows_logon": hclspec.NewBlock("windows_logon", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"password_file": hclspec.NewAttr("password_file", "string", true),
+			"logon_type":    hclspec.NewAttr("logon_type", "string", false),
+		})),
 	})
 
 	// capabilities is returned by the Capabilities RPC and indicates what
@@ -108,6 +135,11 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportNone,
+		AllocStats:   true,
+		Processes:    true,
+		Profile:      true,
+		SharedCgroup: true,
+		Stats:        executor.StatsCapabilities(),
 	}
 )
 
@@ -152,6 +184,24 @@ type Config struct {
 
 	DeniedHostUids string `codec:"denied_host_uids"`
 	DeniedHostGids string `codec:"denied_host_gids"`
+
+	// PerfEventStats enables collection of hardware performance counters
+	// (cycles, instructions, cache and branch misses) for each task.
+	PerfEventStats bool `codec:"perf_event_stats"`
+
+	// EgressClasses are the classes of destinations the traffic each task
+	// sends is accounted by, using an eBPF program attached to the task's
+	// cgroup.
+	EgressClasses []*egressstats.Class `codec:"egress_class"`
+
+	// Provenance is "binary" or "libraries" to record the digests of the
+	// binary each task executes, and of the shared libraries it links, in a
+	// task event when it starts.
+	Provenance string `codec:"provenance"`
+
+	// ResctrlClasses are the classes of L3 cache and memory bandwidth
+	// allocation tasks can be assigned to with resctrl_class.
+	ResctrlClasses []*resctrl.Class `codec:"resctrl_class"`
 }
 
 // TaskConfig is the driver configuration of a task within a job
@@ -176,6 +226,32 @@ type TaskConfig struct {
 
 	// WorkDir sets the working directory of the task
 	WorkDir string `codec:"work_dir"`
+
+	// RuntimeHints sets GOMAXPROCS and the JVM's active processor count from
+	// the CPUs the task can use
+	RuntimeHints bool `codec:"runtime_hints"`
+
+	// DNSServers, DNSSearchDomains and DNSOptions configure the resolv.conf
+	// the task sees. The group network's DNS configuration doesn't apply to
+	// raw_exec tasks, which share the resolv.conf of the host otherwise.
+	DNSServers       []string `codec:"dns_servers"`
+	DNSSearchDomains []string `codec:"dns_search_domains"`
+	DNSOptions       []string `codec:"dns_options"`
+
+	// ResctrlClass assigns the task to a resctrl class of the plugin
+	// configuration, which allocates it L3 cache and memory bandwidth
+	ResctrlClass string `codec:"resctrl_class"`
+
+	// WindowsLogon logs on as the task user with the password in a file of
+	// the task directory on Windows, usually rendered from Vault by a
+	// template, so the task runs with the user's credentials
+	WindowsLogon *WindowsLogon `codec:"windows_logon"`
+}
+
+// WindowsLogon is the windows_logon block of a task.
+type WindowsLogon struct {
+	PasswordFile string `codec:"password_file"`
+	LogonType    string `codec:"logon_type"`
 }
 
 func (t *TaskConfig) validate() error {
@@ -191,9 +267,29 @@ func (t *TaskConfig) validate() error {
 	if t.WorkDir != "" && !filepath.IsAbs(t.WorkDir) {
 		return errors.New("work_dir must be an absolute path")
 	}
+	if t.WindowsLogon != nil {
+		if !filepath.IsLocal(t.WindowsLogon.PasswordFile) {
+			return errors.New("windows_logon password_file must be a path in the task directory")
+		}
+		if err := t.windowsLogon("").Validate(); err != nil {
+			return fmt.Errorf("invalid windows_logon: %v", err)
+		}
+	}
 	return nil
 }
 
+// windowsLogon returns the windows logon of the executor for the task
+// directory taskDir.
+func (t *TaskConfig) windowsLogon(taskDir string) *executor.WindowsLogon {
+	if t.WindowsLogon == nil {
+		return nil
+	}
+	return &executor.WindowsLogon{
+		PasswordFile: filepath.Join(taskDir, t.WindowsLogon.PasswordFile),
+		LogonType:    t.WindowsLogon.LogonType,
+	}
+}
+
 // TaskState is the state which is encoded in the handle returned in
 // StartTask. This information is needed to rebuild the task state and handler
 // during recovery.
@@ -233,6 +329,16 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 		}
 	}
 
+	if err := egressstats.Validate(config.EgressClasses); err != nil {
+		return err
+	}
+	if err := resctrl.Validate(config.ResctrlClasses); err != nil {
+		return err
+	}
+	if err := executor.ValidateProvenance(config.Provenance); err != nil {
+		return err
+	}
+
 	if d.userIDValidator == nil {
 		idValidator, err := validators.NewValidator(d.logger, config.DeniedHostUids, config.DeniedHostGids)
 		if err != nil {
@@ -378,10 +484,27 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	var resctrlClass *resctrl.Class
+	if driverConfig.ResctrlClass != "" {
+		var err error
+		if resctrlClass, err = resctrl.Find(d.config.ResctrlClasses, driverConfig.ResctrlClass); err != nil {
+			return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+		}
+	}
+
 	if err := d.Validate(*cfg); err != nil {
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	if driverConfig.WindowsLogon != nil {
+		if runtime.GOOS != "windows" {
+			return nil, nil, errors.New("failed driver config validation: windows_logon is only supported on windows")
+		}
+		if cfg.User == "" {
+			return nil, nil, errors.New("failed driver config validation: windows_logon requires the task user to be set")
+		}
+	}
+
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -404,15 +527,24 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Args:             driverConfig.Args,
 		Env:              cfg.EnvList(),
 		User:             cfg.User,
+		WindowsLogon:     driverConfig.windowsLogon(cfg.TaskDir().Dir),
 		TaskDir:          cfg.TaskDir().Dir,
 		WorkDir:          driverConfig.WorkDir,
 		StdoutPath:       cfg.StdoutPath,
 		StderrPath:       cfg.StderrPath,
+		FramedLogs:       cfg.FramedLogs,
+		QoSClasses:       cfg.QoSClasses,
 		NetworkIsolation: cfg.NetworkIsolation,
 		Resources:        cfg.Resources.Copy(),
 		OverrideCgroupV2: driverConfig.OverrideCgroupV2,
 		OverrideCgroupV1: driverConfig.OverrideCgroupV1,
 		OOMScoreAdj:      int32(driverConfig.OOMScoreAdj),
+		PerfEventStats:   d.config.PerfEventStats,
+		RuntimeHints:     driverConfig.RuntimeHints,
+		EgressClasses:    d.config.EgressClasses,
+		ResctrlClass:     resctrlClass,
+		Provenance:       d.config.Provenance,
+		DNS:              resolvconf.TaskDNS(nil, driverConfig.DNSServers, driverConfig.DNSSearchDomains, driverConfig.DNSOptions),
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -421,6 +553,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
+	if ps.Provenance != nil {
+		d.eventer.EmitEvent(executor.ProvenanceEvent(cfg, ps.Provenance))
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          ps.Pid,
@@ -433,7 +569,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 
 	driverState := TaskState{
-		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(executor.ReattachConfig(pluginClient)),
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
@@ -473,9 +609,10 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode:  ps.ExitCode,
-			Signal:    ps.Signal,
-			OOMKilled: ps.OOMKilled,
+			ExitCode:   ps.ExitCode,
+			Signal:     ps.Signal,
+			OOMKilled:  ps.OOMKilled,
+			ExitReason: ps.ExitReason,
 		}
 	}
 
@@ -550,6 +687,33 @@ func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Dur
 	return handle.exec.Stats(ctx, interval)
 }
 
+// AllocStats returns a single stream of resource usage for the given tasks
+// of an allocation.
+func (d *Driver) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+	return drivers.MergeTaskStats(ctx, d, taskIDs, interval)
+}
+
+// TaskProcesses returns a snapshot of the processes running in the task.
+func (d *Driver) TaskProcesses(taskID string) ([]*drivers.TaskProcess, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Processes()
+}
+
+// ProfileTask samples the call stacks of the task's processes with perf for
+// the duration and returns them folded.
+func (d *Driver) ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Profile(ctx, duration)
+}
+
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.eventer.TaskEvents(ctx)
 }
@@ -583,11 +583,24 @@ func TestConfig_ParseAllHCL(t *testing.T) {
 config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
+  dns_servers = ["10.0.0.53"]
+  dns_options = ["ndots:2"]
+
+  windows_logon {
+    password_file = "secrets/password"
+    logon_type    = "service"
+  }
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+		Command:    "/bin/bash",
+		Args:       []string{"-c", "echo hello"},
+		DNSServers: []string{"10.0.0.53"},
+		DNSOptions: []string{"ndots:2"},
+		WindowsLogon: &WindowsLogon{
+			PasswordFile: "secrets/password",
+			LogonType:    "service",
+		},
 	}
 
 	var tc *TaskConfig
@@ -653,6 +666,26 @@ func TestRawExecDriver_validate(t *testing.T) {
 			},
 			exp: errors.New("work_dir must be an absolute path"),
 		},
+		{
+			name: "validates windows_logon password_file is in the task dir",
+			config: &TaskConfig{
+				WindowsLogon: &WindowsLogon{PasswordFile: "../password"},
+			},
+			exp: errors.New("windows_logon password_file must be a path in the task directory"),
+		},
+		{
+			name: "validates windows_logon logon_type",
+			config: &TaskConfig{
+				WindowsLogon: &WindowsLogon{PasswordFile: "secrets/password", LogonType: "remote"},
+			},
+			exp: errors.New(`invalid windows_logon: logon_type must be one of "batch", "service", "interactive", "network" or "network_cleartext": "remote"`),
+		},
+		{
+			name: "accepts windows_logon",
+			config: &TaskConfig{
+				WindowsLogon: &WindowsLogon{PasswordFile: "secrets/password"},
+			},
+		},
 	}
 
 	for _, i := range testCases {
@@ -371,6 +371,62 @@ func TestRawExec_ExecTaskStreaming(t *testing.T) {
 
 }
 
+func TestRawExec_TaskStatsConformance(t *testing.T) {
+	ci.Parallel(t)
+
+	// total ticks are derived from the compute of the node, which is set
+	// from the agent config in SetConfig
+	newDriver := func(t *testing.T) *Driver {
+		d := newEnabledRawExecDriver(t)
+		d.compute = topology.Compute()
+		return d
+	}
+
+	d := newDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	allocID := uuid.Generate()
+	taskName := "sleep"
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      taskName,
+		Env:       defaultEnv(),
+		Resources: testResources(allocID, taskName),
+	}
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	harness.MakeTaskCgroup(allocID, taskName)
+
+	tc := &TaskConfig{
+		Command: testtask.Path(),
+		Args:    []string{"sleep", "9000s"},
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+	testtask.SetTaskConfigEnv(task)
+
+	handle, _, err := harness.StartTask(task)
+	must.NoError(t, err)
+	defer d.DestroyTask(task.ID, true)
+
+	dtestutil.TaskStatsConformanceTests(t, harness, task.ID, &dtestutil.StatsConformanceOptions{
+		Interval: 100 * time.Millisecond,
+		Handle:   handle,
+		Recover: func(t *testing.T, handle *drivers.TaskHandle) *dtestutil.DriverHarness {
+			recovered := newDriver(t)
+			must.NoError(t, recovered.RecoverTask(handle))
+			t.Cleanup(func() { _ = recovered.DestroyTask(handle.Config.ID, true) })
+
+			h := dtestutil.NewDriverHarness(t, recovered)
+			t.Cleanup(h.Kill)
+			return h
+		},
+	})
+}
+
 func TestRawExec_ExecTaskStreaming_User(t *testing.T) {
 	t.Skip("todo(shoenig): this test has always been broken, now we skip instead of paving over it")
 	ci.Parallel(t)
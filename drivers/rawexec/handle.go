@@ -78,6 +78,7 @@ func (h *taskHandle) run() {
 	h.procState = drivers.TaskStateExited
 	h.exitResult.ExitCode = ps.ExitCode
 	h.exitResult.Signal = ps.Signal
+	h.exitResult.ExitReason = ps.ExitReason
 	h.completedAt = ps.Time
 
 	// TODO: detect if the task OOMed
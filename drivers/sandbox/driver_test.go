@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sandbox
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/testlog"
+	basePlug "github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestSandboxDriver_Fingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS == "darwin" {
+		t.Skip("sandbox driver is supported on macOS")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewSandboxDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateUndetected, fp.Health)
+	must.Eq(t, "sandbox driver is only supported on macOS", fp.HealthDescription)
+}
+
+func TestSandboxDriver_SetConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewSandboxDriver(ctx, testlog.HCLogger(t))
+
+	config := &Config{WritablePaths: []string{"/opt/cache"}}
+	var data []byte
+	must.NoError(t, basePlug.MsgPackEncode(&data, config))
+	must.NoError(t, d.SetConfig(&basePlug.Config{PluginConfig: data}))
+	must.Eq(t, config, d.(*Driver).config)
+
+	config.WritablePaths = []string{"cache"}
+	data = []byte{}
+	must.NoError(t, basePlug.MsgPackEncode(&data, config))
+	must.ErrorContains(t, d.SetConfig(&basePlug.Config{PluginConfig: data}), "must be absolute")
+
+	config = &Config{ReadablePaths: []string{"src"}}
+	data = []byte{}
+	must.NoError(t, basePlug.MsgPackEncode(&data, config))
+	must.ErrorContains(t, d.SetConfig(&basePlug.Config{PluginConfig: data}), "must be absolute")
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  command = "/bin/bash"
+  args = ["-c", "echo hello"]
+  work_dir = "/tmp"
+  readable_paths = ["/Users/dev/src"]
+  deny_network = true
+}`
+
+	expected := &TaskConfig{
+		Command:       "/bin/bash",
+		Args:          []string{"-c", "echo hello"},
+		WorkDir:       "/tmp",
+		ReadablePaths: []string{"/Users/dev/src"},
+		DenyNetwork:   true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+	must.Eq(t, expected, tc)
+
+	config := &Config{ReadablePaths: []string{"/Users/dev"}}
+	must.NoError(t, tc.validate(config))
+
+	tc.ReadablePaths = []string{"src"}
+	must.ErrorContains(t, tc.validate(config), "must be absolute")
+
+	// tasks may only read paths the operator allowed
+	for _, path := range []string{"/Users/other", "/Users/dev/../other", "/Users/developer"} {
+		tc.ReadablePaths = []string{path}
+		must.ErrorContains(t, tc.validate(config), "within the readable_paths of the plugin configuration")
+	}
+	tc.ReadablePaths = []string{"/Users/dev/src"}
+	must.ErrorContains(t, tc.validate(&Config{}), "within the readable_paths")
+}
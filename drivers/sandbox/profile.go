@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sandbox
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// sandboxExecPath is the path of sandbox-exec(1), which runs a command
+	// in a sandbox described by a profile written in the Sandbox Profile
+	// Language (SBPL)
+	sandboxExecPath = "/usr/bin/sandbox-exec"
+)
+
+// profileConfig describes what a task may access from inside its sandbox.
+type profileConfig struct {
+	// taskDir and allocDir are the task's directory and the allocation's
+	// shared directory, which the task may read and write
+	taskDir  string
+	allocDir string
+
+	// writablePaths are further paths the task may read and write
+	writablePaths []string
+
+	// readablePaths are paths under /Users the task may read
+	readablePaths []string
+
+	// dataDirs are the client's data directories, which the task may not
+	// read except for its own task and allocation directories
+	dataDirs []string
+
+	// denyNetwork denies all network access
+	denyNetwork bool
+}
+
+// buildProfile returns the sandbox profile for a task. The task may read
+// anything but the home directories under /Users and the client's data
+// directories, and write only to its task and allocation directories. In
+// SBPL the last rule matching an operation wins, so the allow rules for the
+// task's paths come after the rules denying access, and the readable paths
+// come before the data directories are denied so they can't expose them.
+func buildProfile(cfg profileConfig) string {
+	var b strings.Builder
+	b.WriteString("(version 1)\n")
+	b.WriteString("(allow default)\n")
+	b.WriteString("(deny file-write*)\n")
+	b.WriteString(`(deny file-read* (subpath "/Users"))` + "\n")
+	if len(cfg.readablePaths) > 0 {
+		fmt.Fprintf(&b, "(allow file-read*%s)\n", subpaths(cfg.readablePaths))
+	}
+	if len(cfg.dataDirs) > 0 {
+		fmt.Fprintf(&b, "(deny file-read*%s)\n", subpaths(cfg.dataDirs))
+	}
+
+	writable := append([]string{cfg.taskDir, cfg.allocDir}, cfg.writablePaths...)
+	fmt.Fprintf(&b, "(allow file-read* file-write*%s)\n", subpaths(writable))
+	b.WriteString(`(allow file-write* (literal "/dev/null") (literal "/dev/zero") (literal "/dev/tty") (regex #"^/dev/fd/"))` + "\n")
+
+	if cfg.denyNetwork {
+		b.WriteString("(deny network*)\n")
+	}
+	return b.String()
+}
+
+// subpaths returns the SBPL subpath filters matching the paths. The sandbox
+// matches the paths files are opened by after resolving symlinks, such as
+// /private/tmp for /tmp, so the paths are resolved too.
+func subpaths(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, " (subpath %s)", quote(resolvePath(path)))
+	}
+	return b.String()
+}
+
+// resolvePath returns the cleaned path with its symlinks resolved, if it
+// exists.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
+
+// quote returns s as an SBPL string literal.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
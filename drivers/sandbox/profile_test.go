@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestBuildProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	profile := buildProfile(profileConfig{
+		taskDir:       "/nomad/alloc/abc/web",
+		allocDir:      "/nomad/alloc/abc/alloc",
+		writablePaths: []string{"/opt/cache/"},
+		readablePaths: []string{`/Users/dev/my "src"`},
+		dataDirs:      []string{"/nomad", "/nomad/client"},
+		denyNetwork:   true,
+	})
+	must.Eq(t, `(version 1)
+(allow default)
+(deny file-write*)
+(deny file-read* (subpath "/Users"))
+(allow file-read* (subpath "/Users/dev/my \"src\""))
+(deny file-read* (subpath "/nomad") (subpath "/nomad/client"))
+(allow file-read* file-write* (subpath "/nomad/alloc/abc/web") (subpath "/nomad/alloc/abc/alloc") (subpath "/opt/cache"))
+(allow file-write* (literal "/dev/null") (literal "/dev/zero") (literal "/dev/tty") (regex #"^/dev/fd/"))
+(deny network*)
+`, profile)
+
+	profile = buildProfile(profileConfig{
+		taskDir:  "/nomad/alloc/abc/web",
+		allocDir: "/nomad/alloc/abc/alloc",
+	})
+	must.StrNotContains(t, profile, "network")
+	must.StrNotContains(t, profile, "(allow file-read* (subpath")
+	must.StrNotContains(t, profile, `(deny file-read* (subpath "/nomad`)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package executor
+
+import (
+	"os"
+	"os/exec"
+)
+
+// executorCommand returns the command to launch an executor with the binary
+// of the running agent.
+func executorCommand(args ...string) (*exec.Cmd, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(bin, args...), nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"os"
+	"os/exec"
+)
+
+// executorCommand returns the command to launch an executor with the binary
+// of the running agent. It is run through /proc/self/exe, which refers to
+// the image of the running agent even after its binary was replaced on disk,
+// such as by an in-place upgrade before the agent restarts. An executor thus
+// always runs the same Nomad version as the agent that launched it. The first
+// argument remains the path of the binary, so the executor is recognizable.
+func executorCommand(args ...string) (*exec.Cmd, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(bin, args...)
+	if _, err := os.Stat("/proc/self/exe"); err == nil {
+		cmd.Path = "/proc/self/exe"
+	}
+	return cmd, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+)
+
+const (
+	// CPUQuotaEnv is the environment variable with the CPU time the task may
+	// use every CPUPeriodEnv, in microseconds. Omitted if the task's CPU
+	// bandwidth is not limited.
+	CPUQuotaEnv = "NOMAD_CPU_QUOTA_US"
+
+	// CPUPeriodEnv is the environment variable with the period of the task's
+	// CPU quota, in microseconds.
+	CPUPeriodEnv = "NOMAD_CPU_PERIOD_US"
+
+	// MemoryMaxEnv is the environment variable with the task's hard memory
+	// limit, in bytes. Omitted if the task's memory is not limited.
+	MemoryMaxEnv = "NOMAD_MEMORY_MAX_BYTES"
+
+	// CpusetEnv is the environment variable with the CPUs the task may run on,
+	// in cpuset list notation.
+	CpusetEnv = "NOMAD_CPUSET"
+
+	// javaToolOptionsEnv is read by every JVM at startup, unlike JAVA_OPTS
+	// which is only a convention of launcher scripts.
+	javaToolOptionsEnv = "JAVA_TOOL_OPTIONS"
+)
+
+// cgroupLimits are the limits in effect on a task's cgroup, as read back
+// from cgroupfs after the executor configured the cgroup. Zero values mean
+// no limit, or that the limit could not be read.
+type cgroupLimits struct {
+	cpuQuota  int64
+	cpuPeriod int64
+	memoryMax int64
+	cpuset    string
+}
+
+// appendEnv returns env with the limits appended as environment variables,
+// so runtimes can size themselves without parsing cgroupfs. Variables
+// already set in env are left as they are.
+func (l cgroupLimits) appendEnv(env []string) []string {
+	set := func(key string) bool {
+		return slices.ContainsFunc(env, func(kv string) bool {
+			return strings.HasPrefix(kv, key+"=")
+		})
+	}
+
+	out := slices.Clone(env)
+	add := func(key, value string) {
+		if value != "" && !set(key) {
+			out = append(out, key+"="+value)
+		}
+	}
+	if l.cpuQuota > 0 && l.cpuPeriod > 0 {
+		add(CPUQuotaEnv, strconv.FormatInt(l.cpuQuota, 10))
+		add(CPUPeriodEnv, strconv.FormatInt(l.cpuPeriod, 10))
+	}
+	if l.memoryMax > 0 {
+		add(MemoryMaxEnv, strconv.FormatInt(l.memoryMax, 10))
+	}
+	add(CpusetEnv, l.cpuset)
+	return out
+}
+
+// processors returns how many CPUs the task can make use of at once: the
+// CPU quota rounded up to whole CPUs, bounded by the size of its cpuset.
+// Zero means neither is known.
+func (l cgroupLimits) processors() int {
+	var n int
+	if l.cpuset != "" {
+		n = idset.Parse[hw.CoreID](l.cpuset).Size()
+	}
+	if l.cpuQuota > 0 && l.cpuPeriod > 0 {
+		// Rounded up without adding to the quota, which may be as large as
+		// a malformed cpu.max makes it
+		quota := int(l.cpuQuota / l.cpuPeriod)
+		if l.cpuQuota%l.cpuPeriod != 0 {
+			quota++
+		}
+		if n == 0 || quota < n {
+			n = quota
+		}
+	}
+	return n
+}
+
+// appendHints returns env with hints sizing the Go and JVM runtimes to the
+// CPUs the task can use, rather than every CPU of the node. GOMAXPROCS is
+// left as it is if already set, and the JVM option is appended to any
+// existing JAVA_TOOL_OPTIONS unless those already set the processor count.
+func (l cgroupLimits) appendHints(env []string) []string {
+	n := l.processors()
+	if n == 0 {
+		return env
+	}
+
+	out := slices.Clone(env)
+	if !slices.ContainsFunc(out, func(kv string) bool { return strings.HasPrefix(kv, "GOMAXPROCS=") }) {
+		out = append(out, "GOMAXPROCS="+strconv.Itoa(n))
+	}
+
+	jvmOpt := fmt.Sprintf("-XX:ActiveProcessorCount=%d", n)
+	i := slices.IndexFunc(out, func(kv string) bool { return strings.HasPrefix(kv, javaToolOptionsEnv+"=") })
+	switch {
+	case i < 0:
+		out = append(out, javaToolOptionsEnv+"="+jvmOpt)
+	case !strings.Contains(out[i], "ActiveProcessorCount"):
+		opts := strings.TrimPrefix(out[i], javaToolOptionsEnv+"=")
+		out[i] = javaToolOptionsEnv + "=" + strings.TrimSpace(opts+" "+jvmOpt)
+	}
+	return out
+}
+
+// parseCPUMax parses the cgroups v2 cpu.max interface file, which holds the
+// quota and period in microseconds, with a quota of "max" when the CPU
+// bandwidth is unlimited.
+func parseCPUMax(s string) (quota, period int64) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, 0
+	}
+	quota, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	period, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	return quota, period
+}
+
+// parseMemoryMax parses a memory limit interface file. cgroups v2 uses "max"
+// for no limit, while cgroups v1 uses the largest page aligned value that
+// fits in an int64, so implausibly large values are also treated as no limit.
+func parseMemoryMax(s string) int64 {
+	if s == "max" {
+		return 0
+	}
+	limit, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || limit < 0 || limit >= 1<<62 {
+		return 0
+	}
+	return limit
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestCgroupLimits_appendEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	env := []string{"PATH=/bin", "NOMAD_CPUSET=0"}
+	limits := cgroupLimits{
+		cpuQuota:  50000,
+		cpuPeriod: 100000,
+		memoryMax: 256 * 1024 * 1024,
+		cpuset:    "0-3",
+	}
+	must.Eq(t, []string{
+		"PATH=/bin",
+		"NOMAD_CPUSET=0",
+		"NOMAD_CPU_QUOTA_US=50000",
+		"NOMAD_CPU_PERIOD_US=100000",
+		"NOMAD_MEMORY_MAX_BYTES=268435456",
+	}, limits.appendEnv(env))
+	must.Eq(t, []string{"PATH=/bin", "NOMAD_CPUSET=0"}, env)
+
+	// unlimited resources are omitted
+	must.Eq(t, []string{"PATH=/bin"}, cgroupLimits{cpuPeriod: 100000}.appendEnv([]string{"PATH=/bin"}))
+}
+
+func TestCgroupLimits_parse(t *testing.T) {
+	ci.Parallel(t)
+
+	quota, period := parseCPUMax("max 100000")
+	must.Zero(t, quota)
+	must.Zero(t, period)
+
+	quota, period = parseCPUMax("150000 100000")
+	must.Eq(t, 150000, quota)
+	must.Eq(t, 100000, period)
+
+	must.Zero(t, parseMemoryMax("max"))
+	must.Zero(t, parseMemoryMax("9223372036854771712"))
+	must.Zero(t, parseMemoryMax(""))
+	must.Eq(t, 268435456, parseMemoryMax("268435456"))
+}
+
+func TestCgroupLimits_appendHints(t *testing.T) {
+	ci.Parallel(t)
+
+	// the quota bounds the cpuset
+	limits := cgroupLimits{cpuQuota: 150000, cpuPeriod: 100000, cpuset: "0-7"}
+	must.Eq(t, 2, limits.processors())
+	must.Eq(t, []string{
+		"PATH=/bin",
+		"GOMAXPROCS=2",
+		"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=2",
+	}, limits.appendHints([]string{"PATH=/bin"}))
+
+	// existing settings are kept
+	limits = cgroupLimits{cpuset: "0-1,4"}
+	must.Eq(t, []string{
+		"GOMAXPROCS=8",
+		"JAVA_TOOL_OPTIONS=-Xmx1g -XX:ActiveProcessorCount=3",
+	}, limits.appendHints([]string{"GOMAXPROCS=8", "JAVA_TOOL_OPTIONS=-Xmx1g"}))
+
+	// without limits there is nothing to hint
+	must.Eq(t, []string{"PATH=/bin"}, cgroupLimits{}.appendHints([]string{"PATH=/bin"}))
+}
+
+// FuzzCgroupLimits asserts the limits read back from malformed cgroup
+// interface files, such as those of nested containers or unusual kernels,
+// can't panic the executor or make it hint a nonsensical processor count.
+func FuzzCgroupLimits(f *testing.F) {
+	f.Add("150000 100000", "268435456", "0-3")
+	f.Add("max 100000", "max", "")
+	f.Add("9223372036854775807 1", "9223372036854771712", "0-65535")
+	f.Add("-1 100000", "-1", "3,1-2,7 ")
+	f.Add("100000 0", "", "a-b,,")
+
+	f.Fuzz(func(t *testing.T, cpuMax, memoryMax, cpuset string) {
+		var limits cgroupLimits
+		limits.cpuQuota, limits.cpuPeriod = parseCPUMax(cpuMax)
+		limits.memoryMax = parseMemoryMax(memoryMax)
+		limits.cpuset = cpuset
+
+		must.NonNegative(t, limits.memoryMax)
+		must.NonNegative(t, limits.processors())
+		_ = limits.appendHints(limits.appendEnv(nil))
+	})
+}
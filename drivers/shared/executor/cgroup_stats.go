@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"strconv"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// parseIOStat parses the cgroups v2 io.stat interface file, which holds a
+// line of counters for each device the cgroup did I/O on, and returns their
+// sum over all devices.
+func parseIOStat(s string) *cstructs.DiskIOStats {
+	stats := new(cstructs.DiskIOStats)
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				stats.ReadBytes += n
+			case "wbytes":
+				stats.WriteBytes += n
+			case "rios":
+				stats.ReadOps += n
+			case "wios":
+				stats.WriteOps += n
+			}
+		}
+	}
+	return stats
+}
+
+// parsePressure parses a cgroups v2 pressure interface file, such as
+// cpu.pressure, and returns the avg60 of its "some" line, which is the
+// percentage of the last minute in which some of the cgroup's processes
+// stalled on the resource.
+func parsePressure(s string) (float64, bool) {
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			value, ok := strings.CutPrefix(field, "avg60=")
+			if !ok {
+				continue
+			}
+			avg, err := strconv.ParseFloat(value, 64)
+			return avg, err == nil
+		}
+	}
+	return 0, false
+}
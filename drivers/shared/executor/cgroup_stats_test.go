@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestCgroupStats_parseIOStat(t *testing.T) {
+	ci.Parallel(t)
+
+	stats := parseIOStat("8:0 rbytes=4096 wbytes=8192 rios=2 wios=3 dbytes=0 dios=0\n" +
+		"253:0 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n")
+	must.Eq(t, &cstructs.DiskIOStats{ReadBytes: 5120, WriteBytes: 8192, ReadOps: 3, WriteOps: 3}, stats)
+
+	// a cgroup that did no I/O has an empty io.stat
+	must.Eq(t, &cstructs.DiskIOStats{}, parseIOStat(""))
+}
+
+func TestCgroupStats_parsePressure(t *testing.T) {
+	ci.Parallel(t)
+
+	avg, ok := parsePressure("some avg10=1.50 avg60=2.25 avg300=0.75 total=123456\n" +
+		"full avg10=0.50 avg60=1.00 avg300=0.25 total=65432\n")
+	must.True(t, ok)
+	must.Eq(t, 2.25, avg)
+
+	_, ok = parsePressure("full avg10=0.50 avg60=1.00 avg300=0.25 total=65432\n")
+	must.False(t, ok)
+}
@@ -22,11 +22,20 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/corestats"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/client/lib/perfstats"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
+	"github.com/hashicorp/nomad/client/logmon/logframe"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
+	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/version"
 	"github.com/moby/sys/capability"
 )
 
@@ -48,7 +57,7 @@ const (
 var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
-	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
+	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent", "Total CPU Seconds"}
 )
 
 // Executor is the interface which allows a driver to launch and supervise
@@ -77,6 +86,9 @@ type Executor interface {
 	// Version returns the executor API version
 	Version() (*ExecutorVersion, error)
 
+	// Capabilities returns the optional features the executor supports
+	Capabilities() (*ExecutorCapabilities, error)
+
 	// Returns a channel of stats. Stats are collected and
 	// pushed to the channel on the given interval
 	Stats(context.Context, time.Duration) (<-chan *cstructs.TaskResourceUsage, error)
@@ -90,6 +102,15 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Processes returns a snapshot of the processes of the user process's
+	// tree
+	Processes() ([]*drivers.TaskProcess, error)
+
+	// Profile samples the call stacks of the user process's tree with perf
+	// for the duration and returns them in the folded format read by
+	// flamegraph tools
+	Profile(ctx context.Context, duration time.Duration) ([]byte, error)
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -116,12 +137,30 @@ type ExecCommand struct {
 	StderrPath string
 	stderr     io.WriteCloser
 
+	// FramedLogs frames the output written to StdoutPath and StderrPath with
+	// timestamps. It must only be set if the log collector reading them
+	// decodes framed output.
+	FramedLogs bool
+
+	// QoSClasses enforces the QoS class of the task's resources: its
+	// oom_score_adj and the memory protected from reclaim follow its class.
+	QoSClasses bool
+
+	// Provenance is ProvenanceBinary or ProvenanceLibraries to record the
+	// digests of the files the task is launched from in the ProcessState
+	// returned by Launch.
+	Provenance string
+
 	// Env is the list of KEY=val pairs of environment variables to be set
 	Env []string
 
 	// User is the user which the executor uses to run the command.
 	User string
 
+	// WindowsLogon logs on as User with a password on Windows, so the command
+	// runs with the user's credentials
+	WindowsLogon *WindowsLogon
+
 	// TaskDir is the directory path on the host where for the task
 	TaskDir string
 
@@ -171,6 +210,42 @@ type ExecCommand struct {
 	// OOMScoreAdj allows setting oom_score_adj (likelihood of process being
 	// OOM killed) on Linux systems
 	OOMScoreAdj int32
+
+	// PerfEventStats enables collection of hardware performance counters for
+	// the task cgroup (cgroups v2 only).
+	PerfEventStats bool
+
+	// RuntimeHints sets GOMAXPROCS and the JVM's active processor count from
+	// the CPUs the task cgroup can use.
+	RuntimeHints bool
+
+	// SELinuxLabel is the SELinux process label the task runs with, e.g.
+	// "system_u:system_r:container_t:s0".
+	SELinuxLabel string
+
+	// AppArmorProfile is the name of the AppArmor profile the task runs
+	// confined by, which must be loaded on the host.
+	AppArmorProfile string
+
+	// MaskedPaths are paths inside the task's mount namespace, in addition to
+	// the default ones, that are masked so the task can't read them.
+	MaskedPaths []string
+
+	// ReadonlyPaths are paths inside the task's mount namespace, in addition
+	// to the default ones, that are remounted read-only.
+	ReadonlyPaths []string
+
+	// DNS configures the resolv.conf the executor bind mounts into the task
+	// over /etc/resolv.conf. It is unset for tasks using the host's.
+	DNS *drivers.DNSConfig
+
+	// EgressClasses are the classes of destinations whose traffic is
+	// accounted in the task's stats (cgroups v2 only).
+	EgressClasses []*egressstats.Class
+
+	// ResctrlClass is the class of L3 cache and memory bandwidth allocation
+	// the task is assigned to, if any.
+	ResctrlClass *resctrl.Class
 }
 
 func (c *ExecCommand) getCgroupOr(controller, fallback string) string {
@@ -236,6 +311,16 @@ func (c *ExecCommand) StatsCgroup() string {
 	return c.getCgroupOr("pids", fallback)
 }
 
+// qosOOMScoreAdj returns the oom_score_adj of the task, which is the one
+// configured or else the one of the QoS class of its resources, if QoS classes
+// are enforced.
+func (c *ExecCommand) qosOOMScoreAdj() int32 {
+	if !c.QoSClasses || c.OOMScoreAdj != 0 || c.Resources == nil || c.Resources.NomadResources == nil {
+		return c.OOMScoreAdj
+	}
+	return structs.QoSOOMScoreAdj(c.Resources.NomadResources.QoSClass())
+}
+
 // SetWriters sets the writer for the process stdout and stderr. This should
 // not be used if writing to a file path such as a fifo file. SetStdoutWriter
 // is mainly used for unit testing purposes.
@@ -260,7 +345,7 @@ func (nopCloser) Close() error { return nil }
 func (c *ExecCommand) Stdout() (io.WriteCloser, error) {
 	if c.stdout == nil {
 		if c.StdoutPath != "" && c.StdoutPath != os.DevNull {
-			f, err := fifo.OpenWriter(c.StdoutPath)
+			f, err := c.openOutput(c.StdoutPath, logframe.StreamStdout)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create stdout: %v", err)
 			}
@@ -269,14 +354,14 @@ func (c *ExecCommand) Stdout() (io.WriteCloser, error) {
 			c.stdout = nopCloser{io.Discard}
 		}
 	}
-	return c.stdout, nil
+	return taskWriter(c.stdout), nil
 }
 
 // Stderr returns a writer for the configured file descriptor
 func (c *ExecCommand) Stderr() (io.WriteCloser, error) {
 	if c.stderr == nil {
 		if c.StderrPath != "" && c.StderrPath != os.DevNull {
-			f, err := fifo.OpenWriter(c.StderrPath)
+			f, err := c.openOutput(c.StderrPath, logframe.StreamStderr)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create stderr: %v", err)
 			}
@@ -285,7 +370,35 @@ func (c *ExecCommand) Stderr() (io.WriteCloser, error) {
 			c.stderr = nopCloser{io.Discard}
 		}
 	}
-	return c.stderr, nil
+	return taskWriter(c.stderr), nil
+}
+
+// openOutput opens the fifo at path for the output of stream, framing the
+// output if FramedLogs is set.
+func (c *ExecCommand) openOutput(path string, stream logframe.Stream) (io.WriteCloser, error) {
+	f, err := fifo.OpenWriter(path)
+	if err != nil {
+		return nil, err
+	}
+	if !c.FramedLogs {
+		return f, nil
+	}
+	p, err := newFramedPipe(f, stream)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// taskWriter returns the writer to give the task for its output.
+func taskWriter(w io.WriteCloser) io.WriteCloser {
+	// The task writes to the pipe directly, so its output isn't copied by
+	// the executor until it has been read from the pipe
+	if p, ok := w.(*framedPipe); ok {
+		return p.File
+	}
+	return w
 }
 
 func (c *ExecCommand) Close() {
@@ -303,7 +416,16 @@ type ProcessState struct {
 	ExitCode  int
 	Signal    int
 	OOMKilled bool
-	Time      time.Time
+
+	// ExitReason classifies the exit, as one of the structs.TaskExitReason
+	// constants
+	ExitReason string
+
+	Time time.Time
+
+	// Provenance records the files the task was launched from, if requested.
+	// It is only set by Launch.
+	Provenance *Provenance
 }
 
 // ExecutorVersion is the version of the executor
@@ -315,6 +437,29 @@ func (v *ExecutorVersion) GoString() string {
 	return v.Version
 }
 
+// ExecutorCapabilities are the optional features an executor supports. They
+// differ between the executors launched by different versions of Nomad, such
+// as those an agent reattaches to after an in-place upgrade.
+type ExecutorCapabilities struct {
+	// ProtocolVersion is the executor protocol version in use
+	ProtocolVersion int
+
+	// NomadVersion is the version of the Nomad binary the executor runs. It
+	// is empty for executors speaking ExecutorProtocolV2.
+	NomadVersion string
+
+	// Processes and Profile are whether the executor implements listing and
+	// profiling the processes of its task
+	Processes bool
+	Profile   bool
+
+	// PerfStats is whether the executor can collect hardware performance
+	// counters, and TotalCpuSeconds whether its CPU stats include the
+	// monotonic TotalCpuSeconds counter
+	PerfStats       bool
+	TotalCpuSeconds bool
+}
+
 // UniversalExecutor is an implementation of the Executor which launches and
 // supervises processes. In addition to process supervision it provides resource
 // and file system isolation
@@ -329,6 +474,25 @@ type UniversalExecutor struct {
 	userCpuStats   *cpustats.Tracker
 	systemCpuStats *cpustats.Tracker
 	processStats   procstats.ProcessStats
+	perfStats      *perfstats.Tracker
+	coreStats      *corestats.Tracker
+	egressStats    egressstats.Collector
+	resctrl        resctrl.Monitor
+
+	// cgroupPath and cgroupID identify the cgroup of the task processes,
+	// which doesn't change while the task runs
+	cgroupPath string
+	cgroupID   uint64
+
+	// compute is the compute capacity of the node, used to size the limits
+	// of the task on platforms without cgroups
+	compute cpustats.Compute
+
+	// limits are the resource limits enforced on the task at launch, if any
+	limits *cstructs.ResourceLimits
+
+	// statsSeq numbers the stats samples of the task
+	statsSeq statsSequence
 
 	logger hclog.Logger
 }
@@ -341,16 +505,37 @@ func NewExecutor(logger hclog.Logger, compute cpustats.Compute) Executor {
 		totalCpuStats:  cpustats.New(compute),
 		userCpuStats:   cpustats.New(compute),
 		systemCpuStats: cpustats.New(compute),
+		compute:        compute,
 	}
-	ue.processStats = procstats.New(compute, ue)
+	ue.totalCpuStats.SetLogger(ue.logger.Named("total_cpu"))
+	ue.userCpuStats.SetLogger(ue.logger.Named("user_cpu"))
+	ue.systemCpuStats.SetLogger(ue.logger.Named("system_cpu"))
+	ue.processStats = procstats.New(compute, ue, ue.logger)
 	return ue
 }
 
+// setStatsMode makes the executor read the processes of its task in the
+// given procstats mode.
+func (e *UniversalExecutor) setStatsMode(mode procstats.Mode) {
+	e.processStats = procstats.NewWithMode(e.compute, e, mode, e.logger)
+}
+
 // Version returns the api version of the executor
 func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 	return &ExecutorVersion{Version: ExecutorVersionLatest}, nil
 }
 
+func (e *UniversalExecutor) Capabilities() (*ExecutorCapabilities, error) {
+	return &ExecutorCapabilities{
+		ProtocolVersion: ExecutorProtocolLatest,
+		NomadVersion:    version.GetVersion().VersionNumber(),
+		Processes:       true,
+		Profile:         true,
+		PerfStats:       true,
+		TotalCpuSeconds: true,
+	}, nil
+}
+
 // Launch launches the main process and returns its state. It also
 // configures an applies isolation on certain platforms.
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
@@ -359,7 +544,14 @@ func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error)
 	e.command = command
 
 	// setting the user of the process
-	if command.User != "" {
+	if command.User != "" && command.WindowsLogon != nil {
+		e.logger.Debug("logging on as user", "user", command.User, "logon_type", command.WindowsLogon.LogonType)
+		closeToken, err := setCmdLogon(&e.childCmd, command.User, command.WindowsLogon)
+		if err != nil {
+			return nil, err
+		}
+		defer closeToken()
+	} else if command.User != "" {
 		e.logger.Debug("running command as user", "user", command.User)
 		if err := setCmdUser(&e.childCmd, command.User); err != nil {
 			return nil, err
@@ -415,13 +607,42 @@ func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error)
 
 	path := absPath
 
+	provenance, err := recordProvenance(command.Provenance, "/", absPath)
+	if err != nil {
+		e.logger.Warn("failed to record task provenance", "error", err)
+	}
+
 	// Set the commands arguments
 	e.childCmd.Path = path
 	e.childCmd.Args = append([]string{e.childCmd.Path}, command.Args...)
-	e.childCmd.Env = e.command.Env
+	limits := readCgroupLimits(command)
+	if rl := limits.resourceLimits(); rl != nil {
+		e.limits = rl
+	}
+	e.childCmd.Env = limits.appendEnv(e.command.Env)
+	if command.RuntimeHints {
+		e.childCmd.Env = limits.appendHints(e.childCmd.Env)
+	}
+
+	// Generate the resolv.conf of the task, which it gets in a mount
+	// namespace of its own
+	var resolvConf string
+	if command.DNS != nil {
+		dnsMount, err := resolvconf.GenerateDNSMount(command.TaskDir, command.DNS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build resolv.conf: %v", err)
+		}
+		resolvConf = dnsMount.HostPath
+	}
+
+	if err := assignResctrl(command); err != nil {
+		return nil, err
+	}
 
 	// Start the process
-	if err = withNetworkIsolation(e.childCmd.Start, command.NetworkIsolation); err != nil {
+	policy, nodes := command.memoryPolicy()
+	start := func() error { return withMemoryPolicy(policy, nodes, e.childCmd.Start) }
+	if err = withIsolation(start, command.NetworkIsolation, resolvConf); err != nil {
 		return nil, fmt.Errorf("failed to start command path=%q --- args=%q: %v", path, e.childCmd.Args, err)
 	}
 
@@ -430,9 +651,21 @@ func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error)
 		return nil, err
 	}
 
+	e.resctrl = resctrlMonitor(command)
+
+	e.perfStats = openPerfStats(e.logger, command)
+	e.cgroupPath, e.cgroupID = cgroupIdentity(command)
+	e.coreStats = openCoreStats(e.logger, command)
+	e.egressStats = openEgressStats(e.logger, command)
+
 	// Wait on the task process
 	go e.wait()
-	return &ProcessState{Pid: e.childCmd.Process.Pid, ExitCode: -1, Time: time.Now()}, nil
+	return &ProcessState{
+		Pid:        e.childCmd.Process.Pid,
+		ExitCode:   -1,
+		Time:       time.Now(),
+		Provenance: provenance,
+	}, nil
 }
 
 // Exec a command inside a container for exec and java drivers.
@@ -526,7 +759,13 @@ func (e *UniversalExecutor) ExecStreaming(ctx context.Context, command []string,
 			return nil
 		},
 		processStart: func() error {
-			if u := e.command.User; u != "" {
+			if u, logon := e.command.User, e.command.WindowsLogon; u != "" && logon != nil {
+				closeToken, err := setCmdLogon(cmd, u, logon)
+				if err != nil {
+					return err
+				}
+				defer closeToken()
+			} else if u != "" {
 				if err := setCmdUser(cmd, u); err != nil {
 					return err
 				}
@@ -562,13 +801,30 @@ func (e *UniversalExecutor) UpdateResources(resources *drivers.Resources) error
 	return nil
 }
 
+// Processes returns a snapshot of the processes of the user process's tree
+func (e *UniversalExecutor) Processes() ([]*drivers.TaskProcess, error) {
+	if e.childCmd.Process == nil {
+		return nil, fmt.Errorf("executor has not launched a process")
+	}
+	return procstats.Describe(e.ListProcesses()), nil
+}
+
+// Profile samples the call stacks of the user process's tree with perf for
+// the duration and returns them folded
+func (e *UniversalExecutor) Profile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	if e.childCmd.Process == nil {
+		return nil, fmt.Errorf("executor has not launched a process")
+	}
+	return profile(ctx, profileTargetOf(e.command, e), duration)
+}
+
 func (e *UniversalExecutor) wait() {
 	defer close(e.processExited)
 	defer e.command.Close()
 	pid := e.childCmd.Process.Pid
 	err := e.childCmd.Wait()
 	if err == nil {
-		e.exitState = &ProcessState{Pid: pid, ExitCode: 0, Time: time.Now()}
+		e.exitState = &ProcessState{Pid: pid, ExitCode: 0, ExitReason: structs.TaskExitReasonCompleted, Time: time.Now()}
 		return
 	}
 
@@ -592,9 +848,17 @@ func (e *UniversalExecutor) wait() {
 		}
 	} else {
 		e.logger.Warn("unexpected Cmd.Wait() error type", "error", err)
+		e.exitState = &ProcessState{Pid: pid, ExitCode: exitCode, ExitReason: structs.TaskExitReasonExecutorFailure, Time: time.Now()}
+		return
 	}
 
-	e.exitState = &ProcessState{Pid: pid, ExitCode: exitCode, Signal: signal, Time: time.Now()}
+	e.exitState = &ProcessState{
+		Pid:        pid,
+		ExitCode:   exitCode,
+		Signal:     signal,
+		ExitReason: drivers.ClassifyExit(exitCode, signal, false),
+		Time:       time.Now(),
+	}
 }
 
 var (
@@ -618,6 +882,13 @@ func (e *UniversalExecutor) Shutdown(signal string, grace time.Duration) error {
 		return nil
 	}
 
+	if e.perfStats != nil {
+		_ = e.perfStats.Close()
+	}
+	if e.egressStats != nil {
+		_ = e.egressStats.Close()
+	}
+
 	// If there is no process we can't shutdown
 	if e.childCmd.Process == nil {
 		e.logger.Warn("failed to shutdown due to missing process", "error", "no process found")
@@ -705,6 +976,16 @@ func (e *UniversalExecutor) Stats(ctx context.Context, interval time.Duration) (
 	return ch, nil
 }
 
+// resourceLimits returns the resource limits enforced on the task, if any. The
+// client resizes the cgroup of running tasks in place, so the limits in effect
+// on it are read for every sample rather than only at launch.
+func (e *UniversalExecutor) resourceLimits() *cstructs.ResourceLimits {
+	if rl := readCgroupLimits(e.command).resourceLimits(); rl != nil {
+		return rl
+	}
+	return e.limits
+}
+
 func (e *UniversalExecutor) handleStats(ch chan *cstructs.TaskResourceUsage, ctx context.Context, interval time.Duration) {
 	defer close(ch)
 	timer := time.NewTimer(0)
@@ -717,16 +998,129 @@ func (e *UniversalExecutor) handleStats(ch chan *cstructs.TaskResourceUsage, ctx
 			timer.Reset(interval)
 		}
 
+		collected := time.Now()
 		stats := e.processStats.StatProcesses()
+		usage := procstats.Aggregate(e.systemCpuStats, stats, e.processStats.ExitedCPUSeconds())
+		e.statsSeq.stamp(usage, collected)
+		usage.CgroupPath, usage.CgroupID = e.cgroupPath, e.cgroupID
+		usage.ExecutorPID = os.Getpid()
+		usage.Limits = e.resourceLimits()
+		if e.perfStats != nil {
+			usage.ResourceUsage.PerfStats = e.perfStats.Stats()
+		}
+		if e.coreStats != nil {
+			usage.ResourceUsage.CpuStats.Cores = e.coreStats.Stats()
+		}
+		usage.ResourceUsage.Egress = readEgressStats(e.logger, e.egressStats)
+		usage.ResourceUsage.Resctrl = readResctrlStats(e.logger, e.resctrl)
+		usage.ResourceUsage.DiskIO, usage.ResourceUsage.Pressure = readCgroupStats(e.command)
 
 		select {
 		case <-ctx.Done():
 			return
-		case ch <- procstats.Aggregate(e.systemCpuStats, stats):
+		case ch <- usage:
 		}
 	}
 }
 
+// openPerfStats starts collecting hardware performance counters for the task
+// cgroup if enabled by the task driver. Perf event stats are best effort, so
+// failures are logged rather than preventing the task from starting.
+func openPerfStats(logger hclog.Logger, command *ExecCommand) *perfstats.Tracker {
+	if !command.PerfEventStats {
+		return nil
+	}
+	c, err := perfstats.Open(command.StatsCgroup())
+	if err != nil {
+		logger.Warn("unable to collect perf event stats", "error", err)
+		return nil
+	}
+	return perfstats.NewTracker(c)
+}
+
+// openCoreStats starts breaking down the CPU usage of the task by core if the
+// task reserved cores, whose utilization would otherwise be hidden behind
+// the utilization of the task as a whole.
+func openCoreStats(logger hclog.Logger, command *ExecCommand) *corestats.Tracker {
+	if command.Resources == nil || command.Resources.NomadResources == nil {
+		return nil
+	}
+	reserved := command.Resources.NomadResources.Cpu.ReservedCores
+	if len(reserved) == 0 {
+		return nil
+	}
+	c, err := corestats.Open(command.StatsCgroup())
+	if err != nil {
+		logger.Warn("unable to collect per-core CPU stats", "error", err)
+		return nil
+	}
+	cores := make([]hw.CoreID, 0, len(reserved))
+	for _, core := range reserved {
+		cores = append(cores, hw.CoreID(core))
+	}
+	return corestats.NewTracker(c, cores)
+}
+
+// openEgressStats starts accounting the egress traffic of the task cgroup by
+// class if the task driver configured classes. Like perf event stats, egress
+// stats are best effort.
+func openEgressStats(logger hclog.Logger, command *ExecCommand) egressstats.Collector {
+	if len(command.EgressClasses) == 0 {
+		return nil
+	}
+	c, err := egressstats.Open(command.StatsCgroup(), command.EgressClasses)
+	if err != nil {
+		logger.Warn("unable to collect egress stats", "error", err)
+		return nil
+	}
+	return c
+}
+
+func readEgressStats(logger hclog.Logger, c egressstats.Collector) map[string]*cstructs.EgressStats {
+	if c == nil {
+		return nil
+	}
+	stats, err := c.Stats()
+	if err != nil {
+		logger.Debug("failed to read egress stats", "error", err)
+	}
+	return stats
+}
+
+// assignResctrl assigns the executor to the resctrl class of the task, if it
+// has one, before it starts the task so the task inherits the class from its
+// first instruction. Unlike the other stats, failing to do so fails the task
+// since the class isolates it from noisy neighbors.
+func assignResctrl(command *ExecCommand) error {
+	if command.ResctrlClass == nil {
+		return nil
+	}
+	if err := resctrl.Assign(command.ResctrlClass, os.Getpid()); err != nil {
+		return fmt.Errorf("failed to assign task to resctrl class %q: %w", command.ResctrlClass.Name, err)
+	}
+	return nil
+}
+
+// resctrlMonitor returns the monitor of the resctrl class of the task, if it
+// has one and the system supports monitoring.
+func resctrlMonitor(command *ExecCommand) resctrl.Monitor {
+	if command.ResctrlClass == nil {
+		return nil
+	}
+	return resctrl.NewMonitor(command.ResctrlClass)
+}
+
+func readResctrlStats(logger hclog.Logger, m resctrl.Monitor) *cstructs.ResctrlStats {
+	if m == nil {
+		return nil
+	}
+	stats, err := m.Stats()
+	if err != nil {
+		logger.Debug("failed to read resctrl stats", "error", err)
+	}
+	return stats
+}
+
 // usesCustomCgroup whether cgroup_v1_override or cgroup_v2_override is set
 func (e *UniversalExecutor) usesCustomCgroup() bool {
 	return len(e.command.OverrideCgroupV1) > 0 || e.command.OverrideCgroupV2 != ""
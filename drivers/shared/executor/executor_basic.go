@@ -6,11 +6,13 @@
 package executor
 
 import (
+	"errors"
 	"os/exec"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -35,6 +37,13 @@ func withNetworkIsolation(f func() error, _ *drivers.NetworkIsolationSpec) error
 	return f()
 }
 
+func withIsolation(f func() error, _ *drivers.NetworkIsolationSpec, resolvConf string) error {
+	if resolvConf != "" {
+		return errors.New("task DNS configuration is only supported on Linux")
+	}
+	return f()
+}
+
 func setCmdUser(*exec.Cmd, string) error { return nil }
 
 func (e *UniversalExecutor) ListProcesses() set.Collection[int] {
@@ -44,3 +53,25 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[int] {
 func (e *UniversalExecutor) setSubCmdCgroup(*exec.Cmd, string) (func(), error) {
 	return func() {}, nil
 }
+
+func cgroupIdentity(*ExecCommand) (string, uint64) {
+	return "", 0
+}
+
+func profileTargetOf(_ *ExecCommand, list procstats.ProcessList) profileTarget {
+	return profileTarget{pids: list.ListProcesses()}
+}
+
+func readCgroupLimits(*ExecCommand) cgroupLimits {
+	return cgroupLimits{}
+}
+
+func readCgroupStats(*ExecCommand) (*cstructs.DiskIOStats, *cstructs.PressureStats) {
+	return nil, nil
+}
+
+// StatsCapabilities returns the optional stats the executor reports for the
+// tasks it runs on this node, which has no cgroups to read them from.
+func StatsCapabilities() *drivers.StatsCapabilities {
+	return &drivers.StatsCapabilities{Devices: true}
+}
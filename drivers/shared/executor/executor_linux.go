@@ -15,6 +15,7 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -26,14 +27,21 @@ import (
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/corestats"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
+	"github.com/hashicorp/nomad/client/lib/perfstats"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
+	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/version"
 	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	runc "github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/devices"
@@ -41,6 +49,7 @@ import (
 	"github.com/opencontainers/runc/libcontainer/specconv"
 	lutils "github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/selinux/go-selinux"
 	"golang.org/x/sys/unix"
 )
 
@@ -59,7 +68,7 @@ var (
 	ExecutorCgroupV2MeasuredMemStats = []string{"Cache", "Swap", "Usage"}
 
 	// ExecutorCgroupMeasuredCpuStats is the list of CPU stats captures by the executor
-	ExecutorCgroupMeasuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent"}
+	ExecutorCgroupMeasuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent", "Total CPU Seconds"}
 )
 
 // LibcontainerExecutor implements an Executor with the runc/libcontainer api
@@ -74,12 +83,26 @@ type LibcontainerExecutor struct {
 	userCpuStats   *cpustats.Tracker
 	systemCpuStats *cpustats.Tracker
 	processStats   procstats.ProcessStats
+	perfStats      *perfstats.Tracker
+	coreStats      *corestats.Tracker
+	egressStats    egressstats.Collector
+	resctrl        resctrl.Monitor
+
+	// cgroupPath and cgroupID identify the cgroup of the task processes,
+	// which doesn't change while the task runs
+	cgroupPath string
+	cgroupID   uint64
 
 	container      libcontainer.Container
 	userProc       *libcontainer.Process
 	userProcExited chan interface{}
+	statsSeq       statsSequence
 	exitState      *ProcessState
 	sigChan        chan os.Signal
+
+	// hostShutdown is set once the executor is asked to stop by the host,
+	// which happens when the node shuts down
+	hostShutdown atomic.Bool
 }
 
 func (l *LibcontainerExecutor) catchSignals() {
@@ -91,6 +114,7 @@ func (l *LibcontainerExecutor) catchSignals() {
 	for {
 		signal := <-l.sigChan
 		if signal == syscall.SIGTERM || signal == syscall.SIGINT {
+			l.hostShutdown.Store(true)
 			l.Shutdown("SIGINT", 0)
 			break
 		}
@@ -116,10 +140,16 @@ func NewExecutorWithIsolation(logger hclog.Logger, compute cpustats.Compute) Exe
 
 	go le.catchSignals()
 
-	le.processStats = procstats.New(compute, le)
+	le.processStats = procstats.New(compute, le, le.logger)
 	return le
 }
 
+// setStatsMode makes the executor read the processes of its task in the
+// given procstats mode.
+func (l *LibcontainerExecutor) setStatsMode(mode procstats.Mode) {
+	l.processStats = procstats.NewWithMode(l.compute, l, mode, l.logger)
+}
+
 func (l *LibcontainerExecutor) ListProcesses() set.Collection[int] {
 	return procstats.List(l.command)
 }
@@ -176,6 +206,15 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, fmt.Errorf("failed to create factory: %v", err)
 	}
 
+	// The task sees the resolv.conf generated from its DNS configuration
+	if command.DNS != nil {
+		dnsMount, err := resolvconf.GenerateDNSMount(command.TaskDir, command.DNS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
+		}
+		command.Mounts = append(command.Mounts, dnsMount)
+	}
+
 	// A container groups processes under the same isolation enforcement
 	containerCfg, err := l.newLibcontainerConfig(command)
 	if err != nil {
@@ -198,6 +237,11 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
+	provenance, err := recordProvenance(command.Provenance, command.TaskDir, hostPath)
+	if err != nil {
+		l.logger.Warn("failed to record task provenance", "error", err)
+	}
+
 	combined := append([]string{taskPath}, command.Args...)
 	stdout, err := command.Stdout()
 	if err != nil {
@@ -210,10 +254,19 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 
 	l.logger.Debug("launching", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	// libcontainer only configures the task's cgroup once the container
+	// starts, after the environment of the task is set, so take the limits
+	// of the environment from the container's configuration
+	limits := containerLimits(containerCfg, command)
+	env := limits.appendEnv(command.Env)
+	if command.RuntimeHints {
+		env = limits.appendHints(env)
+	}
+
 	// the task process will be started by the container
 	process := &libcontainer.Process{
 		Args:   combined,
-		Env:    command.Env,
+		Env:    env,
 		Cwd:    command.WorkDir,
 		Stdout: stdout,
 		Stderr: stderr,
@@ -229,9 +282,14 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.totalCpuStats = cpustats.New(l.compute)
 	l.userCpuStats = cpustats.New(l.compute)
 	l.systemCpuStats = cpustats.New(l.compute)
-
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	l.totalCpuStats.SetLogger(l.logger.Named("total_cpu"))
+	l.userCpuStats.SetLogger(l.logger.Named("user_cpu"))
+	l.systemCpuStats.SetLogger(l.logger.Named("system_cpu"))
+
+	// Starts the task, which inherits the memory policy of the thread that
+	// starts the container
+	policy, nodes := command.memoryPolicy()
+	if err := withMemoryPolicy(policy, nodes, func() error { return container.Run(process) }); err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -242,15 +300,22 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
+	l.perfStats = openPerfStats(l.logger, command)
+	l.cgroupPath, l.cgroupID = cgroupIdentity(command)
+	l.coreStats = openCoreStats(l.logger, command)
+	l.egressStats = openEgressStats(l.logger, command)
+	l.resctrl = resctrlMonitor(command)
+
 	// start a goroutine to wait on the process to complete, so Wait calls can
 	// be multiplexed
 	l.userProcExited = make(chan interface{})
 	go l.wait()
 
 	return &ProcessState{
-		Pid:      pid,
-		ExitCode: -1,
-		Time:     time.Now(),
+		Pid:        pid,
+		ExitCode:   -1,
+		Time:       time.Now(),
+		Provenance: provenance,
 	}, nil
 }
 
@@ -292,7 +357,7 @@ func (l *LibcontainerExecutor) wait() {
 			ps = exitErr.ProcessState
 		} else {
 			l.logger.Error("failed to call wait on user process", "error", err)
-			l.exitState = &ProcessState{Pid: 0, ExitCode: 1, Time: time.Now()}
+			l.exitState = &ProcessState{Pid: 0, ExitCode: 1, ExitReason: structs.TaskExitReasonExecutorFailure, Time: time.Now()}
 			return
 		}
 	}
@@ -310,12 +375,18 @@ func (l *LibcontainerExecutor) wait() {
 		}
 	}
 
+	exitReason := drivers.ClassifyExit(exitCode, signal, oomKilled.Load())
+	if exitReason == structs.TaskExitReasonSignaled && l.hostShutdown.Load() {
+		exitReason = structs.TaskExitReasonNodeShutdown
+	}
+
 	l.exitState = &ProcessState{
-		Pid:       ps.Pid(),
-		ExitCode:  exitCode,
-		Signal:    signal,
-		OOMKilled: oomKilled.Load(),
-		Time:      time.Now(),
+		Pid:        ps.Pid(),
+		ExitCode:   exitCode,
+		Signal:     signal,
+		OOMKilled:  oomKilled.Load(),
+		ExitReason: exitReason,
+		Time:       time.Now(),
 	}
 }
 
@@ -333,6 +404,13 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 
 	defer l.container.Destroy()
 
+	if l.perfStats != nil {
+		_ = l.perfStats.Close()
+	}
+	if l.egressStats != nil {
+		_ = l.egressStats.Close()
+	}
+
 	if status == libcontainer.Stopped {
 		return nil
 	}
@@ -390,6 +468,34 @@ func (l *LibcontainerExecutor) Version() (*ExecutorVersion, error) {
 	return &ExecutorVersion{Version: ExecutorVersionLatest}, nil
 }
 
+func (l *LibcontainerExecutor) Capabilities() (*ExecutorCapabilities, error) {
+	return &ExecutorCapabilities{
+		ProtocolVersion: ExecutorProtocolLatest,
+		NomadVersion:    version.GetVersion().VersionNumber(),
+		Processes:       true,
+		Profile:         true,
+		PerfStats:       true,
+		TotalCpuSeconds: true,
+	}, nil
+}
+
+// Processes returns a snapshot of the processes running in the container
+func (l *LibcontainerExecutor) Processes() ([]*drivers.TaskProcess, error) {
+	if l.command == nil {
+		return nil, fmt.Errorf("executor has not launched a process")
+	}
+	return procstats.Describe(l.ListProcesses()), nil
+}
+
+// Profile samples the call stacks of the processes running in the container
+// with perf for the duration and returns them folded
+func (l *LibcontainerExecutor) Profile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	if l.command == nil {
+		return nil, fmt.Errorf("executor has not launched a process")
+	}
+	return profile(ctx, profileTargetOf(l.command, l), duration)
+}
+
 // Stats returns the resource statistics for processes managed by the executor
 func (l *LibcontainerExecutor) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	ch := make(chan *cstructs.TaskResourceUsage)
@@ -464,16 +570,36 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			ThrottledPeriods: stats.CpuStats.ThrottlingData.ThrottledPeriods,
 			ThrottledTime:    stats.CpuStats.ThrottlingData.ThrottledTime,
 			TotalTicks:       l.systemCpuStats.TicksConsumed(totalPercent),
+			TotalCpuSeconds:  totalProcessCPUUsage / float64(time.Second),
 			Measured:         ExecutorCgroupMeasuredCpuStats,
 		}
+		if l.coreStats != nil {
+			cs.Cores = l.coreStats.Stats()
+		}
+		var perf *cstructs.PerfStats
+		if l.perfStats != nil {
+			perf = l.perfStats.Stats()
+		}
+
+		diskIO, pressure := readCgroupStats(l.command)
+
 		taskResUsage := cstructs.TaskResourceUsage{
 			ResourceUsage: &cstructs.ResourceUsage{
 				MemoryStats: ms,
 				CpuStats:    cs,
+				PerfStats:   perf,
+				Egress:      readEgressStats(l.logger, l.egressStats),
+				Resctrl:     readResctrlStats(l.logger, l.resctrl),
+				DiskIO:      diskIO,
+				Pressure:    pressure,
 			},
-			Timestamp: ts.UTC().UnixNano(),
-			Pids:      pstats,
+			Timestamp:   ts.UTC().UnixNano(),
+			Pids:        pstats,
+			ExecutorPID: os.Getpid(),
+			Limits:      readCgroupLimits(l.command).resourceLimits(),
 		}
+		taskResUsage.CgroupPath, taskResUsage.CgroupID = l.cgroupPath, l.cgroupID
+		l.statsSeq.stamp(&taskResUsage, ts)
 
 		select {
 		case <-ctx.Done():
@@ -622,6 +748,36 @@ func configureCapabilities(cfg *runc.Config, command *ExecCommand) {
 	}
 }
 
+// DefaultMaskedPaths are the procfs and sysfs paths masked in the mount
+// namespace of every task, which expose kernel memory, keys, and hardware
+// details or let tasks infer what other tasks are doing. They match the
+// defaults of Docker.
+var DefaultMaskedPaths = []string{
+	"/proc/acpi",
+	"/proc/asound",
+	"/proc/interrupts",
+	"/proc/kcore",
+	"/proc/keys",
+	"/proc/latency_stats",
+	"/proc/sched_debug",
+	"/proc/scsi",
+	"/proc/timer_list",
+	"/proc/timer_stats",
+	"/sys/devices/virtual/powercap",
+	"/sys/firmware",
+}
+
+// DefaultReadonlyPaths are the procfs paths remounted read-only in the mount
+// namespace of every task, through which tasks could otherwise reconfigure
+// the kernel of the host.
+var DefaultReadonlyPaths = []string{
+	"/proc/bus",
+	"/proc/fs",
+	"/proc/irq",
+	"/proc/sys",
+	"/proc/sysrq-trigger",
+}
+
 func configureNamespaces(pidMode, ipcMode string) runc.Namespaces {
 	namespaces := runc.Namespaces{{Type: runc.NEWNS}}
 	if pidMode == IsolationModePrivate {
@@ -660,15 +816,10 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 	}
 
 	// paths to mask using a bind mount to /dev/null to prevent reading
-	cfg.MaskPaths = []string{
-		"/proc/kcore",
-		"/sys/firmware",
-	}
+	cfg.MaskPaths = append(slices.Clone(DefaultMaskedPaths), command.MaskedPaths...)
 
 	// paths that should be remounted as readonly inside the container
-	cfg.ReadonlyPaths = []string{
-		"/proc/sys", "/proc/sysrq-trigger", "/proc/irq", "/proc/bus",
-	}
+	cfg.ReadonlyPaths = append(slices.Clone(DefaultReadonlyPaths), command.ReadonlyPaths...)
 
 	cfg.Devices = specconv.AllowedDevices
 	if len(command.Devices) > 0 {
@@ -732,6 +883,27 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 	return nil
 }
 
+// configureSecurityLabels sets the SELinux label and AppArmor profile the
+// task process runs with, which libcontainer applies when it execs the task.
+// Asking for either on a host without the security module enabled is an error
+// rather than silently running the task unconfined.
+func configureSecurityLabels(cfg *runc.Config, command *ExecCommand) error {
+	if command.SELinuxLabel != "" {
+		if !selinux.GetEnabled() {
+			return errors.New("selinux_label is set but SELinux is not enabled on the client")
+		}
+		cfg.ProcessLabel = command.SELinuxLabel
+	}
+
+	if command.AppArmorProfile != "" {
+		if !apparmor.IsEnabled() {
+			return errors.New("apparmor_profile is set but AppArmor is not enabled on the client")
+		}
+		cfg.AppArmorProfile = command.AppArmorProfile
+	}
+	return nil
+}
+
 func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecCommand) error {
 	// note: an alloc TR hook pre-creates the cgroup(s) in both v1 and v2
 
@@ -748,8 +920,11 @@ func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecC
 	// TODO: this can be cg1 only, right?
 	// l.configureCgroupHook(cfg, command)
 
-	// set the libcontainer memory limits
-	l.configureCgroupMemory(cfg, command)
+	// set the libcontainer memory limits, unless the node only accounts for
+	// memory, in which case libcontainer would fail to set them
+	if cgroupslib.Enforces("memory") {
+		l.configureCgroupMemory(cfg, command)
+	}
 
 	// set cgroup v1/v2 specific attributes (cpu, path)
 	switch cgroupslib.GetMode() {
@@ -760,6 +935,26 @@ func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecC
 	}
 }
 
+// containerLimits returns the limits libcontainer applies to the task's
+// cgroup as the container starts.
+func containerLimits(cfg *runc.Config, command *ExecCommand) cgroupLimits {
+	res := cfg.Cgroups.Resources
+	limits := cgroupLimits{
+		cpuQuota:  res.CpuQuota,
+		cpuPeriod: int64(res.CpuPeriod),
+		cpuset:    res.CpusetCpus,
+	}
+	if command.ResourceLimits {
+		limits.memoryMax = res.Memory
+	}
+	// the cpuset of cgroups v1 is written by the executor rather than by
+	// libcontainer, so it is already in effect
+	if limits.cpuset == "" && cgroupslib.GetMode() == cgroupslib.CG1 {
+		limits.cpuset = readCgroupLimits(command).cpuset
+	}
+	return limits
+}
+
 func (*LibcontainerExecutor) configureCgroupHook(cfg *runc.Config, command *ExecCommand) {
 	cfg.Hooks = runc.Hooks{
 		runc.CreateRuntime: runc.HookList{
@@ -772,7 +967,11 @@ func (l *LibcontainerExecutor) configureCgroupMemory(cfg *runc.Config, command *
 	// Total amount of memory allowed to consume
 	res := command.Resources.NomadResources
 	memHard, memSoft := res.Memory.MemoryMaxMB, res.Memory.MemoryMB
-	if memHard <= 0 {
+	switch {
+	case memHard == 0 && command.QoSClasses:
+		// guaranteed tasks have their memory protected from reclaim
+		memHard = res.Memory.MemoryMB
+	case memHard <= 0:
 		memHard = res.Memory.MemoryMB
 		memSoft = 0
 	}
@@ -795,16 +994,29 @@ func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecComma
 	cfg.Cgroups.Path = filepath.Join("/", cgroupslib.NomadCgroupParent, scope)
 
 	// set cpu resources
-	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
-
-	// we need to manually set the cpuset, because libcontainer will not set
-	// it for our special cpuset cgroup
-	if err := l.cpusetCG1(cpusetPath, cpuCores); err != nil {
-		return fmt.Errorf("failed to set cpuset: %w", err)
+	if cgroupslib.Enforces("cpu") {
+		cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 	}
 
-	// tell libcontainer to write the pid to our special cpuset cgroup
-	l.configureCgroupHook(cfg, command)
+	if cgroupslib.Enforces("cpuset") {
+		// we need to manually set the cpuset, because libcontainer will not set
+		// it for our special cpuset cgroup
+		if err := l.cpusetCG1(cpusetPath, cpuCores); err != nil {
+			return fmt.Errorf("failed to set cpuset: %w", err)
+		}
+
+		// bind the memory of the task to its numa nodes in the cgroup too, as
+		// on cgroups v2
+		if policy, nodes := command.memoryPolicy(); policy == structs.BindMemoryPolicy {
+			ed := cgroupslib.OpenPath(cpusetPath)
+			if err := ed.Write("cpuset.mems", nodes); err != nil {
+				return fmt.Errorf("failed to set cpuset mems: %w", err)
+			}
+		}
+
+		// tell libcontainer to write the pid to our special cpuset cgroup
+		l.configureCgroupHook(cfg, command)
+	}
 
 	return nil
 }
@@ -821,18 +1033,33 @@ func (l *LibcontainerExecutor) configureCG2(cfg *runc.Config, command *ExecComma
 	cpuShares := l.clampCpuShares(command.Resources.LinuxResources.CPUShares)
 	cpuCores := command.Resources.LinuxResources.CpusetCpus
 
-	// Set the v2 specific unified path
-	cfg.Cgroups.Resources.CpusetCpus = cpuCores
+	if cgroupslib.Enforces("cpuset") {
+		// Set the v2 specific unified path
+		cfg.Cgroups.Resources.CpusetCpus = cpuCores
+
+		// bind the memory of the task to its numa nodes in the cgroup too, so
+		// processes it starts with another memory policy are bound all the same
+		if policy, nodes := command.memoryPolicy(); policy == structs.BindMemoryPolicy {
+			cfg.Cgroups.Resources.CpusetMems = nodes
+		}
+	}
 	partition := cgroupslib.GetPartitionFromCores(cpuCores)
 
 	// sets cpu.weight, which the kernel also translates to cpu.weight.nice
 	// despite what the libcontainer docs say, this sets priority not bandwidth
-	cpuWeight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
-	cfg.Cgroups.Resources.CpuWeight = cpuWeight
+	if cgroupslib.Enforces("cpu") {
+		cpuWeight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+		cfg.Cgroups.Resources.CpuWeight = cpuWeight
+	}
 
-	// finally set the path of the cgroup in which to run the task
+	// finally set the path of the cgroup in which to run the task, which is
+	// under the alloc-level cgroup of allocs with shared resources
 	scope := filepath.Base(cg)
-	cfg.Cgroups.Path = filepath.Join("/", cgroupslib.NomadCgroupParent, partition, scope)
+	parent := filepath.Join("/", cgroupslib.NomadCgroupParent, partition)
+	if slice := filepath.Base(filepath.Dir(cg)); slice != partition && strings.HasSuffix(slice, ".slice") {
+		parent = filepath.Join(parent, slice)
+	}
+	cfg.Cgroups.Path = filepath.Join(parent, scope)
 
 	// todo(shoenig): we will also want to set cpu bandwidth (i.e. cpu_hard_limit)
 	// hopefully for 1.7
@@ -852,21 +1079,87 @@ func (l *LibcontainerExecutor) newLibcontainerConfig(command *ExecCommand) (*run
 
 	configureCapabilities(cfg, command)
 
-	// children should not inherit Nomad agent oom_score_adj value
-	oomScoreAdj := 0
+	// children should not inherit Nomad agent oom_score_adj value, but the
+	// one of their QoS class
+	oomScoreAdj := int(command.qosOOMScoreAdj())
 	cfg.OomScoreAdj = &oomScoreAdj
 
 	if err := configureIsolation(cfg, command); err != nil {
 		return nil, err
 	}
 
+	if err := configureSecurityLabels(cfg, command); err != nil {
+		return nil, err
+	}
+
+	configureResctrl(cfg, command)
+
 	if err := l.configureCgroups(cfg, command); err != nil {
 		return nil, err
 	}
 
+	if os.Geteuid() != 0 {
+		configureRootless(cfg)
+	}
+
 	return cfg, nil
 }
 
+// configureResctrl assigns the container to the control group of the resctrl
+// class of the task, if it has one, which libcontainer creates and configures
+// before the task starts. The group is named by the class so its tasks share
+// it, and libcontainer leaves it in place when the container is destroyed.
+func configureResctrl(cfg *runc.Config, command *ExecCommand) {
+	class := command.ResctrlClass
+	if class == nil {
+		return
+	}
+	l3, mb := class.Schema()
+	cfg.IntelRdt = &runc.IntelRdt{
+		ClosID:        class.GroupName(),
+		L3CacheSchema: l3,
+		MemBwSchema:   mb,
+	}
+}
+
+// configureRootless adapts the container for an executor running as an
+// unprivileged user, such as under a rootless client. The container gets a
+// user namespace in which the user of the executor is root, and only uses the
+// cgroups delegated to that user.
+func configureRootless(cfg *runc.Config) {
+	cfg.RootlessEUID = true
+	cfg.RootlessCgroups = true
+
+	cfg.Namespaces = append(cfg.Namespaces, runc.Namespace{Type: runc.NEWUSER})
+	cfg.UidMappings = []runc.IDMap{{ContainerID: 0, HostID: int64(os.Geteuid()), Size: 1}}
+	cfg.GidMappings = []runc.IDMap{{ContainerID: 0, HostID: int64(os.Getegid()), Size: 1}}
+
+	// lowering the OOM score adjustment the task inherits is privileged
+	cfg.OomScoreAdj = nil
+
+	// sysfs and mqueue can only be mounted by the owner of the network and
+	// IPC namespaces, which the container may share with the host, and the
+	// only group of devpts is the one mapped into the container
+	mounts := make([]*runc.Mount, 0, len(cfg.Mounts))
+	for _, m := range cfg.Mounts {
+		switch m.Destination {
+		case "/sys":
+			m = &runc.Mount{
+				Source:      "/sys",
+				Destination: "/sys",
+				Device:      "bind",
+				Flags:       syscall.MS_BIND | syscall.MS_REC | syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NOEXEC | syscall.MS_NODEV,
+			}
+		case "/dev/mqueue":
+			continue
+		case "/dev/pts":
+			m.Data = strings.ReplaceAll(m.Data, ",gid=5", "")
+		}
+		mounts = append(mounts, m)
+	}
+	cfg.Mounts = mounts
+}
+
 func (l *LibcontainerExecutor) clampCpuShares(shares int64) int64 {
 	if shares < MinCPUShares {
 		l.logger.Warn(
@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
 	tu "github.com/hashicorp/nomad/testutil"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
 	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -139,6 +140,66 @@ func TestExecutor_configureNamespaces(t *testing.T) {
 	})
 }
 
+func TestExecutor_configureRootless(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &lconfigs.Config{
+		Namespaces: configureNamespaces("private", "host"),
+		Mounts: []*lconfigs.Mount{
+			{Source: "devpts", Destination: "/dev/pts", Device: "devpts", Data: "newinstance,ptmxmode=0666,mode=0620,gid=5"},
+			{Source: "mqueue", Destination: "/dev/mqueue", Device: "mqueue"},
+			{Source: "sysfs", Destination: "/sys", Device: "sysfs"},
+		},
+	}
+	configureRootless(cfg)
+
+	require.True(t, cfg.RootlessEUID)
+	require.True(t, cfg.RootlessCgroups)
+	require.True(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+	require.Equal(t, []lconfigs.IDMap{{ContainerID: 0, HostID: int64(os.Geteuid()), Size: 1}}, cfg.UidMappings)
+	require.Nil(t, cfg.OomScoreAdj)
+
+	require.Len(t, cfg.Mounts, 2)
+	require.Equal(t, "newinstance,ptmxmode=0666,mode=0620", cfg.Mounts[0].Data)
+	require.Equal(t, "bind", cfg.Mounts[1].Device)
+	require.Equal(t, "/sys", cfg.Mounts[1].Source)
+}
+
+func TestExecutor_configureSecurityLabels(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureSecurityLabels(cfg, &ExecCommand{}))
+	require.Empty(t, cfg.ProcessLabel)
+	require.Empty(t, cfg.AppArmorProfile)
+
+	err := configureSecurityLabels(cfg, &ExecCommand{AppArmorProfile: "nomad-task"})
+	if apparmor.IsEnabled() {
+		require.NoError(t, err)
+		require.Equal(t, "nomad-task", cfg.AppArmorProfile)
+	} else {
+		require.ErrorContains(t, err, "AppArmor is not enabled")
+	}
+}
+
+func TestExecutor_configureIsolation_maskedPaths(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+	require.NoError(t, configureIsolation(cfg, &ExecCommand{
+		MaskedPaths:   []string{"/proc/meminfo"},
+		ReadonlyPaths: []string{"/sys/kernel"},
+	}))
+
+	require.Contains(t, cfg.MaskPaths, "/proc/kcore")
+	require.Contains(t, cfg.MaskPaths, "/proc/meminfo")
+	require.Contains(t, cfg.ReadonlyPaths, "/proc/sys")
+	require.Contains(t, cfg.ReadonlyPaths, "/sys/kernel")
+
+	// the defaults are not modified
+	require.NotContains(t, DefaultMaskedPaths, "/proc/meminfo")
+}
+
 func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
@@ -1034,3 +1095,125 @@ func TestCgroupDeviceRules(t *testing.T) {
 		Allow:       true,
 	})
 }
+
+func TestContainerLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &lconfigs.Config{
+		Cgroups: &lconfigs.Cgroup{
+			Resources: &lconfigs.Resources{
+				Memory:     256 * 1024 * 1024,
+				CpuQuota:   150_000,
+				CpuPeriod:  100_000,
+				CpusetCpus: "2-3",
+			},
+		},
+	}
+
+	// the memory limit is only enforced with resource limits
+	limits := containerLimits(cfg, &ExecCommand{})
+	must.Eq(t, cgroupLimits{cpuQuota: 150_000, cpuPeriod: 100_000, cpuset: "2-3"}, limits)
+
+	limits = containerLimits(cfg, &ExecCommand{ResourceLimits: true})
+	must.Eq(t, 256*1024*1024, limits.memoryMax)
+	must.Eq(t, []string{
+		CPUQuotaEnv + "=150000",
+		CPUPeriodEnv + "=100000",
+		MemoryMaxEnv + "=268435456",
+		CpusetEnv + "=2-3",
+	}, limits.appendEnv(nil))
+}
+
+func TestRuntimeHints_drivers(t *testing.T) {
+	ci.Parallel(t)
+
+	// exec and java tasks run in libcontainer, whose limits are taken from
+	// the container configuration, while raw_exec tasks run in a cgroup
+	// configured before they start, whose limits are read back
+	container := func(quota int64, period uint64, cpuset string) cgroupLimits {
+		cfg := &lconfigs.Config{
+			Cgroups: &lconfigs.Cgroup{
+				Resources: &lconfigs.Resources{
+					CpuQuota:   quota,
+					CpuPeriod:  period,
+					CpusetCpus: cpuset,
+				},
+			},
+		}
+		return containerLimits(cfg, &ExecCommand{RuntimeHints: true})
+	}
+
+	cases := []struct {
+		name   string
+		limits cgroupLimits
+		env    []string
+		exp    []string
+	}{
+		{
+			name:   "exec with reserved cores",
+			limits: container(0, 0, "2-5"),
+			env:    []string{"PATH=/bin"},
+			exp: []string{
+				"PATH=/bin",
+				"NOMAD_CPUSET=2-5",
+				"GOMAXPROCS=4",
+				"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=4",
+			},
+		},
+		{
+			name:   "exec with a cpu quota",
+			limits: container(50000, 100000, "0-7"),
+			env:    nil,
+			exp: []string{
+				"NOMAD_CPU_QUOTA_US=50000",
+				"NOMAD_CPU_PERIOD_US=100000",
+				"NOMAD_CPUSET=0-7",
+				"GOMAXPROCS=1",
+				"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=1",
+			},
+		},
+		{
+			name:   "java with jvm options",
+			limits: container(0, 0, "0,2"),
+			env:    []string{"JAVA_TOOL_OPTIONS=-Xss1m"},
+			exp: []string{
+				"JAVA_TOOL_OPTIONS=-Xss1m -XX:ActiveProcessorCount=2",
+				"NOMAD_CPUSET=0,2",
+				"GOMAXPROCS=2",
+			},
+		},
+		{
+			name:   "java with a processor count",
+			limits: container(0, 0, "0-3"),
+			env:    []string{"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=8"},
+			exp: []string{
+				"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=8",
+				"NOMAD_CPUSET=0-3",
+				"GOMAXPROCS=4",
+			},
+		},
+		{
+			name:   "raw_exec with a cpu quota",
+			limits: cgroupLimits{cpuQuota: 250000, cpuPeriod: 100000},
+			env:    []string{"GOMAXPROCS=16"},
+			exp: []string{
+				"GOMAXPROCS=16",
+				"NOMAD_CPU_QUOTA_US=250000",
+				"NOMAD_CPU_PERIOD_US=100000",
+				"JAVA_TOOL_OPTIONS=-XX:ActiveProcessorCount=3",
+			},
+		},
+		{
+			name:   "raw_exec without limits",
+			limits: cgroupLimits{},
+			env:    []string{"PATH=/bin"},
+			exp:    []string{"PATH=/bin"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, tc.limits.appendHints(tc.limits.appendEnv(tc.env)))
+		})
+	}
+}
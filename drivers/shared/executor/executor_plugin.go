@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/drivers/shared/executor/proto"
 	"google.golang.org/grpc"
 )
@@ -19,21 +20,64 @@ type ExecutorPlugin struct {
 	logger      hclog.Logger
 	fsIsolation bool
 	compute     cpustats.Compute
+
+	// protocolVersion is the executor protocol version of the plugin set
+	// the plugin belongs to
+	protocolVersion int
+
+	// statsRecordFile is the file the executor records the stats samples it
+	// sends to, if recording is enabled
+	statsRecordFile string
+
+	// statsMode is the procstats mode the executor reads the processes of
+	// its task in, if not the default
+	statsMode procstats.Mode
+}
+
+// withStatsMode makes the executor plugins read the processes of their task
+// in the given procstats mode.
+func withStatsMode(plugins map[int]plugin.PluginSet, mode procstats.Mode) map[int]plugin.PluginSet {
+	for _, set := range plugins {
+		for _, p := range set {
+			if ep, ok := p.(*ExecutorPlugin); ok {
+				ep.statsMode = mode
+			}
+		}
+	}
+	return plugins
 }
 
 func (p *ExecutorPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	server := &grpcExecutorServer{}
 	if p.fsIsolation {
-		proto.RegisterExecutorServer(s, &grpcExecutorServer{impl: NewExecutorWithIsolation(p.logger, p.compute)})
+		server.impl = NewExecutorWithIsolation(p.logger, p.compute)
 	} else {
-		proto.RegisterExecutorServer(s, &grpcExecutorServer{impl: NewExecutor(p.logger, p.compute)})
+		server.impl = NewExecutor(p.logger, p.compute)
 	}
+
+	if p.statsMode != "" {
+		if e, ok := server.impl.(interface{ setStatsMode(procstats.Mode) }); ok {
+			e.setStatsMode(p.statsMode)
+		}
+	}
+
+	if p.statsRecordFile != "" {
+		recorder, err := newStatsRecorder(p.logger, p.statsRecordFile)
+		if err != nil {
+			p.logger.Warn("failed to open stats record file", "path", p.statsRecordFile, "error", err)
+		}
+		server.recorder = recorder
+	}
+
+	proto.RegisterExecutorServer(s, server)
 	return nil
 }
 
 func (p *ExecutorPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
 	return &grpcExecutorClient{
-		client:  proto.NewExecutorClient(c),
-		doneCtx: ctx,
-		logger:  p.logger,
+		client:          proto.NewExecutorClient(c),
+		doneCtx:         ctx,
+		logger:          p.logger,
+		protocolVersion: p.protocolVersion,
 	}, nil
 }
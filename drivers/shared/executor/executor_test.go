@@ -154,6 +154,7 @@ func TestExecutor_Start_Wait_Failure_Code(t *testing.T) {
 			require.NotZero(ps.Pid)
 			ps, _ = executor.Wait(context.Background())
 			require.NotZero(ps.ExitCode, "expected exit code to be non zero")
+			require.Equal(structs.TaskExitReasonNonZeroExit, ps.ExitReason)
 			require.NoError(executor.Shutdown("SIGINT", 100*time.Millisecond))
 		})
 	}
@@ -291,6 +292,7 @@ func TestExecutor_WaitExitSignal(t *testing.T) {
 			pState, err = executor.Wait(context.Background())
 			require.NoError(t, err)
 			require.Equal(t, pState.Signal, int(syscall.SIGKILL))
+			require.Equal(t, structs.TaskExitReasonSignaled, pState.ExitReason)
 		})
 	}
 }
@@ -637,3 +639,30 @@ func TestExecutor_Start_NonExecutableBinaries(t *testing.T) {
 		})
 	}
 }
+
+func TestExecCommand_qosOOMScoreAdj(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{Resources: &drivers.Resources{
+		NomadResources: &structs.AllocatedTaskResources{
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 100, MemoryMaxMB: -1},
+		},
+	}}
+
+	// The class is only enforced if QoS classes are
+	must.Eq(t, 0, command.qosOOMScoreAdj())
+
+	command.QoSClasses = true
+	command.Resources.NomadResources.Memory.MemoryMaxMB = 0
+	must.Eq(t, 0, command.qosOOMScoreAdj())
+
+	command.Resources.NomadResources.Memory.MemoryMaxMB = 200
+	must.Eq(t, 500, command.qosOOMScoreAdj())
+
+	command.Resources.NomadResources.Memory.MemoryMaxMB = -1
+	must.Eq(t, 1000, command.qosOOMScoreAdj())
+
+	// The configured oom_score_adj overrides the one of the class
+	command.OOMScoreAdj = 200
+	must.Eq(t, 200, command.qosOOMScoreAdj())
+}
@@ -9,14 +9,18 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/nsutil"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"golang.org/x/sys/unix"
@@ -106,6 +110,119 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[procstats.ProcessID]
 	return procstats.List(e.command)
 }
 
+// cgroupIdentity returns the path and kernel ID of the cgroup the task
+// processes are members of, so they can be published alongside task stats.
+// It is called once the task is launched and its cgroup exists.
+func cgroupIdentity(command *ExecCommand) (string, uint64) {
+	cgroup := command.StatsCgroup()
+	if cgroup == "" {
+		return "", 0
+	}
+	id, err := cgroupslib.OpenPath(cgroup).ID()
+	if err != nil {
+		return cgroup, 0
+	}
+	return cgroup, id
+}
+
+// readCgroupLimits reads back the limits in effect on the task's cgroup.
+func readCgroupLimits(command *ExecCommand) cgroupLimits {
+	var limits cgroupLimits
+	cgroup := command.StatsCgroup()
+	if cgroup == "" {
+		return limits
+	}
+
+	read := func(ed cgroupslib.Interface, filename string) string {
+		s, _ := ed.Read(filename)
+		return s
+	}
+
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		cpu := cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
+		quota, _ := strconv.ParseInt(read(cpu, "cpu.cfs_quota_us"), 10, 64)
+		period, _ := strconv.ParseInt(read(cpu, "cpu.cfs_period_us"), 10, 64)
+		limits.cpuQuota, limits.cpuPeriod = quota, period
+		limits.memoryMax = parseMemoryMax(read(cgroupslib.OpenFromFreezerCG1(cgroup, "memory"), "memory.limit_in_bytes"))
+		if cpuset := command.CpusetCgroup(); cpuset != "" {
+			limits.cpuset = read(cgroupslib.OpenPath(cpuset), "cpuset.effective_cpus")
+		}
+	case cgroupslib.CG2:
+		ed := cgroupslib.OpenPath(cgroup)
+		limits.cpuQuota, limits.cpuPeriod = parseCPUMax(read(ed, "cpu.max"))
+		limits.memoryMax = parseMemoryMax(read(ed, "memory.max"))
+		limits.cpuset = read(ed, "cpuset.cpus.effective")
+	}
+	return limits
+}
+
+// readCgroupStats reads the block I/O and pressure stall information of the
+// task's cgroup, which are only accounted per cgroup on cgroups v2.
+func readCgroupStats(command *ExecCommand) (*cstructs.DiskIOStats, *cstructs.PressureStats) {
+	cgroup := command.StatsCgroup()
+	if cgroup == "" || cgroupslib.GetMode() != cgroupslib.CG2 {
+		return nil, nil
+	}
+	ed := cgroupslib.OpenPath(cgroup)
+
+	var diskIO *cstructs.DiskIOStats
+	if s, err := ed.Read("io.stat"); err == nil {
+		diskIO = parseIOStat(s)
+	}
+
+	var pressure *cstructs.PressureStats
+	read := func(filename string, into *float64) {
+		s, err := ed.Read(filename)
+		if err != nil {
+			return
+		}
+		if avg, ok := parsePressure(s); ok {
+			if pressure == nil {
+				pressure = new(cstructs.PressureStats)
+			}
+			*into = avg
+		}
+	}
+	var cpu, memory, io float64
+	read("cpu.pressure", &cpu)
+	read("memory.pressure", &memory)
+	read("io.pressure", &io)
+	if pressure != nil {
+		pressure.CPU, pressure.Memory, pressure.IO = cpu, memory, io
+	}
+	return diskIO, pressure
+}
+
+// StatsCapabilities returns the optional stats the executor reports for the
+// tasks it runs on this node. Block I/O and pressure stall information are
+// read from the task's cgroup, so they need cgroups v2, and pressure also
+// needs a kernel with PSI enabled.
+func StatsCapabilities() *drivers.StatsCapabilities {
+	cg2 := cgroupslib.GetMode() == cgroupslib.CG2
+	_, err := os.Stat("/proc/pressure/cpu")
+	return &drivers.StatsCapabilities{
+		Network:  true,
+		DiskIO:   cg2,
+		Devices:  true,
+		Pressure: cg2 && err == nil,
+	}
+}
+
+// profileTargetOf returns the task's cgroup as the target of a profile when
+// running on cgroups v2, so processes forked while profiling are included.
+// perf can only profile cgroups of the perf_event controller on cgroups v1,
+// which Nomad doesn't manage, so the task's processes are targeted instead.
+func profileTargetOf(command *ExecCommand, list procstats.ProcessList) profileTarget {
+	if cgroupslib.GetMode() == cgroupslib.CG2 {
+		if cgroup := command.StatsCgroup(); cgroup != "" {
+			rel := strings.TrimPrefix(cgroup, cgroupslib.GetDefaultRoot())
+			return profileTarget{cgroup: strings.TrimPrefix(rel, "/")}
+		}
+	}
+	return profileTarget{pids: list.ListProcesses()}
+}
+
 func (e *UniversalExecutor) statCG(cgroup string) (int, func(), error) {
 	fd, err := unix.Open(cgroup, unix.O_PATH, 0)
 	cleanup := func() {
@@ -137,7 +254,7 @@ func (e *UniversalExecutor) configureResourceContainer(
 	cgroup := command.StatsCgroup()
 
 	// ensure tasks get the desired oom_score_adj value set
-	if err := e.setOomAdj(command.OOMScoreAdj); err != nil {
+	if err := e.setOomAdj(command.qosOOMScoreAdj()); err != nil {
 		return nil, nil, err
 	}
 
@@ -182,8 +299,13 @@ func (e *UniversalExecutor) enterCG1(statsCgroup, cpusetCgroup string) (runningF
 	ed := cgroupslib.OpenPath(cpusetCgroup)
 	pid := strconv.Itoa(unix.Getpid())
 
-	// write pid to all the normal interfaces
-	ifaces := []string{"freezer", "cpu", "memory"}
+	// write pid to all the normal interfaces the node mounts
+	ifaces := []string{"freezer"}
+	for _, iface := range []string{"cpu", "memory"} {
+		if cgroupslib.Enforces(iface) {
+			ifaces = append(ifaces, iface)
+		}
+	}
 	for _, iface := range ifaces {
 		ed := cgroupslib.OpenFromFreezerCG1(statsCgroup, iface)
 		err := ed.Write("cgroup.procs", pid)
@@ -193,14 +315,17 @@ func (e *UniversalExecutor) enterCG1(statsCgroup, cpusetCgroup string) (runningF
 	}
 
 	// write pid to the cpuset interface, which varies between reserve/share
-	err := ed.Write("cgroup.procs", pid)
-	if err != nil {
-		e.logger.Warn("failed to write cpuset cgroup", "error", err)
+	if cgroupslib.Enforces("cpuset") {
+		err := ed.Write("cgroup.procs", pid)
+		if err != nil {
+			e.logger.Warn("failed to write cpuset cgroup", "error", err)
+		}
+		ifaces = append(ifaces, "cpuset")
 	}
 
 	move := func() error {
 		// move the executor back out
-		for _, iface := range append(ifaces, "cpuset") {
+		for _, iface := range ifaces {
 			err := cgroupslib.WriteNomadCG1(iface, "cgroup.procs", pid)
 			if err != nil {
 				e.logger.Warn("failed to move executor cgroup", "interface", iface, "error", err)
@@ -239,30 +364,40 @@ func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) er
 	}
 
 	// write memory limits
-	memHard, memSoft := e.computeMemory(command)
-	ed := cgroupslib.OpenFromFreezerCG1(cgroup, "memory")
-	_ = ed.Write("memory.limit_in_bytes", strconv.FormatInt(memHard, 10))
-	if memSoft > 0 {
-		_ = ed.Write("memory.soft_limit_in_bytes", strconv.FormatInt(memSoft, 10))
-	}
+	if cgroupslib.Enforces("memory") {
+		memHard, memSoft := e.computeMemory(command)
+		ed := cgroupslib.OpenFromFreezerCG1(cgroup, "memory")
+		_ = ed.Write("memory.limit_in_bytes", strconv.FormatInt(memHard, 10))
+		if memSoft > 0 {
+			_ = ed.Write("memory.soft_limit_in_bytes", strconv.FormatInt(memSoft, 10))
+		}
 
-	// write memory swappiness
-	swappiness := cgroupslib.MaybeDisableMemorySwappiness()
-	if swappiness != nil {
-		value := int64(*swappiness)
-		_ = ed.Write("memory.swappiness", strconv.FormatInt(value, 10))
+		// write memory swappiness
+		swappiness := cgroupslib.MaybeDisableMemorySwappiness()
+		if swappiness != nil {
+			value := int64(*swappiness)
+			_ = ed.Write("memory.swappiness", strconv.FormatInt(value, 10))
+		}
 	}
 
 	// write cpu shares
-	cpuShares := strconv.FormatInt(command.Resources.LinuxResources.CPUShares, 10)
-	ed = cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
-	_ = ed.Write("cpu.shares", cpuShares)
+	if cgroupslib.Enforces("cpu") {
+		cpuShares := strconv.FormatInt(command.Resources.LinuxResources.CPUShares, 10)
+		ed := cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
+		_ = ed.Write("cpu.shares", cpuShares)
+	}
 
 	// write cpuset, if set
-	if cpuSet := command.Resources.LinuxResources.CpusetCpus; cpuSet != "" {
+	if cpuSet := command.Resources.LinuxResources.CpusetCpus; cpuSet != "" && cgroupslib.Enforces("cpuset") {
 		cpusetPath := command.Resources.LinuxResources.CpusetCgroupPath
-		ed = cgroupslib.OpenPath(cpusetPath)
+		ed := cgroupslib.OpenPath(cpusetPath)
 		_ = ed.Write("cpuset.cpus", cpuSet)
+
+		// bind the memory of the task to its numa nodes in the cgroup too, so
+		// processes it starts with another memory policy are bound all the same
+		if policy, nodes := command.memoryPolicy(); policy == structs.BindMemoryPolicy {
+			_ = ed.Write("cpuset.mems", nodes)
+		}
 	}
 
 	return nil
@@ -274,35 +409,43 @@ func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
 		return
 	}
 
-	// write memory cgroup files
-	memHard, memSoft := e.computeMemory(command)
 	ed := cgroupslib.OpenPath(cgroup)
-	if memHard == memoryNoLimit {
-		_ = ed.Write("memory.max", "max")
-	} else {
-		_ = ed.Write("memory.max", strconv.FormatInt(memHard, 10))
-	}
-	if memSoft > 0 {
-		ed = cgroupslib.OpenPath(cgroup)
-		_ = ed.Write("memory.low", strconv.FormatInt(memSoft, 10))
-	}
 
-	// set memory swappiness
-	swappiness := cgroupslib.MaybeDisableMemorySwappiness()
-	if swappiness != nil {
-		ed := cgroupslib.OpenPath(cgroup)
-		value := int64(*swappiness)
-		_ = ed.Write("memory.swappiness", strconv.FormatInt(value, 10))
+	// write memory cgroup files
+	if cgroupslib.Enforces("memory") {
+		memHard, memSoft := e.computeMemory(command)
+		if memHard == memoryNoLimit {
+			_ = ed.Write("memory.max", "max")
+		} else {
+			_ = ed.Write("memory.max", strconv.FormatInt(memHard, 10))
+		}
+		if memSoft > 0 {
+			_ = ed.Write("memory.low", strconv.FormatInt(memSoft, 10))
+		}
+
+		// set memory swappiness
+		swappiness := cgroupslib.MaybeDisableMemorySwappiness()
+		if swappiness != nil {
+			value := int64(*swappiness)
+			_ = ed.Write("memory.swappiness", strconv.FormatInt(value, 10))
+		}
 	}
 
 	// write cpu weight cgroup file
-	cpuWeight := e.computeCPU(command)
-	ed = cgroupslib.OpenPath(cgroup)
-	_ = ed.Write("cpu.weight", strconv.FormatUint(cpuWeight, 10))
+	if cgroupslib.Enforces("cpu") {
+		cpuWeight := e.computeCPU(command)
+		_ = ed.Write("cpu.weight", strconv.FormatUint(cpuWeight, 10))
+	}
 
 	// write cpuset cgroup file, if set
-	cpusetCpus := command.Resources.LinuxResources.CpusetCpus
-	_ = ed.Write("cpuset.cpus", cpusetCpus)
+	if cgroupslib.Enforces("cpuset") {
+		cpusetCpus := command.Resources.LinuxResources.CpusetCpus
+		_ = ed.Write("cpuset.cpus", cpusetCpus)
+
+		if policy, nodes := command.memoryPolicy(); policy == structs.BindMemoryPolicy {
+			_ = ed.Write("cpuset.mems", nodes)
+		}
+	}
 }
 
 func (e *UniversalExecutor) setOomAdj(oomScore int32) error {
@@ -317,10 +460,6 @@ func (*UniversalExecutor) computeCPU(command *ExecCommand) uint64 {
 	return cpuWeight
 }
 
-func mbToBytes(n int64) int64 {
-	return n * 1024 * 1024
-}
-
 // computeMemory returns the hard and soft memory limits for the task
 func (*UniversalExecutor) computeMemory(command *ExecCommand) (int64, int64) {
 	mem := command.Resources.NomadResources.Memory
@@ -330,10 +469,18 @@ func (*UniversalExecutor) computeMemory(command *ExecCommand) (int64, int64) {
 	case 0:
 		// typical case where 'memory' is the hard limit
 		memHard = mem.MemoryMB
+		if command.QoSClasses {
+			// guaranteed tasks have their memory protected from reclaim
+			return mbToBytes(memHard), mbToBytes(memSoft)
+		}
 		return mbToBytes(memHard), 0
 	case memoryNoLimit:
 		// special oversub case where 'memory' is soft limit and there is no
 		// hard limit - helping re-create old raw_exec behavior
+		if command.QoSClasses {
+			// best-effort tasks have no protected memory
+			return memoryNoLimit, 0
+		}
 		return memoryNoLimit, mbToBytes(memSoft)
 	default:
 		// typical oversub case where 'memory' is soft limit and 'memory_max'
@@ -358,3 +505,47 @@ func withNetworkIsolation(f func() error, spec *drivers.NetworkIsolationSpec) er
 	}
 	return f()
 }
+
+// withIsolation calls f, which starts the task, in the network namespace of
+// the task, and in a private mount namespace where resolvConf is bind mounted
+// over /etc/resolv.conf if it is set. The task inherits the namespaces of the
+// thread starting it, so both are entered from a locked thread which is then
+// discarded rather than returned to the Go runtime.
+func withIsolation(f func() error, spec *drivers.NetworkIsolationSpec, resolvConf string) error {
+	if resolvConf == "" {
+		return withNetworkIsolation(f, spec)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		// never unlocked, so the thread exits along with the goroutine
+		runtime.LockOSThread()
+		errCh <- func() error {
+			if err := unix.Unshare(unix.CLONE_NEWNS); err != nil {
+				return fmt.Errorf("failed to create mount namespace: %w", err)
+			}
+			if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+				return fmt.Errorf("failed to make mounts private: %w", err)
+			}
+			if err := unix.Mount(resolvConf, "/etc/resolv.conf", "", unix.MS_BIND, ""); err != nil {
+				return fmt.Errorf("failed to mount resolv.conf: %w", err)
+			}
+			if err := unix.Mount("", "/etc/resolv.conf", "", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_RDONLY, ""); err != nil {
+				return fmt.Errorf("failed to make resolv.conf read-only: %w", err)
+			}
+
+			if spec != nil && spec.Path != "" {
+				netNS, err := nsutil.GetNS(spec.Path)
+				if err != nil {
+					return err
+				}
+				defer netNS.Close()
+				if err := netNS.Set(); err != nil {
+					return fmt.Errorf("failed to enter network namespace: %w", err)
+				}
+			}
+			return f()
+		}()
+	}()
+	return <-errCh
+}
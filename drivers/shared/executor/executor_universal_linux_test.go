@@ -6,12 +6,14 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
@@ -20,14 +22,16 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 )
 
 func Test_computeMemory(t *testing.T) {
 	cases := []struct {
-		memory    int64
-		memoryMax int64
-		expSoft   int64
-		expHard   int64
+		memory     int64
+		memoryMax  int64
+		qosClasses bool
+		expSoft    int64
+		expHard    int64
 	}{
 		{
 			// typical case; only 'memory' is set and that is used as the hard
@@ -53,12 +57,31 @@ func Test_computeMemory(t *testing.T) {
 			expSoft:   mbToBytes(100),
 			expHard:   memoryNoLimit,
 		},
+		{
+			// guaranteed task with QoS classes enforced; 'memory' is the hard
+			// limit and is protected from reclaim
+			memory:     100,
+			memoryMax:  0,
+			qosClasses: true,
+			expSoft:    mbToBytes(100),
+			expHard:    mbToBytes(100),
+		},
+		{
+			// best-effort task with QoS classes enforced; there is no hard
+			// limit nor protected memory
+			memory:     100,
+			memoryMax:  memoryNoLimit,
+			qosClasses: true,
+			expSoft:    0,
+			expHard:    memoryNoLimit,
+		},
 	}
 
 	for _, tc := range cases {
-		name := fmt.Sprintf("(%d,%d)", tc.memory, tc.memoryMax)
+		name := fmt.Sprintf("(%d,%d,%t)", tc.memory, tc.memoryMax, tc.qosClasses)
 		t.Run(name, func(t *testing.T) {
 			command := &ExecCommand{
+				QoSClasses: tc.qosClasses,
 				Resources: &drivers.Resources{
 					NomadResources: &structs.AllocatedTaskResources{
 						Memory: structs.AllocatedMemoryResources{
@@ -162,3 +185,38 @@ func TestUniversalExecutor_cg1_no_executor_pid(t *testing.T) {
 		must.Eq(t, pids[0], strconv.Itoa(p.Pid))
 	}
 }
+
+func TestUniversalExecutor_DNS(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	factory := universalFactory
+	testExecCmd := testExecutorCommand(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/cat"
+	execCmd.Args = []string{"/etc/resolv.conf"}
+	execCmd.DNS = &drivers.DNSConfig{Servers: []string{"10.0.0.53"}}
+
+	factory.configureExecCmd(t, execCmd)
+	defer allocDir.Destroy()
+	executor := factory.new(testlog.HCLogger(t), compute)
+	defer executor.Shutdown("", 0)
+
+	_, err := executor.Launch(execCmd)
+	must.NoError(t, err)
+	ps, err := executor.Wait(context.Background())
+	must.NoError(t, err)
+	must.Zero(t, ps.ExitCode)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return strings.Contains(testExecCmd.stdout.String(), "nameserver 10.0.0.53")
+		}),
+		wait.Timeout(5*time.Second),
+	))
+
+	// the host's resolv.conf is left alone
+	host, err := os.ReadFile("/etc/resolv.conf")
+	must.NoError(t, err)
+	must.StrNotContains(t, string(host), "nameserver 10.0.0.53")
+}
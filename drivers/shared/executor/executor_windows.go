@@ -14,6 +14,21 @@ import (
 	"golang.org/x/sys/windows"
 )
 
+const (
+	// flags of JOBOBJECT_CPU_RATE_CONTROL_INFORMATION, which x/sys/windows
+	// does not define
+	jobObjectCPURateControlEnable  = 0x1
+	jobObjectCPURateControlHardCap = 0x4
+)
+
+// jobObjectCPURateControlInformation is JOBOBJECT_CPU_RATE_CONTROL_INFORMATION
+// with the CpuRate member of its union.
+// Ref: https://learn.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-jobobject_cpu_rate_control_information
+type jobObjectCPURateControlInformation struct {
+	ControlFlags uint32
+	CpuRate      uint32
+}
+
 // configure new process group for child process and creates a JobObject for the
 // executor. Children of the executor will be created in the same JobObject
 // Ref: https://learn.microsoft.com/en-us/windows/win32/procthread/job-objects
@@ -31,11 +46,19 @@ func (e *UniversalExecutor) setNewProcessGroup() error {
 		return fmt.Errorf("could not create Windows job object for executor: %w", err)
 	}
 
+	// the executor is in the job along with the task, so the limits apply to
+	// the executor too
+	limits := newJobObjectLimits(e.command, e.compute)
+
 	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
 		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
 			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
 		},
 	}
+	if limits.memoryMax > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = uintptr(limits.memoryMax)
+	}
 	_, err = windows.SetInformationJobObject(
 		job,
 		windows.JobObjectExtendedLimitInformation,
@@ -45,6 +68,22 @@ func (e *UniversalExecutor) setNewProcessGroup() error {
 		return fmt.Errorf("could not configure Windows job object for executor: %w", err)
 	}
 
+	if limits.cpuRate > 0 {
+		cpuInfo := jobObjectCPURateControlInformation{
+			ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlHardCap,
+			CpuRate:      limits.cpuRate,
+		}
+		_, err = windows.SetInformationJobObject(
+			job,
+			windows.JobObjectCpuRateControlInformation,
+			uintptr(unsafe.Pointer(&cpuInfo)),
+			uint32(unsafe.Sizeof(cpuInfo)))
+		if err != nil {
+			return fmt.Errorf("could not limit CPU of Windows job object for executor: %w", err)
+		}
+	}
+	e.limits = limits.resourceLimits(e.compute)
+
 	handle := windows.CurrentProcess()
 	err = windows.AssignProcessToJobObject(job, handle)
 	if err != nil {
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/hashicorp/nomad/client/logmon/logframe"
+)
+
+// framedPipeCloseTolerance is how long closing a framed pipe waits for the
+// output the task wrote to it to be copied to the fifo.
+const framedPipeCloseTolerance = 2 * time.Second
+
+// framedPipe is a pipe the task writes its output to, which is framed with the
+// time it was read at and copied to the log collector's fifo. The task is
+// given the write end as a file so its output doesn't go through the executor
+// until it is read from the pipe.
+type framedPipe struct {
+	*os.File
+
+	done chan struct{}
+}
+
+func newFramedPipe(fifo io.WriteCloser, stream logframe.Stream) (*framedPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &framedPipe{
+		File: w,
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		defer fifo.Close()
+		defer r.Close()
+
+		copyFramed(logframe.NewWriter(fifo, stream), r)
+	}()
+	return p, nil
+}
+
+// copyFramed copies the output read from r to w until r is closed. The copy
+// ends once the task and any processes that inherited its output close the
+// pipe, so the fifo stays open for them like it would if they wrote to it
+// directly.
+//
+// Output that can't be written to the fifo, such as while logmon is relaunched,
+// is dropped like the task's own writes to the fifo would fail. The copy
+// carries on, so the output reaches logmon again once it reopens the fifo.
+func copyFramed(w io.Writer, r io.Reader) {
+	buf := make([]byte, logframe.MaxPayload)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			w.Write(buf[:n])
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Close closes the executor's write end of the pipe and waits for the output
+// written to it to be copied.
+func (p *framedPipe) Close() error {
+	err := p.File.Close()
+	select {
+	case <-p.done:
+	case <-time.After(framedPipeCloseTolerance):
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logframe"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestFramedPipe(t *testing.T) {
+	ci.Parallel(t)
+
+	var fifo bufferCloser
+	p, err := newFramedPipe(&fifo, logframe.StreamStderr)
+	must.NoError(t, err)
+
+	_, err = p.File.Write([]byte("hello\n"))
+	must.NoError(t, err)
+	must.NoError(t, p.Close())
+	must.True(t, fifo.closed, must.Sprint("expected the fifo to be closed once the output was copied"))
+
+	frame, err := logframe.NewReader(&fifo.Buffer).Next()
+	must.NoError(t, err)
+	must.Eq(t, logframe.StreamStderr, frame.Stream)
+	must.Eq(t, "hello\n", string(frame.Payload))
+}
+
+// brokenFifo fails the first writes to it, like a fifo without a reader
+type brokenFifo struct {
+	bufferCloser
+
+	lock     sync.Mutex
+	failures int
+}
+
+func (b *brokenFifo) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.failures > 0 {
+		b.failures--
+		return 0, syscall.EPIPE
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *brokenFifo) remaining() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.failures
+}
+
+// TestFramedPipe_BrokenFifo asserts the output is copied again once the fifo
+// can be written to, such as after logmon was relaunched.
+func TestFramedPipe_BrokenFifo(t *testing.T) {
+	ci.Parallel(t)
+
+	fifo := &brokenFifo{failures: 1}
+	p, err := newFramedPipe(fifo, logframe.StreamStdout)
+	must.NoError(t, err)
+
+	_, err = p.File.Write([]byte("lost\n"))
+	must.NoError(t, err)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return fifo.remaining() == 0 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	_, err = p.File.Write([]byte("hello\n"))
+	must.NoError(t, err)
+	must.NoError(t, p.Close())
+	must.True(t, fifo.closed)
+
+	r := logframe.NewReader(&fifo.Buffer)
+	frame, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, "hello\n", string(frame.Payload))
+	_, err = r.Next()
+	must.ErrorIs(t, err, io.EOF)
+}
+
+func TestExecCommand_FramedLogs(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a unix fifo path")
+	}
+
+	path := filepath.Join(t.TempDir(), "stdout.fifo")
+	openFn, err := fifo.CreateAndRead(path)
+	must.NoError(t, err)
+
+	outputCh := make(chan []byte, 1)
+	go func() {
+		r, err := openFn()
+		if err != nil {
+			close(outputCh)
+			return
+		}
+		defer r.Close()
+		b, _ := io.ReadAll(r)
+		outputCh <- b
+	}()
+
+	cmd := &ExecCommand{StdoutPath: path, FramedLogs: true}
+	w, err := cmd.Stdout()
+	must.NoError(t, err)
+
+	// The task is given the pipe itself so its output isn't copied by
+	// exec.Cmd
+	_, ok := w.(*os.File)
+	must.True(t, ok)
+
+	_, err = w.Write([]byte("hello\n"))
+	must.NoError(t, err)
+	cmd.Close()
+
+	output := <-outputCh
+	frame, err := logframe.NewReader(bytes.NewReader(output)).Next()
+	must.NoError(t, err)
+	must.Eq(t, logframe.StreamStdout, frame.Stream)
+	must.Eq(t, "hello\n", string(frame.Payload))
+}
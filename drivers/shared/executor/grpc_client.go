@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -31,6 +32,15 @@ type grpcExecutorClient struct {
 
 	// doneCtx is close when the plugin exits
 	doneCtx context.Context
+
+	// protocolVersion is the executor protocol version spoken with the
+	// executor
+	protocolVersion int
+
+	// caps caches the capabilities of the executor, which don't change for
+	// the lifetime of its process
+	caps     *ExecutorCapabilities
+	capsLock sync.Mutex
 }
 
 func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
@@ -56,6 +66,19 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		CgroupV1Override: cmd.OverrideCgroupV1,
 		OomScoreAdj:      cmd.OOMScoreAdj,
 		WorkDir:          cmd.WorkDir,
+		PerfEventStats:   cmd.PerfEventStats,
+		RuntimeHints:     cmd.RuntimeHints,
+		SelinuxLabel:     cmd.SELinuxLabel,
+		ApparmorProfile:  cmd.AppArmorProfile,
+		MaskedPaths:      cmd.MaskedPaths,
+		ReadonlyPaths:    cmd.ReadonlyPaths,
+		Dns:              drivers.DNSConfigToProto(cmd.DNS),
+		EgressClasses:    egressClassesToProto(cmd.EgressClasses),
+		FramedLogs:       cmd.FramedLogs,
+		QosClasses:       cmd.QoSClasses,
+		Provenance:       cmd.Provenance,
+		WindowsLogon:     windowsLogonToProto(cmd.WindowsLogon),
+		ResctrlClass:     resctrlClassToProto(cmd.ResctrlClass),
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -66,6 +89,7 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 	if err != nil {
 		return nil, err
 	}
+	ps.Provenance = provenanceFromProto(resp.Provenance)
 	return ps, nil
 }
 
@@ -118,6 +142,74 @@ func (c *grpcExecutorClient) Version() (*ExecutorVersion, error) {
 	return &ExecutorVersion{Version: resp.Version}, nil
 }
 
+// Capabilities returns the optional features of the executor. Executors
+// speaking ExecutorProtocolV2 don't implement the RPC, and are assumed to
+// support none of them.
+func (c *grpcExecutorClient) Capabilities() (*ExecutorCapabilities, error) {
+	c.capsLock.Lock()
+	defer c.capsLock.Unlock()
+	if c.caps != nil {
+		return c.caps, nil
+	}
+
+	if c.protocolVersion < ExecutorProtocolV3 {
+		c.caps = &ExecutorCapabilities{ProtocolVersion: ExecutorProtocolV2}
+		return c.caps, nil
+	}
+
+	resp, err := c.client.Capabilities(context.Background(), &proto.CapabilitiesRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		c.caps = &ExecutorCapabilities{ProtocolVersion: ExecutorProtocolV2}
+		return c.caps, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	c.caps = &ExecutorCapabilities{
+		ProtocolVersion: min(int(resp.ProtocolVersion), c.protocolVersion),
+		NomadVersion:    resp.NomadVersion,
+		Processes:       resp.Processes,
+		Profile:         resp.Profile,
+		PerfStats:       resp.PerfStats,
+		TotalCpuSeconds: resp.TotalCpuSeconds,
+	}
+	return c.caps, nil
+}
+
+// unsupported returns an error stating the executor doesn't support the
+// feature if the executor doesn't implement the RPC that failed, such as when
+// it runs a previous Nomad binary.
+func (c *grpcExecutorClient) unsupported(err error, feature string) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	version := "a previous version"
+	if caps, _ := c.Capabilities(); caps != nil && caps.NomadVersion != "" {
+		version = caps.NomadVersion
+	}
+	return fmt.Errorf("%w: the executor of the task runs Nomad %s, which does not support %s",
+		ErrUnsupportedByExecutor, version, feature)
+}
+
+func (c *grpcExecutorClient) Processes() ([]*drivers.TaskProcess, error) {
+	ctx := context.Background()
+	resp, err := c.client.Processes(ctx, &proto.ProcessesRequest{})
+	if err != nil {
+		return nil, c.unsupported(err, "listing processes")
+	}
+	return drivers.TaskProcessesFromProto(resp.Processes)
+}
+
+func (c *grpcExecutorClient) Profile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	resp, err := c.client.Profile(ctx, &proto.ProfileRequest{
+		Duration: int64(duration),
+	})
+	if err != nil {
+		return nil, c.unsupported(err, "profiling")
+	}
+	return resp.FoldedStacks, nil
+}
+
 func (c *grpcExecutorClient) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	stream, err := c.client.Stats(ctx, &proto.StatsRequest{
 		Interval: int64(interval),
@@ -21,6 +21,9 @@ import (
 
 type grpcExecutorServer struct {
 	impl Executor
+
+	// recorder records the stats samples sent to the client, if enabled
+	recorder *statsRecorder
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
@@ -45,6 +48,19 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		OverrideCgroupV1: req.CgroupV1Override,
 		OOMScoreAdj:      req.OomScoreAdj,
 		WorkDir:          req.WorkDir,
+		PerfEventStats:   req.PerfEventStats,
+		RuntimeHints:     req.RuntimeHints,
+		SELinuxLabel:     req.SelinuxLabel,
+		AppArmorProfile:  req.ApparmorProfile,
+		MaskedPaths:      req.MaskedPaths,
+		ReadonlyPaths:    req.ReadonlyPaths,
+		DNS:              drivers.DNSConfigFromProto(req.Dns),
+		EgressClasses:    egressClassesFromProto(req.EgressClasses),
+		FramedLogs:       req.FramedLogs,
+		QoSClasses:       req.QosClasses,
+		Provenance:       req.Provenance,
+		WindowsLogon:     windowsLogonFromProto(req.WindowsLogon),
+		ResctrlClass:     resctrlClassFromProto(req.ResctrlClass),
 	})
 
 	if err != nil {
@@ -57,7 +73,8 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 	}
 
 	return &proto.LaunchResponse{
-		Process: process,
+		Process:    process,
+		Provenance: provenanceToProto(ps.Provenance),
 	}, nil
 }
 
@@ -104,6 +121,45 @@ func (s *grpcExecutorServer) Version(context.Context, *proto.VersionRequest) (*p
 	}, nil
 }
 
+func (s *grpcExecutorServer) Capabilities(context.Context, *proto.CapabilitiesRequest) (*proto.CapabilitiesResponse, error) {
+	caps, err := s.impl.Capabilities()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.CapabilitiesResponse{
+		ProtocolVersion: int32(caps.ProtocolVersion),
+		NomadVersion:    caps.NomadVersion,
+		Processes:       caps.Processes,
+		Profile:         caps.Profile,
+		PerfStats:       caps.PerfStats,
+		TotalCpuSeconds: caps.TotalCpuSeconds,
+	}, nil
+}
+
+func (s *grpcExecutorServer) Processes(context.Context, *proto.ProcessesRequest) (*proto.ProcessesResponse, error) {
+	procs, err := s.impl.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	pbs, err := drivers.TaskProcessesToProto(procs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ProcessesResponse{Processes: pbs}, nil
+}
+
+func (s *grpcExecutorServer) Profile(ctx context.Context, req *proto.ProfileRequest) (*proto.ProfileResponse, error) {
+	folded, err := s.impl.Profile(ctx, time.Duration(req.Duration))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ProfileResponse{FoldedStacks: folded}, nil
+}
+
 func (s *grpcExecutorServer) Stats(req *proto.StatsRequest, stream proto.Executor_StatsServer) error {
 	interval := time.Duration(req.Interval)
 	if interval == 0 {
@@ -130,6 +186,7 @@ func (s *grpcExecutorServer) Stats(req *proto.StatsRequest, stream proto.Executo
 			return err
 		}
 
+		s.recorder.record(pbStats)
 		presp := &proto.StatsResponse{
 			Stats: pbStats,
 		}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const (
+	// jobCPURateScale is the CPU rate of a Windows job object that allows it
+	// to use all of the node's CPUs; rates are expressed in 1/100ths of a
+	// percent.
+	jobCPURateScale = 10000
+)
+
+// jobObjectLimits are the limits enforced on the Windows job object holding
+// the executor and the task's processes. Zero values mean no limit.
+type jobObjectLimits struct {
+	// memoryMax is the memory the processes of the job may commit, in bytes
+	memoryMax uint64
+
+	// cpuRate is the share of the node's CPU time the processes of the job
+	// may use, in 1/100ths of a percent
+	cpuRate uint32
+}
+
+// newJobObjectLimits returns the job object limits of the task's resources.
+// The task may use memory up to memory_max if set, otherwise memory, like the
+// memory.max limit of a Linux cgroup. A memory_max of -1 disables the memory
+// limit. The CPU rate is the task's share of the total compute of the node.
+func newJobObjectLimits(command *ExecCommand, compute cpustats.Compute) jobObjectLimits {
+	var limits jobObjectLimits
+	if command.Resources == nil || command.Resources.NomadResources == nil {
+		return limits
+	}
+	res := command.Resources.NomadResources
+
+	memory := res.Memory.MemoryMaxMB
+	if memory == 0 {
+		memory = res.Memory.MemoryMB
+	}
+	if memory > 0 {
+		limits.memoryMax = uint64(mbToBytes(memory))
+	}
+
+	if shares, total := res.Cpu.CpuShares, int64(compute.TotalCompute); shares > 0 && total > 0 {
+		rate := shares * jobCPURateScale / total
+		limits.cpuRate = uint32(max(1, min(rate, jobCPURateScale)))
+	}
+	return limits
+}
+
+// resourceLimits returns the limits as reported in task stats.
+func (l jobObjectLimits) resourceLimits(compute cpustats.Compute) *cstructs.ResourceLimits {
+	if l.memoryMax == 0 && l.cpuRate == 0 {
+		return nil
+	}
+	return &cstructs.ResourceLimits{
+		MemoryMax: l.memoryMax,
+		CpuMax:    float64(l.cpuRate) / jobCPURateScale * float64(compute.NumCores),
+	}
+}
+
+// resourceLimits returns the limits as reported in task stats.
+func (l cgroupLimits) resourceLimits() *cstructs.ResourceLimits {
+	if l.memoryMax <= 0 && (l.cpuQuota <= 0 || l.cpuPeriod <= 0) {
+		return nil
+	}
+	limits := &cstructs.ResourceLimits{}
+	if l.memoryMax > 0 {
+		limits.MemoryMax = uint64(l.memoryMax)
+	}
+	if l.cpuQuota > 0 && l.cpuPeriod > 0 {
+		limits.CpuMax = float64(l.cpuQuota) / float64(l.cpuPeriod)
+	}
+	return limits
+}
+
+func mbToBytes(n int64) int64 {
+	return n * 1024 * 1024
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobObjectLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	compute := cpustats.Compute{TotalCompute: 8000, NumCores: 4}
+	command := func(cpu, memory, memoryMax int64) *ExecCommand {
+		return &ExecCommand{
+			Resources: &drivers.Resources{
+				NomadResources: &structs.AllocatedTaskResources{
+					Cpu:    structs.AllocatedCpuResources{CpuShares: cpu},
+					Memory: structs.AllocatedMemoryResources{MemoryMB: memory, MemoryMaxMB: memoryMax},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		command *ExecCommand
+		exp     jobObjectLimits
+	}{
+		{
+			name:    "no resources",
+			command: &ExecCommand{},
+			exp:     jobObjectLimits{},
+		},
+		{
+			name:    "memory",
+			command: command(2000, 256, 0),
+			exp:     jobObjectLimits{memoryMax: 256 << 20, cpuRate: 2500},
+		},
+		{
+			name:    "memory max",
+			command: command(2000, 256, 512),
+			exp:     jobObjectLimits{memoryMax: 512 << 20, cpuRate: 2500},
+		},
+		{
+			name:    "no memory limit",
+			command: command(2000, 256, -1),
+			exp:     jobObjectLimits{cpuRate: 2500},
+		},
+		{
+			name:    "more cpu than node",
+			command: command(16000, 0, 0),
+			exp:     jobObjectLimits{cpuRate: jobCPURateScale},
+		},
+		{
+			name:    "no cpu",
+			command: command(0, 0, 0),
+			exp:     jobObjectLimits{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, newJobObjectLimits(tc.command, compute))
+		})
+	}
+
+	must.Nil(t, jobObjectLimits{}.resourceLimits(compute))
+	must.Eq(t, &cstructs.ResourceLimits{MemoryMax: 256 << 20, CpuMax: 1},
+		jobObjectLimits{memoryMax: 256 << 20, cpuRate: 2500}.resourceLimits(compute))
+}
+
+func TestCgroupLimits_resourceLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, cgroupLimits{cpuset: "0-3"}.resourceLimits())
+	must.Eq(t, &cstructs.ResourceLimits{MemoryMax: 1 << 30, CpuMax: 1.5},
+		cgroupLimits{cpuQuota: 150000, cpuPeriod: 100000, memoryMax: 1 << 30}.resourceLimits())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/client/lib/idset"
+	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// modes of set_mempolicy(2)
+const (
+	mpolPreferred  = 1
+	mpolBind       = 2
+	mpolInterleave = 3
+)
+
+// memoryPolicy returns the numa memory policy of the command and the numa
+// nodes it applies to, if any.
+func (c *ExecCommand) memoryPolicy() (string, string) {
+	if c.Resources == nil || c.Resources.LinuxResources == nil {
+		return "", ""
+	}
+	return c.Resources.LinuxResources.MemoryPolicy, c.Resources.LinuxResources.MemoryNodes
+}
+
+// parseMemoryPolicy returns the set_mempolicy mode and node mask of the numa
+// memory policy of a task over nodes, in cpuset list notation. Preferred
+// policies prefer the lowest of the nodes.
+func parseMemoryPolicy(policy, nodes string) (int, []uint64, error) {
+	set := idset.Parse[hw.NodeID](nodes)
+	if set.Empty() {
+		return 0, nil, fmt.Errorf("no numa nodes for memory policy %q", policy)
+	}
+	ids := set.Slice()
+
+	var mode int
+	switch policy {
+	case structs.BindMemoryPolicy:
+		mode = mpolBind
+	case structs.InterleaveMemoryPolicy:
+		mode = mpolInterleave
+	case structs.PreferredMemoryPolicy:
+		mode = mpolPreferred
+		ids = ids[:1]
+	default:
+		return 0, nil, fmt.Errorf("invalid memory policy %q", policy)
+	}
+
+	mask := make([]uint64, int(ids[len(ids)-1])/64+1)
+	for _, id := range ids {
+		mask[id/64] |= 1 << (id % 64)
+	}
+	return mode, mask, nil
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package executor
+
+// withMemoryPolicy calls f, since numa memory policies are only supported on
+// linux.
+func withMemoryPolicy(_, _ string, f func() error) error {
+	return f()
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// withMemoryPolicy calls f, which starts the task, with the numa memory policy
+// of the task set on the calling thread so the task inherits it. The policy of
+// the thread is reset once f returns.
+func withMemoryPolicy(policy, nodes string, f func() error) error {
+	if policy == "" {
+		return f()
+	}
+	mode, mask, err := parseMemoryPolicy(policy, nodes)
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := setMempolicy(mode, mask); err != nil {
+		return fmt.Errorf("failed to set memory policy %q on nodes %s: %w", policy, nodes, err)
+	}
+	defer setMempolicy(0, nil)
+	return f()
+}
+
+func setMempolicy(mode int, mask []uint64) error {
+	var ptr unsafe.Pointer
+	if len(mask) > 0 {
+		ptr = unsafe.Pointer(&mask[0])
+	}
+	// the kernel ignores the last bit of maxnode, so libnuma passes one more
+	// than the bits of the mask, and so do we
+	maxnode := uintptr(len(mask)*64 + 1)
+	_, _, errno := unix.Syscall(unix.SYS_SET_MEMPOLICY, uintptr(mode), uintptr(ptr), maxnode)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
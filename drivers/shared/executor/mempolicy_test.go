@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestExecutor_parseMemoryPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	mode, mask, err := parseMemoryPolicy("bind", "0,2")
+	must.NoError(t, err)
+	must.Eq(t, mpolBind, mode)
+	must.Eq(t, []uint64{0b101}, mask)
+
+	mode, mask, err = parseMemoryPolicy("interleave", "1,65")
+	must.NoError(t, err)
+	must.Eq(t, mpolInterleave, mode)
+	must.Eq(t, []uint64{0b10, 0b10}, mask)
+
+	// preferred policies prefer a single node
+	mode, mask, err = parseMemoryPolicy("preferred", "1-3")
+	must.NoError(t, err)
+	must.Eq(t, mpolPreferred, mode)
+	must.Eq(t, []uint64{0b10}, mask)
+
+	_, _, err = parseMemoryPolicy("local", "0")
+	must.ErrorContains(t, err, "invalid memory policy")
+
+	_, _, err = parseMemoryPolicy("bind", "")
+	must.ErrorContains(t, err, "no numa nodes")
+}
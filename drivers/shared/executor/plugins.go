@@ -26,14 +26,27 @@ type ExecutorConfig struct {
 
 	// Compute contains system cpu compute information
 	Compute cpustats.Compute
+
+	// StatsRecordFile if set is the file the executor records the stats
+	// samples it sends to, for debugging
+	StatsRecordFile string `json:",omitempty"`
+
+	// StatsMode if set is the procstats mode the executor reads the
+	// processes of its task in
+	StatsMode string `json:",omitempty"`
 }
 
 func GetPluginMap(logger hclog.Logger, fsIsolation bool, compute cpustats.Compute) map[string]plugin.Plugin {
+	return pluginMap(logger, fsIsolation, compute, ExecutorProtocolLatest)
+}
+
+func pluginMap(logger hclog.Logger, fsIsolation bool, compute cpustats.Compute, protocolVersion int) map[string]plugin.Plugin {
 	return map[string]plugin.Plugin{
 		"executor": &ExecutorPlugin{
-			logger:      logger,
-			fsIsolation: fsIsolation,
-			compute:     compute,
+			logger:          logger,
+			fsIsolation:     fsIsolation,
+			compute:         compute,
+			protocolVersion: protocolVersion,
 		},
 	}
 }
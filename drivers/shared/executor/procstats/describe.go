@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package procstats
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Describe returns the pid, parent, command line, state and start time of
+// the given processes, sorted by pid. Processes that exit while being
+// described are omitted.
+func Describe(pids set.Collection[ProcessID]) []*drivers.TaskProcess {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	procs := make([]*drivers.TaskProcess, 0, pids.Size())
+	for pid := range pids.Items() {
+		p, err := process.NewProcessWithContext(ctx, int32(pid))
+		if err != nil {
+			continue
+		}
+
+		tp := &drivers.TaskProcess{Pid: pid}
+		if ppid, err := p.PpidWithContext(ctx); err == nil {
+			tp.Ppid = int(ppid)
+		}
+		if cmdline, err := p.CmdlineSliceWithContext(ctx); err == nil {
+			tp.Cmdline = cmdline
+		}
+		if status, err := p.StatusWithContext(ctx); err == nil {
+			tp.State = strings.Join(status, ",")
+		}
+		if created, err := p.CreateTimeWithContext(ctx); err == nil {
+			tp.StartTime = time.UnixMilli(created).UnixNano()
+		}
+		procs = append(procs, tp)
+	}
+
+	slices.SortFunc(procs, func(a, b *drivers.TaskProcess) int {
+		return a.Pid - b.Pid
+	})
+	return procs
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package procstats
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDescribe(t *testing.T) {
+	ci.Parallel(t)
+
+	// the second pid is very unlikely to exist and must be omitted
+	procs := Describe(set.From([]ProcessID{os.Getpid(), 1 << 30}))
+	must.Len(t, 1, procs)
+
+	p := procs[0]
+	must.Eq(t, os.Getpid(), p.Pid)
+	must.Eq(t, os.Getppid(), p.Ppid)
+	must.SliceNotEmpty(t, p.Cmdline)
+	must.NotEq(t, "", p.State)
+	must.Positive(t, p.StartTime)
+}
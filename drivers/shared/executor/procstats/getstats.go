@@ -5,25 +5,47 @@ package procstats
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/shirou/gopsutil/v3/process"
 	"oss.indeed.com/go/libtime"
 )
 
-func New(compute cpustats.Compute, pl ProcessList) ProcessStats {
-	const cacheTTL = 5 * time.Second
+// readTimeout is how long reading the usage of a process may take before it
+// is reported as unreadable. Reading the /proc files of a process can block
+// while it is in uninterruptible sleep, such as on a hung NFS mount.
+const readTimeout = 1 * time.Second
+
+func New(compute cpustats.Compute, pl ProcessList, logger hclog.Logger) ProcessStats {
+	return NewWithMode(compute, pl, ModeFull, logger)
+}
+
+// NewWithMode returns a ProcessStats reading the processes of a task in the
+// given mode.
+func NewWithMode(compute cpustats.Compute, pl ProcessList, mode Mode, logger hclog.Logger) ProcessStats {
+	cacheTTL := 5 * time.Second
+	read := readUsage
+	if mode == ModeJiffies {
+		cacheTTL = 1 * time.Second
+		read = readJiffies
+	}
 	return &linuxProcStats{
-		cacheTTL: cacheTTL,
-		procList: pl,
-		compute:  compute,
-		clock:    libtime.SystemClock(),
-		latest:   make(map[ProcessID]*stats),
-		cache:    make(ProcUsages),
+		cacheTTL:    cacheTTL,
+		read:        read,
+		readTimeout: readTimeout,
+		procList:    pl,
+		compute:     compute,
+		clock:       libtime.SystemClock(),
+		logger:      logger.Named("procstats"),
+		latest:      make(map[ProcessID]*stats),
+		cache:       make(ProcUsages),
+		blocked:     make(map[ProcessID]struct{}),
 	}
 }
 
@@ -31,18 +53,64 @@ type stats struct {
 	TotalCPU  *cpustats.Tracker
 	UserCPU   *cpustats.Tracker
 	SystemCPU *cpustats.Tracker
+
+	// cpuSeconds is the CPU time used by the process when it was last read
+	cpuSeconds float64
+
+	// last is the usage of the process when it was last read
+	last *drivers.ResourceUsage
+}
+
+// unreadable returns the usage of the process while its reads are blocked,
+// which is the usage it was last read with, so the CPU time and memory of the
+// task don't drop while the process can't be read.
+func (s *stats) unreadable() *drivers.ResourceUsage {
+	ru := &drivers.ResourceUsage{
+		MemoryStats: new(drivers.MemoryStats),
+		CpuStats:    new(drivers.CpuStats),
+		Unreadable:  true,
+	}
+	if s.last != nil && s.last.MemoryStats != nil {
+		ms := *s.last.MemoryStats
+		ru.MemoryStats = &ms
+	}
+	if s.last != nil && s.last.CpuStats != nil {
+		cs := *s.last.CpuStats
+		ru.CpuStats = &cs
+	}
+	return ru
 }
 
 type linuxProcStats struct {
-	cacheTTL time.Duration
-	procList ProcessList
-	clock    libtime.Clock
-	compute  cpustats.Compute
+	cacheTTL    time.Duration
+	read        func(ProcessID) (*processUsage, error)
+	readTimeout time.Duration
+	procList    ProcessList
+	clock       libtime.Clock
+	compute     cpustats.Compute
+	logger      hclog.Logger
+
+	// collectLock serializes collections, which read the processes without
+	// holding lock, so reads blocked on a process don't hold back the
+	// readers of the collected stats
+	collectLock sync.Mutex
 
-	lock   sync.Mutex
+	// latest is only accessed while collecting
 	latest map[ProcessID]*stats
-	cache  ProcUsages
-	at     time.Time
+
+	lock  sync.Mutex
+	cache ProcUsages
+	at    time.Time
+
+	// exitedCPUSeconds is the CPU time used by processes of the task which
+	// have exited, so the task's total CPU time does not drop when they do
+	exitedCPUSeconds float64
+
+	// blocked are the processes whose read timed out and hasn't returned
+	// yet, which aren't read again until it does so blocked reads don't
+	// pile up
+	blockedLock sync.Mutex
+	blocked     map[ProcessID]struct{}
 }
 
 func (lps *linuxProcStats) expired() bool {
@@ -51,25 +119,36 @@ func (lps *linuxProcStats) expired() bool {
 }
 
 // scanPIDs will update lps.latest with the set of detected live pids that make
-// up the task process tree / are in the tasks cgroup
+// up the task process tree / are in the tasks cgroup. Must be called with
+// collectLock held.
 func (lps *linuxProcStats) scanPIDs() {
 	currentPIDs := lps.procList.ListProcesses()
 
 	// remove old pids no longer present
-	for pid := range lps.latest {
+	var exited float64
+	for pid, s := range lps.latest {
 		if !currentPIDs.Contains(pid) {
+			exited += s.cpuSeconds
 			delete(lps.latest, pid)
 		}
 	}
+	lps.lock.Lock()
+	lps.exitedCPUSeconds += exited
+	lps.lock.Unlock()
 
 	// insert trackers for new pids not yet present
 	for pid := range currentPIDs.Items() {
 		if _, exists := lps.latest[pid]; !exists {
-			lps.latest[pid] = &stats{
+			s := &stats{
 				TotalCPU:  cpustats.New(lps.compute),
 				UserCPU:   cpustats.New(lps.compute),
 				SystemCPU: cpustats.New(lps.compute),
 			}
+			logger := lps.logger.With("pid", pid)
+			s.TotalCPU.SetLogger(logger.Named("total_cpu"))
+			s.UserCPU.SetLogger(logger.Named("user_cpu"))
+			s.SystemCPU.SetLogger(logger.Named("system_cpu"))
+			lps.latest[pid] = s
 		}
 	}
 }
@@ -78,12 +157,24 @@ func (lps *linuxProcStats) cached() ProcUsages {
 	return lps.cache
 }
 
-func (lps *linuxProcStats) StatProcesses() ProcUsages {
+// ExitedCPUSeconds returns the CPU time used by the processes of the task
+// which exited before the latest call to StatProcesses.
+func (lps *linuxProcStats) ExitedCPUSeconds() float64 {
 	lps.lock.Lock()
 	defer lps.lock.Unlock()
+	return lps.exitedCPUSeconds
+}
 
-	if !lps.expired() {
-		return lps.cache
+func (lps *linuxProcStats) StatProcesses() ProcUsages {
+	lps.collectLock.Lock()
+	defer lps.collectLock.Unlock()
+
+	lps.lock.Lock()
+	expired := lps.expired()
+	cache := lps.cache
+	lps.lock.Unlock()
+	if !expired {
+		return cache
 	}
 
 	// the stats are expired, scan for new information
@@ -92,44 +183,136 @@ func (lps *linuxProcStats) StatProcesses() ProcUsages {
 	// create the response resource usage map
 	var result = make(ProcUsages)
 	for pid, s := range lps.latest {
-		p, err := process.NewProcess(int32(pid))
-		if err != nil {
+		spid := strconv.Itoa(pid)
+		usage, err := lps.readBounded(pid)
+		if err == errReadTimeout {
+			result[spid] = s.unreadable()
 			continue
 		}
-
-		getMemory := func() *drivers.MemoryStats {
-			ms := new(drivers.MemoryStats)
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-			defer cancel()
-			if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil {
-				ms.RSS = memInfo.RSS
-				ms.Swap = memInfo.Swap
-				ms.Measured = ExecutorBasicMeasuredMemStats
-			}
-			return ms
+		if err != nil {
+			continue
 		}
 
-		getCPU := func() *drivers.CpuStats {
-			cs := new(drivers.CpuStats)
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-			defer cancel()
-			if cpuInfo, err := p.TimesWithContext(ctx); err == nil {
-				const second = float64(time.Second)
-				cs.SystemMode = s.SystemCPU.Percent(cpuInfo.System * second)
-				cs.UserMode = s.UserCPU.Percent(cpuInfo.User * second)
-				cs.Percent = s.TotalCPU.Percent(cpuInfo.Total() * second)
-				cs.Measured = ExecutorBasicMeasuredCpuStats
-			}
-			return cs
+		cs := new(drivers.CpuStats)
+		if usage.cpu != nil {
+			cs.SystemMode = s.SystemCPU.Percent(usage.cpu.system)
+			cs.UserMode = s.UserCPU.Percent(usage.cpu.user)
+			cs.Percent = s.TotalCPU.Percent(usage.cpu.total)
+			cs.TotalCpuSeconds = usage.cpu.total / float64(time.Second)
+			cs.Measured = ExecutorBasicMeasuredCpuStats
+			s.cpuSeconds = cs.TotalCpuSeconds
 		}
 
-		spid := strconv.Itoa(pid)
-		result[spid] = &drivers.ResourceUsage{
-			MemoryStats: getMemory(),
-			CpuStats:    getCPU(),
+		s.last = &drivers.ResourceUsage{
+			MemoryStats: usage.memory,
+			CpuStats:    cs,
 		}
+		result[spid] = s.last
 	}
 
+	lps.lock.Lock()
+	defer lps.lock.Unlock()
 	lps.cache = result
 	return result
 }
+
+// errReadTimeout is returned by readBounded for processes whose read timed
+// out, or is still blocked since a previous collection.
+var errReadTimeout = errors.New("timed out reading process")
+
+// readBounded reads the usage of a process, giving up after the read
+// timeout. A read which times out is left to return in the background, and
+// the process is reported as timed out until it does.
+func (lps *linuxProcStats) readBounded(pid ProcessID) (*processUsage, error) {
+	lps.blockedLock.Lock()
+	_, blocked := lps.blocked[pid]
+	lps.blockedLock.Unlock()
+	if blocked {
+		return nil, errReadTimeout
+	}
+
+	type result struct {
+		usage *processUsage
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		usage, err := lps.read(pid)
+		done <- result{usage, err}
+
+		lps.blockedLock.Lock()
+		delete(lps.blocked, pid)
+		lps.blockedLock.Unlock()
+	}()
+
+	timer := time.NewTimer(lps.readTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.usage, r.err
+	case <-timer.C:
+	}
+
+	// The read may have returned since the timer fired, in which case the
+	// process isn't marked as blocked after being unmarked
+	lps.blockedLock.Lock()
+	defer lps.blockedLock.Unlock()
+	select {
+	case r := <-done:
+		return r.usage, r.err
+	default:
+		lps.blocked[pid] = struct{}{}
+		return nil, errReadTimeout
+	}
+}
+
+// processUsage is the resource usage of a single process.
+type processUsage struct {
+	// cpu is nil if the CPU times of the process could not be read
+	cpu    *cpuTimes
+	memory *drivers.MemoryStats
+}
+
+// cpuTimes are the CPU times used by a process, in nanoseconds.
+type cpuTimes struct {
+	user   float64
+	system float64
+	total  float64
+}
+
+// psutilUsage reads the resource usage of a process with gopsutil.
+func psutilUsage(pid ProcessID) (*processUsage, error) {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &processUsage{memory: new(drivers.MemoryStats)}
+
+	getMemory := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil {
+			usage.memory.RSS = memInfo.RSS
+			usage.memory.Swap = memInfo.Swap
+			usage.memory.Measured = ExecutorBasicMeasuredMemStats
+		}
+	}
+
+	getCPU := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		if cpuInfo, err := p.TimesWithContext(ctx); err == nil {
+			const second = float64(time.Second)
+			usage.cpu = &cpuTimes{
+				user:   cpuInfo.User * second,
+				system: cpuInfo.System * second,
+				total:  cpuInfo.Total() * second,
+			}
+		}
+	}
+
+	getMemory()
+	getCPU()
+	return usage, nil
+}
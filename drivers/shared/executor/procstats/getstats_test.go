@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package procstats
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+type staticProcessList []ProcessID
+
+func (l staticProcessList) ListProcesses() set.Collection[ProcessID] {
+	return set.From(l)
+}
+
+func TestStatProcesses_ReadTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	var blockedReads atomic.Int32
+	unblock := make(chan struct{})
+	read := func(pid ProcessID) (*processUsage, error) {
+		if pid == 2 {
+			blockedReads.Add(1)
+			<-unblock
+		}
+		return &processUsage{
+			cpu:    &cpuTimes{user: 1e9, total: 1e9},
+			memory: &drivers.MemoryStats{RSS: 4096, Measured: []string{"RSS"}},
+		}, nil
+	}
+
+	lps := NewWithMode(cpustats.Compute{TotalCompute: 1000, NumCores: 1},
+		staticProcessList{1, 2}, ModeFull, testlog.HCLogger(t)).(*linuxProcStats)
+	lps.cacheTTL = 0
+	lps.read = read
+	lps.readTimeout = 20 * time.Millisecond
+
+	// The blocked process is reported as unreadable without holding back
+	// the others
+	usages := lps.StatProcesses()
+	must.MapLen(t, 2, usages)
+	must.False(t, usages["1"].Unreadable)
+	must.Eq(t, 4096, usages["1"].MemoryStats.RSS)
+	must.True(t, usages["2"].Unreadable)
+	must.NotNil(t, usages["2"].CpuStats)
+	must.NotNil(t, usages["2"].MemoryStats)
+
+	// The process isn't read again while its read is blocked
+	usages = lps.StatProcesses()
+	must.True(t, usages["2"].Unreadable)
+	must.Eq(t, 1, blockedReads.Load())
+
+	// Once the read returns, the process is read again
+	close(unblock)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return !lps.StatProcesses()["2"].Unreadable
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, 2, blockedReads.Load())
+}
+
+func TestStatProcesses_ReadTimeout_LastUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	var block atomic.Bool
+	unblock := make(chan struct{})
+	defer close(unblock)
+	read := func(pid ProcessID) (*processUsage, error) {
+		if pid == 2 && block.Load() {
+			<-unblock
+		}
+		return &processUsage{
+			cpu:    &cpuTimes{user: 1e9, total: 1e9},
+			memory: &drivers.MemoryStats{RSS: 4096, Measured: []string{"RSS"}},
+		}, nil
+	}
+
+	lps := NewWithMode(cpustats.Compute{TotalCompute: 1000, NumCores: 1},
+		staticProcessList{1, 2}, ModeFull, testlog.HCLogger(t)).(*linuxProcStats)
+	lps.cacheTTL = 0
+	lps.read = read
+	lps.readTimeout = 200 * time.Millisecond
+
+	usages := lps.StatProcesses()
+	must.False(t, usages["2"].Unreadable)
+
+	// The blocked process is reported with the usage it was last read with,
+	// so the CPU time of the task doesn't drop
+	block.Store(true)
+	done := make(chan ProcUsages)
+	go func() { done <- lps.StatProcesses() }()
+
+	// Readers of the collected stats aren't held back by the blocked read
+	time.Sleep(20 * time.Millisecond)
+	start := time.Now()
+	must.Eq(t, 0, lps.ExitedCPUSeconds())
+	must.Less(t, 100*time.Millisecond, time.Since(start))
+
+	usages = <-done
+	must.True(t, usages["2"].Unreadable)
+	must.Eq(t, 1, usages["2"].CpuStats.TotalCpuSeconds)
+	must.Eq(t, 4096, usages["2"].MemoryStats.RSS)
+	must.Eq(t, 2, Aggregate(cpustats.New(lps.compute), usages, 0).ResourceUsage.CpuStats.TotalCpuSeconds)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build darwin
+
+package procstats
+
+import (
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/lib/lang"
+	"golang.org/x/sys/unix"
+)
+
+// List the process tree starting at the given executorPID. The process table
+// is read with a single sysctl rather than running pgrep(1) for every
+// process in the tree.
+func List(executorPID int) set.Collection[ProcessID] {
+	result := set.New[ProcessID](10)
+	result.Insert(executorPID)
+
+	procs, err := unix.SysctlKinfoProcSlice("kern.proc.all")
+	if err != nil {
+		return result
+	}
+
+	children := make(map[ProcessID][]ProcessID, len(procs))
+	for _, p := range procs {
+		ppid := ProcessID(p.Eproc.Ppid)
+		children[ppid] = append(children[ppid], ProcessID(p.Proc.P_pid))
+	}
+
+	stack := lang.NewStack[ProcessID]()
+	stack.Push(executorPID)
+	for !stack.Empty() {
+		for _, child := range children[stack.Pop()] {
+			if result.Insert(child) {
+				stack.Push(child)
+			}
+		}
+	}
+
+	return result
+}
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-//go:build !linux && !windows
+//go:build !linux && !windows && !darwin
 
 package procstats
 
@@ -4,6 +4,7 @@
 package procstats
 
 import (
+	"slices"
 	"time"
 
 	"github.com/hashicorp/go-set/v3"
@@ -15,7 +16,31 @@ import (
 var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
-	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
+	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent", "Total CPU Seconds"}
+
+	// executorSharedMeasuredMemStats are the memory stats of the basic
+	// executor on platforms where it tells apart the shared memory in RSS
+	executorSharedMeasuredMemStats = []string{"RSS", "Swap", "Shared"}
+
+	// executorJiffiesMeasuredMemStats are the memory stats of the basic
+	// executor reading processes in ModeJiffies
+	executorJiffiesMeasuredMemStats = []string{"RSS"}
+)
+
+// Mode is how the basic executor reads the resource usage of the processes
+// of a task.
+type Mode string
+
+const (
+	// ModeFull reads the CPU times and memory of each process with gopsutil,
+	// and the shared memory from procfs on Linux, every 5 seconds.
+	ModeFull Mode = "full"
+
+	// ModeJiffies reads only the CPU times and RSS of each process from its
+	// /proc/<pid>/stat file, every second. It is meant for nodes which want
+	// a fine resolution of the CPU usage of tasks at a minimal cost.
+	// Platforms without procfs read processes as in ModeFull.
+	ModeJiffies Mode = "jiffies"
 )
 
 // ProcessID is an alias for int; it just helps us identify where PIDs from
@@ -32,6 +57,10 @@ type ProcUsages map[string]*drivers.ResourceUsage
 // a task.
 type ProcessStats interface {
 	StatProcesses() ProcUsages
+
+	// ExitedCPUSeconds returns the CPU time used by processes of the task
+	// which are no longer running.
+	ExitedCPUSeconds() float64
 }
 
 // A ProcessList is anything (i.e. a task driver) that implements ListProcesses
@@ -40,21 +69,38 @@ type ProcessList interface {
 	ListProcesses() set.Collection[ProcessID]
 }
 
-// Aggregate combines a given ProcUsages with the Tracker for the Client.
-func Aggregate(systemStats *cpustats.Tracker, procStats ProcUsages) *drivers.TaskResourceUsage {
+// Aggregate combines a given ProcUsages with the Tracker for the Client. The
+// CPU time of exited processes is added to the total CPU time of the live ones.
+func Aggregate(systemStats *cpustats.Tracker, procStats ProcUsages, exitedCPUSeconds float64) *drivers.TaskResourceUsage {
 	ts := time.Now().UTC().UnixNano()
 	var (
 		systemModeCPU, userModeCPU, percent float64
-		totalRSS, totalSwap                 uint64
+		totalRSS, totalSwap, totalUsage     uint64
+		shared                              []uint64
+		memoryMeasured                      []string
 	)
+	cpuSeconds := exitedCPUSeconds
 
 	for _, pidStat := range procStats {
-		systemModeCPU += pidStat.CpuStats.SystemMode
-		userModeCPU += pidStat.CpuStats.UserMode
-		percent += pidStat.CpuStats.Percent
-
-		totalRSS += pidStat.MemoryStats.RSS
-		totalSwap += pidStat.MemoryStats.Swap
+		// Processes whose stats could not be read are skipped
+		if pidStat == nil {
+			continue
+		}
+		if cs := pidStat.CpuStats; cs != nil {
+			systemModeCPU += cs.SystemMode
+			userModeCPU += cs.UserMode
+			percent += cs.Percent
+			cpuSeconds += cs.TotalCpuSeconds
+		}
+		if ms := pidStat.MemoryStats; ms != nil {
+			totalRSS += ms.RSS
+			totalSwap += ms.Swap
+			totalUsage += ms.Usage
+			if slices.Contains(ms.Measured, "Shared") {
+				shared = append(shared, ms.Shared)
+			}
+			memoryMeasured = intersectMeasured(memoryMeasured, ms.Measured)
+		}
 	}
 
 	totalCPU := &drivers.CpuStats{
@@ -63,14 +109,32 @@ func Aggregate(systemStats *cpustats.Tracker, procStats ProcUsages) *drivers.Tas
 		Percent:    percent,
 		Measured:   ExecutorBasicMeasuredCpuStats,
 		TotalTicks: systemStats.TicksConsumed(percent),
+
+		TotalCpuSeconds: cpuSeconds,
 	}
 
 	totalMemory := &drivers.MemoryStats{
 		RSS:      totalRSS,
 		Swap:     totalSwap,
+		Usage:    totalUsage,
 		Measured: ExecutorBasicMeasuredMemStats,
 	}
 
+	// The total only measures the stats every process measured, such as
+	// only RSS for processes read in ModeJiffies, or RSS and Usage on macOS
+	if memoryMeasured != nil {
+		totalMemory.Measured = memoryMeasured
+	}
+
+	// The processes of a task commonly map the same shared memory, which
+	// each of them includes in its RSS
+	if len(shared) > 0 {
+		totalMemory.DedupShared(shared)
+		if !slices.Contains(totalMemory.Measured, "Shared") {
+			totalMemory.Measured = append(slices.Clone(totalMemory.Measured), "Shared")
+		}
+	}
+
 	resourceUsage := drivers.ResourceUsage{
 		MemoryStats: totalMemory,
 		CpuStats:    totalCPU,
@@ -82,6 +146,23 @@ func Aggregate(systemStats *cpustats.Tracker, procStats ProcUsages) *drivers.Tas
 	}
 }
 
+// intersectMeasured returns the stats measured in both a running
+// intersection and the Measured of a process. Processes which measured no
+// stats are left out, and the intersection is nil until one measured some.
+// Shared is left out, since the shared memory of the processes which measured
+// it is deduplicated regardless.
+func intersectMeasured(measured, process []string) []string {
+	if len(process) == 0 {
+		return measured
+	}
+	if measured == nil {
+		measured = slices.Clone(process)
+	}
+	return slices.DeleteFunc(measured, func(m string) bool {
+		return m == "Shared" || !slices.Contains(process, m)
+	})
+}
+
 func list(executorPID int, processes func() ([]ps.Process, error)) set.Collection[ProcessID] {
 	processFamily := set.From([]ProcessID{executorPID})
 
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package procstats
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestAggregate_TotalCpuSeconds(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := func(cpuSeconds float64) *drivers.ResourceUsage {
+		return &drivers.ResourceUsage{
+			MemoryStats: new(drivers.MemoryStats),
+			CpuStats:    &drivers.CpuStats{TotalCpuSeconds: cpuSeconds},
+		}
+	}
+	tracker := cpustats.New(cpustats.Compute{TotalCompute: 1000, NumCores: 1})
+
+	result := Aggregate(tracker, ProcUsages{"1": usage(2), "2": usage(3)}, 0)
+	must.Eq(t, 5, result.ResourceUsage.CpuStats.TotalCpuSeconds)
+
+	// the CPU time of exited processes is still counted
+	result = Aggregate(tracker, ProcUsages{"1": usage(2.5)}, 3)
+	must.Eq(t, 5.5, result.ResourceUsage.CpuStats.TotalCpuSeconds)
+}
+
+func TestAggregate_Shared(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := func(rss, shared uint64, measured []string) *drivers.ResourceUsage {
+		return &drivers.ResourceUsage{
+			MemoryStats: &drivers.MemoryStats{RSS: rss, Shared: shared, Measured: measured},
+			CpuStats:    new(drivers.CpuStats),
+		}
+	}
+	tracker := cpustats.New(cpustats.Compute{TotalCompute: 1000, NumCores: 1})
+
+	// the shared memory each process counts in its RSS is counted once
+	result := Aggregate(tracker, ProcUsages{
+		"1": usage(100, 40, executorSharedMeasuredMemStats),
+		"2": usage(60, 30, executorSharedMeasuredMemStats),
+	}, 0)
+	must.Eq(t, 130, result.ResourceUsage.MemoryStats.RSS)
+	must.Eq(t, 40, result.ResourceUsage.MemoryStats.Shared)
+	must.Eq(t, executorSharedMeasuredMemStats, result.ResourceUsage.MemoryStats.Measured)
+
+	// without shared memory measured, RSS is summed
+	result = Aggregate(tracker, ProcUsages{
+		"1": usage(100, 0, ExecutorBasicMeasuredMemStats),
+		"2": usage(60, 0, ExecutorBasicMeasuredMemStats),
+	}, 0)
+	must.Eq(t, 160, result.ResourceUsage.MemoryStats.RSS)
+	must.Eq(t, ExecutorBasicMeasuredMemStats, result.ResourceUsage.MemoryStats.Measured)
+}
+
+func TestAggregate_Measured(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := func(rss, usage uint64, measured ...string) *drivers.ResourceUsage {
+		return &drivers.ResourceUsage{
+			MemoryStats: &drivers.MemoryStats{RSS: rss, Usage: usage, Measured: measured},
+			CpuStats:    new(drivers.CpuStats),
+		}
+	}
+	tracker := cpustats.New(cpustats.Compute{TotalCompute: 1000, NumCores: 1})
+
+	// the stats the processes measured are kept, such as on macOS
+	result := Aggregate(tracker, ProcUsages{
+		"1": usage(100, 150, "RSS", "Usage"),
+		"2": usage(60, 80, "RSS", "Usage"),
+	}, 0)
+	must.Eq(t, []string{"RSS", "Usage"}, result.ResourceUsage.MemoryStats.Measured)
+	must.Eq(t, 230, result.ResourceUsage.MemoryStats.Usage)
+
+	// only the stats every process measured are
+	result = Aggregate(tracker, ProcUsages{
+		"1": usage(100, 0, "RSS", "Swap"),
+		"2": usage(60, 0, "RSS"),
+		"3": usage(0, 0),
+	}, 0)
+	must.Eq(t, []string{"RSS"}, result.ResourceUsage.MemoryStats.Measured)
+
+	// processes which measured nothing report the basic stats
+	result = Aggregate(tracker, ProcUsages{"1": usage(100, 0)}, 0)
+	must.Eq(t, ExecutorBasicMeasuredMemStats, result.ResourceUsage.MemoryStats.Measured)
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the aggregation tests")
+
+// aggregateInput is the input of a golden aggregation test, read from a
+// testdata/aggregate/*.json file
+type aggregateInput struct {
+	Compute          cpustats.Compute
+	ExitedCPUSeconds float64
+	Processes        ProcUsages
+}
+
+// TestAggregate_Golden asserts the aggregation of the stats of the processes
+// of each testdata/aggregate/*.json file matches its .golden file. Run the
+// tests with -update to regenerate the golden files.
+func TestAggregate_Golden(t *testing.T) {
+	ci.Parallel(t)
+
+	inputs, err := filepath.Glob("testdata/aggregate/*.json")
+	must.NoError(t, err)
+	must.SliceNotEmpty(t, inputs)
+
+	for _, path := range inputs {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			b, err := os.ReadFile(path)
+			must.NoError(t, err)
+			var input aggregateInput
+			must.NoError(t, json.Unmarshal(b, &input))
+
+			tracker := cpustats.New(input.Compute)
+			result := Aggregate(tracker, input.Processes, input.ExitedCPUSeconds)
+			got, err := json.MarshalIndent(result.ResourceUsage, "", "  ")
+			must.NoError(t, err)
+			got = append(got, '\n')
+
+			golden := strings.TrimSuffix(path, ".json") + ".golden"
+			if *updateGolden {
+				must.NoError(t, os.WriteFile(golden, got, 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			must.NoError(t, err)
+			must.Eq(t, string(want), string(got))
+		})
+	}
+}
+
+// FuzzAggregate asserts the stats of processes read from malformed or racing
+// /proc files can't panic the aggregation, and that the shared memory of the
+// processes is never deduplicated below zero.
+func FuzzAggregate(f *testing.F) {
+	f.Add(uint64(100), uint64(40), true, 12.5, 2.0, false, uint64(60), uint64(30), true, 7.5, 1.0, false, 3.0)
+	f.Add(uint64(10), uint64(40), true, 0.0, 0.0, false, uint64(0), uint64(90), true, 0.0, 0.0, false, 0.0)
+	f.Add(uint64(1<<63), uint64(0), false, -1.0, -5.0, false, uint64(1<<63), uint64(0), false, 1e308, 1e308, true, -1.0)
+
+	f.Fuzz(func(t *testing.T,
+		rss1, shared1 uint64, measuredShared1 bool, percent1, cpuSeconds1 float64, nil1 bool,
+		rss2, shared2 uint64, measuredShared2 bool, percent2, cpuSeconds2 float64, nil2 bool,
+		exitedCPUSeconds float64) {
+
+		usage := func(rss, shared uint64, measuredShared bool, percent, cpuSeconds float64, missing bool) *drivers.ResourceUsage {
+			measured := ExecutorBasicMeasuredMemStats
+			if measuredShared {
+				measured = executorSharedMeasuredMemStats
+			}
+			ru := &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{RSS: rss, Shared: shared, Measured: measured},
+				CpuStats:    &drivers.CpuStats{Percent: percent, TotalCpuSeconds: cpuSeconds},
+			}
+			if missing {
+				ru.MemoryStats, ru.CpuStats = nil, nil
+			}
+			return ru
+		}
+
+		tracker := cpustats.New(cpustats.Compute{TotalCompute: 1000, NumCores: 1})
+		result := Aggregate(tracker, ProcUsages{
+			"1": usage(rss1, shared1, measuredShared1, percent1, cpuSeconds1, nil1),
+			"2": usage(rss2, shared2, measuredShared2, percent2, cpuSeconds2, nil2),
+		}, exitedCPUSeconds)
+		must.NotNil(t, result.ResourceUsage.MemoryStats)
+		must.NotNil(t, result.ResourceUsage.CpuStats)
+
+		// Without wrapping, the deduplicated RSS never exceeds the sum
+		if !nil1 && !nil2 && rss1 <= math.MaxUint64-rss2 {
+			must.LessEq(t, rss1+rss2, result.ResourceUsage.MemoryStats.RSS)
+		}
+	})
+}
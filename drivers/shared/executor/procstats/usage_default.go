@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !darwin && !linux
+
+package procstats
+
+func readUsage(pid ProcessID) (*processUsage, error) {
+	return psutilUsage(pid)
+}
+
+func readJiffies(pid ProcessID) (*processUsage, error) {
+	return readUsage(pid)
+}
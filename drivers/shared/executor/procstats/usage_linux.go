@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package procstats
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// clockTicks is the USER_HZ of the kernel, the unit of the CPU times in
+// /proc/<pid>/stat, which is 100 on all the architectures Nomad supports.
+const clockTicks = 100
+
+// readUsage reads the resource usage of a process with gopsutil, and the
+// shared memory included in its RSS from procfs. Processes in uninterruptible
+// sleep are only read from their /proc/<pid>/stat file, since reading their
+// memory waits on locks they may hold until they wake up.
+func readUsage(pid ProcessID) (*processUsage, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	if procState(stat) == 'D' {
+		return parseProcStat(stat, uint64(os.Getpagesize()))
+	}
+
+	usage, err := psutilUsage(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The memory stats are unset if gopsutil could not read them
+	if usage.memory.Measured == nil {
+		return usage, nil
+	}
+	status, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return usage, nil
+	}
+	if shared, ok := parseRssShmem(status); ok {
+		usage.memory.Shared = shared
+		usage.memory.Measured = executorSharedMeasuredMemStats
+	}
+	return usage, nil
+}
+
+// parseRssShmem returns the resident shared memory of a process in bytes
+// from the contents of its /proc/<pid>/status file. Kernels older than 4.5
+// don't report it.
+func parseRssShmem(status []byte) (uint64, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(status))
+	for scanner.Scan() {
+		value, ok := bytes.CutPrefix(scanner.Bytes(), []byte("RssShmem:"))
+		if !ok {
+			continue
+		}
+		// The value is in kB, such as "RssShmem:\t    1024 kB"
+		fields := bytes.Fields(value)
+		if len(fields) != 2 || string(fields[1]) != "kB" {
+			return 0, false
+		}
+		kb, err := strconv.ParseUint(string(fields[0]), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return kb * 1024, true
+	}
+	return 0, false
+}
+
+// readJiffies reads the CPU times and RSS of a process from its
+// /proc/<pid>/stat file alone, instead of the several files read by
+// gopsutil and readUsage.
+func readJiffies(pid ProcessID) (*processUsage, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	return parseProcStat(stat, uint64(os.Getpagesize()))
+}
+
+// procState returns the state of a process from the contents of its
+// /proc/<pid>/stat file, such as 'R' for running or 'D' for uninterruptible
+// sleep, or 0 if the file is invalid.
+func procState(stat []byte) byte {
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 {
+		return 0
+	}
+	fields := bytes.Fields(stat[i+1:])
+	if len(fields) == 0 || len(fields[0]) != 1 {
+		return 0
+	}
+	return fields[0][0]
+}
+
+// parseProcStat returns the usage of a process from the contents of its
+// /proc/<pid>/stat file. The command name of the process may contain spaces
+// and parentheses, so fields are counted from after its last ')'.
+func parseProcStat(stat []byte, pageSize uint64) (*processUsage, error) {
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid stat file: missing command name")
+	}
+
+	// The fields following the command name start at the 3rd, the state
+	fields := bytes.Fields(stat[i+1:])
+	field := func(n int) (uint64, error) {
+		if n-3 >= len(fields) {
+			return 0, fmt.Errorf("invalid stat file: missing field %d", n)
+		}
+		return strconv.ParseUint(string(fields[n-3]), 10, 64)
+	}
+
+	utime, err := field(14)
+	if err != nil {
+		return nil, err
+	}
+	stime, err := field(15)
+	if err != nil {
+		return nil, err
+	}
+	rss, err := field(24)
+	if err != nil {
+		return nil, err
+	}
+
+	const tick = float64(time.Second) / clockTicks
+	user := float64(utime) * tick
+	system := float64(stime) * tick
+	return &processUsage{
+		cpu: &cpuTimes{
+			user:   user,
+			system: system,
+			total:  user + system,
+		},
+		memory: &drivers.MemoryStats{
+			RSS:      rss * pageSize,
+			Measured: executorJiffiesMeasuredMemStats,
+		},
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package procstats
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func Test_parseRssShmem(t *testing.T) {
+	ci.Parallel(t)
+
+	shared, ok := parseRssShmem([]byte("VmRSS:\t    9632 kB\nRssAnon:\t    2048 kB\nRssFile:\t    6560 kB\nRssShmem:\t    1024 kB\n"))
+	must.True(t, ok)
+	must.Eq(t, 1024*1024, shared)
+
+	// kernels before 4.5 don't report it
+	_, ok = parseRssShmem([]byte("VmRSS:\t    9632 kB\n"))
+	must.False(t, ok)
+
+	_, ok = parseRssShmem([]byte("RssShmem:\t    invalid kB\n"))
+	must.False(t, ok)
+}
+
+// Fuzz_parseRssShmem asserts malformed /proc/<pid>/status files can't panic
+// the parser.
+func Fuzz_parseRssShmem(f *testing.F) {
+	f.Add([]byte("VmRSS:\t    9632 kB\nRssShmem:\t    1024 kB\n"))
+	f.Add([]byte("RssShmem:\t1024\n"))
+	f.Add([]byte("RssShmem:\t    1 MB\n"))
+	f.Add([]byte("RssShmem:\t18446744073709551615 kB\n"))
+
+	f.Fuzz(func(t *testing.T, status []byte) {
+		_, _ = parseRssShmem(status)
+	})
+}
+
+func Test_parseProcStat(t *testing.T) {
+	ci.Parallel(t)
+
+	// The command name contains spaces and parentheses
+	stat := []byte("1234 (my (odd) cmd) S 1 1234 1234 0 -1 4194560 1024 0 0 0 250 50 0 0 20 0 4 0 100 123456789 2048 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n")
+	usage, err := parseProcStat(stat, 4096)
+	must.NoError(t, err)
+	must.Eq(t, 2.5e9, usage.cpu.user)
+	must.Eq(t, 0.5e9, usage.cpu.system)
+	must.Eq(t, 3e9, usage.cpu.total)
+	must.Eq(t, 2048*4096, usage.memory.RSS)
+	must.Eq(t, []string{"RSS"}, usage.memory.Measured)
+
+	must.Eq(t, 'S', procState(stat))
+	must.Eq(t, 'D', procState([]byte("1234 (nfs) D 1 1234")))
+	must.Eq(t, 0, procState([]byte("1234 (cmd)")))
+
+	_, err = parseProcStat([]byte("1234 cmd S 1"), 4096)
+	must.ErrorContains(t, err, "missing command name")
+
+	_, err = parseProcStat([]byte("1234 (cmd) S 1 1234"), 4096)
+	must.ErrorContains(t, err, "missing field 14")
+}
+
+// Fuzz_parseProcStat asserts malformed /proc/<pid>/stat files can't panic
+// the parser.
+func Fuzz_parseProcStat(f *testing.F) {
+	f.Add([]byte("1234 (cmd) S 1 1234 1234 0 -1 4194560 1024 0 0 0 250 50 0 0 20 0 4 0 100 123456789 2048\n"))
+	f.Add([]byte("1234 (cmd)"))
+	f.Add([]byte(")))"))
+
+	f.Fuzz(func(t *testing.T, stat []byte) {
+		_, _ = parseProcStat(stat, 4096)
+	})
+}
+
+func Test_readJiffies(t *testing.T) {
+	ci.Parallel(t)
+
+	usage, err := readJiffies(os.Getpid())
+	must.NoError(t, err)
+	must.Positive(t, usage.memory.RSS)
+	must.NotNil(t, usage.cpu)
+}
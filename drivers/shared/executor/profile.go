@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
+)
+
+const (
+	// profileFrequency is the frequency in Hz perf samples stacks at. An odd
+	// frequency avoids sampling in lockstep with periodic work.
+	profileFrequency = 99
+)
+
+// ErrProfilingNotSupported is returned when perf is not available on the
+// node.
+var ErrProfilingNotSupported = errors.New("profiling requires perf to be installed on the client")
+
+// profileTarget is what perf records: the processes of a cgroup if the task
+// has a unified cgroup, or otherwise the task's processes at the time the
+// profile starts.
+type profileTarget struct {
+	// cgroup is the path of the task's cgroup relative to the cgroup root
+	cgroup string
+	pids   set.Collection[procstats.ProcessID]
+}
+
+// args returns the perf record arguments selecting the target.
+func (t profileTarget) args() ([]string, error) {
+	if t.cgroup != "" {
+		return []string{"--all-cpus", "--cgroup", t.cgroup}, nil
+	}
+	if t.pids == nil || t.pids.Empty() {
+		return nil, errors.New("task has no processes to profile")
+	}
+	pids := t.pids.Slice()
+	slices.Sort(pids)
+	strs := make([]string, len(pids))
+	for i, pid := range pids {
+		strs[i] = strconv.Itoa(pid)
+	}
+	return []string{"--pid", strings.Join(strs, ",")}, nil
+}
+
+// profile runs perf record against the target for the duration and returns
+// the sampled call stacks in the folded format read by flamegraph tools.
+func profile(ctx context.Context, target profileTarget, duration time.Duration) ([]byte, error) {
+	perf, err := exec.LookPath("perf")
+	if err != nil {
+		return nil, ErrProfilingNotSupported
+	}
+
+	targetArgs, err := target.args()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "nomad-profile")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	data := filepath.Join(dir, "perf.data")
+
+	// perf records for as long as the command it runs, so sleep for the
+	// duration of the profile
+	args := []string{"record", "--quiet", "-F", strconv.Itoa(profileFrequency), "-g", "-o", data}
+	args = append(args, targetArgs...)
+	args = append(args, "--", "sleep", strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+
+	var stderr bytes.Buffer
+	record := exec.CommandContext(ctx, perf, args...)
+	record.Stderr = &stderr
+	if err := record.Run(); err != nil {
+		return nil, fmt.Errorf("perf record failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	stderr.Reset()
+	script := exec.CommandContext(ctx, perf, "script", "-i", data)
+	script.Stderr = &stderr
+	out, err := script.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := script.Start(); err != nil {
+		return nil, fmt.Errorf("perf script failed: %v", err)
+	}
+	folded, foldErr := foldStacks(out)
+	if err := script.Wait(); err != nil {
+		return nil, fmt.Errorf("perf script failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return folded, foldErr
+}
+
+// foldStacks converts perf script output to folded stacks: one line per
+// distinct stack, holding the command name and frames from the outermost
+// frame in, separated by semicolons, followed by the number of samples.
+func foldStacks(r io.Reader) ([]byte, error) {
+	counts := map[string]int{}
+
+	var comm string
+	var frames []string
+	flush := func() {
+		if comm == "" {
+			return
+		}
+		stack := make([]string, 0, len(frames)+1)
+		stack = append(stack, comm)
+		for i := len(frames) - 1; i >= 0; i-- {
+			stack = append(stack, frames[i])
+		}
+		counts[strings.Join(stack, ";")]++
+		comm, frames = "", frames[:0]
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case line[0] == ' ' || line[0] == '\t':
+			if comm != "" {
+				frames = append(frames, parseFrame(line))
+			}
+		default:
+			flush()
+			comm = parseSampleComm(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read perf script output: %w", err)
+	}
+	flush()
+
+	stacks := make([]string, 0, len(counts))
+	for stack := range counts {
+		stacks = append(stacks, stack)
+	}
+	slices.Sort(stacks)
+
+	var buf bytes.Buffer
+	for _, stack := range stacks {
+		fmt.Fprintf(&buf, "%s %d\n", stack, counts[stack])
+	}
+	return buf.Bytes(), nil
+}
+
+// parseSampleComm returns the command name of a perf script sample header,
+// such as "redis-server 1234/1235 [002] 1234.5678: 10101010 cpu-clock:". The
+// command name may contain spaces, so it is everything before the pid.
+func parseSampleComm(line string) string {
+	fields := strings.Fields(line)
+	for i, f := range fields {
+		pid, _, _ := strings.Cut(f, "/")
+		if _, err := strconv.Atoi(pid); err == nil && i > 0 {
+			return strings.ReplaceAll(strings.Join(fields[:i], "_"), ";", ":")
+		}
+	}
+	if len(fields) > 0 {
+		return strings.ReplaceAll(fields[0], ";", ":")
+	}
+	return "[unknown]"
+}
+
+// parseFrame returns the function name of a perf script frame, such as
+// "ffffffff8100a1b2 do_syscall_64+0x5c ([kernel.kallsyms])". Frames without a
+// symbol are named after their object file.
+func parseFrame(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "[unknown]"
+	}
+
+	var dso string
+	if last := fields[len(fields)-1]; strings.HasPrefix(last, "(") && strings.HasSuffix(last, ")") {
+		dso = strings.Trim(last, "()")
+		fields = fields[:len(fields)-1]
+	}
+
+	sym := strings.Join(fields[1:], " ")
+	if sym == "" || sym == "[unknown]" {
+		if dso == "" || dso == "unknown" {
+			return "[unknown]"
+		}
+		return "[" + filepath.Base(dso) + "]"
+	}
+	if i := strings.LastIndex(sym, "+0x"); i > 0 {
+		sym = sym[:i]
+	}
+	return strings.ReplaceAll(sym, ";", ":")
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+const perfScriptOutput = `redis-server 1234/1234 [002] 1234.567890:   10101010 cpu-clock:
+	ffffffff8100a1b2 do_syscall_64+0x5c ([kernel.kallsyms])
+	    7f0a12345678 epoll_wait+0x4e (/usr/lib/x86_64-linux-gnu/libc.so.6)
+	    55d0aaaa1111 aeMain+0x1d (/usr/bin/redis-server)
+	    55d0aaaa2222 main+0x31f (/usr/bin/redis-server)
+
+redis-server 1234/1234 [002] 1234.577890:   10101010 cpu-clock:
+	ffffffff8100a1b2 do_syscall_64+0x5c ([kernel.kallsyms])
+	    7f0a12345678 epoll_wait+0x4e (/usr/lib/x86_64-linux-gnu/libc.so.6)
+	    55d0aaaa1111 aeMain+0x1d (/usr/bin/redis-server)
+	    55d0aaaa2222 main+0x31f (/usr/bin/redis-server)
+
+bio close file 1234/1240 [000] 1234.580000:   10101010 cpu-clock:
+	    7f0a00000000 [unknown] (/usr/lib/x86_64-linux-gnu/libc.so.6)
+	    55d0aaaa3333 bioProcessBackgroundJobs+0x80 (/usr/bin/redis-server)
+`
+
+func TestProfile_foldStacks(t *testing.T) {
+	ci.Parallel(t)
+
+	folded, err := foldStacks(strings.NewReader(perfScriptOutput))
+	must.NoError(t, err)
+	must.Eq(t, `bio_close_file;bioProcessBackgroundJobs;[libc.so.6] 1
+redis-server;main;aeMain;epoll_wait;do_syscall_64 2
+`, string(folded))
+}
+
+func TestProfile_targetArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	args, err := profileTarget{cgroup: "nomad.slice/share.slice/abc.web.scope"}.args()
+	must.NoError(t, err)
+	must.Eq(t, []string{"--all-cpus", "--cgroup", "nomad.slice/share.slice/abc.web.scope"}, args)
+
+	args, err = profileTarget{pids: set.From([]int{42, 7})}.args()
+	must.NoError(t, err)
+	must.Eq(t, []string{"--pid", "7,42"}, args)
+
+	_, err = profileTarget{}.args()
+	must.Error(t, err)
+}
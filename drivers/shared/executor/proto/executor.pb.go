@@ -50,6 +50,19 @@ type LaunchRequest struct {
 	CgroupV1Override     map[string]string            `protobuf:"bytes,21,rep,name=cgroup_v1_override,json=cgroupV1Override,proto3" json:"cgroup_v1_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OomScoreAdj          int32                        `protobuf:"varint,22,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
 	WorkDir              string                       `protobuf:"bytes,23,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	PerfEventStats       bool                         `protobuf:"varint,24,opt,name=perf_event_stats,json=perfEventStats,proto3" json:"perf_event_stats,omitempty"`
+	RuntimeHints         bool                         `protobuf:"varint,25,opt,name=runtime_hints,json=runtimeHints,proto3" json:"runtime_hints,omitempty"`
+	SelinuxLabel         string                       `protobuf:"bytes,26,opt,name=selinux_label,json=selinuxLabel,proto3" json:"selinux_label,omitempty"`
+	ApparmorProfile      string                       `protobuf:"bytes,27,opt,name=apparmor_profile,json=apparmorProfile,proto3" json:"apparmor_profile,omitempty"`
+	MaskedPaths          []string                     `protobuf:"bytes,28,rep,name=masked_paths,json=maskedPaths,proto3" json:"masked_paths,omitempty"`
+	ReadonlyPaths        []string                     `protobuf:"bytes,29,rep,name=readonly_paths,json=readonlyPaths,proto3" json:"readonly_paths,omitempty"`
+	Dns                  *proto1.DNSConfig            `protobuf:"bytes,30,opt,name=dns,proto3" json:"dns,omitempty"`
+	EgressClasses        []*EgressClass               `protobuf:"bytes,31,rep,name=egress_classes,json=egressClasses,proto3" json:"egress_classes,omitempty"`
+	FramedLogs           bool                         `protobuf:"varint,32,opt,name=framed_logs,json=framedLogs,proto3" json:"framed_logs,omitempty"`
+	Provenance           string                       `protobuf:"bytes,33,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	WindowsLogon         *WindowsLogon                `protobuf:"bytes,34,opt,name=windows_logon,json=windowsLogon,proto3" json:"windows_logon,omitempty"`
+	ResctrlClass         *ResctrlClass                `protobuf:"bytes,35,opt,name=resctrl_class,json=resctrlClass,proto3" json:"resctrl_class,omitempty"`
+	QosClasses           bool                         `protobuf:"varint,36,opt,name=qos_classes,json=qosClasses,proto3" json:"qos_classes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -242,8 +255,249 @@ func (m *LaunchRequest) GetWorkDir() string {
 	return ""
 }
 
+func (m *LaunchRequest) GetPerfEventStats() bool {
+	if m != nil {
+		return m.PerfEventStats
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetRuntimeHints() bool {
+	if m != nil {
+		return m.RuntimeHints
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetSelinuxLabel() string {
+	if m != nil {
+		return m.SelinuxLabel
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetApparmorProfile() string {
+	if m != nil {
+		return m.ApparmorProfile
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetMaskedPaths() []string {
+	if m != nil {
+		return m.MaskedPaths
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetReadonlyPaths() []string {
+	if m != nil {
+		return m.ReadonlyPaths
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetDns() *proto1.DNSConfig {
+	if m != nil {
+		return m.Dns
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetEgressClasses() []*EgressClass {
+	if m != nil {
+		return m.EgressClasses
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetFramedLogs() bool {
+	if m != nil {
+		return m.FramedLogs
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetProvenance() string {
+	if m != nil {
+		return m.Provenance
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetWindowsLogon() *WindowsLogon {
+	if m != nil {
+		return m.WindowsLogon
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetResctrlClass() *ResctrlClass {
+	if m != nil {
+		return m.ResctrlClass
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetQosClasses() bool {
+	if m != nil {
+		return m.QosClasses
+	}
+	return false
+}
+
+type ResctrlClass struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schemata             []string `protobuf:"bytes,2,rep,name=schemata,proto3" json:"schemata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResctrlClass) Reset()         { *m = ResctrlClass{} }
+func (m *ResctrlClass) String() string { return proto.CompactTextString(m) }
+func (*ResctrlClass) ProtoMessage()    {}
+func (*ResctrlClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{1}
+}
+
+func (m *ResctrlClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResctrlClass.Unmarshal(m, b)
+}
+func (m *ResctrlClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResctrlClass.Marshal(b, m, deterministic)
+}
+func (m *ResctrlClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResctrlClass.Merge(m, src)
+}
+func (m *ResctrlClass) XXX_Size() int {
+	return xxx_messageInfo_ResctrlClass.Size(m)
+}
+func (m *ResctrlClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResctrlClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResctrlClass proto.InternalMessageInfo
+
+func (m *ResctrlClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResctrlClass) GetSchemata() []string {
+	if m != nil {
+		return m.Schemata
+	}
+	return nil
+}
+
+type WindowsLogon struct {
+	PasswordFile         string   `protobuf:"bytes,1,opt,name=password_file,json=passwordFile,proto3" json:"password_file,omitempty"`
+	LogonType            string   `protobuf:"bytes,2,opt,name=logon_type,json=logonType,proto3" json:"logon_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WindowsLogon) Reset()         { *m = WindowsLogon{} }
+func (m *WindowsLogon) String() string { return proto.CompactTextString(m) }
+func (*WindowsLogon) ProtoMessage()    {}
+func (*WindowsLogon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{2}
+}
+
+func (m *WindowsLogon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WindowsLogon.Unmarshal(m, b)
+}
+func (m *WindowsLogon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WindowsLogon.Marshal(b, m, deterministic)
+}
+func (m *WindowsLogon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WindowsLogon.Merge(m, src)
+}
+func (m *WindowsLogon) XXX_Size() int {
+	return xxx_messageInfo_WindowsLogon.Size(m)
+}
+func (m *WindowsLogon) XXX_DiscardUnknown() {
+	xxx_messageInfo_WindowsLogon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WindowsLogon proto.InternalMessageInfo
+
+func (m *WindowsLogon) GetPasswordFile() string {
+	if m != nil {
+		return m.PasswordFile
+	}
+	return ""
+}
+
+func (m *WindowsLogon) GetLogonType() string {
+	if m != nil {
+		return m.LogonType
+	}
+	return ""
+}
+
+type EgressClass struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cidrs                []string `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	Ports                []int32  `protobuf:"varint,3,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EgressClass) Reset()         { *m = EgressClass{} }
+func (m *EgressClass) String() string { return proto.CompactTextString(m) }
+func (*EgressClass) ProtoMessage()    {}
+func (*EgressClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{3}
+}
+
+func (m *EgressClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EgressClass.Unmarshal(m, b)
+}
+func (m *EgressClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EgressClass.Marshal(b, m, deterministic)
+}
+func (m *EgressClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EgressClass.Merge(m, src)
+}
+func (m *EgressClass) XXX_Size() int {
+	return xxx_messageInfo_EgressClass.Size(m)
+}
+func (m *EgressClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_EgressClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EgressClass proto.InternalMessageInfo
+
+func (m *EgressClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EgressClass) GetCidrs() []string {
+	if m != nil {
+		return m.Cidrs
+	}
+	return nil
+}
+
+func (m *EgressClass) GetPorts() []int32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	Provenance           *Provenance   `protobuf:"bytes,2,opt,name=provenance,proto3" json:"provenance,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -253,7 +507,7 @@ func (m *LaunchResponse) Reset()         { *m = LaunchResponse{} }
 func (m *LaunchResponse) String() string { return proto.CompactTextString(m) }
 func (*LaunchResponse) ProtoMessage()    {}
 func (*LaunchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{1}
+	return fileDescriptor_66b85426380683f3, []int{4}
 }
 
 func (m *LaunchResponse) XXX_Unmarshal(b []byte) error {
@@ -281,6 +535,107 @@ func (m *LaunchResponse) GetProcess() *ProcessState {
 	return nil
 }
 
+func (m *LaunchResponse) GetProvenance() *Provenance {
+	if m != nil {
+		return m.Provenance
+	}
+	return nil
+}
+
+type Provenance struct {
+	Binary               *FileDigest   `protobuf:"bytes,1,opt,name=binary,proto3" json:"binary,omitempty"`
+	Libraries            []*FileDigest `protobuf:"bytes,2,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Provenance) Reset()         { *m = Provenance{} }
+func (m *Provenance) String() string { return proto.CompactTextString(m) }
+func (*Provenance) ProtoMessage()    {}
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{5}
+}
+
+func (m *Provenance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Provenance.Unmarshal(m, b)
+}
+func (m *Provenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Provenance.Marshal(b, m, deterministic)
+}
+func (m *Provenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Provenance.Merge(m, src)
+}
+func (m *Provenance) XXX_Size() int {
+	return xxx_messageInfo_Provenance.Size(m)
+}
+func (m *Provenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Provenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Provenance proto.InternalMessageInfo
+
+func (m *Provenance) GetBinary() *FileDigest {
+	if m != nil {
+		return m.Binary
+	}
+	return nil
+}
+
+func (m *Provenance) GetLibraries() []*FileDigest {
+	if m != nil {
+		return m.Libraries
+	}
+	return nil
+}
+
+type FileDigest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Sha256               string   `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileDigest) Reset()         { *m = FileDigest{} }
+func (m *FileDigest) String() string { return proto.CompactTextString(m) }
+func (*FileDigest) ProtoMessage()    {}
+func (*FileDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{6}
+}
+
+func (m *FileDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileDigest.Unmarshal(m, b)
+}
+func (m *FileDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileDigest.Marshal(b, m, deterministic)
+}
+func (m *FileDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileDigest.Merge(m, src)
+}
+func (m *FileDigest) XXX_Size() int {
+	return xxx_messageInfo_FileDigest.Size(m)
+}
+func (m *FileDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileDigest proto.InternalMessageInfo
+
+func (m *FileDigest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileDigest) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
 type WaitRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -291,7 +646,7 @@ func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
 func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitRequest) ProtoMessage()    {}
 func (*WaitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{2}
+	return fileDescriptor_66b85426380683f3, []int{7}
 }
 
 func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
@@ -323,7 +678,7 @@ func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
 func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
 func (*WaitResponse) ProtoMessage()    {}
 func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{3}
+	return fileDescriptor_66b85426380683f3, []int{8}
 }
 
 func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
@@ -363,7 +718,7 @@ func (m *ShutdownRequest) Reset()         { *m = ShutdownRequest{} }
 func (m *ShutdownRequest) String() string { return proto.CompactTextString(m) }
 func (*ShutdownRequest) ProtoMessage()    {}
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{4}
+	return fileDescriptor_66b85426380683f3, []int{9}
 }
 
 func (m *ShutdownRequest) XXX_Unmarshal(b []byte) error {
@@ -408,7 +763,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{5}
+	return fileDescriptor_66b85426380683f3, []int{10}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -440,7 +795,7 @@ func (m *UpdateResourcesRequest) Reset()         { *m = UpdateResourcesRequest{}
 func (m *UpdateResourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesRequest) ProtoMessage()    {}
 func (*UpdateResourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{6}
+	return fileDescriptor_66b85426380683f3, []int{11}
 }
 
 func (m *UpdateResourcesRequest) XXX_Unmarshal(b []byte) error {
@@ -478,7 +833,7 @@ func (m *UpdateResourcesResponse) Reset()         { *m = UpdateResourcesResponse
 func (m *UpdateResourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesResponse) ProtoMessage()    {}
 func (*UpdateResourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{7}
+	return fileDescriptor_66b85426380683f3, []int{12}
 }
 
 func (m *UpdateResourcesResponse) XXX_Unmarshal(b []byte) error {
@@ -509,7 +864,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{8}
+	return fileDescriptor_66b85426380683f3, []int{13}
 }
 
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
@@ -541,7 +896,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{9}
+	return fileDescriptor_66b85426380683f3, []int{14}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -569,6 +924,119 @@ func (m *VersionResponse) GetVersion() string {
 	return ""
 }
 
+type CapabilitiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()    {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{15}
+}
+
+func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesRequest.Unmarshal(m, b)
+}
+func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *CapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesRequest.Merge(m, src)
+}
+func (m *CapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesRequest.Size(m)
+}
+func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
+
+type CapabilitiesResponse struct {
+	// protocol_version is the latest executor protocol version the executor
+	// speaks
+	ProtocolVersion int32 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// nomad_version is the version of the Nomad binary the executor runs
+	NomadVersion         string   `protobuf:"bytes,2,opt,name=nomad_version,json=nomadVersion,proto3" json:"nomad_version,omitempty"`
+	Processes            bool     `protobuf:"varint,3,opt,name=processes,proto3" json:"processes,omitempty"`
+	Profile              bool     `protobuf:"varint,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	PerfStats            bool     `protobuf:"varint,5,opt,name=perf_stats,json=perfStats,proto3" json:"perf_stats,omitempty"`
+	TotalCpuSeconds      bool     `protobuf:"varint,6,opt,name=total_cpu_seconds,json=totalCpuSeconds,proto3" json:"total_cpu_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()    {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{16}
+}
+
+func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *CapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesResponse.Merge(m, src)
+}
+func (m *CapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesResponse.Size(m)
+}
+func (m *CapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesResponse proto.InternalMessageInfo
+
+func (m *CapabilitiesResponse) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *CapabilitiesResponse) GetNomadVersion() string {
+	if m != nil {
+		return m.NomadVersion
+	}
+	return ""
+}
+
+func (m *CapabilitiesResponse) GetProcesses() bool {
+	if m != nil {
+		return m.Processes
+	}
+	return false
+}
+
+func (m *CapabilitiesResponse) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *CapabilitiesResponse) GetPerfStats() bool {
+	if m != nil {
+		return m.PerfStats
+	}
+	return false
+}
+
+func (m *CapabilitiesResponse) GetTotalCpuSeconds() bool {
+	if m != nil {
+		return m.TotalCpuSeconds
+	}
+	return false
+}
+
 type StatsRequest struct {
 	Interval             int64    `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -580,7 +1048,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{10}
+	return fileDescriptor_66b85426380683f3, []int{17}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -619,7 +1087,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{11}
+	return fileDescriptor_66b85426380683f3, []int{18}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -647,6 +1115,154 @@ func (m *StatsResponse) GetStats() *proto1.TaskStats {
 	return nil
 }
 
+type ProcessesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProcessesRequest) Reset()         { *m = ProcessesRequest{} }
+func (m *ProcessesRequest) String() string { return proto.CompactTextString(m) }
+func (*ProcessesRequest) ProtoMessage()    {}
+func (*ProcessesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{19}
+}
+
+func (m *ProcessesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessesRequest.Unmarshal(m, b)
+}
+func (m *ProcessesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessesRequest.Marshal(b, m, deterministic)
+}
+func (m *ProcessesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessesRequest.Merge(m, src)
+}
+func (m *ProcessesRequest) XXX_Size() int {
+	return xxx_messageInfo_ProcessesRequest.Size(m)
+}
+func (m *ProcessesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessesRequest proto.InternalMessageInfo
+
+type ProcessesResponse struct {
+	Processes            []*proto1.TaskProcess `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ProcessesResponse) Reset()         { *m = ProcessesResponse{} }
+func (m *ProcessesResponse) String() string { return proto.CompactTextString(m) }
+func (*ProcessesResponse) ProtoMessage()    {}
+func (*ProcessesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{20}
+}
+
+func (m *ProcessesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessesResponse.Unmarshal(m, b)
+}
+func (m *ProcessesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessesResponse.Marshal(b, m, deterministic)
+}
+func (m *ProcessesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessesResponse.Merge(m, src)
+}
+func (m *ProcessesResponse) XXX_Size() int {
+	return xxx_messageInfo_ProcessesResponse.Size(m)
+}
+func (m *ProcessesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessesResponse proto.InternalMessageInfo
+
+func (m *ProcessesResponse) GetProcesses() []*proto1.TaskProcess {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type ProfileRequest struct {
+	Duration             int64    `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileRequest) Reset()         { *m = ProfileRequest{} }
+func (m *ProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*ProfileRequest) ProtoMessage()    {}
+func (*ProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{21}
+}
+
+func (m *ProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileRequest.Unmarshal(m, b)
+}
+func (m *ProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *ProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileRequest.Merge(m, src)
+}
+func (m *ProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_ProfileRequest.Size(m)
+}
+func (m *ProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileRequest proto.InternalMessageInfo
+
+func (m *ProfileRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type ProfileResponse struct {
+	FoldedStacks         []byte   `protobuf:"bytes,1,opt,name=folded_stacks,json=foldedStacks,proto3" json:"folded_stacks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileResponse) Reset()         { *m = ProfileResponse{} }
+func (m *ProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*ProfileResponse) ProtoMessage()    {}
+func (*ProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{22}
+}
+
+func (m *ProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileResponse.Unmarshal(m, b)
+}
+func (m *ProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileResponse.Marshal(b, m, deterministic)
+}
+func (m *ProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileResponse.Merge(m, src)
+}
+func (m *ProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_ProfileResponse.Size(m)
+}
+func (m *ProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileResponse proto.InternalMessageInfo
+
+func (m *ProfileResponse) GetFoldedStacks() []byte {
+	if m != nil {
+		return m.FoldedStacks
+	}
+	return nil
+}
+
 type SignalRequest struct {
 	Signal               int32    `protobuf:"varint,1,opt,name=signal,proto3" json:"signal,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -658,7 +1274,7 @@ func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
 func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()    {}
 func (*SignalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{12}
+	return fileDescriptor_66b85426380683f3, []int{23}
 }
 
 func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
@@ -696,7 +1312,7 @@ func (m *SignalResponse) Reset()         { *m = SignalResponse{} }
 func (m *SignalResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()    {}
 func (*SignalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{13}
+	return fileDescriptor_66b85426380683f3, []int{24}
 }
 
 func (m *SignalResponse) XXX_Unmarshal(b []byte) error {
@@ -730,7 +1346,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{14}
+	return fileDescriptor_66b85426380683f3, []int{25}
 }
 
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
@@ -784,7 +1400,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{15}
+	return fileDescriptor_66b85426380683f3, []int{26}
 }
 
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
@@ -825,6 +1441,7 @@ type ProcessState struct {
 	Signal               int32                `protobuf:"varint,3,opt,name=signal,proto3" json:"signal,omitempty"`
 	Time                 *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
 	OomKilled            bool                 `protobuf:"varint,5,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	ExitReason           string               `protobuf:"bytes,6,opt,name=exit_reason,json=exitReason,proto3" json:"exit_reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -834,7 +1451,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{27}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -890,10 +1507,22 @@ func (m *ProcessState) GetOomKilled() bool {
 	return false
 }
 
+func (m *ProcessState) GetExitReason() string {
+	if m != nil {
+		return m.ExitReason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.CgroupV1OverrideEntry")
+	proto.RegisterType((*ResctrlClass)(nil), "hashicorp.nomad.plugins.executor.proto.ResctrlClass")
+	proto.RegisterType((*WindowsLogon)(nil), "hashicorp.nomad.plugins.executor.proto.WindowsLogon")
+	proto.RegisterType((*EgressClass)(nil), "hashicorp.nomad.plugins.executor.proto.EgressClass")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
+	proto.RegisterType((*Provenance)(nil), "hashicorp.nomad.plugins.executor.proto.Provenance")
+	proto.RegisterType((*FileDigest)(nil), "hashicorp.nomad.plugins.executor.proto.FileDigest")
 	proto.RegisterType((*WaitRequest)(nil), "hashicorp.nomad.plugins.executor.proto.WaitRequest")
 	proto.RegisterType((*WaitResponse)(nil), "hashicorp.nomad.plugins.executor.proto.WaitResponse")
 	proto.RegisterType((*ShutdownRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ShutdownRequest")
@@ -902,8 +1531,14 @@ func init() {
 	proto.RegisterType((*UpdateResourcesResponse)(nil), "hashicorp.nomad.plugins.executor.proto.UpdateResourcesResponse")
 	proto.RegisterType((*VersionRequest)(nil), "hashicorp.nomad.plugins.executor.proto.VersionRequest")
 	proto.RegisterType((*VersionResponse)(nil), "hashicorp.nomad.plugins.executor.proto.VersionResponse")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CapabilitiesResponse")
 	proto.RegisterType((*StatsRequest)(nil), "hashicorp.nomad.plugins.executor.proto.StatsRequest")
 	proto.RegisterType((*StatsResponse)(nil), "hashicorp.nomad.plugins.executor.proto.StatsResponse")
+	proto.RegisterType((*ProcessesRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessesRequest")
+	proto.RegisterType((*ProcessesResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessesResponse")
+	proto.RegisterType((*ProfileRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ProfileRequest")
+	proto.RegisterType((*ProfileResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ProfileResponse")
 	proto.RegisterType((*SignalRequest)(nil), "hashicorp.nomad.plugins.executor.proto.SignalRequest")
 	proto.RegisterType((*SignalResponse)(nil), "hashicorp.nomad.plugins.executor.proto.SignalResponse")
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
@@ -916,83 +1551,126 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1205 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xfb, 0x6e, 0x1b, 0xc5,
-	0x17, 0xee, 0xc6, 0x71, 0x6c, 0x1f, 0xdb, 0x89, 0x3b, 0xbf, 0x36, 0xdd, 0xfa, 0x27, 0xd4, 0xb0,
-	0x48, 0xd4, 0x82, 0xb2, 0x69, 0xd3, 0xab, 0x40, 0xa2, 0xd0, 0xa4, 0xa0, 0xaa, 0x17, 0xa2, 0x4d,
-	0x69, 0x25, 0xfe, 0x60, 0x99, 0xee, 0x4e, 0xed, 0xa9, 0xd7, 0x3b, 0xcb, 0xcc, 0xac, 0x9b, 0x48,
-	0x48, 0xbc, 0x04, 0x48, 0x3c, 0x00, 0xaf, 0xc7, 0x3b, 0xa0, 0xb9, 0x6d, 0xec, 0xb4, 0x54, 0x9b,
-	0x22, 0xfe, 0xca, 0x9e, 0xcf, 0xdf, 0xb9, 0xcc, 0x39, 0x73, 0xbe, 0x09, 0x5c, 0x49, 0x39, 0x9d,
-	0x13, 0x2e, 0xb6, 0xc5, 0x04, 0x73, 0x92, 0x6e, 0x93, 0x43, 0x92, 0x94, 0x92, 0xf1, 0xed, 0x82,
-	0x33, 0xc9, 0x2a, 0x33, 0xd4, 0x26, 0xfa, 0x78, 0x82, 0xc5, 0x84, 0x26, 0x8c, 0x17, 0x61, 0xce,
-	0x66, 0x38, 0x0d, 0x8b, 0xac, 0x1c, 0xd3, 0x5c, 0x84, 0xcb, 0xbc, 0xe1, 0xa5, 0x31, 0x63, 0xe3,
-	0x8c, 0x98, 0x20, 0x2f, 0xca, 0x97, 0xdb, 0x92, 0xce, 0x88, 0x90, 0x78, 0x56, 0x58, 0x42, 0x60,
-	0x1d, 0xb7, 0x5d, 0x7a, 0x93, 0xce, 0x58, 0x86, 0x13, 0xfc, 0xd5, 0x86, 0xfe, 0x23, 0x5c, 0xe6,
-	0xc9, 0x24, 0x22, 0x3f, 0x97, 0x44, 0x48, 0x34, 0x80, 0x46, 0x32, 0x4b, 0x7d, 0x6f, 0xcb, 0x1b,
-	0x75, 0x22, 0xf5, 0x89, 0x10, 0xac, 0x62, 0x3e, 0x16, 0xfe, 0xca, 0x56, 0x63, 0xd4, 0x89, 0xf4,
-	0x37, 0x7a, 0x02, 0x1d, 0x4e, 0x04, 0x2b, 0x79, 0x42, 0x84, 0xdf, 0xd8, 0xf2, 0x46, 0xdd, 0x9d,
-	0xab, 0xe1, 0x3f, 0x15, 0x6e, 0xf3, 0x9b, 0x94, 0x61, 0xe4, 0xfc, 0xa2, 0xe3, 0x10, 0xe8, 0x12,
-	0x74, 0x85, 0x4c, 0x59, 0x29, 0xe3, 0x02, 0xcb, 0x89, 0xbf, 0xaa, 0xb3, 0x83, 0x81, 0xf6, 0xb1,
-	0x9c, 0x58, 0x02, 0xe1, 0xdc, 0x10, 0x9a, 0x15, 0x81, 0x70, 0xae, 0x09, 0x03, 0x68, 0x90, 0x7c,
-	0xee, 0xaf, 0xe9, 0x22, 0xd5, 0xa7, 0xaa, 0xbb, 0x14, 0x84, 0xfb, 0x2d, 0xcd, 0xd5, 0xdf, 0xe8,
-	0x22, 0xb4, 0x25, 0x16, 0xd3, 0x38, 0xa5, 0xdc, 0x6f, 0x6b, 0xbc, 0xa5, 0xec, 0x3d, 0xca, 0xd1,
-	0x65, 0xd8, 0x70, 0xf5, 0xc4, 0x19, 0x9d, 0x51, 0x29, 0xfc, 0xce, 0x96, 0x37, 0x6a, 0x47, 0xeb,
-	0x0e, 0x7e, 0xa4, 0x51, 0x74, 0x03, 0xce, 0xbd, 0xc0, 0x82, 0x26, 0x71, 0xc1, 0x59, 0x42, 0x84,
-	0x88, 0x93, 0x31, 0x67, 0x65, 0xe1, 0x83, 0x62, 0xdf, 0x5b, 0xf1, 0xbd, 0x08, 0xe9, 0xdf, 0xf7,
-	0xcd, 0xcf, 0xbb, 0xfa, 0x57, 0xb4, 0x07, 0x6b, 0x33, 0x56, 0xe6, 0x52, 0xf8, 0xdd, 0xad, 0xc6,
-	0xa8, 0xbb, 0x73, 0xa5, 0x66, 0xbb, 0x1e, 0x2b, 0xa7, 0xc8, 0xfa, 0xa2, 0x6f, 0xa1, 0x95, 0x92,
-	0x39, 0x55, 0x5d, 0xef, 0xe9, 0x30, 0x9f, 0xd5, 0x0c, 0xb3, 0xa7, 0xbd, 0x22, 0xe7, 0x8d, 0x26,
-	0x70, 0x36, 0x27, 0xf2, 0x35, 0xe3, 0xd3, 0x98, 0x0a, 0x96, 0x61, 0x49, 0x59, 0xee, 0xf7, 0xf5,
-	0x20, 0xbf, 0xa8, 0x19, 0xf2, 0x89, 0xf1, 0x7f, 0xe0, 0xdc, 0x0f, 0x0a, 0x92, 0x44, 0x83, 0xfc,
-	0x04, 0x8a, 0x02, 0xe8, 0xe7, 0x2c, 0x2e, 0xe8, 0x9c, 0xc9, 0x98, 0x33, 0x26, 0xfd, 0x75, 0xdd,
-	0xd5, 0x6e, 0xce, 0xf6, 0x15, 0x16, 0x31, 0x26, 0xd1, 0x08, 0x06, 0x29, 0x79, 0x89, 0xcb, 0x4c,
-	0xc6, 0x05, 0x4d, 0xe3, 0x19, 0x4b, 0x89, 0xbf, 0xa1, 0xc7, 0xb3, 0x6e, 0xf1, 0x7d, 0x9a, 0x3e,
-	0x66, 0x29, 0x59, 0x64, 0xd2, 0x22, 0x31, 0xcc, 0xc1, 0x12, 0xf3, 0x41, 0x91, 0x68, 0xe6, 0x47,
-	0xd0, 0x4f, 0x8a, 0x52, 0x10, 0xe9, 0xe6, 0x73, 0x56, 0xd3, 0x7a, 0x06, 0xb4, 0x53, 0xf9, 0x00,
-	0x00, 0x67, 0x19, 0x7b, 0x1d, 0x27, 0xb8, 0x10, 0x3e, 0xd2, 0x97, 0xa7, 0xa3, 0x91, 0x5d, 0x5c,
-	0x08, 0x14, 0x40, 0x2f, 0xc1, 0x05, 0x7e, 0x41, 0x33, 0x2a, 0x29, 0x11, 0xfe, 0xff, 0x34, 0x61,
-	0x09, 0x43, 0x57, 0x00, 0x99, 0x04, 0xf1, 0x7c, 0x27, 0x66, 0x73, 0xc2, 0x39, 0x4d, 0x89, 0x7f,
-	0x4e, 0x27, 0x1b, 0x98, 0x5f, 0x9e, 0xed, 0x7c, 0x67, 0x71, 0x74, 0x74, 0xcc, 0xbe, 0x76, 0xcc,
-	0x3e, 0xaf, 0x67, 0xf9, 0x30, 0xac, 0xb7, 0xfa, 0xe1, 0xd2, 0xc6, 0x86, 0xe6, 0x28, 0xcf, 0xae,
-	0xb9, 0x1c, 0xf7, 0x73, 0xc9, 0x8f, 0xaa, 0xd4, 0x15, 0xac, 0x06, 0xc1, 0xd8, 0x2c, 0x16, 0x09,
-	0xe3, 0x24, 0xc6, 0xe9, 0x2b, 0x7f, 0x73, 0xcb, 0x1b, 0x35, 0xa3, 0x2e, 0x63, 0xb3, 0x03, 0x85,
-	0x7d, 0x9d, 0xbe, 0x52, 0xfb, 0xa1, 0xef, 0x84, 0xda, 0x8f, 0x0b, 0x66, 0x3f, 0x94, 0xbd, 0x47,
-	0xf9, 0x70, 0x17, 0xce, 0xbf, 0x35, 0x93, 0xda, 0xbc, 0x29, 0x39, 0x72, 0x8a, 0x31, 0x25, 0x47,
-	0xe8, 0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0x63, 0x7c, 0xbe, 0x72, 0xc7, 0x0b,
-	0x7e, 0x82, 0x75, 0x57, 0xbc, 0x28, 0x58, 0x2e, 0x08, 0x7a, 0x02, 0x2d, 0xbb, 0x47, 0x3a, 0x42,
-	0x77, 0xe7, 0x46, 0xdd, 0x2e, 0xd8, 0xfd, 0x3a, 0x90, 0x58, 0x92, 0xc8, 0x05, 0x09, 0xfa, 0xd0,
-	0x7d, 0x8e, 0xa9, 0xb4, 0xcd, 0x09, 0x7e, 0x84, 0x9e, 0x31, 0xff, 0xa3, 0x74, 0x8f, 0x60, 0xe3,
-	0x60, 0x52, 0xca, 0x94, 0xbd, 0xce, 0x9d, 0x82, 0x6e, 0xc2, 0x9a, 0xa0, 0xe3, 0x1c, 0x67, 0xb6,
-	0x25, 0xd6, 0x42, 0x1f, 0x42, 0x6f, 0xcc, 0x71, 0x42, 0xe2, 0x82, 0x70, 0xca, 0x52, 0xdd, 0x9c,
-	0x46, 0xd4, 0xd5, 0xd8, 0xbe, 0x86, 0x02, 0x04, 0x83, 0xe3, 0x68, 0xa6, 0xe2, 0x60, 0x02, 0x9b,
-	0xdf, 0x17, 0xa9, 0x4a, 0x5a, 0x09, 0xa7, 0x4d, 0xb4, 0x24, 0xc2, 0xde, 0xbf, 0x16, 0xe1, 0xe0,
-	0x22, 0x5c, 0x78, 0x23, 0x93, 0x2d, 0x62, 0x00, 0xeb, 0xcf, 0x08, 0x17, 0x94, 0xb9, 0x53, 0x06,
-	0x9f, 0xc2, 0x46, 0x85, 0xd8, 0xde, 0xfa, 0xd0, 0x9a, 0x1b, 0xc8, 0x9e, 0xdc, 0x99, 0xc1, 0x27,
-	0xd0, 0x53, 0x7d, 0xab, 0x2a, 0x1f, 0x42, 0x9b, 0xe6, 0x92, 0xf0, 0xb9, 0x6d, 0x52, 0x23, 0xaa,
-	0xec, 0xe0, 0x39, 0xf4, 0x2d, 0xd7, 0x86, 0xfd, 0x06, 0x9a, 0x42, 0x01, 0xa7, 0x3c, 0xe2, 0x53,
-	0x2c, 0xa6, 0x26, 0x90, 0x71, 0x0f, 0x2e, 0x43, 0xff, 0x40, 0x4f, 0xe2, 0xed, 0x83, 0x6a, 0xba,
-	0x41, 0xa9, 0xc3, 0x3a, 0xa2, 0x3d, 0xfe, 0x14, 0xba, 0xf7, 0x0f, 0x49, 0xe2, 0x1c, 0x6f, 0x41,
-	0x3b, 0x25, 0x38, 0xcd, 0x68, 0x4e, 0x6c, 0x51, 0xc3, 0xd0, 0xbc, 0xc6, 0xa1, 0x7b, 0x8d, 0xc3,
-	0xa7, 0xee, 0x35, 0x8e, 0x2a, 0xae, 0x7b, 0x5b, 0x57, 0xde, 0x7c, 0x5b, 0x1b, 0xc7, 0x6f, 0x6b,
-	0xb0, 0x0b, 0x3d, 0x93, 0xcc, 0x9e, 0x7f, 0x13, 0xd6, 0x58, 0x29, 0x8b, 0x52, 0xea, 0x5c, 0xbd,
-	0xc8, 0x5a, 0xe8, 0xff, 0xd0, 0x21, 0x87, 0x54, 0xc6, 0x89, 0xd2, 0xc0, 0x15, 0x7d, 0x82, 0xb6,
-	0x02, 0x76, 0x59, 0x4a, 0x82, 0x3f, 0x3d, 0xe8, 0x2d, 0xde, 0x58, 0x95, 0xbb, 0xa0, 0xa9, 0x3d,
-	0xa9, 0xfa, 0x7c, 0xa7, 0xff, 0x42, 0x6f, 0x1a, 0x8b, 0xbd, 0x41, 0x21, 0xac, 0xaa, 0xff, 0x33,
-	0xf4, 0x0b, 0xfd, 0xee, 0x63, 0x6b, 0x9e, 0x12, 0x58, 0x25, 0x3a, 0x53, 0x9a, 0x65, 0x24, 0xd5,
-	0xcf, 0x76, 0x3b, 0xea, 0x30, 0x36, 0x7b, 0xa8, 0x81, 0x9d, 0xdf, 0x3b, 0xd0, 0xbe, 0x6f, 0xf7,
-	0x0c, 0x1d, 0xc1, 0x9a, 0x11, 0x07, 0x74, 0xf3, 0xbd, 0x94, 0x70, 0x78, 0xeb, 0xb4, 0x6e, 0x76,
-	0xbc, 0x67, 0x90, 0x80, 0x55, 0x25, 0x13, 0xe8, 0x7a, 0xdd, 0x08, 0x0b, 0x1a, 0x33, 0xbc, 0x71,
-	0x3a, 0xa7, 0x2a, 0xe9, 0xaf, 0xd0, 0x76, 0xdb, 0x8e, 0x6e, 0xd7, 0x8d, 0x71, 0x42, 0x6d, 0x86,
-	0x77, 0x4e, 0xef, 0x58, 0x15, 0xf0, 0x9b, 0x07, 0x1b, 0x27, 0x36, 0x1e, 0x7d, 0x59, 0x37, 0xde,
-	0xdb, 0x45, 0x69, 0x78, 0xf7, 0xbd, 0xfd, 0xab, 0xb2, 0x7e, 0x81, 0x96, 0x95, 0x16, 0x54, 0x7b,
-	0xa2, 0xcb, 0xea, 0x34, 0xbc, 0x7d, 0x6a, 0xbf, 0x2a, 0xfb, 0x21, 0x34, 0xb5, 0x6c, 0xa0, 0xda,
-	0x63, 0x5d, 0x94, 0xb6, 0xe1, 0xcd, 0x53, 0x7a, 0xb9, 0xbc, 0x57, 0x3d, 0x75, 0xff, 0x8d, 0xee,
-	0xd4, 0xbf, 0xff, 0x4b, 0x82, 0x56, 0xff, 0xfe, 0x9f, 0x90, 0x37, 0x7d, 0xff, 0xd5, 0x1a, 0xd6,
-	0xbf, 0xff, 0x0b, 0x72, 0x58, 0xff, 0xfe, 0x2f, 0xca, 0x5a, 0x70, 0x06, 0xfd, 0xe1, 0x41, 0x5f,
-	0x41, 0x07, 0x92, 0x13, 0x3c, 0xa3, 0xf9, 0x18, 0xdd, 0xad, 0xa9, 0xed, 0xca, 0xcb, 0xe8, 0xbb,
-	0xf5, 0x74, 0xa5, 0x7c, 0xf5, 0xfe, 0x01, 0x5c, 0x59, 0x23, 0xef, 0xaa, 0x77, 0xaf, 0xf5, 0x43,
-	0xd3, 0x48, 0xda, 0x9a, 0xfe, 0x73, 0xfd, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5b, 0xc0, 0xa1,
-	0x7e, 0xc4, 0x0d, 0x00, 0x00,
+	// 1890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5f, 0x73, 0x1c, 0x47,
+	0x11, 0x67, 0x75, 0x3a, 0xe9, 0xae, 0x6f, 0x4f, 0x3a, 0x4f, 0x6c, 0x67, 0x7d, 0x89, 0x63, 0x65,
+	0x0d, 0xe4, 0x12, 0xcc, 0xc9, 0x51, 0x6c, 0xd9, 0x10, 0x2a, 0x01, 0x4b, 0x0a, 0x84, 0xc8, 0x46,
+	0xb5, 0x32, 0x76, 0x91, 0x07, 0xb6, 0x46, 0xbb, 0xa3, 0xbb, 0x89, 0xf6, 0x76, 0xd6, 0x33, 0xb3,
+	0x27, 0xa9, 0x8a, 0x2a, 0xaa, 0x78, 0xa5, 0x8a, 0x27, 0x1e, 0xf8, 0x04, 0x3c, 0xf0, 0xcc, 0x57,
+	0xe0, 0xc3, 0xf0, 0x2d, 0xa8, 0xf9, 0xb7, 0xb7, 0xb2, 0x05, 0xb9, 0x13, 0x95, 0xa7, 0xbb, 0xfe,
+	0x4d, 0xff, 0x9b, 0xee, 0x9e, 0xee, 0x5e, 0xb8, 0x97, 0x72, 0x3a, 0x25, 0x5c, 0x6c, 0x8a, 0x31,
+	0xe6, 0x24, 0xdd, 0x24, 0x67, 0x24, 0x29, 0x25, 0xe3, 0x9b, 0x05, 0x67, 0x92, 0x55, 0xe4, 0x50,
+	0x93, 0xe8, 0x87, 0x63, 0x2c, 0xc6, 0x34, 0x61, 0xbc, 0x18, 0xe6, 0x6c, 0x82, 0xd3, 0x61, 0x91,
+	0x95, 0x23, 0x9a, 0x8b, 0xe1, 0x45, 0xbe, 0xfe, 0x9d, 0x11, 0x63, 0xa3, 0x8c, 0x18, 0x25, 0x47,
+	0xe5, 0xf1, 0xa6, 0xa4, 0x13, 0x22, 0x24, 0x9e, 0x14, 0x96, 0x21, 0xb4, 0x82, 0x9b, 0xce, 0xbc,
+	0x31, 0x67, 0x28, 0xc3, 0x13, 0xfe, 0x65, 0x0d, 0xba, 0xfb, 0xb8, 0xcc, 0x93, 0x71, 0x44, 0x5e,
+	0x95, 0x44, 0x48, 0xd4, 0x83, 0x46, 0x32, 0x49, 0x03, 0x6f, 0xc3, 0x1b, 0xb4, 0x23, 0xf5, 0x17,
+	0x21, 0x58, 0xc6, 0x7c, 0x24, 0x82, 0xa5, 0x8d, 0xc6, 0xa0, 0x1d, 0xe9, 0xff, 0xe8, 0x19, 0xb4,
+	0x39, 0x11, 0xac, 0xe4, 0x09, 0x11, 0x41, 0x63, 0xc3, 0x1b, 0x74, 0xb6, 0xee, 0x0f, 0xff, 0x9b,
+	0xe3, 0xd6, 0xbe, 0x31, 0x39, 0x8c, 0x9c, 0x5c, 0x34, 0x53, 0x81, 0xee, 0x40, 0x47, 0xc8, 0x94,
+	0x95, 0x32, 0x2e, 0xb0, 0x1c, 0x07, 0xcb, 0xda, 0x3a, 0x18, 0xe8, 0x00, 0xcb, 0xb1, 0x65, 0x20,
+	0x9c, 0x1b, 0x86, 0x66, 0xc5, 0x40, 0x38, 0xd7, 0x0c, 0x3d, 0x68, 0x90, 0x7c, 0x1a, 0xac, 0x68,
+	0x27, 0xd5, 0x5f, 0xe5, 0x77, 0x29, 0x08, 0x0f, 0x56, 0x35, 0xaf, 0xfe, 0x8f, 0x6e, 0x41, 0x4b,
+	0x62, 0x71, 0x12, 0xa7, 0x94, 0x07, 0x2d, 0x8d, 0xaf, 0x2a, 0x7a, 0x97, 0x72, 0xf4, 0x01, 0xac,
+	0x3b, 0x7f, 0xe2, 0x8c, 0x4e, 0xa8, 0x14, 0x41, 0x7b, 0xc3, 0x1b, 0xb4, 0xa2, 0x35, 0x07, 0xef,
+	0x6b, 0x14, 0x3d, 0x80, 0xeb, 0x47, 0x58, 0xd0, 0x24, 0x2e, 0x38, 0x4b, 0x88, 0x10, 0x71, 0x32,
+	0xe2, 0xac, 0x2c, 0x02, 0x50, 0xdc, 0x4f, 0x96, 0x02, 0x2f, 0x42, 0xfa, 0xfc, 0xc0, 0x1c, 0xef,
+	0xe8, 0x53, 0xb4, 0x0b, 0x2b, 0x13, 0x56, 0xe6, 0x52, 0x04, 0x9d, 0x8d, 0xc6, 0xa0, 0xb3, 0x75,
+	0x6f, 0xce, 0x70, 0x3d, 0x55, 0x42, 0x91, 0x95, 0x45, 0xbf, 0x84, 0xd5, 0x94, 0x4c, 0xa9, 0x8a,
+	0xba, 0xaf, 0xd5, 0xfc, 0x78, 0x4e, 0x35, 0xbb, 0x5a, 0x2a, 0x72, 0xd2, 0x68, 0x0c, 0xd7, 0x72,
+	0x22, 0x4f, 0x19, 0x3f, 0x89, 0xa9, 0x60, 0x19, 0x96, 0x94, 0xe5, 0x41, 0x57, 0x27, 0xf2, 0xd3,
+	0x39, 0x55, 0x3e, 0x33, 0xf2, 0x5f, 0x3a, 0xf1, 0xc3, 0x82, 0x24, 0x51, 0x2f, 0x7f, 0x0d, 0x45,
+	0x21, 0x74, 0x73, 0x16, 0x17, 0x74, 0xca, 0x64, 0xcc, 0x19, 0x93, 0xc1, 0x9a, 0x8e, 0x6a, 0x27,
+	0x67, 0x07, 0x0a, 0x8b, 0x18, 0x93, 0x68, 0x00, 0xbd, 0x94, 0x1c, 0xe3, 0x32, 0x93, 0x71, 0x41,
+	0xd3, 0x78, 0xc2, 0x52, 0x12, 0xac, 0xeb, 0xf4, 0xac, 0x59, 0xfc, 0x80, 0xa6, 0x4f, 0x59, 0x4a,
+	0xea, 0x9c, 0xb4, 0x48, 0x0c, 0x67, 0xef, 0x02, 0xe7, 0x97, 0x45, 0xa2, 0x39, 0xef, 0x42, 0x37,
+	0x29, 0x4a, 0x41, 0xa4, 0xcb, 0xcf, 0x35, 0xcd, 0xe6, 0x1b, 0xd0, 0x66, 0xe5, 0x36, 0x00, 0xce,
+	0x32, 0x76, 0x1a, 0x27, 0xb8, 0x10, 0x01, 0xd2, 0xc5, 0xd3, 0xd6, 0xc8, 0x0e, 0x2e, 0x04, 0x0a,
+	0xc1, 0x4f, 0x70, 0x81, 0x8f, 0x68, 0x46, 0x25, 0x25, 0x22, 0x78, 0x4b, 0x33, 0x5c, 0xc0, 0xd0,
+	0x3d, 0x40, 0xc6, 0x40, 0x3c, 0xdd, 0x8a, 0xd9, 0x94, 0x70, 0x4e, 0x53, 0x12, 0x5c, 0xd7, 0xc6,
+	0x7a, 0xe6, 0xe4, 0xc5, 0xd6, 0x6f, 0x2c, 0x8e, 0xce, 0x67, 0xdc, 0x1f, 0xcf, 0xb8, 0x6f, 0xe8,
+	0x5c, 0x7e, 0x35, 0x9c, 0xef, 0xe9, 0x0f, 0x2f, 0xbc, 0xd8, 0xa1, 0xb9, 0xca, 0x8b, 0x8f, 0x9d,
+	0x8d, 0xbd, 0x5c, 0xf2, 0xf3, 0xca, 0x74, 0x05, 0xab, 0x44, 0x30, 0x36, 0x89, 0x45, 0xc2, 0x38,
+	0x89, 0x71, 0xfa, 0x4d, 0x70, 0x73, 0xc3, 0x1b, 0x34, 0xa3, 0x0e, 0x63, 0x93, 0x43, 0x85, 0xfd,
+	0x22, 0xfd, 0x46, 0xbd, 0x0f, 0x5d, 0x13, 0xea, 0x7d, 0xbc, 0x6d, 0xde, 0x87, 0xa2, 0xd5, 0xfb,
+	0x18, 0x40, 0xaf, 0x20, 0xfc, 0x38, 0x26, 0x53, 0x92, 0xcb, 0x58, 0x48, 0x2c, 0x45, 0x10, 0x98,
+	0x07, 0xa2, 0xf0, 0x3d, 0x05, 0x1f, 0x2a, 0x54, 0x45, 0x9e, 0x97, 0xb9, 0x6a, 0x47, 0xf1, 0x98,
+	0xaa, 0x8a, 0xbf, 0xa5, 0xd9, 0x7c, 0x0b, 0xfe, 0x8a, 0xe6, 0x86, 0x49, 0x90, 0x8c, 0xe6, 0xe5,
+	0x59, 0x9c, 0xe1, 0x23, 0x92, 0x05, 0x7d, 0x93, 0x1e, 0x0b, 0xee, 0x2b, 0x0c, 0x7d, 0x08, 0x3d,
+	0x5c, 0x14, 0x98, 0x4f, 0x18, 0x57, 0xaf, 0xed, 0x98, 0x66, 0x24, 0x78, 0x47, 0xf3, 0xad, 0x3b,
+	0xfc, 0xc0, 0xc0, 0xe8, 0x7d, 0xf0, 0x27, 0x58, 0x9c, 0x90, 0x54, 0x37, 0x08, 0x11, 0xbc, 0xab,
+	0x53, 0xd5, 0x31, 0x98, 0xea, 0x10, 0x02, 0xfd, 0x00, 0xd6, 0x38, 0xc1, 0x29, 0xcb, 0xb3, 0x73,
+	0xcb, 0x74, 0x5b, 0x33, 0x75, 0x1d, 0x6a, 0xd8, 0x9e, 0x40, 0x23, 0xcd, 0x45, 0xf0, 0xde, 0x42,
+	0x5d, 0x6d, 0xf7, 0xd9, 0xe1, 0x0e, 0xcb, 0x8f, 0xe9, 0x28, 0x52, 0xc2, 0xe8, 0x6b, 0x58, 0x23,
+	0x23, 0xae, 0x9b, 0x43, 0x86, 0x85, 0x20, 0x22, 0xb8, 0xa3, 0x53, 0xfc, 0xc9, 0xbc, 0x29, 0xde,
+	0xd3, 0xd2, 0x3b, 0x4a, 0x38, 0xea, 0x92, 0x19, 0x61, 0x7a, 0xe5, 0x31, 0xc7, 0x13, 0x92, 0xc6,
+	0x19, 0x1b, 0x89, 0x60, 0x43, 0x07, 0x17, 0x0c, 0xb4, 0xcf, 0x46, 0x02, 0xbd, 0x07, 0x50, 0x70,
+	0x36, 0x25, 0x39, 0xce, 0x13, 0x12, 0xbc, 0x6f, 0x5a, 0xe5, 0x0c, 0x41, 0xbf, 0x83, 0xee, 0x29,
+	0xcd, 0x53, 0x76, 0x2a, 0x94, 0x06, 0x96, 0x07, 0xa1, 0xbe, 0xea, 0x83, 0x79, 0x7d, 0x7b, 0x69,
+	0x84, 0xf7, 0x95, 0x6c, 0xe4, 0x9f, 0xd6, 0x28, 0xa5, 0x9a, 0x13, 0x91, 0x48, 0x9e, 0x99, 0x8b,
+	0x07, 0x77, 0x17, 0x53, 0x1d, 0x19, 0x61, 0x73, 0x6f, 0x9f, 0xd7, 0x28, 0x75, 0xed, 0x57, 0x6c,
+	0x16, 0xcf, 0xef, 0x9b, 0x6b, 0xbf, 0x62, 0x2e, 0x2e, 0xfd, 0x1d, 0xb8, 0x71, 0xe9, 0x53, 0x50,
+	0xa3, 0xe1, 0x84, 0x9c, 0xbb, 0x91, 0x76, 0x42, 0xce, 0xd1, 0x75, 0x68, 0x4e, 0x71, 0x56, 0x92,
+	0x60, 0x49, 0x63, 0x86, 0xf8, 0xe9, 0xd2, 0x63, 0x2f, 0xfc, 0x0c, 0xfc, 0xba, 0x0f, 0x6a, 0x88,
+	0xe4, 0x78, 0x42, 0xac, 0xb0, 0xfe, 0x8f, 0xfa, 0xd0, 0x12, 0xc9, 0x98, 0x4c, 0xb0, 0xc4, 0x76,
+	0x28, 0x56, 0x74, 0x18, 0x81, 0x5f, 0x0f, 0x8f, 0x2a, 0xf3, 0x02, 0x0b, 0x71, 0xca, 0x78, 0x1a,
+	0xeb, 0xf2, 0x35, 0x8a, 0x7c, 0x07, 0x7e, 0xa1, 0x6a, 0xf7, 0x36, 0x80, 0x4e, 0x44, 0x2c, 0xcf,
+	0x0b, 0xe7, 0x53, 0x5b, 0x23, 0xcf, 0xcf, 0x0b, 0x12, 0x3e, 0x85, 0x4e, 0xad, 0x1c, 0x2e, 0x75,
+	0xe9, 0x3a, 0x34, 0x13, 0x9a, 0x72, 0x37, 0xa4, 0x0d, 0xa1, 0xd0, 0x82, 0x71, 0xa9, 0x26, 0x74,
+	0x63, 0xd0, 0x8c, 0x0c, 0x11, 0xfe, 0xd3, 0x83, 0x35, 0xd7, 0x41, 0x44, 0xc1, 0x72, 0x41, 0xd0,
+	0x33, 0x58, 0xb5, 0xc3, 0x2c, 0xf0, 0x16, 0x4b, 0x98, 0x1d, 0x72, 0xea, 0xe1, 0x93, 0xc8, 0x29,
+	0x41, 0xd1, 0x85, 0x0a, 0x5c, 0xd2, 0x2a, 0xb7, 0x16, 0x50, 0x69, 0x25, 0xeb, 0x55, 0x1b, 0xfe,
+	0xc3, 0x03, 0x98, 0x1d, 0xa1, 0x5f, 0xc3, 0xca, 0x11, 0xcd, 0x31, 0x3f, 0x0f, 0xbc, 0xc5, 0xd4,
+	0xab, 0x88, 0xef, 0xd2, 0x11, 0x11, 0x32, 0xb2, 0x1a, 0xd0, 0x01, 0xb4, 0x33, 0x7a, 0xc4, 0x31,
+	0xa7, 0xc4, 0x44, 0xf0, 0x6a, 0xea, 0x66, 0x4a, 0xc2, 0xc7, 0x00, 0xb3, 0x03, 0x95, 0x31, 0xbd,
+	0xb5, 0xd8, 0x8c, 0xa9, 0xff, 0xe8, 0x26, 0xac, 0x88, 0x31, 0xde, 0x7a, 0xb8, 0x6d, 0xf3, 0x6d,
+	0xa9, 0xb0, 0x0b, 0x9d, 0x97, 0x98, 0x4a, 0xdb, 0xdc, 0xc3, 0xdf, 0x83, 0x6f, 0xc8, 0xef, 0x26,
+	0x53, 0xe1, 0x3e, 0xac, 0x1f, 0x8e, 0x4b, 0x99, 0xb2, 0xd3, 0xdc, 0x6d, 0x80, 0xca, 0x33, 0x3a,
+	0xca, 0x71, 0x66, 0xfd, 0xb5, 0x94, 0xea, 0xb0, 0x23, 0x8e, 0x13, 0x12, 0x17, 0x84, 0x53, 0x96,
+	0x6a, 0xbf, 0x1b, 0x51, 0x47, 0x63, 0x07, 0x1a, 0x0a, 0x11, 0xf4, 0x66, 0xda, 0x8c, 0xc7, 0xe1,
+	0x18, 0x6e, 0xfe, 0xb6, 0x48, 0x95, 0xd1, 0x6a, 0xf1, 0xb3, 0x86, 0x2e, 0x2c, 0x91, 0xde, 0xff,
+	0xbd, 0x44, 0x86, 0xb7, 0xe0, 0xed, 0x37, 0x2c, 0x59, 0x27, 0x7a, 0xb0, 0xf6, 0x82, 0x70, 0x41,
+	0x99, 0xbb, 0x65, 0xf8, 0x23, 0x58, 0xaf, 0x10, 0x1b, 0xdb, 0x00, 0x56, 0xa7, 0x06, 0xb2, 0x37,
+	0x77, 0x64, 0x78, 0x03, 0xde, 0xda, 0xa9, 0xcd, 0x7c, 0xa7, 0xe3, 0xdf, 0x1e, 0x5c, 0xbf, 0x88,
+	0x5b, 0x4d, 0x1f, 0x42, 0x4f, 0xfb, 0x99, 0xb0, 0x2c, 0xae, 0xab, 0x6c, 0x46, 0xeb, 0x0e, 0xb7,
+	0xc6, 0x55, 0x83, 0xd0, 0x17, 0xad, 0xf8, 0x4c, 0x39, 0xf8, 0x1a, 0x74, 0x4c, 0xef, 0x42, 0xdb,
+	0x26, 0xcc, 0xae, 0xdb, 0xad, 0x68, 0x06, 0x28, 0xbf, 0xdd, 0x70, 0x5c, 0xd6, 0x67, 0x8e, 0x54,
+	0x8d, 0x45, 0xcf, 0x6c, 0x33, 0xad, 0x9b, 0x56, 0x90, 0xf0, 0x63, 0x33, 0xa8, 0x3f, 0x82, 0x6b,
+	0x92, 0x49, 0x9c, 0xc5, 0x49, 0x51, 0xc6, 0x82, 0x24, 0x2c, 0x4f, 0x45, 0xb0, 0xa2, 0xb9, 0xd6,
+	0xf5, 0xc1, 0x4e, 0x51, 0x1e, 0x1a, 0x38, 0xfc, 0x08, 0x7c, 0x2d, 0xe4, 0x92, 0xd7, 0x87, 0x16,
+	0xcd, 0x25, 0xe1, 0x53, 0x5b, 0x27, 0x8d, 0xa8, 0xa2, 0xc3, 0x97, 0xd0, 0xb5, 0xbc, 0x36, 0x1e,
+	0x5f, 0x40, 0xd3, 0xb8, 0xb0, 0x58, 0x96, 0x9f, 0x63, 0x71, 0x62, 0x14, 0x19, 0x71, 0x55, 0x5f,
+	0x07, 0xee, 0xda, 0x2e, 0x09, 0x04, 0xae, 0xd5, 0x30, 0x6b, 0xf0, 0xa0, 0x1e, 0x30, 0xef, 0x5b,
+	0x5e, 0xf4, 0x9b, 0x46, 0xad, 0xc2, 0x5a, 0x90, 0xc3, 0x7b, 0xb0, 0x66, 0x57, 0x8d, 0x5a, 0x04,
+	0xd2, 0x92, 0x9b, 0xcd, 0xd9, 0x46, 0xc0, 0xd1, 0xe1, 0x36, 0xac, 0x57, 0xdc, 0xd6, 0xa5, 0xbb,
+	0xd0, 0x3d, 0x66, 0x59, 0x4a, 0x52, 0x95, 0x8d, 0xe4, 0xc4, 0xc4, 0xc2, 0x8f, 0x7c, 0x03, 0x1e,
+	0x6a, 0x2c, 0xfc, 0x00, 0xba, 0x87, 0xfa, 0xb5, 0x5d, 0xfe, 0x18, 0x9b, 0xee, 0x31, 0xaa, 0x82,
+	0x76, 0x8c, 0xb6, 0xc4, 0x4f, 0xa0, 0xb3, 0x77, 0x46, 0x12, 0x27, 0xb8, 0x0d, 0xad, 0x94, 0xe0,
+	0x34, 0xa3, 0x39, 0xb1, 0x51, 0xef, 0x0f, 0xcd, 0x17, 0xe3, 0xd0, 0x7d, 0x31, 0x0e, 0x9f, 0xbb,
+	0x2f, 0xc6, 0xa8, 0xe2, 0x75, 0xdf, 0x7f, 0x4b, 0x6f, 0x7e, 0xff, 0x35, 0x66, 0xdf, 0x7f, 0xe1,
+	0x0e, 0xf8, 0xc6, 0x98, 0xbd, 0xdc, 0x4d, 0x58, 0x61, 0xa5, 0x2c, 0x4a, 0x69, 0x6f, 0x65, 0x29,
+	0xf4, 0x0e, 0xb4, 0xc9, 0x19, 0x95, 0x71, 0xa2, 0xf6, 0xf4, 0x25, 0x7d, 0x83, 0x96, 0x02, 0x76,
+	0x58, 0x4a, 0xc2, 0x7f, 0x79, 0xe0, 0xd7, 0xbb, 0x92, 0xb2, 0x5d, 0xd0, 0xd4, 0xde, 0x54, 0xfd,
+	0xfd, 0x9f, 0xf2, 0xb5, 0xd8, 0x34, 0xea, 0xb1, 0x41, 0x43, 0x58, 0x56, 0x7b, 0x66, 0xb0, 0xfc,
+	0xad, 0xd7, 0xd6, 0x7c, 0xea, 0x95, 0xa8, 0xc5, 0xf8, 0x84, 0x66, 0x19, 0x49, 0xdd, 0x2b, 0x61,
+	0x6c, 0xf2, 0x95, 0x06, 0xd4, 0xe2, 0xa1, 0x7d, 0xe0, 0x04, 0x0b, 0x96, 0xeb, 0xf7, 0xd1, 0x8e,
+	0x40, 0x41, 0x91, 0x46, 0xb6, 0xfe, 0xee, 0x43, 0x6b, 0xcf, 0x36, 0x5b, 0x74, 0x0e, 0x2b, 0x66,
+	0xb8, 0xa2, 0x87, 0x57, 0x5a, 0xe7, 0xfb, 0xdb, 0x8b, 0x8a, 0xd9, 0xfc, 0x7f, 0x0f, 0x09, 0x58,
+	0x56, 0xb3, 0x02, 0xcd, 0xbd, 0x64, 0xd6, 0x06, 0x4d, 0xff, 0xc1, 0x62, 0x42, 0x95, 0xd1, 0x3f,
+	0x42, 0xcb, 0xb5, 0x7c, 0xf4, 0x68, 0x5e, 0x1d, 0xaf, 0x8d, 0x9c, 0xfe, 0xe3, 0xc5, 0x05, 0x2b,
+	0x07, 0xfe, 0xea, 0xc1, 0xfa, 0x6b, 0x6d, 0x1f, 0x7d, 0x36, 0xaf, 0xbe, 0xcb, 0x27, 0x53, 0xff,
+	0xf3, 0x2b, 0xcb, 0x57, 0x6e, 0xfd, 0x01, 0x56, 0x5d, 0xf7, 0x9e, 0x3b, 0xa3, 0x17, 0x47, 0x54,
+	0xff, 0xd1, 0xc2, 0x72, 0x95, 0xf5, 0x33, 0x68, 0x9a, 0x16, 0x3f, 0x77, 0x5a, 0xeb, 0xcd, 0xbd,
+	0xff, 0x70, 0x41, 0x29, 0x67, 0xf7, 0xbe, 0xa7, 0xea, 0xdf, 0x34, 0xa6, 0xf9, 0xeb, 0xff, 0x42,
+	0xc7, 0xeb, 0x6f, 0x2f, 0x2a, 0x56, 0xaf, 0x7f, 0xf5, 0x0c, 0xe7, 0xaf, 0xff, 0x5a, 0xbf, 0xec,
+	0x3f, 0x58, 0x4c, 0xa8, 0x32, 0xfa, 0x27, 0x0f, 0xda, 0xd5, 0xfc, 0x41, 0x8f, 0x17, 0xdc, 0xc6,
+	0x66, 0x25, 0xf7, 0x93, 0x2b, 0x48, 0xd6, 0x8b, 0xcd, 0x7d, 0x07, 0x6f, 0x2f, 0xa0, 0xa7, 0x36,
+	0xcd, 0xfa, 0x8f, 0x16, 0x96, 0xab, 0xac, 0xff, 0xd9, 0x03, 0xbf, 0xbe, 0x06, 0xa1, 0x4f, 0xe7,
+	0xd5, 0x75, 0xc9, 0x52, 0xd5, 0xff, 0xd9, 0xd5, 0x84, 0x2b, 0x6f, 0xfe, 0xe6, 0x41, 0x57, 0xe5,
+	0xe8, 0x50, 0x72, 0x82, 0x27, 0x34, 0x1f, 0xa1, 0xcf, 0xe7, 0x9c, 0xfc, 0x4a, 0xca, 0xac, 0x1c,
+	0x56, 0xd2, 0xb9, 0xf4, 0xf3, 0xab, 0x2b, 0x70, 0x6e, 0x0d, 0xbc, 0xfb, 0xde, 0x93, 0xd5, 0xaf,
+	0x9b, 0x66, 0x08, 0xad, 0xe8, 0x9f, 0x4f, 0xfe, 0x33, 0x00, 0xab, 0xa6, 0x9f, 0x5a, 0x1a, 0x16,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1015,6 +1693,9 @@ type ExecutorClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Executor_StatsClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	Processes(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessesResponse, error)
+	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
 }
@@ -1122,6 +1803,33 @@ func (c *executorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *executorClient) Processes(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessesResponse, error) {
+	out := new(ProcessesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Processes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Profile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executorClient) ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Executor_serviceDesc.Streams[1], "/hashicorp.nomad.plugins.executor.proto.Executor/ExecStreaming", opts...)
 	if err != nil {
@@ -1163,6 +1871,9 @@ type ExecutorServer interface {
 	Stats(*StatsRequest, Executor_StatsServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	Processes(context.Context, *ProcessesRequest) (*ProcessesResponse, error)
+	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
 }
@@ -1195,6 +1906,15 @@ func (*UnimplementedExecutorServer) Signal(ctx context.Context, req *SignalReque
 func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
 }
+func (*UnimplementedExecutorServer) Processes(ctx context.Context, req *ProcessesRequest) (*ProcessesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Processes not implemented")
+}
+func (*UnimplementedExecutorServer) Profile(ctx context.Context, req *ProfileRequest) (*ProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Profile not implemented")
+}
+func (*UnimplementedExecutorServer) Capabilities(ctx context.Context, req *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
@@ -1350,6 +2070,60 @@ func _Executor_Exec_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Processes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Processes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Processes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Processes(ctx, req.(*ProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Profile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Profile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Profile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Profile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Executor_ExecStreaming_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ExecutorServer).ExecStreaming(&executorExecStreamingServer{stream})
 }
@@ -1408,6 +2182,18 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Processes",
+			Handler:    _Executor_Processes_Handler,
+		},
+		{
+			MethodName: "Profile",
+			Handler:    _Executor_Profile_Handler,
+		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _Executor_Capabilities_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
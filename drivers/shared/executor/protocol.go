@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+)
+
+const (
+	// ExecutorProtocolV2 is the grpc executor protocol of Nomad 0.9 and
+	// later, from before the protocol version was negotiated. It matches the
+	// ProtocolVersion of the shared plugin handshake.
+	ExecutorProtocolV2 = 2
+
+	// ExecutorProtocolV3 adds the Capabilities RPC, so the client can tell
+	// which optional features an executor supports.
+	ExecutorProtocolV3 = 3
+
+	// ExecutorProtocolLatest is the latest executor protocol version
+	ExecutorProtocolLatest = ExecutorProtocolV3
+)
+
+// versionedPluginMap returns the executor plugin for each protocol version
+// the executor client and server support. go-plugin negotiates the latest
+// version both ends support when launching an executor.
+func versionedPluginMap(logger hclog.Logger, fsIsolation bool, compute cpustats.Compute) map[int]plugin.PluginSet {
+	return map[int]plugin.PluginSet{
+		ExecutorProtocolV2: pluginMap(logger, fsIsolation, compute, ExecutorProtocolV2),
+		ExecutorProtocolV3: pluginMap(logger, fsIsolation, compute, ExecutorProtocolV3),
+	}
+}
+
+// ReattachConfig returns the config to reattach to the executor the plugin
+// client launched, including the protocol version negotiated with it, since
+// go-plugin doesn't negotiate one when reattaching.
+func ReattachConfig(c *plugin.Client) *plugin.ReattachConfig {
+	rc := c.ReattachConfig()
+	if rc != nil && rc.ProtocolVersion == 0 {
+		rc.ProtocolVersion = c.NegotiatedVersion()
+	}
+	return rc
+}
+
+// reattachProtocolVersion returns the protocol version to reattach to an
+// executor with. Reattach configs persisted before the version was recorded
+// are for executors speaking ExecutorProtocolV2.
+func reattachProtocolVersion(rc *plugin.ReattachConfig) int {
+	if rc.ProtocolVersion < ExecutorProtocolV2 || rc.ProtocolVersion > ExecutorProtocolLatest {
+		return ExecutorProtocolV2
+	}
+	return rc.ProtocolVersion
+}
+
+// ErrUnsupportedByExecutor is returned when the executor of a task doesn't
+// support an optional feature.
+var ErrUnsupportedByExecutor = errors.New("unsupported by executor")
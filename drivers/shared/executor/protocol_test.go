@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_ReattachProtocolVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		version, exp int
+	}{
+		{version: 0, exp: ExecutorProtocolV2}, // persisted before it was recorded
+		{version: ExecutorProtocolV2, exp: ExecutorProtocolV2},
+		{version: ExecutorProtocolV3, exp: ExecutorProtocolV3},
+		{version: 99, exp: ExecutorProtocolV2},
+	} {
+		rc := &plugin.ReattachConfig{ProtocolVersion: tc.version}
+		require.Equal(t, tc.exp, reattachProtocolVersion(rc))
+	}
+}
+
+func TestExecutor_VersionedPluginMap(t *testing.T) {
+	ci.Parallel(t)
+
+	plugins := versionedPluginMap(nil, false, cpustats.Compute{})
+	require.Len(t, plugins, 2)
+	for version, set := range plugins {
+		p := set["executor"].(*ExecutorPlugin)
+		require.Equal(t, version, p.protocolVersion)
+	}
+}
+
+func TestExecutor_withStatsMode(t *testing.T) {
+	ci.Parallel(t)
+
+	plugins := withStatsMode(versionedPluginMap(nil, false, cpustats.Compute{}), procstats.ModeJiffies)
+	for _, set := range plugins {
+		p := set["executor"].(*ExecutorPlugin)
+		require.Equal(t, procstats.ModeJiffies, p.statsMode)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// ProvenanceBinary records the digest of the binary a task executes
+	ProvenanceBinary = "binary"
+
+	// ProvenanceLibraries records the digests of the binary a task executes
+	// and of the shared libraries it links
+	ProvenanceLibraries = "libraries"
+
+	// maxProvenanceLibraries bounds the number of shared libraries recorded,
+	// including the ones they link themselves
+	maxProvenanceLibraries = 256
+
+	// maxSymlinkHops bounds the symlinks followed resolving a library
+	maxSymlinkHops = 40
+)
+
+// Provenance records the files a task was launched from, for supply-chain
+// auditing of the workloads a node ran.
+type Provenance struct {
+	// Binary is the binary the task executes
+	Binary *FileDigest
+
+	// Libraries are the shared libraries the binary links, if they were
+	// recorded, including the dynamic loader
+	Libraries []*FileDigest
+}
+
+// FileDigest is the digest of a file a task was launched from.
+type FileDigest struct {
+	// Path is the path of the file as the task sees it
+	Path string
+
+	// SHA256 is the hex encoded SHA-256 digest of the file
+	SHA256 string
+}
+
+// ValidateProvenance returns an error if mode isn't a provenance mode.
+func ValidateProvenance(mode string) error {
+	switch mode {
+	case "", ProvenanceBinary, ProvenanceLibraries:
+		return nil
+	}
+	return fmt.Errorf("provenance must be %q or %q: %q", ProvenanceBinary, ProvenanceLibraries, mode)
+}
+
+// recordProvenance records the provenance of the binary at hostPath, which the
+// task sees under root. Libraries that can't be resolved or read are skipped,
+// since they are resolved the way the dynamic loader would by default, which
+// the task may override.
+func recordProvenance(mode, root, hostPath string) (*Provenance, error) {
+	if mode == "" {
+		return nil, nil
+	}
+
+	binary, err := digestFile(root, hostPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record provenance of %q: %v", hostPath, err)
+	}
+	p := &Provenance{Binary: binary}
+	if mode == ProvenanceLibraries {
+		p.Libraries = linkedLibraries(root, hostPath)
+	}
+	return p, nil
+}
+
+func digestFile(root, hostPath string) (*FileDigest, error) {
+	f, err := os.Open(hostPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return &FileDigest{
+		Path:   provenancePath(root, hostPath),
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// linkedLibraries returns the digests of the shared libraries the ELF binary
+// at hostPath links, transitively, and of its dynamic loader. Binaries that
+// aren't dynamically linked ELF binaries link none.
+func linkedLibraries(root, hostPath string) []*FileDigest {
+	bin, err := elf.Open(hostPath)
+	if err != nil {
+		return nil
+	}
+	defer bin.Close()
+
+	var libraries []*FileDigest
+	seen := map[string]bool{hostPath: true}
+	add := func(hostPath string) bool {
+		if seen[hostPath] || len(libraries) >= maxProvenanceLibraries {
+			return false
+		}
+		seen[hostPath] = true
+		if digest, err := digestFile(root, hostPath); err == nil {
+			libraries = append(libraries, digest)
+		}
+		return true
+	}
+
+	for _, prog := range bin.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+		interp, err := io.ReadAll(prog.Open())
+		if err == nil {
+			if resolved, ok := resolveInRoot(root, strings.TrimRight(string(interp), "\x00")); ok {
+				add(resolved)
+			}
+		}
+	}
+
+	queue := []string{hostPath}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, lib := range neededLibraries(root, next, bin.Class, bin.Machine) {
+			if add(lib) {
+				queue = append(queue, lib)
+			}
+		}
+	}
+	return libraries
+}
+
+// neededLibraries returns the host paths of the libraries the ELF file at
+// hostPath links directly, resolved in the run paths of the file and then in
+// the default library directories.
+func neededLibraries(root, hostPath string, class elf.Class, machine elf.Machine) []string {
+	f, err := elf.Open(hostPath)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	needed, err := f.ImportedLibraries()
+	if err != nil || len(needed) == 0 {
+		return nil
+	}
+
+	origin := path.Dir(provenancePath(root, hostPath))
+	var dirs []string
+	for _, tag := range []elf.DynTag{elf.DT_RUNPATH, elf.DT_RPATH} {
+		values, _ := f.DynString(tag)
+		for _, value := range values {
+			for _, dir := range strings.Split(value, ":") {
+				dir = strings.ReplaceAll(dir, "$ORIGIN", origin)
+				dir = strings.ReplaceAll(dir, "${ORIGIN}", origin)
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	dirs = append(dirs, defaultLibraryDirs(class)...)
+
+	var libraries []string
+	for _, name := range needed {
+		candidates := []string{name}
+		if !strings.Contains(name, "/") {
+			candidates = candidates[:0]
+			for _, dir := range dirs {
+				candidates = append(candidates, path.Join(dir, name))
+			}
+		}
+		for _, candidate := range candidates {
+			resolved, ok := resolveInRoot(root, candidate)
+			if ok && matchesELF(resolved, class, machine) {
+				libraries = append(libraries, resolved)
+				break
+			}
+		}
+	}
+	return libraries
+}
+
+// defaultLibraryDirs returns the directories the dynamic loader searches for
+// libraries by default, from the most specific ones.
+func defaultLibraryDirs(class elf.Class) []string {
+	var multiarch string
+	switch runtime.GOARCH {
+	case "amd64":
+		multiarch = "x86_64-linux-gnu"
+	case "arm64":
+		multiarch = "aarch64-linux-gnu"
+	}
+
+	var dirs []string
+	if multiarch != "" {
+		dirs = append(dirs, "/lib/"+multiarch, "/usr/lib/"+multiarch)
+	}
+	if class == elf.ELFCLASS64 {
+		dirs = append(dirs, "/lib64", "/usr/lib64")
+	}
+	return append(dirs, "/lib", "/usr/lib", "/usr/local/lib")
+}
+
+func matchesELF(hostPath string, class elf.Class, machine elf.Machine) bool {
+	f, err := elf.Open(hostPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return f.Class == class && f.Machine == machine
+}
+
+// resolveInRoot returns the host path of the file at the task path p,
+// following symlinks within root so absolute links don't escape a chroot.
+func resolveInRoot(root, p string) (string, bool) {
+	p = path.Clean("/" + p)
+	for hops := 0; hops < maxSymlinkHops; hops++ {
+		hostPath := filepath.Join(root, filepath.FromSlash(p))
+		fi, err := os.Lstat(hostPath)
+		if err != nil {
+			return "", false
+		}
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return hostPath, fi.Mode().IsRegular()
+		}
+		target, err := os.Readlink(hostPath)
+		if err != nil {
+			return "", false
+		}
+		if !path.IsAbs(target) {
+			target = path.Join(path.Dir(p), target)
+		}
+		p = path.Clean("/" + target)
+	}
+	return "", false
+}
+
+// provenancePath returns the path of the file at hostPath as the task sees it.
+func provenancePath(root, hostPath string) string {
+	rel, err := filepath.Rel(root, hostPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return hostPath
+	}
+	return path.Clean("/" + filepath.ToSlash(rel))
+}
+
+// ProvenanceEvent returns the task event recording the provenance of a task,
+// with the digests of its files as annotations.
+func ProvenanceEvent(cfg *drivers.TaskConfig, p *Provenance) *drivers.TaskEvent {
+	annotations := map[string]string{
+		"binary":        p.Binary.Path,
+		"binary_sha256": p.Binary.SHA256,
+	}
+	for _, lib := range p.Libraries {
+		annotations["library:"+lib.Path] = lib.SHA256
+	}
+
+	message := fmt.Sprintf("Executed %s with sha256 %s", p.Binary.Path, p.Binary.SHA256)
+	if len(p.Libraries) > 0 {
+		message += fmt.Sprintf(" linking %d libraries", len(p.Libraries))
+	}
+	return &drivers.TaskEvent{
+		TaskID:      cfg.ID,
+		AllocID:     cfg.AllocID,
+		TaskName:    cfg.Name,
+		Timestamp:   time.Now(),
+		Message:     message,
+		Annotations: annotations,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestProvenance_Binary(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "local"), 0o755))
+	bin := filepath.Join(root, "local", "app")
+	must.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\necho hi\n"), 0o755))
+	sum := sha256.Sum256([]byte("#!/bin/sh\necho hi\n"))
+
+	p, err := recordProvenance("", root, bin)
+	must.NoError(t, err)
+	must.Nil(t, p)
+
+	// A script links no libraries
+	p, err = recordProvenance(ProvenanceLibraries, root, bin)
+	must.NoError(t, err)
+	must.Eq(t, &Provenance{Binary: &FileDigest{
+		Path:   "/local/app",
+		SHA256: hex.EncodeToString(sum[:]),
+	}}, p)
+
+	_, err = recordProvenance(ProvenanceBinary, root, filepath.Join(root, "missing"))
+	must.Error(t, err)
+
+	event := ProvenanceEvent(&drivers.TaskConfig{ID: "id", Name: "web"}, p)
+	must.Eq(t, "/local/app", event.Annotations["binary"])
+	must.Eq(t, p.Binary.SHA256, event.Annotations["binary_sha256"])
+}
+
+func TestProvenance_Libraries(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("libraries are only resolved for ELF binaries")
+	}
+
+	f, err := elf.Open("/bin/sh")
+	if err != nil {
+		t.Skipf("failed to open /bin/sh: %v", err)
+	}
+	needed, _ := f.ImportedLibraries()
+	f.Close()
+	if len(needed) == 0 {
+		t.Skip("/bin/sh is statically linked")
+	}
+
+	p, err := recordProvenance(ProvenanceLibraries, "/", "/bin/sh")
+	must.NoError(t, err)
+	must.SliceNotEmpty(t, p.Libraries)
+	for _, lib := range p.Libraries {
+		must.StrHasPrefix(t, "/", lib.Path)
+		must.Len(t, 64, []rune(lib.SHA256))
+	}
+	must.True(t, strings.HasPrefix(filepath.Base(p.Libraries[0].Path), "ld"),
+		must.Sprint("expected the dynamic loader to be recorded first"))
+}
+
+func TestProvenance_resolveInRoot(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	root := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "usr", "lib"), 0o755))
+	must.NoError(t, os.MkdirAll(filepath.Join(root, "lib"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(root, "usr", "lib", "libfoo.so.1.2"), nil, 0o644))
+
+	// Absolute links resolve within the root rather than on the host
+	must.NoError(t, os.Symlink("/usr/lib/libfoo.so.1.2", filepath.Join(root, "lib", "libfoo.so.1")))
+	must.NoError(t, os.Symlink("libfoo.so.1", filepath.Join(root, "lib", "libfoo.so")))
+
+	resolved, ok := resolveInRoot(root, "/lib/libfoo.so")
+	must.True(t, ok)
+	must.Eq(t, filepath.Join(root, "usr", "lib", "libfoo.so.1.2"), resolved)
+
+	_, ok = resolveInRoot(root, "/lib/libbar.so")
+	must.False(t, ok)
+}
+
+func TestProvenance_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateProvenance(""))
+	must.NoError(t, ValidateProvenance(ProvenanceBinary))
+	must.NoError(t, ValidateProvenance(ProvenanceLibraries))
+	must.Error(t, ValidateProvenance("all"))
+}
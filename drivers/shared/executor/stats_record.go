@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dproto "github.com/hashicorp/nomad/plugins/drivers/proto"
+)
+
+const (
+	// StatsRecordFile is the name of the file in the task directory the
+	// executor records its stats samples to
+	StatsRecordFile = "executor.stats"
+
+	// statsRecordMaxSize is the size after which the executor stops
+	// recording samples, so a forgotten debug flag can't fill the disk
+	statsRecordMaxSize = 64 * 1024 * 1024
+)
+
+// statsRecordMagic starts every stats record file, and identifies the
+// version of the format. Each sample follows as a uvarint length and the
+// protobuf encoding of the TaskStats sent to the client.
+var statsRecordMagic = []byte("NMDSTAT1")
+
+// statsRecordPath returns the path of the stats record file of the executor
+// whose log file is at logFile, which is in the task directory.
+func statsRecordPath(logFile string) string {
+	return filepath.Join(filepath.Dir(logFile), StatsRecordFile)
+}
+
+// withStatsRecording makes the executor plugins record the stats samples
+// they send to the file at path.
+func withStatsRecording(plugins map[int]plugin.PluginSet, path string) map[int]plugin.PluginSet {
+	for _, set := range plugins {
+		for _, p := range set {
+			if ep, ok := p.(*ExecutorPlugin); ok {
+				ep.statsRecordFile = path
+			}
+		}
+	}
+	return plugins
+}
+
+// statsRecorder appends the stats samples an executor sends to a file. It
+// is safe for concurrent use, since the client may open several stats
+// streams, and a nil recorder discards samples.
+type statsRecorder struct {
+	logger hclog.Logger
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+	buf  []byte
+}
+
+func newStatsRecorder(logger hclog.Logger, path string) (*statsRecorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r := &statsRecorder{logger: logger, f: f, size: info.Size()}
+	if r.size == 0 {
+		if _, err := f.Write(statsRecordMagic); err != nil {
+			f.Close()
+			return nil, err
+		}
+		r.size = int64(len(statsRecordMagic))
+	}
+	return r, nil
+}
+
+// record appends a sample. Failing to record is logged rather than
+// returned, since it must not interrupt the stats stream.
+func (r *statsRecorder) record(stats *dproto.TaskStats) {
+	if r == nil {
+		return
+	}
+
+	data, err := pb.Marshal(stats)
+	if err != nil {
+		r.logger.Warn("failed to encode stats sample for recording", "error", err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return
+	}
+
+	r.buf = binary.AppendUvarint(r.buf[:0], uint64(len(data)))
+	r.buf = append(r.buf, data...)
+	if r.size+int64(len(r.buf)) > statsRecordMaxSize {
+		r.logger.Warn("stats record file reached its maximum size; no longer recording", "path", r.f.Name())
+		r.f.Close()
+		r.f = nil
+		return
+	}
+	if _, err := r.f.Write(r.buf); err != nil {
+		r.logger.Warn("failed to record stats sample; no longer recording", "error", err)
+		r.f.Close()
+		r.f = nil
+		return
+	}
+	r.size += int64(len(r.buf))
+}
+
+// StatsRecordReader reads the stats samples recorded by an executor.
+type StatsRecordReader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+// NewStatsRecordReader returns a reader of the stats samples recorded in r,
+// or an error if r isn't a stats record file.
+func NewStatsRecordReader(r io.Reader) (*StatsRecordReader, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(statsRecordMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, statsRecordMagic) {
+		return nil, errors.New("not an executor stats record file")
+	}
+	return &StatsRecordReader{r: br}, nil
+}
+
+// Next returns the next recorded sample, or io.EOF once all the samples were
+// read. A sample cut short, such as when the executor was killed while
+// recording it, is returned as io.ErrUnexpectedEOF.
+func (s *StatsRecordReader) Next() (*drivers.TaskResourceUsage, error) {
+	size, err := binary.ReadUvarint(s.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	if size > statsRecordMaxSize {
+		return nil, fmt.Errorf("invalid stats sample size %d", size)
+	}
+
+	if uint64(cap(s.buf)) < size {
+		s.buf = make([]byte, size)
+	}
+	s.buf = s.buf[:size]
+	if _, err := io.ReadFull(s.r, s.buf); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	var stats dproto.TaskStats
+	if err := pb.Unmarshal(s.buf, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode stats sample: %w", err)
+	}
+	return drivers.TaskStatsFromProto(&stats)
+}
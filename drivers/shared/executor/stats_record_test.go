@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRecorder_Replay(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), StatsRecordFile)
+	sample := func(cpuSeconds float64) *drivers.TaskResourceUsage {
+		return &drivers.TaskResourceUsage{
+			ResourceUsage: &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{RSS: 1024, Measured: []string{"RSS"}},
+				CpuStats:    &drivers.CpuStats{TotalCpuSeconds: cpuSeconds, Measured: []string{"Total CPU Seconds"}},
+			},
+			Timestamp: time.Now().UnixNano(),
+		}
+	}
+
+	record := func(r *statsRecorder, cpuSeconds float64) {
+		pb, err := drivers.TaskStatsToProto(sample(cpuSeconds))
+		require.NoError(t, err)
+		r.record(pb)
+	}
+
+	r, err := newStatsRecorder(testlog.HCLogger(t), path)
+	require.NoError(t, err)
+	record(r, 1)
+	record(r, 2)
+
+	// Reopening the file, such as by another stats stream, appends to it
+	r, err = newStatsRecorder(testlog.HCLogger(t), path)
+	require.NoError(t, err)
+	record(r, 3)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	reader, err := NewStatsRecordReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	for _, exp := range []float64{1, 2, 3} {
+		s, err := reader.Next()
+		require.NoError(t, err)
+		require.Equal(t, exp, s.ResourceUsage.CpuStats.TotalCpuSeconds)
+		require.Equal(t, uint64(1024), s.ResourceUsage.MemoryStats.RSS)
+	}
+	_, err = reader.Next()
+	require.Equal(t, io.EOF, err)
+
+	// A sample cut short is reported as such
+	reader, err = NewStatsRecordReader(bytes.NewReader(data[:len(data)-3]))
+	require.NoError(t, err)
+	reader.Next()
+	reader.Next()
+	_, err = reader.Next()
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = NewStatsRecordReader(bytes.NewReader([]byte("not stats")))
+	require.Error(t, err)
+
+	// A nil recorder discards samples
+	var nilRecorder *statsRecorder
+	record(nilRecorder, 4)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// statsSequence numbers the stats samples an executor collects and records
+// the time elapsed between them, as they are collected rather than when the
+// client receives them, so the client can tell samples lost on the way from
+// samples that were never collected. The numbering carries on across the
+// stats streams the client opens, since it may restart a stalled stream.
+type statsSequence struct {
+	mu        sync.Mutex
+	seq       uint64
+	collected time.Time
+}
+
+// stamp numbers a sample collected at the given time, which must carry a
+// monotonic clock reading.
+func (s *statsSequence) stamp(usage *cstructs.TaskResourceUsage, collected time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	usage.Sequence = s.seq
+	if !s.collected.IsZero() {
+		usage.CollectionDuration = collected.Sub(s.collected)
+	}
+	s.collected = collected
+}
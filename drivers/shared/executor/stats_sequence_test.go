@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStatsSequence_stamp(t *testing.T) {
+	ci.Parallel(t)
+
+	var seq statsSequence
+	start := time.Now()
+
+	first := &cstructs.TaskResourceUsage{}
+	seq.stamp(first, start)
+	must.Eq(t, 1, first.Sequence)
+	must.Zero(t, first.CollectionDuration)
+
+	second := &cstructs.TaskResourceUsage{}
+	seq.stamp(second, start.Add(1500*time.Millisecond))
+	must.Eq(t, 2, second.Sequence)
+	must.Eq(t, 1500*time.Millisecond, second.CollectionDuration)
+}
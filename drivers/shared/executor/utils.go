@@ -6,15 +6,16 @@ package executor
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
 
 	"github.com/golang/protobuf/ptypes"
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/egressstats"
+	"github.com/hashicorp/nomad/client/lib/resctrl"
 	"github.com/hashicorp/nomad/drivers/shared/executor/proto"
 	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/version"
 )
 
 const (
@@ -35,25 +36,30 @@ func CreateExecutor(
 	executorConfig *ExecutorConfig,
 ) (Executor, *plugin.Client, error) {
 
+	if driverConfig != nil && driverConfig.RecordExecutorStats && executorConfig.LogFile != "" {
+		recording := *executorConfig
+		recording.StatsRecordFile = statsRecordPath(executorConfig.LogFile)
+		executorConfig = &recording
+	}
+	if driverConfig != nil && driverConfig.ExecutorStatsMode != "" {
+		sampling := *executorConfig
+		sampling.StatsMode = driverConfig.ExecutorStatsMode
+		executorConfig = &sampling
+	}
+
 	c, err := json.Marshal(executorConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create executor config: %v", err)
 	}
-	bin, err := os.Executable()
+	cmd, err := executorCommand("executor", string(c))
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to find the nomad binary: %v", err)
 	}
 
-	p := &ExecutorPlugin{
-		logger:      logger,
-		fsIsolation: executorConfig.FSIsolation,
-		compute:     driverConfig.Topology.Compute(),
-	}
-
 	config := &plugin.ClientConfig{
 		HandshakeConfig:  base.Handshake,
-		Plugins:          map[string]plugin.Plugin{"executor": p},
-		Cmd:              exec.Command(bin, "executor", string(c)),
+		VersionedPlugins: versionedPluginMap(logger, executorConfig.FSIsolation, driverConfig.Topology.Compute()),
+		Cmd:              cmd,
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 		Logger:           logger.Named("executor"),
 	}
@@ -80,11 +86,17 @@ func CreateExecutor(
 // is listening on that port, and a process is running with the previous executors PID, leading the Nomad
 // TaskRunner to kill the PID after it errors calling the Wait RPC. So, fail early via the Version RPC if
 // we detect the listener isn't actually an Executor.
+//
+// The executor may run a previous Nomad binary, such as after an in-place
+// upgrade of the agent, so the client speaks the protocol version persisted
+// in the reattach config and negotiates its optional features through the
+// Capabilities RPC rather than assuming the ones of the current version.
 func ReattachToExecutor(reattachConfig *plugin.ReattachConfig, logger hclog.Logger, compute cpustats.Compute) (Executor, *plugin.Client, error) {
+	protocolVersion := reattachProtocolVersion(reattachConfig)
 	config := &plugin.ClientConfig{
 		HandshakeConfig:  base.Handshake,
 		Reattach:         reattachConfig,
-		Plugins:          GetPluginMap(logger, false, compute),
+		Plugins:          pluginMap(logger, false, compute, protocolVersion),
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 		Logger:           logger.Named("executor"),
 	}
@@ -95,6 +107,15 @@ func ReattachToExecutor(reattachConfig *plugin.ReattachConfig, logger hclog.Logg
 	if _, err := exec.Version(); err != nil {
 		return nil, nil, err
 	}
+
+	caps, err := exec.Capabilities()
+	if err != nil {
+		return nil, nil, err
+	}
+	if caps.NomadVersion != version.GetVersion().VersionNumber() {
+		logger.Info("reattached to executor of a different Nomad version",
+			"executor_version", caps.NomadVersion, "protocol_version", caps.ProtocolVersion)
+	}
 	return exec, pluginClient, nil
 }
 
@@ -122,11 +143,12 @@ func processStateToProto(ps *ProcessState) (*proto.ProcessState, error) {
 		return nil, err
 	}
 	pb := &proto.ProcessState{
-		Pid:       int32(ps.Pid),
-		ExitCode:  int32(ps.ExitCode),
-		Signal:    int32(ps.Signal),
-		OomKilled: ps.OOMKilled,
-		Time:      timestamp,
+		Pid:        int32(ps.Pid),
+		ExitCode:   int32(ps.ExitCode),
+		Signal:     int32(ps.Signal),
+		OomKilled:  ps.OOMKilled,
+		ExitReason: ps.ExitReason,
+		Time:       timestamp,
 	}
 
 	return pb, nil
@@ -139,14 +161,103 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}
 
 	return &ProcessState{
-		Pid:       int(pb.Pid),
-		ExitCode:  int(pb.ExitCode),
-		Signal:    int(pb.Signal),
-		OOMKilled: pb.OomKilled,
-		Time:      timestamp,
+		Pid:        int(pb.Pid),
+		ExitCode:   int(pb.ExitCode),
+		Signal:     int(pb.Signal),
+		OOMKilled:  pb.OomKilled,
+		ExitReason: pb.ExitReason,
+		Time:       timestamp,
 	}, nil
 }
 
+func provenanceToProto(p *Provenance) *proto.Provenance {
+	if p == nil {
+		return nil
+	}
+	pb := &proto.Provenance{Binary: fileDigestToProto(p.Binary)}
+	for _, lib := range p.Libraries {
+		pb.Libraries = append(pb.Libraries, fileDigestToProto(lib))
+	}
+	return pb
+}
+
+func fileDigestToProto(d *FileDigest) *proto.FileDigest {
+	return &proto.FileDigest{Path: d.Path, Sha256: d.SHA256}
+}
+
+func provenanceFromProto(pb *proto.Provenance) *Provenance {
+	if pb == nil || pb.Binary == nil {
+		return nil
+	}
+	p := &Provenance{Binary: fileDigestFromProto(pb.Binary)}
+	for _, lib := range pb.Libraries {
+		p.Libraries = append(p.Libraries, fileDigestFromProto(lib))
+	}
+	return p
+}
+
+func fileDigestFromProto(pb *proto.FileDigest) *FileDigest {
+	return &FileDigest{Path: pb.Path, SHA256: pb.Sha256}
+}
+
+func windowsLogonToProto(l *WindowsLogon) *proto.WindowsLogon {
+	if l == nil {
+		return nil
+	}
+	return &proto.WindowsLogon{PasswordFile: l.PasswordFile, LogonType: l.LogonType}
+}
+
+func windowsLogonFromProto(pb *proto.WindowsLogon) *WindowsLogon {
+	if pb == nil {
+		return nil
+	}
+	return &WindowsLogon{PasswordFile: pb.PasswordFile, LogonType: pb.LogonType}
+}
+
+func egressClassesToProto(classes []*egressstats.Class) []*proto.EgressClass {
+	if len(classes) == 0 {
+		return nil
+	}
+	pb := make([]*proto.EgressClass, len(classes))
+	for i, c := range classes {
+		ports := make([]int32, len(c.Ports))
+		for j, port := range c.Ports {
+			ports[j] = int32(port)
+		}
+		pb[i] = &proto.EgressClass{Name: c.Name, Cidrs: c.CIDRs, Ports: ports}
+	}
+	return pb
+}
+
+func egressClassesFromProto(pb []*proto.EgressClass) []*egressstats.Class {
+	if len(pb) == 0 {
+		return nil
+	}
+	classes := make([]*egressstats.Class, len(pb))
+	for i, c := range pb {
+		ports := make([]int, len(c.Ports))
+		for j, port := range c.Ports {
+			ports[j] = int(port)
+		}
+		classes[i] = &egressstats.Class{Name: c.Name, CIDRs: c.Cidrs, Ports: ports}
+	}
+	return classes
+}
+
+func resctrlClassToProto(c *resctrl.Class) *proto.ResctrlClass {
+	if c == nil {
+		return nil
+	}
+	return &proto.ResctrlClass{Name: c.Name, Schemata: c.Schemata}
+}
+
+func resctrlClassFromProto(pb *proto.ResctrlClass) *resctrl.Class {
+	if pb == nil {
+		return nil
+	}
+	return &resctrl.Class{Name: pb.Name, Schemata: pb.Schemata}
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
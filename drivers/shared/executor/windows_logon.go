@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// WindowsLogonBatch logs on as a batch user, which is the default since
+	// it doesn't require the user to be allowed to log on interactively
+	WindowsLogonBatch = "batch"
+
+	// WindowsLogonService logs on as a service user
+	WindowsLogonService = "service"
+
+	// WindowsLogonInteractive logs on as an interactive user
+	WindowsLogonInteractive = "interactive"
+
+	// WindowsLogonNetwork logs on as a network user, whose credentials
+	// aren't cached so the task can't reach other machines as the user
+	WindowsLogonNetwork = "network"
+
+	// WindowsLogonNetworkCleartext logs on as a network user whose
+	// credentials are kept, so the task can reach other machines as the user
+	WindowsLogonNetworkCleartext = "network_cleartext"
+)
+
+// WindowsLogon configures how the executor logs on as the user of a task on
+// Windows, so the task runs in the security context of that user rather than
+// of the Nomad agent.
+type WindowsLogon struct {
+	// PasswordFile is the file the password of the user is read from when the
+	// task is launched, such as a secret a template rendered from Vault with
+	// the task's workload identity
+	PasswordFile string
+
+	// LogonType is one of the WindowsLogon constants, and defaults to
+	// WindowsLogonBatch
+	LogonType string
+}
+
+// Validate returns an error if the logon is invalid.
+func (l *WindowsLogon) Validate() error {
+	if l.PasswordFile == "" {
+		return fmt.Errorf("password_file must be set")
+	}
+	switch l.LogonType {
+	case "", WindowsLogonBatch, WindowsLogonService, WindowsLogonInteractive,
+		WindowsLogonNetwork, WindowsLogonNetworkCleartext:
+		return nil
+	}
+	return fmt.Errorf("logon_type must be one of %q, %q, %q, %q or %q: %q",
+		WindowsLogonBatch, WindowsLogonService, WindowsLogonInteractive,
+		WindowsLogonNetwork, WindowsLogonNetworkCleartext, l.LogonType)
+}
+
+// splitWindowsUser splits a user formatted as DOMAIN\user into its domain and
+// user name. Users formatted as user@domain are passed as they are, with an
+// empty domain.
+func splitWindowsUser(user string) (string, string) {
+	if domain, name, ok := strings.Cut(user, `\`); ok {
+		return domain, name
+	}
+	return "", user
+}
+
+// readWindowsPassword reads the password of a user from path, without the
+// new line that usually ends the file.
+func readWindowsPassword(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %v", err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package executor
+
+import (
+	"errors"
+	"os/exec"
+)
+
+func setCmdLogon(*exec.Cmd, string, *WindowsLogon) (func(), error) {
+	return nil, errors.New("windows logon is only supported on windows")
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestWindowsLogon_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&WindowsLogon{PasswordFile: "password"}).Validate())
+	must.NoError(t, (&WindowsLogon{PasswordFile: "password", LogonType: WindowsLogonNetworkCleartext}).Validate())
+	must.ErrorContains(t, (&WindowsLogon{}).Validate(), "password_file must be set")
+	must.ErrorContains(t, (&WindowsLogon{PasswordFile: "password", LogonType: "remote"}).Validate(), "logon_type must be one of")
+}
+
+func TestWindowsLogon_splitUser(t *testing.T) {
+	ci.Parallel(t)
+
+	domain, name := splitWindowsUser(`CORP\svc-web`)
+	must.Eq(t, "CORP", domain)
+	must.Eq(t, "svc-web", name)
+
+	// UPNs are passed as they are, since LogonUser takes them without a domain
+	domain, name = splitWindowsUser("svc-web@corp.example.com")
+	must.Eq(t, "", domain)
+	must.Eq(t, "svc-web@corp.example.com", name)
+}
+
+func TestWindowsLogon_readPassword(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "password")
+	must.NoError(t, os.WriteFile(path, []byte("hunter2\r\n"), 0o600))
+
+	password, err := readWindowsPassword(path)
+	must.NoError(t, err)
+	must.Eq(t, "hunter2", password)
+
+	_, err = readWindowsPassword(filepath.Join(t.TempDir(), "missing"))
+	must.ErrorContains(t, err, "failed to read password file")
+}
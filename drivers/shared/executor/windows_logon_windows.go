@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package executor
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// logon types and provider of LogonUserW, which x/sys/windows does not define
+const (
+	logon32LogonInteractive      = 2
+	logon32LogonNetwork          = 3
+	logon32LogonBatch            = 4
+	logon32LogonService          = 5
+	logon32LogonNetworkCleartext = 8
+	logon32ProviderDefault       = 0
+)
+
+var procLogonUserW = windows.NewLazySystemDLL("advapi32.dll").NewProc("LogonUserW")
+
+// setCmdLogon logs on as user with the password of the logon, and makes cmd
+// run with the user's token. The returned function closes the token once cmd
+// started.
+func setCmdLogon(cmd *exec.Cmd, user string, logon *WindowsLogon) (func(), error) {
+	if user == "" {
+		return nil, fmt.Errorf("windows logon requires the task user to be set")
+	}
+	password, err := readWindowsPassword(logon.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var logonType uint32
+	switch logon.LogonType {
+	case "", WindowsLogonBatch:
+		logonType = logon32LogonBatch
+	case WindowsLogonService:
+		logonType = logon32LogonService
+	case WindowsLogonInteractive:
+		logonType = logon32LogonInteractive
+	case WindowsLogonNetwork:
+		logonType = logon32LogonNetwork
+	case WindowsLogonNetworkCleartext:
+		logonType = logon32LogonNetworkCleartext
+	default:
+		return nil, fmt.Errorf("invalid logon type %q", logon.LogonType)
+	}
+
+	domain, name := splitWindowsUser(user)
+	token, err := logonUser(name, domain, password, logonType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log on as %q: %w", user, err)
+	}
+
+	// Network logons return impersonation tokens, but processes need a
+	// primary token
+	var primary windows.Token
+	err = windows.DuplicateTokenEx(token, windows.MAXIMUM_ALLOWED, nil,
+		windows.SecurityImpersonation, windows.TokenPrimary, &primary)
+	token.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to duplicate the token of %q: %w", user, err)
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Token = syscall.Token(primary)
+	return func() { primary.Close() }, nil
+}
+
+func logonUser(name, domain, password string, logonType uint32) (windows.Token, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	var domainPtr *uint16
+	if domain != "" {
+		if domainPtr, err = windows.UTF16PtrFromString(domain); err != nil {
+			return 0, err
+		}
+	}
+	passwordPtr, err := windows.UTF16PtrFromString(password)
+	if err != nil {
+		return 0, err
+	}
+
+	var token windows.Token
+	r, _, err := procLogonUserW.Call(
+		uintptr(unsafe.Pointer(namePtr)),
+		uintptr(unsafe.Pointer(domainPtr)),
+		uintptr(unsafe.Pointer(passwordPtr)),
+		uintptr(logonType),
+		logon32ProviderDefault,
+		uintptr(unsafe.Pointer(&token)))
+	if r == 0 {
+		return 0, err
+	}
+	return token, nil
+}
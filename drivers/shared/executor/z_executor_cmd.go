@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/plugins/base"
 )
 
@@ -43,15 +44,23 @@ func init() {
 			Output:     f,
 		})
 
+		plugins := versionedPluginMap(
+			logger,
+			executorConfig.FSIsolation,
+			executorConfig.Compute,
+		)
+		if executorConfig.StatsRecordFile != "" {
+			plugins = withStatsRecording(plugins, executorConfig.StatsRecordFile)
+		}
+		if executorConfig.StatsMode != "" {
+			plugins = withStatsMode(plugins, procstats.Mode(executorConfig.StatsMode))
+		}
+
 		plugin.Serve(&plugin.ServeConfig{
-			HandshakeConfig: base.Handshake,
-			Plugins: GetPluginMap(
-				logger,
-				executorConfig.FSIsolation,
-				executorConfig.Compute,
-			),
-			GRPCServer: plugin.DefaultGRPCServer,
-			Logger:     logger,
+			HandshakeConfig:  base.Handshake,
+			VersionedPlugins: plugins,
+			GRPCServer:       plugin.DefaultGRPCServer,
+			Logger:           logger,
 		})
 
 		os.Exit(0)
@@ -81,3 +81,27 @@ func copySystemDNS(filePath string) error {
 
 	return os.WriteFile(filePath, content, 0644)
 }
+
+// TaskDNS returns the DNS configuration of a task, where the servers, search
+// domains, and options set in its driver config override those of the
+// network of its group. It returns nil if neither configures DNS.
+func TaskDNS(group *drivers.DNSConfig, servers, searches, options []string) *drivers.DNSConfig {
+	if group == nil && len(servers) == 0 && len(searches) == 0 && len(options) == 0 {
+		return nil
+	}
+
+	dns := &drivers.DNSConfig{}
+	if group != nil {
+		*dns = *group
+	}
+	if len(servers) > 0 {
+		dns.Servers = servers
+	}
+	if len(searches) > 0 {
+		dns.Searches = searches
+	}
+	if len(options) > 0 {
+		dns.Options = options
+	}
+	return dns
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resolvconf
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestTaskDNS(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, TaskDNS(nil, nil, nil, nil))
+
+	group := &drivers.DNSConfig{
+		Servers:  []string{"10.0.0.2"},
+		Searches: []string{"service.consul"},
+	}
+	must.Eq(t, group, TaskDNS(group, nil, nil, nil))
+
+	// the task's settings override the group's, field by field
+	must.Eq(t, &drivers.DNSConfig{
+		Servers:  []string{"1.1.1.1"},
+		Searches: []string{"service.consul"},
+		Options:  []string{"ndots:2"},
+	}, TaskDNS(group, []string{"1.1.1.1"}, nil, []string{"ndots:2"}))
+	must.Eq(t, []string{"10.0.0.2"}, group.Servers)
+
+	must.Eq(t, &drivers.DNSConfig{
+		Searches: []string{"example.com"},
+	}, TaskDNS(nil, nil, []string{"example.com"}, nil))
+}
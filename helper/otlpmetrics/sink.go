@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package otlpmetrics implements a go-metrics sink exporting metrics to an
+// OpenTelemetry collector over OTLP/HTTP.
+package otlpmetrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	// DefaultExportInterval is how often metrics are pushed to the collector
+	// when no interval is configured.
+	DefaultExportInterval = 10 * time.Second
+
+	// shutdownTimeout bounds how long Shutdown waits on the final export.
+	shutdownTimeout = 5 * time.Second
+
+	instrumentationName = "github.com/hashicorp/nomad"
+)
+
+// attributeNames maps the labels Nomad attaches to metrics to the attribute
+// names used in OpenTelemetry, so node, allocation and task metrics can be
+// correlated with other telemetry. Unlisted labels keep their name.
+var attributeNames = map[string]string{
+	"alloc_id":   "nomad.alloc.id",
+	"datacenter": "nomad.datacenter",
+	"host":       "host.name",
+	"job":        "nomad.job.id",
+	"namespace":  "nomad.namespace",
+	"node_class": "nomad.node.class",
+	"node_id":    "nomad.node.id",
+	"node_pool":  "nomad.node.pool",
+	"task":       "nomad.task.name",
+	"task_group": "nomad.task_group.name",
+}
+
+// Config is used to configure the OTLP sink
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP metrics endpoint of the collector,
+	// such as http://localhost:4318. The /v1/metrics path is used when the
+	// URL has no path.
+	Endpoint string
+
+	// Headers are sent with every export request, such as for authentication
+	Headers map[string]string
+
+	// ExportInterval is how often metrics are pushed to the collector
+	ExportInterval time.Duration
+
+	// ResourceAttributes describe the agent emitting the metrics
+	ResourceAttributes map[string]string
+}
+
+// Sink is a go-metrics sink exporting metrics over OTLP. Gauges, counters
+// and samples are recorded as OpenTelemetry gauges, sums and histograms.
+type Sink struct {
+	provider *sdkmetric.MeterProvider
+	meter    otelmetric.Meter
+
+	gauges     map[string]otelmetric.Float64Gauge
+	counters   map[string]otelmetric.Float64Counter
+	histograms map[string]otelmetric.Float64Histogram
+	lock       sync.Mutex
+}
+
+// NewSink returns a sink exporting metrics to the configured collector.
+func NewSink(config *Config) (*Sink, error) {
+	opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpointURL(config.Endpoint)}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(config.Headers))
+	}
+
+	// the exporter only dials the collector when metrics are exported
+	exporter, err := otlpmetrichttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := config.ExportInterval
+	if interval <= 0 {
+		interval = DefaultExportInterval
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(config.ResourceAttributes))
+	for k, v := range config.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))
+	return newSink(reader, resource.NewSchemaless(attrs...)), nil
+}
+
+func newSink(reader sdkmetric.Reader, res *resource.Resource) *Sink {
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(reader),
+	)
+
+	return &Sink{
+		provider:   provider,
+		meter:      provider.Meter(instrumentationName),
+		gauges:     make(map[string]otelmetric.Float64Gauge),
+		counters:   make(map[string]otelmetric.Float64Counter),
+		histograms: make(map[string]otelmetric.Float64Histogram),
+	}
+}
+
+// SetGauge is used to set a gauge value
+func (s *Sink) SetGauge(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+// SetGaugeWithLabels is used to set a gauge value with labels
+func (s *Sink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	name := metricName(key)
+
+	s.lock.Lock()
+	gauge, ok := s.gauges[name]
+	if !ok {
+		var err error
+		if gauge, err = s.meter.Float64Gauge(name); err != nil {
+			s.lock.Unlock()
+			return
+		}
+		s.gauges[name] = gauge
+	}
+	s.lock.Unlock()
+
+	gauge.Record(context.Background(), float64(val), otelmetric.WithAttributes(attributes(labels)...))
+}
+
+// EmitKey is not supported by OpenTelemetry and is a no-op
+func (s *Sink) EmitKey(key []string, val float32) {}
+
+// IncrCounter is used to increment a counter
+func (s *Sink) IncrCounter(key []string, val float32) {
+	s.IncrCounterWithLabels(key, val, nil)
+}
+
+// IncrCounterWithLabels is used to increment a counter with labels
+func (s *Sink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	name := metricName(key)
+
+	s.lock.Lock()
+	counter, ok := s.counters[name]
+	if !ok {
+		var err error
+		if counter, err = s.meter.Float64Counter(name); err != nil {
+			s.lock.Unlock()
+			return
+		}
+		s.counters[name] = counter
+	}
+	s.lock.Unlock()
+
+	counter.Add(context.Background(), float64(val), otelmetric.WithAttributes(attributes(labels)...))
+}
+
+// AddSample is used to add a sample to a histogram
+func (s *Sink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+// AddSampleWithLabels is used to add a sample to a histogram with labels
+func (s *Sink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	name := metricName(key)
+
+	s.lock.Lock()
+	histogram, ok := s.histograms[name]
+	if !ok {
+		var err error
+		if histogram, err = s.meter.Float64Histogram(name); err != nil {
+			s.lock.Unlock()
+			return
+		}
+		s.histograms[name] = histogram
+	}
+	s.lock.Unlock()
+
+	histogram.Record(context.Background(), float64(val), otelmetric.WithAttributes(attributes(labels)...))
+}
+
+// Shutdown exports the remaining metrics and stops the exporter
+func (s *Sink) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	_ = s.provider.Shutdown(ctx)
+}
+
+func metricName(key []string) string {
+	return strings.Join(key, ".")
+}
+
+func attributes(labels []metrics.Label) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		name := label.Name
+		if mapped, ok := attributeNames[name]; ok {
+			name = mapped
+		}
+		attrs = append(attrs, attribute.String(name, label.Value))
+	}
+	return attrs
+}
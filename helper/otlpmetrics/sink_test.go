@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package otlpmetrics
+
+import (
+	"context"
+	"testing"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestSink(t *testing.T) {
+	ci.Parallel(t)
+
+	reader := sdkmetric.NewManualReader()
+	res := resource.NewSchemaless(attribute.String("service.name", "nomad"))
+	sink := newSink(reader, res)
+
+	labels := []metrics.Label{
+		{Name: "alloc_id", Value: "8f8cbc2e"},
+		{Name: "task", Value: "redis"},
+		{Name: "node_status", Value: "ready"},
+	}
+	sink.SetGaugeWithLabels([]string{"nomad", "client", "allocs", "memory", "rss"}, 1024, labels)
+	sink.SetGaugeWithLabels([]string{"nomad", "client", "allocs", "memory", "rss"}, 2048, labels)
+	sink.IncrCounter([]string{"nomad", "nomad", "rpc", "request"}, 1)
+	sink.IncrCounter([]string{"nomad", "nomad", "rpc", "request"}, 2)
+	sink.AddSample([]string{"nomad", "nomad", "plan", "evaluate"}, 12)
+
+	var rm metricdata.ResourceMetrics
+	must.NoError(t, reader.Collect(context.Background(), &rm))
+	must.Eq(t, res, rm.Resource)
+	must.Len(t, 1, rm.ScopeMetrics)
+
+	byName := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		byName[m.Name] = m
+	}
+
+	gauge, ok := byName["nomad.client.allocs.memory.rss"].Data.(metricdata.Gauge[float64])
+	must.True(t, ok)
+	must.Len(t, 1, gauge.DataPoints)
+	must.Eq(t, 2048, gauge.DataPoints[0].Value)
+	must.Eq(t, attribute.NewSet(
+		attribute.String("nomad.alloc.id", "8f8cbc2e"),
+		attribute.String("nomad.task.name", "redis"),
+		attribute.String("node_status", "ready"),
+	), gauge.DataPoints[0].Attributes)
+
+	sum, ok := byName["nomad.nomad.rpc.request"].Data.(metricdata.Sum[float64])
+	must.True(t, ok)
+	must.True(t, sum.IsMonotonic)
+	must.Eq(t, 3, sum.DataPoints[0].Value)
+
+	histogram, ok := byName["nomad.nomad.plan.evaluate"].Data.(metricdata.Histogram[float64])
+	must.True(t, ok)
+	must.Eq(t, 1, histogram.DataPoints[0].Count)
+	must.Eq(t, 12, histogram.DataPoints[0].Sum)
+}
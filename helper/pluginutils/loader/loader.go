@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	"github.com/hashicorp/nomad/plugins/statssink"
 )
 
 // PluginCatalog is used to retrieve plugins, either external or internal
@@ -275,6 +276,8 @@ func getPluginMap(pluginType string, logger log.Logger) map[string]plugin.Plugin
 		pmap[base.PluginTypeDevice] = &device.PluginDevice{}
 	case base.PluginTypeDriver:
 		pmap[base.PluginTypeDriver] = drivers.NewDriverPlugin(nil, logger)
+	case base.PluginTypeStatsSink:
+		pmap[base.PluginTypeStatsSink] = &statssink.PluginStatsSink{}
 	}
 
 	return pmap
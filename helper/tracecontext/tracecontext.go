@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tracecontext generates and propagates W3C Trace Context
+// "traceparent" values. Nomad does not record spans itself; it carries the
+// trace context from job dispatch through evaluation, allocation placement,
+// and task start so that workloads and external tracing systems can connect
+// scheduling latency with task startup.
+package tracecontext
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/crypto"
+)
+
+const (
+	// HeaderName is the HTTP header carrying the trace context.
+	HeaderName = "traceparent"
+
+	// EnvName is the environment variable containing the trace context
+	// injected into tasks, following the OpenTelemetry convention for
+	// environment variable carriers.
+	EnvName = "TRACEPARENT"
+
+	// version is the only traceparent version we generate.
+	version = "00"
+
+	// flagSampled is set so downstream tracers record the trace.
+	flagSampled = "01"
+)
+
+// TraceParent is a parsed W3C traceparent value.
+type TraceParent struct {
+	TraceID string
+	SpanID  string
+	Flags   string
+}
+
+// String returns the traceparent in its header encoding.
+func (t TraceParent) String() string {
+	return fmt.Sprintf("%s-%s-%s-%s", version, t.TraceID, t.SpanID, t.Flags)
+}
+
+// Parse parses a traceparent header value. Only the fields defined by
+// version 00 are read; future versions may append fields, which are ignored.
+func Parse(s string) (TraceParent, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 {
+		return TraceParent{}, fmt.Errorf("invalid traceparent %q", s)
+	}
+	ver, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	switch {
+	case !isHex(ver, 2) || ver == "ff":
+		return TraceParent{}, fmt.Errorf("invalid traceparent version %q", ver)
+	case ver == version && len(parts) != 4:
+		return TraceParent{}, fmt.Errorf("invalid traceparent %q", s)
+	case !isHex(traceID, 32) || isZero(traceID):
+		return TraceParent{}, fmt.Errorf("invalid trace ID %q", traceID)
+	case !isHex(spanID, 16) || isZero(spanID):
+		return TraceParent{}, fmt.Errorf("invalid span ID %q", spanID)
+	case !isHex(flags, 2):
+		return TraceParent{}, fmt.Errorf("invalid trace flags %q", flags)
+	}
+
+	return TraceParent{TraceID: traceID, SpanID: spanID, Flags: flags}, nil
+}
+
+// New returns a traceparent starting a new sampled trace.
+func New() string {
+	return TraceParent{
+		TraceID: randomHex(16),
+		SpanID:  randomHex(8),
+		Flags:   flagSampled,
+	}.String()
+}
+
+// Child returns a traceparent for a new span within the trace identified by
+// parent. If parent is empty or invalid a new trace is started instead, so
+// callers always receive a usable value.
+func Child(parent string) string {
+	tp, err := Parse(parent)
+	if err != nil {
+		return New()
+	}
+	tp.SpanID = randomHex(8)
+	return tp.String()
+}
+
+// ChildIfSet is like Child but returns an empty string when parent is empty,
+// so trace context is only propagated when the caller started a trace.
+func ChildIfSet(parent string) string {
+	if parent == "" {
+		return ""
+	}
+	return Child(parent)
+}
+
+func randomHex(n int) string {
+	for {
+		buf, err := crypto.Bytes(n)
+		if err != nil {
+			panic(fmt.Errorf("failed to read random bytes: %v", err))
+		}
+		if s := hex.EncodeToString(buf); !isZero(s) {
+			return s
+		}
+	}
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
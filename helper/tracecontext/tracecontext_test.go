@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tracecontext
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParse(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name  string
+		input string
+		ok    bool
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"future version extra fields", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-ab", true},
+		{"v00 extra fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-ab", false},
+		{"empty", "", false},
+		{"bad version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"zero trace", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"zero span", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"short span", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa-01", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			if tc.ok {
+				must.NoError(t, err)
+			} else {
+				must.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestChild(t *testing.T) {
+	ci.Parallel(t)
+
+	parent := New()
+	p, err := Parse(parent)
+	must.NoError(t, err)
+
+	child := Child(parent)
+	c, err := Parse(child)
+	must.NoError(t, err)
+	must.Eq(t, p.TraceID, c.TraceID)
+	must.NotEq(t, p.SpanID, c.SpanID)
+	must.Eq(t, p.Flags, c.Flags)
+
+	// invalid parents start a new trace
+	fresh, err := Parse(Child("garbage"))
+	must.NoError(t, err)
+	must.NotEq(t, p.TraceID, fresh.TraceID)
+
+	must.Eq(t, "", ChildIfSet(""))
+	must.NotEq(t, "", ChildIfSet(parent))
+}
@@ -443,6 +443,78 @@ func (a *Alloc) GetServiceRegistrations(
 	})
 }
 
+// BillingRecords lists the billing records the clients reported for the
+// allocations of a namespace or job when they terminated.
+func (a *Alloc) BillingRecords(args *structs.AllocBillingRecordsRequest, reply *structs.AllocBillingRecordsResponse) error {
+	authErr := a.srv.Authenticate(a.ctx, args)
+	if done, err := a.srv.forward(structs.AllocBillingRecordsRPCMethod, args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("alloc", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "billing_records"}, time.Now())
+
+	namespace := args.RequestNamespace()
+	if args.JobID != "" && namespace == structs.AllNamespacesSentinel {
+		return fmt.Errorf("listing the billing records of a job requires a namespace")
+	}
+	if args.Until != 0 && args.Until < args.Since {
+		return fmt.Errorf("until must not be before since")
+	}
+
+	// Check namespace read-job permissions
+	aclObj, err := a.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+	allow := aclObj.AllowNsOpFunc(acl.NamespaceCapabilityReadJob)
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			reply.Records = nil
+
+			allowableNamespaces, err := allowedNSes(aclObj, store, allow)
+			if err == structs.ErrPermissionDenied {
+				return a.srv.setReplyQueryMeta(store, state.TableBillingRecords, &reply.QueryMeta)
+			} else if err != nil {
+				return err
+			}
+
+			var iter memdb.ResultIterator
+			switch {
+			case args.JobID != "":
+				iter, err = store.BillingRecordsByJob(ws, namespace, args.JobID)
+			case namespace != structs.AllNamespacesSentinel:
+				iter, err = store.BillingRecordsByNamespace(ws, namespace)
+			default:
+				iter, err = store.BillingRecords(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				record := raw.(*structs.AllocBillingRecord)
+				if allowableNamespaces != nil && !allowableNamespaces[record.Namespace] {
+					continue
+				}
+				if record.EndTime < args.Since || (args.Until != 0 && record.EndTime > args.Until) {
+					continue
+				}
+				reply.Records = append(reply.Records, record)
+			}
+			return a.srv.setReplyQueryMeta(store, state.TableBillingRecords, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
 // SignIdentities allows nodes to retrieve workload identities for their
 // allocations.
 //
@@ -1374,6 +1374,100 @@ func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 
 }
 
+func TestAllocEndpoint_BillingRecords(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(900, []*structs.Namespace{ns1, ns2}))
+
+	// Terminate an allocation in each namespace an hour apart
+	now := time.Now()
+	alloc1 := mock.Alloc()
+	alloc1.Namespace = ns1.Name
+	alloc2 := mock.Alloc()
+	alloc2.Namespace = ns2.Name
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+	var updates []*structs.Allocation
+	for i, alloc := range []*structs.Allocation{alloc1, alloc2} {
+		end := now.Add(time.Duration(i-1) * time.Hour)
+		updates = append(updates, &structs.Allocation{
+			ID:           alloc.ID,
+			NodeID:       alloc.NodeID,
+			ClientStatus: structs.AllocClientStatusComplete,
+			ModifyTime:   end.UnixNano(),
+			BillingRecord: &structs.AllocBillingRecord{
+				StartTime:  end.Add(-time.Hour).UnixNano(),
+				EndTime:    end.UnixNano(),
+				CPUSeconds: float64(3600 * (i + 1)),
+			},
+		})
+	}
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1010, updates))
+
+	get := &structs.AllocBillingRecordsRequest{
+		JobID: alloc1.JobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: ns1.Name,
+		},
+	}
+
+	// Listing the billing records requires read-job
+	var resp structs.AllocBillingRecordsResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp))
+	must.Eq(t, 1010, resp.Index)
+	must.Len(t, 1, resp.Records)
+	must.Eq(t, alloc1.ID, resp.Records[0].AllocID)
+	must.Eq(t, 3600, resp.Records[0].CPUSeconds)
+
+	get.JobID = alloc2.JobID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp))
+	must.Len(t, 0, resp.Records)
+
+	// The records of a namespace the token can't read are left out of the
+	// wildcard listing
+	token := mock.CreatePolicyAndToken(t, state, 1020, "ns1-read",
+		mock.NamespacePolicy(ns1.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+	get.JobID = ""
+	get.Namespace = structs.AllNamespacesSentinel
+	get.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp))
+	must.Len(t, 1, resp.Records)
+	must.Eq(t, ns1.Name, resp.Records[0].Namespace)
+
+	get.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp))
+	must.Len(t, 2, resp.Records)
+
+	// The range bounds the end times, inclusive
+	get.Since = now.UnixNano()
+	get.Until = now.UnixNano()
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp))
+	must.Len(t, 1, resp.Records)
+	must.Eq(t, alloc2.ID, resp.Records[0].AllocID)
+
+	get.Until = now.Add(-time.Hour).UnixNano()
+	err = msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp)
+	must.ErrorContains(t, err, "until must not be before since")
+
+	get.Since, get.Until = 0, 0
+	get.JobID = alloc1.JobID
+	err = msgpackrpc.CallWithCodec(codec, structs.AllocBillingRecordsRPCMethod, get, &resp)
+	must.ErrorContains(t, err, "requires a namespace")
+}
+
 func TestAlloc_GetServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 
@@ -86,8 +86,13 @@ func (a *Agent) Profile(args *structs.AgentPprofRequest, reply *structs.AgentPpr
 		}
 	}
 
-	// This server is the target, so now we can check for AllowAgentDebug
-	if !aclObj.AllowAgentDebug(a.srv.config.EnableDebug) {
+	// This server is the target, so now we can check for AllowAgentDebug.
+	// Profiles of the server are audited like the profiles of clients.
+	allowed := aclObj.AllowAgentDebug(a.srv.config.EnableDebug)
+	a.srv.auditLogger.Info("access", "endpoint", "Agent.Profile",
+		"identity", args.GetIdentity().String(), "allowed", allowed,
+		"type", args.ReqType, "profile", args.Profile)
+	if !allowed {
 		return structs.ErrPermissionDenied
 	}
 
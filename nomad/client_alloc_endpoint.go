@@ -32,6 +32,20 @@ func NewClientAllocationsEndpoint(srv *Server) *ClientAllocations {
 	return &ClientAllocations{srv: srv, logger: srv.logger.Named("client_allocs")}
 }
 
+// auditDenied records in the audit log an access to an endpoint exposing what
+// the tasks of an allocation run that the server denied, with the identity
+// that requested it. Allowed accesses are audited by the client that serves
+// them, which denied accesses never reach.
+func (a *ClientAllocations) auditDenied(endpoint string, args structs.RequestWithIdentity, allocID string) {
+	ident := args.GetIdentity()
+	logArgs := []any{"endpoint", endpoint, "identity", ident.String(), "allowed", false}
+	if token := ident.GetACLToken(); token != nil && token != structs.AnonymousACLToken {
+		logArgs = append(logArgs, "access_token_name", token.Name)
+	}
+	logArgs = append(logArgs, "error", structs.ErrPermissionDenied, "alloc_id", allocID)
+	a.srv.auditLogger.Info("access", logArgs...)
+}
+
 func (a *ClientAllocations) register() {
 	a.srv.streamingRpcs.Register("Allocations.Exec", a.exec)
 }
@@ -393,11 +407,15 @@ func (a *ClientAllocations) Stats(args *cstructs.AllocStatsRequest, reply *cstru
 		return err
 	}
 
-	// Check for namespace read-job permissions.
+	// Check for namespace read-job or read-stats permissions.
 	if aclObj, err := a.srv.ResolveACL(args); err != nil {
 		return err
-	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
-		return structs.ErrPermissionDenied
+	} else {
+		hasReadJob := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob)
+		hasReadStats := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadStats)
+		if !(hasReadJob || hasReadStats) {
+			return structs.ErrPermissionDenied
+		}
 	}
 
 	// Make sure Node is valid and new enough to support RPC
@@ -416,6 +434,126 @@ func (a *ClientAllocations) Stats(args *cstructs.AllocStatsRequest, reply *cstru
 	return NodeRpc(state.Session, "Allocations.Stats", args, reply)
 }
 
+// Processes is the server implementation of the allocation processes RPC.
+// The ultimate response is provided by the node running the allocation.
+func (a *ClientAllocations) Processes(args *cstructs.AllocProcessesRequest, reply *cstructs.AllocProcessesResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	authErr := a.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := a.srv.forward("ClientAllocations.Processes", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("client_allocations", structs.RateMetricRead, args)
+	if authErr != nil {
+		a.auditDenied("ClientAllocations.Processes", args, args.AllocID)
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_allocations", "processes"}, time.Now())
+
+	// Find the allocation
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check for namespace read-processes permissions.
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadProcesses) {
+		a.auditDenied("ClientAllocations.Processes", args, args.AllocID)
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	_, err = getNodeForRpc(snap, alloc.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		return findNodeConnAndForward(a.srv, alloc.NodeID, "ClientAllocations.Processes", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "Allocations.Processes", args, reply)
+}
+
+// Capture is the server implementation of the allocation capture RPC. The
+// capture is taken by the node running the allocation.
+func (a *ClientAllocations) Capture(args *cstructs.AllocCaptureRequest, reply *cstructs.AllocCaptureResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	authErr := a.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := a.srv.forward("ClientAllocations.Capture", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("client_allocations", structs.RateMetricWrite, args)
+	if authErr != nil {
+		a.auditDenied("ClientAllocations.Capture", args, args.AllocID)
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_allocations", "capture"}, time.Now())
+
+	// Verify the arguments.
+	if args.AllocID == "" {
+		return errors.New("missing AllocID")
+	}
+	if args.Task == "" {
+		return errors.New("missing Task")
+	}
+
+	// Find the allocation
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace alloc-lifecycle permission.
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocLifecycle) {
+		a.auditDenied("ClientAllocations.Capture", args, args.AllocID)
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	_, err = getNodeForRpc(snap, alloc.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		return findNodeConnAndForward(a.srv, alloc.NodeID, "ClientAllocations.Capture", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "Allocations.Capture", args, reply)
+}
+
 // Checks is the server implementation of the allocation checks RPC. The
 // ultimate response is provided by the node running the allocation. This RPC
 // is needed to handle queries which hit the server agent API directly, or via
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/nomad/acl"
@@ -723,6 +724,58 @@ func TestClientAllocations_Stats_Local_ACL(t *testing.T) {
 	}
 }
 
+// auditLines is an hclog output sending each logged line on a channel.
+type auditLines chan string
+
+func (l auditLines) Write(p []byte) (int, error) {
+	l <- string(p)
+	return len(p), nil
+}
+
+func TestClientAllocations_Processes_AuditDenied(t *testing.T) {
+	ci.Parallel(t)
+
+	lines := make(auditLines, 10)
+	s, root, cleanupS := TestACLServer(t, func(c *Config) {
+		c.AuditLogger = hclog.New(&hclog.LoggerOptions{Output: lines, Level: hclog.Trace})
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	policyBad := mock.NamespacePolicy(nstructs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	state := s.State()
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(nstructs.MsgTypeTestSetup, 1010, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1011, []*nstructs.Allocation{alloc}))
+
+	req := &cstructs.AllocProcessesRequest{
+		AllocID: alloc.ID,
+		QueryOptions: nstructs.QueryOptions{
+			AuthToken: tokenBad.SecretID,
+			Region:    "global",
+			Namespace: nstructs.DefaultNamespace,
+		},
+	}
+	var resp cstructs.AllocProcessesResponse
+	err := msgpackrpc.CallWithCodec(codec, "ClientAllocations.Processes", req, &resp)
+	must.EqError(t, err, nstructs.ErrPermissionDenied.Error())
+
+	line := <-lines
+	must.StrContains(t, line, "nomad.audit: access: endpoint=ClientAllocations.Processes")
+	must.StrContains(t, line, "identity=token:"+tokenBad.AccessorID)
+	must.StrContains(t, line, "allowed=false")
+	must.StrContains(t, line, "alloc_id="+alloc.ID)
+
+	// Allowed accesses are audited by the client
+	req.AuthToken = root.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "ClientAllocations.Processes", req, &resp)
+	must.ErrorContains(t, err, nstructs.ErrUnknownNodePrefix)
+	must.Eq(t, 0, len(lines))
+}
+
 func TestClientAllocations_Stats_Remote(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
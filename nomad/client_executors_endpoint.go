@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Executors is used to forward RPC requests to the Executors endpoint of the
+// targeted Nomad client.
+type Executors struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func newExecutorsEndpoint(srv *Server) *Executors {
+	return &Executors{srv: srv, logger: srv.logger.Named("executors")}
+}
+
+func (e *Executors) List(args *structs.NodeSpecificRequest, reply *cstructs.ExecutorsResponse) error {
+	const method = "Executors.List"
+
+	// Prevent infinite loop between leader and
+	// follower-with-the-target-node-connection.
+	args.QueryOptions.AllowStale = true
+
+	authErr := e.srv.Authenticate(nil, args)
+	if done, err := e.srv.forward(method, args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("executors", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "executors", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	return e.srv.forwardClientRPC(method, args.NodeID, args, reply)
+}
+
+func (e *Executors) GarbageCollect(args *structs.NodeSpecificRequest, reply *cstructs.ExecutorsResponse) error {
+	const method = "Executors.GarbageCollect"
+
+	// Prevent infinite loop between leader and
+	// follower-with-the-target-node-connection.
+	args.QueryOptions.AllowStale = true
+
+	authErr := e.srv.Authenticate(nil, args)
+	if done, err := e.srv.forward(method, args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("executors", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "executors", "garbage_collect"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	return e.srv.forwardClientRPC(method, args.NodeID, args, reply)
+}
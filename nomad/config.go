@@ -80,6 +80,12 @@ type Config struct {
 	// Logger is the logger used by the server.
 	Logger log.InterceptLogger
 
+	// AuditLogger records the accesses to the endpoints exposing what the
+	// tasks run that the server denies. It must not be filtered by the log
+	// level, since these accesses may be regulated. Logger is used if it is
+	// not set.
+	AuditLogger log.Logger
+
 	// RPCAddr is the RPC address used by Nomad. This should be reachable
 	// by the other servers and clients
 	RPCAddr *net.TCPAddr
@@ -232,6 +238,23 @@ type Config struct {
 	// before it's rotated
 	RootKeyRotationThreshold time.Duration
 
+	// UsageHistoryRetention is how long the usage samples of allocations
+	// are kept. Zero disables recording usage history.
+	UsageHistoryRetention time.Duration
+
+	// UsageHistoryInterval is the minimum time between the recorded usage
+	// samples of an allocation, which bounds the number of samples kept per
+	// allocation to UsageHistoryRetention / UsageHistoryInterval.
+	UsageHistoryInterval time.Duration
+
+	// UsageHistoryGCInterval is how often we dispatch a job to GC usage
+	// samples older than UsageHistoryRetention
+	UsageHistoryGCInterval time.Duration
+
+	// BillingRecordRetention is how long the billing records of allocations
+	// are kept after they terminated
+	BillingRecordRetention time.Duration
+
 	// VariablesRekeyInterval is how often we dispatch a job to
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
@@ -289,6 +312,20 @@ type Config struct {
 	// rejections for nodes.
 	NodePlanRejectionWindow time.Duration
 
+	// UsageAdmissionMode is whether job submissions requesting resources that
+	// deviate from the usage observed for the job are warned about or denied.
+	// Requests aren't compared to the usage if it is empty.
+	UsageAdmissionMode string
+
+	// UsageAdmissionMaxRatio is the ratio between the requested resources and
+	// the observed usage of a task over which the request deviates, in either
+	// direction.
+	UsageAdmissionMaxRatio float64
+
+	// UsageAdmissionMinAllocations is the number of running allocations of a
+	// task group that must report usage before requests are compared to it.
+	UsageAdmissionMinAllocations int
+
 	// MinHeartbeatTTL is the minimum time between heartbeats.
 	// This is used as a floor to prevent excessive updates.
 	MinHeartbeatTTL time.Duration
@@ -594,6 +631,10 @@ func DefaultConfig() *Config {
 		ACLTokenExpirationGCThreshold:    1 * time.Hour,
 		RootKeyGCInterval:                10 * time.Minute,
 		RootKeyGCThreshold:               1 * time.Hour,
+		UsageHistoryRetention:            7 * 24 * time.Hour,
+		BillingRecordRetention:           structs.DefaultBillingRecordRetention,
+		UsageHistoryInterval:             10 * time.Minute,
+		UsageHistoryGCInterval:           1 * time.Hour,
 		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
 		VariablesRekeyInterval:           10 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
@@ -610,6 +651,8 @@ func DefaultConfig() *Config {
 		NodePlanRejectionEnabled:         false,
 		NodePlanRejectionThreshold:       15,
 		NodePlanRejectionWindow:          10 * time.Minute,
+		UsageAdmissionMaxRatio:           4,
+		UsageAdmissionMinAllocations:     3,
 		ConsulConfigs: map[string]*config.ConsulConfig{
 			structs.ConsulDefaultCluster: config.DefaultConsulConfig()},
 		VaultConfigs: map[string]*config.VaultConfig{
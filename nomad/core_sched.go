@@ -77,6 +77,8 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.rootKeyRotateOrGC(eval)
 	case structs.CoreJobVariablesRekey:
 		return c.variablesRekey(eval)
+	case structs.CoreJobUsageSampleGC:
+		return c.usageSampleGC(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -116,6 +118,9 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.rootKeyGC(eval, time.Now()); err != nil {
 		return err
 	}
+	if err := c.usageSampleGC(eval); err != nil {
+		return err
+	}
 
 	// Node GC must occur after the others to ensure the allocations are
 	// cleared.
@@ -867,6 +872,31 @@ func (c *CoreScheduler) expiredOneTimeTokenGC(eval *structs.Evaluation) error {
 	return c.srv.RPC("ACL.ExpireOneTimeTokens", req, &structs.GenericResponse{})
 }
 
+// usageSampleGC is used to garbage collect the usage samples of allocations
+// older than the usage history retention. A retention of zero disables the
+// usage history, so all samples are collected.
+func (c *CoreScheduler) usageSampleGC(eval *structs.Evaluation) error {
+	cutoff := time.Now().Add(-c.srv.config.UsageHistoryRetention).UnixNano()
+
+	oldest, err := c.snap.OldestUsageSample(nil)
+	if err != nil {
+		return err
+	}
+	if oldest == nil || oldest.Timestamp >= cutoff {
+		return nil
+	}
+
+	c.logger.Debug("usage sample GC found expired samples", "before", cutoff)
+	req := &structs.UsageSamplesUpsertRequest{
+		PruneBefore: cutoff,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	return c.srv.RPC(structs.NodeReapUsageSamplesRPCMethod, req, &structs.GenericResponse{})
+}
+
 // expiredACLTokenGC handles running the garbage collector for expired ACL
 // tokens. It can be used for both local and global tokens and includes
 // behaviour to account for periodic and user actioned garbage collection
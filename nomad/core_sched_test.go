@@ -2841,6 +2841,53 @@ func TestCoreScheduler_VariablesRekey(t *testing.T) {
 	), must.Sprint("variable rekey should be complete"))
 }
 
+func TestCoreScheduler_UsageSampleGC(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.UsageHistoryRetention = time.Hour
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Samples expire even if their allocation never reports usage again
+	store := s1.fsm.State()
+	alloc := mock.Alloc()
+	sample := func(cpu int, ago time.Duration) *structs.UsageSample {
+		return structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+			CPU:       cpu,
+			Timestamp: time.Now().Add(-ago).UnixNano(),
+		})
+	}
+	must.NoError(t, store.UpsertUsageSamples(structs.MsgTypeTestSetup, 1000,
+		[]*structs.UsageSample{sample(100, 2*time.Hour), sample(200, time.Minute)}, 0))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+	must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobUsageSampleGC, 2000)))
+
+	iter, err := store.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+	must.Len(t, 1, samples)
+	must.Eq(t, 200, samples[0].CPU)
+
+	// Nothing to collect doesn't write to raft
+	index, err := store.Index(state.TableUsageSamples)
+	must.NoError(t, err)
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	core = NewCoreScheduler(s1, snap)
+	must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobUsageSampleGC, 3000)))
+	after, err := store.Index(state.TableUsageSamples)
+	must.NoError(t, err)
+	must.Eq(t, index, after)
+}
+
 func TestCoreScheduler_FailLoop(t *testing.T) {
 	ci.Parallel(t)
 
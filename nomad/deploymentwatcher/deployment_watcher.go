@@ -6,6 +6,8 @@ package deploymentwatcher
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -279,11 +281,14 @@ func (w *deploymentWatcher) PromoteDeployment(
 	return nil
 }
 
-// autoPromoteDeployment creates a synthetic promotion request, and upserts it for processing
-func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStub) error {
+// autoPromoteDeployment creates a synthetic promotion request, and upserts it
+// for processing. If the canaries exceed the usage criteria of their task
+// group, it returns the description of the failure instead, and whether the
+// job should be rolled back.
+func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStub) (failDesc string, rollback bool, err error) {
 	d := w.getDeployment()
 	if !d.HasPlacedCanaries() || !d.RequiresPromotion() {
-		return nil
+		return "", false, nil
 	}
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
@@ -297,7 +302,7 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 		}
 
 		if !dstate.AutoPromote || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
-			return nil
+			return "", false, nil
 		}
 
 		healthyCanaries := 0
@@ -310,16 +315,158 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			}
 		}
 		if healthyCanaries != dstate.DesiredCanaries {
-			return nil
+			return "", false, nil
 		}
 	}
 
+	// Compare the usage of the healthy canaries to the stable version
+	exceeded, ready, err := w.canaryUsageExceeded(d)
+	if err != nil || !ready {
+		return "", false, err
+	}
+	if len(exceeded) > 0 {
+		for _, tg := range exceeded {
+			if w.j.LookupTaskGroup(tg.group).Update.AutoRevert {
+				rollback = true
+			}
+		}
+		return canaryUsageDescription(exceeded), rollback, nil
+	}
+
 	// Send the request
-	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
+	_, err = w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
 		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
 		Eval:                     w.getEval(),
 	})
-	return err
+	return "", false, err
+}
+
+// canaryUsageExceeded compares the usage of the canaries of each task group
+// with canary usage criteria to the usage of the allocations of the latest
+// stable job version, and returns the criteria the canaries exceed. It isn't
+// ready until every canary of those groups reported its usage. Groups without
+// usage of the stable version to compare to are skipped.
+func (w *deploymentWatcher) canaryUsageExceeded(d *structs.Deployment) ([]*canaryUsageExcess, bool, error) {
+	var groups []string
+	for name, dstate := range d.TaskGroups {
+		if tg := w.j.LookupTaskGroup(name); dstate.DesiredCanaries > 0 && tg != nil && tg.Update.HasCanaryUsageCriteria() {
+			groups = append(groups, name)
+		}
+	}
+	if len(groups) == 0 {
+		return nil, true, nil
+	}
+
+	stable, err := w.latestStableJob()
+	if err != nil || stable == nil {
+		return nil, true, err
+	}
+
+	snap, err := w.state.Snapshot()
+	if err != nil {
+		return nil, false, err
+	}
+	allocs, err := snap.AllocsByJob(nil, w.j.Namespace, w.j.ID, true)
+	if err != nil {
+		return nil, false, err
+	}
+	stableAllocs := make(map[string]bool)
+	for _, alloc := range allocs {
+		if alloc.Job != nil && alloc.Job.Version == stable.Version {
+			stableAllocs[alloc.ID] = true
+		}
+	}
+
+	iter, err := snap.UsageSamplesByJob(nil, w.j.Namespace, w.j.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+
+	var exceeded []*canaryUsageExcess
+	for _, name := range groups {
+		groupExceeded, ready := canaryUsageExceededGroup(name, w.j.LookupTaskGroup(name).Update,
+			d.TaskGroups[name].PlacedCanaries, stableAllocs, samples)
+		if !ready {
+			return nil, false, nil
+		}
+		exceeded = append(exceeded, groupExceeded...)
+	}
+	return exceeded, true, nil
+}
+
+// canaryUsageExcess is a usage criteria of a task group exceeded by its
+// canaries.
+type canaryUsageExcess struct {
+	group    string
+	resource string
+	unit     string
+	canary   int
+	stable   int
+	maxRatio float64
+}
+
+func (e *canaryUsageExcess) String() string {
+	return fmt.Sprintf("task group %q canary %s p95 %d %s exceeds %v times stable p95 %d %s",
+		e.group, e.resource, e.canary, e.unit, e.maxRatio, e.stable, e.unit)
+}
+
+// canaryUsageExceededGroup returns the usage criteria of the update strategy
+// of a task group whose canaries exceed them, comparing the 95th percentile
+// of the usage samples of the canaries to that of the samples of the stable
+// allocations of the group. It isn't ready while some canaries have no
+// samples.
+func canaryUsageExceededGroup(group string, u *structs.UpdateStrategy, canaries []string,
+	stableAllocs map[string]bool, samples []*structs.UsageSample) ([]*canaryUsageExcess, bool) {
+
+	reported := make(map[string]bool, len(canaries))
+	var canaryCPU, canaryMemory, stableCPU, stableMemory []int
+	for _, sample := range samples {
+		switch {
+		case sample.TaskGroup != group:
+		case slices.Contains(canaries, sample.AllocID):
+			reported[sample.AllocID] = true
+			canaryCPU = append(canaryCPU, sample.CPU)
+			canaryMemory = append(canaryMemory, sample.MemoryMB)
+		case stableAllocs[sample.AllocID]:
+			stableCPU = append(stableCPU, sample.CPU)
+			stableMemory = append(stableMemory, sample.MemoryMB)
+		}
+	}
+	if len(reported) < len(canaries) {
+		return nil, false
+	}
+	if len(stableCPU) == 0 {
+		return nil, true
+	}
+
+	var exceeded []*canaryUsageExcess
+	check := func(resource, unit string, maxRatio float64, canary, stable []int) {
+		c, s := structs.NewUsagePercentiles(canary).P95, structs.NewUsagePercentiles(stable).P95
+		if maxRatio > 0 && s > 0 && float64(c) > maxRatio*float64(s) {
+			exceeded = append(exceeded, &canaryUsageExcess{
+				group: group, resource: resource, unit: unit,
+				canary: c, stable: s, maxRatio: maxRatio,
+			})
+		}
+	}
+	check("cpu", "MHz", u.CanaryMaxCPURatio, canaryCPU, stableCPU)
+	check("memory", "MB", u.CanaryMaxMemoryRatio, canaryMemory, stableMemory)
+	return exceeded, true
+}
+
+// canaryUsageDescription returns the status description of a deployment
+// failed because its canaries exceeded their usage criteria.
+func canaryUsageDescription(exceeded []*canaryUsageExcess) string {
+	reasons := make([]string, 0, len(exceeded))
+	for _, e := range exceeded {
+		reasons = append(reasons, e.String())
+	}
+	slices.Sort(reasons)
+	return fmt.Sprintf("%s: %s", structs.DeploymentStatusDescriptionCanaryUsage, strings.Join(reasons, "; "))
 }
 
 func (w *deploymentWatcher) PauseDeployment(
@@ -434,6 +581,7 @@ func (w *deploymentWatcher) watch() {
 	var updates *allocUpdates
 
 	rollback, deadlineHit := false, false
+	var failDesc string
 
 FAIL:
 	for {
@@ -537,11 +685,23 @@ FAIL:
 			}
 
 			// If permitted, automatically promote this canary deployment
-			err = w.autoPromoteDeployment(updates.allocs)
+			desc, rback, err := w.autoPromoteDeployment(updates.allocs)
 			if err != nil {
 				w.logger.Error("failed to auto promote deployment", "error", err)
 			}
 
+			// The canaries exceeded their usage criteria, so fail the
+			// deployment instead
+			if desc != "" {
+				w.logger.Debug("canaries exceeded usage criteria", "description", desc, "rollback", rback)
+				rollback, failDesc = rback, desc
+				err := w.nextRegion(structs.DeploymentStatusFailed)
+				if err != nil {
+					w.logger.Error("multiregion deployment error", "error", err)
+				}
+				break FAIL
+			}
+
 			// Create an eval to push the deployment along
 			if res.createEval || len(res.allowReplacements) != 0 {
 				w.createBatchedUpdate(res.allowReplacements, allocIndex)
@@ -556,6 +716,8 @@ FAIL:
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
 	if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
+	} else if failDesc != "" {
+		desc = failDesc
 	}
 
 	// Rollback to the old job if necessary
@@ -701,6 +701,49 @@ func TestWatcher_AutoPromoteDeployment(t *testing.T) {
 	require.False(t, b1.DeploymentStatus.Canary)
 }
 
+func TestWatcher_canaryUsageExceededGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	u := structs.DefaultUpdateStrategy.Copy()
+	u.CanaryMaxMemoryRatio = 1.2
+
+	sample := func(allocID string, cpu, memoryMB int) *structs.UsageSample {
+		return &structs.UsageSample{TaskGroup: "web", AllocID: allocID, CPU: cpu, MemoryMB: memoryMB}
+	}
+	canaries := []string{"c1", "c2"}
+	stableAllocs := map[string]bool{"s1": true, "s2": true}
+	samples := []*structs.UsageSample{
+		sample("s1", 100, 200),
+		sample("s2", 100, 250),
+		sample("old", 100, 1000),
+		sample("c1", 400, 280),
+	}
+
+	// Canaries that haven't reported their usage yet can't be compared
+	_, ready := canaryUsageExceededGroup("web", u, canaries, stableAllocs, samples)
+	must.False(t, ready)
+
+	// Canaries within the criteria are promoted, whatever their CPU usage
+	samples = append(samples, sample("c2", 400, 290))
+	exceeded, ready := canaryUsageExceededGroup("web", u, canaries, stableAllocs, samples)
+	must.True(t, ready)
+	must.SliceEmpty(t, exceeded)
+
+	// Canaries exceeding the criteria fail the deployment
+	samples = append(samples, sample("c2", 400, 310))
+	exceeded, ready = canaryUsageExceededGroup("web", u, canaries, stableAllocs, samples)
+	must.True(t, ready)
+	must.Len(t, 1, exceeded)
+	must.Eq(t, structs.DeploymentStatusDescriptionCanaryUsage+
+		`: task group "web" canary memory p95 310 MB exceeds 1.2 times stable p95 250 MB`,
+		canaryUsageDescription(exceeded))
+
+	// Groups without usage of the stable version are promoted
+	exceeded, ready = canaryUsageExceededGroup("web", u, canaries, nil, samples)
+	must.True(t, ready)
+	must.SliceEmpty(t, exceeded)
+}
+
 func TestWatcher_AutoPromoteDeployment_UnhealthyCanaries(t *testing.T) {
 	ci.Parallel(t)
 	w, m := defaultTestDeploymentWatcher(t)
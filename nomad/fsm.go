@@ -57,6 +57,8 @@ const (
 	NodePoolSnapshot                     SnapshotType = 28
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
+	UsageSampleSnapshot                  SnapshotType = 31
+	BillingRecordSnapshot                SnapshotType = 32
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -102,6 +104,8 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	NodePoolSnapshot:                     "NodePool",
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
+	UsageSampleSnapshot:                  "UsageSample",
+	BillingRecordSnapshot:                "BillingRecord",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -188,17 +192,22 @@ type FSMConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// BillingRecordRetention is how long the billing records of allocations
+	// are kept after they terminated.
+	BillingRecordRetention time.Duration
 }
 
 // NewFSM is used to construct a new FSM with a blank state.
 func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 	// Create a state store
 	sconfig := &state.StateStoreConfig{
-		Logger:             config.Logger,
-		Region:             config.Region,
-		EnablePublisher:    config.EnableEventBroker,
-		EventBufferSize:    config.EventBufferSize,
-		JobTrackedVersions: config.JobTrackedVersions,
+		Logger:                 config.Logger,
+		Region:                 config.Region,
+		EnablePublisher:        config.EnableEventBroker,
+		EventBufferSize:        config.EventBufferSize,
+		JobTrackedVersions:     config.JobTrackedVersions,
+		BillingRecordRetention: config.BillingRecordRetention,
 	}
 	state, err := state.NewStateStore(sconfig)
 	if err != nil {
@@ -384,6 +393,10 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 
 	case structs.JobVersionTagRequestType:
 		return n.applyJobVersionTag(buf[1:], log.Index)
+	case structs.UsageSamplesUpsertRequestType:
+		return n.applyUsageSamplesUpsert(msgType, buf[1:], log.Index)
+	case structs.AllocUsageUpdateRequestType:
+		return n.applyAllocUsageUpdate(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -485,14 +498,26 @@ func (n *nomadFSM) applyStatusUpdate(msgType structs.MessageType, buf []byte, in
 		panic(fmt.Errorf("failed to decode request: %v", err))
 	}
 
-	if err := n.state.UpdateNodeStatus(msgType, index, req.NodeID, req.Status, req.UpdatedAt, req.NodeEvent); err != nil {
+	// Updates of the live utilization of a ready node are sent with its
+	// heartbeats, and don't unblock evals unless its status changed too
+	statusUpdated := true
+	if req.Utilization != nil && req.NodeEvent == nil {
+		existing, err := n.state.NodeByID(nil, req.NodeID)
+		if err != nil {
+			n.logger.Error("looking up node failed", "node_id", req.NodeID, "error", err)
+			return err
+		}
+		statusUpdated = existing == nil || existing.Status != req.Status
+	}
+
+	if err := n.state.UpdateNodeStatus(msgType, index, req.NodeID, req.Status, req.UpdatedAt, req.NodeEvent, req.Utilization); err != nil {
 		n.logger.Error("UpdateNodeStatus failed", "error", err)
 		return err
 	}
 
 	// Unblock evals for the nodes computed node class if it is in a ready
 	// state.
-	if req.Status == structs.NodeStatusReady {
+	if req.Status == structs.NodeStatusReady && statusUpdated {
 		ws := memdb.NewWatchSet()
 		node, err := n.state.NodeByID(ws, req.NodeID)
 		if err != nil {
@@ -949,6 +974,14 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 		return err
 	}
 
+	// Record the usage history of the allocations
+	if len(req.UsageSamples) > 0 {
+		if err := n.state.UpsertUsageSamples(msgType, index, req.UsageSamples, 0); err != nil {
+			n.logger.Error("UpsertUsageSamples failed", "error", err)
+			return err
+		}
+	}
+
 	// Update any evals
 	if len(req.Evals) > 0 {
 		if err := n.upsertEvals(msgType, index, req.Evals); err != nil {
@@ -1193,6 +1226,39 @@ func (n *nomadFSM) applyJobVersionTag(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyUsageSamplesUpsert is used to record the usage history of allocations
+func (n *nomadFSM) applyUsageSamplesUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_usage_samples_upsert"}, time.Now())
+	var req structs.UsageSamplesUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertUsageSamples(msgType, index, req.Samples, req.PruneBefore); err != nil {
+		n.logger.Error("UpsertUsageSamples failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyAllocUsageUpdate records the usage summaries clients sent with their
+// heartbeats
+func (n *nomadFSM) applyAllocUsageUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_alloc_usage_update"}, time.Now())
+	var req structs.AllocUsageUpdateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateAllocsUsage(msgType, index, req.Summaries, req.Samples); err != nil {
+		n.logger.Error("UpdateAllocsUsage failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // applyJobStability is used to set the stability of a job
 func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_stability"}, time.Now())
@@ -1528,11 +1594,12 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 
 	// Create a new state store
 	config := &state.StateStoreConfig{
-		Logger:             n.config.Logger,
-		Region:             n.config.Region,
-		EnablePublisher:    n.config.EnableEventBroker,
-		EventBufferSize:    n.config.EventBufferSize,
-		JobTrackedVersions: n.config.JobTrackedVersions,
+		Logger:                 n.config.Logger,
+		Region:                 n.config.Region,
+		EnablePublisher:        n.config.EnableEventBroker,
+		EventBufferSize:        n.config.EventBufferSize,
+		JobTrackedVersions:     n.config.JobTrackedVersions,
+		BillingRecordRetention: n.config.BillingRecordRetention,
 	}
 	newState, err := state.NewStateStore(config)
 	if err != nil {
@@ -1936,6 +2003,28 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case UsageSampleSnapshot:
+			sample := new(structs.UsageSample)
+			if err := dec.Decode(sample); err != nil {
+				return err
+			}
+			if filter.Include(sample) {
+				if err := restore.UsageSampleRestore(sample); err != nil {
+					return err
+				}
+			}
+
+		case BillingRecordSnapshot:
+			record := new(structs.AllocBillingRecord)
+			if err := dec.Decode(record); err != nil {
+				return err
+			}
+			if filter.Include(record) {
+				if err := restore.BillingRecordRestore(record); err != nil {
+					return err
+				}
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2537,6 +2626,14 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistUsageSamples(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistBillingRecords(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3274,6 +3371,42 @@ func (s *nomadSnapshot) persistJobSubmissions(sink raft.SnapshotSink, encoder *c
 	return nil
 }
 
+func (s *nomadSnapshot) persistUsageSamples(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	samples, err := s.snap.UsageSamples(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := samples.Next(); raw != nil; raw = samples.Next() {
+		sample := raw.(*structs.UsageSample)
+
+		sink.Write([]byte{byte(UsageSampleSnapshot)})
+		if err := encoder.Encode(sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistBillingRecords(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	records, err := s.snap.BillingRecords(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := records.Next(); raw != nil; raw = records.Next() {
+		record := raw.(*structs.AllocBillingRecord)
+
+		sink.Write([]byte{byte(BillingRecordSnapshot)})
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -2940,6 +2940,61 @@ func TestFSM_SnapshotRestore_JobSubmissions(t *testing.T) {
 	must.Eq(t, mockJobSubmission2, jobSubmission2Resp)
 }
 
+func TestFSM_SnapshotRestore_UsageSamples(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	alloc := mock.Alloc()
+	sample := structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+		CPU:       250,
+		MemoryMB:  64,
+		Timestamp: time.Now().UnixNano(),
+		Tasks:     map[string]*structs.TaskUsageSummary{"web": {CPU: 250, MemoryMB: 64}},
+	})
+	sample.Backfilled = true
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 10, []*structs.UsageSample{sample}, 0))
+
+	restoredFSM := testSnapshotRestore(t, fsm)
+	iter, err := restoredFSM.State().UsageSamples(memdb.NewWatchSet())
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, sample, raw.(*structs.UsageSample))
+	must.Nil(t, iter.Next())
+}
+
+func TestFSM_SnapshotRestore_BillingRecords(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	alloc := mock.Alloc()
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 10, []*structs.Allocation{alloc}))
+	now := time.Now().UnixNano()
+	billing := structs.NewAllocBillingRecord(map[string]*structs.TaskBillingRecord{
+		"web": {CPUSeconds: 30, MemoryGBHours: 0.5, PeakMemoryMB: 512},
+	})
+	billing.EndTime = now
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 20, []*structs.Allocation{{
+		ID:            alloc.ID,
+		NodeID:        alloc.NodeID,
+		ClientStatus:  structs.AllocClientStatusComplete,
+		ModifyTime:    now,
+		BillingRecord: billing,
+	}}))
+	record, err := testState.BillingRecordByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.NotNil(t, record)
+
+	restoredFSM := testSnapshotRestore(t, fsm)
+	out, err := restoredFSM.State().BillingRecordByAllocID(memdb.NewWatchSet(), alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, record, out)
+}
+
 func TestFSM_ReconcileSummaries(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
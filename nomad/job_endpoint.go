@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
@@ -87,6 +89,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobNodePoolValidatingHook{srv: s},
 			&jobValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
+			&jobUsageAdmissionHook{srv: s},
 			jobNumaHook{},
 			&jobSchedHook{},
 		},
@@ -1601,6 +1604,108 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// Utilization is used to roll up the resource usage the clients reported for
+// the running allocations of a job
+func (j *Job) Utilization(args *structs.JobSpecificRequest,
+	reply *structs.JobUtilizationResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward(structs.JobUtilizationRPCMethod, args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "utilization"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			allocs, err := state.AllocsByJob(ws, args.RequestNamespace(), args.JobID, false)
+			if err != nil {
+				return err
+			}
+			reply.Utilization = structs.NewJobUtilization(args.RequestNamespace(), args.JobID, allocs)
+
+			// Use the last index that affected the allocs table
+			index, err := state.Index("allocs")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// UsageHistory is used to read the usage samples the clients reported for
+// the allocations of a job
+func (j *Job) UsageHistory(args *structs.JobUsageHistoryRequest,
+	reply *structs.JobUsageHistoryResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward(structs.JobUsageHistoryRPCMethod, args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "usage_history"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+	if args.Until != 0 && args.Until < args.Since {
+		return fmt.Errorf("until must not be before since")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			iter, err := stateStore.UsageSamplesByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			reply.Samples = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				sample := raw.(*structs.UsageSample)
+				if sample.Timestamp < args.Since || (args.Until != 0 && sample.Timestamp > args.Until) {
+					continue
+				}
+				reply.Samples = append(reply.Samples, sample)
+			}
+
+			return j.srv.setReplyQueryMeta(stateStore, state.TableUsageSamples, &reply.QueryMeta)
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // Evaluations is used to list the evaluations for a job
 func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 	reply *structs.JobEvaluationsResponse) error {
@@ -1848,47 +1953,222 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		}
 	}
 
+	sim, err := j.simulateJob(args)
+	if err != nil {
+		return err
+	}
+	if sim.policyWarnings != nil {
+		warnings = append(warnings, sim.policyWarnings)
+		reply.Warnings = helper.MergeMultierrorWarnings(warnings...)
+	}
+
+	// Annotate and store the diff
+	annotations := sim.plan.Annotations
+	if args.Diff {
+		jobDiff, err := sim.existingJob.Diff(args.Job, true)
+		if err != nil {
+			return fmt.Errorf("failed to create job diff: %v", err)
+		}
+
+		if err := scheduler.Annotate(jobDiff, annotations); err != nil {
+			return fmt.Errorf("failed to annotate job diff: %v", err)
+		}
+		reply.Diff = jobDiff
+	}
+
+	// If it is a periodic job calculate the next launch
+	if args.Job.IsPeriodic() && args.Job.Periodic.Enabled {
+		reply.NextPeriodicLaunch, err = args.Job.Periodic.Next(time.Now().In(args.Job.Periodic.GetLocation()))
+		if err != nil {
+			return fmt.Errorf("Failed to parse cron expression: %v", err)
+		}
+	}
+
+	// Project the pressure of the nodes the plan changes
+	reply.NodeImpacts, err = sim.nodeImpacts()
+	if err != nil {
+		return err
+	}
+
+	// Grab the failures
+	reply.FailedTGAllocs = sim.eval.FailedTGAllocs
+	reply.JobModifyIndex = sim.index
+	reply.Annotations = annotations
+	reply.CreatedEvals = sim.createdEvals
+	reply.Index = sim.index
+	return nil
+}
+
+// WhatIf is used to simulate the placement of a job, such as one with
+// modified resources, against the current state of the cluster, and to
+// project the usage of the nodes it would be placed on from the usage their
+// allocations were observed to have.
+func (j *Job) WhatIf(args *structs.JobWhatIfRequest, reply *structs.JobWhatIfResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward(structs.JobWhatIfRPCMethod, args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "what_if"}, time.Now())
+
+	if args.Job == nil {
+		return fmt.Errorf("Job required for what-if")
+	}
+
+	// Run admission controllers
+	job, warnings, err := j.admissionControllers(args.Job)
+	if err != nil {
+		return err
+	}
+	args.Job = job
+
+	// Check job submission permissions, which we assume is the same for
+	// what-if as for plan
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else {
+		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+		if args.PolicyOverride {
+			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
+				return structs.ErrPermissionDenied
+			}
+		}
+	}
+
+	sim, err := j.simulateJob(&structs.JobPlanRequest{
+		Job:            args.Job,
+		PolicyOverride: args.PolicyOverride,
+		WriteRequest:   args.WriteRequest,
+	})
+	if err != nil {
+		return err
+	}
+	if sim.policyWarnings != nil {
+		warnings = append(warnings, sim.policyWarnings)
+	}
+	reply.Warnings = helper.MergeMultierrorWarnings(warnings...)
+
+	reply.Nodes, err = sim.nodeImpacts()
+	if err != nil {
+		return err
+	}
+
+	reply.FailedTGAllocs = sim.eval.FailedTGAllocs
+	reply.Feasible = len(reply.FailedTGAllocs) == 0
+	reply.Index = sim.index
+	return nil
+}
+
+// nodeImpacts returns the observed and projected usage of the nodes the
+// simulated plan places allocations on or stops allocations from, sorted by
+// their projected pressure, highest first.
+func (sim *jobSimulation) nodeImpacts() ([]*structs.NodeWhatIfImpact, error) {
+	nodeIDs := make(map[string]struct{})
+	for nodeID := range sim.plan.NodeAllocation {
+		nodeIDs[nodeID] = struct{}{}
+	}
+	for nodeID := range sim.plan.NodeUpdate {
+		nodeIDs[nodeID] = struct{}{}
+	}
+	for nodeID := range sim.plan.NodePreemptions {
+		nodeIDs[nodeID] = struct{}{}
+	}
+
+	impacts := make([]*structs.NodeWhatIfImpact, 0, len(nodeIDs))
+	for nodeID := range nodeIDs {
+		node, err := sim.before.NodeByID(nil, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			continue
+		}
+		allocs, err := sim.before.AllocsByNode(nil, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		stopped := slices.Concat(sim.plan.NodeUpdate[nodeID], sim.plan.NodePreemptions[nodeID])
+		impacts = append(impacts,
+			structs.NewNodeWhatIfImpact(node, allocs, sim.plan.NodeAllocation[nodeID], stopped))
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].ProjectedPressure != impacts[j].ProjectedPressure {
+			return impacts[i].ProjectedPressure > impacts[j].ProjectedPressure
+		}
+		return impacts[i].NodeID < impacts[j].NodeID
+	})
+	return impacts, nil
+}
+
+// jobSimulation is the outcome of running the scheduler for a job that
+// hasn't been registered.
+type jobSimulation struct {
+	// existingJob is the registered version of the job, if any, and index is
+	// its JobModifyIndex
+	existingJob *structs.Job
+	index       uint64
+
+	// policyWarnings are the warnings of the submission policies
+	policyWarnings error
+
+	// before is the state the job was scheduled against, before the planner
+	// applied the plan to it
+	before *state.StateSnapshot
+
+	// plan is the plan the scheduler submitted, eval is the evaluation as
+	// updated by the scheduler, and createdEvals are the evaluations it
+	// created, such as for rolling updates or blocked placements
+	plan         *structs.Plan
+	eval         *structs.Evaluation
+	createdEvals []*structs.Evaluation
+}
+
+// simulateJob runs the scheduler for the job against a snapshot of the state,
+// without submitting the resulting plan. The caller must have already
+// checked the job submission permissions.
+func (j *Job) simulateJob(args *structs.JobPlanRequest) (*jobSimulation, error) {
 	// Acquire a snapshot of the state
 	snap, err := j.srv.fsm.State().Snapshot()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Enforce Sentinel policies
 	nomadACLToken, err := snap.ACLTokenBySecretID(nil, args.AuthToken)
 	if err != nil && !strings.Contains(err.Error(), "missing secret id") {
-		return err
+		return nil, err
 	}
 	ns, err := snap.NamespaceByName(nil, args.RequestNamespace())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get the original job
 	ws := memdb.NewWatchSet()
 	existingJob, err := snap.JobByID(ws, args.RequestNamespace(), args.Job.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	policyWarnings, err := j.enforceSubmitJob(args.PolicyOverride, args.Job, existingJob, nomadACLToken, ns)
 	if err != nil {
-		return err
-	}
-	if policyWarnings != nil {
-		warnings = append(warnings, policyWarnings)
-		reply.Warnings = helper.MergeMultierrorWarnings(warnings...)
+		return nil, err
 	}
 
 	// Interpolate the job for this region
 	err = j.interpolateMultiregionFields(args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Ensure that all scaling policies have an appropriate ID
 	if err := propagateScalingPolicyIDs(existingJob, args.Job); err != nil {
-		return err
+		return nil, err
 	}
 
 	var index uint64
@@ -1903,14 +2183,14 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 			// Insert the updated Job into the snapshot
 			updatedIndex = existingJob.JobModifyIndex + 1
 			if err := snap.UpsertJob(structs.IgnoreUnknownTypeFlag, updatedIndex, nil, args.Job); err != nil {
-				return err
+				return nil, err
 			}
 		}
 	} else if existingJob == nil {
 		// Insert the updated Job into the snapshot
 		err := snap.UpsertJob(structs.IgnoreUnknownTypeFlag, 100, nil, args.Job)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -1934,6 +2214,13 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	// Ignore eval event creation during snapshot eval creation
 	snap.UpsertEvals(structs.IgnoreUnknownTypeFlag, 100, []*structs.Evaluation{eval})
 
+	// The planner applies the plan to the snapshot, so keep the state from
+	// before it
+	before, err := snap.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create an in-memory Planner that returns no errors and stores the
 	// submitted plan and created evals.
 	planner := &scheduler.Harness{
@@ -1943,50 +2230,29 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	// Create the scheduler and run it
 	sched, err := scheduler.NewScheduler(eval.Type, j.logger, j.srv.workersEventCh, snap, planner)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := sched.Process(eval); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Annotate and store the diff
 	if plans := len(planner.Plans); plans != 1 {
-		return fmt.Errorf("scheduler resulted in an unexpected number of plans: %v", plans)
-	}
-	annotations := planner.Plans[0].Annotations
-	if args.Diff {
-		jobDiff, err := existingJob.Diff(args.Job, true)
-		if err != nil {
-			return fmt.Errorf("failed to create job diff: %v", err)
-		}
-
-		if err := scheduler.Annotate(jobDiff, annotations); err != nil {
-			return fmt.Errorf("failed to annotate job diff: %v", err)
-		}
-		reply.Diff = jobDiff
+		return nil, fmt.Errorf("scheduler resulted in an unexpected number of plans: %v", plans)
 	}
-
-	// Grab the failures
 	if len(planner.Evals) != 1 {
-		return fmt.Errorf("scheduler resulted in an unexpected number of eval updates: %v", planner.Evals)
-	}
-	updatedEval := planner.Evals[0]
-
-	// If it is a periodic job calculate the next launch
-	if args.Job.IsPeriodic() && args.Job.Periodic.Enabled {
-		reply.NextPeriodicLaunch, err = args.Job.Periodic.Next(time.Now().In(args.Job.Periodic.GetLocation()))
-		if err != nil {
-			return fmt.Errorf("Failed to parse cron expression: %v", err)
-		}
-	}
-
-	reply.FailedTGAllocs = updatedEval.FailedTGAllocs
-	reply.JobModifyIndex = index
-	reply.Annotations = annotations
-	reply.CreatedEvals = planner.CreateEvals
-	reply.Index = index
-	return nil
+		return nil, fmt.Errorf("scheduler resulted in an unexpected number of eval updates: %v", planner.Evals)
+	}
+
+	return &jobSimulation{
+		existingJob:    existingJob,
+		index:          index,
+		policyWarnings: policyWarnings,
+		before:         before,
+		plan:           planner.Plans[0],
+		eval:           planner.Evals[0],
+		createdEvals:   planner.CreateEvals,
+	}, nil
 }
 
 // validateJobUpdate ensures updates to a job are valid.
@@ -2171,6 +2437,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 			JobID:          dispatchJob.ID,
 			JobModifyIndex: jobCreateIndex,
 			Status:         structs.EvalStatusPending,
+			TraceParent:    tracecontext.Child(args.TraceParent),
 			CreateTime:     now,
 			ModifyTime:     now,
 		}
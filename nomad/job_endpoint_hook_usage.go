@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// UsageAdmissionModeWarn warns about job submissions requesting resources
+	// that deviate from the usage observed for the job
+	UsageAdmissionModeWarn = "warn"
+
+	// UsageAdmissionModeDeny rejects job submissions requesting resources
+	// that deviate from the usage observed for the job
+	UsageAdmissionModeDeny = "deny"
+)
+
+// jobUsageAdmissionHook compares the resources requested by the tasks of a
+// job submission against the usage history the servers recorded for the
+// allocations of the job, and warns about or rejects requests that deviate
+// from the usage by more than the configured ratio.
+type jobUsageAdmissionHook struct {
+	srv *Server
+}
+
+func (*jobUsageAdmissionHook) Name() string {
+	return "usage_admission"
+}
+
+func (h *jobUsageAdmissionHook) Validate(job *structs.Job) (warnings []error, err error) {
+	conf := h.srv.config
+	if conf.UsageAdmissionMode == "" {
+		return nil, nil
+	}
+
+	iter, err := h.srv.State().UsageSamplesByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+	if len(samples) == 0 {
+		return nil, nil
+	}
+	utilization := structs.NewUsageHistoryUtilization(job.Namespace, job.ID, samples)
+
+	deviations := usageDeviations(job, utilization,
+		conf.UsageAdmissionMaxRatio, conf.UsageAdmissionMinAllocations)
+	if conf.UsageAdmissionMode == UsageAdmissionModeDeny {
+		var mErr *multierror.Error
+		for _, d := range deviations {
+			mErr = multierror.Append(mErr, d)
+		}
+		return nil, mErr.ErrorOrNil()
+	}
+	return deviations, nil
+}
+
+// usageDeviations returns an error for each CPU and memory request of the
+// tasks of the job whose ratio to the 95th percentile of the usage observed
+// for the task exceeds maxRatio. Task groups with fewer than minAllocs
+// allocations with usage are skipped, since their usage is not
+// representative yet.
+func usageDeviations(job *structs.Job, u *structs.JobUtilization, maxRatio float64, minAllocs int) []error {
+	var deviations []error
+	for _, tg := range job.TaskGroups {
+		tgu, ok := u.TaskGroups[tg.Name]
+		if !ok || tgu.Allocations < minAllocs {
+			continue
+		}
+		for _, task := range tg.Tasks {
+			tu, ok := tgu.Tasks[task.Name]
+			if !ok || task.Resources == nil {
+				continue
+			}
+
+			// Reserved cores are sized by their count, not their usage
+			if task.Resources.Cores == 0 && usageDeviates(task.Resources.CPU, tu.CPU.P95, maxRatio) {
+				deviations = append(deviations, fmt.Errorf(
+					"Task %q.%q requests %d MHz of CPU, but its p95 usage over %d allocations is %d MHz",
+					tg.Name, task.Name, task.Resources.CPU, tgu.Allocations, tu.CPU.P95))
+			}
+			if usageDeviates(task.Resources.MemoryMB, tu.MemoryMB.P95, maxRatio) {
+				deviations = append(deviations, fmt.Errorf(
+					"Task %q.%q requests %d MB of memory, but its p95 usage over %d allocations is %d MB",
+					tg.Name, task.Name, task.Resources.MemoryMB, tgu.Allocations, tu.MemoryMB.P95))
+			}
+		}
+	}
+	return deviations
+}
+
+// usageDeviates returns whether the requested value is more than maxRatio
+// times the used value, or less than the used value divided by maxRatio.
+func usageDeviates(requested, used int, maxRatio float64) bool {
+	if requested <= 0 || used <= 0 || maxRatio <= 1 {
+		return false
+	}
+	ratio := float64(requested) / float64(used)
+	return ratio > maxRatio || ratio < 1/maxRatio
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobUsageAdmissionHook_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.UsageAdmissionMode = UsageAdmissionModeWarn
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	task := tg.Tasks[0]
+	task.Resources.CPU = 500
+	task.Resources.MemoryMB = 256
+
+	// Jobs that haven't run yet have no usage to compare to
+	hook := &jobUsageAdmissionHook{srv: s1}
+	warnings, err := hook.Validate(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, warnings)
+
+	// Record two samples of each of three allocations
+	var samples []*structs.UsageSample
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = tg.Name
+		for ts := int64(1); ts <= 2; ts++ {
+			samples = append(samples, structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+				Timestamp: ts,
+				Tasks: map[string]*structs.TaskUsageSummary{
+					task.Name: {CPU: 50 * int(ts), MemoryMB: 200},
+				},
+			}))
+		}
+	}
+	must.NoError(t, s1.State().UpsertUsageSamples(structs.MsgTypeTestSetup, 1000, samples, 0))
+
+	warnings, err = hook.Validate(job)
+	must.NoError(t, err)
+	must.Len(t, 1, warnings)
+	must.ErrorContains(t, warnings[0], `requests 500 MHz of CPU, but its p95 usage over 3 allocations is 100 MHz`)
+
+	s1.config.UsageAdmissionMode = UsageAdmissionModeDeny
+	_, err = hook.Validate(job)
+	must.ErrorContains(t, err, `requests 500 MHz of CPU`)
+
+	// Requests close to the usage are admitted
+	task.Resources.CPU = 150
+	warnings, err = hook.Validate(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, warnings)
+}
+
+func TestUsageDeviations(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	task := tg.Tasks[0]
+	task.Resources.CPU = 100
+	task.Resources.MemoryMB = 4096
+
+	u := &structs.JobUtilization{
+		TaskGroups: map[string]*structs.TaskGroupUtilization{
+			tg.Name: {
+				Allocations: 3,
+				Tasks: map[string]*structs.TaskUtilization{
+					task.Name: {
+						CPU:      structs.UsagePercentiles{P95: 1000},
+						MemoryMB: structs.UsagePercentiles{P95: 512},
+					},
+				},
+			},
+		},
+	}
+
+	// Requests deviating in either direction are reported
+	deviations := usageDeviations(job, u, 4, 3)
+	must.Len(t, 2, deviations)
+	must.ErrorContains(t, deviations[0], "requests 100 MHz of CPU")
+	must.ErrorContains(t, deviations[1], "requests 4096 MB of memory")
+
+	// Groups with too few allocations are skipped
+	must.SliceEmpty(t, usageDeviations(job, u, 4, 5))
+
+	// Reserved cores aren't compared to the usage
+	task.Resources.Cores = 2
+	must.Len(t, 1, usageDeviations(job, u, 4, 3))
+	must.Len(t, 0, usageDeviations(job, u, 10, 3))
+}
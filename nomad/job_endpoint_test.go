@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -5658,6 +5659,106 @@ func TestJobEndpoint_Allocations(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	alloc1 := mock.Alloc()
+	alloc1.ClientStatus = structs.AllocClientStatusRunning
+	alloc1.UsageSummary = &structs.AllocUsageSummary{
+		CPU:      250,
+		MemoryMB: 64,
+		Tasks: map[string]*structs.TaskUsageSummary{
+			"web": {CPU: 250, MemoryMB: 64},
+		},
+	}
+	alloc2 := mock.Alloc()
+	alloc2.JobID = alloc1.JobID
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+	get := &structs.JobSpecificRequest{
+		JobID: alloc1.JobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: alloc1.Job.Namespace,
+		},
+	}
+
+	// Reading the utilization requires read-job
+	var resp structs.JobUtilizationResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.JobUtilizationRPCMethod, get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobUtilizationRPCMethod, get, &resp))
+	must.Eq(t, 1000, resp.Index)
+
+	// Only the allocation that reported usage is rolled up
+	tgu := resp.Utilization.TaskGroups[alloc1.TaskGroup]
+	must.NotNil(t, tgu)
+	must.Eq(t, 1, tgu.Allocations)
+	must.Eq(t, 250, tgu.Tasks["web"].CPU.P95)
+	must.Eq(t, 64, tgu.Tasks["web"].MemoryMB.Max)
+	must.Eq(t, int(alloc1.AllocatedResources.Tasks["web"].Cpu.CpuShares), tgu.Tasks["web"].ReservedCPU)
+}
+
+func TestJobEndpoint_UsageHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	alloc := mock.Alloc()
+	now := time.Now()
+	var samples []*structs.UsageSample
+	for i := range 3 {
+		samples = append(samples, structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+			CPU:       100 * (i + 1),
+			MemoryMB:  64,
+			Timestamp: now.Add(time.Duration(i-2) * time.Hour).UnixNano(),
+		}))
+	}
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertUsageSamples(structs.MsgTypeTestSetup, 1000, samples, 0))
+
+	get := &structs.JobUsageHistoryRequest{
+		JobID: alloc.JobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: alloc.Namespace,
+		},
+	}
+
+	// Reading the usage history requires read-job
+	var resp structs.JobUsageHistoryResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.JobUsageHistoryRPCMethod, get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobUsageHistoryRPCMethod, get, &resp))
+	must.Eq(t, 1000, resp.Index)
+	must.Len(t, 3, resp.Samples)
+
+	// The range bounds are inclusive
+	get.Since = samples[1].Timestamp
+	get.Until = samples[1].Timestamp
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobUsageHistoryRPCMethod, get, &resp))
+	must.Len(t, 1, resp.Samples)
+	must.Eq(t, 200, resp.Samples[0].CPU)
+
+	get.Since, get.Until = samples[2].Timestamp, samples[0].Timestamp
+	err = msgpackrpc.CallWithCodec(codec, structs.JobUsageHistoryRPCMethod, get, &resp)
+	must.ErrorContains(t, err, "until must not be before since")
+}
+
 func TestJobEndpoint_Allocations_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -6611,6 +6712,115 @@ func TestJobEndpoint_Plan_NoDiff(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_WhatIf(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// A running alloc observed to use less than it reserves
+	existing := mock.Alloc()
+	existing.NodeID = node.ID
+	existing.ClientStatus = structs.AllocClientStatusRunning
+	existing.UsageSummary = &structs.AllocUsageSummary{CPU: 100, MemoryMB: 64}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, existing.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{existing}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	req := &structs.JobWhatIfRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Try without a token, expect failure
+	var resp structs.JobWhatIfResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.JobWhatIfRPCMethod, req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobWhatIfRPCMethod, req, &resp))
+	must.True(t, resp.Feasible)
+	must.MapEmpty(t, resp.FailedTGAllocs)
+	must.Len(t, 1, resp.Nodes)
+
+	impact := resp.Nodes[0]
+	must.Eq(t, node.ID, impact.NodeID)
+	must.Eq(t, 1, impact.Placed)
+	must.Eq(t, 13900, impact.CPUCapacity)
+	must.Eq(t, 100, impact.CurrentCPU)
+	must.Eq(t, 64, impact.CurrentMemoryMB)
+	must.Eq(t, 100+500, impact.ProjectedCPU)
+	must.Eq(t, 64+256, impact.ProjectedMemoryMB)
+	must.Greater(t, impact.CurrentPressure, impact.ProjectedPressure)
+
+	// The job isn't registered
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Resources the node can't fit make the job infeasible
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 16384
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobWhatIfRPCMethod, req, &resp))
+	must.False(t, resp.Feasible)
+	must.MapContainsKey(t, resp.FailedTGAllocs, job.TaskGroups[0].Name)
+	must.SliceEmpty(t, resp.Nodes)
+}
+
+func TestJobEndpoint_Plan_NodeImpacts(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	existing := mock.Alloc()
+	existing.NodeID = node.ID
+	existing.ClientStatus = structs.AllocClientStatusRunning
+	existing.UsageSummary = &structs.AllocUsageSummary{CPU: 100, MemoryMB: 64}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, existing.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{existing}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	req := &structs.JobPlanRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// The plan projects the pressure of the node it places the job on
+	var resp structs.JobPlanResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Plan", req, &resp))
+	must.Len(t, 1, resp.NodeImpacts)
+	impact := resp.NodeImpacts[0]
+	must.Eq(t, node.ID, impact.NodeID)
+	must.Eq(t, 1, impact.Placed)
+	must.Eq(t, 100, impact.CurrentCPU)
+	must.Eq(t, 100+500, impact.ProjectedCPU)
+	must.Eq(t, 64+256, impact.ProjectedMemoryMB)
+}
+
 // TestJobEndpoint_Plan_Scaling asserts that the plan endpoint handles
 // jobs with scaling block
 func TestJobEndpoint_Plan_Scaling(t *testing.T) {
@@ -7425,6 +7635,58 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 	}
 }
 
+// TestJobEndpoint_Dispatch_TraceParent asserts the dispatch eval joins the
+// trace supplied with the request, or starts a new one.
+func TestJobEndpoint_Dispatch_TraceParent(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatch := func(traceParent string) *structs.Evaluation {
+		req := &structs.JobDispatchRequest{
+			JobID:       job.ID,
+			TraceParent: traceParent,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp))
+		eval, err := s1.fsm.State().EvalByID(nil, resp.EvalID)
+		must.NoError(t, err)
+		must.NotNil(t, eval)
+		return eval
+	}
+
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	joined, err := tracecontext.Parse(dispatch(parent).TraceParent)
+	must.NoError(t, err)
+	must.Eq(t, "4bf92f3577b34da6a3ce929d0e0e4736", joined.TraceID)
+	must.NotEq(t, "00f067aa0ba902b7", joined.SpanID)
+
+	started, err := tracecontext.Parse(dispatch("").TraceParent)
+	must.NoError(t, err)
+	must.NotEq(t, joined.TraceID, started.TraceID)
+}
+
 // TestJobEndpoint_Dispatch_JobChildrenSummary asserts that the job summary is updated
 // appropriately as its dispatched/children jobs status are updated.
 func TestJobEndpoint_Dispatch_JobChildrenSummary(t *testing.T) {
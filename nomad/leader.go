@@ -84,6 +84,20 @@ var minNodePoolsVersion = version.Must(version.NewVersion("1.6.0"))
 // automatically added to jobs that need access to Consul or Vault
 var minVersionMultiIdentities = version.Must(version.NewVersion("1.7.0"))
 
+// minVersionAllocUpdateDeltas is the Nomad version at which servers fill in
+// the task states and usage summary clients omit from allocation updates
+// when they did not change.
+var minVersionAllocUpdateDeltas = version.Must(version.NewVersion("1.9.4"))
+
+// minVersionUsageBackfill is the Nomad version at which servers record the
+// usage history of allocations and accept the usage summaries clients
+// buffered while disconnected.
+var minVersionUsageBackfill = version.Must(version.NewVersion("1.9.4"))
+
+// minVersionUsageHeartbeats is the Nomad version at which servers accept the
+// usage summaries of allocations with client heartbeats.
+var minVersionUsageHeartbeats = version.Must(version.NewVersion("1.9.4"))
+
 // monitorLeadership is used to monitor if we acquire or lose our role
 // as the leader in the Raft cluster. There is some work the leader is
 // expected to do, so we must react to changes
@@ -1048,6 +1062,8 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer rootKeyGC.Stop()
 	variablesRekey := time.NewTicker(s.config.VariablesRekeyInterval)
 	defer variablesRekey.Stop()
+	usageSampleGC := time.NewTicker(s.config.UsageHistoryGCInterval)
+	defer usageSampleGC.Stop()
 
 	// Set up the expired ACL local token garbage collection timer.
 	localTokenExpiredGC, localTokenExpiredGCStop := helper.NewSafeTimer(s.config.ACLTokenExpirationGCInterval)
@@ -1101,6 +1117,10 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariablesRekey, index))
 			}
+		case <-usageSampleGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobUsageSampleGC, index))
+			}
 		case <-stopCh:
 			return
 		}
@@ -4,11 +4,14 @@
 package nomad
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -289,12 +292,13 @@ func shouldCreateNodeEval(original, updated *structs.Node) bool {
 	// added through a driver/volume check.
 	//
 	// Node Resources (e.g. CPU/Memory) are handled differently, using blocked evals,
-	// and not relevant in this check.
+	// and not relevant in this check. Neither is the live utilization of the
+	// node, which changes on every refresh.
 	return !(original.ID == updated.ID &&
 		original.Datacenter == updated.Datacenter &&
 		original.Name == updated.Name &&
 		original.NodeClass == updated.NodeClass &&
-		reflect.DeepEqual(original.Attributes, updated.Attributes) &&
+		structs.NodeAttributesEqual(original.Attributes, updated.Attributes) &&
 		reflect.DeepEqual(original.Meta, updated.Meta) &&
 		reflect.DeepEqual(original.Drivers, updated.Drivers) &&
 		reflect.DeepEqual(original.HostVolumes, updated.HostVolumes) &&
@@ -366,6 +370,12 @@ func (n *Node) constructNodeServerInfoResponse(nodeID string, snap *state.StateS
 	}
 
 	reply.Features = n.srv.EnterpriseState.Features()
+	reply.AllocUpdateDeltas = ServersMeetMinimumVersion(
+		n.srv.Members(), n.srv.Region(), minVersionAllocUpdateDeltas, true)
+	reply.UsageBackfill = ServersMeetMinimumVersion(
+		n.srv.Members(), n.srv.Region(), minVersionUsageBackfill, true)
+	reply.UsageHeartbeats = ServersMeetMinimumVersion(
+		n.srv.Members(), n.srv.Region(), minVersionUsageHeartbeats, true)
 
 	return nil
 }
@@ -645,9 +655,22 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		}
 	}
 
+	// Usage summaries are recorded with their own raft message below, so
+	// they aren't written with the node status
+	allocUsage := args.AllocUsage
+	args.AllocUsage = nil
+
+	// The live utilization of the node is only written if it changed, and
+	// doesn't create evaluations
+	args.Utilization = structs.LiveUtilizationAttrs(args.Utilization)
+	if args.Utilization != nil && maps.Equal(args.Utilization, structs.LiveUtilizationAttrs(node.Attributes)) {
+		args.Utilization = nil
+	}
+	statusUpdated := node.Status != args.Status || args.NodeEvent != nil
+
 	// Commit this update via Raft
 	var index uint64
-	if node.Status != args.Status || args.NodeEvent != nil {
+	if statusUpdated || args.Utilization != nil {
 		// Attach an event if we are updating the node status to ready when it
 		// is down via a heartbeat
 		if node.Status == structs.NodeStatusDown && args.NodeEvent == nil {
@@ -664,6 +687,10 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		reply.NodeModifyIndex = index
 	}
 
+	if err := n.updateAllocUsage(snap, args.NodeID, allocUsage); err != nil {
+		return err
+	}
+
 	// Check if we should trigger evaluations
 	if structs.ShouldDrainNode(args.Status) ||
 		nodeStatusTransitionRequiresEval(args.Status, node.Status) {
@@ -709,8 +736,12 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		reply.HeartbeatTTL = ttl
 	}
 
-	// Set the reply index and leader
-	reply.Index = index
+	// Set the reply index and leader. Clients take an index as their status
+	// having been updated after a missed heartbeat, so writing the utilization
+	// alone doesn't set it.
+	if statusUpdated {
+		reply.Index = index
+	}
 	n.srv.peerLock.RLock()
 	defer n.srv.peerLock.RUnlock()
 	if err := n.constructNodeServerInfoResponse(node.GetID(), snap, reply); err != nil {
@@ -1319,6 +1350,14 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 		return structs.ErrPermissionDenied
 	}
 
+	if len(args.CompressedAllocs) > 0 {
+		allocs, err := structs.DecodeAllocUpdates(args.CompressedAllocs)
+		if err != nil {
+			return err
+		}
+		args.Alloc, args.CompressedAllocs = allocs, nil
+	}
+
 	// Ensure at least a single alloc
 	if len(args.Alloc) == 0 {
 		return fmt.Errorf("must update at least one allocation")
@@ -1360,6 +1399,7 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 		if alloc == nil {
 			continue
 		}
+		mergeAllocUpdateDelta(allocToUpdate, alloc)
 
 		if !allocToUpdate.TerminalStatus() && alloc.ClientStatus != structs.AllocClientStatusUnknown {
 			continue
@@ -1477,6 +1517,20 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 	return nil
 }
 
+// mergeAllocUpdateDelta fills in the task states a client omitted from an
+// allocation update because they did not change since its last acknowledged
+// update. Clients only omit them once all servers support it, and older
+// clients always send every task state, so the merge is a no-op for them. An
+// omitted usage summary is kept by the state store.
+func mergeAllocUpdateDelta(update, existing *structs.Allocation) {
+	if len(existing.TaskStates) == 0 {
+		return
+	}
+	states := maps.Clone(existing.TaskStates)
+	maps.Copy(states, update.TaskStates)
+	update.TaskStates = states
+}
+
 // batchUpdate is used to update all the allocations
 func (n *Node) batchUpdate(future *structs.BatchFuture, updates []*structs.Allocation, evals []*structs.Evaluation) {
 	var mErr multierror.Error
@@ -1505,6 +1559,7 @@ func (n *Node) batchUpdate(future *structs.BatchFuture, updates []*structs.Alloc
 	batch := &structs.AllocUpdateRequest{
 		Alloc:        updates,
 		Evals:        trimmedEvals,
+		UsageSamples: n.usageSamples(updates),
 		WriteRequest: structs.WriteRequest{Region: n.srv.config.Region},
 	}
 
@@ -2279,3 +2334,230 @@ func (n *Node) EmitEvents(args *structs.EmitNodeEventsRequest, reply *structs.Em
 	reply.Index = index
 	return nil
 }
+
+// usageSamples returns the usage samples to record for the usage summaries of
+// client allocation updates. A summary is only recorded if it was collected at
+// least the usage history interval after the latest sample of its allocation,
+// which bounds the usage history of an allocation raft has to replicate.
+func (n *Node) usageSamples(updates []*structs.Allocation) []*structs.UsageSample {
+	if n.srv.config.UsageHistoryRetention <= 0 {
+		return nil
+	}
+
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		n.logger.Error("failed to snapshot state for usage history", "error", err)
+		return nil
+	}
+
+	var samples []*structs.UsageSample
+	latest := make(map[string]int64)
+	for _, update := range updates {
+		summary := update.UsageSummary
+		if summary == nil {
+			continue
+		}
+
+		last, ok := latest[update.ID]
+		if !ok {
+			sample, err := snap.LatestUsageSample(nil, update.ID)
+			if err != nil {
+				n.logger.Error("looking up usage history for alloc failed", "alloc_id", update.ID, "error", err)
+				continue
+			}
+			if sample != nil {
+				last = sample.Timestamp
+			}
+		}
+		if last != 0 && summary.Timestamp-last < n.srv.config.UsageHistoryInterval.Nanoseconds() {
+			continue
+		}
+
+		alloc, err := snap.AllocByID(nil, update.ID)
+		if err != nil {
+			n.logger.Error("looking up alloc for usage history failed", "alloc_id", update.ID, "error", err)
+			continue
+		}
+		if alloc == nil {
+			continue
+		}
+
+		samples = append(samples, structs.NewUsageSample(alloc, summary))
+		latest[update.ID] = summary.Timestamp
+	}
+	return samples
+}
+
+// updateAllocUsage records the usage summaries a client sent with its
+// heartbeat for the allocations placed on the node. They are applied apart
+// from allocation updates, so usage drift doesn't rewrite the allocations.
+func (n *Node) updateAllocUsage(snap *state.StateSnapshot, nodeID string, usage map[string]*structs.AllocUsageSummary) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	summaries := make(map[string]*structs.AllocUsageSummary, len(usage))
+	updates := make([]*structs.Allocation, 0, len(usage))
+	for allocID, summary := range usage {
+		if summary == nil {
+			continue
+		}
+		alloc, err := snap.AllocByID(nil, allocID)
+		if err != nil {
+			return fmt.Errorf("failed to look up alloc %q: %v", allocID, err)
+		}
+		if alloc == nil || alloc.NodeID != nodeID {
+			continue
+		}
+		summaries[allocID] = summary
+		updates = append(updates, &structs.Allocation{ID: allocID, UsageSummary: summary})
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	req := &structs.AllocUsageUpdateRequest{
+		Summaries:    summaries,
+		Samples:      n.usageSamples(updates),
+		WriteRequest: structs.WriteRequest{Region: n.srv.config.Region},
+	}
+	if _, _, err := n.srv.raftApply(structs.AllocUsageUpdateRequestType, req); err != nil {
+		n.logger.Error("alloc usage update failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// BackfillUsage records the usage summaries a client buffered while it could
+// not reach the servers, so the usage history of its allocations has no gaps
+// for the time it was disconnected.
+func (n *Node) BackfillUsage(args *structs.NodeUsageBackfillRequest, reply *structs.GenericResponse) error {
+	aclObj, err := n.srv.AuthenticateClientOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if err != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := n.srv.forward(structs.NodeBackfillUsageRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "backfill_usage"}, time.Now())
+
+	if !aclObj.AllowClientOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID")
+	}
+	backfills, err := structs.DecodeUsageBackfill(args.Backfill)
+	if err != nil {
+		return err
+	}
+
+	if n.srv.config.UsageHistoryRetention <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-n.srv.config.UsageHistoryRetention).UnixNano()
+
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	var samples []*structs.UsageSample
+	for _, backfill := range backfills {
+		alloc, err := snap.AllocByID(nil, backfill.AllocID)
+		if err != nil {
+			return err
+		}
+
+		// The allocation may have been garbage collected while the client
+		// was disconnected, in which case its usage history is gone too
+		if alloc == nil {
+			continue
+		}
+		if alloc.NodeID != args.NodeID {
+			return fmt.Errorf("allocation %s is not placed on node %s", alloc.ID, args.NodeID)
+		}
+
+		samples = append(samples, n.backfillSamples(alloc, backfill.Summaries, cutoff)...)
+	}
+	if len(samples) > structs.MaxUsageBackfillSamples {
+		return fmt.Errorf("backfill of %d usage samples exceeds maximum of %d",
+			len(samples), structs.MaxUsageBackfillSamples)
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	req := &structs.UsageSamplesUpsertRequest{
+		Samples:      samples,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := n.srv.raftApply(structs.UsageSamplesUpsertRequestType, req)
+	if err != nil {
+		n.logger.Error("backfilling usage samples failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// ReapUsageSamples garbage collects the usage samples of all allocations older
+// than the time of the request. It is only called by the core scheduler.
+func (n *Node) ReapUsageSamples(args *structs.UsageSamplesUpsertRequest, reply *structs.GenericResponse) error {
+	aclObj, err := n.srv.AuthenticateServerOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if err != nil || !aclObj.AllowServerOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := n.srv.forward(structs.NodeReapUsageSamplesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "reap_usage_samples"}, time.Now())
+
+	if len(args.Samples) > 0 {
+		return fmt.Errorf("usage samples can't be recorded when reaping")
+	}
+	if args.PruneBefore <= 0 {
+		return fmt.Errorf("missing time to reap usage samples before")
+	}
+
+	_, index, err := n.srv.raftApply(structs.UsageSamplesUpsertRequestType, args)
+	if err != nil {
+		n.logger.Error("reaping usage samples failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// backfillSamples returns the usage samples to record for the backfilled
+// summaries of an allocation. Summaries collected before the cutoff would be
+// garbage collected right away, and the rest are downsampled to the usage
+// history interval like the summaries of allocation updates.
+func (n *Node) backfillSamples(alloc *structs.Allocation, summaries []*structs.AllocUsageSummary, cutoff int64) []*structs.UsageSample {
+	summaries = slices.DeleteFunc(slices.Clone(summaries), func(summary *structs.AllocUsageSummary) bool {
+		return summary == nil || summary.Timestamp < cutoff
+	})
+	slices.SortFunc(summaries, func(a, b *structs.AllocUsageSummary) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	var samples []*structs.UsageSample
+	var last int64
+	for _, summary := range summaries {
+		if last != 0 && summary.Timestamp-last < n.srv.config.UsageHistoryInterval.Nanoseconds() {
+			continue
+		}
+		sample := structs.NewUsageSample(alloc, summary)
+		sample.Backfilled = true
+		samples = append(samples, sample)
+		last = summary.Timestamp
+	}
+	return samples
+}
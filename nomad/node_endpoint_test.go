@@ -1448,6 +1448,119 @@ func TestClientEndpoint_UpdateStatus_HeartbeatOnly(t *testing.T) {
 	}
 }
 
+// TestClientEndpoint_UpdateStatus_Utilization asserts the live utilization
+// sent with heartbeats is written to the node only if it changed, without
+// creating evals.
+func TestClientEndpoint_UpdateStatus_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	node.Attributes["unique.utilization.cpu.percent"] = "10"
+	node.Attributes["unique.utilization.bucket"] = "low"
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Only the live utilization attributes are written
+	req := &structs.NodeUpdateStatusRequest{
+		NodeID: node.ID,
+		Status: structs.NodeStatusReady,
+		Utilization: map[string]string{
+			"unique.utilization.cpu.percent":    "20",
+			"unique.utilization.memory.percent": "30",
+			"unique.utilization.bucket":         "high",
+			"kernel.name":                       "other",
+		},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+	}
+	var resp2 structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp2))
+	must.NonZero(t, resp2.NodeModifyIndex)
+	must.Zero(t, resp2.Index)
+	must.SliceEmpty(t, resp2.EvalIDs)
+
+	out, err := s1.fsm.State().NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, "20", out.Attributes["unique.utilization.cpu.percent"])
+	must.Eq(t, "30", out.Attributes["unique.utilization.memory.percent"])
+	must.Eq(t, "low", out.Attributes["unique.utilization.bucket"])
+	must.Eq(t, node.Attributes["kernel.name"], out.Attributes["kernel.name"])
+	must.Eq(t, structs.NodeStatusReady, out.Status)
+
+	// Unchanged utilization isn't written again
+	var resp3 structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp3))
+	must.Zero(t, resp3.NodeModifyIndex)
+}
+
+func TestClientEndpoint_UpdateStatus_AllocUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+	must.True(t, resp.UsageHeartbeats)
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	other := mock.Alloc()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 100,
+		[]*structs.Allocation{alloc, other}))
+
+	// Only the usage of the node's allocations is recorded, and heartbeats
+	// carrying usage aren't taken as a status update
+	summary := &structs.AllocUsageSummary{CPU: 250, MemoryMB: 64, Timestamp: time.Now().UnixNano()}
+	req := &structs.NodeUpdateStatusRequest{
+		NodeID: node.ID,
+		Status: structs.NodeStatusReady,
+		AllocUsage: map[string]*structs.AllocUsageSummary{
+			alloc.ID: summary,
+			other.ID: summary,
+		},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+	}
+	var resp2 structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp2))
+	must.Zero(t, resp2.Index)
+	must.True(t, resp2.UsageHeartbeats)
+
+	// The usage summary is swapped without rewriting the allocation
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, summary, out.UsageSummary)
+	must.Eq(t, 100, out.ModifyIndex)
+	must.Eq(t, 100, out.AllocModifyIndex)
+
+	out, err = state.AllocByID(nil, other.ID)
+	must.NoError(t, err)
+	must.Nil(t, out.UsageSummary)
+
+	iter, err := state.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+	iter, err = state.UsageSamplesByAllocID(nil, other.ID)
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+}
+
 func TestClientEndpoint_UpdateStatus_HeartbeatOnly_Advertise(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -3143,6 +3256,115 @@ func TestNode_UpdateAlloc(t *testing.T) {
 	must.Eq(t, 1, foundCount, must.Sprint("Should create exactly one eval for failed allocs"))
 }
 
+// TestNode_UpdateAlloc_Delta asserts the task states and usage summary clients
+// omit from allocation updates because they did not change are kept.
+func TestNode_UpdateAlloc_Delta(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+	must.True(t, resp.AllocUpdateDeltas)
+
+	store := s1.fsm.State()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.TaskStates = map[string]*structs.TaskState{
+		"web":     {State: structs.TaskStateRunning},
+		"sidecar": {State: structs.TaskStateRunning},
+	}
+	alloc.UsageSummary = &structs.AllocUsageSummary{CPU: 250, MemoryMB: 64}
+	must.NoError(t, store.UpsertJobSummary(99, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 100, []*structs.Allocation{alloc}))
+
+	update := &structs.AllocUpdateRequest{
+		Alloc: []*structs.Allocation{{
+			ID:           alloc.ID,
+			NodeID:       node.ID,
+			ClientStatus: structs.AllocClientStatusRunning,
+			TaskStates: map[string]*structs.TaskState{
+				"sidecar": {State: structs.TaskStateRunning, Restarts: 1},
+			},
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+	}
+	var resp2 structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", update, &resp2))
+
+	out, err := store.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.MapLen(t, 2, out.TaskStates)
+	must.Eq(t, structs.TaskStateRunning, out.TaskStates["web"].State)
+	must.Eq(t, 1, out.TaskStates["sidecar"].Restarts)
+	must.Eq(t, alloc.UsageSummary, out.UsageSummary)
+}
+
+// TestNode_UpdateAlloc_Compressed asserts compressed allocation updates are
+// applied like uncompressed ones.
+func TestNode_UpdateAlloc_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	store := s1.fsm.State()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	must.NoError(t, store.UpsertJobSummary(99, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 100, []*structs.Allocation{alloc}))
+
+	// The description makes the update large enough to be compressed
+	description := strings.Repeat("still running ", 500)
+	compressed, err := structs.EncodeAllocUpdates([]*structs.Allocation{{
+		ID:                alloc.ID,
+		NodeID:            node.ID,
+		ClientStatus:      structs.AllocClientStatusRunning,
+		ClientDescription: description,
+	}})
+	must.NoError(t, err)
+	must.NotNil(t, compressed)
+
+	update := &structs.AllocUpdateRequest{
+		CompressedAllocs: compressed,
+		WriteRequest:     structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+	}
+	var resp2 structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", update, &resp2))
+
+	out, err := store.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.AllocClientStatusRunning, out.ClientStatus)
+	must.Eq(t, description, out.ClientDescription)
+
+	// Corrupt payloads are rejected
+	update.CompressedAllocs = []byte("not gzip")
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", update, &resp2)
+	must.ErrorContains(t, err, "invalid allocation updates")
+}
+
 func TestNode_UpdateAlloc_NodeNotReady(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3177,7 +3399,7 @@ func TestNode_UpdateAlloc_NodeNotReady(t *testing.T) {
 
 	// Mark node as down.
 	must.NoError(t, store.UpdateNodeStatus(
-		structs.MsgTypeTestSetup, 101, node.ID, structs.NodeStatusDown, time.Now().UnixNano(), nil))
+		structs.MsgTypeTestSetup, 101, node.ID, structs.NodeStatusDown, time.Now().UnixNano(), nil, nil))
 
 	// Try to update alloc.
 	updatedAlloc := new(structs.Allocation)
@@ -3209,7 +3431,7 @@ func TestNode_UpdateAlloc_NodeNotReady(t *testing.T) {
 
 	// Mark node as ready and try again.
 	must.NoError(t, store.UpdateNodeStatus(
-		structs.MsgTypeTestSetup, 102, node.ID, structs.NodeStatusReady, time.Now().UnixNano(), nil))
+		structs.MsgTypeTestSetup, 102, node.ID, structs.NodeStatusReady, time.Now().UnixNano(), nil, nil))
 
 	updatedAlloc.NodeID = node.ID
 	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", allocUpdateReq, &allocUpdateResp))
@@ -4124,7 +4346,7 @@ func TestClientEndpoint_ListNodes_Blocking(t *testing.T) {
 
 	// Node status update triggers watches
 	time.AfterFunc(100*time.Millisecond, func() {
-		errCh <- state.UpdateNodeStatus(structs.MsgTypeTestSetup, 40, node.ID, structs.NodeStatusDown, 0, nil)
+		errCh <- state.UpdateNodeStatus(structs.MsgTypeTestSetup, 40, node.ID, structs.NodeStatusDown, 0, nil, nil)
 	})
 
 	req.MinQueryIndex = 38
@@ -4629,6 +4851,136 @@ func TestClientEndpoint_EmitEvents(t *testing.T) {
 	require.False(len(out.Events) < 2)
 }
 
+func TestClientEndpoint_BackfillUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 20, []*structs.Allocation{alloc}))
+
+	now := time.Now()
+	summary := func(cpu int, ago time.Duration) *structs.AllocUsageSummary {
+		return &structs.AllocUsageSummary{CPU: cpu, MemoryMB: 64, Timestamp: now.Add(-ago).UnixNano()}
+	}
+	backfill := func(backfills ...*structs.AllocUsageBackfill) error {
+		payload, err := structs.EncodeUsageBackfill(backfills)
+		must.NoError(t, err)
+		req := &structs.NodeUsageBackfillRequest{
+			NodeID:       node.ID,
+			Backfill:     payload,
+			WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, structs.NodeBackfillUsageRPCMethod, req, &resp)
+	}
+
+	// Summaries of garbage collected allocations are skipped, as are
+	// summaries past the retention and within the interval of a recorded one
+	must.NoError(t, backfill(
+		&structs.AllocUsageBackfill{
+			AllocID: alloc.ID,
+			Summaries: []*structs.AllocUsageSummary{
+				summary(200, time.Hour),
+				summary(100, 2*time.Hour),
+				summary(150, 2*time.Hour-5*time.Minute),
+				summary(50, 8*24*time.Hour),
+			},
+		},
+		&structs.AllocUsageBackfill{
+			AllocID:   uuid.Generate(),
+			Summaries: []*structs.AllocUsageSummary{summary(300, time.Hour)},
+		},
+	))
+
+	iter, err := state.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+	must.Len(t, 2, samples)
+	must.Eq(t, 100, samples[0].CPU)
+	must.Eq(t, 200, samples[1].CPU)
+	must.True(t, samples[0].Backfilled)
+	must.Eq(t, alloc.JobID, samples[0].JobID)
+
+	// A node can't backfill the usage of allocations of other nodes
+	other := mock.Alloc()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{other}))
+	err = backfill(&structs.AllocUsageBackfill{
+		AllocID:   other.ID,
+		Summaries: []*structs.AllocUsageSummary{summary(100, time.Hour)},
+	})
+	must.ErrorContains(t, err, "is not placed on node")
+}
+
+// TestClientEndpoint_UpdateAlloc_UsageHistory asserts the leader records the
+// usage summaries of allocation updates at most once per usage history
+// interval.
+func TestClientEndpoint_UpdateAlloc_UsageHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.UsageHistoryInterval = time.Minute
+	})
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	must.NoError(t, state.UpsertJobSummary(15, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 20, []*structs.Allocation{alloc}))
+
+	start := time.Now().Add(-time.Hour)
+	update := func(cpu int, at time.Duration) {
+		t.Helper()
+		req := &structs.AllocUpdateRequest{
+			Alloc: []*structs.Allocation{{
+				ID:           alloc.ID,
+				NodeID:       node.ID,
+				ClientStatus: structs.AllocClientStatusRunning,
+				UsageSummary: &structs.AllocUsageSummary{
+					CPU:       cpu,
+					MemoryMB:  64,
+					Timestamp: start.Add(at).UnixNano(),
+				},
+			}},
+			WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+		}
+		var resp structs.GenericResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", req, &resp))
+	}
+
+	update(100, 0)
+	update(200, 30*time.Second)
+	update(300, time.Minute)
+
+	iter, err := state.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+	must.Len(t, 2, samples)
+	must.Eq(t, 100, samples[0].CPU)
+	must.Eq(t, 300, samples[1].CPU)
+	must.Eq(t, alloc.JobID, samples[1].JobID)
+	must.False(t, samples[1].Backfilled)
+}
+
 func TestClientEndpoint_ShouldCreateNodeEval(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4642,6 +4994,16 @@ func TestClientEndpoint_ShouldCreateNodeEval(t *testing.T) {
 		require.False(t, shouldCreateNodeEval(n1, n2))
 	})
 
+	t.Run("live utilization changes don't require eval", func(t *testing.T) {
+		n1 := mock.Node()
+		n1.Attributes["unique.utilization.cpu.percent"] = "10"
+		n2 := n1.Copy()
+		n2.Attributes["unique.utilization.cpu.percent"] = "90"
+		n2.Attributes["unique.utilization.memory.percent"] = "40"
+
+		require.False(t, shouldCreateNodeEval(n1, n2))
+	})
+
 	positiveCases := []struct {
 		name     string
 		updateFn func(n *structs.Node)
@@ -4654,6 +5016,10 @@ func TestClientEndpoint_ShouldCreateNodeEval(t *testing.T) {
 			"attribute change",
 			func(n *structs.Node) { n.Attributes["test.attribute"] = "something" },
 		},
+		{
+			"utilization bucket change",
+			func(n *structs.Node) { n.Attributes["unique.utilization.bucket"] = "high" },
+		},
 		{
 			"meta change",
 			func(n *structs.Node) { n.Meta["test.meta"] = "something" },
@@ -107,6 +107,10 @@ type Server struct {
 
 	logger log.InterceptLogger
 
+	// auditLogger records the accesses to the endpoints exposing what the
+	// tasks or the agents run that the server handles
+	auditLogger log.Logger
+
 	// Connection pool to other Nomad servers
 	connPool *pool.ConnPool
 
@@ -365,6 +369,10 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		lockTTLTimer:            lock.NewTTLTimer(),
 		lockDelayTimer:          lock.NewDelayTimer(),
 	}
+	s.auditLogger = logger.Named("audit")
+	if config.AuditLogger != nil {
+		s.auditLogger = config.AuditLogger.Named("nomad").Named("audit")
+	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
 	s.shutdownCh = s.shutdownCtx.Done()
@@ -1324,6 +1332,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	// These endpoints are client RPCs and don't include a connection context
 	_ = server.Register(NewClientStatsEndpoint(s))
 	_ = server.Register(newNodeMetaEndpoint(s))
+	_ = server.Register(newExecutorsEndpoint(s))
 
 	// These endpoints have their streaming component registered in
 	// setupStreamingEndpoints, but their non-streaming RPCs are registered
@@ -1378,15 +1387,16 @@ func (s *Server) setupRaft() error {
 
 	// Create the FSM
 	fsmConfig := &FSMConfig{
-		EvalBroker:         s.evalBroker,
-		Periodic:           s.periodicDispatcher,
-		Blocked:            s.blockedEvals,
-		Encrypter:          s.encrypter,
-		Logger:             s.logger,
-		Region:             s.Region(),
-		EnableEventBroker:  s.config.EnableEventBroker,
-		EventBufferSize:    s.config.EventBufferSize,
-		JobTrackedVersions: s.config.JobTrackedVersions,
+		EvalBroker:             s.evalBroker,
+		Periodic:               s.periodicDispatcher,
+		Blocked:                s.blockedEvals,
+		Encrypter:              s.encrypter,
+		Logger:                 s.logger,
+		Region:                 s.Region(),
+		EnableEventBroker:      s.config.EnableEventBroker,
+		EventBufferSize:        s.config.EventBufferSize,
+		JobTrackedVersions:     s.config.JobTrackedVersions,
+		BillingRecordRetention: s.config.BillingRecordRetention,
 	}
 
 	var err error
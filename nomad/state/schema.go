@@ -26,6 +26,8 @@ const (
 	TableACLBindingRules      = "acl_binding_rules"
 	TableAllocs               = "allocs"
 	TableJobSubmission        = "job_submission"
+	TableUsageSamples         = "usage_samples"
+	TableBillingRecords       = "billing_records"
 )
 
 const (
@@ -41,6 +43,8 @@ const (
 	indexName          = "name"
 	indexSigningKey    = "signing_key"
 	indexAuthMethod    = "auth_method"
+	indexTimestamp     = "timestamp"
+	indexEndTime       = "end_time"
 )
 
 var (
@@ -97,6 +101,8 @@ func init() {
 		aclRolesTableSchema,
 		aclAuthMethodsTableSchema,
 		bindingRulesTableSchema,
+		usageSamplesTableSchema,
+		billingRecordsTableSchema,
 	}...)
 }
 
@@ -1643,3 +1649,107 @@ func bindingRulesTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// usageSamplesTableSchema returns the MemDB schema for the usage history of
+// allocations.
+func usageSamplesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableUsageSamples,
+		Indexes: map[string]*memdb.IndexSchema{
+			// An allocation has at most one sample per timestamp, so a
+			// backfilled sample the servers already recorded is not
+			// duplicated. The timestamp orders the samples of an allocation.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "AllocID",
+						},
+						&memdb.IntFieldIndex{
+							Field: "Timestamp",
+						},
+					},
+				},
+			},
+			indexJob: {
+				Name:         indexJob,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+			indexAllocID: {
+				Name:         indexAllocID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AllocID",
+				},
+			},
+			// The timestamp index orders samples by age for pruning them.
+			indexTimestamp: {
+				Name:         indexTimestamp,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.IntFieldIndex{
+					Field: "Timestamp",
+				},
+			},
+		},
+	}
+}
+
+// billingRecordsTableSchema returns the MemDB schema for the billing records
+// of terminated allocations.
+func billingRecordsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableBillingRecords,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AllocID",
+				},
+			},
+			// The job index also serves listing the records of a namespace,
+			// with a prefix query of the namespace alone.
+			indexJob: {
+				Name:         indexJob,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+			// The end time index orders records by age for pruning them.
+			indexEndTime: {
+				Name:         indexEndTime,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.IntFieldIndex{
+					Field: "EndTime",
+				},
+			},
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"sort"
@@ -90,6 +91,11 @@ type StateStoreConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// BillingRecordRetention is how long the billing records of allocations
+	// are kept after they terminated. Zero keeps them for
+	// structs.DefaultBillingRecordRetention.
+	BillingRecordRetention time.Duration
 }
 
 func (c *StateStoreConfig) Validate() error {
@@ -1097,18 +1103,18 @@ func deleteNodeTxn(txn *txn, index uint64, nodes []string) error {
 }
 
 // UpdateNodeStatus is used to update the status of a node
-func (s *StateStore) UpdateNodeStatus(msgType structs.MessageType, index uint64, nodeID, status string, updatedAt int64, event *structs.NodeEvent) error {
+func (s *StateStore) UpdateNodeStatus(msgType structs.MessageType, index uint64, nodeID, status string, updatedAt int64, event *structs.NodeEvent, utilization map[string]string) error {
 	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
 
-	if err := s.updateNodeStatusTxn(txn, nodeID, status, updatedAt, event); err != nil {
+	if err := s.updateNodeStatusTxn(txn, nodeID, status, updatedAt, event, utilization); err != nil {
 		return err
 	}
 
 	return txn.Commit()
 }
 
-func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, updatedAt int64, event *structs.NodeEvent) error {
+func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, updatedAt int64, event *structs.NodeEvent, utilization map[string]string) error {
 
 	// Lookup the node
 	existing, err := txn.First("nodes", "id", nodeID)
@@ -1129,6 +1135,19 @@ func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, update
 		appendNodeEvents(txn.Index, copyNode, []*structs.NodeEvent{event})
 	}
 
+	// Replace the live utilization attributes if they were sent
+	if utilization != nil {
+		maps.DeleteFunc(copyNode.Attributes, func(name, _ string) bool {
+			return structs.IsLiveUtilizationAttr(name)
+		})
+		if copyNode.Attributes == nil {
+			copyNode.Attributes = make(map[string]string, len(utilization))
+		}
+		for name, val := range structs.LiveUtilizationAttrs(utilization) {
+			copyNode.Attributes[name] = val
+		}
+	}
+
 	// Update the status in the copy
 	copyNode.Status = status
 	copyNode.ModifyIndex = txn.Index
@@ -3708,6 +3727,9 @@ func (s *StateStore) DeleteEval(index uint64, evals, allocs []string, userInitia
 		if err := s.deleteServiceRegistrationByAllocIDTxn(txn, index, alloc); err != nil {
 			return fmt.Errorf("service registration delete for alloc failed: %v", err)
 		}
+		if err := s.deleteUsageSamplesByAllocIDTxn(txn, index, alloc); err != nil {
+			return err
+		}
 	}
 
 	// Update the indexes
@@ -3991,6 +4013,9 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 	copyAlloc.ClientDescription = alloc.ClientDescription
 	copyAlloc.TaskStates = alloc.TaskStates
 	copyAlloc.NetworkStatus = alloc.NetworkStatus
+	if alloc.UsageSummary != nil {
+		copyAlloc.UsageSummary = alloc.UsageSummary
+	}
 
 	// The client can only set its deployment health and timestamp, so just take
 	// those
@@ -4034,6 +4059,10 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 		return err
 	}
 
+	if err := s.recordBillingTxn(txn, index, exist, alloc); err != nil {
+		return err
+	}
+
 	// Update the allocation
 	if err := txn.Insert("allocs", copyAlloc); err != nil {
 		return fmt.Errorf("alloc insert failed: %v", err)
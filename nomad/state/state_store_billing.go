@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// recordBillingTxn stores the billing record of a terminal allocation update
+// from a client, in an existing transaction. The allocation fields of the
+// record come from the existing allocation, so a client can only report the
+// usage of its allocations. Records that ended before the retention period
+// are pruned, relative to the time of the update.
+func (s *StateStore) recordBillingTxn(txn *txn, index uint64, exist, alloc *structs.Allocation) error {
+	if alloc.BillingRecord == nil || !alloc.ClientTerminalStatus() {
+		return nil
+	}
+
+	record := alloc.BillingRecord.Copy()
+	record.AllocID = exist.ID
+	record.Namespace = exist.Namespace
+	record.JobID = exist.JobID
+	record.TaskGroup = exist.TaskGroup
+	record.NodeID = exist.NodeID
+	record.ClientStatus = alloc.ClientStatus
+	record.CreateIndex = index
+	record.ModifyIndex = index
+
+	existing, err := txn.First(TableBillingRecords, indexID, exist.ID)
+	if err != nil {
+		return fmt.Errorf("billing record lookup failed: %v", err)
+	}
+	if existing != nil {
+		record.CreateIndex = existing.(*structs.AllocBillingRecord).CreateIndex
+	}
+
+	if err := txn.Insert(TableBillingRecords, record); err != nil {
+		return fmt.Errorf("billing record insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableBillingRecords, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	retention := s.config.BillingRecordRetention
+	if retention <= 0 {
+		retention = structs.DefaultBillingRecordRetention
+	}
+	return s.pruneBillingRecordsTxn(txn, index, alloc.ModifyTime-retention.Nanoseconds())
+}
+
+// pruneBillingRecordsTxn removes the billing records that ended before the
+// given time, in an existing transaction.
+func (s *StateStore) pruneBillingRecordsTxn(txn *txn, index uint64, before int64) error {
+	iter, err := txn.Get(TableBillingRecords, indexEndTime)
+	if err != nil {
+		return fmt.Errorf("billing record lookup failed: %v", err)
+	}
+
+	var expired []*structs.AllocBillingRecord
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		record := raw.(*structs.AllocBillingRecord)
+		if record.EndTime >= before {
+			break
+		}
+		expired = append(expired, record)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, record := range expired {
+		if err := txn.Delete(TableBillingRecords, record); err != nil {
+			return fmt.Errorf("billing record delete failed: %v", err)
+		}
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableBillingRecords, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// BillingRecordByAllocID returns the billing record of an allocation, or nil
+// if its client did not report one.
+func (s *StateStore) BillingRecordByAllocID(ws memdb.WatchSet, allocID string) (*structs.AllocBillingRecord, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableBillingRecords, indexID, allocID)
+	if err != nil {
+		return nil, fmt.Errorf("billing record lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.AllocBillingRecord), nil
+	}
+	return nil, nil
+}
+
+// BillingRecordsByJob returns an iterator over the billing records of the
+// allocations of a job.
+func (s *StateStore) BillingRecordsByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableBillingRecords, indexJob, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("billing record lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// BillingRecordsByNamespace returns an iterator over the billing records of
+// the allocations of a namespace, ordered by job.
+func (s *StateStore) BillingRecordsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableBillingRecords, indexJob+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("billing record lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// BillingRecords returns an iterator over all the billing records of the
+// state store.
+func (s *StateStore) BillingRecords(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableBillingRecords, indexJob)
+	if err != nil {
+		return nil, fmt.Errorf("billing record lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_UpdateAllocsFromClient_BillingRecord(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	node := mock.Node()
+	must.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+
+	now := time.Now()
+	old := mock.Alloc()
+	old.NodeID = node.ID
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.JobID = old.JobID
+	alloc.Job = old.Job
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 20, nil, alloc.Job))
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 30, []*structs.Allocation{old, alloc}))
+
+	update := func(index uint64, id, status string, at time.Time, record *structs.AllocBillingRecord) {
+		t.Helper()
+		must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index, []*structs.Allocation{{
+			ID:            id,
+			NodeID:        node.ID,
+			ClientStatus:  status,
+			ModifyTime:    at.UnixNano(),
+			BillingRecord: record,
+		}}))
+	}
+
+	// Records ended before the retention period are pruned
+	oldEnd := now.Add(-structs.DefaultBillingRecordRetention - time.Hour)
+	update(40, old.ID, structs.AllocClientStatusComplete, oldEnd,
+		&structs.AllocBillingRecord{EndTime: oldEnd.UnixNano(), CPUSeconds: 1})
+	record, err := testState.BillingRecordByAllocID(nil, old.ID)
+	must.NoError(t, err)
+	must.NotNil(t, record)
+
+	// A record of an allocation that isn't terminal is ignored
+	update(50, alloc.ID, structs.AllocClientStatusRunning, now,
+		&structs.AllocBillingRecord{EndTime: now.UnixNano(), CPUSeconds: 10})
+	record, err = testState.BillingRecordByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Nil(t, record)
+
+	// The allocation fields of the record come from the allocation
+	update(60, alloc.ID, structs.AllocClientStatusFailed, now,
+		&structs.AllocBillingRecord{JobID: "other", EndTime: now.UnixNano(), CPUSeconds: 20})
+	record, err = testState.BillingRecordByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.NotNil(t, record)
+	must.Eq(t, alloc.JobID, record.JobID)
+	must.Eq(t, alloc.Namespace, record.Namespace)
+	must.Eq(t, alloc.TaskGroup, record.TaskGroup)
+	must.Eq(t, node.ID, record.NodeID)
+	must.Eq(t, structs.AllocClientStatusFailed, record.ClientStatus)
+	must.Eq(t, 20, record.CPUSeconds)
+	must.Eq(t, 60, record.CreateIndex)
+
+	record, err = testState.BillingRecordByAllocID(nil, old.ID)
+	must.NoError(t, err)
+	must.Nil(t, record)
+
+	// The record is not stored on the allocation, and outlives it
+	out, err := testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Nil(t, out.BillingRecord)
+	must.NoError(t, testState.DeleteEval(70, nil, []string{alloc.ID}, false))
+
+	iter, err := testState.BillingRecordsByNamespace(nil, alloc.Namespace)
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+	must.Nil(t, iter.Next())
+
+	iter, err = testState.BillingRecordsByJob(nil, alloc.Namespace, "other")
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+}
+
+func TestStateStore_UpdateAllocsFromClient_BillingRecordRetention(t *testing.T) {
+	ci.Parallel(t)
+	testState := TestStateStoreCfg(t, &StateStoreConfig{
+		Logger:                 testlog.HCLogger(t),
+		Region:                 "global",
+		JobTrackedVersions:     structs.JobDefaultTrackedVersions,
+		BillingRecordRetention: 24 * time.Hour,
+	})
+
+	node := mock.Node()
+	must.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+
+	now := time.Now()
+	old := mock.Alloc()
+	old.NodeID = node.ID
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 20, []*structs.Allocation{old, alloc}))
+
+	oldEnd := now.Add(-48 * time.Hour)
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 30, []*structs.Allocation{{
+		ID:            old.ID,
+		NodeID:        node.ID,
+		ClientStatus:  structs.AllocClientStatusComplete,
+		ModifyTime:    oldEnd.UnixNano(),
+		BillingRecord: &structs.AllocBillingRecord{EndTime: oldEnd.UnixNano()},
+	}}))
+
+	// Records are pruned after the configured retention rather than the
+	// default
+	must.NoError(t, testState.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 40, []*structs.Allocation{{
+		ID:            alloc.ID,
+		NodeID:        node.ID,
+		ClientStatus:  structs.AllocClientStatusComplete,
+		ModifyTime:    now.UnixNano(),
+		BillingRecord: &structs.AllocBillingRecord{EndTime: now.UnixNano()},
+	}}))
+	record, err := testState.BillingRecordByAllocID(nil, old.ID)
+	must.NoError(t, err)
+	must.Nil(t, record)
+	record, err = testState.BillingRecordByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.NotNil(t, record)
+}
@@ -291,3 +291,21 @@ func (r *StateRestore) JobSubmissionRestore(jobSubmission *structs.JobSubmission
 	}
 	return nil
 }
+
+// UsageSampleRestore is used to restore a single usage sample into the
+// usage_samples table.
+func (r *StateRestore) UsageSampleRestore(sample *structs.UsageSample) error {
+	if err := r.txn.Insert(TableUsageSamples, sample); err != nil {
+		return fmt.Errorf("usage sample insert failed: %v", err)
+	}
+	return nil
+}
+
+// BillingRecordRestore is used to restore a single billing record into the
+// billing_records table.
+func (r *StateRestore) BillingRecordRestore(record *structs.AllocBillingRecord) error {
+	if err := r.txn.Insert(TableBillingRecords, record); err != nil {
+		return fmt.Errorf("billing record insert failed: %v", err)
+	}
+	return nil
+}
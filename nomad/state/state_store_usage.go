@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertUsageSamples records usage samples of allocations. Samples the state
+// store already has for the same allocation and timestamp are skipped, and the
+// samples of all allocations older than pruneBefore are removed.
+func (s *StateStore) UpsertUsageSamples(
+	msgType structs.MessageType, index uint64, samples []*structs.UsageSample, pruneBefore int64) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	if err := s.upsertUsageSamplesTxn(txn, index, samples); err != nil {
+		return err
+	}
+	if err := s.pruneUsageSamplesTxn(txn, index, pruneBefore); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+// UpdateAllocsUsage replaces the usage summary of allocations and records
+// their usage samples. Unlike client allocation updates, it leaves the modify
+// indexes, job summaries and deployments of the allocations untouched, since
+// usage changes with every report. Summaries of allocations that no longer
+// exist, or that are older than the allocation's, are skipped.
+func (s *StateStore) UpdateAllocsUsage(msgType structs.MessageType, index uint64,
+	summaries map[string]*structs.AllocUsageSummary, samples []*structs.UsageSample) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for allocID, summary := range summaries {
+		raw, err := txn.First("allocs", "id", allocID)
+		if err != nil {
+			return fmt.Errorf("alloc lookup failed: %v", err)
+		}
+		if raw == nil || summary == nil {
+			continue
+		}
+		alloc := raw.(*structs.Allocation)
+		if alloc.UsageSummary != nil && alloc.UsageSummary.Timestamp >= summary.Timestamp {
+			continue
+		}
+
+		copyAlloc := alloc.CopySkipJob()
+		copyAlloc.UsageSummary = summary
+		if err := txn.Insert("allocs", copyAlloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+
+	if err := s.upsertUsageSamplesTxn(txn, index, samples); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+// upsertUsageSamplesTxn records usage samples in an existing transaction.
+func (s *StateStore) upsertUsageSamplesTxn(txn *txn, index uint64, samples []*structs.UsageSample) error {
+	for _, sample := range samples {
+		if err := s.upsertUsageSampleTxn(txn, index, sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertUsageSampleTxn records a usage sample in an existing transaction,
+// unless there is already one for its allocation and timestamp.
+func (s *StateStore) upsertUsageSampleTxn(txn *txn, index uint64, sample *structs.UsageSample) error {
+	existing, err := txn.First(TableUsageSamples, indexID, sample.AllocID, sample.Timestamp)
+	if err != nil {
+		return fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	sample.CreateIndex = index
+	if err := txn.Insert(TableUsageSamples, sample); err != nil {
+		return fmt.Errorf("usage sample insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableUsageSamples, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// pruneUsageSamplesTxn removes the samples of all allocations older than
+// before, in an existing transaction.
+func (s *StateStore) pruneUsageSamplesTxn(txn *txn, index uint64, before int64) error {
+	if before <= 0 {
+		return nil
+	}
+
+	iter, err := txn.Get(TableUsageSamples, indexTimestamp)
+	if err != nil {
+		return fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+
+	var expired []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sample := raw.(*structs.UsageSample)
+		if sample.Timestamp >= before {
+			break
+		}
+		expired = append(expired, sample)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, sample := range expired {
+		if err := txn.Delete(TableUsageSamples, sample); err != nil {
+			return fmt.Errorf("usage sample delete failed: %v", err)
+		}
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableUsageSamples, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// deleteUsageSamplesByAllocIDTxn deletes the usage history of an allocation,
+// in an existing transaction.
+func (s *StateStore) deleteUsageSamplesByAllocIDTxn(txn *txn, index uint64, allocID string) error {
+	num, err := txn.DeleteAll(TableUsageSamples, indexAllocID, allocID)
+	if err != nil {
+		return fmt.Errorf("usage sample delete failed: %v", err)
+	}
+	if num == 0 {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableUsageSamples, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// UsageSamplesByJob returns an iterator over the usage samples of the
+// allocations of a job, ordered by allocation ID.
+func (s *StateStore) UsageSamplesByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableUsageSamples, indexJob, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UsageSamplesByAllocID returns an iterator over the usage samples of an
+// allocation, ordered by timestamp.
+func (s *StateStore) UsageSamplesByAllocID(ws memdb.WatchSet, allocID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableUsageSamples, indexID+"_prefix", allocID)
+	if err != nil {
+		return nil, fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// LatestUsageSample returns the most recent usage sample of an allocation, or
+// nil if it has none.
+func (s *StateStore) LatestUsageSample(ws memdb.WatchSet, allocID string) (*structs.UsageSample, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.LastWatch(TableUsageSamples, indexID+"_prefix", allocID)
+	if err != nil {
+		return nil, fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.UsageSample), nil
+	}
+	return nil, nil
+}
+
+// OldestUsageSample returns the oldest usage sample of all allocations, or nil
+// if there are none.
+func (s *StateStore) OldestUsageSample(ws memdb.WatchSet) (*structs.UsageSample, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableUsageSamples, indexTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.UsageSample), nil
+	}
+	return nil, nil
+}
+
+// UsageSamples returns an iterator over all the usage samples of the state
+// store.
+func (s *StateStore) UsageSamples(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableUsageSamples, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("usage sample lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
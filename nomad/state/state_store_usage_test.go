@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func usageSamples(t *testing.T, iter memdb.ResultIterator) []*structs.UsageSample {
+	t.Helper()
+	var samples []*structs.UsageSample
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		samples = append(samples, raw.(*structs.UsageSample))
+	}
+	return samples
+}
+
+func TestStateStore_UpdateAllocsUsage(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	alloc := mock.Alloc()
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 10, []*structs.Allocation{alloc}))
+
+	now := time.Now()
+	summary := &structs.AllocUsageSummary{CPU: 200, MemoryMB: 64, Timestamp: now.UnixNano()}
+	sample := structs.NewUsageSample(alloc, summary)
+
+	// Summaries of unknown allocations are skipped
+	must.NoError(t, testState.UpdateAllocsUsage(structs.MsgTypeTestSetup, 20,
+		map[string]*structs.AllocUsageSummary{alloc.ID: summary, uuid.Generate(): summary},
+		[]*structs.UsageSample{sample}))
+
+	// Only the usage summary changes, not the indexes of the allocation
+	out, err := testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, summary, out.UsageSummary)
+	must.Eq(t, 10, out.ModifyIndex)
+	must.Eq(t, 10, out.AllocModifyIndex)
+	must.Eq(t, alloc.ModifyTime, out.ModifyTime)
+
+	iter, err := testState.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, usageSamples(t, iter))
+
+	// A summary older than the recorded one is skipped
+	older := &structs.AllocUsageSummary{CPU: 100, Timestamp: now.Add(-time.Minute).UnixNano()}
+	must.NoError(t, testState.UpdateAllocsUsage(structs.MsgTypeTestSetup, 30,
+		map[string]*structs.AllocUsageSummary{alloc.ID: older}, nil))
+	out, err = testState.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, 200, out.UsageSummary.CPU)
+}
+
+func TestStateStore_UpsertUsageSamples(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	alloc := mock.Alloc()
+	now := time.Now()
+	sample := func(ts time.Time, cpu int) *structs.UsageSample {
+		return structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+			CPU:       cpu,
+			MemoryMB:  64,
+			Timestamp: ts.UnixNano(),
+		})
+	}
+
+	old := sample(now.Add(-2*time.Hour), 100)
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 10,
+		[]*structs.UsageSample{old, sample(now.Add(-time.Minute), 200)}, 0))
+
+	index, err := testState.Index(TableUsageSamples)
+	must.NoError(t, err)
+	must.Eq(t, 10, index)
+
+	// A sample already recorded for the timestamp is not duplicated, and
+	// samples before the pruning time are removed
+	dup := sample(now.Add(-time.Minute), 999)
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 20,
+		[]*structs.UsageSample{dup, sample(now, 300)}, now.Add(-time.Hour).UnixNano()))
+
+	iter, err := testState.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	samples := usageSamples(t, iter)
+	must.Len(t, 2, samples)
+	must.Eq(t, 200, samples[0].CPU)
+	must.Eq(t, 10, samples[0].CreateIndex)
+	must.Eq(t, 300, samples[1].CPU)
+	must.Eq(t, 20, samples[1].CreateIndex)
+
+	iter, err = testState.UsageSamplesByJob(nil, alloc.Namespace, alloc.JobID)
+	must.NoError(t, err)
+	must.Len(t, 2, usageSamples(t, iter))
+}
+
+func TestStateStore_UpsertUsageSamples_Prune(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	now := time.Now()
+	sample := func(alloc *structs.Allocation, ago time.Duration) *structs.UsageSample {
+		return structs.NewUsageSample(alloc, &structs.AllocUsageSummary{
+			CPU:       100,
+			MemoryMB:  64,
+			Timestamp: now.Add(-ago).UnixNano(),
+		})
+	}
+
+	// Samples are pruned across allocations, including allocations that no
+	// longer report usage
+	alloc1, alloc2 := mock.Alloc(), mock.Alloc()
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 10, []*structs.UsageSample{
+		sample(alloc1, 3*time.Hour),
+		sample(alloc1, time.Minute),
+		sample(alloc2, 2*time.Hour),
+	}, 0))
+
+	oldest, err := testState.OldestUsageSample(nil)
+	must.NoError(t, err)
+	must.Eq(t, alloc1.ID, oldest.AllocID)
+
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 20, nil,
+		now.Add(-time.Hour).UnixNano()))
+
+	iter, err := testState.UsageSamples(nil)
+	must.NoError(t, err)
+	samples := usageSamples(t, iter)
+	must.Len(t, 1, samples)
+	must.Eq(t, alloc1.ID, samples[0].AllocID)
+
+	latest, err := testState.LatestUsageSample(nil, alloc1.ID)
+	must.NoError(t, err)
+	must.Eq(t, samples[0], latest)
+
+	latest, err = testState.LatestUsageSample(nil, alloc2.ID)
+	must.NoError(t, err)
+	must.Nil(t, latest)
+
+	index, err := testState.Index(TableUsageSamples)
+	must.NoError(t, err)
+	must.Eq(t, 20, index)
+}
+
+func TestStateStore_DeleteEval_UsageSamples(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	alloc := mock.Alloc()
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, alloc.Job))
+	must.NoError(t, testState.UpsertAllocs(structs.MsgTypeTestSetup, 20, []*structs.Allocation{alloc}))
+	must.NoError(t, testState.UpsertUsageSamples(structs.MsgTypeTestSetup, 30, []*structs.UsageSample{
+		structs.NewUsageSample(alloc, &structs.AllocUsageSummary{CPU: 100, Timestamp: time.Now().UnixNano()}),
+	}, 0))
+
+	// Garbage collecting the allocation removes its usage history
+	must.NoError(t, testState.DeleteEval(40, nil, []string{alloc.ID}, false))
+	iter, err := testState.UsageSamplesByAllocID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Len(t, 0, usageSamples(t, iter))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+)
+
+const (
+	// allocUpdateCompressionMinSize is the encoded size of a batch of
+	// allocation updates below which clients don't compress it, since the
+	// gzip header and the time spent compressing don't pay off
+	allocUpdateCompressionMinSize = 4 * 1024
+
+	// maxAllocUpdateSize bounds the decompressed size of the allocation
+	// updates of a request, well above the size of the largest batch a
+	// client sends
+	maxAllocUpdateSize = 64 * 1024 * 1024
+)
+
+// EncodeAllocUpdates compresses allocation updates for the CompressedAllocs
+// of an AllocUpdateRequest. Allocation updates are dominated by task states
+// and usage summaries whose field names repeat across allocations, so they
+// compress well. It returns nil if the updates are too small to be worth
+// compressing.
+func EncodeAllocUpdates(allocs []*Allocation) ([]byte, error) {
+	var raw bytes.Buffer
+	if err := codec.NewEncoder(&raw, MsgpackHandle).Encode(allocs); err != nil {
+		return nil, err
+	}
+	if raw.Len() < allocUpdateCompressionMinSize {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeAllocUpdates decompresses the CompressedAllocs of an
+// AllocUpdateRequest.
+func DecodeAllocUpdates(data []byte) ([]*Allocation, error) {
+	raw, err := gunzipLimited(data, maxAllocUpdateSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allocation updates: %w", err)
+	}
+
+	var allocs []*Allocation
+	if err := codec.NewDecoderBytes(raw, MsgpackHandle).Decode(&allocs); err != nil {
+		return nil, fmt.Errorf("invalid allocation updates: %w", err)
+	}
+	return allocs, nil
+}
+
+// gunzipLimited decompresses gzip compressed data, failing if it
+// decompresses to more than limit bytes so that a corrupt or malicious
+// payload can't exhaust the memory of the servers.
+func gunzipLimited(data []byte, limit int64) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	// Read one byte past the limit to tell a payload at the limit from one
+	// exceeding it
+	raw, err := io.ReadAll(io.LimitReader(zr, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, errors.New("payload exceeds maximum size")
+	}
+	return raw, nil
+}
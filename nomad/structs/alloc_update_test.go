@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocUpdates_EncodeDecode(t *testing.T) {
+	ci.Parallel(t)
+
+	update := func(i int) *Allocation {
+		return &Allocation{
+			ID:           fmt.Sprintf("alloc-%d", i),
+			NodeID:       "node",
+			ClientStatus: AllocClientStatusRunning,
+			TaskStates: map[string]*TaskState{
+				"web": {State: TaskStateRunning, Events: []*TaskEvent{
+					{Type: TaskStarted, Time: 1700000000000000000, Details: map[string]string{}},
+				}},
+			},
+			UsageSummary: &AllocUsageSummary{
+				CPU:       250,
+				MemoryMB:  64,
+				Timestamp: 1700000000000000000,
+				Tasks:     map[string]*TaskUsageSummary{"web": {CPU: 250, MemoryMB: 64}},
+			},
+		}
+	}
+
+	// A single update isn't worth compressing
+	payload, err := EncodeAllocUpdates([]*Allocation{update(0)})
+	must.NoError(t, err)
+	must.Nil(t, payload)
+
+	var allocs []*Allocation
+	for i := range 50 {
+		allocs = append(allocs, update(i))
+	}
+	payload, err = EncodeAllocUpdates(allocs)
+	must.NoError(t, err)
+	must.NotNil(t, payload)
+
+	decoded, err := DecodeAllocUpdates(payload)
+	must.NoError(t, err)
+	must.Eq(t, allocs, decoded)
+
+	_, err = DecodeAllocUpdates([]byte("not gzip"))
+	must.ErrorContains(t, err, "invalid allocation updates")
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// AllocBillingRecordsRPCMethod is the RPC method for listing the billing
+	// records of terminated allocations.
+	//
+	// Args: AllocBillingRecordsRequest
+	// Reply: AllocBillingRecordsResponse
+	AllocBillingRecordsRPCMethod = "Alloc.BillingRecords"
+
+	// DefaultBillingRecordRetention is how long the servers keep the billing
+	// record of an allocation after it terminated, unless configured
+	// otherwise. Records outlive the garbage collection of their allocation,
+	// so usage can be charged back after the allocation is gone.
+	DefaultBillingRecordRetention = 90 * 24 * time.Hour
+)
+
+// AllocBillingRecord is the cumulative resource usage of an allocation over
+// its lifetime, which its client reports when the allocation terminates.
+type AllocBillingRecord struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+
+	// ClientStatus is the terminal client status of the allocation
+	ClientStatus string
+
+	// StartTime and EndTime are when the first task of the allocation
+	// started and its last task finished, in Unix nanoseconds
+	StartTime int64
+	EndTime   int64
+
+	// CPUSeconds is the CPU time used by the tasks
+	CPUSeconds float64
+
+	// MemoryGBHours is the memory used by the tasks integrated over time
+	MemoryGBHours float64
+
+	// EgressGB is the network traffic sent by the tasks, if the driver
+	// accounts it
+	EgressGB float64
+
+	// PeakMemoryMB is the sum of the peak memory usage of the tasks, which
+	// bounds the peak of the allocation
+	PeakMemoryMB int
+
+	// Partial is set if the usage of a task was not metered for part of its
+	// lifetime, such as while lazy_task_stats paused its stats collection,
+	// so the record undercounts the usage
+	Partial bool
+
+	// Tasks is the usage of each task, keyed by task name
+	Tasks map[string]*TaskBillingRecord
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (r *AllocBillingRecord) Copy() *AllocBillingRecord {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	nr.Tasks = helper.DeepCopyMap(r.Tasks)
+	return &nr
+}
+
+// TaskBillingRecord is the cumulative resource usage of a task in an
+// AllocBillingRecord.
+type TaskBillingRecord struct {
+	CPUSeconds    float64
+	MemoryGBHours float64
+	EgressGB      float64
+	PeakMemoryMB  int
+	Partial       bool
+}
+
+func (r *TaskBillingRecord) Copy() *TaskBillingRecord {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	return &nr
+}
+
+// NewAllocBillingRecord totals the usage of the tasks of an allocation.
+func NewAllocBillingRecord(tasks map[string]*TaskBillingRecord) *AllocBillingRecord {
+	record := &AllocBillingRecord{Tasks: tasks}
+	for _, task := range tasks {
+		record.CPUSeconds += task.CPUSeconds
+		record.MemoryGBHours += task.MemoryGBHours
+		record.EgressGB += task.EgressGB
+		record.PeakMemoryMB += task.PeakMemoryMB
+		record.Partial = record.Partial || task.Partial
+	}
+	return record
+}
+
+// AllocBillingRecordsRequest lists the billing records of the allocations of
+// a namespace, or of a job when JobID is set. The namespace may be the
+// wildcard to list the records of all namespaces the token can read.
+type AllocBillingRecordsRequest struct {
+	JobID string
+
+	// Since and Until bound the end times of the returned records,
+	// inclusive, in Unix nanoseconds. Zero means unbounded.
+	Since int64
+	Until int64
+
+	QueryOptions
+}
+
+// AllocBillingRecordsResponse is the billing records matching an
+// AllocBillingRecordsRequest, ordered by namespace, job, and allocation ID.
+type AllocBillingRecordsResponse struct {
+	Records []*AllocBillingRecord
+	QueryMeta
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// DriverFencingConfig configures how the client fences the tasks of drivers
+// that stay unhealthy.
+type DriverFencingConfig struct {
+	// Policy is what the client does with the tasks of an unhealthy driver,
+	// either "none" or "stop".
+	Policy *string `hcl:"policy"`
+
+	// Grace is how long a driver must have been unhealthy for before its
+	// tasks are fenced, such as "5m".
+	Grace *string `hcl:"grace"`
+}
+
+func (d *DriverFencingConfig) Copy() *DriverFencingConfig {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DriverFencingConfig)
+	*nd = *d
+	return nd
+}
+
+func (d *DriverFencingConfig) Merge(o *DriverFencingConfig) *DriverFencingConfig {
+	switch {
+	case d == nil:
+		return o.Copy()
+	case o == nil:
+		return d.Copy()
+	default:
+		nd := d.Copy()
+		if o.Policy != nil {
+			nd.Policy = pointer.Copy(o.Policy)
+		}
+		if o.Grace != nil {
+			nd.Grace = pointer.Copy(o.Grace)
+		}
+		return nd
+	}
+}
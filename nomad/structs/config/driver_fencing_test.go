@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestDriverFencingConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		input    *DriverFencingConfig
+		merge    *DriverFencingConfig
+		expected *DriverFencingConfig
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			merge:    nil,
+			expected: nil,
+		},
+		{
+			name:  "nil input",
+			input: nil,
+			merge: &DriverFencingConfig{
+				Policy: pointer.Of("stop"),
+			},
+			expected: &DriverFencingConfig{
+				Policy: pointer.Of("stop"),
+			},
+		},
+		{
+			name: "partial",
+			input: &DriverFencingConfig{
+				Policy: pointer.Of("stop"),
+				Grace:  pointer.Of("1m"),
+			},
+			merge: &DriverFencingConfig{
+				Grace: pointer.Of("10m"),
+			},
+			expected: &DriverFencingConfig{
+				Policy: pointer.Of("stop"),
+				Grace:  pointer.Of("10m"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.input.Merge(tc.merge))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+// HotplugConfig configures the client to fingerprint devices again when they
+// are attached to or removed from the node.
+type HotplugConfig struct {
+	// Enabled turns on watching for devices being attached or removed.
+	Enabled *bool `hcl:"enabled"`
+
+	// Subsystems are the kernel subsystems of the devices watched, such as
+	// "pci" or "usb".
+	Subsystems []string `hcl:"subsystems"`
+
+	// Quiescence is how long the events of devices must have stopped for
+	// before fingerprinting, such as "2s".
+	Quiescence *string `hcl:"quiescence"`
+}
+
+func (h *HotplugConfig) Copy() *HotplugConfig {
+	if h == nil {
+		return nil
+	}
+
+	nh := new(HotplugConfig)
+	*nh = *h
+	nh.Subsystems = slices.Clone(h.Subsystems)
+	return nh
+}
+
+func (h *HotplugConfig) Merge(o *HotplugConfig) *HotplugConfig {
+	switch {
+	case h == nil:
+		return o.Copy()
+	case o == nil:
+		return h.Copy()
+	default:
+		nh := h.Copy()
+		if o.Enabled != nil {
+			nh.Enabled = pointer.Copy(o.Enabled)
+		}
+		if len(o.Subsystems) > 0 {
+			nh.Subsystems = slices.Clone(o.Subsystems)
+		}
+		if o.Quiescence != nil {
+			nh.Quiescence = pointer.Copy(o.Quiescence)
+		}
+		return nh
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestHotplugConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		input    *HotplugConfig
+		merge    *HotplugConfig
+		expected *HotplugConfig
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			merge:    nil,
+			expected: nil,
+		},
+		{
+			name:  "nil input",
+			input: nil,
+			merge: &HotplugConfig{
+				Enabled: pointer.Of(true),
+			},
+			expected: &HotplugConfig{
+				Enabled: pointer.Of(true),
+			},
+		},
+		{
+			name: "partial",
+			input: &HotplugConfig{
+				Enabled:    pointer.Of(true),
+				Subsystems: []string{"pci"},
+			},
+			merge: &HotplugConfig{
+				Subsystems: []string{"usb", "drm"},
+				Quiescence: pointer.Of("5s"),
+			},
+			expected: &HotplugConfig{
+				Enabled:    pointer.Of(true),
+				Subsystems: []string{"usb", "drm"},
+				Quiescence: pointer.Of("5s"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.input.Merge(tc.merge))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// SelfProfilingConfig configures the client to profile itself when its own
+// resource usage exceeds thresholds.
+type SelfProfilingConfig struct {
+	// Enabled turns on self profiling.
+	Enabled *bool `hcl:"enabled"`
+
+	// CPUThreshold is the CPU usage of the agent, as a percentage of one
+	// core, above which a profile is captured.
+	CPUThreshold *int `hcl:"cpu_threshold"`
+
+	// MemoryThreshold is the resident memory of the agent above which a
+	// profile is captured, such as "2GiB".
+	MemoryThreshold *string `hcl:"memory_threshold"`
+
+	// CPUProfileDuration is how long the CPU profile of a capture runs.
+	CPUProfileDuration *string `hcl:"cpu_profile_duration"`
+
+	// Cooldown is the minimum time between two captures.
+	Cooldown *string `hcl:"cooldown"`
+
+	// Retain is the number of captures kept in the data dir.
+	Retain *int `hcl:"retain"`
+}
+
+func (s *SelfProfilingConfig) Copy() *SelfProfilingConfig {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(SelfProfilingConfig)
+	*ns = *s
+	return ns
+}
+
+func (s *SelfProfilingConfig) Merge(o *SelfProfilingConfig) *SelfProfilingConfig {
+	switch {
+	case s == nil:
+		return o.Copy()
+	case o == nil:
+		return s.Copy()
+	default:
+		ns := s.Copy()
+		if o.Enabled != nil {
+			ns.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.CPUThreshold != nil {
+			ns.CPUThreshold = pointer.Copy(o.CPUThreshold)
+		}
+		if o.MemoryThreshold != nil {
+			ns.MemoryThreshold = pointer.Copy(o.MemoryThreshold)
+		}
+		if o.CPUProfileDuration != nil {
+			ns.CPUProfileDuration = pointer.Copy(o.CPUProfileDuration)
+		}
+		if o.Cooldown != nil {
+			ns.Cooldown = pointer.Copy(o.Cooldown)
+		}
+		if o.Retain != nil {
+			ns.Retain = pointer.Copy(o.Retain)
+		}
+		return ns
+	}
+}
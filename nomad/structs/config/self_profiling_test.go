@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestSelfProfilingConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		input    *SelfProfilingConfig
+		merge    *SelfProfilingConfig
+		expected *SelfProfilingConfig
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			merge:    nil,
+			expected: nil,
+		},
+		{
+			name:  "nil input",
+			input: nil,
+			merge: &SelfProfilingConfig{
+				Enabled:      pointer.Of(true),
+				CPUThreshold: pointer.Of(150),
+			},
+			expected: &SelfProfilingConfig{
+				Enabled:      pointer.Of(true),
+				CPUThreshold: pointer.Of(150),
+			},
+		},
+		{
+			name: "partial",
+			input: &SelfProfilingConfig{
+				Enabled:         pointer.Of(true),
+				MemoryThreshold: pointer.Of("1GiB"),
+				Retain:          pointer.Of(3),
+			},
+			merge: &SelfProfilingConfig{
+				Enabled:  pointer.Of(false),
+				Cooldown: pointer.Of("1h"),
+				Retain:   pointer.Of(10),
+			},
+			expected: &SelfProfilingConfig{
+				Enabled:         pointer.Of(false),
+				MemoryThreshold: pointer.Of("1GiB"),
+				Cooldown:        pointer.Of("1h"),
+				Retain:          pointer.Of(10),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.input.Merge(tc.merge))
+		})
+	}
+}
@@ -11,6 +11,18 @@ import "maps"
 type DeviceAccounter struct {
 	// Devices maps a device group to its device accounter instance
 	Devices map[DeviceIdTuple]*DeviceAccounterInstance
+
+	// partitions maps the IDs of device instances to the groups and IDs of
+	// the instances they overlap with, which are its parent device and its
+	// partitions. It is immutable once the accounter is built.
+	partitions map[string][]partitionOf
+}
+
+// partitionOf is a device instance overlapping another one, because it is a
+// partition of it or the other way around.
+type partitionOf struct {
+	group DeviceIdTuple
+	id    string
 }
 
 // DeviceAccounterInstance wraps a device and adds tracking to the instances of
@@ -60,6 +72,7 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 		Devices: make(map[DeviceIdTuple]*DeviceAccounterInstance, numDevices),
 	}
 
+	groups := make(map[string]DeviceIdTuple)
 	for _, dev := range devices {
 		id := *dev.ID()
 		d.Devices[id] = &DeviceAccounterInstance{
@@ -67,6 +80,8 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 			Instances: make(map[string]int, len(dev.Instances)),
 		}
 		for _, instance := range dev.Instances {
+			groups[instance.ID] = id
+
 			// Skip unhealthy devices as they aren't allocatable
 			if !instance.Healthy {
 				continue
@@ -76,6 +91,24 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 		}
 	}
 
+	// Partitions and the device they're a slice of can't be used at once, so
+	// each is marked as used along with the other
+	for _, dev := range devices {
+		for _, instance := range dev.Instances {
+			parent, ok := groups[instance.ParentID]
+			if instance.ParentID == "" || !ok {
+				continue
+			}
+			if d.partitions == nil {
+				d.partitions = make(map[string][]partitionOf)
+			}
+			d.partitions[instance.ID] = append(d.partitions[instance.ID],
+				partitionOf{group: parent, id: instance.ParentID})
+			d.partitions[instance.ParentID] = append(d.partitions[instance.ParentID],
+				partitionOf{group: *dev.ID(), id: instance.ID})
+		}
+	}
+
 	return d
 }
 
@@ -84,7 +117,7 @@ func (d *DeviceAccounter) Copy() *DeviceAccounter {
 	for k, v := range d.Devices {
 		devices[k] = v.Copy()
 	}
-	return &DeviceAccounter{Devices: devices}
+	return &DeviceAccounter{Devices: devices, partitions: d.partitions}
 }
 
 // AddAllocs takes a set of allocations and internally marks which devices are
@@ -118,13 +151,8 @@ func (d *DeviceAccounter) AddAllocs(allocs []*Allocation) (collision bool) {
 					// map if the device is no longer being fingerprinted, is
 					// unhealthy, etc.
 					if devInst, ok := d.Devices[*devID]; ok {
-						if i, ok := devInst.Instances[instanceID]; ok {
-							// Mark that the device is in use
-							devInst.Instances[instanceID]++
-
-							if i != 0 {
-								collision = true
-							}
+						if d.use(devInst, instanceID) {
+							collision = true
 						}
 					}
 				}
@@ -146,22 +174,37 @@ func (d *DeviceAccounter) AddReserved(res *AllocatedDeviceResource) (collision b
 
 	// For each reserved instance, mark it as used
 	for _, id := range res.DeviceIDs {
-		cur, ok := devInst.Instances[id]
-		if !ok {
-			continue
-		}
-
-		// It has already been used, so mark that there is a collision
-		if cur != 0 {
+		if d.use(devInst, id) {
 			collision = true
 		}
-
-		devInst.Instances[id]++
 	}
 
 	return
 }
 
+// use marks the device instance with the given ID as used, along with its
+// parent device and its partitions, and returns if it was already used. The
+// instance may not be in the accounter if it is no longer being
+// fingerprinted, is unhealthy, etc.
+func (d *DeviceAccounter) use(devInst *DeviceAccounterInstance, id string) (collision bool) {
+	cur, ok := devInst.Instances[id]
+	if !ok {
+		return false
+	}
+	devInst.Instances[id]++
+
+	// Partitions of the same device don't collide with each other, so only
+	// the instance itself being used is a collision
+	for _, p := range d.partitions[id] {
+		if other, ok := d.Devices[p.group]; ok {
+			if _, ok := other.Instances[p.id]; ok {
+				other.Instances[p.id]++
+			}
+		}
+	}
+	return cur != 0
+}
+
 // FreeCount returns the number of free device instances
 func (i *DeviceAccounterInstance) FreeCount() int {
 	count := 0
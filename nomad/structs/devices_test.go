@@ -241,6 +241,66 @@ func TestDeviceAccounter_AddReserved(t *testing.T) {
 	require.Equal(0, intelDevice.Instances[intelDev0ID])
 }
 
+// partitionNode returns a node with an nvidia gpu and a group of two
+// partitions of its first instance.
+func partitionNode() *Node {
+	n := MockNvidiaNode()
+	parent := n.NodeResources.Devices[0].Instances[0].ID
+	n.NodeResources.Devices = append(n.NodeResources.Devices, &NodeDeviceResource{
+		Type:   "gpu",
+		Vendor: "nvidia",
+		Name:   "1g.10gb",
+		Instances: []*NodeDevice{
+			{ID: uuid.Generate(), Healthy: true, ParentID: parent},
+			{ID: uuid.Generate(), Healthy: true, ParentID: parent},
+		},
+	})
+	return n
+}
+
+// Test that a device and its partitions aren't used at once
+func TestDeviceAccounter_AddAllocs_Partitions(t *testing.T) {
+	ci.Parallel(t)
+
+	n := partitionNode()
+	parentID := n.NodeResources.Devices[0].Instances[0].ID
+	otherID := n.NodeResources.Devices[0].Instances[1].ID
+	part0ID := n.NodeResources.Devices[1].Instances[0].ID
+	part1ID := n.NodeResources.Devices[1].Instances[1].ID
+
+	// Using a partition marks the parent as used, but not the other partition
+	d := NewDeviceAccounter(n)
+	a1 := nvidiaAlloc()
+	a1.AllocatedResources.Tasks["web"].Devices[0].Name = "1g.10gb"
+	a1.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{part0ID}
+	must.False(t, d.AddAllocs([]*Allocation{a1}))
+
+	gpus := d.Devices[*n.NodeResources.Devices[0].ID()]
+	partitions := d.Devices[*n.NodeResources.Devices[1].ID()]
+	must.Eq(t, 1, gpus.Instances[parentID])
+	must.Eq(t, 0, gpus.Instances[otherID])
+	must.Eq(t, 1, partitions.Instances[part0ID])
+	must.Eq(t, 0, partitions.Instances[part1ID])
+
+	// The other partition is free
+	a2 := a1.Copy()
+	a2.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{part1ID}
+	must.False(t, d.AddAllocs([]*Allocation{a2}))
+
+	// Using the parent marks its partitions as used
+	d = NewDeviceAccounter(n)
+	a3 := nvidiaAlloc()
+	a3.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{parentID}
+	must.False(t, d.AddAllocs([]*Allocation{a3}))
+	partitions = d.Devices[*n.NodeResources.Devices[1].ID()]
+	must.Eq(t, 1, partitions.Instances[part0ID])
+	must.Eq(t, 1, partitions.Instances[part1ID])
+
+	// The copy carries the partitions over
+	d = d.Copy()
+	must.True(t, d.AddAllocs([]*Allocation{a1}))
+}
+
 // Test that collision detection works
 func TestDeviceAccounter_AddReserved_Collision(t *testing.T) {
 	ci.Parallel(t)
@@ -316,7 +316,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Restart policy diff
-	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
+	rDiff := restartPolicyDiff(tg.RestartPolicy, other.RestartPolicy, contextual)
 	if rDiff != nil {
 		diff.Objects = append(diff.Objects, rDiff)
 	}
@@ -338,6 +338,11 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, consulDiff)
 	}
 
+	// SharedResources diff
+	if srDiff := primitiveObjectDiff(tg.SharedResources, other.SharedResources, nil, "SharedResources", contextual); srDiff != nil {
+		diff.Objects = append(diff.Objects, srDiff)
+	}
+
 	// Update diff
 	// COMPAT: Remove "Stagger" in 0.7.0.
 	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
@@ -537,6 +542,12 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Warmup diff
+	wDiff := primitiveObjectDiff(t.Warmup, other.Warmup, nil, "Warmup", contextual)
+	if wDiff != nil {
+		diff.Objects = append(diff.Objects, wDiff)
+	}
+
 	// Artifacts diff
 	diffs := primitiveObjectSetDiff(
 		interfaceSlice(t.Artifacts),
@@ -1073,6 +1084,39 @@ func checkRestartDiff(old, new *CheckRestart, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// restartPolicyDiff returns the diff of two restart policies. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func restartPolicyDiff(old, new *RestartPolicy, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "RestartPolicy"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &RestartPolicy{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &RestartPolicy{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Resource trigger diff
+	if tDiff := primitiveObjectDiff(old.ResourceTrigger, new.ResourceTrigger, nil, "ResourceTrigger", contextual); tDiff != nil {
+		diff.Objects = append(diff.Objects, tDiff)
+	}
+
+	return diff
+}
+
 // connectDiffs returns the diff of two Consul connect objects. If contextual
 // diff is enabled, all fields will be returned, even if no diff occurred.
 func connectDiffs(old, new *ConsulConnect, contextual bool) *ObjectDiff {
@@ -2372,6 +2372,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "fail",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "OOMMemoryStepMB",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "RenderTemplates",
@@ -2425,6 +2431,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "fail",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "OOMMemoryStepMB",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "RenderTemplates",
@@ -2453,6 +2465,7 @@ func TestTaskGroupDiff(t *testing.T) {
 					Delay:           2 * time.Second,
 					Mode:            "delay",
 					RenderTemplates: true,
+					OOMMemoryStepMB: 256,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -2486,6 +2499,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "fail",
 								New:  "delay",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "OOMMemoryStepMB",
+								Old:  "0",
+								New:  "256",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "RenderTemplates",
@@ -2549,6 +2568,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "fail",
 								New:  "fail",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "OOMMemoryStepMB",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "RenderTemplates",
@@ -2610,6 +2635,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "20000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxOOMKills",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Unlimited",
@@ -2671,6 +2702,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "20000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxOOMKills",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Unlimited",
@@ -2798,6 +2835,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxOOMKills",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Unlimited",
@@ -2842,6 +2885,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "CanaryMaxCPURatio",
+								Old:  "0",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "CanaryMaxMemoryRatio",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "HealthyDeadline",
@@ -2904,6 +2959,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CanaryMaxCPURatio",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CanaryMaxMemoryRatio",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "HealthyDeadline",
@@ -3071,6 +3138,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "2",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CanaryMaxCPURatio",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "CanaryMaxMemoryRatio",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "HealthCheck",
@@ -5888,6 +5967,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxBytesPerSecond",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxFileSizeMB",
@@ -5900,6 +5985,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxLinesPerSecond",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -5928,6 +6019,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "true",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxBytesPerSecond",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxFileSizeMB",
@@ -5940,6 +6037,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxLinesPerSecond",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -5956,9 +6059,11 @@ func TestTaskDiff(t *testing.T) {
 			},
 			New: &Task{
 				LogConfig: &LogConfig{
-					MaxFiles:      2,
-					MaxFileSizeMB: 20,
-					Disabled:      true,
+					MaxFiles:          2,
+					MaxFileSizeMB:     20,
+					Disabled:          true,
+					MaxBytesPerSecond: 1024,
+					RateLimitMode:     LogRateLimitModeBlock,
 				},
 			},
 			Expected: &TaskDiff{
@@ -5974,6 +6079,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxBytesPerSecond",
+								Old:  "0",
+								New:  "1024",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "MaxFileSizeMB",
@@ -5986,6 +6097,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RateLimitMode",
+								Old:  "",
+								New:  "block",
+							},
 						},
 					},
 				},
@@ -6021,6 +6138,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxBytesPerSecond",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "MaxFileSizeMB",
@@ -6033,6 +6156,24 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxLinesPerSecond",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "MultilinePattern",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "RateLimitMode",
+								Old:  "",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -6320,6 +6461,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ResizeInPlace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "SecretsMB",
@@ -6429,6 +6576,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "200",
 								New:  "300",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ResizeInPlace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "SecretsMB",
@@ -6796,6 +6949,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ResizeInPlace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "SecretsMB",
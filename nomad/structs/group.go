@@ -137,3 +137,102 @@ func (ds *DisconnectStrategy) ReconcileStrategy() string {
 
 	return ds.Reconcile
 }
+
+var (
+	// Shared resources validation errors
+	errNegativeOverhead   = errors.New("shared_resources overhead cannot be negative")
+	errSharedReservesCore = errors.New("shared_resources cannot be used with tasks reserving cores")
+	errCPUCapBelowTasks   = errors.New("shared_resources cpu_cap cannot be less than the cpu of the tasks")
+)
+
+// SharedResources configures an alloc-level cgroup the tasks of a group run
+// under, whose limits are the sum of the resources of the tasks plus an
+// overhead. Tasks without a memory_max may use the memory of the alloc the
+// other tasks don't, so sidecars and main tasks share headroom within the
+// ceiling of the alloc.
+type SharedResources struct {
+	// MemoryOverheadMB is added to the sum of the memory of the tasks for the
+	// memory ceiling of the alloc, and reserved by the scheduler.
+	MemoryOverheadMB int
+
+	// CPUOverhead is added to the sum of the CPU of the tasks, in MHz, for the
+	// CPU weight of the alloc, and reserved by the scheduler.
+	CPUOverhead int
+
+	// CPUCap is the CPU in MHz the tasks of the alloc may use together, if
+	// set. Tasks borrow the CPU their siblings don't use up to the cap, which
+	// makes them burstable within the alloc instead of the whole node.
+	CPUCap int
+}
+
+func (sr *SharedResources) Copy() *SharedResources {
+	if sr == nil {
+		return nil
+	}
+	nsr := new(SharedResources)
+	*nsr = *sr
+	return nsr
+}
+
+func (sr *SharedResources) Equal(o *SharedResources) bool {
+	if sr == nil || o == nil {
+		return sr == o
+	}
+	return *sr == *o
+}
+
+// Overhead returns the memory in MB and CPU shares the scheduler reserves for
+// the alloc-level cgroup on top of the resources of the tasks.
+func (sr *SharedResources) Overhead() (int64, int64) {
+	if sr == nil {
+		return 0, 0
+	}
+	return int64(sr.MemoryOverheadMB), int64(sr.CPUOverhead)
+}
+
+func (sr *SharedResources) Validate(tg *TaskGroup) error {
+	if sr == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	if sr.MemoryOverheadMB < 0 || sr.CPUOverhead < 0 || sr.CPUCap < 0 {
+		mErr = multierror.Append(mErr, errNegativeOverhead)
+	}
+
+	cpu := 0
+	for _, task := range tg.Tasks {
+		if task.Resources == nil {
+			continue
+		}
+		if task.Resources.Cores > 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("%w: %s", errSharedReservesCore, task.Name))
+		}
+		cpu += task.Resources.CPU
+	}
+	if sr.CPUCap > 0 && sr.CPUCap < cpu {
+		mErr = multierror.Append(mErr, fmt.Errorf("%w: %d < %d", errCPUCapBelowTasks, sr.CPUCap, cpu))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Ceiling returns the memory in MB and CPU shares of the alloc-level cgroup of
+// the tasks: the sum of the memory_max or memory of each task and of their CPU
+// shares, plus the overheads. The memory is -1 if a task has no memory limit.
+func (sr *SharedResources) Ceiling(tasks map[string]*AllocatedTaskResources) (int64, int64) {
+	memoryMB, cpuShares := int64(sr.MemoryOverheadMB), int64(sr.CPUOverhead)
+	unlimited := false
+	for _, task := range tasks {
+		if task.Memory.MemoryMaxMB == memoryNoLimit {
+			unlimited = true
+		}
+		memoryMB += max(task.Memory.MemoryMB, task.Memory.MemoryMaxMB)
+		cpuShares += task.Cpu.CpuShares
+	}
+	if unlimited {
+		memoryMB = memoryNoLimit
+	}
+	return memoryMB, cpuShares
+}
@@ -271,3 +271,83 @@ func TestJobConfig_Validate_MaxClientDisconnect(t *testing.T) {
 	err = job.Validate()
 	must.NoError(t, err)
 }
+
+func TestSharedResources_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	tg := &TaskGroup{Tasks: []*Task{
+		{Name: "main", Resources: &Resources{CPU: 500}},
+		{Name: "sidecar", Resources: &Resources{CPU: 100}},
+	}}
+
+	var sr *SharedResources
+	must.NoError(t, sr.Validate(tg))
+
+	sr = &SharedResources{MemoryOverheadMB: 64, CPUOverhead: 100}
+	must.NoError(t, sr.Validate(tg))
+
+	sr.MemoryOverheadMB = -1
+	must.ErrorIs(t, sr.Validate(tg), errNegativeOverhead)
+
+	sr.MemoryOverheadMB = 0
+	sr.CPUCap = 500
+	must.ErrorIs(t, sr.Validate(tg), errCPUCapBelowTasks)
+
+	sr.CPUCap = 1000
+	must.NoError(t, sr.Validate(tg))
+
+	tg.Tasks[1].Resources.Cores = 1
+	must.ErrorIs(t, sr.Validate(tg), errSharedReservesCore)
+}
+
+func TestSharedResources_Ceiling(t *testing.T) {
+	ci.Parallel(t)
+
+	sr := &SharedResources{MemoryOverheadMB: 64, CPUOverhead: 100}
+	tasks := map[string]*AllocatedTaskResources{
+		"main": {
+			Cpu:    AllocatedCpuResources{CpuShares: 500},
+			Memory: AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 512},
+		},
+		"sidecar": {
+			Cpu:    AllocatedCpuResources{CpuShares: 100},
+			Memory: AllocatedMemoryResources{MemoryMB: 128},
+		},
+	}
+	memoryMB, cpuShares := sr.Ceiling(tasks)
+	must.Eq(t, 512+128+64, memoryMB)
+	must.Eq(t, 500+100+100, cpuShares)
+
+	// A task without a memory limit leaves the alloc without one
+	tasks["sidecar"].Memory.MemoryMaxMB = -1
+	memoryMB, _ = sr.Ceiling(tasks)
+	must.Eq(t, -1, memoryMB)
+}
+
+func TestSharedResources_Overhead(t *testing.T) {
+	ci.Parallel(t)
+
+	var sr *SharedResources
+	memoryMB, cpuShares := sr.Overhead()
+	must.Zero(t, memoryMB)
+	must.Zero(t, cpuShares)
+
+	// The overhead is reserved on top of the tasks
+	sr = &SharedResources{MemoryOverheadMB: 64, CPUOverhead: 100, CPUCap: 1000}
+	memoryMB, cpuShares = sr.Overhead()
+	must.Eq(t, 64, memoryMB)
+	must.Eq(t, 100, cpuShares)
+
+	ar := &AllocatedResources{
+		Tasks: map[string]*AllocatedTaskResources{
+			"main": {
+				Cpu:    AllocatedCpuResources{CpuShares: 500},
+				Memory: AllocatedMemoryResources{MemoryMB: 256},
+			},
+		},
+		Shared: AllocatedSharedResources{MemoryMB: memoryMB, CpuShares: cpuShares},
+	}
+	c := ar.Comparable()
+	must.Eq(t, 500+100, c.Flattened.Cpu.CpuShares)
+	must.Eq(t, 256+64, c.Flattened.Memory.MemoryMB)
+}
@@ -492,3 +492,60 @@ type NodeMetaResponse struct {
 	// Static is the static Node metadata (set via agent configuration)
 	Static map[string]string
 }
+
+const (
+	// NodeUtilizationAttrPrefix prefixes the node attributes that expose the
+	// utilization of the node.
+	NodeUtilizationAttrPrefix = "unique.utilization."
+
+	// NodeUtilizationBucketAttr is the node attribute with the utilization
+	// bucket of the node, which only changes once the utilization moved past
+	// a margin.
+	NodeUtilizationBucketAttr = NodeUtilizationAttrPrefix + "bucket"
+)
+
+// IsLiveUtilizationAttr returns true if the node attribute exposes the live
+// utilization of the node, which changes whenever the utilization does. These
+// changes are sent with the heartbeats of the node rather than updating it, so
+// they don't create evaluations, unlike changes of the utilization bucket.
+func IsLiveUtilizationAttr(name string) bool {
+	return strings.HasPrefix(name, NodeUtilizationAttrPrefix) && name != NodeUtilizationBucketAttr
+}
+
+// LiveUtilizationAttrs returns the live utilization attributes of the node
+// attributes.
+func LiveUtilizationAttrs(attrs map[string]string) map[string]string {
+	var live map[string]string
+	for name, val := range attrs {
+		if !IsLiveUtilizationAttr(name) {
+			continue
+		}
+		if live == nil {
+			live = make(map[string]string)
+		}
+		live[name] = val
+	}
+	return live
+}
+
+// NodeAttributesEqual returns true if the node attributes are equal, ignoring
+// the live utilization attributes.
+func NodeAttributesEqual(a, b map[string]string) bool {
+	for name, val := range a {
+		if IsLiveUtilizationAttr(name) {
+			continue
+		}
+		if other, ok := b[name]; !ok || other != val {
+			return false
+		}
+	}
+	for name := range b {
+		if IsLiveUtilizationAttr(name) {
+			continue
+		}
+		if _, ok := a[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -20,6 +20,17 @@ const (
 
 	// RequireNUMA indicates a task must be placed on a node with available NUMA ideal cores
 	RequireNUMA = "require"
+
+	// BindMemoryPolicy restricts the memory of a task to the NUMA nodes of its cores
+	BindMemoryPolicy = "bind"
+
+	// InterleaveMemoryPolicy interleaves the memory of a task across the NUMA
+	// nodes of its cores
+	InterleaveMemoryPolicy = "interleave"
+
+	// PreferredMemoryPolicy allocates the memory of a task from the NUMA node of
+	// its cores, falling back to other nodes when it is full
+	PreferredMemoryPolicy = "preferred"
 )
 
 type NUMA struct {
@@ -30,6 +41,11 @@ type NUMA struct {
 	// Devices is the set of devices requsted by the task that must share the
 	// same numa node, along with reserved cpu cores for the task.
 	Devices []string
+
+	// MemoryPolicy is the memory policy applied to the task over the numa
+	// nodes of its reserved cpu cores. One of "", "bind", "interleave",
+	// "preferred".
+	MemoryPolicy string
 }
 
 func (n *NUMA) GetDevices() []string {
@@ -61,6 +77,8 @@ func (n *NUMA) Equal(o *NUMA) bool {
 		return false
 	case !slices.Equal(n.Devices, o.Devices):
 		return false
+	case n.MemoryPolicy != o.MemoryPolicy:
+		return false
 	default:
 		return true
 	}
@@ -71,8 +89,9 @@ func (n *NUMA) Copy() *NUMA {
 		return nil
 	}
 	return &NUMA{
-		Affinity: n.Affinity,
-		Devices:  slices.Clone(n.Devices),
+		Affinity:     n.Affinity,
+		Devices:      slices.Clone(n.Devices),
+		MemoryPolicy: n.MemoryPolicy,
 	}
 }
 
@@ -82,10 +101,15 @@ func (n *NUMA) Validate() error {
 	}
 	switch n.Affinity {
 	case NoneNUMA, PreferNUMA, RequireNUMA:
-		return nil
 	default:
 		return errors.New("numa affinity must be one of none, prefer, or require")
 	}
+	switch n.MemoryPolicy {
+	case "", BindMemoryPolicy, InterleaveMemoryPolicy, PreferredMemoryPolicy:
+		return nil
+	default:
+		return errors.New("numa memory_policy must be one of bind, interleave, or preferred")
+	}
 }
 
 // Requested returns true if the NUMA.Affinity is set to one of "prefer" or
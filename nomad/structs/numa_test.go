@@ -28,6 +28,9 @@ func TestNUMA_Equal(t *testing.T) {
 	}, {
 		Field: "Devices",
 		Apply: func(n *NUMA) { n.Devices = []string{"a/b", "c/d"} },
+	}, {
+		Field: "MemoryPolicy",
+		Apply: func(n *NUMA) { n.MemoryPolicy = "bind" },
 	}})
 }
 
@@ -80,6 +83,18 @@ func TestNUMA_Validate(t *testing.T) {
 	}
 }
 
+func TestNUMA_Validate_MemoryPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, policy := range []string{"", "bind", "interleave", "preferred"} {
+		n := &NUMA{Affinity: "require", MemoryPolicy: policy}
+		must.NoError(t, n.Validate())
+	}
+
+	n := &NUMA{Affinity: "require", MemoryPolicy: "local"}
+	must.EqError(t, n.Validate(), "numa memory_policy must be one of bind, interleave, or preferred")
+}
+
 func TestNUMA_Copy(t *testing.T) {
 	ci.Parallel(t)
 
@@ -326,6 +326,11 @@ type PreemptionConfig struct {
 
 	// ServiceSchedulerEnabled specifies if preemption is enabled for service jobs
 	ServiceSchedulerEnabled bool `hcl:"service_scheduler_enabled"`
+
+	// IgnoreObservedUsage disables favoring allocations whose clients report
+	// they use little of their reserved resources when choosing allocations
+	// to preempt, so they are chosen by their reserved resources only
+	IgnoreObservedUsage bool `hcl:"ignore_observed_usage"`
 }
 
 // SchedulerSetConfigRequest is used by the Operator endpoint to update the
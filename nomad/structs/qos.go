@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+const (
+	// QoSClassGuaranteed is the QoS class of tasks whose memory limit is
+	// their reservation
+	QoSClassGuaranteed = "guaranteed"
+
+	// QoSClassBurstable is the QoS class of tasks that may use memory beyond
+	// their reservation, up to a limit
+	QoSClassBurstable = "burstable"
+
+	// QoSClassBestEffort is the QoS class of tasks without a memory limit
+	QoSClassBestEffort = "best-effort"
+)
+
+// QoSClass returns the QoS class of the task, derived from its memory
+// reservation and memory_max.
+func (a *AllocatedTaskResources) QoSClass() string {
+	switch max := a.Memory.MemoryMaxMB; {
+	case max == memoryNoLimit:
+		return QoSClassBestEffort
+	case max == 0 || max == a.Memory.MemoryMB:
+		return QoSClassGuaranteed
+	default:
+		return QoSClassBurstable
+	}
+}
+
+// QoSClass returns the QoS class of the allocation, which is the lowest class
+// of its tasks. Allocations of groups with shared resources are at most
+// burstable, since their tasks may use the memory their siblings don't.
+func (a *Allocation) QoSClass() string {
+	class := QoSClassGuaranteed
+	if a.AllocatedResources == nil {
+		return class
+	}
+	for _, task := range a.AllocatedResources.Tasks {
+		if taskClass := task.QoSClass(); QoSRank(taskClass) < QoSRank(class) {
+			class = taskClass
+		}
+	}
+	if class == QoSClassGuaranteed && a.Job != nil {
+		if tg := a.Job.LookupTaskGroup(a.TaskGroup); tg != nil && tg.SharedResources != nil {
+			class = QoSClassBurstable
+		}
+	}
+	return class
+}
+
+// QoSRank orders QoS classes from the lowest, whose allocations are evicted
+// first when the node runs out of memory.
+func QoSRank(class string) int {
+	switch class {
+	case QoSClassBestEffort:
+		return 0
+	case QoSClassBurstable:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// QoSOOMScoreAdj returns the oom_score_adj of the tasks of the QoS class, so
+// the kernel kills processes of best-effort tasks first and of guaranteed
+// tasks last. None are negative, since lowering the oom_score_adj of a
+// process requires privileges the client may not have.
+func QoSOOMScoreAdj(class string) int32 {
+	switch class {
+	case QoSClassBestEffort:
+		return 1000
+	case QoSClassBurstable:
+		return 500
+	default:
+		return 0
+	}
+}
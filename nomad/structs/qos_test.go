@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocatedTaskResources_QoSClass(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		memoryMB, memoryMaxMB int64
+		exp                   string
+	}{
+		{256, 0, QoSClassGuaranteed},
+		{256, 256, QoSClassGuaranteed},
+		{256, 512, QoSClassBurstable},
+		{256, -1, QoSClassBestEffort},
+	}
+	for _, tc := range cases {
+		task := &AllocatedTaskResources{Memory: AllocatedMemoryResources{
+			MemoryMB: tc.memoryMB, MemoryMaxMB: tc.memoryMaxMB,
+		}}
+		must.Eq(t, tc.exp, task.QoSClass())
+	}
+}
+
+func TestAllocation_QoSClass(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		TaskGroup: "web",
+		Job:       &Job{TaskGroups: []*TaskGroup{{Name: "web"}}},
+		AllocatedResources: &AllocatedResources{Tasks: map[string]*AllocatedTaskResources{
+			"main":    {Memory: AllocatedMemoryResources{MemoryMB: 256}},
+			"sidecar": {Memory: AllocatedMemoryResources{MemoryMB: 64}},
+		}},
+	}
+	must.Eq(t, QoSClassGuaranteed, alloc.QoSClass())
+
+	// Tasks sharing the resources of the alloc make it burstable
+	alloc.Job.TaskGroups[0].SharedResources = &SharedResources{}
+	must.Eq(t, QoSClassBurstable, alloc.QoSClass())
+
+	// The lowest class of the tasks is the class of the alloc
+	alloc.AllocatedResources.Tasks["sidecar"].Memory.MemoryMaxMB = -1
+	must.Eq(t, QoSClassBestEffort, alloc.QoSClass())
+}
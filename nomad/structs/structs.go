@@ -44,6 +44,7 @@ import (
 	"github.com/hashicorp/nomad/helper/constraints/semver"
 	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/lib/kheap"
 	psstructs "github.com/hashicorp/nomad/plugins/shared/structs"
@@ -131,6 +132,8 @@ const (
 	WrappedRootKeysUpsertRequestType             MessageType = 62
 	NamespaceUpsertRequestType                   MessageType = 64
 	NamespaceDeleteRequestType                   MessageType = 65
+	UsageSamplesUpsertRequestType                MessageType = 66
+	AllocUsageUpdateRequestType                  MessageType = 67
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -642,6 +645,18 @@ type NodeUpdateStatusRequest struct {
 	Status    string
 	NodeEvent *NodeEvent
 	UpdatedAt int64
+
+	// Utilization holds the live utilization attributes of the node, which
+	// clients send with their heartbeats. They are written to the node without
+	// creating evaluations, and only if they changed.
+	Utilization map[string]string
+
+	// AllocUsage holds the usage summaries of the node's allocations that
+	// changed since the last heartbeat, keyed by allocation ID. They are
+	// recorded without updating the allocations, so usage drift doesn't
+	// rewrite them.
+	AllocUsage map[string]*AllocUsageSummary
+
 	WriteRequest
 }
 
@@ -929,6 +944,10 @@ type JobDispatchRequest struct {
 	Meta    map[string]string
 	WriteRequest
 	IdPrefixTemplate string
+
+	// TraceParent is an optional W3C trace context the dispatch joins. When
+	// empty a new trace is started for the dispatched job.
+	TraceParent string
 }
 
 // JobValidateRequest is used to validate a job
@@ -1132,10 +1151,20 @@ type AllocUpdateRequest struct {
 	// New or updated allocations
 	AllocsUpdated []*Allocation
 
+	// CompressedAllocs is the gzip compressed msgpack encoding of the
+	// allocations of Alloc, as returned by EncodeAllocUpdates, which clients
+	// send instead of Alloc once all servers support it
+	CompressedAllocs []byte
+
 	// Evals is the list of new evaluations to create
 	// Evals are valid only when used in the Raft RPC
 	Evals []*Evaluation
 
+	// UsageSamples is the usage history the leader records for the usage
+	// summaries of the allocations. UsageSamples are valid only when used in
+	// the Raft RPC
+	UsageSamples []*UsageSample
+
 	// Job is the shared parent job of the allocations.
 	// It is pulled out since it is common to reduce payload size.
 	Job *Job
@@ -1553,6 +1582,21 @@ type NodeUpdateResponse struct {
 	// has for their scheduling status during heartbeats.
 	SchedulingEligibility string
 
+	// AllocUpdateDeltas informs clients that all servers in the region accept
+	// allocation updates which omit the task states and usage summary that
+	// did not change since the client's last acknowledged update, and
+	// compressed allocation updates.
+	AllocUpdateDeltas bool
+
+	// UsageBackfill informs clients that all servers in the region accept
+	// the usage summaries clients buffered while disconnected.
+	UsageBackfill bool
+
+	// UsageHeartbeats informs clients that all servers in the region accept
+	// usage summaries with heartbeats, so they don't need to send allocation
+	// updates when only the usage of an allocation changed.
+	UsageHeartbeats bool
+
 	QueryMeta
 }
 
@@ -1693,6 +1737,11 @@ type JobPlanResponse struct {
 	// submitted.
 	NextPeriodicLaunch time.Time
 
+	// NodeImpacts is the observed and projected usage of the nodes the plan
+	// places allocations on or stops allocations from, highest projected
+	// pressure first.
+	NodeImpacts []*NodeWhatIfImpact
+
 	// Warnings contains any warnings about the given job. These may include
 	// deprecation warnings.
 	Warnings string
@@ -2440,6 +2489,10 @@ type Resources struct {
 	Devices     ResourceDevices
 	NUMA        *NUMA
 	SecretsMB   int
+
+	// ResizeInPlace updates the cpu and memory of the running allocations of
+	// the task in place when they change, instead of replacing them
+	ResizeInPlace bool
 }
 
 const (
@@ -2521,6 +2574,11 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, err)
 	}
 
+	// Ensure the memory policy has numa nodes to apply to
+	if r.NUMA != nil && r.NUMA.MemoryPolicy != "" && r.Cores == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("numa memory_policy requires cores to be reserved"))
+	}
+
 	// Ensure memory_max is greater than memory, unless it is set to 0 or -1 which
 	// are both sentinel values
 	if (r.MemoryMaxMB != 0 && r.MemoryMaxMB != memoryNoLimit) && r.MemoryMaxMB < r.MemoryMB {
@@ -2564,6 +2622,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != 0 {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.ResizeInPlace {
+		r.ResizeInPlace = true
+	}
 }
 
 // Equal Resources.
@@ -2584,7 +2645,8 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.IOPS == o.IOPS &&
 		r.Networks.Equal(&o.Networks) &&
 		r.Devices.Equal(&o.Devices) &&
-		r.SecretsMB == o.SecretsMB
+		r.SecretsMB == o.SecretsMB &&
+		r.ResizeInPlace == o.ResizeInPlace
 }
 
 // ResourceDevices are part of Resources.
@@ -2674,16 +2736,17 @@ func (r *Resources) Copy() *Resources {
 		return nil
 	}
 	return &Resources{
-		CPU:         r.CPU,
-		Cores:       r.Cores,
-		MemoryMB:    r.MemoryMB,
-		MemoryMaxMB: r.MemoryMaxMB,
-		DiskMB:      r.DiskMB,
-		IOPS:        r.IOPS,
-		Networks:    r.Networks.Copy(),
-		Devices:     r.Devices.Copy(),
-		NUMA:        r.NUMA.Copy(),
-		SecretsMB:   r.SecretsMB,
+		CPU:           r.CPU,
+		Cores:         r.Cores,
+		MemoryMB:      r.MemoryMB,
+		MemoryMaxMB:   r.MemoryMaxMB,
+		DiskMB:        r.DiskMB,
+		IOPS:          r.IOPS,
+		Networks:      r.Networks.Copy(),
+		Devices:       r.Devices.Copy(),
+		NUMA:          r.NUMA.Copy(),
+		SecretsMB:     r.SecretsMB,
+		ResizeInPlace: r.ResizeInPlace,
 	}
 }
 
@@ -3655,6 +3718,11 @@ type NodeDevice struct {
 	// Locality stores HW locality information for the node to optionally be
 	// used when making placement decisions.
 	Locality *NodeDeviceLocality
+
+	// ParentID is the ID of the device this device is a partition of, such as
+	// the GPU of an NVIDIA MIG slice. A device is never allocated along with
+	// its partitions.
+	ParentID string
 }
 
 func (n *NodeDevice) Equal(o *NodeDevice) bool {
@@ -3674,6 +3742,8 @@ func (n *NodeDevice) Equal(o *NodeDevice) bool {
 		return false
 	} else if !n.Locality.Equal(o.Locality) {
 		return false
+	} else if n.ParentID != o.ParentID {
+		return false
 	}
 
 	return false
@@ -3903,6 +3973,12 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 		})
 	}
 
+	// Add the overhead of the alloc-level cgroup
+	c.Flattened.Add(&AllocatedTaskResources{
+		Cpu:    AllocatedCpuResources{CpuShares: a.Shared.CpuShares},
+		Memory: AllocatedMemoryResources{MemoryMB: a.Shared.MemoryMB},
+	})
+
 	return c
 }
 
@@ -4068,13 +4144,20 @@ type AllocatedSharedResources struct {
 	Networks Networks
 	DiskMB   int64
 	Ports    AllocatedPorts
+
+	// MemoryMB and CpuShares are the overhead of the alloc-level cgroup of
+	// groups with shared resources, reserved on top of the tasks.
+	MemoryMB  int64
+	CpuShares int64
 }
 
 func (a AllocatedSharedResources) Copy() AllocatedSharedResources {
 	return AllocatedSharedResources{
-		Networks: a.Networks.Copy(),
-		DiskMB:   a.DiskMB,
-		Ports:    a.Ports,
+		Networks:  a.Networks.Copy(),
+		DiskMB:    a.DiskMB,
+		Ports:     a.Ports,
+		MemoryMB:  a.MemoryMB,
+		CpuShares: a.CpuShares,
 	}
 }
 
@@ -4084,6 +4167,8 @@ func (a *AllocatedSharedResources) Add(delta *AllocatedSharedResources) {
 	}
 	a.Networks = append(a.Networks, delta.Networks...)
 	a.DiskMB += delta.DiskMB
+	a.MemoryMB += delta.MemoryMB
+	a.CpuShares += delta.CpuShares
 
 }
 
@@ -4104,6 +4189,8 @@ func (a *AllocatedSharedResources) Subtract(delta *AllocatedSharedResources) {
 	}
 	a.Networks = nets
 	a.DiskMB -= delta.DiskMB
+	a.MemoryMB -= delta.MemoryMB
+	a.CpuShares -= delta.CpuShares
 }
 
 func (a *AllocatedSharedResources) Canonicalize() {
@@ -5440,6 +5527,14 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// CanaryMaxCPURatio and CanaryMaxMemoryRatio are the maximum ratios of the
+	// 95th percentile of the CPU and memory used by healthy canaries to those
+	// of the allocations of the latest stable job version for the deployment
+	// to be automatically promoted. The deployment fails if the canaries use
+	// more. Zero disables the criteria.
+	CanaryMaxCPURatio    float64
+	CanaryMaxMemoryRatio float64
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5473,6 +5568,15 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary == 0 && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if u.CanaryMaxCPURatio < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary max CPU ratio can not be less than zero: %v < 0", u.CanaryMaxCPURatio))
+	}
+	if u.CanaryMaxMemoryRatio < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary max memory ratio can not be less than zero: %v < 0", u.CanaryMaxMemoryRatio))
+	}
+	if u.HasCanaryUsageCriteria() && !u.AutoPromote {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary usage ratios require Auto Promote"))
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -5509,6 +5613,12 @@ func (u *UpdateStrategy) IsEmpty() bool {
 	return u.MaxParallel == 0
 }
 
+// HasCanaryUsageCriteria returns whether the automatic promotion of the
+// canaries depends on their usage.
+func (u *UpdateStrategy) HasCanaryUsageCriteria() bool {
+	return u != nil && (u.CanaryMaxCPURatio > 0 || u.CanaryMaxMemoryRatio > 0)
+}
+
 // Rolling returns if a rolling strategy should be used.
 // TODO(alexdadgar): Remove once no longer used by the scheduler.
 func (u *UpdateStrategy) Rolling() bool {
@@ -6183,6 +6293,43 @@ func (d *TaskLifecycleConfig) Validate() error {
 	return nil
 }
 
+// TaskWarmup delays a task being considered healthy until it has finished
+// warming up, as signaled by its CPU usage staying below CPUPercent for
+// Duration.
+type TaskWarmup struct {
+	// CPUPercent is the CPU usage of the task, in percent of a core, below
+	// which the task is considered warmed up.
+	CPUPercent float64
+
+	// Duration is how long the CPU usage of the task must stay below
+	// CPUPercent.
+	Duration time.Duration
+}
+
+func (w *TaskWarmup) Copy() *TaskWarmup {
+	if w == nil {
+		return nil
+	}
+	nw := new(TaskWarmup)
+	*nw = *w
+	return nw
+}
+
+func (w *TaskWarmup) Validate() error {
+	if w == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if w.CPUPercent <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("cpu_percent must be greater than 0 (got %v)", w.CPUPercent))
+	}
+	if w.Duration < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("duration can not be negative (got %v)", w.Duration))
+	}
+	return mErr.ErrorOrNil()
+}
+
 var (
 	// These default restart policies needs to be in sync with
 	// Canonicalize in api/tasks.go
@@ -6546,6 +6693,16 @@ type RestartPolicy struct {
 
 	// RenderTemplates is flag to explicitly render all templates on task restart
 	RenderTemplates bool
+
+	// ResourceTrigger restarts the task when its resource usage stays beyond
+	// thresholds, as measured by the client.
+	ResourceTrigger *RestartResourceTrigger
+
+	// OOMMemoryStepMB is the memory in MB by which the client raises the
+	// memory limit of a task each time it restarts the task after an OOM
+	// kill, up to the memory_max of the task. Tasks with a memory_max start
+	// limited to their memory when it's set. Zero disables the escalation.
+	OOMMemoryStepMB int
 }
 
 func (r *RestartPolicy) Copy() *RestartPolicy {
@@ -6554,6 +6711,7 @@ func (r *RestartPolicy) Copy() *RestartPolicy {
 	}
 	nrp := new(RestartPolicy)
 	*nrp = *r
+	nrp.ResourceTrigger = r.ResourceTrigger.Copy()
 	return nrp
 }
 
@@ -6577,6 +6735,57 @@ func (r *RestartPolicy) Validate() error {
 		_ = multierror.Append(&mErr,
 			fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with a delay of %v", r.Attempts, r.Interval, r.Delay))
 	}
+	if r.ResourceTrigger != nil {
+		if err := r.ResourceTrigger.Validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Resource trigger: %v", err))
+		}
+	}
+	if r.OOMMemoryStepMB < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("OOM memory step can not be less than zero: %d < 0", r.OOMMemoryStepMB))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// RestartResourceTrigger restarts a task when its resource usage stays beyond
+// any of its thresholds for Duration. Thresholds are evaluated by the client
+// against the task's resource usage samples; zero values disable them.
+type RestartResourceTrigger struct {
+	// MemoryRSSMB is the resident set size of the task's processes above
+	// which the task is restarted.
+	MemoryRSSMB int
+
+	// CPUThrottledRatio is the fraction of time the task's processes may
+	// spend throttled by their CPU limit before the task is restarted.
+	CPUThrottledRatio float64
+
+	// Duration is how long the task's usage must stay beyond a threshold
+	// before the task is restarted.
+	Duration time.Duration
+}
+
+func (r *RestartResourceTrigger) Copy() *RestartResourceTrigger {
+	if r == nil {
+		return nil
+	}
+	nr := new(RestartResourceTrigger)
+	*nr = *r
+	return nr
+}
+
+func (r *RestartResourceTrigger) Validate() error {
+	var mErr multierror.Error
+	if r.MemoryRSSMB == 0 && r.CPUThrottledRatio == 0 {
+		_ = multierror.Append(&mErr, errors.New("at least one of memory_rss_mb or cpu_throttled_ratio must be set"))
+	}
+	if r.MemoryRSSMB < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("memory_rss_mb must be positive (got %d)", r.MemoryRSSMB))
+	}
+	if r.CPUThrottledRatio < 0 || r.CPUThrottledRatio > 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("cpu_throttled_ratio must be between 0 and 1 (got %v)", r.CPUThrottledRatio))
+	}
+	if r.Duration < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("duration can not be negative (got %v)", r.Duration))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -6619,6 +6828,12 @@ type ReschedulePolicy struct {
 	// Unlimited allows infinite rescheduling attempts. Only allowed when delay is set
 	// between reschedule attempts.
 	Unlimited bool
+
+	// MaxOOMKills is the number of times the allocations of a job version can
+	// be OOM-killed in a row of reschedule attempts before they are no longer
+	// rescheduled. Each OOM kill doubles the delay of the next reschedule
+	// attempt, up to MaxDelay. Zero disables the limit and the penalty.
+	MaxOOMKills int
 }
 
 func (r *ReschedulePolicy) Copy() *ReschedulePolicy {
@@ -6644,6 +6859,9 @@ func (r *ReschedulePolicy) Validate() error {
 		return nil
 	}
 	var mErr multierror.Error
+	if r.MaxOOMKills < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max OOM kills can not be less than zero: %d < 0", r.MaxOOMKills))
+	}
 	// Check for ambiguous/confusing settings
 	if r.Attempts > 0 {
 		if r.Interval <= 0 {
@@ -6921,6 +7139,10 @@ type TaskGroup struct {
 	// Consul configuration specific to this task group
 	Consul *Consul
 
+	// SharedResources configures the alloc-level cgroup the tasks of the
+	// group run under, if set
+	SharedResources *SharedResources
+
 	// Services this group provides
 	Services []*Service
 
@@ -6964,6 +7186,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
+	ntg.SharedResources = ntg.SharedResources.Copy()
 
 	// Copy the network objects
 	if tg.Networks != nil {
@@ -7158,6 +7381,10 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if err := tg.SharedResources.Validate(tg); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -7836,8 +8063,32 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// MaxBytesPerSecond and MaxLinesPerSecond limit the rate of output of
+	// each of the task's streams written to its log files. Zero is unlimited.
+	MaxBytesPerSecond int
+	MaxLinesPerSecond int
+
+	// RateLimitMode is either LogRateLimitModeDrop or LogRateLimitModeBlock,
+	// and defaults to LogRateLimitModeDrop
+	RateLimitMode string
+
+	// MultilinePattern is a regular expression matching the first line of
+	// each event of a task that writes multiline events, such as stack traces.
+	// The lines that don't match it are grouped with the event before them.
+	MultilinePattern string
 }
 
+const (
+	// LogRateLimitModeDrop drops the output of a task over its log rate
+	// limits, and annotates its log with how much was dropped
+	LogRateLimitModeDrop = "drop"
+
+	// LogRateLimitModeBlock blocks the writes of a task to stdout or stderr
+	// while its output is over its log rate limits
+	LogRateLimitModeBlock = "block"
+)
+
 func (l *LogConfig) Equal(o *LogConfig) bool {
 	if l == nil || o == nil {
 		return l == o
@@ -7855,6 +8106,22 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if l.MaxBytesPerSecond != o.MaxBytesPerSecond {
+		return false
+	}
+
+	if l.MaxLinesPerSecond != o.MaxLinesPerSecond {
+		return false
+	}
+
+	if l.RateLimitMode != o.RateLimitMode {
+		return false
+	}
+
+	if l.MultilinePattern != o.MultilinePattern {
+		return false
+	}
+
 	return true
 }
 
@@ -7863,9 +8130,13 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:          l.MaxFiles,
+		MaxFileSizeMB:     l.MaxFileSizeMB,
+		Disabled:          l.Disabled,
+		MaxBytesPerSecond: l.MaxBytesPerSecond,
+		MaxLinesPerSecond: l.MaxLinesPerSecond,
+		RateLimitMode:     l.RateLimitMode,
+		MultilinePattern:  l.MultilinePattern,
 	}
 }
 
@@ -7889,6 +8160,23 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	if l.MaxBytesPerSecond < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max_bytes_per_second must not be negative; got %d", l.MaxBytesPerSecond))
+	}
+	if l.MaxLinesPerSecond < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max_lines_per_second must not be negative; got %d", l.MaxLinesPerSecond))
+	}
+	switch l.RateLimitMode {
+	case "", LogRateLimitModeDrop, LogRateLimitModeBlock:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("rate_limit_mode must be %q or %q; got %q",
+			LogRateLimitModeDrop, LogRateLimitModeBlock, l.RateLimitMode))
+	}
+	if l.MultilinePattern != "" {
+		if _, err := regexp.Compile(l.MultilinePattern); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid multiline_pattern: %v", err))
+		}
+	}
 	if disk != nil {
 		logUsage := (l.MaxFiles * l.MaxFileSizeMB)
 		if disk.SizeMB <= logUsage {
@@ -7951,6 +8239,10 @@ type Task struct {
 
 	Lifecycle *TaskLifecycleConfig
 
+	// Warmup delays the task being considered healthy until its CPU usage
+	// settles.
+	Warmup *TaskWarmup
+
 	// Meta is used to associate arbitrary metadata with this
 	// task. This is opaque to Nomad.
 	Meta map[string]string
@@ -8097,6 +8389,7 @@ func (t *Task) Copy() *Task {
 	nt.Meta = maps.Clone(nt.Meta)
 	nt.DispatchPayload = nt.DispatchPayload.Copy()
 	nt.Lifecycle = nt.Lifecycle.Copy()
+	nt.Warmup = nt.Warmup.Copy()
 	nt.Identity = nt.Identity.Copy()
 	nt.Identities = helper.CopySlice(nt.Identities)
 	nt.Actions = helper.CopySlice(nt.Actions)
@@ -8358,6 +8651,11 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 
 	}
 
+	// Validate the Warmup block if there
+	if err := t.Warmup.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Warmup validation failed: %v", err))
+	}
+
 	// Validation for TaskKind field which is used for Consul Connect integration
 	if t.Kind.IsConnectProxy() {
 		// This task is a Connect proxy so it should not have service blocks
@@ -9158,6 +9456,32 @@ const (
 	TaskStateDead    = "dead"    // Terminal state of task.
 )
 
+const (
+	// TaskExitReasonCompleted is the exit reason of a task which exited
+	// successfully.
+	TaskExitReasonCompleted = "completed"
+
+	// TaskExitReasonNonZeroExit is the exit reason of a task which exited
+	// with a non-zero exit code.
+	TaskExitReasonNonZeroExit = "nonzero-exit"
+
+	// TaskExitReasonSignaled is the exit reason of a task which was
+	// terminated by a signal.
+	TaskExitReasonSignaled = "signaled"
+
+	// TaskExitReasonOOMKilled is the exit reason of a task which was killed
+	// by the kernel for going over its memory limit.
+	TaskExitReasonOOMKilled = "oom-killed"
+
+	// TaskExitReasonExecutorFailure is the exit reason of a task whose exit
+	// could not be observed because its driver or executor failed.
+	TaskExitReasonExecutorFailure = "executor-failure"
+
+	// TaskExitReasonNodeShutdown is the exit reason of a task which was
+	// terminated because the node it runs on was shutting down.
+	TaskExitReasonNodeShutdown = "node-shutdown"
+)
+
 // TaskState tracks the current state of a task and events that caused state
 // transitions.
 type TaskState struct {
@@ -9182,6 +9506,22 @@ type TaskState struct {
 	// not be started again.
 	FinishedAt time.Time
 
+	// CgroupPath, CgroupID, and ExecutorPID identify the cgroup and executor
+	// process of the task as last reported by its driver, so external tools
+	// can correlate kernel data with the task. See
+	// client/structs.TaskResourceUsage.
+	CgroupPath  string
+	CgroupID    uint64
+	ExecutorPID int
+
+	// ExitReason classifies why the task last exited, as one of the
+	// TaskExitReason constants. It is updated each time the task exits
+	ExitReason string
+
+	// WarmedUpAt is the time the task finished warming up, for tasks with a
+	// warmup block. It is updated each time the task warms up after starting
+	WarmedUpAt time.Time
+
 	// Series of task events that transition the state of the task.
 	Events []*TaskEvent
 
@@ -9253,6 +9593,15 @@ func (ts *TaskState) Equal(o *TaskState) bool {
 	if ts.FinishedAt != o.FinishedAt {
 		return false
 	}
+	if ts.CgroupPath != o.CgroupPath || ts.CgroupID != o.CgroupID || ts.ExecutorPID != o.ExecutorPID {
+		return false
+	}
+	if ts.ExitReason != o.ExitReason {
+		return false
+	}
+	if ts.WarmedUpAt != o.WarmedUpAt {
+		return false
+	}
 	if !slices.EqualFunc(ts.Events, o.Events, func(ts, o *TaskEvent) bool {
 		return ts.Equal(o)
 	}) {
@@ -9367,6 +9716,18 @@ const (
 	// TaskClientReconnected indicates that the client running the task reconnected.
 	TaskClientReconnected = "Reconnected"
 
+	// TaskWarmedUp indicates that the CPU usage of a task with a warmup block
+	// settled, and the task may be considered healthy.
+	TaskWarmedUp = "Warmed Up"
+
+	// TaskResized indicates that the cpu and memory of a running task were
+	// updated in place.
+	TaskResized = "Resized"
+
+	// TaskUsageAnomaly indicates that the resource usage of a task changed
+	// abruptly, which may be an early sign of a leak or a runaway loop.
+	TaskUsageAnomaly = "Usage Anomaly"
+
 	// TaskWaitingShuttingDownDelay indicates that the task is waiting for
 	// shutdown delay before being TaskKilled
 	TaskWaitingShuttingDownDelay = "Waiting for shutdown delay"
@@ -9801,6 +10162,74 @@ func (e *TaskEvent) SetOOMKilled(oom bool) *TaskEvent {
 	return e
 }
 
+// TaskKilledResourceMemory and TaskKilledResourceCPU are the resources the
+// usage of which a task may be killed for.
+const (
+	TaskKilledResourceMemory = "memory"
+	TaskKilledResourceCPU    = "cpu"
+)
+
+// SetKilledResource records the resource the usage of which the task was
+// killed for.
+func (e *TaskEvent) SetKilledResource(r string) *TaskEvent {
+	if r != "" {
+		e.Details["killed_resource"] = r
+	}
+	return e
+}
+
+func (e *TaskEvent) SetExitReason(r string) *TaskEvent {
+	if r != "" {
+		e.Details["exit_reason"] = r
+	}
+	return e
+}
+
+// TaskStartRecord is the audit record of a task start: what the client
+// started, as it resolved it.
+type TaskStartRecord struct {
+	// Image and Command are the image and command of the driver config of
+	// the task after interpolation, for the drivers that have them
+	Image   string
+	Command string
+
+	// ArgsSHA256 is the hex encoded SHA-256 digest of the interpolated
+	// arguments of the driver config, which may include secrets, if it has
+	// any
+	ArgsSHA256 string
+
+	// User is the user the task runs as, if set
+	User string
+
+	// Cgroup is the cgroup the client assigned the task to
+	Cgroup string
+
+	// EnvSHA256 is the hex encoded SHA-256 digest of the environment of the
+	// task, so it can be compared across starts without recording secrets
+	EnvSHA256 string
+}
+
+// SetTaskStartRecord sets the details of the audit record of a task start.
+func (e *TaskEvent) SetTaskStartRecord(r *TaskStartRecord) *TaskEvent {
+	if r.Image != "" {
+		e.Details["image"] = r.Image
+	}
+	if r.Command != "" {
+		e.Details["command"] = r.Command
+	}
+	if r.ArgsSHA256 != "" {
+		e.Details["args_sha256"] = r.ArgsSHA256
+	}
+	if r.User != "" {
+		e.Details["user"] = r.User
+	}
+	if r.Cgroup != "" {
+		e.Details["cgroup"] = r.Cgroup
+	}
+	e.Details["env_sha256"] = r.EnvSHA256
+	return e
+}
+
 // TaskArtifact is an artifact to download before running the task.
 type TaskArtifact struct {
 	// GetterSource is the source to download an artifact using go-getter
@@ -10574,6 +11003,7 @@ const (
 	DeploymentStatusDescriptionNewerJob              = "Cancelled due to newer version of job"
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
+	DeploymentStatusDescriptionCanaryUsage           = "Failed due to canary resource usage"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
 
 	// used only in multiregion deployments
@@ -10864,6 +11294,11 @@ type RescheduleTracker struct {
 	// LastReschedule represents whether the most recent attempt to reschedule
 	// the allocation (if any) was successful
 	LastReschedule RescheduleTrackerAnnotation
+
+	// OOMKills is the number of consecutive previous allocations of the job
+	// version of the latest event that were OOM-killed. Unlike the events,
+	// which are trimmed, it is carried over on every reschedule.
+	OOMKills int
 }
 
 type RescheduleTrackerAnnotation string
@@ -10871,6 +11306,10 @@ type RescheduleTrackerAnnotation string
 const (
 	LastRescheduleSuccess       RescheduleTrackerAnnotation = "ok"
 	LastRescheduleFailedToPlace RescheduleTrackerAnnotation = "no placement"
+
+	// LastRescheduleOOMKillLimit marks an allocation that isn't rescheduled
+	// because its job version reached the MaxOOMKills of its reschedule policy
+	LastRescheduleOOMKillLimit RescheduleTrackerAnnotation = "oom kill limit"
 )
 
 func (rt *RescheduleTracker) Copy() *RescheduleTracker {
@@ -10940,6 +11379,13 @@ type RescheduleEvent struct {
 
 	// Delay is the reschedule delay associated with the attempt
 	Delay time.Duration
+
+	// JobVersion is the job version of the previous allocation
+	JobVersion uint64
+
+	// OOMKilled is true if the previous allocation failed because a task was
+	// OOM-killed
+	OOMKilled bool
 }
 
 func NewRescheduleEvent(rescheduleTime int64, prevAllocID string, prevNodeID string, delay time.Duration) *RescheduleEvent {
@@ -11155,6 +11601,16 @@ type Allocation struct {
 	// NetworkStatus captures networking details of an allocation known at runtime
 	NetworkStatus *AllocNetworkStatus
 
+	// UsageSummary is the resource usage of the allocation's tasks as last
+	// reported by the client
+	UsageSummary *AllocUsageSummary
+
+	// BillingRecord is the cumulative resource usage of the allocation, which
+	// the client reports once the allocation is terminal. The servers store
+	// it apart from the allocation, so it is never set on allocations read
+	// from the state store.
+	BillingRecord *AllocBillingRecord
+
 	// FollowupEvalID captures a follow up evaluation created to handle a failed allocation
 	// that can be rescheduled in the future
 	FollowupEvalID string
@@ -11175,6 +11631,11 @@ type Allocation struct {
 	// SigningKeyID is the key used to sign the SignedIdentities field.
 	SigningKeyID string
 
+	// TraceParent is the W3C trace context of the placement of this
+	// allocation, derived from the evaluation that created it. Tasks receive
+	// a child of it in their environment.
+	TraceParent string
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -11349,6 +11810,8 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 	}
 
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
+	na.UsageSummary = a.UsageSummary.Copy()
+	na.BillingRecord = a.BillingRecord.Copy()
 	na.PreemptedAllocations = slices.Clone(a.PreemptedAllocations)
 	return na
 }
@@ -11396,7 +11859,47 @@ func (a *Allocation) ShouldReschedule(reschedulePolicy *ReschedulePolicy, failTi
 // RescheduleEligible returns if the allocation is eligible to be rescheduled according
 // to its ReschedulePolicy and the current state of its reschedule trackers
 func (a *Allocation) RescheduleEligible(reschedulePolicy *ReschedulePolicy, failTime time.Time) bool {
-	return a.RescheduleTracker.RescheduleEligible(reschedulePolicy, failTime)
+	return a.RescheduleTracker.RescheduleEligible(reschedulePolicy, failTime) &&
+		!a.OOMKillLimitReached(reschedulePolicy)
+}
+
+// OOMKilled returns whether the allocation failed because one of its tasks
+// was OOM-killed.
+func (a *Allocation) OOMKilled() bool {
+	if a.ClientStatus != AllocClientStatusFailed {
+		return false
+	}
+	for _, ts := range a.TaskStates {
+		if ts.Failed && ts.ExitReason == TaskExitReasonOOMKilled {
+			return true
+		}
+	}
+	return false
+}
+
+// OOMKills returns the number of consecutive allocations of its job version,
+// up to and including this allocation, that were OOM-killed, according to
+// its reschedule tracker.
+func (a *Allocation) OOMKills() int {
+	if !a.OOMKilled() {
+		return 0
+	}
+	kills := 1
+	rt := a.RescheduleTracker
+	if rt == nil || len(rt.Events) == 0 || a.Job == nil {
+		return kills
+	}
+	if rt.Events[len(rt.Events)-1].JobVersion == a.Job.Version {
+		kills += rt.OOMKills
+	}
+	return kills
+}
+
+// OOMKillLimitReached returns whether the allocation must not be rescheduled
+// because its job version was OOM-killed MaxOOMKills times in a row.
+func (a *Allocation) OOMKillLimitReached(reschedulePolicy *ReschedulePolicy) bool {
+	return reschedulePolicy != nil && reschedulePolicy.MaxOOMKills > 0 &&
+		a.OOMKills() >= reschedulePolicy.MaxOOMKills
 }
 
 func (a *Allocation) RescheduleInfo() (int, int) {
@@ -11461,6 +11964,9 @@ func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 }
 
 func (a *Allocation) nextRescheduleTime(failTime time.Time, reschedulePolicy *ReschedulePolicy) (time.Time, bool) {
+	if a.OOMKillLimitReached(reschedulePolicy) {
+		return time.Time{}, false
+	}
 	nextDelay := a.NextDelay()
 	nextRescheduleTime := failTime.Add(nextDelay)
 	rescheduleEligible := reschedulePolicy.Unlimited || (reschedulePolicy.Attempts > 0 && a.RescheduleTracker == nil)
@@ -11597,6 +12103,28 @@ func (a *Allocation) NextDelay() time.Duration {
 	if policy == nil {
 		return 0
 	}
+	return a.oomKillDelay(policy, a.rescheduleDelay(policy))
+}
+
+// oomKillDelay doubles the reschedule delay for each consecutive OOM kill of
+// the job version of the allocation before this one, up to the MaxDelay of
+// the policy.
+func (a *Allocation) oomKillDelay(policy *ReschedulePolicy, delayDur time.Duration) time.Duration {
+	if policy.MaxOOMKills == 0 {
+		return delayDur
+	}
+	for i := 1; i < a.OOMKills(); i++ {
+		delayDur *= 2
+		if policy.MaxDelay > 0 && delayDur >= policy.MaxDelay {
+			return policy.MaxDelay
+		}
+	}
+	return delayDur
+}
+
+// rescheduleDelay returns the reschedule delay of the allocation according
+// to the delay function of the policy.
+func (a *Allocation) rescheduleDelay(policy *ReschedulePolicy) time.Duration {
 	delayDur := policy.Delay
 	if a.RescheduleTracker == nil || a.RescheduleTracker.Events == nil || len(a.RescheduleTracker.Events) == 0 {
 		return delayDur
@@ -11758,6 +12286,7 @@ func (a *Allocation) Stub(fields *AllocStubFields) *AllocListStub {
 		ModifyIndex:           a.ModifyIndex,
 		CreateTime:            a.CreateTime,
 		ModifyTime:            a.ModifyTime,
+		Usage:                 a.stubUsage(),
 	}
 
 	if fields != nil {
@@ -11772,6 +12301,29 @@ func (a *Allocation) Stub(fields *AllocStubFields) *AllocListStub {
 	return s
 }
 
+// stubUsage returns the usage of a running allocation for its list stub, or
+// nil if the allocation is not running or its client did not report usage.
+func (a *Allocation) stubUsage() *AllocStubUsage {
+	if a.UsageSummary == nil || a.ClientStatus != AllocClientStatusRunning {
+		return nil
+	}
+
+	usage := &AllocStubUsage{
+		MemoryMB:  a.UsageSummary.MemoryMB,
+		Timestamp: a.UsageSummary.Timestamp,
+	}
+	if a.AllocatedResources != nil {
+		var cpu int64
+		for _, tr := range a.AllocatedResources.Tasks {
+			cpu += tr.Cpu.CpuShares
+		}
+		if cpu > 0 {
+			usage.CPUPercent = float64(a.UsageSummary.CPU) / float64(cpu) * 100
+		}
+	}
+	return usage
+}
+
 // AllocationDiff converts an Allocation type to an AllocationDiff type
 // If at any time, modification are made to AllocationDiff so that an
 // Allocation can no longer be safely converted to AllocationDiff,
@@ -11887,8 +12439,7 @@ func (a *Allocation) LastRescheduleFailed() bool {
 	if a.RescheduleTracker == nil {
 		return false
 	}
-	return a.RescheduleTracker.LastReschedule != "" &&
-		a.RescheduleTracker.LastReschedule != LastRescheduleSuccess
+	return a.RescheduleTracker.LastReschedule == LastRescheduleFailedToPlace
 }
 
 // AllocationDiff is another named type for Allocation (to use the same fields),
@@ -11925,6 +12476,21 @@ type AllocListStub struct {
 	ModifyIndex           uint64
 	CreateTime            int64
 	ModifyTime            int64
+	Usage                 *AllocStubUsage `json:",omitempty"`
+}
+
+// AllocStubUsage is the resource usage of a running allocation as last
+// reported by its client, summarized for allocation lists.
+type AllocStubUsage struct {
+	// CPUPercent is the CPU used by the allocation in percent of its
+	// allocated CPU
+	CPUPercent float64
+
+	// MemoryMB is the memory used by the allocation in MB
+	MemoryMB int
+
+	// Timestamp is when the usage was collected, in Unix nanoseconds
+	Timestamp int64
 }
 
 // SetEventDisplayMessages populates the display message if its not already
@@ -12268,6 +12834,76 @@ func (a *AllocNetworkStatus) IsZero() bool {
 	return true
 }
 
+// AllocUsageSummary is a compact summary of the resource usage of an
+// allocation's tasks, which clients include in their allocation updates.
+type AllocUsageSummary struct {
+	// CPU is the CPU used by the tasks in MHz
+	CPU int
+
+	// MemoryMB is the memory used by the tasks in MB
+	MemoryMB int
+
+	// Timestamp is when the summarized stats were collected, in Unix
+	// nanoseconds
+	Timestamp int64
+
+	// Partial is set while the stats collection of a task is paused, so the
+	// summary reports its last sample rather than its current usage
+	Partial bool
+
+	// Tasks is the usage of each task that reported stats, keyed by task
+	// name
+	Tasks map[string]*TaskUsageSummary
+}
+
+func (s *AllocUsageSummary) Copy() *AllocUsageSummary {
+	if s == nil {
+		return nil
+	}
+	ns := *s
+	ns.Tasks = helper.DeepCopyMap(s.Tasks)
+	return &ns
+}
+
+// Equal returns true if both summaries report the same usage, even if it
+// was collected at different times.
+func (s *AllocUsageSummary) Equal(o *AllocUsageSummary) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	if s.CPU != o.CPU || s.MemoryMB != o.MemoryMB || s.Partial != o.Partial {
+		return false
+	}
+	return maps.EqualFunc(s.Tasks, o.Tasks, func(a, b *TaskUsageSummary) bool {
+		return a.Equal(b)
+	})
+}
+
+// TaskUsageSummary is the resource usage of a single task in an
+// AllocUsageSummary.
+type TaskUsageSummary struct {
+	// CPU is the CPU used by the task in MHz
+	CPU int
+
+	// MemoryMB is the memory used by the task in MB
+	MemoryMB int
+}
+
+func (s *TaskUsageSummary) Copy() *TaskUsageSummary {
+	if s == nil {
+		return nil
+	}
+	ns := *s
+	return &ns
+}
+
+func (s *TaskUsageSummary) Equal(o *TaskUsageSummary) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return *s == *o
+}
+
 // NetworkStatus is an interface satisfied by alloc runner, for acquiring the
 // network status of an allocation.
 type NetworkStatus interface {
@@ -12446,6 +13082,10 @@ const (
 	// active key
 	CoreJobVariablesRekey = "variables-rekey"
 
+	// CoreJobUsageSampleGC is used for the garbage collection of the usage
+	// samples of allocations older than the usage history retention.
+	CoreJobUsageSampleGC = "usage-sample-gc"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -12569,6 +13209,12 @@ type Evaluation struct {
 	// the SnapshotIndex being less than the CreateIndex.
 	SnapshotIndex uint64
 
+	// TraceParent is the W3C trace context carried by this evaluation, set
+	// when the triggering request (such as a job dispatch) started or joined
+	// a trace. Allocations placed by this evaluation and follow-up
+	// evaluations derive their trace context from it.
+	TraceParent string
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -12777,6 +13423,7 @@ func (e *Evaluation) NextRollingEval(wait time.Duration) *Evaluation {
 		Status:         EvalStatusPending,
 		Wait:           wait,
 		PreviousEval:   e.ID,
+		TraceParent:    tracecontext.ChildIfSet(e.TraceParent),
 		CreateTime:     now,
 		ModifyTime:     now,
 	}
@@ -12803,6 +13450,7 @@ func (e *Evaluation) CreateBlockedEval(classEligibility map[string]bool,
 		ClassEligibility:     classEligibility,
 		EscapedComputedClass: escaped,
 		QuotaLimitReached:    quotaReached,
+		TraceParent:          tracecontext.ChildIfSet(e.TraceParent),
 		CreateTime:           now,
 		ModifyTime:           now,
 	}
@@ -12825,6 +13473,7 @@ func (e *Evaluation) CreateFailedFollowUpEval(wait time.Duration) *Evaluation {
 		Status:         EvalStatusPending,
 		Wait:           wait,
 		PreviousEval:   e.ID,
+		TraceParent:    tracecontext.ChildIfSet(e.TraceParent),
 		CreateTime:     now,
 		ModifyTime:     now,
 	}
@@ -2349,6 +2349,29 @@ func TestTask_Validate_Resources(t *testing.T) {
 			},
 			err: "numa affinity must be one of none, prefer, or require",
 		},
+		{
+			name: "numa memory policy without cores",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				NUMA: &NUMA{
+					Affinity:     "none",
+					MemoryPolicy: "bind",
+				},
+			},
+			err: "numa memory_policy requires cores to be reserved",
+		},
+		{
+			name: "numa memory policy",
+			res: &Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA: &NUMA{
+					Affinity:     "require",
+					MemoryPolicy: "interleave",
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -3170,6 +3193,32 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestLogConfig_Validate_RateLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	l := DefaultLogConfig()
+	l.MaxBytesPerSecond = 1024
+	l.RateLimitMode = LogRateLimitModeBlock
+	must.NoError(t, l.Validate(nil))
+
+	l.MaxLinesPerSecond = -1
+	l.RateLimitMode = "throttle"
+	err := l.Validate(nil)
+	must.ErrorContains(t, err, "max_lines_per_second must not be negative")
+	must.ErrorContains(t, err, `rate_limit_mode must be "drop" or "block"`)
+}
+
+func TestLogConfig_Validate_MultilinePattern(t *testing.T) {
+	ci.Parallel(t)
+
+	l := DefaultLogConfig()
+	l.MultilinePattern = `^\S`
+	must.NoError(t, l.Validate(nil))
+
+	l.MultilinePattern = `^(`
+	must.ErrorContains(t, l.Validate(nil), "invalid multiline_pattern")
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3197,6 +3246,18 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equal(b))
 	})
 
+	t.Run("rate limit", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MaxBytesPerSecond: 1024}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MaxBytesPerSecond: 1024, RateLimitMode: LogRateLimitModeBlock}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("multiline pattern", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MultilinePattern: `^\S`}
+		require.False(t, a.Equal(b))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -3795,6 +3856,9 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		ProgressDeadline: -25,
 		AutoRevert:       false,
 		Canary:           -1,
+
+		CanaryMaxCPURatio:    -1,
+		CanaryMaxMemoryRatio: 1.2,
 	}
 
 	err := u.Validate()
@@ -3802,6 +3866,8 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		"Invalid health check given",
 		"Max parallel can not be less than zero",
 		"Canary count can not be less than zero",
+		"Canary max CPU ratio can not be less than zero",
+		"Canary usage ratios require Auto Promote",
 		"Minimum healthy time may not be less than zero",
 		"Healthy deadline must be greater than zero",
 		"Progress deadline must be zero or greater",
@@ -4707,6 +4773,32 @@ func TestRestartPolicy_Validate(t *testing.T) {
 	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Interval can not be less than") {
 		t.Fatalf("expect interval too small error, got: %v", err)
 	}
+
+	// Fails when the resource trigger has no threshold
+	p = &RestartPolicy{
+		Mode:            RestartPolicyModeFail,
+		Interval:        5 * time.Second,
+		ResourceTrigger: &RestartResourceTrigger{Duration: time.Minute},
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "at least one of") {
+		t.Fatalf("expect resource trigger error, got: %v", err)
+	}
+
+	// Fails when the CPU throttled ratio is out of range
+	p.ResourceTrigger = &RestartResourceTrigger{CPUThrottledRatio: 1.5}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "cpu_throttled_ratio") {
+		t.Fatalf("expect cpu_throttled_ratio error, got: %v", err)
+	}
+
+	// Fails when the OOM memory step is negative
+	p = &RestartPolicy{
+		Mode:            RestartPolicyModeFail,
+		Interval:        5 * time.Second,
+		OOMMemoryStepMB: -1,
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "OOM memory step") {
+		t.Fatalf("expect OOM memory step error, got: %v", err)
+	}
 }
 
 func TestReschedulePolicy_Validate(t *testing.T) {
@@ -6371,6 +6463,65 @@ func TestAllocation_Canonicalize_New(t *testing.T) {
 	require.Equal(t, copy, alloc)
 }
 
+func TestAllocation_OOMKills(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &ReschedulePolicy{
+		DelayFunction: "constant",
+		Delay:         30 * time.Second,
+		MaxDelay:      time.Minute,
+		Unlimited:     true,
+		MaxOOMKills:   3,
+	}
+	job := testJob()
+	job.Version = 2
+	job.TaskGroups[0].ReschedulePolicy = policy
+
+	alloc := &Allocation{
+		Job:          job,
+		TaskGroup:    job.TaskGroups[0].Name,
+		ClientStatus: AllocClientStatusFailed,
+		TaskStates: map[string]*TaskState{
+			"web": {State: TaskStateDead, Failed: true, ExitReason: TaskExitReasonOOMKilled, FinishedAt: time.Now()},
+		},
+		RescheduleTracker: &RescheduleTracker{
+			Events: []*RescheduleEvent{
+				{JobVersion: 1, OOMKilled: true},
+				{JobVersion: 2, OOMKilled: true},
+			},
+			OOMKills: 1,
+		},
+	}
+
+	// The consecutive OOM kills of the job version are counted, and each one
+	// doubles the reschedule delay up to the max delay
+	must.True(t, alloc.OOMKilled())
+	must.Eq(t, 2, alloc.OOMKills())
+	must.Eq(t, time.Minute, alloc.NextDelay())
+	_, eligible := alloc.NextRescheduleTime()
+	must.True(t, eligible)
+	must.False(t, alloc.OOMKillLimitReached(policy))
+
+	// Allocations reaching the limit aren't rescheduled
+	alloc.RescheduleTracker.OOMKills = 3
+	must.Eq(t, 4, alloc.OOMKills())
+	must.True(t, alloc.OOMKillLimitReached(policy))
+	_, eligible = alloc.NextRescheduleTime()
+	must.False(t, eligible)
+	must.False(t, alloc.RescheduleEligible(policy, time.Now()))
+
+	// The OOM kills of other job versions aren't counted
+	alloc.RescheduleTracker.Events[1].JobVersion = 1
+	must.Eq(t, 1, alloc.OOMKills())
+	alloc.RescheduleTracker.Events[1].JobVersion = 2
+
+	// Allocations failing for other reasons aren't penalized
+	alloc.TaskStates["web"].ExitReason = TaskExitReasonNonZeroExit
+	must.Eq(t, 0, alloc.OOMKills())
+	must.Eq(t, 30*time.Second, alloc.NextDelay())
+	must.True(t, alloc.RescheduleEligible(policy, time.Now()))
+}
+
 func TestRescheduleTracker_Copy(t *testing.T) {
 	ci.Parallel(t)
 	type testCase struct {
@@ -8328,3 +8479,26 @@ func TestTaskIdentity_Canonicalize(t *testing.T) {
 	must.True(t, task.Identities[1].Env)
 	must.False(t, task.Identities[1].File)
 }
+
+func TestAllocation_Stub_Usage(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		Job:          &Job{Type: JobTypeService},
+		ClientStatus: AllocClientStatusRunning,
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web":     {Cpu: AllocatedCpuResources{CpuShares: 300}},
+				"sidecar": {Cpu: AllocatedCpuResources{CpuShares: 100}},
+			},
+		},
+		UsageSummary: &AllocUsageSummary{CPU: 100, MemoryMB: 48, Timestamp: 1},
+	}
+
+	stub := alloc.Stub(nil)
+	must.Eq(t, &AllocStubUsage{CPUPercent: 25, MemoryMB: 48, Timestamp: 1}, stub.Usage)
+
+	// the usage of stopped allocations is stale
+	alloc.ClientStatus = AllocClientStatusComplete
+	must.Nil(t, alloc.Stub(nil).Usage)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// NodeBackfillUsageRPCMethod is the RPC method clients upload the usage
+	// summaries they could not report while disconnected with.
+	//
+	// Args: NodeUsageBackfillRequest
+	// Reply: GenericResponse
+	NodeBackfillUsageRPCMethod = "Node.BackfillUsage"
+
+	// NodeReapUsageSamplesRPCMethod is the RPC method the core scheduler
+	// garbage collects expired usage samples with.
+	//
+	// Args: UsageSamplesUpsertRequest
+	// Reply: GenericResponse
+	NodeReapUsageSamplesRPCMethod = "Node.ReapUsageSamples"
+
+	// JobUsageHistoryRPCMethod is the RPC method for reading the usage
+	// samples of a job's allocations.
+	//
+	// Args: JobUsageHistoryRequest
+	// Reply: JobUsageHistoryResponse
+	JobUsageHistoryRPCMethod = "Job.UsageHistory"
+
+	// MaxUsageBackfillSamples is the maximum number of usage summaries a
+	// client uploads in one backfill request.
+	MaxUsageBackfillSamples = 1000
+
+	// maxUsageBackfillSize bounds the decompressed size of a backfill
+	// request, so a corrupt or malicious payload can't exhaust the memory of
+	// the servers.
+	maxUsageBackfillSize = 16 * 1024 * 1024
+)
+
+// UsageSample is a usage summary of an allocation at a point in time, as
+// reported by its client. The leader records a sample when a client reports a
+// summary at least the server's usage_history_interval after the allocation's
+// latest sample, and clients backfill the summaries they could not report
+// while disconnected. Samples are garbage collected after the server's
+// usage_history_retention, and with their allocation.
+type UsageSample struct {
+	Namespace string
+	JobID     string
+	TaskGroup string
+	AllocID   string
+	NodeID    string
+
+	// CPU is the CPU used by the allocation's tasks in MHz
+	CPU int
+
+	// MemoryMB is the memory used by the allocation's tasks in MB
+	MemoryMB int
+
+	// Timestamp is when the summarized stats were collected, in Unix
+	// nanoseconds
+	Timestamp int64
+
+	// Tasks is the usage of each task, keyed by task name
+	Tasks map[string]*TaskUsageSummary
+
+	// Backfilled is true if the client uploaded the sample after
+	// reconnecting, rather than reporting it with an allocation update
+	Backfilled bool
+
+	CreateIndex uint64
+}
+
+// NewUsageSample returns the usage sample of a summary of the allocation.
+func NewUsageSample(alloc *Allocation, summary *AllocUsageSummary) *UsageSample {
+	return &UsageSample{
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		TaskGroup: alloc.TaskGroup,
+		AllocID:   alloc.ID,
+		NodeID:    alloc.NodeID,
+		CPU:       summary.CPU,
+		MemoryMB:  summary.MemoryMB,
+		Timestamp: summary.Timestamp,
+		Tasks:     helper.DeepCopyMap(summary.Tasks),
+	}
+}
+
+// AllocUsageBackfill is the usage summaries of an allocation a client could
+// not report to the servers.
+type AllocUsageBackfill struct {
+	AllocID   string
+	Summaries []*AllocUsageSummary
+}
+
+// NodeUsageBackfillRequest uploads the usage summaries a client buffered
+// while it could not reach the servers.
+type NodeUsageBackfillRequest struct {
+	NodeID string
+
+	// Backfill is the gzip compressed msgpack encoding of the
+	// AllocUsageBackfill of each allocation, as returned by
+	// EncodeUsageBackfill.
+	Backfill []byte
+
+	WriteRequest
+}
+
+// UsageSamplesUpsertRequest is the raft request recording usage samples and
+// garbage collecting expired ones.
+type UsageSamplesUpsertRequest struct {
+	Samples []*UsageSample
+
+	// PruneBefore is the time in Unix nanoseconds before which the samples
+	// of all allocations are removed.
+	PruneBefore int64
+
+	WriteRequest
+}
+
+// AllocUsageUpdateRequest is the raft request recording the usage summaries
+// clients sent with their heartbeats. Unlike client allocation updates, it
+// only replaces the usage summary of each allocation.
+type AllocUsageUpdateRequest struct {
+	// Summaries is the usage summary of each allocation, keyed by allocation
+	// ID.
+	Summaries map[string]*AllocUsageSummary
+
+	// Samples are the usage samples recorded for the summaries.
+	Samples []*UsageSample
+
+	WriteRequest
+}
+
+// JobUsageHistoryRequest reads the usage samples of a job's allocations.
+type JobUsageHistoryRequest struct {
+	JobID string
+
+	// Since and Until bound the timestamps of the returned samples,
+	// inclusive, in Unix nanoseconds. Zero means unbounded.
+	Since int64
+	Until int64
+
+	QueryOptions
+}
+
+// JobUsageHistoryResponse is the usage samples of a job's allocations,
+// ordered by allocation and timestamp.
+type JobUsageHistoryResponse struct {
+	Samples []*UsageSample
+	QueryMeta
+}
+
+// EncodeUsageBackfill compresses the backfills for a NodeUsageBackfillRequest.
+func EncodeUsageBackfill(backfills []*AllocUsageBackfill) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := codec.NewEncoder(zw, MsgpackHandle).Encode(backfills); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeUsageBackfill decompresses the backfills of a
+// NodeUsageBackfillRequest.
+func DecodeUsageBackfill(data []byte) ([]*AllocUsageBackfill, error) {
+	raw, err := gunzipLimited(data, maxUsageBackfillSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid usage backfill: %w", err)
+	}
+
+	var backfills []*AllocUsageBackfill
+	if err := codec.NewDecoderBytes(raw, MsgpackHandle).Decode(&backfills); err != nil {
+		return nil, fmt.Errorf("invalid usage backfill: %w", err)
+	}
+	return backfills, nil
+}
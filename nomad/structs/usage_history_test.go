@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestUsageBackfill_EncodeDecode(t *testing.T) {
+	ci.Parallel(t)
+
+	backfills := []*AllocUsageBackfill{{
+		AllocID: "alloc",
+		Summaries: []*AllocUsageSummary{{
+			CPU:       250,
+			MemoryMB:  64,
+			Timestamp: 1700000000000000000,
+			Tasks:     map[string]*TaskUsageSummary{"web": {CPU: 250, MemoryMB: 64}},
+		}},
+	}}
+
+	payload, err := EncodeUsageBackfill(backfills)
+	must.NoError(t, err)
+	decoded, err := DecodeUsageBackfill(payload)
+	must.NoError(t, err)
+	must.Eq(t, backfills, decoded)
+
+	_, err = DecodeUsageBackfill([]byte("not gzip"))
+	must.ErrorContains(t, err, "invalid usage backfill")
+
+	// A payload that decompresses past the limit is rejected
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write(make([]byte, maxUsageBackfillSize+1))
+	must.NoError(t, err)
+	must.NoError(t, zw.Close())
+	_, err = DecodeUsageBackfill(buf.Bytes())
+	must.ErrorContains(t, err, "exceeds maximum size")
+}
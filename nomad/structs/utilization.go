@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// JobUtilizationRPCMethod is the RPC method for rolling up the resource
+	// usage of a job's running allocations.
+	//
+	// Args: JobSpecificRequest
+	// Reply: JobUtilizationResponse
+	JobUtilizationRPCMethod = "Job.Utilization"
+)
+
+// JobUtilizationResponse is the response object when rolling up the resource
+// usage of a job.
+type JobUtilizationResponse struct {
+	Utilization *JobUtilization
+	QueryMeta
+}
+
+// JobUtilization is a rollup of the usage summaries the clients reported for
+// the running allocations of a job, compared against what the allocations
+// reserve.
+type JobUtilization struct {
+	Namespace string
+	JobID     string
+
+	// TaskGroups is the utilization of each task group with running
+	// allocations that reported usage, keyed by task group name
+	TaskGroups map[string]*TaskGroupUtilization
+}
+
+// TaskGroupUtilization is the utilization of the tasks of a task group.
+type TaskGroupUtilization struct {
+	// Allocations is the number of running allocations whose usage was
+	// rolled up
+	Allocations int
+
+	// Tasks is the utilization of each task, keyed by task name
+	Tasks map[string]*TaskUtilization
+}
+
+// TaskUtilization compares the usage of a task across allocations against
+// its reserved resources.
+type TaskUtilization struct {
+	// ReservedCPU is the CPU reserved for the task in MHz, including the CPU
+	// of reserved cores
+	ReservedCPU int
+
+	// ReservedMemoryMB and ReservedMemoryMaxMB are the memory reserved for
+	// the task and the memory it may oversubscribe up to
+	ReservedMemoryMB    int
+	ReservedMemoryMaxMB int
+
+	// CPU is the distribution of the CPU used by the task in MHz
+	CPU UsagePercentiles
+
+	// MemoryMB is the distribution of the memory used by the task in MB
+	MemoryMB UsagePercentiles
+}
+
+// UsagePercentiles is the distribution of a usage value, such as across
+// allocations or over time.
+type UsagePercentiles struct {
+	P50 int
+	P95 int
+	Max int
+}
+
+// NewJobUtilization rolls up the usage summaries of the running allocations
+// of a job. The reserved resources of a task are those of its most recently
+// created allocation, so they reflect the latest job version.
+func NewJobUtilization(namespace, jobID string, allocs []*Allocation) *JobUtilization {
+	r := newUtilizationRollup()
+	for _, alloc := range allocs {
+		usage := alloc.UsageSummary
+		if alloc.ClientStatus != AllocClientStatusRunning || usage == nil || len(usage.Tasks) == 0 {
+			continue
+		}
+		r.add(alloc.TaskGroup, alloc.ID, usage.Tasks, alloc)
+	}
+	return r.utilization(namespace, jobID)
+}
+
+// NewUsageHistoryUtilization rolls up the usage samples of the allocations of
+// a job into the distribution of the usage of each task over time. The
+// allocations of a task group are those with samples of the group. Samples
+// don't carry the resources the allocations reserve, so the reserved
+// resources of the tasks are left unset.
+func NewUsageHistoryUtilization(namespace, jobID string, samples []*UsageSample) *JobUtilization {
+	r := newUtilizationRollup()
+	for _, sample := range samples {
+		r.add(sample.TaskGroup, sample.AllocID, sample.Tasks, nil)
+	}
+	return r.utilization(namespace, jobID)
+}
+
+// utilizationRollup collects the usage of the tasks of the allocations of a
+// job, keyed by task group and task name.
+type utilizationRollup struct {
+	allocs map[string]map[string]struct{}
+	tasks  map[string]map[string]*utilizationSamples
+}
+
+// utilizationSamples is the usage of a task across samples, and the most
+// recently created allocation that reserves resources for it.
+type utilizationSamples struct {
+	cpu, memory []int
+	reserved    *Allocation
+}
+
+func newUtilizationRollup() *utilizationRollup {
+	return &utilizationRollup{
+		allocs: make(map[string]map[string]struct{}),
+		tasks:  make(map[string]map[string]*utilizationSamples),
+	}
+}
+
+// add records the usage of the tasks of an allocation of the task group. The
+// allocation whose resources the tasks reserve may be nil.
+func (r *utilizationRollup) add(group, allocID string, usage map[string]*TaskUsageSummary, alloc *Allocation) {
+	if r.allocs[group] == nil {
+		r.allocs[group] = make(map[string]struct{})
+		r.tasks[group] = make(map[string]*utilizationSamples)
+	}
+	r.allocs[group][allocID] = struct{}{}
+	for name, ts := range usage {
+		s := r.tasks[group][name]
+		if s == nil {
+			s = new(utilizationSamples)
+			r.tasks[group][name] = s
+		}
+		s.cpu = append(s.cpu, ts.CPU)
+		s.memory = append(s.memory, ts.MemoryMB)
+		if alloc != nil && (s.reserved == nil || alloc.CreateIndex > s.reserved.CreateIndex) {
+			s.reserved = alloc
+		}
+	}
+}
+
+func (r *utilizationRollup) utilization(namespace, jobID string) *JobUtilization {
+	u := &JobUtilization{
+		Namespace:  namespace,
+		JobID:      jobID,
+		TaskGroups: make(map[string]*TaskGroupUtilization, len(r.allocs)),
+	}
+	for group, ids := range r.allocs {
+		tgu := &TaskGroupUtilization{
+			Allocations: len(ids),
+			Tasks:       make(map[string]*TaskUtilization, len(r.tasks[group])),
+		}
+		for name, s := range r.tasks[group] {
+			tu := &TaskUtilization{
+				CPU:      NewUsagePercentiles(s.cpu),
+				MemoryMB: NewUsagePercentiles(s.memory),
+			}
+			if s.reserved != nil && s.reserved.AllocatedResources != nil {
+				if res, ok := s.reserved.AllocatedResources.Tasks[name]; ok {
+					tu.ReservedCPU = int(res.Cpu.CpuShares)
+					tu.ReservedMemoryMB = int(res.Memory.MemoryMB)
+					tu.ReservedMemoryMaxMB = int(res.Memory.MemoryMaxMB)
+				}
+			}
+			tgu.Tasks[name] = tu
+		}
+		u.TaskGroups[group] = tgu
+	}
+	return u
+}
+
+// NewUsagePercentiles computes the nearest-rank percentiles of the values,
+// which it sorts in place.
+func NewUsagePercentiles(values []int) UsagePercentiles {
+	if len(values) == 0 {
+		return UsagePercentiles{}
+	}
+	slices.Sort(values)
+	rank := func(p float64) int {
+		i := int(math.Ceil(p*float64(len(values)))) - 1
+		return values[max(i, 0)]
+	}
+	return UsagePercentiles{
+		P50: rank(0.5),
+		P95: rank(0.95),
+		Max: values[len(values)-1],
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/shoenig/test/must"
+)
+
+func TestNewJobUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := func(index uint64, status string, cpu, memoryMB int, reservedCPU int64) *Allocation {
+		return &Allocation{
+			ID:           uuid.Generate(),
+			TaskGroup:    "web",
+			ClientStatus: status,
+			CreateIndex:  index,
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"server": {
+						Cpu:    AllocatedCpuResources{CpuShares: reservedCPU},
+						Memory: AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 512},
+					},
+				},
+			},
+			UsageSummary: &AllocUsageSummary{
+				Tasks: map[string]*TaskUsageSummary{
+					"server": {CPU: cpu, MemoryMB: memoryMB},
+				},
+			},
+		}
+	}
+
+	allocs := []*Allocation{
+		alloc(10, AllocClientStatusRunning, 100, 64, 500),
+		alloc(30, AllocClientStatusRunning, 300, 32, 1000),
+		alloc(20, AllocClientStatusRunning, 200, 128, 500),
+
+		// allocations that aren't running or reported no usage are left out
+		alloc(40, AllocClientStatusComplete, 900, 900, 500),
+		{TaskGroup: "web", ClientStatus: AllocClientStatusRunning, CreateIndex: 50},
+	}
+
+	u := NewJobUtilization("default", "example", allocs)
+	must.Eq(t, "example", u.JobID)
+	must.MapLen(t, 1, u.TaskGroups)
+
+	tgu := u.TaskGroups["web"]
+	must.Eq(t, 3, tgu.Allocations)
+	must.Eq(t, &TaskUtilization{
+		// the reserved resources are those of the latest allocation
+		ReservedCPU:         1000,
+		ReservedMemoryMB:    256,
+		ReservedMemoryMaxMB: 512,
+		CPU:                 UsagePercentiles{P50: 200, P95: 300, Max: 300},
+		MemoryMB:            UsagePercentiles{P50: 64, P95: 128, Max: 128},
+	}, tgu.Tasks["server"])
+}
+
+func TestNewUsageHistoryUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	sample := func(group, allocID string, cpu, memoryMB int) *UsageSample {
+		return &UsageSample{
+			TaskGroup: group,
+			AllocID:   allocID,
+			Tasks: map[string]*TaskUsageSummary{
+				"server": {CPU: cpu, MemoryMB: memoryMB},
+			},
+		}
+	}
+
+	samples := []*UsageSample{
+		sample("web", "a", 100, 64),
+		sample("web", "a", 300, 32),
+		sample("web", "b", 200, 128),
+		sample("db", "c", 50, 512),
+	}
+
+	u := NewUsageHistoryUtilization("default", "example", samples)
+	must.Eq(t, "example", u.JobID)
+	must.MapLen(t, 2, u.TaskGroups)
+
+	// the allocations are counted once however many samples they have
+	tgu := u.TaskGroups["web"]
+	must.Eq(t, 2, tgu.Allocations)
+	must.Eq(t, &TaskUtilization{
+		CPU:      UsagePercentiles{P50: 200, P95: 300, Max: 300},
+		MemoryMB: UsagePercentiles{P50: 64, P95: 128, Max: 128},
+	}, tgu.Tasks["server"])
+	must.Eq(t, 1, u.TaskGroups["db"].Allocations)
+}
+
+func TestNewUsagePercentiles(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, UsagePercentiles{}, NewUsagePercentiles(nil))
+	must.Eq(t, UsagePercentiles{P50: 7, P95: 7, Max: 7}, NewUsagePercentiles([]int{7}))
+
+	values := make([]int, 0, 100)
+	for i := 100; i > 0; i-- {
+		values = append(values, i)
+	}
+	must.Eq(t, UsagePercentiles{P50: 50, P95: 95, Max: 100}, NewUsagePercentiles(values))
+}
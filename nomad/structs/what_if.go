@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+const (
+	// JobWhatIfRPCMethod is the RPC method for simulating the placement of a
+	// job against the current state of the cluster and the observed usage of
+	// its nodes.
+	//
+	// Args: JobWhatIfRequest
+	// Reply: JobWhatIfResponse
+	JobWhatIfRPCMethod = "Job.WhatIf"
+)
+
+// JobWhatIfRequest is used for the Job.WhatIf endpoint to simulate the
+// placement of a job, such as one with modified resources.
+type JobWhatIfRequest struct {
+	Job *Job
+
+	// PolicyOverride is set when the user is attempting to override any
+	// policies
+	PolicyOverride bool
+
+	WriteRequest
+}
+
+// JobWhatIfResponse is the response of the Job.WhatIf endpoint.
+type JobWhatIfResponse struct {
+	// Feasible is whether every allocation of the job could be placed
+	Feasible bool
+
+	// FailedTGAllocs is the placement failures per task group
+	FailedTGAllocs map[string]*AllocMetric
+
+	// Nodes is the projected impact on each node the plan places
+	// allocations on or stops allocations from, ordered from the node with
+	// the highest projected pressure
+	Nodes []*NodeWhatIfImpact
+
+	// Warnings contains any warnings about the given job
+	Warnings string
+
+	WriteMeta
+}
+
+// NodeWhatIfImpact is the observed and projected usage of a node if a plan
+// were applied. The usage of an allocation is the usage its client last
+// reported, or its reservation if it hasn't reported any. New allocations
+// are projected to use all of their reservation.
+type NodeWhatIfImpact struct {
+	NodeID     string
+	NodeName   string
+	Datacenter string
+
+	// Placed and Stopped are the number of allocations the plan places on
+	// and stops from the node. Placed includes in-place updates.
+	Placed  int
+	Stopped int
+
+	// CPUCapacity and MemoryCapacityMB are the resources of the node
+	// available to allocations
+	CPUCapacity      int64
+	MemoryCapacityMB int64
+
+	// CurrentCPU and CurrentMemoryMB are the observed usage of the node's
+	// allocations, and ProjectedCPU and ProjectedMemoryMB their usage once
+	// the plan is applied
+	CurrentCPU        int64
+	CurrentMemoryMB   int64
+	ProjectedCPU      int64
+	ProjectedMemoryMB int64
+
+	// CurrentPressure and ProjectedPressure are the percentage of the
+	// capacity of the busier of the CPU and memory of the node that is used
+	CurrentPressure   float64
+	ProjectedPressure float64
+}
+
+// NewNodeWhatIfImpact projects the usage of the node if the allocations of
+// the plan for the node were placed, and the stopped allocations stopped.
+// The allocs are the allocations on the node before the plan is applied.
+func NewNodeWhatIfImpact(node *Node, allocs, placed, stopped []*Allocation) *NodeWhatIfImpact {
+	impact := &NodeWhatIfImpact{
+		NodeID:     node.ID,
+		NodeName:   node.Name,
+		Datacenter: node.Datacenter,
+		Stopped:    len(stopped),
+	}
+
+	if capacity := node.NodeResources.Comparable(); capacity != nil {
+		capacity.Subtract(node.ReservedResources.Comparable())
+		impact.CPUCapacity = capacity.Flattened.Cpu.CpuShares
+		impact.MemoryCapacityMB = capacity.Flattened.Memory.MemoryMB
+	}
+
+	stopping := make(map[string]bool, len(stopped))
+	for _, alloc := range stopped {
+		stopping[alloc.ID] = true
+	}
+	updated := make(map[string]*Allocation, len(placed))
+	for _, alloc := range placed {
+		updated[alloc.ID] = alloc
+	}
+
+	for _, alloc := range allocs {
+		if alloc.ClientTerminalStatus() {
+			continue
+		}
+		cpu, memory := observedAllocUsage(alloc)
+		impact.CurrentCPU += cpu
+		impact.CurrentMemoryMB += memory
+		if stopping[alloc.ID] {
+			continue
+		}
+
+		// An in-place update keeps the observed usage of the allocation,
+		// up to its new reservation
+		if update, ok := updated[alloc.ID]; ok {
+			delete(updated, alloc.ID)
+			impact.Placed++
+			reservedCPU, reservedMemory := reservedAllocUsage(update)
+			cpu = min(cpu, reservedCPU)
+			memory = min(memory, reservedMemory)
+		}
+		impact.ProjectedCPU += cpu
+		impact.ProjectedMemoryMB += memory
+	}
+
+	for _, alloc := range placed {
+		if _, ok := updated[alloc.ID]; !ok {
+			continue
+		}
+		impact.Placed++
+		cpu, memory := reservedAllocUsage(alloc)
+		impact.ProjectedCPU += cpu
+		impact.ProjectedMemoryMB += memory
+	}
+
+	impact.CurrentPressure = impact.pressure(impact.CurrentCPU, impact.CurrentMemoryMB)
+	impact.ProjectedPressure = impact.pressure(impact.ProjectedCPU, impact.ProjectedMemoryMB)
+	return impact
+}
+
+func (n *NodeWhatIfImpact) pressure(cpu, memory int64) float64 {
+	var pressure float64
+	if n.CPUCapacity > 0 {
+		pressure = float64(cpu) / float64(n.CPUCapacity) * 100
+	}
+	if n.MemoryCapacityMB > 0 {
+		pressure = max(pressure, float64(memory)/float64(n.MemoryCapacityMB)*100)
+	}
+	return pressure
+}
+
+// observedAllocUsage returns the CPU in MHz and the memory in MB the
+// allocation was last observed to use, or its reservation if its client
+// hasn't reported its usage while running.
+func observedAllocUsage(alloc *Allocation) (int64, int64) {
+	if usage := alloc.UsageSummary; usage != nil && alloc.ClientStatus == AllocClientStatusRunning {
+		return int64(usage.CPU), int64(usage.MemoryMB)
+	}
+	return reservedAllocUsage(alloc)
+}
+
+// reservedAllocUsage returns the CPU in MHz and the memory in MB reserved for
+// the allocation.
+func reservedAllocUsage(alloc *Allocation) (int64, int64) {
+	reserved := alloc.AllocatedResources.Comparable()
+	if reserved == nil {
+		return 0, 0
+	}
+	return reserved.Flattened.Cpu.CpuShares, reserved.Flattened.Memory.MemoryMB
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNewNodeWhatIfImpact(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{
+		ID: "node",
+		NodeResources: &NodeResources{
+			Processors: NodeProcessorResources{
+				Topology: MockBasicTopology(),
+			},
+			Memory: NodeMemoryResources{MemoryMB: 2048},
+		},
+		ReservedResources: &NodeReservedResources{
+			Memory: NodeReservedMemoryResources{MemoryMB: 48},
+		},
+	}
+	node.NodeResources.Compatibility()
+
+	alloc := func(id, status string, reservedCPU, reservedMemoryMB int64, usage *AllocUsageSummary) *Allocation {
+		return &Allocation{
+			ID:           id,
+			ClientStatus: status,
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {
+						Cpu:    AllocatedCpuResources{CpuShares: reservedCPU},
+						Memory: AllocatedMemoryResources{MemoryMB: reservedMemoryMB},
+					},
+				},
+			},
+			UsageSummary: usage,
+		}
+	}
+
+	allocs := []*Allocation{
+		alloc("observed", AllocClientStatusRunning, 1000, 512, &AllocUsageSummary{CPU: 200, MemoryMB: 100}),
+		alloc("pending", AllocClientStatusPending, 500, 256, nil),
+		alloc("stopped", AllocClientStatusRunning, 500, 256, &AllocUsageSummary{CPU: 300, MemoryMB: 200}),
+		alloc("complete", AllocClientStatusComplete, 4000, 1024, nil),
+	}
+	placed := []*Allocation{
+		// In-place update shrinking the observed alloc below its usage
+		alloc("observed", AllocClientStatusRunning, 100, 512, nil),
+		alloc("new", AllocClientStatusPending, 250, 512, nil),
+	}
+	stopped := []*Allocation{allocs[2]}
+
+	impact := NewNodeWhatIfImpact(node, allocs, placed, stopped)
+	must.Eq(t, &NodeWhatIfImpact{
+		NodeID:            "node",
+		Placed:            2,
+		Stopped:           1,
+		CPUCapacity:       int64(node.NodeResources.Processors.TotalCompute()),
+		MemoryCapacityMB:  2000,
+		CurrentCPU:        200 + 500 + 300,
+		CurrentMemoryMB:   100 + 256 + 200,
+		ProjectedCPU:      100 + 500 + 250,
+		ProjectedMemoryMB: 100 + 256 + 512,
+		CurrentPressure:   float64(556) / 2000 * 100,
+		ProjectedPressure: float64(868) / 2000 * 100,
+	}, impact)
+}
@@ -82,6 +82,20 @@ type ClientDriverConfig struct {
 	// Topology is the system hardware topology that is the result of scanning
 	// hardware combined with client configuration.
 	Topology *numalib.Topology
+
+	// RecordExecutorStats enables recording the stats samples executors send
+	// to a file in the task directory, for debugging
+	RecordExecutorStats bool
+
+	// ExecutorStatsMode is the procstats mode executors read the processes
+	// of tasks in, such as "jiffies" for a cheap read every second
+	ExecutorStatsMode string
+
+	// DataDirs are the directories the client keeps its own data and the
+	// data of allocations in, such as its data_dir, state_dir and alloc_dir.
+	// Drivers restricting what tasks may read deny them access, except to
+	// the directories of their own allocation.
+	DataDirs []string
 }
 
 func (c *AgentConfig) toProto() *proto.NomadConfig {
@@ -94,6 +108,10 @@ func (c *AgentConfig) toProto() *proto.NomadConfig {
 			ClientMaxPort: uint32(c.Driver.ClientMaxPort),
 			ClientMinPort: uint32(c.Driver.ClientMinPort),
 			Topology:      nomadTopologyToProto(c.Driver.Topology),
+
+			RecordExecutorStats: c.Driver.RecordExecutorStats,
+			ExecutorStatsMode:   c.Driver.ExecutorStatsMode,
+			DataDirs:            c.Driver.DataDirs,
 		}
 	}
 	return cfg
@@ -109,6 +127,10 @@ func nomadConfigFromProto(pb *proto.NomadConfig) *AgentConfig {
 			ClientMaxPort: uint(pb.Driver.ClientMaxPort),
 			ClientMinPort: uint(pb.Driver.ClientMinPort),
 			Topology:      nomadTopologyFromProto(pb.Driver.Topology),
+
+			RecordExecutorStats: pb.Driver.RecordExecutorStats,
+			ExecutorStatsMode:   pb.Driver.ExecutorStatsMode,
+			DataDirs:            pb.Driver.DataDirs,
 		}
 	}
 	return cfg
@@ -23,6 +23,9 @@ const (
 
 	// PluginTypeDevice implements the device plugin interface
 	PluginTypeDevice = "device"
+
+	// PluginTypeStatsSink implements the stats sink plugin interface
+	PluginTypeStatsSink = "stats_sink"
 )
 
 var (
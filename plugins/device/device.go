@@ -133,6 +133,11 @@ type Device struct {
 
 	// HwLocality captures hardware locality information for the device.
 	HwLocality *DeviceLocality
+
+	// ParentID is the ID of the device this device is a partition of, such as
+	// the GPU of an NVIDIA MIG slice. Partitions are schedulable devices of
+	// their own, but a device is never allocated along with its partitions.
+	ParentID string
 }
 
 // Validate validates that the device is valid
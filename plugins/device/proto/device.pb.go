@@ -193,10 +193,14 @@ type DetectedDevice struct {
 	HealthDescription string `protobuf:"bytes,3,opt,name=health_description,json=healthDescription,proto3" json:"health_description,omitempty"`
 	// hw_locality is optionally set to expose hardware locality information for
 	// more optimal placement decisions.
-	HwLocality           *DeviceLocality `protobuf:"bytes,4,opt,name=hw_locality,json=hwLocality,proto3" json:"hw_locality,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	HwLocality *DeviceLocality `protobuf:"bytes,4,opt,name=hw_locality,json=hwLocality,proto3" json:"hw_locality,omitempty"`
+	// parent_id is the ID of the device this device is a partition of, such as
+	// the GPU of an NVIDIA MIG slice. A device is never allocated along with
+	// its partitions.
+	ParentId             string   `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DetectedDevice) Reset()         { *m = DetectedDevice{} }
@@ -252,6 +256,13 @@ func (m *DetectedDevice) GetHwLocality() *DeviceLocality {
 	return nil
 }
 
+func (m *DetectedDevice) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
 // DeviceLocality is used to expose HW locality information about a device.
 type DeviceLocality struct {
 	// pci_bus_id is the PCI bus ID for the device. If reported, it
@@ -795,68 +806,68 @@ func init() {
 }
 
 var fileDescriptor_5edb0c35c07fa415 = []byte{
-	// 965 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xef, 0x8e, 0xdb, 0x44,
-	0x10, 0x27, 0xc9, 0xe5, 0x92, 0x4c, 0xee, 0xae, 0x65, 0x7b, 0x42, 0xc6, 0x40, 0x7b, 0x58, 0x42,
-	0x3a, 0x41, 0xeb, 0x94, 0x14, 0x89, 0x0a, 0x04, 0x52, 0xdb, 0x94, 0x5e, 0xf8, 0xd3, 0xab, 0xb6,
-	0x15, 0x52, 0x8b, 0x84, 0xb5, 0x67, 0x2f, 0xf1, 0xb6, 0xf6, 0xda, 0xec, 0xae, 0x53, 0x99, 0x4f,
-	0x3c, 0x0e, 0x5f, 0x78, 0x01, 0x1e, 0x86, 0x0f, 0x3c, 0x09, 0xf2, 0xee, 0x3a, 0xf1, 0xfd, 0xe9,
-	0x25, 0x81, 0x4f, 0xde, 0x9d, 0x99, 0xdf, 0xcc, 0xec, 0xcc, 0x6f, 0x67, 0x0d, 0x1f, 0xe6, 0x49,
-	0x31, 0x63, 0x5c, 0x8e, 0x22, 0x3a, 0x67, 0x21, 0x1d, 0xe5, 0x22, 0x53, 0x99, 0xdd, 0xf8, 0x7a,
-	0x83, 0xae, 0xc7, 0x44, 0xc6, 0x2c, 0xcc, 0x44, 0xee, 0xf3, 0x2c, 0x25, 0x91, 0x6f, 0x21, 0xbe,
-	0xb1, 0x72, 0x6f, 0xcc, 0xb2, 0x6c, 0x96, 0x58, 0xe8, 0x49, 0xf1, 0xcb, 0x48, 0xb1, 0x94, 0x4a,
-	0x45, 0xd2, 0xdc, 0x38, 0x70, 0xaf, 0x9f, 0x35, 0x88, 0x0a, 0x41, 0x14, 0xcb, 0xb8, 0xd5, 0xdf,
-	0xac, 0x73, 0x90, 0x31, 0x11, 0x34, 0x1a, 0x49, 0x25, 0x8a, 0x50, 0x49, 0x9b, 0x0b, 0x51, 0x4a,
-	0xb0, 0x93, 0x42, 0xd9, 0x74, 0xdc, 0xc3, 0x4b, 0xad, 0xa5, 0x22, 0x4a, 0x1a, 0x4b, 0x6f, 0x1f,
-	0xd0, 0x37, 0x8c, 0xcf, 0xa8, 0xc8, 0x05, 0xe3, 0x0a, 0xd3, 0x5f, 0x0b, 0x2a, 0x95, 0x47, 0xe1,
-	0xda, 0x29, 0xa9, 0xcc, 0x33, 0x2e, 0x29, 0x7a, 0x0c, 0x3b, 0xe6, 0x3c, 0xc1, 0x4c, 0x64, 0x45,
-	0xee, 0xb4, 0x0e, 0x3a, 0x87, 0xc3, 0xf1, 0x27, 0xfe, 0xe5, 0x87, 0xf7, 0x27, 0xfa, 0xf3, 0xa8,
-	0x82, 0xe0, 0x61, 0xb4, 0xdc, 0x78, 0xbf, 0x77, 0x60, 0xd8, 0x50, 0xa2, 0x77, 0x60, 0x7b, 0x4e,
-	0x79, 0x94, 0x09, 0xa7, 0x75, 0xd0, 0x3a, 0x1c, 0x60, 0xbb, 0x43, 0x37, 0xc0, 0xc2, 0x02, 0x55,
-	0xe6, 0xd4, 0x69, 0x6b, 0x25, 0x18, 0xd1, 0xb3, 0x32, 0xa7, 0x0d, 0x03, 0x4e, 0x52, 0xea, 0x74,
-	0x9a, 0x06, 0x8f, 0x49, 0x4a, 0xd1, 0x11, 0xf4, 0xcc, 0x4e, 0x3a, 0x5b, 0x3a, 0x69, 0x7f, 0x75,
-	0xd2, 0x8a, 0x86, 0x8a, 0x46, 0x26, 0x3f, 0x5c, 0xc3, 0xd1, 0x4f, 0x00, 0x8b, 0x6a, 0x4b, 0xa7,
-	0xab, 0x9d, 0x7d, 0xb9, 0x41, 0x05, 0xfc, 0x7b, 0x0b, 0xf4, 0x43, 0xae, 0x44, 0x89, 0x1b, 0xee,
-	0xdc, 0x1c, 0xae, 0x9c, 0x51, 0xa3, 0xab, 0xd0, 0x79, 0x45, 0x4b, 0x5b, 0x90, 0x6a, 0x89, 0x1e,
-	0x41, 0x77, 0x4e, 0x92, 0xc2, 0xd4, 0x61, 0x38, 0xfe, 0xf4, 0x8d, 0xc1, 0x4d, 0xf3, 0x7d, 0xdb,
-	0xfc, 0x65, 0x60, 0x6c, 0xf0, 0x5f, 0xb4, 0xef, 0xb6, 0xbc, 0xbf, 0x5a, 0xb0, 0x77, 0xfa, 0xa8,
-	0x68, 0x0f, 0xda, 0xd3, 0x89, 0x0d, 0xd8, 0x9e, 0x4e, 0x90, 0x03, 0xbd, 0x98, 0x92, 0x44, 0xc5,
-	0xa5, 0x8e, 0xd8, 0xc7, 0xf5, 0x16, 0xdd, 0x02, 0x64, 0x96, 0x41, 0x44, 0x65, 0x28, 0x58, 0x5e,
-	0x11, 0xd6, 0x56, 0xff, 0x6d, 0xa3, 0x99, 0x2c, 0x15, 0xe8, 0x18, 0x86, 0xf1, 0xeb, 0x20, 0xc9,
-	0x42, 0x92, 0x30, 0x55, 0x3a, 0x5b, 0x3a, 0x7d, 0x7f, 0xbd, 0xda, 0x7d, 0x6f, 0x51, 0x18, 0xe2,
-	0xd7, 0xf5, 0xda, 0xf3, 0xab, 0xdc, 0x9b, 0x5a, 0xf4, 0x3e, 0x40, 0x1e, 0xb2, 0xe0, 0xa4, 0x90,
-	0x01, 0x8b, 0xec, 0x19, 0xfa, 0x79, 0xc8, 0xee, 0x17, 0x72, 0x1a, 0x79, 0x23, 0xd8, 0xc3, 0x54,
-	0x52, 0x31, 0xa7, 0x96, 0xe8, 0xe8, 0x03, 0xb0, 0x2c, 0x09, 0x58, 0x24, 0x35, 0x9f, 0x07, 0x78,
-	0x60, 0x24, 0xd3, 0x48, 0x7a, 0x09, 0x5c, 0x59, 0x00, 0xec, 0x1d, 0x78, 0x0e, 0xbb, 0x61, 0xc6,
-	0x15, 0x61, 0x9c, 0x8a, 0x40, 0x50, 0xa9, 0x83, 0x0c, 0xc7, 0x9f, 0xad, 0x3a, 0xc6, 0x83, 0x1a,
-	0x64, 0x1c, 0xea, 0xbb, 0x8d, 0x77, 0xc2, 0x86, 0xd4, 0xfb, 0xa3, 0x0d, 0xfb, 0x17, 0x99, 0x21,
-	0x0c, 0x5b, 0x94, 0xcf, 0xa5, 0xbd, 0x6f, 0x5f, 0xff, 0x97, 0x50, 0xfe, 0x43, 0x3e, 0xb7, 0x84,
-	0xd3, 0xbe, 0xd0, 0x57, 0xb0, 0x9d, 0x66, 0x05, 0x57, 0xd2, 0x69, 0x6b, 0xaf, 0x1f, 0xad, 0xf2,
-	0xfa, 0x43, 0x65, 0x8d, 0x2d, 0x08, 0x4d, 0x96, 0x17, 0xaa, 0xa3, 0xf1, 0x1f, 0xaf, 0xd7, 0xc7,
-	0xa7, 0x39, 0x0d, 0x17, 0x97, 0xc9, 0xfd, 0x1c, 0x06, 0x8b, 0xbc, 0x2e, 0x60, 0xfa, 0x7e, 0x93,
-	0xe9, 0x83, 0x26, 0x6d, 0x7f, 0x86, 0xae, 0xce, 0x07, 0xbd, 0x07, 0x03, 0x45, 0xe4, 0xab, 0x20,
-	0x27, 0x2a, 0xae, 0xfb, 0x5d, 0x09, 0x9e, 0x10, 0x15, 0x57, 0xca, 0x38, 0x93, 0xca, 0x28, 0x8d,
-	0x8f, 0x7e, 0x25, 0xa8, 0x95, 0x82, 0x92, 0x28, 0xc8, 0x78, 0x52, 0x6a, 0xce, 0xf6, 0x71, 0xbf,
-	0x12, 0x1c, 0xf3, 0xa4, 0xf4, 0x62, 0x80, 0x65, 0xbe, 0xff, 0x23, 0xc8, 0x01, 0x0c, 0x73, 0x2a,
-	0x52, 0x26, 0x25, 0xcb, 0xb8, 0xb4, 0x57, 0xa3, 0x29, 0xf2, 0x5e, 0xc0, 0xce, 0xd3, 0x6a, 0x1e,
-	0xd7, 0x8c, 0xfc, 0x16, 0xae, 0x85, 0x59, 0x92, 0xd0, 0xb0, 0xea, 0x5a, 0xc0, 0xb8, 0xaa, 0x3a,
-	0x98, 0x58, 0x96, 0xbd, 0xeb, 0x9b, 0x67, 0xc2, 0xaf, 0x9f, 0x09, 0x7f, 0x62, 0x9f, 0x09, 0x8c,
-	0x96, 0xa8, 0xa9, 0x05, 0x79, 0xcf, 0x61, 0xd7, 0xfa, 0xb6, 0xe4, 0x3d, 0x82, 0x6d, 0x3d, 0xb9,
-	0x6b, 0x2a, 0xdd, 0xde, 0x60, 0x70, 0x19, 0x4f, 0x16, 0xef, 0xfd, 0xd9, 0x86, 0xab, 0x67, 0x95,
-	0x6f, 0x9c, 0xdf, 0x08, 0xb6, 0x1a, 0x83, 0x5b, 0xaf, 0x2b, 0x59, 0x63, 0x56, 0xeb, 0x35, 0x7a,
-	0x09, 0x7b, 0x8c, 0x4b, 0x45, 0x78, 0x48, 0x03, 0xfd, 0x48, 0xd9, 0x61, 0xfd, 0x60, 0xd3, 0x34,
-	0xfd, 0xa9, 0x75, 0xa3, 0x77, 0x86, 0xf6, 0xbb, 0xac, 0x29, 0x73, 0x53, 0x40, 0xe7, 0x8d, 0x2e,
-	0xe0, 0xe0, 0xbd, 0xd3, 0xd3, 0x76, 0xcd, 0xc7, 0xce, 0x14, 0xab, 0x41, 0xd8, 0xbf, 0x5b, 0xf5,
-	0x53, 0x67, 0x4a, 0xf5, 0x1d, 0xf4, 0x64, 0x91, 0xa6, 0x44, 0x94, 0xb6, 0xb5, 0x6b, 0x8f, 0xf1,
-	0x0a, 0xff, 0x63, 0xe5, 0x17, 0xd7, 0x1e, 0xd0, 0x11, 0x74, 0x4d, 0xb9, 0x4c, 0x8e, 0xe3, 0x4d,
-	0x5c, 0x1d, 0x9f, 0xbc, 0xa4, 0xa1, 0xc2, 0xc6, 0x01, 0xba, 0x0b, 0x83, 0xc5, 0x9f, 0x89, 0x6e,
-	0xcd, 0x70, 0xec, 0x9e, 0xe3, 0xdc, 0xb3, 0xda, 0x02, 0x2f, 0x8d, 0xc7, 0xff, 0xb4, 0x61, 0xc7,
-	0x1c, 0xf0, 0x89, 0x0e, 0x86, 0x7e, 0x83, 0x61, 0xe3, 0x1f, 0x02, 0x8d, 0x57, 0x15, 0xee, 0xfc,
-	0x6f, 0x88, 0x7b, 0x67, 0x23, 0x8c, 0xe1, 0xb8, 0xf7, 0xd6, 0xed, 0x16, 0x4a, 0xa0, 0x67, 0xe7,
-	0x36, 0x5a, 0xf9, 0xbe, 0x9c, 0x7e, 0x11, 0xdc, 0xd1, 0xda, 0xf6, 0x75, 0x3c, 0x14, 0x43, 0xd7,
-	0x34, 0xf5, 0xe6, 0x2a, 0x6c, 0xf3, 0xa6, 0xbb, 0xb7, 0xd6, 0xb4, 0x5e, 0x9e, 0xeb, 0x7e, 0xef,
-	0x45, 0xd7, 0x74, 0x61, 0x5b, 0x7f, 0xee, 0xfc, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x11, 0xd4, 0x56,
-	0x04, 0x9b, 0x0a, 0x00, 0x00,
+	// 975 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0xc7, 0xc9, 0xe5, 0x92, 0x8c, 0xef, 0xd2, 0xb2, 0x3d, 0x21, 0x63, 0xa0, 0x3d, 0x2c, 0x21,
+	0x9d, 0xa0, 0x75, 0x4a, 0x8a, 0x44, 0x05, 0x02, 0xa9, 0x6d, 0x4a, 0x2f, 0xfc, 0xe9, 0x55, 0x6e,
+	0x85, 0xd4, 0x22, 0x61, 0xed, 0xd9, 0x4b, 0xbc, 0xad, 0xbd, 0x36, 0xbb, 0xeb, 0x54, 0xe1, 0x89,
+	0x8f, 0xc3, 0x0b, 0x9f, 0x87, 0x37, 0x1e, 0xf8, 0x24, 0xc8, 0xbb, 0xeb, 0xc4, 0xb9, 0xbb, 0x5e,
+	0x12, 0x78, 0xf2, 0xee, 0xcc, 0xfc, 0x66, 0x66, 0x77, 0x7e, 0x33, 0x6b, 0xf8, 0xb0, 0x48, 0xcb,
+	0x29, 0x65, 0x62, 0x18, 0x93, 0x19, 0x8d, 0xc8, 0xb0, 0xe0, 0xb9, 0xcc, 0xcd, 0xc6, 0x57, 0x1b,
+	0x74, 0x3d, 0xc1, 0x22, 0xa1, 0x51, 0xce, 0x0b, 0x9f, 0xe5, 0x19, 0x8e, 0x7d, 0x03, 0xf1, 0xb5,
+	0x95, 0x7b, 0x63, 0x9a, 0xe7, 0xd3, 0xd4, 0x40, 0x4f, 0xcb, 0x5f, 0x86, 0x92, 0x66, 0x44, 0x48,
+	0x9c, 0x15, 0xda, 0x81, 0x7b, 0xfd, 0xac, 0x41, 0x5c, 0x72, 0x2c, 0x69, 0xce, 0x8c, 0xfe, 0x66,
+	0x9d, 0x83, 0x48, 0x30, 0x27, 0xf1, 0x50, 0x48, 0x5e, 0x46, 0x52, 0x98, 0x5c, 0xb0, 0x94, 0x9c,
+	0x9e, 0x96, 0xd2, 0xa4, 0xe3, 0x1e, 0x5d, 0x6a, 0x2d, 0x24, 0x96, 0x42, 0x5b, 0x7a, 0x07, 0x80,
+	0xbe, 0xa1, 0x6c, 0x4a, 0x78, 0xc1, 0x29, 0x93, 0x01, 0xf9, 0xb5, 0x24, 0x42, 0x7a, 0x04, 0xae,
+	0xad, 0x48, 0x45, 0x91, 0x33, 0x41, 0xd0, 0x63, 0xd8, 0xd3, 0xe7, 0x09, 0xa7, 0x3c, 0x2f, 0x0b,
+	0xc7, 0x3a, 0x6c, 0x1f, 0xd9, 0xa3, 0x4f, 0xfc, 0xcb, 0x0f, 0xef, 0x8f, 0xd5, 0xe7, 0x51, 0x05,
+	0x09, 0xec, 0x78, 0xb9, 0xf1, 0x7e, 0x6f, 0x83, 0xdd, 0x50, 0xa2, 0x77, 0x60, 0x77, 0x46, 0x58,
+	0x9c, 0x73, 0xc7, 0x3a, 0xb4, 0x8e, 0xfa, 0x81, 0xd9, 0xa1, 0x1b, 0x60, 0x60, 0xa1, 0x9c, 0x17,
+	0xc4, 0x69, 0x29, 0x25, 0x68, 0xd1, 0xb3, 0x79, 0x41, 0x1a, 0x06, 0x0c, 0x67, 0xc4, 0x69, 0x37,
+	0x0d, 0x1e, 0xe3, 0x8c, 0xa0, 0x63, 0xe8, 0xea, 0x9d, 0x70, 0x76, 0x54, 0xd2, 0xfe, 0xfa, 0xa4,
+	0x25, 0x89, 0x24, 0x89, 0x75, 0x7e, 0x41, 0x0d, 0x47, 0x3f, 0x01, 0x2c, 0x6e, 0x5b, 0x38, 0x1d,
+	0xe5, 0xec, 0xcb, 0x2d, 0x6e, 0xc0, 0xbf, 0xb7, 0x40, 0x3f, 0x64, 0x92, 0xcf, 0x83, 0x86, 0x3b,
+	0xb7, 0x80, 0x2b, 0x67, 0xd4, 0xe8, 0x2a, 0xb4, 0x5f, 0x91, 0xb9, 0xb9, 0x90, 0x6a, 0x89, 0x1e,
+	0x41, 0x67, 0x86, 0xd3, 0x52, 0xdf, 0x83, 0x3d, 0xfa, 0xf4, 0x8d, 0xc1, 0x75, 0xf1, 0x7d, 0x53,
+	0xfc, 0x65, 0xe0, 0x40, 0xe3, 0xbf, 0x68, 0xdd, 0xb5, 0xbc, 0xbf, 0x2c, 0x18, 0xac, 0x1e, 0x15,
+	0x0d, 0xa0, 0x35, 0x19, 0x9b, 0x80, 0xad, 0xc9, 0x18, 0x39, 0xd0, 0x4d, 0x08, 0x4e, 0x65, 0x32,
+	0x57, 0x11, 0x7b, 0x41, 0xbd, 0x45, 0xb7, 0x00, 0xe9, 0x65, 0x18, 0x13, 0x11, 0x71, 0x5a, 0x54,
+	0x84, 0x35, 0xb7, 0xff, 0xb6, 0xd6, 0x8c, 0x97, 0x0a, 0x74, 0x02, 0x76, 0xf2, 0x3a, 0x4c, 0xf3,
+	0x08, 0xa7, 0x54, 0xce, 0x9d, 0x9d, 0x43, 0x6b, 0xb3, 0x42, 0x54, 0x9f, 0xef, 0x0d, 0x2a, 0x80,
+	0xe4, 0x75, 0xbd, 0x46, 0xef, 0x41, 0xbf, 0xc0, 0x9c, 0x30, 0x19, 0xd2, 0xd8, 0xe9, 0xa8, 0xb0,
+	0x3d, 0x2d, 0x98, 0xc4, 0x9e, 0x0f, 0x83, 0x55, 0x28, 0x7a, 0x1f, 0xa0, 0x88, 0x68, 0x78, 0x5a,
+	0x8a, 0xca, 0xde, 0x32, 0xf6, 0x11, 0xbd, 0x5f, 0x8a, 0x49, 0xec, 0x0d, 0x61, 0x10, 0x10, 0x41,
+	0xf8, 0x8c, 0x98, 0x2e, 0x40, 0x1f, 0x80, 0xa1, 0x50, 0x48, 0x63, 0xa1, 0xc8, 0xde, 0x0f, 0xfa,
+	0x5a, 0x32, 0x89, 0x85, 0x97, 0xc2, 0x95, 0x05, 0xc0, 0x34, 0xc8, 0x73, 0xd8, 0x8f, 0x72, 0x26,
+	0x31, 0x65, 0x84, 0x87, 0x9c, 0x08, 0x15, 0xc4, 0x1e, 0x7d, 0xb6, 0xee, 0x8c, 0x0f, 0x6a, 0x90,
+	0x76, 0xa8, 0x1a, 0x3f, 0xd8, 0x8b, 0x1a, 0x52, 0xef, 0x8f, 0x16, 0x1c, 0x5c, 0x64, 0x86, 0x02,
+	0xd8, 0x21, 0x6c, 0x26, 0x4c, 0x33, 0x7e, 0xfd, 0x5f, 0x42, 0xf9, 0x0f, 0xd9, 0xcc, 0xb0, 0x51,
+	0xf9, 0x42, 0x5f, 0xc1, 0x6e, 0x96, 0x97, 0x4c, 0x0a, 0xa7, 0xa5, 0xbc, 0x7e, 0xb4, 0xce, 0xeb,
+	0x0f, 0x95, 0x75, 0x60, 0x40, 0x68, 0xbc, 0xec, 0xb6, 0xb6, 0xc2, 0x7f, 0xbc, 0x59, 0x91, 0x9f,
+	0x16, 0x24, 0x5a, 0x74, 0x9a, 0xfb, 0x39, 0xf4, 0x17, 0x79, 0x5d, 0xd0, 0x06, 0x07, 0xcd, 0x36,
+	0xe8, 0x37, 0x39, 0xfd, 0x33, 0x74, 0x54, 0x3e, 0x15, 0x3f, 0x24, 0x16, 0xaf, 0xc2, 0x02, 0xcb,
+	0xa4, 0xae, 0x77, 0x25, 0x78, 0x82, 0x65, 0x52, 0x29, 0x93, 0x5c, 0x48, 0xad, 0xd4, 0x3e, 0x7a,
+	0x95, 0xa0, 0x56, 0x72, 0x82, 0xe3, 0x30, 0x67, 0xe9, 0x5c, 0x11, 0xba, 0x17, 0xf4, 0x2a, 0xc1,
+	0x09, 0x4b, 0xe7, 0x5e, 0x02, 0xb0, 0xcc, 0xf7, 0x7f, 0x04, 0x39, 0x04, 0xbb, 0x20, 0x3c, 0xa3,
+	0x42, 0xd0, 0x9c, 0x09, 0xd3, 0x37, 0x4d, 0x91, 0xf7, 0x02, 0xf6, 0x9e, 0x4a, 0x2c, 0x45, 0xcd,
+	0xc8, 0x6f, 0xe1, 0x5a, 0x94, 0xa7, 0x29, 0x89, 0xaa, 0xaa, 0x85, 0x94, 0xc9, 0xaa, 0x82, 0xa9,
+	0x61, 0xd9, 0xbb, 0xbe, 0x7e, 0x43, 0xfc, 0xfa, 0x0d, 0xf1, 0xc7, 0xe6, 0x0d, 0x09, 0xd0, 0x12,
+	0x35, 0x31, 0x20, 0xef, 0x39, 0xec, 0x1b, 0xdf, 0x86, 0xbc, 0xc7, 0xb0, 0xab, 0xc6, 0x7a, 0x4d,
+	0xa5, 0xdb, 0x5b, 0x4c, 0x35, 0xed, 0xc9, 0xe0, 0xbd, 0x3f, 0x5b, 0x70, 0xf5, 0xac, 0xf2, 0x8d,
+	0xc3, 0x1d, 0xc1, 0x4e, 0x63, 0xaa, 0xab, 0x75, 0x25, 0x6b, 0x0c, 0x72, 0xb5, 0x46, 0x2f, 0x61,
+	0x40, 0x99, 0x90, 0x98, 0x45, 0x24, 0x54, 0x2f, 0x98, 0x99, 0xe4, 0x0f, 0xb6, 0x4d, 0xd3, 0x9f,
+	0x18, 0x37, 0x6a, 0xa7, 0x69, 0xbf, 0x4f, 0x9b, 0x32, 0x37, 0x03, 0x74, 0xde, 0xe8, 0x02, 0x0e,
+	0xde, 0x5b, 0x1d, 0xc5, 0x1b, 0xbe, 0x84, 0xfa, 0xb2, 0x1a, 0x84, 0xfd, 0xdb, 0x02, 0xbb, 0xa1,
+	0x42, 0xdf, 0x41, 0x57, 0x94, 0x59, 0x86, 0xf9, 0xdc, 0xb1, 0xb6, 0x9b, 0xf1, 0x15, 0xfe, 0xc7,
+	0xca, 0x6f, 0x50, 0x7b, 0x40, 0xc7, 0xd0, 0xd1, 0xd7, 0xa5, 0x73, 0x1c, 0x6d, 0xe3, 0xea, 0xe4,
+	0xf4, 0x25, 0x89, 0x64, 0xa0, 0x1d, 0xa0, 0xbb, 0xd0, 0x5f, 0xfc, 0xb6, 0xa8, 0xd2, 0xd8, 0x23,
+	0xf7, 0x1c, 0xe7, 0x9e, 0xd5, 0x16, 0xc1, 0xd2, 0x78, 0xf4, 0x4f, 0x0b, 0xf6, 0xf4, 0x01, 0x9f,
+	0xa8, 0x60, 0xe8, 0x37, 0xb0, 0x1b, 0x3f, 0x18, 0x68, 0xb4, 0xee, 0xe2, 0xce, 0xff, 0xa3, 0xb8,
+	0x77, 0xb6, 0xc2, 0x68, 0x8e, 0x7b, 0x6f, 0xdd, 0xb6, 0x50, 0x0a, 0x5d, 0x33, 0xb7, 0xd1, 0xda,
+	0xc7, 0x67, 0xf5, 0x45, 0x70, 0x87, 0x1b, 0xdb, 0xd7, 0xf1, 0x50, 0x02, 0x1d, 0x5d, 0xd4, 0x9b,
+	0xeb, 0xb0, 0xcd, 0x4e, 0x77, 0x6f, 0x6d, 0x68, 0xbd, 0x3c, 0xd7, 0xfd, 0xee, 0x8b, 0x8e, 0xae,
+	0xc2, 0xae, 0xfa, 0xdc, 0xf9, 0x77, 0x00, 0xaf, 0x3e, 0x10, 0x29, 0xb8, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
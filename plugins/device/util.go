@@ -63,6 +63,7 @@ func convertProtoDevice(in *proto.DetectedDevice) *Device {
 		Healthy:    in.Healthy,
 		HealthDesc: in.HealthDescription,
 		HwLocality: convertProtoDeviceLocality(in.HwLocality),
+		ParentID:   in.ParentId,
 	}
 }
 
@@ -199,6 +200,7 @@ func convertStructDevice(in *Device) *proto.DetectedDevice {
 		Healthy:           in.Healthy,
 		HealthDescription: in.HealthDesc,
 		HwLocality:        convertStructDeviceLocality(in.HwLocality),
+		ParentId:          in.ParentID,
 	}
 }
 
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package drivers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MergeTaskStats implements AllocStats for drivers that collect stats per
+// task, by merging the TaskStats streams of the given tasks. Samples
+// received within an interval are sent together. The returned channel is
+// closed once the context is canceled or every task's stream has ended.
+func MergeTaskStats(ctx context.Context, d DriverPlugin, taskIDs []string, interval time.Duration) (<-chan map[string]*TaskResourceUsage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	streams := make(map[string]<-chan *TaskResourceUsage, len(taskIDs))
+	for _, id := range taskIDs {
+		ch, err := d.TaskStats(ctx, id, interval)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		streams[id] = ch
+	}
+
+	type sample struct {
+		id    string
+		usage *TaskResourceUsage
+	}
+	samples := make(chan sample)
+
+	var wg sync.WaitGroup
+	for id, ch := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for usage := range ch {
+				select {
+				case samples <- sample{id, usage}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(samples)
+	}()
+
+	out := make(chan map[string]*TaskResourceUsage, 1)
+	go func() {
+		defer cancel()
+		defer close(out)
+
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		pending := map[string]*TaskResourceUsage{}
+		flush := func() bool {
+			if len(pending) == 0 {
+				return true
+			}
+			select {
+			case out <- pending:
+				pending = map[string]*TaskResourceUsage{}
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case s, ok := <-samples:
+				if !ok {
+					flush()
+					return
+				}
+				pending[s.id] = s.usage
+				if tick == nil && !flush() {
+					return
+				}
+			case <-tick:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -82,6 +82,11 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
 		caps.DisableLogCollection = resp.Capabilities.DisableLogCollection
 		caps.DynamicWorkloadUsers = resp.Capabilities.DynamicWorkloadUsers
+		caps.Stats = statsCapabilitiesFromProto(resp.Capabilities.Stats)
+		caps.AllocStats = resp.Capabilities.AllocStats
+		caps.Processes = resp.Capabilities.Processes
+		caps.Profile = resp.Capabilities.Profile
+		caps.SharedCgroup = resp.Capabilities.SharedCgroup
 	}
 
 	return caps, nil
@@ -205,6 +210,7 @@ func (d *driverPluginClient) handleWaitTask(ctx context.Context, id string, ch c
 		result.ExitCode = int(resp.Result.ExitCode)
 		result.Signal = int(resp.Result.Signal)
 		result.OOMKilled = resp.Result.OomKilled
+		result.ExitReason = resp.Result.ExitReason
 		if len(resp.Err) > 0 {
 			result.Err = errors.New(resp.Err)
 		}
@@ -295,6 +301,89 @@ func (d *driverPluginClient) TaskStats(ctx context.Context, taskID string, inter
 	return ch, nil
 }
 
+// AllocStats returns a channel on which the resource usage of the given
+// tasks is streamed together at the given interval
+func (d *driverPluginClient) AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*cstructs.TaskResourceUsage, error) {
+	req := &proto.AllocStatsRequest{
+		TaskIds:            taskIDs,
+		CollectionInterval: ptypes.DurationProto(interval),
+	}
+	ctx, _ = joincontext.Join(ctx, d.doneCtx)
+	stream, err := d.client.AllocStats(ctx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	ch := make(chan map[string]*cstructs.TaskResourceUsage, 1)
+	go d.handleAllocStats(ctx, ch, stream)
+
+	return ch, nil
+}
+
+// TaskProcesses returns a snapshot of the processes running in the task
+func (d *driverPluginClient) TaskProcesses(taskID string) ([]*TaskProcess, error) {
+	req := &proto.TaskProcessesRequest{TaskId: taskID}
+
+	resp, err := d.client.TaskProcesses(d.doneCtx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return TaskProcessesFromProto(resp.Processes)
+}
+
+// ProfileTask samples the call stacks of the task's processes for the
+// duration and returns them folded
+func (d *driverPluginClient) ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error) {
+	req := &proto.ProfileTaskRequest{
+		TaskId:   taskID,
+		Duration: int64(duration),
+	}
+
+	resp, err := d.client.ProfileTask(ctx, req)
+	if err != nil {
+		return nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return resp.FoldedStacks, nil
+}
+
+func (d *driverPluginClient) handleAllocStats(ctx context.Context, ch chan<- map[string]*cstructs.TaskResourceUsage, stream proto.Driver_AllocStatsClient) {
+	defer close(ch)
+	for {
+		resp, err := stream.Recv()
+		if ctx.Err() != nil {
+			// Context canceled; exit gracefully
+			return
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				d.logger.Error("error receiving stream from AllocStats driver RPC, closing stream", "error", err)
+			}
+
+			// End of stream
+			return
+		}
+
+		batch := make(map[string]*cstructs.TaskResourceUsage, len(resp.Stats))
+		for id, pb := range resp.Stats {
+			stats, err := TaskStatsFromProto(pb)
+			if err != nil {
+				d.logger.Error("failed to decode stats from RPC", "error", err, "task_id", id)
+				continue
+			}
+			batch[id] = stats
+		}
+
+		select {
+		case ch <- batch:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (d *driverPluginClient) handleStats(ctx context.Context, ch chan<- *cstructs.TaskResourceUsage, stream proto.Driver_TaskStatsClient) {
 	defer close(ch)
 	for {
@@ -17,6 +17,34 @@ type MemoryStats = cstructs.MemoryStats
 // CpuStats holds cpu usage related stats
 type CpuStats = cstructs.CpuStats
 
+// CoreStats holds the utilization of a core reserved for a task
+type CoreStats = cstructs.CoreStats
+
+// PerfStats holds hardware performance counter stats
+type PerfStats = cstructs.PerfStats
+
+// EgressStats holds the traffic sent to a class of destinations
+type EgressStats = cstructs.EgressStats
+
+// ResctrlStats holds the cache occupancy and memory bandwidth of the resctrl
+// class of a task
+type ResctrlStats = cstructs.ResctrlStats
+
+// DiskIOStats holds the block I/O of a task
+type DiskIOStats = cstructs.DiskIOStats
+
+// PressureStats holds the pressure stall information of a task
+type PressureStats = cstructs.PressureStats
+
+// Gauge is a driver-specific measurement of a task's resource usage
+type Gauge = cstructs.Gauge
+
+// StatsCapabilities describes which optional stats a driver reports
+type StatsCapabilities = cstructs.StatsCapabilities
+
+// StatSchema describes a driver-specific gauge
+type StatSchema = cstructs.StatSchema
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage = cstructs.ResourceUsage
 
@@ -24,6 +52,12 @@ type ResourceUsage = cstructs.ResourceUsage
 // and the resource usage of the individual pids
 type TaskResourceUsage = cstructs.TaskResourceUsage
 
+// ResourceLimits are the limits enforced on a task's resource usage
+type ResourceLimits = cstructs.ResourceLimits
+
+// TaskProcess describes a process running as part of a task
+type TaskProcess = cstructs.TaskProcess
+
 // CheckBufSize is the size of the buffer that is used for job output
 const CheckBufSize = cstructs.CheckBufSize
 
@@ -90,6 +90,28 @@ type ExecOptions struct {
 	ResizeCh <-chan TerminalSize
 }
 
+// AllocStatsDriver is implemented by drivers that can stream the resource
+// usage of several tasks at once. Each sample on the returned channel holds
+// the tasks that have new usage, keyed by task ID.
+type AllocStatsDriver interface {
+	AllocStats(ctx context.Context, taskIDs []string, interval time.Duration) (<-chan map[string]*TaskResourceUsage, error)
+}
+
+// ProcessListDriver is implemented by drivers that can list the processes
+// running in a task, for debugging tasks without access to the node.
+type ProcessListDriver interface {
+	TaskProcesses(taskID string) ([]*TaskProcess, error)
+}
+
+// ProfileDriver is implemented by drivers that can sample the call stacks of
+// a task's processes for a duration. The stacks are returned in the folded
+// format read by flamegraph tools: one line per distinct stack, with its
+// frames from the outermost in separated by semicolons, followed by the
+// number of samples.
+type ProfileDriver interface {
+	ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error)
+}
+
 // DriverNetworkManager is the interface with exposes function for creating a
 // network namespace for which tasks can join. This only needs to be implemented
 // if the driver MUST create the network namespace
@@ -185,6 +207,26 @@ type Capabilities struct {
 	// The allocation of a unique, not-in-use UID/GID is managed by Nomad client
 	// ensuring no overlap.
 	DynamicWorkloadUsers bool
+
+	// Stats declares which optional resource usage stats the driver reports
+	// in TaskStats. Drivers that leave it nil are assumed to report
+	// whatever they populate.
+	Stats *StatsCapabilities
+
+	// AllocStats indicates the driver implements AllocStatsDriver, so the
+	// stats of all of an allocation's tasks can be streamed together.
+	AllocStats bool
+
+	// Processes indicates the driver implements ProcessListDriver.
+	Processes bool
+
+	// Profile indicates the driver implements ProfileDriver.
+	Profile bool
+
+	// SharedCgroup indicates the driver runs tasks in the cgroup of
+	// LinuxResources.CpusetCgroupPath, so tasks of allocs with shared
+	// resources run under the alloc-level cgroup and may share its headroom.
+	SharedCgroup bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -299,6 +341,14 @@ type TaskConfig struct {
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
+
+	// FramedLogs is true if the task's log collector decodes framed output,
+	// so drivers that ship output through an executor can frame it
+	FramedLogs bool
+
+	// QoSClasses is true if the client enforces the QoS classes of tasks, so
+	// drivers set the oom_score_adj and protected memory of the class
+	QoSClasses bool
 }
 
 func (tc *TaskConfig) Copy() *TaskConfig {
@@ -420,6 +470,11 @@ type LinuxResources struct {
 	CpusetCpus       string
 	CpusetCgroupPath string
 
+	// MemoryPolicy is the numa memory policy of the task, applied over the
+	// numa nodes in MemoryNodes, in cpuset list notation
+	MemoryPolicy string
+	MemoryNodes  string
+
 	// PrecentTicks is used to calculate the CPUQuota, currently the docker
 	// driver exposes cpu period and quota through the driver configuration
 	// and thus the calculation for CPUQuota cannot be done on the client.
@@ -489,13 +544,47 @@ type ExitResult struct {
 	ExitCode  int
 	Signal    int
 	OOMKilled bool
-	Err       error
+
+	// ExitReason classifies the exit, as one of the structs.TaskExitReason
+	// constants. Drivers may leave it empty, in which case Reason classifies
+	// the exit from the other fields.
+	ExitReason string
+
+	Err error
 }
 
 func (r *ExitResult) Successful() bool {
 	return r.ExitCode == 0 && r.Signal == 0 && r.Err == nil
 }
 
+// Reason returns the reason of the exit: the ExitReason set by the driver, or
+// else the reason classified from the exit code, signal and OOM kill.
+func (r *ExitResult) Reason() string {
+	if r.ExitReason != "" {
+		return r.ExitReason
+	}
+	if r.Err != nil {
+		return structs.TaskExitReasonExecutorFailure
+	}
+	return ClassifyExit(r.ExitCode, r.Signal, r.OOMKilled)
+}
+
+// ClassifyExit returns the reason a process exited for, from its exit code,
+// the signal which terminated it, and whether it was OOM killed. An OOM kill
+// takes precedence, as the kernel reports it as a SIGKILL.
+func ClassifyExit(exitCode, signal int, oomKilled bool) string {
+	switch {
+	case oomKilled:
+		return structs.TaskExitReasonOOMKilled
+	case signal != 0:
+		return structs.TaskExitReasonSignaled
+	case exitCode != 0:
+		return structs.TaskExitReasonNonZeroExit
+	default:
+		return structs.TaskExitReasonCompleted
+	}
+}
+
 func (r *ExitResult) Copy() *ExitResult {
 	if r == nil {
 		return nil
@@ -516,6 +605,12 @@ type TaskStatus struct {
 	NetworkOverride  *DriverNetwork
 }
 
+// TaskEventKilledResource is the annotation of a TaskEvent with which the
+// driver reports it is killing the task for its usage of a resource, such as
+// "memory" or "cpu". The client annotates the final stats sample of the task
+// with it too.
+const TaskEventKilledResource = "killed_resource"
+
 type TaskEvent struct {
 	TaskID      string
 	TaskName    string
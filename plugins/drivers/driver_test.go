@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package drivers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestExitResult_Reason(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		result *ExitResult
+		exp    string
+	}{
+		{
+			name:   "completed",
+			result: &ExitResult{},
+			exp:    structs.TaskExitReasonCompleted,
+		},
+		{
+			name:   "nonzero exit",
+			result: &ExitResult{ExitCode: 2},
+			exp:    structs.TaskExitReasonNonZeroExit,
+		},
+		{
+			name:   "signaled",
+			result: &ExitResult{ExitCode: 143, Signal: 15},
+			exp:    structs.TaskExitReasonSignaled,
+		},
+		{
+			name:   "oom killed",
+			result: &ExitResult{ExitCode: 137, Signal: 9, OOMKilled: true},
+			exp:    structs.TaskExitReasonOOMKilled,
+		},
+		{
+			name:   "wait error",
+			result: &ExitResult{Err: errors.New("executor died")},
+			exp:    structs.TaskExitReasonExecutorFailure,
+		},
+		{
+			name:   "set by driver",
+			result: &ExitResult{ExitCode: 130, Signal: 2, ExitReason: structs.TaskExitReasonNodeShutdown},
+			exp:    structs.TaskExitReasonNodeShutdown,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, tc.result.Reason())
+		})
+	}
+}
+
+func TestExitResultRoundTrip(t *testing.T) {
+	ci.Parallel(t)
+
+	input := &ExitResult{
+		ExitCode:   137,
+		Signal:     9,
+		OOMKilled:  true,
+		ExitReason: structs.TaskExitReasonOOMKilled,
+	}
+
+	must.Eq(t, input, exitResultFromProto(exitResultToProto(input)))
+}
@@ -56,6 +56,65 @@ func (TaskState) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_4a8f45747846a74d, []int{0}
 }
 
+// StatUnit is the unit a driver measured a field of the stats of a task in.
+// The client converts fields in other units than it expects, and rejects
+// stats with fields in units it can't convert, so that drivers on different
+// platforms can't report the same field in different units unnoticed.
+// Drivers which don't declare the units of their fields must report them in
+// the units the client expects: bytes for memory, percent of one core for
+// system_mode, user_mode and percent, MHz for total_ticks, nanoseconds for
+// throttled_time, and seconds for total_cpu_seconds.
+type StatUnit int32
+
+const (
+	StatUnit_STAT_UNIT_UNSPECIFIED  StatUnit = 0
+	StatUnit_STAT_UNIT_BYTES        StatUnit = 1
+	StatUnit_STAT_UNIT_KIBIBYTES    StatUnit = 2
+	StatUnit_STAT_UNIT_NANOSECONDS  StatUnit = 3
+	StatUnit_STAT_UNIT_MICROSECONDS StatUnit = 4
+	StatUnit_STAT_UNIT_SECONDS      StatUnit = 5
+	// STAT_UNIT_PERCENT is a percent from 0 to 100 of one core
+	StatUnit_STAT_UNIT_PERCENT StatUnit = 6
+	// STAT_UNIT_RATIO is a fraction from 0 to 1 of one core
+	StatUnit_STAT_UNIT_RATIO StatUnit = 7
+	StatUnit_STAT_UNIT_MHZ   StatUnit = 8
+	StatUnit_STAT_UNIT_COUNT StatUnit = 9
+)
+
+var StatUnit_name = map[int32]string{
+	0: "STAT_UNIT_UNSPECIFIED",
+	1: "STAT_UNIT_BYTES",
+	2: "STAT_UNIT_KIBIBYTES",
+	3: "STAT_UNIT_NANOSECONDS",
+	4: "STAT_UNIT_MICROSECONDS",
+	5: "STAT_UNIT_SECONDS",
+	6: "STAT_UNIT_PERCENT",
+	7: "STAT_UNIT_RATIO",
+	8: "STAT_UNIT_MHZ",
+	9: "STAT_UNIT_COUNT",
+}
+
+var StatUnit_value = map[string]int32{
+	"STAT_UNIT_UNSPECIFIED":  0,
+	"STAT_UNIT_BYTES":        1,
+	"STAT_UNIT_KIBIBYTES":    2,
+	"STAT_UNIT_NANOSECONDS":  3,
+	"STAT_UNIT_MICROSECONDS": 4,
+	"STAT_UNIT_SECONDS":      5,
+	"STAT_UNIT_PERCENT":      6,
+	"STAT_UNIT_RATIO":        7,
+	"STAT_UNIT_MHZ":          8,
+	"STAT_UNIT_COUNT":        9,
+}
+
+func (x StatUnit) String() string {
+	return proto.EnumName(StatUnit_name, int32(x))
+}
+
+func (StatUnit) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{1}
+}
+
 type FingerprintResponse_HealthState int32
 
 const (
@@ -140,7 +199,7 @@ func (x DriverCapabilities_FSIsolation) String() string {
 }
 
 func (DriverCapabilities_FSIsolation) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{39, 0}
 }
 
 type DriverCapabilities_MountConfigs int32
@@ -168,7 +227,7 @@ func (x DriverCapabilities_MountConfigs) String() string {
 }
 
 func (DriverCapabilities_MountConfigs) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 1}
+	return fileDescriptor_4a8f45747846a74d, []int{39, 1}
 }
 
 type NetworkIsolationSpec_NetworkIsolationMode int32
@@ -199,7 +258,7 @@ func (x NetworkIsolationSpec_NetworkIsolationMode) String() string {
 }
 
 func (NetworkIsolationSpec_NetworkIsolationMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{42, 0}
 }
 
 type CPUUsage_Fields int32
@@ -211,6 +270,7 @@ const (
 	CPUUsage_THROTTLED_PERIODS CPUUsage_Fields = 3
 	CPUUsage_THROTTLED_TIME    CPUUsage_Fields = 4
 	CPUUsage_PERCENT           CPUUsage_Fields = 5
+	CPUUsage_TOTAL_CPU_SECONDS CPUUsage_Fields = 6
 )
 
 var CPUUsage_Fields_name = map[int32]string{
@@ -220,6 +280,7 @@ var CPUUsage_Fields_name = map[int32]string{
 	3: "THROTTLED_PERIODS",
 	4: "THROTTLED_TIME",
 	5: "PERCENT",
+	6: "TOTAL_CPU_SECONDS",
 }
 
 var CPUUsage_Fields_value = map[string]int32{
@@ -229,6 +290,7 @@ var CPUUsage_Fields_value = map[string]int32{
 	"THROTTLED_PERIODS": 3,
 	"THROTTLED_TIME":    4,
 	"PERCENT":           5,
+	"TOTAL_CPU_SECONDS": 6,
 }
 
 func (x CPUUsage_Fields) String() string {
@@ -236,7 +298,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{65, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -249,6 +311,7 @@ const (
 	MemoryUsage_KERNEL_MAX_USAGE MemoryUsage_Fields = 4
 	MemoryUsage_USAGE            MemoryUsage_Fields = 5
 	MemoryUsage_SWAP             MemoryUsage_Fields = 6
+	MemoryUsage_SHARED           MemoryUsage_Fields = 7
 )
 
 var MemoryUsage_Fields_name = map[int32]string{
@@ -259,6 +322,7 @@ var MemoryUsage_Fields_name = map[int32]string{
 	4: "KERNEL_MAX_USAGE",
 	5: "USAGE",
 	6: "SWAP",
+	7: "SHARED",
 }
 
 var MemoryUsage_Fields_value = map[string]int32{
@@ -269,6 +333,7 @@ var MemoryUsage_Fields_value = map[string]int32{
 	"KERNEL_MAX_USAGE": 4,
 	"USAGE":            5,
 	"SWAP":             6,
+	"SHARED":           7,
 }
 
 func (x MemoryUsage_Fields) String() string {
@@ -276,7 +341,7 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{67, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -1163,6 +1228,341 @@ func (m *TaskStatsResponse) GetStats() *TaskStats {
 	return nil
 }
 
+type AllocStatsRequest struct {
+	// TaskIds are the IDs of the target tasks
+	TaskIds []string `protobuf:"bytes,1,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	// CollectionInterval is the interval at which to stream stats to the caller
+	CollectionInterval   *duration.Duration `protobuf:"bytes,2,opt,name=collection_interval,json=collectionInterval,proto3" json:"collection_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *AllocStatsRequest) Reset()         { *m = AllocStatsRequest{} }
+func (m *AllocStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*AllocStatsRequest) ProtoMessage()    {}
+func (*AllocStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{20}
+}
+
+func (m *AllocStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocStatsRequest.Unmarshal(m, b)
+}
+func (m *AllocStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *AllocStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocStatsRequest.Merge(m, src)
+}
+func (m *AllocStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_AllocStatsRequest.Size(m)
+}
+func (m *AllocStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocStatsRequest proto.InternalMessageInfo
+
+func (m *AllocStatsRequest) GetTaskIds() []string {
+	if m != nil {
+		return m.TaskIds
+	}
+	return nil
+}
+
+func (m *AllocStatsRequest) GetCollectionInterval() *duration.Duration {
+	if m != nil {
+		return m.CollectionInterval
+	}
+	return nil
+}
+
+type AllocStatsResponse struct {
+	// Stats for the tasks that have new samples, keyed by task ID
+	Stats                map[string]*TaskStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *AllocStatsResponse) Reset()         { *m = AllocStatsResponse{} }
+func (m *AllocStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*AllocStatsResponse) ProtoMessage()    {}
+func (*AllocStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{21}
+}
+
+func (m *AllocStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocStatsResponse.Unmarshal(m, b)
+}
+func (m *AllocStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *AllocStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocStatsResponse.Merge(m, src)
+}
+func (m *AllocStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_AllocStatsResponse.Size(m)
+}
+func (m *AllocStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocStatsResponse proto.InternalMessageInfo
+
+func (m *AllocStatsResponse) GetStats() map[string]*TaskStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type TaskProcessesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskProcessesRequest) Reset()         { *m = TaskProcessesRequest{} }
+func (m *TaskProcessesRequest) String() string { return proto.CompactTextString(m) }
+func (*TaskProcessesRequest) ProtoMessage()    {}
+func (*TaskProcessesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{22}
+}
+
+func (m *TaskProcessesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskProcessesRequest.Unmarshal(m, b)
+}
+func (m *TaskProcessesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskProcessesRequest.Marshal(b, m, deterministic)
+}
+func (m *TaskProcessesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskProcessesRequest.Merge(m, src)
+}
+func (m *TaskProcessesRequest) XXX_Size() int {
+	return xxx_messageInfo_TaskProcessesRequest.Size(m)
+}
+func (m *TaskProcessesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskProcessesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskProcessesRequest proto.InternalMessageInfo
+
+func (m *TaskProcessesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type TaskProcessesResponse struct {
+	// Processes running in the task
+	Processes            []*TaskProcess `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TaskProcessesResponse) Reset()         { *m = TaskProcessesResponse{} }
+func (m *TaskProcessesResponse) String() string { return proto.CompactTextString(m) }
+func (*TaskProcessesResponse) ProtoMessage()    {}
+func (*TaskProcessesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{23}
+}
+
+func (m *TaskProcessesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskProcessesResponse.Unmarshal(m, b)
+}
+func (m *TaskProcessesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskProcessesResponse.Marshal(b, m, deterministic)
+}
+func (m *TaskProcessesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskProcessesResponse.Merge(m, src)
+}
+func (m *TaskProcessesResponse) XXX_Size() int {
+	return xxx_messageInfo_TaskProcessesResponse.Size(m)
+}
+func (m *TaskProcessesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskProcessesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskProcessesResponse proto.InternalMessageInfo
+
+func (m *TaskProcessesResponse) GetProcesses() []*TaskProcess {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type ProfileTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Duration is how long to sample stacks for, in nanoseconds
+	Duration             int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileTaskRequest) Reset()         { *m = ProfileTaskRequest{} }
+func (m *ProfileTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*ProfileTaskRequest) ProtoMessage()    {}
+func (*ProfileTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{24}
+}
+
+func (m *ProfileTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileTaskRequest.Unmarshal(m, b)
+}
+func (m *ProfileTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *ProfileTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileTaskRequest.Merge(m, src)
+}
+func (m *ProfileTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_ProfileTaskRequest.Size(m)
+}
+func (m *ProfileTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileTaskRequest proto.InternalMessageInfo
+
+func (m *ProfileTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *ProfileTaskRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type ProfileTaskResponse struct {
+	// FoldedStacks holds one line per distinct stack sampled, followed by
+	// the number of times it was sampled
+	FoldedStacks         []byte   `protobuf:"bytes,1,opt,name=folded_stacks,json=foldedStacks,proto3" json:"folded_stacks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileTaskResponse) Reset()         { *m = ProfileTaskResponse{} }
+func (m *ProfileTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*ProfileTaskResponse) ProtoMessage()    {}
+func (*ProfileTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{25}
+}
+
+func (m *ProfileTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileTaskResponse.Unmarshal(m, b)
+}
+func (m *ProfileTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *ProfileTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileTaskResponse.Merge(m, src)
+}
+func (m *ProfileTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_ProfileTaskResponse.Size(m)
+}
+func (m *ProfileTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileTaskResponse proto.InternalMessageInfo
+
+func (m *ProfileTaskResponse) GetFoldedStacks() []byte {
+	if m != nil {
+		return m.FoldedStacks
+	}
+	return nil
+}
+
+type TaskProcess struct {
+	// Pid is the process ID
+	Pid int64 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Ppid is the ID of the parent process
+	Ppid int64 `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	// Cmdline is the command line of the process
+	Cmdline []string `protobuf:"bytes,3,rep,name=cmdline,proto3" json:"cmdline,omitempty"`
+	// State is the process state as reported by the operating system
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// StartTime is when the process started
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TaskProcess) Reset()         { *m = TaskProcess{} }
+func (m *TaskProcess) String() string { return proto.CompactTextString(m) }
+func (*TaskProcess) ProtoMessage()    {}
+func (*TaskProcess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{26}
+}
+
+func (m *TaskProcess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskProcess.Unmarshal(m, b)
+}
+func (m *TaskProcess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskProcess.Marshal(b, m, deterministic)
+}
+func (m *TaskProcess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskProcess.Merge(m, src)
+}
+func (m *TaskProcess) XXX_Size() int {
+	return xxx_messageInfo_TaskProcess.Size(m)
+}
+func (m *TaskProcess) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskProcess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskProcess proto.InternalMessageInfo
+
+func (m *TaskProcess) GetPid() int64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *TaskProcess) GetPpid() int64 {
+	if m != nil {
+		return m.Ppid
+	}
+	return 0
+}
+
+func (m *TaskProcess) GetCmdline() []string {
+	if m != nil {
+		return m.Cmdline
+	}
+	return nil
+}
+
+func (m *TaskProcess) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *TaskProcess) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
 type TaskEventsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1173,7 +1573,7 @@ func (m *TaskEventsRequest) Reset()         { *m = TaskEventsRequest{} }
 func (m *TaskEventsRequest) String() string { return proto.CompactTextString(m) }
 func (*TaskEventsRequest) ProtoMessage()    {}
 func (*TaskEventsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{20}
+	return fileDescriptor_4a8f45747846a74d, []int{27}
 }
 
 func (m *TaskEventsRequest) XXX_Unmarshal(b []byte) error {
@@ -1208,7 +1608,7 @@ func (m *SignalTaskRequest) Reset()         { *m = SignalTaskRequest{} }
 func (m *SignalTaskRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalTaskRequest) ProtoMessage()    {}
 func (*SignalTaskRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{21}
+	return fileDescriptor_4a8f45747846a74d, []int{28}
 }
 
 func (m *SignalTaskRequest) XXX_Unmarshal(b []byte) error {
@@ -1253,7 +1653,7 @@ func (m *SignalTaskResponse) Reset()         { *m = SignalTaskResponse{} }
 func (m *SignalTaskResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalTaskResponse) ProtoMessage()    {}
 func (*SignalTaskResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{22}
+	return fileDescriptor_4a8f45747846a74d, []int{29}
 }
 
 func (m *SignalTaskResponse) XXX_Unmarshal(b []byte) error {
@@ -1291,7 +1691,7 @@ func (m *ExecTaskRequest) Reset()         { *m = ExecTaskRequest{} }
 func (m *ExecTaskRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskRequest) ProtoMessage()    {}
 func (*ExecTaskRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{23}
+	return fileDescriptor_4a8f45747846a74d, []int{30}
 }
 
 func (m *ExecTaskRequest) XXX_Unmarshal(b []byte) error {
@@ -1349,7 +1749,7 @@ func (m *ExecTaskResponse) Reset()         { *m = ExecTaskResponse{} }
 func (m *ExecTaskResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskResponse) ProtoMessage()    {}
 func (*ExecTaskResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{24}
+	return fileDescriptor_4a8f45747846a74d, []int{31}
 }
 
 func (m *ExecTaskResponse) XXX_Unmarshal(b []byte) error {
@@ -1403,7 +1803,7 @@ func (m *ExecTaskStreamingIOOperation) Reset()         { *m = ExecTaskStreamingI
 func (m *ExecTaskStreamingIOOperation) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskStreamingIOOperation) ProtoMessage()    {}
 func (*ExecTaskStreamingIOOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{25}
+	return fileDescriptor_4a8f45747846a74d, []int{32}
 }
 
 func (m *ExecTaskStreamingIOOperation) XXX_Unmarshal(b []byte) error {
@@ -1451,7 +1851,7 @@ func (m *ExecTaskStreamingRequest) Reset()         { *m = ExecTaskStreamingReque
 func (m *ExecTaskStreamingRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskStreamingRequest) ProtoMessage()    {}
 func (*ExecTaskStreamingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{26}
+	return fileDescriptor_4a8f45747846a74d, []int{33}
 }
 
 func (m *ExecTaskStreamingRequest) XXX_Unmarshal(b []byte) error {
@@ -1506,7 +1906,7 @@ func (m *ExecTaskStreamingRequest_Setup) Reset()         { *m = ExecTaskStreamin
 func (m *ExecTaskStreamingRequest_Setup) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskStreamingRequest_Setup) ProtoMessage()    {}
 func (*ExecTaskStreamingRequest_Setup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{26, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{33, 0}
 }
 
 func (m *ExecTaskStreamingRequest_Setup) XXX_Unmarshal(b []byte) error {
@@ -1560,7 +1960,7 @@ func (m *ExecTaskStreamingRequest_TerminalSize) Reset()         { *m = ExecTaskS
 func (m *ExecTaskStreamingRequest_TerminalSize) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskStreamingRequest_TerminalSize) ProtoMessage()    {}
 func (*ExecTaskStreamingRequest_TerminalSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{26, 1}
+	return fileDescriptor_4a8f45747846a74d, []int{33, 1}
 }
 
 func (m *ExecTaskStreamingRequest_TerminalSize) XXX_Unmarshal(b []byte) error {
@@ -1609,7 +2009,7 @@ func (m *ExecTaskStreamingResponse) Reset()         { *m = ExecTaskStreamingResp
 func (m *ExecTaskStreamingResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecTaskStreamingResponse) ProtoMessage()    {}
 func (*ExecTaskStreamingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{27}
+	return fileDescriptor_4a8f45747846a74d, []int{34}
 }
 
 func (m *ExecTaskStreamingResponse) XXX_Unmarshal(b []byte) error {
@@ -1672,7 +2072,7 @@ func (m *CreateNetworkRequest) Reset()         { *m = CreateNetworkRequest{} }
 func (m *CreateNetworkRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworkRequest) ProtoMessage()    {}
 func (*CreateNetworkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{28}
+	return fileDescriptor_4a8f45747846a74d, []int{35}
 }
 
 func (m *CreateNetworkRequest) XXX_Unmarshal(b []byte) error {
@@ -1722,7 +2122,7 @@ func (m *CreateNetworkResponse) Reset()         { *m = CreateNetworkResponse{} }
 func (m *CreateNetworkResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateNetworkResponse) ProtoMessage()    {}
 func (*CreateNetworkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{29}
+	return fileDescriptor_4a8f45747846a74d, []int{36}
 }
 
 func (m *CreateNetworkResponse) XXX_Unmarshal(b []byte) error {
@@ -1770,7 +2170,7 @@ func (m *DestroyNetworkRequest) Reset()         { *m = DestroyNetworkRequest{} }
 func (m *DestroyNetworkRequest) String() string { return proto.CompactTextString(m) }
 func (*DestroyNetworkRequest) ProtoMessage()    {}
 func (*DestroyNetworkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{30}
+	return fileDescriptor_4a8f45747846a74d, []int{37}
 }
 
 func (m *DestroyNetworkRequest) XXX_Unmarshal(b []byte) error {
@@ -1815,7 +2215,7 @@ func (m *DestroyNetworkResponse) Reset()         { *m = DestroyNetworkResponse{}
 func (m *DestroyNetworkResponse) String() string { return proto.CompactTextString(m) }
 func (*DestroyNetworkResponse) ProtoMessage()    {}
 func (*DestroyNetworkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{31}
+	return fileDescriptor_4a8f45747846a74d, []int{38}
 }
 
 func (m *DestroyNetworkResponse) XXX_Unmarshal(b []byte) error {
@@ -1857,7 +2257,19 @@ type DriverCapabilities struct {
 	DisableLogCollection bool `protobuf:"varint,8,opt,name=disable_log_collection,json=disableLogCollection,proto3" json:"disable_log_collection,omitempty"`
 	// dynamic_workload_users indicates the task is capable of using UID/GID
 	// assigned from the Nomad client as user credentials for the task.
-	DynamicWorkloadUsers bool     `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	DynamicWorkloadUsers bool `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	// stats declares which optional resource usage stats the driver reports
+	// for its tasks. Unset means the driver has not declared its stats.
+	Stats *StatsCapabilities `protobuf:"bytes,10,opt,name=stats,proto3" json:"stats,omitempty"`
+	// alloc_stats indicates the driver implements the AllocStats RPC.
+	AllocStats bool `protobuf:"varint,11,opt,name=alloc_stats,json=allocStats,proto3" json:"alloc_stats,omitempty"`
+	// processes indicates the driver implements the TaskProcesses RPC.
+	Processes bool `protobuf:"varint,12,opt,name=processes,proto3" json:"processes,omitempty"`
+	// profile indicates the driver implements the ProfileTask RPC.
+	Profile bool `protobuf:"varint,13,opt,name=profile,proto3" json:"profile,omitempty"`
+	// shared_cgroup indicates the driver runs tasks in the cgroup of the
+	// cpuset_cgroup of their resources.
+	SharedCgroup         bool     `protobuf:"varint,14,opt,name=shared_cgroup,json=sharedCgroup,proto3" json:"shared_cgroup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1867,7 +2279,7 @@ func (m *DriverCapabilities) Reset()         { *m = DriverCapabilities{} }
 func (m *DriverCapabilities) String() string { return proto.CompactTextString(m) }
 func (*DriverCapabilities) ProtoMessage()    {}
 func (*DriverCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32}
+	return fileDescriptor_4a8f45747846a74d, []int{39}
 }
 
 func (m *DriverCapabilities) XXX_Unmarshal(b []byte) error {
@@ -1952,42 +2364,240 @@ func (m *DriverCapabilities) GetDynamicWorkloadUsers() bool {
 	return false
 }
 
-type NetworkIsolationSpec struct {
-	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
-	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	Labels               map[string]string                         `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	HostsConfig          *HostsConfig                              `protobuf:"bytes,4,opt,name=hostsConfig,proto3" json:"hostsConfig,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+func (m *DriverCapabilities) GetStats() *StatsCapabilities {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
 }
 
-func (m *NetworkIsolationSpec) Reset()         { *m = NetworkIsolationSpec{} }
-func (m *NetworkIsolationSpec) String() string { return proto.CompactTextString(m) }
-func (*NetworkIsolationSpec) ProtoMessage()    {}
-func (*NetworkIsolationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33}
+func (m *DriverCapabilities) GetAllocStats() bool {
+	if m != nil {
+		return m.AllocStats
+	}
+	return false
 }
 
-func (m *NetworkIsolationSpec) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NetworkIsolationSpec.Unmarshal(m, b)
-}
-func (m *NetworkIsolationSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NetworkIsolationSpec.Marshal(b, m, deterministic)
-}
-func (m *NetworkIsolationSpec) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NetworkIsolationSpec.Merge(m, src)
-}
-func (m *NetworkIsolationSpec) XXX_Size() int {
-	return xxx_messageInfo_NetworkIsolationSpec.Size(m)
-}
-func (m *NetworkIsolationSpec) XXX_DiscardUnknown() {
-	xxx_messageInfo_NetworkIsolationSpec.DiscardUnknown(m)
+func (m *DriverCapabilities) GetProcesses() bool {
+	if m != nil {
+		return m.Processes
+	}
+	return false
 }
 
-var xxx_messageInfo_NetworkIsolationSpec proto.InternalMessageInfo
-
-func (m *NetworkIsolationSpec) GetMode() NetworkIsolationSpec_NetworkIsolationMode {
+func (m *DriverCapabilities) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetSharedCgroup() bool {
+	if m != nil {
+		return m.SharedCgroup
+	}
+	return false
+}
+
+type StatsCapabilities struct {
+	// network indicates the driver reports task network usage.
+	Network bool `protobuf:"varint,1,opt,name=network,proto3" json:"network,omitempty"`
+	// disk_io indicates the driver reports task block I/O usage.
+	DiskIo bool `protobuf:"varint,2,opt,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	// devices indicates the driver reports usage of devices such as GPUs
+	// attached to the task.
+	Devices bool `protobuf:"varint,3,opt,name=devices,proto3" json:"devices,omitempty"`
+	// pressure indicates the driver reports pressure stall information.
+	Pressure bool `protobuf:"varint,4,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	// gauges are the names of the driver-specific gauges the driver reports.
+	Gauges []string `protobuf:"bytes,5,rep,name=gauges,proto3" json:"gauges,omitempty"`
+	// schema describes the driver-specific gauges the driver reports, which
+	// declares them like their names in gauges.
+	Schema []*StatSchema `protobuf:"bytes,6,rep,name=schema,proto3" json:"schema,omitempty"`
+	// memory_measured and cpu_measured are the memory and CPU stats the
+	// driver measures.
+	MemoryMeasured       []MemoryUsage_Fields `protobuf:"varint,7,rep,packed,name=memory_measured,json=memoryMeasured,proto3,enum=hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields" json:"memory_measured,omitempty"`
+	CpuMeasured          []CPUUsage_Fields    `protobuf:"varint,8,rep,packed,name=cpu_measured,json=cpuMeasured,proto3,enum=hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields" json:"cpu_measured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StatsCapabilities) Reset()         { *m = StatsCapabilities{} }
+func (m *StatsCapabilities) String() string { return proto.CompactTextString(m) }
+func (*StatsCapabilities) ProtoMessage()    {}
+func (*StatsCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{40}
+}
+
+func (m *StatsCapabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsCapabilities.Unmarshal(m, b)
+}
+func (m *StatsCapabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsCapabilities.Marshal(b, m, deterministic)
+}
+func (m *StatsCapabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsCapabilities.Merge(m, src)
+}
+func (m *StatsCapabilities) XXX_Size() int {
+	return xxx_messageInfo_StatsCapabilities.Size(m)
+}
+func (m *StatsCapabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsCapabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsCapabilities proto.InternalMessageInfo
+
+func (m *StatsCapabilities) GetNetwork() bool {
+	if m != nil {
+		return m.Network
+	}
+	return false
+}
+
+func (m *StatsCapabilities) GetDiskIo() bool {
+	if m != nil {
+		return m.DiskIo
+	}
+	return false
+}
+
+func (m *StatsCapabilities) GetDevices() bool {
+	if m != nil {
+		return m.Devices
+	}
+	return false
+}
+
+func (m *StatsCapabilities) GetPressure() bool {
+	if m != nil {
+		return m.Pressure
+	}
+	return false
+}
+
+func (m *StatsCapabilities) GetGauges() []string {
+	if m != nil {
+		return m.Gauges
+	}
+	return nil
+}
+
+func (m *StatsCapabilities) GetSchema() []*StatSchema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *StatsCapabilities) GetMemoryMeasured() []MemoryUsage_Fields {
+	if m != nil {
+		return m.MemoryMeasured
+	}
+	return nil
+}
+
+func (m *StatsCapabilities) GetCpuMeasured() []CPUUsage_Fields {
+	if m != nil {
+		return m.CpuMeasured
+	}
+	return nil
+}
+
+type StatSchema struct {
+	// name is the name the gauge is reported under.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// unit is the unit of the gauge, such as "bytes" or "requests".
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// description is a human readable description of the gauge.
+	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatSchema) Reset()         { *m = StatSchema{} }
+func (m *StatSchema) String() string { return proto.CompactTextString(m) }
+func (*StatSchema) ProtoMessage()    {}
+func (*StatSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{41}
+}
+
+func (m *StatSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatSchema.Unmarshal(m, b)
+}
+func (m *StatSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatSchema.Marshal(b, m, deterministic)
+}
+func (m *StatSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatSchema.Merge(m, src)
+}
+func (m *StatSchema) XXX_Size() int {
+	return xxx_messageInfo_StatSchema.Size(m)
+}
+func (m *StatSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatSchema proto.InternalMessageInfo
+
+func (m *StatSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StatSchema) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *StatSchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type NetworkIsolationSpec struct {
+	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
+	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Labels               map[string]string                         `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HostsConfig          *HostsConfig                              `protobuf:"bytes,4,opt,name=hostsConfig,proto3" json:"hostsConfig,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *NetworkIsolationSpec) Reset()         { *m = NetworkIsolationSpec{} }
+func (m *NetworkIsolationSpec) String() string { return proto.CompactTextString(m) }
+func (*NetworkIsolationSpec) ProtoMessage()    {}
+func (*NetworkIsolationSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{42}
+}
+
+func (m *NetworkIsolationSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkIsolationSpec.Unmarshal(m, b)
+}
+func (m *NetworkIsolationSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkIsolationSpec.Marshal(b, m, deterministic)
+}
+func (m *NetworkIsolationSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkIsolationSpec.Merge(m, src)
+}
+func (m *NetworkIsolationSpec) XXX_Size() int {
+	return xxx_messageInfo_NetworkIsolationSpec.Size(m)
+}
+func (m *NetworkIsolationSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkIsolationSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkIsolationSpec proto.InternalMessageInfo
+
+func (m *NetworkIsolationSpec) GetMode() NetworkIsolationSpec_NetworkIsolationMode {
 	if m != nil {
 		return m.Mode
 	}
@@ -2027,7 +2637,7 @@ func (m *HostsConfig) Reset()         { *m = HostsConfig{} }
 func (m *HostsConfig) String() string { return proto.CompactTextString(m) }
 func (*HostsConfig) ProtoMessage()    {}
 func (*HostsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{34}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *HostsConfig) XXX_Unmarshal(b []byte) error {
@@ -2075,7 +2685,7 @@ func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
 func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
 func (*DNSConfig) ProtoMessage()    {}
 func (*DNSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{35}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
@@ -2167,7 +2777,11 @@ type TaskConfig struct {
 	// NodeId is the ID of the node where the associated allocation is running
 	NodeId string `protobuf:"bytes,21,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	// ParentJobID is the parent id for dispatch and periodic jobs
-	ParentJobId          string   `protobuf:"bytes,22,opt,name=parent_job_id,json=parentJobId,proto3" json:"parent_job_id,omitempty"`
+	ParentJobId string `protobuf:"bytes,22,opt,name=parent_job_id,json=parentJobId,proto3" json:"parent_job_id,omitempty"`
+	// FramedLogs is true if the task's log collector decodes framed output
+	FramedLogs bool `protobuf:"varint,23,opt,name=framed_logs,json=framedLogs,proto3" json:"framed_logs,omitempty"`
+	// QosClasses is true if the client enforces the QoS classes of tasks
+	QosClasses           bool     `protobuf:"varint,24,opt,name=qos_classes,json=qosClasses,proto3" json:"qos_classes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2177,7 +2791,7 @@ func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
 func (m *TaskConfig) String() string { return proto.CompactTextString(m) }
 func (*TaskConfig) ProtoMessage()    {}
 func (*TaskConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{36}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *TaskConfig) XXX_Unmarshal(b []byte) error {
@@ -2352,6 +2966,20 @@ func (m *TaskConfig) GetParentJobId() string {
 	return ""
 }
 
+func (m *TaskConfig) GetFramedLogs() bool {
+	if m != nil {
+		return m.FramedLogs
+	}
+	return false
+}
+
+func (m *TaskConfig) GetQosClasses() bool {
+	if m != nil {
+		return m.QosClasses
+	}
+	return false
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -2369,7 +2997,7 @@ func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{37}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *Resources) XXX_Unmarshal(b []byte) error {
@@ -2424,7 +3052,7 @@ func (m *AllocatedTaskResources) Reset()         { *m = AllocatedTaskResources{}
 func (m *AllocatedTaskResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedTaskResources) ProtoMessage()    {}
 func (*AllocatedTaskResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{38}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *AllocatedTaskResources) XXX_Unmarshal(b []byte) error {
@@ -2477,7 +3105,7 @@ func (m *AllocatedCpuResources) Reset()         { *m = AllocatedCpuResources{} }
 func (m *AllocatedCpuResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedCpuResources) ProtoMessage()    {}
 func (*AllocatedCpuResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{39}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *AllocatedCpuResources) XXX_Unmarshal(b []byte) error {
@@ -2517,7 +3145,7 @@ func (m *AllocatedMemoryResources) Reset()         { *m = AllocatedMemoryResourc
 func (m *AllocatedMemoryResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedMemoryResources) ProtoMessage()    {}
 func (*AllocatedMemoryResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{40}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *AllocatedMemoryResources) XXX_Unmarshal(b []byte) error {
@@ -2568,7 +3196,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2643,7 +3271,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2692,7 +3320,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{52}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2759,7 +3387,11 @@ type LinuxResources struct {
 	CpusetCgroup string `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
 	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
-	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	PercentTicks float64 `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	// MemoryPolicy is the numa memory policy of the task. Default: "" (not specified)
+	MemoryPolicy string `protobuf:"bytes,10,opt,name=memory_policy,json=memoryPolicy,proto3" json:"memory_policy,omitempty"`
+	// MemoryNodes are the numa nodes the memory policy applies to
+	MemoryNodes          string   `protobuf:"bytes,11,opt,name=memory_nodes,json=memoryNodes,proto3" json:"memory_nodes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2769,7 +3401,7 @@ func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{53}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2846,6 +3478,20 @@ func (m *LinuxResources) GetPercentTicks() float64 {
 	return 0
 }
 
+func (m *LinuxResources) GetMemoryPolicy() string {
+	if m != nil {
+		return m.MemoryPolicy
+	}
+	return ""
+}
+
+func (m *LinuxResources) GetMemoryNodes() string {
+	if m != nil {
+		return m.MemoryNodes
+	}
+	return ""
+}
+
 type Mount struct {
 	// TaskPath is the file path within the task directory to mount to
 	TaskPath string `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
@@ -2866,7 +3512,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2944,7 +3590,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -3006,7 +3652,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -3074,7 +3720,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3123,7 +3769,9 @@ type ExitResult struct {
 	// Signal is set if a signal was sent to the task
 	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
 	// OomKilled is true if the task exited as a result of the OOM Killer
-	OomKilled            bool     `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	OomKilled bool `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	// ExitReason classifies the exit, see structs.TaskExitReason
+	ExitReason           string   `protobuf:"bytes,4,opt,name=exit_reason,json=exitReason,proto3" json:"exit_reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3133,7 +3781,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{58}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3175,6 +3823,13 @@ func (m *ExitResult) GetOomKilled() bool {
 	return false
 }
 
+func (m *ExitResult) GetExitReason() string {
+	if m != nil {
+		return m.ExitReason
+	}
+	return ""
+}
+
 // TaskStatus includes information of a specific task
 type TaskStatus struct {
 	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3197,7 +3852,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{59}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3273,7 +3928,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{60}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3309,17 +3964,30 @@ type TaskStats struct {
 	// AggResourceUsage is the aggreate usage of all processes
 	AggResourceUsage *TaskResourceUsage `protobuf:"bytes,3,opt,name=agg_resource_usage,json=aggResourceUsage,proto3" json:"agg_resource_usage,omitempty"`
 	// ResourceUsageByPid breaks the usage stats by process
-	ResourceUsageByPid   map[string]*TaskResourceUsage `protobuf:"bytes,4,rep,name=resource_usage_by_pid,json=resourceUsageByPid,proto3" json:"resource_usage_by_pid,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	ResourceUsageByPid map[string]*TaskResourceUsage `protobuf:"bytes,4,rep,name=resource_usage_by_pid,json=resourceUsageByPid,proto3" json:"resource_usage_by_pid,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// CgroupPath is the path of the cgroup the task processes are members of
+	CgroupPath string `protobuf:"bytes,5,opt,name=cgroup_path,json=cgroupPath,proto3" json:"cgroup_path,omitempty"`
+	// CgroupId is the inode number of the cgroup, as seen by the kernel
+	CgroupId uint64 `protobuf:"varint,6,opt,name=cgroup_id,json=cgroupId,proto3" json:"cgroup_id,omitempty"`
+	// ExecutorPid is the PID of the executor supervising the task
+	ExecutorPid int32 `protobuf:"varint,7,opt,name=executor_pid,json=executorPid,proto3" json:"executor_pid,omitempty"`
+	// Limits are the limits enforced on the task's resource usage, if known
+	Limits *ResourceLimits `protobuf:"bytes,8,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Sequence numbers the samples collected for the task, starting at 1
+	Sequence uint64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// CollectionDuration is the monotonic time elapsed since the previous
+	// sample was collected, unset for the first sample
+	CollectionDuration   *duration.Duration `protobuf:"bytes,10,opt,name=collection_duration,json=collectionDuration,proto3" json:"collection_duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{61}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3368,21 +4036,131 @@ func (m *TaskStats) GetResourceUsageByPid() map[string]*TaskResourceUsage {
 	return nil
 }
 
+func (m *TaskStats) GetCgroupPath() string {
+	if m != nil {
+		return m.CgroupPath
+	}
+	return ""
+}
+
+func (m *TaskStats) GetCgroupId() uint64 {
+	if m != nil {
+		return m.CgroupId
+	}
+	return 0
+}
+
+func (m *TaskStats) GetExecutorPid() int32 {
+	if m != nil {
+		return m.ExecutorPid
+	}
+	return 0
+}
+
+func (m *TaskStats) GetLimits() *ResourceLimits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func (m *TaskStats) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *TaskStats) GetCollectionDuration() *duration.Duration {
+	if m != nil {
+		return m.CollectionDuration
+	}
+	return nil
+}
+
+type ResourceLimits struct {
+	// MemoryMax is the hard memory limit in bytes, zero if not limited
+	MemoryMax uint64 `protobuf:"varint,1,opt,name=memory_max,json=memoryMax,proto3" json:"memory_max,omitempty"`
+	// CpuMax is how many CPUs worth of time the task may use, zero if not
+	// limited
+	CpuMax               float64  `protobuf:"fixed64,2,opt,name=cpu_max,json=cpuMax,proto3" json:"cpu_max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceLimits) Reset()         { *m = ResourceLimits{} }
+func (m *ResourceLimits) String() string { return proto.CompactTextString(m) }
+func (*ResourceLimits) ProtoMessage()    {}
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{62}
+}
+
+func (m *ResourceLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceLimits.Unmarshal(m, b)
+}
+func (m *ResourceLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceLimits.Marshal(b, m, deterministic)
+}
+func (m *ResourceLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceLimits.Merge(m, src)
+}
+func (m *ResourceLimits) XXX_Size() int {
+	return xxx_messageInfo_ResourceLimits.Size(m)
+}
+func (m *ResourceLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceLimits proto.InternalMessageInfo
+
+func (m *ResourceLimits) GetMemoryMax() uint64 {
+	if m != nil {
+		return m.MemoryMax
+	}
+	return 0
+}
+
+func (m *ResourceLimits) GetCpuMax() float64 {
+	if m != nil {
+		return m.CpuMax
+	}
+	return 0
+}
+
 type TaskResourceUsage struct {
 	// CPU usage stats
 	Cpu *CPUUsage `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	// Memory usage stats
-	Memory               *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Memory *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Perf is the hardware performance counter stats, if collected
+	Perf *PerfUsage `protobuf:"bytes,3,opt,name=perf,proto3" json:"perf,omitempty"`
+	// Gauges are the driver-specific gauges reported by the driver, keyed by
+	// name
+	Gauges map[string]*Gauge `protobuf:"bytes,4,rep,name=gauges,proto3" json:"gauges,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Egress is the traffic sent to each class of destinations, keyed by
+	// class name
+	Egress map[string]*EgressUsage `protobuf:"bytes,5,rep,name=egress,proto3" json:"egress,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Resctrl is the cache occupancy and memory bandwidth of the resctrl
+	// class the task is assigned to, if any
+	Resctrl *ResctrlUsage `protobuf:"bytes,6,opt,name=resctrl,proto3" json:"resctrl,omitempty"`
+	// Unreadable is set on the usage of a process whose stats could not be
+	// read in time
+	Unreadable bool `protobuf:"varint,7,opt,name=unreadable,proto3" json:"unreadable,omitempty"`
+	// DiskIO is the block I/O the task did since it started
+	DiskIo *DiskIOUsage `protobuf:"bytes,8,opt,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	// Pressure is the pressure stall information of the task
+	Pressure             *PressureUsage `protobuf:"bytes,9,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{63}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3417,25 +4195,129 @@ func (m *TaskResourceUsage) GetMemory() *MemoryUsage {
 	return nil
 }
 
-type CPUUsage struct {
+func (m *TaskResourceUsage) GetPerf() *PerfUsage {
+	if m != nil {
+		return m.Perf
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetGauges() map[string]*Gauge {
+	if m != nil {
+		return m.Gauges
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetEgress() map[string]*EgressUsage {
+	if m != nil {
+		return m.Egress
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetResctrl() *ResctrlUsage {
+	if m != nil {
+		return m.Resctrl
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetUnreadable() bool {
+	if m != nil {
+		return m.Unreadable
+	}
+	return false
+}
+
+func (m *TaskResourceUsage) GetDiskIo() *DiskIOUsage {
+	if m != nil {
+		return m.DiskIo
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetPressure() *PressureUsage {
+	if m != nil {
+		return m.Pressure
+	}
+	return nil
+}
+
+// Gauge is a driver-specific measurement of a task's resource usage
+type Gauge struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// unit is the unit of the value, such as "bytes" or "requests"
+	Unit                 string   `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Gauge) Reset()         { *m = Gauge{} }
+func (m *Gauge) String() string { return proto.CompactTextString(m) }
+func (*Gauge) ProtoMessage()    {}
+func (*Gauge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{64}
+}
+
+func (m *Gauge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Gauge.Unmarshal(m, b)
+}
+func (m *Gauge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Gauge.Marshal(b, m, deterministic)
+}
+func (m *Gauge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Gauge.Merge(m, src)
+}
+func (m *Gauge) XXX_Size() int {
+	return xxx_messageInfo_Gauge.Size(m)
+}
+func (m *Gauge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Gauge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Gauge proto.InternalMessageInfo
+
+func (m *Gauge) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Gauge) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+type CPUUsage struct {
 	SystemMode       float64 `protobuf:"fixed64,1,opt,name=system_mode,json=systemMode,proto3" json:"system_mode,omitempty"`
 	UserMode         float64 `protobuf:"fixed64,2,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
 	TotalTicks       float64 `protobuf:"fixed64,3,opt,name=total_ticks,json=totalTicks,proto3" json:"total_ticks,omitempty"`
 	ThrottledPeriods uint64  `protobuf:"varint,4,opt,name=throttled_periods,json=throttledPeriods,proto3" json:"throttled_periods,omitempty"`
 	ThrottledTime    uint64  `protobuf:"varint,5,opt,name=throttled_time,json=throttledTime,proto3" json:"throttled_time,omitempty"`
 	Percent          float64 `protobuf:"fixed64,6,opt,name=percent,proto3" json:"percent,omitempty"`
+	// TotalCpuSeconds is the CPU time used by the task since it started
+	TotalCpuSeconds float64 `protobuf:"fixed64,8,opt,name=total_cpu_seconds,json=totalCpuSeconds,proto3" json:"total_cpu_seconds,omitempty"`
+	// Cores is the utilization of each core reserved for the task
+	Cores []*CoreUsage `protobuf:"bytes,9,rep,name=cores,proto3" json:"cores,omitempty"`
 	// MeasuredFields indicates which fields were actually sampled
-	MeasuredFields       []CPUUsage_Fields `protobuf:"varint,7,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields" json:"measured_fields,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	MeasuredFields []CPUUsage_Fields `protobuf:"varint,7,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields" json:"measured_fields,omitempty"`
+	// MeasuredUnits is the unit of each of MeasuredFields, in the same order
+	MeasuredUnits        []StatUnit `protobuf:"varint,10,rep,packed,name=measured_units,json=measuredUnits,proto3,enum=hashicorp.nomad.plugins.drivers.proto.StatUnit" json:"measured_units,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{65}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3498,6 +4380,20 @@ func (m *CPUUsage) GetPercent() float64 {
 	return 0
 }
 
+func (m *CPUUsage) GetTotalCpuSeconds() float64 {
+	if m != nil {
+		return m.TotalCpuSeconds
+	}
+	return 0
+}
+
+func (m *CPUUsage) GetCores() []*CoreUsage {
+	if m != nil {
+		return m.Cores
+	}
+	return nil
+}
+
 func (m *CPUUsage) GetMeasuredFields() []CPUUsage_Fields {
 	if m != nil {
 		return m.MeasuredFields
@@ -3505,6 +4401,60 @@ func (m *CPUUsage) GetMeasuredFields() []CPUUsage_Fields {
 	return nil
 }
 
+func (m *CPUUsage) GetMeasuredUnits() []StatUnit {
+	if m != nil {
+		return m.MeasuredUnits
+	}
+	return nil
+}
+
+type CoreUsage struct {
+	Id                   uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Percent              float64  `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CoreUsage) Reset()         { *m = CoreUsage{} }
+func (m *CoreUsage) String() string { return proto.CompactTextString(m) }
+func (*CoreUsage) ProtoMessage()    {}
+func (*CoreUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{66}
+}
+
+func (m *CoreUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoreUsage.Unmarshal(m, b)
+}
+func (m *CoreUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoreUsage.Marshal(b, m, deterministic)
+}
+func (m *CoreUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoreUsage.Merge(m, src)
+}
+func (m *CoreUsage) XXX_Size() int {
+	return xxx_messageInfo_CoreUsage.Size(m)
+}
+func (m *CoreUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoreUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoreUsage proto.InternalMessageInfo
+
+func (m *CoreUsage) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CoreUsage) GetPercent() float64 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
 type MemoryUsage struct {
 	Rss            uint64 `protobuf:"varint,1,opt,name=rss,proto3" json:"rss,omitempty"`
 	Cache          uint64 `protobuf:"varint,2,opt,name=cache,proto3" json:"cache,omitempty"`
@@ -3513,18 +4463,22 @@ type MemoryUsage struct {
 	KernelMaxUsage uint64 `protobuf:"varint,5,opt,name=kernel_max_usage,json=kernelMaxUsage,proto3" json:"kernel_max_usage,omitempty"`
 	Usage          uint64 `protobuf:"varint,7,opt,name=usage,proto3" json:"usage,omitempty"`
 	Swap           uint64 `protobuf:"varint,8,opt,name=swap,proto3" json:"swap,omitempty"`
+	// shared is the shared memory, such as files in /dev/shm, included in rss
+	Shared uint64 `protobuf:"varint,9,opt,name=shared,proto3" json:"shared,omitempty"`
 	// MeasuredFields indicates which fields were actually sampled
-	MeasuredFields       []MemoryUsage_Fields `protobuf:"varint,6,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields" json:"measured_fields,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	MeasuredFields []MemoryUsage_Fields `protobuf:"varint,6,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields" json:"measured_fields,omitempty"`
+	// MeasuredUnits is the unit of each of MeasuredFields, in the same order
+	MeasuredUnits        []StatUnit `protobuf:"varint,10,rep,packed,name=measured_units,json=measuredUnits,proto3,enum=hashicorp.nomad.plugins.drivers.proto.StatUnit" json:"measured_units,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{67}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3594,6 +4548,13 @@ func (m *MemoryUsage) GetSwap() uint64 {
 	return 0
 }
 
+func (m *MemoryUsage) GetShared() uint64 {
+	if m != nil {
+		return m.Shared
+	}
+	return 0
+}
+
 func (m *MemoryUsage) GetMeasuredFields() []MemoryUsage_Fields {
 	if m != nil {
 		return m.MeasuredFields
@@ -3601,6 +4562,314 @@ func (m *MemoryUsage) GetMeasuredFields() []MemoryUsage_Fields {
 	return nil
 }
 
+func (m *MemoryUsage) GetMeasuredUnits() []StatUnit {
+	if m != nil {
+		return m.MeasuredUnits
+	}
+	return nil
+}
+
+type PerfUsage struct {
+	Cycles               uint64   `protobuf:"varint,1,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	Instructions         uint64   `protobuf:"varint,2,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	CacheReferences      uint64   `protobuf:"varint,3,opt,name=cache_references,json=cacheReferences,proto3" json:"cache_references,omitempty"`
+	CacheMisses          uint64   `protobuf:"varint,4,opt,name=cache_misses,json=cacheMisses,proto3" json:"cache_misses,omitempty"`
+	BranchInstructions   uint64   `protobuf:"varint,5,opt,name=branch_instructions,json=branchInstructions,proto3" json:"branch_instructions,omitempty"`
+	BranchMisses         uint64   `protobuf:"varint,6,opt,name=branch_misses,json=branchMisses,proto3" json:"branch_misses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PerfUsage) Reset()         { *m = PerfUsage{} }
+func (m *PerfUsage) String() string { return proto.CompactTextString(m) }
+func (*PerfUsage) ProtoMessage()    {}
+func (*PerfUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{68}
+}
+
+func (m *PerfUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PerfUsage.Unmarshal(m, b)
+}
+func (m *PerfUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PerfUsage.Marshal(b, m, deterministic)
+}
+func (m *PerfUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PerfUsage.Merge(m, src)
+}
+func (m *PerfUsage) XXX_Size() int {
+	return xxx_messageInfo_PerfUsage.Size(m)
+}
+func (m *PerfUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PerfUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PerfUsage proto.InternalMessageInfo
+
+func (m *PerfUsage) GetCycles() uint64 {
+	if m != nil {
+		return m.Cycles
+	}
+	return 0
+}
+
+func (m *PerfUsage) GetInstructions() uint64 {
+	if m != nil {
+		return m.Instructions
+	}
+	return 0
+}
+
+func (m *PerfUsage) GetCacheReferences() uint64 {
+	if m != nil {
+		return m.CacheReferences
+	}
+	return 0
+}
+
+func (m *PerfUsage) GetCacheMisses() uint64 {
+	if m != nil {
+		return m.CacheMisses
+	}
+	return 0
+}
+
+func (m *PerfUsage) GetBranchInstructions() uint64 {
+	if m != nil {
+		return m.BranchInstructions
+	}
+	return 0
+}
+
+func (m *PerfUsage) GetBranchMisses() uint64 {
+	if m != nil {
+		return m.BranchMisses
+	}
+	return 0
+}
+
+type EgressUsage struct {
+	Bytes                uint64   `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Packets              uint64   `protobuf:"varint,2,opt,name=packets,proto3" json:"packets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EgressUsage) Reset()         { *m = EgressUsage{} }
+func (m *EgressUsage) String() string { return proto.CompactTextString(m) }
+func (*EgressUsage) ProtoMessage()    {}
+func (*EgressUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{69}
+}
+
+func (m *EgressUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EgressUsage.Unmarshal(m, b)
+}
+func (m *EgressUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EgressUsage.Marshal(b, m, deterministic)
+}
+func (m *EgressUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EgressUsage.Merge(m, src)
+}
+func (m *EgressUsage) XXX_Size() int {
+	return xxx_messageInfo_EgressUsage.Size(m)
+}
+func (m *EgressUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_EgressUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EgressUsage proto.InternalMessageInfo
+
+func (m *EgressUsage) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *EgressUsage) GetPackets() uint64 {
+	if m != nil {
+		return m.Packets
+	}
+	return 0
+}
+
+type ResctrlUsage struct {
+	LlcOccupancy         uint64   `protobuf:"varint,1,opt,name=llc_occupancy,json=llcOccupancy,proto3" json:"llc_occupancy,omitempty"`
+	MbmTotalBytes        uint64   `protobuf:"varint,2,opt,name=mbm_total_bytes,json=mbmTotalBytes,proto3" json:"mbm_total_bytes,omitempty"`
+	MbmLocalBytes        uint64   `protobuf:"varint,3,opt,name=mbm_local_bytes,json=mbmLocalBytes,proto3" json:"mbm_local_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResctrlUsage) Reset()         { *m = ResctrlUsage{} }
+func (m *ResctrlUsage) String() string { return proto.CompactTextString(m) }
+func (*ResctrlUsage) ProtoMessage()    {}
+func (*ResctrlUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{70}
+}
+
+func (m *ResctrlUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResctrlUsage.Unmarshal(m, b)
+}
+func (m *ResctrlUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResctrlUsage.Marshal(b, m, deterministic)
+}
+func (m *ResctrlUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResctrlUsage.Merge(m, src)
+}
+func (m *ResctrlUsage) XXX_Size() int {
+	return xxx_messageInfo_ResctrlUsage.Size(m)
+}
+func (m *ResctrlUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResctrlUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResctrlUsage proto.InternalMessageInfo
+
+func (m *ResctrlUsage) GetLlcOccupancy() uint64 {
+	if m != nil {
+		return m.LlcOccupancy
+	}
+	return 0
+}
+
+func (m *ResctrlUsage) GetMbmTotalBytes() uint64 {
+	if m != nil {
+		return m.MbmTotalBytes
+	}
+	return 0
+}
+
+func (m *ResctrlUsage) GetMbmLocalBytes() uint64 {
+	if m != nil {
+		return m.MbmLocalBytes
+	}
+	return 0
+}
+
+type DiskIOUsage struct {
+	ReadBytes            uint64   `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes           uint64   `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadOps              uint64   `protobuf:"varint,3,opt,name=read_ops,json=readOps,proto3" json:"read_ops,omitempty"`
+	WriteOps             uint64   `protobuf:"varint,4,opt,name=write_ops,json=writeOps,proto3" json:"write_ops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskIOUsage) Reset()         { *m = DiskIOUsage{} }
+func (m *DiskIOUsage) String() string { return proto.CompactTextString(m) }
+func (*DiskIOUsage) ProtoMessage()    {}
+func (*DiskIOUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{71}
+}
+
+func (m *DiskIOUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskIOUsage.Unmarshal(m, b)
+}
+func (m *DiskIOUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskIOUsage.Marshal(b, m, deterministic)
+}
+func (m *DiskIOUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskIOUsage.Merge(m, src)
+}
+func (m *DiskIOUsage) XXX_Size() int {
+	return xxx_messageInfo_DiskIOUsage.Size(m)
+}
+func (m *DiskIOUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskIOUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskIOUsage proto.InternalMessageInfo
+
+func (m *DiskIOUsage) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *DiskIOUsage) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
+func (m *DiskIOUsage) GetReadOps() uint64 {
+	if m != nil {
+		return m.ReadOps
+	}
+	return 0
+}
+
+func (m *DiskIOUsage) GetWriteOps() uint64 {
+	if m != nil {
+		return m.WriteOps
+	}
+	return 0
+}
+
+// PressureUsage is the percentage of time some of the task's processes
+// stalled on each resource over the last minute
+type PressureUsage struct {
+	Cpu                  float64  `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory               float64  `protobuf:"fixed64,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Io                   float64  `protobuf:"fixed64,3,opt,name=io,proto3" json:"io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PressureUsage) Reset()         { *m = PressureUsage{} }
+func (m *PressureUsage) String() string { return proto.CompactTextString(m) }
+func (*PressureUsage) ProtoMessage()    {}
+func (*PressureUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{72}
+}
+
+func (m *PressureUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PressureUsage.Unmarshal(m, b)
+}
+func (m *PressureUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PressureUsage.Marshal(b, m, deterministic)
+}
+func (m *PressureUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PressureUsage.Merge(m, src)
+}
+func (m *PressureUsage) XXX_Size() int {
+	return xxx_messageInfo_PressureUsage.Size(m)
+}
+func (m *PressureUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PressureUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PressureUsage proto.InternalMessageInfo
+
+func (m *PressureUsage) GetCpu() float64 {
+	if m != nil {
+		return m.Cpu
+	}
+	return 0
+}
+
+func (m *PressureUsage) GetMemory() float64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *PressureUsage) GetIo() float64 {
+	if m != nil {
+		return m.Io
+	}
+	return 0
+}
+
 type DriverTaskEvent struct {
 	// TaskId is the id of the task for the event
 	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -3623,7 +4892,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{73}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3688,6 +4957,7 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
+	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.StatUnit", StatUnit_name, StatUnit_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.StartTaskResponse_Result", StartTaskResponse_Result_name, StartTaskResponse_Result_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_FSIsolation", DriverCapabilities_FSIsolation_name, DriverCapabilities_FSIsolation_value)
@@ -3716,6 +4986,14 @@ func init() {
 	proto.RegisterType((*InspectTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.InspectTaskResponse")
 	proto.RegisterType((*TaskStatsRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskStatsRequest")
 	proto.RegisterType((*TaskStatsResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskStatsResponse")
+	proto.RegisterType((*AllocStatsRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocStatsRequest")
+	proto.RegisterType((*AllocStatsResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocStatsResponse")
+	proto.RegisterMapType((map[string]*TaskStats)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocStatsResponse.StatsEntry")
+	proto.RegisterType((*TaskProcessesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskProcessesRequest")
+	proto.RegisterType((*TaskProcessesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskProcessesResponse")
+	proto.RegisterType((*ProfileTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.ProfileTaskRequest")
+	proto.RegisterType((*ProfileTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.ProfileTaskResponse")
+	proto.RegisterType((*TaskProcess)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskProcess")
 	proto.RegisterType((*TaskEventsRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskEventsRequest")
 	proto.RegisterType((*SignalTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.SignalTaskRequest")
 	proto.RegisterType((*SignalTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.SignalTaskResponse")
@@ -3731,6 +5009,8 @@ func init() {
 	proto.RegisterType((*DestroyNetworkRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkRequest")
 	proto.RegisterType((*DestroyNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkResponse")
 	proto.RegisterType((*DriverCapabilities)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverCapabilities")
+	proto.RegisterType((*StatsCapabilities)(nil), "hashicorp.nomad.plugins.drivers.proto.StatsCapabilities")
+	proto.RegisterType((*StatSchema)(nil), "hashicorp.nomad.plugins.drivers.proto.StatSchema")
 	proto.RegisterType((*NetworkIsolationSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec.LabelsEntry")
 	proto.RegisterType((*HostsConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.HostsConfig")
@@ -3757,9 +5037,19 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskDriverStatus.AttributesEntry")
 	proto.RegisterType((*TaskStats)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskStats")
 	proto.RegisterMapType((map[string]*TaskResourceUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskStats.ResourceUsageByPidEntry")
+	proto.RegisterType((*ResourceLimits)(nil), "hashicorp.nomad.plugins.drivers.proto.ResourceLimits")
 	proto.RegisterType((*TaskResourceUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskResourceUsage")
+	proto.RegisterMapType((map[string]*EgressUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskResourceUsage.EgressEntry")
+	proto.RegisterMapType((map[string]*Gauge)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskResourceUsage.GaugesEntry")
+	proto.RegisterType((*Gauge)(nil), "hashicorp.nomad.plugins.drivers.proto.Gauge")
 	proto.RegisterType((*CPUUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.CPUUsage")
+	proto.RegisterType((*CoreUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.CoreUsage")
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
+	proto.RegisterType((*PerfUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.PerfUsage")
+	proto.RegisterType((*EgressUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.EgressUsage")
+	proto.RegisterType((*ResctrlUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.ResctrlUsage")
+	proto.RegisterType((*DiskIOUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.DiskIOUsage")
+	proto.RegisterType((*PressureUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.PressureUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
 }
@@ -3769,255 +5059,336 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3953 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0x08, 0x92, 0x22, 0x1f, 0x29, 0x0a, 0x6a, 0x4b, 0x1e, 0x9a, 0xb3, 0xc9, 0x78, 0xb0,
-	0x35, 0x29, 0x67, 0x77, 0x86, 0x9e, 0xd5, 0x26, 0xe3, 0xb1, 0xd7, 0xb3, 0x1e, 0x9a, 0xa2, 0x2d,
-	0xda, 0x12, 0xa5, 0x34, 0xa9, 0x78, 0x1d, 0x27, 0x83, 0x40, 0x40, 0x9b, 0x82, 0x45, 0x02, 0x18,
-	0x34, 0x28, 0x4b, 0x9b, 0x4a, 0x25, 0xb5, 0xa9, 0x4a, 0x6d, 0xaa, 0x92, 0x4a, 0x2e, 0x93, 0xbd,
-	0xe4, 0xb4, 0x55, 0x39, 0xa5, 0x72, 0x4f, 0x6d, 0x6a, 0x4f, 0x39, 0xe4, 0x4b, 0xe4, 0x92, 0x5b,
-	0xae, 0xa9, 0x7c, 0x80, 0x6c, 0xf5, 0x1f, 0x80, 0x80, 0x48, 0x8f, 0x41, 0xca, 0x27, 0xf2, 0xbd,
-	0xee, 0xfe, 0xf5, 0xc3, 0x7b, 0xaf, 0x5f, 0xbf, 0xee, 0x7e, 0xa0, 0xfb, 0xa3, 0xc9, 0xd0, 0x71,
-	0xe9, 0x6d, 0x3b, 0x70, 0xce, 0x48, 0x40, 0x6f, 0xfb, 0x81, 0x17, 0x7a, 0x92, 0x6a, 0x72, 0x02,
-	0x7d, 0x74, 0x62, 0xd2, 0x13, 0xc7, 0xf2, 0x02, 0xbf, 0xe9, 0x7a, 0x63, 0xd3, 0x6e, 0xca, 0x31,
-	0x4d, 0x39, 0x46, 0x74, 0x6b, 0xfc, 0xf6, 0xd0, 0xf3, 0x86, 0x23, 0x22, 0x10, 0x8e, 0x27, 0x2f,
-	0x6f, 0xdb, 0x93, 0xc0, 0x0c, 0x1d, 0xcf, 0x95, 0xed, 0x1f, 0x5c, 0x6e, 0x0f, 0x9d, 0x31, 0xa1,
-	0xa1, 0x39, 0xf6, 0x65, 0x87, 0x8f, 0x22, 0x59, 0xe8, 0x89, 0x19, 0x10, 0xfb, 0xf6, 0x89, 0x35,
-	0xa2, 0x3e, 0xb1, 0xd8, 0xaf, 0xc1, 0xfe, 0xc8, 0x6e, 0x1f, 0x5f, 0xea, 0x46, 0xc3, 0x60, 0x62,
-	0x85, 0x91, 0xe4, 0x66, 0x18, 0x06, 0xce, 0xf1, 0x24, 0x24, 0xa2, 0xb7, 0x7e, 0x03, 0xde, 0x1b,
-	0x98, 0xf4, 0xb4, 0xed, 0xb9, 0x2f, 0x9d, 0x61, 0xdf, 0x3a, 0x21, 0x63, 0x13, 0x93, 0xaf, 0x27,
-	0x84, 0x86, 0xfa, 0x1f, 0x43, 0x7d, 0xb6, 0x89, 0xfa, 0x9e, 0x4b, 0x09, 0xfa, 0x12, 0xf2, 0x6c,
-	0xca, 0xba, 0x72, 0x53, 0xb9, 0x55, 0xd9, 0xfe, 0xb8, 0xf9, 0x26, 0x15, 0x08, 0x19, 0x9a, 0x52,
-	0xd4, 0x66, 0xdf, 0x27, 0x16, 0xe6, 0x23, 0xf5, 0x2d, 0xb8, 0xd6, 0x36, 0x7d, 0xf3, 0xd8, 0x19,
-	0x39, 0xa1, 0x43, 0x68, 0x34, 0xe9, 0x04, 0x36, 0xd3, 0x6c, 0x39, 0xe1, 0x9f, 0x40, 0xd5, 0x4a,
-	0xf0, 0xe5, 0xc4, 0x77, 0x9b, 0x99, 0x74, 0xdf, 0xdc, 0xe1, 0x54, 0x0a, 0x38, 0x05, 0xa7, 0x6f,
-	0x02, 0x7a, 0xe4, 0xb8, 0x43, 0x12, 0xf8, 0x81, 0xe3, 0x86, 0x91, 0x30, 0xbf, 0x56, 0xe1, 0x5a,
-	0x8a, 0x2d, 0x85, 0x79, 0x05, 0x10, 0xeb, 0x91, 0x89, 0xa2, 0xde, 0xaa, 0x6c, 0x3f, 0xc9, 0x28,
-	0xca, 0x1c, 0xbc, 0x66, 0x2b, 0x06, 0xeb, 0xb8, 0x61, 0x70, 0x81, 0x13, 0xe8, 0xe8, 0x2b, 0x28,
-	0x9e, 0x10, 0x73, 0x14, 0x9e, 0xd4, 0x73, 0x37, 0x95, 0x5b, 0xb5, 0xed, 0x47, 0x57, 0x98, 0x67,
-	0x97, 0x03, 0xf5, 0x43, 0x33, 0x24, 0x58, 0xa2, 0xa2, 0x4f, 0x00, 0x89, 0x7f, 0x86, 0x4d, 0xa8,
-	0x15, 0x38, 0x3e, 0x73, 0xc9, 0xba, 0x7a, 0x53, 0xb9, 0x55, 0xc6, 0x1b, 0xa2, 0x65, 0x67, 0xda,
-	0xd0, 0xf0, 0x61, 0xfd, 0x92, 0xb4, 0x48, 0x03, 0xf5, 0x94, 0x5c, 0x70, 0x8b, 0x94, 0x31, 0xfb,
-	0x8b, 0x1e, 0x43, 0xe1, 0xcc, 0x1c, 0x4d, 0x08, 0x17, 0xb9, 0xb2, 0xfd, 0x83, 0xb7, 0xb9, 0x87,
-	0x74, 0xd1, 0xa9, 0x1e, 0xb0, 0x18, 0x7f, 0x2f, 0xf7, 0xb9, 0xa2, 0xdf, 0x85, 0x4a, 0x42, 0x6e,
-	0x54, 0x03, 0x38, 0xea, 0xed, 0x74, 0x06, 0x9d, 0xf6, 0xa0, 0xb3, 0xa3, 0xad, 0xa0, 0x35, 0x28,
-	0x1f, 0xf5, 0x76, 0x3b, 0xad, 0xbd, 0xc1, 0xee, 0x73, 0x4d, 0x41, 0x15, 0x58, 0x8d, 0x88, 0x9c,
-	0x7e, 0x0e, 0x08, 0x13, 0xcb, 0x3b, 0x23, 0x01, 0x73, 0x64, 0x69, 0x55, 0xf4, 0x1e, 0xac, 0x86,
-	0x26, 0x3d, 0x35, 0x1c, 0x5b, 0xca, 0x5c, 0x64, 0x64, 0xd7, 0x46, 0x5d, 0x28, 0x9e, 0x98, 0xae,
-	0x3d, 0x7a, 0xbb, 0xdc, 0x69, 0x55, 0x33, 0xf0, 0x5d, 0x3e, 0x10, 0x4b, 0x00, 0xe6, 0xdd, 0xa9,
-	0x99, 0x85, 0x01, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xe2, 0x74, 0x20, 0xcf, 0xe6,
-	0x97, 0x1e, 0xbd, 0xc8, 0x9c, 0x62, 0x65, 0x62, 0x3e, 0x5c, 0xff, 0xdf, 0x1c, 0x6c, 0x24, 0xb0,
-	0xa5, 0xa7, 0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x14, 0x72, 0xf8, 0xda, 0xf6, 0x83, 0x8c, 0xf0,
-	0x33, 0x48, 0x4d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xb7, 0x40, 0x13, 0x23, 0x0c, 0x12, 0x04, 0x5e,
-	0x60, 0x8c, 0xe9, 0x90, 0x6b, 0xad, 0x8c, 0x6b, 0x82, 0xdf, 0x61, 0xec, 0x7d, 0x3a, 0x4c, 0x68,
-	0x55, 0xbd, 0xa2, 0x56, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6a, 0x30, 0xd5, 0x06,
-	0x8e, 0x4d, 0xea, 0x79, 0x0e, 0xfa, 0x59, 0x46, 0xd0, 0x9e, 0x18, 0x7e, 0x20, 0x47, 0xe3, 0x75,
-	0x37, 0xcd, 0xd0, 0xbf, 0x0f, 0x45, 0xf1, 0xa5, 0xcc, 0x93, 0xfa, 0x47, 0xed, 0x76, 0xa7, 0xdf,
-	0xd7, 0x56, 0x50, 0x19, 0x0a, 0xb8, 0x33, 0xc0, 0xcc, 0xc3, 0xca, 0x50, 0x78, 0xd4, 0x1a, 0xb4,
-	0xf6, 0xb4, 0x9c, 0xfe, 0x3d, 0x58, 0x7f, 0x66, 0x3a, 0x61, 0x16, 0xe7, 0xd2, 0x3d, 0xd0, 0xa6,
-	0x7d, 0xa5, 0x75, 0xba, 0x29, 0xeb, 0x64, 0x57, 0x4d, 0xe7, 0xdc, 0x09, 0x2f, 0xd9, 0x43, 0x03,
-	0x95, 0x04, 0x81, 0x34, 0x01, 0xfb, 0xab, 0xbf, 0x86, 0xf5, 0x7e, 0xe8, 0xf9, 0x99, 0x3c, 0xff,
-	0x87, 0xb0, 0xca, 0x76, 0x1b, 0x6f, 0x12, 0x4a, 0xd7, 0xbf, 0xd1, 0x14, 0xbb, 0x51, 0x33, 0xda,
-	0x8d, 0x9a, 0x3b, 0x72, 0xb7, 0xc2, 0x51, 0x4f, 0x74, 0x1d, 0x8a, 0xd4, 0x19, 0xba, 0xe6, 0x48,
-	0x46, 0x0b, 0x49, 0xe9, 0x88, 0x39, 0x79, 0x34, 0xb1, 0x74, 0xfc, 0x36, 0xa0, 0x1d, 0x42, 0xc3,
-	0xc0, 0xbb, 0xc8, 0x24, 0xcf, 0x26, 0x14, 0x5e, 0x7a, 0x81, 0x25, 0x16, 0x62, 0x09, 0x0b, 0x82,
-	0x2d, 0xaa, 0x14, 0x88, 0xc4, 0xfe, 0x04, 0x50, 0xd7, 0x65, 0x7b, 0x4a, 0x36, 0x43, 0xfc, 0x43,
-	0x0e, 0xae, 0xa5, 0xfa, 0x4b, 0x63, 0x2c, 0xbf, 0x0e, 0x59, 0x60, 0x9a, 0x50, 0xb1, 0x0e, 0xd1,
-	0x01, 0x14, 0x45, 0x0f, 0xa9, 0xc9, 0x3b, 0x0b, 0x00, 0x89, 0x6d, 0x4a, 0xc2, 0x49, 0x98, 0xb9,
-	0x4e, 0xaf, 0xbe, 0x5b, 0xa7, 0x7f, 0x0d, 0x5a, 0xf4, 0x1d, 0xf4, 0xad, 0xb6, 0x79, 0x02, 0xd7,
-	0x2c, 0x6f, 0x34, 0x22, 0x16, 0xf3, 0x06, 0xc3, 0x71, 0x43, 0x12, 0x9c, 0x99, 0xa3, 0xb7, 0xfb,
-	0x0d, 0x9a, 0x8e, 0xea, 0xca, 0x41, 0xfa, 0x0b, 0xd8, 0x48, 0x4c, 0x2c, 0x0d, 0xf1, 0x08, 0x0a,
-	0x94, 0x31, 0xa4, 0x25, 0x3e, 0x5d, 0xd0, 0x12, 0x14, 0x8b, 0xe1, 0xfa, 0x35, 0x01, 0xde, 0x39,
-	0x23, 0x6e, 0xfc, 0x59, 0xfa, 0x0e, 0x6c, 0xf4, 0xb9, 0x9b, 0x66, 0xf2, 0xc3, 0xa9, 0x8b, 0xe7,
-	0x52, 0x2e, 0xbe, 0x09, 0x28, 0x89, 0x22, 0x1d, 0xf1, 0x02, 0xd6, 0x3b, 0xe7, 0xc4, 0xca, 0x84,
-	0x5c, 0x87, 0x55, 0xcb, 0x1b, 0x8f, 0x4d, 0xd7, 0xae, 0xe7, 0x6e, 0xaa, 0xb7, 0xca, 0x38, 0x22,
-	0x93, 0x6b, 0x51, 0xcd, 0xba, 0x16, 0xf5, 0xbf, 0x53, 0x40, 0x9b, 0xce, 0x2d, 0x15, 0xc9, 0xa4,
-	0x0f, 0x6d, 0x06, 0xc4, 0xe6, 0xae, 0x62, 0x49, 0x49, 0x7e, 0x14, 0x2e, 0x04, 0x9f, 0x04, 0x41,
-	0x22, 0x1c, 0xa9, 0x57, 0x0c, 0x47, 0xfa, 0x2e, 0x7c, 0x27, 0x12, 0xa7, 0x1f, 0x06, 0xc4, 0x1c,
-	0x3b, 0xee, 0xb0, 0x7b, 0x70, 0xe0, 0x13, 0x21, 0x38, 0x42, 0x90, 0xb7, 0xcd, 0xd0, 0x94, 0x82,
-	0xf1, 0xff, 0x6c, 0xd1, 0x5b, 0x23, 0x8f, 0xc6, 0x8b, 0x9e, 0x13, 0xfa, 0x7f, 0xaa, 0x50, 0x9f,
-	0x81, 0x8a, 0xd4, 0xfb, 0x02, 0x0a, 0x94, 0x84, 0x13, 0x5f, 0xba, 0x4a, 0x27, 0xb3, 0xc0, 0xf3,
-	0xf1, 0x9a, 0x7d, 0x06, 0x86, 0x05, 0x26, 0x1a, 0x42, 0x29, 0x0c, 0x2f, 0x0c, 0xea, 0xfc, 0x34,
-	0x4a, 0x08, 0xf6, 0xae, 0x8a, 0x3f, 0x20, 0xc1, 0xd8, 0x71, 0xcd, 0x51, 0xdf, 0xf9, 0x29, 0xc1,
-	0xab, 0x61, 0x78, 0xc1, 0xfe, 0xa0, 0xe7, 0xcc, 0xe1, 0x6d, 0xc7, 0x95, 0x6a, 0x6f, 0x2f, 0x3b,
-	0x4b, 0x42, 0xc1, 0x58, 0x20, 0x36, 0xf6, 0xa0, 0xc0, 0xbf, 0x69, 0x19, 0x47, 0xd4, 0x40, 0x0d,
-	0xc3, 0x0b, 0x2e, 0x54, 0x09, 0xb3, 0xbf, 0x8d, 0xfb, 0x50, 0x4d, 0x7e, 0x01, 0x73, 0xa4, 0x13,
-	0xe2, 0x0c, 0x4f, 0x84, 0x83, 0x15, 0xb0, 0xa4, 0x98, 0x25, 0x5f, 0x3b, 0xb6, 0x4c, 0x59, 0x0b,
-	0x58, 0x10, 0xfa, 0xbf, 0xe5, 0xe0, 0xc6, 0x1c, 0xcd, 0x48, 0x67, 0x7d, 0x91, 0x72, 0xd6, 0x77,
-	0xa4, 0x85, 0xc8, 0xe3, 0x5f, 0xa4, 0x3c, 0xfe, 0x1d, 0x82, 0xb3, 0x65, 0x73, 0x1d, 0x8a, 0xe4,
-	0xdc, 0x09, 0x89, 0x2d, 0x55, 0x25, 0xa9, 0xc4, 0x72, 0xca, 0x5f, 0x75, 0x39, 0xed, 0xc3, 0x66,
-	0x3b, 0x20, 0x66, 0x48, 0x64, 0x28, 0x8f, 0xfc, 0xff, 0x06, 0x94, 0xcc, 0xd1, 0xc8, 0xb3, 0xa6,
-	0x66, 0x5d, 0xe5, 0x74, 0xd7, 0x46, 0x0d, 0x28, 0x9d, 0x78, 0x34, 0x74, 0xcd, 0x31, 0x91, 0xc1,
-	0x2b, 0xa6, 0xf5, 0x6f, 0x14, 0xd8, 0xba, 0x84, 0x27, 0xad, 0x70, 0x0c, 0x35, 0x87, 0x7a, 0x23,
-	0xfe, 0x81, 0x46, 0xe2, 0x84, 0xf7, 0xa3, 0xc5, 0xb6, 0x9a, 0x6e, 0x84, 0xc1, 0x0f, 0x7c, 0x6b,
-	0x4e, 0x92, 0xe4, 0x1e, 0xc7, 0x27, 0xb7, 0xe5, 0x4a, 0x8f, 0x48, 0xfd, 0x1f, 0x15, 0xd8, 0x92,
-	0x3b, 0x7c, 0xf6, 0x0f, 0x9d, 0x15, 0x39, 0xf7, 0xae, 0x45, 0xd6, 0xeb, 0x70, 0xfd, 0xb2, 0x5c,
-	0x32, 0xe6, 0xff, 0x5f, 0x01, 0xd0, 0xec, 0xe9, 0x12, 0x7d, 0x08, 0x55, 0x4a, 0x5c, 0xdb, 0x10,
-	0xfb, 0x85, 0xd8, 0xca, 0x4a, 0xb8, 0xc2, 0x78, 0x62, 0xe3, 0xa0, 0x2c, 0x04, 0x92, 0x73, 0x29,
-	0x6d, 0x09, 0xf3, 0xff, 0xe8, 0x04, 0xaa, 0x2f, 0xa9, 0x11, 0xcf, 0xcd, 0x1d, 0xaa, 0x96, 0x39,
-	0xac, 0xcd, 0xca, 0xd1, 0x7c, 0xd4, 0x8f, 0xbf, 0x0b, 0x57, 0x5e, 0xd2, 0x98, 0x40, 0x3f, 0x57,
-	0xe0, 0xbd, 0x28, 0xad, 0x98, 0xaa, 0x6f, 0xec, 0xd9, 0x84, 0xd6, 0xf3, 0x37, 0xd5, 0x5b, 0xb5,
-	0xed, 0xc3, 0x2b, 0xe8, 0x6f, 0x86, 0xb9, 0xef, 0xd9, 0x04, 0x6f, 0xb9, 0x73, 0xb8, 0x14, 0x35,
-	0xe1, 0xda, 0x78, 0x42, 0x43, 0x43, 0x78, 0x81, 0x21, 0x3b, 0xd5, 0x0b, 0x5c, 0x2f, 0x1b, 0xac,
-	0x29, 0xe5, 0xab, 0xe8, 0x14, 0xd6, 0xc6, 0xde, 0xc4, 0x0d, 0x0d, 0x8b, 0x9f, 0x7f, 0x68, 0xbd,
-	0xb8, 0xd0, 0xc1, 0x78, 0x8e, 0x96, 0xf6, 0x19, 0x9c, 0x38, 0x4d, 0x51, 0x5c, 0x1d, 0x27, 0x28,
-	0xf4, 0x11, 0x54, 0x03, 0x32, 0xf6, 0x42, 0x62, 0xb0, 0x78, 0x49, 0xeb, 0xab, 0x4c, 0xaa, 0x87,
-	0xb9, 0xba, 0x82, 0x2b, 0x82, 0xcf, 0xc2, 0x03, 0x45, 0xbf, 0x07, 0xd7, 0x6d, 0x87, 0x9a, 0xc7,
-	0x23, 0x62, 0x8c, 0xbc, 0xa1, 0x31, 0x4d, 0x75, 0xea, 0x25, 0xfe, 0x19, 0x9b, 0xb2, 0x75, 0xcf,
-	0x1b, 0xb6, 0xe3, 0x36, 0x3e, 0xea, 0xc2, 0x35, 0xc7, 0x8e, 0x65, 0xb0, 0x2f, 0x1b, 0x79, 0xa6,
-	0x6d, 0x4c, 0x28, 0x09, 0x68, 0xbd, 0x2c, 0x47, 0x89, 0xd6, 0x67, 0xb2, 0xf1, 0x88, 0xb5, 0xe9,
-	0xf7, 0xa0, 0x92, 0x30, 0x2b, 0x2a, 0x41, 0xbe, 0x77, 0xd0, 0xeb, 0x68, 0x2b, 0x08, 0xa0, 0xd8,
-	0xde, 0xc5, 0x07, 0x07, 0x03, 0x71, 0x4a, 0xe9, 0xee, 0xb7, 0x1e, 0x77, 0xb4, 0x1c, 0x63, 0x1f,
-	0xf5, 0xfe, 0xb0, 0xd3, 0xdd, 0xd3, 0x54, 0xbd, 0x03, 0xd5, 0xe4, 0xc7, 0x22, 0x04, 0xb5, 0xa3,
-	0xde, 0xd3, 0xde, 0xc1, 0xb3, 0x9e, 0xb1, 0x7f, 0x70, 0xd4, 0x1b, 0xb0, 0xb3, 0x4e, 0x0d, 0xa0,
-	0xd5, 0x7b, 0x3e, 0xa5, 0xd7, 0xa0, 0xdc, 0x3b, 0x88, 0x48, 0xa5, 0x91, 0xd3, 0x14, 0xfd, 0x3f,
-	0x54, 0xd8, 0x9c, 0x67, 0x77, 0x64, 0x43, 0x9e, 0xf9, 0x90, 0x3c, 0x6d, 0xbe, 0x7b, 0x17, 0xe2,
-	0xe8, 0x6c, 0xe9, 0xf8, 0xa6, 0xdc, 0x5e, 0xca, 0x98, 0xff, 0x47, 0x06, 0x14, 0x47, 0xe6, 0x31,
-	0x19, 0xd1, 0xba, 0xca, 0xef, 0x63, 0x1e, 0x5f, 0x65, 0xee, 0x3d, 0x8e, 0x24, 0x2e, 0x63, 0x24,
-	0x2c, 0x1a, 0x40, 0x85, 0x05, 0x50, 0x2a, 0x54, 0x27, 0x63, 0xfa, 0x76, 0xc6, 0x59, 0x76, 0xa7,
-	0x23, 0x71, 0x12, 0xa6, 0x71, 0x17, 0x2a, 0x89, 0xc9, 0xe6, 0xdc, 0xa5, 0x6c, 0x26, 0xef, 0x52,
-	0xca, 0xc9, 0x8b, 0x91, 0x07, 0xb3, 0x36, 0x60, 0x3a, 0x62, 0x0e, 0xb1, 0x7b, 0xd0, 0x1f, 0x88,
-	0x53, 0xeb, 0x63, 0x7c, 0x70, 0x74, 0xa8, 0x29, 0x8c, 0x39, 0x68, 0xf5, 0x9f, 0x6a, 0xb9, 0xd8,
-	0x5f, 0x54, 0xbd, 0x0d, 0x95, 0x84, 0x5c, 0xa9, 0x1d, 0x43, 0x49, 0xef, 0x18, 0x2c, 0x66, 0x9b,
-	0xb6, 0x1d, 0x10, 0x4a, 0xa5, 0x1c, 0x11, 0xa9, 0xbf, 0x80, 0xf2, 0x4e, 0xaf, 0x2f, 0x21, 0xea,
-	0xb0, 0x4a, 0x49, 0xc0, 0xbe, 0x9b, 0xdf, 0x8a, 0x95, 0x71, 0x44, 0x32, 0x70, 0x4a, 0xcc, 0xc0,
-	0x3a, 0x21, 0x54, 0xe6, 0x19, 0x31, 0xcd, 0x46, 0x79, 0xfc, 0x76, 0x49, 0xd8, 0xae, 0x8c, 0x23,
-	0x52, 0xff, 0xff, 0x12, 0xc0, 0xf4, 0xa6, 0x03, 0xd5, 0x20, 0x17, 0xc7, 0xff, 0x9c, 0x63, 0x33,
-	0x3f, 0x48, 0xec, 0x6f, 0xfc, 0x3f, 0xda, 0x86, 0xad, 0x31, 0x1d, 0xfa, 0xa6, 0x75, 0x6a, 0xc8,
-	0x0b, 0x0a, 0x11, 0x26, 0x78, 0x2c, 0xad, 0xe2, 0x6b, 0xb2, 0x51, 0x46, 0x01, 0x81, 0xbb, 0x07,
-	0x2a, 0x71, 0xcf, 0x78, 0xdc, 0xab, 0x6c, 0xdf, 0x5b, 0xf8, 0x06, 0xa6, 0xd9, 0x71, 0xcf, 0x84,
-	0xaf, 0x30, 0x18, 0x64, 0x00, 0xd8, 0xe4, 0xcc, 0xb1, 0x88, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0xcb,
-	0xc5, 0x41, 0x77, 0x38, 0x46, 0x0c, 0x5d, 0xb6, 0x23, 0x1a, 0xf5, 0xa0, 0x1c, 0x10, 0xea, 0x4d,
-	0x02, 0x8b, 0x88, 0xe0, 0x97, 0xfd, 0x90, 0x84, 0xa3, 0x71, 0x78, 0x0a, 0x81, 0x76, 0xa0, 0xc8,
-	0x63, 0x1e, 0x8b, 0x6e, 0xea, 0xb7, 0x5e, 0xe7, 0xa6, 0xc1, 0x78, 0x24, 0xc1, 0x72, 0x2c, 0x7a,
-	0x0c, 0xab, 0x42, 0x44, 0x5a, 0x2f, 0x71, 0x98, 0x4f, 0xb2, 0x06, 0x64, 0x3e, 0x0a, 0x47, 0xa3,
-	0x99, 0x55, 0x59, 0x10, 0xe4, 0x31, 0xb0, 0x8c, 0xf9, 0x7f, 0xf4, 0x3e, 0x94, 0xc5, 0xfe, 0x6f,
-	0x3b, 0x41, 0x1d, 0x84, 0x73, 0x72, 0xc6, 0x8e, 0x13, 0xa0, 0x0f, 0xa0, 0x22, 0xf2, 0x3c, 0x83,
-	0x47, 0x85, 0x0a, 0x6f, 0x06, 0xc1, 0x3a, 0x64, 0xb1, 0x41, 0x74, 0x20, 0x41, 0x20, 0x3a, 0x54,
-	0xe3, 0x0e, 0x24, 0x08, 0x78, 0x87, 0xdf, 0x81, 0x75, 0x9e, 0x1d, 0x0f, 0x03, 0x6f, 0xe2, 0x1b,
-	0xdc, 0xa7, 0xd6, 0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x3d, 0xe6, 0x5c, 0x37, 0xa0, 0xf4,
-	0xca, 0x3b, 0x16, 0x1d, 0x6a, 0x62, 0x1d, 0xbc, 0xf2, 0x8e, 0xa3, 0xa6, 0x38, 0x43, 0x59, 0x4f,
-	0x67, 0x28, 0x5f, 0xc3, 0xf5, 0xd9, 0xad, 0x96, 0x67, 0x2a, 0xda, 0xd5, 0x33, 0x95, 0x4d, 0x77,
-	0x5e, 0x1c, 0x7e, 0x08, 0xaa, 0xed, 0xd2, 0xfa, 0xc6, 0x42, 0xce, 0x11, 0xaf, 0x63, 0xcc, 0x06,
-	0xa3, 0x2d, 0x28, 0xb2, 0x8f, 0x75, 0xec, 0x3a, 0x12, 0xa1, 0xe7, 0x95, 0x77, 0xdc, 0xb5, 0xd1,
-	0x77, 0xa0, 0xcc, 0xbe, 0x9f, 0xfa, 0xa6, 0x45, 0xea, 0xd7, 0x78, 0xcb, 0x94, 0xc1, 0x0c, 0xe5,
-	0x7a, 0x36, 0x11, 0x2a, 0xda, 0x14, 0x86, 0x62, 0x0c, 0xae, 0xa3, 0xf7, 0x60, 0x95, 0x37, 0x3a,
-	0x76, 0x7d, 0x4b, 0x1c, 0x42, 0x18, 0xd9, 0xb5, 0x91, 0x0e, 0x6b, 0xbe, 0x19, 0x10, 0x37, 0x34,
-	0xe4, 0x8c, 0xd7, 0x79, 0x73, 0x45, 0x30, 0x9f, 0xb0, 0x79, 0x1b, 0x9f, 0x41, 0x29, 0x5a, 0x0c,
-	0x8b, 0x84, 0xc9, 0xc6, 0x7d, 0xa8, 0xa5, 0x97, 0xd2, 0x42, 0x41, 0xf6, 0x9f, 0x73, 0x50, 0x8e,
-	0x17, 0x0d, 0x72, 0xe1, 0x1a, 0x37, 0x2a, 0xcb, 0x56, 0x8d, 0xe9, 0x1a, 0x14, 0x39, 0xf2, 0x17,
-	0x19, 0xd5, 0xdc, 0x8a, 0x10, 0xe4, 0x61, 0x5d, 0x2e, 0x48, 0x14, 0x23, 0x4f, 0xe7, 0xfb, 0x0a,
-	0xd6, 0x47, 0x8e, 0x3b, 0x39, 0x4f, 0xcc, 0x25, 0x92, 0xdb, 0xdf, 0xcf, 0x38, 0xd7, 0x1e, 0x1b,
-	0x3d, 0x9d, 0xa3, 0x36, 0x4a, 0xd1, 0x68, 0x17, 0x0a, 0xbe, 0x17, 0x84, 0xd1, 0x9e, 0x99, 0x75,
-	0x37, 0x3b, 0xf4, 0x82, 0x70, 0xdf, 0xf4, 0x7d, 0x76, 0x7e, 0x13, 0x00, 0xfa, 0x37, 0x39, 0xb8,
-	0x3e, 0xff, 0xc3, 0x50, 0x0f, 0x54, 0xcb, 0x9f, 0x48, 0x25, 0xdd, 0x5f, 0x54, 0x49, 0x6d, 0x7f,
-	0x32, 0x95, 0x9f, 0x01, 0xa1, 0x67, 0x50, 0x1c, 0x93, 0xb1, 0x17, 0x5c, 0x48, 0x5d, 0x3c, 0x58,
-	0x14, 0x72, 0x9f, 0x8f, 0x9e, 0xa2, 0x4a, 0x38, 0x84, 0xa1, 0x24, 0x17, 0x13, 0x95, 0x61, 0x7b,
-	0xc1, 0x1b, 0xb6, 0x08, 0x12, 0xc7, 0x38, 0xfa, 0x67, 0xb0, 0x35, 0xf7, 0x53, 0xd0, 0x6f, 0x01,
-	0x58, 0xfe, 0xc4, 0xe0, 0x2f, 0x20, 0xc2, 0x83, 0x54, 0x5c, 0xb6, 0xfc, 0x49, 0x9f, 0x33, 0xf4,
-	0x17, 0x50, 0x7f, 0x93, 0xbc, 0x6c, 0x8d, 0x09, 0x89, 0x8d, 0xf1, 0x31, 0xd7, 0x81, 0x8a, 0x4b,
-	0x82, 0xb1, 0x7f, 0xcc, 0x96, 0x52, 0xd4, 0x68, 0x9e, 0xb3, 0x0e, 0x2a, 0xef, 0x50, 0x91, 0x1d,
-	0xcc, 0xf3, 0xfd, 0x63, 0xfd, 0x17, 0x39, 0x58, 0xbf, 0x24, 0x32, 0x3b, 0xc5, 0x8a, 0x00, 0x1c,
-	0xdd, 0x0f, 0x08, 0x8a, 0x45, 0x63, 0xcb, 0xb1, 0xa3, 0x9b, 0x65, 0xfe, 0x9f, 0xef, 0xc3, 0xbe,
-	0xbc, 0xf5, 0xcd, 0x39, 0x3e, 0x5b, 0x3e, 0xe3, 0x63, 0x27, 0xa4, 0x3c, 0x29, 0x2a, 0x60, 0x41,
-	0xa0, 0xe7, 0x50, 0x0b, 0x08, 0xdf, 0xff, 0x6d, 0x43, 0x78, 0x59, 0x61, 0x21, 0x2f, 0x93, 0x12,
-	0x32, 0x67, 0xc3, 0x6b, 0x11, 0x12, 0xa3, 0x28, 0x7a, 0x06, 0x6b, 0x51, 0xe2, 0x2c, 0x90, 0x8b,
-	0x4b, 0x23, 0x57, 0x25, 0x10, 0x07, 0xd6, 0xef, 0x42, 0x25, 0xd1, 0xc8, 0x3e, 0x8c, 0x67, 0x7f,
-	0x52, 0x27, 0x82, 0x48, 0x47, 0x8b, 0x82, 0x8c, 0x16, 0xfa, 0x31, 0x54, 0x12, 0xeb, 0x62, 0x91,
-	0xa1, 0x4c, 0x9f, 0xa1, 0xc7, 0xf5, 0x59, 0xc0, 0xb9, 0xd0, 0x63, 0x71, 0x92, 0x65, 0x5e, 0x86,
-	0xe3, 0x73, 0x8d, 0x96, 0x71, 0x91, 0x91, 0x5d, 0x5f, 0xff, 0x55, 0x0e, 0x6a, 0xe9, 0x25, 0x1d,
-	0xf9, 0x91, 0x4f, 0x02, 0xc7, 0xb3, 0x13, 0x7e, 0x74, 0xc8, 0x19, 0xcc, 0x57, 0x58, 0xf3, 0xd7,
-	0x13, 0x2f, 0x34, 0x23, 0x5f, 0xb1, 0xfc, 0xc9, 0x1f, 0x30, 0xfa, 0x92, 0x0f, 0xaa, 0x97, 0x7c,
-	0x10, 0x7d, 0x0c, 0x48, 0xba, 0xd2, 0xc8, 0x19, 0x3b, 0xa1, 0x71, 0x7c, 0x11, 0x12, 0x61, 0x63,
-	0x15, 0x6b, 0xa2, 0x65, 0x8f, 0x35, 0x3c, 0x64, 0x7c, 0xe6, 0x78, 0x9e, 0x37, 0x36, 0xa8, 0xe5,
-	0x05, 0xc4, 0x30, 0xed, 0x57, 0xfc, 0x00, 0xa7, 0xe2, 0x8a, 0xe7, 0x8d, 0xfb, 0x8c, 0xd7, 0xb2,
-	0x5f, 0xb1, 0x8d, 0xd8, 0xf2, 0x27, 0x94, 0x84, 0x06, 0xfb, 0xe1, 0xb9, 0x4b, 0x19, 0x83, 0x60,
-	0xb5, 0xfd, 0x09, 0x45, 0xdf, 0x85, 0xb5, 0xa8, 0x03, 0xdf, 0x8b, 0x65, 0x12, 0x50, 0x95, 0x5d,
-	0x38, 0x0f, 0xe9, 0x50, 0x3d, 0x24, 0x81, 0x45, 0xdc, 0x70, 0xe0, 0x58, 0xa7, 0x94, 0x1f, 0xb1,
-	0x14, 0x9c, 0xe2, 0x3d, 0xc9, 0x97, 0x56, 0xb5, 0x12, 0x8e, 0x66, 0x1b, 0x93, 0x31, 0xd5, 0xff,
-	0x55, 0x81, 0x02, 0x4f, 0x59, 0x98, 0x52, 0xf8, 0x76, 0xcf, 0xb3, 0x01, 0x99, 0xea, 0x32, 0x06,
-	0xcf, 0x05, 0xde, 0x87, 0x32, 0x57, 0x7e, 0xe2, 0x84, 0xc1, 0xf3, 0x60, 0xde, 0xd8, 0x80, 0x52,
-	0x40, 0x4c, 0xdb, 0x73, 0x47, 0xd1, 0xc5, 0x58, 0x4c, 0xa3, 0xdf, 0x05, 0xcd, 0x0f, 0x3c, 0xdf,
-	0x1c, 0x4e, 0xcf, 0xd2, 0xd2, 0x7c, 0xeb, 0x09, 0x3e, 0x4f, 0xd1, 0xbf, 0x0b, 0x6b, 0x94, 0x88,
-	0xc8, 0x2e, 0x9c, 0xa4, 0x20, 0x3e, 0x53, 0x32, 0xf9, 0x89, 0x40, 0xff, 0x1a, 0x8a, 0x62, 0xe3,
-	0xba, 0x82, 0xbc, 0x9f, 0x00, 0x12, 0x8a, 0x64, 0x0e, 0x32, 0x76, 0x28, 0x95, 0x59, 0x36, 0x7f,
-	0xdd, 0x15, 0x2d, 0x87, 0xd3, 0x06, 0xfd, 0xbf, 0x14, 0x91, 0x6f, 0x8b, 0x77, 0x37, 0x96, 0x98,
-	0xb3, 0x55, 0xc3, 0x8e, 0xb1, 0xe2, 0x82, 0x2f, 0x22, 0x51, 0x17, 0x8a, 0x32, 0xad, 0xce, 0x2d,
-	0xfb, 0x6c, 0x29, 0x01, 0xa2, 0xeb, 0x7e, 0x22, 0x2f, 0x3b, 0x16, 0xbd, 0xee, 0x27, 0xe2, 0xba,
-	0x9f, 0xa0, 0x0f, 0xa1, 0x2a, 0x13, 0x7e, 0x01, 0x97, 0xe7, 0xf9, 0x7e, 0xc5, 0x8e, 0xdf, 0x54,
-	0x88, 0xfe, 0x3f, 0x4a, 0x1c, 0xf7, 0xa2, 0xb7, 0x0f, 0xf4, 0x15, 0x94, 0x58, 0x08, 0x31, 0xc6,
-	0xa6, 0x2f, 0x5f, 0xf2, 0xdb, 0xcb, 0x3d, 0xab, 0x44, 0xbb, 0xa2, 0x48, 0xd7, 0x57, 0x7d, 0x41,
-	0xb1, 0xf8, 0xc9, 0x8e, 0x4a, 0x51, 0xfc, 0x64, 0xff, 0xd1, 0x47, 0x50, 0x33, 0x27, 0xa1, 0x67,
-	0x98, 0xf6, 0x19, 0x09, 0x42, 0x87, 0x12, 0xe9, 0x4b, 0x6b, 0x8c, 0xdb, 0x8a, 0x98, 0x8d, 0x7b,
-	0x50, 0x4d, 0x62, 0xbe, 0x2d, 0x6f, 0x29, 0x24, 0xf3, 0x96, 0x3f, 0x05, 0x98, 0xde, 0x23, 0x32,
-	0x1f, 0x21, 0xe7, 0x4e, 0x68, 0x58, 0xd1, 0xd9, 0xbc, 0x80, 0x4b, 0x8c, 0xd1, 0x66, 0xce, 0x98,
-	0x7e, 0xe4, 0x28, 0x44, 0x8f, 0x1c, 0x2c, 0x3a, 0xb0, 0x05, 0x7d, 0xea, 0x8c, 0x46, 0xf1, 0xdd,
-	0x66, 0xd9, 0xf3, 0xc6, 0x4f, 0x39, 0x43, 0xff, 0x75, 0x4e, 0xf8, 0x8a, 0x78, 0xae, 0xca, 0x74,
-	0x36, 0x7b, 0x57, 0xa6, 0xbe, 0x0b, 0x40, 0x43, 0x33, 0x60, 0x49, 0x98, 0x19, 0xdd, 0xae, 0x36,
-	0x66, 0x5e, 0x49, 0x06, 0x51, 0xfd, 0x0c, 0x2e, 0xcb, 0xde, 0xad, 0x10, 0x7d, 0x01, 0x55, 0xcb,
-	0x1b, 0xfb, 0x23, 0x22, 0x07, 0x17, 0xde, 0x3a, 0xb8, 0x12, 0xf7, 0x6f, 0x85, 0x89, 0x3b, 0xdd,
-	0xe2, 0x55, 0xef, 0x74, 0x7f, 0xa5, 0x88, 0x57, 0xb7, 0xe4, 0xa3, 0x1f, 0x1a, 0xce, 0xa9, 0x2c,
-	0x79, 0xbc, 0xe4, 0x0b, 0xe2, 0xb7, 0x95, 0x95, 0x34, 0xbe, 0xc8, 0x52, 0xc7, 0xf1, 0xe6, 0xb4,
-	0xf8, 0xdf, 0x55, 0x28, 0xc7, 0x0f, 0x6e, 0x33, 0xb6, 0xff, 0x1c, 0xca, 0x71, 0xf1, 0x92, 0x0c,
-	0x10, 0xdf, 0x6a, 0x9e, 0xb8, 0x33, 0x7a, 0x09, 0xc8, 0x1c, 0x0e, 0xe3, 0x74, 0xd7, 0x98, 0x50,
-	0x73, 0x18, 0x3d, 0x77, 0x7e, 0xbe, 0x80, 0x1e, 0xa2, 0xfd, 0xf1, 0x88, 0x8d, 0xc7, 0x9a, 0x39,
-	0x1c, 0xa6, 0x38, 0xe8, 0xcf, 0x60, 0x2b, 0x3d, 0x87, 0x71, 0x7c, 0x61, 0xf8, 0x8e, 0x2d, 0xef,
-	0x00, 0x76, 0x17, 0x7d, 0x73, 0x6c, 0xa6, 0xe0, 0x1f, 0x5e, 0x1c, 0x3a, 0xb6, 0xd0, 0x39, 0x0a,
-	0x66, 0x1a, 0x1a, 0x7f, 0x01, 0xef, 0xbd, 0xa1, 0xfb, 0x1c, 0x1b, 0xf4, 0xd2, 0xb5, 0x34, 0xcb,
-	0x2b, 0x21, 0x61, 0xbd, 0x5f, 0x2a, 0xe2, 0x69, 0x34, 0xad, 0x93, 0x56, 0x32, 0x4f, 0xbf, 0x9d,
-	0x71, 0x9e, 0xf6, 0xe1, 0x91, 0x80, 0xe7, 0xa9, 0xf9, 0x93, 0x4b, 0xa9, 0x79, 0xd6, 0x84, 0x4c,
-	0x64, 0xb8, 0x02, 0x48, 0x22, 0xe8, 0xff, 0xa2, 0x42, 0x29, 0x42, 0xe7, 0x27, 0xf8, 0x0b, 0x1a,
-	0x92, 0xb1, 0x11, 0x5f, 0x2f, 0x2a, 0x18, 0x04, 0x8b, 0xef, 0xa8, 0xef, 0x43, 0x79, 0x42, 0x49,
-	0x20, 0x9a, 0x73, 0xbc, 0xb9, 0xc4, 0x18, 0xbc, 0xf1, 0x03, 0xa8, 0x84, 0x5e, 0x68, 0x8e, 0x8c,
-	0x90, 0xe7, 0x0b, 0xaa, 0x18, 0xcd, 0x59, 0x3c, 0x5b, 0x40, 0xdf, 0x87, 0x8d, 0xf0, 0x24, 0xf0,
-	0xc2, 0x70, 0xc4, 0x72, 0x55, 0x9e, 0x39, 0x89, 0x44, 0x27, 0x8f, 0xb5, 0xb8, 0x41, 0x64, 0x54,
-	0x94, 0x45, 0xef, 0x69, 0x67, 0xe6, 0xba, 0x3c, 0x88, 0xe4, 0xf1, 0x5a, 0xcc, 0x65, 0xae, 0xcd,
-	0x36, 0x4f, 0x5f, 0x64, 0x24, 0x3c, 0x56, 0x28, 0x38, 0x22, 0x91, 0x01, 0xeb, 0x63, 0x62, 0xd2,
-	0x49, 0x40, 0x6c, 0xe3, 0xa5, 0x43, 0x46, 0xb6, 0xb8, 0x78, 0xa9, 0x65, 0x3e, 0x6e, 0x44, 0x6a,
-	0x69, 0x3e, 0xe2, 0xa3, 0x71, 0x2d, 0x82, 0x13, 0x34, 0xcb, 0x1c, 0xc4, 0x3f, 0xb4, 0x0e, 0x95,
-	0xfe, 0xf3, 0xfe, 0xa0, 0xb3, 0x6f, 0xec, 0x1f, 0xec, 0x74, 0x64, 0xb9, 0x54, 0xbf, 0x83, 0x05,
-	0xa9, 0xb0, 0xf6, 0xc1, 0xc1, 0xa0, 0xb5, 0x67, 0x0c, 0xba, 0xed, 0xa7, 0x7d, 0x2d, 0x87, 0xb6,
-	0x60, 0x63, 0xb0, 0x8b, 0x0f, 0x06, 0x83, 0xbd, 0xce, 0x8e, 0x71, 0xd8, 0xc1, 0xdd, 0x83, 0x9d,
-	0xbe, 0xa6, 0x22, 0x04, 0xb5, 0x29, 0x7b, 0xd0, 0xdd, 0xef, 0x68, 0x79, 0x54, 0x81, 0xd5, 0xc3,
-	0x0e, 0x6e, 0x77, 0x7a, 0x03, 0xad, 0xa0, 0xff, 0x42, 0x85, 0x4a, 0xc2, 0x8a, 0xcc, 0x91, 0x03,
-	0x2a, 0xce, 0x35, 0x79, 0xcc, 0xfe, 0xf2, 0xe7, 0x5d, 0xd3, 0x3a, 0x11, 0xd6, 0xc9, 0x63, 0x41,
-	0xf0, 0xb3, 0x8c, 0x79, 0x9e, 0x58, 0xe7, 0x79, 0x5c, 0x1a, 0x9b, 0xe7, 0x02, 0xe4, 0x43, 0xa8,
-	0x9e, 0x92, 0xc0, 0x25, 0x23, 0xd9, 0x2e, 0x2c, 0x52, 0x11, 0x3c, 0xd1, 0xe5, 0x16, 0x68, 0xb2,
-	0xcb, 0x14, 0x46, 0x98, 0xa3, 0x26, 0xf8, 0xfb, 0x11, 0xd8, 0x26, 0x14, 0x44, 0xf3, 0xaa, 0x98,
-	0x9f, 0x13, 0x6c, 0x9b, 0xa2, 0xaf, 0x4d, 0x9f, 0xe7, 0x90, 0x79, 0xcc, 0xff, 0xa3, 0xe3, 0x59,
-	0xfb, 0x14, 0xb9, 0x7d, 0xee, 0x2e, 0xee, 0xce, 0x6f, 0x32, 0xd1, 0x49, 0x6c, 0xa2, 0x55, 0x50,
-	0x71, 0x54, 0x63, 0xd4, 0x6e, 0xb5, 0x77, 0x99, 0x59, 0xd6, 0xa0, 0xbc, 0xdf, 0xfa, 0x89, 0x71,
-	0xd4, 0x17, 0x37, 0xf8, 0x1a, 0x54, 0x9f, 0x76, 0x70, 0xaf, 0xb3, 0x27, 0x39, 0x2a, 0xda, 0x04,
-	0x4d, 0x72, 0xa6, 0xfd, 0xf2, 0x0c, 0x41, 0xfc, 0x2d, 0xa0, 0x12, 0xe4, 0xfb, 0xcf, 0x5a, 0x87,
-	0x5a, 0x51, 0xff, 0xef, 0x1c, 0xac, 0x8b, 0x6d, 0x21, 0xae, 0x86, 0x78, 0xf3, 0x6b, 0x70, 0xf2,
-	0x16, 0x2b, 0x97, 0xbe, 0xc5, 0x8a, 0x92, 0x50, 0xbe, 0xab, 0xab, 0xd3, 0x24, 0x94, 0xdf, 0xec,
-	0xa4, 0x22, 0x7e, 0x7e, 0x91, 0x88, 0x5f, 0x87, 0xd5, 0x31, 0xa1, 0xb1, 0xdd, 0xca, 0x38, 0x22,
-	0x91, 0x03, 0x15, 0xd3, 0x75, 0xbd, 0xd0, 0x14, 0x57, 0xc3, 0xc5, 0x85, 0x36, 0xc3, 0x4b, 0x5f,
-	0xdc, 0x6c, 0x4d, 0x91, 0x44, 0x60, 0x4e, 0x62, 0x37, 0x7e, 0x0c, 0xda, 0xe5, 0x0e, 0x8b, 0x6c,
-	0x87, 0xdf, 0xfb, 0xc1, 0x74, 0x37, 0x24, 0x6c, 0x5d, 0xc8, 0x37, 0x15, 0x6d, 0x85, 0x11, 0xf8,
-	0xa8, 0xd7, 0xeb, 0xf6, 0x1e, 0x6b, 0x0a, 0x02, 0x28, 0x76, 0x7e, 0xd2, 0x1d, 0x74, 0x76, 0xb4,
-	0xdc, 0xf6, 0x2f, 0x37, 0xa0, 0x28, 0x84, 0x44, 0xdf, 0xc8, 0x4c, 0x20, 0x59, 0x69, 0x8b, 0x7e,
-	0xbc, 0x70, 0x46, 0x9d, 0xaa, 0xde, 0x6d, 0x3c, 0x58, 0x7a, 0xbc, 0x7c, 0xd9, 0x5c, 0x41, 0x7f,
-	0xa3, 0x40, 0x35, 0xf5, 0xaa, 0x99, 0xf5, 0x6a, 0x7c, 0x4e, 0x61, 0x6f, 0xe3, 0x47, 0x4b, 0x8d,
-	0x8d, 0x65, 0xf9, 0xb9, 0x02, 0x95, 0x44, 0x49, 0x2b, 0xba, 0xbb, 0x4c, 0x19, 0xac, 0x90, 0xe4,
-	0xde, 0xf2, 0x15, 0xb4, 0xfa, 0xca, 0xa7, 0x0a, 0xfa, 0x6b, 0x05, 0x2a, 0x89, 0xe2, 0xce, 0xcc,
-	0xa2, 0xcc, 0x96, 0xa2, 0x66, 0x16, 0x65, 0x5e, 0x2d, 0xe9, 0x0a, 0xfa, 0x4b, 0x05, 0xca, 0x71,
-	0xa1, 0x26, 0xba, 0xb3, 0x78, 0x69, 0xa7, 0x10, 0xe2, 0xf3, 0x65, 0x6b, 0x42, 0xf5, 0x15, 0xf4,
-	0xe7, 0x50, 0x8a, 0xaa, 0x1a, 0x51, 0xd6, 0xdd, 0xeb, 0x52, 0xc9, 0x64, 0xe3, 0xce, 0xc2, 0xe3,
-	0x92, 0xd3, 0x47, 0xa5, 0x86, 0x99, 0xa7, 0xbf, 0x54, 0x14, 0xd9, 0xb8, 0xb3, 0xf0, 0xb8, 0x78,
-	0x7a, 0xe6, 0x09, 0x89, 0x8a, 0xc4, 0xcc, 0x9e, 0x30, 0x5b, 0x0a, 0x99, 0xd9, 0x13, 0xe6, 0x15,
-	0x40, 0x0a, 0x41, 0x12, 0x35, 0x8d, 0x99, 0x05, 0x99, 0xad, 0x9b, 0xcc, 0x2c, 0xc8, 0x9c, 0x12,
-	0x4a, 0x7d, 0x05, 0xfd, 0x4c, 0x49, 0x9e, 0x0b, 0xee, 0x2c, 0x5c, 0xba, 0xb7, 0xa0, 0x4b, 0xce,
-	0x14, 0x0f, 0xf2, 0x05, 0xfa, 0x33, 0x79, 0x8b, 0x21, 0x2a, 0xff, 0xd0, 0x22, 0x60, 0xa9, 0x62,
-	0xc1, 0xc6, 0x67, 0xcb, 0x6d, 0x36, 0x5c, 0x88, 0xbf, 0x52, 0x00, 0xa6, 0x35, 0x82, 0x99, 0x85,
-	0x98, 0x29, 0x4e, 0x6c, 0xdc, 0x5d, 0x62, 0x64, 0x72, 0x81, 0x44, 0x35, 0x4c, 0x99, 0x17, 0xc8,
-	0xa5, 0x1a, 0xc6, 0xcc, 0x0b, 0xe4, 0x72, 0xfd, 0xa1, 0xbe, 0x82, 0xfe, 0x49, 0x81, 0x8d, 0x99,
-	0x1a, 0x2a, 0xf4, 0xe0, 0x8a, 0x65, 0x74, 0x8d, 0x2f, 0x97, 0x07, 0x88, 0x44, 0xbb, 0xa5, 0x7c,
-	0xaa, 0xa0, 0xbf, 0x55, 0x60, 0x2d, 0x5d, 0x5b, 0x92, 0x79, 0x97, 0x9a, 0x53, 0x8d, 0xd5, 0xb8,
-	0xbf, 0xdc, 0xe0, 0x58, 0x5b, 0x7f, 0xaf, 0x40, 0x2d, 0x5d, 0x66, 0x84, 0xee, 0x2f, 0x16, 0x16,
-	0x2e, 0x09, 0xf4, 0xc5, 0x92, 0xa3, 0x23, 0x89, 0x1e, 0xae, 0xfe, 0x51, 0x41, 0x64, 0x6f, 0x45,
-	0xfe, 0xf3, 0xc3, 0xdf, 0x04, 0x00, 0x00, 0xff, 0xff, 0x51, 0x2f, 0x79, 0xbc, 0x10, 0x35, 0x00,
-	0x00,
+	// 5261 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5b, 0xcd, 0x73, 0x1b, 0xc9,
+	0x75, 0xd7, 0xe0, 0x1b, 0x0f, 0x20, 0x09, 0x36, 0x49, 0x09, 0x82, 0x1d, 0xef, 0x7a, 0x5c, 0x9b,
+	0x52, 0xd6, 0x5e, 0xca, 0xa6, 0xed, 0xd5, 0x4a, 0xd6, 0x7a, 0x4d, 0x81, 0x90, 0x88, 0x15, 0x09,
+	0x32, 0x03, 0xd0, 0xb2, 0x2c, 0xc7, 0x53, 0xc3, 0x99, 0x26, 0x38, 0x12, 0x30, 0x33, 0x9a, 0x1e,
+	0x48, 0xa4, 0x53, 0xa9, 0x24, 0x76, 0x39, 0xe5, 0xa4, 0x92, 0x72, 0xaa, 0x52, 0x9b, 0x5c, 0x52,
+	0xbe, 0xe5, 0x98, 0x7b, 0x2a, 0x55, 0x3e, 0x38, 0x39, 0xe4, 0x0f, 0xc8, 0x35, 0x97, 0xdc, 0x52,
+	0x95, 0x53, 0x0e, 0xb9, 0xa7, 0x5e, 0x7f, 0xcc, 0x07, 0xc1, 0xb5, 0x00, 0x50, 0x95, 0x0b, 0x89,
+	0xf7, 0xba, 0xfb, 0xd7, 0x6f, 0xba, 0x5f, 0xbf, 0xf7, 0xfa, 0x75, 0x37, 0xe8, 0xc1, 0x68, 0x32,
+	0x74, 0x3d, 0x76, 0xdb, 0x09, 0xdd, 0x57, 0x34, 0x64, 0xb7, 0x83, 0xd0, 0x8f, 0x7c, 0x49, 0x6d,
+	0x72, 0x82, 0xbc, 0x77, 0x6a, 0xb1, 0x53, 0xd7, 0xf6, 0xc3, 0x60, 0xd3, 0xf3, 0xc7, 0x96, 0xb3,
+	0x29, 0xdb, 0x6c, 0xca, 0x36, 0xa2, 0x5a, 0xeb, 0x4b, 0x43, 0xdf, 0x1f, 0x8e, 0xa8, 0x40, 0x38,
+	0x9e, 0x9c, 0xdc, 0x76, 0x26, 0xa1, 0x15, 0xb9, 0xbe, 0x27, 0xcb, 0xdf, 0xb9, 0x58, 0x1e, 0xb9,
+	0x63, 0xca, 0x22, 0x6b, 0x1c, 0xc8, 0x0a, 0xef, 0x29, 0x59, 0xd8, 0xa9, 0x15, 0x52, 0xe7, 0xf6,
+	0xa9, 0x3d, 0x62, 0x01, 0xb5, 0xf1, 0xbf, 0x89, 0x3f, 0x64, 0xb5, 0xaf, 0x5d, 0xa8, 0xc6, 0xa2,
+	0x70, 0x62, 0x47, 0x4a, 0x72, 0x2b, 0x8a, 0x42, 0xf7, 0x78, 0x12, 0x51, 0x51, 0x5b, 0xbf, 0x09,
+	0x37, 0x06, 0x16, 0x7b, 0xd1, 0xf6, 0xbd, 0x13, 0x77, 0xd8, 0xb7, 0x4f, 0xe9, 0xd8, 0x32, 0xe8,
+	0xcb, 0x09, 0x65, 0x91, 0xfe, 0x23, 0x68, 0x4e, 0x17, 0xb1, 0xc0, 0xf7, 0x18, 0x25, 0xdf, 0x83,
+	0x02, 0x76, 0xd9, 0xd4, 0xde, 0xd5, 0x6e, 0xd5, 0xb6, 0xbe, 0xb6, 0xf9, 0x79, 0x43, 0x20, 0x64,
+	0xd8, 0x94, 0xa2, 0x6e, 0xf6, 0x03, 0x6a, 0x1b, 0xbc, 0xa5, 0xbe, 0x01, 0x6b, 0x6d, 0x2b, 0xb0,
+	0x8e, 0xdd, 0x91, 0x1b, 0xb9, 0x94, 0xa9, 0x4e, 0x27, 0xb0, 0x9e, 0x65, 0xcb, 0x0e, 0xff, 0x00,
+	0xea, 0x76, 0x8a, 0x2f, 0x3b, 0xbe, 0xbb, 0x39, 0xd3, 0xd8, 0x6f, 0xee, 0x70, 0x2a, 0x03, 0x9c,
+	0x81, 0xd3, 0xd7, 0x81, 0x3c, 0x74, 0xbd, 0x21, 0x0d, 0x83, 0xd0, 0xf5, 0x22, 0x25, 0xcc, 0xaf,
+	0xf3, 0xb0, 0x96, 0x61, 0x4b, 0x61, 0x9e, 0x03, 0xc4, 0xe3, 0x88, 0xa2, 0xe4, 0x6f, 0xd5, 0xb6,
+	0x3e, 0x9d, 0x51, 0x94, 0x4b, 0xf0, 0x36, 0xb7, 0x63, 0xb0, 0x8e, 0x17, 0x85, 0xe7, 0x46, 0x0a,
+	0x9d, 0xfc, 0x18, 0x4a, 0xa7, 0xd4, 0x1a, 0x45, 0xa7, 0xcd, 0xdc, 0xbb, 0xda, 0xad, 0xe5, 0xad,
+	0x87, 0x57, 0xe8, 0x67, 0x97, 0x03, 0xf5, 0x23, 0x2b, 0xa2, 0x86, 0x44, 0x25, 0x1f, 0x00, 0x11,
+	0xbf, 0x4c, 0x87, 0x32, 0x3b, 0x74, 0x03, 0x54, 0xc9, 0x66, 0xfe, 0x5d, 0xed, 0x56, 0xd5, 0x58,
+	0x15, 0x25, 0x3b, 0x49, 0x41, 0x2b, 0x80, 0x95, 0x0b, 0xd2, 0x92, 0x06, 0xe4, 0x5f, 0xd0, 0x73,
+	0x3e, 0x23, 0x55, 0x03, 0x7f, 0x92, 0x47, 0x50, 0x7c, 0x65, 0x8d, 0x26, 0x94, 0x8b, 0x5c, 0xdb,
+	0xfa, 0xc6, 0x9b, 0xd4, 0x43, 0xaa, 0x68, 0x32, 0x0e, 0x86, 0x68, 0x7f, 0x2f, 0xf7, 0x91, 0xa6,
+	0xdf, 0x85, 0x5a, 0x4a, 0x6e, 0xb2, 0x0c, 0x70, 0xd4, 0xdb, 0xe9, 0x0c, 0x3a, 0xed, 0x41, 0x67,
+	0xa7, 0x71, 0x8d, 0x2c, 0x41, 0xf5, 0xa8, 0xb7, 0xdb, 0xd9, 0xde, 0x1b, 0xec, 0x3e, 0x6d, 0x68,
+	0xa4, 0x06, 0x65, 0x45, 0xe4, 0xf4, 0x33, 0x20, 0x06, 0xb5, 0xfd, 0x57, 0x34, 0x44, 0x45, 0x96,
+	0xb3, 0x4a, 0x6e, 0x40, 0x39, 0xb2, 0xd8, 0x0b, 0xd3, 0x75, 0xa4, 0xcc, 0x25, 0x24, 0xbb, 0x0e,
+	0xe9, 0x42, 0xe9, 0xd4, 0xf2, 0x9c, 0xd1, 0x9b, 0xe5, 0xce, 0x0e, 0x35, 0x82, 0xef, 0xf2, 0x86,
+	0x86, 0x04, 0x40, 0xed, 0xce, 0xf4, 0x2c, 0x26, 0x40, 0x7f, 0x0a, 0x8d, 0x7e, 0x64, 0x85, 0x51,
+	0x5a, 0x9c, 0x0e, 0x14, 0xb0, 0xff, 0xa6, 0x36, 0x77, 0x9f, 0x62, 0x65, 0x1a, 0xbc, 0xb9, 0xfe,
+	0x3f, 0x39, 0x58, 0x4d, 0x61, 0x4b, 0x4d, 0x7d, 0x02, 0xa5, 0x90, 0xb2, 0xc9, 0x28, 0xe2, 0xf0,
+	0xcb, 0x5b, 0x9f, 0xcc, 0x08, 0x3f, 0x85, 0xb4, 0x69, 0x70, 0x18, 0x43, 0xc2, 0x91, 0x5b, 0xd0,
+	0x10, 0x2d, 0x4c, 0x1a, 0x86, 0x7e, 0x68, 0x8e, 0xd9, 0x90, 0x8f, 0x5a, 0xd5, 0x58, 0x16, 0xfc,
+	0x0e, 0xb2, 0xf7, 0xd9, 0x30, 0x35, 0xaa, 0xf9, 0x2b, 0x8e, 0x2a, 0xb1, 0xa0, 0xe1, 0xd1, 0xe8,
+	0xb5, 0x1f, 0xbe, 0x30, 0x71, 0x68, 0x43, 0xd7, 0xa1, 0xcd, 0x02, 0x07, 0xfd, 0x70, 0x46, 0xd0,
+	0x9e, 0x68, 0x7e, 0x20, 0x5b, 0x1b, 0x2b, 0x5e, 0x96, 0xa1, 0x7f, 0x15, 0x4a, 0xe2, 0x4b, 0x51,
+	0x93, 0xfa, 0x47, 0xed, 0x76, 0xa7, 0xdf, 0x6f, 0x5c, 0x23, 0x55, 0x28, 0x1a, 0x9d, 0x81, 0x81,
+	0x1a, 0x56, 0x85, 0xe2, 0xc3, 0xed, 0xc1, 0xf6, 0x5e, 0x23, 0xa7, 0xbf, 0x0f, 0x2b, 0x4f, 0x2c,
+	0x37, 0x9a, 0x45, 0xb9, 0x74, 0x1f, 0x1a, 0x49, 0x5d, 0x39, 0x3b, 0xdd, 0xcc, 0xec, 0xcc, 0x3e,
+	0x34, 0x9d, 0x33, 0x37, 0xba, 0x30, 0x1f, 0x0d, 0xc8, 0xd3, 0x30, 0x94, 0x53, 0x80, 0x3f, 0xf5,
+	0xd7, 0xb0, 0xd2, 0x8f, 0xfc, 0x60, 0x26, 0xcd, 0xff, 0x26, 0x94, 0xd1, 0xdb, 0xf8, 0x93, 0x48,
+	0xaa, 0xfe, 0xcd, 0x4d, 0xe1, 0x8d, 0x36, 0x95, 0x37, 0xda, 0xdc, 0x91, 0xde, 0xca, 0x50, 0x35,
+	0xc9, 0x75, 0x28, 0x31, 0x77, 0xe8, 0x59, 0x23, 0x69, 0x2d, 0x24, 0xa5, 0x13, 0x68, 0x24, 0x1d,
+	0x4b, 0xc5, 0x6f, 0x03, 0xd9, 0xa1, 0x2c, 0x0a, 0xfd, 0xf3, 0x99, 0xe4, 0x59, 0x87, 0xe2, 0x89,
+	0x1f, 0xda, 0x62, 0x21, 0x56, 0x0c, 0x41, 0xe0, 0xa2, 0xca, 0x80, 0x48, 0xec, 0x0f, 0x80, 0x74,
+	0x3d, 0xf4, 0x29, 0xb3, 0x4d, 0xc4, 0x5f, 0xe7, 0x60, 0x2d, 0x53, 0x5f, 0x4e, 0xc6, 0xe2, 0xeb,
+	0x10, 0x0d, 0xd3, 0x84, 0x89, 0x75, 0x48, 0x0e, 0xa0, 0x24, 0x6a, 0xc8, 0x91, 0xbc, 0x33, 0x07,
+	0x90, 0x70, 0x53, 0x12, 0x4e, 0xc2, 0x5c, 0xaa, 0xf4, 0xf9, 0xb7, 0xab, 0xf4, 0xaf, 0xa1, 0xa1,
+	0xbe, 0x83, 0xbd, 0x71, 0x6e, 0x3e, 0x85, 0x35, 0xdb, 0x1f, 0x8d, 0xa8, 0x8d, 0xda, 0x60, 0xba,
+	0x5e, 0x44, 0xc3, 0x57, 0xd6, 0xe8, 0xcd, 0x7a, 0x43, 0x92, 0x56, 0x5d, 0xd9, 0x48, 0x7f, 0x06,
+	0xab, 0xa9, 0x8e, 0xe5, 0x44, 0x3c, 0x84, 0x22, 0x43, 0x86, 0x9c, 0x89, 0xaf, 0xcf, 0x39, 0x13,
+	0xcc, 0x10, 0xcd, 0xf5, 0x9f, 0xc0, 0xea, 0xf6, 0x68, 0xe4, 0xdb, 0x99, 0xcf, 0xba, 0x09, 0x15,
+	0xf9, 0x59, 0xc2, 0x71, 0x57, 0x8d, 0xb2, 0xf8, 0x2e, 0xf6, 0x56, 0x3f, 0xec, 0x3f, 0x34, 0x20,
+	0xe9, 0xce, 0xe5, 0xa7, 0xfd, 0x30, 0xf9, 0x34, 0x8c, 0x19, 0x76, 0x66, 0xfc, 0xb4, 0x69, 0xa4,
+	0x4d, 0x4e, 0x89, 0x68, 0x41, 0x40, 0xb6, 0x9e, 0x03, 0x24, 0xcc, 0x4b, 0x9c, 0xf2, 0xc3, 0xac,
+	0x53, 0x5e, 0x60, 0x58, 0x13, 0x9f, 0x7c, 0x1b, 0xd6, 0x91, 0x7f, 0x18, 0xfa, 0x36, 0x65, 0x8c,
+	0xbe, 0x51, 0x69, 0x74, 0x17, 0x36, 0x2e, 0x34, 0x90, 0x23, 0x72, 0x08, 0xd5, 0x40, 0x31, 0xe5,
+	0xa8, 0x6c, 0xcd, 0x21, 0x99, 0x04, 0x34, 0x12, 0x10, 0xbd, 0x0b, 0xe4, 0x30, 0xf4, 0x4f, 0xdc,
+	0x11, 0x9d, 0xc9, 0xd4, 0xb4, 0xa0, 0xa2, 0x02, 0x71, 0x3e, 0x32, 0x79, 0x23, 0xa6, 0xf5, 0x7b,
+	0xb0, 0x96, 0x81, 0x92, 0x32, 0x7f, 0x05, 0x96, 0x4e, 0xfc, 0x91, 0x43, 0x1d, 0x93, 0x45, 0x96,
+	0xfd, 0x42, 0x28, 0x6a, 0xdd, 0xa8, 0x0b, 0x66, 0x9f, 0xf3, 0xf4, 0x5f, 0x69, 0x50, 0x4b, 0x49,
+	0x88, 0x13, 0x12, 0xc8, 0xce, 0xf3, 0x06, 0xfe, 0x24, 0x04, 0x0a, 0x01, 0xb2, 0x44, 0xaf, 0xfc,
+	0x37, 0x69, 0x42, 0xd9, 0x1e, 0x3b, 0x23, 0xd7, 0xc3, 0x35, 0xce, 0xb5, 0x53, 0x92, 0x68, 0x12,
+	0x71, 0x9e, 0x85, 0xc3, 0xab, 0x8a, 0x49, 0xa7, 0xe4, 0x2e, 0x00, 0x8b, 0xac, 0x30, 0x32, 0xd1,
+	0x28, 0x37, 0x8b, 0x7c, 0x66, 0x5b, 0x53, 0xaa, 0x3a, 0x50, 0x3b, 0x09, 0xa3, 0xca, 0x6b, 0x23,
+	0xad, 0xaf, 0x89, 0xb5, 0xd7, 0x79, 0x45, 0xbd, 0x78, 0x79, 0xe8, 0x3b, 0xb0, 0xda, 0xe7, 0x56,
+	0x7c, 0xa6, 0xb1, 0x4b, 0x3c, 0x40, 0x2e, 0xe3, 0x01, 0xd6, 0x81, 0xa4, 0x51, 0xa4, 0x9d, 0x3e,
+	0x87, 0x95, 0xce, 0x19, 0xb5, 0x67, 0x42, 0xc6, 0x71, 0xf0, 0xc7, 0x63, 0xcb, 0xc3, 0xe1, 0x11,
+	0xe3, 0x20, 0xc8, 0xb4, 0xab, 0xca, 0xcf, 0xea, 0xaa, 0xf4, 0xbf, 0xd2, 0xa0, 0x91, 0xf4, 0x2d,
+	0xa7, 0x11, 0xa5, 0x8f, 0x1c, 0x04, 0x12, 0xf3, 0x27, 0x29, 0xc9, 0x57, 0xde, 0x54, 0xf0, 0x69,
+	0x18, 0xa6, 0xbc, 0x75, 0xfe, 0x8a, 0xde, 0x5a, 0xdf, 0x85, 0x2f, 0x2a, 0x71, 0xfa, 0x51, 0x48,
+	0xad, 0xb1, 0xeb, 0x0d, 0xbb, 0x07, 0x07, 0x01, 0x15, 0x82, 0xa3, 0x6a, 0x38, 0x56, 0x64, 0x49,
+	0xc1, 0xf8, 0x6f, 0x54, 0x00, 0x7b, 0xe4, 0xb3, 0xd8, 0x27, 0x72, 0x42, 0xff, 0xb7, 0x3c, 0x34,
+	0xa7, 0xa0, 0xd4, 0xf0, 0x3e, 0x83, 0x22, 0xa3, 0xd1, 0x24, 0x90, 0x96, 0xb4, 0x33, 0xb3, 0xc0,
+	0x97, 0xe3, 0x6d, 0xf6, 0x11, 0xcc, 0x10, 0x98, 0x64, 0x08, 0x95, 0x28, 0x3a, 0x37, 0x99, 0xfb,
+	0x13, 0x65, 0x52, 0xf6, 0xae, 0x8a, 0x3f, 0xa0, 0xe1, 0xd8, 0xf5, 0xac, 0x51, 0xdf, 0xfd, 0x09,
+	0x35, 0xca, 0x51, 0x74, 0x8e, 0x3f, 0xc8, 0x53, 0xd4, 0x7c, 0xc7, 0xf5, 0xe4, 0xb0, 0xb7, 0x17,
+	0xed, 0x25, 0x35, 0xc0, 0x86, 0x40, 0x6c, 0xed, 0x41, 0x91, 0x7f, 0xd3, 0x22, 0x8a, 0xd8, 0x80,
+	0x7c, 0x14, 0x9d, 0x73, 0xa1, 0x2a, 0x06, 0xfe, 0x6c, 0xdd, 0x87, 0x7a, 0xfa, 0x0b, 0x50, 0x91,
+	0x4e, 0xa9, 0x3b, 0x3c, 0x15, 0x0a, 0x56, 0x34, 0x24, 0x85, 0x33, 0xf9, 0xda, 0x75, 0xe4, 0x8e,
+	0xae, 0x68, 0x08, 0x42, 0xff, 0xa7, 0x1c, 0xdc, 0xbc, 0x64, 0x64, 0xa4, 0xb2, 0x3e, 0xcb, 0x28,
+	0xeb, 0x5b, 0x1a, 0x05, 0xa5, 0xf1, 0xcf, 0x32, 0x1a, 0xff, 0x16, 0xc1, 0x71, 0xd9, 0x5c, 0x87,
+	0x12, 0x3d, 0x73, 0x23, 0xea, 0xc8, 0xa1, 0x92, 0x54, 0x6a, 0x39, 0x15, 0xae, 0xba, 0x9c, 0xf6,
+	0x61, 0xbd, 0x1d, 0x52, 0x2b, 0xa2, 0x32, 0xd2, 0x49, 0x39, 0x7b, 0x0b, 0x5d, 0x67, 0x32, 0xad,
+	0x65, 0x4e, 0x0b, 0xb3, 0x7f, 0xea, 0xb3, 0xc8, 0xb3, 0xc6, 0x54, 0x1a, 0xaf, 0x98, 0xd6, 0x3f,
+	0xd3, 0x60, 0xe3, 0x02, 0x9e, 0x9c, 0x85, 0x63, 0x58, 0x76, 0x99, 0x3f, 0xe2, 0x1f, 0x68, 0xa6,
+	0x12, 0x20, 0xdf, 0x99, 0x2f, 0x12, 0xeb, 0x2a, 0x0c, 0x9e, 0x0f, 0x59, 0x72, 0xd3, 0x24, 0xd7,
+	0x38, 0xde, 0xb9, 0x23, 0x57, 0xba, 0x22, 0xf5, 0xbf, 0xd5, 0x60, 0x43, 0x06, 0xc0, 0xb3, 0x7f,
+	0xe8, 0xb4, 0xc8, 0xb9, 0xb7, 0x2d, 0xb2, 0xde, 0x84, 0xeb, 0x17, 0xe5, 0x92, 0x36, 0xff, 0x37,
+	0x65, 0x20, 0xd3, 0xc9, 0x17, 0xf2, 0x65, 0xa8, 0x33, 0xea, 0x39, 0xa6, 0xf0, 0x17, 0xc2, 0x81,
+	0x56, 0x8c, 0x1a, 0xf2, 0x84, 0xe3, 0x60, 0x68, 0x02, 0xe9, 0x99, 0x94, 0xb6, 0x62, 0xf0, 0xdf,
+	0xe4, 0x14, 0xea, 0x27, 0xcc, 0x8c, 0xfb, 0xe6, 0x0a, 0xb5, 0x3c, 0xb3, 0x59, 0x9b, 0x96, 0x63,
+	0xf3, 0x61, 0x3f, 0xfe, 0x2e, 0xa3, 0x76, 0xc2, 0x62, 0x82, 0xfc, 0x42, 0x83, 0x1b, 0x2a, 0xea,
+	0x4e, 0x86, 0x6f, 0xec, 0x3b, 0x94, 0x35, 0x0b, 0xef, 0xe6, 0x6f, 0x2d, 0x6f, 0x1d, 0x5e, 0x61,
+	0xfc, 0xa6, 0x98, 0xfb, 0xbe, 0x43, 0x8d, 0x0d, 0xef, 0x12, 0x2e, 0x23, 0x9b, 0xb0, 0x36, 0x9e,
+	0xb0, 0xc8, 0x14, 0x5a, 0x60, 0xca, 0x4a, 0xdc, 0xd7, 0x57, 0x8c, 0x55, 0x2c, 0xca, 0xe8, 0x2a,
+	0x79, 0x01, 0x4b, 0x63, 0x7f, 0xe2, 0x45, 0xa6, 0xcd, 0xd3, 0x03, 0xac, 0x59, 0x9a, 0x2b, 0x6f,
+	0x74, 0xc9, 0x28, 0xed, 0x23, 0x9c, 0x48, 0x36, 0x30, 0xa3, 0x3e, 0x4e, 0x51, 0xe4, 0x3d, 0xa8,
+	0x87, 0x74, 0xec, 0x47, 0xd4, 0x44, 0x7b, 0xc9, 0x9a, 0x65, 0x94, 0xea, 0x41, 0xae, 0xa9, 0x19,
+	0x35, 0xc1, 0x47, 0xf3, 0xc0, 0xc8, 0xb7, 0xe0, 0xba, 0xe3, 0x32, 0xeb, 0x78, 0x44, 0xcd, 0x91,
+	0x3f, 0x34, 0x93, 0x80, 0xb9, 0x59, 0xe1, 0x9f, 0xb1, 0x2e, 0x4b, 0xf7, 0xfc, 0x61, 0x3b, 0x2e,
+	0xe3, 0xad, 0xce, 0x3d, 0x6b, 0xec, 0xda, 0x26, 0x7e, 0xd9, 0xc8, 0xb7, 0x1c, 0x73, 0xc2, 0x68,
+	0xc8, 0x9a, 0x55, 0xd9, 0x4a, 0x94, 0x3e, 0x91, 0x85, 0x47, 0x58, 0x46, 0x7a, 0x2a, 0xc6, 0x06,
+	0xae, 0xe7, 0x1f, 0xcd, 0x9e, 0xf1, 0x88, 0x58, 0xfa, 0xb3, 0x65, 0x5c, 0x4d, 0xde, 0x81, 0x9a,
+	0x58, 0x5b, 0x02, 0xb5, 0xc6, 0xbb, 0x06, 0x2b, 0x0e, 0xc9, 0xc9, 0x17, 0xd3, 0x21, 0x6c, 0x9d,
+	0x17, 0x27, 0x0c, 0x5c, 0xce, 0x81, 0x88, 0x21, 0x9b, 0x4b, 0x62, 0x39, 0x4b, 0x12, 0xc3, 0x48,
+	0x91, 0xff, 0x32, 0xed, 0x61, 0xe8, 0x4f, 0x82, 0xe6, 0x32, 0x2f, 0xaf, 0x0b, 0x66, 0x9b, 0xf3,
+	0xf4, 0x7b, 0x50, 0x4b, 0x29, 0x29, 0xa9, 0x40, 0xa1, 0x77, 0xd0, 0xeb, 0x34, 0xae, 0x11, 0x80,
+	0x52, 0x7b, 0xd7, 0x38, 0x38, 0x18, 0x88, 0x94, 0x44, 0x77, 0x7f, 0xfb, 0x51, 0xa7, 0x91, 0x43,
+	0xf6, 0x51, 0xef, 0xfb, 0x9d, 0xee, 0x5e, 0x23, 0xaf, 0x77, 0xa0, 0x9e, 0x9e, 0x3a, 0x42, 0x60,
+	0xf9, 0xa8, 0xf7, 0xb8, 0x77, 0xf0, 0xa4, 0x67, 0xee, 0x1f, 0x1c, 0xf5, 0x06, 0x98, 0xd8, 0x58,
+	0x06, 0xd8, 0xee, 0x3d, 0x4d, 0xe8, 0x25, 0xa8, 0xf6, 0x0e, 0x14, 0xa9, 0xb5, 0x72, 0x0d, 0x4d,
+	0xff, 0x55, 0x1e, 0x56, 0xa7, 0x46, 0x07, 0xbf, 0x4b, 0xa9, 0xa2, 0x58, 0xbd, 0x8a, 0x44, 0x5f,
+	0xea, 0xb8, 0xe8, 0x4b, 0x7d, 0xb9, 0x78, 0x4b, 0x48, 0x76, 0x7d, 0x6c, 0xe2, 0xd0, 0x57, 0xae,
+	0x4d, 0x99, 0x74, 0x05, 0x8a, 0x44, 0x6b, 0x1c, 0x84, 0x94, 0xb1, 0x49, 0x28, 0xe2, 0xdb, 0x8a,
+	0x11, 0xd3, 0xe8, 0x3f, 0x86, 0xd6, 0x64, 0x48, 0x59, 0xb3, 0xc8, 0x1d, 0xb0, 0xa4, 0xd0, 0x7f,
+	0x30, 0x9e, 0x94, 0x6e, 0x96, 0xde, 0xcd, 0xcf, 0xe1, 0x3f, 0xf0, 0x53, 0x64, 0x36, 0x5b, 0x02,
+	0x90, 0x63, 0x58, 0x19, 0xd3, 0xb1, 0x1f, 0x9e, 0x9b, 0x63, 0x6a, 0x61, 0xa7, 0x4e, 0xb3, 0xcc,
+	0x17, 0xf9, 0xac, 0xf9, 0xe5, 0x7d, 0xde, 0xfa, 0x88, 0x59, 0x43, 0xba, 0xf9, 0xd0, 0xa5, 0x23,
+	0x87, 0x19, 0xcb, 0x02, 0x71, 0x5f, 0x02, 0x92, 0xa7, 0x50, 0xb7, 0x83, 0x49, 0xd2, 0x41, 0x85,
+	0x77, 0x30, 0xeb, 0x16, 0xbe, 0x7d, 0x78, 0x94, 0x41, 0xaf, 0xd9, 0xc1, 0x44, 0x41, 0xeb, 0xdf,
+	0x07, 0x48, 0x3e, 0x0a, 0x0d, 0x27, 0xf7, 0x6a, 0xc2, 0x0f, 0xf0, 0xdf, 0xc8, 0x9b, 0x78, 0x6e,
+	0x24, 0x3d, 0x1d, 0xff, 0x4d, 0xde, 0x85, 0xda, 0x74, 0xc6, 0x37, 0xcd, 0xd2, 0xff, 0x35, 0x0f,
+	0xeb, 0x97, 0x99, 0x2f, 0xe2, 0x40, 0x01, 0x4d, 0xa1, 0xcc, 0x29, 0xbe, 0x7d, 0x4b, 0xc8, 0xd1,
+	0xf9, 0xfe, 0xc8, 0x92, 0x51, 0x52, 0xd5, 0xe0, 0xbf, 0x89, 0x09, 0xa5, 0x91, 0x75, 0x4c, 0x47,
+	0x8c, 0x6f, 0x8f, 0x6a, 0x5b, 0x8f, 0xae, 0xd2, 0xf7, 0x1e, 0x47, 0x12, 0x9b, 0x68, 0x09, 0x4b,
+	0x06, 0x50, 0xc3, 0x38, 0x80, 0x89, 0x35, 0x23, 0x43, 0x93, 0x59, 0x77, 0xa4, 0xbb, 0x49, 0x4b,
+	0x23, 0x0d, 0xd3, 0xba, 0x0b, 0xb5, 0x54, 0x67, 0x97, 0x6c, 0xce, 0xd7, 0xd3, 0x9b, 0xf3, 0x6a,
+	0x7a, 0xab, 0xfd, 0x09, 0xac, 0x5f, 0x36, 0x46, 0x68, 0x09, 0x76, 0x0f, 0xfa, 0x03, 0x91, 0x9b,
+	0x7c, 0x64, 0x1c, 0x1c, 0x1d, 0x36, 0x34, 0x64, 0x0e, 0xb6, 0xfb, 0x8f, 0x1b, 0xb9, 0xd8, 0x50,
+	0xe4, 0xf5, 0x36, 0xd4, 0x52, 0x72, 0x65, 0x02, 0x1f, 0x2d, 0x1b, 0xf8, 0xe0, 0x02, 0xb5, 0x1c,
+	0x07, 0x17, 0x9e, 0x94, 0x43, 0x91, 0xfa, 0x33, 0xa8, 0xee, 0xf4, 0xfa, 0x12, 0xa2, 0x09, 0x65,
+	0x46, 0x43, 0xfc, 0x6e, 0x95, 0x42, 0x91, 0x24, 0x82, 0x33, 0x6a, 0x85, 0xf6, 0x29, 0x65, 0x32,
+	0x5c, 0x8e, 0x69, 0x6c, 0xe5, 0x73, 0xbd, 0x62, 0x6a, 0x6b, 0x2b, 0x49, 0xfd, 0x37, 0x55, 0x80,
+	0x24, 0x9f, 0x4d, 0x96, 0x21, 0x17, 0x87, 0x31, 0x39, 0xb1, 0x4f, 0x4e, 0x85, 0x69, 0xfc, 0x37,
+	0xd9, 0x82, 0x8d, 0x31, 0x1b, 0x06, 0x96, 0xfd, 0xc2, 0x94, 0x69, 0x68, 0xe1, 0xed, 0xb8, 0x1a,
+	0xd7, 0x8d, 0x35, 0x59, 0x28, 0x9d, 0x99, 0xc0, 0xdd, 0x83, 0x3c, 0xf5, 0x5e, 0x71, 0xf7, 0x5d,
+	0xdb, 0xba, 0x37, 0x77, 0x9e, 0x7d, 0xb3, 0xe3, 0xbd, 0x12, 0xba, 0x82, 0x30, 0xc4, 0x04, 0x10,
+	0xd6, 0xcb, 0x44, 0xd0, 0x22, 0x07, 0xfd, 0xde, 0xfc, 0xa0, 0x3b, 0x1c, 0x23, 0x86, 0xae, 0x3a,
+	0x8a, 0x26, 0x3d, 0xa8, 0x86, 0x94, 0xf9, 0x93, 0xd0, 0xa6, 0xc2, 0x87, 0xcf, 0x9e, 0xb3, 0x31,
+	0x54, 0x3b, 0x23, 0x81, 0x20, 0x3b, 0x50, 0xe2, 0xae, 0x9b, 0x71, 0xdb, 0xf6, 0xdb, 0x0e, 0xed,
+	0x2e, 0xd8, 0x36, 0x6c, 0x64, 0xc8, 0xb6, 0xe4, 0x51, 0x62, 0xc3, 0x2b, 0x1c, 0xe6, 0x83, 0x59,
+	0xe3, 0x0a, 0xde, 0x2a, 0x31, 0xf9, 0x68, 0x92, 0x18, 0x0d, 0x9b, 0x55, 0x69, 0x92, 0x18, 0x0d,
+	0xc9, 0x17, 0xa0, 0x2a, 0x5c, 0xad, 0xe3, 0x86, 0xdc, 0x7d, 0x57, 0x0d, 0x11, 0xd7, 0xee, 0xb8,
+	0x21, 0xfa, 0x61, 0xb1, 0x5d, 0x31, 0xb9, 0x55, 0xa8, 0xf1, 0x62, 0x10, 0xac, 0x43, 0xb4, 0x0d,
+	0xa2, 0x02, 0x0d, 0x43, 0x51, 0xa1, 0x1e, 0x57, 0xa0, 0x61, 0xc8, 0x2b, 0xfc, 0x2e, 0xac, 0xf0,
+	0x4d, 0x1e, 0xf7, 0xac, 0x26, 0xd7, 0xa9, 0x25, 0x5e, 0x69, 0x09, 0xd9, 0x8f, 0x90, 0xdb, 0x43,
+	0xe5, 0xba, 0x09, 0x95, 0xe7, 0xfe, 0xb1, 0xa8, 0xb0, 0x2c, 0xd6, 0xc1, 0x73, 0xff, 0x58, 0x15,
+	0xc5, 0x81, 0xf6, 0x4a, 0x36, 0xd0, 0x7e, 0x09, 0xd7, 0xa7, 0x23, 0x46, 0x1e, 0x70, 0x37, 0xae,
+	0x1e, 0x70, 0xaf, 0x7b, 0x97, 0x70, 0xc9, 0x03, 0xc8, 0x3b, 0x1e, 0x6b, 0xae, 0xce, 0xa5, 0x1c,
+	0xf1, 0x3a, 0x36, 0xb0, 0x31, 0xd9, 0x80, 0x12, 0x7e, 0xac, 0xeb, 0x34, 0x89, 0x30, 0x3d, 0xcf,
+	0xfd, 0xe3, 0xae, 0x83, 0x41, 0x0d, 0x7e, 0x3f, 0x0b, 0x2c, 0x9b, 0x36, 0xd7, 0x78, 0x49, 0xc2,
+	0xc0, 0x89, 0xf2, 0x7c, 0x87, 0x8a, 0x21, 0x5a, 0x17, 0x13, 0x85, 0x0c, 0x3e, 0x46, 0x37, 0xa0,
+	0xcc, 0x0b, 0x5d, 0xa7, 0xb9, 0xc1, 0x8b, 0x4a, 0x48, 0x76, 0x1d, 0xa2, 0xc3, 0x52, 0x60, 0x85,
+	0xd4, 0x8b, 0x4c, 0xd9, 0xe3, 0x75, 0xe1, 0x73, 0x04, 0xf3, 0x53, 0xde, 0xef, 0x3b, 0x50, 0x3b,
+	0x09, 0xad, 0x31, 0x75, 0x30, 0x50, 0x64, 0xcd, 0x1b, 0x22, 0xda, 0x12, 0xac, 0x3d, 0x7f, 0xc8,
+	0xc3, 0xb1, 0x97, 0x3e, 0x33, 0xed, 0x91, 0xc5, 0xe3, 0xad, 0xa6, 0xa8, 0xf0, 0xd2, 0x67, 0x6d,
+	0xc1, 0x69, 0x7d, 0x08, 0x15, 0xb5, 0x9c, 0xe6, 0x31, 0xb4, 0xad, 0xfb, 0xb0, 0x9c, 0x5d, 0x8c,
+	0x73, 0x99, 0xe9, 0x7f, 0xc8, 0x41, 0x35, 0x5e, 0x76, 0xc4, 0x83, 0x35, 0xae, 0x16, 0x56, 0x44,
+	0x1d, 0x33, 0x59, 0xc5, 0x62, 0xb3, 0xf8, 0xf1, 0x3c, 0x59, 0x5f, 0x44, 0x90, 0x59, 0x2b, 0xb9,
+	0xa4, 0x49, 0x8c, 0x9c, 0xf4, 0xf7, 0x63, 0x58, 0x19, 0xb9, 0xde, 0xe4, 0x2c, 0xd5, 0x97, 0xd8,
+	0xe5, 0x7d, 0x7b, 0xc6, 0xbe, 0xf6, 0xb0, 0x75, 0xd2, 0xc7, 0xf2, 0x28, 0x43, 0x93, 0x5d, 0x28,
+	0x06, 0x7e, 0x18, 0x29, 0xaf, 0x3b, 0xab, 0x3f, 0x3c, 0xf4, 0xc3, 0x68, 0xdf, 0x0a, 0x02, 0x4c,
+	0x64, 0x08, 0x00, 0xfd, 0xb3, 0x1c, 0x5c, 0xbf, 0xfc, 0xc3, 0x48, 0x0f, 0xf2, 0x76, 0x30, 0x91,
+	0x83, 0x74, 0x7f, 0xde, 0x41, 0x6a, 0x07, 0x93, 0x44, 0x7e, 0x04, 0xc2, 0xb3, 0x4f, 0x11, 0x83,
+	0xc9, 0xb1, 0xf8, 0x64, 0x5e, 0x48, 0x11, 0xd5, 0x25, 0xa8, 0x12, 0x8e, 0x18, 0x50, 0x91, 0xcb,
+	0x91, 0x49, 0xc3, 0x3f, 0xe7, 0x49, 0x8c, 0x82, 0x34, 0x62, 0x1c, 0xfd, 0x43, 0xd8, 0xb8, 0xf4,
+	0x53, 0xc8, 0xef, 0x00, 0x60, 0xdc, 0xc8, 0x37, 0x05, 0x4c, 0xa6, 0x8f, 0xab, 0x76, 0x30, 0xe9,
+	0x73, 0x86, 0xfe, 0x0c, 0x9a, 0x9f, 0x27, 0x2f, 0xae, 0x52, 0x15, 0xd6, 0x1e, 0xab, 0xdc, 0xb6,
+	0x8c, 0x4a, 0x8f, 0x71, 0x31, 0xaa, 0x42, 0xeb, 0x0c, 0x2b, 0xe4, 0x79, 0x85, 0x9a, 0xac, 0x60,
+	0x9d, 0xed, 0x1f, 0xeb, 0x7f, 0x97, 0x83, 0x95, 0x0b, 0x22, 0x63, 0x38, 0x2e, 0x4c, 0xb8, 0x4a,
+	0x94, 0x09, 0x0a, 0xed, 0xb9, 0xed, 0x3a, 0xea, 0x04, 0x92, 0xff, 0xe6, 0x9e, 0x3c, 0x90, 0x91,
+	0x65, 0xce, 0x0d, 0x70, 0xf9, 0x8c, 0x8f, 0xdd, 0x88, 0xf1, 0xb0, 0xaa, 0x68, 0x08, 0x82, 0x3c,
+	0x85, 0xe5, 0x90, 0xf2, 0x08, 0xc2, 0x31, 0x85, 0x96, 0x15, 0xe7, 0xd2, 0x32, 0x29, 0x21, 0x2a,
+	0x9b, 0xb1, 0xa4, 0x90, 0x90, 0x62, 0xe4, 0x09, 0x2c, 0xa9, 0x1d, 0xa4, 0x40, 0x2e, 0x2d, 0x8c,
+	0x5c, 0x97, 0x40, 0x1c, 0x18, 0x2f, 0x25, 0xa4, 0x0a, 0xf1, 0xc3, 0x78, 0xfc, 0x28, 0xc7, 0x44,
+	0x10, 0x59, 0x6b, 0x51, 0x94, 0xd6, 0x42, 0x3f, 0x86, 0x5a, 0x6a, 0x5d, 0xcc, 0xd3, 0x14, 0xc7,
+	0x33, 0xf2, 0xf9, 0x78, 0x16, 0x8d, 0x5c, 0xe4, 0xa3, 0xa5, 0xc5, 0xd8, 0xcd, 0x74, 0x03, 0x79,
+	0x2a, 0x50, 0x42, 0xb2, 0x1b, 0xe8, 0x3f, 0xcd, 0xc3, 0x72, 0x76, 0x49, 0x2b, 0x3d, 0x0a, 0x68,
+	0xe8, 0xfa, 0x4e, 0x4a, 0x8f, 0x0e, 0x39, 0x03, 0x75, 0x05, 0x8b, 0x5f, 0x4e, 0xfc, 0xc8, 0x52,
+	0xba, 0x62, 0x07, 0x93, 0xdf, 0x47, 0xfa, 0x82, 0x0e, 0xe6, 0x2f, 0xe8, 0x20, 0xf9, 0x1a, 0x10,
+	0xa9, 0x4a, 0x23, 0x77, 0xec, 0x46, 0xe6, 0xf1, 0x79, 0x44, 0xc5, 0x1c, 0xe7, 0x8d, 0x86, 0x28,
+	0xd9, 0xc3, 0x82, 0x07, 0xc8, 0x47, 0xc5, 0xf3, 0xfd, 0xb1, 0xc9, 0x6c, 0x3f, 0xa4, 0xa6, 0xe5,
+	0x3c, 0xe7, 0x99, 0x8c, 0xbc, 0x51, 0xf3, 0xfd, 0x71, 0x1f, 0x79, 0xdb, 0xce, 0x73, 0x34, 0xf2,
+	0x76, 0x30, 0x61, 0x34, 0x32, 0xf1, 0x1f, 0x8f, 0x7e, 0xaa, 0x06, 0x08, 0x56, 0x3b, 0x98, 0x30,
+	0xdc, 0x3b, 0xab, 0x0a, 0x62, 0xef, 0x2c, 0xc2, 0x88, 0xba, 0xac, 0xc2, 0x79, 0x44, 0x87, 0xfa,
+	0x21, 0x0d, 0x6d, 0xea, 0x45, 0x03, 0x17, 0x8f, 0x69, 0x30, 0xd7, 0xa0, 0x19, 0x19, 0x1e, 0x02,
+	0x49, 0xd9, 0x03, 0x7f, 0xe4, 0xda, 0xe7, 0x32, 0xec, 0xa8, 0x0b, 0xe6, 0x21, 0xe7, 0x61, 0xba,
+	0x4a, 0x56, 0xf2, 0x78, 0x06, 0x48, 0xc4, 0x1e, 0x72, 0xa9, 0xf4, 0x90, 0xf5, 0x69, 0xa1, 0x52,
+	0x6e, 0x54, 0x0c, 0x25, 0xf5, 0x98, 0x8e, 0x99, 0xfe, 0x8f, 0x1a, 0x14, 0x79, 0xf0, 0x84, 0x83,
+	0xcb, 0x03, 0x0f, 0x1e, 0x97, 0xc8, 0xa0, 0x1b, 0x19, 0x3c, 0x2a, 0xf9, 0x02, 0x54, 0xf9, 0x24,
+	0xa6, 0xf6, 0x3a, 0x3c, 0x22, 0xe7, 0x85, 0x2d, 0xa8, 0x84, 0xd4, 0x72, 0x7c, 0x6f, 0xa4, 0x32,
+	0xcd, 0x31, 0x4d, 0x7e, 0x0f, 0x1a, 0x41, 0xe8, 0x07, 0xd6, 0x30, 0x49, 0x4e, 0x49, 0x35, 0x58,
+	0x49, 0xf1, 0xf9, 0x66, 0x01, 0x53, 0x0d, 0x54, 0x78, 0x08, 0xa1, 0x6c, 0x45, 0xf1, 0x95, 0x92,
+	0xc9, 0xf7, 0x26, 0xfa, 0x4b, 0x28, 0x09, 0x07, 0x78, 0x05, 0x79, 0x3f, 0x00, 0x22, 0x26, 0x04,
+	0x15, 0x6d, 0xec, 0x32, 0x26, 0xe3, 0x7d, 0x7e, 0x9b, 0x48, 0x94, 0x1c, 0x26, 0x05, 0x78, 0x4c,
+	0x0a, 0xc9, 0x3d, 0x0f, 0xdc, 0x22, 0xe0, 0xea, 0xc3, 0xed, 0xa8, 0xc8, 0x98, 0x2b, 0x12, 0x37,
+	0xfb, 0x32, 0xc0, 0xcf, 0x2d, 0x7a, 0x4d, 0x46, 0x02, 0xa8, 0xe3, 0x65, 0x2a, 0xb3, 0x87, 0xf3,
+	0x9e, 0x83, 0x52, 0x75, 0xf4, 0xf6, 0x65, 0xa8, 0xcb, 0xad, 0x47, 0x72, 0x2e, 0x57, 0x37, 0x6a,
+	0x4e, 0x7c, 0x86, 0x4f, 0xf5, 0xff, 0xd2, 0x62, 0xfb, 0xa9, 0xce, 0xda, 0xc9, 0x8f, 0xa1, 0x82,
+	0xa6, 0xc8, 0x1c, 0x5b, 0x81, 0x3c, 0xef, 0x6c, 0x2f, 0x76, 0x8c, 0xaf, 0xbc, 0xab, 0xd8, 0x38,
+	0x94, 0x03, 0x41, 0xa1, 0x1d, 0xc6, 0x4d, 0x9b, 0xb2, 0xc3, 0xf8, 0x9b, 0xbc, 0x07, 0xcb, 0xd6,
+	0x24, 0xf2, 0x4d, 0xcb, 0x79, 0x45, 0xc3, 0xc8, 0x65, 0x54, 0xea, 0xd2, 0x12, 0x72, 0xb7, 0x15,
+	0xb3, 0x75, 0x0f, 0xea, 0x69, 0xcc, 0x37, 0xc5, 0x3f, 0xc5, 0x74, 0xfc, 0xf3, 0xa7, 0x1a, 0x40,
+	0x92, 0x99, 0x47, 0x25, 0xc1, 0x34, 0xbf, 0x69, 0xab, 0x34, 0x41, 0xd1, 0xa8, 0x20, 0xa3, 0x8d,
+	0xda, 0x98, 0x3d, 0x36, 0x2c, 0xaa, 0x63, 0x43, 0x34, 0x33, 0x68, 0x19, 0x5e, 0xb8, 0xa3, 0x51,
+	0x7c, 0x5a, 0x50, 0xf5, 0xfd, 0xf1, 0x63, 0xce, 0x40, 0xa3, 0xc0, 0x31, 0x43, 0x6a, 0x31, 0xdf,
+	0x93, 0xaa, 0x0e, 0x94, 0x77, 0x8a, 0x1c, 0xfd, 0xd7, 0x39, 0xa1, 0x4d, 0xe2, 0x02, 0xc5, 0x4c,
+	0xfb, 0xc8, 0xb7, 0xa5, 0x0c, 0xea, 0x1c, 0x96, 0x3a, 0xa6, 0xa5, 0x0e, 0x34, 0xde, 0x7c, 0x0e,
+	0x4b, 0x9d, 0xed, 0x88, 0x7c, 0x0c, 0x75, 0xdb, 0x1f, 0x07, 0x23, 0x2a, 0x1b, 0xbf, 0xf9, 0x10,
+	0xb7, 0x16, 0xd7, 0xdf, 0x8e, 0x52, 0xc7, 0x28, 0xa5, 0xab, 0x1e, 0xa3, 0xfc, 0xb3, 0x26, 0xee,
+	0x81, 0xa4, 0xaf, 0xa1, 0x90, 0xe1, 0x25, 0x77, 0x1d, 0x1f, 0x2d, 0x78, 0xa7, 0xe5, 0xb7, 0x5d,
+	0x74, 0x6c, 0x7d, 0x3c, 0xcb, 0xcd, 0xc2, 0xcf, 0x0f, 0xc0, 0xff, 0xbd, 0x08, 0x55, 0x35, 0x2d,
+	0xd3, 0x73, 0xff, 0x11, 0x54, 0xe3, 0xeb, 0xb4, 0xcd, 0xdc, 0x1b, 0x47, 0x38, 0xa9, 0x4c, 0x4e,
+	0x80, 0x58, 0xc3, 0x61, 0x1c, 0x58, 0x9b, 0x13, 0x66, 0x0d, 0xd5, 0x05, 0x9c, 0x8f, 0xe6, 0x18,
+	0x07, 0xe5, 0x89, 0x79, 0x1a, 0xcf, 0x68, 0x58, 0xc3, 0x61, 0x86, 0x43, 0xfe, 0x10, 0x36, 0xb2,
+	0x7d, 0x98, 0xc7, 0xe7, 0x26, 0x5e, 0x0f, 0x10, 0xf9, 0x8a, 0xdd, 0x39, 0x35, 0x93, 0x6d, 0x66,
+	0xe0, 0x1f, 0x9c, 0x1f, 0xba, 0x8e, 0x18, 0x73, 0x12, 0x4e, 0x15, 0x70, 0x7f, 0x2b, 0xcd, 0x36,
+	0x5a, 0xf5, 0xa2, 0xf4, 0xb7, 0xc2, 0x5e, 0x4b, 0xa3, 0x2f, 0x2b, 0xb8, 0x0e, 0x57, 0xb4, 0x82,
+	0x51, 0x11, 0x8c, 0xae, 0x83, 0x96, 0x10, 0x8f, 0x67, 0x26, 0x91, 0x1f, 0x72, 0x89, 0xcb, 0x7c,
+	0x55, 0xd7, 0x14, 0x0f, 0x3b, 0xd8, 0x87, 0x12, 0x8f, 0x0d, 0x84, 0x13, 0x9e, 0x7d, 0x5f, 0xa2,
+	0x3e, 0x82, 0xc7, 0x0f, 0xcc, 0x90, 0x20, 0x22, 0xcf, 0xf4, 0x72, 0x42, 0x3d, 0x9b, 0x72, 0xcf,
+	0x5f, 0x30, 0x62, 0xfa, 0xc2, 0x35, 0x9e, 0xf8, 0x6e, 0x07, 0xcc, 0x71, 0x8d, 0x47, 0xf1, 0x5a,
+	0x7f, 0x0c, 0x37, 0x3e, 0x67, 0x18, 0x2f, 0xd1, 0xcd, 0x5e, 0xf6, 0x82, 0xcd, 0xe2, 0xca, 0x91,
+	0xd2, 0xea, 0x5d, 0x58, 0xce, 0x0e, 0x01, 0x1a, 0xc9, 0x24, 0x6e, 0xe7, 0xdd, 0x17, 0x8c, 0x6a,
+	0x1c, 0xb4, 0x63, 0x48, 0xc8, 0xd3, 0xcc, 0xd6, 0x19, 0x17, 0x43, 0x33, 0x4a, 0x98, 0x29, 0xb6,
+	0xce, 0xf4, 0xbf, 0x28, 0x8b, 0xfb, 0x1e, 0x59, 0xad, 0xdb, 0x4e, 0xef, 0xb9, 0x6e, 0xcf, 0x99,
+	0x8c, 0x16, 0xdb, 0xac, 0x4f, 0x2f, 0x6c, 0xb3, 0xb6, 0xe6, 0xcf, 0x99, 0xc7, 0x3b, 0xab, 0x1d,
+	0x28, 0x04, 0x34, 0x3c, 0x91, 0xcb, 0x6b, 0x56, 0x6b, 0x7c, 0x48, 0xc3, 0x13, 0x81, 0xc3, 0x5b,
+	0x93, 0x1f, 0xc5, 0x27, 0x06, 0x85, 0xb9, 0xae, 0x59, 0x4d, 0x0d, 0xcf, 0xe6, 0x23, 0x0e, 0x23,
+	0x33, 0xc4, 0x02, 0x13, 0xd1, 0xe9, 0x90, 0xe7, 0x48, 0x8b, 0x57, 0x44, 0xef, 0x70, 0x18, 0x89,
+	0x2e, 0x30, 0xc9, 0x3e, 0x94, 0x43, 0xca, 0xec, 0x28, 0x1c, 0x49, 0x7b, 0xfe, 0xcd, 0xd9, 0x57,
+	0x0a, 0xb6, 0x12, 0xe3, 0xa0, 0x30, 0xc8, 0x97, 0x00, 0x26, 0x5e, 0x48, 0x2d, 0x07, 0x4f, 0xd7,
+	0xc4, 0xe9, 0x9c, 0x91, 0xe2, 0x90, 0xc7, 0xc9, 0x59, 0x4d, 0x65, 0xae, 0xd9, 0xdb, 0xc1, 0x23,
+	0x9d, 0x03, 0x39, 0x7b, 0xf2, 0x7c, 0xe7, 0x30, 0x75, 0x8a, 0x53, 0xe5, 0x68, 0xdf, 0x9a, 0x75,
+	0x06, 0x65, 0x33, 0x81, 0x17, 0xa3, 0xb4, 0x86, 0x50, 0x4b, 0x4d, 0xc1, 0x25, 0x6b, 0xee, 0x41,
+	0x76, 0xcd, 0xcd, 0x9a, 0xd3, 0xe4, 0xa0, 0xe9, 0xe4, 0xcf, 0x18, 0x6a, 0xa9, 0xd9, 0xb8, 0xa4,
+	0xa3, 0xdd, 0x6c, 0x47, 0xb3, 0x0e, 0x93, 0x00, 0x9d, 0x5a, 0xd6, 0xdf, 0x80, 0x22, 0x17, 0x21,
+	0xf1, 0x67, 0x1a, 0x5f, 0xac, 0x82, 0xb8, 0xec, 0xb8, 0x46, 0xff, 0x65, 0x11, 0x2a, 0x6a, 0xe1,
+	0xf1, 0x54, 0xe7, 0x39, 0x8b, 0xe8, 0xd8, 0x8c, 0xcf, 0x61, 0x34, 0x03, 0x04, 0x8b, 0x07, 0xfc,
+	0x5f, 0x80, 0xea, 0x84, 0xd1, 0x50, 0x14, 0x0b, 0x43, 0x50, 0x41, 0x06, 0x2f, 0x7c, 0x07, 0x6a,
+	0x91, 0x1f, 0x59, 0x23, 0x33, 0xe2, 0xdb, 0xa2, 0xbc, 0x68, 0xcd, 0x59, 0x62, 0x53, 0xf4, 0x55,
+	0x58, 0x8d, 0x4e, 0x43, 0x3f, 0x8a, 0x46, 0xb8, 0x25, 0xe7, 0x1b, 0x44, 0xb1, 0x9f, 0x2b, 0x18,
+	0x8d, 0xb8, 0x40, 0x6c, 0x1c, 0xf1, 0x08, 0x78, 0x39, 0xa9, 0x1c, 0x5f, 0x43, 0x2b, 0x18, 0x4b,
+	0x31, 0x17, 0xfd, 0x2a, 0x3f, 0x07, 0x15, 0x1b, 0x2f, 0xae, 0xd8, 0x9a, 0xa1, 0x48, 0xf2, 0x3e,
+	0xac, 0x0a, 0x71, 0xf8, 0x1e, 0x93, 0xda, 0xbe, 0xe7, 0xa8, 0xbd, 0xda, 0x0a, 0x2f, 0x68, 0x07,
+	0x93, 0xbe, 0x60, 0x63, 0xbc, 0x86, 0x7b, 0x44, 0x3c, 0x01, 0xce, 0xcf, 0x61, 0x21, 0xda, 0xbe,
+	0xd2, 0x2d, 0xd1, 0x9c, 0x98, 0x78, 0xe2, 0x27, 0x8e, 0xcf, 0xcc, 0x13, 0x7e, 0xa4, 0x26, 0x4f,
+	0xfc, 0x16, 0x3d, 0x90, 0x5b, 0x56, 0x70, 0x82, 0x26, 0xdf, 0x87, 0x98, 0x63, 0xe2, 0xfc, 0xe1,
+	0x71, 0x34, 0xe2, 0xdf, 0x9e, 0xe3, 0x94, 0xf2, 0xc8, 0x73, 0x23, 0x63, 0x49, 0xc1, 0x20, 0xc5,
+	0xf4, 0x5f, 0x68, 0x50, 0x92, 0x5d, 0xac, 0x40, 0xad, 0xff, 0xb4, 0x3f, 0xe8, 0xec, 0x9b, 0xfb,
+	0x07, 0x3b, 0x1d, 0xf9, 0x14, 0xa2, 0xdf, 0x31, 0x04, 0xa9, 0x61, 0xf9, 0xe0, 0x60, 0xb0, 0xbd,
+	0x67, 0x0e, 0xba, 0xed, 0xc7, 0xfd, 0x46, 0x8e, 0x6c, 0xc0, 0xea, 0x60, 0xd7, 0x38, 0x18, 0x0c,
+	0xf6, 0x3a, 0x3b, 0xe6, 0x61, 0xc7, 0xe8, 0x1e, 0xec, 0xf4, 0x1b, 0x79, 0x3c, 0x16, 0x4e, 0xd8,
+	0x83, 0xee, 0x7e, 0xa7, 0x51, 0xc0, 0xcb, 0xef, 0x87, 0x1d, 0xa3, 0xdd, 0xe9, 0x0d, 0x1a, 0x45,
+	0xde, 0x8e, 0x03, 0xb5, 0x0f, 0x8f, 0xcc, 0x7e, 0xa7, 0x7d, 0xd0, 0xdb, 0xe9, 0x37, 0x4a, 0xfa,
+	0xb7, 0xa1, 0x1a, 0x8f, 0x6b, 0x2a, 0xe0, 0x5a, 0xe2, 0x01, 0x57, 0x6a, 0xba, 0x73, 0x99, 0xe9,
+	0xd6, 0xff, 0xa6, 0x00, 0xb5, 0x94, 0xed, 0xc7, 0xb5, 0x16, 0x32, 0x26, 0x3d, 0x19, 0xfe, 0xe4,
+	0x37, 0xdd, 0x2c, 0xfb, 0x54, 0x28, 0x6e, 0xc1, 0x10, 0x04, 0xcf, 0x66, 0x59, 0x67, 0xa9, 0xf8,
+	0xab, 0x60, 0x54, 0xc6, 0xd6, 0x99, 0x00, 0xf9, 0x32, 0xd4, 0x5f, 0xd0, 0xd0, 0xa3, 0x23, 0x59,
+	0x2e, 0x94, 0xb5, 0x26, 0x78, 0xa2, 0xca, 0x2d, 0x68, 0xc8, 0x2a, 0x09, 0x8c, 0xd0, 0xd4, 0x65,
+	0xc1, 0xdf, 0x57, 0x60, 0xeb, 0x50, 0x14, 0xc5, 0x65, 0xd1, 0x3f, 0x27, 0x70, 0x51, 0xb2, 0xd7,
+	0x56, 0xc0, 0x35, 0xb3, 0x60, 0xf0, 0xdf, 0x7c, 0x27, 0xc3, 0x4f, 0xeb, 0x65, 0x14, 0x22, 0x29,
+	0x71, 0xa0, 0x9c, 0x55, 0xaf, 0xd2, 0x5b, 0x38, 0x50, 0xfe, 0x7f, 0xd1, 0xb0, 0x28, 0x56, 0xb0,
+	0x32, 0xe4, 0x0d, 0xf5, 0xfa, 0xa1, 0xbd, 0xdd, 0xde, 0x45, 0xa5, 0x5a, 0x82, 0xea, 0xfe, 0xf6,
+	0x0f, 0xcc, 0xa3, 0xbe, 0xb8, 0x6e, 0xd0, 0x80, 0xfa, 0xe3, 0x8e, 0xd1, 0xeb, 0xec, 0x49, 0x4e,
+	0x9e, 0xac, 0x43, 0x43, 0x72, 0x92, 0x7a, 0x05, 0x44, 0x10, 0x3f, 0x8b, 0x78, 0x32, 0xd9, 0x7f,
+	0xb2, 0x7d, 0xd8, 0x28, 0xe1, 0x5d, 0x85, 0xfe, 0xee, 0xb6, 0xd1, 0xd9, 0x69, 0x94, 0xf5, 0xff,
+	0xd6, 0xa0, 0x1a, 0xfb, 0x71, 0x1c, 0x57, 0xfb, 0xdc, 0x1e, 0x51, 0xa5, 0x16, 0x92, 0xc2, 0x8c,
+	0x8e, 0xeb, 0x89, 0xd7, 0x42, 0x3c, 0xb1, 0x20, 0x14, 0x24, 0xc3, 0xc3, 0xb4, 0x08, 0x57, 0x18,
+	0x33, 0xa4, 0x27, 0x34, 0xc4, 0x90, 0x90, 0x49, 0x75, 0x59, 0xe1, 0x7c, 0x23, 0x66, 0xa3, 0xd6,
+	0x88, 0xaa, 0x98, 0x90, 0xa0, 0xca, 0xc4, 0xd5, 0x38, 0x6f, 0x9f, 0xb3, 0xc8, 0x6d, 0x58, 0x3b,
+	0x0e, 0x2d, 0xcf, 0x3e, 0x35, 0x33, 0x1d, 0x0b, 0xc5, 0x21, 0xa2, 0xa8, 0x9b, 0xee, 0xfe, 0x2b,
+	0xb0, 0x24, 0x1b, 0x48, 0x50, 0x11, 0x2d, 0xd7, 0x05, 0x53, 0xa0, 0xea, 0x1f, 0x2b, 0x77, 0x13,
+	0x2b, 0x9c, 0xc8, 0x99, 0x89, 0xaf, 0x15, 0x04, 0x5f, 0x42, 0x96, 0xfd, 0x82, 0x46, 0xea, 0x3b,
+	0x15, 0xa9, 0xff, 0x4c, 0x83, 0x7a, 0xda, 0xdf, 0x63, 0xa7, 0xa3, 0x91, 0x6d, 0xfa, 0xb6, 0x3d,
+	0x09, 0x2c, 0xcf, 0x3e, 0x97, 0x40, 0xf5, 0xd1, 0xc8, 0x3e, 0x50, 0x3c, 0x3c, 0xfe, 0x1a, 0x1f,
+	0x8f, 0x4d, 0x61, 0x6b, 0x45, 0x7f, 0x02, 0x77, 0x69, 0x7c, 0x3c, 0x1e, 0x20, 0x57, 0x24, 0xe8,
+	0x64, 0x3d, 0x4c, 0x2a, 0xab, 0x7a, 0xf9, 0xb8, 0xde, 0x9e, 0x6f, 0xcb, 0x7a, 0xfa, 0xcf, 0x35,
+	0xa8, 0xa5, 0xc2, 0x00, 0x8c, 0x4c, 0x43, 0x6a, 0x39, 0x66, 0xfa, 0x53, 0xaa, 0xc8, 0x11, 0xb0,
+	0xef, 0x40, 0xed, 0x75, 0xe8, 0x46, 0x34, 0xd3, 0x35, 0x70, 0x96, 0xa8, 0x70, 0x53, 0xe4, 0xba,
+	0x4c, 0x3f, 0x50, 0x1d, 0x96, 0x91, 0x3e, 0x08, 0x78, 0x26, 0x5b, 0xb4, 0xf5, 0x03, 0x35, 0x4b,
+	0x15, 0xce, 0x38, 0x08, 0xf0, 0xc2, 0xf7, 0x52, 0x26, 0x7e, 0x20, 0x8d, 0x24, 0xa8, 0xd5, 0x44,
+	0x8c, 0x7a, 0x3d, 0x13, 0xa3, 0x6a, 0x71, 0xbc, 0x89, 0x56, 0xcb, 0x97, 0x0e, 0x30, 0xe7, 0xfa,
+	0xfa, 0x7f, 0xe6, 0x60, 0x45, 0x6c, 0x58, 0xe3, 0xab, 0xd1, 0x9f, 0x7f, 0x35, 0x34, 0x7d, 0x16,
+	0x98, 0xcb, 0x9e, 0x05, 0xaa, 0x04, 0x1a, 0xcf, 0x37, 0xe4, 0x93, 0x04, 0x1a, 0x3f, 0x1f, 0xcb,
+	0xec, 0x45, 0x0b, 0xf3, 0xec, 0x45, 0x9b, 0x50, 0x1e, 0x53, 0x16, 0x5b, 0xae, 0xaa, 0xa1, 0x48,
+	0xe2, 0x42, 0xcd, 0xf2, 0x3c, 0x3f, 0xb2, 0x84, 0x7a, 0x96, 0xe6, 0xda, 0xa6, 0x5f, 0xf8, 0xe2,
+	0xcd, 0xed, 0x04, 0x49, 0x04, 0xa7, 0x69, 0xec, 0xd6, 0x77, 0xa1, 0x71, 0xb1, 0xc2, 0x3c, 0x1b,
+	0xf5, 0xf7, 0xbf, 0x91, 0xec, 0xd3, 0x29, 0xfa, 0x19, 0x79, 0x25, 0xa9, 0x71, 0x0d, 0x09, 0xe3,
+	0xa8, 0xd7, 0xeb, 0xf6, 0x1e, 0x35, 0x34, 0x34, 0x0e, 0x9d, 0x1f, 0x74, 0xf1, 0x8d, 0x5f, 0xee,
+	0xfd, 0xff, 0xd5, 0xa0, 0xa2, 0xcc, 0x15, 0xb9, 0x09, 0x1b, 0xfd, 0xc1, 0xf6, 0xc0, 0x3c, 0xea,
+	0x75, 0xf1, 0x4f, 0xff, 0xb0, 0xd3, 0xee, 0x3e, 0xec, 0xf2, 0xb7, 0x80, 0x6b, 0xb0, 0x92, 0x14,
+	0x3d, 0x78, 0x3a, 0xe8, 0xf4, 0x1b, 0x1a, 0xb9, 0x01, 0x6b, 0x09, 0xf3, 0x71, 0xf7, 0x41, 0x57,
+	0x14, 0xe4, 0xb2, 0x40, 0xbd, 0xed, 0xde, 0x81, 0x72, 0x6d, 0x79, 0xd2, 0x82, 0xeb, 0x49, 0xd1,
+	0x7e, 0xb7, 0x6d, 0xc4, 0x65, 0x05, 0xf4, 0x86, 0x49, 0x99, 0x62, 0x17, 0xb3, 0x6c, 0xe5, 0x3b,
+	0x4b, 0x59, 0x91, 0x8c, 0xed, 0x41, 0xf7, 0xa0, 0x51, 0x26, 0xab, 0xb0, 0x94, 0x82, 0xdf, 0xfd,
+	0x61, 0xa3, 0x92, 0xad, 0xd7, 0xc6, 0xeb, 0x57, 0x8d, 0xea, 0xd6, 0xbf, 0xac, 0x43, 0x49, 0x4c,
+	0x0e, 0xf9, 0x4c, 0xe6, 0x66, 0xd2, 0xaf, 0x71, 0xc9, 0x77, 0xe7, 0xce, 0x82, 0x66, 0x5e, 0xf8,
+	0xb6, 0x3e, 0x59, 0xb8, 0xbd, 0xbc, 0xde, 0x79, 0x8d, 0xfc, 0xb9, 0x06, 0xf5, 0xcc, 0xbd, 0xb0,
+	0x59, 0x2f, 0x56, 0x5c, 0xf2, 0xf8, 0xb7, 0xf5, 0x9d, 0x85, 0xda, 0xc6, 0xb2, 0xfc, 0x42, 0x83,
+	0x5a, 0xea, 0xd9, 0x2b, 0xb9, 0xbb, 0xc8, 0x53, 0x59, 0x21, 0xc9, 0xbd, 0xc5, 0x5f, 0xd9, 0xea,
+	0xd7, 0xbe, 0xae, 0x91, 0x3f, 0xd3, 0xa0, 0x96, 0x7a, 0x00, 0x3a, 0xb3, 0x28, 0xd3, 0xcf, 0x55,
+	0x5b, 0xf7, 0x16, 0x69, 0x1a, 0x8f, 0xc9, 0x9f, 0x68, 0x50, 0x8d, 0x1f, 0x73, 0x92, 0x3b, 0xf3,
+	0x3f, 0xff, 0x14, 0x42, 0x7c, 0xb4, 0xe8, 0xbb, 0x51, 0xfd, 0x1a, 0xf9, 0x23, 0xa8, 0xa8, 0x97,
+	0x8f, 0x64, 0xd6, 0xf0, 0xfa, 0xc2, 0xb3, 0xca, 0xd6, 0x9d, 0xb9, 0xdb, 0xa5, 0xbb, 0x57, 0xcf,
+	0x11, 0x67, 0xee, 0xfe, 0xc2, 0xc3, 0xc9, 0xd6, 0x9d, 0xb9, 0xdb, 0xc5, 0xdd, 0xa3, 0x26, 0xa4,
+	0x5e, 0x2d, 0xce, 0xac, 0x09, 0xd3, 0xcf, 0x25, 0x5b, 0xf7, 0x16, 0x69, 0x9a, 0x11, 0x24, 0xf5,
+	0xee, 0x71, 0x66, 0x41, 0xa6, 0xdf, 0x56, 0xb6, 0xee, 0x2d, 0xd2, 0x34, 0x16, 0xe4, 0xa7, 0x5a,
+	0x3a, 0x53, 0x7b, 0x67, 0xee, 0x77, 0x68, 0x73, 0xaa, 0xe4, 0xd4, 0x03, 0x43, 0xbe, 0x40, 0x7f,
+	0x2a, 0x4f, 0x9e, 0xc4, 0xf3, 0x27, 0x32, 0x0f, 0x58, 0xe6, 0xc5, 0x54, 0xeb, 0xc3, 0xc5, 0x9c,
+	0x2c, 0x17, 0xe2, 0x67, 0x1a, 0x40, 0xf2, 0x50, 0x6a, 0x66, 0x21, 0xa6, 0x5e, 0x68, 0xb5, 0xee,
+	0x2e, 0xd0, 0x32, 0xbd, 0x40, 0xd4, 0x43, 0x8e, 0x99, 0x17, 0xc8, 0x85, 0x87, 0x5c, 0xad, 0x3b,
+	0x73, 0xb7, 0x8b, 0xbb, 0xff, 0x7b, 0x0d, 0x56, 0xa7, 0x1e, 0x92, 0x90, 0x4f, 0xae, 0xf8, 0x96,
+	0xa8, 0xf5, 0xbd, 0xc5, 0x01, 0x94, 0x68, 0xb7, 0xb4, 0xaf, 0x6b, 0xe4, 0x2f, 0x35, 0x58, 0xca,
+	0x5e, 0xb0, 0x9f, 0xd9, 0x4b, 0x5d, 0xf2, 0x24, 0xa5, 0x75, 0x7f, 0xb1, 0xc6, 0xf1, 0x68, 0xfd,
+	0x52, 0x83, 0x65, 0xb9, 0xbe, 0x95, 0x3c, 0xf7, 0xe7, 0x33, 0x0b, 0x17, 0x04, 0xfa, 0x78, 0xc1,
+	0xd6, 0xb1, 0x44, 0x3f, 0xd7, 0x00, 0x92, 0x07, 0xaa, 0x33, 0x2b, 0xf1, 0xd4, 0xd3, 0xdc, 0xd6,
+	0xdd, 0x05, 0x5a, 0xa6, 0x56, 0x34, 0x4e, 0x54, 0xe6, 0x8d, 0xe9, 0xcc, 0x13, 0x75, 0xd9, 0x53,
+	0xd6, 0xd6, 0xfd, 0xc5, 0x1a, 0x67, 0xcc, 0x6d, 0xea, 0xf1, 0xe8, 0xcc, 0xe6, 0x76, 0xfa, 0xed,
+	0x6a, 0xeb, 0xde, 0x22, 0x4d, 0x95, 0x20, 0x0f, 0xca, 0x3f, 0x2c, 0x8a, 0x5d, 0x45, 0x89, 0xff,
+	0xfb, 0xe6, 0xff, 0x0d, 0x00, 0x27, 0xff, 0xd9, 0xd8, 0xd4, 0x46, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4085,6 +5456,15 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// AllocStats starts a streaming RPC where the resource usage of several
+	// tasks, usually those of one allocation, is streamed to the caller
+	// together.
+	AllocStats(ctx context.Context, in *AllocStatsRequest, opts ...grpc.CallOption) (Driver_AllocStatsClient, error)
+	// TaskProcesses returns a snapshot of the processes running in a task.
+	TaskProcesses(ctx context.Context, in *TaskProcessesRequest, opts ...grpc.CallOption) (*TaskProcessesResponse, error)
+	// ProfileTask samples the call stacks of a task's processes for a
+	// duration and returns them folded.
+	ProfileTask(ctx context.Context, in *ProfileTaskRequest, opts ...grpc.CallOption) (*ProfileTaskResponse, error)
 }
 
 type driverClient struct {
@@ -4330,6 +5710,56 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) AllocStats(ctx context.Context, in *AllocStatsRequest, opts ...grpc.CallOption) (Driver_AllocStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Driver_serviceDesc.Streams[4], "/hashicorp.nomad.plugins.drivers.proto.Driver/AllocStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driverAllocStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Driver_AllocStatsClient interface {
+	Recv() (*AllocStatsResponse, error)
+	grpc.ClientStream
+}
+
+type driverAllocStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *driverAllocStatsClient) Recv() (*AllocStatsResponse, error) {
+	m := new(AllocStatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *driverClient) TaskProcesses(ctx context.Context, in *TaskProcessesRequest, opts ...grpc.CallOption) (*TaskProcessesResponse, error) {
+	out := new(TaskProcessesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/TaskProcesses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) ProfileTask(ctx context.Context, in *ProfileTaskRequest, opts ...grpc.CallOption) (*ProfileTaskResponse, error) {
+	out := new(ProfileTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/ProfileTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4385,6 +5815,15 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// AllocStats starts a streaming RPC where the resource usage of several
+	// tasks, usually those of one allocation, is streamed to the caller
+	// together.
+	AllocStats(*AllocStatsRequest, Driver_AllocStatsServer) error
+	// TaskProcesses returns a snapshot of the processes running in a task.
+	TaskProcesses(context.Context, *TaskProcessesRequest) (*TaskProcessesResponse, error)
+	// ProfileTask samples the call stacks of a task's processes for a
+	// duration and returns them folded.
+	ProfileTask(context.Context, *ProfileTaskRequest) (*ProfileTaskResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4439,6 +5878,15 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) AllocStats(req *AllocStatsRequest, srv Driver_AllocStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AllocStats not implemented")
+}
+func (*UnimplementedDriverServer) TaskProcesses(ctx context.Context, req *TaskProcessesRequest) (*TaskProcessesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaskProcesses not implemented")
+}
+func (*UnimplementedDriverServer) ProfileTask(ctx context.Context, req *ProfileTaskRequest) (*ProfileTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileTask not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4749,6 +6197,63 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_AllocStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AllocStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DriverServer).AllocStats(m, &driverAllocStatsServer{stream})
+}
+
+type Driver_AllocStatsServer interface {
+	Send(*AllocStatsResponse) error
+	grpc.ServerStream
+}
+
+type driverAllocStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *driverAllocStatsServer) Send(m *AllocStatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Driver_TaskProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).TaskProcesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/TaskProcesses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).TaskProcesses(ctx, req.(*TaskProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_ProfileTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).ProfileTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/ProfileTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).ProfileTask(ctx, req.(*ProfileTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4801,6 +6306,14 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "TaskProcesses",
+			Handler:    _Driver_TaskProcesses_Handler,
+		},
+		{
+			MethodName: "ProfileTask",
+			Handler:    _Driver_ProfileTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4824,6 +6337,11 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "AllocStats",
+			Handler:       _Driver_AllocStats_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "plugins/drivers/proto/driver.proto",
 }
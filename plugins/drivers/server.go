@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-plugin"
@@ -51,6 +52,11 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
 			DynamicWorkloadUsers:  caps.DynamicWorkloadUsers,
+			Stats:                 statsCapabilitiesToProto(caps.Stats),
+			AllocStats:            caps.AllocStats,
+			Processes:             caps.Processes,
+			Profile:               caps.Profile,
+			SharedCgroup:          caps.SharedCgroup,
 		},
 	}
 
@@ -176,9 +182,10 @@ func (b *driverPluginServer) WaitTask(ctx context.Context, req *proto.WaitTaskRe
 	resp := &proto.WaitTaskResponse{
 		Err: errStr,
 		Result: &proto.ExitResult{
-			ExitCode:  int32(result.ExitCode),
-			Signal:    int32(result.Signal),
-			OomKilled: result.OOMKilled,
+			ExitCode:   int32(result.ExitCode),
+			Signal:     int32(result.Signal),
+			OomKilled:  result.OOMKilled,
+			ExitReason: result.ExitReason,
 		},
 	}
 
@@ -277,6 +284,77 @@ func (b *driverPluginServer) TaskStats(req *proto.TaskStatsRequest, srv proto.Dr
 	return nil
 }
 
+func (b *driverPluginServer) TaskProcesses(ctx context.Context, req *proto.TaskProcessesRequest) (*proto.TaskProcessesResponse, error) {
+	impl, ok := b.impl.(ProcessListDriver)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "TaskProcesses RPC not supported by driver")
+	}
+
+	procs, err := impl.TaskProcesses(req.TaskId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbs, err := TaskProcessesToProto(procs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task processes: %v", err)
+	}
+
+	return &proto.TaskProcessesResponse{Processes: pbs}, nil
+}
+
+func (b *driverPluginServer) ProfileTask(ctx context.Context, req *proto.ProfileTaskRequest) (*proto.ProfileTaskResponse, error) {
+	impl, ok := b.impl.(ProfileDriver)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "ProfileTask RPC not supported by driver")
+	}
+
+	folded, err := impl.ProfileTask(ctx, req.TaskId, time.Duration(req.Duration))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ProfileTaskResponse{FoldedStacks: folded}, nil
+}
+
+func (b *driverPluginServer) AllocStats(req *proto.AllocStatsRequest, srv proto.Driver_AllocStatsServer) error {
+	impl, ok := b.impl.(AllocStatsDriver)
+	if !ok {
+		return status.Error(codes.Unimplemented, "AllocStats RPC not supported by driver")
+	}
+
+	interval, err := ptypes.Duration(req.CollectionInterval)
+	if err != nil {
+		return fmt.Errorf("failed to parse collection interval: %v", err)
+	}
+
+	ch, err := impl.AllocStats(srv.Context(), req.TaskIds, interval)
+	if err != nil {
+		return err
+	}
+
+	for batch := range ch {
+		resp := &proto.AllocStatsResponse{
+			Stats: make(map[string]*proto.TaskStats, len(batch)),
+		}
+		for id, stats := range batch {
+			pb, err := TaskStatsToProto(stats)
+			if err != nil {
+				return fmt.Errorf("failed to encode task stats: %v", err)
+			}
+			resp.Stats[id] = pb
+		}
+
+		if err = srv.Send(resp); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (b *driverPluginServer) ExecTask(ctx context.Context, req *proto.ExecTaskRequest) (*proto.ExecTaskResponse, error) {
 	timeout, err := ptypes.Duration(req.Timeout)
 	if err != nil {
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package drivers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+)
+
+// cpuUsageUnits and memoryUsageUnits are the units the fields of the stats of
+// a task are handled in. Drivers implemented with this package always report
+// these units, while drivers implementing the protocol in other languages
+// declare the units they measured each field in.
+var cpuUsageUnits = map[proto.CPUUsage_Fields]proto.StatUnit{
+	proto.CPUUsage_SYSTEM_MODE:       proto.StatUnit_STAT_UNIT_PERCENT,
+	proto.CPUUsage_USER_MODE:         proto.StatUnit_STAT_UNIT_PERCENT,
+	proto.CPUUsage_TOTAL_TICKS:       proto.StatUnit_STAT_UNIT_MHZ,
+	proto.CPUUsage_THROTTLED_PERIODS: proto.StatUnit_STAT_UNIT_COUNT,
+	proto.CPUUsage_THROTTLED_TIME:    proto.StatUnit_STAT_UNIT_NANOSECONDS,
+	proto.CPUUsage_PERCENT:           proto.StatUnit_STAT_UNIT_PERCENT,
+	proto.CPUUsage_TOTAL_CPU_SECONDS: proto.StatUnit_STAT_UNIT_SECONDS,
+}
+
+var memoryUsageUnits = map[proto.MemoryUsage_Fields]proto.StatUnit{
+	proto.MemoryUsage_RSS:              proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_CACHE:            proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_SWAP:             proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_USAGE:            proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_MAX_USAGE:        proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_KERNEL_USAGE:     proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_KERNEL_MAX_USAGE: proto.StatUnit_STAT_UNIT_BYTES,
+	proto.MemoryUsage_SHARED:           proto.StatUnit_STAT_UNIT_BYTES,
+}
+
+// statUnitScales are the units which can be converted to each other, as the
+// size of each unit in the base unit of its dimension.
+var statUnitScales = map[proto.StatUnit]struct {
+	dimension string
+	scale     float64
+}{
+	proto.StatUnit_STAT_UNIT_BYTES:        {"bytes", 1},
+	proto.StatUnit_STAT_UNIT_KIBIBYTES:    {"bytes", 1024},
+	proto.StatUnit_STAT_UNIT_NANOSECONDS:  {"time", 1e-9},
+	proto.StatUnit_STAT_UNIT_MICROSECONDS: {"time", 1e-6},
+	proto.StatUnit_STAT_UNIT_SECONDS:      {"time", 1},
+	proto.StatUnit_STAT_UNIT_PERCENT:      {"cores", 1},
+	proto.StatUnit_STAT_UNIT_RATIO:        {"cores", 100},
+	proto.StatUnit_STAT_UNIT_MHZ:          {"frequency", 1},
+	proto.StatUnit_STAT_UNIT_COUNT:        {"count", 1},
+}
+
+// statUnitFactor returns what a value in unit from must be multiplied by to
+// be in unit to, or false if the units measure different things.
+func statUnitFactor(from, to proto.StatUnit) (float64, bool) {
+	f, ok := statUnitScales[from]
+	if !ok {
+		return 0, false
+	}
+	t, ok := statUnitScales[to]
+	if !ok || f.dimension != t.dimension {
+		return 0, false
+	}
+	return f.scale / t.scale, true
+}
+
+func cpuUsageMeasuredUnitsToProto(fields []proto.CPUUsage_Fields) []proto.StatUnit {
+	units := make([]proto.StatUnit, 0, len(fields))
+	for _, f := range fields {
+		units = append(units, cpuUsageUnits[f])
+	}
+	return units
+}
+
+func memoryUsageMeasuredUnitsToProto(fields []proto.MemoryUsage_Fields) []proto.StatUnit {
+	units := make([]proto.StatUnit, 0, len(fields))
+	for _, f := range fields {
+		units = append(units, memoryUsageUnits[f])
+	}
+	return units
+}
+
+// convertStatUnits converts the fields of the usage the driver declared in
+// other units than they are handled in. Fields without a declared unit are
+// assumed to be in the expected unit already, as reported by drivers which
+// predate declaring units.
+func convertStatUnits(ru *ResourceUsage, pb *proto.TaskResourceUsage) error {
+	if pb.Cpu != nil && ru.CpuStats != nil {
+		cs := ru.CpuStats
+		for i, field := range pb.Cpu.MeasuredFields {
+			factor, err := measuredUnitFactor(field.String(), i, pb.Cpu.MeasuredUnits, cpuUsageUnits[field])
+			if err != nil {
+				return err
+			}
+			switch field {
+			case proto.CPUUsage_SYSTEM_MODE:
+				cs.SystemMode *= factor
+			case proto.CPUUsage_USER_MODE:
+				cs.UserMode *= factor
+			case proto.CPUUsage_TOTAL_TICKS:
+				cs.TotalTicks *= factor
+			case proto.CPUUsage_THROTTLED_PERIODS:
+				cs.ThrottledPeriods = scaleUint(cs.ThrottledPeriods, factor)
+			case proto.CPUUsage_THROTTLED_TIME:
+				cs.ThrottledTime = scaleUint(cs.ThrottledTime, factor)
+			case proto.CPUUsage_PERCENT:
+				cs.Percent *= factor
+			case proto.CPUUsage_TOTAL_CPU_SECONDS:
+				cs.TotalCpuSeconds *= factor
+			}
+		}
+	}
+
+	if pb.Memory != nil && ru.MemoryStats != nil {
+		ms := ru.MemoryStats
+		for i, field := range pb.Memory.MeasuredFields {
+			factor, err := measuredUnitFactor(field.String(), i, pb.Memory.MeasuredUnits, memoryUsageUnits[field])
+			if err != nil {
+				return err
+			}
+			switch field {
+			case proto.MemoryUsage_RSS:
+				ms.RSS = scaleUint(ms.RSS, factor)
+			case proto.MemoryUsage_CACHE:
+				ms.Cache = scaleUint(ms.Cache, factor)
+			case proto.MemoryUsage_SWAP:
+				ms.Swap = scaleUint(ms.Swap, factor)
+			case proto.MemoryUsage_USAGE:
+				ms.Usage = scaleUint(ms.Usage, factor)
+			case proto.MemoryUsage_MAX_USAGE:
+				ms.MaxUsage = scaleUint(ms.MaxUsage, factor)
+			case proto.MemoryUsage_KERNEL_USAGE:
+				ms.KernelUsage = scaleUint(ms.KernelUsage, factor)
+			case proto.MemoryUsage_KERNEL_MAX_USAGE:
+				ms.KernelMaxUsage = scaleUint(ms.KernelMaxUsage, factor)
+			case proto.MemoryUsage_SHARED:
+				ms.Shared = scaleUint(ms.Shared, factor)
+			}
+		}
+	}
+	return nil
+}
+
+// measuredUnitFactor returns what the i-th measured field must be multiplied
+// by to be in the expected unit.
+func measuredUnitFactor(field string, i int, units []proto.StatUnit, expected proto.StatUnit) (float64, error) {
+	if i >= len(units) || units[i] == proto.StatUnit_STAT_UNIT_UNSPECIFIED ||
+		units[i] == expected || expected == proto.StatUnit_STAT_UNIT_UNSPECIFIED {
+		return 1, nil
+	}
+	factor, ok := statUnitFactor(units[i], expected)
+	if !ok {
+		return 0, fmt.Errorf("stat %s is measured in %s, which can't be converted to %s",
+			field, units[i], expected)
+	}
+	return factor, nil
+}
+
+func scaleUint(v uint64, factor float64) uint64 {
+	if factor == 1 {
+		return v
+	}
+	return uint64(math.Round(float64(v) * factor))
+}
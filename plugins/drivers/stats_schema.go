@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package drivers
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// SanitizeStatsCapabilities returns a copy of the stats capabilities a driver
+// declared without the invalid parts of its schema, along with an error
+// describing them. Gauges described more than once, or with names that can't
+// be used as metric names, are dropped, as are measured fields Nomad doesn't
+// know.
+func SanitizeStatsCapabilities(caps *StatsCapabilities) (*StatsCapabilities, error) {
+	if caps == nil {
+		return nil, nil
+	}
+
+	var mErr *multierror.Error
+	clean := *caps
+	clean.Schema = nil
+	seen := make(map[string]bool, len(caps.Schema))
+	for _, ss := range caps.Schema {
+		switch {
+		case ss == nil:
+			continue
+		case !validStatName(ss.Name):
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid gauge name %q", ss.Name))
+		case seen[ss.Name]:
+			mErr = multierror.Append(mErr, fmt.Errorf("gauge %q described more than once", ss.Name))
+		default:
+			seen[ss.Name] = true
+			clean.Schema = append(clean.Schema, ss)
+		}
+	}
+
+	clean.MemoryMeasured = nil
+	for _, f := range caps.MemoryMeasured {
+		if _, ok := memoryUsageMeasuredFieldToProtoMap[f]; !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("unknown memory stat %q", f))
+			continue
+		}
+		clean.MemoryMeasured = append(clean.MemoryMeasured, f)
+	}
+	clean.CpuMeasured = nil
+	for _, f := range caps.CpuMeasured {
+		if _, ok := cpuUsageMeasuredFieldToProtoMap[f]; !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("unknown cpu stat %q", f))
+			continue
+		}
+		clean.CpuMeasured = append(clean.CpuMeasured, f)
+	}
+	return &clean, mErr.ErrorOrNil()
+}
+
+// validStatName returns true if the gauge name can be used in a metric name:
+// letters, digits and separators, starting with a letter.
+func validStatName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' || r == '/'):
+		default:
+			return false
+		}
+	}
+	return true
+}
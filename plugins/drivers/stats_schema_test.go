@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package drivers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSanitizeStatsCapabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	clean, err := SanitizeStatsCapabilities(nil)
+	must.NoError(t, err)
+	must.Nil(t, clean)
+
+	caps := &StatsCapabilities{
+		Gauges: []string{"queue_depth"},
+		Schema: []*StatSchema{
+			{Name: "connections", Unit: "connections"},
+			{Name: "http.requests/5xx", Unit: "requests"},
+			{Name: "connections", Unit: "bytes"},
+			{Name: "1st"},
+			{Name: ""},
+		},
+		MemoryMeasured: []string{"RSS", "Heap"},
+		CpuMeasured:    []string{"Percent"},
+	}
+	clean, err = SanitizeStatsCapabilities(caps)
+	must.ErrorContains(t, err, `gauge "connections" described more than once`)
+	must.ErrorContains(t, err, `invalid gauge name "1st"`)
+	must.ErrorContains(t, err, `unknown memory stat "Heap"`)
+	must.Eq(t, &StatsCapabilities{
+		Gauges: []string{"queue_depth"},
+		Schema: []*StatSchema{
+			{Name: "connections", Unit: "connections"},
+			{Name: "http.requests/5xx", Unit: "requests"},
+		},
+		MemoryMeasured: []string{"RSS"},
+		CpuMeasured:    []string{"Percent"},
+	}, clean)
+
+	// The declared capabilities aren't modified
+	must.Len(t, 5, caps.Schema)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+var (
+	// KnownMeasuredMemStats are the names of the memory stats a driver may
+	// list as measured. Stats under any other name are not reported.
+	KnownMeasuredMemStats = []string{"RSS", "Cache", "Swap", "Mapped File", "Usage", "Max Usage", "Kernel Usage", "Kernel Max Usage", "Shared"}
+
+	// KnownMeasuredCpuStats are the names of the CPU stats a driver may list
+	// as measured. Stats under any other name are not reported.
+	KnownMeasuredCpuStats = []string{"System Mode", "User Mode", "Total Ticks", "Throttled Periods", "Throttled Time", "Percent", "Total CPU Seconds"}
+)
+
+// StatsConformanceOptions configures the TaskStats conformance tests.
+type StatsConformanceOptions struct {
+	// Interval is the interval stats are requested at. Defaults to 500ms.
+	Interval time.Duration
+
+	// Samples is the number of samples read from each stats stream.
+	// Defaults to 3.
+	Samples int
+
+	// Recover is called to restart the driver: it must return a harness for
+	// a new instance of the driver which has recovered the task from handle.
+	// The recovery test is skipped if Recover is nil.
+	Recover func(t *testing.T, handle *drivers.TaskHandle) *DriverHarness
+
+	// Handle is the handle StartTask returned for the task, passed to
+	// Recover.
+	Handle *drivers.TaskHandle
+}
+
+func (o *StatsConformanceOptions) interval() time.Duration {
+	if o == nil || o.Interval <= 0 {
+		return 500 * time.Millisecond
+	}
+	return o.Interval
+}
+
+func (o *StatsConformanceOptions) samples() int {
+	if o == nil || o.Samples <= 0 {
+		return 3
+	}
+	return o.Samples
+}
+
+// TaskStatsConformanceTests verifies that the TaskStats stream of a running
+// task behaves as the Nomad client expects. Driver authors can run these
+// tests against their driver with a long running task.
+func TaskStatsConformanceTests(t *testing.T, driver *DriverHarness, taskID string, opts *StatsConformanceOptions) {
+	t.Helper()
+
+	TestTaskStatsStream(t, driver, taskID, opts)
+	TestTaskStatsNotFound(t, driver)
+	TestTaskStatsRecover(t, driver, taskID, opts)
+}
+
+// TestTaskStatsStream verifies that the stats stream of the task sends valid
+// samples with increasing timestamps, and closes when its context is done.
+func TestTaskStatsStream(t *testing.T, driver *DriverHarness, taskID string, opts *StatsConformanceOptions) {
+	t.Run("stats: stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := driver.TaskStats(ctx, taskID, opts.interval())
+		must.NoError(t, err)
+
+		samples := readTaskStats(t, ch, opts.samples(), opts.interval())
+		for _, usage := range samples {
+			must.NoError(t, ValidateTaskResourceUsage(usage))
+		}
+		must.NoError(t, validateTimestamps(samples))
+		must.NoError(t, validateCPUSeconds(samples))
+
+		cancel()
+		waitTaskStatsClosed(t, ch)
+	})
+}
+
+// TestTaskStatsNotFound verifies that requesting the stats of an unknown task
+// fails. Over gRPC the error ends the stream, so the stream may also be
+// closed without sending any samples.
+func TestTaskStatsNotFound(t *testing.T, driver *DriverHarness) {
+	t.Run("stats: unknown task", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := driver.TaskStats(ctx, uuid.Generate(), time.Second)
+		if err != nil {
+			return
+		}
+
+		select {
+		case usage, ok := <-ch:
+			must.False(t, ok, must.Sprintf("unexpected stats %#v for unknown task", usage))
+		case <-time.After(10 * time.Second):
+			t.Fatal("stats stream of unknown task not closed")
+		}
+	})
+}
+
+// TestTaskStatsRecover verifies that stats of the task are still reported
+// after the driver restarts and recovers the task, and that they carry on
+// from the samples reported before the restart.
+func TestTaskStatsRecover(t *testing.T, driver *DriverHarness, taskID string, opts *StatsConformanceOptions) {
+	t.Run("stats: recover", func(t *testing.T) {
+		if opts == nil || opts.Recover == nil {
+			t.Skip("driver does not support recovery tests")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := driver.TaskStats(ctx, taskID, opts.interval())
+		must.NoError(t, err)
+		before := readTaskStats(t, ch, 1, opts.interval())
+		cancel()
+
+		recovered := opts.Recover(t, opts.Handle)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err = recovered.TaskStats(ctx, taskID, opts.interval())
+		must.NoError(t, err)
+		after := readTaskStats(t, ch, opts.samples(), opts.interval())
+		for _, usage := range after {
+			must.NoError(t, ValidateTaskResourceUsage(usage))
+		}
+		must.NoError(t, validateTimestamps(append(before, after...)))
+		must.NoError(t, validateCPUSeconds(append(before, after...)))
+	})
+}
+
+// ValidateTaskResourceUsage returns an error if a sample of a task's resource
+// usage cannot be reported and aggregated by the Nomad client: the sample
+// must have a timestamp and memory and CPU stats, list only known stats as
+// measured, and report per-process usage keyed by PID.
+func ValidateTaskResourceUsage(usage *drivers.TaskResourceUsage) error {
+	if usage == nil {
+		return errors.New("resource usage is nil")
+	}
+	if usage.Timestamp <= 0 {
+		return fmt.Errorf("invalid timestamp %d", usage.Timestamp)
+	}
+	if err := validateResourceUsage(usage.ResourceUsage); err != nil {
+		return err
+	}
+
+	for pid, ru := range usage.Pids {
+		if n, err := strconv.Atoi(pid); err != nil || n <= 0 {
+			return fmt.Errorf("invalid pid %q", pid)
+		}
+		if err := validateResourceUsage(ru); err != nil {
+			return fmt.Errorf("pid %s: %w", pid, err)
+		}
+	}
+	return nil
+}
+
+func validateResourceUsage(ru *drivers.ResourceUsage) error {
+	if ru == nil {
+		return errors.New("resource usage is nil")
+	}
+	if ru.MemoryStats == nil {
+		return errors.New("memory stats are nil")
+	}
+	if ru.CpuStats == nil {
+		return errors.New("cpu stats are nil")
+	}
+
+	if err := validateMeasured(ru.MemoryStats.Measured, KnownMeasuredMemStats); err != nil {
+		return fmt.Errorf("memory stats: %w", err)
+	}
+	if err := validateMeasured(ru.CpuStats.Measured, KnownMeasuredCpuStats); err != nil {
+		return fmt.Errorf("cpu stats: %w", err)
+	}
+
+	cpu := map[string]float64{
+		"System Mode":       ru.CpuStats.SystemMode,
+		"User Mode":         ru.CpuStats.UserMode,
+		"Total Ticks":       ru.CpuStats.TotalTicks,
+		"Percent":           ru.CpuStats.Percent,
+		"Total CPU Seconds": ru.CpuStats.TotalCpuSeconds,
+	}
+	for name, v := range cpu {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return fmt.Errorf("cpu stats: invalid %s %v", name, v)
+		}
+	}
+
+	// Gauges may be negative, but must have a name and a finite value
+	for name, g := range ru.Gauges {
+		if name == "" || g == nil {
+			return errors.New("gauges: empty gauge")
+		}
+		if math.IsNaN(g.Value) || math.IsInf(g.Value, 0) {
+			return fmt.Errorf("gauges: invalid %s %v", name, g.Value)
+		}
+	}
+	return nil
+}
+
+func validateMeasured(measured, known []string) error {
+	for i, name := range measured {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("unknown measured stat %q", name)
+		}
+		if slices.Contains(measured[:i], name) {
+			return fmt.Errorf("duplicate measured stat %q", name)
+		}
+	}
+	return nil
+}
+
+func validateTimestamps(samples []*drivers.TaskResourceUsage) error {
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Timestamp <= samples[i-1].Timestamp {
+			return fmt.Errorf("timestamp of sample %d (%d) is not after the previous sample (%d)",
+				i, samples[i].Timestamp, samples[i-1].Timestamp)
+		}
+	}
+	return nil
+}
+
+// validateCPUSeconds returns an error if the total CPU time of the task
+// decreases between samples which measure it.
+func validateCPUSeconds(samples []*drivers.TaskResourceUsage) error {
+	var prev float64
+	for i, usage := range samples {
+		cs := usage.ResourceUsage.CpuStats
+		if !slices.Contains(cs.Measured, "Total CPU Seconds") {
+			continue
+		}
+		if cs.TotalCpuSeconds < prev {
+			return fmt.Errorf("total CPU seconds of sample %d (%v) is less than the previous sample (%v)",
+				i, cs.TotalCpuSeconds, prev)
+		}
+		prev = cs.TotalCpuSeconds
+	}
+	return nil
+}
+
+// readTaskStats reads n samples from a stats stream, failing the test if the
+// stream closes or stalls first.
+func readTaskStats(t *testing.T, ch <-chan *drivers.TaskResourceUsage, n int, interval time.Duration) []*drivers.TaskResourceUsage {
+	t.Helper()
+
+	timeout := 10*time.Second + 2*interval
+	samples := make([]*drivers.TaskResourceUsage, 0, n)
+	for len(samples) < n {
+		select {
+		case usage, ok := <-ch:
+			if !ok {
+				t.Fatalf("stats stream closed after %d of %d samples", len(samples), n)
+			}
+			samples = append(samples, usage)
+		case <-time.After(timeout):
+			t.Fatalf("timed out waiting for sample %d of %d", len(samples)+1, n)
+		}
+	}
+	return samples
+}
+
+// waitTaskStatsClosed waits for a stats stream to close once its context is
+// done. Samples already in flight are drained.
+func waitTaskStatsClosed(t *testing.T, ch <-chan *drivers.TaskResourceUsage) {
+	t.Helper()
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("stats stream not closed after its context was canceled")
+		}
+	}
+}
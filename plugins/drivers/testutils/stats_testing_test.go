@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testutils
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+// newStatsMockDriver returns a mock driver streaming stats for the task with
+// the given ID until the stream's context is done.
+func newStatsMockDriver(taskID string) *MockDriver {
+	return &MockDriver{
+		TaskStatsF: func(ctx context.Context, id string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+			if id != taskID {
+				return nil, drivers.ErrTaskNotFound
+			}
+			ch := make(chan *drivers.TaskResourceUsage)
+			go func() {
+				defer close(ch)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					usage := &drivers.TaskResourceUsage{
+						ResourceUsage: &drivers.ResourceUsage{
+							MemoryStats: &drivers.MemoryStats{RSS: 1024, Measured: []string{"RSS"}},
+							CpuStats:    &drivers.CpuStats{Percent: 12.5, Measured: []string{"Percent"}},
+						},
+						Timestamp: time.Now().UnixNano(),
+						Pids: map[string]*drivers.ResourceUsage{
+							"42": {
+								MemoryStats: &drivers.MemoryStats{RSS: 1024, Measured: []string{"RSS"}},
+								CpuStats:    &drivers.CpuStats{Percent: 12.5, Measured: []string{"Percent"}},
+							},
+						},
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case ch <- usage:
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return ch, nil
+		},
+	}
+}
+
+func TestTaskStatsConformanceTests(t *testing.T) {
+	ci.Parallel(t)
+
+	harness := NewDriverHarness(t, newStatsMockDriver("task"))
+	defer harness.Kill()
+
+	recovered := false
+	TaskStatsConformanceTests(t, harness, "task", &StatsConformanceOptions{
+		Interval: 10 * time.Millisecond,
+		Recover: func(t *testing.T, _ *drivers.TaskHandle) *DriverHarness {
+			recovered = true
+			h := NewDriverHarness(t, newStatsMockDriver("task"))
+			t.Cleanup(h.Kill)
+			return h
+		},
+	})
+	must.True(t, recovered)
+}
+
+func TestValidateTaskResourceUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := func() *drivers.TaskResourceUsage {
+		return &drivers.TaskResourceUsage{
+			ResourceUsage: &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{Measured: []string{"RSS", "Swap"}},
+				CpuStats:    &drivers.CpuStats{Measured: []string{"Percent"}},
+			},
+			Timestamp: 1,
+			Pids: map[string]*drivers.ResourceUsage{
+				"7": {
+					MemoryStats: &drivers.MemoryStats{},
+					CpuStats:    &drivers.CpuStats{},
+				},
+			},
+		}
+	}
+	must.NoError(t, ValidateTaskResourceUsage(valid()))
+
+	cases := []struct {
+		name   string
+		modify func(*drivers.TaskResourceUsage)
+		err    string
+	}{
+		{
+			name:   "no timestamp",
+			modify: func(u *drivers.TaskResourceUsage) { u.Timestamp = 0 },
+			err:    "invalid timestamp",
+		},
+		{
+			name:   "no cpu stats",
+			modify: func(u *drivers.TaskResourceUsage) { u.ResourceUsage.CpuStats = nil },
+			err:    "cpu stats are nil",
+		},
+		{
+			name:   "unknown measured",
+			modify: func(u *drivers.TaskResourceUsage) { u.ResourceUsage.MemoryStats.Measured = []string{"rss"} },
+			err:    `unknown measured stat "rss"`,
+		},
+		{
+			name:   "duplicate measured",
+			modify: func(u *drivers.TaskResourceUsage) { u.ResourceUsage.CpuStats.Measured = []string{"Percent", "Percent"} },
+			err:    "duplicate measured stat",
+		},
+		{
+			name:   "nan percent",
+			modify: func(u *drivers.TaskResourceUsage) { u.ResourceUsage.CpuStats.Percent = math.NaN() },
+			err:    "invalid Percent",
+		},
+		{
+			name: "nan gauge",
+			modify: func(u *drivers.TaskResourceUsage) {
+				u.ResourceUsage.Gauges = map[string]*drivers.Gauge{"queue_depth": {Value: math.NaN()}}
+			},
+			err: "invalid queue_depth",
+		},
+		{
+			name: "bad pid",
+			modify: func(u *drivers.TaskResourceUsage) {
+				u.Pids["task"] = u.Pids["7"]
+			},
+			err: `invalid pid "task"`,
+		},
+		{
+			name: "nil pid usage",
+			modify: func(u *drivers.TaskResourceUsage) {
+				u.Pids["7"] = nil
+			},
+			err: "pid 7: resource usage is nil",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := valid()
+			tc.modify(usage)
+			must.ErrorContains(t, ValidateTaskResourceUsage(usage), tc.err)
+		})
+	}
+}
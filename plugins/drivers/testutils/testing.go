@@ -198,6 +198,9 @@ type MockDriver struct {
 	DestroyTaskF       func(string, bool) error
 	InspectTaskF       func(string) (*drivers.TaskStatus, error)
 	TaskStatsF         func(context.Context, string, time.Duration) (<-chan *drivers.TaskResourceUsage, error)
+	AllocStatsF        func(context.Context, []string, time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error)
+	TaskProcessesF     func(string) ([]*drivers.TaskProcess, error)
+	ProfileTaskF       func(context.Context, string, time.Duration) ([]byte, error)
 	TaskEventsF        func(context.Context) (<-chan *drivers.TaskEvent, error)
 	SignalTaskF        func(string, string) error
 	ExecTaskF          func(string, []string, time.Duration) (*drivers.ExecTaskResult, error)
@@ -241,6 +244,15 @@ func (d *MockDriver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 func (d *MockDriver) TaskStats(ctx context.Context, taskID string, i time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
 	return d.TaskStatsF(ctx, taskID, i)
 }
+func (d *MockDriver) AllocStats(ctx context.Context, taskIDs []string, i time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+	return d.AllocStatsF(ctx, taskIDs, i)
+}
+func (d *MockDriver) TaskProcesses(taskID string) ([]*drivers.TaskProcess, error) {
+	return d.TaskProcessesF(taskID)
+}
+func (d *MockDriver) ProfileTask(ctx context.Context, taskID string, duration time.Duration) ([]byte, error) {
+	return d.ProfileTaskF(ctx, taskID, duration)
+}
 func (d *MockDriver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
 	return d.TaskEventsF(ctx)
 }
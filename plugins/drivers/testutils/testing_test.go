@@ -6,6 +6,7 @@ package testutils
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -275,6 +276,10 @@ func TestBaseDriver_Capabilities(t *testing.T) {
 		SendSignals:         true,
 		Exec:                true,
 		FSIsolation:         drivers.FSIsolationNone,
+		Stats: &drivers.StatsCapabilities{
+			Network: true,
+			DiskIO:  true,
+		},
 	}
 	d := &MockDriver{
 		CapabilitiesF: func() (*drivers.Capabilities, error) {
@@ -289,3 +294,102 @@ func TestBaseDriver_Capabilities(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, capabilities, caps)
 }
+
+func TestBaseDriver_AllocStats(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &MockDriver{}
+	d.TaskStatsF = func(ctx context.Context, id string, _ time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+		ch := make(chan *drivers.TaskResourceUsage, 1)
+		ch <- &drivers.TaskResourceUsage{
+			ResourceUsage: &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{RSS: uint64(len(id))},
+				CpuStats:    &drivers.CpuStats{},
+			},
+			Timestamp: time.Now().UnixNano(),
+		}
+		close(ch)
+		return ch, nil
+	}
+	d.AllocStatsF = func(ctx context.Context, ids []string, interval time.Duration) (<-chan map[string]*drivers.TaskResourceUsage, error) {
+		return drivers.MergeTaskStats(ctx, d, ids, interval)
+	}
+
+	harness := NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	impl, ok := harness.DriverPlugin.(drivers.AllocStatsDriver)
+	must.True(t, ok)
+
+	ch, err := impl.AllocStats(context.Background(), []string{"a", "bb"}, 10*time.Millisecond)
+	must.NoError(t, err)
+
+	got := map[string]uint64{}
+	for batch := range ch {
+		for id, usage := range batch {
+			got[id] = usage.ResourceUsage.MemoryStats.RSS
+		}
+	}
+	must.Eq(t, map[string]uint64{"a": 1, "bb": 2}, got)
+}
+
+func TestBaseDriver_TaskProcesses(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UnixNano()
+	procs := []*drivers.TaskProcess{
+		{Pid: 10, Ppid: 1, Cmdline: []string{"/bin/sh", "-c", "sleep 60"}, State: "sleep", StartTime: now},
+		{Pid: 11, Ppid: 10, Cmdline: []string{"sleep", "60"}, State: "sleep", StartTime: now},
+	}
+	d := &MockDriver{
+		TaskProcessesF: func(id string) ([]*drivers.TaskProcess, error) {
+			if id != "task" {
+				return nil, drivers.ErrTaskNotFound
+			}
+			return procs, nil
+		},
+	}
+
+	harness := NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	impl, ok := harness.DriverPlugin.(drivers.ProcessListDriver)
+	must.True(t, ok)
+
+	got, err := impl.TaskProcesses("task")
+	must.NoError(t, err)
+	must.Eq(t, procs, got)
+
+	_, err = impl.TaskProcesses("missing")
+	must.Error(t, err)
+}
+
+func TestBaseDriver_ProfileTask(t *testing.T) {
+	ci.Parallel(t)
+
+	folded := []byte("redis-server;main;aeMain;epoll_wait 42\n")
+	d := &MockDriver{
+		ProfileTaskF: func(_ context.Context, id string, duration time.Duration) ([]byte, error) {
+			if id != "task" {
+				return nil, drivers.ErrTaskNotFound
+			}
+			if duration != 5*time.Second {
+				return nil, fmt.Errorf("unexpected duration %s", duration)
+			}
+			return folded, nil
+		},
+	}
+
+	harness := NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	impl, ok := harness.DriverPlugin.(drivers.ProfileDriver)
+	must.True(t, ok)
+
+	got, err := impl.ProfileTask(context.Background(), "task", 5*time.Second)
+	must.NoError(t, err)
+	must.Eq(t, folded, got)
+
+	_, err = impl.ProfileTask(context.Background(), "missing", 5*time.Second)
+	must.Error(t, err)
+}
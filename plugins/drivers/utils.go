@@ -4,6 +4,7 @@
 package drivers
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -70,9 +71,11 @@ func taskConfigFromProto(pb *proto.TaskConfig) *TaskConfig {
 		rawDriverConfig:  pb.MsgpackDriverConfig,
 		StdoutPath:       pb.StdoutPath,
 		StderrPath:       pb.StderrPath,
+		FramedLogs:       pb.FramedLogs,
+		QoSClasses:       pb.QosClasses,
 		AllocID:          pb.AllocId,
 		NetworkIsolation: NetworkIsolationSpecFromProto(pb.NetworkIsolationSpec),
-		DNS:              dnsConfigFromProto(pb.Dns),
+		DNS:              DNSConfigFromProto(pb.Dns),
 	}
 }
 
@@ -99,9 +102,11 @@ func taskConfigToProto(cfg *TaskConfig) *proto.TaskConfig {
 		MsgpackDriverConfig:  cfg.rawDriverConfig,
 		StdoutPath:           cfg.StdoutPath,
 		StderrPath:           cfg.StderrPath,
+		FramedLogs:           cfg.FramedLogs,
+		QosClasses:           cfg.QoSClasses,
 		AllocId:              cfg.AllocID,
 		NetworkIsolationSpec: NetworkIsolationSpecToProto(cfg.NetworkIsolation),
-		Dns:                  dnsConfigToProto(cfg.DNS),
+		Dns:                  DNSConfigToProto(cfg.DNS),
 	}
 	return pb
 }
@@ -155,6 +160,8 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			OOMScoreAdj:      pb.LinuxResources.OomScoreAdj,
 			CpusetCpus:       pb.LinuxResources.CpusetCpus,
 			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
+			MemoryPolicy:     pb.LinuxResources.MemoryPolicy,
+			MemoryNodes:      pb.LinuxResources.MemoryNodes,
 			PercentTicks:     pb.LinuxResources.PercentTicks,
 		}
 	}
@@ -225,6 +232,8 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			OomScoreAdj:      r.LinuxResources.OOMScoreAdj,
 			CpusetCpus:       r.LinuxResources.CpusetCpus,
 			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
+			MemoryPolicy:     r.LinuxResources.MemoryPolicy,
+			MemoryNodes:      r.LinuxResources.MemoryNodes,
 			PercentTicks:     r.LinuxResources.PercentTicks,
 		}
 	}
@@ -376,17 +385,19 @@ func exitResultToProto(result *ExitResult) *proto.ExitResult {
 		return &proto.ExitResult{}
 	}
 	return &proto.ExitResult{
-		ExitCode:  int32(result.ExitCode),
-		Signal:    int32(result.Signal),
-		OomKilled: result.OOMKilled,
+		ExitCode:   int32(result.ExitCode),
+		Signal:     int32(result.Signal),
+		OomKilled:  result.OOMKilled,
+		ExitReason: result.ExitReason,
 	}
 }
 
 func exitResultFromProto(pb *proto.ExitResult) *ExitResult {
 	return &ExitResult{
-		ExitCode:  int(pb.ExitCode),
-		Signal:    int(pb.Signal),
-		OOMKilled: pb.OomKilled,
+		ExitCode:   int(pb.ExitCode),
+		Signal:     int(pb.Signal),
+		OOMKilled:  pb.OomKilled,
+		ExitReason: pb.ExitReason,
 	}
 }
 
@@ -441,11 +452,20 @@ func TaskStatsToProto(stats *TaskResourceUsage) (*proto.TaskStats, error) {
 		pids[pid] = resourceUsageToProto(ru)
 	}
 
-	return &proto.TaskStats{
+	pb := &proto.TaskStats{
 		Timestamp:          timestamp,
 		AggResourceUsage:   resourceUsageToProto(stats.ResourceUsage),
 		ResourceUsageByPid: pids,
-	}, nil
+		CgroupPath:         stats.CgroupPath,
+		CgroupId:           stats.CgroupID,
+		ExecutorPid:        int32(stats.ExecutorPID),
+		Limits:             resourceLimitsToProto(stats.Limits),
+		Sequence:           stats.Sequence,
+	}
+	if stats.CollectionDuration > 0 {
+		pb.CollectionDuration = ptypes.DurationProto(stats.CollectionDuration)
+	}
+	return pb, nil
 }
 
 func TaskStatsFromProto(pb *proto.TaskStats) (*TaskResourceUsage, error) {
@@ -455,32 +475,84 @@ func TaskStatsFromProto(pb *proto.TaskStats) (*TaskResourceUsage, error) {
 	}
 
 	pids := map[string]*ResourceUsage{}
-	for pid, ru := range pb.ResourceUsageByPid {
-		pids[pid] = resourceUsageFromProto(ru)
+	for pid, pbru := range pb.ResourceUsageByPid {
+		ru := resourceUsageFromProto(pbru)
+		if err := convertStatUnits(ru, pbru); err != nil {
+			return nil, fmt.Errorf("invalid stats of process %s: %w", pid, err)
+		}
+		pids[pid] = ru
+	}
+
+	ru := resourceUsageFromProto(pb.AggResourceUsage)
+	if err := convertStatUnits(ru, pb.AggResourceUsage); err != nil {
+		return nil, fmt.Errorf("invalid stats: %w", err)
 	}
 
 	stats := &TaskResourceUsage{
 		Timestamp:     timestamp.UnixNano(),
-		ResourceUsage: resourceUsageFromProto(pb.AggResourceUsage),
+		ResourceUsage: ru,
 		Pids:          pids,
+		CgroupPath:    pb.CgroupPath,
+		CgroupID:      pb.CgroupId,
+		ExecutorPID:   int(pb.ExecutorPid),
+		Limits:        resourceLimitsFromProto(pb.Limits),
+		Sequence:      pb.Sequence,
+	}
+	if pb.CollectionDuration != nil {
+		d, err := ptypes.Duration(pb.CollectionDuration)
+		if err != nil {
+			return nil, err
+		}
+		stats.CollectionDuration = d
 	}
 
 	return stats, nil
 }
 
+func resourceLimitsToProto(l *ResourceLimits) *proto.ResourceLimits {
+	if l == nil {
+		return nil
+	}
+	return &proto.ResourceLimits{
+		MemoryMax: l.MemoryMax,
+		CpuMax:    l.CpuMax,
+	}
+}
+
+func resourceLimitsFromProto(pb *proto.ResourceLimits) *ResourceLimits {
+	if pb == nil {
+		return nil
+	}
+	return &ResourceLimits{
+		MemoryMax: pb.MemoryMax,
+		CpuMax:    pb.CpuMax,
+	}
+}
+
 func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
+	cpuFields := cpuUsageMeasuredFieldsToProto(ru.CpuStats.Measured)
 	cpu := &proto.CPUUsage{
-		MeasuredFields:   cpuUsageMeasuredFieldsToProto(ru.CpuStats.Measured),
+		MeasuredFields:   cpuFields,
+		MeasuredUnits:    cpuUsageMeasuredUnitsToProto(cpuFields),
 		SystemMode:       ru.CpuStats.SystemMode,
 		UserMode:         ru.CpuStats.UserMode,
 		TotalTicks:       ru.CpuStats.TotalTicks,
 		ThrottledPeriods: ru.CpuStats.ThrottledPeriods,
 		ThrottledTime:    ru.CpuStats.ThrottledTime,
 		Percent:          ru.CpuStats.Percent,
+		TotalCpuSeconds:  ru.CpuStats.TotalCpuSeconds,
+	}
+	for _, core := range ru.CpuStats.Cores {
+		cpu.Cores = append(cpu.Cores, &proto.CoreUsage{
+			Id:      uint32(core.ID),
+			Percent: core.Percent,
+		})
 	}
 
+	memoryFields := memoryUsageMeasuredFieldsToProto(ru.MemoryStats.Measured)
 	memory := &proto.MemoryUsage{
-		MeasuredFields: memoryUsageMeasuredFieldsToProto(ru.MemoryStats.Measured),
+		MeasuredFields: memoryFields,
+		MeasuredUnits:  memoryUsageMeasuredUnitsToProto(memoryFields),
 		Rss:            ru.MemoryStats.RSS,
 		Cache:          ru.MemoryStats.Cache,
 		Swap:           ru.MemoryStats.Swap,
@@ -488,11 +560,80 @@ func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
 		MaxUsage:       ru.MemoryStats.MaxUsage,
 		KernelUsage:    ru.MemoryStats.KernelUsage,
 		KernelMaxUsage: ru.MemoryStats.KernelMaxUsage,
+		Shared:         ru.MemoryStats.Shared,
+	}
+
+	var perf *proto.PerfUsage
+	if ps := ru.PerfStats; ps != nil {
+		perf = &proto.PerfUsage{
+			Cycles:             ps.Cycles,
+			Instructions:       ps.Instructions,
+			CacheReferences:    ps.CacheReferences,
+			CacheMisses:        ps.CacheMisses,
+			BranchInstructions: ps.BranchInstructions,
+			BranchMisses:       ps.BranchMisses,
+		}
+	}
+
+	var gauges map[string]*proto.Gauge
+	if len(ru.Gauges) > 0 {
+		gauges = make(map[string]*proto.Gauge, len(ru.Gauges))
+		for name, g := range ru.Gauges {
+			if g != nil {
+				gauges[name] = &proto.Gauge{Value: g.Value, Unit: g.Unit}
+			}
+		}
+	}
+
+	var egress map[string]*proto.EgressUsage
+	if len(ru.Egress) > 0 {
+		egress = make(map[string]*proto.EgressUsage, len(ru.Egress))
+		for name, e := range ru.Egress {
+			if e != nil {
+				egress[name] = &proto.EgressUsage{Bytes: e.Bytes, Packets: e.Packets}
+			}
+		}
+	}
+
+	var rdt *proto.ResctrlUsage
+	if rs := ru.Resctrl; rs != nil {
+		rdt = &proto.ResctrlUsage{
+			LlcOccupancy:  rs.LLCOccupancy,
+			MbmTotalBytes: rs.MBMTotalBytes,
+			MbmLocalBytes: rs.MBMLocalBytes,
+		}
+	}
+
+	var diskIO *proto.DiskIOUsage
+	if ds := ru.DiskIO; ds != nil {
+		diskIO = &proto.DiskIOUsage{
+			ReadBytes:  ds.ReadBytes,
+			WriteBytes: ds.WriteBytes,
+			ReadOps:    ds.ReadOps,
+			WriteOps:   ds.WriteOps,
+		}
+	}
+
+	var pressure *proto.PressureUsage
+	if ps := ru.Pressure; ps != nil {
+		pressure = &proto.PressureUsage{
+			Cpu:    ps.CPU,
+			Memory: ps.Memory,
+			Io:     ps.IO,
+		}
 	}
 
 	return &proto.TaskResourceUsage{
-		Cpu:    cpu,
-		Memory: memory,
+		Cpu:      cpu,
+		Memory:   memory,
+		Perf:     perf,
+		Gauges:   gauges,
+		Egress:   egress,
+		Resctrl:  rdt,
+		DiskIo:   diskIO,
+		Pressure: pressure,
+
+		Unreadable: ru.Unreadable,
 	}
 }
 
@@ -507,6 +648,13 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 			ThrottledPeriods: pb.Cpu.ThrottledPeriods,
 			ThrottledTime:    pb.Cpu.ThrottledTime,
 			Percent:          pb.Cpu.Percent,
+			TotalCpuSeconds:  pb.Cpu.TotalCpuSeconds,
+		}
+		for _, core := range pb.Cpu.Cores {
+			cpu.Cores = append(cpu.Cores, &CoreStats{
+				ID:      uint16(core.Id),
+				Percent: core.Percent,
+			})
 		}
 	}
 
@@ -521,12 +669,82 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 			MaxUsage:       pb.Memory.MaxUsage,
 			KernelUsage:    pb.Memory.KernelUsage,
 			KernelMaxUsage: pb.Memory.KernelMaxUsage,
+			Shared:         pb.Memory.Shared,
+		}
+	}
+
+	var perf *PerfStats
+	if pb.Perf != nil {
+		perf = &PerfStats{
+			Cycles:             pb.Perf.Cycles,
+			Instructions:       pb.Perf.Instructions,
+			CacheReferences:    pb.Perf.CacheReferences,
+			CacheMisses:        pb.Perf.CacheMisses,
+			BranchInstructions: pb.Perf.BranchInstructions,
+			BranchMisses:       pb.Perf.BranchMisses,
+		}
+		perf.ComputeRatios()
+	}
+
+	var gauges map[string]*Gauge
+	if len(pb.Gauges) > 0 {
+		gauges = make(map[string]*Gauge, len(pb.Gauges))
+		for name, g := range pb.Gauges {
+			if g != nil {
+				gauges[name] = &Gauge{Value: g.Value, Unit: g.Unit}
+			}
+		}
+	}
+
+	var egress map[string]*EgressStats
+	if len(pb.Egress) > 0 {
+		egress = make(map[string]*EgressStats, len(pb.Egress))
+		for name, e := range pb.Egress {
+			if e != nil {
+				egress[name] = &EgressStats{Bytes: e.Bytes, Packets: e.Packets}
+			}
+		}
+	}
+
+	var rdt *ResctrlStats
+	if pb.Resctrl != nil {
+		rdt = &ResctrlStats{
+			LLCOccupancy:  pb.Resctrl.LlcOccupancy,
+			MBMTotalBytes: pb.Resctrl.MbmTotalBytes,
+			MBMLocalBytes: pb.Resctrl.MbmLocalBytes,
+		}
+	}
+
+	var diskIO *DiskIOStats
+	if pb.DiskIo != nil {
+		diskIO = &DiskIOStats{
+			ReadBytes:  pb.DiskIo.ReadBytes,
+			WriteBytes: pb.DiskIo.WriteBytes,
+			ReadOps:    pb.DiskIo.ReadOps,
+			WriteOps:   pb.DiskIo.WriteOps,
+		}
+	}
+
+	var pressure *PressureStats
+	if pb.Pressure != nil {
+		pressure = &PressureStats{
+			CPU:    pb.Pressure.Cpu,
+			Memory: pb.Pressure.Memory,
+			IO:     pb.Pressure.Io,
 		}
 	}
 
 	return &ResourceUsage{
 		CpuStats:    &cpu,
 		MemoryStats: &memory,
+		PerfStats:   perf,
+		Gauges:      gauges,
+		Egress:      egress,
+		Resctrl:     rdt,
+		DiskIO:      diskIO,
+		Pressure:    pressure,
+
+		Unreadable: pb.Unreadable,
 	}
 }
 
@@ -541,6 +759,7 @@ var cpuUsageMeasuredFieldToProtoMap = map[string]proto.CPUUsage_Fields{
 	"Throttled Periods": proto.CPUUsage_THROTTLED_PERIODS,
 	"Throttled Time":    proto.CPUUsage_THROTTLED_TIME,
 	"Percent":           proto.CPUUsage_PERCENT,
+	"Total CPU Seconds": proto.CPUUsage_TOTAL_CPU_SECONDS,
 }
 
 var cpuUsageMeasuredFieldFromProtoMap = map[proto.CPUUsage_Fields]string{
@@ -550,6 +769,7 @@ var cpuUsageMeasuredFieldFromProtoMap = map[proto.CPUUsage_Fields]string{
 	proto.CPUUsage_THROTTLED_PERIODS: "Throttled Periods",
 	proto.CPUUsage_THROTTLED_TIME:    "Throttled Time",
 	proto.CPUUsage_PERCENT:           "Percent",
+	proto.CPUUsage_TOTAL_CPU_SECONDS: "Total CPU Seconds",
 }
 
 func cpuUsageMeasuredFieldsToProto(fields []string) []proto.CPUUsage_Fields {
@@ -584,6 +804,7 @@ var memoryUsageMeasuredFieldToProtoMap = map[string]proto.MemoryUsage_Fields{
 	"Max Usage":        proto.MemoryUsage_MAX_USAGE,
 	"Kernel Usage":     proto.MemoryUsage_KERNEL_USAGE,
 	"Kernel Max Usage": proto.MemoryUsage_KERNEL_MAX_USAGE,
+	"Shared":           proto.MemoryUsage_SHARED,
 }
 
 var memoryUsageMeasuredFieldFromProtoMap = map[proto.MemoryUsage_Fields]string{
@@ -594,6 +815,7 @@ var memoryUsageMeasuredFieldFromProtoMap = map[proto.MemoryUsage_Fields]string{
 	proto.MemoryUsage_MAX_USAGE:        "Max Usage",
 	proto.MemoryUsage_KERNEL_USAGE:     "Kernel Usage",
 	proto.MemoryUsage_KERNEL_MAX_USAGE: "Kernel Max Usage",
+	proto.MemoryUsage_SHARED:           "Shared",
 }
 
 func memoryUsageMeasuredFieldsToProto(fields []string) []proto.MemoryUsage_Fields {
@@ -650,6 +872,95 @@ func netIsolationModeFromProto(pb proto.NetworkIsolationSpec_NetworkIsolationMod
 	}
 }
 
+func statsCapabilitiesToProto(caps *StatsCapabilities) *proto.StatsCapabilities {
+	if caps == nil {
+		return nil
+	}
+	var schema []*proto.StatSchema
+	for _, ss := range caps.Schema {
+		schema = append(schema, &proto.StatSchema{
+			Name:        ss.Name,
+			Unit:        ss.Unit,
+			Description: ss.Description,
+		})
+	}
+	return &proto.StatsCapabilities{
+		Network:        caps.Network,
+		DiskIo:         caps.DiskIO,
+		Devices:        caps.Devices,
+		Pressure:       caps.Pressure,
+		Gauges:         caps.Gauges,
+		Schema:         schema,
+		MemoryMeasured: memoryUsageMeasuredFieldsToProto(caps.MemoryMeasured),
+		CpuMeasured:    cpuUsageMeasuredFieldsToProto(caps.CpuMeasured),
+	}
+}
+
+func statsCapabilitiesFromProto(pb *proto.StatsCapabilities) *StatsCapabilities {
+	if pb == nil {
+		return nil
+	}
+	var schema []*StatSchema
+	for _, ss := range pb.Schema {
+		schema = append(schema, &StatSchema{
+			Name:        ss.Name,
+			Unit:        ss.Unit,
+			Description: ss.Description,
+		})
+	}
+	caps := &StatsCapabilities{
+		Network:  pb.Network,
+		DiskIO:   pb.DiskIo,
+		Devices:  pb.Devices,
+		Pressure: pb.Pressure,
+		Gauges:   pb.Gauges,
+		Schema:   schema,
+	}
+	if len(pb.MemoryMeasured) > 0 {
+		caps.MemoryMeasured = memoryUsageMeasuredFieldsFromProto(pb.MemoryMeasured)
+	}
+	if len(pb.CpuMeasured) > 0 {
+		caps.CpuMeasured = cpuUsageMeasuredFieldsFromProto(pb.CpuMeasured)
+	}
+	return caps
+}
+
+func TaskProcessesToProto(procs []*TaskProcess) ([]*proto.TaskProcess, error) {
+	pbs := make([]*proto.TaskProcess, 0, len(procs))
+	for _, p := range procs {
+		started, err := ptypes.TimestampProto(time.Unix(0, p.StartTime))
+		if err != nil {
+			return nil, err
+		}
+		pbs = append(pbs, &proto.TaskProcess{
+			Pid:       int64(p.Pid),
+			Ppid:      int64(p.Ppid),
+			Cmdline:   p.Cmdline,
+			State:     p.State,
+			StartTime: started,
+		})
+	}
+	return pbs, nil
+}
+
+func TaskProcessesFromProto(pbs []*proto.TaskProcess) ([]*TaskProcess, error) {
+	procs := make([]*TaskProcess, 0, len(pbs))
+	for _, pb := range pbs {
+		started, err := ptypes.Timestamp(pb.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		procs = append(procs, &TaskProcess{
+			Pid:       int(pb.Pid),
+			Ppid:      int(pb.Ppid),
+			Cmdline:   pb.Cmdline,
+			State:     pb.State,
+			StartTime: started.UnixNano(),
+		})
+	}
+	return procs, nil
+}
+
 func networkCreateRequestFromProto(pb *proto.CreateNetworkRequest) *NetworkCreateRequest {
 	if pb == nil {
 		return nil
@@ -705,7 +1016,7 @@ func hostsConfigFromProto(pb *proto.HostsConfig) *HostsConfig {
 	}
 }
 
-func dnsConfigToProto(dns *DNSConfig) *proto.DNSConfig {
+func DNSConfigToProto(dns *DNSConfig) *proto.DNSConfig {
 	if dns == nil {
 		return nil
 	}
@@ -717,7 +1028,7 @@ func dnsConfigToProto(dns *DNSConfig) *proto.DNSConfig {
 	}
 }
 
-func dnsConfigFromProto(pb *proto.DNSConfig) *DNSConfig {
+func DNSConfigFromProto(pb *proto.DNSConfig) *DNSConfig {
 	if pb == nil {
 		return nil
 	}
@@ -5,11 +5,14 @@ package drivers
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers/proto"
 	"github.com/shoenig/test/must"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestResourceUsageRoundTrip(t *testing.T) {
@@ -21,7 +24,9 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 			ThrottledPeriods: 2321,
 			ThrottledTime:    123,
 			Percent:          0.9963906952696598,
-			Measured:         []string{"System Mode", "User Mode", "Percent"},
+			TotalCpuSeconds:  182.25,
+			Cores:            []*CoreStats{{ID: 2, Percent: 98.5}, {ID: 3, Percent: 1.5}},
+			Measured:         []string{"System Mode", "User Mode", "Percent", "Total CPU Seconds"},
 		},
 		MemoryStats: &MemoryStats{
 			RSS:            25681920,
@@ -30,12 +35,149 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 			MaxUsage:       23,
 			KernelUsage:    34,
 			KernelMaxUsage: 45,
-			Measured:       []string{"RSS", "Swap"},
+			Shared:         4096,
+			Measured:       []string{"RSS", "Swap", "Shared"},
+		},
+		Gauges: map[string]*Gauge{
+			"open_connections": {Value: 42, Unit: "connections"},
+			"queue_depth":      {Value: 3},
+		},
+		Egress: map[string]*EgressStats{
+			"cross-az":     {Bytes: 1048576, Packets: 812},
+			"unclassified": {Bytes: 2048, Packets: 3},
+		},
+		Resctrl: &ResctrlStats{
+			LLCOccupancy:  6291456,
+			MBMTotalBytes: 1073741824,
+			MBMLocalBytes: 805306368,
+		},
+		DiskIO: &DiskIOStats{
+			ReadBytes:  4096,
+			WriteBytes: 8192,
+			ReadOps:    2,
+			WriteOps:   3,
+		},
+		Pressure: &PressureStats{
+			CPU:    1.5,
+			Memory: 0.25,
+			IO:     12.75,
 		},
 	}
 
 	parsed := resourceUsageFromProto(resourceUsageToProto(input))
 	must.Eq(t, parsed, input)
+
+	// Processes which could not be read are flagged
+	unreadable := &ResourceUsage{
+		CpuStats:    &CpuStats{},
+		MemoryStats: &MemoryStats{},
+		Unreadable:  true,
+	}
+	must.True(t, resourceUsageFromProto(resourceUsageToProto(unreadable)).Unreadable)
+}
+
+func TestStatsCapabilitiesRoundTrip(t *testing.T) {
+	ci.Parallel(t)
+
+	input := &StatsCapabilities{
+		Network: true,
+		Gauges:  []string{"queue_depth"},
+		Schema: []*StatSchema{
+			{Name: "connections", Unit: "connections", Description: "Open client connections"},
+		},
+		MemoryMeasured: []string{"RSS", "Swap"},
+		CpuMeasured:    []string{"Total CPU Seconds"},
+	}
+	must.Eq(t, input, statsCapabilitiesFromProto(statsCapabilitiesToProto(input)))
+}
+
+func TestTaskStatsRoundTrip(t *testing.T) {
+	input := &TaskResourceUsage{
+		ResourceUsage: &ResourceUsage{
+			CpuStats: &CpuStats{
+				Percent:  12.5,
+				Measured: []string{"Percent"},
+			},
+			MemoryStats: &MemoryStats{
+				RSS:      25681920,
+				Measured: []string{"RSS"},
+			},
+		},
+		Timestamp:   1495743243970720000,
+		Pids:        map[string]*ResourceUsage{},
+		CgroupPath:  "/sys/fs/cgroup/nomad.slice/share.slice/abc.web.scope",
+		CgroupID:    4026,
+		ExecutorPID: 3417,
+		Limits: &ResourceLimits{
+			MemoryMax: 268435456,
+			CpuMax:    1.5,
+		},
+		Sequence:           42,
+		CollectionDuration: 1002 * time.Millisecond,
+	}
+
+	pb, err := TaskStatsToProto(input)
+	must.NoError(t, err)
+
+	parsed, err := TaskStatsFromProto(pb)
+	must.NoError(t, err)
+	must.Eq(t, input, parsed)
+}
+
+func TestTaskStatsFromProto_Units(t *testing.T) {
+	ci.Parallel(t)
+
+	timestamp := timestamppb.New(time.Unix(0, 1495743243970720000))
+	pb := &proto.TaskStats{
+		Timestamp: timestamp,
+		AggResourceUsage: &proto.TaskResourceUsage{
+			Cpu: &proto.CPUUsage{
+				Percent:         0.25,
+				ThrottledTime:   3,
+				TotalCpuSeconds: 42,
+				MeasuredFields: []proto.CPUUsage_Fields{
+					proto.CPUUsage_PERCENT,
+					proto.CPUUsage_THROTTLED_TIME,
+					proto.CPUUsage_TOTAL_CPU_SECONDS,
+				},
+				MeasuredUnits: []proto.StatUnit{
+					proto.StatUnit_STAT_UNIT_RATIO,
+					proto.StatUnit_STAT_UNIT_MICROSECONDS,
+				},
+			},
+			Memory: &proto.MemoryUsage{
+				Rss:            1024,
+				MeasuredFields: []proto.MemoryUsage_Fields{proto.MemoryUsage_RSS},
+				MeasuredUnits:  []proto.StatUnit{proto.StatUnit_STAT_UNIT_KIBIBYTES},
+			},
+		},
+	}
+
+	// Fields in other units are converted, and fields without a declared
+	// unit are left as they are
+	stats, err := TaskStatsFromProto(pb)
+	must.NoError(t, err)
+	must.Eq(t, 25, stats.ResourceUsage.CpuStats.Percent)
+	must.Eq(t, 3000, stats.ResourceUsage.CpuStats.ThrottledTime)
+	must.Eq(t, 42, stats.ResourceUsage.CpuStats.TotalCpuSeconds)
+	must.Eq(t, 1024*1024, stats.ResourceUsage.MemoryStats.RSS)
+
+	// Fields in units measuring something else are rejected
+	pb.AggResourceUsage.Memory.MeasuredUnits = []proto.StatUnit{proto.StatUnit_STAT_UNIT_SECONDS}
+	_, err = TaskStatsFromProto(pb)
+	must.ErrorContains(t, err, "stat RSS is measured in STAT_UNIT_SECONDS")
+
+	// Drivers of this package declare the units they're handled in
+	out, err := TaskStatsToProto(&TaskResourceUsage{ResourceUsage: &ResourceUsage{
+		CpuStats:    &CpuStats{Measured: []string{"Percent", "Throttled Time"}},
+		MemoryStats: &MemoryStats{Measured: []string{"RSS"}},
+	}})
+	must.NoError(t, err)
+	must.Eq(t, []proto.StatUnit{
+		proto.StatUnit_STAT_UNIT_PERCENT,
+		proto.StatUnit_STAT_UNIT_NANOSECONDS,
+	}, out.AggResourceUsage.Cpu.MeasuredUnits)
+	must.Eq(t, []proto.StatUnit{proto.StatUnit_STAT_UNIT_BYTES}, out.AggResourceUsage.Memory.MeasuredUnits)
 }
 
 func TestTaskConfigRoundTrip(t *testing.T) {
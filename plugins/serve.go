@@ -10,6 +10,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/statssink"
 )
 
 // PluginFactory returns a new plugin instance
@@ -48,6 +49,8 @@ func serve(plugin interface{}, logger log.Logger) {
 		device.Serve(p, logger)
 	case drivers.DriverPlugin:
 		drivers.Serve(p, logger)
+	case statssink.StatsSinkPlugin:
+		statssink.Serve(p, logger)
 	default:
 		fmt.Println("Unsupported plugin type")
 	}
@@ -17,6 +17,11 @@ type ReattachConfig struct {
 	Network  string
 	Addr     string
 	Pid      int
+
+	// ProtocolVersion is the plugin protocol version negotiated when the
+	// plugin was launched. It is 0 for plugins that don't negotiate one and
+	// in configs persisted before it was recorded.
+	ProtocolVersion int
 }
 
 // ReattachConfigToGoPlugin converts a ReattachConfig wrapper struct into a go
@@ -27,8 +32,9 @@ func ReattachConfigToGoPlugin(rc *ReattachConfig) (*plugin.ReattachConfig, error
 	}
 
 	plug := &plugin.ReattachConfig{
-		Protocol: plugin.Protocol(rc.Protocol),
-		Pid:      rc.Pid,
+		Protocol:        plugin.Protocol(rc.Protocol),
+		ProtocolVersion: rc.ProtocolVersion,
+		Pid:             rc.Pid,
 	}
 
 	switch rc.Network {
@@ -65,10 +71,11 @@ func ReattachConfigFromGoPlugin(plug *plugin.ReattachConfig) *ReattachConfig {
 	}
 
 	rc := &ReattachConfig{
-		Protocol: string(plug.Protocol),
-		Network:  plug.Addr.Network(),
-		Addr:     plug.Addr.String(),
-		Pid:      plug.Pid,
+		Protocol:        string(plug.Protocol),
+		Network:         plug.Addr.Network(),
+		Addr:            plug.Addr.String(),
+		Pid:             plug.Pid,
+		ProtocolVersion: plug.ProtocolVersion,
 	}
 
 	return rc
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+
+	"github.com/LK4D4/joincontext"
+	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/statssink/proto"
+)
+
+// statsSinkPluginClient implements the client side of a remote stats sink
+// plugin, using gRPC to communicate to the remote plugin.
+type statsSinkPluginClient struct {
+	// basePluginClient is embedded to give access to the base plugin methods.
+	*base.BasePluginClient
+
+	client proto.StatsSinkPluginClient
+
+	// doneCtx is closed when the plugin exits
+	doneCtx context.Context
+}
+
+// EmitTaskStats sends a task resource usage sample to the plugin.
+func (s *statsSinkPluginClient) EmitTaskStats(ctx context.Context, sample *TaskStatsSample) error {
+	stats, err := drivers.TaskStatsToProto(sample.Stats)
+	if err != nil {
+		return err
+	}
+
+	req := &proto.EmitTaskStatsRequest{
+		AllocId:   sample.AllocID,
+		Namespace: sample.Namespace,
+		JobId:     sample.JobID,
+		TaskGroup: sample.TaskGroup,
+		TaskName:  sample.TaskName,
+		NodeId:    sample.NodeID,
+		Stats:     stats,
+	}
+
+	// Join the passed context and the shutdown context
+	joinedCtx, joinedCtxCancel := joincontext.Join(ctx, s.doneCtx)
+	defer joinedCtxCancel()
+
+	if _, err := s.client.EmitTaskStats(joinedCtx, req); err != nil {
+		return grpcutils.HandleReqCtxGrpcErr(err, ctx, s.doneCtx)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+type EmitTaskStatsFn func(context.Context, *TaskStatsSample) error
+
+// MockStatsSinkPlugin is used for testing.
+// Each function can be set as a closure to make assertions about how data
+// is passed through the base plugin layer.
+type MockStatsSinkPlugin struct {
+	*base.MockPlugin
+	EmitTaskStatsF EmitTaskStatsFn
+}
+
+func (p *MockStatsSinkPlugin) EmitTaskStats(ctx context.Context, sample *TaskStatsSample) error {
+	return p.EmitTaskStatsF(ctx, sample)
+}
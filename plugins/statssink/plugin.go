@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+
+	log "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/base"
+	bproto "github.com/hashicorp/nomad/plugins/base/proto"
+	"github.com/hashicorp/nomad/plugins/statssink/proto"
+	"google.golang.org/grpc"
+)
+
+// PluginStatsSink wraps a StatsSinkPlugin and implements go-plugins
+// GRPCPlugin interface to expose the interface over gRPC.
+type PluginStatsSink struct {
+	plugin.NetRPCUnsupportedPlugin
+	Impl StatsSinkPlugin
+}
+
+func (p *PluginStatsSink) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterStatsSinkPluginServer(s, &statsSinkPluginServer{
+		impl:   p.Impl,
+		broker: broker,
+	})
+	return nil
+}
+
+func (p *PluginStatsSink) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &statsSinkPluginClient{
+		doneCtx: ctx,
+		client:  proto.NewStatsSinkPluginClient(c),
+		BasePluginClient: &base.BasePluginClient{
+			Client:  bproto.NewBasePluginClient(c),
+			DoneCtx: ctx,
+		},
+	}, nil
+}
+
+// Serve is used to serve a stats sink plugin
+func Serve(sink StatsSinkPlugin, logger log.Logger) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: base.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			base.PluginTypeBase:      &base.PluginBase{Impl: sink},
+			base.PluginTypeStatsSink: &PluginStatsSink{Impl: sink},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestStatsSinkPlugin_PluginInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &MockStatsSinkPlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF: func() (*base.PluginInfoResponse, error) {
+				return &base.PluginInfoResponse{
+					Type:              base.PluginTypeStatsSink,
+					PluginApiVersions: []string{ApiVersion010},
+					PluginVersion:     "v0.1.0",
+					Name:              "mock_sink",
+				}, nil
+			},
+		},
+	}
+
+	client, server := plugin.TestPluginGRPCConn(t, true, map[string]plugin.Plugin{
+		base.PluginTypeBase:      &base.PluginBase{Impl: mock},
+		base.PluginTypeStatsSink: &PluginStatsSink{Impl: mock},
+	})
+	defer server.Stop()
+	defer client.Close()
+
+	raw, err := client.Dispense(base.PluginTypeStatsSink)
+	must.NoError(t, err)
+
+	impl, ok := raw.(StatsSinkPlugin)
+	must.True(t, ok)
+
+	info, err := impl.PluginInfo()
+	must.NoError(t, err)
+	must.Eq(t, base.PluginTypeStatsSink, info.Type)
+	must.Eq(t, "mock_sink", info.Name)
+}
+
+func TestStatsSinkPlugin_EmitTaskStats(t *testing.T) {
+	ci.Parallel(t)
+
+	var received *TaskStatsSample
+	mock := &MockStatsSinkPlugin{
+		MockPlugin: &base.MockPlugin{},
+		EmitTaskStatsF: func(_ context.Context, sample *TaskStatsSample) error {
+			if sample.TaskName == "fail" {
+				return errors.New("sink unavailable")
+			}
+			received = sample
+			return nil
+		},
+	}
+
+	client, server := plugin.TestPluginGRPCConn(t, true, map[string]plugin.Plugin{
+		base.PluginTypeBase:      &base.PluginBase{Impl: mock},
+		base.PluginTypeStatsSink: &PluginStatsSink{Impl: mock},
+	})
+	defer server.Stop()
+	defer client.Close()
+
+	raw, err := client.Dispense(base.PluginTypeStatsSink)
+	must.NoError(t, err)
+	impl := raw.(StatsSinkPlugin)
+
+	sample := &TaskStatsSample{
+		AllocID:   "alloc",
+		Namespace: "default",
+		JobID:     "example",
+		TaskGroup: "cache",
+		TaskName:  "redis",
+		NodeID:    "node",
+		Stats: &drivers.TaskResourceUsage{
+			ResourceUsage: &drivers.ResourceUsage{
+				MemoryStats: &drivers.MemoryStats{RSS: 1024, Measured: []string{"RSS"}},
+				CpuStats:    &drivers.CpuStats{TotalTicks: 250, Measured: []string{"Total Ticks"}},
+			},
+			Timestamp:   10,
+			CgroupPath:  "/sys/fs/cgroup/nomad.slice/alloc.redis.scope",
+			ExecutorPID: 42,
+		},
+	}
+	must.NoError(t, impl.EmitTaskStats(context.Background(), sample))
+	must.NotNil(t, received)
+	must.Eq(t, "redis", received.TaskName)
+	must.Eq(t, "cache", received.TaskGroup)
+	must.Eq(t, uint64(1024), received.Stats.ResourceUsage.MemoryStats.RSS)
+	must.Eq(t, 250, received.Stats.ResourceUsage.CpuStats.TotalTicks)
+	must.Eq(t, sample.Stats.CgroupPath, received.Stats.CgroupPath)
+
+	sample.TaskName = "fail"
+	err = impl.EmitTaskStats(context.Background(), sample)
+	must.ErrorContains(t, err, "sink unavailable")
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/statssink/proto"
+)
+
+// statsSinkPluginServer wraps a stats sink plugin and exposes it via gRPC.
+type statsSinkPluginServer struct {
+	broker *plugin.GRPCBroker
+	impl   StatsSinkPlugin
+}
+
+func (s *statsSinkPluginServer) EmitTaskStats(ctx context.Context, req *proto.EmitTaskStatsRequest) (*proto.EmitTaskStatsResponse, error) {
+	stats, err := drivers.TaskStatsFromProto(req.GetStats())
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &TaskStatsSample{
+		AllocID:   req.GetAllocId(),
+		Namespace: req.GetNamespace(),
+		JobID:     req.GetJobId(),
+		TaskGroup: req.GetTaskGroup(),
+		TaskName:  req.GetTaskName(),
+		NodeID:    req.GetNodeId(),
+		Stats:     stats,
+	}
+
+	if err := s.impl.EmitTaskStats(ctx, sample); err != nil {
+		return nil, err
+	}
+	return &proto.EmitTaskStatsResponse{}, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// StatsSinkPlugin is the interface for a plugin that receives the resource
+// usage samples collected by the client for every task it runs, so they can
+// be exported to an external system.
+type StatsSinkPlugin interface {
+	base.BasePlugin
+
+	// EmitTaskStats is called with every resource usage sample collected for
+	// a task. Samples are delivered in order per task, but the client does
+	// not wait on the plugin and drops samples if the plugin falls behind.
+	EmitTaskStats(ctx context.Context, sample *TaskStatsSample) error
+}
+
+// TaskStatsSample is a resource usage sample of a task along with the
+// identifiers of the allocation running it.
+type TaskStatsSample struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	TaskName  string
+	NodeID    string
+
+	// Stats is the resource usage sample of the task
+	Stats *drivers.TaskResourceUsage
+}
@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statssink
+
+const (
+	// ApiVersion010 is the initial API version for the stats sink plugins
+	ApiVersion010 = "v0.1.0"
+)
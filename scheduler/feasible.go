@@ -813,6 +813,10 @@ func resolveTarget(target string, node *structs.Node) (string, bool) {
 	case "${node.pool}" == target:
 		return node.NodePool, true
 
+	case "${node.utilization}" == target:
+		val, ok := node.Attributes[structs.NodeUtilizationBucketAttr]
+		return val, ok
+
 	case strings.HasPrefix(target, "${attr."):
 		attr := strings.TrimSuffix(strings.TrimPrefix(target, "${attr."), "}")
 		val, ok := node.Attributes[attr]
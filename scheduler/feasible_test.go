@@ -973,6 +973,11 @@ func TestResolveConstraintTarget(t *testing.T) {
 			val:    node.NodeClass,
 			result: true,
 		},
+		{
+			target: "${node.utilization}",
+			node:   node,
+			result: false,
+		},
 		{
 			target: "${node.foo}",
 			node:   node,
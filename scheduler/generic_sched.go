@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -623,11 +624,14 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 
 			// Set fields based on if we found an allocation option
 			if option != nil {
+				overheadMB, overheadShares := tg.SharedResources.Overhead()
 				resources := &structs.AllocatedResources{
 					Tasks:          option.TaskResources,
 					TaskLifecycles: option.TaskLifecycles,
 					Shared: structs.AllocatedSharedResources{
-						DiskMB: int64(tg.EphemeralDisk.SizeMB),
+						DiskMB:    int64(tg.EphemeralDisk.SizeMB),
+						MemoryMB:  overheadMB,
+						CpuShares: overheadShares,
 					},
 				}
 				if option.AllocResources != nil {
@@ -673,6 +677,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 					AllocatedResources: resources,
 					DesiredStatus:      structs.AllocDesiredStatusRun,
 					ClientStatus:       structs.AllocClientStatusPending,
+					TraceParent:        tracecontext.ChildIfSet(s.eval.TraceParent),
 					// SharedResources is considered deprecated, will be removed in 0.11.
 					// It is only set for compat reasons.
 					SharedResources: &structs.Resources{
@@ -885,10 +890,13 @@ func updateRescheduleTracker(alloc *structs.Allocation, prev *structs.Allocation
 	}
 	nextDelay := prev.NextDelay()
 	rescheduleEvent := structs.NewRescheduleEvent(now.UnixNano(), prev.ID, prev.NodeID, nextDelay)
+	rescheduleEvent.JobVersion = prev.Job.Version
+	rescheduleEvent.OOMKilled = prev.OOMKilled()
 	rescheduleEvents = append(rescheduleEvents, rescheduleEvent)
 	alloc.RescheduleTracker = &structs.RescheduleTracker{
 		Events:         rescheduleEvents,
-		LastReschedule: structs.LastRescheduleSuccess}
+		LastReschedule: structs.LastRescheduleSuccess,
+		OOMKills:       prev.OOMKills()}
 	annotateRescheduleTracker(prev, structs.LastRescheduleSuccess)
 }
 
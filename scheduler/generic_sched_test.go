@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -124,6 +125,49 @@ func TestServiceSched_JobRegister(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_TraceParent(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+		TraceParent: tracecontext.New(),
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+
+	parent, err := tracecontext.Parse(eval.TraceParent)
+	must.NoError(t, err)
+
+	// Every placement is a distinct span within the eval's trace
+	spans := map[string]struct{}{}
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		for _, alloc := range allocs {
+			tp, err := tracecontext.Parse(alloc.TraceParent)
+			must.NoError(t, err)
+			must.Eq(t, parent.TraceID, tp.TraceID)
+			must.NotEq(t, parent.SpanID, tp.SpanID)
+			spans[tp.SpanID] = struct{}{}
+		}
+	}
+	must.MapLen(t, 3, spans)
+}
+
 func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6471,6 +6515,63 @@ func Test_updateRescheduleTracker(t *testing.T) {
 
 }
 
+func Test_updateRescheduleTracker_OOMKills(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		DelayFunction: "constant",
+		Delay:         5 * time.Second,
+		Unlimited:     true,
+		MaxOOMKills:   10,
+	}
+	oomKill := func(alloc *structs.Allocation) {
+		alloc.ClientStatus = structs.AllocClientStatusFailed
+		alloc.TaskStates = map[string]*structs.TaskState{
+			"web": {
+				State:      structs.TaskStateDead,
+				Failed:     true,
+				ExitReason: structs.TaskExitReasonOOMKilled,
+				FinishedAt: time.Now(),
+			},
+		}
+	}
+
+	// The OOM kills are counted past the events the tracker keeps
+	now := time.Now()
+	prev := mock.Alloc()
+	prev.Job = job
+	oomKill(prev)
+	for kills := 2; kills <= 8; kills++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		updateRescheduleTracker(alloc, prev, now)
+		must.LessEq(t, maxPastRescheduleEvents+1, len(alloc.RescheduleTracker.Events))
+
+		oomKill(alloc)
+		must.Eq(t, kills, alloc.OOMKills())
+		prev = alloc
+	}
+	must.True(t, prev.OOMKillLimitReached(&structs.ReschedulePolicy{MaxOOMKills: 8}))
+
+	// A new job version starts counting again
+	next := mock.Alloc()
+	next.Job = job.Copy()
+	next.Job.Version++
+	updateRescheduleTracker(next, prev, now)
+	oomKill(next)
+	must.Eq(t, 1, next.OOMKills())
+
+	// Allocations failing for other reasons reset the count
+	last := mock.Alloc()
+	last.Job = next.Job
+	updateRescheduleTracker(last, next, now)
+	must.Eq(t, 1, last.RescheduleTracker.OOMKills)
+	next.TaskStates["web"].ExitReason = structs.TaskExitReasonNonZeroExit
+	updateRescheduleTracker(last, next, now)
+	must.Eq(t, 0, last.RescheduleTracker.OOMKills)
+}
+
 func TestServiceSched_Preemption(t *testing.T) {
 	ci.Parallel(t)
 
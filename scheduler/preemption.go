@@ -17,6 +17,12 @@ import (
 // number of allocations being preempted exceeds max_parallel value in the job's migrate block
 const maxParallelPenalty = 50.0
 
+// observedUsagePenalty is the score penalty applied to an allocation using all
+// of its reserved CPU or memory, scaled down for allocations using less. This
+// favors preempting allocations that are mostly idle, since they are cheap to
+// reschedule, over allocations of the same size that are running hot.
+const observedUsagePenalty = 0.5
+
 type groupedAllocs struct {
 	priority int
 	allocs   []*structs.Allocation
@@ -25,12 +31,17 @@ type groupedAllocs struct {
 type allocInfo struct {
 	maxParallel int
 	resources   *structs.ComparableResources
+
+	// utilization is the fraction of its reserved CPU or memory, whichever
+	// is higher, the allocation was last observed using
+	utilization float64
 }
 
 func (ai *allocInfo) Copy() *allocInfo {
 	return &allocInfo{
 		maxParallel: ai.maxParallel,
 		resources:   ai.resources.Copy(),
+		utilization: ai.utilization,
 	}
 }
 
@@ -129,6 +140,10 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// observedUsage is whether the usage the clients reported for the
+	// candidates is used when scoring them
+	observedUsage bool
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -156,10 +171,17 @@ func (p *Preemptor) Copy() *Preemptor {
 		jobID:                  p.jobID,
 		nodeRemainingResources: p.nodeRemainingResources.Copy(),
 		currentAllocs:          helper.CopySlice(p.currentAllocs),
+		observedUsage:          p.observedUsage,
 		ctx:                    p.ctx,
 	}
 }
 
+// SetObservedUsage sets whether the usage the clients reported for the
+// candidates is used when choosing which of them to preempt for a task group
+func (p *Preemptor) SetObservedUsage(enabled bool) {
+	p.observedUsage = enabled
+}
+
 // SetNode sets the node
 func (p *Preemptor) SetNode(node *structs.Node) {
 	nodeRemainingResources := node.NodeResources.Comparable()
@@ -187,7 +209,12 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
-		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.AllocatedResources.Comparable()}
+		resources := alloc.AllocatedResources.Comparable()
+		p.allocDetails[alloc.ID] = &allocInfo{
+			maxParallel: maxParallel,
+			resources:   resources,
+			utilization: observedUtilization(alloc, resources),
+		}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
@@ -254,6 +281,9 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 				allocDetails := p.allocDetails[alloc.ID]
 				maxParallel := allocDetails.maxParallel
 				distance := scoreForTaskGroup(resourcesNeeded, allocDetails.resources, maxParallel, currentPreemptionCount)
+				if p.observedUsage {
+					distance += allocDetails.utilization * observedUsagePenalty
+				}
 				if distance < bestDistance {
 					bestDistance = distance
 					closestAllocIndex = index
@@ -674,6 +704,26 @@ func scoreForTaskGroup(resourceAsk *structs.ComparableResources, resourceUsed *s
 	return basicResourceDistance(resourceAsk, resourceUsed) + maxParallelScorePenalty
 }
 
+// observedUtilization returns the fraction of its reserved CPU or memory,
+// whichever is higher, an allocation was last observed using, capped at 1. An
+// allocation whose client did not report its usage is assumed to use all of
+// its reservation, so it is never preferred over one observed to be idle.
+func observedUtilization(alloc *structs.Allocation, reserved *structs.ComparableResources) float64 {
+	usage := alloc.UsageSummary
+	if usage == nil || alloc.ClientStatus != structs.AllocClientStatusRunning {
+		return 1
+	}
+
+	var utilization float64
+	if cpu := reserved.Flattened.Cpu.CpuShares; cpu > 0 {
+		utilization = float64(usage.CPU) / float64(cpu)
+	}
+	if memory := reserved.Flattened.Memory.MemoryMB; memory > 0 {
+		utilization = max(utilization, float64(usage.MemoryMB)/float64(memory))
+	}
+	return min(utilization, 1)
+}
+
 // scoreForNetwork is similar to scoreForTaskGroup
 // but only uses network Mbits to calculate a preemption score
 func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *structs.NetworkResource, maxParallel int, numPreemptedAllocs int) float64 {
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	psstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1436,6 +1437,79 @@ func TestPreemption_Normal(t *testing.T) {
 	}
 }
 
+func TestPreemption_ObservedUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	highPrioJob := mock.Job()
+	highPrioJob.Priority = 100
+	lowPrioJob := mock.Job()
+	lowPrioJob.Priority = 30
+
+	legacyCpuResources, processorResources := cpuResources(4000)
+	node := mock.Node()
+	node.NodeResources = &structs.NodeResources{
+		Processors: processorResources,
+		Cpu:        legacyCpuResources,
+		Memory:     structs.NodeMemoryResources{MemoryMB: 8192},
+		Disk:       structs.NodeDiskResources{DiskMB: 100 * 1024},
+	}
+	node.ReservedResources = &structs.NodeReservedResources{
+		Cpu:    structs.NodeReservedCpuResources{CpuShares: 100},
+		Memory: structs.NodeReservedMemoryResources{MemoryMB: 256},
+	}
+
+	// The hot allocation is the closest fit for the ask, but the idle one is
+	// only a little larger
+	hot := createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{CPU: 1000, MemoryMB: 1024, DiskMB: 1024})
+	hot.UsageSummary = &structs.AllocUsageSummary{CPU: 900, MemoryMB: 512}
+	idle := createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{CPU: 1200, MemoryMB: 1024, DiskMB: 1024})
+	idle.UsageSummary = &structs.AllocUsageSummary{CPU: 60, MemoryMB: 100}
+	filler := createAlloc(uuid.Generate(), highPrioJob, &structs.Resources{CPU: 1700, MemoryMB: 5888, DiskMB: 1024})
+
+	testCases := []struct {
+		name      string
+		ignore    bool
+		preempted string
+	}{
+		{name: "observed usage", preempted: idle.ID},
+		{name: "ignore observed usage", ignore: true, preempted: hot.ID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+			must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+			allocs := []*structs.Allocation{hot.Copy(), idle.Copy(), filler.Copy()}
+			for _, alloc := range allocs {
+				alloc.NodeID = node.ID
+			}
+			must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+			schedConfig := testSchedulerConfig.Copy()
+			schedConfig.PreemptionConfig.IgnoreObservedUsage = tc.ignore
+
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+			binPackIter := NewBinPackIterator(ctx, static, true, 100)
+			job := mock.Job()
+			job.Priority = 100
+			binPackIter.SetJob(job)
+			binPackIter.SetSchedulerConfiguration(schedConfig)
+			binPackIter.SetTaskGroup(&structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{{
+					Name:      "web",
+					Resources: &structs.Resources{CPU: 1000, MemoryMB: 1024, DiskMB: 1024},
+				}},
+			})
+
+			option := binPackIter.Next()
+			must.NotNil(t, option)
+			must.Len(t, 1, option.PreemptedAllocs)
+			must.Eq(t, tc.preempted, option.PreemptedAllocs[0].ID)
+		})
+	}
+}
+
 // TestPreemptionMultiple tests evicting multiple allocations in the same time
 func TestPreemptionMultiple(t *testing.T) {
 	ci.Parallel(t)
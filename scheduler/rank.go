@@ -165,6 +165,7 @@ type BinPackIterator struct {
 	jobId                  structs.NamespacedID
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
+	preemptByUsage         bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
 }
 
@@ -180,6 +181,7 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 		// These are default values that may be overwritten by
 		// SetSchedulerConfiguration.
 		memoryOversubscription: false,
+		preemptByUsage:         true,
 		scoreFit:               structs.ScoreFitBinPack,
 	}
 }
@@ -204,6 +206,9 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 
 	// Set memory oversubscription.
 	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+
+	// Set whether preemption considers the observed usage of allocations.
+	iter.preemptByUsage = schedConfig == nil || !schedConfig.PreemptionConfig.IgnoreObservedUsage
 }
 
 func (iter *BinPackIterator) Next() *RankedNode {
@@ -261,14 +266,18 @@ NEXTNODE:
 		totalDeviceAffinityWeight := 0.0
 		sumMatchingAffinities := 0.0
 
-		// Assign the resources for each task
+		// Assign the resources for each task, and the overhead of the
+		// alloc-level cgroup of groups with shared resources
+		overheadMB, overheadShares := iter.taskGroup.SharedResources.Overhead()
 		total := &structs.AllocatedResources{
 			Tasks: make(map[string]*structs.AllocatedTaskResources,
 				len(iter.taskGroup.Tasks)),
 			TaskLifecycles: make(map[string]*structs.TaskLifecycleConfig,
 				len(iter.taskGroup.Tasks)),
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				DiskMB:    int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				MemoryMB:  overheadMB,
+				CpuShares: overheadShares,
 			},
 		}
 
@@ -277,6 +286,7 @@ NEXTNODE:
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
 		preemptor.SetNode(option.Node)
+		preemptor.SetObservedUsage(iter.preemptByUsage)
 
 		// Count the number of existing preemptions
 		allPreemptions := iter.ctx.Plan().NodePreemptions
@@ -361,9 +371,11 @@ NEXTNODE:
 			total.Shared.Networks = []*structs.NetworkResource{nwRes}
 			total.Shared.Ports = offer
 			option.AllocResources = &structs.AllocatedSharedResources{
-				Networks: []*structs.NetworkResource{nwRes},
-				DiskMB:   int64(iter.taskGroup.EphemeralDisk.SizeMB),
-				Ports:    offer,
+				Networks:  []*structs.NetworkResource{nwRes},
+				DiskMB:    int64(iter.taskGroup.EphemeralDisk.SizeMB),
+				Ports:     offer,
+				MemoryMB:  overheadMB,
+				CpuShares: overheadShares,
 			}
 
 		}
@@ -464,6 +464,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, false, a.now, a.evalID, a.deployment)
+	a.annotateOOMKillLimit(untainted)
 
 	// If there are allocations reconnecting we need to reconcile them and
 	// their replacements first because there is specific logic when deciding
@@ -1308,6 +1309,31 @@ func (a *allocReconciler) createRescheduleLaterEvals(rescheduleLater []*delayedR
 	}
 }
 
+// annotateOOMKillLimit marks the failed allocations that aren't rescheduled
+// because their job version reached the OOM kill limit of their reschedule
+// policy, so their reschedule tracker records why.
+func (a *allocReconciler) annotateOOMKillLimit(untainted allocSet) {
+	for id, alloc := range untainted {
+		if alloc.NextAllocation != "" || !alloc.OOMKillLimitReached(alloc.ReschedulePolicy()) {
+			continue
+		}
+		if alloc.RescheduleTracker != nil &&
+			alloc.RescheduleTracker.LastReschedule == structs.LastRescheduleOOMKillLimit {
+			continue
+		}
+
+		updatedAlloc, ok := a.result.attributeUpdates[id]
+		if !ok {
+			updatedAlloc = alloc.Copy()
+			a.result.attributeUpdates[id] = updatedAlloc
+		}
+		if updatedAlloc.RescheduleTracker == nil {
+			updatedAlloc.RescheduleTracker = &structs.RescheduleTracker{}
+		}
+		updatedAlloc.RescheduleTracker.LastReschedule = structs.LastRescheduleOOMKillLimit
+	}
+}
+
 // computeReconnecting copies existing allocations in the unknown state, but
 // whose nodes have been identified as ready. The Allocations DesiredStatus is
 // set to running, and these allocs are appended to the Plan as non-destructive
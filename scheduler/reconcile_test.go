@@ -1999,6 +1999,71 @@ func TestReconciler_RescheduleLater_Service(t *testing.T) {
 	must.Eq(t, evals[0].ID, annotated.FollowupEvalID)
 }
 
+// Tests that failed allocations of a job version OOM-killed too many times
+// aren't rescheduled, and are annotated with the reason
+func TestReconciler_Reschedule_OOMKillLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		Delay:         5 * time.Second,
+		DelayFunction: "constant",
+		Unlimited:     true,
+		MaxOOMKills:   2,
+	}
+	tgName := job.TaskGroups[0].Name
+	now := time.Now()
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, tgName, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// Mark one as OOM-killed after a reschedule of an OOM-killed allocation
+	allocs[0].ClientStatus = structs.AllocClientStatusFailed
+	allocs[0].TaskStates = map[string]*structs.TaskState{"web": {
+		State:      structs.TaskStateDead,
+		Failed:     true,
+		ExitReason: structs.TaskExitReasonOOMKilled,
+		StartedAt:  now.Add(-time.Minute),
+		FinishedAt: now.Add(-10 * time.Second),
+	}}
+	allocs[0].RescheduleTracker = &structs.RescheduleTracker{
+		Events: []*structs.RescheduleEvent{{
+			RescheduleTime: now.Add(-time.Minute).UnixNano(),
+			PrevAllocID:    uuid.Generate(),
+			PrevNodeID:     uuid.Generate(),
+			JobVersion:     job.Version,
+			OOMKilled:      true,
+		}},
+		OOMKills: 1,
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+		nil, allocs, nil, uuid.Generate(), 50, true)
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		place:            0,
+		attributeUpdates: 1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			tgName: {
+				Ignore: 2,
+			},
+		},
+	})
+	must.Eq(t, structs.LastRescheduleOOMKillLimit,
+		r.attributeUpdates[allocs[0].ID].RescheduleTracker.LastReschedule)
+	must.MapEmpty(t, r.desiredFollowupEvals)
+}
+
 // Tests service allocations with client status complete
 func TestReconciler_Service_ClientStatusComplete(t *testing.T) {
 	ci.Parallel(t)
@@ -9,6 +9,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/tracecontext"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -445,11 +446,14 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 		s.ctx.Metrics().PopulateScoreMetaData()
 
 		// Set fields based on if we found an allocation option
+		overheadMB, overheadShares := missing.TaskGroup.SharedResources.Overhead()
 		resources := &structs.AllocatedResources{
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				DiskMB:    int64(missing.TaskGroup.EphemeralDisk.SizeMB),
+				MemoryMB:  overheadMB,
+				CpuShares: overheadShares,
 			},
 		}
 
@@ -473,6 +477,7 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			AllocatedResources: resources,
 			DesiredStatus:      structs.AllocDesiredStatusRun,
 			ClientStatus:       structs.AllocClientStatusPending,
+			TraceParent:        tracecontext.ChildIfSet(s.eval.TraceParent),
 			// SharedResources is considered deprecated, will be removed in 0.11.
 			// It is only set for compat reasons
 			SharedResources: &structs.Resources{
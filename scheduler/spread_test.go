@@ -565,6 +565,69 @@ func TestSpreadIterator_MaxPenalty(t *testing.T) {
 
 }
 
+func TestSpreadIterator_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	buckets := []string{"low", "low", "medium", "high", ""}
+	var nodes []*RankedNode
+
+	// Add nodes in a single datacenter, one of which doesn't report its
+	// utilization
+	for i, bucket := range buckets {
+		node := mock.Node()
+		if bucket != "" {
+			node.Attributes["unique.utilization.bucket"] = bucket
+		}
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 10
+
+	// One alloc is already placed on a low utilization node
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{{
+		Namespace: structs.DefaultNamespace,
+		TaskGroup: tg.Name,
+		JobID:     job.ID,
+		Job:       job,
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    nodes[0].Node.ID,
+	}}))
+
+	tg.Spreads = []*structs.Spread{{
+		Weight:    100,
+		Attribute: "${node.utilization}",
+		SpreadTarget: []*structs.SpreadTarget{
+			{Value: "low", Percent: 80},
+			{Value: "medium", Percent: 20},
+		},
+	}}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+	scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+	out := collectRanked(scoreNorm)
+	must.Len(t, len(buckets), out)
+
+	// Hot nodes and nodes without the attribute get the maximum penalty
+	expectedScores := map[string]float64{
+		"low":    0.75,
+		"medium": 0.5,
+		"high":   -1,
+		"":       -1,
+	}
+	for _, rn := range out {
+		bucket := rn.Node.Attributes["unique.utilization.bucket"]
+		must.Eq(t, expectedScores[bucket], rn.FinalScore, must.Sprintf("bucket %q", bucket))
+	}
+}
+
 func TestSpreadIterator_NoInfinity(t *testing.T) {
 	ci.Parallel(t)
 
@@ -259,6 +259,12 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		return difference("volume request", a.Volumes, b.Volumes)
 	}
 
+	// Check if the shared resources are updated, since the alloc-level cgroup
+	// the tasks are created under can't be added or removed in-place
+	if !a.SharedResources.Equal(b.SharedResources) {
+		return difference("shared resources", a.SharedResources, b.SharedResources)
+	}
+
 	// Check if restart.render_templates is updated
 	// this requires a destructive update for template hook to receive the new config
 	if c := renderTemplatesUpdated(a.RestartPolicy, b.RestartPolicy,
@@ -354,15 +360,19 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 }
 
 func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
+	// The cpu and memory of tasks resized in place are updated by the client
+	// without replacing their allocations, if the updated job resizes them
+	resizable := b.ResizeInPlace
+
 	// Inspect the non-network resources
 	switch {
-	case a.CPU != b.CPU:
+	case a.CPU != b.CPU && !resizable:
 		return difference("task cpu", a.CPU, b.CPU)
 	case a.Cores != b.Cores:
 		return difference("task cores", a.Cores, b.Cores)
-	case a.MemoryMB != b.MemoryMB:
+	case a.MemoryMB != b.MemoryMB && !resizable:
 		return difference("task memory", a.MemoryMB, b.MemoryMB)
-	case a.MemoryMaxMB != b.MemoryMaxMB:
+	case a.MemoryMaxMB != b.MemoryMaxMB && !resizable:
 		return difference("task memory max", a.MemoryMaxMB, b.MemoryMaxMB)
 	case !a.Devices.Equal(&b.Devices):
 		return difference("task devices", a.Devices, b.Devices)
@@ -789,13 +799,16 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		newAlloc.EvalID = eval.ID
 		newAlloc.Job = nil       // Use the Job in the Plan
 		newAlloc.Resources = nil // Computed in Plan Apply
+		overheadMB, overheadShares := update.TaskGroup.SharedResources.Overhead()
 		newAlloc.AllocatedResources = &structs.AllocatedResources{
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB:   int64(update.TaskGroup.EphemeralDisk.SizeMB),
-				Ports:    update.Alloc.AllocatedResources.Shared.Ports,
-				Networks: update.Alloc.AllocatedResources.Shared.Networks.Copy(),
+				DiskMB:    int64(update.TaskGroup.EphemeralDisk.SizeMB),
+				Ports:     update.Alloc.AllocatedResources.Shared.Ports,
+				Networks:  update.Alloc.AllocatedResources.Shared.Networks.Copy(),
+				MemoryMB:  overheadMB,
+				CpuShares: overheadShares,
 			},
 		}
 		newAlloc.Metrics = ctx.Metrics()
@@ -1035,11 +1048,14 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		newAlloc.EvalID = evalID
 		newAlloc.Job = nil       // Use the Job in the Plan
 		newAlloc.Resources = nil // Computed in Plan Apply
+		overheadMB, overheadShares := newTG.SharedResources.Overhead()
 		newAlloc.AllocatedResources = &structs.AllocatedResources{
 			Tasks:          option.TaskResources,
 			TaskLifecycles: option.TaskLifecycles,
 			Shared: structs.AllocatedSharedResources{
-				DiskMB: int64(newTG.EphemeralDisk.SizeMB),
+				DiskMB:    int64(newTG.EphemeralDisk.SizeMB),
+				MemoryMB:  overheadMB,
+				CpuShares: overheadShares,
 			},
 		}
 
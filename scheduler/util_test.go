@@ -423,6 +423,23 @@ func TestTasksUpdated(t *testing.T) {
 	j11.TaskGroups[0].Tasks[0].Resources.CPU = 1337
 	must.True(t, tasksUpdated(j1, j11, name).modified)
 
+	// Tasks resized in place update their cpu and memory, but not their
+	// cores, without being replaced
+	j11r1 := mock.Job()
+	j11r1.TaskGroups[0].Tasks[0].Resources.ResizeInPlace = true
+	j11r2 := j11r1.Copy()
+	j11r2.TaskGroups[0].Tasks[0].Resources.CPU = 1337
+	j11r2.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1024
+	j11r2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 2048
+	must.False(t, tasksUpdated(j11r2, j11r1, name).modified)
+
+	// Whether tasks are resized in place is up to the updated job
+	must.False(t, tasksUpdated(j11, j11r1, name).modified)
+	must.True(t, tasksUpdated(j11r1, j11, name).modified)
+
+	j11r2.TaskGroups[0].Tasks[0].Resources.Cores = 2
+	must.True(t, tasksUpdated(j11r2, j11r1, name).modified)
+
 	j11d1 := mock.Job()
 	j11d1.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{
 		&structs.RequestedDevice{
@@ -583,6 +600,16 @@ func TestTasksUpdated(t *testing.T) {
 	j32.TaskGroups[0].Tasks[0].VolumeMounts = nil
 
 	must.True(t, tasksUpdated(j31, j32, name).modified)
+
+	// Add or change shared resources
+	j33 := mock.Job()
+	j33.TaskGroups[0].SharedResources = &structs.SharedResources{MemoryOverheadMB: 64}
+	must.True(t, tasksUpdated(j1, j33, name).modified)
+
+	j34 := j33.Copy()
+	must.False(t, tasksUpdated(j33, j34, name).modified)
+	j34.TaskGroups[0].SharedResources.CPUCap = 1000
+	must.True(t, tasksUpdated(j33, j34, name).modified)
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
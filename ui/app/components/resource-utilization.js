@@ -0,0 +1,126 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import Ember from 'ember';
+import Component from '@glimmer/component';
+import { tracked } from '@glimmer/tracking';
+import { action } from '@ember/object';
+import { inject as service } from '@ember/service';
+import { task, timeout } from 'ember-concurrency';
+import jsonWithDefault from 'nomad-ui/utils/json-with-default';
+import {
+  formatScheduledBytes,
+  formatScheduledHertz,
+} from 'nomad-ui/utils/units';
+
+// Tasks whose p95 usage stays below this share of their reservation reserve
+// more than they need, while tasks above the upper share are at risk of being
+// throttled or OOM killed.
+const OVER_PROVISIONED = 0.5;
+const UNDER_PROVISIONED = 0.9;
+
+// The headroom over the max observed usage suggested when right-sizing
+const HEADROOM = 1.2;
+
+const percent = (value, scale) => (scale ? (value / scale) * 100 : 0);
+
+const formatCPU = (mhz) => formatScheduledHertz(mhz, 'MHz');
+const formatMemory = (mb) => formatScheduledBytes(mb, 'MiB');
+
+// Computes the chart geometry and the right-sizing hint of one metric of a
+// task. The chart is scaled to the larger of the reservation and max usage.
+function metric(reserved, usage, format) {
+  const scale = Math.max(reserved, usage.Max);
+  const ratio = reserved ? usage.P95 / reserved : 0;
+
+  let hint = null;
+  if (reserved && ratio < OVER_PROVISIONED) {
+    hint = { label: 'Over-provisioned', class: 'is-warning' };
+  } else if (reserved && ratio >= UNDER_PROVISIONED) {
+    hint = { label: 'Under-provisioned', class: 'is-danger' };
+  }
+  if (hint) {
+    const suggested = Math.max(Math.ceil(usage.Max * HEADROOM), 1);
+    hint.description = `Consider reserving about ${format(suggested)}`;
+  }
+
+  return {
+    reserved: format(reserved),
+    p50: format(usage.P50),
+    p95: format(usage.P95),
+    max: format(usage.Max),
+    hint,
+    reservedWidth: percent(reserved, scale),
+    p95Width: percent(usage.P95, scale),
+    p50Offset: percent(usage.P50, scale),
+    maxOffset: percent(usage.Max, scale),
+  };
+}
+
+export default class ResourceUtilization extends Component {
+  @service token;
+
+  /** Args
+    job = null;
+    taskGroupName = null; (limits the tasks to one task group)
+  */
+
+  // The rollup of the job's utilization from the servers
+  @tracked utilization = null;
+
+  get tasks() {
+    const groups = (this.utilization && this.utilization.TaskGroups) || {};
+    const rows = [];
+    Object.keys(groups)
+      .sort()
+      .forEach((group) => {
+        if (this.args.taskGroupName && this.args.taskGroupName !== group) {
+          return;
+        }
+        const tasks = groups[group].Tasks || {};
+        Object.keys(tasks)
+          .sort()
+          .forEach((name) => {
+            const tu = tasks[name];
+            rows.push({
+              taskGroup: group,
+              task: name,
+              allocations: groups[group].Allocations,
+              cpu: metric(tu.ReservedCPU, tu.CPU, formatCPU),
+              memory: metric(tu.ReservedMemoryMB, tu.MemoryMB, formatMemory),
+            });
+          });
+      });
+    return rows;
+  }
+
+  @task(function* () {
+    const job = this.args.job;
+    const namespace = job.belongsTo('namespace').id() ?? 'default';
+    const url = `/v1/job/${encodeURIComponent(
+      job.get('plainId')
+    )}/utilization?namespace=${encodeURIComponent(namespace)}`;
+
+    do {
+      this.utilization = yield this.token
+        .authorizedRequest(url)
+        .then(jsonWithDefault(null));
+
+      // Clients report usage summaries at most once a minute
+      yield timeout(Ember.testing ? 0 : 30000);
+    } while (!Ember.testing);
+  })
+  poller;
+
+  @action
+  start() {
+    this.poller.perform();
+  }
+
+  willDestroy() {
+    super.willDestroy(...arguments);
+    this.poller.cancelAll();
+  }
+}
@@ -0,0 +1,41 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import Component from '@glimmer/component';
+import { tracked } from '@glimmer/tracking';
+import { inject as service } from '@ember/service';
+import { action, get } from '@ember/object';
+
+export default class TaskOptionalStats extends Component {
+  @service('stats-trackers-registry') statsTrackersRegistry;
+
+  /** Args
+    taskState = null;
+  */
+
+  @tracked tracker = null;
+  @tracked taskState = null;
+
+  // The stats come from the stats tracker shared with the resource
+  // utilization charts, which keep polling it. Only the stats the task's
+  // driver reports are set.
+  get stats() {
+    if (!this.tracker) return {};
+    return get(this.tracker, 'optionalStats')[this.taskState.name] || {};
+  }
+
+  get hasStats() {
+    const { network, diskIO, pressure } = this.stats;
+    return Boolean(network || diskIO || pressure);
+  }
+
+  @action
+  start() {
+    this.taskState = this.args.taskState;
+    this.tracker = this.statsTrackersRegistry.getTracker(
+      this.args.taskState.allocation
+    );
+  }
+}
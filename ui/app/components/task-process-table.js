@@ -0,0 +1,108 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import Ember from 'ember';
+import Component from '@glimmer/component';
+import { tracked } from '@glimmer/tracking';
+import { task, timeout } from 'ember-concurrency';
+import { inject as service } from '@ember/service';
+import { action, get } from '@ember/object';
+import jsonWithDefault from 'nomad-ui/utils/json-with-default';
+
+const compare = (a, b) => {
+  if (a === b) return 0;
+  if (a == null) return -1;
+  if (b == null) return 1;
+  return a < b ? -1 : 1;
+};
+
+export default class TaskProcessTable extends Component {
+  @service('stats-trackers-registry') statsTrackersRegistry;
+  @service token;
+
+  /** Args
+    taskState = null;
+    sortProperty = 'cpu';
+    sortDescending = true;
+  */
+
+  @tracked tracker = null;
+  @tracked taskState = null;
+
+  // The process details of the task, keyed by PID
+  @tracked details = {};
+
+  get sortProperty() {
+    return this.args.sortProperty || 'cpu';
+  }
+
+  get sortDescending() {
+    return this.args.sortDescending ?? true;
+  }
+
+  // The usage of each process comes from the stats tracker shared with the
+  // resource utilization charts, while the command lines come from the
+  // processes endpoint, which is polled separately.
+  get processes() {
+    if (!this.tracker) return [];
+    const processes =
+      get(this.tracker, 'processes')[this.taskState.name] || [];
+    return processes.map((process) => {
+      const details = this.details[process.pid] || {};
+      const cmdline = details.Cmdline || [];
+      return {
+        ...process,
+        ppid: details.Ppid,
+        state: details.State,
+        name: cmdline.length ? cmdline[0].split('/').pop() : '',
+        cmdline: cmdline.join(' '),
+      };
+    });
+  }
+
+  get sortedProcesses() {
+    const prop = this.sortProperty;
+    const sorted = this.processes.sort((a, b) => compare(a[prop], b[prop]));
+    return this.sortDescending ? sorted.reverse() : sorted;
+  }
+
+  @task(function* () {
+    const { allocation, name } = this.taskState;
+    const url = `/v1/client/allocation/${allocation.get(
+      'id'
+    )}/processes?task=${encodeURIComponent(name)}`;
+
+    do {
+      this.tracker.poll.perform();
+
+      const processes = yield this.token
+        .authorizedRequest(url)
+        .then(jsonWithDefault({}));
+      const list = (processes && processes[name]) || [];
+      this.details = list.reduce((details, process) => {
+        details[process.Pid] = process;
+        return details;
+      }, {});
+
+      yield timeout(Ember.testing ? 0 : 2000);
+    } while (!Ember.testing);
+  })
+  poller;
+
+  @action
+  start() {
+    this.taskState = this.args.taskState;
+    this.tracker = this.statsTrackersRegistry.getTracker(
+      this.args.taskState.allocation
+    );
+    this.poller.perform();
+  }
+
+  willDestroy() {
+    super.willDestroy(...arguments);
+    this.poller.cancelAll();
+    this.tracker.signalPause.perform();
+  }
+}
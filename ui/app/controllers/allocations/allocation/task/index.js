@@ -14,6 +14,20 @@ import { inject as service } from '@ember/service';
 export default class IndexController extends Controller {
   @service nomadActions;
   @service notifications;
+
+  queryParams = [
+    {
+      sortProperty: 'sort',
+    },
+    {
+      sortDescending: 'desc',
+    },
+  ];
+
+  // Sorts the process table
+  sortProperty = 'cpu';
+  sortDescending = true;
+
   @overridable(() => {
     // { title, description }
     return null;
@@ -41,11 +41,73 @@ const memoryUsed = (frame) =>
   frame.ResourceUsage.MemoryStats.Usage ||
   0;
 
+// Map the per-process stats of a task frame to a list. Drivers that don't
+// track processes individually report no Pids.
+const processesFromFrame = (taskFrame) =>
+  Object.keys(taskFrame.Pids || {}).map((pid) => {
+    const usage = taskFrame.Pids[pid];
+    return {
+      pid: parseInt(pid, 10),
+      cpu: Math.floor(usage.CpuStats.TotalTicks) || 0,
+      cpuPercent: usage.CpuStats.Percent || 0,
+      memory: usage.MemoryStats.RSS || 0,
+    };
+  });
+
+// Map the optional stats of a task frame to the ones its driver declared in
+// the Capabilities of the frame, so stats a driver doesn't report aren't
+// shown as zero. Frames of drivers that don't declare their stats only have
+// the stats they reported. Stats that aren't reported are null.
+const optionalStatsFromFrame = (taskFrame) => {
+  const usage = taskFrame.ResourceUsage || {};
+  const caps = taskFrame.Capabilities;
+  const reported = (capability, value) =>
+    caps ? Boolean(caps[capability]) : value != null;
+
+  const egress = usage.Egress || {};
+  const diskIO = usage.DiskIO || {};
+  const pressure = usage.Pressure || {};
+  return {
+    network: reported('Network', usage.Egress)
+      ? Object.keys(egress)
+          .sort()
+          .map((name) => ({
+            name,
+            bytes: egress[name].Bytes || 0,
+            packets: egress[name].Packets || 0,
+          }))
+      : null,
+    diskIO: reported('DiskIO', usage.DiskIO)
+      ? {
+          readBytes: diskIO.ReadBytes || 0,
+          writeBytes: diskIO.WriteBytes || 0,
+          readOps: diskIO.ReadOps || 0,
+          writeOps: diskIO.WriteOps || 0,
+        }
+      : null,
+    pressure: reported('Pressure', usage.Pressure)
+      ? {
+          cpu: pressure.CPU || 0,
+          memory: pressure.Memory || 0,
+          io: pressure.IO || 0,
+        }
+      : null,
+  };
+};
+
 @classic
 class AllocationStatsTracker extends EmberObject.extend(AbstractStatsTracker) {
   // Set via the stats computed property macro
   allocation = null;
 
+  // The processes of each task in the latest frame, keyed by task name
+  // { [task]: []{ pid: Number, cpu: Number, cpuPercent: Number, memory: Number } }
+  processes = {};
+
+  // The optional stats of each task in the latest frame, keyed by task name
+  // { [task]: { network: []?, diskIO: {}?, pressure: {}? } }
+  optionalStats = {};
+
   @computed('allocation.id')
   get url() {
     return `/v1/client/allocation/${this.get('allocation.id')}/stats`;
@@ -70,6 +132,8 @@ class AllocationStatsTracker extends EmberObject.extend(AbstractStatsTracker) {
 
     let aggregateCpu = 0;
     let aggregateMemory = 0;
+    const processes = {};
+    const optionalStats = {};
     for (var stats of this.tasks) {
       const taskFrame = frame.Tasks[stats.task];
 
@@ -105,9 +169,14 @@ class AllocationStatsTracker extends EmberObject.extend(AbstractStatsTracker) {
         percentStack: percentMemoryTotal + aggregateMemory,
       });
 
+      processes[stats.task] = processesFromFrame(taskFrame);
+      optionalStats[stats.task] = optionalStatsFromFrame(taskFrame);
+
       aggregateCpu += percentCpuTotal;
       aggregateMemory += percentMemoryTotal;
     }
+    this.set('processes', processes);
+    this.set('optionalStats', optionalStats);
   }
 
   pause() {
@@ -118,6 +187,8 @@ class AllocationStatsTracker extends EmberObject.extend(AbstractStatsTracker) {
       task.memory.pushObject(empty(ts));
       task.cpu.pushObject(empty(ts));
     });
+    this.set('processes', {});
+    this.set('optionalStats', {});
   }
 
   // Static figures, denominators for stats
@@ -453,6 +453,52 @@ export default function () {
     return this.serialize(allocations.where({ jobId: params.id }));
   });
 
+  this.get(
+    '/job/:id/utilization',
+    function ({ jobs, allocations }, { params }) {
+      const job = jobs.find(params.id);
+      if (!job) return new Response(404, {}, null);
+
+      const usage = (reserved) => {
+        const p50 = Math.round(
+          (reserved * faker.random.number({ min: 10, max: 100 })) / 100
+        );
+        return {
+          P50: p50,
+          P95: Math.round(p50 * 1.2),
+          Max: Math.round(p50 * 1.4),
+        };
+      };
+
+      const running = allocations.where({
+        jobId: params.id,
+        clientStatus: 'running',
+      }).models;
+      const TaskGroups = {};
+      job.taskGroups.models.forEach((group) => {
+        const count = running.filter(
+          (alloc) => alloc.taskGroup === group.name
+        ).length;
+        if (!count) return;
+
+        const Tasks = {};
+        group.tasks.models.forEach((task) => {
+          const { CPU, MemoryMB, MemoryMaxMB } = task.resources;
+          Tasks[task.name] = {
+            ReservedCPU: CPU,
+            ReservedMemoryMB: MemoryMB,
+            ReservedMemoryMaxMB: MemoryMaxMB || 0,
+            CPU: usage(CPU),
+            MemoryMB: usage(MemoryMB),
+          };
+        });
+        TaskGroups[group.name] = { Allocations: count, Tasks };
+      });
+
+      return { Namespace: job.namespaceId, JobID: job.id, TaskGroups };
+    }
+  );
+
   this.get('/job/:id/versions', function ({ jobVersions }, { params }) {
     return this.serialize(jobVersions.where({ jobId: params.id }));
   });
@@ -1095,6 +1141,33 @@ export default function () {
     return this.serialize(clientAllocationStats.find(params.id));
   };
 
+  const clientAllocationProcessesHandler = function (
+    { clientAllocationStats },
+    { params, queryParams }
+  ) {
+    const stats = clientAllocationStats.find(params.id);
+    if (!stats) {
+      return new Response(404, {}, 'unknown allocation');
+    }
+
+    const processes = {};
+    Object.keys(stats.tasks).forEach((task) => {
+      if (queryParams.task && queryParams.task !== task) return;
+      const pids = Object.keys(stats.tasks[task].Pids || {}).map(Number);
+      processes[task] = pids.map((pid, index) => ({
+        Pid: pid,
+        Ppid: index === 0 ? 1 : pids[0],
+        Cmdline:
+          index === 0
+            ? [`/usr/bin/${task}`, '-config', 'local']
+            : ['sh', '-c', 'sleep 10'],
+        State: 'sleep',
+        StartTime: stats.timestamp,
+      }));
+    });
+    return processes;
+  };
+
   const clientAllocationLog = function (server, { params, queryParams }) {
     const allocation = server.allocations.find(params.allocation_id);
     const tasks = allocation.taskStateIds.map((id) =>
@@ -1200,6 +1273,10 @@ export default function () {
   });
 
   this.get('/client/allocation/:id/stats', clientAllocationStatsHandler);
+  this.get(
+    '/client/allocation/:id/processes',
+    clientAllocationProcessesHandler
+  );
   this.get('/client/fs/logs/:allocation_id', clientAllocationLog);
 
   this.get('/client/fs/ls/:allocation_id', clientAllocationFSLsHandler);
@@ -1246,6 +1323,10 @@ export default function () {
       `http://${host}/v1/client/allocation/:id/stats`,
       clientAllocationStatsHandler
     );
+    this.get(
+      `http://${host}/v1/client/allocation/:id/processes`,
+      clientAllocationProcessesHandler
+    );
     this.get(
       `http://${host}/v1/client/fs/logs/:allocation_id`,
       clientAllocationLog
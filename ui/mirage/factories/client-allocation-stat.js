@@ -4,8 +4,18 @@
  */
 
 import { Factory } from 'ember-cli-mirage';
+import faker from 'nomad-ui/mirage/faker';
 import generateResources from '../data/generate-resources';
 
+const generatePids = () => {
+  const pid = faker.random.number({ min: 100, max: 30000 });
+  const hash = {};
+  for (let i = 0; i < faker.random.number({ min: 1, max: 4 }); i++) {
+    hash[pid + i] = generateResources();
+  }
+  return hash;
+};
+
 export default Factory.extend({
   resourceUsage: generateResources,
 
@@ -17,7 +27,7 @@ export default Factory.extend({
     var hash = {};
     this._taskNames.forEach(task => {
       hash[task] = {
-        Pids: null,
+        Pids: generatePids(),
         ResourceUsage: generateResources(),
         Timestamp: Date.now() * 1000000,
       };
@@ -0,0 +1,114 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import { setupRenderingTest } from 'ember-qunit';
+import { module, test } from 'qunit';
+import { findAll, render } from '@ember/test-helpers';
+import hbs from 'htmlbars-inline-precompile';
+import { initialize as fragmentSerializerInitializer } from 'nomad-ui/initializers/fragment-serializer';
+import { startMirage } from 'nomad-ui/initializers/ember-cli-mirage';
+import { componentA11yAudit } from 'nomad-ui/tests/helpers/a11y-audit';
+
+const utilization = {
+  Namespace: 'default',
+  JobID: 'example',
+  TaskGroups: {
+    cache: {
+      Allocations: 2,
+      Tasks: {
+        redis: {
+          ReservedCPU: 1000,
+          ReservedMemoryMB: 512,
+          ReservedMemoryMaxMB: 0,
+          CPU: { P50: 100, P95: 200, Max: 250 },
+          MemoryMB: { P50: 400, P95: 480, Max: 500 },
+        },
+      },
+    },
+    web: {
+      Allocations: 3,
+      Tasks: {
+        server: {
+          ReservedCPU: 500,
+          ReservedMemoryMB: 256,
+          ReservedMemoryMaxMB: 0,
+          CPU: { P50: 300, P95: 350, Max: 400 },
+          MemoryMB: { P50: 80, P95: 100, Max: 160 },
+        },
+      },
+    },
+  },
+};
+
+module('Integration | Component | resource utilization', function (hooks) {
+  setupRenderingTest(hooks);
+
+  hooks.beforeEach(async function () {
+    fragmentSerializerInitializer(this.owner);
+    this.store = this.owner.lookup('service:store');
+    this.server = startMirage();
+    this.server.create('namespace');
+    this.server.create('node-pool');
+    this.server.create('job', { id: 'example', createAllocations: false });
+
+    this.server.get('/job/:id/utilization', () => utilization);
+
+    await this.store.findAll('job');
+    this.set('job', this.store.peekAll('job').get('firstObject'));
+  });
+
+  hooks.afterEach(function () {
+    this.server.shutdown();
+  });
+
+  test('charts the usage of each task against its reservation', async function (assert) {
+    await render(hbs`<ResourceUtilization @job={{this.job}} />`);
+
+    assert.equal(findAll('[data-test-utilization-task]').length, 2);
+
+    const redis = '[data-test-utilization-task="cache/redis"]';
+    assert.dom(`${redis} [data-test-utilization-allocations]`).hasText('2');
+    assert
+      .dom(`${redis} [data-test-utilization-cpu] [data-test-utilization-p95]`)
+      .hasText('200 MHz');
+    assert
+      .dom(`${redis} [data-test-utilization-cpu] [data-test-utilization-hint]`)
+      .hasText('Over-provisioned');
+    assert
+      .dom(
+        `${redis} [data-test-utilization-memory] [data-test-utilization-hint]`
+      )
+      .hasText('Under-provisioned');
+
+    const server = '[data-test-utilization-task="web/server"]';
+    assert
+      .dom(`${server} [data-test-utilization-cpu] [data-test-utilization-hint]`)
+      .doesNotExist('tasks using most of their reservation get no hint');
+    assert
+      .dom(
+        `${server} [data-test-utilization-memory] [data-test-utilization-hint]`
+      )
+      .hasAttribute('title', 'Consider reserving about 192 MiB');
+
+    await componentA11yAudit(this.element, assert);
+  });
+
+  test('the tasks can be limited to one task group', async function (assert) {
+    await render(
+      hbs`<ResourceUtilization @job={{this.job}} @taskGroupName="web" />`
+    );
+
+    assert.equal(findAll('[data-test-utilization-task]').length, 1);
+    assert.dom('[data-test-utilization-task="web/server"]').exists();
+  });
+
+  test('shows an empty message when no usage was reported', async function (assert) {
+    this.server.get('/job/:id/utilization', () => ({ TaskGroups: {} }));
+    await render(hbs`<ResourceUtilization @job={{this.job}} />`);
+
+    assert.dom('[data-test-utilization-task]').doesNotExist();
+    assert.dom('[data-test-resource-utilization-empty]').exists();
+  });
+});
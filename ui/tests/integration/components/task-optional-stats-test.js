@@ -0,0 +1,101 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import EmberObject from '@ember/object';
+import Service from '@ember/service';
+import { setupRenderingTest } from 'ember-qunit';
+import { module, test } from 'qunit';
+import { render } from '@ember/test-helpers';
+import hbs from 'htmlbars-inline-precompile';
+import { initialize as fragmentSerializerInitializer } from 'nomad-ui/initializers/fragment-serializer';
+import { startMirage } from 'nomad-ui/initializers/ember-cli-mirage';
+import { componentA11yAudit } from 'nomad-ui/tests/helpers/a11y-audit';
+
+module('Integration | Component | task optional stats', function (hooks) {
+  setupRenderingTest(hooks);
+
+  hooks.beforeEach(function () {
+    fragmentSerializerInitializer(this.owner);
+    this.store = this.owner.lookup('service:store');
+    this.server = startMirage();
+    this.server.create('namespace');
+    this.server.create('node-pool');
+    this.server.create('node');
+    this.server.create('job', { createAllocations: false });
+    this.server.create('allocation', { forceRunningClientStatus: true });
+
+    const optionalStats = (this.optionalStats = {});
+    const MockTracker = EmberObject.extend({ optionalStats });
+
+    this.owner.register(
+      'service:stats-trackers-registry',
+      Service.extend({
+        getTracker() {
+          return MockTracker.create();
+        },
+      })
+    );
+  });
+
+  hooks.afterEach(function () {
+    this.server.shutdown();
+  });
+
+  const template = hbs`<TaskOptionalStats @taskState={{this.taskState}} />`;
+
+  const setup = async function (context) {
+    await context.store.findAll('allocation');
+    const taskState = context.store
+      .peekAll('allocation')
+      .get('firstObject.states.firstObject');
+    context.set('taskState', taskState);
+    return taskState;
+  };
+
+  test('renders only the stats the driver reports', async function (assert) {
+    const taskState = await setup(this);
+    this.optionalStats[taskState.name] = {
+      network: null,
+      diskIO: { readBytes: 4096, writeBytes: 0, readOps: 2, writeOps: 0 },
+      pressure: null,
+    };
+    await render(template);
+
+    assert.dom('[data-test-task-optional-stats]').exists();
+    assert
+      .dom('[data-test-task-stat="disk-read"] [data-test-task-stat-value]')
+      .hasText('4 KiB (2 ops)');
+    assert.dom('[data-test-task-stat="disk-write"]').exists();
+    assert.dom('[data-test-task-stat="pressure"]').doesNotExist();
+    assert.dom('[data-test-task-stat^="egress-"]').doesNotExist();
+
+    await componentA11yAudit(this.element, assert);
+  });
+
+  test('renders pressure and egress when reported', async function (assert) {
+    const taskState = await setup(this);
+    this.optionalStats[taskState.name] = {
+      network: [{ name: 'internet', bytes: 2048, packets: 3 }],
+      diskIO: null,
+      pressure: { cpu: 12, memory: 0, io: 1.5 },
+    };
+    await render(template);
+
+    assert.dom('[data-test-task-stat="disk-read"]').doesNotExist();
+    assert
+      .dom('[data-test-task-stat="pressure"] [data-test-task-stat-value]')
+      .hasText('12% / 0% / 2%');
+    assert
+      .dom('[data-test-task-stat="egress-internet"] [data-test-task-stat-value]')
+      .hasText('2 KiB (3 packets)');
+  });
+
+  test('renders nothing when the driver reports no optional stats', async function (assert) {
+    await setup(this);
+    await render(template);
+
+    assert.dom('[data-test-task-optional-stats]').doesNotExist();
+  });
+});
@@ -0,0 +1,134 @@
+/**
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: BUSL-1.1
+ */
+
+import EmberObject from '@ember/object';
+import Service from '@ember/service';
+import { setupRenderingTest } from 'ember-qunit';
+import { module, test } from 'qunit';
+import { findAll, render, settled } from '@ember/test-helpers';
+import { task } from 'ember-concurrency';
+import hbs from 'htmlbars-inline-precompile';
+import { initialize as fragmentSerializerInitializer } from 'nomad-ui/initializers/fragment-serializer';
+import { startMirage } from 'nomad-ui/initializers/ember-cli-mirage';
+import { componentA11yAudit } from 'nomad-ui/tests/helpers/a11y-audit';
+
+module('Integration | Component | task process table', function (hooks) {
+  setupRenderingTest(hooks);
+
+  hooks.beforeEach(function () {
+    fragmentSerializerInitializer(this.owner);
+    this.store = this.owner.lookup('service:store');
+    this.server = startMirage();
+    this.server.create('namespace');
+    this.server.create('node-pool');
+    this.server.create('node');
+    this.server.create('job', { createAllocations: false });
+    this.server.create('allocation', { forceRunningClientStatus: true });
+
+    const processes = (this.processes = {});
+    const MockTracker = EmberObject.extend({
+      poll: task(function* () {}),
+      signalPause: task(function* () {}),
+      processes,
+    });
+
+    this.owner.register(
+      'service:stats-trackers-registry',
+      Service.extend({
+        getTracker() {
+          return MockTracker.create();
+        },
+      })
+    );
+  });
+
+  hooks.afterEach(function () {
+    this.server.shutdown();
+  });
+
+  const template = hbs`
+    <TaskProcessTable
+      @taskState={{this.taskState}}
+      @sortProperty={{this.sortProperty}}
+      @sortDescending={{this.sortDescending}} />
+  `;
+
+  const setup = async function (context) {
+    await context.store.findAll('allocation');
+    const taskState = context.store.peekAll('allocation').get(
+      'firstObject.states.firstObject'
+    );
+
+    // Report usage for the PIDs the processes endpoint lists
+    const stats = context.server.db.clientAllocationStats[0];
+    const pids = Object.keys(stats.tasks[taskState.name].Pids).map(Number);
+    context.processes[taskState.name] = pids.map((pid, index) => ({
+      pid,
+      cpu: (index + 1) * 100,
+      cpuPercent: index + 1,
+      memory: (pids.length - index) * 1024 * 1024,
+    }));
+
+    context.set('taskState', taskState);
+    return pids;
+  };
+
+  test('lists the processes of the task with their usage and command lines', async function (assert) {
+    const pids = await setup(this);
+    await render(template);
+
+    assert.equal(findAll('[data-test-task-process]').length, pids.length);
+
+    const parent = `[data-test-task-process="${pids[0]}"]`;
+    assert
+      .dom(`${parent} [data-test-task-process-ppid]`)
+      .hasText('1', 'the parent PID comes from the processes endpoint');
+    assert
+      .dom(`${parent} [data-test-task-process-name]`)
+      .hasText(this.taskState.name);
+    assert
+      .dom(`${parent} [data-test-task-process-cmdline]`)
+      .hasText(`/usr/bin/${this.taskState.name} -config local`);
+    assert.dom(`${parent} [data-test-task-process-state]`).hasText('sleep');
+    assert
+      .dom(`${parent} [data-test-task-process-cpu]`)
+      .hasText('100 MHz (1%)');
+    assert.dom('[data-test-task-processes-empty]').doesNotExist();
+
+    await componentA11yAudit(this.element, assert);
+  });
+
+  test('processes are sorted by the sort property', async function (assert) {
+    const pids = await setup(this);
+
+    // CPU, descending by default
+    await render(template);
+    const rows = () =>
+      findAll('[data-test-task-process]').map((row) =>
+        parseInt(row.getAttribute('data-test-task-process'), 10)
+      );
+    assert.deepEqual(rows(), pids.slice().reverse());
+
+    this.setProperties({ sortProperty: 'memory', sortDescending: true });
+    await settled();
+    assert.deepEqual(rows(), pids);
+
+    this.setProperties({ sortProperty: 'pid', sortDescending: false });
+    await settled();
+    assert.deepEqual(rows(), pids);
+  });
+
+  test('shows an empty message when the task reports no processes', async function (assert) {
+    await this.store.findAll('allocation');
+    this.set(
+      'taskState',
+      this.store.peekAll('allocation').get('firstObject.states.firstObject')
+    );
+    await render(template);
+
+    assert.dom('[data-test-task-process]').doesNotExist();
+    assert.dom('[data-test-task-processes-empty]').exists();
+  });
+});
@@ -482,6 +482,88 @@ module('Unit | Util | AllocationStatsTracker', function () {
     );
   });
 
+  test('append tracks the processes of each task in the latest frame', async function (assert) {
+    const allocation = MockAllocation();
+    const tracker = AllocationStatsTracker.create({ fetch, allocation });
+
+    assert.deepEqual(tracker.get('processes'), {}, 'No tracked processes yet');
+
+    const frame = mockFrame(1);
+    frame.Tasks.service.Pids = {
+      1234: {
+        CpuStats: { TotalTicks: 40.6, Percent: 2.5 },
+        MemoryStats: { RSS: 64 * 1024 * 1024 },
+      },
+      1240: {
+        CpuStats: { TotalTicks: 10 },
+        MemoryStats: { RSS: 16 * 1024 * 1024 },
+      },
+    };
+    tracker.append(frame);
+
+    assert.deepEqual(
+      tracker.get('processes'),
+      {
+        service: [
+          { pid: 1234, cpu: 40, cpuPercent: 2.5, memory: 64 * 1024 * 1024 },
+          { pid: 1240, cpu: 10, cpuPercent: 0, memory: 16 * 1024 * 1024 },
+        ],
+        sidecar: [],
+        'log-shipper': [],
+      },
+      'processes are keyed by task, and tasks without Pids have none'
+    );
+
+    tracker.pause();
+    assert.deepEqual(
+      tracker.get('processes'),
+      {},
+      'processes are cleared when the tracker pauses'
+    );
+  });
+
+  test('append tracks the optional stats each task declares in the latest frame', async function (assert) {
+    const allocation = MockAllocation();
+    const tracker = AllocationStatsTracker.create({ fetch, allocation });
+
+    assert.deepEqual(tracker.get('optionalStats'), {}, 'No tracked stats yet');
+
+    const frame = mockFrame(1);
+    frame.Tasks.service.Capabilities = { DiskIO: true, Pressure: false };
+    frame.Tasks.service.ResourceUsage.DiskIO = {
+      ReadBytes: 4096,
+      WriteOps: 3,
+    };
+    frame.Tasks.sidecar.Capabilities = { Network: true };
+    frame.Tasks['log-shipper'].ResourceUsage.Pressure = { IO: 1.5 };
+    tracker.append(frame);
+
+    assert.deepEqual(
+      tracker.get('optionalStats'),
+      {
+        service: {
+          network: null,
+          diskIO: { readBytes: 4096, writeBytes: 0, readOps: 0, writeOps: 3 },
+          pressure: null,
+        },
+        sidecar: { network: [], diskIO: null, pressure: null },
+        'log-shipper': {
+          network: null,
+          diskIO: null,
+          pressure: { cpu: 0, memory: 0, io: 1.5 },
+        },
+      },
+      'declared stats are tracked even when unset, and tasks without capabilities have the stats they reported'
+    );
+
+    tracker.pause();
+    assert.deepEqual(
+      tracker.get('optionalStats'),
+      {},
+      'optional stats are cleared when the tracker pauses'
+    );
+  });
+
   test('each stat list has maxLength equal to bufferSize', async function (assert) {
     assert.expect(16);
 